@@ -18,11 +18,15 @@ func adminCommands() []*cobra.Command {
 		adminHooks(),
 		adminIntegrationModels(),
 		adminMaintenance(),
+		adminCache(),
+		adminQueue(),
 		adminMetadata(),
 		adminMigrations(),
+		adminSCIM(),
 		adminPlugins(),
 		adminBroadcasts(),
 		adminErrors(),
+		adminRoutes(),
 		adminCurl(),
 	}
 }
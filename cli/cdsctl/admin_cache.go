@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var adminCacheCmd = cli.Command{
+	Name:  "cache",
+	Short: "Inspect and invalidate the cache of the API for a project or a workflow",
+}
+
+func adminCache() *cobra.Command {
+	return cli.NewCommand(adminCacheCmd, nil, []*cobra.Command{
+		cli.NewListCommand(adminCacheListCmd, adminCacheListRun, nil),
+		cli.NewCommand(adminCacheInvalidateCmd, adminCacheInvalidateRun, nil),
+	})
+}
+
+var adminCacheFlags = []cli.Flag{
+	{
+		Name:  "workflow",
+		Usage: "Name of a workflow of the project, all the keys of the project are selected if not given",
+	},
+}
+
+var adminCacheListCmd = cli.Command{
+	Name:  "list",
+	Short: "List the cache keys of a project or a workflow with their TTL in seconds",
+	Example: `cdsctl admin cache list MYPROJECT
+cdsctl admin cache list MYPROJECT --workflow my-workflow`,
+	Args: []cli.Arg{
+		{Name: "project-key"},
+	},
+	Flags: adminCacheFlags,
+}
+
+func adminCacheListRun(v cli.Values) (cli.ListResult, error) {
+	keys, err := client.AdminCacheKeys(v.GetString("project-key"), v.GetString("workflow"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(keys), nil
+}
+
+var adminCacheInvalidateCmd = cli.Command{
+	Name:  "invalidate",
+	Short: "Delete the cache keys of a project or a workflow",
+	Example: `cdsctl admin cache invalidate MYPROJECT
+cdsctl admin cache invalidate MYPROJECT --workflow my-workflow`,
+	Args: []cli.Arg{
+		{Name: "project-key"},
+	},
+	Flags: adminCacheFlags,
+}
+
+func adminCacheInvalidateRun(v cli.Values) error {
+	if err := client.AdminCacheInvalidate(v.GetString("project-key"), v.GetString("workflow")); err != nil {
+		return err
+	}
+	fmt.Println("Cache invalidated")
+	return nil
+}
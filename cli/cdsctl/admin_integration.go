@@ -9,6 +9,7 @@ import (
 
 	"github.com/ovh/cds/cli"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
 )
 
 var adminIntegrationModelsCmd = cli.Command{
@@ -23,6 +24,7 @@ func adminIntegrationModels() *cobra.Command {
 		cli.NewCommand(adminIntegrationModelExportCmd, adminIntegrationModelExportRun, nil),
 		cli.NewCommand(adminIntegrationModelImportCmd, adminIntegrationModelImportRun, nil),
 		cli.NewDeleteCommand(adminIntegrationModelDeleteCmd, adminIntegrationModelDeleteRun, nil),
+		cli.NewListCommand(adminIntegrationModelApplyCmd, adminIntegrationModelApplyRun, nil),
 	})
 }
 
@@ -63,6 +65,10 @@ func adminIntegrationModelShowRun(v cli.Values) (interface{}, error) {
 var adminIntegrationModelExportCmd = cli.Command{
 	Name:  "export",
 	Short: "Export a CDS Integration model",
+	Long: `Export a CDS Integration model with its default and public configurations, and the references of its plugins and their binaries.
+
+Values of password configurations are not exported.`,
+	Example: "cdsctl admin integration-model export my-model > my-model.yml",
 	Args: []cli.Arg{
 		{
 			Name: "name",
@@ -76,7 +82,12 @@ func adminIntegrationModelExportRun(v cli.Values) error {
 		return err
 	}
 
-	b, err := yaml.Marshal(model)
+	plugins, err := client.PluginsList()
+	if err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(exportentities.NewIntegrationModel(model, plugins))
 	if err != nil {
 		return fmt.Errorf("unable to marshal: %v", err)
 	}
@@ -89,11 +100,24 @@ func adminIntegrationModelExportRun(v cli.Values) error {
 var adminIntegrationModelImportCmd = cli.Command{
 	Name:  "import",
 	Short: "Import a CDS Integration model from a yaml file",
+	Long: `Import a CDS Integration model from a yaml file, the model is created or updated.
+
+The plugins referenced by the model must have been imported before with their binaries, unless the --skip-plugins-check flag is set.
+When the model is updated, password configurations without value keep their current value.`,
+	Example: "cdsctl admin integration-model import my-model.yml",
 	Args: []cli.Arg{
 		{
 			Name: "file",
 		},
 	},
+	Flags: []cli.Flag{
+		{
+			Type:    cli.FlagBool,
+			Name:    "skip-plugins-check",
+			Usage:   "Do not check that the plugins referenced by the model exist",
+			Default: "false",
+		},
+	},
 }
 
 func adminIntegrationModelImportRun(v cli.Values) error {
@@ -102,18 +126,47 @@ func adminIntegrationModelImportRun(v cli.Values) error {
 		return fmt.Errorf("unable to read file %s: %v", v.GetString("file"), err)
 	}
 
-	m := new(sdk.IntegrationModel)
-	if err := yaml.Unmarshal(b, m); err != nil {
+	var e exportentities.IntegrationModel
+	if err := yaml.Unmarshal(b, &e); err != nil {
 		return fmt.Errorf("unable to load file: %v", err)
 	}
 
+	if !v.GetBool("skip-plugins-check") && len(e.Plugins) > 0 {
+		plugins, err := client.PluginsList()
+		if err != nil {
+			return err
+		}
+		if err := e.CheckPlugins(plugins); err != nil {
+			return err
+		}
+	}
+
+	m := e.GetIntegrationModel()
+
 	//Try to load the model to know if we have to add it or update it
 	model, _ := client.IntegrationModelGet(m.Name)
 	if model.ID == 0 { // If the model has not been found
-		return client.IntegrationModelAdd(m)
+		for k, cfg := range m.PublicConfigurations {
+			for kk, value := range cfg {
+				if value.Type == sdk.IntegrationConfigTypePassword && (value.Value == "" || value.Value == sdk.PasswordPlaceholder) {
+					fmt.Printf("Warning: no value for password %s of public configuration %s\n", kk, k)
+					value.Value = ""
+					cfg[kk] = value
+				}
+			}
+		}
+		return client.IntegrationModelAdd(&m)
 	}
 
-	return client.IntegrationModelUpdate(m)
+	for _, cfg := range m.PublicConfigurations {
+		for kk, value := range cfg {
+			if value.Type == sdk.IntegrationConfigTypePassword && value.Value == "" {
+				value.Value = sdk.PasswordPlaceholder
+				cfg[kk] = value
+			}
+		}
+	}
+	return client.IntegrationModelUpdate(&m)
 }
 
 var adminIntegrationModelDeleteCmd = cli.Command{
@@ -129,3 +182,62 @@ var adminIntegrationModelDeleteCmd = cli.Command{
 func adminIntegrationModelDeleteRun(v cli.Values) error {
 	return client.IntegrationModelDelete(v.GetString("name"))
 }
+
+var adminIntegrationModelApplyCmd = cli.Command{
+	Name:  "apply",
+	Short: "Create an integration on several projects from a yaml file",
+	Long: `Create an integration on several projects from a yaml file:
+
+	name: my-openstack
+	model: Openstack
+	config:
+	  tenant_name:
+	    type: string
+	    value: "{{.cds.project}}"
+	project_keys:
+	- PROJ1
+	- PROJ2
+
+The {{.cds.project}} expression in config values is replaced by the key of each project. Projects can also be given as arguments.
+Existing integrations are not modified unless the --update flag is set.`,
+	Args: []cli.Arg{
+		{
+			Name: "file",
+		},
+	},
+	VariadicArgs: cli.Arg{
+		Name: "project-key",
+	},
+	Flags: []cli.Flag{
+		{
+			Type:    cli.FlagBool,
+			Name:    "update",
+			Usage:   "Update existing integrations",
+			Default: "false",
+		},
+	},
+}
+
+func adminIntegrationModelApplyRun(v cli.Values) (cli.ListResult, error) {
+	b, err := ioutil.ReadFile(v.GetString("file"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file %s: %v", v.GetString("file"), err)
+	}
+
+	var req sdk.ProjectIntegrationApplyRequest
+	if err := yaml.Unmarshal(b, &req); err != nil {
+		return nil, fmt.Errorf("unable to load file: %v", err)
+	}
+	if keys := v.GetStringSlice("project-key"); len(keys) > 0 {
+		req.ProjectKeys = keys
+	}
+	if v.GetBool("update") {
+		req.Update = true
+	}
+
+	results, err := client.AdminProjectIntegrationApply(req)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(results), nil
+}
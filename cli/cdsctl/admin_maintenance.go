@@ -1,9 +1,14 @@
 package main
 
 import (
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
 )
 
 var adminMaintenancesCmd = cli.Command{
@@ -15,6 +20,8 @@ func adminMaintenance() *cobra.Command {
 	return cli.NewCommand(adminMaintenancesCmd, nil, []*cobra.Command{
 		cli.NewCommand(adminMaintenanceEnableCmd, adminMaintenanceEnable, nil),
 		cli.NewCommand(adminMaintenanceDisableCmd, adminMaintenanceDisable, nil),
+		adminMaintenanceWindow(),
+		cli.NewCommand(adminMaintenanceOverrideCmd, adminMaintenanceOverrideRun, nil),
 	})
 }
 
@@ -51,3 +58,141 @@ var adminMaintenanceDisableCmd = cli.Command{
 func adminMaintenanceDisable(v cli.Values) error {
 	return client.Maintenance(false, v.GetBool("hooks"))
 }
+
+var adminMaintenanceWindowCmd = cli.Command{
+	Name:    "window",
+	Aliases: []string{"windows"},
+	Short:   "Manage CDS maintenance windows, during which the jobs of the selected projects and workflows stay in queue",
+}
+
+func adminMaintenanceWindow() *cobra.Command {
+	return cli.NewCommand(adminMaintenanceWindowCmd, nil, []*cobra.Command{
+		cli.NewListCommand(adminMaintenanceWindowListCmd, adminMaintenanceWindowListRun, nil),
+		cli.NewCommand(adminMaintenanceWindowAddCmd, adminMaintenanceWindowAddRun, nil),
+		cli.NewCommand(adminMaintenanceWindowDeleteCmd, adminMaintenanceWindowDeleteRun, nil),
+	})
+}
+
+var adminMaintenanceWindowListCmd = cli.Command{
+	Name:  "list",
+	Short: "List current and upcoming CDS maintenance windows",
+}
+
+func adminMaintenanceWindowListRun(v cli.Values) (cli.ListResult, error) {
+	windows, err := client.MaintenanceWindowList()
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(windows), nil
+}
+
+var adminMaintenanceWindowAddCmd = cli.Command{
+	Name:    "add",
+	Aliases: []string{"create"},
+	Short:   "Add a CDS maintenance window",
+	Example: `cdsctl admin maintenance window add "Production freeze" --project MYPROJECT --end 2020-12-26T08:00:00Z
+cdsctl admin maintenance window add "Database migration" --project MYPROJECT --workflow deploy --start 2020-11-03T22:00:00+01:00 --duration 2h`,
+	Args: []cli.Arg{
+		{Name: "reason"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "project",
+			Usage: "Key of the project in maintenance, all the projects are selected if not given",
+		},
+		{
+			Name:  "workflow",
+			Usage: "Name of the workflow in maintenance, all the workflows of the project are selected if not given",
+		},
+		{
+			Name:  "start",
+			Usage: "Start of the window (RFC3339), now if not given",
+		},
+		{
+			Name:  "end",
+			Usage: "End of the window (RFC3339)",
+		},
+		{
+			Name:  "duration",
+			Usage: "Duration of the window (ie. 2h30m), used if no end is given",
+		},
+	},
+}
+
+func adminMaintenanceWindowAddRun(v cli.Values) error {
+	w := sdk.MaintenanceWindow{
+		ProjectKey:   v.GetString("project"),
+		WorkflowName: v.GetString("workflow"),
+		Reason:       v.GetString("reason"),
+		Start:        time.Now(),
+	}
+
+	var err error
+	if s := v.GetString("start"); s != "" {
+		w.Start, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return fmt.Errorf("invalid start %q: %v", s, err)
+		}
+	}
+	switch {
+	case v.GetString("end") != "":
+		w.End, err = time.Parse(time.RFC3339, v.GetString("end"))
+		if err != nil {
+			return fmt.Errorf("invalid end %q: %v", v.GetString("end"), err)
+		}
+	case v.GetString("duration") != "":
+		d, err := time.ParseDuration(v.GetString("duration"))
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %v", v.GetString("duration"), err)
+		}
+		w.End = w.Start.Add(d)
+	default:
+		return fmt.Errorf("an end or a duration is required")
+	}
+
+	if err := w.IsValid(); err != nil {
+		return err
+	}
+	if err := client.MaintenanceWindowCreate(&w); err != nil {
+		return err
+	}
+	fmt.Printf("Maintenance window %d added\n", w.ID)
+	return nil
+}
+
+var adminMaintenanceWindowDeleteCmd = cli.Command{
+	Name:    "delete",
+	Aliases: []string{"rm"},
+	Short:   "Delete a CDS maintenance window, the held jobs are released",
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+}
+
+func adminMaintenanceWindowDeleteRun(v cli.Values) error {
+	id, err := strconv.ParseInt(v.GetString("id"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("id parameter have to be an integer")
+	}
+	return client.MaintenanceWindowDelete(id)
+}
+
+var adminMaintenanceOverrideCmd = cli.Command{
+	Name:    "override",
+	Short:   "Allow the jobs of one Workflow Run to be executed during the maintenance windows, for emergencies",
+	Example: `cdsctl admin maintenance override MYPROJECT my-workflow 12 "Hotfix for the incident 42"`,
+	Args: []cli.Arg{
+		{Name: "project-key"},
+		{Name: "workflow-name"},
+		{Name: "number"},
+		{Name: "reason"},
+	},
+}
+
+func adminMaintenanceOverrideRun(v cli.Values) error {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("number parameter have to be an integer")
+	}
+	return client.MaintenanceWindowOverride(v.GetString("project-key"), v.GetString("workflow-name"), number, v.GetString("reason"))
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var adminQueueCmd = cli.Command{
+	Name:  "queue",
+	Short: "Inspect the queue and act on its jobs",
+}
+
+func adminQueue() *cobra.Command {
+	return cli.NewCommand(adminQueueCmd, nil, []*cobra.Command{
+		cli.NewListCommand(adminQueueListCmd, adminQueueListRun, nil),
+		cli.NewListCommand(adminQueueCancelCmd, adminQueueCancelRun, nil),
+		cli.NewListCommand(adminQueueRequeueCmd, adminQueueRequeueRun, nil),
+		cli.NewListCommand(adminQueueDiagnoseCmd, adminQueueDiagnoseRun, nil),
+	})
+}
+
+var adminQueueFilterFlags = []cli.Flag{
+	{
+		Type:    cli.FlagSlice,
+		Name:    "status",
+		Usage:   "Status of the jobs",
+		Default: sdk.StatusWaiting,
+	},
+	{
+		Name:  "older-than",
+		Usage: "Only the jobs queued for more than given duration (ie. 30m)",
+	},
+	{
+		Name:  "project",
+		Usage: "Only the jobs of given project key",
+	},
+	{
+		Name:  "model",
+		Usage: "Only the jobs requiring given worker model, with or without its group (ie. shared.infra/debian or debian)",
+	},
+}
+
+type adminQueueJob struct {
+	ID       int64  `cli:"id,key"`
+	Project  string `cli:"project"`
+	Workflow string `cli:"workflow"`
+	Run      string `cli:"run"`
+	Node     string `cli:"node"`
+	Job      string `cli:"job"`
+	Status   string `cli:"status"`
+	Since    string `cli:"since"`
+	Model    string `cli:"model"`
+	BookedBy string `cli:"booked_by"`
+	Retry    int    `cli:"retry"`
+}
+
+func newAdminQueueJob(j sdk.WorkflowNodeJobRun) adminQueueJob {
+	return adminQueueJob{
+		ID:       j.ID,
+		Project:  getVarsInPbj("cds.project", j.Parameters),
+		Workflow: getVarsInPbj("cds.workflow", j.Parameters),
+		Run:      getVarsInPbj("cds.run.number", j.Parameters),
+		Node:     getVarsInPbj("cds.node", j.Parameters),
+		Job:      j.Job.Action.Name,
+		Status:   j.Status,
+		Since:    sdk.Round(time.Since(j.Queued), time.Second).String(),
+		Model:    adminQueueJobModel(j),
+		BookedBy: j.BookedBy.Name,
+		Retry:    j.Retry,
+	}
+}
+
+// adminQueueJobModel returns the name of the worker model required by a job, without its options.
+func adminQueueJobModel(j sdk.WorkflowNodeJobRun) string {
+	for _, r := range j.Job.Action.Requirements {
+		if r.Type == sdk.ModelRequirement {
+			return strings.Split(r.Value, " ")[0]
+		}
+	}
+	return ""
+}
+
+// adminQueueJobs returns the jobs of the queue matching the filter flags.
+func adminQueueJobs(v cli.Values) ([]sdk.WorkflowNodeJobRun, error) {
+	var olderThan time.Duration
+	if s := v.GetString("older-than"); s != "" {
+		var err error
+		olderThan, err = time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid given duration %q: %v", s, err)
+		}
+	}
+	project := v.GetString("project")
+	model := v.GetString("model")
+
+	jobs, err := client.QueueWorkflowNodeJobRun(v.GetStringSlice("status")...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]sdk.WorkflowNodeJobRun, 0, len(jobs))
+	for _, j := range jobs {
+		if olderThan > 0 && time.Since(j.Queued) < olderThan {
+			continue
+		}
+		if project != "" && getVarsInPbj("cds.project", j.Parameters) != project {
+			continue
+		}
+		if model != "" {
+			m := adminQueueJobModel(j)
+			if m != model && !strings.HasSuffix(m, "/"+model) {
+				continue
+			}
+		}
+		res = append(res, j)
+	}
+	return res, nil
+}
+
+var adminQueueListCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Short:   "List the jobs of the queue",
+	Example: `cdsctl admin queue list --status Waiting,Building --older-than 1h --project MYPROJECT`,
+	Flags:   adminQueueFilterFlags,
+}
+
+func adminQueueListRun(v cli.Values) (cli.ListResult, error) {
+	jobs, err := adminQueueJobs(v)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]adminQueueJob, len(jobs))
+	for i := range jobs {
+		res[i] = newAdminQueueJob(jobs[i])
+	}
+	return cli.AsListResult(res), nil
+}
+
+type adminQueueJobResult struct {
+	adminQueueJob
+	Result string `cli:"result"`
+}
+
+// adminQueueBulk applies given action on the jobs matching the filter flags, after a confirmation.
+func adminQueueBulk(v cli.Values, verb string, action func(id int64) error) (cli.ListResult, error) {
+	jobs, err := adminQueueJobs(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return cli.AsListResult([]adminQueueJobResult{}), nil
+	}
+	if !v.GetBool("no-interactive") && !cli.AskConfirm(fmt.Sprintf("Do you want to %s %d jobs?", verb, len(jobs))) {
+		return nil, fmt.Errorf("operation aborted")
+	}
+
+	res := make([]adminQueueJobResult, len(jobs))
+	for i := range jobs {
+		res[i] = adminQueueJobResult{adminQueueJob: newAdminQueueJob(jobs[i]), Result: "OK"}
+		if err := action(jobs[i].ID); err != nil {
+			res[i].Result = err.Error()
+		}
+	}
+	return cli.AsListResult(res), nil
+}
+
+var adminQueueCancelCmd = cli.Command{
+	Name:  "cancel",
+	Short: "Cancel the jobs of the queue matching given filters",
+	Long: `Cancel the jobs of the queue matching given filters, the pipeline of each job is stopped.
+
+Confirmation is asked before canceling the jobs, unless the --no-interactive flag is set.`,
+	Example: `cdsctl admin queue cancel --older-than 24h --model shared.infra/debian`,
+	Flags:   adminQueueFilterFlags,
+}
+
+func adminQueueCancelRun(v cli.Values) (cli.ListResult, error) {
+	return adminQueueBulk(v, "cancel", client.AdminQueueJobCancel)
+}
+
+var adminQueueRequeueCmd = cli.Command{
+	Name:  "requeue",
+	Short: "Requeue the jobs of the queue matching given filters",
+	Long: `Replace in queue the jobs matching given filters, their booking is released so they can be taken by any hatchery.
+Building jobs are restarted.
+
+Confirmation is asked before requeuing the jobs, unless the --no-interactive flag is set.`,
+	Example: `cdsctl admin queue requeue --project MYPROJECT`,
+	Flags:   adminQueueFilterFlags,
+}
+
+func adminQueueRequeueRun(v cli.Values) (cli.ListResult, error) {
+	return adminQueueBulk(v, "requeue", client.AdminQueueJobRequeue)
+}
+
+var adminQueueDiagnoseCmd = cli.Command{
+	Name:    "diagnose",
+	Aliases: []string{"diag"},
+	Short:   "Explain why a job of the queue is not taken by a hatchery",
+	Example: `cdsctl admin queue diagnose 1234`,
+	Args: []cli.Arg{
+		{Name: "job-id"},
+	},
+}
+
+func adminQueueDiagnoseRun(v cli.Values) (cli.ListResult, error) {
+	id, err := v.GetInt64("job-id")
+	if err != nil {
+		return nil, err
+	}
+	diag, err := client.AdminQueueJobDiagnostic(id)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(diag.Checks), nil
+}
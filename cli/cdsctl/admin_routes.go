@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var adminRoutesCmd = cli.Command{
+	Name:    "routes",
+	Aliases: []string{"route"},
+	Short:   "List the routes of the CDS API",
+	Long: `List the routes of the CDS API with the API versions in which they are available. A deprecated route is only
+available in the API v1 and is removed at its sunset date.`,
+	Flags: []cli.Flag{
+		{
+			Type:  cli.FlagBool,
+			Name:  "deprecated",
+			Usage: "List only deprecated routes",
+		},
+	},
+}
+
+func adminRoutes() *cobra.Command {
+	return cli.NewListCommand(adminRoutesCmd, adminRoutesRun, nil)
+}
+
+func adminRoutesRun(v cli.Values) (cli.ListResult, error) {
+	routes, err := client.APIRoutes()
+	if err != nil {
+		return nil, err
+	}
+	if v.GetBool("deprecated") {
+		filtered := make([]sdk.APIRoute, 0, len(routes))
+		for _, r := range routes {
+			if r.Deprecated {
+				filtered = append(filtered, r)
+			}
+		}
+		routes = filtered
+	}
+	return cli.AsListResult(routes), nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var adminSCIMCmd = cli.Command{
+	Name:  "scim",
+	Short: "Manage the SCIM provisioning of users and groups",
+}
+
+func adminSCIM() *cobra.Command {
+	return cli.NewCommand(adminSCIMCmd, nil, []*cobra.Command{
+		cli.NewListCommand(adminSCIMAuditCmd, adminSCIMAuditRun, nil),
+	})
+}
+
+var adminSCIMAuditCmd = cli.Command{
+	Name:  "audit",
+	Short: "List the last operations of the identity provider on users and groups",
+	Flags: []cli.Flag{
+		{
+			Name:    "limit",
+			Usage:   "Maximum number of operations to list",
+			Default: "100",
+		},
+	},
+}
+
+func adminSCIMAuditRun(v cli.Values) (cli.ListResult, error) {
+	limit, err := v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+	audits, err := client.AdminSCIMAudit(int(limit))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(audits), nil
+}
@@ -165,19 +165,23 @@ var applicationExportCmd = cli.Command{
 		{Name: _ProjectKey},
 		{Name: _ApplicationName},
 	},
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		{
 			Type:    cli.FlagString,
 			Name:    "format",
 			Usage:   "Specify export format (json or yaml)",
 			Default: "yaml",
 		},
-	},
+	}, exportSealFlags...),
 }
 
 func applicationExportRun(c cli.Values) error {
+	mods, err := exportSealModifiers(c)
+	if err != nil {
+		return err
+	}
 	btes, err := client.ApplicationExport(c.GetString(_ProjectKey), c.GetString(_ApplicationName),
-		cdsclient.Format(c.GetString("format")))
+		append(mods, cdsclient.Format(c.GetString("format")))...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+// Values given by the API for the completion are kept in a local cache to keep completion fast.
+const completionCacheDuration = time.Minute
+
+var completionCmd = cli.Command{
+	Name:  "completion",
+	Short: "Generate the bash completion script of cdsctl",
+	Long: `
+The completion script completes the commands, the flags, and dynamically the project keys, workflow names, integration
+names and worker model paths given by the CDS API. Values given by the API are cached one minute in ~/.cdsctl_completion_cache.
+
+Load the completion in the current bash shell:
+
+	source <(cdsctl completion)
+
+Or add it to your bash profile:
+
+	cdsctl completion > /etc/bash_completion.d/cdsctl
+
+With zsh, enable the bash completion compatibility first:
+
+	autoload -U +X bashcompinit && bashcompinit
+	source <(cdsctl completion)
+`,
+}
+
+func completion() *cobra.Command {
+	return cli.NewCommand(completionCmd, completionRun, nil, cli.CommandWithoutExtraFlags)
+}
+
+// The bash completion generated by cobra calls __custom_func when there is no command or flag to complete.
+const completionBashFunction = `__custom_func() {
+    local out
+    if out=$("${words[0]}" __complete "${words[@]:1:$((cword-1))}" 2>/dev/null); then
+        COMPREPLY=( $(compgen -W "${out}" -- "$cur") )
+    fi
+}`
+
+func completionRun(v cli.Values) error {
+	root.BashCompletionFunction = completionBashFunction
+	return root.GenBashCompletion(os.Stdout)
+}
+
+// completionValues is called by the completion script, it prints the values for the argument to complete
+// after given command line words.
+func completionValues() *cobra.Command {
+	return &cobra.Command{
+		Use:                "__complete",
+		Hidden:             true,
+		DisableFlagParsing: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			if client == nil {
+				return
+			}
+			argName, vals := completionArg(root, args)
+			values, err := completionValuesForArg(argName, vals)
+			if err != nil {
+				return
+			}
+			for _, v := range values {
+				fmt.Println(v)
+			}
+		},
+	}
+}
+
+// completionArg returns the name of the argument to complete after given command line words, with the values of the
+// previous arguments.
+func completionArg(root *cobra.Command, words []string) (string, cli.Values) {
+	cmd, rest, err := root.Find(words)
+	if err != nil {
+		return "", nil
+	}
+
+	var positionals []string
+	for i := 0; i < len(rest); i++ {
+		w := rest[i]
+		if !strings.HasPrefix(w, "-") || w == "-" {
+			positionals = append(positionals, w)
+			continue
+		}
+		if strings.Contains(w, "=") {
+			continue
+		}
+		name := strings.TrimLeft(w, "-")
+		f := cmd.Flags().Lookup(name)
+		if f == nil && len(name) == 1 && !strings.HasPrefix(w, "--") {
+			f = cmd.Flags().ShorthandLookup(name)
+		}
+		if f == nil {
+			f = cmd.InheritedFlags().Lookup(name)
+		}
+		// skip the value of a non boolean flag
+		if f != nil && f.Value.Type() != "bool" {
+			i++
+		}
+	}
+
+	names := cli.ArgNames(cmd)
+	if len(positionals) >= len(names) {
+		return "", nil
+	}
+	vals := cli.Values{}
+	for i := range positionals {
+		vals[names[i]] = []string{positionals[i]}
+	}
+	return names[len(positionals)], vals
+}
+
+func completionValuesForArg(argName string, vals cli.Values) ([]string, error) {
+	projectKey := vals.GetString(_ProjectKey)
+	switch argName {
+	case _ProjectKey:
+		return cachedCompletionValues("projects", func() ([]string, error) {
+			projs, err := client.ProjectList(false, false)
+			if err != nil {
+				return nil, err
+			}
+			res := make([]string, len(projs))
+			for i := range projs {
+				res[i] = projs[i].Key
+			}
+			return res, nil
+		})
+	case _WorkflowName:
+		if projectKey == "" {
+			return nil, nil
+		}
+		return cachedCompletionValues("workflows/"+projectKey, func() ([]string, error) {
+			wfs, err := client.WorkflowList(projectKey)
+			if err != nil {
+				return nil, err
+			}
+			res := make([]string, len(wfs))
+			for i := range wfs {
+				res[i] = wfs[i].Name
+			}
+			return res, nil
+		})
+	case _IntegrationName:
+		if projectKey == "" {
+			return nil, nil
+		}
+		return cachedCompletionValues("integrations/"+projectKey, func() ([]string, error) {
+			integs, err := client.ProjectIntegrationList(projectKey)
+			if err != nil {
+				return nil, err
+			}
+			res := make([]string, len(integs))
+			for i := range integs {
+				res[i] = integs[i].Name
+			}
+			return res, nil
+		})
+	case _WorkerModelPath:
+		return cachedCompletionValues("worker-models", func() ([]string, error) {
+			models, err := client.WorkerModels(nil)
+			if err != nil {
+				return nil, err
+			}
+			res := make([]string, 0, len(models))
+			for i := range models {
+				if models[i].Group == nil {
+					continue
+				}
+				res = append(res, models[i].GetPath(models[i].Group.Name))
+			}
+			return res, nil
+		})
+	}
+	return nil, nil
+}
+
+type completionCache map[string]completionCacheEntry
+
+type completionCacheEntry struct {
+	Values []string  `json:"values"`
+	Date   time.Time `json:"date"`
+}
+
+func completionCacheFile() string {
+	return path.Join(userHomeDir(), ".cdsctl_completion_cache")
+}
+
+// cachedCompletionValues returns the cached values for given key, values are fetched again if they are not cached for
+// current API and user or if they are expired.
+func cachedCompletionValues(key string, fetch func() ([]string, error)) ([]string, error) {
+	if cfg != nil {
+		key = cfg.Host + "|" + cfg.User + "|" + key
+	}
+
+	file := completionCacheFile()
+	cache := loadCompletionCache(file)
+	if values, ok := cache.get(key, time.Now()); ok {
+		return values, nil
+	}
+
+	values, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(values)
+	cache.set(key, values, time.Now())
+	_ = cache.save(file) // the completion still works if the cache can't be saved
+	return values, nil
+}
+
+func loadCompletionCache(file string) completionCache {
+	cache := completionCache{}
+	btes, err := ioutil.ReadFile(file)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(btes, &cache); err != nil {
+		return completionCache{}
+	}
+	return cache
+}
+
+func (c completionCache) get(key string, now time.Time) ([]string, bool) {
+	e, ok := c[key]
+	if !ok || now.Sub(e.Date) > completionCacheDuration {
+		return nil, false
+	}
+	return e.Values, true
+}
+
+func (c completionCache) set(key string, values []string, now time.Time) {
+	for k, e := range c {
+		if now.Sub(e.Date) > completionCacheDuration {
+			delete(c, k)
+		}
+	}
+	c[key] = completionCacheEntry{Values: values, Date: now}
+}
+
+func (c completionCache) save(file string) error {
+	btes, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, btes, 0600)
+}
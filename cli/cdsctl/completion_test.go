@@ -0,0 +1,78 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/cli"
+)
+
+func TestCompletionArg(t *testing.T) {
+	tree := cli.NewCommand(cli.Command{Name: "cdsctl"}, nil, []*cobra.Command{
+		cli.NewCommand(cli.Command{Name: "workflow"}, nil, []*cobra.Command{
+			cli.NewGetCommand(cli.Command{
+				Name: "status",
+				Ctx:  []cli.Arg{{Name: _ProjectKey}, {Name: _WorkflowName}},
+				Flags: []cli.Flag{
+					{Name: "track", Type: cli.FlagBool},
+					{Name: "branch", ShortHand: "b", Type: cli.FlagString},
+				},
+			}, func(v cli.Values) (interface{}, error) { return nil, nil }, nil),
+		}),
+	})
+	tree.PersistentFlags().StringP("context", "c", "", "")
+
+	tests := []struct {
+		words   []string
+		arg     string
+		project string
+	}{
+		{words: []string{"workflow", "status"}, arg: _ProjectKey},
+		{words: []string{"workflow", "status", "MY_PRJ"}, arg: _WorkflowName, project: "MY_PRJ"},
+		{words: []string{"workflow", "status", "--track", "MY_PRJ"}, arg: _WorkflowName, project: "MY_PRJ"},
+		{words: []string{"workflow", "status", "-b", "master", "MY_PRJ"}, arg: _WorkflowName, project: "MY_PRJ"},
+		{words: []string{"workflow", "status", "--format=json", "MY_PRJ"}, arg: _WorkflowName, project: "MY_PRJ"},
+		{words: []string{"workflow", "status", "--context", "prod", "MY_PRJ"}, arg: _WorkflowName, project: "MY_PRJ"},
+		{words: []string{"workflow", "status", "MY_PRJ", "MY_WF"}},
+		{words: []string{"workflow"}},
+		{words: []string{"unknown"}},
+	}
+	for _, tt := range tests {
+		arg, vals := completionArg(tree, tt.words)
+		assert.Equal(t, tt.arg, arg, "words: %v", tt.words)
+		assert.Equal(t, tt.project, vals.GetString(_ProjectKey), "words: %v", tt.words)
+	}
+}
+
+func TestCompletionCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cdsctl-completion")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "cache")
+	now := time.Now()
+
+	c := loadCompletionCache(file)
+	_, ok := c.get("projects", now)
+	assert.False(t, ok)
+
+	c.set("old", []string{"A"}, now.Add(-2*completionCacheDuration))
+	c.set("projects", []string{"A", "B"}, now)
+	require.NoError(t, c.save(file))
+
+	c = loadCompletionCache(file)
+	values, ok := c.get("projects", now.Add(completionCacheDuration/2))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"A", "B"}, values)
+	_, ok = c.get("old", now)
+	assert.False(t, ok, "expired entries should be removed from the cache")
+
+	_, ok = c.get("projects", now.Add(2*completionCacheDuration))
+	assert.False(t, ok)
+}
@@ -20,6 +20,8 @@ var (
 	_ProjectKey      = "project-key"
 	_ApplicationName = "application-name"
 	_WorkflowName    = "workflow-name"
+	_IntegrationName = "integration-name"
+	_WorkerModelPath = "worker-model-path"
 )
 
 func userHomeDir() string {
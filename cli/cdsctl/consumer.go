@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -20,6 +22,7 @@ func consumer() *cobra.Command {
 		cli.SubCommands{
 			cli.NewListCommand(authConsumerListCmd, authConsumerListRun, nil),
 			cli.NewCommand(authConsumerNewCmd, authConsumerNewRun, nil),
+			cli.NewCommand(authConsumerBrowserTokenCmd, authConsumerBrowserTokenRun, nil),
 			cli.NewCommand(authConsumerDeleteCmd, authConsumerDeleteRun, nil),
 			cli.NewCommand(authConsumerRegenCmd, authConsumerRegenRun, nil),
 		},
@@ -84,6 +87,11 @@ var authConsumerNewCmd = cli.Command{
 			Type:  cli.FlagSlice,
 			Usage: "Define the list of scopes for the consumer",
 		},
+		{
+			Name:  "projects",
+			Type:  cli.FlagSlice,
+			Usage: "Restrict the consumer to given project verbs, as PROJECT_KEY:Verb (ex: MYPROJ:RunWorkflow)",
+		},
 	},
 }
 
@@ -151,11 +159,38 @@ func authConsumerNewRun(v cli.Values) error {
 		}
 	}
 
+	var projectScopes sdk.AuthConsumerProjectScopes
+	for _, p := range v.GetStringSlice("projects") {
+		tuple := strings.SplitN(p, ":", 2)
+		if len(tuple) != 2 {
+			return errors.Errorf("invalid given project scope: '%s', expected PROJECT_KEY:Verb", p)
+		}
+		verb := sdk.AuthConsumerProjectVerb(tuple[1])
+		if !verb.IsValid() {
+			return errors.Errorf("invalid given project verb value: '%s'", verb)
+		}
+		var found bool
+		for i := range projectScopes {
+			if projectScopes[i].ProjectKey == tuple[0] {
+				projectScopes[i].Verbs = append(projectScopes[i].Verbs, verb)
+				found = true
+				break
+			}
+		}
+		if !found {
+			projectScopes = append(projectScopes, sdk.AuthConsumerProjectScope{
+				ProjectKey: tuple[0],
+				Verbs:      []sdk.AuthConsumerProjectVerb{verb},
+			})
+		}
+	}
+
 	res, err := client.AuthConsumerCreateForUser(username, sdk.AuthConsumer{
-		Name:         name,
-		Description:  description,
-		GroupIDs:     groupIDs,
-		ScopeDetails: sdk.NewAuthConsumerScopeDetails(scopes...),
+		Name:          name,
+		Description:   description,
+		GroupIDs:      groupIDs,
+		ScopeDetails:  sdk.NewAuthConsumerScopeDetails(scopes...),
+		ProjectScopes: projectScopes,
 	})
 	if err != nil {
 		return err
@@ -167,6 +202,75 @@ func authConsumerNewRun(v cli.Values) error {
 	return nil
 }
 
+var authConsumerBrowserTokenCmd = cli.Command{
+	Name:  "browser-token",
+	Short: "Create a short-lived read only token usable from given browser origins",
+	OptionalArgs: []cli.Arg{
+		{
+			Name: "username",
+		},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "name",
+			Usage: "What is the name of this token",
+		},
+		{
+			Name:  "description",
+			Usage: "What is the purpose of this token",
+		},
+		{
+			Name:  "origins",
+			Type:  cli.FlagSlice,
+			Usage: "Define the list of origins allowed to use the token (ex: https://github.com)",
+		},
+		{
+			Name:    "scopes",
+			Type:    cli.FlagSlice,
+			Usage:   "Define the list of scopes for the token, among User, Project and Run",
+			Default: string(sdk.AuthConsumerScopeRun),
+		},
+		{
+			Name:    "duration",
+			Usage:   "Validity of the token, at most 24h",
+			Default: "1h",
+		},
+	},
+}
+
+func authConsumerBrowserTokenRun(v cli.Values) error {
+	username := v.GetString("username")
+	if username == "" {
+		username = "me"
+	}
+
+	duration, err := time.ParseDuration(v.GetString("duration"))
+	if err != nil {
+		return errors.Errorf("invalid given duration: '%s'", v.GetString("duration"))
+	}
+
+	var scopes []sdk.AuthConsumerScope
+	for _, s := range v.GetStringSlice("scopes") {
+		scopes = append(scopes, sdk.AuthConsumerScope(s))
+	}
+
+	res, err := client.AuthBrowserTokenCreateForUser(username, sdk.AuthConsumerBrowserTokenRequest{
+		Name:        v.GetString("name"),
+		Description: v.GetString("description"),
+		Origins:     v.GetStringSlice("origins"),
+		Scopes:      scopes,
+		Duration:    int64(duration.Seconds()),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Browser token successfully created, it expires at %s:\n", res.ExpireAt.Format(time.RFC3339))
+	fmt.Println(res.Token)
+
+	return nil
+}
+
 var authConsumerDeleteCmd = cli.Command{
 	Name:  "delete",
 	Short: "Delete an auth consumer",
@@ -136,19 +136,23 @@ var environmentExportCmd = cli.Command{
 	Args: []cli.Arg{
 		{Name: "environment-name"},
 	},
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		{
 			Type:    cli.FlagString,
 			Name:    "format",
 			Usage:   "Specify export format (json or yaml)",
 			Default: "yaml",
 		},
-	},
+	}, exportSealFlags...),
 }
 
 func environmentExportRun(c cli.Values) error {
+	mods, err := exportSealModifiers(c)
+	if err != nil {
+		return err
+	}
 	btes, err := client.EnvironmentExport(c.GetString(_ProjectKey), c.GetString("environment-name"),
-		cdsclient.Format(c.GetString("format")))
+		append(mods, cdsclient.Format(c.GetString("format")))...)
 	if err != nil {
 		return err
 	}
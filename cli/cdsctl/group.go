@@ -25,6 +25,7 @@ func group() *cobra.Command {
 		cli.NewCommand(groupGrantCmd, groupGrantRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(groupRevokeCmd, groupRevokeRun, nil, withAllCommandModifiers()...),
 		groupMember(),
+		groupIntegration(),
 	})
 }
 
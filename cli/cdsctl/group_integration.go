@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+var groupIntegrationCmd = cli.Command{
+	Name:  "integration",
+	Short: "Manage default integrations of a group, inherited by the projects created with the group",
+}
+
+func groupIntegration() *cobra.Command {
+	return cli.NewCommand(groupIntegrationCmd, nil, []*cobra.Command{
+		cli.NewListCommand(groupIntegrationListCmd, groupIntegrationListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(groupIntegrationImportCmd, groupIntegrationImportRun, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(groupIntegrationDeleteCmd, groupIntegrationDeleteRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var groupIntegrationListCmd = cli.Command{
+	Name:  "list",
+	Short: "List default integrations of a group",
+	Args: []cli.Arg{
+		{Name: "group-name"},
+	},
+}
+
+func groupIntegrationListRun(v cli.Values) (cli.ListResult, error) {
+	gis, err := client.GroupIntegrationList(v.GetString("group-name"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(gis), nil
+}
+
+var groupIntegrationImportCmd = cli.Command{
+	Name:  "import",
+	Short: "Import a default integration on a group from a yaml file",
+	Long: `
+The file has the same format as a project integration, only storage and event integrations can be set as default:
+
+	name: my-kafka
+	model:
+	  name: Kafka
+	config:
+	  broker url:
+	    value: localhost:9092
+	    type: string
+
+With --force an existing integration is updated, its config is copied on the projects that inherited it and did not override it.
+`,
+	Example: "cdsctl group integration import my-group file.yml",
+	Args: []cli.Arg{
+		{Name: "group-name"},
+		{Name: "filename"},
+	},
+	Flags: []cli.Flag{
+		{Name: "force", Type: cli.FlagBool},
+	},
+}
+
+func groupIntegrationImportRun(v cli.Values) error {
+	btes, err := ioutil.ReadFile(v.GetString("filename"))
+	if err != nil {
+		return fmt.Errorf("unable to read file %s: %v", v.GetString("filename"), err)
+	}
+
+	var gi sdk.GroupIntegration
+	if err := exportentities.Unmarshal(btes, exportentities.FormatYAML, &gi); err != nil {
+		return err
+	}
+
+	groupName := v.GetString("group-name")
+	if v.GetBool("force") {
+		gis, err := client.GroupIntegrationList(groupName)
+		if err != nil {
+			return err
+		}
+		for i := range gis {
+			if gis[i].Name == gi.Name {
+				return client.GroupIntegrationUpdate(groupName, &gi)
+			}
+		}
+	}
+	return client.GroupIntegrationCreate(groupName, &gi)
+}
+
+var groupIntegrationDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete a default integration of a group, integrations of existing projects are kept",
+	Args: []cli.Arg{
+		{Name: "group-name"},
+		{Name: "name"},
+	},
+}
+
+func groupIntegrationDeleteRun(v cli.Values) error {
+	return client.GroupIntegrationDelete(v.GetString("group-name"), v.GetString("name"))
+}
@@ -24,6 +24,8 @@ func main() {
 		action(),
 		admin(),
 		application(),
+		completion(),
+		completionValues(), // hidden command
 		consumer(),
 		encrypt(),
 		contexts(),
@@ -76,6 +78,8 @@ func rootFromSubCommands(cmds []*cobra.Command) *cobra.Command {
 			cmd.Name() == "reset-password" ||
 			cmd.Name() == "confirm" ||
 			cmd.Name() == "version" ||
+			cmd.Name() == "completion" ||
+			cmd.Name() == "__complete" ||
 			cmd.Name() == "doc" || strings.HasPrefix(cmd.Use, "doc ") || (cmd.Run == nil && cmd.RunE == nil) {
 			return
 		}
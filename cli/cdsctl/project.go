@@ -2,11 +2,13 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/ovh/cds/cli"
 	"github.com/ovh/cds/sdk"
@@ -30,6 +32,10 @@ func projectCommands() []*cobra.Command {
 		projectVariable(),
 		projectIntegration(),
 		projectRepositoryManager(),
+		projectQuota(),
+		projectCost(),
+		projectArtifactRetention(),
+		projectWebHookSecret(),
 	}
 }
 
@@ -125,10 +131,20 @@ var projectCreateCmd = cli.Command{
 	OptionalArgs: []cli.Arg{
 		{Name: "group-name"},
 	},
+	Flags: []cli.Flag{
+		{
+			Name:  "from-template",
+			Usage: "Path of a project template file, to create the project with its groups, integrations and starter workflow",
+		},
+	},
 	Aliases: []string{"add"},
 }
 
 func projectCreateRun(v cli.Values) error {
+	if path := v.GetString("from-template"); path != "" {
+		return projectCreateFromTemplate(v, path)
+	}
+
 	proj := &sdk.Project{
 		Name: v.GetString("project-name"),
 		Key:  v.GetString(_ProjectKey),
@@ -146,6 +162,49 @@ func projectCreateRun(v cli.Values) error {
 	return client.ProjectCreate(proj)
 }
 
+func projectCreateFromTemplate(v cli.Values, path string) error {
+	btes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read file %s: %v", path, err)
+	}
+	var tmpl sdk.ProjectTemplate
+	if err := yaml.Unmarshal(btes, &tmpl); err != nil {
+		return fmt.Errorf("unable to parse project template file %s: %v", path, err)
+	}
+
+	// The group given as argument is added with RWX permission
+	if groupName := v.GetString("group-name"); groupName != "" {
+		tmpl.Groups = append(tmpl.Groups, sdk.ProjectTemplateGroup{
+			Name:       groupName,
+			Permission: sdk.PermissionReadWriteExecute,
+		})
+	}
+
+	res, err := client.ProjectBootstrap(sdk.ProjectBootstrap{
+		Key:      v.GetString(_ProjectKey),
+		Name:     v.GetString("project-name"),
+		Template: tmpl,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Project %s created\n", res.Project.Key)
+	if res.Workflow != "" {
+		fmt.Printf("Workflow %s created\n", res.Workflow)
+	}
+	for _, msg := range res.Messages {
+		fmt.Println(msg)
+	}
+	if len(res.Placeholders) > 0 {
+		fmt.Println("Set the value of the following project variables to complete the integrations:")
+		for _, name := range res.Placeholders {
+			fmt.Printf("  cdsctl project variable update %s %s %s %s <value>\n", res.Project.Key, name, name, sdk.SecretVariable)
+		}
+	}
+	return nil
+}
+
 var projectDeleteCmd = cli.Command{
 	Name:  "delete",
 	Short: "Delete a CDS project",
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectArtifactRetentionCmd = cli.Command{
+	Name:  "artifact-retention",
+	Short: "Manage the retention of the artifacts of a CDS project",
+}
+
+func projectArtifactRetention() *cobra.Command {
+	return cli.NewCommand(projectArtifactRetentionCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(projectArtifactRetentionShowCmd, projectArtifactRetentionShowRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectArtifactRetentionUpdateCmd, projectArtifactRetentionUpdateRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectArtifactRetentionShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show the retention in days of the artifacts of a CDS project for each retention class",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+type projectArtifactRetentionDisplay struct {
+	Branch  string `cli:"branch"`
+	Tag     string `cli:"tag"`
+	Release string `cli:"release"`
+}
+
+func projectArtifactRetentionShowRun(v cli.Values) (interface{}, error) {
+	p, err := client.ProjectArtifactRetentionGet(v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	display := func(days int64) string {
+		if days == 0 {
+			return "kept with the workflow run"
+		}
+		return fmt.Sprintf("%d days", days)
+	}
+	return projectArtifactRetentionDisplay{
+		Branch:  display(p.Days(sdk.ArtifactRetentionClassBranch)),
+		Tag:     display(p.Days(sdk.ArtifactRetentionClassTag)),
+		Release: display(p.Days(sdk.ArtifactRetentionClassRelease)),
+	}, nil
+}
+
+var projectArtifactRetentionUpdateCmd = cli.Command{
+	Name:  "update",
+	Short: "Update the retention in days of the artifacts of a CDS project, 0 means that artifacts are kept with their workflow run",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "branch",
+			Usage: "Retention in days of the artifacts of the runs on a branch",
+		},
+		{
+			Name:  "tag",
+			Usage: "Retention in days of the artifacts of the runs on a git tag",
+		},
+		{
+			Name:  "release",
+			Usage: "Retention in days of the artifacts of the runs from which a release was created",
+		},
+	},
+}
+
+func projectArtifactRetentionUpdateRun(v cli.Values) error {
+	p, err := client.ProjectArtifactRetentionGet(v.GetString(_ProjectKey))
+	if err != nil {
+		return err
+	}
+
+	for flag, value := range map[string]*int64{
+		"branch":  &p.Branch,
+		"tag":     &p.Tag,
+		"release": &p.Release,
+	} {
+		if v.GetString(flag) == "" {
+			continue
+		}
+		n, err := v.GetInt64(flag)
+		if err != nil {
+			return err
+		}
+		*value = n
+	}
+
+	if err := client.ProjectArtifactRetentionUpdate(v.GetString(_ProjectKey), &p); err != nil {
+		return err
+	}
+	fmt.Printf("Artifact retention of project %s updated\n", v.GetString(_ProjectKey))
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var projectCostCmd = cli.Command{
+	Name:  "cost",
+	Short: "Show the cost of the workflow runs of a CDS project",
+}
+
+func projectCost() *cobra.Command {
+	return cli.NewCommand(projectCostCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectCostShowCmd, projectCostShowRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectCostExportCmd, projectCostExportRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+// costFlags are the flags of the cost commands used to filter the job runs and group the lines of the report.
+var costFlags = []cli.Flag{
+	{
+		Name:  "from",
+		Usage: "Only count the jobs started after this RFC3339 date",
+	},
+	{
+		Name:  "to",
+		Usage: "Only count the jobs started before this RFC3339 date",
+	},
+	{
+		Name:  "group-by",
+		Usage: "Group the lines of the report by workflow, run or job",
+	},
+}
+
+func costModifiers(v cli.Values) []cdsclient.RequestModifier {
+	var mods []cdsclient.RequestModifier
+	for _, k := range []string{"from", "to"} {
+		if v.GetString(k) != "" {
+			mods = append(mods, cdsclient.WithQueryParameter(k, v.GetString(k)))
+		}
+	}
+	if v.GetString("group-by") != "" {
+		mods = append(mods, cdsclient.WithQueryParameter("groupBy", v.GetString("group-by")))
+	}
+	return mods
+}
+
+// costReportLines returns the lines of a report followed by its total.
+func costReportLines(r sdk.WorkflowCostReport) cli.ListResult {
+	total := r.Total
+	total.WorkflowName = "total"
+	return cli.AsListResult(append(r.Lines, total))
+}
+
+func writeCostReportCSV(r sdk.WorkflowCostReport) error {
+	w := csv.NewWriter(os.Stdout)
+	return w.WriteAll(r.CSV())
+}
+
+var projectCostShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show the cost of the workflow runs of a CDS project by workflow",
+	Long: `Show the usage and the cost of the jobs of a CDS project: the duration of the jobs on worker model flavors and the
+size of the artifacts and caches they uploaded. The cost is computed with the unit prices of the CDS API configuration.`,
+	Example: `cdsctl project cost show MYPROJECT --from 2020-01-01T00:00:00Z --to 2020-02-01T00:00:00Z`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: costFlags,
+}
+
+func projectCostShowRun(v cli.Values) (cli.ListResult, error) {
+	r, err := client.ProjectCostReport(v.GetString(_ProjectKey), costModifiers(v)...)
+	if err != nil {
+		return nil, err
+	}
+	return costReportLines(r), nil
+}
+
+var projectCostExportCmd = cli.Command{
+	Name:    "export",
+	Short:   "Export the cost of the workflow runs of a CDS project as CSV",
+	Example: `cdsctl project cost export MYPROJECT --from 2020-01-01T00:00:00Z --group-by run > cost.csv`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: costFlags,
+}
+
+func projectCostExportRun(v cli.Values) error {
+	r, err := client.ProjectCostReport(v.GetString(_ProjectKey), costModifiers(v)...)
+	if err != nil {
+		return err
+	}
+	return writeCostReportCSV(r)
+}
@@ -22,6 +22,8 @@ func projectIntegration() *cobra.Command {
 		cli.NewDeleteCommand(projectIntegrationDeleteCmd, projectIntegrationDeleteFunc, nil, withAllCommandModifiers()...),
 		cli.NewCommand(projectIntegrationImportCmd, projectIntegrationImportFunc, nil, withAllCommandModifiers()...),
 		cli.NewCommand(projectIntegrationExportCmd, projectIntegrationExportFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectIntegrationCatalogCmd, projectIntegrationCatalogFunc, nil),
+		cli.NewCommand(projectIntegrationExampleCmd, projectIntegrationExampleFunc, nil),
 	})
 }
 
@@ -45,12 +47,12 @@ var projectIntegrationDeleteCmd = cli.Command{
 		{Name: _ProjectKey},
 	},
 	Args: []cli.Arg{
-		{Name: "name"},
+		{Name: _IntegrationName},
 	},
 }
 
 func projectIntegrationDeleteFunc(v cli.Values) error {
-	return client.ProjectIntegrationDelete(v.GetString(_ProjectKey), v.GetString("name"))
+	return client.ProjectIntegrationDelete(v.GetString(_ProjectKey), v.GetString(_IntegrationName))
 }
 
 var projectIntegrationImportCmd = cli.Command{
@@ -92,12 +94,12 @@ var projectIntegrationExportCmd = cli.Command{
 		{Name: _ProjectKey},
 	},
 	Args: []cli.Arg{
-		{Name: "name"},
+		{Name: _IntegrationName},
 	},
 }
 
 func projectIntegrationExportFunc(v cli.Values) error {
-	pf, err := client.ProjectIntegrationGet(v.GetString(_ProjectKey), v.GetString("name"), false)
+	pf, err := client.ProjectIntegrationGet(v.GetString(_ProjectKey), v.GetString(_IntegrationName), false)
 	if err != nil {
 		return err
 	}
@@ -110,3 +112,40 @@ func projectIntegrationExportFunc(v cli.Values) error {
 	fmt.Println(string(btes))
 	return nil
 }
+
+var projectIntegrationCatalogCmd = cli.Command{
+	Name:  "catalog",
+	Short: "List the integration models that can be used on a project",
+}
+
+func projectIntegrationCatalogFunc(v cli.Values) (cli.ListResult, error) {
+	catalog, err := client.IntegrationModelCatalog()
+	return cli.AsListResult(catalog), err
+}
+
+var projectIntegrationExampleCmd = cli.Command{
+	Name:    "example",
+	Short:   "Display an example of integration file for an integration model, that can be imported on a project",
+	Example: "cdsctl project integration example RabbitMQ > file.yml",
+	Args: []cli.Arg{
+		{Name: "model"},
+	},
+}
+
+func projectIntegrationExampleFunc(v cli.Values) error {
+	catalog, err := client.IntegrationModelCatalog()
+	if err != nil {
+		return err
+	}
+	for _, e := range catalog {
+		if e.Name != v.GetString("model") {
+			continue
+		}
+		if e.Example == "" {
+			return fmt.Errorf("integration model %s is public, its integration is already available on all projects", e.Name)
+		}
+		fmt.Print(e.Example)
+		return nil
+	}
+	return fmt.Errorf("integration model %s not found", v.GetString("model"))
+}
@@ -2,11 +2,13 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 
 	"github.com/spf13/cobra"
 
 	"github.com/ovh/cds/cli"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 )
 
 var projectKeyCmd = cli.Command{
@@ -19,6 +21,7 @@ func projectKey() *cobra.Command {
 		cli.NewCommand(projectKeyCreateCmd, projectCreateKeyRun, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(projectKeyListCmd, projectListKeyRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(projectKeyDeleteCmd, projectDeleteKeyRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectKeyBuiltinCmd, projectKeyBuiltinRun, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -78,3 +81,56 @@ var projectKeyDeleteCmd = cli.Command{
 func projectDeleteKeyRun(v cli.Values) error {
 	return client.ProjectKeysDelete(v.GetString(_ProjectKey), v.GetString("key-name"))
 }
+
+var projectKeyBuiltinCmd = cli.Command{
+	Name:  "builtin",
+	Short: "Print the builtin public key of a project, used to seal secrets exported from another CDS instance",
+	Example: `# on the target CDS instance
+cdsctl project keys builtin TARGET > target.pub
+# on the source CDS instance
+cdsctl application export SOURCE my-app --seal-public-key-file target.pub`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectKeyBuiltinRun(v cli.Values) error {
+	k, err := client.ProjectBuiltinPublicKey(v.GetString(_ProjectKey))
+	if err != nil {
+		return err
+	}
+	fmt.Print(k.Public)
+	return nil
+}
+
+// exportSealFlags are the flags of the export commands used to seal secrets for another project.
+var exportSealFlags = []cli.Flag{
+	{
+		Type:  cli.FlagString,
+		Name:  "seal-to",
+		Usage: "Seal secrets and keys for this project of the same CDS instance, so they can be imported in it",
+	},
+	{
+		Type:  cli.FlagString,
+		Name:  "seal-public-key-file",
+		Usage: "Seal secrets and keys with the builtin public key of a project in this file, given by 'cdsctl project keys builtin'",
+	},
+}
+
+func exportSealModifiers(v cli.Values) ([]cdsclient.RequestModifier, error) {
+	var mods []cdsclient.RequestModifier
+	if v.GetString("seal-to") != "" {
+		mods = append(mods, cdsclient.SealTo(v.GetString("seal-to")))
+	}
+	if path := v.GetString("seal-public-key-file"); path != "" {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read public key file: %v", err)
+		}
+		mods = append(mods, cdsclient.SealWithPublicKey(string(b)))
+	}
+	if len(mods) > 1 {
+		return nil, fmt.Errorf("seal-to and seal-public-key-file flags can't be used together")
+	}
+	return mods, nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectQuotaCmd = cli.Command{
+	Name:  "quota",
+	Short: "Manage CDS project quotas",
+}
+
+func projectQuota() *cobra.Command {
+	return cli.NewCommand(projectQuotaCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(projectQuotaShowCmd, projectQuotaShowRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectQuotaUpdateCmd, projectQuotaUpdateRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectQuotaShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show the quotas of a CDS project with their current usage",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+type projectQuotaDisplay struct {
+	ConcurrentJobs   string `cli:"concurrent_jobs"`
+	ArtifactStorage  string `cli:"artifact_storage"`
+	RunsPerDay       string `cli:"runs_per_day"`
+	WarningThreshold string `cli:"warning_threshold"`
+	HardStop         bool   `cli:"hard_stop"`
+}
+
+func projectQuotaShowRun(v cli.Values) (interface{}, error) {
+	s, err := client.ProjectQuotaGet(v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	display := func(usage, limit int64) string {
+		if limit == 0 {
+			return fmt.Sprintf("%d (no limit)", usage)
+		}
+		return fmt.Sprintf("%d/%d", usage, limit)
+	}
+	threshold := s.Quota.WarningThreshold
+	if threshold == 0 {
+		threshold = sdk.DefaultProjectQuotaWarningThreshold
+	}
+	return projectQuotaDisplay{
+		ConcurrentJobs:   display(s.Usage.ConcurrentJobs, s.Quota.ConcurrentJobs),
+		ArtifactStorage:  display(s.Usage.ArtifactStorage, s.Quota.ArtifactStorage),
+		RunsPerDay:       display(s.Usage.RunsPerDay, s.Quota.RunsPerDay),
+		WarningThreshold: fmt.Sprintf("%d%%", threshold),
+		HardStop:         s.Quota.HardStop,
+	}, nil
+}
+
+var projectQuotaUpdateCmd = cli.Command{
+	Name:  "update",
+	Short: "Update the quotas of a CDS project, a limit of 0 means no limit (admin only)",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "concurrent-jobs",
+			Usage: "Maximum number of building jobs",
+		},
+		{
+			Name:  "artifact-storage",
+			Usage: "Maximum size in bytes of the artifacts of all the workflow runs",
+		},
+		{
+			Name:  "runs-per-day",
+			Usage: "Maximum number of workflow runs started in the last 24 hours",
+		},
+		{
+			Name:  "warning-threshold",
+			Usage: "Percentage of a quota above which a warning event is sent",
+		},
+		{
+			Name:  "hard-stop",
+			Usage: "Refuse the jobs, runs and artifacts that would exceed a quota",
+			IsValid: func(s string) bool {
+				return s == "" || s == "true" || s == "false"
+			},
+		},
+	},
+}
+
+func projectQuotaUpdateRun(v cli.Values) error {
+	s, err := client.ProjectQuotaGet(v.GetString(_ProjectKey))
+	if err != nil {
+		return err
+	}
+	q := s.Quota
+
+	for flag, value := range map[string]*int64{
+		"concurrent-jobs":   &q.ConcurrentJobs,
+		"artifact-storage":  &q.ArtifactStorage,
+		"runs-per-day":      &q.RunsPerDay,
+		"warning-threshold": &q.WarningThreshold,
+	} {
+		if v.GetString(flag) == "" {
+			continue
+		}
+		n, err := v.GetInt64(flag)
+		if err != nil {
+			return err
+		}
+		*value = n
+	}
+	if v.GetString("hard-stop") != "" {
+		q.HardStop = v.GetBool("hard-stop")
+	}
+
+	if err := client.ProjectQuotaUpdate(v.GetString(_ProjectKey), &q); err != nil {
+		return err
+	}
+	fmt.Printf("Quotas of project %s updated\n", v.GetString(_ProjectKey))
+	return nil
+}
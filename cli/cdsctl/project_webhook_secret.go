@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectWebHookSecretCmd = cli.Command{
+	Name:  "webhook-secret",
+	Short: "Manage the secrets used to sign the payloads sent to the webhooks of a CDS project",
+	Long: `When a project has webhook secrets, the payloads sent to its webhooks must be signed with one of them.
+The signature is the HMAC-SHA256 of the payload, sent in the X-Hub-Signature header as sha256=<hex signature>.`,
+}
+
+func projectWebHookSecret() *cobra.Command {
+	return cli.NewCommand(projectWebHookSecretCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectWebHookSecretListCmd, projectWebHookSecretListRun, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(projectWebHookSecretRotateCmd, projectWebHookSecretRotateRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectWebHookSecretDeleteCmd, projectWebHookSecretDeleteRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+type projectWebHookSecretDisplay struct {
+	ID       int64  `cli:"id,key"`
+	Created  string `cli:"created"`
+	ExpireAt string `cli:"expire_at"`
+	Secret   string `cli:"secret"`
+}
+
+func newProjectWebHookSecretDisplay(s sdk.ProjectWebHookSecret) projectWebHookSecretDisplay {
+	d := projectWebHookSecretDisplay{
+		ID:      s.ID,
+		Created: s.Created.Format(time.RFC3339),
+		Secret:  s.Secret,
+	}
+	if s.ExpireAt != nil {
+		d.ExpireAt = s.ExpireAt.Format(time.RFC3339)
+	}
+	return d
+}
+
+var projectWebHookSecretListCmd = cli.Command{
+	Name:  "list",
+	Short: "List the webhook secrets of a CDS project that are not expired",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectWebHookSecretListRun(v cli.Values) (cli.ListResult, error) {
+	secrets, err := client.ProjectWebHookSecretList(v.GetString(_ProjectKey), false)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]projectWebHookSecretDisplay, len(secrets))
+	for i := range secrets {
+		res[i] = newProjectWebHookSecretDisplay(secrets[i])
+	}
+	return cli.AsListResult(res), nil
+}
+
+var projectWebHookSecretRotateCmd = cli.Command{
+	Name:  "rotate",
+	Short: "Generate a new webhook secret for a CDS project",
+	Long: `Generate a new webhook secret for a CDS project, the value of the secret is only displayed by this command.
+The previous secrets are still accepted during the overlap, to let the time to update the senders of the payloads.`,
+	Example: `cdsctl project webhook-secret rotate MYPROJECT --overlap 1h`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "overlap",
+			Usage:   "Delay during which the previous secrets are still accepted",
+			Default: sdk.DefaultWebHookSecretOverlap.String(),
+		},
+	},
+}
+
+func projectWebHookSecretRotateRun(v cli.Values) (interface{}, error) {
+	overlap, err := time.ParseDuration(v.GetString("overlap"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid given overlap %q: %v", v.GetString("overlap"), err)
+	}
+	s, err := client.ProjectWebHookSecretRotate(v.GetString(_ProjectKey), overlap)
+	if err != nil {
+		return nil, err
+	}
+	return newProjectWebHookSecretDisplay(s), nil
+}
+
+var projectWebHookSecretDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete all the webhook secrets of a CDS project, its webhooks don't require signed payloads anymore",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectWebHookSecretDeleteRun(v cli.Values) error {
+	return client.ProjectWebHookSecretDelete(v.GetString(_ProjectKey))
+}
@@ -220,24 +220,30 @@ func findComplete() func(string) []string {
 		if len(words) == 0 {
 			return nil
 		}
-		nav, err := client.Navbar()
+		out, err := cachedCompletionValues("navbar", func() ([]string, error) {
+			nav, err := client.Navbar()
+			if err != nil {
+				return nil, err
+			}
+			out := make([]string, len(nav))
+			for i, p := range nav {
+				switch p.Type {
+				case "project":
+					out[i] = "/project/" + p.Key
+				case "application":
+					out[i] = "/project/" + p.Key + "/application/" + p.ApplicationName
+				case "workflow":
+					out[i] = "/project/" + p.Key + "/workflow/" + p.WorkflowName
+				}
+			}
+			return out, nil
+		})
 		if err != nil {
 			return []string{fmt.Sprintf("Error while getting data: %s\n", err)}
 		}
-		if len(nav) == 0 {
+		if len(out) == 0 {
 			return []string{fmt.Sprintf("no project found")}
 		}
-		out := make([]string, len(nav))
-		for i, p := range nav {
-			switch p.Type {
-			case "project":
-				out[i] = "/project/" + p.Key
-			case "application":
-				out[i] = "/project/" + p.Key + "/application/" + p.ApplicationName
-			case "workflow":
-				out[i] = "/project/" + p.Key + "/workflow/" + p.WorkflowName
-			}
-		}
 		return out
 	}
 }
@@ -30,6 +30,8 @@ func template() *cobra.Command {
 		cli.NewDeleteCommand(templateDeleteCmd, templateDeleteRun, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(templateInstancesCmd, templateInstancesRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(templateDetachCmd, templateDetachRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(templateDriftCmd, templateDriftRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(templateReapplyCmd, templateReapplyRun, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -318,3 +320,73 @@ func templateDetachRun(v cli.Values) error {
 
 	return nil
 }
+
+var templateDriftCmd = cli.Command{
+	Name:    "drift",
+	Short:   "Show the changes made on a workflow since its template was applied",
+	Example: "cdsctl template drift project-key workflow-name",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName, AllowEmpty: true},
+	},
+}
+
+func templateDriftRun(v cli.Values) error {
+	projectKey := v.GetString(_ProjectKey)
+	workflowName := v.GetString(_WorkflowName)
+
+	drift, err := client.WorkflowTemplateInstanceDrift(projectKey, workflowName)
+	if err != nil {
+		return err
+	}
+
+	if !drift.IsDrifted() {
+		fmt.Printf("Workflow %s/%s was not changed since its template was applied\n", projectKey, workflowName)
+		return nil
+	}
+	for _, s := range drift.Workflow.Summary {
+		fmt.Println(s)
+	}
+	for _, p := range drift.Pipelines {
+		fmt.Printf("pipeline %s changed\n", p)
+	}
+
+	return nil
+}
+
+var templateReapplyCmd = cli.Command{
+	Name:  "reapply",
+	Short: "Apply the last version of the template of a workflow",
+	Long: `Apply the last version of the template of a workflow with the same parameters. The changes made on the workflow
+since the template was applied are discarded, unless --keep-changes is given. In this case the changes on the workflow
+are applied again on the generated workflow, and the pipelines that were changed are not overridden.`,
+	Example: "cdsctl template reapply project-key workflow-name --keep-changes",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName, AllowEmpty: true},
+	},
+	Flags: []cli.Flag{
+		{
+			Type:  cli.FlagBool,
+			Name:  "keep-changes",
+			Usage: "Keep the changes made on the workflow since the template was applied",
+		},
+	},
+}
+
+func templateReapplyRun(v cli.Values) error {
+	projectKey := v.GetString(_ProjectKey)
+	workflowName := v.GetString(_WorkflowName)
+
+	msgs, err := client.WorkflowTemplateInstanceReapply(projectKey, workflowName, v.GetBool("keep-changes"))
+	if err != nil {
+		return err
+	}
+	for _, m := range msgs {
+		fmt.Println(m)
+	}
+
+	fmt.Printf("Template successfully applied on workflow %s/%s\n", projectKey, workflowName)
+
+	return nil
+}
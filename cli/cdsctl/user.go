@@ -20,6 +20,7 @@ func usr() *cobra.Command {
 		cli.NewListCommand(userListCmd, userListRun, nil),
 		cli.NewGetCommand(userShowCmd, userShowRun, nil),
 		cli.NewCommand(userFavoriteCmd, userFavoriteRun, nil),
+		userDashboard(),
 	})
 }
 
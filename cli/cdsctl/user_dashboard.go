@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+var userDashboardCmd = cli.Command{
+	Name:  "dashboard",
+	Short: "Manage your personal dashboards",
+}
+
+func userDashboard() *cobra.Command {
+	return cli.NewCommand(userDashboardCmd, nil, []*cobra.Command{
+		cli.NewListCommand(userDashboardListCmd, userDashboardListRun, nil),
+		cli.NewCommand(userDashboardShowCmd, userDashboardShowRun, nil),
+		cli.NewCommand(userDashboardImportCmd, userDashboardImportRun, nil),
+		cli.NewDeleteCommand(userDashboardDeleteCmd, userDashboardDeleteRun, nil),
+	})
+}
+
+var userDashboardListCmd = cli.Command{
+	Name:  "list",
+	Short: "List your dashboards",
+}
+
+func userDashboardListRun(v cli.Values) (cli.ListResult, error) {
+	ds, err := client.UserDashboardList()
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ds), nil
+}
+
+func userDashboardID(v cli.Values) (int64, error) {
+	id, err := strconv.ParseInt(v.GetString("id"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid given dashboard id %s", v.GetString("id"))
+	}
+	return id, nil
+}
+
+var userDashboardShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Display the widgets of a dashboard",
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+}
+
+func userDashboardShowRun(v cli.Values) error {
+	id, err := userDashboardID(v)
+	if err != nil {
+		return err
+	}
+
+	data, err := client.UserDashboardData(id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(" -=-=-=-=- %s -=-=-=-=-\n", data.Dashboard.Name)
+	for _, w := range data.Widgets {
+		title := w.Widget.Title
+		if title == "" {
+			title = w.Widget.Type
+		}
+		fmt.Printf("\n%s\n", title)
+		if w.Error != "" {
+			fmt.Printf("  error: %s\n", w.Error)
+			continue
+		}
+		switch w.Widget.Type {
+		case sdk.DashboardWidgetLatestRuns:
+			printDashboardRuns(w.Runs)
+		case sdk.DashboardWidgetFailingWorkflows:
+			printDashboardRuns(w.FailingWorkflows)
+		case sdk.DashboardWidgetQueueDepth:
+			for _, q := range w.QueueDepth {
+				fmt.Printf("- %s: %d waiting, %d building\n", q.ProjectKey, q.Waiting, q.Building)
+			}
+		}
+	}
+	return nil
+}
+
+func printDashboardRuns(rs []sdk.DashboardRun) {
+	if len(rs) == 0 {
+		fmt.Println("  no run")
+	}
+	for _, r := range rs {
+		branch := ""
+		if r.Branch != "" {
+			branch = " on " + r.Branch
+		}
+		fmt.Printf("- %s/%s #%d%s: %s (%s)\n", r.ProjectKey, r.WorkflowName, r.Number, branch, r.Status, r.Start.Format("2006-01-02 15:04:05"))
+	}
+}
+
+var userDashboardImportCmd = cli.Command{
+	Name:  "import",
+	Short: "Import a dashboard from a yaml file",
+	Long: `
+The file contains the name of the dashboard and its widgets:
+
+	name: my-dashboard
+	widgets:
+	- type: latest_runs
+	  title: Releases
+	  limit: 10
+	  workflows:
+	  - project_key: MY_PROJECT
+	    workflow_name: release
+	- type: queue_depth
+	  projects: [MY_PROJECT]
+	- type: failing_workflows
+
+Without projects, queue_depth and failing_workflows widgets use all your projects.
+With --force an existing dashboard with the same name is updated.
+`,
+	Example: "cdsctl user dashboard import dashboard.yml",
+	Args: []cli.Arg{
+		{Name: "filename"},
+	},
+	Flags: []cli.Flag{
+		{Name: "force", Type: cli.FlagBool},
+	},
+}
+
+func userDashboardImportRun(v cli.Values) error {
+	btes, err := ioutil.ReadFile(v.GetString("filename"))
+	if err != nil {
+		return fmt.Errorf("unable to read file %s: %v", v.GetString("filename"), err)
+	}
+
+	var d sdk.Dashboard
+	if err := exportentities.Unmarshal(btes, exportentities.FormatYAML, &d); err != nil {
+		return err
+	}
+
+	if v.GetBool("force") {
+		ds, err := client.UserDashboardList()
+		if err != nil {
+			return err
+		}
+		for i := range ds {
+			if ds[i].Name == d.Name {
+				d.ID = ds[i].ID
+				return client.UserDashboardUpdate(&d)
+			}
+		}
+	}
+	return client.UserDashboardCreate(&d)
+}
+
+var userDashboardDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete a dashboard",
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+}
+
+func userDashboardDeleteRun(v cli.Values) error {
+	id, err := userDashboardID(v)
+	if err != nil {
+		return err
+	}
+	return client.UserDashboardDelete(id)
+}
@@ -20,6 +20,7 @@ func worker() *cobra.Command {
 	return cli.NewCommand(workerCmd, nil, []*cobra.Command{
 		cli.NewListCommand(workerListCmd, workerListRun, nil),
 		cli.NewCommand(workerDisableCmd, workerDisableRun, nil),
+		cli.NewCommand(workerDrainCmd, workerDrainRun, nil),
 		workerModel(),
 	})
 }
@@ -82,3 +83,48 @@ func workerDisableRun(v cli.Values) error {
 
 	return nil
 }
+
+var workerDrainCmd = cli.Command{
+	Name:  "drain",
+	Short: "Drain CDS workers",
+	Long: `Drain one on more CDS worker by their names. A drained worker finishes its current job then stops without
+taking a new one.
+
+For example if your want to drain all CDS workers before an upgrade you can run:
+
+$ cdsctl worker drain $(cdsctl worker list)`,
+	VariadicArgs: cli.Arg{
+		Name: "name",
+	},
+}
+
+func workerDrainRun(v cli.Values) error {
+	names := v.GetStringSlice("name")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	workers, err := client.WorkerList(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range names {
+		var found bool
+		for _, w := range workers {
+			if w.ID == n || strings.ToLower(w.Name) == strings.ToLower(n) {
+				found = true
+				fmt.Printf("Draining worker %s [status %s]... ", cli.Magenta(w.Name), w.Status)
+				if err := client.WorkerDrain(context.Background(), w.ID); err != nil {
+					fmt.Printf("Error draining worker %s : %s\n", w.ID, err)
+				} else {
+					fmt.Printf("Done\n")
+				}
+			}
+		}
+		if !found {
+			fmt.Printf("Worker %s not found\n", n)
+		}
+	}
+
+	return nil
+}
@@ -22,6 +22,9 @@ func workerModel() *cobra.Command {
 		cli.NewListCommand(workerModelListCmd, workerModelListRun, nil),
 		cli.NewGetCommand(workerModelShowCmd, workerModelShowRun, nil, withAllCommandModifiers()...),
 		cli.NewDeleteCommand(workerModelDeleteCmd, workerModelDeleteRun, nil),
+		cli.NewGetCommand(workerModelHealthCmd, workerModelHealthRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workerModelErrorsCmd, workerModelErrorsRun, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(workerModelReleaseCmd, workerModelReleaseRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workerModelImportCmd, workerModelImportRun, nil),
 		cli.NewCommand(workerModelExportCmd, workerModelExportRun, nil, withAllCommandModifiers()...),
 	})
@@ -38,9 +41,13 @@ var workerModelListCmd = cli.Command{
 		},
 		{
 			Name:      "state",
-			Usage:     "Use this flag to filter worker model by his state (disabled|error|register|deprecated)",
+			Usage:     "Use this flag to filter worker model by his state (disabled|error|register|deprecated|quarantined)",
 			ShortHand: "s",
 		},
+		{
+			Name:  "os-architecture",
+			Usage: "Use this flag to filter worker model list by its registered os and architecture (ex: linux/arm64)",
+		},
 	},
 }
 
@@ -50,13 +57,17 @@ func workerModelListRun(v cli.Values) (cli.ListResult, error) {
 	binaryFlag := v.GetString("binary")
 	stateFlag := v.GetString("state")
 
+	osArchFlag := v.GetString("os-architecture")
+
 	if binaryFlag != "" {
 		workerModels, err = client.WorkerModels(&cdsclient.WorkerModelFilter{
 			Binary: binaryFlag,
+			OSArch: osArchFlag,
 		})
 	} else {
 		workerModels, err = client.WorkerModels(&cdsclient.WorkerModelFilter{
-			State: stateFlag,
+			State:  stateFlag,
+			OSArch: osArchFlag,
 		})
 	}
 
@@ -137,12 +148,12 @@ var workerModelShowCmd = cli.Command{
 	Short:   "Show a Worker Model",
 	Example: `cdsctl worker model show myGroup/myModel`,
 	Args: []cli.Arg{
-		{Name: "worker-model-path"},
+		{Name: _WorkerModelPath},
 	},
 }
 
 func workerModelShowRun(v cli.Values) (interface{}, error) {
-	groupName, modelName, err := cli.ParsePath(v.GetString("worker-model-path"))
+	groupName, modelName, err := cli.ParsePath(v.GetString(_WorkerModelPath))
 	if err != nil {
 		return nil, err
 	}
@@ -160,12 +171,12 @@ var workerModelDeleteCmd = cli.Command{
 	Short:   "Delete a CDS worker model",
 	Example: `cdsctl worker model delete shared.infra/myModel`,
 	Args: []cli.Arg{
-		{Name: "worker-model-path"},
+		{Name: _WorkerModelPath},
 	},
 }
 
 func workerModelDeleteRun(v cli.Values) error {
-	groupName, modelName, err := cli.ParsePath(v.GetString("worker-model-path"))
+	groupName, modelName, err := cli.ParsePath(v.GetString(_WorkerModelPath))
 	if err != nil {
 		return err
 	}
@@ -180,12 +191,81 @@ func workerModelDeleteRun(v cli.Values) error {
 	return nil
 }
 
+var workerModelHealthCmd = cli.Command{
+	Name:    "health",
+	Short:   "Show the health of a Worker Model",
+	Example: `cdsctl worker model health myGroup/myModel`,
+	Args: []cli.Arg{
+		{Name: _WorkerModelPath},
+	},
+}
+
+func workerModelHealthRun(v cli.Values) (interface{}, error) {
+	groupName, modelName, err := cli.ParsePath(v.GetString(_WorkerModelPath))
+	if err != nil {
+		return nil, err
+	}
+
+	health, err := client.WorkerModelHealth(groupName, modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	return health, nil
+}
+
+var workerModelErrorsCmd = cli.Command{
+	Name:    "errors",
+	Short:   "List the last spawn errors of a Worker Model",
+	Example: `cdsctl worker model errors myGroup/myModel`,
+	Args: []cli.Arg{
+		{Name: _WorkerModelPath},
+	},
+}
+
+func workerModelErrorsRun(v cli.Values) (cli.ListResult, error) {
+	groupName, modelName, err := cli.ParsePath(v.GetString(_WorkerModelPath))
+	if err != nil {
+		return nil, err
+	}
+
+	health, err := client.WorkerModelHealth(groupName, modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.AsListResult(health.LastErrors), nil
+}
+
+var workerModelReleaseCmd = cli.Command{
+	Name:    "release",
+	Short:   "Release a Worker Model from quarantine",
+	Example: `cdsctl worker model release myGroup/myModel`,
+	Args: []cli.Arg{
+		{Name: _WorkerModelPath},
+	},
+}
+
+func workerModelReleaseRun(v cli.Values) (interface{}, error) {
+	groupName, modelName, err := cli.ParsePath(v.GetString(_WorkerModelPath))
+	if err != nil {
+		return nil, err
+	}
+
+	wm, err := client.WorkerModelQuarantineRelease(groupName, modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	return wm, nil
+}
+
 var workerModelExportCmd = cli.Command{
 	Name:    "export",
 	Short:   "Export a worker model",
 	Example: `cdsctl worker model export myGroup/myModel`,
 	Args: []cli.Arg{
-		{Name: "worker-model-path"},
+		{Name: _WorkerModelPath},
 	},
 	Flags: []cli.Flag{
 		{
@@ -197,7 +277,7 @@ var workerModelExportCmd = cli.Command{
 }
 
 func workerModelExportRun(c cli.Values) error {
-	groupName, modelName, err := cli.ParsePath(c.GetString("worker-model-path"))
+	groupName, modelName, err := cli.ParsePath(c.GetString(_WorkerModelPath))
 	if err != nil {
 		return err
 	}
@@ -24,15 +24,27 @@ func workflow() *cobra.Command {
 		cli.NewGetCommand(workflowShowCmd, workflowShowRun, nil, withAllCommandModifiers()...),
 		cli.NewGetCommand(workflowStatusCmd, workflowStatusRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowRunManualCmd, workflowRunManualRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowExplainCmd, workflowExplainRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowStopCmd, workflowStopRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowDebugCmd, workflowDebugRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowRunExportCmd, workflowRunExportRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowExportCmd, workflowExportRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowImportCmd, workflowImportRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowImportCheckCmd, workflowImportCheckRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowPullCmd, workflowPullRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowPushCmd, workflowPushRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowLintCmd, workflowLintRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowDiffCmd, workflowDiffRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowFavoriteCmd, workflowFavoriteRun, nil, withAllCommandModifiers()...),
 		cli.NewGetCommand(workflowTransformAsCodeCmd, workflowTransformAsCodeRun, nil, withAllCommandModifiers()...),
 		workflowLabel(),
 		workflowArtifact(),
+		workflowAnnotation(),
+		workflowResult(),
+		workflowVersion(),
+		workflowWebhook(),
+		workflowRuns(),
+		workflowCost(),
 		workflowLog(),
 		workflowAdvanced(),
 	})
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowAnnotationCmd = cli.Command{
+	Name:    "annotation",
+	Aliases: []string{"annotations"},
+	Short:   "Manage Workflow Run Annotation",
+}
+
+func workflowAnnotation() *cobra.Command {
+	return cli.NewCommand(workflowAnnotationCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowAnnotationListCmd, workflowAnnotationListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowAnnotationAddCmd, workflowAnnotationAddRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowAnnotationDeleteCmd, workflowAnnotationDeleteRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowAnnotationListCmd = cli.Command{
+	Name:  "list",
+	Short: "List annotations of one Workflow Run",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+}
+
+func workflowAnnotationListRun(v cli.Values) (cli.ListResult, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+	annotations, err := client.WorkflowRunAnnotationList(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(annotations), nil
+}
+
+var workflowAnnotationAddCmd = cli.Command{
+	Name:  "add",
+	Short: "Add an annotation on one Workflow Run, an existing annotation with the same key is replaced",
+	Example: `cdsctl workflow annotation add MYPROJECT my-workflow 12 "deployed version" 1.2.3
+cdsctl workflow annotation add MYPROJECT my-workflow 12 ticket https://jira.example.com/browse/CDS-1 --type link`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+		{Name: "key"},
+		{Name: "value"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "type",
+			Usage:   "Type of the annotation: link, value or markdown",
+			Default: sdk.WorkflowRunAnnotationTypeValue,
+		},
+	},
+}
+
+func workflowAnnotationAddRun(v cli.Values) error {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("number parameter have to be an integer")
+	}
+	a := sdk.WorkflowRunAnnotation{
+		Type:  v.GetString("type"),
+		Key:   v.GetString("key"),
+		Value: v.GetString("value"),
+	}
+	if err := a.IsValid(); err != nil {
+		return err
+	}
+	return client.WorkflowRunAnnotationAdd(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, a)
+}
+
+var workflowAnnotationDeleteCmd = cli.Command{
+	Name:    "delete",
+	Aliases: []string{"rm"},
+	Short:   "Delete an annotation from one Workflow Run",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+		{Name: "key"},
+	},
+}
+
+func workflowAnnotationDeleteRun(v cli.Values) error {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("number parameter have to be an integer")
+	}
+	return client.WorkflowRunAnnotationDelete(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, v.GetString("key"))
+}
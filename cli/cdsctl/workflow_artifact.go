@@ -21,6 +21,8 @@ func workflowArtifact() *cobra.Command {
 	return cli.NewCommand(workflowArtifactCmd, nil, []*cobra.Command{
 		cli.NewListCommand(workflowArtifactListCmd, workflowArtifactListRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowArtifactDownloadCmd, workflowArtifactDownloadRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowArtifactPinCmd, workflowArtifactPinRun(true), nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowArtifactUnpinCmd, workflowArtifactPinRun(false), nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -168,3 +170,58 @@ func workflowArtifactDownloadRun(v cli.Values) error {
 	}
 	return nil
 }
+
+var workflowArtifactPinCmd = cli.Command{
+	Name:  "pin",
+	Short: "Pin an artifact of one Workflow Run, it will be kept indefinitely",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+		{Name: "artefact-name"},
+	},
+}
+
+var workflowArtifactUnpinCmd = cli.Command{
+	Name:  "unpin",
+	Short: "Unpin an artifact of one Workflow Run, it will be deleted with the artifact retention of the project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+		{Name: "artefact-name"},
+	},
+}
+
+func workflowArtifactPinRun(pinned bool) func(v cli.Values) error {
+	return func(v cli.Values) error {
+		number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+		if err != nil {
+			return fmt.Errorf("number parameter have to be an integer")
+		}
+
+		artifacts, err := client.WorkflowRunArtifacts(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+		if err != nil {
+			return err
+		}
+
+		var found bool
+		for _, a := range artifacts {
+			if a.Name != v.GetString("artefact-name") {
+				continue
+			}
+			found = true
+			if _, err := client.WorkflowArtifactPin(v.GetString(_ProjectKey), v.GetString(_WorkflowName), a.ID, pinned); err != nil {
+				return err
+			}
+		}
+		if !found {
+			return fmt.Errorf("artifact %s not found on workflow run %d", v.GetString("artefact-name"), number)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowCostCmd = cli.Command{
+	Name:  "cost",
+	Short: "Show the cost of the runs of a CDS workflow",
+}
+
+func workflowCost() *cobra.Command {
+	return cli.NewCommand(workflowCostCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowCostShowCmd, workflowCostShowRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowCostExportCmd, workflowCostExportRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowCostShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show the cost of the runs of a CDS workflow by run, or of the jobs of a run",
+	Example: `cdsctl workflow cost show MYPROJECT my-workflow --from 2020-01-01T00:00:00Z
+cdsctl workflow cost show MYPROJECT my-workflow 42`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "number"},
+	},
+	Flags: costFlags,
+}
+
+func workflowCostReport(v cli.Values) (sdk.WorkflowCostReport, error) {
+	var number int64
+	if v.GetString("number") != "" {
+		var err error
+		number, err = strconv.ParseInt(v.GetString("number"), 10, 64)
+		if err != nil {
+			return sdk.WorkflowCostReport{}, fmt.Errorf("number parameter have to be an integer")
+		}
+	}
+	return client.WorkflowCostReport(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, costModifiers(v)...)
+}
+
+func workflowCostShowRun(v cli.Values) (cli.ListResult, error) {
+	r, err := workflowCostReport(v)
+	if err != nil {
+		return nil, err
+	}
+	return costReportLines(r), nil
+}
+
+var workflowCostExportCmd = cli.Command{
+	Name:    "export",
+	Short:   "Export the cost of the runs of a CDS workflow, or of the jobs of a run, as CSV",
+	Example: `cdsctl workflow cost export MYPROJECT my-workflow 42 > cost.csv`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "number"},
+	},
+	Flags: costFlags,
+}
+
+func workflowCostExportRun(v cli.Values) error {
+	r, err := workflowCostReport(v)
+	if err != nil {
+		return err
+	}
+	return writeCostReportCSV(r)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowDebugCmd = cli.Command{
+	Name:  "debug",
+	Short: "Open a shell on the worker of a failed job held for debugging",
+	Long: `Open a shell in the workspace of a failed job held for debugging. A job is held on its worker when it fails if
+the parameter cds.debug.hold is set to a number of minutes, ex: run the workflow with the payload {"cds.debug.hold": "15"}.
+
+The job is given by its ID or by its name. The shell ends when it exits, when the hold expires or when the job is stopped.`,
+	Example: `cdsctl workflow debug MYPROJECT my-workflow 12 build`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "run-number"},
+		{Name: "job"},
+	},
+}
+
+func workflowDebugRun(v cli.Values) error {
+	projectKey := v.GetString(_ProjectKey)
+	workflowName := v.GetString(_WorkflowName)
+	number, err := v.GetInt64("run-number")
+	if err != nil {
+		return err
+	}
+
+	wr, err := client.WorkflowRunGet(projectKey, workflowName, number)
+	if err != nil {
+		return err
+	}
+
+	// Find the building job with given ID or name
+	job := v.GetString("job")
+	jobID, _ := strconv.ParseInt(job, 10, 64)
+	var nodeRunID, runJobID int64
+	for _, nodeRuns := range wr.WorkflowNodeRuns {
+		for _, nodeRun := range nodeRuns {
+			for _, s := range nodeRun.Stages {
+				for _, rj := range s.RunJobs {
+					if rj.Status != sdk.StatusBuilding || (rj.ID != jobID && rj.Job.Action.Name != job) {
+						continue
+					}
+					nodeRunID, runJobID = nodeRun.ID, rj.ID
+				}
+			}
+		}
+	}
+	if runJobID == 0 {
+		return fmt.Errorf("no job %s in progress on workflow run %d", job, number)
+	}
+
+	conn, err := client.WorkflowNodeRunJobDebug(projectKey, workflowName, number, nodeRunID, runJobID)
+	if err != nil {
+		return err
+	}
+	defer conn.Close() // nolint
+	fmt.Fprintf(os.Stderr, "Connected to job %d, type exit to end the shell\n", runJobID)
+
+	// Send the standard input to the shell
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				msg := sdk.WorkflowNodeJobRunDebugMessage{Type: sdk.WorkflowNodeJobRunDebugMessageInput, Data: buf[:n]}
+				if err := websocket.JSON.Send(conn, msg); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		var msg sdk.WorkflowNodeJobRunDebugMessage
+		if err := websocket.JSON.Receive(conn, &msg); err != nil {
+			return fmt.Errorf("connection closed: %v", err)
+		}
+		switch msg.Type {
+		case sdk.WorkflowNodeJobRunDebugMessageOutput:
+			os.Stdout.Write(msg.Data) // nolint
+		case sdk.WorkflowNodeJobRunDebugMessageExit:
+			fmt.Fprintln(os.Stderr, "Shell exited")
+			return nil
+		}
+	}
+}
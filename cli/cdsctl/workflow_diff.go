@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowDiffCmd = cli.Command{
+	Name:  "diff",
+	Short: "Show changes between two versions of a workflow",
+	Long: `
+Compare the stored workflow with a workflow file, like before a push of a workflow as code:
+
+	cdsctl workflow diff MY-PROJECT my-workflow --file .cds/my-workflow.yml
+
+Compare a previous version of the workflow with the stored one, or with a file. The version is the state of the workflow after the change of the given audit:
+
+	cdsctl workflow diff MY-PROJECT my-workflow --audit-id 42
+
+Added and removed nodes, changed conditions and integrations are listed. Use --json to get the changes as a JSON patch.
+	`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "file",
+			Usage: "Path of a workflow file (yaml or json)",
+		},
+		{
+			Name:  "audit-id",
+			Usage: "ID of an audit of the workflow",
+		},
+		{
+			Type:    cli.FlagBool,
+			Name:    "json",
+			Usage:   "Display the changes as a JSON patch",
+			Default: "false",
+		},
+	},
+}
+
+func workflowDiffRun(c cli.Values) error {
+	var req sdk.WorkflowDiffRequest
+	if file := c.GetString("file"); file != "" {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("unable to read file %s: %v", file, err)
+		}
+		req.Workflow = string(content)
+	}
+	auditID, err := c.GetInt64("audit-id")
+	if err != nil {
+		return err
+	}
+	req.AuditID = auditID
+	if req.Workflow == "" && req.AuditID == 0 {
+		return fmt.Errorf("a file or an audit id should be given")
+	}
+
+	diff, err := client.WorkflowDiff(c.GetString(_ProjectKey), c.GetString(_WorkflowName), req)
+	if err != nil {
+		return err
+	}
+
+	if c.GetBool("json") {
+		buf, err := json.MarshalIndent(diff.Patch, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(buf))
+		return nil
+	}
+
+	if len(diff.Summary) == 0 {
+		fmt.Println("No difference found")
+		return nil
+	}
+	for _, line := range diff.Summary {
+		fmt.Printf("%s %s\n", cli.Yellow("~"), line)
+	}
+	return nil
+}
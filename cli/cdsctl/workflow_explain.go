@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowExplainCmd = cli.Command{
+	Name:  "explain",
+	Short: "Explain which nodes of a CDS workflow would run, without running it",
+	Long: `Explain which nodes of a CDS workflow would run for a manual run or a hook event, and why.
+
+The conditions of each node are evaluated with the given payload and parameters, considering that the parents of the node are successful.
+Nothing is created on CDS.`,
+	Example: `cdsctl workflow explain MYPROJECT my-workflow -d '{"git.branch": "master"}'
+cdsctl workflow explain MYPROJECT my-workflow --hook 6f1a3c2e-... -d '{"git.branch": "feat/foo", "git.author": "bob"}'`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:      "data",
+			ShortHand: "d",
+			Usage:     "Payload data of the manual run or of the hook event",
+			IsValid: func(s string) bool {
+				if strings.TrimSpace(s) == "" {
+					return true
+				}
+				data := map[string]interface{}{}
+				return json.Unmarshal([]byte(s), &data) == nil
+			},
+		},
+		{
+			Name:      "parameter",
+			ShortHand: "p",
+			Usage:     "Pipeline parameter of the manual run",
+			Type:      cli.FlagSlice,
+		},
+		{
+			Name:  "hook",
+			Usage: "UUID of the hook that receives the event, explain a manual run if not set",
+		},
+	},
+}
+
+type workflowExplainDisplay struct {
+	Node       string `cli:"node,key"`
+	Type       string `cli:"type"`
+	Run        bool   `cli:"run"`
+	Reason     string `cli:"reason"`
+	Conditions string `cli:"conditions"`
+}
+
+// workflowExplainConditions returns a one line summary of the conditions evaluation, with the value of the variable
+// for each failed condition.
+func workflowExplainConditions(conditions []sdk.WorkflowConditionResult, luaScript string) string {
+	if luaScript != "" {
+		return "lua: " + luaScript
+	}
+	res := make([]string, len(conditions))
+	for i, c := range conditions {
+		res[i] = fmt.Sprintf("%s %s %s", c.Variable, c.Operator, c.ResolvedValue)
+		if !c.OK {
+			res[i] += fmt.Sprintf(" (got %q)", c.VariableValue)
+		}
+	}
+	return strings.Join(res, ", ")
+}
+
+func workflowExplainRun(v cli.Values) (cli.ListResult, error) {
+	data := map[string]interface{}{}
+	if s := strings.TrimSpace(v.GetString("data")); s != "" {
+		if err := json.Unmarshal([]byte(s), &data); err != nil {
+			return nil, fmt.Errorf("Error payload isn't a valid json")
+		}
+	}
+
+	var opts sdk.WorkflowRunPostHandlerOption
+	if uuid := v.GetString("hook"); uuid != "" {
+		// Hook events contain a flat payload
+		payload := make(map[string]string, len(data))
+		for k, v := range data {
+			payload[k] = fmt.Sprintf("%v", v)
+		}
+		opts.Hook = &sdk.WorkflowNodeRunHookEvent{WorkflowNodeHookUUID: uuid, Payload: payload}
+	} else {
+		manual := sdk.WorkflowNodeRunManual{}
+		if len(data) > 0 {
+			manual.Payload = data
+		}
+		for _, p := range v.GetStringSlice("parameter") {
+			if p == "" {
+				continue
+			}
+			splittedParam := strings.SplitN(p, "=", 2)
+			if len(splittedParam) != 2 {
+				return nil, fmt.Errorf("invalid given parameter %q, expected name=value", p)
+			}
+			sdk.AddParameter(&manual.PipelineParameters, splittedParam[0], sdk.StringParameter, splittedParam[1])
+		}
+		opts.Manual = &manual
+	}
+
+	explain, err := client.WorkflowRunExplain(v.GetString(_ProjectKey), v.GetString(_WorkflowName), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]workflowExplainDisplay, 0, len(explain.Nodes)+1)
+	if explain.Hook != nil {
+		res = append(res, workflowExplainDisplay{
+			Node:       "hook " + explain.Hook.UUID,
+			Type:       explain.Hook.Model,
+			Run:        explain.Hook.Run,
+			Reason:     explain.Hook.Reason,
+			Conditions: workflowExplainConditions(explain.Hook.Conditions, explain.Hook.LuaScript),
+		})
+	}
+	for _, n := range explain.Nodes {
+		res = append(res, workflowExplainDisplay{
+			Node:       n.Name,
+			Type:       n.Type,
+			Run:        n.Run,
+			Reason:     n.Reason,
+			Conditions: workflowExplainConditions(n.Conditions, n.LuaScript),
+		})
+	}
+	return cli.AsListResult(res), nil
+}
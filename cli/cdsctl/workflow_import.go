@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ovh/cds/cli"
 	"github.com/ovh/cds/sdk/cdsclient"
@@ -54,3 +55,53 @@ func workflowImportRun(c cli.Values) error {
 	}
 	return err
 }
+
+var workflowImportCheckCmd = cli.Command{
+	Name:  "import-check",
+	Short: "Check the integrations used by a workflow before importing it",
+	Long: `
+Check that the project integrations used by the nodes and the hooks of a workflow exist with a model compatible with their usage, and that you are allowed to use them.
+For a missing integration, the models that can be used to create it are listed.
+
+	`,
+	Example: `cdsctl workflow import-check MYPROJECT my-workflow.yml`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "path"},
+	},
+}
+
+type workflowImportCheckDisplay struct {
+	Name           string `cli:"name,key"`
+	Status         string `cli:"status"`
+	Model          string `cli:"model"`
+	RequiredModels string `cli:"required_models"`
+	Usages         string `cli:"usages"`
+}
+
+func workflowImportCheckRun(c cli.Values) (cli.ListResult, error) {
+	contentFile, format, err := exportentities.OpenPath(c.GetString("path"))
+	if err != nil {
+		return nil, err
+	}
+	defer contentFile.Close() //nolint
+
+	report, err := client.WorkflowImportIntegrationsCheck(c.GetString(_ProjectKey), contentFile, cdsclient.ContentType(format.ContentType()))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]workflowImportCheckDisplay, len(report.Integrations))
+	for i, ic := range report.Integrations {
+		res[i] = workflowImportCheckDisplay{
+			Name:           ic.Name,
+			Status:         ic.Status,
+			Model:          ic.Model,
+			RequiredModels: strings.Join(ic.RequiredModels, ","),
+			Usages:         strings.Join(ic.Usages, ","),
+		}
+	}
+	return cli.AsListResult(res), nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+var workflowLintCmd = cli.Command{
+	Name:  "lint",
+	Short: "Lint a workflow",
+	Long: `
+Check a workflow and its dependencies (pipelines, applications, environments) against a project before pushing them.
+Unknown requirements, undefined variables, deprecated actions and unreachable nodes are reported with a suggested fix.
+
+	cdsctl workflow lint tests.pip.yml build.pip.yml myWorkflow.yml
+
+The command fails if an error is found.
+	`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	VariadicArgs: cli.Arg{
+		Name: "yaml-file",
+	},
+}
+
+func workflowLintRun(c cli.Values) error {
+	contents, err := workflowReadFiles(strings.Split(c.GetString("yaml-file"), ","))
+	if err != nil {
+		return err
+	}
+
+	// Fragments given with the workflow files are included or extended then removed
+	files, err := exportentities.ResolveIncludes(contents, nil)
+	if err != nil {
+		return err
+	}
+
+	diagnostics, err := client.ProjectLint(c.GetString(_ProjectKey), files)
+	if err != nil {
+		return err
+	}
+
+	var nbErrors int
+	for _, d := range diagnostics {
+		severity := d.Severity
+		switch d.Severity {
+		case sdk.LintSeverityError:
+			nbErrors++
+			severity = cli.Red(d.Severity)
+		case sdk.LintSeverityWarning:
+			severity = cli.Yellow(d.Severity)
+		}
+
+		location := d.File
+		if d.Path != "" {
+			location += ":" + d.Path
+		}
+		fmt.Printf("%s: %s: %s\n", cli.Magenta(location), severity, d.Message)
+		if d.Fix != "" {
+			fmt.Printf("\t%s\n", d.Fix)
+		}
+	}
+
+	if nbErrors > 0 {
+		return fmt.Errorf("%d error(s) found", nbErrors)
+	}
+	fmt.Println("No error found")
+	return nil
+}
@@ -3,8 +3,10 @@ package main
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -39,6 +41,7 @@ func workflowLog() *cobra.Command {
 	return cli.NewCommand(workflowLogCmd, nil, []*cobra.Command{
 		cli.NewCommand(workflowLogListCmd, workflowLogListRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowLogDownloadCmd, workflowLogDownloadRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowLogGrepCmd, workflowLogGrepRun, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -260,3 +263,142 @@ func workflowLogDownloadRun(v cli.Values) error {
 	}
 	return nil
 }
+
+var workflowLogGrepCmd = cli.Command{
+	Name:  "grep",
+	Short: "Filter the logs of the jobs of a workflow run on the server.",
+	Long: `Filter the logs of the jobs of a workflow run on the server, only matching lines are downloaded.
+
+	# print the lines of the latest run matching a regex
+	$ cdsctl workflow logs grep KEY WF --regex="timeout|refused"
+
+	# print the warnings and errors of job MyJob on run number 1
+	$ cdsctl workflow logs grep KEY WF 1 --pattern="MyJob" --level=WARN
+
+	# download the gzipped logs of each job, written during a time range
+	$ cdsctl workflow logs grep KEY WF 1 --from=2020-04-01T10:00:00Z --to=2020-04-01T11:00:00Z --gzip
+
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	OptionalArgs: []cli.Arg{
+		{
+			Name: "run-number",
+			IsValid: func(s string) bool {
+				match, _ := regexp.MatchString(`[0-9]?`, s)
+				return match
+			},
+			Weight: 1,
+		},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "pattern",
+			Usage: "Filter on job log filename",
+		},
+		{
+			Name:  "regex",
+			Usage: "Only keep the lines matching this regex",
+		},
+		{
+			Name:  "level",
+			Usage: "Only keep the lines with this level or higher: DEBUG, INFO, WARN or ERROR",
+		},
+		{
+			Name:  "from",
+			Usage: "Only keep the steps that ran after this RFC3339 date",
+		},
+		{
+			Name:  "to",
+			Usage: "Only keep the steps that ran before this RFC3339 date",
+		},
+		{
+			Name:  "gzip",
+			Usage: "Download a gzipped file for each job instead of printing the lines",
+			Type:  cli.FlagBool,
+		},
+	},
+}
+
+func workflowLogGrepRun(v cli.Values) error {
+	filter := sdk.JobLogFilter{
+		Regex: v.GetString("regex"),
+		Level: v.GetString("level"),
+	}
+	for _, f := range []struct {
+		name string
+		dest **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		if v.GetString(f.name) == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v.GetString(f.name))
+		if err != nil {
+			return fmt.Errorf("invalid %s date %s: %v", f.name, v.GetString(f.name), err)
+		}
+		*f.dest = &t
+	}
+	if err := filter.Compile(); err != nil {
+		return err
+	}
+
+	runNumber, err := workflowLogSearchNumber(v)
+	if err != nil {
+		return err
+	}
+
+	wr, err := client.WorkflowRunGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber)
+	if err != nil {
+		return err
+	}
+
+	var reg *regexp.Regexp
+	if v.GetString("pattern") != "" {
+		reg, err = regexp.Compile(v.GetString("pattern"))
+		if err != nil {
+			return fmt.Errorf("Invalid pattern %s: %v", v.GetString("pattern"), err)
+		}
+	}
+
+	// Logs are filtered by job, so only the first step of each job is kept
+	jobs := make(map[int64]struct{})
+	for _, log := range workflowLogProcess(wr) {
+		if _, ok := jobs[log.jobID]; ok {
+			continue
+		}
+		if reg != nil && !reg.MatchString(log.getFilename()) {
+			continue
+		}
+		jobs[log.jobID] = struct{}{}
+		name := strings.TrimSuffix(log.getFilename(), fmt.Sprintf("-step.%d.log", log.stepOrder))
+
+		if v.GetBool("gzip") {
+			filename := name + ".log.gz"
+			f, err := os.Create(filename)
+			if err != nil {
+				return err
+			}
+			if err := client.WorkflowNodeRunJobLogsDownload(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, log.runID, log.jobID, filter, true, f); err != nil {
+				f.Close() // nolint
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			fmt.Printf("file %s created\n", filename)
+			continue
+		}
+
+		fmt.Printf("==> %s <==\n", name)
+		if err := client.WorkflowNodeRunJobLogsDownload(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, log.runID, log.jobID, filter, false, os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	if len(jobs) == 0 {
+		return fmt.Errorf("No job found")
+	}
+	return nil
+}
@@ -19,7 +19,7 @@ var workflowPullCmd = cli.Command{
 		{Name: _ProjectKey},
 		{Name: _WorkflowName},
 	},
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		{
 			Name:      "output-dir",
 			ShortHand: "d",
@@ -44,7 +44,7 @@ var workflowPullCmd = cli.Command{
 			Usage:   "If true, do not output filename created",
 			Default: "false",
 		},
-	},
+	}, exportSealFlags...),
 }
 
 func workflowPullRun(c cli.Values) error {
@@ -56,7 +56,10 @@ func workflowPullRun(c cli.Values) error {
 		return fmt.Errorf("Unable to create directory %s: %v", c.GetString("output-dir"), err)
 	}
 
-	var mods []cdsclient.RequestModifier
+	mods, err := exportSealModifiers(c)
+	if err != nil {
+		return err
+	}
 	if c.GetBool("with-permissions") {
 		mods = append(mods, cdsclient.WithPermissions())
 	}
@@ -8,9 +8,11 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk/exportentities"
 )
 
 var workflowPushCmd = cli.Command{
@@ -23,6 +25,10 @@ For example if you have a workflow with pipelines build and tests you can push y
 
 	cdsctl workflow push tests.pip.yml build.pip.yml myWorkflow.yml
 
+Fragments used by include or extends directives should be given with the workflow files, for example
+
+	cdsctl workflow push build.pip.yml myWorkflow.yml base.fragment.yml
+
 	`,
 	Ctx: []cli.Arg{
 		{Name: _ProjectKey},
@@ -76,7 +82,24 @@ func workflowPushRun(c cli.Values) error {
 		return fmt.Errorf("wrong usage: you should specify your workflow YAML files. See %s workflow push --help for more details", os.Args[0])
 	}
 
-	if err := workflowFilesToTarWriter(filesToRead, buf); err != nil {
+	contents, err := workflowReadFiles(filesToRead)
+	if err != nil {
+		return err
+	}
+
+	// Fragments given with the workflow files are included or extended then removed
+	resolvedFiles, err := exportentities.ResolveIncludes(contents, nil)
+	if err != nil {
+		return err
+	}
+	resolved := len(resolvedFiles) != len(contents)
+	for name, content := range resolvedFiles {
+		if !bytes.Equal(content, contents[name]) {
+			resolved = true
+		}
+	}
+
+	if err := workflowFilesContentToTarWriter(resolvedFiles, buf); err != nil {
 		return err
 	}
 
@@ -100,19 +123,47 @@ func workflowPushRun(c cli.Values) error {
 		return nil
 	}
 
+	// Do not override files that include fragments with their flattened content
+	if resolved {
+		fmt.Println("Workflow files include fragments, they will not be updated")
+		return nil
+	}
+
 	return workflowTarReaderToFiles(c, dir, tr)
 }
 
-func workflowFilesToTarWriter(files []string, buf io.Writer) error {
-	tw := tar.NewWriter(buf)
-
-	// add some files to the archive
+func workflowReadFiles(files []string) (map[string][]byte, error) {
+	res := make(map[string][]byte, len(files))
 	for _, file := range files {
 		filBuf, err := ioutil.ReadFile(file)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		res[filepath.ToSlash(file)] = filBuf
+	}
+	return res, nil
+}
+
+func workflowFilesToTarWriter(files []string, buf io.Writer) error {
+	contents, err := workflowReadFiles(files)
+	if err != nil {
+		return err
+	}
+	return workflowFilesContentToTarWriter(contents, buf)
+}
+
+func workflowFilesContentToTarWriter(files map[string][]byte, buf io.Writer) error {
+	tw := tar.NewWriter(buf)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
+	// add some files to the archive
+	for _, file := range names {
+		filBuf := files[file]
 		hdr := &tar.Header{
 			Name: filepath.Base(file),
 			Mode: 0600,
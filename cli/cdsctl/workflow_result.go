@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowResultCmd = cli.Command{
+	Name:    "result",
+	Aliases: []string{"results"},
+	Short:   "Manage Workflow Run Result",
+}
+
+func workflowResult() *cobra.Command {
+	return cli.NewCommand(workflowResultCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowResultListCmd, workflowResultListRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowResultReleaseCmd, workflowResultReleaseRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowResultListCmd = cli.Command{
+	Name:  "list",
+	Short: "List results of one Workflow Run",
+	Example: `cdsctl workflow result list MYPROJECT my-workflow 12
+cdsctl workflow result list MYPROJECT my-workflow 12 --type docker-image`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "type",
+			Usage: "Filter the results by type: docker-image, changelog",
+		},
+	},
+}
+
+type workflowResultDisplay struct {
+	ID      int64  `cli:"id,key"`
+	SubNum  int64  `cli:"sub_num"`
+	Type    string `cli:"type"`
+	Ref     string `cli:"ref"`
+	Digest  string `cli:"digest"`
+	Labels  string `cli:"labels"`
+	Version string `cli:"version"`
+	Created string `cli:"created"`
+}
+
+func workflowResultListRun(v cli.Values) (cli.ListResult, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+	var types []string
+	if t := v.GetString("type"); t != "" {
+		types = append(types, t)
+	}
+	results, err := client.WorkflowRunResultList(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, types...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]workflowResultDisplay, len(results))
+	for i, r := range results {
+		res[i] = workflowResultDisplay{
+			ID:      r.ID,
+			SubNum:  r.SubNumber,
+			Type:    r.Type,
+			Created: r.Created.String(),
+		}
+		if r.Type == sdk.WorkflowRunResultTypeDockerImage {
+			image, err := r.GetDockerImage()
+			if err != nil {
+				return nil, err
+			}
+			labels := make([]string, 0, len(image.Labels))
+			for k, l := range image.Labels {
+				labels = append(labels, k+"="+l)
+			}
+			sort.Strings(labels)
+			res[i].Ref = image.Ref
+			res[i].Digest = image.Digest
+			res[i].Labels = strings.Join(labels, ",")
+		}
+		if r.Type == sdk.WorkflowRunResultTypeChangelog {
+			changelog, err := r.GetChangelog()
+			if err != nil {
+				return nil, err
+			}
+			res[i].Version = changelog.Version
+		}
+	}
+	return cli.AsListResult(res), nil
+}
+
+var workflowResultReleaseCmd = cli.Command{
+	Name:  "release",
+	Short: "List the release targets of one Workflow Run with their status",
+	Example: `cdsctl workflow result release MYPROJECT my-workflow 12
+cdsctl workflow result release MYPROJECT my-workflow 12 --node release-pipeline`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "node",
+			Usage: "Filter the release targets by pipeline node name",
+		},
+	},
+}
+
+func workflowResultReleaseRun(v cli.Values) (cli.ListResult, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+	var nodes []string
+	if n := v.GetString("node"); n != "" {
+		nodes = append(nodes, n)
+	}
+	targets, err := client.WorkflowRunReleaseTargetList(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, nodes...)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(targets), nil
+}
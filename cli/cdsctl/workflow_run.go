@@ -85,6 +85,11 @@ var workflowRunManualCmd = cli.Command{
 			Usage:     "Synchronise your pipelines with your last editions. Must be used with flag run-number",
 			Type:      cli.FlagBool,
 		},
+		{
+			Name:  "follow",
+			Usage: "Follow the workflow run and print its logs, exit code will be 0 only if the run is successful",
+			Type:  cli.FlagBool,
+		},
 	},
 }
 
@@ -92,6 +97,9 @@ func workflowRunManualRun(v cli.Values) error {
 	if v.GetBool("sync") && v.GetString("run-number") == "" {
 		return fmt.Errorf("Could not use flag --sync without flag --run-number")
 	}
+	if v.GetBool("follow") && v.GetBool("interactive") {
+		return fmt.Errorf("Could not use flag --follow with flag --interactive")
+	}
 
 	manual := sdk.WorkflowNodeRunManual{}
 	if strings.TrimSpace(v.GetString("data")) != "" {
@@ -159,6 +167,13 @@ func workflowRunManualRun(v cli.Values) error {
 		}
 	}
 
+	// The run form of the workflow only applies to new runs
+	if runNumber == 0 {
+		if err := workflowRunManualForm(v, &manual); err != nil {
+			return err
+		}
+	}
+
 	if v.GetBool("sync") {
 		if _, err := client.WorkflowRunResync(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber); err != nil {
 			return fmt.Errorf("Cannot resync your workflow run %d : %v", runNumber, err)
@@ -191,6 +206,10 @@ func workflowRunManualRun(v cli.Values) error {
 
 	fmt.Printf("Workflow %s #%d has been launched\n", v.GetString(_WorkflowName), w.Number)
 
+	if v.GetBool("follow") {
+		return workflowRunFollow(v, w)
+	}
+
 	configUser, err := client.ConfigUser()
 	if err != nil {
 		return err
@@ -213,3 +232,53 @@ func workflowRunManualRun(v cli.Values) error {
 
 	return workflowRunInteractive(v, w, configUser.URLUI)
 }
+
+// workflowRunManualForm asks for the parameters of the run form of the workflow that are not given in the payload,
+// then checks the payload before creating the run.
+func workflowRunManualForm(v cli.Values, manual *sdk.WorkflowNodeRunManual) error {
+	form, err := client.WorkflowRunForm(v.GetString(_ProjectKey), v.GetString(_WorkflowName))
+	if err != nil {
+		return err
+	}
+	if len(form.Parameters) == 0 {
+		return nil
+	}
+
+	payload := map[string]interface{}{}
+	switch p := manual.Payload.(type) {
+	case map[string]interface{}:
+		payload = p
+	case map[string]string:
+		for key, value := range p {
+			payload[key] = value
+		}
+	}
+
+	for _, p := range form.Parameters {
+		if _, ok := payload[p.Name]; ok || v.GetBool("no-interactive") {
+			continue
+		}
+		label := p.Name
+		if p.Description != "" {
+			label = fmt.Sprintf("%s - %s", p.Name, p.Description)
+		}
+		if p.Type == sdk.WorkflowRunFormParameterChoice {
+			payload[p.Name] = p.Choices[cli.AskChoice(label, p.Choices...)]
+			continue
+		}
+		if p.Default != "" {
+			label = fmt.Sprintf("%s (default: %s)", label, p.Default)
+		}
+		if value := cli.AskValue(label); value != "" {
+			payload[p.Name] = value
+		}
+	}
+
+	if err := form.Check(payload); err != nil {
+		return err
+	}
+	if len(payload) > 0 {
+		manual.Payload = payload
+	}
+	return nil
+}
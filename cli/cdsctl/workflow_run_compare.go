@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var workflowRunsCmd = cli.Command{
+	Name:  "runs",
+	Short: "Manage CDS workflow runs",
+}
+
+func workflowRuns() *cobra.Command {
+	return cli.NewCommand(workflowRunsCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowRunCompareCmd, workflowRunCompareRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowRunSecretsCmd, workflowRunSecretsRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowRunFingerprintCmd, workflowRunFingerprintRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowRunCompareCmd = cli.Command{
+	Name:  "compare",
+	Short: "Compare the durations of one Workflow Run with the median of the last successful runs on the default branch",
+	Long: `Compare the durations of the pipelines, jobs and steps of one Workflow Run with the median durations of the last
+successful runs on the default branch of the repository. Durations are given in seconds, elements slower than their median
+by the threshold ratio are outliers.`,
+	Example: `cdsctl workflow runs compare MYPROJECT my-workflow 42
+cdsctl workflow runs compare MYPROJECT my-workflow 42 --outliers --baseline 20 --branch develop`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "baseline",
+			Usage: "Number of runs used as baseline",
+		},
+		{
+			Name:  "branch",
+			Usage: "Branch of the runs used as baseline, the default branch of the repository if not given",
+		},
+		{
+			Name:  "threshold",
+			Usage: "Ratio between the duration and its median above which an element is an outlier",
+		},
+		{
+			Name:  "outliers",
+			Usage: "Display only the outliers",
+			Type:  cli.FlagBool,
+		},
+	},
+}
+
+func workflowRunCompareRun(v cli.Values) (cli.ListResult, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+
+	var mods []cdsclient.RequestModifier
+	for _, k := range []string{"baseline", "branch", "threshold"} {
+		if v.GetString(k) != "" {
+			mods = append(mods, cdsclient.WithQueryParameter(k, v.GetString(k)))
+		}
+	}
+
+	c, err := client.WorkflowRunCompare(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, mods...)
+	if err != nil {
+		return nil, err
+	}
+	if v.GetBool("outliers") {
+		return cli.AsListResult(c.Outliers), nil
+	}
+	return cli.AsListResult(c.Deltas), nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowRunExportCmd = cli.Command{
+	Name:  "run-export",
+	Short: "Export a workflow run as a signed archive for compliance records",
+	Long: `Download a gzipped tar archive that contains the node statuses, parameters, step logs, test reports,
+artifacts manifest and manual runs of a workflow run. The archive contains a manifest of its files signed by
+the CDS API, checksums of the files are verified after the download.`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "output",
+			Usage:   "Output directory",
+			Default: ".",
+		},
+	},
+}
+
+func workflowRunExportRun(v cli.Values) error {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("number parameter have to be an integer")
+	}
+
+	btes, err := client.WorkflowRunExport(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return err
+	}
+	if err := workflowRunExportCheck(btes); err != nil {
+		return err
+	}
+
+	path := filepath.Join(v.GetString("output"), fmt.Sprintf("%s-%d-export.tar.gz", v.GetString(_WorkflowName), number))
+	if err := ioutil.WriteFile(path, btes, 0644); err != nil {
+		return fmt.Errorf("unable to write file %s: %v", path, err)
+	}
+	fmt.Printf("Workflow run exported in %s\n", path)
+	return nil
+}
+
+// workflowRunExportCheck verifies the checksums of the files of an export archive with its manifest.
+func workflowRunExportCheck(btes []byte) error {
+	gr, err := gzip.NewReader(bytes.NewReader(btes))
+	if err != nil {
+		return fmt.Errorf("invalid export archive: %v", err)
+	}
+	tr := tar.NewReader(gr)
+
+	var manifest *sdk.WorkflowRunExportManifest
+	files := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid export archive: %v", err)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("invalid export archive: %v", err)
+		}
+		switch hdr.Name {
+		case sdk.WorkflowRunExportManifestFile:
+			manifest = new(sdk.WorkflowRunExportManifest)
+			if err := json.Unmarshal(content, manifest); err != nil {
+				return fmt.Errorf("invalid export manifest: %v", err)
+			}
+		case sdk.WorkflowRunExportSignatureFile:
+		default:
+			files[hdr.Name] = content
+		}
+	}
+	if manifest == nil {
+		return fmt.Errorf("invalid export archive: manifest not found")
+	}
+	return manifest.Check(files)
+}
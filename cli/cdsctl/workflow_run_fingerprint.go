@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowRunFingerprintCmd = cli.Command{
+	Name:  "fingerprint",
+	Short: "Compare the environment of the jobs of one Workflow Run with their last successful run",
+	Long: `Compare the environment fingerprint of each job of one Workflow Run with the one of the last successful run of the
+same job: system, worker version, worker model and image, versions of the binaries required by the job and names of
+the environment variables. A job without reference run never succeeded before.`,
+	Example: `cdsctl workflow runs fingerprint MYPROJECT my-workflow 42`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+}
+
+type workflowRunFingerprintDisplay struct {
+	Node           string `cli:"node,key"`
+	Job            string `cli:"job"`
+	Status         string `cli:"status"`
+	ReferenceRun   int64  `cli:"reference_run"`
+	Field          string `cli:"field"`
+	Current        string `cli:"current"`
+	ReferenceValue string `cli:"reference"`
+}
+
+func workflowRunFingerprintRun(v cli.Values) (cli.ListResult, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+	comparisons, err := client.WorkflowRunJobFingerprintCompare(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []workflowRunFingerprintDisplay
+	for _, c := range comparisons {
+		d := workflowRunFingerprintDisplay{
+			Node:         c.NodeName,
+			Job:          c.JobName,
+			Status:       c.Status,
+			ReferenceRun: c.ReferenceNumber,
+		}
+		// A job with the same environment as its reference is displayed once without field
+		if len(c.Differences) == 0 {
+			res = append(res, d)
+			continue
+		}
+		for _, diff := range c.Differences {
+			d.Field = diff.Field
+			d.Current = diff.Current
+			d.ReferenceValue = diff.Reference
+			res = append(res, d)
+		}
+	}
+	return cli.AsListResult(res), nil
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+// workflowRunFollowInterval is the max interval between two refreshes of a followed run, logs are not sent as
+// events so they are refreshed at this interval while a step is building.
+const workflowRunFollowInterval = 2 * time.Second
+
+// Exit codes of cdsctl workflow run --follow when the run is not successful.
+const (
+	workflowRunExitCodeFail    = 1
+	workflowRunExitCodeStopped = 2
+	workflowRunExitCodeOther   = 3
+)
+
+// workflowRunFollow streams the step logs of a workflow run until it ends. It returns an error with an exit code
+// matching the run status if the run is not successful.
+func workflowRunFollow(v cli.Values, w *sdk.WorkflowRun) error {
+	projectKey, workflowName := v.GetString(_ProjectKey), v.GetString(_WorkflowName)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chanSSE := make(chan cdsclient.SSEvent)
+	sdk.GoRoutine(ctx, "WorkflowRunFollow", func(ctx context.Context) {
+		client.EventsListen(ctx, chanSSE)
+	})
+
+	tick := time.NewTicker(workflowRunFollowInterval)
+	defer tick.Stop()
+
+	f := newWorkflowRunFollower(os.Stdout)
+	for {
+		wr, err := client.WorkflowRunGet(projectKey, workflowName, w.Number)
+		if err != nil {
+			return err
+		}
+		if err := f.print(wr, func(nodeRunID, jobID int64, step int) (string, error) {
+			state, err := client.WorkflowNodeRunJobStep(projectKey, workflowName, wr.Number, nodeRunID, jobID, step)
+			if err != nil {
+				return "", err
+			}
+			return state.StepLogs.Val, nil
+		}); err != nil {
+			return err
+		}
+		if sdk.StatusIsTerminated(wr.Status) {
+			return workflowRunExitError(workflowName, wr)
+		}
+
+		// Wait for an event about the run, or for the next tick to refresh logs
+	wait:
+		for {
+			select {
+			case <-tick.C:
+				break wait
+			case evt := <-chanSSE:
+				var e sdk.Event
+				content, _ := ioutil.ReadAll(evt.Data)
+				if err := json.Unmarshal(content, &e); err != nil {
+					continue
+				}
+				if e.ProjectKey == projectKey && e.WorkflowName == workflowName && e.WorkflowRunNum == w.Number {
+					break wait
+				}
+			}
+		}
+	}
+}
+
+func workflowRunExitError(workflowName string, wr *sdk.WorkflowRun) error {
+	fmt.Printf("Workflow %s #%d: %s\n", workflowName, wr.Number, wr.Status)
+	switch wr.Status {
+	case sdk.StatusSuccess:
+		return nil
+	case sdk.StatusFail:
+		return &cli.Error{Code: workflowRunExitCodeFail, Err: fmt.Errorf("workflow run %d failed", wr.Number)}
+	case sdk.StatusStopped:
+		return &cli.Error{Code: workflowRunExitCodeStopped, Err: fmt.Errorf("workflow run %d was stopped", wr.Number)}
+	}
+	return &cli.Error{Code: workflowRunExitCodeOther, Err: fmt.Errorf("workflow run %d ended with status %s", wr.Number, wr.Status)}
+}
+
+// workflowRunFollower prints the new lines of step logs of a workflow run, prefixed with the node and job names.
+type workflowRunFollower struct {
+	out       io.Writer
+	printed   map[string]int
+	doneSteps map[string]bool
+	doneJobs  map[int64]bool
+}
+
+func newWorkflowRunFollower(out io.Writer) *workflowRunFollower {
+	return &workflowRunFollower{
+		out:       out,
+		printed:   make(map[string]int),
+		doneSteps: make(map[string]bool),
+		doneJobs:  make(map[int64]bool),
+	}
+}
+
+func (f *workflowRunFollower) print(wr *sdk.WorkflowRun, stepLogs func(nodeRunID, jobID int64, step int) (string, error)) error {
+	var nodeRuns []sdk.WorkflowNodeRun
+	for _, nrs := range wr.WorkflowNodeRuns {
+		nodeRuns = append(nodeRuns, nrs...)
+	}
+	sort.Slice(nodeRuns, func(i, j int) bool { return nodeRuns[i].ID < nodeRuns[j].ID })
+
+	for _, nr := range nodeRuns {
+		for _, stage := range nr.Stages {
+			for _, job := range stage.RunJobs {
+				if f.doneJobs[job.ID] {
+					continue
+				}
+				prefix := fmt.Sprintf("[%s/%s] ", nr.WorkflowNodeName, job.Job.Action.Name)
+				for _, step := range job.Job.StepStatus {
+					if err := f.printStep(prefix, nr.ID, job.ID, step, stepLogs); err != nil {
+						return err
+					}
+				}
+				if sdk.StatusIsTerminated(job.Status) {
+					f.doneJobs[job.ID] = true
+					fmt.Fprintf(f.out, "%s%s\n", prefix, job.Status)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (f *workflowRunFollower) printStep(prefix string, nodeRunID, jobID int64, step sdk.StepStatus, stepLogs func(nodeRunID, jobID int64, step int) (string, error)) error {
+	key := fmt.Sprintf("%d-%d-%d", nodeRunID, jobID, step.StepOrder)
+	if f.doneSteps[key] {
+		return nil
+	}
+	done := sdk.StatusIsTerminated(step.Status)
+
+	logs, err := stepLogs(nodeRunID, jobID, step.StepOrder)
+	if err != nil {
+		return err
+	}
+	if len(logs) < f.printed[key] {
+		return nil
+	}
+	logs = logs[f.printed[key]:]
+
+	// Only complete lines are printed while the step is building
+	if !done {
+		logs = logs[:strings.LastIndex(logs, "\n")+1]
+	}
+	f.printed[key] += len(logs)
+
+	for _, line := range strings.Split(strings.TrimSuffix(logs, "\n"), "\n") {
+		if line != "" {
+			fmt.Fprintf(f.out, "%s%s\n", prefix, line)
+		}
+	}
+	f.doneSteps[key] = done
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestWorkflowRunFollower(t *testing.T) {
+	job := sdk.WorkflowNodeJobRun{ID: 2, Status: sdk.StatusBuilding}
+	job.Job.Action.Name = "build"
+	job.Job.StepStatus = []sdk.StepStatus{{StepOrder: 0, Status: sdk.StatusBuilding}}
+	wr := &sdk.WorkflowRun{
+		WorkflowNodeRuns: map[int64][]sdk.WorkflowNodeRun{
+			1: {{ID: 1, WorkflowNodeName: "root", Stages: []sdk.Stage{{RunJobs: []sdk.WorkflowNodeJobRun{job}}}}},
+		},
+	}
+
+	var logs string
+	stepLogs := func(nodeRunID, jobID int64, step int) (string, error) { return logs, nil }
+
+	buf := new(bytes.Buffer)
+	f := newWorkflowRunFollower(buf)
+
+	logs = "line 1\nline"
+	require.NoError(t, f.print(wr, stepLogs))
+	assert.Equal(t, "[root/build] line 1\n", buf.String())
+
+	buf.Reset()
+	logs = "line 1\nline 2\nline 3"
+	wr.WorkflowNodeRuns[1][0].Stages[0].RunJobs[0].Status = sdk.StatusSuccess
+	wr.WorkflowNodeRuns[1][0].Stages[0].RunJobs[0].Job.StepStatus[0].Status = sdk.StatusSuccess
+	require.NoError(t, f.print(wr, stepLogs))
+	assert.Equal(t, "[root/build] line 2\n[root/build] line 3\n[root/build] Success\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, f.print(wr, stepLogs))
+	assert.Empty(t, buf.String())
+}
+
+func TestWorkflowRunExitError(t *testing.T) {
+	assert.NoError(t, workflowRunExitError("wf", &sdk.WorkflowRun{Number: 1, Status: sdk.StatusSuccess}))
+	for status, code := range map[string]int{
+		sdk.StatusFail:    workflowRunExitCodeFail,
+		sdk.StatusStopped: workflowRunExitCodeStopped,
+		sdk.StatusSkipped: workflowRunExitCodeOther,
+	} {
+		err := workflowRunExitError("wf", &sdk.WorkflowRun{Number: 1, Status: status})
+		require.Error(t, err)
+		e, ok := err.(*cli.Error)
+		require.True(t, ok)
+		assert.Equal(t, code, e.Code)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowRunSecretsCmd = cli.Command{
+	Name:  "secrets",
+	Short: "List the secrets read by the jobs of one Workflow Run",
+	Long: `List the secrets and keys read by the jobs of one Workflow Run, with the step that read them. A step order of -1
+means that the secret was read by the parameters of the job. Values of the secrets are never displayed.`,
+	Example: `cdsctl workflow runs secrets MYPROJECT my-workflow 42
+cdsctl workflow runs secrets MYPROJECT my-workflow 42 --node deploy`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "node",
+			Usage: "Filter the secrets by pipeline name",
+		},
+	},
+}
+
+type workflowRunSecretDisplay struct {
+	Name      string `cli:"name,key"`
+	Type      string `cli:"type"`
+	Source    string `cli:"source"`
+	SubNum    int64  `cli:"sub_num"`
+	Node      string `cli:"node"`
+	Job       string `cli:"job"`
+	StepOrder int    `cli:"step_order"`
+}
+
+func workflowRunSecretsRun(v cli.Values) (cli.ListResult, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+	var nodeNames []string
+	if n := v.GetString("node"); n != "" {
+		nodeNames = append(nodeNames, n)
+	}
+	usages, err := client.WorkflowRunSecretUsageList(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, nodeNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]workflowRunSecretDisplay, len(usages))
+	for i, u := range usages {
+		res[i] = workflowRunSecretDisplay{
+			Name:      u.Name,
+			Type:      u.Type,
+			Source:    u.Source,
+			SubNum:    u.SubNumber,
+			Node:      u.NodeName,
+			Job:       u.JobName,
+			StepOrder: u.StepOrder,
+		}
+	}
+	return cli.AsListResult(res), nil
+}
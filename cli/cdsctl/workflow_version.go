@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowVersionCmd = cli.Command{
+	Name:    "version",
+	Aliases: []string{"versions"},
+	Short:   "Manage Workflow versions",
+	Long:    "Each change of the definition of a workflow is saved as a version, with its author and message.",
+}
+
+func workflowVersion() *cobra.Command {
+	return cli.NewCommand(workflowVersionCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowVersionListCmd, workflowVersionListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowVersionShowCmd, workflowVersionShowRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowVersionRollbackCmd, workflowVersionRollbackRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowVersionListCmd = cli.Command{
+	Name:  "list",
+	Short: "List the versions of a Workflow, the last one first",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+}
+
+func workflowVersionListRun(v cli.Values) (cli.ListResult, error) {
+	versions, err := client.WorkflowVersionList(v.GetString(_ProjectKey), v.GetString(_WorkflowName))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(versions), nil
+}
+
+var workflowVersionShowCmd = cli.Command{
+	Name:    "show",
+	Short:   "Display the yaml definition of a version of a Workflow",
+	Example: `cdsctl workflow version show MYPROJECT my-workflow 3`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "version"},
+	},
+}
+
+func workflowVersionShowRun(v cli.Values) error {
+	version, err := strconv.ParseInt(v.GetString("version"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("version parameter have to be an integer")
+	}
+	wv, err := client.WorkflowVersionGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), version)
+	if err != nil {
+		return err
+	}
+	fmt.Print(wv.Data)
+	return nil
+}
+
+var workflowVersionRollbackCmd = cli.Command{
+	Name:    "rollback",
+	Short:   "Restore the definition of a previous version of a Workflow, it creates a new version",
+	Example: `cdsctl workflow version rollback MYPROJECT my-workflow 3`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "version"},
+	},
+}
+
+func workflowVersionRollbackRun(v cli.Values) error {
+	version, err := strconv.ParseInt(v.GetString("version"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("version parameter have to be an integer")
+	}
+	if _, err := client.WorkflowVersionRollback(v.GetString(_ProjectKey), v.GetString(_WorkflowName), version); err != nil {
+		return err
+	}
+	fmt.Printf("Workflow %s rolled back to version %d\n", v.GetString(_WorkflowName), version)
+	return nil
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowWebhookCmd = cli.Command{
+	Name:    "webhook",
+	Aliases: []string{"webhooks"},
+	Short:   "Manage Workflow outgoing webhooks",
+}
+
+func workflowWebhook() *cobra.Command {
+	return cli.NewCommand(workflowWebhookCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowWebhookListCmd, workflowWebhookListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowWebhookAddCmd, workflowWebhookAddRun, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(workflowWebhookDeleteCmd, workflowWebhookDeleteRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowWebhookDeliveriesCmd, workflowWebhookDeliveriesRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowWebhookDeadLetterCmd, workflowWebhookDeadLetterRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowWebhookReplayCmd, workflowWebhookReplayRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowWebhookListCmd = cli.Command{
+	Name:  "list",
+	Short: "List outgoing webhooks of one workflow",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+}
+
+func workflowWebhookListRun(v cli.Values) (cli.ListResult, error) {
+	ws, err := client.WorkflowWebhookList(v.GetString(_ProjectKey), v.GetString(_WorkflowName))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ws), nil
+}
+
+var workflowWebhookAddCmd = cli.Command{
+	Name:  "add",
+	Short: "Add an outgoing webhook on one workflow",
+	Long: `Add an outgoing webhook that sends the status changes of the workflow runs, and of their nodes, to an url.
+By default all events and statuses are sent with a JSON payload, the payload template can be interpolated with {{.cds.event}}, {{.cds.project}}, {{.cds.workflow}}, {{.cds.run.number}}, {{.cds.node}}, {{.cds.status}}, {{.cds.branch}}, {{.cds.buildURL}} and the build parameters of node runs.`,
+	Example: `cdsctl workflow webhook add MYPROJECT my-workflow https://chat.example.com/hooks/123 --event run --status Success --status Fail
+cdsctl workflow webhook add MYPROJECT my-workflow https://example.com/cds --payload-file ./payload.json --secret my-secret`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "url"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "event",
+			Usage: "Event to send: run or node, can be repeated",
+			Type:  cli.FlagArray,
+		},
+		{
+			Name:  "status",
+			Usage: "Status to send, can be repeated",
+			Type:  cli.FlagArray,
+		},
+		{
+			Name:  "payload-file",
+			Usage: "Path of a file containing the payload template",
+		},
+		{
+			Name:  "content-type",
+			Usage: "Content type of the payload",
+		},
+		{
+			Name:  "secret",
+			Usage: "Secret used to sign the payload",
+		},
+		{
+			Name:  "max-attempts",
+			Usage: "Number of attempts after which an event is moved to the dead letter queue",
+		},
+	},
+}
+
+func workflowWebhookAddRun(v cli.Values) error {
+	w := sdk.WorkflowWebhook{
+		URL:           v.GetString("url"),
+		Events:        v.GetStringArray("event"),
+		Statuses:      v.GetStringArray("status"),
+		ContentType:   v.GetString("content-type"),
+		SigningSecret: v.GetString("secret"),
+	}
+	if path := v.GetString("payload-file"); path != "" {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("cannot read payload file: %v", err)
+		}
+		w.PayloadTemplate = string(b)
+	}
+	if s := v.GetString("max-attempts"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("max-attempts parameter have to be an integer")
+		}
+		w.MaxAttempts = n
+	}
+
+	if err := client.WorkflowWebhookAdd(v.GetString(_ProjectKey), v.GetString(_WorkflowName), &w); err != nil {
+		return err
+	}
+	fmt.Printf("Webhook %d created\n", w.ID)
+	return nil
+}
+
+var workflowWebhookDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete an outgoing webhook from one workflow",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+}
+
+func workflowWebhookDeleteRun(v cli.Values) error {
+	id, err := v.GetInt64("id")
+	if err != nil {
+		return err
+	}
+	return client.WorkflowWebhookDelete(v.GetString(_ProjectKey), v.GetString(_WorkflowName), id)
+}
+
+var workflowWebhookDeliveriesCmd = cli.Command{
+	Name:  "deliveries",
+	Short: "List the last deliveries of the outgoing webhooks of one workflow",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "status",
+			Usage: "Filter deliveries on their status: Pending, Success, Error or Dead",
+		},
+		{
+			Name:    "limit",
+			Usage:   "Max number of deliveries",
+			Default: "20",
+		},
+	},
+}
+
+func workflowWebhookDeliveriesRun(v cli.Values) (cli.ListResult, error) {
+	limit, err := v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+	ds, err := client.WorkflowWebhookDeliveries(v.GetString(_ProjectKey), v.GetString(_WorkflowName), v.GetString("status"), int(limit))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ds), nil
+}
+
+var workflowWebhookDeadLetterCmd = cli.Command{
+	Name:  "deadletter",
+	Short: "List the events of one workflow that could not be delivered",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "limit",
+			Usage:   "Max number of deliveries",
+			Default: "20",
+		},
+	},
+}
+
+func workflowWebhookDeadLetterRun(v cli.Values) (cli.ListResult, error) {
+	limit, err := v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+	ds, err := client.WorkflowWebhookDeadLetter(v.GetString(_ProjectKey), v.GetString(_WorkflowName), int(limit))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ds), nil
+}
+
+var workflowWebhookReplayCmd = cli.Command{
+	Name:  "replay",
+	Short: "Replay a delivery, or all the dead deliveries of one workflow",
+	Example: `cdsctl workflow webhook replay MYPROJECT my-workflow 42
+cdsctl workflow webhook replay MYPROJECT my-workflow --dead`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "id"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "dead",
+			Usage: "Replay all the dead deliveries",
+			Type:  cli.FlagBool,
+		},
+	},
+}
+
+func workflowWebhookReplayRun(v cli.Values) error {
+	if v.GetBool("dead") {
+		n, err := client.WorkflowWebhookDeadLetterReplay(v.GetString(_ProjectKey), v.GetString(_WorkflowName))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d deliveries will be replayed\n", n)
+		return nil
+	}
+
+	if v.GetString("id") == "" {
+		return fmt.Errorf("a delivery id or the --dead flag is required")
+	}
+	id, err := v.GetInt64("id")
+	if err != nil {
+		return err
+	}
+	return client.WorkflowWebhookDeliveryReplay(v.GetString(_ProjectKey), v.GetString(_WorkflowName), id)
+}
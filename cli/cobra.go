@@ -59,6 +59,17 @@ func OSExit(code int) {
 	}
 }
 
+// AnnotationArgs is the cobra annotation that contains the ordered names of a command's arguments.
+const AnnotationArgs = "cds_args"
+
+// ArgNames returns the ordered names of the arguments of a command created with NewCommand.
+func ArgNames(cmd *cobra.Command) []string {
+	if cmd.Annotations[AnnotationArgs] == "" {
+		return nil
+	}
+	return strings.Split(cmd.Annotations[AnnotationArgs], ",")
+}
+
 // SubCommands represents an array of cobra.Command
 type SubCommands []*cobra.Command
 
@@ -141,6 +152,14 @@ func newCommand(c Command, run interface{}, subCommands SubCommands, mods ...Com
 	sort.Sort(orderArgs(definedArgs...))
 	definedArgs = append(definedArgs, c.VariadicArgs)
 
+	argNames := make([]string, 0, len(definedArgs))
+	for _, a := range definedArgs {
+		if a.Name != "" {
+			argNames = append(argNames, a.Name)
+		}
+	}
+	cmd.Annotations = map[string]string{AnnotationArgs: strings.Join(argNames, ",")}
+
 	cmd.Short = c.Short
 	cmd.Long = c.Long
 	cmd.Hidden = c.Hidden
@@ -3,6 +3,7 @@ package cli
 import (
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ovh/cds/sdk"
@@ -26,3 +27,16 @@ func TestListItem(t *testing.T) {
 	result = listItem(keyProject, nil, false, []string{"NAME"}, false, map[string]string{})
 	assert.Equal(t, map[string]string{"name": "myKey"}, result)
 }
+
+func TestArgNames(t *testing.T) {
+	cmd := NewCommand(Command{
+		Name:         "show",
+		Ctx:          []Arg{{Name: "project-key"}},
+		Args:         []Arg{{Name: "workflow-name"}},
+		OptionalArgs: []Arg{{Name: "number"}},
+	}, func(v Values) error { return nil }, nil)
+	assert.Equal(t, []string{"project-key", "workflow-name", "number"}, ArgNames(cmd))
+
+	assert.Nil(t, ArgNames(NewCommand(Command{Name: "list"}, nil, nil)))
+	assert.Nil(t, ArgNames(&cobra.Command{Use: "raw"}))
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 )
 
 // SendVulnerabilityReport call worker to send vulnerabiliry report to API
@@ -26,7 +27,7 @@ func SendVulnerabilityReport(workerHTTPPort int32, report sdk.VulnerabilityWorke
 		return fmt.Errorf("send report to worker /vulnerability: %v", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := cdsclient.DefaultHTTPClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("cannot send report to worker /vulnerability: %v", err)
 	}
@@ -49,7 +50,7 @@ func GetExternalServices(workerHTTPPort int32, serviceType string) (sdk.External
 		return sdk.ExternalService{}, fmt.Errorf("get service from worker /services: %v", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := cdsclient.DefaultHTTPClient.Do(req)
 	if err != nil {
 		return sdk.ExternalService{}, fmt.Errorf("cannot get service from worker /services: %v", err)
 	}
@@ -200,6 +200,10 @@ func (e *arsenalDeploymentPlugin) DeployStatus(ctx context.Context, q *integrati
 	}, nil
 }
 
+func (e *arsenalDeploymentPlugin) Plan(ctx context.Context, q *integrationplugin.DeployQuery) (*integrationplugin.DeployResult, error) {
+	return fail("plan mode is not supported by the arsenal deployment plugin")
+}
+
 func main() {
 	e := arsenalDeploymentPlugin{}
 	if err := integrationplugin.Start(context.Background(), &e); err != nil {
@@ -177,6 +177,25 @@ func (e *helloDeploymentPlugin) DeployStatus(ctx context.Context, q *integration
 	}, nil
 }
 
+// Plan returns what would be sent to your "deployment" system without deploying anything.
+// The details of the result are displayed to the user before the deployment is approved.
+func (e *helloDeploymentPlugin) Plan(ctx context.Context, q *integrationplugin.DeployQuery) (*integrationplugin.DeployResult, error) {
+	var application = q.GetOptions()["cds.application"]
+	var helloHost = q.GetOptions()["cds.integration.host"]
+
+	deployData, err := interpolate.Do(deployData, q.GetOptions())
+	if err != nil {
+		return fail("Error: unable to interpolate data: %v. Please check you integration configuration\n", err)
+	}
+
+	// Here, you should ask your "deployment" system what would change, for example
+	// by comparing the current version with the version that would be deployed.
+	return &integrationplugin.DeployResult{
+		Details: fmt.Sprintf("%s would be deployed on Hello at %s with:\n%s", application, helloHost, deployData),
+		Status:  sdk.StatusSuccess,
+	}, nil
+}
+
 func main() {
 	e := helloDeploymentPlugin{}
 	if err := integrationplugin.Start(context.Background(), &e); err != nil {
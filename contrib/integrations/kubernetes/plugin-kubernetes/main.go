@@ -158,6 +158,10 @@ func (k8sPlugin *kubernetesDeploymentPlugin) DeployStatus(ctx context.Context, q
 	}, nil
 }
 
+func (k8sPlugin *kubernetesDeploymentPlugin) Plan(ctx context.Context, q *integrationplugin.DeployQuery) (*integrationplugin.DeployResult, error) {
+	return fail("plan mode is not supported by the kubernetes deployment plugin")
+}
+
 func main() {
 	e := kubernetesDeploymentPlugin{}
 	if err := integrationplugin.Start(context.Background(), &e); err != nil {
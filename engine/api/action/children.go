@@ -23,6 +23,8 @@ func insertActionChild(db gorp.SqlExecutor, child sdk.Action, actionID int64, ex
 		StepName:       child.StepName,
 		Optional:       child.Optional,
 		AlwaysExecuted: child.AlwaysExecuted,
+		Condition:      child.Condition,
+		ActionVersion:  child.Version,
 		Enabled:        child.Enabled,
 	}
 	if err := insertEdge(db, &ae); err != nil {
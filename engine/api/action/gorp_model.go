@@ -38,7 +38,9 @@ type actionEdge struct {
 	Enabled        bool   `db:"enabled"`
 	Optional       bool   `db:"optional"`
 	AlwaysExecuted bool   `db:"always_executed"`
+	Condition      string `db:"condition"`
 	StepName       string `db:"step_name"`
+	ActionVersion  string `db:"action_version"`
 	// aggregates
 	Parameters []actionEdgeParameter `db:"-"`
 	Child      *sdk.Action           `db:"-"`
@@ -60,6 +62,8 @@ func actionEdgesToChildIDs(aes []*actionEdge) []int64 {
 	return ids
 }
 
+type actionVersion sdk.ActionVersion
+
 type actionEdgeParameter struct {
 	ID           int64  `json:"id" yaml:"-" db:"id"`
 	ActionEdgeID int64  `json:"action_id" yaml:"-" db:"action_edge_id"`
@@ -78,5 +82,6 @@ func init() {
 		gorpmapping.New(sdk.Requirement{}, "action_requirement", true, "id"),
 		gorpmapping.New(actionEdge{}, "action_edge", true, "id"),
 		gorpmapping.New(actionEdgeParameter{}, "action_edge_parameter", true, "id"),
+		gorpmapping.New(actionVersion{}, "action_version", true, "id"),
 	)
 }
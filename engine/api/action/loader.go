@@ -2,6 +2,7 @@ package action
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-gorp/gorp"
 
@@ -139,6 +140,12 @@ func loadChildren(ctx context.Context, db gorp.SqlExecutor, as ...*sdk.Action) e
 		mEdges[edges[i].ParentID] = append(mEdges[edges[i].ParentID], edges[i])
 	}
 
+	// load published versions pinned by edges
+	versions, err := loadVersionsForEdges(ctx, db, edges)
+	if err != nil {
+		return err
+	}
+
 	// for all actions set children from its edges
 	for i := range actionsNotBuiltIn {
 		edges, ok := mEdges[actionsNotBuiltIn[i].ID]
@@ -153,7 +160,20 @@ func loadChildren(ctx context.Context, db gorp.SqlExecutor, as ...*sdk.Action) e
 			child.StepName = edges[i].StepName
 			child.Optional = edges[i].Optional
 			child.AlwaysExecuted = edges[i].AlwaysExecuted
+			child.Condition = edges[i].Condition
 			child.Enabled = edges[i].Enabled
+			child.Version = edges[i].ActionVersion
+
+			// if a version is pinned, use the content of the action when it was published
+			if edges[i].ActionVersion != "" {
+				v, ok := versions[actionVersionKey(edges[i].ChildID, edges[i].ActionVersion)]
+				if !ok {
+					return sdk.NewErrorFrom(sdk.ErrNoAction, "version %s of action %s not found", edges[i].ActionVersion, child.Name)
+				}
+				child.Parameters = v.Action.Parameters
+				child.Requirements = v.Action.Requirements
+				child.Actions = v.Action.Actions
+			}
 
 			// replace action parameter with value configured by user when he created the child action
 			params := make([]sdk.Parameter, len(child.Parameters))
@@ -260,3 +280,29 @@ func loadEdgeChildren(ctx context.Context, db gorp.SqlExecutor, es ...*actionEdg
 
 	return nil
 }
+
+func actionVersionKey(actionID int64, version string) string {
+	return fmt.Sprintf("%d@%s", actionID, version)
+}
+
+func loadVersionsForEdges(ctx context.Context, db gorp.SqlExecutor, edges []actionEdge) (map[string]sdk.ActionVersion, error) {
+	var actionIDs []int64
+	for i := range edges {
+		if edges[i].ActionVersion != "" {
+			actionIDs = append(actionIDs, edges[i].ChildID)
+		}
+	}
+	if len(actionIDs) == 0 {
+		return nil, nil
+	}
+
+	vs, err := LoadVersionsByActionIDs(ctx, db, actionIDs)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]sdk.ActionVersion, len(vs))
+	for i := range vs {
+		m[actionVersionKey(vs[i].ActionID, vs[i].Version)] = vs[i]
+	}
+	return m, nil
+}
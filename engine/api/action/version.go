@@ -0,0 +1,126 @@
+package action
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func getVersions(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.ActionVersion, error) {
+	var avs []actionVersion
+	if err := gorpmapping.GetAll(ctx, db, q, &avs); err != nil {
+		return nil, sdk.WrapError(err, "cannot get action versions")
+	}
+	vs := make([]sdk.ActionVersion, len(avs))
+	for i := range avs {
+		vs[i] = sdk.ActionVersion(avs[i])
+	}
+	sdk.SortActionVersions(vs)
+	return vs, nil
+}
+
+// LoadVersionsByActionID returns all published versions for given action id, from the greatest to the lowest.
+func LoadVersionsByActionID(ctx context.Context, db gorp.SqlExecutor, actionID int64) ([]sdk.ActionVersion, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM action_version WHERE action_id = $1").Args(actionID)
+	return getVersions(ctx, db, query)
+}
+
+// LoadVersionsByActionIDs returns all published versions for given action ids.
+func LoadVersionsByActionIDs(ctx context.Context, db gorp.SqlExecutor, actionIDs []int64) ([]sdk.ActionVersion, error) {
+	query := gorpmapping.NewQuery(
+		"SELECT * FROM action_version WHERE action_id = ANY(string_to_array($1, ',')::int[])",
+	).Args(gorpmapping.IDsToQueryString(actionIDs))
+	return getVersions(ctx, db, query)
+}
+
+// LoadVersion returns the published version of an action.
+func LoadVersion(ctx context.Context, db gorp.SqlExecutor, actionID int64, version string) (*sdk.ActionVersion, error) {
+	var av actionVersion
+	query := gorpmapping.NewQuery("SELECT * FROM action_version WHERE action_id = $1 AND version = $2").Args(actionID, version)
+	found, err := gorpmapping.Get(ctx, db, query, &av)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get version %s for action %d", version, actionID)
+	}
+	if !found {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "version %s not found", version)
+	}
+	res := sdk.ActionVersion(av)
+	return &res, nil
+}
+
+func insertVersion(db gorp.SqlExecutor, v *sdk.ActionVersion) error {
+	av := actionVersion(*v)
+	if err := gorpmapping.Insert(db, &av); err != nil {
+		return sdk.WrapError(err, "unable to insert version %s for action %d", v.Version, v.ActionID)
+	}
+	*v = sdk.ActionVersion(av)
+	return nil
+}
+
+func updateVersion(db gorp.SqlExecutor, v *sdk.ActionVersion) error {
+	av := actionVersion(*v)
+	return sdk.WrapError(gorpmapping.Update(db, &av), "unable to update version %s for action %d", v.Version, v.ActionID)
+}
+
+// Publish creates a new version for given action, the action should have been loaded with its children, parameters
+// and requirements. A published version can't be updated.
+func Publish(ctx context.Context, db gorp.SqlExecutor, a sdk.Action, version string, author sdk.Identifiable) (*sdk.ActionVersion, error) {
+	if a.Type != sdk.DefaultAction {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "only user actions can be published")
+	}
+
+	version = strings.TrimPrefix(version, "v")
+	if err := sdk.IsValidActionVersion(version); err != nil {
+		return nil, err
+	}
+
+	vs, err := LoadVersionsByActionID(ctx, db, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range vs {
+		if vs[i].Version == version {
+			return nil, sdk.NewErrorFrom(sdk.ErrAlreadyExist, "version %s already published for action %s", version, a.Name)
+		}
+	}
+
+	// remove data that are not relevant in the snapshot
+	a.FirstAudit, a.LastAudit, a.Editable = nil, nil, false
+
+	v := sdk.ActionVersion{
+		ActionID: a.ID,
+		Version:  version,
+		Action:   a,
+		Author:   author.GetUsername(),
+		Created:  time.Now(),
+	}
+	if err := insertVersion(db, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// Yank marks given version as broken, it will not be resolved anymore but steps that already pinned it still works.
+func Yank(db gorp.SqlExecutor, v *sdk.ActionVersion, reason string) error {
+	v.Yanked = true
+	v.YankedReason = reason
+	return updateVersion(db, v)
+}
+
+// ResolveVersion returns the greatest published version of given action that matches the constraint.
+func ResolveVersion(ctx context.Context, db gorp.SqlExecutor, a sdk.Action, constraint string) (*sdk.ActionVersion, error) {
+	vs, err := LoadVersionsByActionID(ctx, db, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	v, err := sdk.ResolveActionVersion(vs, constraint)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot resolve version %s of action %s", constraint, a.Name)
+	}
+	return v, nil
+}
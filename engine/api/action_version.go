@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/action"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getActionVersionsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		groupName := vars["permGroupName"]
+		actionName := vars["permActionName"]
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName)
+		if err != nil {
+			return err
+		}
+
+		a, err := action.LoadTypeDefaultByNameAndGroupID(ctx, api.mustDB(), actionName, g.ID)
+		if err != nil {
+			return err
+		}
+		if a == nil {
+			return sdk.WithStack(sdk.ErrNoAction)
+		}
+
+		vs, err := action.LoadVersionsByActionID(ctx, api.mustDB(), a.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, vs, http.StatusOK)
+	}
+}
+
+func (api *API) postActionVersionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		groupName := vars["permGroupName"]
+		actionName := vars["permActionName"]
+
+		var data sdk.ActionVersion
+		if err := service.UnmarshalBody(r, &data); err != nil {
+			return err
+		}
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot begin transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		a, err := action.LoadTypeDefaultByNameAndGroupID(ctx, tx, actionName, g.ID, action.LoadOptions.Default)
+		if err != nil {
+			return err
+		}
+		if a == nil {
+			return sdk.WithStack(sdk.ErrNoAction)
+		}
+
+		v, err := action.Publish(ctx, tx, *a, data.Version, getAPIConsumer(ctx))
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, v, http.StatusOK)
+	}
+}
+
+func (api *API) postActionVersionYankHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		groupName := vars["permGroupName"]
+		actionName := vars["permActionName"]
+		version := vars["version"]
+
+		var data sdk.ActionVersionYank
+		if err := service.UnmarshalBody(r, &data); err != nil {
+			return err
+		}
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName, group.LoadOptions.WithMembers)
+		if err != nil {
+			return err
+		}
+		if !isGroupAdmin(ctx, g) && !isAdmin(ctx) {
+			return sdk.WithStack(sdk.ErrInvalidGroupAdmin)
+		}
+
+		a, err := action.LoadTypeDefaultByNameAndGroupID(ctx, api.mustDB(), actionName, g.ID)
+		if err != nil {
+			return err
+		}
+		if a == nil {
+			return sdk.WithStack(sdk.ErrNoAction)
+		}
+
+		v, err := action.LoadVersion(ctx, api.mustDB(), a.ID, version)
+		if err != nil {
+			return err
+		}
+
+		if err := action.Yank(api.mustDB(), v, data.Reason); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, v, http.StatusOK)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/objectstore"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
@@ -247,3 +248,30 @@ func (api *API) postAdminDatabaseRollEncryptedEntityByPrimaryKey() service.Handl
 		return nil
 	}
 }
+
+func (api *API) getAdminArtifactReplicationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if _, ok := api.SharedStorage.(*objectstore.ReplicatedDriver); !ok {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "artifact replication is not enabled")
+		}
+		status, err := objectstore.LoadReplicationStatus(ctx, api.mustDB())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, status, http.StatusOK)
+	}
+}
+
+func (api *API) postAdminArtifactReplicationRetryHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if _, ok := api.SharedStorage.(*objectstore.ReplicatedDriver); !ok {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "artifact replication is not enabled")
+		}
+		n, err := objectstore.RetryReplicationErrors(api.mustDB())
+		if err != nil {
+			return err
+		}
+		log.Info(ctx, "postAdminArtifactReplicationRetryHandler> %d artifacts will be replicated again", n)
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// adminCachePattern returns the pattern of the cache keys of the project, or of the workflow if given by the route.
+func adminCachePattern(r *http.Request) (string, error) {
+	vars := mux.Vars(r)
+	projectKey := vars["projectKey"]
+	workflowName, hasWorkflow := vars["workflowName"]
+	if !sdk.NamePatternRegex.MatchString(projectKey) || (hasWorkflow && !sdk.NamePatternRegex.MatchString(workflowName)) {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid project key or workflow name, it should match %s", sdk.NamePattern)
+	}
+	if hasWorkflow {
+		return cache.WorkflowKey(projectKey, workflowName, "*"), nil
+	}
+	return cache.ProjectKey(projectKey, "*"), nil
+}
+
+func (api *API) getAdminCacheKeysHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		pattern, err := adminCachePattern(r)
+		if err != nil {
+			return err
+		}
+		limit, err := FormInt(r, "limit")
+		if err != nil {
+			return err
+		}
+		if limit <= 0 {
+			limit = 1000
+		}
+
+		names, err := api.Cache.Keys(pattern)
+		if err != nil {
+			return err
+		}
+		sort.Strings(names)
+		if len(names) > limit {
+			names = names[:limit]
+		}
+
+		keys := make([]sdk.CacheKey, 0, len(names))
+		for _, name := range names {
+			ttl, err := api.Cache.TTL(name)
+			if err != nil {
+				return err
+			}
+			// The key may have expired since it was listed
+			if ttl == -2*time.Second {
+				continue
+			}
+			keys = append(keys, sdk.CacheKey{Key: name, TTL: int64(ttl.Seconds())})
+		}
+
+		return service.WriteJSON(w, keys, http.StatusOK)
+	}
+}
+
+func (api *API) deleteAdminCacheKeysHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		pattern, err := adminCachePattern(r)
+		if err != nil {
+			return err
+		}
+		if err := api.Cache.DeleteAll(pattern); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
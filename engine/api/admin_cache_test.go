@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_adminCacheKeysHandlers(t *testing.T) {
+	api, _, _, end := newTestAPI(t)
+	defer end()
+
+	_, jwt := assets.InsertAdminUser(t, api.mustDB())
+
+	projectKey := sdk.RandomString(10)
+	workflowKey := cache.WorkflowKey(projectKey, "my-workflow", "data")
+	projectOnlyKey := cache.ProjectKey(projectKey, "reposmanager", "repos", "github")
+	require.NoError(t, api.Cache.SetWithTTL(workflowKey, "value", 60))
+	require.NoError(t, api.Cache.SetWithTTL(projectOnlyKey, "value", 0))
+
+	uri := api.Router.Prefix + "/admin/cache/project/" + projectKey
+	listKeys := func(u string) []sdk.CacheKey {
+		req := assets.NewJWTAuthentifiedRequest(t, jwt, "GET", u, nil)
+		w := httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(w, req)
+		require.Equal(t, 200, w.Code)
+		var keys []sdk.CacheKey
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &keys))
+		return keys
+	}
+
+	keys := listKeys(uri)
+	require.Len(t, keys, 2)
+	assert.Equal(t, workflowKey, keys[0].Key)
+	assert.True(t, keys[0].TTL > 0)
+	assert.Equal(t, projectOnlyKey, keys[1].Key)
+	assert.Equal(t, int64(-1), keys[1].TTL)
+
+	require.Len(t, listKeys(uri+"/workflow/my-workflow"), 1)
+
+	// Invalidating the workflow keeps the keys of the project
+	req := assets.NewJWTAuthentifiedRequest(t, jwt, "DELETE", uri+"/workflow/my-workflow", nil)
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	keys = listKeys(uri)
+	require.Len(t, keys, 1)
+	assert.Equal(t, projectOnlyKey, keys[0].Key)
+
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "DELETE", uri, nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	assert.Len(t, listKeys(uri), 0)
+
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "GET", api.Router.Prefix+"/admin/cache/project/*", nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 400, w.Code)
+}
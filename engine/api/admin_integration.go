@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/interpolate"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (api *API) postAdminProjectIntegrationApplyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.ProjectIntegrationApplyRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		model, err := integration.LoadModelByName(api.mustDB(), req.Model)
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "integration model %s not found", req.Model))
+		}
+		if model.Public {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "integration model %s is public", req.Model)
+		}
+
+		// Each project is updated in its own transaction, an error on a project is reported without stopping the apply
+		results := make([]sdk.ProjectIntegrationApplyResult, 0, len(req.ProjectKeys))
+		for _, key := range req.ProjectKeys {
+			res := sdk.ProjectIntegrationApplyResult{ProjectKey: key}
+			res.Status, err = api.applyProjectIntegration(ctx, key, model, req)
+			if err != nil {
+				log.Error(ctx, "postAdminProjectIntegrationApplyHandler> cannot apply integration %s on project %s: %v", req.Name, key, err)
+				res.Status = sdk.ProjectIntegrationApplyError
+				res.Message = sdk.ExtractHTTPError(err, r.Header.Get("Accept-Language")).Message
+			}
+			if res.Status == sdk.ProjectIntegrationApplySkipped {
+				res.Message = "integration already exists"
+			}
+			results = append(results, res)
+		}
+
+		return service.WriteJSON(w, results, http.StatusOK)
+	}
+}
+
+// applyProjectIntegration creates or updates the integration of given request on a project, and returns the status
+// of the apply.
+func (api *API) applyProjectIntegration(ctx context.Context, key string, model sdk.IntegrationModel, req sdk.ProjectIntegrationApplyRequest) (string, error) {
+	p, err := project.Load(api.mustDB(), key)
+	if err != nil {
+		return "", err
+	}
+
+	config := req.Config.Clone()
+	for k, v := range config {
+		v.Value, err = interpolate.Do(v.Value, map[string]string{"cds.project": p.Key})
+		if err != nil {
+			return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot interpolate integration config %s: %v", k, err)
+		}
+		config[k] = v
+	}
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	old, err := integration.LoadProjectIntegrationByNameWithClearPassword(tx, p.Key, req.Name)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return "", err
+	}
+	exists := err == nil
+	if exists && !req.Update {
+		return sdk.ProjectIntegrationApplySkipped, nil
+	}
+	if exists && old.Model.Public {
+		return "", sdk.WithStack(sdk.ErrForbidden)
+	}
+
+	pi := sdk.ProjectIntegration{
+		Name:               req.Name,
+		ProjectID:          p.ID,
+		IntegrationModelID: model.ID,
+		Model:              model,
+		Config:             config,
+	}
+	status := sdk.ProjectIntegrationApplyCreated
+	if exists {
+		pi.ID = old.ID
+		pi.GroupIntegrationID = old.GroupIntegrationID
+		if err := integration.UpdateIntegration(tx, api.Cache, pi); err != nil {
+			return "", err
+		}
+		status = sdk.ProjectIntegrationApplyUpdated
+	} else {
+		if err := integration.InsertIntegration(tx, &pi); err != nil {
+			return "", err
+		}
+	}
+
+	if model.Event {
+		if err := event.ResetEventIntegration(ctx, tx, pi.ID); err != nil {
+			return "", sdk.WrapError(err, "cannot connect to event broker")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", sdk.WithStack(err)
+	}
+
+	if exists {
+		event.PublishUpdateProjectIntegration(ctx, p, pi, old, getAPIConsumer(ctx))
+	} else {
+		event.PublishAddProjectIntegration(ctx, p, pi, getAPIConsumer(ctx))
+	}
+	return status, nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_postAdminProjectIntegrationApplyHandler(t *testing.T) {
+	api, db, router, end := newTestAPI(t)
+	defer end()
+
+	if _, err := integration.LoadModelByName(db, sdk.OpenstackIntegration.Name); err != nil {
+		require.NoError(t, integration.CreateBuiltinModels(db))
+	}
+
+	proj1 := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	proj2 := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	u, pass := assets.InsertAdminUser(t, db)
+
+	config := sdk.OpenstackIntegration.DefaultConfig.Clone()
+	tenant := config["tenant_name"]
+	tenant.Value = "tenant-{{.cds.project}}"
+	config["tenant_name"] = tenant
+	applyReq := sdk.ProjectIntegrationApplyRequest{
+		Name:        "openstack-" + sdk.RandomString(5),
+		Model:       sdk.OpenstackIntegration.Name,
+		Config:      config,
+		ProjectKeys: []string{proj1.Key, proj2.Key, "UNKNOWN" + sdk.RandomString(5)},
+	}
+
+	apply := func(req sdk.ProjectIntegrationApplyRequest) []sdk.ProjectIntegrationApplyResult {
+		uri := router.GetRoute("POST", api.postAdminProjectIntegrationApplyHandler, nil)
+		r := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, req)
+		w := httptest.NewRecorder()
+		router.Mux.ServeHTTP(w, r)
+		require.Equal(t, 200, w.Code)
+		var results []sdk.ProjectIntegrationApplyResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+		require.Len(t, results, 3)
+		return results
+	}
+
+	results := apply(applyReq)
+	assert.Equal(t, sdk.ProjectIntegrationApplyCreated, results[0].Status)
+	assert.Equal(t, sdk.ProjectIntegrationApplyCreated, results[1].Status)
+	assert.Equal(t, sdk.ProjectIntegrationApplyError, results[2].Status)
+
+	pi, err := integration.LoadProjectIntegrationByNameWithClearPassword(db, proj2.Key, applyReq.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-"+proj2.Key, pi.Config["tenant_name"].Value)
+
+	// Existing integrations are kept unless an update is asked
+	results = apply(applyReq)
+	assert.Equal(t, sdk.ProjectIntegrationApplySkipped, results[0].Status)
+	assert.Equal(t, sdk.ProjectIntegrationApplySkipped, results[1].Status)
+
+	applyReq.Update = true
+	region := applyReq.Config["region"]
+	region.Value = "GRA"
+	applyReq.Config["region"] = region
+	results = apply(applyReq)
+	assert.Equal(t, sdk.ProjectIntegrationApplyUpdated, results[0].Status)
+	assert.Equal(t, sdk.ProjectIntegrationApplyUpdated, results[1].Status)
+
+	pi, err = integration.LoadProjectIntegrationByNameWithClearPassword(db, proj1.Key, applyReq.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "GRA", pi.Config["region"].Value)
+	assert.Equal(t, "tenant-"+proj1.Key, pi.Config["tenant_name"].Value)
+}
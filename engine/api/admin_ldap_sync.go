@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication/ldap"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/ldapsync"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// checkLDAPGroupSyncEnabled returns ErrNotFound if the LDAP group sync is not configured.
+func (api *API) checkLDAPGroupSyncEnabled() error {
+	if !api.Config.Auth.LDAP.Enabled || !api.Config.Auth.LDAP.GroupSync.Enabled {
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "LDAP group sync is not enabled")
+	}
+	return nil
+}
+
+// ldapGroupSearch returns the search of the LDAP groups used by the group sync.
+func (api *API) ldapGroupSearch() ldapsync.SearchFunc {
+	return func(ctx context.Context) ([]sdk.LDAPGroup, error) {
+		d, ok := api.AuthenticationDrivers[sdk.ConsumerLDAP].(ldap.AuthDriver)
+		if !ok {
+			return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "LDAP authentication is not enabled")
+		}
+		cfg := api.Config.Auth.LDAP.GroupSync
+		return d.SearchGroups(ctx, ldap.GroupSearch{
+			Base:            cfg.GroupSearchBase,
+			Filter:          cfg.GroupFilter,
+			NameAttribute:   cfg.GroupNameAttribute,
+			MemberAttribute: cfg.MemberAttribute,
+		})
+	}
+}
+
+// getAdminLDAPSyncHandler returns the report of the last LDAP group sync.
+func (api *API) getAdminLDAPSyncHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkLDAPGroupSyncEnabled(); err != nil {
+			return err
+		}
+
+		report, err := ldapsync.LoadLastReport(api.Cache)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, report, http.StatusOK)
+	}
+}
+
+// postAdminLDAPSyncHandler runs a LDAP group sync now. With dryRun, the groups are not changed.
+func (api *API) postAdminLDAPSyncHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkLDAPGroupSyncEnabled(); err != nil {
+			return err
+		}
+
+		dryRun := FormBool(r, "dryRun") || api.Config.Auth.LDAP.GroupSync.DryRun
+		report, err := ldapsync.Run(ctx, api.mustDB(), api.Cache, api.Config.Auth.LDAP.GroupSync, api.ldapGroupSearch(), dryRun)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, report, http.StatusOK)
+	}
+}
+
+// putAdminLDAPSyncGroupHandler opts a group in or out of the LDAP group sync.
+func (api *API) putAdminLDAPSyncGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkLDAPGroupSyncEnabled(); err != nil {
+			return err
+		}
+
+		vars := mux.Vars(r)
+		groupName := vars["groupName"]
+
+		var req sdk.LDAPSyncGroupOptOut
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		g, err := group.LoadByName(ctx, tx, groupName)
+		if err != nil {
+			return err
+		}
+
+		l, err := ldapsync.LoadGroupLinkByGroupID(ctx, tx, g.ID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+
+		switch {
+		case l == nil && req.OptOut:
+			if err := ldapsync.InsertGroupLink(tx, &ldapsync.GroupLink{GroupID: g.ID, OptOut: true}); err != nil {
+				return err
+			}
+		case l != nil && !req.OptOut && l.LDAPGroup == "":
+			// The group was opted out before being synchronized, it is a local group again
+			if err := ldapsync.DeleteGroupLink(tx, l); err != nil {
+				return err
+			}
+		case l != nil && l.OptOut != req.OptOut:
+			l.OptOut = req.OptOut
+			if err := ldapsync.UpdateGroupLink(tx, l); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, req, http.StatusOK)
+	}
+}
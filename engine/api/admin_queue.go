@@ -0,0 +1,215 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/maintenance"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// queueHatcheryHeartbeatDelay is the delay after which a hatchery that didn't send a heartbeat is considered as down.
+const queueHatcheryHeartbeatDelay = time.Minute
+
+// postAdminQueueJobCancelHandler stops the pipeline of a job of the queue.
+func (api *API) postAdminQueueJobCancelHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		job, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return err
+		}
+		if job.Status != sdk.StatusWaiting && job.Status != sdk.StatusBuilding {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "job %d is %s, only waiting or building jobs can be canceled", id, job.Status)
+		}
+
+		proj, err := project.LoadProjectByNodeJobRunID(ctx, api.mustDB(), api.Cache, id, project.LoadOptions.WithVariables)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project by nodeJobRunID: %d", id)
+		}
+		nodeRun, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node run by nodeJobRunID: %d", id)
+		}
+		workflowName, _ := job.Header.Get(sdk.WorkflowHeader)
+
+		report, err := api.stopWorkflowNodeRun(ctx, api.mustDB, api.Cache, proj, nodeRun, workflowName, getAPIConsumer(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "unable to stop workflow node run %d", nodeRun.ID)
+		}
+		log.Info(ctx, "postAdminQueueJobCancelHandler> job %d of %s/%s canceled by %s", id, proj.Key, workflowName, getAPIConsumer(ctx).GetUsername())
+
+		go WorkflowSendEvent(context.Background(), api.mustDB(), api.Cache, *proj, report)
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// postAdminQueueJobRequeueHandler replaces a job in queue, its booking is released so it can be taken by any hatchery.
+func (api *API) postAdminQueueJobRequeueHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		job, err := workflow.LoadAndLockNodeJobRunSkipLocked(ctx, tx, api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node run job %d", id)
+		}
+		if err := workflow.RequeueNodeJobRun(ctx, tx, api.Cache, job, getAPIConsumer(ctx)); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// getAdminQueueJobDiagnosticHandler explains why a job of the queue is not taken by a hatchery.
+func (api *API) getAdminQueueJobDiagnosticHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		job, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		diag := sdk.QueueJobDiagnostic{JobID: job.ID}
+
+		if job.Status == sdk.StatusWaiting {
+			diag.Add("status", true, "job is waiting since %s", sdk.Round(now.Sub(job.Queued), time.Second))
+		} else {
+			diag.Add("status", false, "job is %s, only waiting jobs are taken by hatcheries", job.Status)
+		}
+
+		if job.RetryAfter != nil && job.RetryAfter.After(now) {
+			diag.Add("retry", false, "job can't be booked before %s after infrastructure error: %s", job.RetryAfter.Format(time.RFC3339), job.InfraError)
+		} else {
+			diag.Add("retry", true, "job was tried %d times", job.Retry+1)
+		}
+
+		// Jobs held by a maintenance window are hidden to hatcheries
+		windows, err := maintenance.LoadWindows(api.mustDB())
+		if err != nil {
+			return err
+		}
+		if window := jobMaintenanceWindow(windows, now, job); window != nil {
+			overridden, err := maintenance.LoadOverriddenNodeRunIDs(ctx, api.mustDB(), []int64{job.WorkflowNodeRunID})
+			if err != nil {
+				return err
+			}
+			if _, ok := overridden[job.WorkflowNodeRunID]; ok {
+				diag.Add("maintenance", true, "maintenance window %q is overridden for this run", window.Reason)
+			} else {
+				diag.Add("maintenance", false, "job is held by maintenance window %q until %s", window.Reason, window.End.Format(time.RFC3339))
+			}
+		} else {
+			diag.Add("maintenance", true, "no active maintenance window")
+		}
+
+		if job.BookedBy.ID != 0 {
+			diag.Add("booking", true, "job is booked by hatchery %s, a worker should be spawned", job.BookedBy.Name)
+		} else {
+			diag.Add("booking", true, "job is not booked")
+		}
+
+		model, err := workflow.LoadNodeJobRunModel(ctx, api.mustDB(), *job)
+		switch {
+		case err != nil:
+			diag.Add("model", false, "cannot find required worker model: %v", sdk.ExtractHTTPError(err, "").Message)
+		case model == nil:
+			diag.Add("model", true, "no model requirement")
+		case model.Disabled:
+			diag.Add("model", false, "worker model %s is disabled", model.Name)
+		case model.NbSpawnErr > 0:
+			diag.Add("model", false, "worker model %s failed to spawn %d times: %s", model.Name, model.NbSpawnErr, model.LastSpawnErr)
+		default:
+			diag.Add("model", true, "worker model %s", model.Name)
+		}
+
+		hatcheries, err := services.LoadAllByType(ctx, api.mustDB(), services.TypeHatchery)
+		if err != nil {
+			return err
+		}
+		var eligible int
+		for _, h := range hatcheries {
+			var groupIDs []int64
+			if h.ConsumerID != nil {
+				c, err := authentication.LoadConsumerByID(ctx, api.mustDB(), *h.ConsumerID, authentication.LoadConsumerOptions.WithAuthentifiedUser)
+				if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+					return err
+				}
+				if c != nil {
+					groupIDs = c.GetGroupIDs()
+				}
+			}
+			ok, detail := queueJobHatcheryCheck(h, groupIDs, *job, model, group.SharedInfraGroup.ID, now)
+			if ok {
+				eligible++
+			}
+			diag.Add("hatchery "+h.Name, ok, "%s", detail)
+		}
+		if eligible == 0 {
+			diag.Add("hatcheries", false, "no hatchery can take this job")
+		} else {
+			diag.Add("hatcheries", true, "%d hatcheries can take this job", eligible)
+		}
+
+		return service.WriteJSON(w, diag, http.StatusOK)
+	}
+}
+
+// queueJobHatcheryCheck returns true if given hatchery can take the job: it is alive, its groups give access to the
+// job and to its worker model.
+func queueJobHatcheryCheck(h sdk.Service, groupIDs []int64, job sdk.WorkflowNodeJobRun, model *sdk.Model, sharedInfraGroupID int64, now time.Time) (bool, string) {
+	if since := now.Sub(h.LastHeartbeat); since > queueHatcheryHeartbeatDelay {
+		return false, fmt.Sprintf("no heartbeat since %s", sdk.Round(since, time.Second))
+	}
+
+	sharedInfra := sdk.IsInInt64Array(sharedInfraGroupID, groupIDs)
+	if !sharedInfra {
+		var hasExecGroup bool
+		for _, g := range job.ExecGroups {
+			if sdk.IsInInt64Array(g.ID, groupIDs) {
+				hasExecGroup = true
+				break
+			}
+		}
+		if !hasExecGroup {
+			return false, "none of its groups can execute the job"
+		}
+	}
+
+	if model != nil && model.GroupID != sharedInfraGroupID && !sdk.IsInInt64Array(model.GroupID, groupIDs) {
+		return false, fmt.Sprintf("none of its groups can use worker model %s", model.Name)
+	}
+
+	return true, "can take the job"
+}
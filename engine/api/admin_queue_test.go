@@ -0,0 +1,44 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_queueJobHatcheryCheck(t *testing.T) {
+	now := time.Now()
+	const sharedInfraID = 1
+
+	h := sdk.Service{LastHeartbeat: now.Add(-10 * time.Second)}
+	job := sdk.WorkflowNodeJobRun{ExecGroups: sdk.Groups{{ID: 2}}}
+	model := &sdk.Model{Name: "debian", GroupID: 3}
+
+	// Hatchery without heartbeat
+	ok, detail := queueJobHatcheryCheck(sdk.Service{LastHeartbeat: now.Add(-5 * time.Minute)}, []int64{2}, job, nil, sharedInfraID, now)
+	require.False(t, ok)
+	require.Equal(t, "no heartbeat since 5m0s", detail)
+
+	// Hatchery without exec group
+	ok, detail = queueJobHatcheryCheck(h, []int64{4}, job, nil, sharedInfraID, now)
+	require.False(t, ok)
+	require.Equal(t, "none of its groups can execute the job", detail)
+
+	ok, _ = queueJobHatcheryCheck(h, []int64{2}, job, nil, sharedInfraID, now)
+	require.True(t, ok)
+
+	// Hatchery without the group of the model
+	ok, detail = queueJobHatcheryCheck(h, []int64{2}, job, model, sharedInfraID, now)
+	require.False(t, ok)
+	require.Equal(t, "none of its groups can use worker model debian", detail)
+
+	ok, _ = queueJobHatcheryCheck(h, []int64{2, 3}, job, model, sharedInfraID, now)
+	require.True(t, ok)
+
+	// Shared infra hatchery can take all the jobs
+	ok, _ = queueJobHatcheryCheck(h, []int64{sharedInfraID}, job, &sdk.Model{Name: "debian", GroupID: sharedInfraID}, sharedInfraID, now)
+	require.True(t, ok)
+}
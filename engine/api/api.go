@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/blang/semver"
+	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"go.opencensus.io/stats"
@@ -31,17 +32,22 @@ import (
 	"github.com/ovh/cds/engine/api/bootstrap"
 	"github.com/ovh/cds/engine/api/broadcast"
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/cost"
 	"github.com/ovh/cds/engine/api/database"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/feature"
+	"github.com/ovh/cds/engine/api/idempotency"
 	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/ldapsync"
 	"github.com/ovh/cds/engine/api/mail"
 	"github.com/ovh/cds/engine/api/metrics"
 	"github.com/ovh/cds/engine/api/migrate"
 	"github.com/ovh/cds/engine/api/notification"
 	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/purge"
+	"github.com/ovh/cds/engine/api/queue"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/engine/api/services"
@@ -49,6 +55,7 @@ import (
 	"github.com/ovh/cds/engine/api/worker"
 	"github.com/ovh/cds/engine/api/workermodel"
 	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowwebhook"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
@@ -67,6 +74,10 @@ type Configuration struct {
 		Addr string `toml:"addr" default:"" commented:"true" comment:"Listen HTTP address without port, example: 127.0.0.1" json:"addr"`
 		Port int    `toml:"port" default:"8081" json:"port"`
 	} `toml:"http" json:"http"`
+	GRPC struct {
+		Addr string `toml:"addr" default:"" commented:"true" comment:"Listen gRPC address without port, example: 127.0.0.1" json:"addr"`
+		Port int    `toml:"port" default:"0" comment:"Listen gRPC port, the gRPC server is disabled if not set" json:"port"`
+	} `toml:"grpc" json:"grpc"`
 	Secrets struct {
 		Key string `toml:"key" json:"-"`
 	} `toml:"secrets" json:"secrets"`
@@ -85,17 +96,18 @@ type Configuration struct {
 		DefaultGroup  string `toml:"defaultGroup" default:"" comment:"The default group is the group in which every new user will be granted at signup" json:"defaultGroup"`
 		RSAPrivateKey string `toml:"rsaPrivateKey" default:"" comment:"The RSA Private Key used to sign and verify the JWT Tokens issued by the API \nThis is mandatory." json:"-"`
 		LDAP          struct {
-			Enabled         bool   `toml:"enabled" default:"false" json:"enabled"`
-			SignupDisabled  bool   `toml:"signupDisabled" default:"false" json:"signupDisabled"`
-			Host            string `toml:"host" json:"host"`
-			Port            int    `toml:"port" default:"636" json:"port"`
-			SSL             bool   `toml:"ssl" default:"true" json:"ssl"`
-			RootDN          string `toml:"rootDN" default:"dc=myorganization,dc=com" json:"rootDN"`
-			UserSearchBase  string `toml:"userSearchBase" default:"ou=people" json:"userSearchBase"`
-			UserSearch      string `toml:"userSearch" default:"uid={0}" json:"userSearch"`
-			UserFullname    string `toml:"userFullname" default:"{{.givenName}} {{.sn}}" json:"userFullname"`
-			ManagerDN       string `toml:"managerDN" default:"cn=admin,dc=myorganization,dc=com" comment:"Define it if ldapsearch need to be authenticated" json:"managerDN"`
-			ManagerPassword string `toml:"managerPassword" default:"SECRET_PASSWORD_MANAGER" comment:"Define it if ldapsearch need to be authenticated" json:"-"`
+			Enabled         bool                   `toml:"enabled" default:"false" json:"enabled"`
+			SignupDisabled  bool                   `toml:"signupDisabled" default:"false" json:"signupDisabled"`
+			Host            string                 `toml:"host" json:"host"`
+			Port            int                    `toml:"port" default:"636" json:"port"`
+			SSL             bool                   `toml:"ssl" default:"true" json:"ssl"`
+			RootDN          string                 `toml:"rootDN" default:"dc=myorganization,dc=com" json:"rootDN"`
+			UserSearchBase  string                 `toml:"userSearchBase" default:"ou=people" json:"userSearchBase"`
+			UserSearch      string                 `toml:"userSearch" default:"uid={0}" json:"userSearch"`
+			UserFullname    string                 `toml:"userFullname" default:"{{.givenName}} {{.sn}}" json:"userFullname"`
+			ManagerDN       string                 `toml:"managerDN" default:"cn=admin,dc=myorganization,dc=com" comment:"Define it if ldapsearch need to be authenticated" json:"managerDN"`
+			ManagerPassword string                 `toml:"managerPassword" default:"SECRET_PASSWORD_MANAGER" comment:"Define it if ldapsearch need to be authenticated" json:"-"`
+			GroupSync       ldapsync.Configuration `toml:"groupSync" comment:"#######\n Synchronization of the CDS groups with the LDAP groups" json:"groupSync"`
 		} `toml:"ldap" json:"ldap"`
 		Local struct {
 			Enabled              bool   `toml:"enabled" default:"true" json:"enabled"`
@@ -132,6 +144,11 @@ type Configuration struct {
 			ApplicationID  string `toml:"applicationID" json:"-" comment:"#######\n Gitlab OAuth Application ID"`
 			Secret         string `toml:"secret" json:"-"  comment:"Gitlab OAuth Application Secret"`
 		} `toml:"gitlab" json:"gitlab"`
+		SCIM struct {
+			Enabled          bool   `toml:"enabled" default:"false" json:"enabled"`
+			Token            string `toml:"token" comment:"#######\n Bearer token used by the identity provider to call the SCIM endpoints" json:"-"`
+			AdoptLocalGroups bool   `toml:"adoptLocalGroups" default:"false" comment:"Let the identity provider manage existing local groups with the same name, else a conflict is returned" json:"adoptLocalGroups"`
+		} `toml:"scim" comment:"#######\n SCIM 2.0 provisioning of users and groups by an identity provider" json:"scim"`
 	} `toml:"auth" comment:"##############################\n CDS Authentication Settings#\n#############################" json:"auth"`
 	SMTP struct {
 		Disable  bool   `toml:"disable" default:"true" json:"disable" comment:"Set to false to enable the internal SMTP client"`
@@ -143,34 +160,11 @@ type Configuration struct {
 		From     string `toml:"from" default:"no-reply@cds.local" json:"from"`
 	} `toml:"smtp" comment:"#####################\n# CDS SMTP Settings \n####################" json:"smtp"`
 	Artifact struct {
-		Mode  string `toml:"mode" default:"local" comment:"swift, awss3 or local" json:"mode"`
-		Local struct {
-			BaseDirectory string `toml:"baseDirectory" default:"/var/lib/cds-engine/artifacts" json:"baseDirectory"`
-		} `toml:"local"`
-		Openstack struct {
-			URL             string `toml:"url" comment:"Authentication Endpoint, generally value of $OS_AUTH_URL" json:"url"`
-			Username        string `toml:"username" comment:"Openstack Username, generally value of $OS_USERNAME" json:"username"`
-			Password        string `toml:"password" comment:"Openstack Password, generally value of $OS_PASSWORD" json:"-"`
-			Tenant          string `toml:"tenant" comment:"Openstack Tenant, generally value of $OS_TENANT_NAME, v2 auth only" json:"tenant"`
-			Domain          string `toml:"domain" comment:"Openstack Domain, generally value of $OS_DOMAIN_NAME, v3 auth only" json:"domain"`
-			Region          string `toml:"region" comment:"Region, generally value of $OS_REGION_NAME" json:"region"`
-			ContainerPrefix string `toml:"containerPrefix" comment:"Use if your want to prefix containers for CDS Artifacts" json:"containerPrefix"`
-			DisableTempURL  bool   `toml:"disableTempURL" default:"false" commented:"true" comment:"True if you want to disable Temporary URL in file upload" json:"disableTempURL"`
-		} `toml:"openstack" json:"openstack"`
-		AWSS3 struct {
-			BucketName          string `toml:"bucketName" json:"bucketName" comment:"Name of the S3 bucket to use when storing artifacts"`
-			Region              string `toml:"region" json:"region" default:"us-east-1" comment:"The AWS region"`
-			Prefix              string `toml:"prefix" json:"prefix" comment:"A subfolder of the bucket to store objects in, if left empty will store at the root of the bucket"`
-			AuthFromEnvironment bool   `toml:"authFromEnv" json:"authFromEnv" default:"false" comment:"Pull S3 auth information from env vars AWS_SECRET_ACCESS_KEY and AWS_SECRET_KEY_ID"`
-			SharedCredsFile     string `toml:"sharedCredsFile" json:"sharedCredsFile" comment:"The path for the AWS credential file, used with profile"`
-			Profile             string `toml:"profile" json:"profile" comment:"The profile within the AWS credentials file to use"`
-			AccessKeyID         string `toml:"accessKeyId" json:"accessKeyId" comment:"A static AWS Secret Key ID"`
-			SecretAccessKey     string `toml:"secretAccessKey" json:"-" comment:"A static AWS Secret Access Key"`
-			SessionToken        string `toml:"sessionToken" json:"-" comment:"A static AWS session token"`
-			Endpoint            string `toml:"endpoint" json:"endpoint" comment:"S3 API Endpoint (optional)" commented:"true"` //optional
-			DisableSSL          bool   `toml:"disableSSL" json:"disableSSL" commented:"true"`                                  //optional
-			ForcePathStyle      bool   `toml:"forcePathStyle" json:"forcePathStyle" commented:"true"`                          //optional
-		} `toml:"awss3" json:"awss3"`
+		Mode        string                           `toml:"mode" default:"local" comment:"swift, awss3 or local" json:"mode"`
+		Local       ArtifactLocalConfiguration       `toml:"local"`
+		Openstack   ArtifactOpenstackConfiguration   `toml:"openstack" json:"openstack"`
+		AWSS3       ArtifactAWSS3Configuration       `toml:"awss3" json:"awss3"`
+		Replication ArtifactReplicationConfiguration `toml:"replication" comment:"Artifacts stored in the storage can be asynchronously replicated to a second storage" json:"replication"`
 	} `toml:"artifact" comment:"Either filesystem local storage or Openstack Swift Storage are supported" json:"artifact"`
 	Features struct {
 		Izanami struct {
@@ -192,6 +186,62 @@ type Configuration struct {
 		StepMaxSize    int64 `toml:"stepMaxSize" default:"15728640" comment:"Max step logs size in bytes (default: 15MB)" json:"stepMaxSize"`
 		ServiceMaxSize int64 `toml:"serviceMaxSize" default:"15728640" comment:"Max service logs size in bytes (default: 15MB)" json:"serviceMaxSize"`
 	} `toml:"log" json:"log" comment:"###########################\n Log settings.\n##########################"`
+	Queue struct {
+		Scheduler queue.Configuration `toml:"scheduler" json:"scheduler"`
+	} `toml:"queue" json:"queue" comment:"###########################\n Job queue settings.\n##########################"`
+	Audit struct {
+		Export event.AuditExportConfiguration `toml:"export" json:"export"`
+	} `toml:"audit" json:"audit" comment:"###########################\n Audit settings.\n##########################"`
+	Cost       cost.Configuration `toml:"cost" json:"cost" comment:"###########################\n Cost accounting settings.\n Unit prices used to compute the cost of workflow runs.\n##########################"`
+	Versioning struct {
+		V1Sunset string `toml:"v1Sunset" default:"" commented:"true" comment:"Date at which the routes of the API v1 (routes without version in their path) will be removed, format: 2006-01-02. If set, deprecation headers are sent to the clients of the API v1" json:"v1Sunset"`
+	} `toml:"versioning" json:"versioning" comment:"###########################\n API versioning settings.\n##########################"`
+	CORS struct {
+		Policies []CORSPolicy `toml:"policies" json:"policies"`
+	} `toml:"cors" json:"cors" comment:"###########################\n CORS settings.\n Cross origin requests are allowed from all origins if no policy is set, else from the origins of the first policy matching the request.\n##########################"`
+}
+
+// ArtifactLocalConfiguration is the configuration of the filesystem artifact storage
+type ArtifactLocalConfiguration struct {
+	BaseDirectory string `toml:"baseDirectory" default:"/var/lib/cds-engine/artifacts" json:"baseDirectory"`
+}
+
+// ArtifactOpenstackConfiguration is the configuration of the Openstack Swift artifact storage
+type ArtifactOpenstackConfiguration struct {
+	URL             string `toml:"url" comment:"Authentication Endpoint, generally value of $OS_AUTH_URL" json:"url"`
+	Username        string `toml:"username" comment:"Openstack Username, generally value of $OS_USERNAME" json:"username"`
+	Password        string `toml:"password" comment:"Openstack Password, generally value of $OS_PASSWORD" json:"-"`
+	Tenant          string `toml:"tenant" comment:"Openstack Tenant, generally value of $OS_TENANT_NAME, v2 auth only" json:"tenant"`
+	Domain          string `toml:"domain" comment:"Openstack Domain, generally value of $OS_DOMAIN_NAME, v3 auth only" json:"domain"`
+	Region          string `toml:"region" comment:"Region, generally value of $OS_REGION_NAME" json:"region"`
+	ContainerPrefix string `toml:"containerPrefix" comment:"Use if your want to prefix containers for CDS Artifacts" json:"containerPrefix"`
+	DisableTempURL  bool   `toml:"disableTempURL" default:"false" commented:"true" comment:"True if you want to disable Temporary URL in file upload" json:"disableTempURL"`
+}
+
+// ArtifactAWSS3Configuration is the configuration of the AWS S3 artifact storage
+type ArtifactAWSS3Configuration struct {
+	BucketName          string `toml:"bucketName" json:"bucketName" comment:"Name of the S3 bucket to use when storing artifacts"`
+	Region              string `toml:"region" json:"region" default:"us-east-1" comment:"The AWS region"`
+	Prefix              string `toml:"prefix" json:"prefix" comment:"A subfolder of the bucket to store objects in, if left empty will store at the root of the bucket"`
+	AuthFromEnvironment bool   `toml:"authFromEnv" json:"authFromEnv" default:"false" comment:"Pull S3 auth information from env vars AWS_SECRET_ACCESS_KEY and AWS_SECRET_KEY_ID"`
+	SharedCredsFile     string `toml:"sharedCredsFile" json:"sharedCredsFile" comment:"The path for the AWS credential file, used with profile"`
+	Profile             string `toml:"profile" json:"profile" comment:"The profile within the AWS credentials file to use"`
+	AccessKeyID         string `toml:"accessKeyId" json:"accessKeyId" comment:"A static AWS Secret Key ID"`
+	SecretAccessKey     string `toml:"secretAccessKey" json:"-" comment:"A static AWS Secret Access Key"`
+	SessionToken        string `toml:"sessionToken" json:"-" comment:"A static AWS session token"`
+	Endpoint            string `toml:"endpoint" json:"endpoint" comment:"S3 API Endpoint (optional)" commented:"true"` //optional
+	DisableSSL          bool   `toml:"disableSSL" json:"disableSSL" commented:"true"`                                  //optional
+	ForcePathStyle      bool   `toml:"forcePathStyle" json:"forcePathStyle" commented:"true"`                          //optional
+}
+
+// ArtifactReplicationConfiguration is the configuration of the storage where artifacts are replicated
+type ArtifactReplicationConfiguration struct {
+	Enabled   bool                           `toml:"enabled" default:"false" json:"enabled"`
+	Mode      string                         `toml:"mode" comment:"swift, awss3 or local" json:"mode"`
+	Local     ArtifactLocalConfiguration     `toml:"local"`
+	Openstack ArtifactOpenstackConfiguration `toml:"openstack" json:"openstack"`
+	AWSS3     ArtifactAWSS3Configuration     `toml:"awss3" json:"awss3"`
+	Parallel  int                            `toml:"parallel" default:"2" comment:"Number of artifacts replicated at the same time" json:"parallel"`
 }
 
 // ServiceConfiguration is the configuration of external service
@@ -311,6 +361,18 @@ func (a *API) CheckConfiguration(config interface{}) error {
 		}
 	}
 
+	if aConfig.Versioning.V1Sunset != "" {
+		if _, err := time.Parse("2006-01-02", aConfig.Versioning.V1Sunset); err != nil {
+			return fmt.Errorf("Invalid API v1 sunset date %s, format should be 2006-01-02", aConfig.Versioning.V1Sunset)
+		}
+	}
+
+	for i, p := range aConfig.CORS.Policies {
+		if err := p.IsValid(); err != nil {
+			return fmt.Errorf("Invalid CORS policy %d: %v", i, err)
+		}
+	}
+
 	if aConfig.Directories.Download == "" {
 		return fmt.Errorf("Invalid download directory (empty)")
 	}
@@ -344,6 +406,34 @@ func (a *API) CheckConfiguration(config interface{}) error {
 		}
 	}
 
+	if aConfig.Artifact.Replication.Enabled {
+		switch aConfig.Artifact.Replication.Mode {
+		case "local", "awss3", "openstack", "swift":
+		default:
+			return fmt.Errorf("Invalid artifact replication mode")
+		}
+		if aConfig.Artifact.Replication.Mode == "local" {
+			if aConfig.Artifact.Replication.Local.BaseDirectory == "" {
+				return fmt.Errorf("Invalid artifact replication local base directory (empty name)")
+			}
+			if aConfig.Artifact.Mode == "local" && aConfig.Artifact.Replication.Local.BaseDirectory == aConfig.Artifact.Local.BaseDirectory {
+				return fmt.Errorf("Invalid artifact replication local base directory, it should not be the artifact local base directory")
+			}
+			if ok, err := sdk.DirectoryExists(aConfig.Artifact.Replication.Local.BaseDirectory); !ok {
+				if err := os.MkdirAll(aConfig.Artifact.Replication.Local.BaseDirectory, os.FileMode(0700)); err != nil {
+					return fmt.Errorf("Unable to create directory %s: %v", aConfig.Artifact.Replication.Local.BaseDirectory, err)
+				}
+				log.Info(context.Background(), "Directory %s has been created", aConfig.Artifact.Replication.Local.BaseDirectory)
+			} else if err != nil {
+				return fmt.Errorf("Invalid artifact replication local base directory %s: %v", aConfig.Artifact.Replication.Local.BaseDirectory, err)
+			}
+		}
+	}
+
+	if aConfig.Audit.Export.Enabled && (aConfig.Audit.Export.ProjectKey == "" || aConfig.Audit.Export.IntegrationName == "") {
+		return fmt.Errorf("Invalid audit export configuration, project key and integration name should be given")
+	}
+
 	if len(aConfig.Secrets.Key) != 32 {
 		return fmt.Errorf("Invalid secret key. It should be 32 bits (%d)", len(aConfig.Secrets.Key))
 	}
@@ -377,6 +467,56 @@ type StartupConfigService struct {
 	ServiceType string `json:"service_type"`
 }
 
+// artifactObjectstoreConfig returns the objectstore configuration for given artifact storage mode and options.
+func artifactObjectstoreConfig(mode string, local ArtifactLocalConfiguration, openstack ArtifactOpenstackConfiguration, awss3 ArtifactAWSS3Configuration) (objectstore.Config, error) {
+	var objectstoreKind objectstore.Kind
+	switch mode {
+	case "openstack":
+		objectstoreKind = objectstore.Openstack
+	case "swift":
+		objectstoreKind = objectstore.Swift
+	case "awss3":
+		objectstoreKind = objectstore.AWSS3
+	case "filesystem", "local":
+		objectstoreKind = objectstore.Filesystem
+	default:
+		return objectstore.Config{}, fmt.Errorf("unsupported objecstore mode : %s", mode)
+	}
+
+	return objectstore.Config{
+		Kind: objectstoreKind,
+		Options: objectstore.ConfigOptions{
+			AWSS3: objectstore.ConfigOptionsAWSS3{
+				Prefix:              awss3.Prefix,
+				SecretAccessKey:     awss3.SecretAccessKey,
+				AccessKeyID:         awss3.AccessKeyID,
+				Profile:             awss3.Profile,
+				SharedCredsFile:     awss3.SharedCredsFile,
+				AuthFromEnvironment: awss3.AuthFromEnvironment,
+				BucketName:          awss3.BucketName,
+				Region:              awss3.Region,
+				SessionToken:        awss3.SessionToken,
+				Endpoint:            awss3.Endpoint,
+				DisableSSL:          awss3.DisableSSL,
+				ForcePathStyle:      awss3.ForcePathStyle,
+			},
+			Openstack: objectstore.ConfigOptionsOpenstack{
+				Address:         openstack.URL,
+				Username:        openstack.Username,
+				Password:        openstack.Password,
+				Tenant:          openstack.Tenant,
+				Domain:          openstack.Domain,
+				Region:          openstack.Region,
+				ContainerPrefix: openstack.ContainerPrefix,
+				DisableTempURL:  openstack.DisableTempURL,
+			},
+			Filesystem: objectstore.ConfigOptionsFilesystem{
+				Basedir: local.BaseDirectory,
+			},
+		},
+	}, nil
+}
+
 // Serve will start the http api server
 func (a *API) Serve(ctx context.Context) error {
 	log.Info(ctx, "Starting CDS API Server %s", sdk.VERSION)
@@ -438,61 +578,31 @@ func (a *API) Serve(ctx context.Context) error {
 
 	//Initialize artifacts storage
 	log.Info(ctx, "Initializing %s objectstore...", a.Config.Artifact.Mode)
-	var objectstoreKind objectstore.Kind
-	switch a.Config.Artifact.Mode {
-	case "openstack":
-		objectstoreKind = objectstore.Openstack
-	case "swift":
-		objectstoreKind = objectstore.Swift
-	case "awss3":
-		objectstoreKind = objectstore.AWSS3
-	case "filesystem", "local":
-		objectstoreKind = objectstore.Filesystem
-	default:
-		return fmt.Errorf("unsupported objecstore mode : %s", a.Config.Artifact.Mode)
-	}
-
-	cfg := objectstore.Config{
-		Kind: objectstoreKind,
-		Options: objectstore.ConfigOptions{
-			AWSS3: objectstore.ConfigOptionsAWSS3{
-				Prefix:              a.Config.Artifact.AWSS3.Prefix,
-				SecretAccessKey:     a.Config.Artifact.AWSS3.SecretAccessKey,
-				AccessKeyID:         a.Config.Artifact.AWSS3.AccessKeyID,
-				Profile:             a.Config.Artifact.AWSS3.Profile,
-				SharedCredsFile:     a.Config.Artifact.AWSS3.SharedCredsFile,
-				AuthFromEnvironment: a.Config.Artifact.AWSS3.AuthFromEnvironment,
-				BucketName:          a.Config.Artifact.AWSS3.BucketName,
-				Region:              a.Config.Artifact.AWSS3.Region,
-				SessionToken:        a.Config.Artifact.AWSS3.SessionToken,
-				Endpoint:            a.Config.Artifact.AWSS3.Endpoint,
-				DisableSSL:          a.Config.Artifact.AWSS3.DisableSSL,
-				ForcePathStyle:      a.Config.Artifact.AWSS3.ForcePathStyle,
-			},
-			Openstack: objectstore.ConfigOptionsOpenstack{
-				Address:         a.Config.Artifact.Openstack.URL,
-				Username:        a.Config.Artifact.Openstack.Username,
-				Password:        a.Config.Artifact.Openstack.Password,
-				Tenant:          a.Config.Artifact.Openstack.Tenant,
-				Domain:          a.Config.Artifact.Openstack.Domain,
-				Region:          a.Config.Artifact.Openstack.Region,
-				ContainerPrefix: a.Config.Artifact.Openstack.ContainerPrefix,
-				DisableTempURL:  a.Config.Artifact.Openstack.DisableTempURL,
-			},
-			Filesystem: objectstore.ConfigOptionsFilesystem{
-				Basedir: a.Config.Artifact.Local.BaseDirectory,
-			},
-		},
+	cfg, err := artifactObjectstoreConfig(a.Config.Artifact.Mode, a.Config.Artifact.Local, a.Config.Artifact.Openstack, a.Config.Artifact.AWSS3)
+	if err != nil {
+		return err
 	}
 
 	// DEPRECATED
 	// API Storage will be a public integration
-	var err error
 	a.SharedStorage, err = objectstore.Init(ctx, cfg)
 	if err != nil {
 		return fmt.Errorf("cannot initialize storage: %v", err)
 	}
 
+	if a.Config.Artifact.Replication.Enabled {
+		log.Info(ctx, "Initializing %s objectstore for artifacts replication...", a.Config.Artifact.Replication.Mode)
+		replicaCfg, err := artifactObjectstoreConfig(a.Config.Artifact.Replication.Mode, a.Config.Artifact.Replication.Local, a.Config.Artifact.Replication.Openstack, a.Config.Artifact.Replication.AWSS3)
+		if err != nil {
+			return err
+		}
+		replica, err := objectstore.Init(ctx, replicaCfg)
+		if err != nil {
+			return fmt.Errorf("cannot initialize replication storage: %v", err)
+		}
+		a.SharedStorage = objectstore.NewReplicatedDriver(a.SharedStorage, replica, func() *gorp.DbMap { return a.mustDB() })
+	}
+
 	log.Info(ctx, "Initializing database connection...")
 	//Intialize database
 	a.DBConnectionFactory, err = database.Init(
@@ -511,6 +621,33 @@ func (a *API) Serve(ctx context.Context) error {
 		return fmt.Errorf("cannot connect to database: %v", err)
 	}
 
+	if a.Config.Database.Replica.Host != "" {
+		log.Info(ctx, "Initializing database replica connection...")
+		replicaCfg := a.Config.Database.Replica
+		if replicaCfg.User == "" {
+			replicaCfg.User, replicaCfg.Password = a.Config.Database.User, a.Config.Database.Password
+		}
+		if replicaCfg.Name == "" {
+			replicaCfg.Name = a.Config.Database.Name
+		}
+		replica, err := database.Init(
+			ctx,
+			replicaCfg.User,
+			a.Config.Database.Role,
+			replicaCfg.Password,
+			replicaCfg.Name,
+			replicaCfg.Host,
+			replicaCfg.Port,
+			replicaCfg.SSLMode,
+			a.Config.Database.ConnectTimeout,
+			a.Config.Database.Timeout,
+			replicaCfg.MaxConn)
+		if err != nil {
+			return fmt.Errorf("cannot connect to database replica: %v", err)
+		}
+		a.DBConnectionFactory.SetReplica(replica, time.Duration(replicaCfg.MaxStaleness)*time.Second)
+	}
+
 	log.Info(ctx, "Setting up database keys...")
 	encryptionKeyConfig := a.Config.Database.EncryptionKey.GetKeys(gorpmapping.KeyEcnryptionIdentifier)
 	signatureKeyConfig := a.Config.Database.SignatureKey.GetKeys(gorpmapping.KeySignIdentifier)
@@ -533,6 +670,14 @@ func (a *API) Serve(ctx context.Context) error {
 		Mux:        mux.NewRouter(),
 		Background: ctx,
 	}
+	if a.Config.Versioning.V1Sunset != "" {
+		v1Sunset, err := time.Parse("2006-01-02", a.Config.Versioning.V1Sunset)
+		if err != nil {
+			return fmt.Errorf("invalid API v1 sunset date %s: %v", a.Config.Versioning.V1Sunset, err)
+		}
+		a.Router.V1Sunset = &v1Sunset
+	}
+	a.Router.CORSPolicies = a.Config.CORS.Policies
 	a.InitRouter()
 	if err := InitRouterMetrics(a); err != nil {
 		log.Error(ctx, "unable to init router metrics: %v", err)
@@ -613,7 +758,11 @@ func (a *API) Serve(ctx context.Context) error {
 		a.AuthenticationDrivers[sdk.ConsumerCorporateSSO] = corpsso.NewDriver(driverConfig)
 	}
 
+	// Project variables referenced by integration configs are resolved at use time
+	integration.ProjectVariablesLoader = project.LoadAllVariablesWithDecrytion
+
 	log.Info(ctx, "Initializing event broker...")
+	event.SetUIURL(a.Config.URL.UI)
 	if err := event.Initialize(ctx, a.mustDB(), a.Cache); err != nil {
 		log.Error(ctx, "error while initializing event system: %s", err)
 	} else {
@@ -666,6 +815,15 @@ func (a *API) Serve(ctx context.Context) error {
 	sdk.GoRoutine(ctx, "authentication.SessionCleaner", func(ctx context.Context) {
 		authentication.SessionCleaner(ctx, a.mustDB)
 	}, a.PanicDump())
+	sdk.GoRoutine(ctx, "idempotency.Cleaner", func(ctx context.Context) {
+		idempotency.Cleaner(ctx, a.mustDB)
+	}, a.PanicDump())
+	sdk.GoRoutine(ctx, "database.CheckReplica", func(ctx context.Context) {
+		a.DBConnectionFactory.CheckReplica(ctx)
+	}, a.PanicDump())
+	sdk.GoRoutine(ctx, "templateDriftChecker", func(ctx context.Context) {
+		templateDriftChecker(ctx, a.DBConnectionFactory.GetDBMap, a.Cache)
+	}, a.PanicDump())
 
 	migrate.Add(ctx, sdk.Migration{Name: "RefactorGroupMembership", Release: "0.44.0", Blocker: true, Automatic: true, ExecFunc: func(ctx context.Context) error {
 		return migrate.RefactorGroupMembership(ctx, a.DBConnectionFactory.GetDBMap())
@@ -811,10 +969,24 @@ func (a *API) Serve(ctx context.Context) error {
 		func(ctx context.Context) {
 			workflow.Initialize(ctx, a.DBConnectionFactory.GetDBMap, a.Cache, a.Config.URL.UI, a.Config.DefaultOS, a.Config.DefaultArch)
 		}, a.PanicDump())
+	sdk.GoRoutine(ctx, "workflowwebhook.Initialize",
+		func(ctx context.Context) {
+			workflowwebhook.Initialize(ctx, a.DBConnectionFactory.GetDBMap, a.Config.URL.UI)
+		}, a.PanicDump())
 	sdk.GoRoutine(ctx, "PushInElasticSearch",
 		func(ctx context.Context) {
 			event.PushInElasticSearch(ctx, a.mustDB(), a.Cache)
 		}, a.PanicDump())
+	if a.Config.Audit.Export.Enabled {
+		sdk.GoRoutine(ctx, "RecordAuditEvents",
+			func(ctx context.Context) {
+				event.RecordAuditEvents(ctx, a.DBConnectionFactory.GetDBMap)
+			}, a.PanicDump())
+		sdk.GoRoutine(ctx, "ExportAuditEvents",
+			func(ctx context.Context) {
+				event.ExportAuditEvents(ctx, a.DBConnectionFactory.GetDBMap, a.Config.Audit.Export)
+			}, a.PanicDump())
+	}
 	sdk.GoRoutine(ctx, "Metrics.pushInElasticSearch",
 		func(ctx context.Context) {
 			metrics.Init(ctx, a.DBConnectionFactory.GetDBMap)
@@ -823,6 +995,18 @@ func (a *API) Serve(ctx context.Context) error {
 		func(ctx context.Context) {
 			purge.Initialize(ctx, a.Cache, a.DBConnectionFactory.GetDBMap, a.SharedStorage, a.Metrics.WorkflowRunsMarkToDelete, a.Metrics.WorkflowRunsDeleted)
 		}, a.PanicDump())
+	if a.Config.Auth.LDAP.Enabled && a.Config.Auth.LDAP.GroupSync.Enabled {
+		sdk.GoRoutine(ctx, "ldapsync.Initialize",
+			func(ctx context.Context) {
+				ldapsync.Initialize(ctx, a.DBConnectionFactory.GetDBMap, a.Cache, a.Config.Auth.LDAP.GroupSync, a.ldapGroupSearch())
+			}, a.PanicDump())
+	}
+	if s, ok := a.SharedStorage.(*objectstore.ReplicatedDriver); ok {
+		sdk.GoRoutine(ctx, "ArtifactReplication",
+			func(ctx context.Context) {
+				s.StartReplication(ctx, a.Config.Artifact.Replication.Parallel)
+			}, a.PanicDump())
+	}
 
 	// Check maintenance on redis
 	if _, err := a.Cache.Get(sdk.MaintenanceAPIKey, &a.Maintenance); err != nil {
@@ -831,7 +1015,7 @@ func (a *API) Serve(ctx context.Context) error {
 
 	s := &http.Server{
 		Addr:           fmt.Sprintf("%s:%d", a.Config.HTTP.Addr, a.Config.HTTP.Port),
-		Handler:        a.Router.Mux,
+		Handler:        a.Router,
 		ReadTimeout:    10 * time.Minute,
 		WriteTimeout:   10 * time.Minute,
 		MaxHeaderBytes: 1 << 20,
@@ -871,6 +1055,14 @@ func (a *API) Serve(ctx context.Context) error {
 		log.Error(ctx, "api> heap dump uploaded to %s", s)
 	}()
 
+	if a.Config.GRPC.Port > 0 {
+		sdk.GoRoutine(ctx, "api.serveGRPC", func(ctx context.Context) {
+			if err := a.serveGRPC(ctx); err != nil {
+				log.Error(ctx, "%v", err)
+			}
+		}, a.PanicDump())
+	}
+
 	log.Info(ctx, "Starting CDS API HTTP Server on %s:%d", a.Config.HTTP.Addr, a.Config.HTTP.Port)
 	if err := s.ListenAndServe(); err != nil {
 		return fmt.Errorf("Cannot start HTTP server: %v", err)
@@ -122,6 +122,16 @@ func (a *API) mustDB() *gorp.DbMap {
 	return db
 }
 
+// mustDBRead returns the database to use for read-only queries, the replica database is used if it is up to date.
+// Entities written during the request should not be loaded from this database.
+func (a *API) mustDBRead() *gorp.DbMap {
+	db := a.DBConnectionFactory.GetReadDBMap()
+	if db == nil {
+		panic(fmt.Errorf("Database unavailable"))
+	}
+	return db
+}
+
 func (a *API) mustDBWithCtx(ctx context.Context) *gorp.DbMap {
 	db := a.DBConnectionFactory.GetDBMap()
 	db = db.WithContext(ctx).(*gorp.DbMap)
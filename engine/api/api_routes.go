@@ -24,26 +24,30 @@ var (
 func (api *API) InitRouter() {
 	api.Router.URL = api.Config.URL.API
 	api.Router.SetHeaderFunc = DefaultHeaders
-	api.Router.Middlewares = append(api.Router.Middlewares, api.authMiddleware, api.tracingMiddleware, api.maintenanceMiddleware)
+	api.Router.Middlewares = append(api.Router.Middlewares, api.authMiddleware, api.integrationSignatureMiddleware, api.tracingMiddleware, api.maintenanceMiddleware)
 	api.Router.PostMiddlewares = append(api.Router.PostMiddlewares, TracingPostMiddleware)
+	api.Router.Idempotency = api.idempotencyHandler
 
 	r := api.Router
 
 	log.Info(api.Router.Background, "Initializing Events broker")
 	// Initialize event broker
 	api.eventsBroker = &eventsBroker{
-		router:   api.Router,
-		cache:    api.Cache,
-		clients:  make(map[string]*eventsBrokerSubscribe),
-		dbFunc:   api.DBConnectionFactory.GetDBMap,
-		messages: make(chan sdk.Event),
+		router:       api.Router,
+		cache:        api.Cache,
+		clients:      make(map[string]*eventsBrokerSubscribe),
+		queueClients: make(map[string]chan<- sdk.Event),
+		dbFunc:       api.DBConnectionFactory.GetDBMap,
+		messages:     make(chan sdk.Event),
 	}
 	api.eventsBroker.Init(r.Background, api.PanicDump())
 
 	// Auth
 	r.Handle("/auth/driver", ScopeNone(), r.GET(api.getAuthDriversHandler, Auth(false)))
-	r.Handle("/auth/me", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getAuthMe))
+	r.Handle("/auth/me", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getAuthMe, ResponseBody(sdk.AuthCurrentConsumerResponse{})))
 	r.Handle("/auth/scope", ScopeNone(), r.GET(api.getAuthScopesHandler, Auth(false)))
+	r.Handle("/route", ScopeNone(), r.GET(api.getRouteCatalogHandler, Auth(false), ResponseBody([]sdk.APIRoute{})))
+	r.Handle("/openapi.json", ScopeNone(), r.GET(api.getOpenAPIHandler, Auth(false)))
 	r.Handle("/auth/consumer/local/signup", ScopeNone(), r.POST(api.postAuthLocalSignupHandler, Auth(false)))
 	r.Handle("/auth/consumer/local/signin", ScopeNone(), r.POST(api.postAuthLocalSigninHandler, Auth(false), MaintenanceAware()))
 	r.Handle("/auth/consumer/local/verify", ScopeNone(), r.POST(api.postAuthLocalVerifyHandler, Auth(false)))
@@ -58,13 +62,15 @@ func (api *API) InitRouter() {
 	r.Handle("/auth/consumer/signout", ScopeNone(), r.POST(api.postAuthSignoutHandler))
 
 	// Action
-	r.Handle("/action", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionsHandler), r.POST(api.postActionHandler))
+	r.Handle("/action", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionsHandler, ResponseBody([]sdk.Action{})), r.POST(api.postActionHandler, RequestBody(sdk.Action{}), ResponseBody(sdk.Action{})))
 	r.Handle("/action/import", Scope(sdk.AuthConsumerScopeAction), r.POST(api.importActionHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionHandler), r.PUT(api.putActionHandler), r.DELETE(api.deleteActionHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/usage", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionUsageHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/export", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionExportHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/audit", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionAuditHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/audit/{auditID}/rollback", Scope(sdk.AuthConsumerScopeAction), r.POST(api.postActionAuditRollbackHandler))
+	r.Handle("/action/{permGroupName}/{permActionName}/version", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionVersionsHandler), r.POST(api.postActionVersionHandler))
+	r.Handle("/action/{permGroupName}/{permActionName}/version/{version}/yank", Scope(sdk.AuthConsumerScopeAction), r.POST(api.postActionVersionYankHandler))
 	r.Handle("/action/requirement", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionsRequirements, Auth(false))) // FIXME add auth used by hatcheries
 	r.Handle("/project/{permProjectKey}/action", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getActionsForProjectHandler))
 	r.Handle("/group/{permGroupName}/action", Scope(sdk.AuthConsumerScopeGroup), r.GET(api.getActionsForGroupHandler))
@@ -74,6 +80,16 @@ func (api *API) InitRouter() {
 
 	// Admin
 	r.Handle("/admin/maintenance", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postMaintenanceHandler, NeedAdmin(true)))
+	r.Handle("/admin/maintenance/window", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getMaintenanceWindowsHandler, NeedAdmin(true)), r.POST(api.postMaintenanceWindowHandler, NeedAdmin(true)))
+	r.Handle("/admin/maintenance/window/{id}", Scope(sdk.AuthConsumerScopeAdmin), r.DELETE(api.deleteMaintenanceWindowHandler, NeedAdmin(true)))
+	r.Handle("/admin/queue/job/{id}/cancel", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminQueueJobCancelHandler, NeedAdmin(true)))
+	r.Handle("/admin/queue/job/{id}/requeue", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminQueueJobRequeueHandler, NeedAdmin(true)))
+	r.Handle("/admin/queue/job/{id}/diagnostic", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminQueueJobDiagnosticHandler, NeedAdmin(true)))
+	r.Handle("/admin/cache/project/{projectKey}", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminCacheKeysHandler, NeedAdmin(true)), r.DELETE(api.deleteAdminCacheKeysHandler, NeedAdmin(true)))
+	r.Handle("/admin/cache/project/{projectKey}/workflow/{workflowName}", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminCacheKeysHandler, NeedAdmin(true)), r.DELETE(api.deleteAdminCacheKeysHandler, NeedAdmin(true)))
+	r.Handle("/admin/scim/audit", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminSCIMAuditHandler, NeedAdmin(true)))
+	r.Handle("/admin/ldap/sync", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminLDAPSyncHandler, NeedAdmin(true)), r.POST(api.postAdminLDAPSyncHandler, NeedAdmin(true)))
+	r.Handle("/admin/ldap/sync/group/{groupName}", Scope(sdk.AuthConsumerScopeAdmin), r.PUT(api.putAdminLDAPSyncGroupHandler, NeedAdmin(true)))
 	r.Handle("/admin/cds/migration", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminMigrationsHandler, NeedAdmin(true)))
 	r.Handle("/admin/cds/migration/{id}/cancel", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminMigrationCancelHandler, NeedAdmin(true)))
 	r.Handle("/admin/cds/migration/{id}/todo", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminMigrationTodoHandler, NeedAdmin(true)))
@@ -98,6 +114,13 @@ func (api *API) InitRouter() {
 	r.Handle("/admin/services", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminServicesHandler, NeedAdmin(true)))
 	r.Handle("/admin/services/call", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminServiceCallHandler, NeedAdmin(true)), r.POST(api.postAdminServiceCallHandler, NeedAdmin(true)), r.PUT(api.putAdminServiceCallHandler, NeedAdmin(true)), r.DELETE(api.deleteAdminServiceCallHandler, NeedAdmin(true)))
 
+	// Admin integration
+	r.Handle("/admin/integration/apply", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminProjectIntegrationApplyHandler, NeedAdmin(true)))
+
+	// Admin artifact replication
+	r.Handle("/admin/artifact/replication", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminArtifactReplicationHandler, NeedAdmin(true)))
+	r.Handle("/admin/artifact/replication/retry", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminArtifactReplicationRetryHandler, NeedAdmin(true)))
+
 	// Admin database
 	r.Handle("/admin/database/signature", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminDatabaseSignatureResume, NeedAdmin(true)))
 	r.Handle("/admin/database/signature/{entity}/roll/{pk}", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminDatabaseSignatureRollEntityByPrimaryKey, NeedAdmin(true)))
@@ -114,9 +137,11 @@ func (api *API) InitRouter() {
 	r.Handle("/download/{name}/{os}/{arch}", ScopeNone(), r.GET(api.downloadHandler, Auth(false)))
 
 	// Group
-	r.Handle("/group", Scope(sdk.AuthConsumerScopeGroup), r.GET(api.getGroupsHandler), r.POST(api.postGroupHandler))
-	r.Handle("/group/{permGroupName}", Scope(sdk.AuthConsumerScopeGroup), r.GET(api.getGroupHandler), r.PUT(api.putGroupHandler), r.DELETE(api.deleteGroupHandler))
+	r.Handle("/group", Scope(sdk.AuthConsumerScopeGroup), r.GET(api.getGroupsHandler, ResponseBody([]sdk.Group{})), r.POST(api.postGroupHandler, RequestBody(sdk.Group{}), ResponseBody(sdk.Group{})))
+	r.Handle("/group/{permGroupName}", Scope(sdk.AuthConsumerScopeGroup), r.GET(api.getGroupHandler, ResponseBody(sdk.Group{})), r.PUT(api.putGroupHandler), r.DELETE(api.deleteGroupHandler))
 	r.Handle("/group/{permGroupName}/user", Scope(sdk.AuthConsumerScopeGroup), r.POST(api.postGroupUserHandler))
+	r.Handle("/group/{permGroupName}/integrations", Scope(sdk.AuthConsumerScopeGroup), r.GET(api.getGroupIntegrationsHandler), r.POST(api.postGroupIntegrationHandler, Idempotent()))
+	r.Handle("/group/{permGroupName}/integrations/{integrationName}", Scope(sdk.AuthConsumerScopeGroup), r.PUT(api.putGroupIntegrationHandler), r.DELETE(api.deleteGroupIntegrationHandler))
 	r.Handle("/group/{permGroupName}/user/{username}", Scope(sdk.AuthConsumerScopeGroup), r.PUT(api.putGroupUserHandler), r.DELETE(api.deleteGroupUserHandler))
 
 	// Hooks
@@ -124,6 +149,7 @@ func (api *API) InitRouter() {
 
 	// Integration
 	r.Handle("/integration/models", ScopeNone(), r.GET(api.getIntegrationModelsHandler), r.POST(api.postIntegrationModelHandler, NeedAdmin(true)))
+	r.Handle("/integration/models/catalog", ScopeNone(), r.GET(api.getIntegrationModelsCatalogHandler, Auth(false)))
 	r.Handle("/integration/models/{name}", ScopeNone(), r.GET(api.getIntegrationModelHandler), r.PUT(api.putIntegrationModelHandler, NeedAdmin(true)), r.DELETE(api.deleteIntegrationModelHandler, NeedAdmin(true)))
 
 	// Broadcast
@@ -133,7 +159,7 @@ func (api *API) InitRouter() {
 
 	// Overall health
 	r.Handle("/mon/status", ScopeNone(), r.GET(api.statusHandler, Auth(false)))
-	r.Handle("/mon/version", ScopeNone(), r.GET(VersionHandler, Auth(false)))
+	r.Handle("/mon/version", ScopeNone(), r.GET(VersionHandler, ResponseBody(sdk.Version{}), Auth(false)))
 	r.Handle("/mon/db/migrate", ScopeNone(), r.GET(api.getMonDBStatusMigrateHandler, NeedAdmin(true)))
 	r.Handle("/mon/metrics", ScopeNone(), r.GET(service.GetPrometheustMetricsHandler(api), Auth(false)))
 	r.Handle("/mon/metrics/all", ScopeNone(), r.GET(service.GetMetricsHandler, Auth(false)))
@@ -153,23 +179,32 @@ func (api *API) InitRouter() {
 	r.Handle("/bookmarks", ScopeNone(), r.GET(api.getBookmarksHandler))
 
 	// Project
-	r.Handle("/project", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectsHandler, AllowProvider(true), EnableTracing()), r.POST(api.postProjectHandler))
-	r.Handle("/project/{permProjectKey}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectHandler), r.PUT(api.updateProjectHandler), r.DELETE(api.deleteProjectHandler))
+	r.Handle("/project", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectsHandler, ResponseBody([]sdk.Project{}), AllowProvider(true), EnableTracing()), r.POST(api.postProjectHandler, RequestBody(sdk.Project{}), ResponseBody(sdk.Project{})))
+	r.Handle("/project/bootstrap", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postProjectBootstrapHandler, RequestBody(sdk.ProjectBootstrap{}), ResponseBody(sdk.ProjectBootstrapResult{})))
+	r.Handle("/project/{permProjectKey}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectHandler, ResponseBody(sdk.Project{})), r.PUT(api.updateProjectHandler), r.DELETE(api.deleteProjectHandler))
 	r.Handle("/project/{permProjectKey}/labels", Scope(sdk.AuthConsumerScopeProject), r.PUT(api.putProjectLabelsHandler))
 	r.Handle("/project/{permProjectKey}/group", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postGroupInProjectHandler))
 	r.Handle("/project/{permProjectKey}/group/import", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postImportGroupsInProjectHandler))
 	r.Handle("/project/{permProjectKey}/group/{groupName}", Scope(sdk.AuthConsumerScopeProject), r.PUT(api.putGroupRoleOnProjectHandler), r.DELETE(api.deleteGroupFromProjectHandler))
-	r.Handle("/project/{permProjectKey}/variable", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariablesInProjectHandler))
+	r.Handle("/project/{permProjectKey}/variable", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariablesInProjectHandler, ResponseBody([]sdk.Variable{})), r.POST(api.postVariablesBatchInProjectHandler, ResponseBody(sdk.VariableBatchDiff{})))
 	r.Handle("/project/{permProjectKey}/encrypt", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postEncryptVariableHandler))
+	r.Handle("/project/{permProjectKey}/encrypt/key", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectBuiltinPublicKeyHandler))
 	r.Handle("/project/{permProjectKey}/variable/audit", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariablesAuditInProjectnHandler))
 	r.Handle("/project/{permProjectKey}/variable/{name}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariableInProjectHandler), r.POST(api.addVariableInProjectHandler), r.PUT(api.updateVariableInProjectHandler), r.DELETE(api.deleteVariableFromProjectHandler))
 	r.Handle("/project/{permProjectKey}/variable/{name}/audit", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariableAuditInProjectHandler))
-	r.Handle("/project/{permProjectKey}/applications", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getApplicationsHandler, AllowProvider(true)), r.POST(api.addApplicationHandler))
-	r.Handle("/project/{permProjectKey}/integrations", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectIntegrationsHandler), r.POST(api.postProjectIntegrationHandler))
-	r.Handle("/project/{permProjectKey}/integrations/{integrationName}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectIntegrationHandler), r.PUT(api.putProjectIntegrationHandler), r.DELETE(api.deleteProjectIntegrationHandler))
+	r.Handle("/project/{permProjectKey}/applications", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getApplicationsHandler, ResponseBody([]sdk.Application{}), AllowProvider(true)), r.POST(api.addApplicationHandler))
+	r.Handle("/project/{permProjectKey}/integrations", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectIntegrationsHandler, ProjectVerb(sdk.AuthConsumerProjectVerbManageIntegration)), r.POST(api.postProjectIntegrationHandler, Idempotent(), ProjectVerb(sdk.AuthConsumerProjectVerbManageIntegration)))
+	r.Handle("/project/{permProjectKey}/integrations/{integrationName}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectIntegrationHandler, ProjectVerb(sdk.AuthConsumerProjectVerbManageIntegration)), r.PUT(api.putProjectIntegrationHandler, ProjectVerb(sdk.AuthConsumerProjectVerbManageIntegration)), r.DELETE(api.deleteProjectIntegrationHandler, ProjectVerb(sdk.AuthConsumerProjectVerbManageIntegration)))
+	r.Handle("/project/{key}/integrations/{integrationName}/deployments/{deploymentID}/status", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postIntegrationDeploymentStatusHandler, Auth(false), IntegrationSignature()))
 	r.Handle("/project/{permProjectKey}/notifications", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectNotificationsHandler, DEPRECATED))
-	r.Handle("/project/{permProjectKey}/keys", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getKeysInProjectHandler), r.POST(api.addKeyInProjectHandler))
+	r.Handle("/project/{permProjectKey}/quota", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectQuotaHandler), r.PUT(api.putProjectQuotaHandler))
+	r.Handle("/project/{permProjectKey}/cost", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectCostHandler, ResponseBody(sdk.WorkflowCostReport{})))
+	r.Handle("/project/{permProjectKey}/artifact/retention", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectArtifactRetentionHandler), r.PUT(api.putProjectArtifactRetentionHandler))
+	r.Handle("/project/{permProjectKey}/lint", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postProjectLintHandler))
+	r.Handle("/project/{permProjectKey}/keys", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getKeysInProjectHandler, ResponseBody([]sdk.ProjectKey{})), r.POST(api.addKeyInProjectHandler))
 	r.Handle("/project/{permProjectKey}/keys/{name}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteKeyInProjectHandler))
+	r.Handle("/project/{permProjectKey}/webhook/secret", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectWebHookSecretsHandler, ResponseBody([]sdk.ProjectWebHookSecret{})), r.DELETE(api.deleteProjectWebHookSecretsHandler))
+	r.Handle("/project/{permProjectKey}/webhook/secret/rotate", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postProjectWebHookSecretRotateHandler))
 
 	// As Code
 	r.Handle("/project/{key}/ascode/events/resync", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postResyncPRAsCodeHandler, EnableTracing()))
@@ -187,7 +222,7 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/keys/{name}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteKeyInApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/vcsinfos", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getApplicationVCSInfosHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/clone", Scope(sdk.AuthConsumerScopeProject), r.POST(api.cloneApplicationHandler))
-	r.Handle("/project/{permProjectKey}/application/{applicationName}/variable", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariablesInApplicationHandler))
+	r.Handle("/project/{permProjectKey}/application/{applicationName}/variable", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariablesInApplicationHandler), r.POST(api.postVariablesBatchInApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/variable/audit", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariablesAuditInApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/variable/{name}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariableInApplicationHandler), r.POST(api.addVariableInApplicationHandler), r.PUT(api.updateVariableInApplicationHandler), r.DELETE(api.deleteVariableFromApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/variable/{name}/audit", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariableAuditInApplicationHandler))
@@ -198,7 +233,7 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/metadata/{metadata}", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postApplicationMetadataHandler, AllowProvider(true)))
 
 	// Pipeline
-	r.Handle("/project/{permProjectKey}/pipeline", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getPipelinesHandler), r.POST(api.addPipelineHandler))
+	r.Handle("/project/{permProjectKey}/pipeline", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getPipelinesHandler, ResponseBody([]sdk.Pipeline{})), r.POST(api.addPipelineHandler))
 	r.Handle("/project/{permProjectKey}/pipeline/{pipelineKey}/parameter", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getParametersInPipelineHandler))
 	r.Handle("/project/{permProjectKey}/pipeline/{pipelineKey}/parameter/{name}", Scope(sdk.AuthConsumerScopeProject), r.POST(api.addParameterInPipelineHandler), r.PUT(api.updateParameterInPipelineHandler), r.DELETE(api.deleteParameterFromPipelineHandler))
 	r.Handle("/project/{permProjectKey}/pipeline/{pipelineKey}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getPipelineHandler), r.PUT(api.updatePipelineHandler), r.DELETE(api.deletePipelineHandler))
@@ -225,18 +260,30 @@ func (api *API) InitRouter() {
 	r.Handle("/workflow/artifact/{hash}", ScopeNone(), r.GET(api.downloadworkflowArtifactDirectHandler, Auth(false)))
 
 	r.Handle("/project/{permProjectKey}/workflows", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowHandler, EnableTracing()), r.GET(api.getWorkflowsHandler, AllowProvider(true), EnableTracing()))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowHandler, AllowProvider(true), EnableTracing()), r.PUT(api.putWorkflowHandler, EnableTracing()), r.DELETE(api.deleteWorkflowHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowHandler, ResponseBody(sdk.Workflow{}), AllowProvider(true), EnableTracing()), r.PUT(api.putWorkflowHandler, EnableTracing()), r.DELETE(api.deleteWorkflowHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/eventsintegration/{integrationID}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteWorkflowEventsIntegrationHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/icon", Scope(sdk.AuthConsumerScopeProject), r.PUT(api.putWorkflowIconHandler), r.DELETE(api.deleteWorkflowIconHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/ascode", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowAsCodeHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/ascode/{uuid}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowAsCodeHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/diff", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowDiffHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/label", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowLabelHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/label/{labelID}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteWorkflowLabelHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/rollback/{auditID}", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowRollbackHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/versions", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowVersionsHandler, ResponseBody([]sdk.WorkflowVersion{})))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/versions/{version}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowVersionHandler, ResponseBody(sdk.WorkflowVersion{})))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/versions/{version}/rollback", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowVersionRollbackHandler, ResponseBody(sdk.Workflow{})))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/notifications/conditions", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowNotificationsConditionsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/groups", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowGroupHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/groups/{groupName}", Scope(sdk.AuthConsumerScopeProject), r.PUT(api.putWorkflowGroupHandler), r.DELETE(api.deleteWorkflowGroupHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/webhooks", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowWebhooksHandler), r.POST(api.postWorkflowWebhookHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/webhooks/deliveries", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowWebhookDeliveriesHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/webhooks/deliveries/{deliveryID}/replay", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowWebhookDeliveryReplayHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/webhooks/deadletter", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowWebhookDeadLetterHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/webhooks/deadletter/replay", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowWebhookDeadLetterReplayHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/webhooks/{webhookID}", Scope(sdk.AuthConsumerScopeProject), r.PUT(api.putWorkflowWebhookHandler), r.DELETE(api.deleteWorkflowWebhookHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/hooks/{uuid}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowHookHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/hooks/{uuid}/secret", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowHookSecretHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/hooks/{uuid}/pathfilters/check", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowHookPathFiltersCheckHandler))
 	r.Handle("/project/{key}/workflow/{permWorkflowName}/node/{nodeID}/hook/model", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowHookModelsHandler))
 	r.Handle("/project/{key}/workflow/{permWorkflowName}/node/{nodeID}/outgoinghook/model", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowOutgoingHookModelsHandler))
 
@@ -245,6 +292,7 @@ func (api *API) InitRouter() {
 
 	// Preview workflows
 	r.Handle("/project/{permProjectKey}/preview/workflows", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowPreviewHandler))
+	r.Handle("/project/{permProjectKey}/preview/workflows/integrations", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowPreviewIntegrationsHandler))
 	// Import workflows
 	r.Handle("/project/{permProjectKey}/import/workflows", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowImportHandler))
 	// Import workflows (ONLY USE FOR UI EDIT AS CODE)
@@ -257,24 +305,48 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/push/workflows", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowPushHandler, EnableTracing()))
 
 	// Workflows run
-	r.Handle("/project/{permProjectKey}/runs", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowAllRunsHandler, EnableTracing()))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifact/{artifactId}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getDownloadArtifactHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunsHandler, EnableTracing()), r.POSTEXECUTE(api.postWorkflowRunHandler /*, AllowServices(true)*/, EnableTracing()))
+	r.Handle("/project/{permProjectKey}/runs", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowAllRunsHandler, EnableTracing(), ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{permProjectKey}/runs/search", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowRunsSearchHandler, EnableTracing(), ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifact/{artifactId}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getDownloadArtifactHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifact/{artifactId}/pin", Scope(sdk.AuthConsumerScopeRun), r.POST(api.postWorkflowArtifactPinHandler, MaintenanceAware()), r.DELETE(api.deleteWorkflowArtifactPinHandler, MaintenanceAware()))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunsHandler, ResponseBody([]sdk.WorkflowRun{}), EnableTracing(), ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)), r.POSTEXECUTE(api.postWorkflowRunHandler, RequestBody(sdk.WorkflowRunPostHandlerOption{}), ResponseBody(sdk.WorkflowRun{}) /*, AllowServices(true)*/, EnableTracing(), Idempotent(), ProjectVerb(sdk.AuthConsumerProjectVerbRunWorkflow)))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/branch/{branch}", Scope(sdk.AuthConsumerScopeRun), r.DELETE(api.deleteWorkflowRunsBranchHandler /*, NeedService()*/))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/latest", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getLatestWorkflowRunHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/tags", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunTagsHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/num", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunNumHandler), r.POST(api.postWorkflowRunNumHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunHandler /*, AllowServices(true)*/, EnableTracing()), r.DELETE(api.deleteWorkflowRunHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.stopWorkflowRunHandler, EnableTracing(), MaintenanceAware()))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/vcs/resync", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.postResyncVCSWorkflowRunHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/artifacts", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunArtifactsHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.stopWorkflowNodeRunHandler, MaintenanceAware()))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeID}/history", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunHistoryHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/{nodeName}/commits", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowCommitsHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/info", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunJobSpawnInfosHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/log/service", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunJobServiceLogsHandler))
-	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/step/{stepOrder}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunJobStepHandler))
+	// Badges are public to be embedded in READMEs, they only expose the status of the latest run
+	r.Handle("/project/{key}/workflows/{workflowName}/badge.svg", ScopeNone(), r.GET(api.getWorkflowRunBadgeHandler, Auth(false)))
+	r.Handle("/project/{key}/workflows/{workflowName}/badge", ScopeNone(), r.GET(api.getWorkflowRunShieldHandler, Auth(false)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/promote", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.postWorkflowPromoteHandler, ProjectVerb(sdk.AuthConsumerProjectVerbRunWorkflow)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/mutex", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowMutexesHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/mutex/{nodeName}/release", Scope(sdk.AuthConsumerScopeRun), r.POST(api.postWorkflowMutexReleaseHandler, NeedAdmin(true)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/cost", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowCostHandler, ResponseBody(sdk.WorkflowCostReport{}), ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/latest", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getLatestWorkflowRunHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/tags", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunTagsHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/num", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunNumHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)), r.POST(api.postWorkflowRunNumHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/form", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunFormHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/explain", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.postWorkflowRunExplainHandler, RequestBody(sdk.WorkflowRunPostHandlerOption{}), ResponseBody(sdk.WorkflowRunExplain{}), ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunHandler, ResponseBody(sdk.WorkflowRun{}) /*, AllowServices(true)*/, EnableTracing(), ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)), r.DELETE(api.deleteWorkflowRunHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.stopWorkflowRunHandler, EnableTracing(), MaintenanceAware(), ProjectVerb(sdk.AuthConsumerProjectVerbRunWorkflow)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/vcs/resync", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.postResyncVCSWorkflowRunHandler, ProjectVerb(sdk.AuthConsumerProjectVerbRunWorkflow)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/export", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunExportHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/cost", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunCostHandler, ResponseBody(sdk.WorkflowCostReport{}), ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/artifacts", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunArtifactsHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/compare", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunDurationComparisonHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/annotations", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunAnnotationsHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)), r.POSTEXECUTE(api.postWorkflowRunAnnotationHandler, MaintenanceAware(), ProjectVerb(sdk.AuthConsumerProjectVerbRunWorkflow)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/maintenance/override", Scope(sdk.AuthConsumerScopeRun), r.POST(api.postWorkflowRunMaintenanceOverrideHandler, NeedAdmin(true), MaintenanceAware()))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/annotations/{annotationKey}", Scope(sdk.AuthConsumerScopeRun), r.DELETE(api.deleteWorkflowRunAnnotationHandler, MaintenanceAware()))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/results", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunResultsHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/release/targets", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunReleaseTargetsHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/secrets/usage", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunSecretUsagesHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/fingerprints", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunJobFingerprintsHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/fingerprints/compare", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunJobFingerprintsCompareHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.stopWorkflowNodeRunHandler, MaintenanceAware(), ProjectVerb(sdk.AuthConsumerProjectVerbRunWorkflow)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeID}/history", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunHistoryHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/{nodeName}/commits", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowCommitsHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/info", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunJobSpawnInfosHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/log/service", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunJobServiceLogsHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/log/download", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunJobLogsDownloadHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/step/{stepOrder}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunJobStepHandler, ProjectVerb(sdk.AuthConsumerProjectVerbReadRun)))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/debug", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunJobDebugHandler, NeedPermission(sdk.PermissionReadWriteExecute), MaintenanceAware(), ProjectVerb(sdk.AuthConsumerProjectVerbRunWorkflow)))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/node/{nodeID}/triggers/condition", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowTriggerConditionHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/hook/triggers/condition", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowTriggerHookConditionHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/triggers/condition", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowTriggerConditionHandler))
@@ -283,15 +355,17 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/hooks/{hookRunID}/details", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowJobHookDetailsHandler /*, NeedService()*/))
 
 	// Environment
-	r.Handle("/project/{permProjectKey}/environment", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getEnvironmentsHandler), r.POST(api.addEnvironmentHandler))
+	r.Handle("/project/{permProjectKey}/environment", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getEnvironmentsHandler, ResponseBody([]sdk.Environment{})), r.POST(api.addEnvironmentHandler))
 	r.Handle("/project/{permProjectKey}/environment/import", Scope(sdk.AuthConsumerScopeProject), r.POST(api.importNewEnvironmentHandler, DEPRECATED))
 	r.Handle("/project/{permProjectKey}/environment/import/{environmentName}", Scope(sdk.AuthConsumerScopeProject), r.POST(api.importIntoEnvironmentHandler, DEPRECATED))
 	r.Handle("/project/{permProjectKey}/environment/{environmentName}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getEnvironmentHandler), r.PUT(api.updateEnvironmentHandler), r.DELETE(api.deleteEnvironmentHandler))
 	r.Handle("/project/{permProjectKey}/environment/{environmentName}/usage", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getEnvironmentUsageHandler))
 	r.Handle("/project/{permProjectKey}/environment/{environmentName}/keys", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getKeysInEnvironmentHandler), r.POST(api.addKeyInEnvironmentHandler))
 	r.Handle("/project/{permProjectKey}/environment/{environmentName}/keys/{name}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteKeyInEnvironmentHandler))
+	r.Handle("/project/{permProjectKey}/environment/{environmentName}/integration", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getEnvironmentIntegrationHandler), r.PUT(api.putEnvironmentIntegrationHandler), r.DELETE(api.deleteEnvironmentIntegrationHandler))
+	r.Handle("/project/{permProjectKey}/environment/{environmentName}/deployment", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getEnvironmentDeploymentsHandler))
 	r.Handle("/project/{permProjectKey}/environment/{environmentName}/clone/{cloneName}", Scope(sdk.AuthConsumerScopeProject), r.POST(api.cloneEnvironmentHandler))
-	r.Handle("/project/{permProjectKey}/environment/{environmentName}/variable", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariablesInEnvironmentHandler))
+	r.Handle("/project/{permProjectKey}/environment/{environmentName}/variable", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariablesInEnvironmentHandler), r.POST(api.postVariablesBatchInEnvironmentHandler))
 	r.Handle("/project/{permProjectKey}/environment/{environmentName}/variable/{name}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariableInEnvironmentHandler), r.POST(api.addVariableInEnvironmentHandler), r.PUT(api.updateVariableInEnvironmentHandler), r.DELETE(api.deleteVariableFromEnvironmentHandler))
 	r.Handle("/project/{permProjectKey}/environment/{environmentName}/variable/{name}/audit", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariableAuditInEnvironmentHandler))
 
@@ -314,17 +388,25 @@ func (api *API) InitRouter() {
 	//Workflow queue
 	r.Handle("/queue/workflows", Scope(sdk.AuthConsumerScopeRun, sdk.AuthConsumerScopeRunExecution), r.GET(api.getWorkflowJobQueueHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/count", Scope(sdk.AuthConsumerScopeRun), r.GET(api.countWorkflowJobQueueHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/stream", Scope(sdk.AuthConsumerScopeRun, sdk.AuthConsumerScopeRunExecution), r.GET(api.getWorkflowJobQueueStreamHandler))
 	r.Handle("/queue/workflows/{id}/take", Scope(sdk.AuthConsumerScopeRunExecution), r.POST(api.postTakeWorkflowJobHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/book", Scope(sdk.AuthConsumerScopeRunExecution), r.POST(api.postBookWorkflowJobHandler, EnableTracing(), MaintenanceAware()), r.DELETE(api.deleteBookWorkflowJobHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/infos", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getWorkflowJobHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/vulnerability", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postVulnerabilityReportHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/spawn/infos", Scope(sdk.AuthConsumerScopeRunExecution), r.POST(api.postSpawnInfosWorkflowJobHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/infraerror", Scope(sdk.AuthConsumerScopeRunExecution), r.POST(api.postWorkflowJobInfraErrorHandler, EnableTracing(), MaintenanceAware(), RequestBody(sdk.JobInfraError{})))
 	r.Handle("/queue/workflows/{permJobID}/result", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobResultHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/log", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobLogsHandler, MaintenanceAware()))
 	r.Handle("/queue/workflows/log/service", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(r.Asynchronous(api.postWorkflowJobServiceLogsHandler, 1), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/coverage", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobCoverageResultsHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/test", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobTestsResultsHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/tag", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobTagsHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/annotation", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobAnnotationHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/run-result", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobRunResultHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/release/targets", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobReleaseTargetsHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/release/targets/{integrationName}", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobReleaseTargetStatusHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/metrics", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobMetricsHandler, EnableTracing(), MaintenanceAware(), RequestBody(sdk.JobMetrics{})))
+	r.Handle("/queue/workflows/{permJobID}/debug", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getWorkflowJobDebugHandler, NeedPermission(sdk.PermissionReadExecute), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/step", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobStepStatusHandler, EnableTracing(), MaintenanceAware()))
 
 	r.Handle("/variable/type", ScopeNone(), r.GET(api.getVariableTypeHandler))
@@ -362,28 +444,35 @@ func (api *API) InitRouter() {
 	r.Handle("/config/vcs", ScopeNone(), r.GET(api.ConfigVCShandler))
 
 	// Users
-	r.Handle("/user", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUsersHandler))
+	r.Handle("/user", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUsersHandler, ResponseBody([]sdk.AuthentifiedUser{})))
 	r.Handle("/user/favorite", Scope(sdk.AuthConsumerScopeUser), r.POST(api.postUserFavoriteHandler))
 	r.Handle("/user/schema", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserJSONSchema))
 	r.Handle("/user/timeline", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getTimelineHandler))
 	r.Handle("/user/timeline/filter", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getTimelineFilterHandler), r.POST(api.postTimelineFilterHandler))
+	r.Handle("/user/search/run", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserWorkflowRunSearchesHandler), r.POST(api.postUserWorkflowRunSearchHandler))
+	r.Handle("/user/search/run/{id}", Scope(sdk.AuthConsumerScopeUser), r.DELETE(api.deleteUserWorkflowRunSearchHandler))
+	r.Handle("/user/dashboard", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserDashboardsHandler), r.POST(api.postUserDashboardHandler))
+	r.Handle("/user/dashboard/{id}", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserDashboardHandler), r.PUT(api.putUserDashboardHandler), r.DELETE(api.deleteUserDashboardHandler))
+	r.Handle("/user/dashboard/{id}/data", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserDashboardDataHandler))
 	r.Handle("/user/{permUsernamePublic}", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserHandler), r.PUT(api.putUserHandler), r.DELETE(api.deleteUserHandler))
 	r.Handle("/user/{permUsernamePublic}/group", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserGroupsHandler))
 	r.Handle("/user/{permUsername}/contact", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserContactsHandler))
 	r.Handle("/user/{permUsername}/auth/consumer", Scope(sdk.AuthConsumerScopeAccessToken), r.GET(api.getConsumersByUserHandler), r.POST(api.postConsumerByUserHandler))
+	r.Handle("/user/{permUsername}/auth/consumer/browser", Scope(sdk.AuthConsumerScopeAccessToken), r.POST(api.postBrowserTokenByUserHandler))
 	r.Handle("/user/{permUsername}/auth/consumer/{permConsumerID}", Scope(sdk.AuthConsumerScopeAccessToken), r.DELETE(api.deleteConsumerByUserHandler))
 	r.Handle("/user/{permUsername}/auth/consumer/{permConsumerID}/regen", Scope(sdk.AuthConsumerScopeAccessToken), r.POST(api.postConsumerRegenByUserHandler))
 	r.Handle("/user/{permUsername}/auth/session", Scope(sdk.AuthConsumerScopeAccessToken), r.GET(api.getSessionsByUserHandler))
 	r.Handle("/user/{permUsername}/auth/session/{permSessionID}", Scope(sdk.AuthConsumerScopeAccessToken), r.DELETE(api.deleteSessionByUserHandler))
 
 	// Workers
-	r.Handle("/worker", Scope(sdk.AuthConsumerScopeAdmin, sdk.AuthConsumerScopeWorker, sdk.AuthConsumerScopeHatchery), r.GET(api.getWorkersHandler))
+	r.Handle("/worker", Scope(sdk.AuthConsumerScopeAdmin, sdk.AuthConsumerScopeWorker, sdk.AuthConsumerScopeHatchery), r.GET(api.getWorkersHandler, ResponseBody([]sdk.Worker{})))
 	r.Handle("/worker/refresh", Scope(sdk.AuthConsumerScopeWorker), r.POST(api.postRefreshWorkerHandler, MaintenanceAware()))
 	r.Handle("/worker/waiting", Scope(sdk.AuthConsumerScopeWorker), r.POST(api.workerWaitingHandler, MaintenanceAware()))
 	r.Handle("/worker/{id}/disable", Scope(sdk.AuthConsumerScopeAdmin, sdk.AuthConsumerScopeHatchery), r.POST(api.disableWorkerHandler, MaintenanceAware()))
+	r.Handle("/worker/{id}/drain", Scope(sdk.AuthConsumerScopeAdmin, sdk.AuthConsumerScopeHatchery), r.POST(api.drainWorkerHandler))
 
 	// Worker models
-	r.Handle("/worker/model", Scope(sdk.AuthConsumerScopeWorkerModel), r.POST(api.postWorkerModelHandler), r.GET(api.getWorkerModelsHandler))
+	r.Handle("/worker/model", Scope(sdk.AuthConsumerScopeWorkerModel), r.POST(api.postWorkerModelHandler), r.GET(api.getWorkerModelsHandler, ResponseBody([]sdk.Model{})))
 	r.Handle("/worker/model/enabled", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getWorkerModelsEnabledHandler))
 	r.Handle("/worker/model/type", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getWorkerModelTypesHandler))
 	r.Handle("/worker/model/capability/type", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getRequirementTypesHandler))
@@ -393,6 +482,8 @@ func (api *API) InitRouter() {
 	r.Handle("/worker/model/{permGroupName}/{permModelName}", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getWorkerModelHandler), r.PUT(api.putWorkerModelHandler), r.DELETE(api.deleteWorkerModelHandler))
 	r.Handle("/worker/model/{permGroupName}/{permModelName}/export", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getWorkerModelExportHandler))
 	r.Handle("/worker/model/{permGroupName}/{permModelName}/usage", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getWorkerModelUsageHandler))
+	r.Handle("/worker/model/{permGroupName}/{permModelName}/health", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getWorkerModelHealthHandler))
+	r.Handle("/worker/model/{permGroupName}/{permModelName}/quarantine", Scope(sdk.AuthConsumerScopeWorkerModel), r.DELETE(api.deleteWorkerModelQuarantineHandler))
 	r.Handle("/worker/model/{permGroupName}/{permModelName}/book", Scope(sdk.AuthConsumerScopeWorkerModel), r.PUT(api.putBookWorkerModelHandler, MaintenanceAware()))
 	r.Handle("/worker/model/{permGroupName}/{permModelName}/error", Scope(sdk.AuthConsumerScopeWorkerModel), r.PUT(api.putSpawnErrorWorkerModelHandler, MaintenanceAware()))
 
@@ -409,6 +500,13 @@ func (api *API) InitRouter() {
 	// Feature
 	r.Handle("/feature/clean", ScopeNone(), r.POST(api.cleanFeatureHandler, NeedToken("X-Izanami-Token", api.Config.Features.Izanami.Token)))
 
+	// SCIM provisioning
+	scimToken := NeedToken("Authorization", "Bearer "+api.Config.Auth.SCIM.Token)
+	r.Handle("/scim/v2/Users", ScopeNone(), r.GET(api.getSCIMUsersHandler, scimToken), r.POST(api.postSCIMUserHandler, scimToken))
+	r.Handle("/scim/v2/Users/{id}", ScopeNone(), r.GET(api.getSCIMUserHandler, scimToken), r.PUT(api.putSCIMUserHandler, scimToken), r.PATCH(api.patchSCIMUserHandler, scimToken), r.DELETE(api.deleteSCIMUserHandler, scimToken))
+	r.Handle("/scim/v2/Groups", ScopeNone(), r.GET(api.getSCIMGroupsHandler, scimToken), r.POST(api.postSCIMGroupHandler, scimToken))
+	r.Handle("/scim/v2/Groups/{id}", ScopeNone(), r.GET(api.getSCIMGroupHandler, scimToken), r.PUT(api.putSCIMGroupHandler, scimToken), r.PATCH(api.patchSCIMGroupHandler, scimToken), r.DELETE(api.deleteSCIMGroupHandler, scimToken))
+
 	// Engine µServices
 	r.Handle("/services/register", Scope(sdk.AuthConsumerScopeService), r.POST(api.postServiceRegisterHandler, MaintenanceAware()))
 	r.Handle("/services/heartbeat", Scope(sdk.AuthConsumerScopeService), r.POST(api.postServiceHearbeatHandler))
@@ -427,9 +525,13 @@ func (api *API) InitRouter() {
 	r.Handle("/template/{groupName}/{templateSlug}/instance/{instanceID}", Scope(sdk.AuthConsumerScopeTemplate), r.DELETE(api.deleteTemplateInstanceHandler))
 	r.Handle("/template/{groupName}/{templateSlug}/usage", Scope(sdk.AuthConsumerScopeTemplate), r.GET(api.getTemplateUsageHandler))
 	r.Handle("/project/{key}/workflow/{permWorkflowName}/templateInstance", Scope(sdk.AuthConsumerScopeTemplate), r.GET(api.getTemplateInstanceHandler))
+	r.Handle("/project/{key}/workflow/{permWorkflowName}/templateInstance/drift", Scope(sdk.AuthConsumerScopeTemplate), r.GET(api.getTemplateInstanceDriftHandler))
+	r.Handle("/project/{key}/workflow/{permWorkflowName}/templateInstance/reapply", Scope(sdk.AuthConsumerScopeTemplate), r.POST(api.postTemplateInstanceReapplyHandler))
 
 	//Not Found handler
 	r.Mux.NotFoundHandler = http.HandlerFunc(NotFoundHandler)
 
 	r.computeScopeDetails()
+	r.computeRouteCatalog()
+	r.computeOpenAPI()
 }
@@ -64,8 +64,8 @@ func (api *API) postApplicationDeploymentStrategyConfigHandler() service.Handler
 			return sdk.WrapError(sdk.ErrNotFound, "postApplicationDeploymentStrategyConfigHandler> integration not found on project")
 		}
 
-		if !pf.Model.Deployment {
-			return sdk.WrapError(sdk.ErrForbidden, "postApplicationDeploymentStrategyConfigHandler> integration doesn't support deployment")
+		if pf.Model.PluginType() == "" {
+			return sdk.WrapError(sdk.ErrForbidden, "postApplicationDeploymentStrategyConfigHandler> integration doesn't support deployment or release")
 		}
 
 		app, err := application.LoadByName(tx, key, appName, application.LoadOptions.WithClearDeploymentStrategies)
@@ -133,8 +133,8 @@ func (api *API) deleteApplicationDeploymentStrategyConfigHandler() service.Handl
 			return sdk.WrapError(sdk.ErrNotFound, "deleteApplicationDeploymentStrategyConfigHandler> integration not found on project")
 		}
 
-		if !pf.Model.Deployment {
-			return sdk.WrapError(sdk.ErrForbidden, "deleteApplicationDeploymentStrategyConfigHandler> integration doesn't support deployment")
+		if pf.Model.PluginType() == "" {
+			return sdk.WrapError(sdk.ErrForbidden, "deleteApplicationDeploymentStrategyConfigHandler> integration doesn't support deployment or release")
 		}
 
 		app, err := application.LoadByName(tx, key, appName, application.LoadOptions.WithDeploymentStrategies)
@@ -6,7 +6,6 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/ovh/cds/engine/api/application"
-	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/exportentities"
@@ -27,7 +26,12 @@ func (api *API) getApplicationExportHandler() service.Handler {
 			return err
 		}
 
-		app, err := application.Export(api.mustDB(), api.Cache, key, appName, project.EncryptWithBuiltinKey)
+		encryptFunc, err := api.exportEncryptFunc(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		app, err := application.Export(api.mustDB(), api.Cache, key, appName, encryptFunc)
 		if err != nil {
 			return sdk.WithStack(err)
 		}
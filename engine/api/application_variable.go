@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/application"
@@ -224,3 +225,74 @@ func (api *API) addVariableInApplicationHandler() service.Handler {
 		return service.WriteJSON(w, newVar, http.StatusOK)
 	}
 }
+
+func (api *API) postVariablesBatchInApplicationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		appName := vars["applicationName"]
+
+		var batch sdk.VariableBatch
+		if err := service.UnmarshalBody(r, &batch); err != nil {
+			return err
+		}
+		for _, v := range batch.Update {
+			if v.Type == sdk.KeyVariable {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "key variable %s can't be updated", v.Name)
+			}
+		}
+
+		app, err := application.LoadByName(api.mustDB(), key, appName)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load application %s", appName)
+		}
+		if app.FromRepository != "" {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		diff, err := applyVariableBatch(tx, variableBatchStore{
+			load: func(db gorp.SqlExecutor, name string) (*sdk.Variable, error) {
+				v, err := application.LoadVariable(db, app.ID, name)
+				if err != nil {
+					return nil, err
+				}
+				return application.LoadVariableWithDecryption(db, app.ID, v.ID, name)
+			},
+			insert: func(db gorp.SqlExecutor, v *sdk.Variable) error {
+				return application.InsertVariable(db, app.ID, v, getAPIConsumer(ctx))
+			},
+			update: func(db gorp.SqlExecutor, v *sdk.Variable, before *sdk.Variable) error {
+				return application.UpdateVariable(db, app.ID, v, before, getAPIConsumer(ctx))
+			},
+			delete: func(db gorp.SqlExecutor, v *sdk.Variable) error {
+				return application.DeleteVariable(db, app.ID, v, getAPIConsumer(ctx))
+			},
+		}, batch)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		for _, v := range diff.Deleted {
+			event.PublishDeleteVariableApplication(ctx, key, *app, v, getAPIConsumer(ctx))
+		}
+		for _, c := range diff.Updated {
+			event.PublishUpdateVariableApplication(ctx, key, *app, c.After, c.Before, getAPIConsumer(ctx))
+		}
+		for _, v := range diff.Created {
+			event.PublishAddVariableApplication(ctx, key, *app, v, getAPIConsumer(ctx))
+		}
+
+		diff.HidePasswords()
+		return service.WriteJSON(w, diff, http.StatusOK)
+	}
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getProjectArtifactRetentionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(api.mustDBRead(), key)
+		if err != nil {
+			return err
+		}
+
+		policy, err := project.LoadArtifactRetention(ctx, api.mustDBRead(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, policy, http.StatusOK)
+	}
+}
+
+func (api *API) putProjectArtifactRetentionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		var policy sdk.ProjectArtifactRetention
+		if err := service.UnmarshalBody(r, &policy); err != nil {
+			return err
+		}
+		if err := policy.IsValid(); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		policy.ProjectID = p.ID
+		if err := project.UpsertArtifactRetention(tx, &policy); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, policy, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowArtifactPinHandler() service.Handler {
+	return api.updateWorkflowArtifactPinned(true)
+}
+
+func (api *API) deleteWorkflowArtifactPinHandler() service.Handler {
+	return api.updateWorkflowArtifactPinned(false)
+}
+
+// updateWorkflowArtifactPinned returns a handler that pins or unpins an artifact of a workflow, pinned artifacts are
+// kept indefinitely whatever the artifact retention of the project and the history length of the workflow.
+func (api *API) updateWorkflowArtifactPinned(pinned bool) service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		id, err := requestVarInt(r, "artifactId")
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrInvalidID, "invalid artifact ID")
+		}
+
+		proj, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load projet")
+		}
+
+		work, err := workflow.Load(ctx, api.mustDB(), api.Cache, *proj, name, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow")
+		}
+
+		art, err := workflow.LoadArtifactByIDs(api.mustDB(), work.ID, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load artifact")
+		}
+
+		if err := workflow.UpdateArtifactPinned(api.mustDB(), art.ID, pinned); err != nil {
+			return err
+		}
+		art.Pinned = pinned
+
+		return service.WriteJSON(w, art, http.StatusOK)
+	}
+}
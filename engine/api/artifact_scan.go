@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"io"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/artifactscan"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// scanArtifact scans the content of an artifact with the artifact scan integration of its project, if any. The scan
+// status and report are set on the artifact, an infected artifact is quarantined: it is stored but can't be
+// downloaded. The content is only opened if the project has an artifact scan integration. A scanner error doesn't
+// fail the upload, it is given by the scan status.
+func (api *API) scanArtifact(ctx context.Context, projectID int64, nodeJobRunID int64, art *sdk.WorkflowNodeRunArtifact, open func() (io.ReadCloser, error)) error {
+	pp, err := loadProjectArtifactScanIntegration(api.mustDB(), projectID)
+	if err != nil {
+		return err
+	}
+	if pp == nil {
+		return nil
+	}
+
+	r, err := open()
+	if err != nil {
+		return err
+	}
+	res, err := artifactscan.Scan(ctx, pp.Config, r)
+	_ = r.Close()
+	if err != nil {
+		log.Error(ctx, "scanArtifact> cannot scan artifact %s with integration %s: %v", art.Name, pp.Name, err)
+		art.ScanStatus = sdk.ArtifactScanStatusError
+		art.ScanReport = sdk.ExtractHTTPError(err, "").Message
+		return nil
+	}
+	if !res.Infected {
+		art.ScanStatus = sdk.ArtifactScanStatusClean
+		return nil
+	}
+
+	log.Warning(ctx, "scanArtifact> artifact %s of node run %d is infected: %s", art.Name, art.WorkflowNodeRunID, res.Report)
+	art.ScanStatus = sdk.ArtifactScanStatusInfected
+	art.ScanReport = res.Report
+
+	info := sdk.SpawnInfo{Message: sdk.SpawnMsg{
+		ID:   sdk.MsgSpawnInfoArtifactInfected.ID,
+		Args: []interface{}{art.Name, res.Report},
+	}}
+	return workflow.AddSpawnInfosNodeJobRun(api.mustDB(), art.WorkflowNodeRunID, nodeJobRunID, []sdk.SpawnInfo{info})
+}
+
+// loadProjectArtifactScanIntegration returns the artifact scan integration of a project with clear passwords, or nil
+// if the project has none.
+func loadProjectArtifactScanIntegration(db gorp.SqlExecutor, projectID int64) (*sdk.ProjectIntegration, error) {
+	pps, err := integration.LoadIntegrationsByProjectIDWithClearPassword(db, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pps {
+		if pps[i].Model.Name != sdk.ArtifactScanIntegrationModel {
+			continue
+		}
+		pp := pps[i]
+		if err := integration.InterpolateConfig(db, &pp); err != nil {
+			return nil, err
+		}
+		return &pp, nil
+	}
+	return nil, nil
+}
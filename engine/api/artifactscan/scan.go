@@ -0,0 +1,196 @@
+package artifactscan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	chunkSize      = 64 * 1024
+	defaultTimeout = 60 * time.Second
+)
+
+// Result is the result of the scan of an artifact.
+type Result struct {
+	Infected bool
+	Report   string
+}
+
+// Scan streams given content to the scanner of an artifact scan integration config.
+func Scan(ctx context.Context, config sdk.IntegrationConfig, r io.Reader) (Result, error) {
+	address := config["address"].Value
+	if address == "" {
+		return Result{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing address in artifact scan integration config")
+	}
+
+	timeout := defaultTimeout
+	if t, err := strconv.Atoi(config["timeout"].Value); err == nil && t > 0 {
+		timeout = time.Duration(t) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return Result{}, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrUnknownError, "cannot connect to artifact scanner %s", address))
+	}
+	defer conn.Close() // nolint
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	switch t := config["type"].Value; t {
+	case sdk.ArtifactScanIntegrationTypeClamAV, "":
+		return scanClamAV(conn, r)
+	case sdk.ArtifactScanIntegrationTypeICAP:
+		return scanICAP(conn, address, config["service"].Value, r)
+	default:
+		return Result{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid artifact scan integration type %q, should be %s or %s", t,
+			sdk.ArtifactScanIntegrationTypeClamAV, sdk.ArtifactScanIntegrationTypeICAP)
+	}
+}
+
+// scanClamAV sends the content with the INSTREAM command of clamd, as chunks prefixed by their size.
+func scanClamAV(conn net.Conn, r io.Reader) (Result, error) {
+	w := bufio.NewWriter(conn)
+	if _, err := w.WriteString("zINSTREAM\x00"); err != nil {
+		return Result{}, sdk.WithStack(err)
+	}
+
+	buf := make([]byte, chunkSize)
+	size := make([]byte, 4)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := w.Write(size); err != nil {
+				return Result{}, sdk.WithStack(err)
+			}
+			if _, err := w.Write(buf[:n]); err != nil {
+				return Result{}, sdk.WithStack(err)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Result{}, sdk.WithStack(err)
+		}
+	}
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := w.Write(size); err != nil {
+		return Result{}, sdk.WithStack(err)
+	}
+	if err := w.Flush(); err != nil {
+		return Result{}, sdk.WithStack(err)
+	}
+
+	resp, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && err != io.EOF {
+		return Result{}, sdk.WithStack(err)
+	}
+	resp = strings.TrimSpace(strings.TrimSuffix(resp, "\x00"))
+	resp = strings.TrimPrefix(resp, "stream: ")
+
+	switch {
+	case resp == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(resp, " FOUND"):
+		return Result{Infected: true, Report: strings.TrimSuffix(resp, " FOUND")}, nil
+	default:
+		return Result{}, sdk.NewErrorFrom(sdk.ErrUnknownError, "artifact scanner returned an error: %s", resp)
+	}
+}
+
+// scanICAP sends the content as the body of an HTTP response with a RESPMOD request. The scanner returns 204 if the
+// content is clean.
+func scanICAP(conn net.Conn, address, service string, r io.Reader) (Result, error) {
+	if service == "" {
+		service = "avscan"
+	}
+	resHeader := "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\nTransfer-Encoding: chunked\r\n\r\n"
+
+	w := bufio.NewWriter(conn)
+	fmt.Fprintf(w, "RESPMOD icap://%s/%s ICAP/1.0\r\n", address, strings.TrimPrefix(service, "/"))
+	fmt.Fprintf(w, "Host: %s\r\n", address)
+	fmt.Fprintf(w, "Allow: 204\r\n")
+	fmt.Fprintf(w, "Encapsulated: res-hdr=0, res-body=%d\r\n\r\n", len(resHeader))
+	w.WriteString(resHeader) // nolint
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			fmt.Fprintf(w, "%x\r\n", n)
+			w.Write(buf[:n])      // nolint
+			w.WriteString("\r\n") // nolint
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Result{}, sdk.WithStack(err)
+		}
+	}
+	w.WriteString("0\r\n\r\n") // nolint
+	if err := w.Flush(); err != nil {
+		return Result{}, sdk.WithStack(err)
+	}
+
+	tp := textproto.NewReader(bufio.NewReader(conn))
+	status, err := tp.ReadLine()
+	if err != nil {
+		return Result{}, sdk.WithStack(err)
+	}
+	header, err := tp.ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		return Result{}, sdk.WithStack(err)
+	}
+
+	fields := strings.Fields(status)
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "ICAP/") {
+		return Result{}, sdk.NewErrorFrom(sdk.ErrUnknownError, "invalid response from artifact scanner: %s", status)
+	}
+	switch fields[1] {
+	case "204":
+		return Result{}, nil
+	case "200":
+		// The content was replaced, the threat is given by one of the non standard headers of the scanners
+		report := icapThreat(header)
+		if report == "" {
+			report = "content blocked by ICAP service " + service
+		}
+		return Result{Infected: true, Report: report}, nil
+	default:
+		return Result{}, sdk.NewErrorFrom(sdk.ErrUnknownError, "artifact scanner returned an error: %s", status)
+	}
+}
+
+func icapThreat(header textproto.MIMEHeader) string {
+	if v := header.Get("X-Infection-Found"); v != "" {
+		for _, part := range strings.Split(v, ";") {
+			part = strings.TrimSpace(part)
+			if strings.HasPrefix(part, "Threat=") {
+				return strings.TrimPrefix(part, "Threat=")
+			}
+		}
+		return v
+	}
+	for _, k := range []string{"X-Virus-ID", "X-Violations-Found"} {
+		if v := header.Get(k); v != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
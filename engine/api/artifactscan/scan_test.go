@@ -0,0 +1,141 @@
+package artifactscan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// serve accepts one connection on a local listener and handles it with given func.
+func serve(t *testing.T, handle func(conn net.Conn)) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		defer l.Close()
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(conn)
+	}()
+	return l.Addr().String()
+}
+
+// fakeClamd reads an INSTREAM command and answers like clamd.
+func fakeClamd(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	if cmd, err := r.ReadString(0); err != nil || cmd != "zINSTREAM\x00" {
+		conn.Write([]byte("UNKNOWN COMMAND\x00")) // nolint
+		return
+	}
+	var content bytes.Buffer
+	size := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(r, size); err != nil {
+			return
+		}
+		n := binary.BigEndian.Uint32(size)
+		if n == 0 {
+			break
+		}
+		if _, err := io.CopyN(&content, r, int64(n)); err != nil {
+			return
+		}
+	}
+	if strings.Contains(content.String(), "EICAR-STANDARD-ANTIVIRUS-TEST-FILE") {
+		conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00")) // nolint
+		return
+	}
+	conn.Write([]byte("stream: OK\x00")) // nolint
+}
+
+// fakeICAP reads a RESPMOD request with a chunked body and answers like an ICAP antivirus service.
+func fakeICAP(conn net.Conn) {
+	tp := textproto.NewReader(bufio.NewReader(conn))
+	if _, err := tp.ReadLine(); err != nil {
+		return
+	}
+	if _, err := tp.ReadMIMEHeader(); err != nil {
+		return
+	}
+	// Encapsulated HTTP response header
+	if _, err := tp.ReadLine(); err != nil {
+		return
+	}
+	if _, err := tp.ReadMIMEHeader(); err != nil {
+		return
+	}
+	var content bytes.Buffer
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		n, _ := strconv.ParseInt(line, 16, 64)
+		if n == 0 {
+			break
+		}
+		if _, err := io.CopyN(&content, tp.R, n+2); err != nil {
+			return
+		}
+	}
+	if strings.Contains(content.String(), "EICAR-STANDARD-ANTIVIRUS-TEST-FILE") {
+		conn.Write([]byte("ICAP/1.0 200 OK\r\nX-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Test-Signature;\r\nEncapsulated: null-body=0\r\n\r\n")) // nolint
+		return
+	}
+	conn.Write([]byte("ICAP/1.0 204 No Content\r\nEncapsulated: null-body=0\r\n\r\n")) // nolint
+}
+
+func TestScanClamAV(t *testing.T) {
+	config := sdk.ArtifactScanIntegration.DefaultConfig.Clone()
+
+	config["address"] = sdk.IntegrationConfigValue{Value: serve(t, fakeClamd)}
+	res, err := Scan(context.TODO(), config, bytes.NewReader(bytes.Repeat([]byte("clean content "), 10000)))
+	require.NoError(t, err)
+	assert.False(t, res.Infected)
+
+	config["address"] = sdk.IntegrationConfigValue{Value: serve(t, fakeClamd)}
+	res, err = Scan(context.TODO(), config, strings.NewReader(eicar))
+	require.NoError(t, err)
+	assert.True(t, res.Infected)
+	assert.Equal(t, "Eicar-Test-Signature", res.Report)
+
+	config["address"] = sdk.IntegrationConfigValue{Value: serve(t, func(conn net.Conn) {
+		ioutil.ReadAll(io.LimitReader(conn, 10))                      // nolint
+		conn.Write([]byte("INSTREAM size limit exceeded. ERROR\x00")) // nolint
+	})}
+	_, err = Scan(context.TODO(), config, strings.NewReader(eicar))
+	assert.Error(t, err)
+}
+
+func TestScanICAP(t *testing.T) {
+	config := sdk.ArtifactScanIntegration.DefaultConfig.Clone()
+	config["type"] = sdk.IntegrationConfigValue{Value: sdk.ArtifactScanIntegrationTypeICAP}
+
+	config["address"] = sdk.IntegrationConfigValue{Value: serve(t, fakeICAP)}
+	res, err := Scan(context.TODO(), config, bytes.NewReader(bytes.Repeat([]byte("clean content "), 10000)))
+	require.NoError(t, err)
+	assert.False(t, res.Infected)
+
+	config["address"] = sdk.IntegrationConfigValue{Value: serve(t, fakeICAP)}
+	res, err = Scan(context.TODO(), config, strings.NewReader(eicar))
+	require.NoError(t, err)
+	assert.True(t, res.Infected)
+	assert.Equal(t, "Eicar-Test-Signature", res.Report)
+}
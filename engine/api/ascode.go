@@ -118,6 +118,7 @@ func (api *API) postPerformImportAsCodeHandler() service.Handler {
 			project.LoadOptions.WithPipelines,
 			project.LoadOptions.WithFeatures(api.Cache),
 			project.LoadOptions.WithClearIntegrations,
+			project.LoadOptions.WithClearKeys,
 		)
 		if errp != nil {
 			return sdk.WrapError(errp, "postPerformImportAsCodeHandler> Cannot load project %s", key)
@@ -134,7 +135,12 @@ func (api *API) postPerformImportAsCodeHandler() service.Handler {
 			return sdk.WithStack(sdk.ErrMethodNotAllowed)
 		}
 
-		tr, err := workflow.ReadCDSFiles(ope.LoadFiles.Results)
+		files, err := workflow.ResolveCDSFiles(ctx, api.mustDB(), api.Cache, *proj, *ope)
+		if err != nil {
+			return sdk.WrapError(err, "unable to resolve cds files")
+		}
+
+		tr, err := workflow.ReadCDSFiles(files)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to read cds files")
 		}
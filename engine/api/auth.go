@@ -8,6 +8,7 @@ import (
 
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/scim"
 	"github.com/ovh/cds/engine/api/user"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
@@ -41,6 +42,18 @@ func (api *API) getAuthScopesHandler() service.Handler {
 	}
 }
 
+func (api *API) getRouteCatalogHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		return service.WriteJSON(w, api.Router.routeCatalog, http.StatusOK)
+	}
+}
+
+func (api *API) getOpenAPIHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		return service.WriteJSON(w, api.Router.openAPI[getAPIVersion(ctx)], http.StatusOK)
+	}
+}
+
 func (api *API) getAuthAskSigninHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -260,6 +273,11 @@ func (api *API) postAuthSigninHandler() service.Handler {
 			}
 		}
 
+		// Users deactivated by the identity provider can't sign in
+		if err := scim.CheckUserActive(ctx, tx, consumer.AuthentifiedUserID); err != nil {
+			return err
+		}
+
 		// Generate a new session for consumer
 		session, err := authentication.NewSession(ctx, tx, consumer, driver.GetSessionDuration(), userInfo.MFA)
 		if err != nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/authentication/builtin"
+	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/user"
 	"github.com/ovh/cds/engine/service"
 )
@@ -73,8 +74,8 @@ func (api *API) postConsumerByUserHandler() service.Handler {
 		}
 
 		// Create the new built in consumer from request data
-		newConsumer, token, err := builtin.NewConsumer(ctx, api.mustDB(), reqData.Name, reqData.Description,
-			consumer, reqData.GroupIDs, reqData.ScopeDetails)
+		newConsumer, token, err := builtin.NewConsumerWithProjectScopes(ctx, api.mustDB(), reqData.Name, reqData.Description,
+			consumer, reqData.GroupIDs, reqData.ScopeDetails, reqData.ProjectScopes)
 		if err != nil {
 			return err
 		}
@@ -82,6 +83,8 @@ func (api *API) postConsumerByUserHandler() service.Handler {
 			return err
 		}
 
+		event.PublishConsumerAdd(ctx, *newConsumer, consumer)
+
 		return service.WriteJSON(w, sdk.AuthConsumerCreateResponse{
 			Token:    token,
 			Consumer: newConsumer,
@@ -89,6 +92,83 @@ func (api *API) postConsumerByUserHandler() service.Handler {
 	}
 }
 
+func (api *API) postBrowserTokenByUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		username := vars["permUsername"]
+
+		consumer := getAPIConsumer(ctx)
+
+		// Only a user can create a browser token for itself
+		var u *sdk.AuthentifiedUser
+		var err error
+		if username == "me" {
+			u, err = user.LoadByID(ctx, api.mustDB(), consumer.AuthentifiedUserID)
+		} else {
+			u, err = user.LoadByUsername(ctx, api.mustDB(), username)
+		}
+		if err != nil {
+			return err
+		}
+		if u.ID != consumer.AuthentifiedUserID {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "a user can't create a browser token for someone else")
+		}
+
+		var req sdk.AuthConsumerBrowserTokenRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		// Browser tokens are read only, their scopes are restricted to the GET routes
+		scopes, err := api.Router.readOnlyScopeDetails(req.Scopes)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		newConsumer, err := builtin.NewBrowserConsumer(ctx, tx, req.Name, req.Description, consumer, scopes,
+			req.ProjectScopes, req.Origins)
+		if err != nil {
+			return err
+		}
+
+		// The token is a short-lived session, the consumer is removed when the session expires
+		session, err := authentication.NewSession(ctx, tx, newConsumer, req.GetDuration(), false)
+		if err != nil {
+			return err
+		}
+		jwt, err := authentication.NewSessionJWT(session)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		if err := authentication.LoadConsumerOptions.Default(ctx, api.mustDB(), newConsumer); err != nil {
+			return err
+		}
+
+		event.PublishConsumerAdd(ctx, *newConsumer, consumer)
+
+		return service.WriteJSON(w, sdk.AuthConsumerBrowserTokenResponse{
+			Token:    jwt,
+			ExpireAt: session.ExpireAt,
+			Consumer: newConsumer,
+		}, http.StatusCreated)
+	}
+}
+
 func (api *API) deleteConsumerByUserHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -117,6 +197,8 @@ func (api *API) deleteConsumerByUserHandler() service.Handler {
 			return sdk.WithStack(err)
 		}
 
+		event.PublishConsumerDelete(ctx, *consumer, getAPIConsumer(ctx))
+
 		return service.WriteJSON(w, nil, http.StatusOK)
 	}
 }
@@ -144,6 +226,10 @@ func (api *API) postConsumerRegenByUserHandler() service.Handler {
 			return err
 		}
 
+		if consumer.IsBrowserToken() {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "a browser token can't be regenerated")
+		}
+
 		if err := authentication.ConsumerRegen(ctx, tx, consumer); err != nil {
 			return err
 		}
@@ -169,6 +255,8 @@ func (api *API) postConsumerRegenByUserHandler() service.Handler {
 			return sdk.WithStack(err)
 		}
 
+		event.PublishConsumerRegen(ctx, *consumer, req.RevokeSessions, getAPIConsumer(ctx))
+
 		return service.WriteJSON(w, sdk.AuthConsumerCreateResponse{
 			Token:    jws,
 			Consumer: consumer,
@@ -67,8 +67,51 @@ func (d AuthDriver) CheckSigninRequest(req sdk.AuthConsumerSigninRequest) error
 // The parent consumer should be given with all data loaded including the authentified user.
 func NewConsumer(ctx context.Context, db gorp.SqlExecutor, name, description string, parentConsumer *sdk.AuthConsumer,
 	groupIDs []int64, scopes sdk.AuthConsumerScopeDetails) (*sdk.AuthConsumer, string, error) {
+	return NewConsumerWithProjectScopes(ctx, db, name, description, parentConsumer, groupIDs, scopes, nil)
+}
+
+// NewConsumerWithProjectScopes returns a new builtin consumer for given data, restricted to given projects and verbs.
+// The parent consumer should be given with all data loaded including the authentified user.
+func NewConsumerWithProjectScopes(ctx context.Context, db gorp.SqlExecutor, name, description string, parentConsumer *sdk.AuthConsumer,
+	groupIDs []int64, scopes sdk.AuthConsumerScopeDetails, projectScopes sdk.AuthConsumerProjectScopes) (*sdk.AuthConsumer, string, error) {
+	c, err := prepareConsumer(name, description, parentConsumer, groupIDs, scopes, projectScopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := authentication.InsertConsumer(ctx, db, c); err != nil {
+		return nil, "", err
+	}
+
+	jws, err := NewSigninConsumerToken(c)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return c, jws, nil
+}
+
+// NewBrowserConsumer returns a new builtin consumer usable as a browser token from given origins. No signin token is
+// generated for this consumer, a short-lived session should be created for it instead.
+func NewBrowserConsumer(ctx context.Context, db gorp.SqlExecutor, name, description string, parentConsumer *sdk.AuthConsumer,
+	scopes sdk.AuthConsumerScopeDetails, projectScopes sdk.AuthConsumerProjectScopes, origins []string) (*sdk.AuthConsumer, error) {
+	c, err := prepareConsumer(name, description, parentConsumer, parentConsumer.GroupIDs, scopes, projectScopes)
+	if err != nil {
+		return nil, err
+	}
+	c.SetBrowserTokenOrigins(origins)
+
+	if err := authentication.InsertConsumer(ctx, db, c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func prepareConsumer(name, description string, parentConsumer *sdk.AuthConsumer, groupIDs []int64,
+	scopes sdk.AuthConsumerScopeDetails, projectScopes sdk.AuthConsumerProjectScopes) (*sdk.AuthConsumer, error) {
 	if name == "" {
-		return nil, "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "name should be given to create a built in consumer")
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "name should be given to create a built in consumer")
 	}
 
 	// For each given group id check if it's in parent consumer group ids.
@@ -80,7 +123,7 @@ func NewConsumer(ctx context.Context, db gorp.SqlExecutor, name, description str
 			parentGroupIDs := parentConsumer.GetGroupIDs()
 			for i := range groupIDs {
 				if !sdk.IsInInt64Array(groupIDs[i], parentGroupIDs) {
-					return nil, "", sdk.WrapError(sdk.ErrWrongRequest, "invalid given group id %d", groupIDs[i])
+					return nil, sdk.WrapError(sdk.ErrWrongRequest, "invalid given group id %d", groupIDs[i])
 				}
 			}
 		}
@@ -88,10 +131,15 @@ func NewConsumer(ctx context.Context, db gorp.SqlExecutor, name, description str
 
 	// Check that given scopes are valid and if they match parent scopes
 	if err := checkNewConsumerScopes(parentConsumer.ScopeDetails, scopes); err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
-	c := sdk.AuthConsumer{
+	// Check that given project scopes are valid and if they match parent project scopes
+	if err := checkNewConsumerProjectScopes(parentConsumer.ProjectScopes, projectScopes); err != nil {
+		return nil, err
+	}
+
+	return &sdk.AuthConsumer{
 		Name:               name,
 		Description:        description,
 		ParentID:           &parentConsumer.ID,
@@ -100,19 +148,9 @@ func NewConsumer(ctx context.Context, db gorp.SqlExecutor, name, description str
 		Data:               map[string]string{},
 		GroupIDs:           groupIDs,
 		ScopeDetails:       scopes,
+		ProjectScopes:      projectScopes,
 		IssuedAt:           time.Now(),
-	}
-
-	if err := authentication.InsertConsumer(ctx, db, &c); err != nil {
-		return nil, "", err
-	}
-
-	jws, err := NewSigninConsumerToken(&c)
-	if err != nil {
-		return nil, "", err
-	}
-
-	return &c, jws, nil
+	}, nil
 }
 
 func checkNewConsumerScopes(parentScopes, scopes sdk.AuthConsumerScopeDetails) error {
@@ -172,3 +210,27 @@ func checkNewConsumerScopes(parentScopes, scopes sdk.AuthConsumerScopeDetails) e
 
 	return nil
 }
+
+func checkNewConsumerProjectScopes(parentProjectScopes, projectScopes sdk.AuthConsumerProjectScopes) error {
+	if err := projectScopes.IsValid(); err != nil {
+		return err
+	}
+	// If parent project scopes length equals 0 this means no project restriction
+	if len(parentProjectScopes) == 0 {
+		return nil
+	}
+
+	// A child of a project scoped consumer should be restricted to a subset of its parent project scopes
+	if len(projectScopes) == 0 {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "project scopes should be given when creating built in consumer from a project scoped consumer")
+	}
+	for _, ps := range projectScopes {
+		for _, v := range ps.Verbs {
+			if !parentProjectScopes.IsAllowed(ps.ProjectKey, v) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given verb %s for project %s when creating built in consumer", v, ps.ProjectKey)
+			}
+		}
+	}
+
+	return nil
+}
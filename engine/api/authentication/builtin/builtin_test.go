@@ -148,3 +148,69 @@ func Test_checkNewConsumerScopes(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkNewConsumerProjectScopes(t *testing.T) {
+	cases := []struct {
+		Name                string
+		ParentProjectScopes sdk.AuthConsumerProjectScopes
+		ProjectScopes       sdk.AuthConsumerProjectScopes
+		Error               bool
+	}{
+		{
+			Name: "Parent has no project scopes",
+			ProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbRunWorkflow}},
+			},
+			Error: false,
+		},
+		{
+			Name: "Parent has project scopes but not the child",
+			ParentProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbRunWorkflow}},
+			},
+			Error: true,
+		},
+		{
+			Name: "Project is not in parent project scopes",
+			ParentProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbRunWorkflow}},
+			},
+			ProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ2", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbRunWorkflow}},
+			},
+			Error: true,
+		},
+		{
+			Name: "Verb is not in parent project scopes",
+			ParentProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbReadRun}},
+			},
+			ProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbReadRun, sdk.AuthConsumerProjectVerbRunWorkflow}},
+			},
+			Error: true,
+		},
+		{
+			Name: "Project scopes are a subset of parent project scopes",
+			ParentProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbReadRun, sdk.AuthConsumerProjectVerbRunWorkflow}},
+				{ProjectKey: "PROJ2", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbManageIntegration}},
+			},
+			ProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbReadRun}},
+			},
+			Error: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := checkNewConsumerProjectScopes(c.ParentProjectScopes, c.ProjectScopes)
+			if c.Error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ovh/cds/engine/api/authentication"
 
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 	"golang.org/x/oauth2"
 )
 
@@ -91,7 +92,7 @@ func (d authDriver) GetUserInfo(ctx context.Context, req sdk.AuthConsumerSigninR
 		},
 	}
 
-	ctx2 := context.WithValue(context.Background(), oauth2.HTTPClient, http.DefaultClient)
+	ctx2 := context.WithValue(context.Background(), oauth2.HTTPClient, cdsclient.DefaultHTTPClient)
 	t, err := config.Exchange(ctx2, req["code"],
 		oauth2.SetAuthURLParam("client_id", d.clientID),
 		oauth2.SetAuthURLParam("client_secret", d.clientSecret),
@@ -108,7 +109,7 @@ func (d authDriver) GetUserInfo(ctx context.Context, req sdk.AuthConsumerSigninR
 	}
 	request.Header.Set("Authorization", "token "+t.AccessToken)
 
-	res, err := http.DefaultClient.Do(request)
+	res, err := cdsclient.DefaultHTTPClient.Do(request)
 	if err != nil {
 		return info, sdk.WithStack(err)
 	}
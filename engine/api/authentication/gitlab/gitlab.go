@@ -12,6 +12,7 @@ import (
 
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 )
 
 var _ sdk.AuthDriverWithRedirect = new(authDriver)
@@ -88,7 +89,7 @@ func (d authDriver) GetUserInfo(ctx context.Context, req sdk.AuthConsumerSigninR
 		},
 	}
 
-	ctx2 := context.WithValue(ctx, oauth2.HTTPClient, http.DefaultClient)
+	ctx2 := context.WithValue(ctx, oauth2.HTTPClient, cdsclient.DefaultHTTPClient)
 	t, err := config.Exchange(ctx2, req["code"],
 		oauth2.SetAuthURLParam("client_id", d.applicationID),
 		oauth2.SetAuthURLParam("client_secret", d.secret),
@@ -99,7 +100,7 @@ func (d authDriver) GetUserInfo(ctx context.Context, req sdk.AuthConsumerSigninR
 		return info, sdk.WrapError(err, "cannot get gitlab token with given code")
 	}
 
-	c := gitlab.NewOAuthClient(http.DefaultClient, t.AccessToken)
+	c := gitlab.NewOAuthClient(cdsclient.DefaultHTTPClient, t.AccessToken)
 	if err := c.SetBaseURL(d.url); err != nil {
 		return info, sdk.WrapError(err, "invalid gitlab url")
 	}
@@ -242,3 +242,62 @@ func (d *AuthDriver) search(ctx context.Context, term string, attributes ...stri
 
 	return entries, nil
 }
+
+// GroupSearch describes how groups are read from the LDAP directory.
+type GroupSearch struct {
+	Base            string // ou=groups
+	Filter          string // (objectClass=groupOfNames)
+	NameAttribute   string // cn
+	MemberAttribute string // member
+}
+
+// SearchGroups returns the groups found in the LDAP directory with the uid of their members.
+func (d AuthDriver) SearchGroups(ctx context.Context, s GroupSearch) ([]sdk.LDAPGroup, error) {
+	base := d.conf.RootDN
+	if s.Base != "" {
+		base = s.Base + "," + d.conf.RootDN
+	}
+
+	log.Debug("LDAP> Search groups %s in %s", s.Filter, base)
+	searchRequest := ldap.NewSearchRequest(
+		base,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		s.Filter,
+		[]string{s.NameAttribute, s.MemberAttribute},
+		nil,
+	)
+
+	sr, err := d.conn.Search(searchRequest)
+	if err != nil {
+		if !shoudRetry(ctx, err) {
+			return nil, sdk.WithStack(err)
+		}
+		if err := d.openLDAP(ctx, d.conf); err != nil {
+			return nil, err
+		}
+		sr, err = d.conn.Search(searchRequest)
+		if err != nil {
+			return nil, sdk.WithStack(err)
+		}
+	}
+
+	groups := make([]sdk.LDAPGroup, 0, len(sr.Entries))
+	for _, e := range sr.Entries {
+		g := sdk.LDAPGroup{
+			DN:   e.DN,
+			Name: e.GetAttributeValue(s.NameAttribute),
+		}
+		for _, m := range e.GetAttributeValues(s.MemberAttribute) {
+			if uid := memberUID(m); uid != "" {
+				g.Members = append(g.Members, uid)
+			}
+		}
+		groups = append(groups, g)
+	}
+
+	return groups, nil
+}
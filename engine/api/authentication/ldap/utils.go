@@ -2,6 +2,7 @@ package ldap
 
 import (
 	"context"
+	"strings"
 
 	"gopkg.in/ldap.v2"
 
@@ -28,3 +29,23 @@ type Entry struct {
 	DN         string
 	Attributes map[string]string
 }
+
+// memberUID returns the uid of a group member. Members given as DN (i.e. uid=john,ou=people,dc=myorganization,dc=com)
+// are replaced by the value of their first attribute.
+func memberUID(member string) string {
+	member = strings.TrimSpace(member)
+	i := strings.Index(member, "=")
+	if i < 0 {
+		return member
+	}
+	value := member[i+1:]
+	for j := 0; j < len(value); j++ {
+		switch value[j] {
+		case '\\':
+			j++
+		case ',', '+':
+			return strings.TrimSpace(strings.Replace(value[:j], "\\", "", -1))
+		}
+	}
+	return strings.TrimSpace(strings.Replace(value, "\\", "", -1))
+}
@@ -0,0 +1,14 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberUID(t *testing.T) {
+	assert.Equal(t, "john", memberUID("john"))
+	assert.Equal(t, "john", memberUID("uid=john,ou=people,dc=myorganization,dc=com"))
+	assert.Equal(t, "doe, john", memberUID(`cn=doe\, john,ou=people,dc=myorganization,dc=com`))
+	assert.Equal(t, "john", memberUID("uid=john+mail=john@myorganization.com,ou=people"))
+}
@@ -95,6 +95,7 @@ func SessionCleaner(ctx context.Context, dbFunc func() *gorp.DbMap) {
 					log.Error(ctx, "SessionCleaner> unable to delete session %s: %v", s.ID, err)
 				}
 				log.Debug("SessionCleaner> expired session %s deleted", s.ID)
+				deleteExpiredBrowserConsumer(ctx, db, s.ConsumerID)
 			}
 		case <-tickCorruped.C:
 			// This part of the goroutine should be remove in a next release
@@ -111,3 +112,23 @@ func SessionCleaner(ctx context.Context, dbFunc func() *gorp.DbMap) {
 		}
 	}
 }
+
+// deleteExpiredBrowserConsumer deletes the consumer of an expired session if it is a browser token, because it can't
+// be used anymore.
+func deleteExpiredBrowserConsumer(ctx context.Context, db gorp.SqlExecutor, consumerID string) {
+	c, err := LoadConsumerByID(ctx, db, consumerID)
+	if err != nil {
+		if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			log.Error(ctx, "SessionCleaner> unable to load consumer %s: %v", consumerID, err)
+		}
+		return
+	}
+	if !c.IsBrowserToken() {
+		return
+	}
+	if err := DeleteConsumerByID(db, c.ID); err != nil {
+		log.Error(ctx, "SessionCleaner> unable to delete browser token %s: %v", c.ID, err)
+		return
+	}
+	log.Debug("SessionCleaner> expired browser token %s deleted", c.ID)
+}
@@ -7,9 +7,11 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/ovh/cds/engine/api/cost"
 	"github.com/ovh/cds/engine/api/objectstore"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
 )
 
 func (api *API) postPushCacheHandler() service.Handler {
@@ -43,14 +45,37 @@ func (api *API) postPushCacheHandler() service.Handler {
 			return err
 		}
 
-		if _, err := storageDriver.Store(&cacheObject, r.Body); err != nil {
+		body := &countReader{r: r.Body}
+		if _, err := storageDriver.Store(&cacheObject, body); err != nil {
 			return sdk.WrapError(err, "cannot store cache")
 		}
 
+		if wk := getAPIConsumer(ctx).Worker; wk.JobRunID != nil {
+			if err := cost.AddUsageBytes(api.mustDB(), *wk.JobRunID, 0, body.n); err != nil {
+				log.Error(ctx, "postPushCacheHandler> %v", err)
+			}
+		}
+
 		return nil
 	}
 }
 
+// countReader counts the bytes read from a reader.
+type countReader struct {
+	r io.ReadCloser
+	n int64
+}
+
+func (c *countReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countReader) Close() error {
+	return c.r.Close()
+}
+
 func (api *API) getPullCacheHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		if isWorker := isWorker(ctx); !isWorker {
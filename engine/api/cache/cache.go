@@ -20,6 +20,35 @@ func Key(args ...string) string {
 	return strings.Join(args, ":")
 }
 
+// ProjectKey makes a key for data of a project, all the keys of a project are invalidated by InvalidateProject.
+func ProjectKey(projectKey string, args ...string) string {
+	return Key(append([]string{"project", projectKey}, args...)...)
+}
+
+// WorkflowKey makes a key for data of a workflow, all the keys of a workflow are invalidated by InvalidateWorkflow.
+func WorkflowKey(projectKey, workflowName string, args ...string) string {
+	return ProjectKey(projectKey, append([]string{"workflow", workflowName}, args...)...)
+}
+
+// InvalidateProject deletes all the keys of a project, including the keys of its workflows.
+func InvalidateProject(store Store, projectKey string) error {
+	return store.DeleteAll(ProjectKey(projectKey, "*"))
+}
+
+// InvalidateWorkflow deletes all the keys of a workflow.
+func InvalidateWorkflow(store Store, projectKey, workflowName string) error {
+	return store.DeleteAll(WorkflowKey(projectKey, workflowName, "*"))
+}
+
+// InvalidateProjectIntegration deletes the keys of a project integration and the keys of the workflows of the
+// project, that may contain the integration.
+func InvalidateProjectIntegration(store Store, projectKey, integrationName string) error {
+	if err := store.DeleteAll(ProjectKey(projectKey, "integration", integrationName, "*")); err != nil {
+		return err
+	}
+	return store.DeleteAll(ProjectKey(projectKey, "workflow", "*"))
+}
+
 //Store is an interface
 type Store interface {
 	Get(key string, value interface{}) (bool, error)
@@ -29,6 +58,8 @@ type Store interface {
 	UpdateTTL(key string, ttl int) error
 	Delete(key string) error
 	DeleteAll(key string) error
+	Keys(pattern string) ([]string, error)
+	TTL(key string) (time.Duration, error)
 	Enqueue(queueName string, value interface{}) error
 	DequeueWithContext(c context.Context, queueName string, value interface{}) error
 	QueueLen(queueName string) (int, error)
@@ -160,7 +160,7 @@ func (s *RedisStore) DeleteAll(pattern string) error {
 	if s.Client == nil {
 		return sdk.WithStack(fmt.Errorf("redis> cannot get redis client"))
 	}
-	keys, err := s.Client.Keys(pattern).Result()
+	keys, err := s.Keys(pattern)
 	if err != nil {
 		return sdk.WrapError(err, "redis> Error deleting %s", pattern)
 	}
@@ -173,6 +173,34 @@ func (s *RedisStore) DeleteAll(pattern string) error {
 	return nil
 }
 
+// Keys returns all the keys matching given pattern, they are iterated with SCAN to not block redis
+func (s *RedisStore) Keys(pattern string) ([]string, error) {
+	if s.Client == nil {
+		return nil, sdk.WithStack(fmt.Errorf("redis> cannot get redis client"))
+	}
+	var keys []string
+	iter := s.Client.Scan(0, pattern, 1000).Iterator()
+	for iter.Next() {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, sdk.WrapError(err, "redis> error scanning %s", pattern)
+	}
+	return keys, nil
+}
+
+// TTL returns the remaining time to live of a key, -1s if the key has no expiration and -2s if it doesn't exist
+func (s *RedisStore) TTL(key string) (time.Duration, error) {
+	if s.Client == nil {
+		return 0, sdk.WithStack(fmt.Errorf("redis> cannot get redis client"))
+	}
+	ttl, err := s.Client.TTL(key).Result()
+	if err != nil {
+		return 0, sdk.WrapError(err, "redis> error getting ttl of %s", key)
+	}
+	return ttl, nil
+}
+
 // Enqueue pushes to queue
 func (s *RedisStore) Enqueue(queueName string, value interface{}) error {
 	if s.Client == nil {
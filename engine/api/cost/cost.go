@@ -0,0 +1,102 @@
+package cost
+
+import (
+	"sort"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const bytesPerGB = 1024 * 1024 * 1024
+
+// Configuration of the unit prices used to compute the cost of workflow runs
+type Configuration struct {
+	Currency           string             `toml:"currency" default:"EUR" comment:"Currency of the prices, only used in reports" json:"currency"`
+	MinutePrice        float64            `toml:"minutePrice" default:"0" comment:"Price of a minute of job on a worker model without specific price" json:"minutePrice"`
+	FlavorMinutePrices map[string]float64 `toml:"flavorMinutePrices" comment:"Prices of a minute of job by worker model flavor. The flavor of a docker model is its type: docker" json:"flavorMinutePrices"`
+	ArtifactGBPrice    float64            `toml:"artifactGBPrice" default:"0" comment:"Price of a GB of artifact uploaded by a job" json:"artifactGBPrice"`
+	CacheGBPrice       float64            `toml:"cacheGBPrice" default:"0" comment:"Price of a GB of cache uploaded by a job, caches uploaded with temporary urls are not counted" json:"cacheGBPrice"`
+}
+
+func (c Configuration) minutePrice(flavor string) float64 {
+	if p, has := c.FlavorMinutePrices[flavor]; has {
+		return p
+	}
+	return c.MinutePrice
+}
+
+// JobCost returns the usage and cost of a job run.
+func (c Configuration) JobCost(u sdk.WorkflowNodeJobRunUsage) sdk.WorkflowCostLine {
+	l := sdk.WorkflowCostLine{
+		WorkflowName:    u.WorkflowName,
+		RunNumber:       u.RunNumber,
+		NodeName:        u.NodeName,
+		JobName:         u.JobName,
+		Flavor:          u.Flavor,
+		Jobs:            1,
+		DurationSeconds: u.DurationSeconds,
+		ArtifactBytes:   u.ArtifactBytes,
+		CacheBytes:      u.CacheBytes,
+		DurationCost:    float64(u.DurationSeconds) / 60 * c.minutePrice(u.Flavor),
+		ArtifactCost:    float64(u.ArtifactBytes) / bytesPerGB * c.ArtifactGBPrice,
+		CacheCost:       float64(u.CacheBytes) / bytesPerGB * c.CacheGBPrice,
+	}
+	l.Cost = l.DurationCost + l.ArtifactCost + l.CacheCost
+	return l
+}
+
+// Report aggregates the cost of given job runs by workflow, run or job. Lines are sorted by workflow name, then by
+// descending run number.
+func (c Configuration) Report(groupBy string, usages []sdk.WorkflowNodeJobRunUsage) (sdk.WorkflowCostReport, error) {
+	r := sdk.WorkflowCostReport{
+		Currency: c.Currency,
+		GroupBy:  groupBy,
+		Lines:    []sdk.WorkflowCostLine{},
+	}
+
+	type lineKey struct {
+		workflow string
+		run      int64
+		jobRunID int64
+	}
+	lines := make(map[lineKey]*sdk.WorkflowCostLine)
+	for _, u := range usages {
+		job := c.JobCost(u)
+		var k lineKey
+		switch groupBy {
+		case sdk.WorkflowCostGroupByWorkflow:
+			k = lineKey{workflow: u.WorkflowName}
+			job.RunNumber, job.NodeName, job.JobName, job.Flavor = 0, "", "", ""
+		case sdk.WorkflowCostGroupByRun:
+			k = lineKey{workflow: u.WorkflowName, run: u.RunNumber}
+			job.NodeName, job.JobName, job.Flavor = "", "", ""
+		case sdk.WorkflowCostGroupByJob:
+			k = lineKey{workflow: u.WorkflowName, run: u.RunNumber, jobRunID: u.WorkflowNodeJobRunID}
+		default:
+			return r, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid group %q, should be one of %s, %s or %s", groupBy,
+				sdk.WorkflowCostGroupByWorkflow, sdk.WorkflowCostGroupByRun, sdk.WorkflowCostGroupByJob)
+		}
+		r.Total.Add(job)
+		if l, has := lines[k]; has {
+			l.Add(job)
+			continue
+		}
+		lines[k] = &job
+	}
+
+	for _, l := range lines {
+		r.Lines = append(r.Lines, *l)
+	}
+	sort.Slice(r.Lines, func(i, j int) bool {
+		if r.Lines[i].WorkflowName != r.Lines[j].WorkflowName {
+			return r.Lines[i].WorkflowName < r.Lines[j].WorkflowName
+		}
+		if r.Lines[i].RunNumber != r.Lines[j].RunNumber {
+			return r.Lines[i].RunNumber > r.Lines[j].RunNumber
+		}
+		if r.Lines[i].NodeName != r.Lines[j].NodeName {
+			return r.Lines[i].NodeName < r.Lines[j].NodeName
+		}
+		return r.Lines[i].JobName < r.Lines[j].JobName
+	})
+	return r, nil
+}
@@ -0,0 +1,63 @@
+package cost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestReport(t *testing.T) {
+	c := Configuration{
+		Currency:           "EUR",
+		MinutePrice:        0.01,
+		FlavorMinutePrices: map[string]float64{"b2-7": 0.1},
+		ArtifactGBPrice:    1,
+		CacheGBPrice:       0.5,
+	}
+	usages := []sdk.WorkflowNodeJobRunUsage{
+		{WorkflowName: "wf1", RunNumber: 1, WorkflowNodeJobRunID: 1, NodeName: "build", JobName: "compile", Flavor: "b2-7", DurationSeconds: 600, ArtifactBytes: bytesPerGB},
+		{WorkflowName: "wf1", RunNumber: 1, WorkflowNodeJobRunID: 2, NodeName: "build", JobName: "test", Flavor: "docker", DurationSeconds: 300, CacheBytes: 2 * bytesPerGB},
+		{WorkflowName: "wf1", RunNumber: 2, WorkflowNodeJobRunID: 3, NodeName: "build", JobName: "compile", Flavor: "b2-7", DurationSeconds: 60},
+		{WorkflowName: "wf2", RunNumber: 1, WorkflowNodeJobRunID: 4, NodeName: "deploy", JobName: "deploy", DurationSeconds: 120},
+	}
+
+	r, err := c.Report(sdk.WorkflowCostGroupByWorkflow, usages)
+	require.NoError(t, err)
+	require.Len(t, r.Lines, 2)
+	assert.Equal(t, "wf1", r.Lines[0].WorkflowName)
+	assert.Equal(t, int64(3), r.Lines[0].Jobs)
+	assert.Equal(t, int64(960), r.Lines[0].DurationSeconds)
+	assert.InDelta(t, 1+0.05+0.1, r.Lines[0].DurationCost, 0.0001)
+	assert.InDelta(t, 1, r.Lines[0].ArtifactCost, 0.0001)
+	assert.InDelta(t, 1, r.Lines[0].CacheCost, 0.0001)
+	assert.InDelta(t, 3.15, r.Lines[0].Cost, 0.0001)
+	assert.Empty(t, r.Lines[0].Flavor)
+	assert.Equal(t, "wf2", r.Lines[1].WorkflowName)
+	assert.InDelta(t, 0.02, r.Lines[1].Cost, 0.0001)
+	assert.Equal(t, int64(4), r.Total.Jobs)
+	assert.InDelta(t, 3.17, r.Total.Cost, 0.0001)
+
+	r, err = c.Report(sdk.WorkflowCostGroupByRun, usages)
+	require.NoError(t, err)
+	require.Len(t, r.Lines, 3)
+	assert.Equal(t, int64(2), r.Lines[0].RunNumber)
+	assert.Equal(t, int64(1), r.Lines[1].RunNumber)
+	assert.Equal(t, int64(2), r.Lines[1].Jobs)
+
+	r, err = c.Report(sdk.WorkflowCostGroupByJob, usages)
+	require.NoError(t, err)
+	require.Len(t, r.Lines, 4)
+	assert.Equal(t, "b2-7", r.Lines[1].Flavor)
+	assert.Equal(t, "compile", r.Lines[1].JobName)
+
+	records := r.CSV()
+	require.Len(t, records, 6)
+	assert.Equal(t, []string{"wf1", "1", "build", "compile", "b2-7", "1", "600", "1073741824", "0", "1.0000", "1.0000", "0.0000", "2.0000", "EUR"}, records[2])
+	assert.Equal(t, "total", records[5][0])
+
+	_, err = c.Report("unknown", usages)
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package cost
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadFilter filters the usages of job runs of a project on a workflow, a run number and the start of the jobs.
+type LoadFilter struct {
+	ProjectID  int64
+	WorkflowID int64
+	RunNumber  int64
+	From       *time.Time
+	To         *time.Time
+}
+
+// LoadUsages returns the usages of the job runs that match given filter.
+func LoadUsages(ctx context.Context, db gorp.SqlExecutor, filter LoadFilter) ([]sdk.WorkflowNodeJobRunUsage, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM workflow_node_run_job_usage
+		WHERE project_id = $1
+		AND ($2 = 0 OR workflow_id = $2)
+		AND ($3 = 0 OR run_number = $3)
+		AND ($4::TIMESTAMP WITH TIME ZONE IS NULL OR start >= $4)
+		AND ($5::TIMESTAMP WITH TIME ZONE IS NULL OR start < $5)
+		ORDER BY id`).Args(filter.ProjectID, filter.WorkflowID, filter.RunNumber, filter.From, filter.To)
+	var us []dbUsage
+	if err := gorpmapping.GetAll(ctx, db, query, &us); err != nil {
+		return nil, sdk.WrapError(err, "cannot load job usages for project %d", filter.ProjectID)
+	}
+
+	res := make([]sdk.WorkflowNodeJobRunUsage, len(us))
+	for i := range us {
+		res[i] = sdk.WorkflowNodeJobRunUsage(us[i])
+	}
+	return res, nil
+}
+
+// InsertUsage starts the usage of a job run when it is taken by a worker. If the job was already taken, the usage
+// of the first worker is kept so the duration covers all the attempts.
+func InsertUsage(db gorp.SqlExecutor, u sdk.WorkflowNodeJobRunUsage) error {
+	_, err := db.Exec(`
+		INSERT INTO workflow_node_run_job_usage (project_id, workflow_id, workflow_name, workflow_run_id, run_number,
+			workflow_node_run_id, workflow_node_run_job_id, node_name, job_name, model, flavor, start)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT DO NOTHING
+	`, u.ProjectID, u.WorkflowID, u.WorkflowName, u.WorkflowRunID, u.RunNumber, u.WorkflowNodeRunID,
+		u.WorkflowNodeJobRunID, u.NodeName, u.JobName, u.Model, u.Flavor, u.Start)
+	return sdk.WrapError(err, "cannot insert usage of job run %d", u.WorkflowNodeJobRunID)
+}
+
+// SetUsageDone ends the usage of a job run and computes its duration.
+func SetUsageDone(db gorp.SqlExecutor, jobRunID int64, done time.Time) error {
+	_, err := db.Exec(`
+		UPDATE workflow_node_run_job_usage
+		SET done = $2, duration_seconds = GREATEST(0, EXTRACT(EPOCH FROM ($2 - start)))::BIGINT
+		WHERE workflow_node_run_job_id = $1 AND done IS NULL
+	`, jobRunID, done)
+	return sdk.WrapError(err, "cannot update usage of job run %d", jobRunID)
+}
+
+// AddUsageBytes adds the size of uploaded artifacts and caches to the usage of a job run.
+func AddUsageBytes(db gorp.SqlExecutor, jobRunID, artifactBytes, cacheBytes int64) error {
+	_, err := db.Exec(`
+		UPDATE workflow_node_run_job_usage
+		SET artifact_bytes = artifact_bytes + $2, cache_bytes = cache_bytes + $3
+		WHERE workflow_node_run_job_id = $1
+	`, jobRunID, artifactBytes, cacheBytes)
+	return sdk.WrapError(err, "cannot update usage of job run %d", jobRunID)
+}
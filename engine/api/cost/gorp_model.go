@@ -0,0 +1,12 @@
+package cost
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type dbUsage sdk.WorkflowNodeJobRunUsage
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbUsage{}, "workflow_node_run_job_usage", true, "id"))
+}
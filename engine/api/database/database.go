@@ -29,6 +29,7 @@ type DBConnectionFactory struct {
 	DBMaxConn        int
 	Database         *sql.DB
 	mutex            *sync.Mutex
+	replica          *replica
 }
 
 // DB returns the current sql.DB object
@@ -42,6 +43,7 @@ func (f *DBConnectionFactory) DB() *sql.DB {
 			log.Error(context.TODO(), "Database> cannot init db connection : %s", err)
 			return nil
 		}
+		newF.replica = f.replica
 		*f = *newF
 	}
 	if err := f.Database.Ping(); err != nil {
@@ -159,8 +161,13 @@ func (f *DBConnectionFactory) Status(ctx context.Context) sdk.MonitoringStatusLi
 	return sdk.MonitoringStatusLine{Component: "Database Conns", Value: fmt.Sprintf("%d", f.Database.Stats().OpenConnections), Status: sdk.MonitoringStatusOK}
 }
 
-// Close closes the database, releasing any open resources.
+// Close closes the database and its replica, releasing any open resources.
 func (f *DBConnectionFactory) Close() error {
+	if f.replica != nil {
+		if err := f.replica.factory.Close(); err != nil {
+			return err
+		}
+	}
 	if f.Database != nil {
 		return f.Database.Close()
 	}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/go-gorp/gorp"
@@ -19,17 +20,20 @@ type gorpLogger struct{}
 func (g gorpLogger) Printf(format string, v ...interface{}) { log.Debug(format, v...) }
 
 var (
-	lastDB    *sql.DB
-	lastDBMap *gorp.DbMap
+	dbMaps      = make(map[*sql.DB]*gorp.DbMap)
+	dbMapsMutex sync.RWMutex
 )
 
 //DBMap returns a propor intialized gorp.DBMap pointer
 func DBMap(db *sql.DB) *gorp.DbMap {
-	if db == lastDB && lastDBMap != nil && db == lastDBMap.Db {
-		return lastDBMap
+	dbMapsMutex.RLock()
+	dbmap, ok := dbMaps[db]
+	dbMapsMutex.RUnlock()
+	if ok {
+		return dbmap
 	}
 
-	dbmap := &gorp.DbMap{Db: db, Dialect: gorp.PostgresDialect{}, TypeConverter: new(TypeConverter)}
+	dbmap = &gorp.DbMap{Db: db, Dialect: gorp.PostgresDialect{}, TypeConverter: new(TypeConverter)}
 
 	if os.Getenv("gorp_trace") == "true" {
 		dbmap.TraceOn("[GORP]     Query>", gorpLogger{})
@@ -49,8 +53,13 @@ func DBMap(db *sql.DB) *gorp.DbMap {
 
 	}
 
-	lastDB = db
-	lastDBMap = dbmap
+	// Maps are kept for the primary database and its replica, maps of previous connections are dropped
+	dbMapsMutex.Lock()
+	if len(dbMaps) >= 2 {
+		dbMaps = make(map[*sql.DB]*gorp.DbMap)
+	}
+	dbMaps[db] = dbmap
+	dbMapsMutex.Unlock()
 
 	return dbmap
 }
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// replicaLagQuery returns the replication lag of a standby in seconds, a standby that replayed all the received
+// changes is not late even if the primary database was not updated for a while.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`
+
+// replicaCheckInterval is the delay between two checks of the replication lag.
+const replicaCheckInterval = 5 * time.Second
+
+type replica struct {
+	factory      *DBConnectionFactory
+	maxStaleness time.Duration
+	lag          int64 // nanoseconds
+	fresh        int32
+}
+
+// SetReplica sets a read-only replica of the database, returned by GetReadDBMap while its replication lag is under
+// given staleness. The replica is not used before its lag is checked by CheckReplica.
+func (f *DBConnectionFactory) SetReplica(r *DBConnectionFactory, maxStaleness time.Duration) {
+	f.replica = &replica{factory: r, maxStaleness: maxStaleness}
+}
+
+// GetReadDBMap returns a gorp.DbMap pointer for read-only queries. The replica database is used if it is set and up
+// to date, else the primary database.
+func (f *DBConnectionFactory) GetReadDBMap() *gorp.DbMap {
+	if f.replica != nil && atomic.LoadInt32(&f.replica.fresh) == 1 {
+		if db := f.replica.factory.DB(); db != nil {
+			return DBMap(db)
+		}
+	}
+	return f.GetDBMap()
+}
+
+// CheckReplica checks periodically the replication lag of the replica database if any.
+func (f *DBConnectionFactory) CheckReplica(ctx context.Context) {
+	if f.replica == nil {
+		return
+	}
+
+	f.replica.check(ctx)
+	tick := time.NewTicker(replicaCheckInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "database> exiting replica check: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			f.replica.check(ctx)
+		}
+	}
+}
+
+func (r *replica) check(ctx context.Context) {
+	fresh := int32(0)
+	db := r.factory.DB()
+	if db == nil {
+		log.Warning(ctx, "database> replica unavailable")
+	} else {
+		var lag float64
+		if err := db.QueryRow(replicaLagQuery).Scan(&lag); err != nil {
+			log.Warning(ctx, "database> cannot get replication lag of replica: %v", err)
+		} else {
+			atomic.StoreInt64(&r.lag, int64(lag*float64(time.Second)))
+			if time.Duration(atomic.LoadInt64(&r.lag)) <= r.maxStaleness {
+				fresh = 1
+			}
+		}
+	}
+
+	if old := atomic.SwapInt32(&r.fresh, fresh); old != fresh {
+		if fresh == 1 {
+			log.Info(ctx, "database> replica is up to date, read-only queries are sent to the replica")
+		} else {
+			log.Warning(ctx, "database> replica is late or unavailable, read-only queries are sent to the primary database")
+		}
+	}
+}
+
+// ReplicaStatus returns replica status in a printable string.
+func (f *DBConnectionFactory) ReplicaStatus() sdk.MonitoringStatusLine {
+	if f.replica == nil {
+		return sdk.MonitoringStatusLine{Component: "Database Replica", Value: "disabled", Status: sdk.MonitoringStatusOK}
+	}
+	lag := time.Duration(atomic.LoadInt64(&f.replica.lag)).Round(time.Millisecond)
+	if atomic.LoadInt32(&f.replica.fresh) == 0 {
+		return sdk.MonitoringStatusLine{Component: "Database Replica", Value: fmt.Sprintf("not used (lag %s)", lag), Status: sdk.MonitoringStatusWarn}
+	}
+	return sdk.MonitoringStatusLine{Component: "Database Replica", Value: fmt.Sprintf("lag %s", lag), Status: sdk.MonitoringStatusOK}
+}
@@ -23,6 +23,21 @@ type DBConfiguration struct {
 	Timeout        int              `toml:"timeout" default:"3000" comment:"Statement timeout value in milliseconds" json:"timeout"`
 	SignatureKey   RollingKeyConfig `json:"-" toml:"signatureRollingKeys" comment:"Signature rolling keys" mapstructure:"signatureRollingKeys"`
 	EncryptionKey  RollingKeyConfig `json:"-" toml:"encryptionRollingKeys" comment:"Encryption rolling keys" mapstructure:"encryptionRollingKeys"`
+
+	Replica DBReplicaConfiguration `toml:"replica" comment:"Read-only replica of the database used by read-heavy routes, disabled if no host is set" json:"replica" mapstructure:"replica"`
+}
+
+// DBReplicaConfiguration is the configuration of a read-only replica of the database. User, password and name of the
+// primary database are used if not set.
+type DBReplicaConfiguration struct {
+	User         string `toml:"user" default:"" commented:"true" json:"user"`
+	Password     string `toml:"password" default:"" commented:"true" json:"-"`
+	Name         string `toml:"name" default:"" commented:"true" json:"name"`
+	Host         string `toml:"host" default:"" commented:"true" json:"host"`
+	Port         int    `toml:"port" default:"5432" commented:"true" json:"port"`
+	SSLMode      string `toml:"sslmode" default:"disable" commented:"true" comment:"DB SSL Mode: require (default), verify-full, or disable" json:"sslmode"`
+	MaxConn      int    `toml:"maxconn" default:"20" commented:"true" comment:"DB Max connection" json:"maxconn"`
+	MaxStaleness int    `toml:"maxStaleness" default:"5" commented:"true" comment:"Maximum replication lag in seconds, reads are sent to the primary database when the replica is late" json:"maxStaleness"`
 }
 
 type RollingKeyConfig struct {
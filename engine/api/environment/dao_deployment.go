@@ -0,0 +1,180 @@
+package environment
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadIntegration returns the deployment integration bound to given environment.
+func LoadIntegration(ctx context.Context, db gorp.SqlExecutor, environmentID int64) (*sdk.EnvironmentIntegration, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT environment_integration.*
+		FROM environment_integration
+		WHERE environment_id = $1
+	`).Args(environmentID)
+	var ei dbEnvironmentIntegration
+	found, err := gorpmapping.Get(ctx, db, query, &ei)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load integration for environment %d", environmentID)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := sdk.EnvironmentIntegration(ei)
+	return &res, nil
+}
+
+// UpsertIntegration binds given deployment integration to an environment, replacing the previous one if any.
+func UpsertIntegration(db gorp.SqlExecutor, ei *sdk.EnvironmentIntegration) error {
+	if err := DeleteIntegration(db, ei.EnvironmentID); err != nil {
+		return err
+	}
+	dbei := dbEnvironmentIntegration(*ei)
+	if err := gorpmapping.Insert(db, &dbei); err != nil {
+		return sdk.WrapError(err, "cannot insert integration for environment %d", ei.EnvironmentID)
+	}
+	ei.ID = dbei.ID
+	return nil
+}
+
+// DeleteIntegration removes the deployment integration bound to given environment.
+func DeleteIntegration(db gorp.SqlExecutor, environmentID int64) error {
+	_, err := db.Exec("DELETE FROM environment_integration WHERE environment_id = $1", environmentID)
+	return sdk.WrapError(err, "cannot delete integration for environment %d", environmentID)
+}
+
+// InsertDeployment records a deployment on an environment.
+func InsertDeployment(db gorp.SqlExecutor, d *sdk.EnvironmentDeployment) error {
+	if d.Deployed.IsZero() {
+		d.Deployed = time.Now()
+	}
+	dbd := dbEnvironmentDeployment(*d)
+	if err := gorpmapping.Insert(db, &dbd); err != nil {
+		return sdk.WrapError(err, "cannot insert deployment for environment %d", d.EnvironmentID)
+	}
+	d.ID = dbd.ID
+	return nil
+}
+
+// UpdateDeploymentStatus updates the status of a deployment, it is set by the callbacks of the deployment integration.
+func UpdateDeploymentStatus(db gorp.SqlExecutor, id int64, status string) error {
+	res, err := db.Exec("UPDATE environment_deployment SET status = $2 WHERE id = $1", id, status)
+	if err != nil {
+		return sdk.WrapError(err, "cannot update status of deployment %d", id)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if n == 0 {
+		return sdk.WithStack(sdk.ErrNotFound)
+	}
+	return nil
+}
+
+const deploymentColumns = `
+	environment_deployment.id, environment_deployment.environment_id, environment_deployment.application_id,
+	environment_deployment.project_integration_id, environment_deployment.workflow_id, environment_deployment.workflow_node_id,
+	environment_deployment.workflow_run_id, environment_deployment.workflow_node_run_id, environment_deployment.num,
+	environment_deployment.sub_num, environment_deployment.version, environment_deployment.vcs_hash, environment_deployment.deployed,
+	environment_deployment.status, environment.name, application.name, project_integration.name, workflow.name`
+
+const deploymentJoins = `
+	JOIN environment ON environment.id = environment_deployment.environment_id
+	JOIN application ON application.id = environment_deployment.application_id
+	JOIN project_integration ON project_integration.id = environment_deployment.project_integration_id
+	JOIN workflow ON workflow.id = environment_deployment.workflow_id`
+
+func loadDeployments(db gorp.SqlExecutor, query string, args ...interface{}) ([]sdk.EnvironmentDeployment, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	defer rows.Close() // nolint
+
+	var res []sdk.EnvironmentDeployment
+	for rows.Next() {
+		var d sdk.EnvironmentDeployment
+		if err := rows.Scan(&d.ID, &d.EnvironmentID, &d.ApplicationID,
+			&d.ProjectIntegrationID, &d.WorkflowID, &d.WorkflowNodeID,
+			&d.WorkflowRunID, &d.WorkflowNodeRunID, &d.Number,
+			&d.SubNumber, &d.Version, &d.VCSHash, &d.Deployed,
+			&d.Status, &d.EnvironmentName, &d.ApplicationName, &d.ProjectIntegrationName, &d.WorkflowName); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		res = append(res, d)
+	}
+	return res, sdk.WithStack(rows.Err())
+}
+
+// LoadCurrentDeployments returns the last deployment of each application by each workflow on given environment.
+func LoadCurrentDeployments(db gorp.SqlExecutor, environmentID int64) ([]sdk.EnvironmentDeployment, error) {
+	query := `
+		SELECT DISTINCT ON (environment_deployment.workflow_id, environment_deployment.application_id) ` + deploymentColumns + `
+		FROM environment_deployment` + deploymentJoins + `
+		WHERE environment_deployment.environment_id = $1
+		ORDER BY environment_deployment.workflow_id, environment_deployment.application_id, environment_deployment.deployed DESC`
+	ds, err := loadDeployments(db, query, environmentID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load deployments for environment %d", environmentID)
+	}
+	return ds, nil
+}
+
+// LoadDeploymentsHistory returns the deployments on given environment, most recent first.
+func LoadDeploymentsHistory(db gorp.SqlExecutor, environmentID int64, limit int) ([]sdk.EnvironmentDeployment, error) {
+	query := `
+		SELECT ` + deploymentColumns + `
+		FROM environment_deployment` + deploymentJoins + `
+		WHERE environment_deployment.environment_id = $1
+		ORDER BY environment_deployment.deployed DESC
+		LIMIT $2`
+	ds, err := loadDeployments(db, query, environmentID, limit)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load deployments history for environment %d", environmentID)
+	}
+	return ds, nil
+}
+
+// LoadLastDeployment returns the last deployment on given environment by given workflow. If applicationID is
+// not zero, only deployments of this application are considered.
+func LoadLastDeployment(db gorp.SqlExecutor, environmentID, workflowID, applicationID int64) (*sdk.EnvironmentDeployment, error) {
+	query := `
+		SELECT ` + deploymentColumns + `
+		FROM environment_deployment` + deploymentJoins + `
+		WHERE environment_deployment.environment_id = $1
+		AND environment_deployment.workflow_id = $2
+		AND ($3 = 0 OR environment_deployment.application_id = $3)
+		ORDER BY environment_deployment.deployed DESC
+		LIMIT 1`
+	ds, err := loadDeployments(db, query, environmentID, workflowID, applicationID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load last deployment for environment %d", environmentID)
+	}
+	if len(ds) == 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "nothing deployed on environment by this workflow")
+	}
+	return &ds[0], nil
+}
+
+// LoadDeploymentByID returns a deployment of given project integration.
+func LoadDeploymentByID(db gorp.SqlExecutor, projectIntegrationID, id int64) (*sdk.EnvironmentDeployment, error) {
+	query := `
+		SELECT ` + deploymentColumns + `
+		FROM environment_deployment` + deploymentJoins + `
+		WHERE environment_deployment.project_integration_id = $1
+		AND environment_deployment.id = $2`
+	ds, err := loadDeployments(db, query, projectIntegrationID, id)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load deployment %d", id)
+	}
+	if len(ds) == 0 {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &ds[0], nil
+}
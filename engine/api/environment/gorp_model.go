@@ -12,6 +12,10 @@ import (
 
 type dbEnvironmentVariableAudit sdk.EnvironmentVariableAudit
 
+type dbEnvironmentIntegration sdk.EnvironmentIntegration
+
+type dbEnvironmentDeployment sdk.EnvironmentDeployment
+
 type dbEnvironmentKey struct {
 	gorpmapping.SignedEntity
 	sdk.EnvironmentKey
@@ -82,6 +86,8 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(dbEnvironmentVariableAudit{}, "environment_variable_audit", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbEnvironmentKey{}, "environment_key", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbEnvironmentVariable{}, "environment_variable", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbEnvironmentIntegration{}, "environment_integration", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbEnvironmentDeployment{}, "environment_deployment", true, "id"))
 }
 
 // PostGet is a db hook
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getEnvironmentIntegrationHandler returns the deployment integration bound to an environment.
+func (api *API) getEnvironmentIntegrationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars[permProjectKey]
+		environmentName := vars["environmentName"]
+
+		env, err := environment.LoadEnvironmentByName(api.mustDB(), projectKey, environmentName)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load environment %s", environmentName)
+		}
+
+		ei, err := environment.LoadIntegration(ctx, api.mustDB(), env.ID)
+		if err != nil {
+			return err
+		}
+		pi, err := integration.LoadProjectIntegrationByID(api.mustDB(), ei.ProjectIntegrationID)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load integration %d", ei.ProjectIntegrationID)
+		}
+		ei.ProjectIntegrationName = pi.Name
+
+		return service.WriteJSON(w, ei, http.StatusOK)
+	}
+}
+
+// putEnvironmentIntegrationHandler binds a deployment integration of the project to an environment.
+func (api *API) putEnvironmentIntegrationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars[permProjectKey]
+		environmentName := vars["environmentName"]
+
+		env, err := environment.LoadEnvironmentByName(api.mustDB(), projectKey, environmentName)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load environment %s", environmentName)
+		}
+		if env.FromRepository != "" {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		var ei sdk.EnvironmentIntegration
+		if err := service.UnmarshalBody(r, &ei); err != nil {
+			return err
+		}
+
+		pi, err := integration.LoadProjectIntegrationByName(api.mustDB(), projectKey, ei.ProjectIntegrationName)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load integration %s", ei.ProjectIntegrationName)
+		}
+		if !pi.Model.Deployment {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "integration %s is not a deployment integration", pi.Name)
+		}
+
+		ei.EnvironmentID = env.ID
+		ei.ProjectIntegrationID = pi.ID
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		if err := environment.UpsertIntegration(tx, &ei); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, ei, http.StatusOK)
+	}
+}
+
+// deleteEnvironmentIntegrationHandler removes the deployment integration bound to an environment.
+func (api *API) deleteEnvironmentIntegrationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars[permProjectKey]
+		environmentName := vars["environmentName"]
+
+		env, err := environment.LoadEnvironmentByName(api.mustDB(), projectKey, environmentName)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load environment %s", environmentName)
+		}
+		if env.FromRepository != "" {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		if err := environment.DeleteIntegration(api.mustDB(), env.ID); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// getEnvironmentDeploymentsHandler returns the versions of applications deployed on an environment. Set the
+// history query param to get all the deployments instead of the current ones.
+func (api *API) getEnvironmentDeploymentsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars[permProjectKey]
+		environmentName := vars["environmentName"]
+
+		env, err := environment.LoadEnvironmentByName(api.mustDB(), projectKey, environmentName)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load environment %s", environmentName)
+		}
+
+		withHistory, _ := strconv.ParseBool(QueryString(r, "history"))
+		if !withHistory {
+			ds, err := environment.LoadCurrentDeployments(api.mustDB(), env.ID)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, ds, http.StatusOK)
+		}
+
+		limit := 50
+		if l := QueryString(r, "limit"); l != "" {
+			limit, err = strconv.Atoi(l)
+			if err != nil || limit <= 0 {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given limit")
+			}
+		}
+		ds, err := environment.LoadDeploymentsHistory(api.mustDB(), env.ID, limit)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, ds, http.StatusOK)
+	}
+}
+
+// postIntegrationDeploymentStatusHandler updates the status of a deployment made with a deployment integration. It is
+// called back by the integration, the request is authenticated with the signing secret of the integration.
+func (api *API) postIntegrationDeploymentStatusHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars["key"]
+		integrationName := vars["integrationName"]
+
+		deploymentID, err := strconv.ParseInt(vars["deploymentID"], 10, 64)
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given deployment id")
+		}
+
+		var status sdk.EnvironmentDeploymentStatus
+		if err := service.UnmarshalBody(r, &status); err != nil {
+			return err
+		}
+		if err := status.IsValid(); err != nil {
+			return err
+		}
+
+		pi, err := integration.LoadProjectIntegrationByName(api.mustDB(), projectKey, integrationName)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load integration %s", integrationName)
+		}
+
+		d, err := environment.LoadDeploymentByID(api.mustDB(), pi.ID, deploymentID)
+		if err != nil {
+			return err
+		}
+		if err := environment.UpdateDeploymentStatus(api.mustDB(), d.ID, status.Status); err != nil {
+			return err
+		}
+		d.Status = status.Status
+
+		return service.WriteJSON(w, d, http.StatusOK)
+	}
+}
+
+// postWorkflowPromoteHandler deploys on the target environment the version deployed on the source environment by
+// the workflow. The run of the source deployment is restarted from the node that deploys on the target environment,
+// so that the target environment is deployed with the artifacts of this run.
+func (api *API) postWorkflowPromoteHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		var promotion sdk.EnvironmentPromotion
+		if err := service.UnmarshalBody(r, &promotion); err != nil {
+			return err
+		}
+		if err := promotion.IsValid(); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), key,
+			project.LoadOptions.WithVariables,
+			project.LoadOptions.WithFeatures(api.Cache),
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithApplicationVariables,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithPipelines,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		from, err := environment.LoadEnvironmentByName(api.mustDB(), key, promotion.From)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load environment %s", promotion.From)
+		}
+		to, err := environment.LoadEnvironmentByName(api.mustDB(), key, promotion.To)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load environment %s", promotion.To)
+		}
+
+		var applicationID int64
+		if promotion.ApplicationName != "" {
+			app, err := application.LoadByName(api.mustDB(), key, promotion.ApplicationName)
+			if err != nil {
+				return sdk.WrapError(err, "cannot load application %s", promotion.ApplicationName)
+			}
+			applicationID = app.ID
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s/%s", key, name)
+		}
+
+		deployment, err := environment.LoadLastDeployment(api.mustDB(), from.ID, wf.ID, applicationID)
+		if err != nil {
+			return err
+		}
+
+		// If the target environment is bound to a deployment integration, only a node using it can deploy on it
+		var integrationID int64
+		ei, err := environment.LoadIntegration(ctx, api.mustDB(), to.ID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if ei != nil {
+			integrationID = ei.ProjectIntegrationID
+		}
+
+		lastRun, err := workflow.LoadRun(ctx, api.mustDB(), key, name, deployment.Number, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow run %d", deployment.Number)
+		}
+
+		node, err := lastRun.Workflow.WorkflowData.NodeDeployingOn(to.ID, deployment.ApplicationID, integrationID)
+		if err != nil {
+			return sdk.WrapError(err, "cannot find node of workflow %s that deploys application %s on environment %s", name, deployment.ApplicationName, to.Name)
+		}
+
+		c := getAPIConsumer(ctx)
+		if !permission.AccessToWorkflowNode(ctx, api.mustDB(), &lastRun.Workflow, node, c, lastRun.Workflow.ExecutePermission(node)) {
+			return sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %s", node.Name)
+		}
+
+		opts := &sdk.WorkflowRunPostHandlerOption{
+			Number:      &deployment.Number,
+			FromNodeIDs: []int64{node.ID},
+			Manual:      &sdk.WorkflowNodeRunManual{},
+		}
+
+		runWorkflow := &lastRun.Workflow
+		runWorkflow.Name = name
+		lastRun.Status = sdk.StatusWaiting
+
+		sdk.GoRoutine(context.Background(), fmt.Sprintf("api.initWorkflowRun-%d", lastRun.ID), func(ctx context.Context) {
+			api.initWorkflowRun(ctx, p.Key, runWorkflow, lastRun, opts, c)
+		}, api.PanicDump())
+
+		return service.WriteJSON(w, lastRun, http.StatusAccepted)
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/ovh/cds/engine/api/environment"
-	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/exportentities"
@@ -27,7 +26,12 @@ func (api *API) getEnvironmentExportHandler() service.Handler {
 			return err
 		}
 
-		env, err := environment.Export(ctx, api.mustDB(), key, envName, project.EncryptWithBuiltinKey)
+		encryptFunc, err := api.exportEncryptFunc(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		env, err := environment.Export(ctx, api.mustDB(), key, envName, encryptFunc)
 		if err != nil {
 			return err
 		}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/environment"
@@ -222,3 +223,74 @@ func (api *API) addVariableInEnvironmentHandler() service.Handler {
 		return service.WriteJSON(w, newVar, http.StatusOK)
 	}
 }
+
+func (api *API) postVariablesBatchInEnvironmentHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		envName := vars["environmentName"]
+
+		var batch sdk.VariableBatch
+		if err := service.UnmarshalBody(r, &batch); err != nil {
+			return err
+		}
+		for _, v := range batch.Update {
+			if v.Type == sdk.KeyVariable {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "key variable %s can't be updated", v.Name)
+			}
+		}
+
+		env, err := environment.LoadEnvironmentByName(api.mustDB(), key, envName)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load environment %s", envName)
+		}
+		if env.FromRepository != "" {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		diff, err := applyVariableBatch(tx, variableBatchStore{
+			load: func(db gorp.SqlExecutor, name string) (*sdk.Variable, error) {
+				v, err := environment.LoadVariable(db, env.ID, name)
+				if err != nil {
+					return nil, err
+				}
+				return environment.LoadVariableWithDecryption(db, env.ID, v.ID, name)
+			},
+			insert: func(db gorp.SqlExecutor, v *sdk.Variable) error {
+				return environment.InsertVariable(db, env.ID, v, getAPIConsumer(ctx))
+			},
+			update: func(db gorp.SqlExecutor, v *sdk.Variable, before *sdk.Variable) error {
+				return environment.UpdateVariable(db, env.ID, v, before, getAPIConsumer(ctx))
+			},
+			delete: func(db gorp.SqlExecutor, v *sdk.Variable) error {
+				return environment.DeleteVariable(db, env.ID, v, getAPIConsumer(ctx))
+			},
+		}, batch)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		for _, v := range diff.Deleted {
+			event.PublishEnvironmentVariableDelete(ctx, key, *env, v, getAPIConsumer(ctx))
+		}
+		for _, c := range diff.Updated {
+			event.PublishEnvironmentVariableUpdate(ctx, key, *env, c.After, c.Before, getAPIConsumer(ctx))
+		}
+		for _, v := range diff.Created {
+			event.PublishEnvironmentVariableAdd(ctx, key, *env, v, getAPIConsumer(ctx))
+		}
+
+		diff.HidePasswords()
+		return service.WriteJSON(w, diff, http.StatusOK)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -45,7 +46,7 @@ func (api *API) getErrorHandler() service.Handler {
 		req.URL.RawQuery = q.Encode()
 
 		req.SetBasicAuth(api.Config.Graylog.AccessToken, "token")
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cdsclient.DefaultHTTPClient.Do(req.WithContext(ctx))
 		if err != nil {
 			return sdk.WrapError(err, "cannot send query to Graylog")
 		}
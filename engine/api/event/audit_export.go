@@ -0,0 +1,156 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const auditExportInterval = 5 * time.Second
+
+// AuditExportConfiguration is the configuration of the export of audit events to an event integration
+type AuditExportConfiguration struct {
+	Enabled         bool   `toml:"enabled" default:"false" comment:"Enable the export of all audit events (project, integration, permission, consumer changes...) to an event integration" json:"enabled"`
+	ProjectKey      string `toml:"projectKey" comment:"Key of the project that contains the event integration" json:"projectKey"`
+	IntegrationName string `toml:"integrationName" comment:"Name of the Kafka or Webhook project integration that receives audit events" json:"integrationName"`
+	BatchSize       int    `toml:"batchSize" default:"100" comment:"Maximum number of audit events sent at each export" json:"batchSize"`
+	RetentionDays   int    `toml:"retentionDays" default:"30" comment:"Number of days audit events are kept in database after being exported" json:"retentionDays"`
+}
+
+// Target returns the name of the export target used for its cursor.
+func (c AuditExportConfiguration) Target() string {
+	return c.ProjectKey + "/" + c.IntegrationName
+}
+
+// RecordAuditEvents stores in database all audit events, they will be sent by ExportAuditEvents.
+func RecordAuditEvents(ctx context.Context, DBFunc func() *gorp.DbMap) {
+	eventChan := make(chan sdk.Event, 100)
+	Subscribe(eventChan)
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "RecordAuditEvents> Exiting: %v", ctx.Err())
+				return
+			}
+		case e := <-eventChan:
+			if !e.IsAudit() {
+				continue
+			}
+			if err := insertAuditEvent(DBFunc(), e); err != nil {
+				log.Error(ctx, "RecordAuditEvents> %v", err)
+			}
+		}
+	}
+}
+
+// ExportAuditEvents sends recorded audit events to the configured event integration.
+// Events are sent in order and the cursor is updated after each event, so an event
+// can be sent more than once but is never lost.
+func ExportAuditEvents(ctx context.Context, DBFunc func() *gorp.DbMap, cfg AuditExportConfiguration) {
+	tick := time.NewTicker(auditExportInterval)
+	defer tick.Stop()
+	purgeTick := time.NewTicker(time.Hour)
+	defer purgeTick.Stop()
+
+	var broker Broker
+	for {
+		select {
+		case <-ctx.Done():
+			if broker != nil {
+				broker.close(ctx)
+			}
+			if ctx.Err() != nil {
+				log.Error(ctx, "ExportAuditEvents> Exiting: %v", ctx.Err())
+				return
+			}
+		case <-tick.C:
+			if broker == nil {
+				projInt, err := integration.LoadProjectIntegrationByNameWithClearPassword(DBFunc(), cfg.ProjectKey, cfg.IntegrationName)
+				if err != nil {
+					log.Error(ctx, "ExportAuditEvents> cannot load integration %s: %v", cfg.Target(), err)
+					continue
+				}
+				if !projInt.Model.Event {
+					log.Error(ctx, "ExportAuditEvents> integration %s is not an event integration", cfg.Target())
+					continue
+				}
+				broker, err = getBrokerForProjectIntegration(ctx, DBFunc(), projInt)
+				if err != nil {
+					log.Error(ctx, "ExportAuditEvents> cannot get broker for integration %s: %v", cfg.Target(), err)
+					continue
+				}
+			}
+			if err := exportAuditEvents(ctx, DBFunc(), cfg, broker); err != nil {
+				log.Error(ctx, "ExportAuditEvents> %v", err)
+				// The broker will be reset at next tick
+				broker.close(ctx)
+				broker = nil
+			}
+		case <-purgeTick.C:
+			n, err := deleteExportedAuditEvents(DBFunc(), cfg.Target(), time.Now().AddDate(0, 0, -cfg.RetentionDays))
+			if err != nil {
+				log.Error(ctx, "ExportAuditEvents> %v", err)
+				continue
+			}
+			log.Debug("ExportAuditEvents> %d audit events purged", n)
+		}
+	}
+}
+
+func exportAuditEvents(ctx context.Context, db *gorp.DbMap, cfg AuditExportConfiguration, broker Broker) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	cursor, err := loadAuditExportCursorForUpdate(ctx, tx, cfg.Target())
+	if err != nil {
+		return err
+	}
+	// Another API instance is exporting audit events
+	if cursor == nil {
+		return nil
+	}
+
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	events, err := loadAuditEventsAfter(ctx, tx, cursor.LastEventID, batchSize)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	var errSend error
+	for i := range events {
+		e := sdk.Event(events[i].Data)
+		if err := broker.sendEvent(&e); err != nil {
+			errSend = fmt.Errorf("cannot send audit event %d to %s: %v", events[i].ID, cfg.Target(), err)
+			break
+		}
+		cursor.LastEventID = events[i].ID
+	}
+
+	now := time.Now()
+	cursor.LastExport = &now
+	if err := updateAuditExportCursor(tx, cursor); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	return errSend
+}
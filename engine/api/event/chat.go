@@ -0,0 +1,242 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/interpolate"
+)
+
+// ChatClient sends workflow run events as messages to a Slack or Microsoft Teams incoming webhook
+type ChatClient struct {
+	options ChatConfig
+	client  *http.Client
+}
+
+// ChatConfig handles all config to send messages to a chat integration
+type ChatConfig struct {
+	Model            string
+	URL              string
+	Events           []string
+	Statuses         []string
+	WorkflowTemplate string
+	NodeTemplate     string
+	ProxyURL         string
+	CABundle         string
+}
+
+// initialize returns broker and err if config is invalid
+func (c *ChatClient) initialize(ctx context.Context, options interface{}) (Broker, error) {
+	conf, ok := options.(ChatConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid Chat Initialization")
+	}
+
+	if conf.URL == "" {
+		return nil, fmt.Errorf("initChat> Invalid Chat Configuration")
+	}
+	if conf.Model != sdk.SlackIntegrationModel && conf.Model != sdk.MicrosoftTeamsIntegrationModel {
+		return nil, fmt.Errorf("initChat> Invalid Chat model %s", conf.Model)
+	}
+	if len(conf.Events) == 0 {
+		conf.Events = chatList(sdk.ChatIntegrationDefaultEvents)
+	}
+	if conf.WorkflowTemplate == "" {
+		conf.WorkflowTemplate = sdk.ChatIntegrationDefaultWorkflowTemplate
+	}
+	if conf.NodeTemplate == "" {
+		conf.NodeTemplate = sdk.ChatIntegrationDefaultNodeTemplate
+	}
+	client, err := cdsclient.NewHTTPClientWithOptions(30*time.Second, cdsclient.HTTPClientOptions{ProxyURL: conf.ProxyURL, CABundle: conf.CABundle})
+	if err != nil {
+		return nil, fmt.Errorf("initChat> Invalid Chat Configuration: %v", err)
+	}
+	c.options = conf
+	c.client = client
+
+	return c, nil
+}
+
+// close does nothing, there is no connection to close
+func (c *ChatClient) close(ctx context.Context) {}
+
+// sendEvent posts a message for workflow and node run events, other events are ignored
+func (c *ChatClient) sendEvent(event *sdk.Event) error {
+	message, status, ok, err := c.message(event)
+	if err != nil || !ok {
+		return err
+	}
+
+	link := chatRunURL(event)
+	var payload interface{}
+	if c.options.Model == sdk.MicrosoftTeamsIntegrationModel {
+		payload = teamsPayload(message, status, link)
+	} else {
+		payload = slackPayload(message, link)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Post(c.options.URL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s webhook returned HTTP status %d", c.options.Model, resp.StatusCode)
+	}
+	return nil
+}
+
+// status: here, if c is initialized, chat is ok
+func (c *ChatClient) status() string {
+	return c.options.Model + " OK"
+}
+
+// message returns the message and the status of given event, ok is false if the event should not be sent.
+func (c *ChatClient) message(event *sdk.Event) (message, status string, ok bool, err error) {
+	vars := map[string]string{
+		"cds.project":    event.ProjectKey,
+		"cds.workflow":   event.WorkflowName,
+		"cds.run.number": strconv.FormatInt(event.WorkflowRunNum, 10),
+		"cds.author":     event.Username,
+	}
+
+	var kind, tmpl, branch string
+	switch event.EventType {
+	case fmt.Sprintf("%T", sdk.EventRunWorkflow{}):
+		var e sdk.EventRunWorkflow
+		if err := json.Unmarshal(event.Payload, &e); err != nil {
+			return "", "", false, sdk.WithStack(err)
+		}
+		kind, tmpl, status = "workflow", c.options.WorkflowTemplate, e.Status
+		for _, t := range e.Tags {
+			if t.Tag == "git.branch" {
+				branch = t.Value
+			}
+		}
+	case fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}):
+		var e sdk.EventRunWorkflowNode
+		if err := json.Unmarshal(event.Payload, &e); err != nil {
+			return "", "", false, sdk.WithStack(err)
+		}
+		kind, tmpl, status, branch = "node", c.options.NodeTemplate, e.Status, e.BranchName
+		vars["cds.node"] = e.NodeName
+	default:
+		return "", "", false, nil
+	}
+	if !chatContains(c.options.Events, kind) || (len(c.options.Statuses) > 0 && !chatContains(c.options.Statuses, status)) {
+		return "", "", false, nil
+	}
+
+	if branch == "" {
+		branch = "n/a"
+	}
+	vars["cds.branch"] = branch
+	vars["cds.status"] = status
+	vars["cds.buildURL"] = chatRunURL(event)
+
+	message, err = interpolate.Do(tmpl, vars)
+	if err != nil {
+		return "", "", false, sdk.WrapError(err, "cannot interpolate %s template", kind)
+	}
+	return message, status, true, nil
+}
+
+func chatRunURL(event *sdk.Event) string {
+	return fmt.Sprintf("%s/project/%s/workflow/%s/run/%d", uiURL, event.ProjectKey, event.WorkflowName, event.WorkflowRunNum)
+}
+
+// slackPayload returns a Block Kit message with a button that opens the run in CDS.
+func slackPayload(message, link string) interface{} {
+	return map[string]interface{}{
+		"text": message,
+		"blocks": []interface{}{
+			map[string]interface{}{
+				"type": "section",
+				"text": map[string]string{"type": "mrkdwn", "text": message},
+			},
+			map[string]interface{}{
+				"type": "actions",
+				"elements": []interface{}{
+					map[string]interface{}{
+						"type": "button",
+						"text": map[string]string{"type": "plain_text", "text": "Open in CDS"},
+						"url":  link,
+					},
+				},
+			},
+		},
+	}
+}
+
+// teamsPayload returns an Adaptive Card message with an action that opens the run in CDS.
+func teamsPayload(message, status, link string) interface{} {
+	color := "Default"
+	switch status {
+	case sdk.StatusSuccess:
+		color = "Good"
+	case sdk.StatusFail:
+		color = "Attention"
+	case sdk.StatusStopped:
+		color = "Warning"
+	}
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.2",
+					"body": []interface{}{
+						map[string]interface{}{
+							"type":  "TextBlock",
+							"text":  message,
+							"wrap":  true,
+							"color": color,
+						},
+					},
+					"actions": []interface{}{
+						map[string]interface{}{
+							"type":  "Action.OpenUrl",
+							"title": "Open in CDS",
+							"url":   link,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// chatList splits a comma separated list of the config of a chat integration.
+func chatList(s string) []string {
+	var res []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func chatContains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
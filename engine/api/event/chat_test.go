@@ -0,0 +1,79 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestChatClientSendEvent(t *testing.T) {
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		btes, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(btes, &body))
+		bodies = append(bodies, body)
+	}))
+	defer srv.Close()
+
+	newEvent := func(payload interface{}) *sdk.Event {
+		btes, err := json.Marshal(payload)
+		require.NoError(t, err)
+		return &sdk.Event{
+			EventType:      fmt.Sprintf("%T", payload),
+			Payload:        btes,
+			ProjectKey:     "PROJ",
+			WorkflowName:   "my-workflow",
+			WorkflowRunNum: 12,
+		}
+	}
+
+	b, err := getBroker(context.TODO(), "chat", ChatConfig{
+		Model:    sdk.SlackIntegrationModel,
+		URL:      srv.URL,
+		Statuses: chatList(sdk.ChatIntegrationDefaultStatuses),
+	})
+	require.NoError(t, err)
+
+	// Building runs, node runs and other events are not sent with the default config
+	require.NoError(t, b.sendEvent(newEvent(sdk.EventRunWorkflow{Status: sdk.StatusBuilding})))
+	require.NoError(t, b.sendEvent(newEvent(sdk.EventRunWorkflowNode{Status: sdk.StatusSuccess, NodeName: "build"})))
+	require.NoError(t, b.sendEvent(newEvent(sdk.EventJob{Status: sdk.StatusSuccess})))
+	require.Len(t, bodies, 0)
+
+	require.NoError(t, b.sendEvent(newEvent(sdk.EventRunWorkflow{
+		Status: sdk.StatusSuccess,
+		Tags:   []sdk.WorkflowRunTag{{Tag: "git.branch", Value: "master"}},
+	})))
+	require.Len(t, bodies, 1)
+	assert.Equal(t, "Workflow *PROJ/my-workflow* #12 on master: *Success*", bodies[0]["text"])
+	require.Len(t, bodies[0]["blocks"], 2)
+
+	b, err = getBroker(context.TODO(), "chat", ChatConfig{
+		Model:        sdk.MicrosoftTeamsIntegrationModel,
+		URL:          srv.URL,
+		Events:       []string{"node"},
+		NodeTemplate: "{{.cds.node}} is {{.cds.status}}",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, b.sendEvent(newEvent(sdk.EventRunWorkflow{Status: sdk.StatusSuccess})))
+	require.NoError(t, b.sendEvent(newEvent(sdk.EventRunWorkflowNode{Status: sdk.StatusFail, NodeName: "deploy"})))
+	require.Len(t, bodies, 2)
+	attachments := bodies[1]["attachments"].([]interface{})
+	require.Len(t, attachments, 1)
+	content := attachments[0].(map[string]interface{})["content"].(map[string]interface{})
+	block := content["body"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "deploy is Fail", block["text"])
+	assert.Equal(t, "Attention", block["color"])
+}
@@ -0,0 +1,72 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func insertAuditEvent(db gorp.SqlExecutor, e sdk.Event) error {
+	ae := auditEvent{
+		Created:   e.Timestamp,
+		EventType: e.EventType,
+		Data:      auditEventData(e),
+	}
+	if ae.Created.IsZero() {
+		ae.Created = time.Now()
+	}
+	return sdk.WrapError(gorpmapping.Insert(db, &ae), "cannot insert audit event %s", e.EventType)
+}
+
+func loadAuditEventsAfter(ctx context.Context, db gorp.SqlExecutor, lastEventID int64, limit int) ([]auditEvent, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM audit_event
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2`).Args(lastEventID, limit)
+	var events []auditEvent
+	if err := gorpmapping.GetAll(ctx, db, query, &events); err != nil {
+		return nil, sdk.WrapError(err, "cannot load audit events")
+	}
+	return events, nil
+}
+
+// loadAuditExportCursorForUpdate returns the cursor for given target, it returns nil if the cursor
+// is locked by another API instance that is already exporting audit events.
+func loadAuditExportCursorForUpdate(ctx context.Context, db gorp.SqlExecutor, target string) (*auditExportCursor, error) {
+	if _, err := db.Exec("INSERT INTO audit_export_cursor (target, last_event_id) VALUES ($1, 0) ON CONFLICT DO NOTHING", target); err != nil {
+		return nil, sdk.WrapError(err, "cannot insert audit export cursor for %s", target)
+	}
+
+	query := gorpmapping.NewQuery("SELECT * FROM audit_export_cursor WHERE target = $1 FOR UPDATE SKIP LOCKED").Args(target)
+	var c auditExportCursor
+	found, err := gorpmapping.Get(ctx, db, query, &c)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load audit export cursor for %s", target)
+	}
+	if !found {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+func updateAuditExportCursor(db gorp.SqlExecutor, c *auditExportCursor) error {
+	return sdk.WrapError(gorpmapping.Update(db, c), "cannot update audit export cursor for %s", c.Target)
+}
+
+// deleteExportedAuditEvents removes audit events older than given date that were exported to given target.
+func deleteExportedAuditEvents(db gorp.SqlExecutor, target string, before time.Time) (int64, error) {
+	res, err := db.Exec(`
+		DELETE FROM audit_event
+		WHERE created < $1
+		AND id <= (SELECT COALESCE(MAX(last_event_id), 0) FROM audit_export_cursor WHERE target = $2)`, before, target)
+	if err != nil {
+		return 0, sdk.WrapError(err, "cannot delete audit events")
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
@@ -22,6 +22,7 @@ import (
 var brokersConnectionCache = gocache.New(10*time.Minute, 6*time.Hour)
 var publicBrokersConnectionCache = []Broker{}
 var hostname, cdsname string
+var uiURL string
 var brokers []Broker
 var subscribers []chan<- sdk.Event
 
@@ -42,10 +43,75 @@ func getBroker(ctx context.Context, t string, option interface{}) (Broker, error
 	case "kafka":
 		k := &KafkaClient{}
 		return k.initialize(ctx, option)
+	case "webhook":
+		w := &WebhookClient{}
+		return w.initialize(ctx, option)
+	case "chat":
+		c := &ChatClient{}
+		return c.initialize(ctx, option)
+	case "jira":
+		j := &JiraClient{}
+		return j.initialize(ctx, option)
 	}
 	return nil, fmt.Errorf("Invalid Broker Type %s", t)
 }
 
+// getBrokerForProjectIntegration returns a broker for given event integration, the config should contains clear passwords.
+// Project variables referenced in the config are resolved.
+func getBrokerForProjectIntegration(ctx context.Context, db gorp.SqlExecutor, projInt sdk.ProjectIntegration) (Broker, error) {
+	if err := integration.InterpolateConfig(db, &projInt); err != nil {
+		return nil, err
+	}
+	switch projInt.Model.Name {
+	case sdk.WebhookIntegrationModel:
+		return getBroker(ctx, "webhook", WebhookConfig{
+			URL:           projInt.Config["url"].Value,
+			AuthHeader:    projInt.Config["auth header"].Value,
+			AuthToken:     projInt.Config["auth token"].Value,
+			SigningSecret: projInt.Config.SigningSecret(),
+			ProxyURL:      projInt.Config.ProxyURL(),
+			CABundle:      projInt.Config.CABundle(),
+		})
+	case sdk.SlackIntegrationModel, sdk.MicrosoftTeamsIntegrationModel:
+		return getBroker(ctx, "chat", ChatConfig{
+			Model:            projInt.Model.Name,
+			URL:              projInt.Config["url"].Value,
+			Events:           chatList(projInt.Config["events"].Value),
+			Statuses:         chatList(projInt.Config["statuses"].Value),
+			WorkflowTemplate: projInt.Config["workflow template"].Value,
+			NodeTemplate:     projInt.Config["node template"].Value,
+			ProxyURL:         projInt.Config.ProxyURL(),
+			CABundle:         projInt.Config.CABundle(),
+		})
+	case sdk.JiraIntegrationModel:
+		return getBroker(ctx, "jira", JiraConfig{
+			URL:                 projInt.Config["url"].Value,
+			Username:            projInt.Config["username"].Value,
+			Token:               projInt.Config["token"].Value,
+			Project:             projInt.Config["project"].Value,
+			Statuses:            chatList(projInt.Config["statuses"].Value),
+			Transition:          projInt.Config["transition"].Value,
+			CommentTemplate:     projInt.Config["comment template"].Value,
+			CreateIssue:         projInt.Config["create issue"].Value == "true",
+			IssueType:           projInt.Config["issue type"].Value,
+			SummaryTemplate:     projInt.Config["summary template"].Value,
+			DescriptionTemplate: projInt.Config["description template"].Value,
+			FieldsTemplate:      projInt.Config["fields template"].Value,
+			ProxyURL:            projInt.Config.ProxyURL(),
+			CABundle:            projInt.Config.CABundle(),
+		})
+	default:
+		return getBroker(ctx, "kafka", KafkaConfig{
+			Enabled:         true,
+			BrokerAddresses: projInt.Config["broker url"].Value,
+			User:            projInt.Config["username"].Value,
+			Password:        projInt.Config["password"].Value,
+			Topic:           projInt.Config["topic"].Value,
+			MaxMessageByte:  10000000,
+		})
+	}
+}
+
 func ResetPublicIntegrations(ctx context.Context, db *gorp.DbMap) error {
 	filterType := sdk.IntegrationTypeEvent
 	integrations, err := integration.LoadPublicModelsByTypeWithDecryption(db, &filterType)
@@ -91,24 +157,21 @@ func ResetEventIntegration(ctx context.Context, db gorp.SqlExecutor, eventIntegr
 		return fmt.Errorf("cannot load project integration id %d and type event: %v", eventIntegrationID, err)
 	}
 
-	kafkaCfg := KafkaConfig{
-		Enabled:         true,
-		BrokerAddresses: projInt.Config["broker url"].Value,
-		User:            projInt.Config["username"].Value,
-		Password:        projInt.Config["password"].Value,
-		Topic:           projInt.Config["topic"].Value,
-		MaxMessageByte:  10000000,
-	}
-	kafkaBroker, errk := getBroker(ctx, "kafka", kafkaCfg)
+	broker, errk := getBrokerForProjectIntegration(ctx, db, *projInt)
 	if errk != nil {
-		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "cannot get broker for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, errk)
+		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "cannot get broker for integration %s : %v", projInt.Name, errk)
 	}
-	if err := brokersConnectionCache.Add(brokerConnectionKey, kafkaBroker, gocache.DefaultExpiration); err != nil {
-		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "cannot add broker in cache for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, err)
+	if err := brokersConnectionCache.Add(brokerConnectionKey, broker, gocache.DefaultExpiration); err != nil {
+		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "cannot add broker in cache for integration %s : %v", projInt.Name, err)
 	}
 	return nil
 }
 
+// SetUIURL sets the URL of the CDS UI used in the messages sent to chat integrations.
+func SetUIURL(url string) {
+	uiURL = url
+}
+
 // Initialize initializes event system
 func Initialize(ctx context.Context, db *gorp.DbMap, cache cache.Store) error {
 	store = cache
@@ -173,24 +236,16 @@ func DequeueEvent(ctx context.Context, db *gorp.DbMap) {
 					continue
 				}
 
-				kafkaCfg := KafkaConfig{
-					Enabled:         true,
-					BrokerAddresses: projInt.Config["broker url"].Value,
-					User:            projInt.Config["username"].Value,
-					Password:        projInt.Config["password"].Value,
-					Topic:           projInt.Config["topic"].Value,
-					MaxMessageByte:  10000000,
-				}
-				kafkaBroker, errk := getBroker(ctx, "kafka", kafkaCfg)
+				newBroker, errk := getBrokerForProjectIntegration(ctx, db, *projInt)
 				if errk != nil {
-					log.Error(ctx, "Event.DequeueEvent> cannot get broker for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, errk)
+					log.Error(ctx, "Event.DequeueEvent> cannot get broker for integration %s : %v", projInt.Name, errk)
 					continue
 				}
-				if err := brokersConnectionCache.Add(brokerConnectionKey, kafkaBroker, gocache.DefaultExpiration); err != nil {
-					log.Error(ctx, "Event.DequeueEvent> cannot add broker in cache for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, err)
+				if err := brokersConnectionCache.Add(brokerConnectionKey, newBroker, gocache.DefaultExpiration); err != nil {
+					log.Error(ctx, "Event.DequeueEvent> cannot add broker in cache for integration %s : %v", projInt.Name, err)
 					continue
 				}
-				brokerConnection = kafkaBroker
+				brokerConnection = newBroker
 			}
 
 			broker, ok := brokerConnection.(Broker)
@@ -0,0 +1,49 @@
+package event
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type auditEvent struct {
+	ID        int64          `db:"id"`
+	Created   time.Time      `db:"created"`
+	EventType string         `db:"event_type"`
+	Data      auditEventData `db:"data"`
+}
+
+// auditEventData type used for database json storage of an event.
+type auditEventData sdk.Event
+
+// Scan event.
+func (d *auditEventData) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return sdk.WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return sdk.WrapError(json.Unmarshal(source, d), "cannot unmarshal audit event")
+}
+
+// Value returns driver.Value from event.
+func (d auditEventData) Value() (driver.Value, error) {
+	j, err := json.Marshal(d)
+	return j, sdk.WrapError(err, "cannot marshal audit event")
+}
+
+type auditExportCursor struct {
+	Target      string     `db:"target"`
+	LastEventID int64      `db:"last_event_id"`
+	LastExport  *time.Time `db:"last_export"`
+}
+
+func init() {
+	gorpmapping.Register(
+		gorpmapping.New(auditEvent{}, "audit_event", true, "id"),
+		gorpmapping.New(auditExportCursor{}, "audit_export_cursor", false, "target"),
+	)
+}
@@ -0,0 +1,302 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/interpolate"
+)
+
+var jiraIssueKeyRegex = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[0-9]+\b`)
+
+// JiraClient updates the JIRA issues referenced by the commits of the runs of deployment and release pipelines, and
+// can create an issue for each run
+type JiraClient struct {
+	options JiraConfig
+	client  *http.Client
+}
+
+// JiraConfig handles all config to call the JIRA REST API
+type JiraConfig struct {
+	URL                 string
+	Username            string
+	Token               string
+	Project             string
+	Statuses            []string
+	Transition          string
+	CommentTemplate     string
+	CreateIssue         bool
+	IssueType           string
+	SummaryTemplate     string
+	DescriptionTemplate string
+	FieldsTemplate      string
+	ProxyURL            string
+	CABundle            string
+}
+
+// initialize returns broker and err if config is invalid
+func (c *JiraClient) initialize(ctx context.Context, options interface{}) (Broker, error) {
+	conf, ok := options.(JiraConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid Jira Initialization")
+	}
+
+	if conf.URL == "" || conf.Token == "" {
+		return nil, fmt.Errorf("initJira> Invalid Jira Configuration")
+	}
+	if conf.CreateIssue && conf.Project == "" {
+		return nil, fmt.Errorf("initJira> Invalid Jira Configuration: project is mandatory to create issues")
+	}
+	conf.URL = strings.TrimSuffix(conf.URL, "/")
+	if len(conf.Statuses) == 0 {
+		conf.Statuses = chatList(sdk.JiraIntegrationDefaultStatuses)
+	}
+	if conf.IssueType == "" {
+		conf.IssueType = "Task"
+	}
+	if conf.SummaryTemplate == "" {
+		conf.SummaryTemplate = sdk.JiraIntegrationDefaultSummaryTemplate
+	}
+	if conf.DescriptionTemplate == "" {
+		conf.DescriptionTemplate = sdk.JiraIntegrationDefaultDescriptionTemplate
+	}
+	client, err := cdsclient.NewHTTPClientWithOptions(30*time.Second, cdsclient.HTTPClientOptions{ProxyURL: conf.ProxyURL, CABundle: conf.CABundle})
+	if err != nil {
+		return nil, fmt.Errorf("initJira> Invalid Jira Configuration: %v", err)
+	}
+	c.options = conf
+	c.client = client
+
+	return c, nil
+}
+
+// close does nothing, there is no connection to close
+func (c *JiraClient) close(ctx context.Context) {}
+
+// status: here, if c is initialized, jira is ok
+func (c *JiraClient) status() string {
+	return "Jira OK"
+}
+
+// sendEvent updates JIRA for the runs of the deployment and release pipelines, other events are ignored. The issues
+// referenced by the commits get a comment and a link to the run, then the transition is applied. An issue is created
+// first if enabled.
+func (c *JiraClient) sendEvent(event *sdk.Event) error {
+	if event.EventType != fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}) {
+		return nil
+	}
+	var e sdk.EventRunWorkflowNode
+	if err := json.Unmarshal(event.Payload, &e); err != nil {
+		return sdk.WithStack(err)
+	}
+	if e.IntegrationType == "" || !chatContains(c.options.Statuses, e.Status) {
+		return nil
+	}
+
+	keys := jiraIssueKeys(e.Commits, c.options.Project)
+	branch := e.BranchName
+	if branch == "" {
+		branch = "n/a"
+	}
+	vars := map[string]string{
+		"cds.project":     event.ProjectKey,
+		"cds.workflow":    event.WorkflowName,
+		"cds.run.number":  strconv.FormatInt(event.WorkflowRunNum, 10),
+		"cds.author":      event.Username,
+		"cds.node":        e.NodeName,
+		"cds.status":      e.Status,
+		"cds.branch":      branch,
+		"cds.buildURL":    chatRunURL(event),
+		"cds.integration": e.IntegrationName,
+		"cds.issues":      strings.Join(keys, ", "),
+	}
+	linkTitle := fmt.Sprintf("%s/%s #%d: %s", event.ProjectKey, event.WorkflowName, event.WorkflowRunNum, e.NodeName)
+
+	if c.options.CreateIssue {
+		key, err := c.createIssue(vars)
+		if err != nil {
+			return err
+		}
+		if err := c.addRemoteLink(key, vars["cds.buildURL"], linkTitle); err != nil {
+			return err
+		}
+	}
+
+	var errs []string
+	for _, key := range keys {
+		if err := c.updateIssue(key, vars, linkTitle); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("cannot update jira issues: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// updateIssue adds a comment and a link to the run to given issue, then applies the transition.
+func (c *JiraClient) updateIssue(key string, vars map[string]string, linkTitle string) error {
+	if c.options.CommentTemplate != "" {
+		comment, err := interpolate.Do(c.options.CommentTemplate, vars)
+		if err != nil {
+			return sdk.WrapError(err, "cannot interpolate comment template")
+		}
+		if err := c.do(http.MethodPost, "/rest/api/2/issue/"+url.PathEscape(key)+"/comment", map[string]string{"body": comment}, nil); err != nil {
+			return err
+		}
+	}
+	if err := c.addRemoteLink(key, vars["cds.buildURL"], linkTitle); err != nil {
+		return err
+	}
+	if c.options.Transition != "" {
+		return c.transitionIssue(key)
+	}
+	return nil
+}
+
+// createIssue creates an issue in the project of the integration and returns its key. The additional fields template
+// is interpolated with JSON escaped values.
+func (c *JiraClient) createIssue(vars map[string]string) (string, error) {
+	fields := map[string]interface{}{}
+	if c.options.FieldsTemplate != "" {
+		escaped := make(map[string]string, len(vars))
+		for k, v := range vars {
+			b, _ := json.Marshal(v)
+			escaped[k] = string(b[1 : len(b)-1])
+		}
+		data, err := interpolate.Do(c.options.FieldsTemplate, escaped)
+		if err != nil {
+			return "", sdk.WrapError(err, "cannot interpolate fields template")
+		}
+		if err := json.Unmarshal([]byte(data), &fields); err != nil {
+			return "", sdk.WrapError(err, "invalid fields template, it should be a JSON object")
+		}
+	}
+	summary, err := interpolate.Do(c.options.SummaryTemplate, vars)
+	if err != nil {
+		return "", sdk.WrapError(err, "cannot interpolate summary template")
+	}
+	description, err := interpolate.Do(c.options.DescriptionTemplate, vars)
+	if err != nil {
+		return "", sdk.WrapError(err, "cannot interpolate description template")
+	}
+	fields["project"] = map[string]string{"key": c.options.Project}
+	fields["issuetype"] = map[string]string{"name": c.options.IssueType}
+	fields["summary"] = summary
+	fields["description"] = description
+
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := c.do(http.MethodPost, "/rest/api/2/issue", map[string]interface{}{"fields": fields}, &created); err != nil {
+		return "", fmt.Errorf("cannot create jira issue: %v", err)
+	}
+	return created.Key, nil
+}
+
+// addRemoteLink links the run to given issue, the link is updated if it already exists.
+func (c *JiraClient) addRemoteLink(key, link, title string) error {
+	return c.do(http.MethodPost, "/rest/api/2/issue/"+url.PathEscape(key)+"/remotelink", map[string]interface{}{
+		"globalId": link,
+		"object": map[string]string{
+			"url":   link,
+			"title": title,
+		},
+	}, nil)
+}
+
+// transitionIssue applies the transition of the integration to given issue. Nothing is done if the transition is not
+// available from the current status of the issue, for example if it was already applied.
+func (c *JiraClient) transitionIssue(key string) error {
+	var res struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"transitions"`
+	}
+	path := "/rest/api/2/issue/" + url.PathEscape(key) + "/transitions"
+	if err := c.do(http.MethodGet, path, nil, &res); err != nil {
+		return err
+	}
+	for _, t := range res.Transitions {
+		if strings.EqualFold(t.Name, c.options.Transition) {
+			return c.do(http.MethodPost, path, map[string]interface{}{"transition": map[string]string{"id": t.ID}}, nil)
+		}
+	}
+	return nil
+}
+
+// do calls the JIRA REST API, the response is decoded in given out value if not nil.
+func (c *JiraClient) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.options.URL+path, body)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.options.Username != "" {
+		req.SetBasicAuth(c.options.Username, c.options.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.options.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned HTTP status %d: %s", method, path, resp.StatusCode, string(data))
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return sdk.WrapError(err, "cannot read response of %s %s", method, path)
+		}
+	}
+	return nil
+}
+
+// jiraIssueKeys returns the sorted issue keys found in the messages of given commits, only the keys of given project
+// are returned if it is not empty.
+func jiraIssueKeys(commits []sdk.VCSCommit, project string) []string {
+	found := make(map[string]struct{})
+	for _, c := range commits {
+		for _, key := range jiraIssueKeyRegex.FindAllString(c.Message, -1) {
+			if project != "" && !strings.HasPrefix(key, project+"-") {
+				continue
+			}
+			found[key] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(found))
+	for k := range found {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,130 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestJiraIssueKeys(t *testing.T) {
+	commits := []sdk.VCSCommit{
+		{Message: "OPS-12 fix deployment\n\nSee also OPS-7 and DEV-3"},
+		{Message: "Merge pull request (OPS-12)"},
+		{Message: "no issue, not-an-issue-1"},
+	}
+	assert.Equal(t, []string{"DEV-3", "OPS-12", "OPS-7"}, jiraIssueKeys(commits, ""))
+	assert.Equal(t, []string{"OPS-12", "OPS-7"}, jiraIssueKeys(commits, "OPS"))
+}
+
+func TestJiraClientSendEvent(t *testing.T) {
+	type request struct {
+		Method string
+		Path   string
+		Body   map[string]interface{}
+	}
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		assert.Equal(t, "cds@example.com", user)
+		assert.Equal(t, "my-token", password)
+
+		req := request{Method: r.Method, Path: r.URL.Path}
+		btes, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		if len(btes) > 0 {
+			require.NoError(t, json.Unmarshal(btes, &req.Body))
+		}
+		requests = append(requests, req)
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "10001", "key": "OPS-100"}`)) // nolint
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/OPS-12/transitions":
+			w.Write([]byte(`{"transitions": [{"id": "11", "name": "In Progress"}, {"id": "31", "name": "Deployed"}]}`)) // nolint
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"transitions": []}`)) // nolint
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	newEvent := func(payload interface{}) *sdk.Event {
+		btes, err := json.Marshal(payload)
+		require.NoError(t, err)
+		return &sdk.Event{
+			EventType:      fmt.Sprintf("%T", payload),
+			Payload:        btes,
+			ProjectKey:     "PROJ",
+			WorkflowName:   "my-workflow",
+			WorkflowRunNum: 12,
+		}
+	}
+
+	b, err := getBroker(context.TODO(), "jira", JiraConfig{
+		URL:             srv.URL + "/",
+		Username:        "cds@example.com",
+		Token:           "my-token",
+		Project:         "OPS",
+		Transition:      "deployed",
+		CommentTemplate: "{{.cds.node}} is {{.cds.status}}",
+		CreateIssue:     true,
+		FieldsTemplate:  `{"labels": ["{{.cds.workflow}}"]}`,
+	})
+	require.NoError(t, err)
+
+	deploy := sdk.EventRunWorkflowNode{
+		Status:          sdk.StatusSuccess,
+		NodeName:        "deploy",
+		BranchName:      "master",
+		IntegrationName: "my-k8s",
+		IntegrationType: string(sdk.IntegrationTypeDeployment),
+		Commits:         []sdk.VCSCommit{{Message: "OPS-12 new feature"}, {Message: "OPS-13 fix"}, {Message: "DEV-1 other project"}},
+	}
+
+	// Workflow runs, failed runs and nodes without deployment or release integration are ignored
+	require.NoError(t, b.sendEvent(newEvent(sdk.EventRunWorkflow{Status: sdk.StatusSuccess})))
+	failed := deploy
+	failed.Status = sdk.StatusFail
+	require.NoError(t, b.sendEvent(newEvent(failed)))
+	build := deploy
+	build.IntegrationType = ""
+	require.NoError(t, b.sendEvent(newEvent(build)))
+	require.Len(t, requests, 0)
+
+	require.NoError(t, b.sendEvent(newEvent(deploy)))
+	require.Len(t, requests, 9)
+
+	// The issue of the run is created then linked to the run
+	assert.Equal(t, "/rest/api/2/issue", requests[0].Path)
+	fields := requests[0].Body["fields"].(map[string]interface{})
+	assert.Equal(t, "OPS", fields["project"].(map[string]interface{})["key"])
+	assert.Equal(t, "Task", fields["issuetype"].(map[string]interface{})["name"])
+	assert.Equal(t, "my-workflow #12: deploy with my-k8s", fields["summary"])
+	assert.Contains(t, fields["description"], "Issues: OPS-12, OPS-13")
+	assert.Equal(t, []interface{}{"my-workflow"}, fields["labels"])
+	assert.Equal(t, "/rest/api/2/issue/OPS-100/remotelink", requests[1].Path)
+	assert.Equal(t, "/project/PROJ/workflow/my-workflow/run/12", requests[1].Body["globalId"])
+
+	// The referenced issues of the project are commented, linked and transitioned if the transition is available
+	assert.Equal(t, "/rest/api/2/issue/OPS-12/comment", requests[2].Path)
+	assert.Equal(t, "deploy is Success", requests[2].Body["body"])
+	assert.Equal(t, "/rest/api/2/issue/OPS-12/remotelink", requests[3].Path)
+	assert.Equal(t, http.MethodGet, requests[4].Method)
+	assert.Equal(t, "/rest/api/2/issue/OPS-12/transitions", requests[5].Path)
+	assert.Equal(t, "31", requests[5].Body["transition"].(map[string]interface{})["id"])
+	assert.Equal(t, "/rest/api/2/issue/OPS-13/comment", requests[6].Path)
+	assert.Equal(t, "/rest/api/2/issue/OPS-13/remotelink", requests[7].Path)
+	assert.Equal(t, "/rest/api/2/issue/OPS-13/transitions", requests[8].Path)
+}
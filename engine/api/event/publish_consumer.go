@@ -0,0 +1,41 @@
+package event
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// PublishConsumerAdd publish event when adding a builtin consumer
+func PublishConsumerAdd(ctx context.Context, c sdk.AuthConsumer, u sdk.Identifiable) {
+	e := sdk.EventConsumerAdd{
+		ConsumerID:    c.ID,
+		Name:          c.Name,
+		GroupIDs:      c.GroupIDs,
+		ScopeDetails:  c.ScopeDetails,
+		ProjectScopes: c.ProjectScopes,
+	}
+	if c.ParentID != nil {
+		e.ParentID = *c.ParentID
+	}
+	Publish(ctx, e, u)
+}
+
+// PublishConsumerRegen publish event when regenerating the signin token of a builtin consumer
+func PublishConsumerRegen(ctx context.Context, c sdk.AuthConsumer, revokeSessions bool, u sdk.Identifiable) {
+	e := sdk.EventConsumerRegen{
+		ConsumerID:     c.ID,
+		Name:           c.Name,
+		RevokeSessions: revokeSessions,
+	}
+	Publish(ctx, e, u)
+}
+
+// PublishConsumerDelete publish event when deleting a builtin consumer
+func PublishConsumerDelete(ctx context.Context, c sdk.AuthConsumer, u sdk.Identifiable) {
+	e := sdk.EventConsumerDelete{
+		ConsumerID: c.ID,
+		Name:       c.Name,
+	}
+	Publish(ctx, e, u)
+}
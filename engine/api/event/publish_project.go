@@ -178,3 +178,39 @@ func PublishDeleteProjectIntegration(ctx context.Context, p *sdk.Project, pf sdk
 	}
 	PublishProjectEvent(ctx, e, p.Key, u)
 }
+
+// PublishUpdateProjectQuota publishes an event on updating the quotas of a project
+func PublishUpdateProjectQuota(ctx context.Context, p *sdk.Project, q sdk.ProjectQuota, qOld sdk.ProjectQuota, u sdk.Identifiable) {
+	e := sdk.EventProjectQuotaUpdate{
+		NewQuota: q,
+		OldQuota: qOld,
+	}
+	PublishProjectEvent(ctx, e, p.Key, u)
+}
+
+// PublishProjectQuota publishes an event when the usage of a quota reaches its warning threshold or exceeds its limit,
+// the event is also sent to given event integrations of the project
+func PublishProjectQuota(ctx context.Context, key string, q sdk.ProjectQuota, name, level string, usage int64, eventIntegrations []sdk.ProjectIntegration) {
+	eventIntegrationsID := make([]int64, len(eventIntegrations))
+	for i, eventIntegration := range eventIntegrations {
+		eventIntegrationsID[i] = eventIntegration.ID
+	}
+
+	bts, _ := json.Marshal(sdk.EventProjectQuota{
+		Quota:    name,
+		Level:    level,
+		Usage:    usage,
+		Limit:    q.Limit(name),
+		HardStop: q.HardStop,
+	})
+	event := sdk.Event{
+		Timestamp:           time.Now(),
+		Hostname:            hostname,
+		CDSName:             cdsname,
+		EventType:           fmt.Sprintf("%T", sdk.EventProjectQuota{}),
+		Payload:             bts,
+		ProjectKey:          key,
+		EventIntegrationsID: eventIntegrationsID,
+	}
+	publishEvent(ctx, event)
+}
@@ -54,6 +54,11 @@ func PublishWorkflowRun(ctx context.Context, wr sdk.WorkflowRun, projectKey stri
 	publishRunWorkflow(ctx, e, projectKey, wr.Workflow.Name, "", "", "", wr.Number, wr.LastSubNumber, wr.Status, wr.Tags, wr.Workflow.EventIntegrations)
 }
 
+// PublishWorkflowNodeMutex publish event when a workflow node run acquires the mutex of its node
+func PublishWorkflowNodeMutex(ctx context.Context, e sdk.EventRunWorkflowNodeMutex, wr sdk.WorkflowRun, projectKey string) {
+	publishRunWorkflow(ctx, e, projectKey, wr.Workflow.Name, "", "", "", wr.Number, e.SubNumber, sdk.StatusBuilding, wr.Tags, wr.Workflow.EventIntegrations)
+}
+
 // PublishWorkflowNodeRun publish event on a workflow node run
 func PublishWorkflowNodeRun(ctx context.Context, nr sdk.WorkflowNodeRun, w sdk.Workflow, userWorkflowEvent []sdk.EventNotif) {
 	// get and send all user notifications
@@ -110,6 +115,19 @@ func PublishWorkflowNodeRun(ctx context.Context, nr sdk.WorkflowNodeRun, w sdk.W
 		env = w.Environments[wnode.Context.EnvironmentID]
 	}
 	e.NodeType = wnode.Type
+	if wnode.Context != nil && wnode.Context.ProjectIntegrationID != 0 {
+		pi := w.ProjectIntegrations[wnode.Context.ProjectIntegrationID]
+		switch {
+		case pi.Model.Deployment:
+			e.IntegrationType = string(sdk.IntegrationTypeDeployment)
+		case pi.Model.Release:
+			e.IntegrationType = string(sdk.IntegrationTypeRelease)
+		}
+		if e.IntegrationType != "" {
+			e.IntegrationName = pi.Name
+			e.Commits = nr.Commits
+		}
+	}
 
 	// Try to get gerrit variable
 	var project, changeID, branch, revision, url string
@@ -0,0 +1,90 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+// WebhookClient sends events with HTTP POST requests
+type WebhookClient struct {
+	options WebhookConfig
+	client  *http.Client
+}
+
+// WebhookConfig handles all config to send events to a webhook
+type WebhookConfig struct {
+	URL           string
+	AuthHeader    string
+	AuthToken     string
+	SigningSecret string
+	ProxyURL      string
+	CABundle      string
+}
+
+// initialize returns broker and err if config is invalid
+func (c *WebhookClient) initialize(ctx context.Context, options interface{}) (Broker, error) {
+	conf, ok := options.(WebhookConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid Webhook Initialization")
+	}
+
+	if conf.URL == "" {
+		return nil, fmt.Errorf("initWebhook> Invalid Webhook Configuration")
+	}
+	if conf.AuthHeader == "" {
+		conf.AuthHeader = "Authorization"
+	}
+	client, err := cdsclient.NewHTTPClientWithOptions(30*time.Second, cdsclient.HTTPClientOptions{ProxyURL: conf.ProxyURL, CABundle: conf.CABundle})
+	if err != nil {
+		return nil, fmt.Errorf("initWebhook> Invalid Webhook Configuration: %v", err)
+	}
+	c.options = conf
+	c.client = client
+
+	return c, nil
+}
+
+// close does nothing, there is no connection to close
+func (c *WebhookClient) close(ctx context.Context) {}
+
+// sendEvent posts the event as JSON on the webhook url
+func (c *WebhookClient) sendEvent(event *sdk.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.options.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.options.AuthToken != "" {
+		req.Header.Set(c.options.AuthHeader, c.options.AuthToken)
+	}
+	if c.options.SigningSecret != "" {
+		sdk.SignIntegrationRequest(req, c.options.SigningSecret, data, time.Now())
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// status: here, if c is initialized, webhook is ok
+func (c *WebhookClient) status() string {
+	return "Webhook OK"
+}
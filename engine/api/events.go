@@ -41,6 +41,16 @@ type eventsBroker struct {
 	router           *Router
 	chanAddClient    chan (*eventsBrokerSubscribe)
 	chanRemoveClient chan (string)
+	// queue subscribers only receive job run events
+	queueClients          map[string]chan<- sdk.Event
+	chanAddQueueClient    chan (eventsBrokerQueueSubscribe)
+	chanRemoveQueueClient chan (string)
+}
+
+// eventsBrokerQueueSubscribe is the information needed to subscribe to job run events
+type eventsBrokerQueueSubscribe struct {
+	UUID   string
+	events chan<- sdk.Event
 }
 
 var handledEventErrors = []string{
@@ -108,6 +118,8 @@ func (b *eventsBroker) cacheSubscribe(c context.Context, cacheMsgChan chan<- sdk
 func (b *eventsBroker) Start(ctx context.Context, panicCallback func(s string) (io.WriteCloser, error)) {
 	b.chanAddClient = make(chan (*eventsBrokerSubscribe))
 	b.chanRemoveClient = make(chan (string))
+	b.chanAddQueueClient = make(chan (eventsBrokerQueueSubscribe))
+	b.chanRemoveQueueClient = make(chan (string))
 
 	tickerMetrics := time.NewTicker(10 * time.Second)
 	defer tickerMetrics.Stop()
@@ -130,6 +142,16 @@ func (b *eventsBroker) Start(ctx context.Context, panicCallback func(s string) (
 			}
 
 		case receivedEvent := <-b.messages:
+			if receivedEvent.EventType == fmt.Sprintf("%T", sdk.EventRunWorkflowJob{}) {
+				for _, c := range b.queueClients {
+					// Do not block the broker if the queue subscriber is busy, it will reload the queue anyway
+					select {
+					case c <- receivedEvent:
+					default:
+					}
+				}
+			}
+
 			for i := range b.clients {
 				c := b.clients[i]
 				if c == nil {
@@ -170,6 +192,12 @@ func (b *eventsBroker) Start(ctx context.Context, panicCallback func(s string) (
 
 			client.isAlive.UnSet()
 			delete(b.clients, uuid)
+
+		case client := <-b.chanAddQueueClient:
+			b.queueClients[client.UUID] = client.events
+
+		case uuid := <-b.chanRemoveQueueClient:
+			delete(b.queueClients, uuid)
 		}
 	}
 }
@@ -239,7 +267,7 @@ func (client *eventsBrokerSubscribe) manageEvent(db gorp.SqlExecutor, event sdk.
 	var isHatcheryWithGroups = isHatchery && len(client.consumer.GroupIDs) > 0
 
 	switch {
-    case strings.HasPrefix(event.EventType, "sdk.EventProject") || strings.HasPrefix(event.EventType, "sdk.EventAsCodeEvent"):
+	case strings.HasPrefix(event.EventType, "sdk.EventProject") || strings.HasPrefix(event.EventType, "sdk.EventAsCodeEvent"):
 		if client.consumer.Maintainer() && !isHatcheryWithGroups {
 			return true, nil
 		}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (api *API) getGroupIntegrationsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		groupName := vars["permGroupName"]
+
+		g, err := group.LoadByName(ctx, api.mustDBRead(), groupName)
+		if err != nil {
+			return err
+		}
+
+		gis, err := integration.LoadGroupIntegrationsByGroupID(api.mustDBRead(), g.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, gis, http.StatusOK)
+	}
+}
+
+func (api *API) postGroupIntegrationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		groupName := vars["permGroupName"]
+
+		var gi sdk.GroupIntegration
+		if err := service.UnmarshalBody(r, &gi); err != nil {
+			return err
+		}
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName)
+		if err != nil {
+			return err
+		}
+
+		if gi.IntegrationModelID == 0 {
+			gi.IntegrationModelID = gi.Model.ID
+		}
+		if gi.IntegrationModelID != 0 {
+			gi.Model, err = integration.LoadModel(api.mustDB(), gi.IntegrationModelID)
+		} else {
+			gi.Model, err = integration.LoadModelByName(api.mustDB(), gi.Model.Name)
+		}
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "integration model not found"))
+		}
+		gi.IntegrationModelID = gi.Model.ID
+		gi.GroupID = g.ID
+		if err := gi.IsValid(); err != nil {
+			return err
+		}
+
+		if _, err := integration.LoadGroupIntegrationByNameWithClearPassword(api.mustDB(), g.ID, gi.Name); err == nil {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "integration %s already exists for group %s", gi.Name, g.Name)
+		} else if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+
+		if err := integration.InsertGroupIntegration(api.mustDB(), &gi); err != nil {
+			return err
+		}
+		gi.Blur()
+
+		return service.WriteJSON(w, gi, http.StatusOK)
+	}
+}
+
+func (api *API) putGroupIntegrationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		groupName := vars["permGroupName"]
+		integrationName := vars["integrationName"]
+
+		var data sdk.GroupIntegration
+		if err := service.UnmarshalBody(r, &data); err != nil {
+			return err
+		}
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		gi, err := integration.LoadGroupIntegrationByNameWithClearPassword(tx, g.ID, integrationName)
+		if err != nil {
+			return err
+		}
+
+		// Only the config of a group integration can be updated
+		gi.Config = data.Config
+		if err := integration.UpdateGroupIntegration(tx, &gi); err != nil {
+			return err
+		}
+		if err := gi.Config.CheckConnectivity(); err != nil {
+			return err
+		}
+
+		resetIDs, err := propagateGroupIntegration(tx, api.Cache, gi)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		for _, id := range resetIDs {
+			if err := event.ResetEventIntegration(ctx, api.mustDB(), id); err != nil {
+				log.Error(ctx, "putGroupIntegrationHandler> cannot connect to event broker of project integration %d: %v", id, err)
+			}
+		}
+
+		gi.Blur()
+		return service.WriteJSON(w, gi, http.StatusOK)
+	}
+}
+
+func (api *API) deleteGroupIntegrationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		groupName := vars["permGroupName"]
+		integrationName := vars["integrationName"]
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName)
+		if err != nil {
+			return err
+		}
+
+		gi, err := integration.LoadGroupIntegrationByNameWithClearPassword(api.mustDB(), g.ID, integrationName)
+		if err != nil {
+			return err
+		}
+
+		if err := integration.DeleteGroupIntegration(api.mustDB(), gi); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// propagateGroupIntegration copies the config of given group integration on the project integrations that inherit it
+// and were not overridden. Returns the ids of updated event integrations that should be reset.
+func propagateGroupIntegration(db gorp.SqlExecutor, store cache.Store, gi sdk.GroupIntegration) ([]int64, error) {
+	pis, err := integration.LoadIntegrationsByGroupIntegrationIDWithClearPassword(db, gi.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resetIDs []int64
+	for _, pi := range pis {
+		pi.Config = gi.Config.Clone()
+		if err := integration.UpdateIntegration(db, store, pi); err != nil {
+			return nil, err
+		}
+		if pi.Model.Event {
+			resetIDs = append(resetIDs, pi.ID)
+		}
+	}
+	return resetIDs, nil
+}
+
+// inheritGroupIntegrations adds the default integrations of given groups on a new project. When several groups
+// define an integration with the same name, or if the name is already used on the project, the first one is kept.
+// Returns the ids of added event integrations.
+func inheritGroupIntegrations(db gorp.SqlExecutor, p sdk.Project, groupIDs []int64) ([]int64, error) {
+	gis, err := integration.LoadGroupIntegrationsByGroupIDsWithClearPassword(db, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := integration.LoadIntegrationsByProjectID(db, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventIDs []int64
+	names := make(map[string]bool, len(gis)+len(existing))
+	for _, pi := range existing {
+		names[pi.Name] = true
+	}
+	for _, gi := range gis {
+		if names[gi.Name] {
+			continue
+		}
+		names[gi.Name] = true
+
+		pi := gi.ProjectIntegration(p.ID)
+		if err := integration.InsertIntegration(db, &pi); err != nil {
+			return nil, err
+		}
+		if pi.Model.Event {
+			eventIDs = append(eventIDs, pi.ID)
+		}
+	}
+	return eventIDs, nil
+}
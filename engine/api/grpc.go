@@ -0,0 +1,298 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/grpcapi"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// grpcRunStatusInterval is the interval between two checks of a run status when it is watched.
+const grpcRunStatusInterval = 2 * time.Second
+
+// grpcHandlers implements the gRPC services of the API, all calls are authenticated with a session JWT given
+// as bearer token in the authorization metadata.
+type grpcHandlers struct {
+	api *API
+}
+
+// serveGRPC starts the gRPC server, it stops when given context is done.
+func (a *API) serveGRPC(ctx context.Context) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", a.Config.GRPC.Addr, a.Config.GRPC.Port))
+	if err != nil {
+		return sdk.WrapError(err, "cannot listen on %s:%d", a.Config.GRPC.Addr, a.Config.GRPC.Port)
+	}
+
+	h := &grpcHandlers{api: a}
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(h.unaryInterceptor),
+		grpc.StreamInterceptor(h.streamInterceptor),
+	)
+	grpcapi.RegisterWorkflowQueueServer(s, h)
+
+	go func() {
+		<-ctx.Done()
+		s.GracefulStop()
+	}()
+
+	log.Info(ctx, "Starting CDS API gRPC Server on %s:%d", a.Config.GRPC.Addr, a.Config.GRPC.Port)
+	if err := s.Serve(lis); err != nil {
+		return sdk.WrapError(err, "cannot start gRPC server")
+	}
+	return nil
+}
+
+// authenticate checks the session JWT given in call metadata and adds the session and its consumer to the context.
+func (h *grpcHandlers) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var jwtRaw string
+	for _, v := range md.Get("authorization") {
+		if strings.HasPrefix(v, "Bearer ") {
+			jwtRaw = strings.TrimPrefix(v, "Bearer ")
+		}
+	}
+	if jwtRaw == "" {
+		return ctx, sdk.WithStack(sdk.ErrUnauthorized)
+	}
+
+	jwt, err := authentication.CheckSessionJWT(jwtRaw)
+	if err != nil {
+		return ctx, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+	claims := jwt.Claims.(*sdk.AuthSessionJWTClaims)
+	session, err := authentication.CheckSession(ctx, h.api.mustDB(), claims.StandardClaims.Id)
+	if err != nil {
+		return ctx, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+	ctx = context.WithValue(ctx, contextSession, session)
+
+	consumer, err := h.api.loadSessionConsumer(ctx, session)
+	if err != nil {
+		return ctx, err
+	}
+	if consumer == nil {
+		return ctx, sdk.WithStack(sdk.ErrUnauthorized)
+	}
+	return context.WithValue(ctx, contextAPIConsumer, consumer), nil
+}
+
+// grpcError converts a CDS error to a gRPC status error.
+func grpcError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	httpErr := sdk.ExtractHTTPError(err, "")
+	code := codes.Unknown
+	switch httpErr.Status {
+	case 400:
+		code = codes.InvalidArgument
+	case 401:
+		code = codes.Unauthenticated
+	case 403:
+		code = codes.PermissionDenied
+	case 404:
+		code = codes.NotFound
+	case 409:
+		code = codes.AlreadyExists
+	case 500:
+		code = codes.Internal
+	}
+	if code == codes.Unknown || code == codes.Internal {
+		log.Error(ctx, "gRPC call failed: %+v", err)
+	}
+	return status.Error(code, httpErr.Error())
+}
+
+func (h *grpcHandlers) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := h.authenticate(ctx)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	res, err := handler(ctx, req)
+	return res, grpcError(ctx, err)
+}
+
+// grpcAuthenticatedStream overrides the context of a server stream with the authenticated one.
+type grpcAuthenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s grpcAuthenticatedStream) Context() context.Context { return s.ctx }
+
+func (h *grpcHandlers) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := h.authenticate(ss.Context())
+	if err != nil {
+		return grpcError(ctx, err)
+	}
+	return grpcError(ctx, handler(srv, grpcAuthenticatedStream{ServerStream: ss, ctx: ctx}))
+}
+
+// checkScopes checks that the consumer has one of given scopes. As restricted scopes are defined with REST
+// routes, they never allow gRPC calls.
+func (h *grpcHandlers) checkScopes(ctx context.Context, scopes ...sdk.AuthConsumerScope) error {
+	c := getAPIConsumer(ctx)
+	if len(c.ScopeDetails) == 0 {
+		return nil
+	}
+	for _, s := range scopes {
+		for _, d := range c.ScopeDetails {
+			if d.Scope == s && len(d.Endpoints) == 0 {
+				return nil
+			}
+		}
+	}
+	return sdk.WrapError(sdk.ErrUnauthorized, "token scopes doesn't match expected: %v", scopes)
+}
+
+// Jobs returns the jobs in queue matching given filter, like the GET /queue/workflows route.
+func (h *grpcHandlers) Jobs(ctx context.Context, f *grpcapi.QueueFilter) (*grpcapi.JobList, error) {
+	if err := h.checkScopes(ctx, sdk.AuthConsumerScopeRun, sdk.AuthConsumerScopeRunExecution); err != nil {
+		return nil, err
+	}
+	if len(getAPIConsumer(ctx).ProjectScopes) > 0 {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "queue is not allowed for project scoped consumer")
+	}
+	if !sdk.StatusValidate(f.Status...) {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given status")
+	}
+	if f.ModelType != "" && !sdk.WorkerModelValidate(f.ModelType) {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given model type")
+	}
+
+	filter := workflow.NewQueueFilter()
+	if len(f.Status) > 0 {
+		filter.Statuses = f.Status
+	}
+	if f.ModelType != "" {
+		filter.ModelType = []string{f.ModelType}
+	}
+	if f.Since != nil {
+		since, err := ptypes.Timestamp(f.Since)
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given since date")
+		}
+		filter.Since = &since
+	}
+	if f.Limit > 0 {
+		limit := int(f.Limit)
+		filter.Limit = &limit
+	}
+	if isWorker(ctx) || isService(ctx) {
+		filter.Rights = sdk.PermissionReadExecute
+	}
+
+	jobs, err := h.api.loadScheduledWorkflowJobQueue(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &grpcapi.JobList{Jobs: make([]*grpcapi.Job, len(jobs))}
+	for i := range jobs {
+		res.Jobs[i] = grpcapi.NewJob(jobs[i])
+	}
+	return res, nil
+}
+
+// checkJob checks that the consumer is a hatchery allowed to book given job.
+func (h *grpcHandlers) checkJob(ctx context.Context, id int64) error {
+	if err := h.checkScopes(ctx, sdk.AuthConsumerScopeRunExecution); err != nil {
+		return err
+	}
+	if !isHatchery(ctx) {
+		return sdk.WithStack(sdk.ErrForbidden)
+	}
+	return h.api.checkJobIDPermissions(ctx, strconv.FormatInt(id, 10), sdk.PermissionReadWriteExecute, nil)
+}
+
+// Book books a job in queue for the hatchery, like the POST /queue/workflows/{id}/book route.
+func (h *grpcHandlers) Book(ctx context.Context, j *grpcapi.JobID) (*empty.Empty, error) {
+	if err := h.checkJob(ctx, j.Id); err != nil {
+		return nil, err
+	}
+
+	s, err := services.LoadByID(ctx, h.api.mustDB(), getAPIConsumer(ctx).Service.ID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := workflow.BookNodeJobRun(ctx, h.api.Cache, j.Id, s); err != nil {
+		return nil, sdk.WrapError(err, "job already booked")
+	}
+	return &empty.Empty{}, nil
+}
+
+// Unbook releases a job booked by the hatchery, like the DELETE /queue/workflows/{id}/book route.
+func (h *grpcHandlers) Unbook(ctx context.Context, j *grpcapi.JobID) (*empty.Empty, error) {
+	if err := h.checkJob(ctx, j.Id); err != nil {
+		return nil, err
+	}
+
+	if err := workflow.FreeNodeJobRun(ctx, h.api.Cache, j.Id); err != nil {
+		return nil, sdk.WrapError(err, "job not booked")
+	}
+	return &empty.Empty{}, nil
+}
+
+// WatchRun sends the status of a workflow run each time it changes, until the run is terminated.
+func (h *grpcHandlers) WatchRun(q *grpcapi.RunQuery, stream grpcapi.WorkflowQueue_WatchRunServer) error {
+	ctx := stream.Context()
+	if err := h.checkScopes(ctx, sdk.AuthConsumerScopeRun); err != nil {
+		return err
+	}
+	vars := map[string]string{"key": q.ProjectKey, "permWorkflowName": q.WorkflowName}
+	if err := checkConsumerProjectScopes(getAPIConsumer(ctx), vars, &service.HandlerConfig{
+		Method:      "GRPC",
+		CleanURL:    "/grpcapi.WorkflowQueue/WatchRun",
+		ProjectVerb: sdk.AuthConsumerProjectVerbReadRun,
+	}); err != nil {
+		return err
+	}
+	if err := h.api.checkPermission(ctx, vars, sdk.PermissionRead); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(grpcRunStatusInterval)
+	defer ticker.Stop()
+
+	var last time.Time
+	for {
+		wr, err := workflow.LoadRun(ctx, h.api.mustDB(), q.ProjectKey, q.WorkflowName, q.Number, workflow.LoadRunOptions{
+			DisableDetailledNodeRun: true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow run %d", q.Number)
+		}
+		if !wr.LastModified.Equal(last) {
+			last = wr.LastModified
+			if err := stream.Send(grpcapi.NewRunStatus(*wr)); err != nil {
+				return sdk.WithStack(err)
+			}
+		}
+		if sdk.StatusIsTerminated(wr.Status) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
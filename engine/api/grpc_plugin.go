@@ -26,6 +26,9 @@ func (api *API) postGRPCluginHandler() service.Handler {
 		if err := service.UnmarshalBody(r, &p); err != nil {
 			return sdk.WithStack(err)
 		}
+		if err := p.IsValid(); err != nil {
+			return err
+		}
 		p.Binaries = nil
 
 		tx, err := db.Begin()
@@ -40,6 +43,9 @@ func (api *API) postGRPCluginHandler() service.Handler {
 			if err != nil {
 				return err
 			}
+			if err := p.IsCompatibleWith(integrationModel); err != nil {
+				return err
+			}
 			p.IntegrationModelID = &integrationModel.ID
 		}
 
@@ -103,6 +109,9 @@ func (api *API) putGRPCluginHandler() service.Handler {
 		if err := service.UnmarshalBody(r, &p); err != nil {
 			return sdk.WithStack(err)
 		}
+		if err := p.IsValid(); err != nil {
+			return err
+		}
 
 		var name = mux.Vars(r)["name"]
 		old, err := plugin.LoadByName(api.mustDB(), name)
@@ -125,6 +134,9 @@ func (api *API) putGRPCluginHandler() service.Handler {
 			if err != nil {
 				return err
 			}
+			if err := p.IsCompatibleWith(integrationModel); err != nil {
+				return err
+			}
 			p.IntegrationModelID = &integrationModel.ID
 		}
 
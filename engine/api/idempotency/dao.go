@@ -0,0 +1,102 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// TTL is the duration during which the response of a request is returned again for a retry with the same key.
+const TTL = 24 * time.Hour
+
+// Insert creates given idempotency key for a request being processed. Returns false if a key with the same value
+// was already created by the consumer less than TTL ago.
+func Insert(db gorp.SqlExecutor, k *sdk.IdempotencyKey) (bool, error) {
+	k.Created = time.Now()
+	if _, err := db.Exec("DELETE FROM idempotency_key WHERE consumer_id = $1 AND key = $2 AND created < $3",
+		k.ConsumerID, k.Key, k.Created.Add(-TTL)); err != nil {
+		return false, sdk.WrapError(err, "cannot delete expired idempotency key")
+	}
+
+	id, err := db.SelectNullInt(`
+		INSERT INTO idempotency_key (consumer_id, key, request_hash, status_code, content_type, created)
+		VALUES ($1, $2, $3, 0, '', $4)
+		ON CONFLICT (consumer_id, key) DO NOTHING
+		RETURNING id`, k.ConsumerID, k.Key, k.RequestHash, k.Created)
+	if err != nil {
+		return false, sdk.WrapError(err, "cannot insert idempotency key")
+	}
+	if !id.Valid {
+		return false, nil
+	}
+	k.ID = id.Int64
+	return true, nil
+}
+
+// LoadByConsumerIDAndKey returns the idempotency key with given value created by a consumer.
+func LoadByConsumerIDAndKey(ctx context.Context, db gorp.SqlExecutor, consumerID, key string) (*sdk.IdempotencyKey, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM idempotency_key
+		WHERE consumer_id = $1 AND key = $2
+	`).Args(consumerID, key)
+	var k dbIdempotencyKey
+	found, err := gorpmapping.Get(ctx, db, query, &k)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load idempotency key")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := sdk.IdempotencyKey(k)
+	return &res, nil
+}
+
+// Update stores the response of the request of given idempotency key.
+func Update(db gorp.SqlExecutor, k *sdk.IdempotencyKey) error {
+	dbk := dbIdempotencyKey(*k)
+	return sdk.WrapError(gorpmapping.Update(db, &dbk), "cannot update idempotency key %d", k.ID)
+}
+
+// Delete removes given idempotency key, so that the request can be sent again.
+func Delete(db gorp.SqlExecutor, id int64) error {
+	_, err := db.Exec("DELETE FROM idempotency_key WHERE id = $1", id)
+	return sdk.WrapError(err, "cannot delete idempotency key %d", id)
+}
+
+// DeleteExpired removes the idempotency keys created more than TTL ago.
+func DeleteExpired(db gorp.SqlExecutor) (int64, error) {
+	res, err := db.Exec("DELETE FROM idempotency_key WHERE created < $1", time.Now().Add(-TTL))
+	if err != nil {
+		return 0, sdk.WrapError(err, "cannot delete expired idempotency keys")
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// Cleaner removes expired idempotency keys every hour.
+func Cleaner(ctx context.Context, dbFunc func() *gorp.DbMap) {
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "idempotency.Cleaner> exiting: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			n, err := DeleteExpired(dbFunc())
+			if err != nil {
+				log.Error(ctx, "idempotency.Cleaner> %v", err)
+				continue
+			}
+			log.Debug("idempotency.Cleaner> %d expired keys deleted", n)
+		}
+	}
+}
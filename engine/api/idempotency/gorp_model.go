@@ -0,0 +1,12 @@
+package idempotency
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type dbIdempotencyKey sdk.IdempotencyKey
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbIdempotencyKey{}, "idempotency_key", true, "id"))
+}
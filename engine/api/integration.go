@@ -27,6 +27,27 @@ func (api *API) getIntegrationModelsHandler() service.Handler {
 	}
 }
 
+// getIntegrationModelsCatalogHandler returns the catalog of the integration models, with for each model its config,
+// capabilities, required permissions and an example of project integration.
+func (api *API) getIntegrationModelsCatalogHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		models, err := integration.LoadModels(api.mustDB())
+		if err != nil {
+			return sdk.WrapError(err, "cannot get integration models")
+		}
+
+		catalog := make([]sdk.IntegrationModelCatalogEntry, 0, len(models))
+		for i := range models {
+			e, err := models[i].CatalogEntry()
+			if err != nil {
+				return err
+			}
+			catalog = append(catalog, e)
+		}
+		return service.WriteJSON(w, catalog, http.StatusOK)
+	}
+}
+
 func (api *API) getIntegrationModelHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -186,7 +207,7 @@ func propagatePublicIntegrationModelOnProject(ctx context.Context, db gorp.SqlEx
 			ProjectID:          p.ID,
 		}
 		oldPP.Config = m.DefaultConfig
-		if err := integration.UpdateIntegration(db, pp); err != nil {
+		if err := integration.UpdateIntegration(db, store, pp); err != nil {
 			return err
 		}
 		event.PublishUpdateProjectIntegration(ctx, &p, oldPP, pp, u)
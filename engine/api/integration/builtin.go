@@ -14,6 +14,10 @@ var (
 		sdk.RabbitMQIntegration,
 		sdk.OpenstackIntegration,
 		sdk.AWSIntegration,
+		sdk.WebhookIntegration,
+		sdk.DockerRegistryIntegration,
+		sdk.SlackIntegration,
+		sdk.MicrosoftTeamsIntegration,
 	}
 )
 
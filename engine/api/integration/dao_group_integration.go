@@ -0,0 +1,126 @@
+package integration
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func getAllGroupIntegrations(db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.GroupIntegration, error) {
+	var gis []dbGroupIntegration
+	if err := gorpmapping.GetAll(context.Background(), db, query, &gis, gorpmapping.GetOptions.WithDecryption); err != nil {
+		return nil, err
+	}
+
+	res := make([]sdk.GroupIntegration, 0, len(gis))
+	for i := range gis {
+		isValid, err := gorpmapping.CheckSignature(gis[i], gis[i].Signature)
+		if err != nil {
+			return nil, err
+		}
+		if !isValid {
+			log.Error(context.Background(), "integration.getAllGroupIntegrations> group integration %d data corrupted", gis[i].ID)
+			continue
+		}
+
+		m, err := LoadModelWithClearPassword(db, gis[i].IntegrationModelID)
+		if err != nil {
+			return nil, err
+		}
+		gis[i].Model = m
+		res = append(res, gis[i].GroupIntegration)
+	}
+	return res, nil
+}
+
+// LoadGroupIntegrationsByGroupID returns the default integrations of given group, secrets are blurred.
+func LoadGroupIntegrationsByGroupID(db gorp.SqlExecutor, groupID int64) ([]sdk.GroupIntegration, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM group_integration
+		WHERE group_id = $1
+		ORDER BY name`).Args(groupID)
+	gis, err := getAllGroupIntegrations(db, query)
+	if err != nil {
+		return nil, err
+	}
+	for i := range gis {
+		gis[i].Blur()
+	}
+	return gis, nil
+}
+
+// LoadGroupIntegrationsByGroupIDsWithClearPassword returns the default integrations of given groups.
+func LoadGroupIntegrationsByGroupIDsWithClearPassword(db gorp.SqlExecutor, groupIDs []int64) ([]sdk.GroupIntegration, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM group_integration
+		WHERE group_id = ANY(string_to_array($1, ',')::int[])
+		ORDER BY group_id, name`).Args(gorpmapping.IDsToQueryString(groupIDs))
+	return getAllGroupIntegrations(db, query)
+}
+
+// LoadGroupIntegrationByNameWithClearPassword returns a default integration of given group by its name.
+func LoadGroupIntegrationByNameWithClearPassword(db gorp.SqlExecutor, groupID int64, name string) (sdk.GroupIntegration, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM group_integration
+		WHERE group_id = $1 AND name = $2`).Args(groupID, name)
+	gis, err := getAllGroupIntegrations(db, query)
+	if err != nil {
+		return sdk.GroupIntegration{}, err
+	}
+	if len(gis) == 0 {
+		return sdk.GroupIntegration{}, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return gis[0], nil
+}
+
+// InsertGroupIntegration inserts a default integration for a group.
+func InsertGroupIntegration(db gorp.SqlExecutor, gi *sdk.GroupIntegration) error {
+	config := gi.Config.Clone()
+	dbGI := dbGroupIntegration{GroupIntegration: *gi}
+	if err := gorpmapping.InsertAndSign(context.Background(), db, &dbGI); err != nil {
+		return sdk.WrapError(err, "cannot insert group integration")
+	}
+	*gi = dbGI.GroupIntegration
+	gi.Config = config
+	return nil
+}
+
+// UpdateGroupIntegration updates a default integration of a group, passwords given with a placeholder are kept.
+func UpdateGroupIntegration(db gorp.SqlExecutor, gi *sdk.GroupIntegration) error {
+	old, err := LoadGroupIntegrationByNameWithClearPassword(db, gi.GroupID, gi.Name)
+	if err != nil {
+		return err
+	}
+	config := gi.Config.Clone()
+	for k, cfg := range config {
+		if cfg.Type == sdk.IntegrationConfigTypePassword && cfg.Value == sdk.PasswordPlaceholder {
+			cfg.Value = old.Config[k].Value
+			config[k] = cfg
+		}
+	}
+	gi.Config = config
+
+	dbGI := dbGroupIntegration{GroupIntegration: *gi}
+	if err := gorpmapping.UpdateAndSign(context.Background(), db, &dbGI); err != nil {
+		return sdk.WrapError(err, "cannot update group integration")
+	}
+	*gi = dbGI.GroupIntegration
+	gi.Config = config
+	return nil
+}
+
+// DeleteGroupIntegration deletes a default integration of a group, inherited project integrations are kept.
+func DeleteGroupIntegration(db gorp.SqlExecutor, gi sdk.GroupIntegration) error {
+	dbGI := dbGroupIntegration{GroupIntegration: gi}
+	if _, err := db.Delete(&dbGI); err != nil {
+		return sdk.WrapError(err, "cannot delete group integration")
+	}
+	return nil
+}
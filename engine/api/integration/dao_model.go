@@ -52,6 +52,8 @@ func LoadPublicModelsByTypeWithDecryption(db gorp.SqlExecutor, integrationType *
 			q += " AND integration_model.hook = true"
 		case sdk.IntegrationTypeDeployment:
 			q += " AND integration_model.deployment = true"
+		case sdk.IntegrationTypeRelease:
+			q += " AND integration_model.release = true"
 		}
 	}
 
@@ -5,20 +5,38 @@ import (
 
 	"github.com/go-gorp/gorp"
 
+	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
 
 // DeleteIntegration deletes a integration
-func DeleteIntegration(db gorp.SqlExecutor, integration sdk.ProjectIntegration) error {
+func DeleteIntegration(db gorp.SqlExecutor, store cache.Store, integration sdk.ProjectIntegration) error {
 	pp := dbProjectIntegration{ProjectIntegration: integration}
 	if _, err := db.Delete(&pp); err != nil {
 		return sdk.WrapError(err, "Cannot remove integration")
 	}
+	invalidateCache(db, store, integration)
 	return nil
 }
 
+// invalidateCache deletes the cache keys of given project integration, an error is only logged to not fail the
+// update. Nothing is done without store.
+func invalidateCache(db gorp.SqlExecutor, store cache.Store, pp sdk.ProjectIntegration) {
+	if store == nil {
+		return
+	}
+	projectKey, err := db.SelectStr("SELECT projectkey FROM project WHERE id = $1", pp.ProjectID)
+	if err != nil {
+		log.Error(context.Background(), "unable to load key of project %d: %v", pp.ProjectID, err)
+		return
+	}
+	if err := cache.InvalidateProjectIntegration(store, projectKey, pp.Name); err != nil {
+		log.Error(context.Background(), "unable to invalidate cache of integration %s/%s: %v", projectKey, pp.Name, err)
+	}
+}
+
 func load(db gorp.SqlExecutor, query gorpmapping.Query) (sdk.ProjectIntegration, error) {
 	pi, err := loadWithClearPassword(db, query)
 	pi.Blur()
@@ -167,7 +185,7 @@ func InsertIntegration(db gorp.SqlExecutor, pp *sdk.ProjectIntegration) error {
 }
 
 // UpdateIntegration Update a integration
-func UpdateIntegration(db gorp.SqlExecutor, pp sdk.ProjectIntegration) error {
+func UpdateIntegration(db gorp.SqlExecutor, store cache.Store, pp sdk.ProjectIntegration) error {
 	var oldConfig *sdk.ProjectIntegration
 
 	givenConfig := pp.Config.Clone()
@@ -191,20 +209,72 @@ func UpdateIntegration(db gorp.SqlExecutor, pp sdk.ProjectIntegration) error {
 	if err := gorpmapping.UpdateAndSign(context.Background(), db, &ppDb); err != nil {
 		return sdk.WrapError(err, "Cannot update integration")
 	}
+	invalidateCache(db, store, pp)
 	pp.Config = givenConfig
 	pp.Blur()
 	return nil
 }
 
+// LoadIntegrationsByGroupIntegrationIDWithClearPassword returns the project integrations inherited from given group
+// integration that were not overridden.
+func LoadIntegrationsByGroupIntegrationIDWithClearPassword(db gorp.SqlExecutor, groupIntegrationID int64) ([]sdk.ProjectIntegration, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM project_integration
+		WHERE group_integration_id = $1 AND overridden = false`).Args(groupIntegrationID)
+	return loadAllWithClearPassword(db, query)
+}
+
 // LoadIntegrationsByWorkflowID load integration integrations by Workflow id
 func LoadIntegrationsByWorkflowID(db gorp.SqlExecutor, id int64, clearPassword bool) ([]sdk.ProjectIntegration, error) {
 	query := gorpmapping.NewQuery(`SELECT project_integration.*
 	FROM project_integration
 		JOIN workflow_project_integration ON workflow_project_integration.project_integration_id = project_integration.id
-	WHERE workflow_project_integration.workflow_id = $1`).Args(id)
+	WHERE workflow_project_integration.workflow_id = $1 AND workflow_project_integration.artifact_storage = false`).Args(id)
 	return loadAll(db, query)
 }
 
+// LoadArtifactStoragesByWorkflowID loads the storage integrations pinned on a workflow with their routing
+func LoadArtifactStoragesByWorkflowID(db gorp.SqlExecutor, id int64) ([]sdk.WorkflowArtifactStorage, error) {
+	query := `SELECT project_integration.id, project_integration.name, workflow_project_integration.artifact_routing
+	FROM project_integration
+		JOIN workflow_project_integration ON workflow_project_integration.project_integration_id = project_integration.id
+	WHERE workflow_project_integration.workflow_id = $1 AND workflow_project_integration.artifact_storage = true
+	ORDER BY project_integration.name`
+	rows, err := db.Query(query, id)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	defer rows.Close() // nolint
+
+	var res []sdk.WorkflowArtifactStorage
+	for rows.Next() {
+		var s sdk.WorkflowArtifactStorage
+		var routing sdk.WorkflowArtifactStorageRouting
+		if err := rows.Scan(&s.ProjectIntegrationID, &s.ProjectIntegrationName, &routing); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		s.Nodes = routing.Nodes
+		res = append(res, s)
+	}
+	return res, sdk.WithStack(rows.Err())
+}
+
+// SetArtifactStoragesOnWorkflow replaces the storage integrations pinned on a workflow
+func SetArtifactStoragesOnWorkflow(db gorp.SqlExecutor, workflowID int64, storages []sdk.WorkflowArtifactStorage) error {
+	if _, err := db.Exec("DELETE FROM workflow_project_integration WHERE workflow_id = $1 AND artifact_storage = true", workflowID); err != nil {
+		return sdk.WithStack(err)
+	}
+	query := `INSERT INTO workflow_project_integration (workflow_id, project_integration_id, artifact_storage, artifact_routing) VALUES ($1, $2, true, $3)
+	ON CONFLICT (workflow_id, project_integration_id) DO UPDATE SET artifact_storage = true, artifact_routing = $3`
+	for _, s := range storages {
+		if _, err := db.Exec(query, workflowID, s.ProjectIntegrationID, sdk.WorkflowArtifactStorageRouting{Nodes: s.Nodes}); err != nil {
+			return sdk.WithStack(err)
+		}
+	}
+	return nil
+}
+
 // AddOnWorkflow link a project integration on a workflow
 func AddOnWorkflow(db gorp.SqlExecutor, workflowID int64, projectIntegrationID int64) error {
 	query := "INSERT INTO workflow_project_integration (workflow_id, project_integration_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
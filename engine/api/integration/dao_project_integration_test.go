@@ -50,6 +50,6 @@ func TestCRUDIntegration(t *testing.T) {
 	require.Len(t, reloadedInteg, 1)
 	assert.Equal(t, "mypassword", reloadedInteg[0].Config["password"].Value)
 
-	require.NoError(t, integration.DeleteIntegration(db, reloadedInteg[0]))
+	require.NoError(t, integration.DeleteIntegration(db, nil, reloadedInteg[0]))
 
 }
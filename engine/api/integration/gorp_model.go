@@ -52,7 +52,20 @@ func (e dbProjectIntegration) Canonical() gorpmapping.CanonicalForms {
 	}
 }
 
+type dbGroupIntegration struct {
+	gorpmapping.SignedEntity
+	sdk.GroupIntegration
+}
+
+func (e dbGroupIntegration) Canonical() gorpmapping.CanonicalForms {
+	var _ = []interface{}{e.IntegrationModelID, e.GroupID, e.Name}
+	return gorpmapping.CanonicalForms{
+		"{{.IntegrationModelID}}{{.GroupID}}{{.Name}}",
+	}
+}
+
 func init() {
 	gorpmapping.Register(gorpmapping.New(integrationModel{}, "integration_model", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectIntegration{}, "project_integration", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbGroupIntegration{}, "group_integration", true, "id"))
 }
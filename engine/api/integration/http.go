@@ -0,0 +1,18 @@
+package integration
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+// NewHTTPClient returns a HTTP client that reaches an integration through the proxy and with the ca bundle set in
+// given config. The config should have been interpolated.
+func NewHTTPClient(config sdk.IntegrationConfig, timeout time.Duration) (*http.Client, error) {
+	return cdsclient.NewHTTPClientWithOptions(timeout, cdsclient.HTTPClientOptions{
+		ProxyURL: config.ProxyURL(),
+		CABundle: config.CABundle(),
+	})
+}
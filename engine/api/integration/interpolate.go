@@ -0,0 +1,29 @@
+package integration
+
+import (
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ProjectVariablesLoader loads all the variables of a project with clear secrets. It is set by the API at startup
+// because the project package depends on this one.
+var ProjectVariablesLoader func(db gorp.SqlExecutor, projectID int64) ([]sdk.Variable, error)
+
+// InterpolateConfig resolves project variables referenced in the config of given project integration
+// with the {{.cds.proj.*}} syntax. The integration should have been loaded with clear passwords.
+func InterpolateConfig(db gorp.SqlExecutor, pp *sdk.ProjectIntegration) error {
+	if ProjectVariablesLoader == nil || pp.ProjectID == 0 {
+		return nil
+	}
+	vars, err := ProjectVariablesLoader(db, pp.ProjectID)
+	if err != nil {
+		return sdk.WrapError(err, "cannot load variables for project %d", pp.ProjectID)
+	}
+	config, err := pp.Config.Interpolate(vars)
+	if err != nil {
+		return err
+	}
+	pp.Config = config
+	return nil
+}
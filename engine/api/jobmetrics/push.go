@@ -0,0 +1,160 @@
+package jobmetrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var client = &http.Client{Timeout: 30 * time.Second, Transport: cdsclient.NewInstrumentedTransport(http.DefaultTransport)}
+
+// Push sends metrics to the backend of given metrics integration config, given labels are added to all the metrics.
+func Push(ctx context.Context, config sdk.IntegrationConfig, labels map[string]string, metrics []sdk.JobMetric) error {
+	u := strings.TrimSuffix(config["url"].Value, "/")
+	if u == "" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing url in metrics integration config")
+	}
+
+	var req *http.Request
+	var err error
+	switch t := config["type"].Value; t {
+	case sdk.MetricsIntegrationTypePushgateway, "":
+		job := config["job"].Value
+		if job == "" {
+			job = "cds"
+		}
+		req, err = http.NewRequest(http.MethodPost, u+pushgatewayPath(job, labels), bytes.NewBufferString(pushgatewayBody(metrics)))
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	case sdk.MetricsIntegrationTypeInfluxDB:
+		req, err = http.NewRequest(http.MethodPost, u+"/write?precision=s&db="+url.QueryEscape(config["database"].Value),
+			bytes.NewBufferString(influxDBBody(labels, metrics, time.Now())))
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	default:
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid metrics integration type %q, should be %s or %s", t,
+			sdk.MetricsIntegrationTypePushgateway, sdk.MetricsIntegrationTypeInfluxDB)
+	}
+
+	if username := config["username"].Value; username != "" {
+		req.SetBasicAuth(username, config["password"].Value)
+	}
+
+	c := client
+	if config.ProxyURL() != "" || config.CABundle() != "" {
+		c, err = cdsclient.NewHTTPClientWithOptions(client.Timeout, cdsclient.HTTPClientOptions{ProxyURL: config.ProxyURL(), CABundle: config.CABundle()})
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := c.Do(req.WithContext(ctx))
+	if err != nil {
+		return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrUnknownError, "cannot push metrics"))
+	}
+	defer resp.Body.Close() // nolint
+	if resp.StatusCode >= 300 {
+		return sdk.NewErrorFrom(sdk.ErrUnknownError, "cannot push metrics: %s returned HTTP status %d", req.URL.Host, resp.StatusCode)
+	}
+	return nil
+}
+
+// pushgatewayPath returns the path of the group of the metrics, identified by the job and given labels.
+// Pushing to the same group replaces the previous metrics with the same names.
+func pushgatewayPath(job string, labels map[string]string) string {
+	var b strings.Builder
+	b.WriteString("/metrics")
+	writeGroupingKey(&b, "job", job)
+	for _, k := range sortedKeys(labels) {
+		writeGroupingKey(&b, k, labels[k])
+	}
+	return b.String()
+}
+
+func writeGroupingKey(b *strings.Builder, name, value string) {
+	// Values that contain a slash or are empty should be base64 encoded
+	if value == "" || strings.Contains(value, "/") {
+		fmt.Fprintf(b, "/%s@base64/%s", name, base64.RawURLEncoding.EncodeToString([]byte(value)))
+		return
+	}
+	fmt.Fprintf(b, "/%s/%s", name, url.PathEscape(value))
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// pushgatewayBody returns metrics in the Prometheus text exposition format.
+func pushgatewayBody(metrics []sdk.JobMetric) string {
+	var b strings.Builder
+	declared := make(map[string]struct{}, len(metrics))
+	for _, m := range metrics {
+		if _, ok := declared[m.Name]; !ok {
+			declared[m.Name] = struct{}{}
+			if m.Help != "" {
+				fmt.Fprintf(&b, "# HELP %s %s\n", m.Name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(m.Help))
+			}
+			fmt.Fprintf(&b, "# TYPE %s %s\n", m.Name, m.Type)
+		}
+		b.WriteString(m.Name)
+		if len(m.Labels) > 0 {
+			b.WriteString("{")
+			for i, k := range sortedKeys(m.Labels) {
+				if i > 0 {
+					b.WriteString(",")
+				}
+				fmt.Fprintf(&b, "%s=\"%s\"", k, labelValueEscaper.Replace(m.Labels[k]))
+			}
+			b.WriteString("}")
+		}
+		fmt.Fprintf(&b, " %s\n", strconv.FormatFloat(m.Value, 'g', -1, 64))
+	}
+	return b.String()
+}
+
+var influxDBTagEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+// influxDBBody returns metrics in the InfluxDB line protocol, the name of a metric is the measurement and its value
+// is the field value.
+func influxDBBody(labels map[string]string, metrics []sdk.JobMetric, t time.Time) string {
+	var b strings.Builder
+	for _, m := range metrics {
+		tags := make(map[string]string, len(labels)+len(m.Labels))
+		for k, v := range m.Labels {
+			tags[k] = v
+		}
+		for k, v := range labels {
+			tags[k] = v
+		}
+		b.WriteString(m.Name)
+		for _, k := range sortedKeys(tags) {
+			if tags[k] == "" {
+				continue
+			}
+			fmt.Fprintf(&b, ",%s=%s", influxDBTagEscaper.Replace(k), influxDBTagEscaper.Replace(tags[k]))
+		}
+		fmt.Fprintf(&b, " value=%s %d\n", strconv.FormatFloat(m.Value, 'g', -1, 64), t.Unix())
+	}
+	return b.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,81 @@
+package jobmetrics
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var testMetrics = []sdk.JobMetric{
+	{Name: "tests_total", Type: sdk.JobMetricTypeCounter, Help: "Number of tests", Value: 42, Labels: map[string]string{"status": "ok"}},
+	{Name: "tests_total", Type: sdk.JobMetricTypeCounter, Value: 2, Labels: map[string]string{"status": "ko"}},
+	{Name: "binary_size_bytes", Type: sdk.JobMetricTypeGauge, Value: 1.5e+06},
+}
+
+func TestPushPushgateway(t *testing.T) {
+	var path, body, user string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		user, _, _ = r.BasicAuth()
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer srv.Close()
+
+	config := sdk.MetricsIntegration.DefaultConfig.Clone()
+	config["url"] = sdk.IntegrationConfigValue{Value: srv.URL + "/"}
+	config["username"] = sdk.IntegrationConfigValue{Value: "cds"}
+	labels := map[string]string{sdk.JobMetricLabelProject: "PROJ", sdk.JobMetricLabelWorkflow: "my workflow", sdk.JobMetricLabelNode: "build/test"}
+	require.NoError(t, Push(context.TODO(), config, labels, testMetrics))
+
+	assert.Equal(t, "/metrics/job/cds/cds_node@base64/YnVpbGQvdGVzdA/cds_project/PROJ/cds_workflow/my%20workflow", path)
+	assert.Equal(t, "cds", user)
+	assert.Equal(t, `# HELP tests_total Number of tests
+# TYPE tests_total counter
+tests_total{status="ok"} 42
+tests_total{status="ko"} 2
+# TYPE binary_size_bytes gauge
+binary_size_bytes 1.5e+06
+`, body)
+}
+
+func TestPushInfluxDB(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	config := sdk.MetricsIntegration.DefaultConfig.Clone()
+	config["type"] = sdk.IntegrationConfigValue{Value: sdk.MetricsIntegrationTypeInfluxDB}
+	config["url"] = sdk.IntegrationConfigValue{Value: srv.URL}
+	config["database"] = sdk.IntegrationConfigValue{Value: "builds"}
+	require.NoError(t, Push(context.TODO(), config, nil, testMetrics))
+	assert.Equal(t, "precision=s&db=builds", query)
+
+	body := influxDBBody(map[string]string{sdk.JobMetricLabelWorkflow: "my workflow"}, testMetrics[:1], time.Unix(1600000000, 0))
+	assert.Equal(t, "tests_total,cds_workflow=my\\ workflow,status=ok value=42 1600000000\n", body)
+}
+
+func TestPushError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	config := sdk.MetricsIntegration.DefaultConfig.Clone()
+	config["url"] = sdk.IntegrationConfigValue{Value: srv.URL}
+	assert.Error(t, Push(context.TODO(), config, nil, testMetrics))
+
+	config["type"] = sdk.IntegrationConfigValue{Value: "graphite"}
+	assert.Error(t, Push(context.TODO(), config, nil, testMetrics))
+}
@@ -0,0 +1,87 @@
+package ldapsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadGroupLinks returns the links of all the groups known by the sync.
+func LoadGroupLinks(ctx context.Context, db gorp.SqlExecutor) ([]GroupLink, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM ldap_sync_group ORDER BY id")
+	var ls []GroupLink
+	if err := gorpmapping.GetAll(ctx, db, query, &ls); err != nil {
+		return nil, sdk.WrapError(err, "cannot load ldap sync groups")
+	}
+	return ls, nil
+}
+
+// LoadGroupLinkByGroupID returns the link of given group, ErrNotFound if the group is not known by the sync.
+func LoadGroupLinkByGroupID(ctx context.Context, db gorp.SqlExecutor, groupID int64) (*GroupLink, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM ldap_sync_group WHERE group_id = $1").Args(groupID)
+	var l GroupLink
+	found, err := gorpmapping.Get(ctx, db, query, &l)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load ldap sync group %d", groupID)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &l, nil
+}
+
+// InsertGroupLink inserts a link for a group synchronized with a LDAP group.
+func InsertGroupLink(db gorp.SqlExecutor, l *GroupLink) error {
+	l.Created = time.Now()
+	if err := gorpmapping.Insert(db, l); err != nil {
+		return sdk.WrapError(err, "cannot insert ldap sync group %d", l.GroupID)
+	}
+	return nil
+}
+
+// UpdateGroupLink updates the link of a group synchronized with a LDAP group.
+func UpdateGroupLink(db gorp.SqlExecutor, l *GroupLink) error {
+	if err := gorpmapping.Update(db, l); err != nil {
+		return sdk.WrapError(err, "cannot update ldap sync group %d", l.GroupID)
+	}
+	return nil
+}
+
+// DeleteGroupLink removes the link of a group, the group is then managed manually.
+func DeleteGroupLink(db gorp.SqlExecutor, l *GroupLink) error {
+	if err := gorpmapping.Delete(db, l); err != nil {
+		return sdk.WrapError(err, "cannot delete ldap sync group %d", l.GroupID)
+	}
+	return nil
+}
+
+// LoadMemberLinks returns the links of all the memberships added by the sync.
+func LoadMemberLinks(ctx context.Context, db gorp.SqlExecutor) ([]MemberLink, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM ldap_sync_member ORDER BY id")
+	var ls []MemberLink
+	if err := gorpmapping.GetAll(ctx, db, query, &ls); err != nil {
+		return nil, sdk.WrapError(err, "cannot load ldap sync members")
+	}
+	return ls, nil
+}
+
+// InsertMemberLink marks a membership as added by the sync.
+func InsertMemberLink(db gorp.SqlExecutor, l *MemberLink) error {
+	l.Created = time.Now()
+	if err := gorpmapping.Insert(db, l); err != nil {
+		return sdk.WrapError(err, "cannot insert ldap sync member %s in group %d", l.AuthentifiedUserID, l.GroupID)
+	}
+	return nil
+}
+
+// DeleteMemberLink removes the mark of a membership added by the sync.
+func DeleteMemberLink(db gorp.SqlExecutor, groupID int64, userID string) error {
+	if _, err := db.Exec("DELETE FROM ldap_sync_member WHERE group_id = $1 AND authentified_user_id = $2", groupID, userID); err != nil {
+		return sdk.WrapError(err, "cannot delete ldap sync member %s in group %d", userID, groupID)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package ldapsync
+
+import (
+	"time"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+)
+
+// GroupLink links a CDS group to the LDAP group it is synchronized with. An opted out group is never changed by
+// the sync.
+type GroupLink struct {
+	ID        int64     `db:"id"`
+	GroupID   int64     `db:"group_id"`
+	LDAPGroup string    `db:"ldap_group"`
+	OptOut    bool      `db:"opt_out"`
+	Created   time.Time `db:"created"`
+}
+
+// MemberLink marks a group membership added by the sync, other memberships are managed manually.
+type MemberLink struct {
+	ID                 int64     `db:"id"`
+	GroupID            int64     `db:"group_id"`
+	AuthentifiedUserID string    `db:"authentified_user_id"`
+	Created            time.Time `db:"created"`
+}
+
+func init() {
+	gorpmapping.Register(
+		gorpmapping.New(GroupLink{}, "ldap_sync_group", true, "id"),
+		gorpmapping.New(MemberLink{}, "ldap_sync_member", true, "id"),
+	)
+}
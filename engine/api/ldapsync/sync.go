@@ -0,0 +1,420 @@
+package ldapsync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/scim"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+var (
+	lockKey   = cache.Key("api", "ldapsync", "lock")
+	reportKey = cache.Key("api", "ldapsync", "report")
+)
+
+// Configuration of the LDAP group sync
+type Configuration struct {
+	Enabled            bool   `toml:"enabled" default:"false" comment:"Periodically synchronize the CDS groups with the LDAP groups" json:"enabled"`
+	Interval           int    `toml:"interval" default:"3600" comment:"Interval between two synchronizations, in seconds" json:"interval"`
+	DryRun             bool   `toml:"dryRun" default:"false" comment:"Only compute the sync reports, without changing the CDS groups" json:"dryRun"`
+	GroupSearchBase    string `toml:"groupSearchBase" default:"ou=groups" json:"groupSearchBase"`
+	GroupFilter        string `toml:"groupFilter" default:"(objectClass=groupOfNames)" json:"groupFilter"`
+	GroupNameAttribute string `toml:"groupNameAttribute" default:"cn" json:"groupNameAttribute"`
+	MemberAttribute    string `toml:"memberAttribute" default:"member" comment:"Attribute of the members of a group, given as uid or as DN" json:"memberAttribute"`
+	GroupPrefix        string `toml:"groupPrefix" default:"" comment:"Only the LDAP groups with this prefix are synchronized, the prefix is removed from the name of the CDS group" json:"groupPrefix"`
+	AdoptLocalGroups   bool   `toml:"adoptLocalGroups" default:"false" comment:"Let the sync manage existing local groups with the same name, else it is reported as a conflict" json:"adoptLocalGroups"`
+}
+
+// SearchFunc returns the groups of the LDAP directory.
+type SearchFunc func(ctx context.Context) ([]sdk.LDAPGroup, error)
+
+// State is the current state of the CDS groups and users the sync is computed on.
+type State struct {
+	Groups         map[string]sdk.Group            // by name, with members
+	GroupLinks     map[int64]GroupLink             // by group id
+	MemberLinks    map[int64]map[string]struct{}   // user ids by group id
+	Users          map[string]sdk.AuthentifiedUser // by LDAP uid
+	ReservedGroups map[int64]struct{}              // groups that can't be adopted
+}
+
+// Compute returns the actions needed to synchronize the CDS groups with given LDAP groups, and the conflicts with the
+// groups and memberships managed manually.
+func Compute(cfg Configuration, ldapGroups []sdk.LDAPGroup, s State) sdk.LDAPSyncReport {
+	var report sdk.LDAPSyncReport
+
+	sorted := make([]sdk.LDAPGroup, len(ldapGroups))
+	copy(sorted, ldapGroups)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for _, lg := range sorted {
+		name := lg.Name
+		if cfg.GroupPrefix != "" {
+			if !strings.HasPrefix(name, cfg.GroupPrefix) {
+				continue
+			}
+			name = strings.TrimPrefix(name, cfg.GroupPrefix)
+		}
+		if err := (sdk.Group{Name: name}).IsValid(); err != nil {
+			report.Conflicts = append(report.Conflicts, sdk.LDAPSyncConflict{
+				Type:   sdk.LDAPSyncConflictInvalidGroup,
+				Group:  name,
+				Detail: fmt.Sprintf("LDAP group %s can't be synchronized, %s is not a valid group name", lg.DN, name),
+			})
+			continue
+		}
+
+		g, exists := s.Groups[name]
+		if exists {
+			l, linked := s.GroupLinks[g.ID]
+			if linked && l.OptOut {
+				report.OptedOut = append(report.OptedOut, name)
+				continue
+			}
+			if !linked {
+				if _, reserved := s.ReservedGroups[g.ID]; reserved || !cfg.AdoptLocalGroups {
+					report.Conflicts = append(report.Conflicts, sdk.LDAPSyncConflict{
+						Type:   sdk.LDAPSyncConflictLocalGroup,
+						Group:  name,
+						Detail: fmt.Sprintf("group %s already exists and is not managed by the LDAP sync", name),
+					})
+					continue
+				}
+				report.Actions = append(report.Actions, sdk.LDAPSyncAction{
+					Type:      sdk.LDAPSyncActionGroupAdopt,
+					Group:     name,
+					LDAPGroup: lg.DN,
+				})
+			}
+		} else {
+			report.Actions = append(report.Actions, sdk.LDAPSyncAction{
+				Type:      sdk.LDAPSyncActionGroupCreate,
+				Group:     name,
+				LDAPGroup: lg.DN,
+			})
+		}
+
+		desired := make(map[string]sdk.AuthentifiedUser, len(lg.Members))
+		for _, uid := range lg.Members {
+			u, ok := s.Users[uid]
+			if !ok {
+				report.Conflicts = append(report.Conflicts, sdk.LDAPSyncConflict{
+					Type:     sdk.LDAPSyncConflictUnknownUser,
+					Group:    name,
+					Username: uid,
+					Detail:   fmt.Sprintf("LDAP user %s never signed in to CDS, it is not added to group %s", uid, name),
+				})
+				continue
+			}
+			desired[u.ID] = u
+		}
+
+		current := make(map[string]struct{}, len(g.Members))
+		for _, m := range g.Members {
+			current[m.ID] = struct{}{}
+		}
+
+		desiredIDs := make([]string, 0, len(desired))
+		for id := range desired {
+			desiredIDs = append(desiredIDs, id)
+		}
+		sort.Strings(desiredIDs)
+		for _, id := range desiredIDs {
+			if _, ok := current[id]; ok {
+				continue
+			}
+			report.Actions = append(report.Actions, sdk.LDAPSyncAction{
+				Type:     sdk.LDAPSyncActionMemberAdd,
+				Group:    name,
+				UserID:   id,
+				Username: desired[id].Username,
+			})
+		}
+
+		for _, m := range g.Members {
+			if _, ok := desired[m.ID]; ok {
+				continue
+			}
+			if _, synced := s.MemberLinks[g.ID][m.ID]; !synced {
+				report.Conflicts = append(report.Conflicts, sdk.LDAPSyncConflict{
+					Type:     sdk.LDAPSyncConflictManualMember,
+					Group:    name,
+					Username: m.Username,
+					Detail:   fmt.Sprintf("user %s was added manually to group %s but is not a member of LDAP group %s", m.Username, name, lg.DN),
+				})
+				continue
+			}
+			if m.Admin {
+				report.Conflicts = append(report.Conflicts, sdk.LDAPSyncConflict{
+					Type:     sdk.LDAPSyncConflictAdminMember,
+					Group:    name,
+					Username: m.Username,
+					Detail:   fmt.Sprintf("user %s is no more a member of LDAP group %s but is an admin of group %s, it is not removed", m.Username, lg.DN, name),
+				})
+				continue
+			}
+			report.Actions = append(report.Actions, sdk.LDAPSyncAction{
+				Type:     sdk.LDAPSyncActionMemberRemove,
+				Group:    name,
+				UserID:   m.ID,
+				Username: m.Username,
+			})
+		}
+	}
+
+	return report
+}
+
+// LoadState returns the current state of the CDS groups and of the users that signed in with LDAP.
+func LoadState(ctx context.Context, db gorp.SqlExecutor) (State, error) {
+	s := State{
+		Groups:         make(map[string]sdk.Group),
+		GroupLinks:     make(map[int64]GroupLink),
+		MemberLinks:    make(map[int64]map[string]struct{}),
+		Users:          make(map[string]sdk.AuthentifiedUser),
+		ReservedGroups: make(map[int64]struct{}),
+	}
+
+	groups, err := group.LoadAll(ctx, db, group.LoadOptions.WithMembers)
+	if err != nil {
+		return s, err
+	}
+	for _, g := range groups {
+		s.Groups[g.Name] = g
+	}
+
+	groupLinks, err := LoadGroupLinks(ctx, db)
+	if err != nil {
+		return s, err
+	}
+	for _, l := range groupLinks {
+		s.GroupLinks[l.GroupID] = l
+	}
+
+	memberLinks, err := LoadMemberLinks(ctx, db)
+	if err != nil {
+		return s, err
+	}
+	for _, l := range memberLinks {
+		if _, ok := s.MemberLinks[l.GroupID]; !ok {
+			s.MemberLinks[l.GroupID] = make(map[string]struct{})
+		}
+		s.MemberLinks[l.GroupID][l.AuthentifiedUserID] = struct{}{}
+	}
+
+	consumers, err := authentication.LoadConsumersByType(ctx, db, sdk.ConsumerLDAP, authentication.LoadConsumerOptions.WithAuthentifiedUser)
+	if err != nil {
+		return s, err
+	}
+	for _, c := range consumers {
+		if uid := c.Data["external_id"]; uid != "" && c.AuthentifiedUser != nil {
+			s.Users[uid] = *c.AuthentifiedUser
+		}
+	}
+
+	// The default group and the groups provisioned by SCIM are never managed by the sync
+	if group.DefaultGroup != nil && group.DefaultGroup.ID != 0 {
+		s.ReservedGroups[group.DefaultGroup.ID] = struct{}{}
+	}
+	scimLinks, err := scim.LoadGroupLinks(ctx, db)
+	if err != nil {
+		return s, err
+	}
+	for _, l := range scimLinks {
+		s.ReservedGroups[l.GroupID] = struct{}{}
+	}
+
+	return s, nil
+}
+
+// Apply applies given actions on the CDS groups.
+func Apply(ctx context.Context, db gorp.SqlExecutor, actions []sdk.LDAPSyncAction) error {
+	groups := make(map[string]*sdk.Group)
+	loadGroup := func(name string) (*sdk.Group, error) {
+		if g, ok := groups[name]; ok {
+			return g, nil
+		}
+		g, err := group.LoadByName(ctx, db, name)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot load group %s", name)
+		}
+		groups[name] = g
+		return g, nil
+	}
+
+	for _, a := range actions {
+		switch a.Type {
+		case sdk.LDAPSyncActionGroupCreate:
+			g := &sdk.Group{Name: a.Group}
+			if err := group.Insert(ctx, db, g); err != nil {
+				return err
+			}
+			groups[a.Group] = g
+			if err := InsertGroupLink(db, &GroupLink{GroupID: g.ID, LDAPGroup: a.LDAPGroup}); err != nil {
+				return err
+			}
+		case sdk.LDAPSyncActionGroupAdopt:
+			g, err := loadGroup(a.Group)
+			if err != nil {
+				return err
+			}
+			if err := InsertGroupLink(db, &GroupLink{GroupID: g.ID, LDAPGroup: a.LDAPGroup}); err != nil {
+				return err
+			}
+		case sdk.LDAPSyncActionMemberAdd:
+			g, err := loadGroup(a.Group)
+			if err != nil {
+				return err
+			}
+			if err := group.InsertLinkGroupUser(ctx, db, &group.LinkGroupUser{
+				GroupID:            g.ID,
+				AuthentifiedUserID: a.UserID,
+			}); err != nil {
+				return err
+			}
+			if err := authentication.ConsumerRestoreInvalidatedGroupForUser(ctx, db, g.ID, a.UserID); err != nil {
+				return err
+			}
+			if err := InsertMemberLink(db, &MemberLink{GroupID: g.ID, AuthentifiedUserID: a.UserID}); err != nil {
+				return err
+			}
+		case sdk.LDAPSyncActionMemberRemove:
+			g, err := loadGroup(a.Group)
+			if err != nil {
+				return err
+			}
+			u, err := user.LoadByID(ctx, db, a.UserID)
+			if err != nil {
+				return sdk.WrapError(err, "cannot load user %s", a.UserID)
+			}
+			link, err := group.LoadLinkGroupUserForGroupIDAndUserID(ctx, db, g.ID, u.ID)
+			if err != nil {
+				return err
+			}
+			if err := group.DeleteLinkGroupUser(db, link); err != nil {
+				return err
+			}
+			if err := authentication.ConsumerInvalidateGroupForUser(ctx, db, g, u); err != nil {
+				return err
+			}
+			if err := DeleteMemberLink(db, g.ID, u.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Run synchronizes the CDS groups with the LDAP groups. With dryRun, the report is computed but the groups are not
+// changed. The report is saved as the last sync report.
+func Run(ctx context.Context, db *gorp.DbMap, store cache.Store, cfg Configuration, search SearchFunc, dryRun bool) (*sdk.LDAPSyncReport, error) {
+	locked, err := store.Lock(lockKey, 10*time.Minute, -1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, sdk.NewErrorFrom(sdk.ErrConflict, "a LDAP group sync is already running")
+	}
+	defer store.Unlock(lockKey) // nolint
+
+	report := sdk.LDAPSyncReport{Start: time.Now()}
+	if err := run(ctx, db, cfg, search, dryRun, &report); err != nil {
+		log.Error(ctx, "ldapsync.Run> %v", err)
+		report.Status = sdk.StatusFail
+		report.Error = err.Error()
+	} else {
+		report.Status = sdk.StatusSuccess
+	}
+	report.DryRun = dryRun
+	report.End = time.Now()
+
+	if err := store.Set(reportKey, report); err != nil {
+		return nil, sdk.WrapError(err, "cannot save ldap sync report")
+	}
+	return &report, nil
+}
+
+func run(ctx context.Context, db *gorp.DbMap, cfg Configuration, search SearchFunc, dryRun bool, report *sdk.LDAPSyncReport) error {
+	ldapGroups, err := search(ctx)
+	if err != nil {
+		return sdk.WrapError(err, "cannot search LDAP groups")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	s, err := LoadState(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	start := report.Start
+	*report = Compute(cfg, ldapGroups, s)
+	report.Start = start
+	if dryRun {
+		return nil
+	}
+
+	if err := Apply(ctx, tx, report.Actions); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
+
+// LoadLastReport returns the report of the last sync, ErrNotFound if no sync was run.
+func LoadLastReport(store cache.Store) (*sdk.LDAPSyncReport, error) {
+	var report sdk.LDAPSyncReport
+	found, err := store.Get(reportKey, &report)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load ldap sync report")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &report, nil
+}
+
+// Initialize starts the periodic sync of the CDS groups with the LDAP groups.
+func Initialize(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.Store, cfg Configuration, search SearchFunc) {
+	interval := time.Duration(cfg.Interval) * time.Second
+	if interval < time.Minute {
+		interval = time.Minute
+	}
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "Exiting ldapsync: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			report, err := Run(ctx, DBFunc(), store, cfg, search, cfg.DryRun)
+			if err != nil {
+				if sdk.ErrorIs(err, sdk.ErrConflict) {
+					log.Debug("ldapsync> %v", err)
+					continue
+				}
+				log.Warning(ctx, "ldapsync> Error on sync: %v", err)
+				continue
+			}
+			log.Info(ctx, "ldapsync> sync %s with %d actions and %d conflicts (dry run: %t)", report.Status, len(report.Actions), len(report.Conflicts), report.DryRun)
+		}
+	}
+}
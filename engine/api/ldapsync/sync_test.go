@@ -0,0 +1,79 @@
+package ldapsync_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/ldapsync"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestCompute(t *testing.T) {
+	s := ldapsync.State{
+		Groups: map[string]sdk.Group{
+			"devs": {ID: 1, Name: "devs", Members: sdk.GroupMembers{
+				{ID: "u1", Username: "alice"},
+				{ID: "u2", Username: "bob"},
+				{ID: "u3", Username: "carol", Admin: true},
+				{ID: "u4", Username: "dave"},
+			}},
+			"ops":    {ID: 2, Name: "ops"},
+			"local":  {ID: 3, Name: "local"},
+			"legacy": {ID: 4, Name: "legacy"},
+		},
+		GroupLinks: map[int64]ldapsync.GroupLink{
+			1: {GroupID: 1, LDAPGroup: "cn=cds-devs,ou=groups"},
+			2: {GroupID: 2, OptOut: true},
+		},
+		MemberLinks: map[int64]map[string]struct{}{
+			1: {"u2": {}, "u3": {}},
+		},
+		Users: map[string]sdk.AuthentifiedUser{
+			"alice": {ID: "u1", Username: "alice"},
+			"bob":   {ID: "u2", Username: "bob"},
+			"eve":   {ID: "u5", Username: "eve"},
+		},
+		ReservedGroups: map[int64]struct{}{},
+	}
+	ldapGroups := []sdk.LDAPGroup{
+		{DN: "cn=cds-devs,ou=groups", Name: "cds-devs", Members: []string{"alice", "eve", "frank"}},
+		{DN: "cn=cds-ops,ou=groups", Name: "cds-ops", Members: []string{"alice"}},
+		{DN: "cn=cds-local,ou=groups", Name: "cds-local", Members: []string{"alice"}},
+		{DN: "cn=cds-new,ou=groups", Name: "cds-new", Members: []string{"bob"}},
+		{DN: "cn=other,ou=groups", Name: "other", Members: []string{"bob"}},
+	}
+
+	report := ldapsync.Compute(ldapsync.Configuration{GroupPrefix: "cds-"}, ldapGroups, s)
+
+	assert.Equal(t, []sdk.LDAPSyncAction{
+		{Type: sdk.LDAPSyncActionMemberAdd, Group: "devs", UserID: "u5", Username: "eve"},
+		{Type: sdk.LDAPSyncActionMemberRemove, Group: "devs", UserID: "u2", Username: "bob"},
+		{Type: sdk.LDAPSyncActionGroupCreate, Group: "new", LDAPGroup: "cn=cds-new,ou=groups"},
+		{Type: sdk.LDAPSyncActionMemberAdd, Group: "new", UserID: "u2", Username: "bob"},
+	}, report.Actions)
+
+	conflicts := make([]string, len(report.Conflicts))
+	for i := range report.Conflicts {
+		conflicts[i] = report.Conflicts[i].Type + ":" + report.Conflicts[i].Group + ":" + report.Conflicts[i].Username
+	}
+	assert.Equal(t, []string{
+		"unknown_user:devs:frank",
+		"admin_member:devs:carol",
+		"manual_member:devs:dave",
+		"local_group:local:",
+	}, conflicts)
+	assert.Equal(t, []string{"ops"}, report.OptedOut)
+
+	// Local groups can be adopted, except the reserved ones
+	report = ldapsync.Compute(ldapsync.Configuration{GroupPrefix: "cds-", AdoptLocalGroups: true}, ldapGroups[2:3], s)
+	assert.Equal(t, []sdk.LDAPSyncAction{
+		{Type: sdk.LDAPSyncActionGroupAdopt, Group: "local", LDAPGroup: "cn=cds-local,ou=groups"},
+		{Type: sdk.LDAPSyncActionMemberAdd, Group: "local", UserID: "u1", Username: "alice"},
+	}, report.Actions)
+
+	s.ReservedGroups[3] = struct{}{}
+	report = ldapsync.Compute(ldapsync.Configuration{GroupPrefix: "cds-", AdoptLocalGroups: true}, ldapGroups[2:3], s)
+	assert.Empty(t, report.Actions)
+	assert.Len(t, report.Conflicts, 1)
+}
@@ -0,0 +1,47 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func (l *linter) application(file string, app exportentities.Application) {
+	if app.VCSServer != "" && !contains(l.project.RepositoriesManagers, app.VCSServer) {
+		l.add(file, sdk.LintSeverityError, "vcs_server", fmt.Sprintf("repository manager %s is not linked to the project", app.VCSServer),
+			suggest(app.VCSServer, l.project.RepositoriesManagers, fmt.Sprintf("Link the repository manager %s to the project", app.VCSServer)))
+	}
+
+	for _, name := range sortedKeys(app.Variables) {
+		l.variables(file, "variables."+name, app.Variables[name].Value, nil)
+	}
+
+	integrations := make([]string, 0, len(app.DeploymentStrategies))
+	for name := range app.DeploymentStrategies {
+		integrations = append(integrations, name)
+	}
+	sort.Strings(integrations)
+	for _, name := range integrations {
+		l.reference(file, "deployments."+name, "integration", name, l.project.Integrations)
+		for _, k := range sortedKeys(app.DeploymentStrategies[name]) {
+			l.variables(file, "deployments."+name+"."+k, app.DeploymentStrategies[name][k].Value, nil)
+		}
+	}
+}
+
+func (l *linter) environment(file string, env exportentities.Environment) {
+	for _, name := range sortedKeys(env.Values) {
+		l.variables(file, "values."+name, env.Values[name].Value, nil)
+	}
+}
+
+func sortedKeys(m map[string]exportentities.VariableValue) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,251 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+	v1 "github.com/ovh/cds/sdk/exportentities/v1"
+	v2 "github.com/ovh/cds/sdk/exportentities/v2"
+)
+
+// Project is the content of a project used to lint files: the names of its variables, pipelines, applications,
+// environments, integrations and repositories managers, the worker models and actions usable by its groups.
+type Project struct {
+	Variables            []string
+	ApplicationVariables []string
+	EnvironmentVariables []string
+	Pipelines            []string
+	Applications         []string
+	Environments         []string
+	Integrations         []string
+	RepositoriesManagers []string
+	// WorkerModels and Actions are indexed by path, the value is true if the worker model or the action is deprecated
+	WorkerModels map[string]bool
+	Actions      map[string]bool
+}
+
+// Files lints given workflow, pipeline, application and environment files. The type of a file is given by its name,
+// like for a push of a workflow. Pipelines, applications and environments defined in given files can be used by the
+// given workflow even if they don't exist in the project yet.
+func Files(p Project, files map[string][]byte) []sdk.LintDiagnostic {
+	l := linter{project: p, diagnostics: []sdk.LintDiagnostic{}}
+
+	type parsed struct {
+		name  string
+		value interface{}
+	}
+	var all []parsed
+	for name, content := range files {
+		format, err := exportentities.GetFormatFromPath(name)
+		if err != nil {
+			l.add(name, sdk.LintSeverityError, "", "format of the file is not supported", "Use a file with extension .yml, .yaml or .json")
+			continue
+		}
+
+		var value interface{}
+		switch {
+		case strings.Contains(name, ".pip."):
+			var pip exportentities.PipelineV1
+			value = &pip
+		case strings.Contains(name, ".app."):
+			var app exportentities.Application
+			value = &app
+		case strings.Contains(name, ".env."):
+			var env exportentities.Environment
+			value = &env
+		default:
+			var version exportentities.WorkflowVersion
+			if err := exportentities.Unmarshal(content, format, &version); err != nil {
+				l.add(name, sdk.LintSeverityError, "", "invalid workflow file", "Check the syntax of the file")
+				continue
+			}
+			switch version.Version {
+			case exportentities.WorkflowVersion2:
+				value = &v2.Workflow{}
+			case exportentities.WorkflowVersion1:
+				value = &v1.Workflow{}
+			default:
+				l.add(name, sdk.LintSeverityError, "version", fmt.Sprintf("unknown workflow version %q", version.Version),
+					"Set version: "+exportentities.WorkflowVersion2)
+				continue
+			}
+		}
+
+		if err := exportentities.UnmarshalStrict(content, format, value); err != nil {
+			l.addUnmarshalError(name, err)
+			if err := exportentities.Unmarshal(content, format, value); err != nil {
+				continue
+			}
+		}
+		all = append(all, parsed{name: name, value: value})
+	}
+
+	// Entities defined in given files are known by the workflow
+	for _, f := range all {
+		switch v := f.value.(type) {
+		case *exportentities.PipelineV1:
+			l.project.Pipelines = append(l.project.Pipelines, v.Name)
+		case *exportentities.Application:
+			l.project.Applications = append(l.project.Applications, v.Name)
+			for name := range v.Variables {
+				l.project.ApplicationVariables = append(l.project.ApplicationVariables, name)
+			}
+		case *exportentities.Environment:
+			l.project.Environments = append(l.project.Environments, v.Name)
+			for name := range v.Values {
+				l.project.EnvironmentVariables = append(l.project.EnvironmentVariables, name)
+			}
+		}
+	}
+
+	for _, f := range all {
+		switch v := f.value.(type) {
+		case *exportentities.PipelineV1:
+			l.pipeline(f.name, *v)
+		case *exportentities.Application:
+			l.application(f.name, *v)
+		case *exportentities.Environment:
+			l.environment(f.name, *v)
+		case *v2.Workflow:
+			l.workflow(f.name, *v)
+		case *v1.Workflow:
+			l.add(f.name, sdk.LintSeverityInfo, "version", fmt.Sprintf("workflow version %s is deprecated", exportentities.WorkflowVersion1),
+				"Export the workflow again to get the version "+exportentities.WorkflowVersion2)
+		}
+	}
+
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		if l.diagnostics[i].File != l.diagnostics[j].File {
+			return l.diagnostics[i].File < l.diagnostics[j].File
+		}
+		return l.diagnostics[i].Path < l.diagnostics[j].Path
+	})
+	return l.diagnostics
+}
+
+type linter struct {
+	project     Project
+	diagnostics []sdk.LintDiagnostic
+}
+
+func (l *linter) add(file, severity, path, message, fix string) {
+	l.diagnostics = append(l.diagnostics, sdk.LintDiagnostic{
+		File:     file,
+		Severity: severity,
+		Path:     path,
+		Message:  message,
+		Fix:      fix,
+	})
+}
+
+// addUnmarshalError adds a diagnostic for each unknown or invalid field reported by a strict unmarshal.
+func (l *linter) addUnmarshalError(file string, err error) {
+	for _, line := range strings.Split(sdk.Cause(err).Error(), "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(line, "yaml: unmarshal errors:"))
+		if line == "" {
+			continue
+		}
+		var path string
+		if i := strings.Index(line, ": "); strings.HasPrefix(line, "line ") && i > 0 {
+			path, line = line[:i], line[i+2:]
+		}
+		l.add(file, sdk.LintSeverityError, path, line, "Remove the field or check its name in the documentation")
+	}
+}
+
+var variableRegexp = regexp.MustCompile(`{{\s*\.(cds\.(proj|app|env|pip)\.[a-zA-Z0-9_.\-]+)`)
+
+// variables checks that the project, application, environment and pipeline variables used in given value are defined.
+func (l *linter) variables(file, path, value string, pipelineParameters []string) {
+	for _, m := range variableRegexp.FindAllStringSubmatch(value, -1) {
+		variable, scope := m[1], m[2]
+		name := strings.TrimPrefix(variable, "cds."+scope+".")
+		switch scope {
+		case "proj":
+			if !contains(l.project.Variables, name) {
+				l.add(file, sdk.LintSeverityError, path, fmt.Sprintf("project variable %s is not defined", name),
+					suggest(name, l.project.Variables, fmt.Sprintf("Add variable %s to the project", name)))
+			}
+		case "pip":
+			if pipelineParameters != nil && !contains(pipelineParameters, name) {
+				l.add(file, sdk.LintSeverityError, path, fmt.Sprintf("pipeline parameter %s is not defined", name),
+					suggest(name, pipelineParameters, fmt.Sprintf("Add parameter %s to the pipeline", name)))
+			}
+		case "app":
+			if !contains(l.project.ApplicationVariables, name) {
+				l.add(file, sdk.LintSeverityWarning, path, fmt.Sprintf("variable %s is not defined in any application of the project", name),
+					suggest(name, l.project.ApplicationVariables, fmt.Sprintf("Add variable %s to the application of the workflow node", name)))
+			}
+		case "env":
+			if !contains(l.project.EnvironmentVariables, name) {
+				l.add(file, sdk.LintSeverityWarning, path, fmt.Sprintf("variable %s is not defined in any environment of the project", name),
+					suggest(name, l.project.EnvironmentVariables, fmt.Sprintf("Add variable %s to the environment of the workflow node", name)))
+			}
+		}
+	}
+}
+
+// reference checks that an entity used by a file exists in the project.
+func (l *linter) reference(file, path, kind, name string, names []string) {
+	if name == "" || contains(names, name) {
+		return
+	}
+	l.add(file, sdk.LintSeverityError, path, fmt.Sprintf("%s %s not found in project", kind, name),
+		suggest(name, names, fmt.Sprintf("Create the %s %s or push its file with the workflow", kind, name)))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// suggest returns a fix with the closest name from given ones if any, else the default fix.
+func suggest(name string, names []string, defaultFix string) string {
+	best, bestDistance := "", len(name)/3+1
+	for _, n := range names {
+		if d := distance(name, n); d < bestDistance {
+			best, bestDistance = n, d
+		}
+	}
+	if best == "" {
+		return defaultFix
+	}
+	return fmt.Sprintf("Did you mean %s?", best)
+}
+
+// distance returns the Levenshtein distance between two strings.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(min(cur[j-1]+1, prev[j]+1), prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,113 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/lint"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestFiles(t *testing.T) {
+	p := lint.Project{
+		Variables:            []string{"registry"},
+		Pipelines:            []string{"deploy"},
+		Applications:         []string{"api"},
+		Environments:         []string{"prod"},
+		Integrations:         []string{"my-k8s"},
+		RepositoriesManagers: []string{"github"},
+		WorkerModels:         map[string]bool{"go-official": false, "my-group/old-model": true},
+		Actions:              map[string]bool{"CDS_GitClone": false, "my-group/notify": true},
+	}
+
+	files := map[string][]byte{
+		"build.pip.yml": []byte(`version: v1.0
+name: build
+parameters:
+  tag:
+    type: string
+jobs:
+- job: compile
+  requirements:
+  - model: go-oficial
+  - memory: lot
+  steps:
+  - script: docker push {{.cds.proj.registy}}/app:{{.cds.pip.tag}}
+  - my-group/notify:
+      channel: builds
+`),
+		"api.app.yml": []byte(`version: v1.0
+name: api
+vcs_server: gitlab
+deployments:
+  my-k8s:
+    namespace:
+      type: string
+      value: '{{.cds.env.namespace}}'
+`),
+		"my-workflow.yml": []byte(`version: v2.0
+name: my-workflow
+workflow:
+  build:
+    pipeline: build
+    application: api
+  deploy:
+    depends_on:
+    - buil
+    pipeline: deploy
+    environment: prd
+  loop-a:
+    depends_on:
+    - loop-b
+  loop-b:
+    depends_on:
+    - loop-a
+`),
+	}
+
+	res := lint.Files(p, files)
+
+	type diagnostic struct{ file, severity, path, fix string }
+	var got []diagnostic
+	for _, d := range res {
+		got = append(got, diagnostic{d.File, d.Severity, d.Path, d.Fix})
+	}
+	assert.Equal(t, []diagnostic{
+		{"api.app.yml", sdk.LintSeverityWarning, "deployments.my-k8s.namespace", "Add variable namespace to the environment of the workflow node"},
+		{"api.app.yml", sdk.LintSeverityError, "vcs_server", "Did you mean github?"},
+		{"build.pip.yml", sdk.LintSeverityError, "jobs[0].requirements[0].model", "Did you mean go-official?"},
+		{"build.pip.yml", sdk.LintSeverityError, "jobs[0].requirements[1].memory", "Set the memory as a number of megabytes, ex: 4096"},
+		{"build.pip.yml", sdk.LintSeverityError, "jobs[0].steps[0]", "Did you mean registry?"},
+		{"build.pip.yml", sdk.LintSeverityWarning, "jobs[0].steps[1]", "Replace the step with a maintained action"},
+		{"my-workflow.yml", sdk.LintSeverityError, "workflow.deploy.depends_on", "Did you mean build?"},
+		{"my-workflow.yml", sdk.LintSeverityError, "workflow.deploy.environment", "Did you mean prod?"},
+		{"my-workflow.yml", sdk.LintSeverityError, "workflow.loop-a", "Fix the depends_on of the node, it is part of a dependency cycle"},
+		{"my-workflow.yml", sdk.LintSeverityError, "workflow.loop-b", "Fix the depends_on of the node, it is part of a dependency cycle"},
+	}, got)
+}
+
+func TestFilesInvalid(t *testing.T) {
+	res := lint.Files(lint.Project{}, map[string][]byte{
+		"build.pip.yml": []byte(`version: v1.0
+name: build
+jobs:
+- job: compile
+  requirement:
+  - binary: git
+`),
+		"my-workflow.yml": []byte(`version: v3.0
+name: my-workflow
+`),
+		"my-workflow.txt": []byte(`name: my-workflow`),
+	})
+	require.Len(t, res, 3)
+	assert.Equal(t, "build.pip.yml", res[0].File)
+	assert.Equal(t, "line 5", res[0].Path)
+	assert.Contains(t, res[0].Message, "field requirement not found")
+	assert.Equal(t, "my-workflow.txt", res[1].File)
+	assert.Equal(t, "my-workflow.yml", res[2].File)
+	assert.Equal(t, "version", res[2].Path)
+	assert.Equal(t, "Set version: v2.0", res[2].Fix)
+}
@@ -0,0 +1,111 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func (l *linter) pipeline(file string, pip exportentities.PipelineV1) {
+	parameters := make([]string, 0, len(pip.Parameters))
+	for name := range pip.Parameters {
+		parameters = append(parameters, name)
+	}
+	sort.Strings(parameters)
+	for _, name := range parameters {
+		l.variables(file, "parameters."+name, pip.Parameters[name].DefaultValue, parameters)
+	}
+
+	for i, j := range pip.Jobs {
+		jobPath := fmt.Sprintf("jobs[%d]", i)
+		if j.Stage != "" && len(pip.Stages) > 0 && !contains(pip.Stages, j.Stage) {
+			l.add(file, sdk.LintSeverityError, jobPath+".stage", fmt.Sprintf("stage %s is not declared", j.Stage),
+				suggest(j.Stage, pip.Stages, fmt.Sprintf("Add %s to the stages of the pipeline", j.Stage)))
+		}
+
+		for k, s := range j.Steps {
+			stepPath := fmt.Sprintf("%s.steps[%d]", jobPath, k)
+			if btes, err := json.Marshal(s); err == nil {
+				l.variables(file, stepPath, string(btes), parameters)
+			}
+			for ref := range s.StepCustom {
+				l.action(file, stepPath, ref)
+			}
+		}
+
+		for k, r := range j.Requirements {
+			l.requirement(file, fmt.Sprintf("%s.requirements[%d]", jobPath, k), r)
+		}
+	}
+}
+
+// action checks that a custom step uses an existing action that is not deprecated.
+func (l *linter) action(file, path, ref string) {
+	groupName, name, _ := sdk.ParseActionReference(ref)
+	actionPath := name
+	if groupName != "" && groupName != sdk.SharedInfraGroupName {
+		actionPath = groupName + "/" + name
+	}
+
+	deprecated, ok := l.project.Actions[actionPath]
+	if !ok {
+		names := make([]string, 0, len(l.project.Actions))
+		for n := range l.project.Actions {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		l.add(file, sdk.LintSeverityError, path, fmt.Sprintf("action %s not found", actionPath),
+			suggest(actionPath, names, "Check the name of the action and that the project groups can use it"))
+		return
+	}
+	if deprecated {
+		l.add(file, sdk.LintSeverityWarning, path, fmt.Sprintf("action %s is deprecated", actionPath),
+			"Replace the step with a maintained action")
+	}
+}
+
+func (l *linter) requirement(file, path string, r exportentities.Requirement) {
+	switch {
+	case r.Model != "":
+		// The value of a model requirement can contain options after the name of the model
+		model := strings.Fields(r.Model)[0]
+		deprecated, ok := l.project.WorkerModels[model]
+		if !ok {
+			names := make([]string, 0, len(l.project.WorkerModels))
+			for n := range l.project.WorkerModels {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			l.add(file, sdk.LintSeverityError, path+".model", fmt.Sprintf("worker model %s not found", model),
+				suggest(model, names, "Check the name of the worker model and that the project groups can use it"))
+		} else if deprecated {
+			l.add(file, sdk.LintSeverityWarning, path+".model", fmt.Sprintf("worker model %s is deprecated", model),
+				"Use a maintained worker model")
+		}
+	case r.Memory != "":
+		if _, err := strconv.ParseInt(r.Memory, 10, 64); err != nil {
+			l.add(file, sdk.LintSeverityError, path+".memory", fmt.Sprintf("invalid memory requirement %s", r.Memory),
+				"Set the memory as a number of megabytes, ex: 4096")
+		}
+	case r.OSArchRequirement != "":
+		if !sdk.IsValidOSArch(r.OSArchRequirement) {
+			l.add(file, sdk.LintSeverityError, path+".os-architecture", fmt.Sprintf("unknown os-architecture requirement %s", r.OSArchRequirement),
+				"Use a value like linux/amd64")
+		}
+	case r.Isolation != nil:
+		for _, p := range r.Isolation.Paths {
+			if !filepath.IsAbs(p) {
+				l.add(file, sdk.LintSeverityError, path+".isolation.paths", fmt.Sprintf("invalid isolation path %s", p),
+					"Set the absolute path of the tool, ex: /usr/local/go")
+			}
+		}
+	case r.Service.Value != "" && r.Service.Name == "":
+		l.add(file, sdk.LintSeverityError, path+".service", "service requirement has no name", "Set the name of the service")
+	}
+}
@@ -0,0 +1,92 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	v2 "github.com/ovh/cds/sdk/exportentities/v2"
+)
+
+func (l *linter) workflow(file string, w v2.Workflow) {
+	if len(w.Workflow) == 0 {
+		l.add(file, sdk.LintSeverityError, "workflow", "workflow has no node", "Add at least one pipeline node in the workflow")
+		return
+	}
+
+	nodes := make([]string, 0, len(w.Workflow))
+	for name := range w.Workflow {
+		nodes = append(nodes, name)
+	}
+	sort.Strings(nodes)
+
+	var roots []string
+	children := make(map[string][]string, len(nodes))
+	brokenDependencies := make(map[string]bool)
+	for _, name := range nodes {
+		n := w.Workflow[name]
+		path := "workflow." + name
+		l.reference(file, path+".pipeline", "pipeline", n.PipelineName, l.project.Pipelines)
+		l.reference(file, path+".application", "application", n.ApplicationName, l.project.Applications)
+		l.reference(file, path+".environment", "environment", n.EnvironmentName, l.project.Environments)
+		l.reference(file, path+".integration", "integration", n.ProjectIntegrationName, l.project.Integrations)
+
+		for k, v := range n.Parameters {
+			l.variables(file, path+".parameters."+k, v, nil)
+		}
+
+		if len(n.DependsOn) == 0 {
+			roots = append(roots, name)
+		}
+		for _, parent := range n.DependsOn {
+			if _, ok := w.Workflow[parent]; !ok {
+				l.add(file, sdk.LintSeverityError, path+".depends_on", fmt.Sprintf("node %s depends on unknown node %s", name, parent),
+					suggest(parent, nodes, fmt.Sprintf("Remove %s from the dependencies of the node", parent)))
+				brokenDependencies[name] = true
+				continue
+			}
+			children[parent] = append(children[parent], name)
+		}
+	}
+
+	switch {
+	case len(roots) == 0:
+		l.add(file, sdk.LintSeverityError, "workflow", "workflow has no root node", "Remove the depends_on of the first node of the workflow")
+	case len(roots) > 1:
+		l.add(file, sdk.LintSeverityError, "workflow", fmt.Sprintf("workflow has several root nodes: %s", strings.Join(roots, ", ")),
+			fmt.Sprintf("Add a depends_on to all root nodes except %s", roots[0]))
+	}
+
+	// Nodes that can't be reached from a root node, and that don't depend on an unknown node, are part of a
+	// dependency cycle
+	reached := make(map[string]bool, len(nodes))
+	queue := append([]string{}, roots...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if reached[name] {
+			continue
+		}
+		reached[name] = true
+		queue = append(queue, children[name]...)
+	}
+	for _, name := range nodes {
+		if !reached[name] && !brokenDependencies[name] {
+			l.add(file, sdk.LintSeverityError, "workflow."+name, fmt.Sprintf("node %s is unreachable", name),
+				"Fix the depends_on of the node, it is part of a dependency cycle")
+		}
+	}
+
+	hookNodes := make([]string, 0, len(w.Hooks))
+	for name := range w.Hooks {
+		hookNodes = append(hookNodes, name)
+	}
+	sort.Strings(hookNodes)
+	for _, name := range hookNodes {
+		if _, ok := w.Workflow[name]; !ok {
+			l.add(file, sdk.LintSeverityError, "hooks."+name, fmt.Sprintf("hook is set on unknown node %s", name),
+				suggest(name, nodes, fmt.Sprintf("Remove the hooks of %s", name)))
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadWindows loads the maintenance windows that are not ended ordered by start date.
+func LoadWindows(db gorp.SqlExecutor) (sdk.MaintenanceWindows, error) {
+	var dbWindows []window
+	if _, err := db.Select(&dbWindows, "SELECT * FROM maintenance_window WHERE end_date > $1 ORDER BY start_date, id", time.Now()); err != nil {
+		return nil, sdk.WrapError(err, "unable to load maintenance windows")
+	}
+	windows := make(sdk.MaintenanceWindows, len(dbWindows))
+	for i := range dbWindows {
+		windows[i] = sdk.MaintenanceWindow(dbWindows[i])
+	}
+	return windows, nil
+}
+
+// InsertWindow inserts given maintenance window.
+func InsertWindow(db gorp.SqlExecutor, w *sdk.MaintenanceWindow) error {
+	w.Created = time.Now()
+	dbWindow := window(*w)
+	if err := db.Insert(&dbWindow); err != nil {
+		return sdk.WrapError(err, "unable to insert maintenance window")
+	}
+	w.ID = dbWindow.ID
+	return nil
+}
+
+// DeleteWindow deletes the maintenance window with given id.
+func DeleteWindow(db gorp.SqlExecutor, id int64) error {
+	res, err := db.Exec("DELETE FROM maintenance_window WHERE id = $1", id)
+	if err != nil {
+		return sdk.WrapError(err, "unable to delete maintenance window %d", id)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if n == 0 {
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "maintenance window %d not found", id)
+	}
+	return nil
+}
+
+// LoadOverride loads the override of given workflow run, it returns nil if the run is not overridden.
+func LoadOverride(db gorp.SqlExecutor, workflowRunID int64) (*sdk.MaintenanceWindowOverride, error) {
+	var o override
+	if err := db.SelectOne(&o, "SELECT * FROM maintenance_window_override WHERE workflow_run_id = $1", workflowRunID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WrapError(err, "unable to load maintenance window override of workflow run %d", workflowRunID)
+	}
+	res := sdk.MaintenanceWindowOverride(o)
+	return &res, nil
+}
+
+// UpsertOverride inserts given override, or updates the override of the same workflow run.
+func UpsertOverride(db gorp.SqlExecutor, o *sdk.MaintenanceWindowOverride) error {
+	o.Created = time.Now()
+	dbOverride := override(*o)
+	n, err := db.Update(&dbOverride)
+	if err != nil {
+		return sdk.WrapError(err, "unable to update maintenance window override of workflow run %d", o.WorkflowRunID)
+	}
+	if n == 0 {
+		if err := db.Insert(&dbOverride); err != nil {
+			return sdk.WrapError(err, "unable to insert maintenance window override of workflow run %d", o.WorkflowRunID)
+		}
+	}
+	return nil
+}
+
+// LoadOverriddenNodeRunIDs returns among given workflow node runs the ones whose workflow run is overridden.
+func LoadOverriddenNodeRunIDs(ctx context.Context, db gorp.SqlExecutor, nodeRunIDs []int64) (map[int64]struct{}, error) {
+	_, end := observability.Span(ctx, "maintenance.LoadOverriddenNodeRunIDs")
+	defer end()
+
+	res := make(map[int64]struct{})
+	if len(nodeRunIDs) == 0 {
+		return res, nil
+	}
+
+	var ids []int64
+	query := `
+	SELECT workflow_node_run.id
+	FROM workflow_node_run
+	JOIN maintenance_window_override ON maintenance_window_override.workflow_run_id = workflow_node_run.workflow_run_id
+	WHERE workflow_node_run.id = ANY(string_to_array($1, ',')::int[])`
+	if _, err := db.Select(&ids, query, gorpmapping.IDsToQueryString(nodeRunIDs)); err != nil {
+		return nil, sdk.WrapError(err, "unable to load overridden workflow node runs")
+	}
+	for _, id := range ids {
+		res[id] = struct{}{}
+	}
+	return res, nil
+}
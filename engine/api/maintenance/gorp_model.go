@@ -0,0 +1,17 @@
+package maintenance
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// window is a gorp wrapper around sdk.MaintenanceWindow
+type window sdk.MaintenanceWindow
+
+// override is a gorp wrapper around sdk.MaintenanceWindowOverride
+type override sdk.MaintenanceWindowOverride
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(window{}, "maintenance_window", true, "id"))
+	gorpmapping.Register(gorpmapping.New(override{}, "maintenance_window_override", false, "workflow_run_id"))
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/maintenance"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getMaintenanceWindowsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		windows, err := maintenance.LoadWindows(api.mustDB())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, windows, http.StatusOK)
+	}
+}
+
+func (api *API) postMaintenanceWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var window sdk.MaintenanceWindow
+		if err := service.UnmarshalBody(r, &window); err != nil {
+			return err
+		}
+		if err := window.IsValid(); err != nil {
+			return err
+		}
+
+		window.Author = getAPIConsumer(ctx).GetUsername()
+		if err := maintenance.InsertWindow(api.mustDB(), &window); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, window, http.StatusCreated)
+	}
+}
+
+func (api *API) deleteMaintenanceWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := maintenance.DeleteWindow(api.mustDB(), id); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// postWorkflowRunMaintenanceOverrideHandler allows the jobs of a workflow run to be executed during the maintenance
+// windows, for emergencies.
+func (api *API) postWorkflowRunMaintenanceOverrideHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		var o sdk.MaintenanceWindowOverride
+		if err := service.UnmarshalBody(r, &o); err != nil {
+			return err
+		}
+		if o.Reason == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid empty reason for maintenance window override")
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		o.WorkflowRunID = wr.ID
+		o.Author = getAPIConsumer(ctx).GetUsername()
+		if err := maintenance.UpsertOverride(api.mustDB(), &o); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, o, http.StatusOK)
+	}
+}
+
+// filterMaintenanceWindowJobs splits given jobs between the ones that can be executed and the ones held by an
+// active maintenance window. Jobs of overridden workflow runs are never held.
+func (api *API) filterMaintenanceWindowJobs(ctx context.Context, jobs []sdk.WorkflowNodeJobRun) ([]sdk.WorkflowNodeJobRun, []sdk.WorkflowNodeJobRun, error) {
+	if len(jobs) == 0 {
+		return jobs, nil, nil
+	}
+
+	windows, err := maintenance.LoadWindows(api.mustDB())
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+
+	var candidates []int64
+	for i := range jobs {
+		if jobMaintenanceWindow(windows, now, &jobs[i]) != nil {
+			candidates = append(candidates, jobs[i].WorkflowNodeRunID)
+		}
+	}
+	if len(candidates) == 0 {
+		return jobs, nil, nil
+	}
+
+	overridden, err := maintenance.LoadOverriddenNodeRunIDs(ctx, api.mustDB(), candidates)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	visible := make([]sdk.WorkflowNodeJobRun, 0, len(jobs))
+	var held []sdk.WorkflowNodeJobRun
+	for i := range jobs {
+		if _, ok := overridden[jobs[i].WorkflowNodeRunID]; ok {
+			visible = append(visible, jobs[i])
+			continue
+		}
+		if jobMaintenanceWindow(windows, now, &jobs[i]) != nil {
+			held = append(held, jobs[i])
+			continue
+		}
+		visible = append(visible, jobs[i])
+	}
+	return visible, held, nil
+}
+
+// jobMaintenanceWindow returns the active maintenance window for the workflow of given job, if any.
+func jobMaintenanceWindow(windows sdk.MaintenanceWindows, now time.Time, job *sdk.WorkflowNodeJobRun) *sdk.MaintenanceWindow {
+	projectKey, _ := job.Header.Get(sdk.ProjectKeyHeader)
+	workflowName, _ := job.Header.Get(sdk.WorkflowHeader)
+	return windows.Active(now, projectKey, workflowName)
+}
+
+// checkJobMaintenanceWindow returns an error if given job is held by an active maintenance window.
+func (api *API) checkJobMaintenanceWindow(ctx context.Context, job sdk.WorkflowNodeJobRun) error {
+	windows, err := maintenance.LoadWindows(api.mustDB())
+	if err != nil {
+		return err
+	}
+	window := jobMaintenanceWindow(windows, time.Now(), &job)
+	if window == nil {
+		return nil
+	}
+
+	overridden, err := maintenance.LoadOverriddenNodeRunIDs(ctx, api.mustDB(), []int64{job.WorkflowNodeRunID})
+	if err != nil {
+		return err
+	}
+	if _, ok := overridden[job.WorkflowNodeRunID]; ok {
+		return nil
+	}
+
+	return sdk.NewErrorFrom(sdk.ErrJobHeldByMaintenanceWindow, "job %d is held until %s: %s", job.ID, window.End.Format(time.RFC3339), window.Reason)
+}
+
+// loadWorkflowRunMaintenanceWindow returns the active maintenance window that holds the jobs of given workflow run,
+// or nil if the run is not held.
+func (api *API) loadWorkflowRunMaintenanceWindow(run sdk.WorkflowRun) (*sdk.MaintenanceWindow, error) {
+	if sdk.StatusIsTerminated(run.Status) {
+		return nil, nil
+	}
+
+	windows, err := maintenance.LoadWindows(api.mustDB())
+	if err != nil {
+		return nil, err
+	}
+	window := windows.Active(time.Now(), run.Workflow.ProjectKey, run.Workflow.Name)
+	if window == nil {
+		return nil, nil
+	}
+
+	o, err := maintenance.LoadOverride(api.mustDB(), run.ID)
+	if err != nil {
+		return nil, err
+	}
+	if o != nil {
+		return nil, nil
+	}
+	return window, nil
+}
@@ -210,7 +210,7 @@ func refactorProjectIntegrationCrypto(ctx context.Context, db *gorp.DbMap, id in
 	}
 	oldCfg := projectIntegration.Config.Clone()
 
-	if err := integration.UpdateIntegration(tx, projectIntegration); err != nil {
+	if err := integration.UpdateIntegration(tx, nil, projectIntegration); err != nil {
 		return sdk.WithStack(err)
 	}
 
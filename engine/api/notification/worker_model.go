@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+)
+
+// WorkerModelQuarantine notifies the administrators of the worker model's group that the model was quarantined.
+func WorkerModelQuarantine(ctx context.Context, db gorp.SqlExecutor, m sdk.Model) error {
+	g, err := group.LoadByID(ctx, db, m.GroupID, group.LoadOptions.WithMembers)
+	if err != nil {
+		return err
+	}
+
+	var userIDs []string
+	for _, member := range g.Members {
+		if member.Admin {
+			userIDs = append(userIDs, member.ID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	users, err := user.LoadAllByIDs(ctx, db, userIDs, user.LoadOptions.WithContacts)
+	if err != nil {
+		return err
+	}
+
+	notif := sdk.EventNotif{
+		Subject: fmt.Sprintf("[CDS] Worker model %s/%s has been quarantined", g.Name, m.Name),
+		Body: fmt.Sprintf("Worker model %s/%s failed to spawn %d times in a row and has been quarantined, hatcheries will not use it until it is updated or released.\n\nReason: %s\n\nDetails: %s/settings/worker-model/%s/%s",
+			g.Name, m.Name, m.NbSpawnErr, m.QuarantineReason, uiURL, g.Name, m.Name),
+	}
+	for _, u := range users {
+		if email := u.GetEmail(); email != "" {
+			notif.Recipients = append(notif.Recipients, email)
+		}
+	}
+
+	go sendMailNotif(ctx, notif)
+	return nil
+}
@@ -46,6 +46,9 @@ func newS3Store(ctx context.Context, integration sdk.ProjectIntegration, conf Co
 		aConf.DisableSSL = aws.Bool(conf.DisableSSL)
 		aConf.S3ForcePathStyle = aws.Bool(conf.ForcePathStyle)
 	}
+	if conf.HTTPClient != nil {
+		aConf.HTTPClient = conf.HTTPClient
+	}
 
 	sess, err := session.NewSession(aConf)
 	if err != nil {
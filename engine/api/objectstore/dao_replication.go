@@ -0,0 +1,111 @@
+package objectstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// upsertReplication marks an object as to be replicated, a previous replication state is reset.
+func upsertReplication(db gorp.SqlExecutor, o Object) error {
+	_, err := db.Exec(`
+		INSERT INTO artifact_replication (object_path, object_name, status, created)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (object_path, object_name) DO UPDATE SET status = $3, checksum = '', error = '', attempts = 0, last_attempt = NULL
+	`, o.GetPath(), o.GetName(), sdk.ArtifactReplicationStatusPending, time.Now())
+	return sdk.WrapError(err, "cannot insert replication for object %s/%s", o.GetPath(), o.GetName())
+}
+
+// loadNextReplicationForUpdate returns the next object to replicate and locks it. Objects in error are retried
+// after given delay until the max number of attempts is reached.
+func loadNextReplicationForUpdate(ctx context.Context, db gorp.SqlExecutor, maxAttempts int, retryDelay time.Duration) (*sdk.ArtifactReplication, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM artifact_replication
+		WHERE status = $1 OR (status = $2 AND attempts < $3 AND last_attempt < $4)
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`).Args(sdk.ArtifactReplicationStatusPending, sdk.ArtifactReplicationStatusError, maxAttempts, time.Now().Add(-retryDelay))
+	var r artifactReplication
+	found, err := gorpmapping.Get(ctx, db, query, &r)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load replication")
+	}
+	if !found {
+		return nil, nil
+	}
+	res := sdk.ArtifactReplication(r)
+	return &res, nil
+}
+
+func updateReplication(db gorp.SqlExecutor, r *sdk.ArtifactReplication) error {
+	dbr := artifactReplication(*r)
+	return sdk.WrapError(gorpmapping.Update(db, &dbr), "cannot update replication %d", r.ID)
+}
+
+func deleteReplication(db gorp.SqlExecutor, o Object) error {
+	_, err := db.Exec("DELETE FROM artifact_replication WHERE object_path = $1 AND object_name = $2", o.GetPath(), o.GetName())
+	return sdk.WrapError(err, "cannot delete replication for object %s/%s", o.GetPath(), o.GetName())
+}
+
+func deleteReplicationsForContainer(db gorp.SqlExecutor, containerPath string) error {
+	_, err := db.Exec("DELETE FROM artifact_replication WHERE object_path = $1 OR object_path LIKE $2", containerPath, containerPath+"/%")
+	return sdk.WrapError(err, "cannot delete replications for container %s", containerPath)
+}
+
+// LoadReplicationStatus returns the number of objects by replication status and the last replication errors.
+func LoadReplicationStatus(ctx context.Context, db gorp.SqlExecutor) (sdk.ArtifactReplicationStatus, error) {
+	var res sdk.ArtifactReplicationStatus
+	rows, err := db.Query("SELECT status, COUNT(id) FROM artifact_replication GROUP BY status")
+	if err != nil {
+		return res, sdk.WrapError(err, "cannot count replications")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return res, sdk.WithStack(err)
+		}
+		switch status {
+		case sdk.ArtifactReplicationStatusPending:
+			res.Pending = count
+		case sdk.ArtifactReplicationStatusSynced:
+			res.Synced = count
+		case sdk.ArtifactReplicationStatusError:
+			res.Error = count
+		}
+	}
+
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM artifact_replication
+		WHERE status = $1
+		ORDER BY last_attempt DESC
+		LIMIT 50
+	`).Args(sdk.ArtifactReplicationStatusError)
+	var errs []artifactReplication
+	if err := gorpmapping.GetAll(ctx, db, query, &errs); err != nil {
+		return res, sdk.WrapError(err, "cannot load replication errors")
+	}
+	res.Errors = make([]sdk.ArtifactReplication, len(errs))
+	for i := range errs {
+		res.Errors[i] = sdk.ArtifactReplication(errs[i])
+	}
+	return res, nil
+}
+
+// RetryReplicationErrors resets all objects in error to be replicated again.
+func RetryReplicationErrors(db gorp.SqlExecutor) (int64, error) {
+	res, err := db.Exec("UPDATE artifact_replication SET status = $1, attempts = 0 WHERE status = $2", sdk.ArtifactReplicationStatusPending, sdk.ArtifactReplicationStatusError)
+	if err != nil {
+		return 0, sdk.WrapError(err, "cannot reset replication errors")
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
@@ -0,0 +1,12 @@
+package objectstore
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type artifactReplication sdk.ArtifactReplication
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(artifactReplication{}, "artifact_replication", true, "id"))
+}
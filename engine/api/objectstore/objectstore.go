@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -76,9 +77,10 @@ type ConfigOptionsAWSS3 struct {
 	AccessKeyID         string
 	SecretAccessKey     string
 	SessionToken        string
-	Endpoint            string //optional
-	DisableSSL          bool   //optional
-	ForcePathStyle      bool   //optional
+	Endpoint            string       //optional
+	DisableSSL          bool         //optional
+	ForcePathStyle      bool         //optional
+	HTTPClient          *http.Client //optional
 }
 
 // ConfigOptionsOpenstack is used by ConfigOptions
@@ -91,6 +93,7 @@ type ConfigOptionsOpenstack struct {
 	Region          string
 	ContainerPrefix string
 	DisableTempURL  bool
+	HTTPClient      *http.Client //optional
 }
 
 // ConfigOptionsFilesystem is used by ConfigOptions
@@ -117,18 +120,37 @@ func initDriver(ctx context.Context, db gorp.SqlExecutor, projectKey, integratio
 		return nil, sdk.WrapError(err, "Cannot load projectIntegration %s/%s", projectKey, integrationName)
 	}
 
-	if !projectIntegration.Model.Storage {
-		return nil, fmt.Errorf("projectIntegration.Model %t is not a storage integration", projectIntegration.Model.Storage)
+	if !projectIntegration.Model.Supports(sdk.IntegrationTypeStorage) {
+		return nil, fmt.Errorf("integration model %s is not a storage integration", projectIntegration.Model.Name)
 	}
 
-	switch projectIntegration.Model.Name {
-	case sdk.AWSIntegrationModel:
+	if err := integration.InterpolateConfig(db, &projectIntegration); err != nil {
+		return nil, sdk.WrapError(err, "Cannot resolve projectIntegration %s/%s config", projectKey, integrationName)
+	}
+
+	kind, err := integrationKind(projectIntegration)
+	if err != nil {
+		return nil, err
+	}
+
+	// The default http client of the drivers is used if the integration has no proxy or ca bundle
+	var httpClient *http.Client
+	if projectIntegration.Config.ProxyURL() != "" || projectIntegration.Config.CABundle() != "" {
+		httpClient, err = integration.NewHTTPClient(projectIntegration.Config, 0)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot create http client for projectIntegration %s/%s", projectKey, integrationName)
+		}
+	}
+
+	switch kind {
+	case AWSS3:
 		cfg := ConfigOptionsAWSS3{
 			Region:          projectIntegration.Config["region"].Value,
 			BucketName:      projectIntegration.Config["bucket_name"].Value,
 			Prefix:          projectIntegration.Config["prefix"].Value,
 			AccessKeyID:     projectIntegration.Config["access_key_id"].Value,
 			SecretAccessKey: projectIntegration.Config["secret_access_key"].Value,
+			HTTPClient:      httpClient,
 		}
 		if endpoint := projectIntegration.Config["endpoint"].Value; endpoint != "" {
 			cfg.Endpoint = endpoint
@@ -136,7 +158,7 @@ func initDriver(ctx context.Context, db gorp.SqlExecutor, projectKey, integratio
 			cfg.ForcePathStyle, _ = strconv.ParseBool(projectIntegration.Config["force_path_style"].Value)
 		}
 		return newS3Store(ctx, projectIntegration, cfg)
-	case sdk.OpenstackIntegrationModel:
+	case Swift:
 		return newSwiftStore(ctx, projectIntegration, ConfigOptionsOpenstack{
 			Address:         projectIntegration.Config["address"].Value,
 			Region:          projectIntegration.Config["region"].Value,
@@ -146,12 +168,34 @@ func initDriver(ctx context.Context, db gorp.SqlExecutor, projectKey, integratio
 			Password:        projectIntegration.Config["password"].Value,
 			ContainerPrefix: projectIntegration.Config["storage_container_prefix"].Value,
 			DisableTempURL:  projectIntegration.Config["storage_temporary_url_supported"].Value == "false",
+			HTTPClient:      httpClient,
 		})
 	default:
 		return nil, fmt.Errorf("Invalid Integration %s", projectIntegration.Model.Name)
 	}
 }
 
+// integrationKind returns the driver of a storage integration. Builtin models use their own driver, other models
+// give it in their config so they can use any object storage compatible with s3 or swift.
+func integrationKind(projectIntegration sdk.ProjectIntegration) (Kind, error) {
+	switch projectIntegration.Model.Name {
+	case sdk.AWSIntegrationModel:
+		return AWSS3, nil
+	case sdk.OpenstackIntegrationModel:
+		return Swift, nil
+	}
+	switch driver := projectIntegration.Config[sdk.IntegrationStorageDriverConfigKey].Value; strings.ToLower(driver) {
+	case "s3":
+		return AWSS3, nil
+	case "swift":
+		return Swift, nil
+	case "":
+		return 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "integration %s should give its %q, s3 or swift", projectIntegration.Name, sdk.IntegrationStorageDriverConfigKey)
+	default:
+		return 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid %s %q for integration %s, should be s3 or swift", sdk.IntegrationStorageDriverConfigKey, driver, projectIntegration.Name)
+	}
+}
+
 // Init initialise a new ArtifactStorage
 func Init(c context.Context, cfg Config) (Driver, error) {
 	switch cfg.Kind {
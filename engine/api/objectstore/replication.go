@@ -0,0 +1,211 @@
+package objectstore
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	replicationMaxAttempts = 10
+	replicationRetryDelay  = 5 * time.Minute
+	replicationInterval    = 10 * time.Second
+)
+
+// ReplicatedDriver stores objects in a primary driver and replicates them asynchronously to a replica driver.
+// Objects to replicate are tracked in database so replication can be resumed by any API instance.
+type ReplicatedDriver struct {
+	Driver
+	replica Driver
+	dbFunc  func() *gorp.DbMap
+}
+
+// NewReplicatedDriver returns a driver that replicates objects stored in primary to replica.
+func NewReplicatedDriver(primary, replica Driver, dbFunc func() *gorp.DbMap) *ReplicatedDriver {
+	return &ReplicatedDriver{Driver: primary, replica: replica, dbFunc: dbFunc}
+}
+
+// TemporaryURLSupported returns false because objects uploaded with a temporary URL could not be tracked for replication.
+func (d *ReplicatedDriver) TemporaryURLSupported() bool {
+	return false
+}
+
+// Status returns the status of both primary and replica drivers.
+func (d *ReplicatedDriver) Status(ctx context.Context) sdk.MonitoringStatusLine {
+	primary := d.Driver.Status(ctx)
+	replica := d.replica.Status(ctx)
+	status := primary.Status
+	if status == sdk.MonitoringStatusOK && replica.Status != sdk.MonitoringStatusOK {
+		status = replica.Status
+	}
+	return sdk.MonitoringStatusLine{
+		Component: primary.Component,
+		Value:     fmt.Sprintf("%s, replica: %s", primary.Value, replica.Value),
+		Status:    status,
+		Type:      primary.Type,
+	}
+}
+
+// Store stores the object in the primary driver and marks it to be replicated.
+func (d *ReplicatedDriver) Store(o Object, data io.ReadCloser) (string, error) {
+	s, err := d.Driver.Store(o, data)
+	if err != nil {
+		return s, err
+	}
+	if err := upsertReplication(d.dbFunc(), o); err != nil {
+		log.Error(context.Background(), "ReplicatedDriver.Store> %v", err)
+	}
+	return s, nil
+}
+
+// Fetch returns the object from the primary driver, or from the replica if the primary one fails.
+func (d *ReplicatedDriver) Fetch(ctx context.Context, o Object) (io.ReadCloser, error) {
+	r, err := d.Driver.Fetch(ctx, o)
+	if err == nil {
+		return r, nil
+	}
+	log.Warning(ctx, "ReplicatedDriver.Fetch> cannot fetch %s/%s from primary storage, trying replica: %v", o.GetPath(), o.GetName(), err)
+	rr, errR := d.replica.Fetch(ctx, o)
+	if errR != nil {
+		return nil, err
+	}
+	return rr, nil
+}
+
+// Delete removes the object from both primary and replica drivers.
+func (d *ReplicatedDriver) Delete(ctx context.Context, o Object) error {
+	if err := d.Driver.Delete(ctx, o); err != nil {
+		return err
+	}
+	if err := d.replica.Delete(ctx, o); err != nil {
+		log.Error(ctx, "ReplicatedDriver.Delete> cannot delete %s/%s from replica: %v", o.GetPath(), o.GetName(), err)
+	}
+	return deleteReplication(d.dbFunc(), o)
+}
+
+// DeleteContainer removes the container from both primary and replica drivers.
+func (d *ReplicatedDriver) DeleteContainer(ctx context.Context, containerPath string) error {
+	if err := d.Driver.DeleteContainer(ctx, containerPath); err != nil {
+		return err
+	}
+	if err := d.replica.DeleteContainer(ctx, containerPath); err != nil {
+		log.Error(ctx, "ReplicatedDriver.DeleteContainer> cannot delete %s from replica: %v", containerPath, err)
+	}
+	return deleteReplicationsForContainer(d.dbFunc(), containerPath)
+}
+
+// StartReplication replicates pending objects until given context is done.
+func (d *ReplicatedDriver) StartReplication(ctx context.Context, parallel int) {
+	if parallel < 1 {
+		parallel = 1
+	}
+	tick := time.NewTicker(replicationInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "ReplicatedDriver.StartReplication> exiting replication: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			var wg sync.WaitGroup
+			for i := 0; i < parallel; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for ctx.Err() == nil {
+						found, err := d.replicateNext(ctx)
+						if err != nil {
+							log.Error(ctx, "ReplicatedDriver.StartReplication> %v", err)
+							return
+						}
+						if !found {
+							return
+						}
+					}
+				}()
+			}
+			wg.Wait()
+		}
+	}
+}
+
+// replicateNext replicates the next pending object, it returns false if there is nothing to replicate.
+func (d *ReplicatedDriver) replicateNext(ctx context.Context) (bool, error) {
+	tx, err := d.dbFunc().Begin()
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	r, err := loadNextReplicationForUpdate(ctx, tx, replicationMaxAttempts, replicationRetryDelay)
+	if err != nil {
+		return false, err
+	}
+	if r == nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	r.Attempts++
+	r.LastAttempt = &now
+	checksum, err := d.replicate(ctx, r)
+	if err != nil {
+		log.Warning(ctx, "ReplicatedDriver.replicateNext> cannot replicate %s/%s: %v", r.Path, r.Name, err)
+		r.Status = sdk.ArtifactReplicationStatusError
+		r.Error = err.Error()
+	} else {
+		r.Status = sdk.ArtifactReplicationStatusSynced
+		r.Checksum = checksum
+		r.Error = ""
+	}
+
+	if err := updateReplication(tx, r); err != nil {
+		return false, err
+	}
+	return true, sdk.WithStack(tx.Commit())
+}
+
+// replicate copies the object to the replica and checks that the replicated content matches the primary one.
+func (d *ReplicatedDriver) replicate(ctx context.Context, r *sdk.ArtifactReplication) (string, error) {
+	src, err := d.Driver.Fetch(ctx, r)
+	if err != nil {
+		return "", fmt.Errorf("cannot fetch object from primary storage: %v", err)
+	}
+	defer src.Close() // nolint
+
+	h := sha512.New()
+	data := struct {
+		io.Reader
+		io.Closer
+	}{io.TeeReader(src, h), src}
+	if _, err := d.replica.Store(r, data); err != nil {
+		return "", fmt.Errorf("cannot store object in replica: %v", err)
+	}
+	checksum := hex.EncodeToString(h.Sum(nil))
+
+	replicated, err := d.replica.Fetch(ctx, r)
+	if err != nil {
+		return "", fmt.Errorf("cannot fetch object from replica: %v", err)
+	}
+	defer replicated.Close() // nolint
+
+	hr := sha512.New()
+	if _, err := io.Copy(hr, replicated); err != nil {
+		return "", fmt.Errorf("cannot read object from replica: %v", err)
+	}
+	if replicatedChecksum := hex.EncodeToString(hr.Sum(nil)); replicatedChecksum != checksum {
+		return "", fmt.Errorf("checksum mismatch between primary (%s) and replica (%s)", checksum, replicatedChecksum)
+	}
+	return checksum, nil
+}
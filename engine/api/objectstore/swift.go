@@ -37,6 +37,9 @@ func newSwiftStore(ctx context.Context, integration sdk.ProjectIntegration, conf
 		disableTempURL:     conf.DisableTempURL,
 		projectIntegration: integration,
 	}
+	if conf.HTTPClient != nil {
+		s.Transport = conf.HTTPClient.Transport
+	}
 	if err := s.Authenticate(); err != nil {
 		return nil, sdk.WrapError(err, "Unable to authenticate on swift storage")
 	}
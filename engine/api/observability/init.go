@@ -10,6 +10,7 @@ import (
 	"go.opencensus.io/trace"
 
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -79,6 +80,10 @@ func Init(ctx context.Context, cfg Configuration, s service) (context.Context, e
 		he := new(HTTPExporter)
 		view.RegisterExporter(he)
 		statsHTTPExporter = he
+
+		if err := RegisterView(cdsclient.HTTPClientViews...); err != nil {
+			return ctx, err
+		}
 	}
 
 	return ctx, nil
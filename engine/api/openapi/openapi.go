@@ -0,0 +1,225 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Version of the OpenAPI specification of the generated documents.
+const Version = "3.0.3"
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Servers    []Server            `json:"servers,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+	Tags       []Tag               `json:"tags,omitempty"`
+}
+
+// Info gives metadata about the API.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Server is an URL of the API.
+type Server struct {
+	URL string `json:"url"`
+}
+
+// Tag groups operations.
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// PathItem contains the operations of a path by lower case HTTP method.
+type PathItem map[string]*Operation
+
+// Operation describes an API operation on a path.
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security"`
+	Scopes      []string              `json:"x-cds-scopes,omitempty"`
+	NeedAdmin   bool                  `json:"x-cds-admin,omitempty"`
+}
+
+// Parameter of an operation.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody of an operation.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response of an operation.
+type Response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType gives the schema of a body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components contains the objects referenced by the document.
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	Responses       map[string]*Response      `json:"responses,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how the API authenticates requests.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Route describes a route of the API to document.
+type Route struct {
+	Path       string
+	Method     string
+	Handler    string
+	NeedAuth   bool
+	NeedAdmin  bool
+	Scopes     []string
+	Deprecated bool
+	// Request and Response are values of the types of the request and response bodies, nil if unknown
+	Request  interface{}
+	Response interface{}
+}
+
+const (
+	securitySchemeName = "bearer"
+	errorResponseName  = "Error"
+	jsonContentType    = "application/json"
+)
+
+// Generate returns the OpenAPI document of given routes. Path variables in routes should be written {name}. Given
+// error is the body returned by the API on error.
+func Generate(info Info, serverURL string, routes []Route, errorBody interface{}) Document {
+	g := newGenerator()
+	doc := Document{
+		OpenAPI: Version,
+		Info:    info,
+		Servers: []Server{{URL: serverURL}},
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas: g.schemas,
+			Responses: map[string]*Response{
+				errorResponseName: {
+					Description: "Error",
+					Content:     map[string]MediaType{jsonContentType: {Schema: g.schema(reflect.TypeOf(errorBody))}},
+				},
+			},
+			SecuritySchemes: map[string]SecurityScheme{
+				securitySchemeName: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	operationIDs := make(map[string]int)
+	tags := make(map[string]struct{})
+	for _, r := range routes {
+		op := &Operation{
+			OperationID: r.Handler,
+			Summary:     fmt.Sprintf("%s %s", r.Method, r.Path),
+			Deprecated:  r.Deprecated,
+			Responses:   make(map[string]*Response),
+			Security:    []map[string][]string{},
+			Scopes:      r.Scopes,
+			NeedAdmin:   r.NeedAdmin,
+		}
+
+		// Operation ids should be unique but a handler can be used on many routes
+		operationIDs[r.Handler]++
+		if n := operationIDs[r.Handler]; n > 1 {
+			op.OperationID = fmt.Sprintf("%s_%d", r.Handler, n)
+		}
+
+		if tag := pathTag(r.Path); tag != "" {
+			op.Tags = []string{tag}
+			tags[tag] = struct{}{}
+		}
+		if r.NeedAuth {
+			op.Security = []map[string][]string{{securitySchemeName: {}}}
+		}
+		for _, name := range pathParameters(r.Path) {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+		if r.Request != nil {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{jsonContentType: {Schema: g.schema(reflect.TypeOf(r.Request))}},
+			}
+		}
+		success := &Response{Description: "Success"}
+		if r.Response != nil {
+			success.Content = map[string]MediaType{jsonContentType: {Schema: g.schema(reflect.TypeOf(r.Response))}}
+		}
+		op.Responses["200"] = success
+		op.Responses["default"] = &Response{Ref: "#/components/responses/" + errorResponseName}
+
+		if _, ok := doc.Paths[r.Path]; !ok {
+			doc.Paths[r.Path] = make(PathItem)
+		}
+		doc.Paths[r.Path][strings.ToLower(r.Method)] = op
+	}
+
+	for t := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: t})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+
+	return doc
+}
+
+// pathTag returns the first element of a path.
+func pathTag(path string) string {
+	s := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(s) == 0 || strings.HasPrefix(s[0], "{") {
+		return ""
+	}
+	return s[0]
+}
+
+// pathParameters returns the names of the variables of a path.
+func pathParameters(path string) []string {
+	var names []string
+	for _, s := range strings.Split(path, "/") {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			names = append(names, strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}"))
+		}
+	}
+	return names
+}
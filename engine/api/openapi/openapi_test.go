@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testError struct {
+	ID      int    `json:"id"`
+	Message string `json:"message"`
+}
+
+type testBase struct {
+	Created time.Time `json:"created"`
+}
+
+type testProject struct {
+	testBase
+	ID       int64             `json:"id"`
+	Key      string            `json:"key"`
+	Secret   string            `json:"-"`
+	Parent   *testProject      `json:"parent,omitempty"`
+	Labels   map[string]string `json:"labels"`
+	Icon     []byte            `json:"icon"`
+	Disabled *bool             `json:"disabled"`
+	Data     interface{}       `json:"data"`
+	internal string
+}
+
+func TestGenerate(t *testing.T) {
+	routes := []Route{
+		{Path: "/project", Method: "GET", Handler: "getProjects", NeedAuth: true, Scopes: []string{"Project"}, Response: []testProject{}},
+		{Path: "/project", Method: "POST", Handler: "postProject", NeedAuth: true, Request: testProject{}, Response: testProject{}},
+		{Path: "/project/{project-key}", Method: "GET", Handler: "getProject", NeedAuth: true, Response: testProject{}},
+		{Path: "/project/{project-key}/old", Method: "GET", Handler: "getProject", Deprecated: true},
+		{Path: "/mon/version", Method: "GET", Handler: "Version"},
+	}
+
+	doc := Generate(Info{Title: "CDS API", Version: "v2"}, "http://cds.local/v2", routes, testError{})
+	assert.Equal(t, Version, doc.OpenAPI)
+	require.Len(t, doc.Paths, 4)
+	require.Len(t, doc.Paths["/project"], 2)
+
+	getProjects := doc.Paths["/project"]["get"]
+	assert.Equal(t, "getProjects", getProjects.OperationID)
+	assert.Equal(t, []string{"project"}, getProjects.Tags)
+	assert.Equal(t, []map[string][]string{{"bearer": {}}}, getProjects.Security)
+	assert.Equal(t, "array", getProjects.Responses["200"].Content["application/json"].Schema.Type)
+	assert.Equal(t, "#/components/schemas/testProject", getProjects.Responses["200"].Content["application/json"].Schema.Items.Ref)
+	assert.Equal(t, "#/components/responses/Error", getProjects.Responses["default"].Ref)
+
+	assert.Equal(t, "#/components/schemas/testProject", doc.Paths["/project"]["post"].RequestBody.Content["application/json"].Schema.Ref)
+
+	getProject := doc.Paths["/project/{project-key}"]["get"]
+	require.Len(t, getProject.Parameters, 1)
+	assert.Equal(t, "project-key", getProject.Parameters[0].Name)
+	assert.Equal(t, "path", getProject.Parameters[0].In)
+
+	old := doc.Paths["/project/{project-key}/old"]["get"]
+	assert.Equal(t, "getProject_2", old.OperationID)
+	assert.True(t, old.Deprecated)
+	assert.Nil(t, old.Responses["200"].Content)
+
+	assert.Empty(t, doc.Paths["/mon/version"]["get"].Security)
+
+	require.Contains(t, doc.Components.Schemas, "testProject")
+	project := doc.Components.Schemas["testProject"]
+	assert.Equal(t, "object", project.Type)
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, project.Properties["created"])
+	assert.Equal(t, &Schema{Type: "integer", Format: "int64"}, project.Properties["id"])
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/testProject"}, project.Properties["parent"])
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, project.Properties["labels"])
+	assert.Equal(t, &Schema{Type: "string", Format: "byte"}, project.Properties["icon"])
+	assert.Equal(t, &Schema{Type: "boolean", Nullable: true}, project.Properties["disabled"])
+	assert.Equal(t, &Schema{}, project.Properties["data"])
+	assert.NotContains(t, project.Properties, "Secret")
+	assert.NotContains(t, project.Properties, "internal")
+	assert.Len(t, project.Properties, 8)
+	require.Contains(t, doc.Components.Schemas, "testError")
+
+	_, err := json.Marshal(doc)
+	require.NoError(t, err)
+}
@@ -80,9 +80,14 @@ func scanPermissions(rows *sql.Rows) (sdk.EntitiesPermissions, error) {
 		case sdk.PermissionReadExecute:
 			p.Readable = true
 			p.Executable = true
+		case sdk.PermissionReadExecuteDeploy:
+			p.Readable = true
+			p.Executable = true
+			p.Deployable = true
 		case sdk.PermissionReadWriteExecute:
 			p.Readable = true
 			p.Executable = true
+			p.Deployable = true
 			p.Writable = true
 		}
 		res[k] = p
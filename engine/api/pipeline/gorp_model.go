@@ -30,6 +30,7 @@ type pipelineAction struct {
 	ActionID        int64     `db:"action_id"`
 	Args            *string   `db:"args"`
 	Enabled         bool      `db:"enabled"`
+	Idempotent      bool      `db:"idempotent"`
 	LastModified    time.Time `db:"last_modified"`
 }
 
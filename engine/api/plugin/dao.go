@@ -45,8 +45,12 @@ func (p *grpcPlugin) PostUpdate(db gorp.SqlExecutor) error {
 	if err != nil {
 		return sdk.WrapError(err, "unable to marshal data")
 	}
+	c, err := gorpmapping.JSONToNullString(p.Contract)
+	if err != nil {
+		return sdk.WrapError(err, "unable to marshal contract")
+	}
 
-	if _, err := db.Exec("UPDATE grpc_plugin SET binaries = $2 WHERE id = $1", p.ID, s); err != nil {
+	if _, err := db.Exec("UPDATE grpc_plugin SET binaries = $2, contract = $3 WHERE id = $1", p.ID, s, c); err != nil {
 		return sdk.WrapError(err, "unable to update data")
 	}
 
@@ -144,6 +148,13 @@ func (p *grpcPlugin) PostGet(db gorp.SqlExecutor) error {
 	if err := gorpmapping.JSONNullString(s, &p.Binaries); err != nil {
 		return sdk.WrapError(err, "plugin.PostGet")
 	}
+	c, err := db.SelectNullStr("SELECT contract FROM grpc_plugin WHERE ID = $1", p.ID)
+	if err != nil {
+		return sdk.WrapError(err, "unable to get contract for ID=%d", p.ID)
+	}
+	if err := gorpmapping.JSONNullString(c, &p.Contract); err != nil {
+		return sdk.WrapError(err, "plugin.PostGet")
+	}
 	if p.IntegrationModelID != nil {
 		var err error
 		p.Integration, err = db.SelectStr("SELECT name FROM integration_model WHERE ID = $1", p.IntegrationModelID)
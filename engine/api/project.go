@@ -89,7 +89,7 @@ func (api *API) getProjectsHandler_FilterByRepo(ctx context.Context, w http.Resp
 	}
 	for i := range projects {
 		if isAdmin(ctx) {
-			projects[i].Permissions = sdk.Permissions{Readable: true, Writable: true, Executable: true}
+			projects[i].Permissions = sdk.Permissions{Readable: true, Writable: true, Executable: true, Deployable: true}
 			continue
 		}
 		projects[i].Permissions = perms[projects[i].Key]
@@ -190,7 +190,7 @@ func (api *API) getProjectsHandler() service.Handler {
 		}
 		for i := range projects {
 			if admin {
-				projects[i].Permissions = sdk.Permissions{Readable: true, Writable: true, Executable: true}
+				projects[i].Permissions = sdk.Permissions{Readable: true, Writable: true, Executable: true, Deployable: true}
 				continue
 			}
 			projects[i].Permissions = perms[projects[i].Key]
@@ -340,7 +340,7 @@ func (api *API) getProjectHandler() service.Handler {
 		p.URLs.UIURL = api.Config.URL.UI + "/project/" + key
 
 		if isAdmin(ctx) {
-			p.Permissions = sdk.Permissions{Readable: true, Writable: true, Executable: true}
+			p.Permissions = sdk.Permissions{Readable: true, Writable: true, Executable: true, Deployable: true}
 		} else {
 			permissions, err := permission.LoadProjectMaxLevelPermission(ctx, api.mustDB(), []string{p.Key}, getAPIConsumer(ctx).GetGroupIDs())
 			if err != nil {
@@ -459,14 +459,9 @@ func (api *API) postProjectHandler() service.Handler {
 			return sdk.WrapError(err, "unable to unmarshal body")
 		}
 
-		// Check key pattern
-		if rgxp := regexp.MustCompile(sdk.ProjectKeyPattern); !rgxp.MatchString(p.Key) {
-			return sdk.WrapError(sdk.ErrInvalidProjectKey, "project key %s do not respect pattern %s", p.Key, sdk.ProjectKeyPattern)
-		}
-
-		// Check project name
-		if p.Name == "" {
-			return sdk.WrapError(sdk.ErrInvalidProjectName, "project name must no be empty")
+		// Groups of the project have RWX permission
+		for i := range p.ProjectGroups {
+			p.ProjectGroups[i].Permission = sdk.PermissionReadWriteExecute
 		}
 
 		//Create a project within a transaction
@@ -476,154 +471,188 @@ func (api *API) postProjectHandler() service.Handler {
 		}
 		defer tx.Rollback() // nolint
 
-		// Check that project does not already exists
-		exist, errExist := project.Exist(tx, p.Key)
-		if errExist != nil {
-			return sdk.WrapError(errExist, "cannot check if project %s exist", p.Key)
-		}
-		if exist {
-			return sdk.WrapError(sdk.ErrConflict, "project %s already exists", p.Key)
-		}
-
-		if err := project.Insert(tx, &p); err != nil {
+		eventIntegrationIDs, err := api.createProject(ctx, tx, &p)
+		if err != nil {
 			return err
 		}
 
-		// Check that given project groups are valid
-		var groupIDs []int64
-		for _, gp := range p.ProjectGroups {
-			var grp *sdk.Group
-			var err error
-			if gp.Group.ID != 0 {
-				grp, err = group.LoadByID(ctx, tx, gp.Group.ID, group.LoadOptions.WithMembers)
-			} else {
-				grp, err = group.LoadByName(ctx, tx, gp.Group.Name, group.LoadOptions.WithMembers)
-			}
-			if err != nil {
-				return err
-			}
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
 
-			// the default group could not be selected
-			if group.IsDefaultGroupID(grp.ID) {
-				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot use default group to create project")
+		for _, id := range eventIntegrationIDs {
+			if err := event.ResetEventIntegration(ctx, api.mustDB(), id); err != nil {
+				log.Error(ctx, "postProjectHandler> cannot connect to event broker of project integration %d: %v", id, err)
 			}
+		}
 
-			// consumer should be group member to add it on a project
-			if !isGroupMember(ctx, grp) && !isAdmin(ctx) {
-				return sdk.WithStack(sdk.ErrInvalidGroupMember)
-			}
+		event.PublishAddProject(ctx, &p, consumer)
 
-			groupIDs = append(groupIDs, grp.ID)
+		proj, err := project.Load(api.mustDB(), p.Key,
+			project.LoadOptions.WithLabels,
+			project.LoadOptions.WithWorkflowNames,
+			project.LoadOptions.WithFavorites(consumer.AuthentifiedUser.ID),
+			project.LoadOptions.WithKeys,
+			project.LoadOptions.WithPermission,
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithVariables,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", p.Key)
 		}
+		proj.Permissions.Readable = true
+		proj.Permissions.Writable = true
 
-		// If no groups were given, try to create a new one with project name
-		if len(groupIDs) == 0 {
-			groupSlug := slug.Convert(p.Name)
-			existingGroop, err := group.LoadByName(ctx, tx, groupSlug)
-			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
-				return err
-			}
-			if existingGroop != nil {
-				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot create a new group %s for given project name", groupSlug)
-			}
+		return service.WriteJSON(w, *proj, http.StatusCreated)
+	}
+}
 
-			newGroup := sdk.Group{Name: groupSlug}
-			if err := group.Create(ctx, tx, &newGroup, consumer.AuthentifiedUser.ID); err != nil {
-				return err
-			}
+// createProject inserts a project with its groups, variables, keys and integrations. Each given group is added with
+// its permission. It returns the ids of the event integrations of the project to reset once the transaction is committed.
+func (api *API) createProject(ctx context.Context, tx gorp.SqlExecutor, p *sdk.Project) ([]int64, error) {
+	consumer := getAPIConsumer(ctx)
 
-			groupIDs = []int64{newGroup.ID}
-		}
+	// Check key pattern
+	if rgxp := regexp.MustCompile(sdk.ProjectKeyPattern); !rgxp.MatchString(p.Key) {
+		return nil, sdk.WrapError(sdk.ErrInvalidProjectKey, "project key %s do not respect pattern %s", p.Key, sdk.ProjectKeyPattern)
+	}
 
-		// Insert all links between project and group
-		for _, groupID := range groupIDs {
-			if err := group.InsertLinkGroupProject(ctx, tx, &group.LinkGroupProject{
-				GroupID:   groupID,
-				ProjectID: p.ID,
-				Role:      sdk.PermissionReadWriteExecute,
-			}); err != nil {
-				return sdk.WrapError(err, "cannot add group %d in project %s", groupID, p.Name)
-			}
+	// Check project name
+	if p.Name == "" {
+		return nil, sdk.WrapError(sdk.ErrInvalidProjectName, "project name must no be empty")
+	}
+
+	// Check that project does not already exists
+	exist, errExist := project.Exist(tx, p.Key)
+	if errExist != nil {
+		return nil, sdk.WrapError(errExist, "cannot check if project %s exist", p.Key)
+	}
+	if exist {
+		return nil, sdk.WrapError(sdk.ErrConflict, "project %s already exists", p.Key)
+	}
+
+	if err := project.Insert(tx, p); err != nil {
+		return nil, err
+	}
+
+	// Check that given project groups are valid
+	var groupIDs []int64
+	roles := make(map[int64]int, len(p.ProjectGroups))
+	for _, gp := range p.ProjectGroups {
+		var grp *sdk.Group
+		var err error
+		if gp.Group.ID != 0 {
+			grp, err = group.LoadByID(ctx, tx, gp.Group.ID, group.LoadOptions.WithMembers)
+		} else {
+			grp, err = group.LoadByName(ctx, tx, gp.Group.Name, group.LoadOptions.WithMembers)
+		}
+		if err != nil {
+			return nil, err
 		}
 
-		for _, v := range p.Variables {
-			if errVar := project.InsertVariable(tx, p.ID, &v, consumer); errVar != nil {
-				return sdk.WrapError(errVar, "addProjectHandler> Cannot add variable %s in project %s", v.Name, p.Name)
-			}
+		// the default group could not be selected
+		if group.IsDefaultGroupID(grp.ID) {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot use default group to create project")
 		}
 
-		p.Keys = []sdk.ProjectKey{
-			{
-				Type: sdk.KeyTypeSSH,
-				Name: sdk.GenerateProjectDefaultKeyName(p.Key, sdk.KeyTypeSSH),
-			},
-			{
-				Type: sdk.KeyTypePGP,
-				Name: sdk.GenerateProjectDefaultKeyName(p.Key, sdk.KeyTypePGP),
-			},
-		}
-		for i := range p.Keys {
-			k := &p.Keys[i]
-			k.ProjectID = p.ID
-			switch k.Type {
-			case sdk.KeyTypeSSH:
-				keyTemp, err := keys.GenerateSSHKey(k.Name)
-				if err != nil {
-					return sdk.WrapError(err, "cannot generate ssh key for project %s", p.Name)
-				}
-				k.Private = keyTemp.Private
-				k.Public = keyTemp.Public
-				k.Type = keyTemp.Type
-			case sdk.KeyTypePGP:
-				keyTemp, err := keys.GeneratePGPKeyPair(k.Name)
-				if err != nil {
-					return sdk.WrapError(err, "cannot generate pgp key for project %s", p.Name)
-				}
-				k.Private = keyTemp.Private
-				k.Public = keyTemp.Public
-				k.Type = keyTemp.Type
-				k.KeyID = keyTemp.KeyID
-			}
-			if err := project.InsertKey(tx, k); err != nil {
-				return sdk.WrapError(err, "cannot add key %s in project %s", k.Name, p.Name)
-			}
+		// consumer should be group member to add it on a project
+		if !isGroupMember(ctx, grp) && !isAdmin(ctx) {
+			return nil, sdk.WithStack(sdk.ErrInvalidGroupMember)
 		}
 
-		integrationModels, err := integration.LoadModels(tx)
-		if err != nil {
-			return sdk.WrapError(err, "cannot load integration models")
+		groupIDs = append(groupIDs, grp.ID)
+		roles[grp.ID] = gp.Permission
+	}
+
+	// If no groups were given, try to create a new one with project name
+	if len(groupIDs) == 0 {
+		groupSlug := slug.Convert(p.Name)
+		existingGroop, err := group.LoadByName(ctx, tx, groupSlug)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, err
+		}
+		if existingGroop != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot create a new group %s for given project name", groupSlug)
 		}
 
-		for i := range integrationModels {
-			pf := &integrationModels[i]
-			if err := propagatePublicIntegrationModelOnProject(ctx, tx, api.Cache, *pf, p, consumer); err != nil {
-				return sdk.WithStack(err)
-			}
+		newGroup := sdk.Group{Name: groupSlug}
+		if err := group.Create(ctx, tx, &newGroup, consumer.AuthentifiedUser.ID); err != nil {
+			return nil, err
 		}
 
-		if err := tx.Commit(); err != nil {
-			return sdk.WithStack(err)
+		groupIDs = []int64{newGroup.ID}
+		roles[newGroup.ID] = sdk.PermissionReadWriteExecute
+	}
+
+	// Insert all links between project and group
+	for _, groupID := range groupIDs {
+		if err := group.InsertLinkGroupProject(ctx, tx, &group.LinkGroupProject{
+			GroupID:   groupID,
+			ProjectID: p.ID,
+			Role:      roles[groupID],
+		}); err != nil {
+			return nil, sdk.WrapError(err, "cannot add group %d in project %s", groupID, p.Name)
 		}
+	}
 
-		event.PublishAddProject(ctx, &p, consumer)
+	for _, v := range p.Variables {
+		if errVar := project.InsertVariable(tx, p.ID, &v, consumer); errVar != nil {
+			return nil, sdk.WrapError(errVar, "addProjectHandler> Cannot add variable %s in project %s", v.Name, p.Name)
+		}
+	}
 
-		proj, err := project.Load(api.mustDB(), p.Key,
-			project.LoadOptions.WithLabels,
-			project.LoadOptions.WithWorkflowNames,
-			project.LoadOptions.WithFavorites(consumer.AuthentifiedUser.ID),
-			project.LoadOptions.WithKeys,
-			project.LoadOptions.WithPermission,
-			project.LoadOptions.WithIntegrations,
-			project.LoadOptions.WithVariables,
-		)
-		if err != nil {
-			return sdk.WrapError(err, "cannot load project %s", p.Key)
+	p.Keys = []sdk.ProjectKey{
+		{
+			Type: sdk.KeyTypeSSH,
+			Name: sdk.GenerateProjectDefaultKeyName(p.Key, sdk.KeyTypeSSH),
+		},
+		{
+			Type: sdk.KeyTypePGP,
+			Name: sdk.GenerateProjectDefaultKeyName(p.Key, sdk.KeyTypePGP),
+		},
+	}
+	for i := range p.Keys {
+		k := &p.Keys[i]
+		k.ProjectID = p.ID
+		switch k.Type {
+		case sdk.KeyTypeSSH:
+			keyTemp, err := keys.GenerateSSHKey(k.Name)
+			if err != nil {
+				return nil, sdk.WrapError(err, "cannot generate ssh key for project %s", p.Name)
+			}
+			k.Private = keyTemp.Private
+			k.Public = keyTemp.Public
+			k.Type = keyTemp.Type
+		case sdk.KeyTypePGP:
+			keyTemp, err := keys.GeneratePGPKeyPair(k.Name)
+			if err != nil {
+				return nil, sdk.WrapError(err, "cannot generate pgp key for project %s", p.Name)
+			}
+			k.Private = keyTemp.Private
+			k.Public = keyTemp.Public
+			k.Type = keyTemp.Type
+			k.KeyID = keyTemp.KeyID
 		}
-		proj.Permissions.Readable = true
-		proj.Permissions.Writable = true
+		if err := project.InsertKey(tx, k); err != nil {
+			return nil, sdk.WrapError(err, "cannot add key %s in project %s", k.Name, p.Name)
+		}
+	}
 
-		return service.WriteJSON(w, *proj, http.StatusCreated)
+	integrationModels, err := integration.LoadModels(tx)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load integration models")
+	}
+
+	for i := range integrationModels {
+		pf := &integrationModels[i]
+		if err := propagatePublicIntegrationModelOnProject(ctx, tx, api.Cache, *pf, *p, consumer); err != nil {
+			return nil, sdk.WithStack(err)
+		}
 	}
+
+	// Default integrations of the project groups are inherited
+	return inheritGroupIntegrations(tx, *p, groupIDs)
+
 }
 
 func (api *API) deleteProjectHandler() service.Handler {
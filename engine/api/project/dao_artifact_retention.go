@@ -0,0 +1,60 @@
+package project
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadArtifactRetention returns the artifact retention policy of given project, the policy is empty if it was never set.
+func LoadArtifactRetention(ctx context.Context, db gorp.SqlExecutor, projectID int64) (sdk.ProjectArtifactRetention, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT project_artifact_retention.*
+		FROM project_artifact_retention
+		WHERE project_id = $1
+	`).Args(projectID)
+	var p dbProjectArtifactRetention
+	found, err := gorpmapping.Get(ctx, db, query, &p)
+	if err != nil {
+		return sdk.ProjectArtifactRetention{}, sdk.WrapError(err, "cannot load artifact retention for project %d", projectID)
+	}
+	if !found {
+		return sdk.ProjectArtifactRetention{ProjectID: projectID}, nil
+	}
+	return sdk.ProjectArtifactRetention(p), nil
+}
+
+// LoadArtifactRetentions returns the artifact retention policies of all the projects that delete artifacts.
+func LoadArtifactRetentions(ctx context.Context, db gorp.SqlExecutor) ([]sdk.ProjectArtifactRetention, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT project_artifact_retention.*
+		FROM project_artifact_retention
+		WHERE branch_days > 0 OR tag_days > 0 OR release_days > 0
+		ORDER BY project_id
+	`)
+	var ps []dbProjectArtifactRetention
+	if err := gorpmapping.GetAll(ctx, db, query, &ps); err != nil {
+		return nil, sdk.WrapError(err, "cannot load artifact retentions")
+	}
+	res := make([]sdk.ProjectArtifactRetention, len(ps))
+	for i := range ps {
+		res[i] = sdk.ProjectArtifactRetention(ps[i])
+	}
+	return res, nil
+}
+
+// UpsertArtifactRetention sets the artifact retention policy of a project, replacing the previous one if any.
+func UpsertArtifactRetention(db gorp.SqlExecutor, p *sdk.ProjectArtifactRetention) error {
+	if _, err := db.Exec("DELETE FROM project_artifact_retention WHERE project_id = $1", p.ProjectID); err != nil {
+		return sdk.WrapError(err, "cannot delete artifact retention for project %d", p.ProjectID)
+	}
+	dbp := dbProjectArtifactRetention(*p)
+	if err := gorpmapping.Insert(db, &dbp); err != nil {
+		return sdk.WrapError(err, "cannot insert artifact retention for project %d", p.ProjectID)
+	}
+	p.ID = dbp.ID
+	return nil
+}
@@ -0,0 +1,92 @@
+package project
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadQuota returns the quotas of given project, the quotas are empty if they were never set.
+func LoadQuota(ctx context.Context, db gorp.SqlExecutor, projectID int64) (sdk.ProjectQuota, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT project_quota.*
+		FROM project_quota
+		WHERE project_id = $1
+	`).Args(projectID)
+	var q dbProjectQuota
+	found, err := gorpmapping.Get(ctx, db, query, &q)
+	if err != nil {
+		return sdk.ProjectQuota{}, sdk.WrapError(err, "cannot load quota for project %d", projectID)
+	}
+	if !found {
+		return sdk.ProjectQuota{ProjectID: projectID}, nil
+	}
+	return sdk.ProjectQuota(q), nil
+}
+
+// UpsertQuota sets the quotas of a project, replacing the previous ones if any.
+func UpsertQuota(db gorp.SqlExecutor, q *sdk.ProjectQuota) error {
+	if _, err := db.Exec("DELETE FROM project_quota WHERE project_id = $1", q.ProjectID); err != nil {
+		return sdk.WrapError(err, "cannot delete quota for project %d", q.ProjectID)
+	}
+	dbq := dbProjectQuota(*q)
+	if err := gorpmapping.Insert(db, &dbq); err != nil {
+		return sdk.WrapError(err, "cannot insert quota for project %d", q.ProjectID)
+	}
+	q.ID = dbq.ID
+	return nil
+}
+
+// LoadQuotaUsage returns the current usage of given quota of a project: the number of building jobs, the size of the
+// artifacts of all the workflow runs or the number of workflow runs started in the last 24 hours.
+func LoadQuotaUsage(db gorp.SqlExecutor, projectID int64, name string) (int64, error) {
+	var query string
+	switch name {
+	case sdk.ProjectQuotaConcurrentJobs:
+		query = `
+			SELECT COUNT(*)
+			FROM workflow_node_run_job
+			WHERE project_id = $1 AND status = $2`
+	case sdk.ProjectQuotaArtifactStorage:
+		query = `
+			SELECT COALESCE(SUM(workflow_node_run_artifacts.size), 0)
+			FROM workflow_node_run_artifacts
+			JOIN workflow_run ON workflow_run.id = workflow_node_run_artifacts.workflow_run_id
+			WHERE workflow_run.project_id = $1`
+	case sdk.ProjectQuotaRunsPerDay:
+		query = `
+			SELECT COUNT(*)
+			FROM workflow_run
+			WHERE project_id = $1 AND start > NOW() - INTERVAL '1 day'`
+	default:
+		return 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid quota %s", name)
+	}
+	args := []interface{}{projectID}
+	if name == sdk.ProjectQuotaConcurrentJobs {
+		args = append(args, sdk.StatusBuilding)
+	}
+	usage, err := db.SelectInt(query, args...)
+	if err != nil {
+		return 0, sdk.WrapError(err, "cannot load usage of quota %s for project %d", name, projectID)
+	}
+	return usage, nil
+}
+
+// LoadQuotaUsages returns the current usage of all the quotas of a project.
+func LoadQuotaUsages(db gorp.SqlExecutor, projectID int64) (sdk.ProjectQuotaUsage, error) {
+	var u sdk.ProjectQuotaUsage
+	var err error
+	if u.ConcurrentJobs, err = LoadQuotaUsage(db, projectID, sdk.ProjectQuotaConcurrentJobs); err != nil {
+		return u, err
+	}
+	if u.ArtifactStorage, err = LoadQuotaUsage(db, projectID, sdk.ProjectQuotaArtifactStorage); err != nil {
+		return u, err
+	}
+	if u.RunsPerDay, err = LoadQuotaUsage(db, projectID, sdk.ProjectQuotaRunsPerDay); err != nil {
+		return u, err
+	}
+	return u, nil
+}
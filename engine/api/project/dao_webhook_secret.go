@@ -0,0 +1,101 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func getAllWebHookSecrets(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query, opts ...gorpmapping.GetOptionFunc) ([]sdk.ProjectWebHookSecret, error) {
+	var res []dbProjectWebHookSecret
+	if err := gorpmapping.GetAll(ctx, db, query, &res, opts...); err != nil {
+		return nil, err
+	}
+
+	secrets := make([]sdk.ProjectWebHookSecret, 0, len(res))
+	for i := range res {
+		isValid, err := gorpmapping.CheckSignature(res[i], res[i].Signature)
+		if err != nil {
+			return nil, err
+		}
+		if !isValid {
+			log.Error(ctx, "project.getAllWebHookSecrets> project webhook secret %d data corrupted", res[i].ID)
+			continue
+		}
+		secrets = append(secrets, res[i].ProjectWebHookSecret)
+	}
+	return secrets, nil
+}
+
+func loadWebHookSecrets(ctx context.Context, db gorp.SqlExecutor, projectID int64, opts ...gorpmapping.GetOptionFunc) ([]sdk.ProjectWebHookSecret, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM project_webhook_secret
+		WHERE project_id = $1
+		AND (expire_at IS NULL OR expire_at > $2)
+		ORDER BY created DESC
+	`).Args(projectID, time.Now())
+	return getAllWebHookSecrets(ctx, db, query, opts...)
+}
+
+// LoadWebHookSecrets returns the webhook secrets of given project that are not expired, without their value.
+func LoadWebHookSecrets(ctx context.Context, db gorp.SqlExecutor, projectID int64) ([]sdk.ProjectWebHookSecret, error) {
+	secrets, err := loadWebHookSecrets(ctx, db, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range secrets {
+		secrets[i].Secret = ""
+	}
+	return secrets, nil
+}
+
+// LoadWebHookSecretsWithDecryption returns the webhook secrets of given project that are not expired, with their value.
+func LoadWebHookSecretsWithDecryption(ctx context.Context, db gorp.SqlExecutor, projectID int64) ([]sdk.ProjectWebHookSecret, error) {
+	return loadWebHookSecrets(ctx, db, projectID, gorpmapping.GetOptions.WithDecryption)
+}
+
+// RotateWebHookSecret generates a new webhook secret for given project. The current secrets expire after given overlap,
+// or immediately if overlap is not positive. The returned secret contains its value.
+func RotateWebHookSecret(ctx context.Context, db gorp.SqlExecutor, projectID int64, overlap time.Duration) (*sdk.ProjectWebHookSecret, error) {
+	now := time.Now()
+	if overlap > 0 {
+		// A secret that already expires before the end of the overlap keeps its expiration date
+		if _, err := db.Exec(`
+			UPDATE project_webhook_secret SET expire_at = $2
+			WHERE project_id = $1 AND (expire_at IS NULL OR expire_at > $2)
+		`, projectID, now.Add(overlap)); err != nil {
+			return nil, sdk.WrapError(err, "cannot set expiration of webhook secrets for project %d", projectID)
+		}
+		if _, err := db.Exec("DELETE FROM project_webhook_secret WHERE project_id = $1 AND expire_at <= $2", projectID, now); err != nil {
+			return nil, sdk.WrapError(err, "cannot delete expired webhook secrets for project %d", projectID)
+		}
+	} else if err := DeleteWebHookSecrets(db, projectID); err != nil {
+		return nil, err
+	}
+
+	value, err := sdk.GenerateHash()
+	if err != nil {
+		return nil, err
+	}
+	s := dbProjectWebHookSecret{ProjectWebHookSecret: sdk.ProjectWebHookSecret{
+		ProjectID: projectID,
+		Secret:    value,
+		Created:   now,
+	}}
+	if err := gorpmapping.InsertAndSign(ctx, db, &s); err != nil {
+		return nil, sdk.WrapError(err, "cannot insert webhook secret for project %d", projectID)
+	}
+	return &s.ProjectWebHookSecret, nil
+}
+
+// DeleteWebHookSecrets removes all the webhook secrets of given project, its webhooks don't require signed payloads anymore.
+func DeleteWebHookSecrets(db gorp.SqlExecutor, projectID int64) error {
+	_, err := db.Exec("DELETE FROM project_webhook_secret WHERE project_id = $1", projectID)
+	return sdk.WrapError(err, "cannot delete webhook secrets for project %d", projectID)
+}
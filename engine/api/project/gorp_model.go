@@ -16,6 +16,8 @@ import (
 
 type dbProject sdk.Project
 type dbProjectVariableAudit sdk.ProjectVariableAudit
+type dbProjectQuota sdk.ProjectQuota
+type dbProjectArtifactRetention sdk.ProjectArtifactRetention
 type dbProjectKey struct {
 	gorpmapping.SignedEntity
 	sdk.ProjectKey
@@ -28,6 +30,18 @@ func (e dbProjectKey) Canonical() gorpmapping.CanonicalForms {
 	}
 }
 
+type dbProjectWebHookSecret struct {
+	gorpmapping.SignedEntity
+	sdk.ProjectWebHookSecret
+}
+
+func (e dbProjectWebHookSecret) Canonical() gorpmapping.CanonicalForms {
+	var _ = []interface{}{e.ProjectID, e.ID}
+	return gorpmapping.CanonicalForms{
+		"{{print .ProjectID}}{{print .ID}}",
+	}
+}
+
 type dbLabel sdk.Label
 
 type dbProjectVariable struct {
@@ -90,6 +104,9 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(dbProjectKey{}, "project_key", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbLabel{}, "project_label", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectVariable{}, "project_variable", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbProjectQuota{}, "project_quota", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbProjectArtifactRetention{}, "project_artifact_retention", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbProjectWebHookSecret{}, "project_webhook_secret", true, "id"))
 }
 
 // PostGet is a db hook
@@ -41,27 +41,11 @@ func EncryptWithBuiltinKey(db gorp.SqlExecutor, projectID int64, name, content s
 		return "", sdk.WrapError(err, "Unable to load builtin key")
 	}
 
-	encryptedReader, err := shredder.GPGEncrypt([]byte(k.Public), strings.NewReader(content))
+	s, err := encryptContent(k.Public, content)
 	if err != nil {
-		return "", sdk.WrapError(err, "Unable to encrypt content")
-	}
-
-	encryptedContent, err := ioutil.ReadAll(encryptedReader)
-	if err != nil {
-		return "", sdk.WrapError(err, "Unable to ungzip content")
-	}
-
-	compressedContent := new(bytes.Buffer)
-	gzipWriter := gzip.NewWriter(compressedContent)
-	if _, err := gzipWriter.Write(encryptedContent); err != nil {
-		return "", sdk.WrapError(err, "Unable to write gzip content")
-	}
-	if err := gzipWriter.Close(); err != nil {
-		return "", sdk.WrapError(err, "Unable to gzip content")
+		return "", err
 	}
 
-	s := base64.StdEncoding.EncodeToString(compressedContent.Bytes())
-
 	token := make([]byte, 16)
 	n, err := io.ReadFull(rand.Reader, token)
 	if n != len(token) || err != nil {
@@ -93,11 +77,45 @@ func EncryptWithBuiltinKey(db gorp.SqlExecutor, projectID int64, name, content s
 	return bded.Token, nil
 }
 
-// DecryptWithBuiltinKey decrypt a base64-ed, gzipped, content
+// LoadBuiltinPublicKey returns the public part of the builtin gpg key of a project.
+func LoadBuiltinPublicKey(db gorp.SqlExecutor, projectID int64) (sdk.Key, error) {
+	k, err := loadBuiltinKey(db, projectID)
+	if err != nil {
+		return sdk.Key{}, sdk.WrapError(err, "Unable to load builtin key")
+	}
+	return sdk.Key{
+		Name:   k.Name,
+		Type:   k.Type,
+		Public: k.Public,
+		KeyID:  k.KeyID,
+	}, nil
+}
+
+// SealWithPublicKey returns an encrypt func that seals contents with the builtin gpg public key of another project.
+// Sealed contents are not stored, they are returned inline with the sealed prefix so they can only be decrypted by
+// the project that owns the key, on any CDS instance.
+func SealWithPublicKey(publicKey string) sdk.EncryptFunc {
+	return func(_ gorp.SqlExecutor, _ int64, _, content string) (string, error) {
+		s, err := encryptContent(publicKey, content)
+		if err != nil {
+			return "", sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to seal content with given public key"))
+		}
+		return sdk.SealedSecretPrefix + s, nil
+	}
+}
+
+// DecryptWithBuiltinKey decrypt a base64-ed, gzipped, content. Given token can also be a content sealed for the
+// project.
 func DecryptWithBuiltinKey(db gorp.SqlExecutor, projectID int64, token string) (string, error) {
-	dbed := dbEncryptedData{}
-	if err := db.SelectOne(&dbed, "select * from encrypted_data where token = $1", token); err != nil {
-		return "", sdk.WithStack(sdk.ErrProjectSecretDataUnknown)
+	var encryptedContent string
+	if strings.HasPrefix(token, sdk.SealedSecretPrefix) {
+		encryptedContent = strings.TrimPrefix(token, sdk.SealedSecretPrefix)
+	} else {
+		dbed := dbEncryptedData{}
+		if err := db.SelectOne(&dbed, "select * from encrypted_data where token = $1", token); err != nil {
+			return "", sdk.WithStack(sdk.ErrProjectSecretDataUnknown)
+		}
+		encryptedContent = string(dbed.EncyptedContent)
 	}
 
 	k, err := loadBuiltinKey(db, projectID)
@@ -105,7 +123,36 @@ func DecryptWithBuiltinKey(db gorp.SqlExecutor, projectID int64, token string) (
 		return "", sdk.WrapError(sdk.ErrProjectSecretDataUnknown, "Unable to load builtin key")
 	}
 
-	b, err := base64.StdEncoding.DecodeString(string(dbed.EncyptedContent))
+	return decryptContent(k.Private, encryptedContent)
+}
+
+// encryptContent encrypts a content with a gpg public key, compress it and encode it with base64.
+func encryptContent(publicKey, content string) (string, error) {
+	encryptedReader, err := shredder.GPGEncrypt([]byte(publicKey), strings.NewReader(content))
+	if err != nil {
+		return "", sdk.WrapError(err, "Unable to encrypt content")
+	}
+
+	encryptedContent, err := ioutil.ReadAll(encryptedReader)
+	if err != nil {
+		return "", sdk.WrapError(err, "Unable to ungzip content")
+	}
+
+	compressedContent := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(compressedContent)
+	if _, err := gzipWriter.Write(encryptedContent); err != nil {
+		return "", sdk.WrapError(err, "Unable to write gzip content")
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return "", sdk.WrapError(err, "Unable to gzip content")
+	}
+
+	return base64.StdEncoding.EncodeToString(compressedContent.Bytes()), nil
+}
+
+// decryptContent decodes, uncompress and decrypts a content with a gpg private key.
+func decryptContent(privateKey, content string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(content)
 	if err != nil {
 		return "", sdk.WrapError(err, "Unable to decode content")
 	}
@@ -124,7 +171,7 @@ func DecryptWithBuiltinKey(db gorp.SqlExecutor, projectID int64, token string) (
 		return "", sdk.WrapError(err, "Unable to ungzip content")
 	}
 
-	decryptedReader, err := shredder.GPGDecrypt([]byte(k.Private), []byte{}, uncompressedContent)
+	decryptedReader, err := shredder.GPGDecrypt([]byte(privateKey), []byte{}, uncompressedContent)
 	if err != nil {
 		return "", sdk.WrapError(err, "Unable to decrypt content")
 	}
@@ -1,6 +1,7 @@
 package project_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ovh/cds/engine/api/project"
@@ -32,3 +33,28 @@ func TestEncryptWithBuiltinKey(t *testing.T) {
 	t.Logf("%s => %s", content, encryptedContent2)
 	assert.Equal(t, encryptedContent, encryptedContent2)
 }
+
+func TestSealWithPublicKey(t *testing.T) {
+	db, cache, end := test.SetupPG(t)
+	defer end()
+	key1 := sdk.RandomString(10)
+	proj1 := assets.InsertTestProject(t, db, cache, key1, key1)
+	key2 := sdk.RandomString(10)
+	proj2 := assets.InsertTestProject(t, db, cache, key2, key2)
+
+	pub, err := project.LoadBuiltinPublicKey(db, proj2.ID)
+	test.NoError(t, err)
+	assert.Empty(t, pub.Private)
+
+	content := "This is my content"
+	sealedContent, err := project.SealWithPublicKey(pub.Public)(db, proj1.ID, "test", content)
+	test.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sealedContent, sdk.SealedSecretPrefix))
+
+	decryptedContent, err := project.DecryptWithBuiltinKey(db, proj2.ID, sealedContent)
+	test.NoError(t, err)
+	assert.Equal(t, content, decryptedContent)
+
+	_, err = project.DecryptWithBuiltinKey(db, proj1.ID, sealedContent)
+	assert.Error(t, err)
+}
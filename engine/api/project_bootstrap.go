@@ -0,0 +1,247 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// postProjectBootstrapHandler creates a project from a project template: the project with its groups and integrations
+// is created in one transaction, then the starter workflow is generated and imported.
+func (api *API) postProjectBootstrapHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		consumer := getAPIConsumer(ctx)
+
+		var req sdk.ProjectBootstrap
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.Template.IsValid(); err != nil {
+			return err
+		}
+
+		p := sdk.Project{
+			Key:         req.Key,
+			Name:        req.Name,
+			Description: req.Description,
+		}
+		for _, g := range req.Template.Groups {
+			p.ProjectGroups = append(p.ProjectGroups, sdk.GroupPermission{
+				Group:      sdk.Group{Name: g.Name},
+				Permission: g.Permission,
+			})
+		}
+
+		// Check that the workflow template exists before creating anything
+		var templateInstance exportentities.TemplateInstance
+		if req.Template.Workflow != nil {
+			templateInstance = exportentities.TemplateInstance{
+				Name:       req.Template.Workflow.Name,
+				From:       req.Template.Workflow.Template,
+				Parameters: req.Template.Workflow.Parameters,
+			}
+			groupName, templateSlug, _, err := templateInstance.ParseFrom()
+			if err != nil {
+				return err
+			}
+			grp, err := group.LoadByName(ctx, api.mustDB(), groupName)
+			if err != nil {
+				return sdk.NewErrorFrom(err, "could not find workflow template %s", templateInstance.From)
+			}
+			if _, err := workflowtemplate.LoadBySlugAndGroupID(ctx, api.mustDB(), templateSlug, grp.ID); err != nil {
+				return sdk.NewErrorFrom(err, "could not find workflow template %s", templateInstance.From)
+			}
+		}
+
+		integrations, placeholders, err := projectTemplateIntegrations(api.mustDB(), req.Template, &p)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		eventIntegrationIDs, err := api.createProject(ctx, tx, &p)
+		if err != nil {
+			return err
+		}
+
+		for i := range integrations {
+			pp := &integrations[i]
+			pp.ProjectID = p.ID
+			if err := integration.InsertIntegration(tx, pp); err != nil {
+				return sdk.WrapError(err, "cannot insert integration %s", pp.Name)
+			}
+			if pp.Model.Event {
+				eventIntegrationIDs = append(eventIntegrationIDs, pp.ID)
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		for _, id := range eventIntegrationIDs {
+			if err := event.ResetEventIntegration(ctx, api.mustDB(), id); err != nil {
+				log.Error(ctx, "postProjectBootstrapHandler> cannot connect to event broker of project integration %d: %v", id, err)
+			}
+		}
+
+		event.PublishAddProject(ctx, &p, consumer)
+		for _, pp := range integrations {
+			event.PublishAddProjectIntegration(ctx, &p, pp, consumer)
+		}
+
+		res := sdk.ProjectBootstrapResult{Placeholders: placeholders}
+
+		if req.Template.Workflow != nil {
+			wf, msgs, err := api.bootstrapProjectWorkflow(ctx, p.Key, templateInstance, req.Template.Workflow.RepositoryHook)
+			if err != nil {
+				return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "project %s was created but its workflow could not be generated: %s", p.Key, sdk.ExtractHTTPError(err, r.Header.Get("Accept-Language")).Message))
+			}
+			res.Workflow = wf.Name
+			res.Messages = translate(r, msgs)
+		}
+
+		proj, err := project.Load(api.mustDB(), p.Key,
+			project.LoadOptions.WithWorkflowNames,
+			project.LoadOptions.WithKeys,
+			project.LoadOptions.WithPermission,
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithVariables,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", p.Key)
+		}
+		proj.Permissions.Readable = true
+		proj.Permissions.Writable = true
+		res.Project = *proj
+
+		return service.WriteJSON(w, res, http.StatusCreated)
+	}
+}
+
+// projectTemplateIntegrations returns the integrations of a project template. Password values are replaced by
+// placeholders that reference project password variables, the variables are added to the project and their names
+// are returned.
+func projectTemplateIntegrations(db gorp.SqlExecutor, t sdk.ProjectTemplate, p *sdk.Project) ([]sdk.ProjectIntegration, []string, error) {
+	var integrations []sdk.ProjectIntegration
+	var placeholders []string
+	for _, ti := range t.Integrations {
+		model, err := integration.LoadModelByName(db, ti.Model)
+		if err != nil {
+			return nil, nil, sdk.NewErrorFrom(err, "could not find integration model %s", ti.Model)
+		}
+		if model.Public {
+			return nil, nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "integration model %s is public, it is already available on all projects", model.Name)
+		}
+
+		config := model.DefaultConfig.Clone()
+		for k := range ti.Config {
+			if _, ok := config[k]; !ok {
+				return nil, nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unknown config %s for integration model %s", k, model.Name)
+			}
+		}
+
+		keys := make([]string, 0, len(config))
+		for k := range config {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v := config[k]
+			if v.Type == sdk.IntegrationConfigTypePassword {
+				if ti.Config[k] != "" {
+					return nil, nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "config %s of integration %s is a password, it should not be given in the template", k, ti.Name)
+				}
+				name := sdk.ProjectTemplateSecretVariable(ti.Name, k)
+				p.Variables = append(p.Variables, sdk.Variable{Name: name, Type: sdk.SecretVariable})
+				placeholders = append(placeholders, name)
+				v.Value = sdk.ProjectTemplateSecretPlaceholder(name)
+			} else if value, ok := ti.Config[k]; ok {
+				v.Value = value
+			}
+			config[k] = v
+		}
+
+		integrations = append(integrations, sdk.ProjectIntegration{
+			Name:               ti.Name,
+			IntegrationModelID: model.ID,
+			Model:              model,
+			Config:             config,
+		})
+	}
+	return integrations, placeholders, nil
+}
+
+// bootstrapProjectWorkflow generates and imports the starter workflow of a new project from a workflow template. A
+// repository webhook is added on the root node of the workflow if required and not already defined.
+func (api *API) bootstrapProjectWorkflow(ctx context.Context, projectKey string, ti exportentities.TemplateInstance, repositoryHook bool) (*sdk.Workflow, []sdk.Message, error) {
+	consumer := getAPIConsumer(ctx)
+
+	p, err := project.Load(api.mustDB(), projectKey,
+		project.LoadOptions.WithGroups,
+		project.LoadOptions.WithApplications,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := exportentities.WorkflowComponents{Template: ti}
+	wti, err := workflowtemplate.CheckAndExecuteTemplate(ctx, api.mustDB(), *consumer, *p, &data,
+		workflowtemplate.TemplateRequestModifiers.DefaultKeys(*p))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	msgs, wf, _, err := workflow.Push(ctx, api.mustDB(), api.Cache, p, data, nil, consumer, project.DecryptWithBuiltinKey)
+	if err != nil {
+		return nil, msgs, sdk.WrapError(err, "cannot push generated workflow")
+	}
+	if err := workflowtemplate.UpdateTemplateInstanceWithWorkflow(ctx, api.mustDB(), *wf, *consumer, wti); err != nil {
+		return nil, msgs, err
+	}
+
+	if repositoryHook {
+		var found bool
+		for _, h := range wf.WorkflowData.GetHooks() {
+			if h.HookModelName == sdk.RepositoryWebHookModelName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			wf.WorkflowData.Node.Hooks = append(wf.WorkflowData.Node.Hooks, sdk.NodeHook{
+				Config:        sdk.RepositoryWebHookModel.DefaultConfig.Clone(),
+				HookModelName: sdk.RepositoryWebHookModel.Name,
+			})
+			if err := workflow.Update(ctx, api.mustDB(), api.Cache, *p, wf, workflow.UpdateOptions{}); err != nil {
+				return nil, msgs, sdk.WrapError(err, "cannot add repository webhook on workflow %s", wf.Name)
+			}
+		}
+	}
+
+	event.PublishWorkflowAdd(ctx, p.Key, *wf, consumer)
+
+	return wf, msgs, nil
+}
@@ -76,6 +76,9 @@ func (api *API) putProjectIntegrationHandler() service.Handler {
 		}
 
 		projectIntegration.ID = ppDB.ID
+		// An integration inherited from a group keeps its link, its config is not updated by the group anymore if
+		// it is overridden
+		projectIntegration.GroupIntegrationID = ppDB.GroupIntegrationID
 
 		for kkBody := range projectIntegration.Config {
 			c := projectIntegration.Config[kkBody]
@@ -91,6 +94,10 @@ func (api *API) putProjectIntegrationHandler() service.Handler {
 			projectIntegration.Config[kkBody] = c
 		}
 
+		if err := projectIntegration.Config.CheckConnectivity(); err != nil {
+			return err
+		}
+
 		tx, errT := api.mustDB().Begin()
 		if errT != nil {
 			return sdk.WrapError(errT, "putProjectIntegrationHandler> Cannot start transaction")
@@ -118,7 +125,7 @@ func (api *API) putProjectIntegrationHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrWrongRequest, "postProjectIntegrationHandler> model not found")
 		}
 
-		if err := integration.UpdateIntegration(tx, projectIntegration); err != nil {
+		if err := integration.UpdateIntegration(tx, api.Cache, projectIntegration); err != nil {
 			return sdk.WrapError(err, "Cannot update integration")
 		}
 
@@ -163,7 +170,7 @@ func (api *API) deleteProjectIntegrationHandler() service.Handler {
 				}
 
 				deletedIntegration = plat
-				if err := integration.DeleteIntegration(tx, plat); err != nil {
+				if err := integration.DeleteIntegration(tx, api.Cache, plat); err != nil {
 					return sdk.WrapError(err, "Cannot delete integration")
 				}
 				break
@@ -187,7 +194,7 @@ func (api *API) getProjectIntegrationsHandler() service.Handler {
 		vars := mux.Vars(r)
 		projectKey := vars[permProjectKey]
 
-		p, errP := project.Load(api.mustDB(), projectKey, project.LoadOptions.WithIntegrations)
+		p, errP := project.Load(api.mustDBRead(), projectKey, project.LoadOptions.WithIntegrations)
 		if errP != nil {
 			return sdk.WrapError(errP, "getProjectIntegrationsHandler> Cannot load project")
 		}
@@ -211,6 +218,7 @@ func (api *API) postProjectIntegrationHandler() service.Handler {
 		}
 
 		pp.ProjectID = p.ID
+		pp.GroupIntegrationID = nil
 		if pp.IntegrationModelID == 0 {
 			pp.IntegrationModelID = pp.Model.ID
 		}
@@ -231,6 +239,10 @@ func (api *API) postProjectIntegrationHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrWrongRequest, "postProjectIntegrationHandler> model not found")
 		}
 
+		if err := pp.Config.CheckConnectivity(); err != nil {
+			return err
+		}
+
 		for _, pprojPlat := range p.Integrations {
 			if pprojPlat.Name == pp.Name {
 				if pprojPlat.Model.Public {
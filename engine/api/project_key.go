@@ -1,7 +1,9 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"net/http"
 	"strings"
 
@@ -137,3 +139,54 @@ func (api *API) addKeyInProjectHandler() service.Handler {
 		return service.WriteJSON(w, newKey, http.StatusOK)
 	}
 }
+
+func (api *API) getProjectBuiltinPublicKeyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		k, err := project.LoadBuiltinPublicKey(api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, k, http.StatusOK)
+	}
+}
+
+// exportEncryptFunc returns the func used to encrypt the secrets of an export. Secrets are sealed for another project
+// if its key is given with the sealTo parameter, or if its builtin public key is given in the seal header.
+func (api *API) exportEncryptFunc(ctx context.Context, r *http.Request) (sdk.EncryptFunc, error) {
+	if sealTo := FormString(r, "sealTo"); sealTo != "" {
+		if err := api.checkProjectPermissions(ctx, sealTo, sdk.PermissionRead, nil); err != nil {
+			return nil, err
+		}
+		p, err := project.Load(api.mustDB(), sealTo)
+		if err != nil {
+			return nil, err
+		}
+		k, err := project.LoadBuiltinPublicKey(api.mustDB(), p.ID)
+		if err != nil {
+			return nil, err
+		}
+		return project.SealWithPublicKey(k.Public), nil
+	}
+
+	if h := r.Header.Get(sdk.ExportSealPublicKeyHeader); h != "" {
+		b, err := base64.StdEncoding.DecodeString(h)
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid seal public key, it should be base64 encoded")
+		}
+		if _, err := keys.GetOpenPGPEntity(bytes.NewReader(b)); err != nil {
+			return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid seal public key"))
+		}
+		return project.SealWithPublicKey(string(b)), nil
+	}
+
+	return project.EncryptWithBuiltinKey, nil
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/action"
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/lint"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/workermodel"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) postProjectLintHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		var req sdk.LintRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if len(req.Files) == 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "no file to lint")
+		}
+
+		proj, err := project.Load(api.mustDB(), key,
+			project.LoadOptions.WithVariables,
+			project.LoadOptions.WithPipelineNames,
+			project.LoadOptions.WithApplicationNames,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithGroups,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %s", key)
+		}
+
+		p := lint.Project{
+			WorkerModels: make(map[string]bool),
+			Actions:      make(map[string]bool),
+		}
+		for _, v := range proj.Variables {
+			p.Variables = append(p.Variables, v.Name)
+		}
+		for _, pip := range proj.PipelineNames {
+			p.Pipelines = append(p.Pipelines, pip.Name)
+		}
+		for _, app := range proj.ApplicationNames {
+			p.Applications = append(p.Applications, app.Name)
+			vs, err := application.LoadAllVariables(api.mustDB(), app.ID)
+			if err != nil {
+				return err
+			}
+			for _, v := range vs {
+				p.ApplicationVariables = append(p.ApplicationVariables, v.Name)
+			}
+		}
+		for _, env := range proj.Environments {
+			p.Environments = append(p.Environments, env.Name)
+			for _, v := range env.Variables {
+				p.EnvironmentVariables = append(p.EnvironmentVariables, v.Name)
+			}
+		}
+		for _, i := range proj.Integrations {
+			p.Integrations = append(p.Integrations, i.Name)
+		}
+
+		vcsServers, err := repositoriesmanager.LoadAllForProject(api.mustDB(), proj.Key)
+		if err != nil {
+			return err
+		}
+		for _, s := range vcsServers {
+			p.RepositoriesManagers = append(p.RepositoriesManagers, s.Name)
+		}
+
+		groupIDs := make([]int64, len(proj.ProjectGroups))
+		for i := range proj.ProjectGroups {
+			groupIDs[i] = proj.ProjectGroups[i].Group.ID
+		}
+		groupIDs = append(groupIDs, group.SharedInfraGroup.ID)
+
+		as, err := action.LoadAllTypeBuiltInOrPluginOrDefaultForGroupIDs(ctx, api.mustDB(), groupIDs, action.LoadOptions.WithGroup)
+		if err != nil {
+			return err
+		}
+		for _, a := range as {
+			path := a.Name
+			if a.Group != nil && a.Group.Name != sdk.SharedInfraGroupName {
+				path = a.Group.Name + "/" + a.Name
+			}
+			p.Actions[path] = a.Deprecated
+		}
+
+		models, err := workermodel.LoadAllByGroupIDs(ctx, api.mustDB(), groupIDs, nil, workermodel.LoadOptions.Default)
+		if err != nil {
+			return err
+		}
+		for _, m := range models {
+			groupName := sdk.SharedInfraGroupName
+			if m.Group != nil {
+				groupName = m.Group.Name
+			}
+			p.WorkerModels[m.GetPath(groupName)] = m.IsDeprecated
+		}
+
+		files := make(map[string][]byte, len(req.Files))
+		for name, content := range req.Files {
+			files[name] = []byte(content)
+		}
+
+		return service.WriteJSON(w, lint.Files(p, files), http.StatusOK)
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// projectQuotaEventTTL is the delay in seconds during which an event is not sent again for the same level of a quota.
+const projectQuotaEventTTL = 60 * 60
+
+func (api *API) getProjectQuotaHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(api.mustDBRead(), key)
+		if err != nil {
+			return err
+		}
+
+		q, err := project.LoadQuota(ctx, api.mustDBRead(), p.ID)
+		if err != nil {
+			return err
+		}
+		usage, err := project.LoadQuotaUsages(api.mustDBRead(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, sdk.ProjectQuotaStatus{Quota: q, Usage: usage}, http.StatusOK)
+	}
+}
+
+func (api *API) putProjectQuotaHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if !isAdmin(ctx) {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		var q sdk.ProjectQuota
+		if err := service.UnmarshalBody(r, &q); err != nil {
+			return err
+		}
+		if err := q.IsValid(); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		oldQuota, err := project.LoadQuota(ctx, api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		q.ProjectID = p.ID
+		if err := project.UpsertQuota(tx, &q); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		event.PublishUpdateProjectQuota(ctx, p, q, oldQuota, getAPIConsumer(ctx))
+
+		return service.WriteJSON(w, q, http.StatusOK)
+	}
+}
+
+// checkProjectQuota checks the usage of a quota of given project increased by given value. An event is sent when the
+// usage reaches the warning threshold or exceeds the limit of the quota. If the limit is exceeded and the quota is
+// enforced, an error is returned.
+func (api *API) checkProjectQuota(ctx context.Context, p sdk.Project, name string, increment int64) error {
+	q, err := project.LoadQuota(ctx, api.mustDB(), p.ID)
+	if err != nil {
+		return err
+	}
+	if q.Limit(name) <= 0 {
+		return nil
+	}
+
+	usage, err := project.LoadQuotaUsage(api.mustDB(), p.ID, name)
+	if err != nil {
+		return err
+	}
+	usage += increment
+
+	level := q.Level(name, usage)
+	if level == "" {
+		return nil
+	}
+
+	cacheKey := cache.Key("api:project:quota", p.Key, name, level)
+	var sent bool
+	found, err := api.Cache.Get(cacheKey, &sent)
+	if err != nil {
+		log.Error(ctx, "checkProjectQuota> cannot get from cache %s: %v", cacheKey, err)
+	}
+	if !found {
+		if err := api.Cache.SetWithTTL(cacheKey, true, projectQuotaEventTTL); err != nil {
+			log.Error(ctx, "checkProjectQuota> cannot set in cache %s: %v", cacheKey, err)
+		}
+		integrations, err := integration.LoadIntegrationsByProjectID(api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+		var eventIntegrations []sdk.ProjectIntegration
+		for _, i := range integrations {
+			if i.Model.Event && !i.Model.Public {
+				eventIntegrations = append(eventIntegrations, i)
+			}
+		}
+		event.PublishProjectQuota(ctx, p.Key, q, name, level, usage, eventIntegrations)
+	}
+
+	if level == sdk.ProjectQuotaLevelExceeded && q.HardStop {
+		return sdk.NewErrorFrom(sdk.ErrProjectQuotaExceeded, "quota %s of project %s exceeded: %d for a limit of %d", name, p.Key, usage, q.Limit(name))
+	}
+	return nil
+}
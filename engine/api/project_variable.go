@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/event"
@@ -237,3 +238,65 @@ func (api *API) getVariableAuditInProjectHandler() service.Handler {
 		return service.WriteJSON(w, audits, http.StatusOK)
 	}
 }
+
+func (api *API) postVariablesBatchInProjectHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		var batch sdk.VariableBatch
+		if err := service.UnmarshalBody(r, &batch); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		diff, err := applyVariableBatch(tx, variableBatchStore{
+			load: func(db gorp.SqlExecutor, name string) (*sdk.Variable, error) {
+				v, err := project.LoadVariable(db, p.ID, name)
+				if err != nil {
+					return nil, err
+				}
+				return project.LoadVariableWithDecryption(db, p.ID, v.ID, name)
+			},
+			insert: func(db gorp.SqlExecutor, v *sdk.Variable) error {
+				return project.InsertVariable(db, p.ID, v, getAPIConsumer(ctx))
+			},
+			update: func(db gorp.SqlExecutor, v *sdk.Variable, before *sdk.Variable) error {
+				return project.UpdateVariable(db, p.ID, v, before, getAPIConsumer(ctx))
+			},
+			delete: func(db gorp.SqlExecutor, v *sdk.Variable) error {
+				return project.DeleteVariable(db, p.ID, v, getAPIConsumer(ctx))
+			},
+		}, batch)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		for _, v := range diff.Deleted {
+			event.PublishDeleteProjectVariable(ctx, p, v, getAPIConsumer(ctx))
+		}
+		for _, c := range diff.Updated {
+			event.PublishUpdateProjectVariable(ctx, p, c.After, c.Before, getAPIConsumer(ctx))
+		}
+		for _, v := range diff.Created {
+			event.PublishAddProjectVariable(ctx, p, v, getAPIConsumer(ctx))
+		}
+
+		diff.HidePasswords()
+		return service.WriteJSON(w, diff, http.StatusOK)
+	}
+}
@@ -95,3 +95,68 @@ func Test_postEncryptVariableHandler(t *testing.T) {
 
 	assert.Equal(t, "bar", decrypt)
 }
+
+func Test_postVariablesBatchInProjectHandler(t *testing.T) {
+	api, db, router, end := newTestAPI(t)
+	defer end()
+
+	u, pass := assets.InsertAdminUser(t, api.mustDB())
+
+	pkey := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, api.Cache, pkey, pkey)
+
+	for _, v := range []sdk.Variable{
+		{Name: "foo", Type: sdk.StringVariable, Value: "bar"},
+		{Name: "token", Type: sdk.SecretVariable, Value: "my-token"},
+		{Name: "old", Type: sdk.StringVariable, Value: "old"},
+	} {
+		test.NoError(t, project.InsertVariable(db, proj.ID, &v, u))
+	}
+
+	uri := router.GetRoute("POST", api.postVariablesBatchInProjectHandler, map[string]string{"permProjectKey": proj.Key})
+	req := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, sdk.VariableBatch{
+		Create: []sdk.Variable{{Name: "new", Type: sdk.SecretVariable, Value: "new-secret"}},
+		Update: []sdk.Variable{
+			{Name: "foo", Type: sdk.StringVariable, Value: "baz"},
+			{Name: "token", Type: sdk.SecretVariable, Value: sdk.PasswordPlaceholder},
+		},
+		Delete: []string{"old"},
+	})
+	rec := httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	assert.Equal(t, 200, rec.Code)
+
+	var diff sdk.VariableBatchDiff
+	test.NoError(t, json.Unmarshal(rec.Body.Bytes(), &diff))
+	assert.Len(t, diff.Created, 1)
+	assert.Equal(t, "new", diff.Created[0].Name)
+	assert.Equal(t, sdk.PasswordPlaceholder, diff.Created[0].Value)
+	assert.Len(t, diff.Updated, 1)
+	assert.Equal(t, "bar", diff.Updated[0].Before.Value)
+	assert.Equal(t, "baz", diff.Updated[0].After.Value)
+	assert.Len(t, diff.Deleted, 1)
+	assert.Equal(t, "old", diff.Deleted[0].Name)
+
+	token, err := project.LoadVariable(db, proj.ID, "token")
+	test.NoError(t, err)
+	token, err = project.LoadVariableWithDecryption(db, proj.ID, token.ID, "token")
+	test.NoError(t, err)
+	assert.Equal(t, "my-token", token.Value)
+
+	// A batch with an error is not applied at all
+	req = assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, sdk.VariableBatch{
+		Create: []sdk.Variable{{Name: "foo", Type: sdk.StringVariable, Value: "foo"}},
+		Delete: []string{"new"},
+	})
+	rec = httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	assert.Equal(t, 409, rec.Code)
+
+	vs, err := project.LoadAllVariables(db, proj.ID)
+	test.NoError(t, err)
+	names := make([]string, 0, len(vs))
+	for _, v := range vs {
+		names = append(names, v.Name)
+	}
+	assert.ElementsMatch(t, []string{"foo", "new", "token"}, names)
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// requestWebHookSecretOverlap returns the overlap given in the request for the rotation of a webhook secret, the
+// default one if not set.
+func requestWebHookSecretOverlap(r *http.Request) (time.Duration, error) {
+	s := FormString(r, "overlap")
+	if s == "" {
+		return sdk.DefaultWebHookSecretOverlap, nil
+	}
+	overlap, err := time.ParseDuration(s)
+	if err != nil || overlap < 0 {
+		return 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given overlap %q", s)
+	}
+	return overlap, nil
+}
+
+// getProjectWebHookSecretsHandler returns the webhook secrets of a project that are not expired. Their values are
+// only returned to the services.
+func (api *API) getProjectWebHookSecretsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		clearSecret := FormBool(r, "clearSecret")
+
+		if clearSecret && !isService(ctx) {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		var secrets []sdk.ProjectWebHookSecret
+		if clearSecret {
+			secrets, err = project.LoadWebHookSecretsWithDecryption(ctx, api.mustDB(), p.ID)
+		} else {
+			secrets, err = project.LoadWebHookSecrets(ctx, api.mustDB(), p.ID)
+		}
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, secrets, http.StatusOK)
+	}
+}
+
+// postProjectWebHookSecretRotateHandler generates a new webhook secret for a project, the previous ones are still
+// accepted during the given overlap. The value of the new secret is only returned by this handler.
+func (api *API) postProjectWebHookSecretRotateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		overlap, err := requestWebHookSecretOverlap(r)
+		if err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		s, err := project.RotateWebHookSecret(ctx, tx, p.ID, overlap)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+		log.Info(ctx, "postProjectWebHookSecretRotateHandler> webhook secret of project %s rotated by %s with an overlap of %s", p.Key, getAPIConsumer(ctx).GetUsername(), overlap)
+
+		return service.WriteJSON(w, s, http.StatusOK)
+	}
+}
+
+// deleteProjectWebHookSecretsHandler removes all the webhook secrets of a project, its webhooks don't require signed
+// payloads anymore.
+func (api *API) deleteProjectWebHookSecretsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		if err := project.DeleteWebHookSecrets(api.mustDB(), p.ID); err != nil {
+			return err
+		}
+		log.Info(ctx, "deleteProjectWebHookSecretsHandler> webhook secrets of project %s deleted by %s", p.Key, getAPIConsumer(ctx).GetUsername())
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
@@ -0,0 +1,106 @@
+package purge
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// deleteExpiredArtifacts deletes the artifacts that exceeded the retention of their class for all the projects
+// with an artifact retention policy. Pinned artifacts are never deleted.
+func deleteExpiredArtifacts(ctx context.Context, db gorp.SqlExecutor, sharedStorage objectstore.Driver) error {
+	policies, err := project.LoadArtifactRetentions(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range policies {
+		if err := deleteProjectExpiredArtifacts(ctx, db, sharedStorage, p); err != nil {
+			log.Error(ctx, "deleteExpiredArtifacts> unable to delete expired artifacts of project %d: %v", p.ProjectID, err)
+		}
+	}
+	return nil
+}
+
+func deleteProjectExpiredArtifacts(ctx context.Context, db gorp.SqlExecutor, sharedStorage objectstore.Driver, p sdk.ProjectArtifactRetention) error {
+	// The shortest retention of the policy gives the artifacts that could be expired
+	var minDays int64
+	for _, c := range sdk.ArtifactRetentionClasses {
+		if d := p.Days(c); d > 0 && (minDays == 0 || d < minDays) {
+			minDays = d
+		}
+	}
+	if minDays == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	arts, err := workflow.LoadUnpinnedArtifactsCreatedBefore(db, p.ProjectID, now.Add(-time.Duration(minDays)*24*time.Hour), 1000)
+	if err != nil {
+		return err
+	}
+	if len(arts) == 0 {
+		return nil
+	}
+
+	proj, err := project.LoadByID(db, p.ProjectID)
+	if err != nil {
+		return sdk.WrapError(err, "cannot load project %d", p.ProjectID)
+	}
+
+	classes := make(map[int64]string)
+	for i := range arts {
+		art := &arts[i]
+		class, ok := classes[art.WorkflowID]
+		if !ok {
+			wr, err := workflow.LoadRunByID(db, art.WorkflowID, workflow.LoadRunOptions{DisableDetailledNodeRun: true, WithDeleted: true})
+			if err != nil {
+				log.Error(ctx, "deleteProjectExpiredArtifacts> cannot load workflow run %d: %v", art.WorkflowID, err)
+				continue
+			}
+			class = wr.ArtifactRetentionClass()
+			classes[art.WorkflowID] = class
+		}
+
+		days := p.Days(class)
+		if days == 0 || art.Created.After(now.Add(-time.Duration(days)*24*time.Hour)) {
+			continue
+		}
+
+		integrationName := sdk.DefaultStorageIntegrationName
+		if art.ProjectIntegrationID != nil && *art.ProjectIntegrationID > 0 {
+			projectIntegration, err := integration.LoadProjectIntegrationByID(db, *art.ProjectIntegrationID)
+			if err != nil {
+				log.Error(ctx, "deleteProjectExpiredArtifacts> cannot load project integration %s/%d: %v", proj.Key, *art.ProjectIntegrationID, err)
+				continue
+			}
+			integrationName = projectIntegration.Name
+		}
+
+		storageDriver, err := objectstore.GetDriver(ctx, db, sharedStorage, proj.Key, integrationName)
+		if err != nil {
+			log.Error(ctx, "deleteProjectExpiredArtifacts> error while getting driver prj:%v integrationName:%v err:%v", proj.Key, integrationName, err)
+			continue
+		}
+
+		log.Debug("deleteProjectExpiredArtifacts> deleting %s artifact %+v", class, art)
+		if err := storageDriver.Delete(ctx, art); err != nil {
+			log.Error(ctx, "deleteProjectExpiredArtifacts> error while deleting artifact prj:%v name:%v err:%v", proj.Key, art.GetPath(), err)
+			continue
+		}
+		if err := workflow.DeleteArtifact(db, art.ID); err != nil {
+			log.Error(ctx, "deleteProjectExpiredArtifacts> %v", err)
+			continue
+		}
+		time.Sleep(10 * time.Millisecond) // avoid DDOS the database
+	}
+	return nil
+}
@@ -40,6 +40,11 @@ func Initialize(ctx context.Context, store cache.Store, DBFunc func() *gorp.DbMa
 			if err := workflows(ctx, DBFunc(), store, workflowRunsMarkToDelete); err != nil {
 				log.Warning(ctx, "purge> Error on workflows : %v", err)
 			}
+
+			log.Debug("purge> Deleting all expired artifacts....")
+			if err := deleteExpiredArtifacts(ctx, DBFunc(), sharedStorage); err != nil {
+				log.Warning(ctx, "purge> Error on deleteExpiredArtifacts : %v", err)
+			}
 		}
 	}
 }
@@ -148,7 +153,17 @@ func workflows(ctx context.Context, db *gorp.DbMap, store cache.Store, workflowR
 // deleteWorkflowRunsHistory is useful to delete all the workflow run marked with to delete flag in db
 func deleteWorkflowRunsHistory(ctx context.Context, db gorp.SqlExecutor, store cache.Store, sharedStorage objectstore.Driver, workflowRunsDeleted *stats.Int64Measure) error {
 	var workflowRunIDs []int64
-	if _, err := db.Select(&workflowRunIDs, "SELECT id FROM workflow_run WHERE to_delete = true ORDER BY id ASC LIMIT 2000"); err != nil {
+	// Workflow runs with pinned artifacts are kept until their artifacts are unpinned
+	query := `
+		SELECT id FROM workflow_run
+		WHERE to_delete = true
+		AND NOT EXISTS (
+			SELECT 1 FROM workflow_node_run_artifacts
+			WHERE workflow_node_run_artifacts.workflow_run_id = workflow_run.id
+			AND workflow_node_run_artifacts.pinned = true
+		)
+		ORDER BY id ASC LIMIT 2000`
+	if _, err := db.Select(&workflowRunIDs, query); err != nil {
 		return err
 	}
 
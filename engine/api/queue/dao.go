@@ -0,0 +1,125 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadProjectStates returns for given project ids the project key, the groups with read, write and execute permission
+// on the project and its number of building jobs
+func LoadProjectStates(ctx context.Context, db gorp.SqlExecutor, projectIDs []int64) (map[int64]ProjectState, error) {
+	_, end := observability.Span(ctx, "queue.LoadProjectStates")
+	defer end()
+
+	query := `
+	SELECT project.id, project.projectkey,
+		ARRAY(
+			SELECT "group".name FROM project_group
+			JOIN "group" ON "group".id = project_group.group_id
+			WHERE project_group.project_id = project.id AND project_group.role = $3
+		) AS groups,
+		COALESCE(SUM(CASE WHEN workflow_node_run_job.status = $2 THEN 1 ELSE 0 END), 0) AS building
+	FROM project
+	LEFT JOIN workflow_node_run_job ON workflow_node_run_job.project_id = project.id
+	WHERE project.id = ANY(string_to_array($1, ',')::int[])
+	GROUP BY project.id, project.projectkey`
+
+	rows, err := db.Query(query, gorpmapping.IDsToQueryString(projectIDs), sdk.StatusBuilding, sdk.PermissionReadWriteExecute)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load project states")
+	}
+	defer rows.Close() // nolint
+
+	states := make(map[int64]ProjectState, len(projectIDs))
+	for rows.Next() {
+		var id int64
+		var state ProjectState
+		var groups pq.StringArray
+		if err := rows.Scan(&id, &state.Key, &groups, &state.Building); err != nil {
+			return nil, sdk.WrapError(err, "cannot scan row")
+		}
+		state.Groups = groups
+		states[id] = state
+	}
+	return states, nil
+}
+
+// ScheduleJobs loads the state of the projects owning given jobs and returns the jobs in scheduling order
+func ScheduleJobs(ctx context.Context, db gorp.SqlExecutor, cfg Configuration, jobs []sdk.WorkflowNodeJobRun) ([]sdk.WorkflowNodeJobRun, error) {
+	if len(jobs) == 0 {
+		return jobs, nil
+	}
+
+	projectIDs := make([]int64, 0, len(jobs))
+	seen := make(map[int64]struct{}, len(jobs))
+	for _, j := range jobs {
+		if _, has := seen[j.ProjectID]; has {
+			continue
+		}
+		seen[j.ProjectID] = struct{}{}
+		projectIDs = append(projectIDs, j.ProjectID)
+	}
+
+	states, err := LoadProjectStates(ctx, db, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return Schedule(cfg, jobs, states), nil
+}
+
+// durationHistory is the number of last node runs of a workflow used to estimate the duration of its jobs
+const durationHistory = 20
+
+// LoadJobDurations returns the durations of the successful jobs of the last node runs of the workflows that own
+// given node runs.
+func LoadJobDurations(ctx context.Context, db gorp.SqlExecutor, nodeRunIDs []int64) (JobDurations, error) {
+	_, end := observability.Span(ctx, "queue.LoadJobDurations")
+	defer end()
+
+	var durations JobDurations
+	if len(nodeRunIDs) == 0 {
+		return durations, nil
+	}
+
+	query := `
+	SELECT (run_job->'job'->>'pipeline_stage_id')::BIGINT, (run_job->'job'->>'pipeline_action_id')::BIGINT,
+		EXTRACT(EPOCH FROM (run_job->>'done')::TIMESTAMP WITH TIME ZONE - (run_job->>'start')::TIMESTAMP WITH TIME ZONE)
+	FROM (
+		SELECT DISTINCT workflow_id FROM workflow_node_run WHERE id = ANY(string_to_array($1, ',')::BIGINT[])
+	) workflows
+	JOIN LATERAL (
+		SELECT stages FROM workflow_node_run
+		WHERE workflow_node_run.workflow_id = workflows.workflow_id AND workflow_node_run.stages IS NOT NULL
+		ORDER BY workflow_node_run.id DESC
+		LIMIT $3
+	) node_runs ON true,
+	jsonb_array_elements(node_runs.stages) stage,
+	jsonb_array_elements(stage->'run_jobs') run_job
+	WHERE run_job->>'status' = $2`
+
+	rows, err := db.Query(query, gorpmapping.IDsToQueryString(nodeRunIDs), sdk.StatusSuccess, durationHistory)
+	if err != nil {
+		return durations, sdk.WrapError(err, "cannot load job durations")
+	}
+	defer rows.Close() // nolint
+
+	for rows.Next() {
+		var stageID, jobID int64
+		var seconds float64
+		if err := rows.Scan(&stageID, &jobID, &seconds); err != nil {
+			return durations, sdk.WrapError(err, "cannot scan row")
+		}
+		if seconds > 0 {
+			durations.Add(stageID, jobID, time.Duration(seconds*float64(time.Second)))
+		}
+	}
+	return durations, nil
+}
@@ -0,0 +1,99 @@
+package queue
+
+import (
+	"container/heap"
+	"sort"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// JobDurations are the durations of the last successful runs of jobs, by pipeline job id and by pipeline stage id.
+type JobDurations struct {
+	Jobs   map[int64][]time.Duration
+	Stages map[int64][]time.Duration
+}
+
+// Add records the duration of a run of given job.
+func (d *JobDurations) Add(stageID, jobID int64, duration time.Duration) {
+	if d.Jobs == nil {
+		d.Jobs = make(map[int64][]time.Duration)
+	}
+	if d.Stages == nil {
+		d.Stages = make(map[int64][]time.Duration)
+	}
+	d.Jobs[jobID] = append(d.Jobs[jobID], duration)
+	d.Stages[stageID] = append(d.Stages[stageID], duration)
+}
+
+// Estimate returns the median duration of the last runs of given job. If the job never ran, the median duration of
+// the jobs of its stage is used. Returns 0 if there is no history.
+func (d JobDurations) Estimate(j sdk.Job) time.Duration {
+	if ds := d.Jobs[j.PipelineActionID]; len(ds) > 0 {
+		return median(ds)
+	}
+	return median(d.Stages[j.PipelineStageID])
+}
+
+func median(ds []time.Duration) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(ds))
+	copy(sorted, ds)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	m := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[m-1] + sorted[m]) / 2
+	}
+	return sorted[m]
+}
+
+// slots is a min heap of the times at which building jobs release their worker.
+type slots []time.Time
+
+func (s slots) Len() int            { return len(s) }
+func (s slots) Less(i, j int) bool  { return s[i].Before(s[j]) }
+func (s slots) Swap(i, j int)       { s[i], s[j] = s[j], s[i] }
+func (s *slots) Push(x interface{}) { *s = append(*s, x.(time.Time)) }
+
+func (s *slots) Pop() interface{} {
+	old := *s
+	x := old[len(old)-1]
+	*s = old[:len(old)-1]
+	return x
+}
+
+// Estimate sets the position, the estimated duration, start and end of given waiting jobs, given in scheduling order.
+// The capacity of the queue is considered to be the number of building jobs, at least one: each waiting job starts
+// when the first building job is expected to end, then holds its slot for its own estimated duration.
+func Estimate(waiting, building []sdk.WorkflowNodeJobRun, durations JobDurations, now time.Time) []sdk.WorkflowNodeJobRun {
+	s := make(slots, 0, len(building)+1)
+	for _, j := range building {
+		end := j.Start.Add(durations.Estimate(j.Job.Job))
+		if end.Before(now) {
+			end = now
+		}
+		s = append(s, end)
+	}
+	if len(s) == 0 {
+		s = append(s, now)
+	}
+	heap.Init(&s)
+
+	res := make([]sdk.WorkflowNodeJobRun, len(waiting))
+	for i, j := range waiting {
+		d := durations.Estimate(j.Job.Job)
+		start := heap.Pop(&s).(time.Time)
+		j.QueuePosition = i + 1
+		j.EstimatedDuration = int64(d.Seconds())
+		j.EstimatedStart = &start
+		if d > 0 {
+			done := start.Add(d)
+			j.EstimatedDone = &done
+		}
+		heap.Push(&s, start.Add(d))
+		res[i] = j
+	}
+	return res
+}
@@ -0,0 +1,67 @@
+package queue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/queue"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestJobDurationsEstimate(t *testing.T) {
+	var d queue.JobDurations
+	d.Add(1, 10, 1*time.Minute)
+	d.Add(1, 10, 3*time.Minute)
+	d.Add(1, 10, 10*time.Minute)
+	d.Add(1, 11, 2*time.Minute)
+
+	assert.Equal(t, 3*time.Minute, d.Estimate(sdk.Job{PipelineStageID: 1, PipelineActionID: 10}))
+	assert.Equal(t, 2*time.Minute, d.Estimate(sdk.Job{PipelineStageID: 1, PipelineActionID: 11}))
+	// A new job of the stage gets the median duration of the stage
+	assert.Equal(t, 150*time.Second, d.Estimate(sdk.Job{PipelineStageID: 1, PipelineActionID: 12}))
+	assert.Equal(t, time.Duration(0), d.Estimate(sdk.Job{PipelineStageID: 2, PipelineActionID: 20}))
+}
+
+func TestEstimate(t *testing.T) {
+	now := time.Now()
+	var d queue.JobDurations
+	d.Add(1, 10, 10*time.Minute)
+	d.Add(1, 11, 2*time.Minute)
+
+	job := func(id, actionID int64) sdk.WorkflowNodeJobRun {
+		return sdk.WorkflowNodeJobRun{ID: id, Job: sdk.ExecutedJob{Job: sdk.Job{PipelineStageID: 1, PipelineActionID: actionID}}}
+	}
+
+	// Without building job, jobs are run one after the other
+	res := queue.Estimate([]sdk.WorkflowNodeJobRun{job(1, 10), job(2, 11), job(3, 20)}, nil, queue.JobDurations{}, now)
+	require.Len(t, res, 3)
+	assert.Equal(t, 1, res[0].QueuePosition)
+	assert.Equal(t, now, *res[0].EstimatedStart)
+	assert.Nil(t, res[0].EstimatedDone)
+
+	res = queue.Estimate([]sdk.WorkflowNodeJobRun{job(1, 10), job(2, 11), job(3, 11)}, nil, d, now)
+	require.Len(t, res, 3)
+	assert.Equal(t, []int{1, 2, 3}, []int{res[0].QueuePosition, res[1].QueuePosition, res[2].QueuePosition})
+	assert.Equal(t, int64(600), res[0].EstimatedDuration)
+	assert.Equal(t, now.Add(10*time.Minute), *res[0].EstimatedDone)
+	assert.Equal(t, now.Add(10*time.Minute), *res[1].EstimatedStart)
+	assert.Equal(t, now.Add(14*time.Minute), *res[2].EstimatedDone)
+
+	// Two building jobs give two slots, the first waiting job starts when the first building job ends
+	building := []sdk.WorkflowNodeJobRun{job(4, 10), job(5, 11)}
+	building[0].Start = now.Add(-5 * time.Minute)
+	building[1].Start = now.Add(-1 * time.Minute)
+	res = queue.Estimate([]sdk.WorkflowNodeJobRun{job(1, 10), job(2, 11)}, building, d, now)
+	require.Len(t, res, 2)
+	assert.Equal(t, now.Add(1*time.Minute), *res[0].EstimatedStart)
+	assert.Equal(t, now.Add(5*time.Minute), *res[1].EstimatedStart)
+	assert.Equal(t, now.Add(7*time.Minute), *res[1].EstimatedDone)
+
+	// A building job over its estimated duration is expected to end now
+	building[1].Start = now.Add(-1 * time.Hour)
+	res = queue.Estimate([]sdk.WorkflowNodeJobRun{job(1, 10)}, building, d, now)
+	assert.Equal(t, now, *res[0].EstimatedStart)
+}
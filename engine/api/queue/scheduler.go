@@ -0,0 +1,92 @@
+package queue
+
+import (
+	"sort"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Configuration of the queue fair scheduler
+type Configuration struct {
+	Enabled                     bool           `toml:"enabled" default:"false" comment:"Enable the weighted fair scheduler on the job queue. If disabled, the queue is consumed in FIFO order" json:"enabled"`
+	DefaultWeight               int            `toml:"defaultWeight" default:"1" comment:"Weight of a project without specific weight" json:"defaultWeight"`
+	ProjectWeights              map[string]int `toml:"projectWeights" comment:"Weights by project key. A project with a weight of 2 gets twice as many jobs scheduled as a project with a weight of 1" json:"projectWeights"`
+	GroupWeights                map[string]int `toml:"groupWeights" comment:"Weights by group name, used for the projects without specific weight. A project gets the highest weight of the groups with read, write and execute permission on it" json:"groupWeights"`
+	MaxConcurrentJobsPerProject int            `toml:"maxConcurrentJobsPerProject" default:"0" comment:"Maximum number of building jobs for a project, waiting jobs over this limit are hidden from the queue. 0 means no limit" json:"maxConcurrentJobsPerProject"`
+}
+
+// ProjectState is the state of a project used by the scheduler
+type ProjectState struct {
+	Key      string
+	Groups   []string
+	Building int
+}
+
+func (c Configuration) weight(state ProjectState) int {
+	if w, has := c.ProjectWeights[state.Key]; has && w > 0 {
+		return w
+	}
+	var groupWeight int
+	for _, g := range state.Groups {
+		if w := c.GroupWeights[g]; w > groupWeight {
+			groupWeight = w
+		}
+	}
+	if groupWeight > 0 {
+		return groupWeight
+	}
+	if c.DefaultWeight > 0 {
+		return c.DefaultWeight
+	}
+	return 1
+}
+
+type scheduledJob struct {
+	job sdk.WorkflowNodeJobRun
+	tag float64
+}
+
+// Schedule sorts the jobs with a weighted fair queuing algorithm across projects, weighted by project or by group.
+// Each job gets a virtual tag computed from its position in the FIFO queue of its project
+// and the number of jobs already building for this project, divided by the project weight.
+// Jobs are returned ordered by priority class then by tag, so a project with thousands of waiting jobs can't starve the
+// others, and critical jobs are always scheduled before default and batch ones.
+func Schedule(cfg Configuration, jobs []sdk.WorkflowNodeJobRun, states map[int64]ProjectState) []sdk.WorkflowNodeJobRun {
+	fifo := make([]sdk.WorkflowNodeJobRun, len(jobs))
+	copy(fifo, jobs)
+	sort.SliceStable(fifo, func(i, j int) bool {
+		return fifo[i].Queued.Before(fifo[j].Queued)
+	})
+
+	positions := make(map[int64]int, len(states))
+	scheduled := make([]scheduledJob, 0, len(fifo))
+	for _, j := range fifo {
+		state := states[j.ProjectID]
+		position := positions[j.ProjectID]
+		positions[j.ProjectID] = position + 1
+
+		if cfg.MaxConcurrentJobsPerProject > 0 && state.Building+position >= cfg.MaxConcurrentJobsPerProject {
+			continue
+		}
+
+		scheduled = append(scheduled, scheduledJob{
+			job: j,
+			tag: float64(state.Building+position+1) / float64(cfg.weight(state)),
+		})
+	}
+
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		ri := sdk.WorkflowPriorityRank(scheduled[i].job.Priority)
+		rj := sdk.WorkflowPriorityRank(scheduled[j].job.Priority)
+		if ri != rj {
+			return ri < rj
+		}
+		return scheduled[i].tag < scheduled[j].tag
+	})
+
+	res := make([]sdk.WorkflowNodeJobRun, len(scheduled))
+	for i := range scheduled {
+		res[i] = scheduled[i].job
+	}
+	return res
+}
@@ -0,0 +1,80 @@
+package queue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/queue"
+	"github.com/ovh/cds/sdk"
+)
+
+func jobIDs(jobs []sdk.WorkflowNodeJobRun) []int64 {
+	ids := make([]int64, len(jobs))
+	for i := range jobs {
+		ids[i] = jobs[i].ID
+	}
+	return ids
+}
+
+func TestSchedule(t *testing.T) {
+	now := time.Now()
+	jobs := []sdk.WorkflowNodeJobRun{
+		{ID: 1, ProjectID: 1, Queued: now},
+		{ID: 2, ProjectID: 1, Queued: now.Add(1 * time.Second)},
+		{ID: 3, ProjectID: 1, Queued: now.Add(2 * time.Second)},
+		{ID: 4, ProjectID: 1, Queued: now.Add(3 * time.Second)},
+		{ID: 5, ProjectID: 2, Queued: now.Add(4 * time.Second)},
+		{ID: 6, ProjectID: 2, Queued: now.Add(5 * time.Second)},
+	}
+	states := map[int64]queue.ProjectState{
+		1: {Key: "PROJ1"},
+		2: {Key: "PROJ2"},
+	}
+
+	// Without weights, projects are interleaved
+	res := queue.Schedule(queue.Configuration{}, jobs, states)
+	assert.Equal(t, []int64{1, 5, 2, 6, 3, 4}, jobIDs(res))
+
+	// A project with a double weight gets two slots for one
+	res = queue.Schedule(queue.Configuration{ProjectWeights: map[string]int{"PROJ1": 2}}, jobs, states)
+	assert.Equal(t, []int64{1, 2, 5, 3, 4, 6}, jobIDs(res))
+
+	// A group weight is used for the projects of the group without specific weight
+	states[1] = queue.ProjectState{Key: "PROJ1", Groups: []string{"team-a"}}
+	res = queue.Schedule(queue.Configuration{GroupWeights: map[string]int{"team-a": 2}}, jobs, states)
+	assert.Equal(t, []int64{1, 2, 5, 3, 4, 6}, jobIDs(res))
+	res = queue.Schedule(queue.Configuration{
+		GroupWeights:   map[string]int{"team-a": 2},
+		ProjectWeights: map[string]int{"PROJ1": 1},
+	}, jobs, states)
+	assert.Equal(t, []int64{1, 5, 2, 6, 3, 4}, jobIDs(res))
+
+	// Building jobs are taken into account
+	states[1] = queue.ProjectState{Key: "PROJ1", Building: 2}
+	res = queue.Schedule(queue.Configuration{}, jobs, states)
+	assert.Equal(t, []int64{5, 6, 1, 2, 3, 4}, jobIDs(res))
+
+	// Jobs over the max concurrent limit are hidden
+	res = queue.Schedule(queue.Configuration{MaxConcurrentJobsPerProject: 3}, jobs, states)
+	assert.Equal(t, []int64{5, 6, 1}, jobIDs(res))
+}
+
+func TestSchedulePriority(t *testing.T) {
+	now := time.Now()
+	jobs := []sdk.WorkflowNodeJobRun{
+		{ID: 1, ProjectID: 1, Queued: now, Priority: sdk.WorkflowPriorityBatch},
+		{ID: 2, ProjectID: 1, Queued: now.Add(1 * time.Second), Priority: sdk.WorkflowPriorityBatch},
+		{ID: 3, ProjectID: 2, Queued: now.Add(2 * time.Second)},
+		{ID: 4, ProjectID: 2, Queued: now.Add(3 * time.Second), Priority: sdk.WorkflowPriorityCritical},
+	}
+	states := map[int64]queue.ProjectState{
+		1: {Key: "PROJ1"},
+		2: {Key: "PROJ2"},
+	}
+
+	// Critical jobs are scheduled first and batch jobs last, whatever the fair share
+	res := queue.Schedule(queue.Configuration{}, jobs, states)
+	assert.Equal(t, []int64{4, 3, 1, 2}, jobIDs(res))
+}
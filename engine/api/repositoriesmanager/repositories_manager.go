@@ -105,7 +105,7 @@ func GetReposForProjectVCSServer(ctx context.Context, db gorp.SqlExecutor, store
 			"cannot get client got %s %s", proj.Key, vcsServer.Name))
 	}
 
-	cacheKey := cache.Key("reposmanager", "repos", proj.Key, vcsServer.Name)
+	cacheKey := cache.ProjectKey(proj.Key, "reposmanager", "repos", vcsServer.Name)
 	if opts.Sync {
 		if err := store.Delete(cacheKey); err != nil {
 			log.Error(ctx, "GetReposForProjectVCSServer> error on delete cache key %v: %s", cacheKey, err)
@@ -419,6 +419,15 @@ func (c *vcsClient) Commit(ctx context.Context, fullname, hash string) (sdk.VCSC
 	return commit, nil
 }
 
+func (c *vcsClient) ChangedFiles(ctx context.Context, fullname, base, head string) ([]string, error) {
+	var files []string
+	path := fmt.Sprintf("/vcs/%s/repos/%s/commits/%s/files?base=%s", c.name, fullname, url.PathEscape(head), url.QueryEscape(base))
+	if _, err := c.doJSONRequest(ctx, "GET", path, nil, &files); err != nil {
+		return nil, sdk.WrapError(err, "unable to find files changed by commit %s on repository %s from %s", head, fullname, c.name)
+	}
+	return files, nil
+}
+
 func (c *vcsClient) PullRequest(ctx context.Context, fullname string, ID int) (sdk.VCSPullRequest, error) {
 	pr := sdk.VCSPullRequest{}
 	path := fmt.Sprintf("/vcs/%s/repos/%s/pullrequests/%d", c.name, fullname, ID)
@@ -616,11 +625,12 @@ func (c *vcsClient) GetAccessToken(_ context.Context) string {
 
 // WebhooksInfos is a set of info about webhooks
 type WebhooksInfos struct {
-	WebhooksSupported  bool     `json:"webhooks_supported"`
-	WebhooksDisabled   bool     `json:"webhooks_disabled"`
-	GerritHookDisabled bool     `json:"gerrithook_disabled"`
-	Icon               string   `json:"webhooks_icon"`
-	Events             []string `json:"events"`
+	WebhooksSupported       bool     `json:"webhooks_supported"`
+	WebhooksDisabled        bool     `json:"webhooks_disabled"`
+	WebhooksSecretSupported bool     `json:"webhooks_secret_supported"`
+	GerritHookDisabled      bool     `json:"gerrithook_disabled"`
+	Icon                    string   `json:"webhooks_icon"`
+	Events                  []string `json:"events"`
 }
 
 // GetWebhooksInfos returns webhooks_supported, webhooks_disabled, webhooks_creation_supported, webhooks_creation_disabled for a vcs server
@@ -24,6 +24,7 @@ import (
 	"go.opencensus.io/tag"
 
 	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/api/openapi"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
@@ -56,12 +57,20 @@ type Router struct {
 	URL                    string
 	Middlewares            []service.Middleware
 	PostMiddlewares        []service.Middleware
+	Idempotency            func(service.Handler) service.Handler
 	mapRouterConfigs       map[string]*service.RouterConfig
 	mapAsynchronousHandler map[string]service.HandlerFunc
 	panicked               bool
 	nbPanic                int
 	lastPanic              *time.Time
 	scopeDetails           []sdk.AuthConsumerScopeDetail
+	routeCatalog           []sdk.APIRoute
+	openAPI                map[string]openapi.Document
+	// V1Sunset is the date at which the routes of the API v1 will be removed, if set deprecation headers are sent on
+	// all the API v1 requests
+	V1Sunset *time.Time
+	// CORSPolicies restrict the cross origin requests, all origins are allowed if empty
+	CORSPolicies []CORSPolicy
 }
 
 // HandlerConfigParam is a type used in handler configuration, to set specific config on a route given a method
@@ -170,6 +179,9 @@ var headers = []string{
 	http.CanonicalHeaderKey(sdk.WorkflowAsCodeHeader),
 	http.CanonicalHeaderKey(sdk.ResponseWorkflowIDHeader),
 	http.CanonicalHeaderKey(sdk.ResponseWorkflowNameHeader),
+	cdsclient.ResponseDeprecationHeader,
+	cdsclient.ResponseSunsetHeader,
+	cdsclient.ResponseLinkHeader,
 }
 
 // DefaultHeaders is a set of default header for the router
@@ -247,6 +259,30 @@ func (r *Router) computeScopeDetails() {
 	r.scopeDetails = details
 }
 
+// readOnlyScopeDetails returns the details of given scopes restricted to their GET routes.
+func (r *Router) readOnlyScopeDetails(scopes []sdk.AuthConsumerScope) (sdk.AuthConsumerScopeDetails, error) {
+	details := make(sdk.AuthConsumerScopeDetails, 0, len(scopes))
+	for _, scope := range scopes {
+		var endpoints sdk.AuthConsumerScopeEndpoints
+		for _, d := range r.scopeDetails {
+			if d.Scope != scope {
+				continue
+			}
+			for _, e := range d.Endpoints {
+				if sdk.IsInArray(http.MethodGet, e.Methods) {
+					endpoints = append(endpoints, sdk.AuthConsumerScopeEndpoint{Route: e.Route, Methods: []string{http.MethodGet}})
+				}
+			}
+		}
+		// An empty list of endpoints would allow all the routes of the scope
+		if len(endpoints) == 0 {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "scope %s has no read only route", scope)
+		}
+		details = append(details, sdk.AuthConsumerScopeDetail{Scope: scope, Endpoints: endpoints})
+	}
+	return details, nil
+}
+
 // Handle adds all handler for their specific verb in gorilla router for given uri
 func (r *Router) Handle(uri string, scope HandlerScope, handlers ...*service.HandlerConfig) {
 	uri = r.Prefix + uri
@@ -279,6 +315,9 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 		name = strings.Replace(name, ".1", "", 1)
 		name = strings.Replace(name, "github.com/ovh/cds/engine/", "", 1)
 		handlers[i].Name = name
+		if handlers[i].Idempotent && r.Idempotency != nil {
+			handlers[i].Handler = r.Idempotency(handlers[i].Handler)
+		}
 		cfg.Config[handlers[i].Method] = handlers[i]
 	}
 
@@ -322,6 +361,7 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 				w.Header().Add(k, v)
 			}
 		}
+		r.setCORSHeaders(w, req)
 
 		//Always returns OK on Options method
 		if req.Method == "OPTIONS" {
@@ -336,6 +376,11 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 			service.WriteError(ctx, w, req, sdk.ErrNotFound)
 			return
 		}
+		if err := r.checkRouteVersion(ctx, w, req, rc); err != nil {
+			observability.Record(ctx, Errors, 1)
+			service.WriteError(ctx, w, req, err)
+			return
+		}
 
 		// Make the request context inherit from the context of the router
 		tags := observability.ContextGetTags(r.Background, observability.TagServiceType, observability.TagServiceName)
@@ -396,6 +441,7 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 				"route":         cleanURL,
 				"request_uri":   req.RequestURI,
 				"deprecated":    rc.IsDeprecated,
+				"api_version":   getAPIVersion(ctx),
 			}, "%s | END   | %s [%s] | [%d]", req.Method, req.URL, rc.Name, responseWriter.statusCode)
 
 			observability.RecordFloat64(ctx, ServerLatency, float64(latency)/float64(time.Millisecond))
@@ -417,13 +463,34 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 			}
 		}
 
-		if err := rc.Handler(ctx, responseWriter.wrappedResponseWriter(), req); err != nil {
+		// Responses of GET requests can be filtered with the fields query parameter
+		handlerWriter := responseWriter.wrappedResponseWriter()
+		var fieldsWriter *fieldsResponseWriter
+		if req.Method == http.MethodGet {
+			fields, err := parseFields(req)
+			if err != nil {
+				service.WriteError(ctx, responseWriter, req, err)
+				deferFunc(ctx)
+				return
+			}
+			if fields != nil {
+				fieldsWriter = &fieldsResponseWriter{ResponseWriter: handlerWriter, fields: fields}
+				handlerWriter = fieldsWriter
+			}
+		}
+
+		if err := rc.Handler(ctx, handlerWriter, req); err != nil {
 			observability.Record(r.Background, Errors, 1)
 			observability.End(ctx, responseWriter, req)
 			service.WriteError(ctx, responseWriter, req, err)
 			deferFunc(ctx)
 			return
 		}
+		if fieldsWriter != nil {
+			if err := fieldsWriter.flush(); err != nil {
+				log.Error(ctx, "unable to filter the fields of the response: %v", err)
+			}
+		}
 
 		// writeNoContentPostMiddleware is compliant Middleware Interface
 		// but no need to check ct, err in return
@@ -586,6 +653,19 @@ func (r *Router) DELETE(h service.HandlerFunc, cfg ...HandlerConfigParam) *servi
 	return &rc
 }
 
+// PATCH will set given handler only for PATCH request
+func (r *Router) PATCH(h service.HandlerFunc, cfg ...HandlerConfigParam) *service.HandlerConfig {
+	var rc service.HandlerConfig
+	rc.Handler = h()
+	rc.NeedAuth = true
+	rc.Method = "PATCH"
+	rc.PermissionLevel = sdk.PermissionReadWriteExecute
+	for _, c := range cfg {
+		c(&rc)
+	}
+	return &rc
+}
+
 // NeedAdmin set the route for cds admin only (or not)
 func NeedAdmin(admin bool) HandlerConfigParam {
 	f := func(rc *service.HandlerConfig) {
@@ -635,6 +715,41 @@ func EnableTracing() HandlerConfigParam {
 	return f
 }
 
+// IntegrationSignature set the route for callbacks of an integration, requests should be signed with the signing
+// secret of the integration given in route vars
+func IntegrationSignature() HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.IntegrationSignature = true
+	}
+	return f
+}
+
+// Idempotent set the route for requests that can be retried with an Idempotency-Key header, the response of the
+// first request is returned for the retries
+func Idempotent() HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.Idempotent = true
+	}
+	return f
+}
+
+// NeedPermission set the permission that the consumer should have on the resources of the route, it overrides the
+// permission given by the method of the route
+func NeedPermission(level int) HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.PermissionLevel = level
+	}
+	return f
+}
+
+// ProjectVerb set the verb that a project scoped consumer should have to access the route
+func ProjectVerb(v sdk.AuthConsumerProjectVerb) HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.ProjectVerb = v
+	}
+	return f
+}
+
 // NotFoundHandler is called by default by Mux is any matching handler has been found
 func NotFoundHandler(w http.ResponseWriter, req *http.Request) {
 	service.WriteError(context.Background(), w, req, sdk.NewError(sdk.ErrNotFound, fmt.Errorf("%s not found", req.URL.Path)))
@@ -13,6 +13,7 @@ const (
 	contextJWTRaw
 	contextDate
 	contextJWTFromCookie
+	contextAPIVersion
 )
 
 // ContextValues retuns auth values of a context
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// CORSPolicy allows the cross origin requests from given origins with given methods.
+type CORSPolicy struct {
+	Origins []string `toml:"origins" comment:"Allowed origins, like https://github.com or https://*.example.com" json:"origins"`
+	Methods []string `toml:"methods" comment:"Allowed methods, only GET if not set" json:"methods"`
+}
+
+// IsValid returns an error if an origin or a method of the policy is not valid.
+func (p CORSPolicy) IsValid() error {
+	if len(p.Origins) == 0 {
+		return fmt.Errorf("at least one origin should be given")
+	}
+	for _, o := range p.Origins {
+		if o == "*" {
+			continue
+		}
+		if err := sdk.IsValidOriginPattern(o); err != nil {
+			return err
+		}
+	}
+	for _, m := range p.Methods {
+		switch m {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete:
+		default:
+			return fmt.Errorf("invalid method %s", m)
+		}
+	}
+	return nil
+}
+
+func (p CORSPolicy) allowedMethods() string {
+	if len(p.Methods) == 0 {
+		return http.MethodGet + "," + http.MethodOptions
+	}
+	return strings.Join(p.Methods, ",") + "," + http.MethodOptions
+}
+
+// setCORSHeaders overrides the default CORS headers with the first policy matching the origin of the request. When
+// policies are configured and none of them matches, cross origin requests are not allowed.
+func (r *Router) setCORSHeaders(w http.ResponseWriter, req *http.Request) {
+	if len(r.CORSPolicies) == 0 {
+		return
+	}
+	h := w.Header()
+	h.Add("Vary", "Origin")
+	origin := req.Header.Get("Origin")
+	for _, p := range r.CORSPolicies {
+		for _, o := range p.Origins {
+			if sdk.MatchOrigin(o, origin) {
+				h.Set("Access-Control-Allow-Origin", origin)
+				h.Set("Access-Control-Allow-Methods", p.allowedMethods())
+				return
+			}
+		}
+	}
+	h.Del("Access-Control-Allow-Origin")
+	h.Del("Access-Control-Allow-Methods")
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// fieldsQueryParam is the query parameter used to select the fields of a JSON response, ex: ?fields=id,status,nodes.*.status
+const fieldsQueryParam = "fields"
+
+// fieldsTree contains the selected fields by key, a nil value selects the whole value of the key. The key * selects all
+// the keys of an object.
+type fieldsTree map[string]fieldsTree
+
+// parseFields returns the fields selected by the query of given request, or nil if no field was given.
+func parseFields(req *http.Request) (fieldsTree, error) {
+	values := req.URL.Query()[fieldsQueryParam]
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	tree := fieldsTree{}
+	for _, value := range values {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			current := tree
+			path := strings.Split(field, ".")
+			for i, key := range path {
+				if key == "" {
+					return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid field %q", field)
+				}
+				sub, ok := current[key]
+				if ok && sub == nil {
+					// The whole value is already selected
+					break
+				}
+				if i == len(path)-1 {
+					current[key] = nil
+					break
+				}
+				if !ok {
+					sub = fieldsTree{}
+					current[key] = sub
+				}
+				current = sub
+			}
+		}
+	}
+	if len(tree) == 0 {
+		return nil, nil
+	}
+	return tree, nil
+}
+
+// filter returns given decoded JSON value with only the selected fields. Fields are selected in each element of an array.
+func (f fieldsTree) filter(v interface{}) interface{} {
+	switch t := v.(type) {
+	case []interface{}:
+		res := make([]interface{}, len(t))
+		for i := range t {
+			res[i] = f.filter(t[i])
+		}
+		return res
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(f))
+		for k, value := range t {
+			sub, ok := f[k]
+			if !ok {
+				sub, ok = f["*"]
+			}
+			if !ok {
+				continue
+			}
+			if sub == nil {
+				res[k] = value
+			} else {
+				res[k] = sub.filter(value)
+			}
+		}
+		return res
+	default:
+		return v
+	}
+}
+
+// filterJSON returns given JSON document with only the selected fields.
+func (f fieldsTree) filterJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	res, err := json.Marshal(f.filter(v))
+	return res, sdk.WithStack(err)
+}
+
+// fieldsResponseWriter keeps the successful JSON response written by a handler, to write it with only the selected
+// fields when the handler returns. Other responses are written without change.
+type fieldsResponseWriter struct {
+	http.ResponseWriter
+	fields     fieldsTree
+	statusCode int
+	buffered   bool
+	body       bytes.Buffer
+}
+
+func (w *fieldsResponseWriter) WriteHeader(statusCode int) {
+	if statusCode >= 200 && statusCode < 300 && statusCode != http.StatusNoContent &&
+		strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		w.statusCode = statusCode
+		w.buffered = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *fieldsResponseWriter) Write(data []byte) (int, error) {
+	if w.buffered {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// flush writes the kept response with only the selected fields. The response is written without change if it can't be
+// filtered.
+func (w *fieldsResponseWriter) flush() error {
+	if !w.buffered {
+		return nil
+	}
+	data, filterErr := w.fields.filterJSON(w.body.Bytes())
+	if filterErr != nil {
+		data = w.body.Bytes()
+	}
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(data)))
+	w.ResponseWriter.WriteHeader(w.statusCode)
+	if _, err := w.ResponseWriter.Write(data); err != nil {
+		return sdk.WithStack(err)
+	}
+	return filterErr
+}
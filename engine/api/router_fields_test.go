@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/service"
+)
+
+func Test_parseFields(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/project/KEY/workflows/wf/runs/1", nil)
+	fields, err := parseFields(req)
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+
+	req = httptest.NewRequest(http.MethodGet, "/project/KEY/workflows/wf/runs/1?fields=id,status,nodes.*.status,nodes.*.stages,nodes&fields=tags.tag", nil)
+	fields, err = parseFields(req)
+	require.NoError(t, err)
+	assert.Equal(t, fieldsTree{
+		"id":     nil,
+		"status": nil,
+		"nodes":  nil,
+		"tags":   fieldsTree{"tag": nil},
+	}, fields)
+
+	req = httptest.NewRequest(http.MethodGet, "/project/KEY/workflows/wf/runs/1?fields=id,nodes..status", nil)
+	_, err = parseFields(req)
+	require.Error(t, err)
+}
+
+func Test_fieldsResponseWriter(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/project/KEY/workflows/wf/runs?fields=num,status,nodes.*.status", nil)
+	fields, err := parseFields(req)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	w := &fieldsResponseWriter{ResponseWriter: rec, fields: fields}
+	require.NoError(t, service.WriteJSON(w, []map[string]interface{}{{
+		"num":    int64(9007199254740993),
+		"status": "Success",
+		"tags":   []string{"git.branch:master"},
+		"nodes": map[string]interface{}{
+			"1": map[string]interface{}{"status": "Success", "stages": []string{"build"}},
+		},
+	}}, http.StatusOK))
+	assert.Empty(t, rec.Body.String())
+
+	require.NoError(t, w.flush())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `[{"nodes":{"1":{"status":"Success"}},"num":9007199254740993,"status":"Success"}]`, rec.Body.String())
+	assert.Equal(t, "80", rec.Header().Get("Content-Length"))
+
+	// Errors are not filtered
+	rec = httptest.NewRecorder()
+	w = &fieldsResponseWriter{ResponseWriter: rec, fields: fields}
+	require.NoError(t, service.WriteJSON(w, map[string]string{"message": "not found"}, http.StatusNotFound))
+	require.NoError(t, w.flush())
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, `{"message":"not found"}`, rec.Body.String())
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/ovh/cds/engine/api/idempotency"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// idempotencyResponseWriter keeps a copy of the response written by an idempotent handler.
+type idempotencyResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *idempotencyResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *idempotencyResponseWriter) Write(data []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// idempotencyHandler returns the response stored for a previous request sent by the same consumer with the same
+// Idempotency-Key header instead of calling given handler again. Requests without the header are not changed.
+func (api *API) idempotencyHandler(h service.Handler) service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+		key := strings.TrimSpace(req.Header.Get(sdk.IdempotencyKeyHeader))
+		consumer := getAPIConsumer(ctx)
+		if key == "" || consumer == nil {
+			return h(ctx, w, req)
+		}
+		if len(key) > sdk.IdempotencyKeyMaxLength {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "%s header should not exceed %d characters", sdk.IdempotencyKeyHeader, sdk.IdempotencyKeyMaxLength)
+		}
+
+		hash, err := idempotencyRequestHash(req)
+		if err != nil {
+			return err
+		}
+
+		k := sdk.IdempotencyKey{ConsumerID: consumer.ID, Key: key, RequestHash: hash}
+		inserted, err := idempotency.Insert(api.mustDB(), &k)
+		if err != nil {
+			return err
+		}
+		if !inserted {
+			existing, err := idempotency.LoadByConsumerIDAndKey(ctx, api.mustDB(), consumer.ID, key)
+			if err != nil {
+				return err
+			}
+			if existing.RequestHash != hash {
+				return sdk.WithStack(sdk.ErrIdempotencyKeyMismatch)
+			}
+			if existing.StatusCode == 0 {
+				return sdk.WithStack(sdk.ErrIdempotencyKeyInProgress)
+			}
+
+			log.Info(ctx, "idempotencyHandler> returning stored response for idempotency key %s", key)
+			if existing.ContentType != "" {
+				w.Header().Set("Content-Type", existing.ContentType)
+			}
+			w.Header().Set(sdk.IdempotencyReplayedHeader, "true")
+			w.WriteHeader(existing.StatusCode)
+			_, err = w.Write(existing.Response)
+			return sdk.WithStack(err)
+		}
+
+		rw := &idempotencyResponseWriter{ResponseWriter: w}
+		err = h(ctx, rw, req)
+		// A failed request can be sent again with the same key
+		if err != nil || rw.statusCode >= http.StatusInternalServerError {
+			if errD := idempotency.Delete(api.mustDB(), k.ID); errD != nil {
+				log.Error(ctx, "idempotencyHandler> %v", errD)
+			}
+			return err
+		}
+
+		k.StatusCode = rw.statusCode
+		if k.StatusCode == 0 {
+			k.StatusCode = http.StatusNoContent
+		}
+		k.ContentType = rw.Header().Get("Content-Type")
+		k.Response = rw.body.Bytes()
+		if err := idempotency.Update(api.mustDB(), &k); err != nil {
+			log.Error(ctx, "idempotencyHandler> %v", err)
+		}
+		return nil
+	}
+}
+
+// idempotencyRequestHash returns a hash of the method, the path and the body of given request. The body of the
+// request is restored to be read again by the handler.
+func idempotencyRequestHash(req *http.Request) (string, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		if err != nil {
+			return "", sdk.WithStack(err)
+		}
+		_ = req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.Path + "\n")) // nolint
+	h.Write(body)                                           // nolint
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
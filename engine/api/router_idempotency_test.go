@@ -0,0 +1,91 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_idempotencyRequestHash(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "/project/KEY/workflows/wf/runs", bytes.NewBufferString(`{"manual":{}}`))
+	require.NoError(t, err)
+	h1, err := idempotencyRequestHash(req)
+	require.NoError(t, err)
+
+	// The body is restored for the handler
+	body, err := ioutil.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"manual":{}}`, string(body))
+
+	req, err = http.NewRequest(http.MethodPost, "/project/KEY/workflows/wf/runs", bytes.NewBufferString(`{"manual":{}}`))
+	require.NoError(t, err)
+	h2, err := idempotencyRequestHash(req)
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2)
+
+	req, err = http.NewRequest(http.MethodPost, "/project/KEY/workflows/wf/runs", bytes.NewBufferString(`{"number":1}`))
+	require.NoError(t, err)
+	h3, err := idempotencyRequestHash(req)
+	require.NoError(t, err)
+	assert.NotEqual(t, h1, h3)
+}
+
+func Test_idempotencyHandler(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+
+	u, _ := assets.InsertLambdaUser(t, db)
+	consumer, err := authentication.LoadConsumerByTypeAndUserID(context.TODO(), db, sdk.ConsumerLocal, u.ID)
+	require.NoError(t, err)
+	ctx := context.WithValue(context.TODO(), contextAPIConsumer, consumer)
+
+	var calls int
+	h := api.idempotencyHandler(func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		calls++
+		return service.WriteJSON(w, map[string]int{"number": calls}, http.StatusAccepted)
+	})
+
+	send := func(key, body string) (*httptest.ResponseRecorder, error) {
+		req, err := http.NewRequest(http.MethodPost, "/project/KEY/workflows/wf/runs", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		if key != "" {
+			req.Header.Set(sdk.IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		return w, h(ctx, w, req)
+	}
+
+	key := sdk.RandomString(20)
+	w, err := send(key, `{}`)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, 1, calls)
+
+	// A retry with the same key returns the first response without calling the handler
+	w, err = send(key, `{}`)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "true", w.Header().Get(sdk.IdempotencyReplayedHeader))
+	assert.JSONEq(t, `{"number":1}`, w.Body.String())
+	assert.Equal(t, 1, calls)
+
+	// The same key can't be used for another request
+	_, err = send(key, `{"number":1}`)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrIdempotencyKeyMismatch))
+
+	// Requests without key are not deduplicated
+	_, err = send("", `{}`)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
@@ -1,9 +1,15 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"io/ioutil"
 	"net/http"
+	"time"
 
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/integration"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 )
@@ -14,3 +20,35 @@ func (api *API) maintenanceMiddleware(ctx context.Context, w http.ResponseWriter
 	}
 	return ctx, nil
 }
+
+// integrationSignatureMiddleware checks that the callbacks of an integration are signed with its signing secret,
+// the body is restored for the handler after the verification.
+func (api *API) integrationSignatureMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
+	if !rc.IntegrationSignature {
+		return ctx, nil
+	}
+
+	vars := mux.Vars(req)
+	pi, err := integration.LoadProjectIntegrationByNameWithClearPassword(api.mustDB(), vars["key"], vars["integrationName"])
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return ctx, sdk.WithStack(sdk.ErrInvalidIntegrationSignature)
+		}
+		return ctx, err
+	}
+	if err := integration.InterpolateConfig(api.mustDB(), &pi); err != nil {
+		return ctx, err
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return ctx, sdk.NewErrorWithStack(err, sdk.ErrWrongRequest)
+	}
+	req.Body.Close() // nolint
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err := sdk.VerifyIntegrationSignature(pi.Config.SigningSecret(), req.Header, body, time.Now()); err != nil {
+		return ctx, sdk.WrapError(err, "invalid signature for callback of integration %s", pi.Name)
+	}
+	return ctx, nil
+}
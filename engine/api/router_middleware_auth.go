@@ -64,44 +64,25 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 
 	if session != nil {
 		ctx = context.WithValue(ctxWithJWT, contextSession, session)
-		// Load auth consumer for current session in database with authentified user and contacts
-		c, err := authentication.LoadConsumerByID(ctx, api.mustDB(), session.ConsumerID,
-			authentication.LoadConsumerOptions.WithAuthentifiedUser)
+		consumer, err = api.loadSessionConsumer(ctx, session)
 		if err != nil {
-			return ctx, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
-		}
-		// If the consumer is disabled, return an error
-		if c.Disabled {
-			return ctx, sdk.WrapError(sdk.ErrUnauthorized, "consumer (%s) is disabled", c.ID)
-		}
-		// If the driver was disabled for the consumer that was found, ignore it
-		if _, ok := api.AuthenticationDrivers[c.Type]; ok {
-			// Add contacts for consumer's user
-			if err := user.LoadOptions.WithContacts(ctx, api.mustDB(), c.AuthentifiedUser); err != nil {
-				return ctx, err
-			}
-
-			// Add service for consumer if exists
-			s, err := services.LoadByConsumerID(ctx, api.mustDB(), c.ID)
-			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
-				return ctx, err
-			}
-			c.Service = s
-
-			// Add worker for consumer if exists
-			w, err := worker.LoadByConsumerID(ctx, api.mustDB(), c.ID)
-			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
-				return ctx, err
-			}
-			c.Worker = w
-
-			consumer = c
+			return ctx, err
 		}
 	}
 
 	if consumer != nil {
 		ctx = context.WithValue(ctx, contextAPIConsumer, consumer)
 
+		// Browser tokens are read only and can only be used from their origins
+		if consumer.IsBrowserToken() {
+			if req.Method != http.MethodGet {
+				return ctx, sdk.NewErrorFrom(sdk.ErrForbidden, "a browser token can only be used for read requests")
+			}
+			if origin := req.Header.Get("Origin"); origin != "" && !consumer.BrowserTokenAllowsOrigin(origin) {
+				return ctx, sdk.WrapError(sdk.ErrUnauthorized, "browser token can't be used from origin %s", origin)
+			}
+		}
+
 		// Checks scopes, one of expected scopes should be in actual scopes
 		// Actual scope empty list means wildcard scope, we don't need to check scopes
 		expectedScopes, actualScopes := rc.AllowedScopes, consumer.ScopeDetails
@@ -131,6 +112,11 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 			}
 		}
 
+		// Check that project scoped consumer is allowed to access current route
+		if err := checkConsumerProjectScopes(consumer, mux.Vars(req), rc); err != nil {
+			return ctx, err
+		}
+
 		// Check that permission are valid for current route and consumer
 		if err := api.checkPermission(ctx, mux.Vars(req), rc.PermissionLevel); err != nil {
 			return ctx, err
@@ -158,6 +144,46 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 	return ctx, nil
 }
 
+// loadSessionConsumer returns the auth consumer for given session with its authentified user, contacts, service and
+// worker. A nil consumer is returned if the authentication driver of the consumer was disabled.
+func (api *API) loadSessionConsumer(ctx context.Context, session *sdk.AuthSession) (*sdk.AuthConsumer, error) {
+	// Load auth consumer for current session in database with authentified user and contacts
+	c, err := authentication.LoadConsumerByID(ctx, api.mustDB(), session.ConsumerID,
+		authentication.LoadConsumerOptions.WithAuthentifiedUser)
+	if err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+	// If the consumer is disabled, return an error
+	if c.Disabled {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "consumer (%s) is disabled", c.ID)
+	}
+	// If the driver was disabled for the consumer that was found, ignore it
+	if _, ok := api.AuthenticationDrivers[c.Type]; !ok {
+		return nil, nil
+	}
+
+	// Add contacts for consumer's user
+	if err := user.LoadOptions.WithContacts(ctx, api.mustDB(), c.AuthentifiedUser); err != nil {
+		return nil, err
+	}
+
+	// Add service for consumer if exists
+	s, err := services.LoadByConsumerID(ctx, api.mustDB(), c.ID)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+	c.Service = s
+
+	// Add worker for consumer if exists
+	w, err := worker.LoadByConsumerID(ctx, api.mustDB(), c.ID)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+	c.Worker = w
+
+	return c, nil
+}
+
 // Checks static tokens
 func (api *API) authStatusTokenMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, bool, error) {
 	if len(rc.AllowedTokens) == 0 {
@@ -165,7 +191,7 @@ func (api *API) authStatusTokenMiddleware(ctx context.Context, w http.ResponseWr
 	}
 	for _, h := range rc.AllowedTokens {
 		log.Debug("authStatusTokenMiddleware> checking allowed token: %v", h)
-		headerSplitted := strings.Split(h, ":")
+		headerSplitted := strings.SplitN(h, ":", 2)
 		receivedValue := req.Header.Get(headerSplitted[0])
 		if receivedValue != headerSplitted[1] {
 			return ctx, false, sdk.WrapError(sdk.ErrUnauthorized, "Router> Authorization denied token on %s %s for %s", req.Method, req.URL, req.RemoteAddr)
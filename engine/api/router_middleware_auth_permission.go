@@ -17,6 +17,7 @@ import (
 	"github.com/ovh/cds/engine/api/user"
 	"github.com/ovh/cds/engine/api/workermodel"
 	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -52,6 +53,28 @@ func (api *API) checkPermission(ctx context.Context, routeVar map[string]string,
 	return nil
 }
 
+// checkConsumerProjectScopes checks that a project scoped consumer has the verb required by the route on the
+// requested project. Group permissions are still checked after, project scopes only restrict the consumer.
+func checkConsumerProjectScopes(consumer *sdk.AuthConsumer, routeVars map[string]string, rc *service.HandlerConfig) error {
+	if len(consumer.ProjectScopes) == 0 {
+		return nil
+	}
+
+	if rc.ProjectVerb == "" {
+		return sdk.WrapError(sdk.ErrUnauthorized, "route %s %s is not allowed for project scoped consumer %s", rc.Method, rc.CleanURL, consumer.ID)
+	}
+
+	projectKey := routeVars["permProjectKey"]
+	if projectKey == "" {
+		projectKey = routeVars["key"]
+	}
+	if projectKey == "" || !consumer.ProjectScopes.IsAllowed(projectKey, rc.ProjectVerb) {
+		return sdk.WrapError(sdk.ErrForbidden, "consumer %s is not allowed to %s on project %s", consumer.ID, rc.ProjectVerb, projectKey)
+	}
+
+	return nil
+}
+
 func (api *API) checkJobIDPermissions(ctx context.Context, jobID string, perm int, routeVars map[string]string) error {
 	ctx, end := observability.Span(ctx, "api.checkJobIDPermissions")
 	defer end()
@@ -104,7 +127,7 @@ func (api *API) checkProjectPermissions(ctx context.Context, projectKey string,
 	ctx, end := observability.Span(ctx, "api.checkProjectPermissions")
 	defer end()
 
-	if _, err := project.Load(api.mustDB(),  projectKey); err != nil {
+	if _, err := project.Load(api.mustDB(), projectKey); err != nil {
 		return err
 	}
 
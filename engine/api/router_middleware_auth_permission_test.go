@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/ovh/cds/engine/api/user"
 	"github.com/ovh/cds/engine/api/workermodel"
 	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -704,6 +706,36 @@ func Test_checkJobIDPermissions(t *testing.T) {
 	// TODO
 }
 
+func Test_checkConsumerProjectScopes(t *testing.T) {
+	consumer := &sdk.AuthConsumer{ID: sdk.UUID()}
+
+	runRoute := &service.HandlerConfig{Method: http.MethodPost, ProjectVerb: sdk.AuthConsumerProjectVerbRunWorkflow}
+	integrationRoute := &service.HandlerConfig{Method: http.MethodPut, ProjectVerb: sdk.AuthConsumerProjectVerbManageIntegration}
+	otherRoute := &service.HandlerConfig{Method: http.MethodDelete}
+
+	// test case: consumer without project scopes is not restricted
+	assert.NoError(t, checkConsumerProjectScopes(consumer, map[string]string{"key": "PROJ1"}, otherRoute))
+
+	consumer.ProjectScopes = sdk.AuthConsumerProjectScopes{
+		{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbRunWorkflow}},
+	}
+
+	// test case: verb is allowed for given project
+	assert.NoError(t, checkConsumerProjectScopes(consumer, map[string]string{"key": "PROJ1"}, runRoute))
+
+	// test case: verb is not allowed for given project
+	assert.Error(t, checkConsumerProjectScopes(consumer, map[string]string{"permProjectKey": "PROJ1"}, integrationRoute))
+
+	// test case: project is not allowed
+	assert.Error(t, checkConsumerProjectScopes(consumer, map[string]string{"key": "PROJ2"}, runRoute))
+
+	// test case: route without project
+	assert.Error(t, checkConsumerProjectScopes(consumer, map[string]string{}, runRoute))
+
+	// test case: route without verb
+	assert.Error(t, checkConsumerProjectScopes(consumer, map[string]string{"key": "PROJ1"}, otherRoute))
+}
+
 func Test_checkGroupPermissions(t *testing.T) {
 	api, _, _, end := newTestAPI(t)
 	defer end()
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/ovh/cds/engine/api/openapi"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/doc"
+)
+
+// RequestBody sets the type of the request body of the route, given value is only used to document the route.
+func RequestBody(v interface{}) HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.RequestBody = v
+	}
+	return f
+}
+
+// ResponseBody sets the type of the response body of the route, given value is only used to document the route.
+func ResponseBody(v interface{}) HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.ResponseBody = v
+	}
+	return f
+}
+
+// computeOpenAPI iterates over declared handlers for routers and generates the OpenAPI document of each API version.
+func (r *Router) computeOpenAPI() {
+	routes := make(map[string][]openapi.Route)
+	for uri, cfg := range r.mapRouterConfigs {
+		path := openAPIPath(strings.TrimPrefix(uri, r.Prefix))
+		for method, handler := range cfg.Config {
+			scopes := make([]string, len(handler.AllowedScopes))
+			for i := range handler.AllowedScopes {
+				scopes[i] = string(handler.AllowedScopes[i])
+			}
+			route := openapi.Route{
+				Path:       path,
+				Method:     method,
+				Handler:    openAPIOperationID(handler.Name),
+				NeedAuth:   handler.NeedAuth,
+				NeedAdmin:  handler.NeedAdmin,
+				Scopes:     scopes,
+				Deprecated: handler.IsDeprecated,
+				Request:    handler.RequestBody,
+				Response:   handler.ResponseBody,
+			}
+			for _, v := range sdk.APIVersions {
+				// Deprecated routes are not part of the API v2
+				if handler.IsDeprecated && v != sdk.APIVersion1 {
+					continue
+				}
+				routes[v] = append(routes[v], route)
+			}
+		}
+	}
+
+	r.openAPI = make(map[string]openapi.Document, len(sdk.APIVersions))
+	for _, v := range sdk.APIVersions {
+		serverURL := r.URL
+		if v != sdk.APIVersion1 {
+			serverURL += "/" + v
+		}
+		r.openAPI[v] = openapi.Generate(openapi.Info{Title: "CDS API", Version: v}, serverURL, routes[v], sdk.Error{})
+	}
+}
+
+// openAPIPath returns the path of a route with harmonized variable names, written {name}.
+func openAPIPath(uri string) string {
+	return strings.NewReplacer("<", "{", ">", "}").Replace(doc.CleanURL(uri))
+}
+
+// openAPIOperationID returns the name of the method of a handler, ex: api.(*API).getProjectsHandler -> getProjects.
+func openAPIOperationID(handlerName string) string {
+	name := handlerName[strings.LastIndex(handlerName, ".")+1:]
+	return strings.TrimSuffix(name, "Handler")
+}
@@ -3,8 +3,10 @@ package api
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/ovh/cds/engine/service"
@@ -54,3 +56,127 @@ func Test_computeScopeDetails(t *testing.T) {
 		}
 	}
 }
+
+func Test_readOnlyScopeDetails(t *testing.T) {
+	r := &Router{
+		Mux:        mux.NewRouter(),
+		Background: context.TODO(),
+	}
+
+	myHandler := func() service.Handler {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			return nil
+		}
+	}
+
+	r.Handle("/handler1", Scope(sdk.AuthConsumerScopeRun), r.GET(myHandler), r.POST(myHandler))
+	r.Handle("/handler2", Scope(sdk.AuthConsumerScopeRun), r.DELETE(myHandler))
+	r.Handle("/handler3", Scope(sdk.AuthConsumerScopeProject), r.POST(myHandler))
+	r.computeScopeDetails()
+
+	details, err := r.readOnlyScopeDetails([]sdk.AuthConsumerScope{sdk.AuthConsumerScopeRun})
+	require.NoError(t, err)
+	require.Len(t, details, 1)
+	assert.Equal(t, sdk.AuthConsumerScopeRun, details[0].Scope)
+	require.Len(t, details[0].Endpoints, 1)
+	assert.Equal(t, "/handler1", details[0].Endpoints[0].Route)
+	assert.Equal(t, sdk.StringSlice{http.MethodGet}, details[0].Endpoints[0].Methods)
+
+	_, err = r.readOnlyScopeDetails([]sdk.AuthConsumerScope{sdk.AuthConsumerScopeProject})
+	assert.Error(t, err)
+}
+
+func Test_setCORSHeaders(t *testing.T) {
+	r := &Router{
+		CORSPolicies: []CORSPolicy{
+			{Origins: []string{"https://github.com"}},
+			{Origins: []string{"https://*.example.com"}, Methods: []string{http.MethodGet, http.MethodPost}},
+		},
+	}
+
+	for _, tt := range []struct {
+		origin  string
+		allowed string
+		methods string
+	}{
+		{origin: "https://github.com", allowed: "https://github.com", methods: "GET,OPTIONS"},
+		{origin: "https://ui.example.com", allowed: "https://ui.example.com", methods: "GET,POST,OPTIONS"},
+		{origin: "https://example.com"},
+		{origin: "http://github.com"},
+		{},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Origin", tt.origin)
+		w := httptest.NewRecorder()
+		for k, v := range DefaultHeaders() {
+			w.Header().Add(k, v)
+		}
+		r.setCORSHeaders(w, req)
+		assert.Equal(t, tt.allowed, w.Header().Get("Access-Control-Allow-Origin"), tt.origin)
+		assert.Equal(t, tt.methods, w.Header().Get("Access-Control-Allow-Methods"), tt.origin)
+		assert.Equal(t, "Origin", w.Header().Get("Vary"))
+	}
+
+	// Without policy all origins are allowed
+	r.CORSPolicies = nil
+	w := httptest.NewRecorder()
+	for k, v := range DefaultHeaders() {
+		w.Header().Add(k, v)
+	}
+	r.setCORSHeaders(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func Test_routeVersion(t *testing.T) {
+	v1Sunset := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &Router{
+		Mux:        mux.NewRouter(),
+		Background: context.TODO(),
+		URL:        "http://cds.local",
+		V1Sunset:   &v1Sunset,
+	}
+
+	myHandler := func() service.Handler {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			return nil
+		}
+	}
+
+	r.Handle("/handler1", ScopeNone(), r.GET(myHandler, Auth(false)))
+	r.Handle("/handler2", ScopeNone(), r.GET(myHandler, Auth(false), Successor("/handler1"), Sunset("2020-06-01")))
+	r.computeRouteCatalog()
+
+	require.Len(t, r.routeCatalog, 2)
+	assert.Equal(t, "/handler1", r.routeCatalog[0].Route)
+	assert.Equal(t, []string{sdk.APIVersion1, sdk.APIVersion2}, r.routeCatalog[0].Versions)
+	assert.Equal(t, "/handler2", r.routeCatalog[1].Route)
+	assert.True(t, r.routeCatalog[1].Deprecated)
+	assert.Equal(t, []string{sdk.APIVersion1}, r.routeCatalog[1].Versions)
+	assert.Equal(t, "/handler1", r.routeCatalog[1].Successor)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := serve("/v2/handler1")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get("Deprecation"))
+
+	for _, path := range []string{"/handler1", "/v1/handler1"} {
+		rec = serve(path)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+		assert.Equal(t, "Fri, 01 Jan 2021 00:00:00 GMT", rec.Header().Get("Sunset"))
+		assert.Equal(t, "<http://cds.local/v2/handler1>; rel=\"successor-version\"", rec.Header().Get("Link"))
+	}
+
+	rec = serve("/handler2")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "Mon, 01 Jun 2020 00:00:00 GMT", rec.Header().Get("Sunset"))
+	assert.Equal(t, "<http://cds.local/v2/handler1>; rel=\"successor-version\"", rec.Header().Get("Link"))
+
+	rec = serve("/v2/handler2")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
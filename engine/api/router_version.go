@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+// ServeHTTP serves the request with the routes of the API version given in its path. Requests without version in
+// their path are served as API v1 requests.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	version := sdk.APIVersion1
+	if strings.HasPrefix(req.URL.Path, r.Prefix) {
+		path := strings.TrimPrefix(req.URL.Path, r.Prefix)
+		for _, v := range sdk.APIVersions {
+			if path != "/"+v && !strings.HasPrefix(path, "/"+v+"/") {
+				continue
+			}
+			version = v
+			req.URL.Path = r.Prefix + strings.TrimPrefix(path, "/"+v)
+			if req.URL.RawPath != "" {
+				req.URL.RawPath = r.Prefix + strings.TrimPrefix(strings.TrimPrefix(req.URL.RawPath, r.Prefix), "/"+v)
+			}
+			break
+		}
+	}
+	r.Mux.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), contextAPIVersion, version)))
+}
+
+func getAPIVersion(ctx context.Context) string {
+	v, ok := ctx.Value(contextAPIVersion).(string)
+	if !ok {
+		return sdk.APIVersion1
+	}
+	return v
+}
+
+// checkRouteVersion returns an error if the route was removed from the API version of the request, else it sets the
+// deprecation headers of the route on the response.
+func (r *Router) checkRouteVersion(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) error {
+	version := getAPIVersion(ctx)
+
+	// Deprecated routes are not part of the API v2
+	if rc.IsDeprecated && version != sdk.APIVersion1 {
+		if rc.Successor != "" {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "route %s %s was removed from API %s, use %s instead", rc.Method, rc.CleanURL, version, rc.Successor)
+		}
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "route %s %s was removed from API %s", rc.Method, rc.CleanURL, version)
+	}
+
+	switch {
+	case rc.IsDeprecated:
+		w.Header().Set(cdsclient.ResponseDeprecationHeader, "true")
+		if rc.Sunset != nil {
+			w.Header().Set(cdsclient.ResponseSunsetHeader, rc.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if rc.Successor != "" {
+			w.Header().Add(cdsclient.ResponseLinkHeader, fmt.Sprintf("<%s/%s%s>; rel=\"successor-version\"", r.URL, sdk.APIVersion2, rc.Successor))
+		}
+	case version == sdk.APIVersion1 && r.V1Sunset != nil:
+		// The whole API v1 is deprecated, the same route exists in API v2
+		w.Header().Set(cdsclient.ResponseDeprecationHeader, "true")
+		w.Header().Set(cdsclient.ResponseSunsetHeader, r.V1Sunset.UTC().Format(http.TimeFormat))
+		w.Header().Add(cdsclient.ResponseLinkHeader, fmt.Sprintf("<%s/%s%s>; rel=\"successor-version\"", r.URL, sdk.APIVersion2, strings.TrimPrefix(req.URL.Path, r.Prefix)))
+	}
+	return nil
+}
+
+// computeRouteCatalog iterates over declared handlers for routers and populates the route catalog.
+func (r *Router) computeRouteCatalog() {
+	routes := make([]sdk.APIRoute, 0, len(r.mapRouterConfigs))
+	for _, cfg := range r.mapRouterConfigs {
+		for method, handler := range cfg.Config {
+			route := sdk.APIRoute{
+				Route:      strings.TrimPrefix(handler.CleanURL, r.Prefix),
+				Method:     method,
+				Handler:    handler.Name,
+				Scopes:     handler.AllowedScopes,
+				Versions:   []string{sdk.APIVersion1, sdk.APIVersion2},
+				Deprecated: handler.IsDeprecated,
+				Sunset:     handler.Sunset,
+				Successor:  handler.Successor,
+			}
+			if handler.IsDeprecated {
+				route.Versions = []string{sdk.APIVersion1}
+			}
+			routes = append(routes, route)
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Route != routes[j].Route {
+			return routes[i].Route < routes[j].Route
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	r.routeCatalog = routes
+}
+
+// Sunset sets the date at which a deprecated route will be removed, with format 2006-01-02.
+func Sunset(date string) HandlerConfigParam {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		panic(fmt.Sprintf("invalid sunset date %s: %v", date, err))
+	}
+	f := func(rc *service.HandlerConfig) {
+		rc.Sunset = &t
+	}
+	return f
+}
+
+// Successor marks the route as deprecated in favor of given route of the API v2.
+func Successor(route string) HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.IsDeprecated = true
+		rc.Successor = route
+	}
+	return f
+}
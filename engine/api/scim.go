@@ -0,0 +1,969 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/scim"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// checkSCIMEnabled returns ErrNotFound if the SCIM provisioning is not configured, so the endpoints can't be reached
+// with an empty token.
+func (api *API) checkSCIMEnabled() error {
+	if !api.Config.Auth.SCIM.Enabled || api.Config.Auth.SCIM.Token == "" {
+		return sdk.WithStack(sdk.ErrNotFound)
+	}
+	return nil
+}
+
+// scimAudit adds an entry to the audit trail of the provisioning.
+func scimAudit(db gorp.SqlExecutor, operation, resourceType, resourceID, resourceName, detail string) error {
+	return scim.InsertAudit(db, &sdk.SCIMAudit{
+		Operation:    operation,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		ResourceName: resourceName,
+		Detail:       detail,
+	})
+}
+
+// scimConflict records the conflict in the audit trail outside of the current transaction, then returns a conflict
+// error to the identity provider.
+func (api *API) scimConflict(ctx context.Context, resourceType, resourceName, detail string) error {
+	if err := scimAudit(api.mustDB(), sdk.SCIMAuditOperationConflict, resourceType, "", resourceName, detail); err != nil {
+		log.Error(ctx, "scimConflict> %v", err)
+	}
+	return sdk.NewErrorFrom(sdk.ErrConflict, "%s", detail)
+}
+
+func (api *API) scimLocation(resourceType, id string) string {
+	return fmt.Sprintf("%s/scim/v2/%ss/%s", api.Config.URL.API, resourceType, id)
+}
+
+// scimPage returns the bounds of the page requested with startIndex and count parameters, startIndex is 1-based.
+func scimPage(r *http.Request, total int) (int, int, error) {
+	startIndex, err := FormInt(r, "startIndex")
+	if err != nil {
+		return 0, 0, err
+	}
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	count := sdk.SCIMMaxCount
+	if FormString(r, "count") != "" {
+		count, err = FormInt(r, "count")
+		if err != nil {
+			return 0, 0, err
+		}
+		if count < 0 || count > sdk.SCIMMaxCount {
+			count = sdk.SCIMMaxCount
+		}
+	}
+	start := startIndex - 1
+	if start > total {
+		start = total
+	}
+	end := start + count
+	if end > total {
+		end = total
+	}
+	return start, end, nil
+}
+
+func writeSCIMList(w http.ResponseWriter, resources interface{}, start, total, itemsPerPage int) error {
+	return service.WriteJSON(w, sdk.SCIMListResponse{
+		Schemas:      []string{sdk.SCIMSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   start + 1,
+		ItemsPerPage: itemsPerPage,
+		Resources:    resources,
+	}, http.StatusOK)
+}
+
+func (api *API) toSCIMUser(u sdk.AuthentifiedUser, l scim.UserLink) sdk.SCIMUser {
+	active := l.Active
+	created := l.Created
+	return sdk.SCIMUser{
+		Schemas:     []string{sdk.SCIMSchemaUser},
+		ID:          u.ID,
+		ExternalID:  l.ExternalID,
+		UserName:    u.Username,
+		Name:        sdk.SCIMName{Formatted: u.Fullname},
+		DisplayName: u.Fullname,
+		Emails:      []sdk.SCIMEmail{{Value: u.GetEmail(), Type: "work", Primary: true}},
+		Active:      &active,
+		Meta: sdk.SCIMMeta{
+			ResourceType: sdk.SCIMResourceTypeUser,
+			Created:      &created,
+			Location:     api.scimLocation(sdk.SCIMResourceTypeUser, u.ID),
+		},
+	}
+}
+
+func scimUserMatch(u sdk.SCIMUser, f *sdk.SCIMFilter) bool {
+	if f == nil {
+		return true
+	}
+	switch f.Attribute {
+	case "username":
+		return strings.EqualFold(u.UserName, f.Value)
+	case "externalid":
+		return u.ExternalID == f.Value
+	case "displayname":
+		return strings.EqualFold(u.DisplayName, f.Value)
+	case "emails", "emails.value":
+		return strings.EqualFold(u.PrimaryEmail(), f.Value)
+	}
+	return false
+}
+
+// loadSCIMUser returns the user for given id and its link, ErrNotFound if the user was not provisioned by the
+// identity provider.
+func loadSCIMUser(ctx context.Context, db gorp.SqlExecutor, id string) (*sdk.AuthentifiedUser, *scim.UserLink, error) {
+	l, err := scim.LoadUserLinkByUserID(ctx, db, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	u, err := user.LoadByID(ctx, db, id, user.LoadOptions.WithContacts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return u, l, nil
+}
+
+// checkSCIMLastAdmin returns an error if given user is the last CDS admin.
+func checkSCIMLastAdmin(db gorp.SqlExecutor, u *sdk.AuthentifiedUser) error {
+	if u.Ring != sdk.UserRingAdmin {
+		return nil
+	}
+	count, err := user.CountAdmin(db)
+	if err != nil {
+		return err
+	}
+	if count < 2 {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "can't remove the last admin")
+	}
+	return nil
+}
+
+// checkSCIMEmailAvailable returns a conflict if given email is used by another user.
+func (api *API) checkSCIMEmailAvailable(ctx context.Context, db gorp.SqlExecutor, username, email, userID string) error {
+	c, err := user.LoadContactByTypeAndValue(ctx, db, sdk.UserContactTypeEmail, email)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	if c != nil && c.UserID != userID {
+		return api.scimConflict(ctx, sdk.SCIMResourceTypeUser, username, fmt.Sprintf("email %s is already used by another user", email))
+	}
+	return nil
+}
+
+// deactivateSCIMUser deletes all the consumers of given user, this also deletes its sessions.
+func deactivateSCIMUser(ctx context.Context, db gorp.SqlExecutor, u *sdk.AuthentifiedUser) error {
+	cs, err := authentication.LoadConsumersByUserID(ctx, db, u.ID)
+	if err != nil {
+		return err
+	}
+	for i := range cs {
+		if err := authentication.DeleteConsumerByID(db, cs[i].ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateSCIMUser applies the attributes of the SCIM user on the CDS user and its link.
+func (api *API) updateSCIMUser(ctx context.Context, db gorp.SqlExecutor, u *sdk.AuthentifiedUser, l *scim.UserLink, su sdk.SCIMUser) error {
+	if err := su.IsValid(); err != nil {
+		return err
+	}
+
+	var changes []string
+	if u.Username != su.UserName {
+		other, err := user.LoadByUsername(ctx, db, su.UserName)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrUserNotFound) {
+			return err
+		}
+		if other != nil && other.ID != u.ID {
+			return api.scimConflict(ctx, sdk.SCIMResourceTypeUser, su.UserName, fmt.Sprintf("cannot rename user %s, username %s is already used", u.Username, su.UserName))
+		}
+		changes = append(changes, fmt.Sprintf("username %s", su.UserName))
+		u.Username = su.UserName
+	}
+	if fullname := su.Fullname(); u.Fullname != fullname {
+		changes = append(changes, fmt.Sprintf("fullname %s", fullname))
+		u.Fullname = fullname
+	}
+	if len(changes) > 0 {
+		if err := user.Update(ctx, db, u); err != nil {
+			return err
+		}
+	}
+
+	if email := su.PrimaryEmail(); !strings.EqualFold(u.GetEmail(), email) {
+		if err := api.checkSCIMEmailAvailable(ctx, db, u.Username, email, u.ID); err != nil {
+			return err
+		}
+		for i := range u.Contacts {
+			if u.Contacts[i].Type != sdk.UserContactTypeEmail || !u.Contacts[i].Primary {
+				continue
+			}
+			u.Contacts[i].Value = email
+			u.Contacts[i].Verified = true
+			if err := user.UpdateContact(ctx, db, &u.Contacts[i]); err != nil {
+				return err
+			}
+		}
+		changes = append(changes, fmt.Sprintf("email %s", email))
+	}
+
+	if l.ExternalID != su.ExternalID {
+		changes = append(changes, fmt.Sprintf("externalId %s", su.ExternalID))
+		l.ExternalID = su.ExternalID
+		if err := scim.UpdateUserLink(db, l); err != nil {
+			return err
+		}
+	}
+	if len(changes) > 0 {
+		if err := scimAudit(db, sdk.SCIMAuditOperationUpdate, sdk.SCIMResourceTypeUser, u.ID, u.Username, strings.Join(changes, ", ")); err != nil {
+			return err
+		}
+	}
+
+	if l.Active != su.IsActive() {
+		l.Active = su.IsActive()
+		operation := sdk.SCIMAuditOperationReactivate
+		if !l.Active {
+			if err := checkSCIMLastAdmin(db, u); err != nil {
+				return err
+			}
+			if err := deactivateSCIMUser(ctx, db, u); err != nil {
+				return err
+			}
+			operation = sdk.SCIMAuditOperationDeactivate
+		}
+		if err := scim.UpdateUserLink(db, l); err != nil {
+			return err
+		}
+		if err := scimAudit(db, operation, sdk.SCIMResourceTypeUser, u.ID, u.Username, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (api *API) getSCIMUsersHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		filter, err := sdk.ParseSCIMFilter(FormString(r, "filter"))
+		if err != nil {
+			return err
+		}
+
+		links, err := scim.LoadUserLinks(ctx, api.mustDB())
+		if err != nil {
+			return err
+		}
+		ids := make([]string, len(links))
+		for i := range links {
+			ids[i] = links[i].AuthentifiedUserID
+		}
+		users, err := user.LoadAllByIDs(ctx, api.mustDB(), ids, user.LoadOptions.WithContacts)
+		if err != nil {
+			return err
+		}
+		mUsers := make(map[string]sdk.AuthentifiedUser, len(users))
+		for i := range users {
+			mUsers[users[i].ID] = users[i]
+		}
+
+		res := make([]sdk.SCIMUser, 0, len(links))
+		for i := range links {
+			u, ok := mUsers[links[i].AuthentifiedUserID]
+			if !ok {
+				continue
+			}
+			if su := api.toSCIMUser(u, links[i]); scimUserMatch(su, filter) {
+				res = append(res, su)
+			}
+		}
+
+		start, end, err := scimPage(r, len(res))
+		if err != nil {
+			return err
+		}
+		return writeSCIMList(w, res[start:end], start, len(res), end-start)
+	}
+}
+
+func (api *API) getSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		u, l, err := loadSCIMUser(ctx, api.mustDB(), mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, api.toSCIMUser(*u, *l), http.StatusOK)
+	}
+}
+
+// postSCIMUserHandler provisions a user. A local user with the same username and email is adopted, any other
+// existing user with the same username or email is a conflict.
+func (api *API) postSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		var su sdk.SCIMUser
+		if err := service.UnmarshalBody(r, &su); err != nil {
+			return err
+		}
+		if err := su.IsValid(); err != nil {
+			return err
+		}
+		email := su.PrimaryEmail()
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		u, err := user.LoadByUsername(ctx, tx, su.UserName, user.LoadOptions.WithContacts)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrUserNotFound) {
+			return err
+		}
+
+		operation := sdk.SCIMAuditOperationCreate
+		if u != nil {
+			if _, err := scim.LoadUserLinkByUserID(ctx, tx, u.ID); err == nil {
+				return api.scimConflict(ctx, sdk.SCIMResourceTypeUser, su.UserName, fmt.Sprintf("user %s is already provisioned", su.UserName))
+			} else if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return err
+			}
+			if !strings.EqualFold(u.GetEmail(), email) {
+				return api.scimConflict(ctx, sdk.SCIMResourceTypeUser, su.UserName, fmt.Sprintf("local user %s exists with a different email", su.UserName))
+			}
+			operation = sdk.SCIMAuditOperationAdopt
+		} else {
+			if err := api.checkSCIMEmailAvailable(ctx, tx, su.UserName, email, ""); err != nil {
+				return err
+			}
+
+			u = &sdk.AuthentifiedUser{
+				Ring:     sdk.UserRingUser,
+				Username: su.UserName,
+				Fullname: su.Fullname(),
+			}
+			if err := user.Insert(ctx, tx, u); err != nil {
+				return err
+			}
+			contact := sdk.UserContact{
+				UserID:   u.ID,
+				Type:     sdk.UserContactTypeEmail,
+				Value:    email,
+				Primary:  true,
+				Verified: true,
+			}
+			if err := user.InsertContact(ctx, tx, &contact); err != nil {
+				return err
+			}
+			u.Contacts = sdk.UserContacts{contact}
+			if err := group.CheckUserInDefaultGroup(ctx, tx, u.ID); err != nil {
+				return err
+			}
+		}
+
+		l := scim.UserLink{
+			AuthentifiedUserID: u.ID,
+			ExternalID:         su.ExternalID,
+			Active:             su.IsActive(),
+		}
+		if err := scim.InsertUserLink(tx, &l); err != nil {
+			return err
+		}
+		if err := scimAudit(tx, operation, sdk.SCIMResourceTypeUser, u.ID, u.Username, su.ExternalID); err != nil {
+			return err
+		}
+		if !l.Active {
+			if err := deactivateSCIMUser(ctx, tx, u); err != nil {
+				return err
+			}
+			if err := scimAudit(tx, sdk.SCIMAuditOperationDeactivate, sdk.SCIMResourceTypeUser, u.ID, u.Username, ""); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, api.toSCIMUser(*u, l), http.StatusCreated)
+	}
+}
+
+func (api *API) putSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		var su sdk.SCIMUser
+		if err := service.UnmarshalBody(r, &su); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		u, l, err := loadSCIMUser(ctx, tx, mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		if err := api.updateSCIMUser(ctx, tx, u, l, su); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, api.toSCIMUser(*u, *l), http.StatusOK)
+	}
+}
+
+func (api *API) patchSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		var patch sdk.SCIMPatchOp
+		if err := service.UnmarshalBody(r, &patch); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		u, l, err := loadSCIMUser(ctx, tx, mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		su := api.toSCIMUser(*u, *l)
+		if err := su.ApplyPatch(patch.Operations); err != nil {
+			return err
+		}
+		if err := api.updateSCIMUser(ctx, tx, u, l, su); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, api.toSCIMUser(*u, *l), http.StatusOK)
+	}
+}
+
+func (api *API) deleteSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		u, _, err := loadSCIMUser(ctx, tx, mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		if err := checkSCIMLastAdmin(tx, u); err != nil {
+			return err
+		}
+
+		// The link of the user is deleted in cascade
+		if err := user.DeleteByID(tx, u.ID); err != nil {
+			return sdk.WrapError(err, "cannot delete user")
+		}
+		if err := scimAudit(tx, sdk.SCIMAuditOperationDelete, sdk.SCIMResourceTypeUser, u.ID, u.Username, ""); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+}
+
+// toSCIMGroup returns the SCIM group for given group, only the members provisioned by the identity provider are
+// listed so that the members added locally are never removed.
+func (api *API) toSCIMGroup(g sdk.Group, l scim.GroupLink, userLinks map[string]scim.UserLink) sdk.SCIMGroup {
+	created := l.Created
+	id := strconv.FormatInt(g.ID, 10)
+	sg := sdk.SCIMGroup{
+		Schemas:     []string{sdk.SCIMSchemaGroup},
+		ID:          id,
+		ExternalID:  l.ExternalID,
+		DisplayName: g.Name,
+		Meta: sdk.SCIMMeta{
+			ResourceType: sdk.SCIMResourceTypeGroup,
+			Created:      &created,
+			Location:     api.scimLocation(sdk.SCIMResourceTypeGroup, id),
+		},
+	}
+	for _, m := range g.Members {
+		if _, ok := userLinks[m.ID]; ok {
+			sg.Members = append(sg.Members, sdk.SCIMMember{Value: m.ID, Display: m.Username})
+		}
+	}
+	return sg
+}
+
+func scimGroupMatch(g sdk.SCIMGroup, f *sdk.SCIMFilter) bool {
+	if f == nil {
+		return true
+	}
+	switch f.Attribute {
+	case "displayname":
+		return strings.EqualFold(g.DisplayName, f.Value) || strings.EqualFold(g.DisplayName, sdk.SCIMGroup{DisplayName: f.Value}.GroupName())
+	case "externalid":
+		return g.ExternalID == f.Value
+	}
+	return false
+}
+
+// loadSCIMUserLinks returns the links of all the provisioned users by user id.
+func loadSCIMUserLinks(ctx context.Context, db gorp.SqlExecutor) (map[string]scim.UserLink, error) {
+	links, err := scim.LoadUserLinks(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]scim.UserLink, len(links))
+	for i := range links {
+		res[links[i].AuthentifiedUserID] = links[i]
+	}
+	return res, nil
+}
+
+// loadSCIMGroup returns the group for given id and its link, ErrNotFound if the group was not provisioned by the
+// identity provider.
+func loadSCIMGroup(ctx context.Context, db gorp.SqlExecutor, id string) (*sdk.Group, *scim.GroupLink, error) {
+	groupID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	l, err := scim.LoadGroupLinkByGroupID(ctx, db, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	g, err := group.LoadByID(ctx, db, groupID, group.LoadOptions.WithMembers)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g, l, nil
+}
+
+// updateSCIMGroup applies the attributes of the SCIM group on the CDS group and its link, and synchronizes the
+// members provisioned by the identity provider.
+func (api *API) updateSCIMGroup(ctx context.Context, db gorp.SqlExecutor, g *sdk.Group, l *scim.GroupLink, sg sdk.SCIMGroup, userLinks map[string]scim.UserLink) error {
+	id := strconv.FormatInt(g.ID, 10)
+	current := api.toSCIMGroup(*g, *l, userLinks)
+
+	var changes []string
+	if name := sg.GroupName(); name != g.Name {
+		newGroup := *g
+		newGroup.Name = name
+		if err := newGroup.IsValid(); err != nil {
+			return err
+		}
+		other, err := group.LoadByName(ctx, db, name)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if other != nil {
+			return api.scimConflict(ctx, sdk.SCIMResourceTypeGroup, name, fmt.Sprintf("cannot rename group %s, name %s is already used", g.Name, name))
+		}
+		if err := group.Update(ctx, db, &newGroup); err != nil {
+			return err
+		}
+		changes = append(changes, fmt.Sprintf("name %s", name))
+		g.Name = name
+	}
+	if l.ExternalID != sg.ExternalID {
+		changes = append(changes, fmt.Sprintf("externalId %s", sg.ExternalID))
+		l.ExternalID = sg.ExternalID
+		if err := scim.UpdateGroupLink(db, l); err != nil {
+			return err
+		}
+	}
+	if len(changes) > 0 {
+		if err := scimAudit(db, sdk.SCIMAuditOperationUpdate, sdk.SCIMResourceTypeGroup, id, g.Name, strings.Join(changes, ", ")); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range sg.Members {
+		if current.HasMember(m.Value) {
+			continue
+		}
+		if _, ok := userLinks[m.Value]; !ok {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "user %s was not provisioned", m.Value)
+		}
+		u, err := user.LoadByID(ctx, db, m.Value)
+		if err != nil {
+			return err
+		}
+		existing, err := group.LoadLinkGroupUserForGroupIDAndUserID(ctx, db, g.ID, u.ID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if existing == nil {
+			if err := group.InsertLinkGroupUser(ctx, db, &group.LinkGroupUser{
+				GroupID:            g.ID,
+				AuthentifiedUserID: u.ID,
+			}); err != nil {
+				return err
+			}
+			if err := authentication.ConsumerRestoreInvalidatedGroupForUser(ctx, db, g.ID, u.ID); err != nil {
+				return err
+			}
+		}
+		if err := scimAudit(db, sdk.SCIMAuditOperationMemberAdd, sdk.SCIMResourceTypeGroup, id, g.Name, u.Username); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range current.Members {
+		if sg.HasMember(m.Value) {
+			continue
+		}
+		u, err := user.LoadByID(ctx, db, m.Value)
+		if err != nil {
+			return err
+		}
+		link, err := group.LoadLinkGroupUserForGroupIDAndUserID(ctx, db, g.ID, u.ID)
+		if err != nil {
+			return err
+		}
+		if err := group.DeleteLinkGroupUser(db, link); err != nil {
+			return err
+		}
+		if err := authentication.ConsumerInvalidateGroupForUser(ctx, db, g, u); err != nil {
+			return err
+		}
+		if err := scimAudit(db, sdk.SCIMAuditOperationMemberRemove, sdk.SCIMResourceTypeGroup, id, g.Name, u.Username); err != nil {
+			return err
+		}
+	}
+
+	return group.LoadOptions.WithMembers(ctx, db, g)
+}
+
+func (api *API) getSCIMGroupsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		filter, err := sdk.ParseSCIMFilter(FormString(r, "filter"))
+		if err != nil {
+			return err
+		}
+
+		links, err := scim.LoadGroupLinks(ctx, api.mustDB())
+		if err != nil {
+			return err
+		}
+		userLinks, err := loadSCIMUserLinks(ctx, api.mustDB())
+		if err != nil {
+			return err
+		}
+		ids := make([]int64, len(links))
+		for i := range links {
+			ids[i] = links[i].GroupID
+		}
+		groups, err := group.LoadAllByIDs(ctx, api.mustDB(), ids, group.LoadOptions.WithMembers)
+		if err != nil {
+			return err
+		}
+		mGroups := make(map[int64]sdk.Group, len(groups))
+		for i := range groups {
+			mGroups[groups[i].ID] = groups[i]
+		}
+
+		res := make([]sdk.SCIMGroup, 0, len(links))
+		for i := range links {
+			g, ok := mGroups[links[i].GroupID]
+			if !ok {
+				continue
+			}
+			if sg := api.toSCIMGroup(g, links[i], userLinks); scimGroupMatch(sg, filter) {
+				res = append(res, sg)
+			}
+		}
+
+		start, end, err := scimPage(r, len(res))
+		if err != nil {
+			return err
+		}
+		return writeSCIMList(w, res[start:end], start, len(res), end-start)
+	}
+}
+
+func (api *API) getSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		g, l, err := loadSCIMGroup(ctx, api.mustDB(), mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		userLinks, err := loadSCIMUserLinks(ctx, api.mustDB())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, api.toSCIMGroup(*g, *l, userLinks), http.StatusOK)
+	}
+}
+
+// postSCIMGroupHandler provisions a group. An existing local group with the same name is adopted only if allowed by
+// the configuration, else it is a conflict.
+func (api *API) postSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		var sg sdk.SCIMGroup
+		if err := service.UnmarshalBody(r, &sg); err != nil {
+			return err
+		}
+		name := sg.GroupName()
+		if err := (sdk.Group{Name: name}).IsValid(); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		g, err := group.LoadByName(ctx, tx, name, group.LoadOptions.WithMembers)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+
+		operation := sdk.SCIMAuditOperationCreate
+		if g != nil {
+			if _, err := scim.LoadGroupLinkByGroupID(ctx, tx, g.ID); err == nil {
+				return api.scimConflict(ctx, sdk.SCIMResourceTypeGroup, name, fmt.Sprintf("group %s is already provisioned", name))
+			} else if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return err
+			}
+			if !api.Config.Auth.SCIM.AdoptLocalGroups || (group.DefaultGroup != nil && group.DefaultGroup.ID == g.ID) {
+				return api.scimConflict(ctx, sdk.SCIMResourceTypeGroup, name, fmt.Sprintf("local group %s already exists", name))
+			}
+			operation = sdk.SCIMAuditOperationAdopt
+		} else {
+			g = &sdk.Group{Name: name}
+			if err := group.Insert(ctx, tx, g); err != nil {
+				return err
+			}
+		}
+
+		l := scim.GroupLink{
+			GroupID:    g.ID,
+			ExternalID: sg.ExternalID,
+		}
+		if err := scim.InsertGroupLink(tx, &l); err != nil {
+			return err
+		}
+		if err := scimAudit(tx, operation, sdk.SCIMResourceTypeGroup, strconv.FormatInt(g.ID, 10), g.Name, sg.ExternalID); err != nil {
+			return err
+		}
+
+		userLinks, err := loadSCIMUserLinks(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if err := api.updateSCIMGroup(ctx, tx, g, &l, sg, userLinks); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, api.toSCIMGroup(*g, l, userLinks), http.StatusCreated)
+	}
+}
+
+func (api *API) putSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		var sg sdk.SCIMGroup
+		if err := service.UnmarshalBody(r, &sg); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		g, l, err := loadSCIMGroup(ctx, tx, mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		userLinks, err := loadSCIMUserLinks(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if err := api.updateSCIMGroup(ctx, tx, g, l, sg, userLinks); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, api.toSCIMGroup(*g, *l, userLinks), http.StatusOK)
+	}
+}
+
+func (api *API) patchSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		var patch sdk.SCIMPatchOp
+		if err := service.UnmarshalBody(r, &patch); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		g, l, err := loadSCIMGroup(ctx, tx, mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		userLinks, err := loadSCIMUserLinks(ctx, tx)
+		if err != nil {
+			return err
+		}
+		sg := api.toSCIMGroup(*g, *l, userLinks)
+		if err := sg.ApplyPatch(patch.Operations); err != nil {
+			return err
+		}
+		if err := api.updateSCIMGroup(ctx, tx, g, l, sg, userLinks); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, api.toSCIMGroup(*g, *l, userLinks), http.StatusOK)
+	}
+}
+
+func (api *API) deleteSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if err := api.checkSCIMEnabled(); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		g, _, err := loadSCIMGroup(ctx, tx, mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+
+		// Remove the group from all consumers, the link of the group is deleted in cascade
+		if err := authentication.ConsumerRemoveGroup(ctx, tx, g); err != nil {
+			return err
+		}
+		if err := group.Delete(ctx, tx, g); err != nil {
+			return sdk.WrapError(err, "cannot delete group")
+		}
+		if err := scimAudit(tx, sdk.SCIMAuditOperationDelete, sdk.SCIMResourceTypeGroup, strconv.FormatInt(g.ID, 10), g.Name, ""); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+}
+
+func (api *API) getAdminSCIMAuditHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		limit, err := FormInt(r, "limit")
+		if err != nil {
+			return err
+		}
+		if limit <= 0 || limit > 1000 {
+			limit = 100
+		}
+
+		audits, err := scim.LoadAudits(ctx, api.mustDB(), limit)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, audits, http.StatusOK)
+	}
+}
@@ -0,0 +1,135 @@
+package scim
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadUserLinks returns the links of all the users provisioned by the identity provider, oldest first.
+func LoadUserLinks(ctx context.Context, db gorp.SqlExecutor) ([]UserLink, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM scim_user ORDER BY id")
+	var ls []UserLink
+	if err := gorpmapping.GetAll(ctx, db, query, &ls); err != nil {
+		return nil, sdk.WrapError(err, "cannot load scim users")
+	}
+	return ls, nil
+}
+
+// LoadUserLinkByUserID returns the link of given user, ErrNotFound if the user was not provisioned by the identity
+// provider.
+func LoadUserLinkByUserID(ctx context.Context, db gorp.SqlExecutor, userID string) (*UserLink, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM scim_user WHERE authentified_user_id = $1").Args(userID)
+	var l UserLink
+	found, err := gorpmapping.Get(ctx, db, query, &l)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load scim user %s", userID)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &l, nil
+}
+
+// InsertUserLink inserts a link for a user provisioned by the identity provider.
+func InsertUserLink(db gorp.SqlExecutor, l *UserLink) error {
+	l.Created = time.Now()
+	if err := gorpmapping.Insert(db, l); err != nil {
+		return sdk.WrapError(err, "cannot insert scim user %s", l.AuthentifiedUserID)
+	}
+	return nil
+}
+
+// UpdateUserLink updates the link of a user provisioned by the identity provider.
+func UpdateUserLink(db gorp.SqlExecutor, l *UserLink) error {
+	if err := gorpmapping.Update(db, l); err != nil {
+		return sdk.WrapError(err, "cannot update scim user %s", l.AuthentifiedUserID)
+	}
+	return nil
+}
+
+// CheckUserActive returns an error if given user was deactivated by the identity provider.
+func CheckUserActive(ctx context.Context, db gorp.SqlExecutor, userID string) error {
+	l, err := LoadUserLinkByUserID(ctx, db, userID)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !l.Active {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "user was deactivated by the identity provider")
+	}
+	return nil
+}
+
+// LoadGroupLinks returns the links of all the groups provisioned by the identity provider, oldest first.
+func LoadGroupLinks(ctx context.Context, db gorp.SqlExecutor) ([]GroupLink, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM scim_group ORDER BY id")
+	var ls []GroupLink
+	if err := gorpmapping.GetAll(ctx, db, query, &ls); err != nil {
+		return nil, sdk.WrapError(err, "cannot load scim groups")
+	}
+	return ls, nil
+}
+
+// LoadGroupLinkByGroupID returns the link of given group, ErrNotFound if the group was not provisioned by the
+// identity provider.
+func LoadGroupLinkByGroupID(ctx context.Context, db gorp.SqlExecutor, groupID int64) (*GroupLink, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM scim_group WHERE group_id = $1").Args(groupID)
+	var l GroupLink
+	found, err := gorpmapping.Get(ctx, db, query, &l)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load scim group %d", groupID)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &l, nil
+}
+
+// InsertGroupLink inserts a link for a group provisioned by the identity provider.
+func InsertGroupLink(db gorp.SqlExecutor, l *GroupLink) error {
+	l.Created = time.Now()
+	if err := gorpmapping.Insert(db, l); err != nil {
+		return sdk.WrapError(err, "cannot insert scim group %d", l.GroupID)
+	}
+	return nil
+}
+
+// UpdateGroupLink updates the link of a group provisioned by the identity provider.
+func UpdateGroupLink(db gorp.SqlExecutor, l *GroupLink) error {
+	if err := gorpmapping.Update(db, l); err != nil {
+		return sdk.WrapError(err, "cannot update scim group %d", l.GroupID)
+	}
+	return nil
+}
+
+// InsertAudit adds an entry to the audit trail of the provisioning.
+func InsertAudit(db gorp.SqlExecutor, a *sdk.SCIMAudit) error {
+	a.Created = time.Now()
+	dba := dbAudit(*a)
+	if err := gorpmapping.Insert(db, &dba); err != nil {
+		return sdk.WrapError(err, "cannot insert scim audit")
+	}
+	a.ID = dba.ID
+	return nil
+}
+
+// LoadAudits returns the last entries of the audit trail of the provisioning, most recent first.
+func LoadAudits(ctx context.Context, db gorp.SqlExecutor, limit int) ([]sdk.SCIMAudit, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM scim_audit ORDER BY id DESC LIMIT $1").Args(limit)
+	var as []dbAudit
+	if err := gorpmapping.GetAll(ctx, db, query, &as); err != nil {
+		return nil, sdk.WrapError(err, "cannot load scim audits")
+	}
+	res := make([]sdk.SCIMAudit, len(as))
+	for i := range as {
+		res[i] = sdk.SCIMAudit(as[i])
+	}
+	return res, nil
+}
@@ -0,0 +1,35 @@
+package scim
+
+import (
+	"time"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// UserLink links a CDS user to the identity provider that provisioned it.
+type UserLink struct {
+	ID                 int64     `db:"id"`
+	AuthentifiedUserID string    `db:"authentified_user_id"`
+	ExternalID         string    `db:"external_id"`
+	Active             bool      `db:"active"`
+	Created            time.Time `db:"created"`
+}
+
+// GroupLink links a CDS group to the identity provider that provisioned it.
+type GroupLink struct {
+	ID         int64     `db:"id"`
+	GroupID    int64     `db:"group_id"`
+	ExternalID string    `db:"external_id"`
+	Created    time.Time `db:"created"`
+}
+
+type dbAudit sdk.SCIMAudit
+
+func init() {
+	gorpmapping.Register(
+		gorpmapping.New(UserLink{}, "scim_user", true, "id"),
+		gorpmapping.New(GroupLink{}, "scim_group", true, "id"),
+		gorpmapping.New(dbAudit{}, "scim_audit", true, "id"),
+	)
+}
@@ -41,6 +41,7 @@ func (api *API) Status(ctx context.Context) sdk.MonitoringStatus {
 	m.Lines = append(m.Lines, api.SharedStorage.Status(ctx))
 	m.Lines = append(m.Lines, mail.Status(ctx))
 	m.Lines = append(m.Lines, api.DBConnectionFactory.Status(ctx))
+	m.Lines = append(m.Lines, api.DBConnectionFactory.ReplicaStatus())
 	m.Lines = append(m.Lines, workermodel.Status(api.mustDB()))
 	m.Lines = append(m.Lines, migrate.Status(api.mustDB()))
 
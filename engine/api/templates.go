@@ -21,6 +21,7 @@ import (
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/exportentities"
+	v2 "github.com/ovh/cds/sdk/exportentities/v2"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -714,6 +715,166 @@ func (api *API) getTemplateInstanceHandler() service.Handler {
 	}
 }
 
+func (api *API) getTemplateInstanceDriftHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		workflowName := vars["permWorkflowName"]
+
+		proj, err := project.Load(api.mustDB(), key, project.LoadOptions.WithIntegrations)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load projet")
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *proj, workflowName, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", workflowName)
+		}
+
+		wti, err := workflowtemplate.LoadInstanceByWorkflowID(ctx, api.mustDB(), wf.ID)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return sdk.NewErrorFrom(sdk.ErrNotFound, "workflow %s was not generated by a template", workflowName)
+			}
+			return err
+		}
+
+		if err := computeTemplateDrift(ctx, api.mustDB(), *proj, *wf, wti); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, wti.Drift, http.StatusOK)
+	}
+}
+
+// postTemplateInstanceReapplyHandler applies the last version of the template of a workflow. With keepChanges, the
+// changes made on the workflow since the template was applied are applied again on the generated workflow, and the
+// pipelines that were changed are not overridden.
+func (api *API) postTemplateInstanceReapplyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		workflowName := vars["permWorkflowName"]
+		keepChanges := FormBool(r, "keepChanges")
+
+		p, err := project.Load(api.mustDB(), key,
+			project.LoadOptions.WithGroups,
+			project.LoadOptions.WithApplications,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithPipelines,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+			project.LoadOptions.WithIntegrations)
+		if err != nil {
+			return err
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, workflowName, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", workflowName)
+		}
+
+		wti, err := workflowtemplate.LoadInstanceByWorkflowID(ctx, api.mustDB(), wf.ID, workflowtemplate.LoadInstanceOptions.WithTemplate)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return sdk.NewErrorFrom(sdk.ErrNotFound, "workflow %s was not generated by a template", workflowName)
+			}
+			return err
+		}
+		if wti.Template == nil {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "cannot find the template of workflow %s", workflowName)
+		}
+
+		// Check that the local changes can be applied on the last version of the template before updating the instance
+		var drift sdk.WorkflowTemplateDrift
+		if keepChanges {
+			if err := computeTemplateDrift(ctx, api.mustDB(), *p, *wf, wti); err != nil {
+				return err
+			}
+			drift = *wti.Drift
+			next := *wti
+			next.WorkflowTemplateVersion = wti.Template.Version
+			generated, err := workflowtemplate.Execute(*wti.Template, next)
+			if err != nil {
+				return err
+			}
+			if _, err := workflowTemplateKeepChanges(ctx, generated, drift); err != nil {
+				return err
+			}
+		}
+
+		data := exportentities.WorkflowComponents{
+			Template: exportentities.TemplateInstance{
+				Name:       wti.Request.WorkflowName,
+				From:       wti.Template.PathWithVersion(),
+				Parameters: wti.Request.Parameters,
+			},
+		}
+		consumer := getAPIConsumer(ctx)
+		newWti, err := workflowtemplate.CheckAndExecuteTemplate(ctx, api.mustDB(), *consumer, *p, &data,
+			workflowtemplate.TemplateRequestModifiers.DefaultKeys(*p))
+		if err != nil {
+			return err
+		}
+		if keepChanges {
+			data, err = workflowTemplateKeepChanges(ctx, data, drift)
+			if err != nil {
+				return err
+			}
+		}
+
+		msgs, wkf, oldWkf, err := workflow.Push(ctx, api.mustDB(), api.Cache, p, data, nil, consumer, project.DecryptWithBuiltinKey)
+		if err != nil {
+			return sdk.WrapError(err, "cannot push generated workflow")
+		}
+		if err := workflowtemplate.UpdateTemplateInstanceWithWorkflow(ctx, api.mustDB(), *wkf, *consumer, newWti); err != nil {
+			return err
+		}
+
+		log.Debug("postTemplateInstanceReapplyHandler> template %s applied again on workflow %s (keepChanges=%v)", wti.Template.Slug, wkf.Name, keepChanges)
+
+		// Compute the drift of the new version of the workflow
+		wkf, err = workflow.Load(ctx, api.mustDB(), api.Cache, *p, wkf.Name, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", workflowName)
+		}
+		if err := computeTemplateDrift(ctx, api.mustDB(), *p, *wkf, newWti); err != nil {
+			log.Warning(ctx, "postTemplateInstanceReapplyHandler> cannot compute drift of workflow %s: %v", wkf.Name, err)
+		}
+
+		if oldWkf != nil {
+			event.PublishWorkflowUpdate(ctx, p.Key, *wkf, *oldWkf, consumer)
+		} else {
+			event.PublishWorkflowAdd(ctx, p.Key, *wkf, consumer)
+		}
+
+		return service.WriteJSON(w, translate(r, msgs), http.StatusOK)
+	}
+}
+
+// workflowTemplateKeepChanges applies the changes of a drift on generated workflow components. The changes on the
+// workflow are applied on the generated workflow, and the pipelines that were changed are removed so the stored ones
+// are kept.
+func workflowTemplateKeepChanges(ctx context.Context, data exportentities.WorkflowComponents, drift sdk.WorkflowTemplateDrift) (exportentities.WorkflowComponents, error) {
+	wf, err := workflowtemplate.GeneratedWorkflow(ctx, data.Workflow)
+	if err != nil {
+		return data, err
+	}
+	wf, err = v2.Patch(wf, drift.Workflow.Patch)
+	if err != nil {
+		return data, err
+	}
+	data.Workflow = wf
+
+	pipelines := make([]exportentities.PipelineV1, 0, len(data.Pipelines))
+	for _, p := range data.Pipelines {
+		if !sdk.IsInArray(p.Name, drift.Pipelines) {
+			pipelines = append(pipelines, p)
+		}
+	}
+	data.Pipelines = pipelines
+	return data, nil
+}
+
 func (api *API) deleteTemplateInstanceHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/pipeline"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+	v2 "github.com/ovh/cds/sdk/exportentities/v2"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// computeTemplateDrift compares the workflow generated by its template instance with the stored workflow and pipelines,
+// and saves the result on the instance.
+func computeTemplateDrift(ctx context.Context, db gorp.SqlExecutor, proj sdk.Project, wf sdk.Workflow, wti *sdk.WorkflowTemplateInstance) error {
+	generated, err := workflowtemplate.ExecuteInstance(ctx, db, *wti)
+	if err != nil {
+		return err
+	}
+
+	var opts []v2.ExportOptions
+	if wf.FromRepository != "" {
+		opts = append(opts, v2.WorkflowSkipIfOnlyOneRepoWebhook)
+	}
+	stored, err := v2.NewWorkflow(ctx, wf, exportentities.WorkflowVersion2, opts...)
+	if err != nil {
+		return sdk.WrapError(err, "unable to export workflow")
+	}
+
+	storedPipelines := make(map[string]exportentities.PipelineV1, len(generated.Pipelines))
+	for _, p := range generated.Pipelines {
+		pip, err := pipeline.Export(ctx, db, proj.Key, p.Name)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrPipelineNotFound) {
+				continue
+			}
+			return err
+		}
+		storedPipelines[p.Name] = pip
+	}
+
+	drift, err := workflowtemplate.Drift(ctx, generated, stored, storedPipelines)
+	if err != nil {
+		return err
+	}
+	if err := workflowtemplate.UpdateInstanceDrift(db, wti.ID, &drift); err != nil {
+		return err
+	}
+	wti.Drift = &drift
+	return nil
+}
+
+// templateDriftChecker computes the drift of all the workflows generated by a template every hour.
+func templateDriftChecker(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store) {
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "api.templateDriftChecker> exiting: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			if err := checkTemplateDrifts(ctx, dbFunc(), store); err != nil {
+				log.Error(ctx, "api.templateDriftChecker> %v", err)
+			}
+		}
+	}
+}
+
+func checkTemplateDrifts(ctx context.Context, db *gorp.DbMap, store cache.Store) error {
+	// The lock is kept until it expires so the drifts are computed by only one API instance every hour
+	locked, err := store.Lock(cache.Key("workflow", "template", "drift", "lock"), 55*time.Minute, -1, 1)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	wtis, err := workflowtemplate.LoadInstancesWithWorkflow(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	var drifted int
+	for i := range wtis {
+		if ctx.Err() != nil {
+			return nil
+		}
+		proj, err := project.LoadByID(db, wtis[i].ProjectID, project.LoadOptions.WithIntegrations)
+		if err != nil {
+			log.Warning(ctx, "api.checkTemplateDrifts> cannot load project %d: %v", wtis[i].ProjectID, err)
+			continue
+		}
+		wf, err := workflow.LoadByID(ctx, db, store, *proj, *wtis[i].WorkflowID, workflow.LoadOptions{})
+		if err != nil {
+			log.Warning(ctx, "api.checkTemplateDrifts> cannot load workflow %d: %v", *wtis[i].WorkflowID, err)
+			continue
+		}
+		if err := computeTemplateDrift(ctx, db, *proj, *wf, &wtis[i]); err != nil {
+			log.Warning(ctx, "api.checkTemplateDrifts> cannot compute drift of workflow %s/%s: %v", proj.Key, wf.Name, err)
+			continue
+		}
+		if wtis[i].Drift.IsDrifted() {
+			drifted++
+		}
+	}
+	log.Info(ctx, "api.checkTemplateDrifts> %d workflows generated by a template checked, %d drifted", len(wtis), drifted)
+	return nil
+}
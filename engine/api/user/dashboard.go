@@ -0,0 +1,84 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadDashboards returns the dashboards of given user.
+func LoadDashboards(ctx context.Context, db gorp.SqlExecutor, userID string) ([]sdk.Dashboard, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT user_dashboard.*
+		FROM user_dashboard
+		WHERE authentified_user_id = $1
+		ORDER BY name
+	`).Args(userID)
+	var ds []dashboard
+	if err := gorpmapping.GetAll(ctx, db, query, &ds); err != nil {
+		return nil, sdk.WrapError(err, "cannot load dashboards for user %s", userID)
+	}
+
+	res := make([]sdk.Dashboard, len(ds))
+	for i := range ds {
+		res[i] = sdk.Dashboard(ds[i])
+	}
+	return res, nil
+}
+
+// LoadDashboardByID returns a dashboard of given user.
+func LoadDashboardByID(ctx context.Context, db gorp.SqlExecutor, userID string, id int64) (*sdk.Dashboard, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT user_dashboard.*
+		FROM user_dashboard
+		WHERE authentified_user_id = $1 AND id = $2
+	`).Args(userID, id)
+	var d dashboard
+	found, err := gorpmapping.Get(ctx, db, query, &d)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load dashboard %d", id)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := sdk.Dashboard(d)
+	return &res, nil
+}
+
+// InsertDashboard saves a dashboard for a user.
+func InsertDashboard(db gorp.SqlExecutor, d *sdk.Dashboard) error {
+	d.Created = time.Now()
+	d.LastModified = d.Created
+	dbd := dashboard(*d)
+	if err := gorpmapping.Insert(db, &dbd); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrInvalidData) {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "a dashboard named %s already exists", d.Name)
+		}
+		return sdk.WrapError(err, "cannot insert dashboard %s", d.Name)
+	}
+	d.ID = dbd.ID
+	return nil
+}
+
+// UpdateDashboard updates a dashboard of a user.
+func UpdateDashboard(db gorp.SqlExecutor, d *sdk.Dashboard) error {
+	d.LastModified = time.Now()
+	dbd := dashboard(*d)
+	if err := gorpmapping.Update(db, &dbd); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrInvalidData) {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "a dashboard named %s already exists", d.Name)
+		}
+		return sdk.WrapError(err, "cannot update dashboard %d", d.ID)
+	}
+	return nil
+}
+
+// DeleteDashboard removes a dashboard of given user.
+func DeleteDashboard(db gorp.SqlExecutor, userID string, id int64) error {
+	_, err := db.Exec("DELETE FROM user_dashboard WHERE authentified_user_id = $1 AND id = $2", userID, id)
+	return sdk.WrapError(err, "cannot delete dashboard %d", id)
+}
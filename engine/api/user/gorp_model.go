@@ -27,7 +27,13 @@ func (c userContact) Canonical() gorpmapping.CanonicalForms {
 	}
 }
 
+type workflowRunSearch sdk.WorkflowRunSearch
+
+type dashboard sdk.Dashboard
+
 func init() {
 	gorpmapping.Register(gorpmapping.New(authentifiedUser{}, "authentified_user", false, "id"))
 	gorpmapping.Register(gorpmapping.New(userContact{}, "user_contact", true, "id"))
+	gorpmapping.Register(gorpmapping.New(workflowRunSearch{}, "user_workflow_run_search", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dashboard{}, "user_dashboard", true, "id"))
 }
@@ -0,0 +1,103 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadWorkflowRunSearches returns the workflow run searches saved by given user. If projectID is not zero, only
+// searches for this project are returned.
+func LoadWorkflowRunSearches(ctx context.Context, db gorp.SqlExecutor, userID string, projectID int64) ([]sdk.WorkflowRunSearch, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT user_workflow_run_search.*
+		FROM user_workflow_run_search
+		WHERE authentified_user_id = $1
+		AND ($2 = 0 OR project_id = $2)
+		ORDER BY name
+	`).Args(userID, projectID)
+	var ss []workflowRunSearch
+	if err := gorpmapping.GetAll(ctx, db, query, &ss); err != nil {
+		return nil, sdk.WrapError(err, "cannot load workflow run searches for user %s", userID)
+	}
+
+	res := make([]sdk.WorkflowRunSearch, len(ss))
+	projectIDs := make([]int64, len(ss))
+	for i := range ss {
+		res[i] = sdk.WorkflowRunSearch(ss[i])
+		projectIDs[i] = ss[i].ProjectID
+	}
+	if err := setWorkflowRunSearchesProjectKey(db, res, projectIDs); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func setWorkflowRunSearchesProjectKey(db gorp.SqlExecutor, ss []sdk.WorkflowRunSearch, projectIDs []int64) error {
+	if len(ss) == 0 {
+		return nil
+	}
+	var keys []struct {
+		ID  int64  `db:"id"`
+		Key string `db:"projectkey"`
+	}
+	if _, err := db.Select(&keys, "SELECT id, projectkey FROM project WHERE id = ANY($1)", pq.Int64Array(projectIDs)); err != nil {
+		return sdk.WrapError(err, "cannot load projects keys")
+	}
+	for i := range ss {
+		for _, k := range keys {
+			if k.ID == ss[i].ProjectID {
+				ss[i].ProjectKey = k.Key
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// LoadWorkflowRunSearchByID returns a workflow run search saved by given user.
+func LoadWorkflowRunSearchByID(ctx context.Context, db gorp.SqlExecutor, userID string, id int64) (*sdk.WorkflowRunSearch, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT user_workflow_run_search.*
+		FROM user_workflow_run_search
+		WHERE authentified_user_id = $1 AND id = $2
+	`).Args(userID, id)
+	var s workflowRunSearch
+	found, err := gorpmapping.Get(ctx, db, query, &s)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load workflow run search %d", id)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := []sdk.WorkflowRunSearch{sdk.WorkflowRunSearch(s)}
+	if err := setWorkflowRunSearchesProjectKey(db, res, []int64{s.ProjectID}); err != nil {
+		return nil, err
+	}
+	return &res[0], nil
+}
+
+// InsertWorkflowRunSearch saves a workflow run search for a user.
+func InsertWorkflowRunSearch(db gorp.SqlExecutor, s *sdk.WorkflowRunSearch) error {
+	s.Created = time.Now()
+	dbs := workflowRunSearch(*s)
+	if err := gorpmapping.Insert(db, &dbs); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrInvalidData) {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "a search named %s already exists for this project", s.Name)
+		}
+		return sdk.WrapError(err, "cannot insert workflow run search %s", s.Name)
+	}
+	s.ID = dbs.ID
+	return nil
+}
+
+// DeleteWorkflowRunSearch removes a workflow run search saved by given user.
+func DeleteWorkflowRunSearch(db gorp.SqlExecutor, userID string, id int64) error {
+	_, err := db.Exec("DELETE FROM user_workflow_run_search WHERE authentified_user_id = $1 AND id = $2", userID, id)
+	return sdk.WrapError(err, "cannot delete workflow run search %d", id)
+}
@@ -0,0 +1,222 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func requestDashboardID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given dashboard id")
+	}
+	return id, nil
+}
+
+// getUserDashboardsHandler returns the dashboards of the current user.
+func (api *API) getUserDashboardsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u := getAPIConsumer(ctx).AuthentifiedUser
+
+		ds, err := user.LoadDashboards(ctx, api.mustDB(), u.ID)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, ds, http.StatusOK)
+	}
+}
+
+// postUserDashboardHandler creates a dashboard for the current user.
+func (api *API) postUserDashboardHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u := getAPIConsumer(ctx).AuthentifiedUser
+
+		var d sdk.Dashboard
+		if err := service.UnmarshalBody(r, &d); err != nil {
+			return err
+		}
+		if err := d.IsValid(); err != nil {
+			return err
+		}
+		d.AuthentifiedUserID = u.ID
+
+		if err := user.InsertDashboard(api.mustDB(), &d); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, d, http.StatusCreated)
+	}
+}
+
+// getUserDashboardHandler returns a dashboard of the current user.
+func (api *API) getUserDashboardHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u := getAPIConsumer(ctx).AuthentifiedUser
+
+		id, err := requestDashboardID(r)
+		if err != nil {
+			return err
+		}
+
+		d, err := user.LoadDashboardByID(ctx, api.mustDB(), u.ID, id)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, d, http.StatusOK)
+	}
+}
+
+// putUserDashboardHandler updates the name and the widgets of a dashboard of the current user.
+func (api *API) putUserDashboardHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u := getAPIConsumer(ctx).AuthentifiedUser
+
+		id, err := requestDashboardID(r)
+		if err != nil {
+			return err
+		}
+
+		var data sdk.Dashboard
+		if err := service.UnmarshalBody(r, &data); err != nil {
+			return err
+		}
+		if err := data.IsValid(); err != nil {
+			return err
+		}
+
+		d, err := user.LoadDashboardByID(ctx, api.mustDB(), u.ID, id)
+		if err != nil {
+			return err
+		}
+		d.Name = data.Name
+		d.Widgets = data.Widgets
+
+		if err := user.UpdateDashboard(api.mustDB(), d); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, d, http.StatusOK)
+	}
+}
+
+// deleteUserDashboardHandler removes a dashboard of the current user.
+func (api *API) deleteUserDashboardHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u := getAPIConsumer(ctx).AuthentifiedUser
+
+		id, err := requestDashboardID(r)
+		if err != nil {
+			return err
+		}
+
+		d, err := user.LoadDashboardByID(ctx, api.mustDB(), u.ID, id)
+		if err != nil {
+			return err
+		}
+		if err := user.DeleteDashboard(api.mustDB(), u.ID, d.ID); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// getUserDashboardDataHandler returns a dashboard of the current user with the data of its widgets. A widget
+// that can't be computed, for example because the user lost the permission on a project, contains an error but
+// doesn't fail the whole dashboard.
+func (api *API) getUserDashboardDataHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u := getAPIConsumer(ctx).AuthentifiedUser
+
+		id, err := requestDashboardID(r)
+		if err != nil {
+			return err
+		}
+
+		d, err := user.LoadDashboardByID(ctx, api.mustDB(), u.ID, id)
+		if err != nil {
+			return err
+		}
+
+		res := sdk.DashboardData{
+			Dashboard: *d,
+			Widgets:   make([]sdk.DashboardWidgetData, len(d.Widgets)),
+		}
+		for i := range d.Widgets {
+			res.Widgets[i] = sdk.DashboardWidgetData{Widget: d.Widgets[i]}
+			if err := api.computeDashboardWidget(ctx, &res.Widgets[i]); err != nil {
+				if sdk.ErrorIsUnknown(err) {
+					return err
+				}
+				res.Widgets[i].Error = sdk.ExtractHTTPError(err, r.Header.Get("Accept-Language")).Message
+			}
+		}
+
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+func (api *API) computeDashboardWidget(ctx context.Context, data *sdk.DashboardWidgetData) error {
+	widget := data.Widget
+	switch widget.Type {
+	case sdk.DashboardWidgetLatestRuns:
+		for _, wf := range widget.Workflows {
+			if err := api.checkWorkflowPermissions(ctx, wf.WorkflowName, sdk.PermissionRead, map[string]string{"key": wf.ProjectKey}); err != nil {
+				return err
+			}
+			rs, err := workflow.LoadLastRunsSummaries(api.mustDB(), wf.ProjectKey, wf.WorkflowName, widget.GetLimit())
+			if err != nil {
+				return err
+			}
+			data.Runs = append(data.Runs, rs...)
+		}
+	case sdk.DashboardWidgetQueueDepth:
+		keys, err := api.dashboardWidgetProjectKeys(ctx, widget)
+		if err != nil {
+			return err
+		}
+		data.QueueDepth, err = workflow.CountNodeJobRunsByProjectKeys(api.mustDB(), keys)
+		if err != nil {
+			return err
+		}
+	case sdk.DashboardWidgetFailingWorkflows:
+		keys, err := api.dashboardWidgetProjectKeys(ctx, widget)
+		if err != nil {
+			return err
+		}
+		data.FailingWorkflows, err = workflow.LoadFailingWorkflows(api.mustDB(), keys, widget.GetLimit())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dashboardWidgetProjectKeys returns the projects of a widget after checking the permissions of the current user,
+// or the projects of the groups of the user if the widget doesn't set any.
+func (api *API) dashboardWidgetProjectKeys(ctx context.Context, widget sdk.DashboardWidget) ([]string, error) {
+	if len(widget.Projects) == 0 {
+		ps, err := project.LoadAllByGroupIDs(ctx, api.mustDB(), api.Cache, getAPIConsumer(ctx).GetGroupIDs())
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, len(ps))
+		for i := range ps {
+			keys[i] = ps[i].Key
+		}
+		return keys, nil
+	}
+
+	for _, key := range widget.Projects {
+		if err := api.checkProjectPermissions(ctx, key, sdk.PermissionRead, nil); err != nil {
+			return nil, err
+		}
+	}
+	return widget.Projects, nil
+}
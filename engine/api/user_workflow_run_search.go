@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getUserWorkflowRunSearchesHandler returns the workflow run searches saved by the current user, set the project
+// query param to get only the searches of a project.
+func (api *API) getUserWorkflowRunSearchesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u := getAPIConsumer(ctx).AuthentifiedUser
+
+		var projectID int64
+		if key := QueryString(r, "project"); key != "" {
+			p, err := project.Load(api.mustDB(), key)
+			if err != nil {
+				return sdk.WrapError(err, "cannot load project %s", key)
+			}
+			projectID = p.ID
+		}
+
+		ss, err := user.LoadWorkflowRunSearches(ctx, api.mustDB(), u.ID, projectID)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, ss, http.StatusOK)
+	}
+}
+
+// postUserWorkflowRunSearchHandler saves a workflow run search for the current user.
+func (api *API) postUserWorkflowRunSearchHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u := getAPIConsumer(ctx).AuthentifiedUser
+
+		var s sdk.WorkflowRunSearch
+		if err := service.UnmarshalBody(r, &s); err != nil {
+			return err
+		}
+		if err := s.IsValid(); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), s.ProjectKey)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", s.ProjectKey)
+		}
+		s.ProjectID = p.ID
+		s.AuthentifiedUserID = u.ID
+
+		if err := user.InsertWorkflowRunSearch(api.mustDB(), &s); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, s, http.StatusCreated)
+	}
+}
+
+// deleteUserWorkflowRunSearchHandler removes a workflow run search saved by the current user.
+func (api *API) deleteUserWorkflowRunSearchHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u := getAPIConsumer(ctx).AuthentifiedUser
+
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given search id")
+		}
+
+		s, err := user.LoadWorkflowRunSearchByID(ctx, api.mustDB(), u.ID, id)
+		if err != nil {
+			return err
+		}
+		if err := user.DeleteWorkflowRunSearch(api.mustDB(), u.ID, s.ID); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// variableBatchStore gives access to the variables of a project, an application or an environment to apply a batch.
+// Loaded variables have their clear value.
+type variableBatchStore struct {
+	load   func(db gorp.SqlExecutor, name string) (*sdk.Variable, error)
+	insert func(db gorp.SqlExecutor, v *sdk.Variable) error
+	update func(db gorp.SqlExecutor, v *sdk.Variable, before *sdk.Variable) error
+	delete func(db gorp.SqlExecutor, v *sdk.Variable) error
+}
+
+// applyVariableBatch applies the changes of the batch and returns the diff, the first error stops the batch so the
+// transaction should be rolled back. A secret updated with the password placeholder keeps its value.
+func applyVariableBatch(db gorp.SqlExecutor, store variableBatchStore, batch sdk.VariableBatch) (sdk.VariableBatchDiff, error) {
+	diff := sdk.VariableBatchDiff{
+		Created: []sdk.Variable{},
+		Updated: []sdk.VariableChange{},
+		Deleted: []sdk.Variable{},
+	}
+	if err := batch.IsValid(); err != nil {
+		return diff, err
+	}
+
+	for _, name := range batch.Delete {
+		v, err := store.load(db, name)
+		if err != nil {
+			return diff, sdk.WrapError(err, "cannot load variable %s", name)
+		}
+		if err := store.delete(db, v); err != nil {
+			return diff, sdk.WrapError(err, "cannot delete variable %s", name)
+		}
+		diff.Deleted = append(diff.Deleted, *v)
+	}
+
+	for _, v := range batch.Update {
+		before, err := store.load(db, v.Name)
+		if err != nil {
+			return diff, sdk.WrapError(err, "cannot load variable %s", v.Name)
+		}
+		v.ID = before.ID
+		if sdk.NeedPlaceholder(v.Type) && v.Value == sdk.PasswordPlaceholder {
+			v.Value = before.Value
+		}
+		if v.Value == before.Value && v.Type == before.Type {
+			continue
+		}
+		if err := store.update(db, &v, before); err != nil {
+			return diff, sdk.WrapError(err, "cannot update variable %s", v.Name)
+		}
+		diff.Updated = append(diff.Updated, sdk.VariableChange{Before: *before, After: v})
+	}
+
+	for _, v := range batch.Create {
+		if _, err := store.load(db, v.Name); err == nil {
+			return diff, sdk.NewErrorFrom(sdk.ErrVariableExists, "variable %s already exists", v.Name)
+		} else if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return diff, sdk.WrapError(err, "cannot load variable %s", v.Name)
+		}
+		v.ID = 0
+		if err := store.insert(db, &v); err != nil {
+			return diff, sdk.WrapError(err, "cannot create variable %s", v.Name)
+		}
+		diff.Created = append(diff.Created, v)
+	}
+
+	return diff, nil
+}
@@ -149,12 +149,8 @@ func (api *API) disableWorkerHandler() service.Handler {
 			if wk.Status == sdk.StatusBuilding {
 				return sdk.WrapError(sdk.ErrForbidden, "Cannot disable a worker with status %s", wk.Status)
 			}
-			hatcherySrv, err := services.LoadByConsumerID(ctx, api.mustDB(), getAPIConsumer(ctx).ID)
-			if err != nil {
-				return sdk.WrapError(sdk.ErrForbidden, "Cannot disable a worker from this hatchery: %v", err)
-			}
-			if wk.HatcheryID != hatcherySrv.ID {
-				return sdk.WrapError(sdk.ErrForbidden, "Cannot disable a worker from hatchery (expected: %d/actual: %d)", wk.HatcheryID, hatcherySrv.ID)
+			if err := api.checkWorkerHatchery(ctx, wk); err != nil {
+				return err
 			}
 		}
 
@@ -180,7 +176,7 @@ func (api *API) postRefreshWorkerHandler() service.Handler {
 		if err := worker.RefreshWorker(api.mustDB(), wk.ID); err != nil && (sdk.Cause(err) != sql.ErrNoRows || sdk.Cause(err) != worker.ErrNoWorker) {
 			return sdk.WrapError(err, "cannot refresh last beat of %s", wk.Name)
 		}
-		return nil
+		return service.WriteJSON(w, sdk.WorkerHeartbeat{Drain: wk.Drain}, http.StatusOK)
 	}
 }
 
@@ -190,6 +186,19 @@ func (api *API) postUnregisterWorkerHandler() service.Handler {
 		if err != nil {
 			return err
 		}
+
+		// Workers older than the drain protocol don't send a shutdown reason
+		if r.ContentLength != 0 {
+			var shutdown sdk.WorkerShutdown
+			if err := service.UnmarshalBody(r, &shutdown); err != nil {
+				return err
+			}
+			log.Info(ctx, "worker %s stopped: %s %s", wk.Name, shutdown.Reason, shutdown.Message)
+			if err := worker.SetShutdown(api.mustDB(), wk.ID, shutdown); err != nil {
+				return sdk.WrapError(err, "cannot save shutdown reason of worker %s", wk.Name)
+			}
+		}
+
 		if err := DisableWorker(ctx, api.mustDB(), wk.ID); err != nil {
 			return sdk.WrapError(err, "cannot delete worker %s", wk.Name)
 		}
@@ -197,6 +206,41 @@ func (api *API) postUnregisterWorkerHandler() service.Handler {
 	}
 }
 
+func (api *API) drainWorkerHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		id := vars["id"]
+
+		wk, err := worker.LoadByID(ctx, api.mustDB(), id)
+		if err != nil {
+			return err
+		}
+
+		if !isAdmin(ctx) {
+			if err := api.checkWorkerHatchery(ctx, wk); err != nil {
+				return err
+			}
+		}
+
+		if err := worker.SetDrain(api.mustDB(), wk.ID); err != nil {
+			return sdk.WrapError(err, "cannot drain worker %s", wk.Name)
+		}
+		return nil
+	}
+}
+
+// checkWorkerHatchery checks that the worker was spawned by the hatchery of the consumer.
+func (api *API) checkWorkerHatchery(ctx context.Context, wk *sdk.Worker) error {
+	hatcherySrv, err := services.LoadByConsumerID(ctx, api.mustDB(), getAPIConsumer(ctx).ID)
+	if err != nil {
+		return sdk.WrapError(sdk.ErrForbidden, "Cannot manage a worker from this hatchery: %v", err)
+	}
+	if wk.HatcheryID != hatcherySrv.ID {
+		return sdk.WrapError(sdk.ErrForbidden, "Cannot manage a worker from hatchery (expected: %d/actual: %d)", wk.HatcheryID, hatcherySrv.ID)
+	}
+	return nil
+}
+
 func (api *API) workerWaitingHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		wk, err := worker.LoadByConsumerID(ctx, api.mustDB(), getAPIConsumer(ctx).ID)
@@ -243,10 +287,12 @@ func DisableWorker(ctx context.Context, db *gorp.DbMap, id string) error {
 		// Worker is awol while building !
 		// We need to restart this action
 		wNodeJob, errL := workflow.LoadNodeJobRun(ctx, tx, nil, jobID.Int64)
-		if errL == nil && wNodeJob.Retry < 3 {
-			if err := workflow.RestartWorkflowNodeJob(context.TODO(), db, *wNodeJob); err != nil {
+		// When the job reached its maximum number of attempts, it will be stopped by the dead jobs routine
+		if errL == nil && wNodeJob.Retry < sdk.JobInfraRetryMax {
+			requeued, err := workflow.RequeueNodeJobRunOnInfraError(ctx, db, nil, wNodeJob, sdk.JobInfraError{Reason: sdk.JobInfraErrorWorkerLost, Message: name})
+			if err != nil {
 				log.Warning(ctx, "DisableWorker[%s]> Cannot restart workflow node run: %v", name, err)
-			} else {
+			} else if requeued {
 				log.Info(ctx, "DisableWorker[%s]> WorkflowNodeRun %d restarted after crash", name, jobID.Int64)
 			}
 		}
@@ -117,3 +117,28 @@ func SetToBuilding(db gorp.SqlExecutor, workerID string, jobRunID int64) error {
 	_, err := res.RowsAffected()
 	return err
 }
+
+// SetDrain asks a worker to finish its current job and to stop without taking a new one.
+func SetDrain(db gorp.SqlExecutor, workerID string) error {
+	res, err := db.Exec(`UPDATE worker SET drain = true WHERE id = $1`, workerID)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if n == 0 {
+		return sdk.WithStack(sdk.ErrNotFound)
+	}
+	return nil
+}
+
+// SetShutdown saves the reason given by a worker when it stops.
+func SetShutdown(db gorp.SqlExecutor, workerID string, shutdown sdk.WorkerShutdown) error {
+	query := `UPDATE worker SET shutdown_reason = $1, shutdown_message = $2 WHERE id = $3`
+	if _, err := db.Exec(query, shutdown.Reason, shutdown.Message, workerID); err != nil {
+		return sdk.WithStack(err)
+	}
+	return nil
+}
@@ -102,6 +102,13 @@ func RegisterWorker(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 		return nil, err
 	}
 
+	// Keep the version of the worker binary spawned with the model, hatcheries use it to not spawn outdated workers
+	if model != nil && registrationForm.Version != "" && model.RegisteredVersion != registrationForm.Version {
+		if err := workermodel.UpdateRegisteredVersion(db, model.ID, registrationForm.Version); err != nil {
+			log.Warning(ctx, "registerWorker> Unable to update registered version: %s", err)
+		}
+	}
+
 	//If the worker is registered for a model and it gave us BinaryCapabilities...
 	if model != nil && spawnArgs.RegisterOnly && len(registrationForm.BinaryCapabilities) > 0 && spawnArgs.Model.ID != 0 {
 		if err := workermodel.UpdateCapabilities(ctx, db, spawnArgs, registrationForm); err != nil {
@@ -59,6 +59,14 @@ func TestDAO(t *testing.T) {
 
 	test.NoError(t, worker.SetStatus(db, wk.ID, sdk.StatusBuilding))
 	test.NoError(t, worker.RefreshWorker(db, wk.ID))
+
+	test.NoError(t, worker.SetDrain(db, wk.ID))
+	test.NoError(t, worker.SetShutdown(db, wk.ID, sdk.WorkerShutdown{Reason: sdk.WorkerShutdownReasonDrained}))
+	wk, err = worker.LoadByID(context.TODO(), db, "foofoo")
+	test.NoError(t, err)
+	assert.True(t, wk.Drain)
+	assert.Equal(t, sdk.WorkerShutdownReasonDrained, wk.ShutdownReason)
+	assert.True(t, sdk.ErrorIs(worker.SetDrain(db, "unknown"), sdk.ErrNotFound))
 }
 
 func TestDeadWorkers(t *testing.T) {
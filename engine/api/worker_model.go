@@ -217,6 +217,14 @@ func (api *API) getWorkerModelsHandler() service.Handler {
 			filter.Binary = binary
 		}
 
+		osArch := r.FormValue("os-architecture")
+		if osArch != "" {
+			if !sdk.IsValidOSArch(osArch) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given os-architecture filter")
+			}
+			filter.OSArch = osArch
+		}
+
 		stateString := r.FormValue("state")
 		if stateString != "" {
 			o := workermodel.StateFilter(stateString)
@@ -279,6 +287,70 @@ func (api *API) getWorkerModelUsageHandler() service.Handler {
 	}
 }
 
+func (api *API) getWorkerModelHealthHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		groupName := vars["permGroupName"]
+		modelName := vars["permModelName"]
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName)
+		if err != nil {
+			return err
+		}
+
+		m, err := workermodel.LoadByNameAndGroupID(api.mustDB(), modelName, g.ID)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load worker model")
+		}
+
+		limit, err := FormInt(r, "limit")
+		if err != nil {
+			return err
+		}
+		if limit <= 0 || limit > 100 {
+			limit = 20
+		}
+
+		errs, err := workermodel.LoadSpawnErrors(ctx, api.mustDB(), m.ID, limit)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, sdk.NewModelHealth(*m, errs), http.StatusOK)
+	}
+}
+
+func (api *API) deleteWorkerModelQuarantineHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		groupName := vars["permGroupName"]
+		modelName := vars["permModelName"]
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName)
+		if err != nil {
+			return err
+		}
+
+		m, err := workermodel.LoadByNameAndGroupID(api.mustDB(), modelName, g.ID)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load worker model")
+		}
+
+		if err := workermodel.ReleaseQuarantine(api.mustDB(), m.ID); err != nil {
+			return sdk.WrapError(err, "cannot release worker model %s/%s from quarantine", g.Name, m.Name)
+		}
+
+		m, err = workermodel.LoadByID(api.mustDB(), m.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, m, http.StatusOK)
+	}
+}
+
 func (api *API) getWorkerModelsForProjectHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
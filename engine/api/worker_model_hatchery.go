@@ -6,10 +6,12 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/notification"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/api/workermodel"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
 )
 
 func (api *API) putBookWorkerModelHandler() service.Handler {
@@ -81,12 +83,28 @@ func (api *API) putSpawnErrorWorkerModelHandler() service.Handler {
 			return sdk.WrapError(err, "cannot update spawn error on worker model")
 		}
 
+		quarantined, err := workermodel.QuarantineIfNeeded(tx, model.ID, spawnErrorForm.Error)
+		if err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return err
 		}
 
 		workermodel.UnbookForRegister(ctx, api.Cache, model.ID)
 
+		if quarantined {
+			log.Warning(ctx, "worker model %s/%s has been quarantined: %s", g.Name, model.Name, spawnErrorForm.Error)
+			model, err = workermodel.LoadByID(api.mustDB(), model.ID)
+			if err != nil {
+				return err
+			}
+			if err := notification.WorkerModelQuarantine(ctx, api.mustDB(), *model); err != nil {
+				log.Error(ctx, "cannot send quarantine notification for worker model %s/%s: %v", g.Name, model.Name, err)
+			}
+		}
+
 		return service.WriteJSON(w, nil, http.StatusOK)
 	}
 }
@@ -130,6 +130,9 @@ const modelColumns = `
 	worker_model.nb_spawn_err,
 	worker_model.date_last_spawn_err,
 	worker_model.is_deprecated,
+	worker_model.quarantined,
+	worker_model.quarantine_reason,
+	worker_model.date_quarantined,
 	"group".name as groupname`
 
 // LoadByID retrieves a specific worker model in database.
@@ -290,6 +293,9 @@ func scanAll(db gorp.SqlExecutor, rows []dbResultWMS, withPassword bool) ([]sdk.
 func Insert(db gorp.SqlExecutor, model *sdk.Model) error {
 	dbmodel := WorkerModel(*model)
 	dbmodel.NeedRegistration = true
+	dbmodel.Quarantined = false
+	dbmodel.QuarantineReason = ""
+	dbmodel.DateQuarantined = nil
 	model.UserLastModified = time.Now()
 	if model.ModelDocker.Password == sdk.PasswordPlaceholder {
 		return sdk.WithStack(sdk.ErrInvalidPassword)
@@ -305,13 +311,16 @@ func Insert(db gorp.SqlExecutor, model *sdk.Model) error {
 }
 
 // UpdateDB a worker model
-// if the worker model have SpawnErr -> clear them.
+// if the worker model have SpawnErr -> clear them, an updated model is also released from quarantine.
 func UpdateDB(db gorp.SqlExecutor, model *sdk.Model) error {
 	model.UserLastModified = time.Now()
 	model.NeedRegistration = true
 	model.NbSpawnErr = 0
 	model.LastSpawnErr = ""
 	model.LastSpawnErrLogs = nil
+	model.Quarantined = false
+	model.QuarantineReason = ""
+	model.DateQuarantined = nil
 	dbmodel := WorkerModel(*model)
 	if _, err := db.Update(&dbmodel); err != nil {
 		return sdk.WithStack(err)
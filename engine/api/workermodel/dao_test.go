@@ -209,6 +209,14 @@ func TestLoadAll(t *testing.T) {
 	test.NoError(t, err)
 	test.Equal(t, 1, len(models))
 	test.Equal(t, m1.ID, models[0].ID)
+
+	test.NoError(t, workermodel.UpdateOSAndArch(db, m3.ID, "linux", "arm64"))
+	models, err = workermodel.LoadAll(context.TODO(), db, &workermodel.LoadFilter{
+		OSArch: "linux/arm64",
+	})
+	test.NoError(t, err)
+	test.Equal(t, 1, len(models))
+	test.Equal(t, m3.ID, models[0].ID)
 }
 
 func TestLoadAllByGroupIDs(t *testing.T) {
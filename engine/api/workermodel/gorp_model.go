@@ -15,6 +15,7 @@ import (
 func init() {
 	gorpmapping.Register(gorpmapping.New(WorkerModel{}, "worker_model", true, "id"))
 	gorpmapping.Register(gorpmapping.New(workerModelPattern{}, "worker_model_pattern", true, "id"))
+	gorpmapping.Register(gorpmapping.New(modelSpawnError{}, "worker_model_spawn_error", true, "id"))
 }
 
 // WorkerModel is a gorp wrapper around sdk.Model.
@@ -111,15 +112,15 @@ func (m *WorkerModel) PostSelect(s gorp.SqlExecutor) error {
 	}
 
 	//Load created_by
-	var createdBy, model, registeredOS, registeredArch, lastSpawnErr, lastSpawnErrLogs sql.NullString
+	var createdBy, model, registeredOS, registeredArch, registeredVersion, lastSpawnErr, lastSpawnErrLogs sql.NullString
 	if err := s.QueryRow(`
     SELECT
-      created_by, model, registered_os, registered_arch, last_spawn_err, last_spawn_err_log
+      created_by, model, registered_os, registered_arch, registered_version, last_spawn_err, last_spawn_err_log
     FROM worker_model
     WHERE id = $1
   `, m.ID).Scan(&createdBy, &model, &registeredOS,
-		&registeredArch, &lastSpawnErr, &lastSpawnErrLogs); err != nil {
-		return sdk.WrapError(err, "unable to load created_by, model, registered_os, registered_arch")
+		&registeredArch, &registeredVersion, &lastSpawnErr, &lastSpawnErrLogs); err != nil {
+		return sdk.WrapError(err, "unable to load created_by, model, registered_os, registered_arch, registered_version")
 	}
 
 	if registeredOS.Valid {
@@ -130,6 +131,10 @@ func (m *WorkerModel) PostSelect(s gorp.SqlExecutor) error {
 		m.RegisteredArch = registeredArch.String
 	}
 
+	if registeredVersion.Valid {
+		m.RegisteredVersion = registeredVersion.String
+	}
+
 	if lastSpawnErr.Valid {
 		m.LastSpawnErr = lastSpawnErr.String
 	}
@@ -196,3 +201,6 @@ func (wmp *workerModelPattern) PostInsert(s gorp.SqlExecutor) error {
 func (wmp *workerModelPattern) PostUpdate(s gorp.SqlExecutor) error {
 	return wmp.PostInsert(s)
 }
+
+// modelSpawnError is a gorp wrapper around sdk.ModelSpawnError
+type modelSpawnError sdk.ModelSpawnError
@@ -30,6 +30,7 @@ export CDS_HATCHERY_NAME={{.HatcheryName}}
 export CDS_BOOKED_WORKFLOW_JOB_ID={{.WorkflowJobID}}
 export CDS_TTL={{.TTL}}
 export CDS_INSECURE={{.HTTPInsecure}}
+export CDS_AUTO_UPDATE={{.AutoUpdate}}
 export CDS_GRAYLOG_HOST={{.GraylogHost}}
 export CDS_GRAYLOG_PORT={{.GraylogPort}}
 export CDS_GRAYLOG_EXTRA_KEY={{.GraylogExtraKey}}
@@ -52,6 +52,7 @@ func loadGroup(ctx context.Context, db gorp.SqlExecutor, ms ...*sdk.Model) error
 type LoadFilter struct {
 	Binary string
 	State  StateFilter
+	OSArch string
 }
 
 // SQL returns the raw sql for current filter.
@@ -63,6 +64,10 @@ func (l LoadFilter) SQL() string {
 		conds = append(conds, "worker_capability.argument = :binary")
 	}
 
+	if l.OSArch != "" {
+		conds = append(conds, "worker_model.registered_os || '/' || worker_model.registered_arch = :osArch")
+	}
+
 	switch l.State {
 	case StateError:
 		conds = append(conds, "worker_model.nb_spawn_err > 0")
@@ -76,6 +81,8 @@ func (l LoadFilter) SQL() string {
 		conds = append(conds, "worker_model.is_deprecated = false")
 	case StateOfficial:
 		conds = append(conds, "worker_model.group_id = :sharedInfraGroupID")
+	case StateQuarantined:
+		conds = append(conds, "worker_model.quarantined = true")
 	}
 
 	return gorpmapping.And(conds...)
@@ -85,6 +92,7 @@ func (l LoadFilter) SQL() string {
 func (l LoadFilter) Args() gorpmapping.ArgsMap {
 	return gorpmapping.ArgsMap{
 		"binary":             l.Binary,
+		"osArch":             l.OSArch,
 		"sharedInfraGroupID": group.SharedInfraGroup.ID,
 	}
 }
@@ -95,7 +103,7 @@ type StateFilter string
 // IsValid returns an error if the state value is not valid.
 func (s StateFilter) IsValid() error {
 	switch s {
-	case StateDisabled, StateOfficial, StateError, StateRegister, StateDeprecated, StateActive:
+	case StateDisabled, StateOfficial, StateError, StateRegister, StateDeprecated, StateActive, StateQuarantined:
 		return nil
 	default:
 		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given state filter")
@@ -104,12 +112,13 @@ func (s StateFilter) IsValid() error {
 
 // List of const for state filter.
 const (
-	StateError      StateFilter = "error"
-	StateDisabled   StateFilter = "disabled"
-	StateRegister   StateFilter = "register"
-	StateDeprecated StateFilter = "deprecated"
-	StateActive     StateFilter = "active"
-	StateOfficial   StateFilter = "official"
+	StateError       StateFilter = "error"
+	StateDisabled    StateFilter = "disabled"
+	StateRegister    StateFilter = "register"
+	StateDeprecated  StateFilter = "deprecated"
+	StateActive      StateFilter = "active"
+	StateOfficial    StateFilter = "official"
+	StateQuarantined StateFilter = "quarantined"
 )
 
 type dbResultWMS struct {
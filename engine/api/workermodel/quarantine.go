@@ -0,0 +1,91 @@
+package workermodel
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// spawnErrorHistoryLength is the number of spawn errors kept for each worker model.
+const spawnErrorHistoryLength = 100
+
+func insertSpawnError(db gorp.SqlExecutor, modelID int64, spawnError sdk.SpawnErrorForm) error {
+	e := modelSpawnError{
+		WorkerModelID: modelID,
+		Created:       time.Now(),
+		Class:         spawnError.Class,
+		Error:         spawnError.Error,
+	}
+	if err := gorpmapping.Insert(db, &e); err != nil {
+		return sdk.WrapError(err, "cannot insert spawn error for worker model %d", modelID)
+	}
+
+	query := `DELETE FROM worker_model_spawn_error WHERE worker_model_id = $1 AND id NOT IN (
+		SELECT id FROM worker_model_spawn_error WHERE worker_model_id = $1 ORDER BY created DESC, id DESC LIMIT $2
+	)`
+	if _, err := db.Exec(query, modelID, spawnErrorHistoryLength); err != nil {
+		return sdk.WithStack(err)
+	}
+	return nil
+}
+
+// LoadSpawnErrors returns the last spawn errors of a worker model, most recent first.
+func LoadSpawnErrors(ctx context.Context, db gorp.SqlExecutor, modelID int64, limit int) ([]sdk.ModelSpawnError, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM worker_model_spawn_error
+		WHERE worker_model_id = $1
+		ORDER BY created DESC, id DESC
+		LIMIT $2`).Args(modelID, limit)
+	var es []modelSpawnError
+	if err := gorpmapping.GetAll(ctx, db, query, &es); err != nil {
+		return nil, sdk.WrapError(err, "cannot load spawn errors for worker model %d", modelID)
+	}
+
+	res := make([]sdk.ModelSpawnError, len(es))
+	for i := range es {
+		res[i] = sdk.ModelSpawnError(es[i])
+	}
+	return res, nil
+}
+
+// QuarantineIfNeeded puts a worker model in quarantine if it failed to spawn too many times in a row.
+// Returns true only if the model was not already in quarantine.
+func QuarantineIfNeeded(db gorp.SqlExecutor, modelID int64, reason string) (bool, error) {
+	query := `UPDATE worker_model SET quarantined = true, quarantine_reason = $2, date_quarantined = $3
+		WHERE id = $1 AND quarantined = false AND nb_spawn_err >= $4`
+	res, err := db.Exec(query, modelID, reason, time.Now(), sdk.WorkerModelQuarantineThreshold)
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	return n > 0, nil
+}
+
+// ReleaseQuarantine releases a worker model from quarantine, resets its spawn errors and asks the hatcheries to check
+// its registration again.
+func ReleaseQuarantine(db gorp.SqlExecutor, modelID int64) error {
+	query := `UPDATE worker_model SET quarantined = false, quarantine_reason = '', date_quarantined = NULL,
+		nb_spawn_err = 0, last_spawn_err = NULL, last_spawn_err_log = NULL, check_registration = true
+		WHERE id = $1`
+	res, err := db.Exec(query, modelID)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if n == 0 {
+		return sdk.WithStack(sdk.ErrNoWorkerModel)
+	}
+	return nil
+}
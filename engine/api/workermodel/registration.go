@@ -79,13 +79,22 @@ func updateAllToCheckRegistration(db gorp.SqlExecutor) error {
 	return nil
 }
 
-// UpdateSpawnErrorWorkerModel updates worker model error registration
+// UpdateSpawnErrorWorkerModel updates worker model error registration and keeps the error in the model's history.
+// Only the errors caused by the model itself increment its spawn error counter.
 func UpdateSpawnErrorWorkerModel(db gorp.SqlExecutor, modelID int64, spawnError sdk.SpawnErrorForm) error {
 	spawnError.Error = sdk.RemoveNotPrintableChar(spawnError.Error)
 	spawnError.Logs = []byte(sdk.RemoveNotPrintableChar(string(spawnError.Logs)))
+	if spawnError.Class == "" {
+		spawnError.Class = sdk.ClassifySpawnError(spawnError.Error)
+	}
+
+	var inc int
+	if sdk.SpawnErrorClassIsModelFailure(spawnError.Class) {
+		inc = 1
+	}
 
-	query := `UPDATE worker_model SET nb_spawn_err=nb_spawn_err+1, last_spawn_err=$3, last_spawn_err_log=$4, date_last_spawn_err=$2 WHERE id = $1`
-	res, err := db.Exec(query, modelID, time.Now(), spawnError.Error, string(spawnError.Logs))
+	query := `UPDATE worker_model SET nb_spawn_err=nb_spawn_err+$5, last_spawn_err=$3, last_spawn_err_log=$4, date_last_spawn_err=$2 WHERE id = $1`
+	res, err := db.Exec(query, modelID, time.Now(), spawnError.Error, string(spawnError.Logs), inc)
 	if err != nil {
 		return sdk.WithStack(err)
 	}
@@ -97,7 +106,8 @@ func UpdateSpawnErrorWorkerModel(db gorp.SqlExecutor, modelID int64, spawnError
 	if n == 0 {
 		return sdk.WithStack(sdk.ErrNoWorkerModel)
 	}
-	return nil
+
+	return insertSpawnError(db, modelID, spawnError)
 }
 
 // UpdateRegistration updates need_registration to false and last_registration time, reset err registration.
@@ -133,6 +143,15 @@ func UpdateOSAndArch(db gorp.SqlExecutor, modelID int64, OS, arch string) error
 	return nil
 }
 
+// UpdateRegisteredVersion updates the version of the last worker binary registered for a worker model.
+func UpdateRegisteredVersion(db gorp.SqlExecutor, modelID int64, version string) error {
+	query := `UPDATE worker_model SET registered_version = $1 WHERE id = $2`
+	if _, err := db.Exec(query, version, modelID); err != nil {
+		return sdk.WithStack(err)
+	}
+	return nil
+}
+
 // KeyBookWorkerModel returns cache key for given model id.
 func KeyBookWorkerModel(id int64) string {
 	return cache.Key("book", "workermodel", strconv.FormatInt(id, 10))
@@ -69,7 +69,7 @@ func (api *API) getWorkflowsHandler() service.Handler {
 
 		for i := range ws {
 			if isAdmin(ctx) {
-				ws[i].Permissions = sdk.Permissions{Readable: true, Writable: true, Executable: true}
+				ws[i].Permissions = sdk.Permissions{Readable: true, Writable: true, Executable: true, Deployable: true}
 			} else {
 				ws[i].Permissions = perms.Permissions(ws[i].Name)
 				if isMaintainer(ctx) {
@@ -138,7 +138,7 @@ func (api *API) getWorkflowHandler() service.Handler {
 		}
 
 		if isAdmin(ctx) {
-			w1.Permissions = sdk.Permissions{Readable: true, Writable: true, Executable: true}
+			w1.Permissions = sdk.Permissions{Readable: true, Writable: true, Executable: true, Deployable: true}
 		} else {
 			perms, err := permission.LoadWorkflowMaxLevelPermission(ctx, api.mustDB(), key, []string{w1.Name}, getAPIConsumer(ctx).GetGroupIDs())
 			if err != nil {
@@ -154,7 +154,7 @@ func (api *API) getWorkflowHandler() service.Handler {
 		w1.URLs.UIURL = api.Config.URL.UI + "/project/" + key + "/workflow/" + w1.Name
 
 		//We filter project and workflow configuration key, because they are always set on insertHooks
-		w1.FilterHooksConfig(sdk.HookConfigProject, sdk.HookConfigWorkflow)
+		w1.FilterHooksConfig(sdk.HookConfigProject, sdk.HookConfigWorkflow, sdk.HookConfigWebHookSecret, sdk.HookConfigWebHookPreviousSecret)
 		return service.WriteJSON(w, w1, http.StatusOK)
 	}
 }
@@ -241,6 +241,7 @@ func (api *API) postWorkflowRollbackHandler() service.Handler {
 
 		newWf.Permissions.Readable = true
 		newWf.Permissions.Executable = true
+		newWf.Permissions.Deployable = true
 		newWf.Permissions.Writable = true
 
 		event.PublishWorkflowUpdate(ctx, key, *wf, *newWf, getAPIConsumer(ctx))
@@ -393,6 +394,9 @@ func (api *API) postWorkflowHandler() service.Handler {
 		if err := workflow.Insert(ctx, tx, api.Cache, *p, &data); err != nil {
 			return sdk.WrapError(err, "cannot insert workflow")
 		}
+		if _, err := workflow.InsertVersion(ctx, tx, api.Cache, *p, data.ID, getAPIConsumer(ctx), r.FormValue("message")); err != nil {
+			return err
+		}
 
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
@@ -408,9 +412,10 @@ func (api *API) postWorkflowHandler() service.Handler {
 		wf.Permissions.Readable = true
 		wf.Permissions.Writable = true
 		wf.Permissions.Executable = true
+		wf.Permissions.Deployable = true
 
 		//We filter project and workflow configurtaion key, because they are always set on insertHooks
-		wf.FilterHooksConfig(sdk.HookConfigProject, sdk.HookConfigWorkflow)
+		wf.FilterHooksConfig(sdk.HookConfigProject, sdk.HookConfigWorkflow, sdk.HookConfigWebHookSecret, sdk.HookConfigWebHookPreviousSecret)
 
 		return service.WriteJSON(w, wf, http.StatusCreated)
 	}
@@ -476,6 +481,10 @@ func (api *API) putWorkflowHandler() service.Handler {
 			}
 		}
 
+		if _, err := workflow.InsertVersion(ctx, tx, api.Cache, *p, wf.ID, getAPIConsumer(ctx), r.FormValue("message")); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -490,6 +499,7 @@ func (api *API) putWorkflowHandler() service.Handler {
 		wf1.Permissions.Readable = true
 		wf1.Permissions.Writable = true
 		wf1.Permissions.Executable = true
+		wf1.Permissions.Deployable = true
 
 		usage, err := loadWorkflowUsage(api.mustDB(), wf1.ID)
 		if err != nil {
@@ -498,7 +508,7 @@ func (api *API) putWorkflowHandler() service.Handler {
 		wf1.Usage = &usage
 
 		//We filter project and workflow configuration key, because they are always set on insertHooks
-		wf1.FilterHooksConfig(sdk.HookConfigProject, sdk.HookConfigWorkflow)
+		wf1.FilterHooksConfig(sdk.HookConfigProject, sdk.HookConfigWorkflow, sdk.HookConfigWebHookSecret, sdk.HookConfigWebHookPreviousSecret)
 		return service.WriteJSON(w, wf1, http.StatusOK)
 	}
 }
@@ -702,6 +712,12 @@ func (api *API) getWorkflowHookHandler() service.Handler {
 		if _, _, err := services.NewClient(api.mustDB(), srvs).DoJSONRequest(ctx, "GET", path, nil, &task); err != nil {
 			return sdk.WrapError(err, "unable to get hook %s task and executions", uuid)
 		}
+		delete(task.Config, sdk.HookConfigWebHookSecret)
+		delete(task.Config, sdk.HookConfigWebHookPreviousSecret)
+		for i := range task.Executions {
+			delete(task.Executions[i].Config, sdk.HookConfigWebHookSecret)
+			delete(task.Executions[i].Config, sdk.HookConfigWebHookPreviousSecret)
+		}
 
 		return service.WriteJSON(w, task, http.StatusOK)
 	}
@@ -164,9 +164,11 @@ func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 		Metadata     sql.NullString `db:"metadata"`
 		PurgeTags    sql.NullString `db:"purge_tags"`
 		WorkflowData sql.NullString `db:"workflow_data"`
+		RunForm      sql.NullString `db:"run_form"`
+		Priority     string         `db:"priority"`
 	}{}
 
-	if err := db.SelectOne(&res, "SELECT metadata, purge_tags, workflow_data FROM workflow WHERE id = $1", w.ID); err != nil {
+	if err := db.SelectOne(&res, "SELECT metadata, purge_tags, workflow_data, run_form, priority FROM workflow WHERE id = $1", w.ID); err != nil {
 		return sdk.WrapError(err, "PostGet> Unable to load marshalled workflow")
 	}
 
@@ -182,6 +184,17 @@ func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 	}
 	w.PurgeTags = purgeTags
 
+	if res.RunForm.Valid {
+		var runForm sdk.WorkflowRunForm
+		if err := gorpmapping.JSONNullString(res.RunForm, &runForm); err != nil {
+			return sdk.WrapError(err, "unable to unmarshal workflow run form")
+		}
+		w.RunForm = &runForm
+	}
+	if res.Priority != sdk.WorkflowPriorityDefault {
+		w.Priority = res.Priority
+	}
+
 	data := sdk.WorkflowData{}
 	if err := gorpmapping.JSONNullString(res.WorkflowData, &data); err != nil {
 		return sdk.WrapError(err, "Unable to unmarshall workflow data")
@@ -213,6 +226,12 @@ func (w *Workflow) PreUpdate(db gorp.SqlExecutor) error {
 		w.FromRepository = fromRepoURL.String()
 	}
 
+	if w.RunForm != nil {
+		if err := w.RunForm.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -231,7 +250,18 @@ func (w *Workflow) PostUpdate(db gorp.SqlExecutor) error {
 	if errD != nil {
 		return sdk.WrapError(errD, "Workflow.PostUpdate> Unable to marshall workflow data")
 	}
-	if _, err := db.Exec("update workflow set purge_tags = $1, workflow_data = $3 where id = $2", pt, w.ID, data); err != nil {
+	var runForm sql.NullString
+	if w.RunForm != nil && len(w.RunForm.Parameters) > 0 {
+		runForm, errD = gorpmapping.JSONToNullString(w.RunForm)
+		if errD != nil {
+			return sdk.WrapError(errD, "Workflow.PostUpdate> Unable to marshall workflow run form")
+		}
+	}
+	priority := w.Priority
+	if priority == "" {
+		priority = sdk.WorkflowPriorityDefault
+	}
+	if _, err := db.Exec("update workflow set purge_tags = $1, workflow_data = $3, run_form = $4, priority = $5 where id = $2", pt, w.ID, data, runForm, priority); err != nil {
 		return err
 	}
 
@@ -241,6 +271,13 @@ func (w *Workflow) PostUpdate(db gorp.SqlExecutor) error {
 		}
 	}
 
+	// Artifact storages are replaced only when given, a workflow loaded without its integrations keeps them
+	if w.ArtifactStorages != nil {
+		if err := integration.SetArtifactStoragesOnWorkflow(db, w.ID, w.ArtifactStorages); err != nil {
+			return sdk.WrapError(err, "cannot set artifact storages on workflow")
+		}
+	}
+
 	return nil
 }
 
@@ -587,6 +624,12 @@ func load(ctx context.Context, db gorp.SqlExecutor, proj sdk.Project, opts LoadO
 			return nil, sdk.WrapError(errInt, "Load> unable to load workflow integrations")
 		}
 		res.EventIntegrations = integrations
+
+		artifactStorages, err := integration.LoadArtifactStoragesByWorkflowID(db, res.ID)
+		if err != nil {
+			return nil, sdk.WrapError(err, "Load> unable to load workflow artifact storages")
+		}
+		res.ArtifactStorages = artifactStorages
 	}
 
 	if opts.WithTemplate {
@@ -994,9 +1037,21 @@ func Update(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sd
 	}
 	*wf = sdk.Workflow(dbw)
 
+	invalidateCache(ctx, store, proj.Key, wf.Name)
+	if oldWf.Name != wf.Name {
+		invalidateCache(ctx, store, proj.Key, oldWf.Name)
+	}
+
 	return nil
 }
 
+// invalidateCache deletes the cache keys of given workflow, an error is only logged to not fail the update.
+func invalidateCache(ctx context.Context, store cache.Store, projectKey, workflowName string) {
+	if err := cache.InvalidateWorkflow(store, projectKey, workflowName); err != nil {
+		log.Error(ctx, "unable to invalidate cache of workflow %s/%s: %v", projectKey, workflowName, err)
+	}
+}
+
 // MarkAsDelete marks a workflow to be deleted
 func MarkAsDelete(db gorp.SqlExecutor, key, name string) error {
 	query := `UPDATE workflow
@@ -1037,6 +1092,8 @@ func Delete(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sd
 		return sdk.WrapError(err, "unable to delete workflow")
 	}
 
+	invalidateCache(ctx, store, proj.Key, w.Name)
+
 	return nil
 }
 
@@ -1062,6 +1119,10 @@ func IsValid(ctx context.Context, store cache.Store, db gorp.SqlExecutor, w *sdk
 		return sdk.NewError(sdk.ErrWorkflowInvalid, fmt.Errorf("Invalid workflow name. It should match %s", sdk.NamePattern))
 	}
 
+	if !sdk.IsValidWorkflowPriority(w.Priority) {
+		return sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "invalid priority %q, it should be one of %s", w.Priority, strings.Join(sdk.WorkflowPriorities, ", "))
+	}
+
 	//Check refs
 	for _, j := range w.WorkflowData.Joins {
 		if len(j.JoinContext) == 0 {
@@ -1106,9 +1167,20 @@ func IsValid(ctx context.Context, store cache.Store, db gorp.SqlExecutor, w *sdk
 		return err
 	}
 
+	if err := checkArtifactStorages(proj, w); err != nil {
+		return err
+	}
+
 	nodesArray := w.WorkflowData.Array()
 	for i := range nodesArray {
 		n := nodesArray[i]
+		if n.Display != nil {
+			if n.Display.IsEmpty() {
+				n.Display = nil
+			} else if err := n.Display.IsValid(n.Name); err != nil {
+				return err
+			}
+		}
 		if n.Context == nil {
 			continue
 		}
@@ -1125,6 +1197,9 @@ func IsValid(ctx context.Context, store cache.Store, db gorp.SqlExecutor, w *sdk
 		if err := checkProjectIntegration(proj, w, n); err != nil {
 			return err
 		}
+		if err := checkReleaseTargets(proj, w, n); err != nil {
+			return err
+		}
 		if err := checkEventIntegration(proj, w); err != nil {
 			return err
 		}
@@ -1140,6 +1215,10 @@ func IsValid(ctx context.Context, store cache.Store, db gorp.SqlExecutor, w *sdk
 				return sdk.NewError(sdk.ErrWorkflowInvalid, err)
 			}
 		}
+
+		if n.Context.DeploymentPlan && !w.ProjectIntegrations[n.Context.ProjectIntegrationID].Model.Deployment {
+			return sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "pipeline %s can only run in deployment plan mode with a deployment integration", n.Name)
+		}
 	}
 
 	return nil
@@ -1230,6 +1309,12 @@ func checkHooks(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) error {
 			v.Configurable = d.Configurable
 			h.Config[k] = v
 		}
+		if _, _, err := sdk.ParseHookExpressions(h.Config); err != nil {
+			return err
+		}
+		if _, err := sdk.ParseHookPathFilters(h.Config); err != nil {
+			return err
+		}
 		// Check hooks duplication
 		for j := range n.Hooks {
 			h2 := n.Hooks[j]
@@ -1278,6 +1363,65 @@ func checkProjectIntegration(proj sdk.Project, w *sdk.Workflow, n *sdk.Node) err
 	return nil
 }
 
+// checkReleaseTargets checks that the release targets of the node are release integrations of the project, and that
+// the node itself uses a release integration.
+func checkReleaseTargets(proj sdk.Project, w *sdk.Workflow, n *sdk.Node) error {
+	if len(n.Context.ReleaseTargets) == 0 {
+		return nil
+	}
+	nodeIntegration, ok := w.ProjectIntegrations[n.Context.ProjectIntegrationID]
+	if !ok || !nodeIntegration.Model.Release {
+		return sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "pipeline %s can only have release targets with a release integration", n.Name)
+	}
+	names := map[string]struct{}{nodeIntegration.Name: {}}
+	for _, t := range n.Context.ReleaseTargets {
+		if _, ok := names[t]; ok {
+			return sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "release target %s is used twice by pipeline %s", t, n.Name)
+		}
+		names[t] = struct{}{}
+
+		var pi *sdk.ProjectIntegration
+		for i := range proj.Integrations {
+			if proj.Integrations[i].Name == t {
+				pi = &proj.Integrations[i]
+				break
+			}
+		}
+		if pi == nil {
+			return sdk.WithData(sdk.ErrIntegrationtNotFound, t)
+		}
+		if !pi.Model.Release {
+			return sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "release target %s of pipeline %s is not a release integration", t, n.Name)
+		}
+	}
+	return nil
+}
+
+// checkArtifactStorages checks that the artifact storages of the workflow are storage integrations of the project and
+// sets their ids from their names
+func checkArtifactStorages(proj sdk.Project, w *sdk.Workflow) error {
+	for i := range w.ArtifactStorages {
+		s := &w.ArtifactStorages[i]
+		var pi *sdk.ProjectIntegration
+		for j := range proj.Integrations {
+			if (s.ProjectIntegrationName != "" && proj.Integrations[j].Name == s.ProjectIntegrationName) ||
+				(s.ProjectIntegrationName == "" && proj.Integrations[j].ID == s.ProjectIntegrationID) {
+				pi = &proj.Integrations[j]
+				break
+			}
+		}
+		if pi == nil {
+			return sdk.WithData(sdk.ErrIntegrationtNotFound, s.ProjectIntegrationName)
+		}
+		if !pi.Model.Storage {
+			return sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "artifact storage %s is not a storage integration", pi.Name)
+		}
+		s.ProjectIntegrationID = pi.ID
+		s.ProjectIntegrationName = pi.Name
+	}
+	return w.CheckArtifactStorages()
+}
+
 // checkEventIntegration checks event integration data
 func checkEventIntegration(proj sdk.Project, w *sdk.Workflow) error {
 	for _, eventIntegration := range w.EventIntegrations {
@@ -1491,6 +1635,7 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 		importOptions.RepositoryName = opts.RepositoryName
 		importOptions.RepositoryStrategy = opts.RepositoryStrategy
 		importOptions.HookUUID = opts.HookUUID
+		importOptions.Message = opts.Message
 	}
 
 	wf, msgList, err := ParseAndImport(ctx, tx, store, *proj, oldWf, data.Workflow, u, importOptions)
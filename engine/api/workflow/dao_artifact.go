@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"time"
+
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/sdk"
@@ -23,6 +25,9 @@ func LoadWorkfowArtifactByHash(db gorp.SqlExecutor, hash string) (*sdk.WorkflowN
 				created,
 				workflow_run_id,
 				project_integration_id,
+				pinned,
+				scan_status,
+				scan_report,
 				coalesce(sha512sum, '') AS sha512sum
 		  FROM workflow_node_run_artifacts
 		  WHERE workflow_node_run_artifacts.download_hash = $1`
@@ -52,6 +57,9 @@ func LoadArtifactByIDs(db gorp.SqlExecutor, workflowID, artifactID int64) (*sdk.
 			workflow_node_run_artifacts.created,
 			workflow_node_run_artifacts.workflow_run_id,
 			workflow_node_run_artifacts.project_integration_id,
+			workflow_node_run_artifacts.pinned,
+			workflow_node_run_artifacts.scan_status,
+			workflow_node_run_artifacts.scan_report,
 			coalesce(workflow_node_run_artifacts.sha512sum, '') AS sha512sum
 		FROM workflow_node_run_artifacts
 		JOIN workflow_run ON workflow_run.id = workflow_node_run_artifacts.workflow_run_id
@@ -81,6 +89,9 @@ func loadArtifactByNodeRunID(db gorp.SqlExecutor, nodeRunID int64) ([]sdk.Workfl
 			created,
 			workflow_run_id,
 			project_integration_id,
+			pinned,
+			scan_status,
+			scan_report,
 			coalesce(sha512sum, '') AS sha512sum
 		FROM workflow_node_run_artifacts WHERE workflow_node_run_id = $1`, nodeRunID); err != nil {
 		return nil, err
@@ -102,3 +113,58 @@ func InsertArtifact(db gorp.SqlExecutor, a *sdk.WorkflowNodeRunArtifact) error {
 	a.ID = wArtifactDB.ID
 	return nil
 }
+
+// LoadUnpinnedArtifactsCreatedBefore loads the artifacts of a project that are not pinned and were created before
+// given date, oldest first.
+func LoadUnpinnedArtifactsCreatedBefore(db gorp.SqlExecutor, projectID int64, before time.Time, limit int) ([]sdk.WorkflowNodeRunArtifact, error) {
+	var artifactsGorp []NodeRunArtifact
+	if _, err := db.Select(&artifactsGorp, `SELECT
+			workflow_node_run_artifacts.id,
+			workflow_node_run_artifacts.name,
+			workflow_node_run_artifacts.tag,
+			workflow_node_run_artifacts.ref,
+			workflow_node_run_artifacts.workflow_node_run_id,
+			workflow_node_run_artifacts.download_hash,
+			workflow_node_run_artifacts.size,
+			workflow_node_run_artifacts.perm,
+			workflow_node_run_artifacts.md5sum,
+			workflow_node_run_artifacts.object_path,
+			workflow_node_run_artifacts.created,
+			workflow_node_run_artifacts.workflow_run_id,
+			workflow_node_run_artifacts.project_integration_id,
+			workflow_node_run_artifacts.pinned,
+			workflow_node_run_artifacts.scan_status,
+			workflow_node_run_artifacts.scan_report,
+			coalesce(workflow_node_run_artifacts.sha512sum, '') AS sha512sum
+		FROM workflow_node_run_artifacts
+		JOIN workflow_run ON workflow_run.id = workflow_node_run_artifacts.workflow_run_id
+		WHERE workflow_run.project_id = $1
+		AND workflow_node_run_artifacts.pinned = false
+		AND workflow_node_run_artifacts.created < $2
+		ORDER BY workflow_node_run_artifacts.id ASC
+		LIMIT $3`, projectID, before, limit); err != nil {
+		return nil, sdk.WrapError(err, "cannot load artifacts of project %d created before %v", projectID, before)
+	}
+
+	artifacts := make([]sdk.WorkflowNodeRunArtifact, len(artifactsGorp))
+	for i := range artifactsGorp {
+		artifacts[i] = sdk.WorkflowNodeRunArtifact(artifactsGorp[i])
+	}
+	return artifacts, nil
+}
+
+// UpdateArtifactPinned pins or unpins an artifact, pinned artifacts are never deleted by the purge.
+func UpdateArtifactPinned(db gorp.SqlExecutor, id int64, pinned bool) error {
+	if _, err := db.Exec("UPDATE workflow_node_run_artifacts SET pinned = $2 WHERE id = $1", id, pinned); err != nil {
+		return sdk.WrapError(err, "cannot update artifact %d", id)
+	}
+	return nil
+}
+
+// DeleteArtifact deletes an artifact from database.
+func DeleteArtifact(db gorp.SqlExecutor, id int64) error {
+	if _, err := db.Exec("DELETE FROM workflow_node_run_artifacts WHERE id = $1", id); err != nil {
+		return sdk.WrapError(err, "cannot delete artifact %d", id)
+	}
+	return nil
+}
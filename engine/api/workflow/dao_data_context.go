@@ -21,6 +21,8 @@ type sqlNodeContextData struct {
 	DefaultPipelineParameters sql.NullString `db:"default_pipeline_parameters"`
 	Conditions                sql.NullString `db:"conditions"`
 	Mutex                     bool           `db:"mutex"`
+	DeploymentPlan            bool           `db:"deployment_plan"`
+	ReleaseTargets            sql.NullString `db:"release_targets"`
 }
 
 func insertNodeContextData(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) error {
@@ -70,6 +72,14 @@ func insertNodeContextData(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) er
 	}
 
 	tempContext.Mutex = n.Context.Mutex
+	tempContext.DeploymentPlan = n.Context.DeploymentPlan
+	if len(n.Context.ReleaseTargets) > 0 {
+		var err error
+		tempContext.ReleaseTargets, err = gorpmapping.JSONToNullString(n.Context.ReleaseTargets)
+		if err != nil {
+			return sdk.WrapError(err, "insertNodeContextData> Cannot stringify release targets")
+		}
+	}
 
 	if n.Context.PipelineID != 0 {
 		//Checks pipeline parameters
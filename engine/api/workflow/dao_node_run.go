@@ -721,6 +721,38 @@ func PreviousNodeRun(db gorp.SqlExecutor, nr sdk.WorkflowNodeRun, nodeName strin
 	return nodeRun, nil
 }
 
+// LoadLastNodeRunByName returns the last run of the node with given name in the runs of the workflow before given run
+// number, restricted to given status if not empty. It returns nil if the node was never run.
+func LoadLastNodeRunByName(db gorp.SqlExecutor, workflowID int64, nodeName string, beforeNumber int64, status string) (*sdk.WorkflowNodeRun, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM workflow_node_run
+		JOIN workflow_run ON workflow_run.id = workflow_node_run.workflow_run_id AND workflow_run.workflow_id = $1
+		WHERE workflow_node_run.workflow_node_name = $2
+			AND workflow_node_run.num < $3
+			AND ($4 = '' OR workflow_node_run.status = $4)
+		ORDER BY workflow_node_run.num DESC, workflow_node_run.sub_num DESC
+		LIMIT 1
+	`, nodeRunFields)
+
+	var rr NodeRun
+	if err := db.SelectOne(&rr, query, workflowID, nodeName, beforeNumber, status); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WrapError(err, "cannot load last run of node %s on workflow %d", nodeName, workflowID)
+	}
+	nodeRun, err := fromDBNodeRun(rr, LoadRunOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nodeRun.Artifacts, err = loadArtifactByNodeRunID(db, nodeRun.ID)
+	if err != nil {
+		return nil, err
+	}
+	return nodeRun, nil
+}
+
 //PreviousNodeRunVCSInfos returns a struct with BuildNumber, Commit Hash, Branch, Remote, Remote_url
 //for the current node run and the previous one on the same branch.
 //Returned value may be zero if node run are not found
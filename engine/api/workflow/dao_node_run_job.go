@@ -95,7 +95,7 @@ func LoadNodeJobRunQueue(ctx context.Context, db gorp.SqlExecutor, store cache.S
 	and workflow_node_run_job.status = ANY(string_to_array($3, ','))
 	AND contains_service IN ($4, $5)
 	AND (model_type is NULL OR model_type = '' OR model_type = ANY(string_to_array($6, ',')))
-	ORDER BY workflow_node_run_job.queued ASC
+	ORDER BY workflow_node_run_job.priority ASC, workflow_node_run_job.queued ASC
 	`).Args(
 		*filter.Since,                       // $1
 		*filter.Until,                       // $2
@@ -174,7 +174,7 @@ func LoadNodeJobRunQueueByGroupIDs(ctx context.Context, db gorp.SqlExecutor, sto
 		OR
 		model_type = '' OR model_type = ANY(string_to_array($6, ','))
 	)
-	ORDER BY workflow_node_run_job.queued ASC
+	ORDER BY workflow_node_run_job.priority ASC, workflow_node_run_job.queued ASC
 	`).Args(
 		*filter.Since,                          // $1
 		*filter.Until,                          // $2
@@ -385,8 +385,8 @@ func getHatcheryInfo(ctx context.Context, store cache.Store, j *JobRun) {
 
 // replaceWorkflowJobRunInQueue restart workflow node job
 func replaceWorkflowJobRunInQueue(db gorp.SqlExecutor, wNodeJob sdk.WorkflowNodeJobRun) error {
-	query := "UPDATE workflow_node_run_job SET status = $1, retry = $2, worker_id = NULL WHERE id = $3"
-	if _, err := db.Exec(query, sdk.StatusWaiting, wNodeJob.Retry+1, wNodeJob.ID); err != nil {
+	query := "UPDATE workflow_node_run_job SET status = $1, retry = $2, worker_id = NULL, retry_after = $3, infra_error = $4 WHERE id = $5"
+	if _, err := db.Exec(query, sdk.StatusWaiting, wNodeJob.Retry+1, wNodeJob.RetryAfter, wNodeJob.InfraError, wNodeJob.ID); err != nil {
 		return sdk.WrapError(err, "Unable to set workflow_node_run_job id %d with status %s", wNodeJob.ID, sdk.StatusWaiting)
 	}
 
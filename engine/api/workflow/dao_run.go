@@ -30,7 +30,9 @@ workflow_run.last_modified,
 workflow_run.status,
 workflow_run.last_sub_num,
 workflow_run.last_execution,
-workflow_run.to_delete
+workflow_run.to_delete,
+workflow_run.priority,
+workflow_run.workflow_version
 `
 
 // LoadRunOptions are options for loading a run (node or workflow)
@@ -422,6 +424,33 @@ func LoadRunsIDByTag(db gorp.SqlExecutor, projectKey, workflowName, tag, tagValu
 	return ids, nil
 }
 
+// LoadLastSuccessfulRunIDs loads the ids of the last successful runs of a workflow with a number lower than given
+// one, only the runs on given branch are loaded if it is not empty.
+func LoadLastSuccessfulRunIDs(db gorp.SqlExecutor, projectKey, workflowName string, number int64, branch string, limit int) ([]int64, error) {
+	query := `SELECT workflow_run.id
+		FROM workflow_run
+		JOIN project on workflow_run.project_id = project.id
+		JOIN workflow on workflow_run.workflow_id = workflow.id
+		WHERE project.projectkey = $1
+		AND workflow.name = $2
+		AND workflow_run.num < $3
+		AND workflow_run.status = $4
+		AND workflow_run.to_delete = false
+		AND ($5::text = '' OR EXISTS (
+			SELECT 1 FROM workflow_run_tag
+			WHERE workflow_run_tag.workflow_run_id = workflow_run.id
+			AND workflow_run_tag.tag = 'git.branch' AND workflow_run_tag.value = $5
+		))
+		ORDER BY workflow_run.num DESC
+		LIMIT $6`
+
+	var ids []int64
+	if _, err := db.Select(&ids, query, projectKey, workflowName, number, sdk.StatusSuccess, branch, limit); err != nil {
+		return nil, sdk.WrapError(err, "cannot load last successful runs of workflow %s/%s", projectKey, workflowName)
+	}
+	return ids, nil
+}
+
 func loadRunTags(db gorp.SqlExecutor, run *sdk.WorkflowRun) error {
 	dbRunTags := []RunTag{}
 	if _, err := db.Select(&dbRunTags, "SELECT * from workflow_run_tag WHERE workflow_run_id=$1", run.ID); err != nil {
@@ -582,6 +611,16 @@ func CreateRun(db *gorp.DbMap, wf *sdk.Workflow, opts *sdk.WorkflowRunPostHandle
 		Workflow:      sdk.Workflow{Name: wf.Name},
 	}
 
+	wr.WorkflowVersion, err = LoadLastVersionNumber(db, wf.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	wr.Priority = wf.Priority
+	if opts != nil && opts.Priority != "" {
+		wr.Priority = opts.Priority
+	}
+
 	if opts != nil && opts.Hook != nil {
 		if trigg, ok := opts.Hook.Payload["cds.triggered_by.username"]; ok {
 			wr.Tag(tagTriggeredBy, trigg)
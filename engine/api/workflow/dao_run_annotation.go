@@ -0,0 +1,63 @@
+package workflow
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadRunAnnotations loads the annotations of given workflow run ordered by key.
+func LoadRunAnnotations(db gorp.SqlExecutor, workflowRunID int64) ([]sdk.WorkflowRunAnnotation, error) {
+	var dbAnnotations []RunAnnotation
+	if _, err := db.Select(&dbAnnotations, "SELECT * FROM workflow_run_annotation WHERE workflow_run_id = $1 ORDER BY key", workflowRunID); err != nil {
+		return nil, sdk.WrapError(err, "unable to load annotations of workflow run %d", workflowRunID)
+	}
+	annotations := make([]sdk.WorkflowRunAnnotation, len(dbAnnotations))
+	for i := range dbAnnotations {
+		annotations[i] = sdk.WorkflowRunAnnotation(dbAnnotations[i])
+	}
+	return annotations, nil
+}
+
+// UpsertRunAnnotation inserts given annotation, or updates the annotation of the run with the same key.
+func UpsertRunAnnotation(db gorp.SqlExecutor, a *sdk.WorkflowRunAnnotation) error {
+	var old RunAnnotation
+	err := db.SelectOne(&old, "SELECT * FROM workflow_run_annotation WHERE workflow_run_id = $1 AND key = $2", a.WorkflowRunID, a.Key)
+	if err != nil && err != sql.ErrNoRows {
+		return sdk.WrapError(err, "unable to load annotation %s", a.Key)
+	}
+
+	a.Created = time.Now()
+	dbAnnotation := RunAnnotation(*a)
+	if err == sql.ErrNoRows {
+		if err := db.Insert(&dbAnnotation); err != nil {
+			return sdk.WrapError(err, "unable to insert annotation %s", a.Key)
+		}
+	} else {
+		dbAnnotation.ID = old.ID
+		if _, err := db.Update(&dbAnnotation); err != nil {
+			return sdk.WrapError(err, "unable to update annotation %s", a.Key)
+		}
+	}
+	a.ID = dbAnnotation.ID
+	return nil
+}
+
+// DeleteRunAnnotation deletes the annotation of given workflow run with given key.
+func DeleteRunAnnotation(db gorp.SqlExecutor, workflowRunID int64, key string) error {
+	res, err := db.Exec("DELETE FROM workflow_run_annotation WHERE workflow_run_id = $1 AND key = $2", workflowRunID, key)
+	if err != nil {
+		return sdk.WrapError(err, "unable to delete annotation %s", key)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if n == 0 {
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "annotation %s not found", key)
+	}
+	return nil
+}
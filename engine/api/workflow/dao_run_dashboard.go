@@ -0,0 +1,95 @@
+package workflow
+
+import (
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const dashboardRunFields = `
+	project.projectkey AS project_key,
+	workflow.name AS workflow_name,
+	workflow_run.num,
+	workflow_run.status,
+	workflow_run.start,
+	workflow_run.last_modified,
+	COALESCE((
+		SELECT workflow_run_tag.value FROM workflow_run_tag
+		WHERE workflow_run_tag.workflow_run_id = workflow_run.id AND workflow_run_tag.tag = 'git.branch'
+		LIMIT 1
+	), '') AS branch`
+
+// LoadLastRunsSummaries returns a summary of the last runs of a workflow, most recent first.
+func LoadLastRunsSummaries(db gorp.SqlExecutor, projectKey, workflowName string, limit int) ([]sdk.DashboardRun, error) {
+	query := `SELECT ` + dashboardRunFields + `
+	FROM workflow_run
+	JOIN project ON workflow_run.project_id = project.id
+	JOIN workflow ON workflow_run.workflow_id = workflow.id
+	WHERE project.projectkey = $1 AND workflow.name = $2 AND workflow_run.to_delete = false
+	ORDER BY workflow_run.num DESC
+	LIMIT $3`
+	var rs []sdk.DashboardRun
+	if _, err := db.Select(&rs, query, projectKey, workflowName, limit); err != nil {
+		return nil, sdk.WrapError(err, "unable to load last runs of workflow %s/%s", projectKey, workflowName)
+	}
+	return rs, nil
+}
+
+// LoadFailingWorkflows returns the last finished run of the workflows of given projects that failed, most recent
+// first. Workflows whose last finished run succeeded are not returned.
+func LoadFailingWorkflows(db gorp.SqlExecutor, projectKeys []string, limit int) ([]sdk.DashboardRun, error) {
+	query := `SELECT * FROM (
+		SELECT DISTINCT ON (workflow_run.workflow_id) ` + dashboardRunFields + `
+		FROM workflow_run
+		JOIN project ON workflow_run.project_id = project.id
+		JOIN workflow ON workflow_run.workflow_id = workflow.id
+		WHERE project.projectkey = ANY($1)
+		AND workflow_run.to_delete = false
+		AND workflow_run.status = ANY($2)
+		ORDER BY workflow_run.workflow_id, workflow_run.num DESC
+	) AS last_run
+	WHERE last_run.status = $3
+	ORDER BY last_run.last_modified DESC
+	LIMIT $4`
+	var rs []sdk.DashboardRun
+	if _, err := db.Select(&rs, query, pq.StringArray(projectKeys), pq.StringArray([]string{sdk.StatusSuccess, sdk.StatusFail}), sdk.StatusFail, limit); err != nil {
+		return nil, sdk.WrapError(err, "unable to load failing workflows")
+	}
+	return rs, nil
+}
+
+// CountNodeJobRunsByProjectKeys returns the number of jobs waiting and building for each given project, in the
+// same order.
+func CountNodeJobRunsByProjectKeys(db gorp.SqlExecutor, projectKeys []string) ([]sdk.DashboardQueueDepth, error) {
+	query := `SELECT project.projectkey AS project_key, workflow_node_run_job.status, COUNT(workflow_node_run_job.id) AS count
+	FROM workflow_node_run_job
+	JOIN project ON workflow_node_run_job.project_id = project.id
+	WHERE project.projectkey = ANY($1) AND workflow_node_run_job.status = ANY($2)
+	GROUP BY project.projectkey, workflow_node_run_job.status`
+	var counts []struct {
+		ProjectKey string `db:"project_key"`
+		Status     string `db:"status"`
+		Count      int64  `db:"count"`
+	}
+	if _, err := db.Select(&counts, query, pq.StringArray(projectKeys), pq.StringArray([]string{sdk.StatusWaiting, sdk.StatusBuilding})); err != nil {
+		return nil, sdk.WrapError(err, "unable to count jobs by project")
+	}
+
+	res := make([]sdk.DashboardQueueDepth, len(projectKeys))
+	for i := range projectKeys {
+		res[i].ProjectKey = projectKeys[i]
+		for _, c := range counts {
+			if c.ProjectKey != projectKeys[i] {
+				continue
+			}
+			switch c.Status {
+			case sdk.StatusWaiting:
+				res[i].Waiting = c.Count
+			case sdk.StatusBuilding:
+				res[i].Building = c.Count
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,101 @@
+package workflow
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadRunJobFingerprints loads the environment fingerprints of the jobs of given workflow run ordered by creation date.
+func LoadRunJobFingerprints(db gorp.SqlExecutor, workflowRunID int64) ([]sdk.WorkflowRunJobFingerprint, error) {
+	var dbFingerprints []RunJobFingerprint
+	if _, err := db.Select(&dbFingerprints, "SELECT * FROM workflow_run_job_fingerprint WHERE workflow_run_id = $1 ORDER BY created, id", workflowRunID); err != nil {
+		return nil, sdk.WrapError(err, "unable to load job fingerprints of workflow run %d", workflowRunID)
+	}
+	fingerprints := make([]sdk.WorkflowRunJobFingerprint, len(dbFingerprints))
+	for i := range dbFingerprints {
+		fingerprints[i] = sdk.WorkflowRunJobFingerprint(dbFingerprints[i])
+	}
+	return fingerprints, nil
+}
+
+// LoadLastSuccessfulRunJobFingerprint loads the fingerprint of the last successful run of a job in the runs of given
+// workflow before given run number. It returns nil if the job never succeeded before.
+func LoadLastSuccessfulRunJobFingerprint(db gorp.SqlExecutor, workflowID, number int64, nodeName, jobName string) (*sdk.WorkflowRunJobFingerprint, error) {
+	query := `
+	SELECT workflow_run_job_fingerprint.*
+	FROM workflow_run_job_fingerprint
+	JOIN workflow_run ON workflow_run.id = workflow_run_job_fingerprint.workflow_run_id
+	WHERE workflow_run.workflow_id = $1
+	AND workflow_run_job_fingerprint.num < $2
+	AND workflow_run_job_fingerprint.node_name = $3
+	AND workflow_run_job_fingerprint.job_name = $4
+	AND workflow_run_job_fingerprint.status = $5
+	ORDER BY workflow_run_job_fingerprint.num DESC, workflow_run_job_fingerprint.id DESC
+	LIMIT 1`
+	var dbFingerprint RunJobFingerprint
+	if err := db.SelectOne(&dbFingerprint, query, workflowID, number, nodeName, jobName, sdk.StatusSuccess); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WrapError(err, "unable to load last successful fingerprint of job %s/%s", nodeName, jobName)
+	}
+	f := sdk.WorkflowRunJobFingerprint(dbFingerprint)
+	return &f, nil
+}
+
+// InsertRunJobFingerprint inserts given job fingerprint of a workflow run.
+func InsertRunJobFingerprint(db gorp.SqlExecutor, f *sdk.WorkflowRunJobFingerprint) error {
+	f.Created = time.Now()
+	dbFingerprint := RunJobFingerprint(*f)
+	if err := db.Insert(&dbFingerprint); err != nil {
+		return sdk.WrapError(err, "unable to insert fingerprint of job %d for workflow run %d", f.WorkflowNodeJobRunID, f.WorkflowRunID)
+	}
+	f.ID = dbFingerprint.ID
+	return nil
+}
+
+// CompareRunJobFingerprints compares the fingerprint of each job of given workflow run with the fingerprint of the
+// last successful run of the same job. When a job was run many times in the workflow run, its last run is compared.
+func CompareRunJobFingerprints(db gorp.SqlExecutor, wr sdk.WorkflowRun) ([]sdk.WorkflowRunJobFingerprintComparison, error) {
+	fingerprints, err := LoadRunJobFingerprints(db, wr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the last fingerprint of each job
+	indexes := make(map[string]int, len(fingerprints))
+	var lasts []sdk.WorkflowRunJobFingerprint
+	for _, f := range fingerprints {
+		k := f.NodeName + "/" + f.JobName
+		if i, ok := indexes[k]; ok {
+			lasts[i] = f
+			continue
+		}
+		indexes[k] = len(lasts)
+		lasts = append(lasts, f)
+	}
+
+	comparisons := make([]sdk.WorkflowRunJobFingerprintComparison, len(lasts))
+	for i, f := range lasts {
+		comparisons[i] = sdk.WorkflowRunJobFingerprintComparison{
+			NodeName:  f.NodeName,
+			JobName:   f.JobName,
+			SubNumber: f.SubNumber,
+			Status:    f.Status,
+		}
+		ref, err := LoadLastSuccessfulRunJobFingerprint(db, wr.WorkflowID, wr.Number, f.NodeName, f.JobName)
+		if err != nil {
+			return nil, err
+		}
+		if ref == nil {
+			continue
+		}
+		comparisons[i].ReferenceNumber = ref.Number
+		comparisons[i].Differences = f.Fingerprint.Diff(ref.Fingerprint)
+	}
+	return comparisons, nil
+}
@@ -0,0 +1,104 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadRunReleaseTargets loads the release targets of given workflow run ordered by node and creation, filtered by node
+// if node IDs are given.
+func LoadRunReleaseTargets(db gorp.SqlExecutor, workflowRunID int64, nodeIDs ...int64) ([]sdk.WorkflowRunReleaseTarget, error) {
+	query := "SELECT * FROM workflow_run_release_target WHERE workflow_run_id = $1 ORDER BY workflow_node_name, id"
+	args := []interface{}{workflowRunID}
+	if len(nodeIDs) > 0 {
+		query = "SELECT * FROM workflow_run_release_target WHERE workflow_run_id = $1 AND workflow_node_id = ANY($2) ORDER BY workflow_node_name, id"
+		args = append(args, pq.Int64Array(nodeIDs))
+	}
+
+	var dbTargets []RunReleaseTarget
+	if _, err := db.Select(&dbTargets, query, args...); err != nil {
+		return nil, sdk.WrapError(err, "unable to load release targets of workflow run %d", workflowRunID)
+	}
+	targets := make([]sdk.WorkflowRunReleaseTarget, len(dbTargets))
+	for i := range dbTargets {
+		targets[i] = sdk.WorkflowRunReleaseTarget(dbTargets[i])
+	}
+	return targets, nil
+}
+
+// InsertRunReleaseTarget inserts given release target of a workflow run.
+func InsertRunReleaseTarget(db gorp.SqlExecutor, t *sdk.WorkflowRunReleaseTarget) error {
+	t.LastModified = time.Now()
+	dbTarget := RunReleaseTarget(*t)
+	if err := db.Insert(&dbTarget); err != nil {
+		return sdk.WrapError(err, "unable to insert release target %s of workflow run %d", t.ProjectIntegrationName, t.WorkflowRunID)
+	}
+	t.ID = dbTarget.ID
+	return nil
+}
+
+// UpdateRunReleaseTarget updates given release target of a workflow run.
+func UpdateRunReleaseTarget(db gorp.SqlExecutor, t *sdk.WorkflowRunReleaseTarget) error {
+	t.LastModified = time.Now()
+	dbTarget := RunReleaseTarget(*t)
+	if _, err := db.Update(&dbTarget); err != nil {
+		return sdk.WrapError(err, "unable to update release target %s of workflow run %d", t.ProjectIntegrationName, t.WorkflowRunID)
+	}
+	return nil
+}
+
+// StartRunReleaseTargets returns the release targets of the node of given node run: the integration of the node then
+// its release targets. Missing targets are created, the targets that were not successfully published by a previous run
+// of the node are set to building for given node run. It returns no target if the node has no release targets.
+func StartRunReleaseTargets(db gorp.SqlExecutor, wr sdk.WorkflowRun, nodeRun sdk.WorkflowNodeRun) ([]sdk.WorkflowRunReleaseTarget, error) {
+	node := wr.Workflow.WorkflowData.NodeByID(nodeRun.WorkflowNodeID)
+	if node == nil || node.Context == nil || len(node.Context.ReleaseTargets) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(node.Context.ReleaseTargets)+1)
+	if pi, ok := wr.Workflow.ProjectIntegrations[node.Context.ProjectIntegrationID]; ok {
+		names = append(names, pi.Name)
+	}
+	names = append(names, node.Context.ReleaseTargets...)
+
+	existing, err := LoadRunReleaseTargets(db, wr.ID, node.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]sdk.WorkflowRunReleaseTarget, 0, len(names))
+	for _, name := range names {
+		var t *sdk.WorkflowRunReleaseTarget
+		for i := range existing {
+			if existing[i].ProjectIntegrationName == name {
+				t = &existing[i]
+				break
+			}
+		}
+		if t == nil {
+			t = &sdk.WorkflowRunReleaseTarget{
+				WorkflowRunID:          wr.ID,
+				WorkflowNodeID:         node.ID,
+				WorkflowNodeRunID:      nodeRun.ID,
+				WorkflowNodeName:       node.Name,
+				ProjectIntegrationName: name,
+				Status:                 sdk.StatusBuilding,
+			}
+			if err := InsertRunReleaseTarget(db, t); err != nil {
+				return nil, err
+			}
+		} else if t.Status != sdk.StatusSuccess {
+			t.WorkflowNodeRunID = nodeRun.ID
+			t.Status = sdk.StatusBuilding
+			if err := UpdateRunReleaseTarget(db, t); err != nil {
+				return nil, err
+			}
+		}
+		targets = append(targets, *t)
+	}
+	return targets, nil
+}
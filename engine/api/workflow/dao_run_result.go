@@ -0,0 +1,42 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadRunResults loads the results of given workflow run ordered by creation date, filtered by type if types are
+// given.
+func LoadRunResults(db gorp.SqlExecutor, workflowRunID int64, types ...string) ([]sdk.WorkflowRunResult, error) {
+	query := "SELECT * FROM workflow_run_result WHERE workflow_run_id = $1 ORDER BY created, id"
+	args := []interface{}{workflowRunID}
+	if len(types) > 0 {
+		query = "SELECT * FROM workflow_run_result WHERE workflow_run_id = $1 AND type = ANY($2) ORDER BY created, id"
+		args = append(args, pq.StringArray(types))
+	}
+
+	var dbResults []RunResult
+	if _, err := db.Select(&dbResults, query, args...); err != nil {
+		return nil, sdk.WrapError(err, "unable to load results of workflow run %d", workflowRunID)
+	}
+	results := make([]sdk.WorkflowRunResult, len(dbResults))
+	for i := range dbResults {
+		results[i] = sdk.WorkflowRunResult(dbResults[i])
+	}
+	return results, nil
+}
+
+// InsertRunResult inserts given workflow run result.
+func InsertRunResult(db gorp.SqlExecutor, r *sdk.WorkflowRunResult) error {
+	r.Created = time.Now()
+	dbResult := RunResult(*r)
+	if err := db.Insert(&dbResult); err != nil {
+		return sdk.WrapError(err, "unable to insert %s result of workflow run %d", r.Type, r.WorkflowRunID)
+	}
+	r.ID = dbResult.ID
+	return nil
+}
@@ -0,0 +1,131 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// runSearchQuery builds the where clause matching given filter, every filter is translated to a condition on
+// indexed columns of workflow_run, workflow_run_tag and workflow_run_annotation.
+type runSearchQuery struct {
+	conditions []string
+	args       []interface{}
+}
+
+func (q *runSearchQuery) arg(v interface{}) string {
+	q.args = append(q.args, v)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+func (q *runSearchQuery) where(format string, args ...interface{}) {
+	placeholders := make([]interface{}, len(args))
+	for i := range args {
+		placeholders[i] = q.arg(args[i])
+	}
+	q.conditions = append(q.conditions, fmt.Sprintf(format, placeholders...))
+}
+
+func (q runSearchQuery) String() string {
+	return strings.Join(q.conditions, "\n\tAND ")
+}
+
+func newRunSearchQuery(projectKey string, filter sdk.WorkflowRunSearchFilter) runSearchQuery {
+	var q runSearchQuery
+	q.where("project.projectkey = %s", projectKey)
+	q.where("workflow_run.to_delete = false")
+	if len(filter.Workflows) > 0 {
+		q.where("workflow.name = ANY(%s)", pq.StringArray(filter.Workflows))
+	}
+	if len(filter.Status) > 0 {
+		q.where("workflow_run.status = ANY(%s)", pq.StringArray(filter.Status))
+	}
+
+	tags := filter.AllTags()
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		q.where(`EXISTS (
+			SELECT 1 FROM workflow_run_tag
+			WHERE workflow_run_tag.workflow_run_id = workflow_run.id
+			AND workflow_run_tag.tag = %s AND workflow_run_tag.value = %s
+		)`, k, tags[k])
+	}
+
+	keys = keys[:0]
+	for k := range filter.Annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		q.where(`EXISTS (
+			SELECT 1 FROM workflow_run_annotation
+			WHERE workflow_run_annotation.workflow_run_id = workflow_run.id
+			AND workflow_run_annotation.key = %s AND workflow_run_annotation.value = %s
+		)`, k, filter.Annotations[k])
+	}
+
+	if filter.StartAfter != nil {
+		q.where("workflow_run.start >= %s", *filter.StartAfter)
+	}
+	if filter.StartBefore != nil {
+		q.where("workflow_run.start <= %s", *filter.StartBefore)
+	}
+	if filter.MinDuration > 0 {
+		q.where("workflow_run.last_modified - workflow_run.start >= %s * INTERVAL '1 second'", filter.MinDuration)
+	}
+	if filter.MaxDuration > 0 {
+		q.where("workflow_run.last_modified - workflow_run.start <= %s * INTERVAL '1 second'", filter.MaxDuration)
+	}
+	return q
+}
+
+// SearchRuns loads the runs of a project matching given filter, most recent first.
+// It returns runs, offset, limit count and an error.
+func SearchRuns(db gorp.SqlExecutor, projectKey string, filter sdk.WorkflowRunSearchFilter, offset, limit int) ([]sdk.WorkflowRun, int, int, int, error) {
+	q := newRunSearchQuery(projectKey, filter)
+
+	queryCount := `SELECT COUNT(workflow_run.id)
+	FROM workflow_run
+	JOIN project ON workflow_run.project_id = project.id
+	JOIN workflow ON workflow_run.workflow_id = workflow.id
+	WHERE ` + q.String()
+	count, err := db.SelectInt(queryCount, q.args...)
+	if err != nil {
+		return nil, 0, 0, 0, sdk.WrapError(err, "unable to count runs")
+	}
+	if count == 0 {
+		return nil, 0, 0, 0, nil
+	}
+
+	query := fmt.Sprintf(`SELECT %s
+	FROM workflow_run
+	JOIN project ON workflow_run.project_id = project.id
+	JOIN workflow ON workflow_run.workflow_id = workflow.id
+	WHERE %s
+	ORDER BY workflow_run.start DESC
+	LIMIT %s OFFSET %s`, wfRunfields, q.String(), q.arg(limit), q.arg(offset))
+
+	runs := []Run{}
+	if _, err := db.Select(&runs, query, q.args...); err != nil {
+		return nil, 0, 0, 0, sdk.WrapError(err, "unable to search runs")
+	}
+	wruns := make([]sdk.WorkflowRun, len(runs))
+	for i := range runs {
+		wr := sdk.WorkflowRun(runs[i])
+		if err := loadRunTags(db, &wr); err != nil {
+			return nil, 0, 0, 0, sdk.WrapError(err, "unable to load tags")
+		}
+		wruns[i] = wr
+	}
+
+	return wruns, offset, limit, int(count), nil
+}
@@ -0,0 +1,51 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestNewRunSearchQuery(t *testing.T) {
+	after := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := newRunSearchQuery("KEY", sdk.WorkflowRunSearchFilter{
+		Workflows:   []string{"wf1", "wf2"},
+		Status:      []string{sdk.StatusFail},
+		Branch:      "master",
+		Tags:        map[string]string{"env": "prod"},
+		StartAfter:  &after,
+		MinDuration: 60,
+	})
+
+	require.Len(t, q.args, 9)
+	assert.Equal(t, "KEY", q.args[0])
+	assert.Equal(t, pq.StringArray{"wf1", "wf2"}, q.args[1])
+	assert.Equal(t, pq.StringArray{sdk.StatusFail}, q.args[2])
+	assert.Equal(t, []interface{}{"env", "prod", "git.branch", "master"}, q.args[3:7])
+	assert.Equal(t, after, q.args[7])
+	assert.Equal(t, int64(60), q.args[8])
+
+	query := q.String()
+	assert.True(t, strings.HasPrefix(query, "project.projectkey = $1"))
+	assert.Contains(t, query, "workflow.name = ANY($2)")
+	assert.Contains(t, query, "workflow_run.status = ANY($3)")
+	assert.Contains(t, query, "workflow_run_tag.tag = $4 AND workflow_run_tag.value = $5")
+	assert.Contains(t, query, "workflow_run.start >= $8")
+	assert.Contains(t, query, "workflow_run.last_modified - workflow_run.start >= $9 * INTERVAL '1 second'")
+}
+
+func TestNewRunSearchQueryWithAnnotations(t *testing.T) {
+	q := newRunSearchQuery("KEY", sdk.WorkflowRunSearchFilter{
+		Annotations: map[string]string{"ticket": "CDS-1", "deployed version": "1.0.1"},
+	})
+
+	require.Len(t, q.args, 5)
+	assert.Equal(t, []interface{}{"deployed version", "1.0.1", "ticket", "CDS-1"}, q.args[1:5])
+	assert.Contains(t, q.String(), "workflow_run_annotation.key = $2 AND workflow_run_annotation.value = $3")
+}
@@ -0,0 +1,42 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadRunSecretUsages loads the secrets read by the jobs of given workflow run ordered by creation date, filtered by
+// node names if names are given.
+func LoadRunSecretUsages(db gorp.SqlExecutor, workflowRunID int64, nodeNames ...string) ([]sdk.WorkflowRunSecretUsage, error) {
+	query := "SELECT * FROM workflow_run_secret_usage WHERE workflow_run_id = $1 ORDER BY created, id"
+	args := []interface{}{workflowRunID}
+	if len(nodeNames) > 0 {
+		query = "SELECT * FROM workflow_run_secret_usage WHERE workflow_run_id = $1 AND node_name = ANY($2) ORDER BY created, id"
+		args = append(args, pq.StringArray(nodeNames))
+	}
+
+	var dbUsages []RunSecretUsage
+	if _, err := db.Select(&dbUsages, query, args...); err != nil {
+		return nil, sdk.WrapError(err, "unable to load secret usages of workflow run %d", workflowRunID)
+	}
+	usages := make([]sdk.WorkflowRunSecretUsage, len(dbUsages))
+	for i := range dbUsages {
+		usages[i] = sdk.WorkflowRunSecretUsage(dbUsages[i])
+	}
+	return usages, nil
+}
+
+// InsertRunSecretUsage inserts given secret usage of a workflow run.
+func InsertRunSecretUsage(db gorp.SqlExecutor, u *sdk.WorkflowRunSecretUsage) error {
+	u.Created = time.Now()
+	dbUsage := RunSecretUsage(*u)
+	if err := db.Insert(&dbUsage); err != nil {
+		return sdk.WrapError(err, "unable to insert usage of secret %s for workflow run %d", u.Name, u.WorkflowRunID)
+	}
+	u.ID = dbUsage.ID
+	return nil
+}
@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// LoadVersions loads the versions of given workflow, the last one first. Data of the versions is not loaded.
+func LoadVersions(db gorp.SqlExecutor, workflowID int64) ([]sdk.WorkflowVersion, error) {
+	query := `
+		SELECT id, workflow_id, version, author, message, '' AS data, created
+		FROM workflow_version WHERE workflow_id = $1 ORDER BY version DESC
+	`
+	var dbVersions []dbWorkflowVersion
+	if _, err := db.Select(&dbVersions, query, workflowID); err != nil {
+		return nil, sdk.WrapError(err, "unable to load versions of workflow %d", workflowID)
+	}
+	versions := make([]sdk.WorkflowVersion, len(dbVersions))
+	for i := range dbVersions {
+		versions[i] = sdk.WorkflowVersion(dbVersions[i])
+	}
+	return versions, nil
+}
+
+// LoadVersion loads a version of given workflow with its data.
+func LoadVersion(db gorp.SqlExecutor, workflowID, version int64) (*sdk.WorkflowVersion, error) {
+	var dbVersion dbWorkflowVersion
+	if err := db.SelectOne(&dbVersion, "SELECT * FROM workflow_version WHERE workflow_id = $1 AND version = $2", workflowID, version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "version %d not found", version)
+		}
+		return nil, sdk.WrapError(err, "unable to load version %d of workflow %d", version, workflowID)
+	}
+	v := sdk.WorkflowVersion(dbVersion)
+	return &v, nil
+}
+
+// LoadLastVersionNumber returns the number of the last version of given workflow, 0 if the workflow has no version.
+func LoadLastVersionNumber(db gorp.SqlExecutor, workflowID int64) (int64, error) {
+	n, err := db.SelectInt("SELECT COALESCE(MAX(version), 0) FROM workflow_version WHERE workflow_id = $1", workflowID)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to load last version of workflow %d", workflowID)
+	}
+	return n, nil
+}
+
+// InsertVersion saves the definition of given workflow as a new version if it changed since the last version. The last
+// version is returned if the definition didn't change.
+func InsertVersion(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, workflowID int64, u sdk.Identifiable, message string) (*sdk.WorkflowVersion, error) {
+	wf, err := LoadByID(ctx, db, store, proj, workflowID, LoadOptions{})
+	if err != nil {
+		return nil, err
+	}
+	exported, err := exportentities.NewWorkflow(ctx, *wf)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to export workflow")
+	}
+	data, err := exportentities.Marshal(exported, exportentities.FormatYAML)
+	if err != nil {
+		return nil, err
+	}
+
+	last, err := LoadLastVersionNumber(db, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	if last > 0 {
+		lastVersion, err := LoadVersion(db, workflowID, last)
+		if err != nil {
+			return nil, err
+		}
+		if lastVersion.Data == string(data) {
+			return lastVersion, nil
+		}
+	}
+
+	v := sdk.WorkflowVersion{
+		WorkflowID: workflowID,
+		Version:    last + 1,
+		Message:    message,
+		Data:       string(data),
+		Created:    time.Now(),
+	}
+	if u != nil {
+		v.Author = u.GetUsername()
+	}
+	dbVersion := dbWorkflowVersion(v)
+	if err := db.Insert(&dbVersion); err != nil {
+		return nil, sdk.WrapError(err, "unable to insert version %d of workflow %d", v.Version, workflowID)
+	}
+	v.ID = dbVersion.ID
+	return &v, nil
+}
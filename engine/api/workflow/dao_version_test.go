@@ -0,0 +1,79 @@
+package workflow_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/pipeline"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestInsertVersion(t *testing.T) {
+	db, cache, end := test.SetupPG(t)
+	defer end()
+
+	u, _ := assets.InsertLambdaUser(t, db)
+
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, key, key)
+	pip := sdk.Pipeline{ProjectID: proj.ID, ProjectKey: proj.Key, Name: "pip1"}
+	require.NoError(t, pipeline.InsertPipeline(db, &pip))
+	proj, _ = project.LoadByID(db, proj.ID, project.LoadOptions.WithApplications, project.LoadOptions.WithPipelines, project.LoadOptions.WithEnvironments, project.LoadOptions.WithGroups)
+
+	w := sdk.Workflow{
+		Name:       sdk.RandomString(10),
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		WorkflowData: sdk.WorkflowData{
+			Node: sdk.Node{
+				Name:    "node1",
+				Ref:     "node1",
+				Type:    sdk.NodeTypePipeline,
+				Context: &sdk.NodeContext{PipelineID: pip.ID},
+			},
+		},
+	}
+	require.NoError(t, workflow.Insert(context.TODO(), db, cache, *proj, &w))
+
+	v1, err := workflow.InsertVersion(context.TODO(), db, cache, *proj, w.ID, u, "first version")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), v1.Version)
+	assert.Equal(t, u.Username, v1.Author)
+	assert.Contains(t, v1.Data, "pipeline: pip1")
+
+	// A version is not created if the definition didn't change
+	v, err := workflow.InsertVersion(context.TODO(), db, cache, *proj, w.ID, u, "no change")
+	require.NoError(t, err)
+	assert.Equal(t, v1.ID, v.ID)
+
+	w.Description = "my workflow"
+	require.NoError(t, workflow.Update(context.TODO(), db, cache, *proj, &w, workflow.UpdateOptions{}))
+	v2, err := workflow.InsertVersion(context.TODO(), db, cache, *proj, w.ID, u, "add description")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), v2.Version)
+
+	versions, err := workflow.LoadVersions(db, w.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, int64(2), versions[0].Version)
+	assert.Equal(t, "add description", versions[0].Message)
+	assert.Empty(t, versions[0].Data)
+
+	v, err = workflow.LoadVersion(db, w.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, v1.Data, v.Data)
+
+	// Runs are created with the last version of the workflow
+	w1, err := workflow.Load(context.TODO(), db, cache, *proj, w.Name, workflow.LoadOptions{})
+	require.NoError(t, err)
+	wr, err := workflow.CreateRun(db, w1, nil, u)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), wr.WorkflowVersion)
+}
@@ -0,0 +1,40 @@
+package workflow
+
+import (
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/sdk"
+)
+
+// recordDeployment records the deployment of an application on an environment when given node run succeeded
+// on a node configured with an environment, an application and a deployment integration.
+func recordDeployment(db gorp.SqlExecutor, wr *sdk.WorkflowRun, node *sdk.Node, nr *sdk.WorkflowNodeRun) error {
+	if nr.Status != sdk.StatusSuccess || node == nil || node.Context == nil {
+		return nil
+	}
+	if node.Context.EnvironmentID == 0 || node.Context.EnvironmentID == sdk.DefaultEnv.ID ||
+		node.Context.ApplicationID == 0 || node.Context.ProjectIntegrationID == 0 {
+		return nil
+	}
+	if pp, ok := wr.Workflow.ProjectIntegrations[node.Context.ProjectIntegrationID]; ok && !pp.Model.Supports(sdk.IntegrationTypeDeployment) {
+		return nil
+	}
+
+	d := sdk.EnvironmentDeployment{
+		EnvironmentID:        node.Context.EnvironmentID,
+		ApplicationID:        node.Context.ApplicationID,
+		ProjectIntegrationID: node.Context.ProjectIntegrationID,
+		WorkflowID:           wr.WorkflowID,
+		WorkflowNodeID:       node.ID,
+		WorkflowRunID:        wr.ID,
+		WorkflowNodeRunID:    nr.ID,
+		Number:               nr.Number,
+		SubNumber:            nr.SubNumber,
+		Version:              sdk.ParameterValue(nr.BuildParameters, "cds.version"),
+		VCSHash:              nr.VCSHash,
+		Deployed:             nr.Done,
+		Status:               sdk.StatusSuccess,
+	}
+	return environment.InsertDeployment(db, &d)
+}
@@ -10,11 +10,13 @@ import (
 	"github.com/go-gorp/gorp"
 	"github.com/ovh/cds/engine/api/application"
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/cost"
 	"github.com/ovh/cds/engine/api/environment"
 	"github.com/ovh/cds/engine/api/integration"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/interpolate"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -24,6 +26,7 @@ type ProcessorReport struct {
 	jobs      []sdk.WorkflowNodeJobRun
 	nodes     []sdk.WorkflowNodeRun
 	workflows []sdk.WorkflowRun
+	mutexes   []sdk.EventRunWorkflowNodeMutex
 	errors    []error
 }
 
@@ -39,6 +42,11 @@ func (r *ProcessorReport) Workflows() []sdk.WorkflowRun {
 	return r.workflows
 }
 
+// Mutexes returns the node mutexes acquired by node runs
+func (r *ProcessorReport) Mutexes() []sdk.EventRunWorkflowNodeMutex {
+	return r.mutexes
+}
+
 // WorkflowRuns returns the list of concerned workflow runs
 func (r *ProcessorReport) WorkflowRuns() []sdk.WorkflowRun {
 	if r == nil {
@@ -68,6 +76,8 @@ func (r *ProcessorReport) Add(ctx context.Context, i ...interface{}) {
 			r.workflows = append(r.workflows, x)
 		case *sdk.WorkflowRun:
 			r.workflows = append(r.workflows, *x)
+		case sdk.EventRunWorkflowNodeMutex:
+			r.mutexes = append(r.mutexes, x)
 		default:
 			log.Warning(ctx, "ProcessorReport> unknown type %T", w)
 		}
@@ -92,6 +102,7 @@ func (r *ProcessorReport) All() []interface{} {
 	res = append(res, sdk.InterfaceSlice(r.workflows)...)
 	res = append(res, sdk.InterfaceSlice(r.nodes)...)
 	res = append(res, sdk.InterfaceSlice(r.jobs)...)
+	res = append(res, sdk.InterfaceSlice(r.mutexes)...)
 	res = append(res, sdk.InterfaceSlice(r.errors)...)
 	return res
 }
@@ -171,6 +182,10 @@ func UpdateNodeJobRunStatus(ctx context.Context, db gorp.SqlExecutor, store cach
 		if err := UpdateWorkflowRun(ctx, db, wf); err != nil {
 			return nil, sdk.WrapError(err, "Cannot update WorkflowRun %d", wf.ID)
 		}
+
+		if err := cost.SetUsageDone(db, job.ID, job.Done); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("workflow.UpdateNodeJobRunStatus> Cannot update WorkflowNodeJobRun %d to status %v", job.ID, status)
 	}
@@ -409,10 +424,74 @@ func LoadNodeJobRunKeys(ctx context.Context, db gorp.SqlExecutor, proj *sdk.Proj
 	return params, secrets, nil
 }
 
+// LoadNodeJobRunDockerRegistries loads the docker registry integrations used by the DockerBuild steps of a job run.
+// Registry address, username and cache repository are returned as cds.registry.{name}.* parameters, and the password
+// as a secret. Steps referencing an unknown integration are ignored, they will fail on the worker.
+func LoadNodeJobRunDockerRegistries(ctx context.Context, db gorp.SqlExecutor, proj *sdk.Project, pv []sdk.Variable, job *sdk.WorkflowNodeJobRun) ([]sdk.Parameter, []sdk.Variable, error) {
+	params := []sdk.Parameter{}
+	secrets := []sdk.Variable{}
+
+	vars := sdk.ParametersToMap(job.Parameters)
+	names := map[string]struct{}{}
+	var findNames func(as []sdk.Action)
+	findNames = func(as []sdk.Action) {
+		for _, a := range as {
+			if a.Type == sdk.BuiltinAction && a.Name == sdk.DockerBuildAction {
+				name, err := interpolate.Do(sdk.ParameterValue(a.Parameters, "integration"), vars)
+				if err == nil && name != "" {
+					names[name] = struct{}{}
+				}
+			}
+			findNames(a.Actions)
+		}
+	}
+	findNames(job.Job.Action.Actions)
+
+	for name := range names {
+		projectIntegration, err := integration.LoadProjectIntegrationByNameWithClearPassword(db, proj.Key, name)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				log.Warning(ctx, "LoadNodeJobRunDockerRegistries> integration %s not found for job %d", name, job.ID)
+				continue
+			}
+			return nil, nil, sdk.WrapError(err, "cannot load integration %s", name)
+		}
+		if projectIntegration.Model.Name != sdk.DockerRegistryIntegrationModel {
+			log.Warning(ctx, "LoadNodeJobRunDockerRegistries> integration %s is not a docker registry", name)
+			continue
+		}
+		projectIntegration.Config, err = projectIntegration.Config.Interpolate(pv)
+		if err != nil {
+			return nil, nil, sdk.WrapError(err, "cannot resolve integration %s config", name)
+		}
+
+		prefix := "cds.registry." + name + "."
+		for k, v := range projectIntegration.Config {
+			if v.Type == sdk.IntegrationConfigTypePassword {
+				secrets = append(secrets, sdk.Variable{
+					Name:  prefix + k,
+					Type:  sdk.SecretVariable,
+					Value: v.Value,
+				})
+				continue
+			}
+			params = append(params, sdk.Parameter{
+				Name:  prefix + k,
+				Type:  sdk.StringParameter,
+				Value: v.Value,
+			})
+		}
+	}
+
+	return params, secrets, nil
+}
+
 // LoadSecrets loads all secrets for a job run
 func LoadSecrets(db gorp.SqlExecutor, store cache.Store, nodeRun *sdk.WorkflowNodeRun, w *sdk.WorkflowRun, pv []sdk.Variable) ([]sdk.Variable, error) {
 	var secrets []sdk.Variable
 
+	// Keep all project variables to resolve the ones referenced by the integration config
+	projectVariables := pv
 	pv = sdk.VariablesFilter(pv, sdk.SecretVariable, sdk.KeyVariable)
 	pv = sdk.VariablesPrefix(pv, "cds.proj.")
 	secrets = append(secrets, pv...)
@@ -480,6 +559,10 @@ func LoadSecrets(db gorp.SqlExecutor, store cache.Store, nodeRun *sdk.WorkflowNo
 			if err != nil {
 				return nil, sdk.WrapError(err, "LoadSecrets> Cannot load integration %d", pp.ID)
 			}
+			projectIntegration.Config, err = projectIntegration.Config.Interpolate(projectVariables)
+			if err != nil {
+				return nil, sdk.WrapError(err, "LoadSecrets> Cannot resolve integration %d config", pp.ID)
+			}
 
 			// Project integration variable
 			pfv := make([]sdk.Variable, 0, len(projectIntegration.Config))
@@ -643,12 +726,17 @@ func RestartWorkflowNodeJob(ctx context.Context, db gorp.SqlExecutor, wNodeJob s
 			return sdk.WrapError(errL, "RestartWorkflowNodeJob> error while load step logs")
 		}
 		wNodeJob.Job.Reason = "Killed (Reason: Timeout)\n"
+		msg := "Worker timeout"
+		if wNodeJob.InfraError != "" {
+			wNodeJob.Job.Reason = fmt.Sprintf("Killed (Reason: %s)\n", wNodeJob.InfraError)
+			msg = "Infrastructure error " + wNodeJob.InfraError
+		}
 		step.Status = sdk.StatusWaiting
 		step.Done = time.Time{}
 		if l != nil { // log could be nil here
 			l.Done = nil
 			logbuf := bytes.NewBufferString(l.Val)
-			logbuf.WriteString("\n\n\n-=-=-=-=-=- Worker timeout: job replaced in queue -=-=-=-=-=-\n\n\n")
+			logbuf.WriteString("\n\n\n-=-=-=-=-=- " + msg + ": job replaced in queue -=-=-=-=-=-\n\n\n")
 			l.Val = logbuf.String()
 			if err := updateLog(db, l); err != nil {
 				return sdk.WrapError(errL, "RestartWorkflowNodeJob> error while update step log")
@@ -679,3 +767,77 @@ func RestartWorkflowNodeJob(ctx context.Context, db gorp.SqlExecutor, wNodeJob s
 
 	return nil
 }
+
+// RequeueNodeJobRunOnInfraError replaces in queue a job that failed because of the infrastructure. The job can't be
+// booked before a delay that doubles at each attempt. It returns false without requeuing the job if the job reached
+// its maximum number of attempts, the caller should then fail the job.
+func RequeueNodeJobRunOnInfraError(ctx context.Context, db gorp.SqlExecutor, store cache.Store, job *sdk.WorkflowNodeJobRun, infraErr sdk.JobInfraError) (bool, error) {
+	job.InfraError = infraErr.Reason
+	log.Info(ctx, "RequeueNodeJobRunOnInfraError> job %d attempt %d failed with infrastructure error %s: %s", job.ID, job.Retry+1, infraErr.Reason, infraErr.Message)
+
+	if job.Retry >= sdk.JobInfraRetryMax {
+		info := sdk.SpawnInfo{Message: sdk.SpawnMsg{
+			ID:   sdk.MsgSpawnInfoJobInfraFail.ID,
+			Args: []interface{}{infraErr.Reason, job.Retry + 1},
+		}}
+		if err := AddSpawnInfosNodeJobRun(db, job.WorkflowNodeRunID, job.ID, []sdk.SpawnInfo{info}); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	delay := sdk.JobInfraRetryDelay(job.Retry)
+	retryAfter := time.Now().Add(delay)
+	job.RetryAfter = &retryAfter
+	info := sdk.SpawnInfo{Message: sdk.SpawnMsg{
+		ID:   sdk.MsgSpawnInfoJobInfraRetry.ID,
+		Args: []interface{}{infraErr.Reason, job.Retry + 2, sdk.JobInfraRetryMax + 1, delay.String()},
+	}}
+	if err := AddSpawnInfosNodeJobRun(db, job.WorkflowNodeRunID, job.ID, []sdk.SpawnInfo{info}); err != nil {
+		return false, err
+	}
+
+	if err := RestartWorkflowNodeJob(ctx, db, *job); err != nil {
+		return false, err
+	}
+	job.Retry++
+	job.Status = sdk.StatusWaiting
+
+	// Release the job if it was booked by a hatchery that failed to spawn a worker
+	if store != nil {
+		if err := FreeNodeJobRun(ctx, store, job.ID); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// RequeueNodeJobRun replaces in queue a waiting or building job on behalf of given user, the job can be booked again
+// immediately by any hatchery.
+func RequeueNodeJobRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, job *sdk.WorkflowNodeJobRun, ident sdk.Identifiable) error {
+	if job.Status != sdk.StatusWaiting && job.Status != sdk.StatusBuilding {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "job %d is %s, only waiting or building jobs can be requeued", job.ID, job.Status)
+	}
+	log.Info(ctx, "RequeueNodeJobRun> job %d requeued by %s", job.ID, ident.GetUsername())
+
+	info := sdk.SpawnInfo{Message: sdk.SpawnMsg{
+		ID:   sdk.MsgSpawnInfoJobRequeued.ID,
+		Args: []interface{}{ident.GetUsername()},
+	}}
+	if err := AddSpawnInfosNodeJobRun(db, job.WorkflowNodeRunID, job.ID, []sdk.SpawnInfo{info}); err != nil {
+		return err
+	}
+
+	job.RetryAfter = nil
+	job.InfraError = "requeued by " + ident.GetUsername()
+	if err := RestartWorkflowNodeJob(ctx, db, *job); err != nil {
+		return err
+	}
+	job.Retry++
+	job.Status = sdk.StatusWaiting
+
+	if err := FreeNodeJobRun(ctx, store, job.ID); err != nil && !sdk.ErrorIs(err, sdk.ErrJobNotBooked) {
+		return err
+	}
+	return nil
+}
@@ -95,6 +95,39 @@ func LoadLogs(db gorp.SqlExecutor, id int64) ([]sdk.Log, error) {
 	return logs, nil
 }
 
+// LoadLogsInfos loads the logs of a job without their value, ordered by step.
+func LoadLogsInfos(db gorp.SqlExecutor, id int64) ([]sdk.Log, error) {
+	query := `
+		SELECT id, workflow_node_run_job_id, workflow_node_run_id, start, last_modified, done, step_order
+		FROM workflow_node_run_job_logs
+		WHERE workflow_node_run_job_id = $1
+		ORDER BY step_order`
+	rows, err := db.Query(query, id)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	defer rows.Close() // nolint
+	var logs []sdk.Log
+	for rows.Next() {
+		var l sdk.Log
+		var s, m, d pq.NullTime
+		if err := rows.Scan(&l.ID, &l.JobID, &l.NodeRunID, &s, &m, &d, &l.StepOrder); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		if s.Valid {
+			l.Start = &s.Time
+		}
+		if m.Valid {
+			l.LastModified = &m.Time
+		}
+		if d.Valid {
+			l.Done = &d.Time
+		}
+		logs = append(logs, l)
+	}
+	return logs, nil
+}
+
 func insertLog(db gorp.SqlExecutor, logs *sdk.Log) error {
 	query := `
 		INSERT INTO workflow_node_run_job_logs (workflow_node_run_job_id, workflow_node_run_id, start, last_modified, done, step_order, value)
@@ -2,7 +2,6 @@ package workflow
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"strings"
 	"sync"
@@ -300,72 +299,30 @@ func executeNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 			return nil, sdk.WrapError(err, "unable to delete node %d job runs", nr.ID)
 		}
 
+		node := updatedWorkflowRun.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID)
+
+		// Keep track of the version deployed on the environment of the node
+		if err := recordDeployment(db, updatedWorkflowRun, node, nr); err != nil {
+			return nil, err
+		}
+
 		var hasMutex bool
 		var nodeName string
 
-		node := updatedWorkflowRun.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID)
 		if node != nil && node.Context != nil && node.Context.Mutex {
 			hasMutex = node.Context.Mutex
 			nodeName = node.Name
 		}
 
 		//Do we release a mutex ?
-		//Try to find one node run of the same node from the same workflow at status Waiting
 		if hasMutex {
 			_, next := observability.Span(ctx, "workflow.releaseMutex")
-
-			mutexQuery := `select workflow_node_run.id
-			from workflow_node_run
-			join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
-			join workflow on workflow.id = workflow_run.workflow_id
-			where workflow.id = $1
-			and workflow_node_run.workflow_node_name = $2
-			and workflow_node_run.status = $3
-			order by workflow_node_run.start asc
-			limit 1`
-			waitingRunID, errID := db.SelectInt(mutexQuery, updatedWorkflowRun.WorkflowID, nodeName, string(sdk.StatusWaiting))
-			if errID != nil && errID != sql.ErrNoRows {
-				log.Error(ctx, "workflow.execute> Unable to load mutex-locked workflow node run ID: %v", errID)
-				return report, nil
-			}
-			//If not more run is found, stop the loop
-			if waitingRunID == 0 {
-				return report, nil
-			}
-			waitingRun, errRun := LoadNodeRunByID(db, waitingRunID, LoadRunOptions{})
-			if errRun != nil && sdk.Cause(errRun) != sql.ErrNoRows {
-				log.Error(ctx, "workflow.execute> Unable to load mutex-locked workflow rnode un: %v", errRun)
-				return report, nil
-			}
-			//If not more run is found, stop the loop
-			if waitingRun == nil {
-				return report, nil
-			}
-
-			//Here we are loading another workflow run
-			workflowRun, errWRun := LoadRunByID(db, waitingRun.WorkflowRunID, LoadRunOptions{})
-			if errWRun != nil {
-				log.Error(ctx, "workflow.execute> Unable to load mutex-locked workflow rnode un: %v", errWRun)
-				return report, nil
-			}
-			AddWorkflowRunInfo(workflowRun, sdk.SpawnMsg{
-				ID:   sdk.MsgWorkflowNodeMutexRelease.ID,
-				Args: []interface{}{waitingRun.WorkflowNodeName},
-				Type: sdk.MsgWorkflowNodeMutexRelease.Type,
-			})
-
-			if err := UpdateWorkflowRun(ctx, db, workflowRun); err != nil {
-				return nil, sdk.WrapError(err, "unable to update workflow run %d after mutex release", workflowRun.ID)
-			}
-
-			log.Debug("workflow.execute> process the node run %d because mutex has been released", waitingRun.ID)
-			r, err := executeNodeRun(ctx, db, store, proj, waitingRun)
+			r, err := releaseMutex(ctx, db, store, proj, updatedWorkflowRun.WorkflowID, nodeName)
+			next()
 			report.Merge(ctx, r)
 			if err != nil {
-				return nil, sdk.WrapError(err, "unable to reprocess workflow")
+				return nil, err
 			}
-
-			next()
 		}
 	}
 	return report, nil
@@ -417,13 +374,20 @@ func addJobsToQueue(ctx context.Context, db gorp.SqlExecutor, stage *sdk.Stage,
 		stage.Status = sdk.StatusDisabled
 	}
 
-	_, next = observability.Span(ctx, "workflow.getIntegrationPluginBinaries")
-	integrationPluginBinaries, err := getIntegrationPluginBinaries(db, wr, nr)
+	_, next = observability.Span(ctx, "workflow.getIntegrationPlugin")
+	integrationPlugin, err := getIntegrationPlugin(db, wr, nr)
 	if err != nil {
 		return report, sdk.WrapError(err, "unable to get integration plugins requirement")
 	}
 	next()
 
+	var integrationPluginBinaries []sdk.GRPCPluginBinary
+	var integrationPluginContract *sdk.GRPCPluginContract
+	if integrationPlugin != nil {
+		integrationPluginBinaries = integrationPlugin.Binaries
+		integrationPluginContract = integrationPlugin.Contract
+	}
+
 	_, next = observability.Span(ctx, "workflow.getJobExecutablesGroups")
 	groups, err := getExecutablesGroups(wr, nr)
 	if err != nil {
@@ -485,11 +449,13 @@ jobLoop:
 			Parameters:                jobParams,
 			ExecGroups:                groups,
 			IntegrationPluginBinaries: integrationPluginBinaries,
+			IntegrationPluginContract: integrationPluginContract,
 			Job: sdk.ExecutedJob{
 				Job: *job,
 			},
 			Header:          nr.Header,
 			ContainsService: containsService,
+			Priority:        wr.Priority,
 		}
 		if wm != nil {
 			wjob.ModelType = wm.Type
@@ -557,24 +523,26 @@ jobLoop:
 	return report, nil
 }
 
-func getIntegrationPluginBinaries(db gorp.SqlExecutor, wr *sdk.WorkflowRun, nr *sdk.WorkflowNodeRun) ([]sdk.GRPCPluginBinary, error) {
-	var projectIntegrationModelID int64
+func getIntegrationPlugin(db gorp.SqlExecutor, wr *sdk.WorkflowRun, nr *sdk.WorkflowNodeRun) (*sdk.GRPCPlugin, error) {
+	var model sdk.IntegrationModel
 	node := wr.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID)
 	if node != nil && node.Context != nil {
 		if node.Context.ProjectIntegrationID != 0 {
 			pp, has := wr.Workflow.ProjectIntegrations[node.Context.ProjectIntegrationID]
 			if has {
-				projectIntegrationModelID = pp.Model.ID
+				model = pp.Model
 			}
 		}
 	}
 
-	if projectIntegrationModelID > 0 {
-		plugin, err := plugin.LoadByIntegrationModelIDAndType(db, projectIntegrationModelID, sdk.GRPCPluginDeploymentIntegration)
+	// The plugin is chosen from the capabilities of the model, a deployment or a release plugin
+	pluginType := model.PluginType()
+	if model.ID > 0 && pluginType != "" {
+		plugin, err := plugin.LoadByIntegrationModelIDAndType(db, model.ID, pluginType)
 		if err != nil {
-			return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "Cannot find plugin for integration model id %d, %v", projectIntegrationModelID, err)
+			return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "Cannot find %s plugin for integration model %s, %v", pluginType, model.Name, err)
 		}
-		return plugin.Binaries, nil
+		return plugin, nil
 	}
 	return nil, nil
 }
@@ -583,15 +551,17 @@ func getExecutablesGroups(wr *sdk.WorkflowRun, nr *sdk.WorkflowNodeRun) ([]sdk.G
 	var node = wr.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID)
 	var groups []sdk.Group
 
+	// Groups that can only execute can't run the jobs of a node bound to a deployment integration
+	perm := wr.Workflow.ExecutePermission(node)
 	if len(node.Groups) > 0 {
 		for _, gp := range node.Groups {
-			if gp.Permission >= sdk.PermissionReadExecute {
+			if gp.Permission >= perm {
 				groups = append(groups, gp.Group)
 			}
 		}
 	} else {
 		for _, gp := range wr.Workflow.Groups {
-			if gp.Permission >= sdk.PermissionReadExecute {
+			if gp.Permission >= perm {
 				groups = append(groups, gp.Group)
 			}
 		}
@@ -723,6 +693,7 @@ func NodeBuildParametersFromWorkflow(ctx context.Context, proj sdk.Project, wf *
 				runContext.ProjectIntegration = pp
 			}
 		}
+		runContext.DeploymentPlan = refNode.Context.DeploymentPlan
 		runContext.NodeGroups = refNode.Groups
 
 		var err error
@@ -999,12 +970,23 @@ type vcsInfos struct {
 	URL        string
 	HTTPUrl    string
 	Server     string
+	// BranchProtected is nil if the protection status of the branch is unknown
+	BranchProtected *bool
 }
 
 func (i vcsInfos) String() string {
 	return fmt.Sprintf("%s:%s:%s:%s", i.Server, i.Repository, i.Branch, i.Hash)
 }
 
+// parseBranchProtected returns nil if the value of git.branch.protected is not set.
+func parseBranchProtected(value string) *bool {
+	if value == "" {
+		return nil
+	}
+	protected := value == "true"
+	return &protected
+}
+
 func getVCSInfos(ctx context.Context, db gorp.SqlExecutor, store cache.Store, projectKey string, vcsServer *sdk.ProjectVCSServer, gitValues map[string]string, applicationName, applicationVCSServer, applicationRepositoryFullname string) (*vcsInfos, error) {
 	var vcsInfos vcsInfos
 	vcsInfos.Repository = gitValues[tagGitRepository]
@@ -1015,6 +997,7 @@ func getVCSInfos(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 	vcsInfos.Message = gitValues[tagGitMessage]
 	vcsInfos.URL = gitValues[tagGitURL]
 	vcsInfos.HTTPUrl = gitValues[tagGitHTTPURL]
+	vcsInfos.BranchProtected = parseBranchProtected(gitValues[tagGitBranchProtected])
 
 	if vcsServer != nil {
 		vcsInfos.Server = vcsServer.Name
@@ -1089,6 +1072,7 @@ func getVCSInfos(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		}
 		vcsInfos.Branch = defaultB.DisplayID
 		vcsInfos.Hash = defaultB.LatestCommit
+		vcsInfos.BranchProtected = defaultB.Protected
 	case vcsInfos.Hash == "" && vcsInfos.Branch != "":
 		// GET COMMIT INFO
 		branch, errB := client.Branch(ctx, vcsInfos.Repository, vcsInfos.Branch)
@@ -1102,6 +1086,15 @@ func getVCSInfos(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 			vcsInfos.Branch = branch.DisplayID
 		}
 		vcsInfos.Hash = branch.LatestCommit
+		vcsInfos.BranchProtected = branch.Protected
+	case vcsInfos.Tag == "" && vcsInfos.Branch != "" && vcsInfos.BranchProtected == nil:
+		// The hash is given by the hook, the branch is only loaded to get its protection status
+		branch, err := client.Branch(ctx, vcsInfos.Repository, vcsInfos.Branch)
+		if err != nil {
+			log.Warning(ctx, "cannot get protection status of branch %s on %s: %v", vcsInfos.Branch, vcsInfos.Repository, err)
+		} else {
+			vcsInfos.BranchProtected = branch.Protected
+		}
 	}
 
 	// Get commit info if needed
@@ -0,0 +1,336 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/luascript"
+)
+
+// explainConditions evaluates the conditions of a node or a hook. The result of each plain condition is returned, a
+// lua script only gives a global result.
+func explainConditions(conditions sdk.WorkflowNodeConditions, params []sdk.Parameter) ([]sdk.WorkflowConditionResult, bool, error) {
+	if conditions.LuaScript == "" {
+		return sdk.WorkflowExplainConditions(conditions.PlainConditions, params)
+	}
+	luacheck, err := luascript.NewCheck()
+	if err != nil {
+		return nil, false, sdk.WrapError(err, "cannot check lua script")
+	}
+	luacheck.SetVariables(sdk.ParametersToMap(params))
+	if err := luacheck.Perform(conditions.LuaScript); err != nil {
+		return nil, false, err
+	}
+	return nil, luacheck.Result, nil
+}
+
+// ExplainRun explains which nodes of given workflow would run for a hook event or a manual run request, and why.
+// Nothing is executed nor saved: each node that would run is considered successful to evaluate the conditions of its
+// children. Only the start of a new run can be explained.
+func ExplainRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wf *sdk.Workflow, opts sdk.WorkflowRunPostHandlerOption) (*sdk.WorkflowRunExplain, error) {
+	if opts.Number != nil || len(opts.FromNodeIDs) > 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "only the start of a new run can be explained")
+	}
+
+	number, err := LoadCurrentRunNum(db, proj.Key, wf.Name)
+	if err != nil {
+		return nil, err
+	}
+	wr := &sdk.WorkflowRun{
+		Number:           number + 1,
+		WorkflowID:       wf.ID,
+		ProjectID:        wf.ProjectID,
+		Workflow:         *wf,
+		Header:           sdk.WorkflowRunHeaders{},
+		WorkflowNodeRuns: make(map[int64][]sdk.WorkflowNodeRun),
+	}
+	wr.Header.Set(sdk.WorkflowRunHeader, strconv.FormatInt(wr.Number, 10))
+	wr.Header.Set(sdk.WorkflowHeader, wf.Name)
+	wr.Header.Set(sdk.ProjectKeyHeader, proj.Key)
+
+	explain := &sdk.WorkflowRunExplain{}
+	results := make(map[int64]sdk.WorkflowRunExplainNode)
+	nodeRuns := make(map[int64]*sdk.WorkflowNodeRun)
+
+	root := &wr.Workflow.WorkflowData.Node
+	start := true
+	if opts.Hook != nil {
+		h, has := wr.Workflow.WorkflowData.GetHooks()[opts.Hook.WorkflowNodeHookUUID]
+		if !has {
+			return nil, sdk.WrapError(sdk.ErrNoHook, "cannot find hook %s", opts.Hook.WorkflowNodeHookUUID)
+		}
+		if h.NodeID != root.ID {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "only the hooks of the root node can be explained")
+		}
+		hookExplain, err := explainHook(ctx, db, store, proj, *h, opts.Hook.Payload)
+		if err != nil {
+			return nil, err
+		}
+		explain.Hook = &hookExplain
+		start = hookExplain.Run
+	}
+
+	var explainTree func(n *sdk.Node, parents []*sdk.WorkflowNodeRun, hookEvent *sdk.WorkflowNodeRunHookEvent, manual *sdk.WorkflowNodeRunManual) error
+	explainTree = func(n *sdk.Node, parents []*sdk.WorkflowNodeRun, hookEvent *sdk.WorkflowNodeRunHookEvent, manual *sdk.WorkflowNodeRunManual) error {
+		res, nr, err := explainNode(ctx, db, store, proj, wr, n, parents, hookEvent, manual)
+		if err != nil {
+			return err
+		}
+		results[n.ID] = res
+		if !res.Run {
+			return nil
+		}
+		nodeRuns[n.ID] = nr
+		for i := range n.Triggers {
+			if err := explainTree(&n.Triggers[i].ChildNode, []*sdk.WorkflowNodeRun{nr}, nil, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if start {
+		if err := explainTree(root, nil, opts.Hook, opts.Manual); err != nil {
+			return nil, err
+		}
+
+		// A join is explained when all its parents are, it runs if all its parents run
+		for {
+			var progress bool
+			for i := range wr.Workflow.WorkflowData.Joins {
+				j := &wr.Workflow.WorkflowData.Joins[i]
+				if _, has := results[j.ID]; has {
+					continue
+				}
+				sources := make([]*sdk.WorkflowNodeRun, 0, len(j.JoinContext))
+				decided := true
+				for _, p := range j.JoinContext {
+					if _, has := results[p.ParentID]; !has {
+						decided = false
+						break
+					}
+					if nr, has := nodeRuns[p.ParentID]; has {
+						sources = append(sources, nr)
+					}
+				}
+				if !decided {
+					continue
+				}
+				progress = true
+				if len(sources) != len(j.JoinContext) {
+					continue
+				}
+				if err := explainTree(j, sources, nil, nil); err != nil {
+					return nil, err
+				}
+			}
+			if !progress {
+				break
+			}
+		}
+	}
+
+	// The nodes that were not explained are not triggered
+	parentNames := workflowNodeParentNames(wr.Workflow.WorkflowData)
+	for _, n := range wr.Workflow.WorkflowData.Array() {
+		res, has := results[n.ID]
+		if !has {
+			res = sdk.WorkflowRunExplainNode{NodeID: n.ID, Name: n.Name, Type: n.Type}
+			switch {
+			case n.ID == root.ID:
+				res.Reason = "the hook would not start a run"
+			case len(parentNames[n.ID]) > 0:
+				res.Reason = fmt.Sprintf("not triggered because one of its parents would not run: %s", strings.Join(parentNames[n.ID], ", "))
+			default:
+				res.Reason = "not triggered"
+			}
+		}
+		explain.Nodes = append(explain.Nodes, res)
+	}
+
+	return explain, nil
+}
+
+// workflowNodeParentNames returns the names of the parents of each node of a workflow.
+func workflowNodeParentNames(data sdk.WorkflowData) map[int64][]string {
+	res := make(map[int64][]string)
+	for _, n := range data.Array() {
+		for _, t := range n.Triggers {
+			res[t.ChildNode.ID] = append(res[t.ChildNode.ID], n.Name)
+		}
+		for _, p := range n.JoinContext {
+			res[n.ID] = append(res[n.ID], p.ParentName)
+		}
+	}
+	return res
+}
+
+// explainHook explains if a hook event would start a run: the conditions of the hook are evaluated with the payload
+// of the event, then its path filters are checked.
+func explainHook(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, h sdk.NodeHook, payload map[string]string) (sdk.WorkflowRunExplainHook, error) {
+	res := sdk.WorkflowRunExplainHook{
+		UUID:      h.UUID,
+		Model:     h.HookModelName,
+		LuaScript: h.Conditions.LuaScript,
+	}
+
+	conditions, ok, err := explainConditions(h.Conditions, sdk.ParametersFromMap(payload))
+	res.Conditions = conditions
+	if err != nil {
+		res.Reason = fmt.Sprintf("error on hook conditions: %v", err)
+		return res, nil
+	}
+	if !ok {
+		res.Reason = "hook conditions are not satisfied"
+		return res, nil
+	}
+
+	pathFiltersOK, err := CheckHookPathFilters(ctx, db, store, proj, h, payload)
+	if err != nil {
+		return res, err
+	}
+	if !pathFiltersOK {
+		res.Reason = "no changed file matches the path filters of the hook"
+		return res, nil
+	}
+
+	res.Run = true
+	res.Reason = "hook conditions are satisfied"
+	return res, nil
+}
+
+// explainNode explains if a node would run: its build parameters are computed like in processNode, then its conditions
+// are evaluated. The returned node run is only set if the node would run.
+func explainNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wr *sdk.WorkflowRun, n *sdk.Node,
+	parents []*sdk.WorkflowNodeRun, hookEvent *sdk.WorkflowNodeRunHookEvent, manual *sdk.WorkflowNodeRunManual) (sdk.WorkflowRunExplainNode, *sdk.WorkflowNodeRun, error) {
+	res := sdk.WorkflowRunExplainNode{NodeID: n.ID, Name: n.Name, Type: n.Type}
+	if n.Context == nil {
+		n.Context = &sdk.NodeContext{}
+	}
+	res.LuaScript = n.Context.Conditions.LuaScript
+
+	// Fork and join without conditions keep the manual event of their parent
+	if manual == nil && len(parents) == 1 && parents[0].Manual != nil &&
+		(n.Type == sdk.NodeTypeJoin || n.Type == sdk.NodeTypeFork) &&
+		n.Context.Conditions.LuaScript == "" && len(n.Context.Conditions.PlainConditions) == 0 {
+		manual = parents[0].Manual
+	}
+
+	if n.Context.PipelineID == 0 && n.Type == sdk.NodeTypePipeline {
+		return res, nil, sdk.WithStack(sdk.ErrPipelineNotFound)
+	}
+
+	nr := createWorkflowNodeRun(wr, n, parents, 0, hookEvent, manual)
+	if n.Type == sdk.NodeTypePipeline {
+		nr.PipelineParameters = computePipelineParameters(wr, n, manual)
+	}
+	var err error
+	nr.Payload, err = computePayload(n, hookEvent, manual)
+	if err != nil {
+		return res, nil, err
+	}
+	nr.BuildParameters, err = computeBuildParameters(wr, nr, parents, manual)
+	if err != nil {
+		return res, nil, err
+	}
+
+	runContext := nodeRunContext{}
+	if n.Context.PipelineID != 0 {
+		runContext.Pipeline = wr.Workflow.Pipelines[n.Context.PipelineID]
+	}
+	if n.Context.ApplicationID != 0 {
+		runContext.Application = wr.Workflow.Applications[n.Context.ApplicationID]
+	}
+	if n.Context.EnvironmentID != 0 {
+		runContext.Environment = wr.Workflow.Environments[n.Context.EnvironmentID]
+	}
+	if n.Context.ProjectIntegrationID != 0 {
+		runContext.ProjectIntegration = wr.Workflow.ProjectIntegrations[n.Context.ProjectIntegrationID]
+		runContext.DeploymentPlan = n.Context.DeploymentPlan
+	}
+	computeNodeContextBuildParameters(ctx, proj, wr, nr, n, runContext)
+
+	if len(parents) > 0 {
+		parentsParams, err := getParentParameters(wr, parents)
+		if err != nil {
+			return res, nil, err
+		}
+		nr.BuildParameters = sdk.ParametersFromMap(sdk.ParametersMapMerge(sdk.ParametersToMap(nr.BuildParameters), sdk.ParametersToMap(parentsParams)))
+	}
+
+	// The git variables of the root node are completed from the repository manager, the other nodes inherit them from
+	// their parents
+	if n.ID == wr.Workflow.WorkflowData.Node.ID {
+		app := wr.Workflow.Applications[n.Context.ApplicationID]
+		gitValues := make(map[string]string)
+		for _, p := range nr.BuildParameters {
+			switch p.Name {
+			case tagGitHash, tagGitBranch, tagGitTag, tagGitAuthor, tagGitMessage, tagGitRepository, tagGitURL, tagGitHTTPURL, tagGitServer:
+				gitValues[p.Name] = p.Value
+			}
+		}
+		vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
+		vcsInf, err := getVCSInfos(ctx, db, store, proj.Key, vcsServer, gitValues, app.Name, app.VCSServer, app.RepositoryFullname)
+		if err != nil {
+			res.Reason = fmt.Sprintf("unable to get git informations: %v", sdk.ExtractHTTPError(err, "").Message)
+			return res, nil, nil
+		}
+		setValuesGitInBuildParameters(nr, *vcsInf)
+	}
+
+	conditionParams := nr.BuildParameters
+	if sdk.WorkflowConditionsUsePrevious(n.Context.Conditions) {
+		previousParams, err := previousRunsConditionParameters(db, wr, n.Name)
+		if err != nil {
+			return res, nil, sdk.WrapError(err, "unable to load previous runs of node %s", n.Name)
+		}
+		conditionParams = append(append([]sdk.Parameter{}, nr.BuildParameters...), previousParams...)
+	}
+	conditions, ok, err := explainConditions(n.Context.Conditions, conditionParams)
+	res.Conditions = conditions
+	if err != nil {
+		res.Reason = fmt.Sprintf("error on conditions: %v", err)
+		return res, nil, nil
+	}
+	if !ok {
+		res.Reason = "conditions are not satisfied"
+		return res, nil, nil
+	}
+
+	if manual != nil && wr.Workflow.ExecutePermission(n) > sdk.PermissionReadExecute {
+		canDeploy, err := checkNodeDeployPermission(ctx, db, wr, n, manual.Username)
+		if err != nil {
+			return res, nil, err
+		}
+		if !canDeploy {
+			res.Reason = fmt.Sprintf("user %s is not allowed to deploy", manual.Username)
+			return res, nil, nil
+		}
+	}
+
+	if manual == nil {
+		parentNodeIDs := make([]int64, 0, len(parents))
+		for _, parent := range parents {
+			parentNodeIDs = append(parentNodeIDs, parent.WorkflowNodeID)
+		}
+		if planNode := wr.Workflow.DeploymentPlanParent(n, parentNodeIDs); planNode != nil {
+			res.Reason = fmt.Sprintf("waits for the manual approval of the deployment plan of node %s", planNode.Name)
+			return res, nil, nil
+		}
+	}
+
+	res.Run = true
+	res.Reason = "conditions are satisfied"
+	if n.Context.Mutex {
+		res.Reason += ", the node could wait for its mutex"
+	}
+	nr.Status = sdk.StatusSuccess
+	return res, nr, nil
+}
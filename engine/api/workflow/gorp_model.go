@@ -69,18 +69,22 @@ type JobRun struct {
 	Parameters                sql.NullString `db:"variables"`
 	Status                    string         `db:"status"`
 	Retry                     int            `db:"retry"`
+	RetryAfter                *time.Time     `db:"retry_after"`
+	InfraError                string         `db:"infra_error"`
 	Queued                    time.Time      `db:"queued"`
 	Start                     time.Time      `db:"start"`
 	Done                      time.Time      `db:"done"`
 	Model                     string         `db:"model"`
 	ExecGroups                sql.NullString `db:"exec_groups"`
 	IntegrationPluginBinaries sql.NullString `db:"integration_plugin_binaries"`
+	IntegrationPluginContract sql.NullString `db:"integration_plugin_contract"`
 	BookedBy                  sdk.Service    `db:"-"`
 	ContainsService           bool           `db:"contains_service"`
 	ModelType                 sql.NullString `db:"model_type"`
 	Header                    sql.NullString `db:"header"`
 	HatcheryName              string         `db:"hatchery_name"`
 	WorkerName                string         `db:"worker_name"`
+	Priority                  int            `db:"priority"`
 }
 
 // ToJobRun transform the JobRun with data of the provided sdk.WorkflowNodeJobRun
@@ -98,6 +102,8 @@ func (j *JobRun) ToJobRun(jr *sdk.WorkflowNodeJobRun) (err error) {
 	}
 	j.Status = jr.Status
 	j.Retry = jr.Retry
+	j.RetryAfter = jr.RetryAfter
+	j.InfraError = jr.InfraError
 	j.Queued = jr.Queued
 	j.Start = jr.Start
 	j.Done = jr.Done
@@ -107,6 +113,7 @@ func (j *JobRun) ToJobRun(jr *sdk.WorkflowNodeJobRun) (err error) {
 	j.ExecGroups, err = gorpmapping.JSONToNullString(jr.ExecGroups)
 	j.WorkerName = jr.WorkerName
 	j.HatcheryName = jr.HatcheryName
+	j.Priority = sdk.WorkflowPriorityRank(jr.Priority)
 	if err != nil {
 		return sdk.WrapError(err, "column exec_groups")
 	}
@@ -114,6 +121,10 @@ func (j *JobRun) ToJobRun(jr *sdk.WorkflowNodeJobRun) (err error) {
 	if err != nil {
 		return sdk.WrapError(err, "column integration_plugin_binaries")
 	}
+	j.IntegrationPluginContract, err = gorpmapping.JSONToNullString(jr.IntegrationPluginContract)
+	if err != nil {
+		return sdk.WrapError(err, "column integration_plugin_contract")
+	}
 	j.Header, err = gorpmapping.JSONToNullString(jr.Header)
 	if err != nil {
 		return sdk.WrapError(err, "column header")
@@ -129,6 +140,8 @@ func (j JobRun) WorkflowNodeRunJob() (sdk.WorkflowNodeJobRun, error) {
 		WorkflowNodeRunID: j.WorkflowNodeRunID,
 		Status:            j.Status,
 		Retry:             j.Retry,
+		RetryAfter:        j.RetryAfter,
+		InfraError:        j.InfraError,
 		Queued:            j.Queued,
 		QueuedSeconds:     time.Now().Unix() - j.Queued.Unix(),
 		Start:             j.Start,
@@ -138,6 +151,7 @@ func (j JobRun) WorkflowNodeRunJob() (sdk.WorkflowNodeJobRun, error) {
 		HatcheryName:      j.HatcheryName,
 		WorkerName:        j.WorkerName,
 		Model:             j.Model,
+		Priority:          sdk.WorkflowPriorityFromRank(j.Priority),
 	}
 	if err := gorpmapping.JSONNullString(j.Job, &jr.Job); err != nil {
 		return jr, sdk.WrapError(err, "column job")
@@ -151,12 +165,17 @@ func (j JobRun) WorkflowNodeRunJob() (sdk.WorkflowNodeJobRun, error) {
 	if err := gorpmapping.JSONNullString(j.IntegrationPluginBinaries, &jr.IntegrationPluginBinaries); err != nil {
 		return jr, sdk.WrapError(err, "integration_plugin_binaries")
 	}
+	if err := gorpmapping.JSONNullString(j.IntegrationPluginContract, &jr.IntegrationPluginContract); err != nil {
+		return jr, sdk.WrapError(err, "integration_plugin_contract")
+	}
 	if err := gorpmapping.JSONNullString(j.Header, &jr.Header); err != nil {
 		return jr, sdk.WrapError(err, "header")
 	}
 	if j.ModelType.Valid {
 		jr.ModelType = j.ModelType.String
 	}
+	jr.WorkerFeatures = jr.Job.Action.WorkerFeatures()
+	jr.WorkerMinVersion = sdk.WorkerMinVersion(jr.WorkerFeatures)
 	if defaultOS != "" && defaultArch != "" {
 		var modelFound, osArchFound bool
 		for _, req := range jr.Job.Action.Requirements {
@@ -188,6 +207,21 @@ type dbStaticFiles sdk.StaticFiles
 // RunTag is a gorp wrapper around sdk.WorkflowRunTag
 type RunTag sdk.WorkflowRunTag
 
+// RunAnnotation is a gorp wrapper around sdk.WorkflowRunAnnotation
+type RunAnnotation sdk.WorkflowRunAnnotation
+
+// RunResult is a gorp wrapper around sdk.WorkflowRunResult
+type RunResult sdk.WorkflowRunResult
+
+// RunSecretUsage is a gorp wrapper around sdk.WorkflowRunSecretUsage
+type RunSecretUsage sdk.WorkflowRunSecretUsage
+
+// RunJobFingerprint is a gorp wrapper around sdk.WorkflowRunJobFingerprint
+type RunJobFingerprint sdk.WorkflowRunJobFingerprint
+
+// RunReleaseTarget is a gorp wrapper around sdk.WorkflowRunReleaseTarget
+type RunReleaseTarget sdk.WorkflowRunReleaseTarget
+
 // hookModel is a gorp wrapper around sdk.WorkflowHookModel
 type hookModel sdk.WorkflowHookModel
 
@@ -196,6 +230,9 @@ type outgoingHookModel sdk.WorkflowHookModel
 
 type auditWorkflow sdk.AuditWorkflow
 
+// dbWorkflowVersion is a gorp wrapper around sdk.WorkflowVersion
+type dbWorkflowVersion sdk.WorkflowVersion
+
 type dbNodeData sdk.Node
 type dbNodeContextData sqlNodeContextData
 type dbNodeTriggerData sdk.NodeTrigger
@@ -212,10 +249,16 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(JobRun{}, "workflow_node_run_job", true, "id"))
 	gorpmapping.Register(gorpmapping.New(NodeRunArtifact{}, "workflow_node_run_artifacts", true, "id"))
 	gorpmapping.Register(gorpmapping.New(RunTag{}, "workflow_run_tag", false, "workflow_run_id", "tag"))
+	gorpmapping.Register(gorpmapping.New(RunAnnotation{}, "workflow_run_annotation", true, "id"))
+	gorpmapping.Register(gorpmapping.New(RunResult{}, "workflow_run_result", true, "id"))
+	gorpmapping.Register(gorpmapping.New(RunSecretUsage{}, "workflow_run_secret_usage", true, "id"))
+	gorpmapping.Register(gorpmapping.New(RunJobFingerprint{}, "workflow_run_job_fingerprint", true, "id"))
+	gorpmapping.Register(gorpmapping.New(RunReleaseTarget{}, "workflow_run_release_target", true, "id"))
 	gorpmapping.Register(gorpmapping.New(hookModel{}, "workflow_hook_model", true, "id"))
 	gorpmapping.Register(gorpmapping.New(outgoingHookModel{}, "workflow_outgoing_hook_model", true, "id"))
 	gorpmapping.Register(gorpmapping.New(Notification{}, "workflow_notification", true, "id"))
 	gorpmapping.Register(gorpmapping.New(auditWorkflow{}, "workflow_audit", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbWorkflowVersion{}, "workflow_version", true, "id"))
 	gorpmapping.Register(gorpmapping.New(Coverage{}, "workflow_node_run_coverage", false, "workflow_id", "workflow_run_id", "workflow_node_run_id", "repository", "branch"))
 	gorpmapping.Register(gorpmapping.New(dbStaticFiles{}, "workflow_node_run_static_files", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbNodeRunVulenrabilitiesReport{}, "workflow_node_run_vulnerability", true, "id"))
@@ -2,17 +2,18 @@ package workflow
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
 
-const maxRetry = 3
-
-// manageDeadJob restart all jobs which are building but without worker
+// manageDeadJob handles all jobs which are building but without worker. The loss of the worker is classified and
+// recorded as an annotation of the run, then the job is requeued if it can be run again or stopped.
 func manageDeadJob(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.Store) error {
 	db := DBFunc()
 	deadJobs, err := LoadDeadNodeJobRun(ctx, db, store)
@@ -28,7 +29,36 @@ func manageDeadJob(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.S
 		}
 
 		if deadJob.Status == sdk.StatusBuilding {
-			if deadJob.Retry >= maxRetry {
+			class := classifyJobLoss(ctx, tx, deadJob)
+			var requeued bool
+			if deadJob.JobLossRequeueable(class) {
+				var err error
+				requeued, err = RequeueNodeJobRunOnInfraError(ctx, tx, nil, &deadJob, sdk.JobInfraError{Reason: sdk.JobInfraErrorWorkerLost, Message: class})
+				if err != nil {
+					log.Warning(ctx, "manageDeadJob> Cannot restart node job run %d: %v", deadJob.ID, err)
+					_ = tx.Rollback()
+					continue
+				}
+			} else {
+				info := sdk.SpawnInfo{Message: sdk.SpawnMsg{
+					ID:   sdk.MsgSpawnInfoJobLost.ID,
+					Args: []interface{}{deadJob.WorkerName, class},
+				}}
+				if err := AddSpawnInfosNodeJobRun(tx, deadJob.WorkflowNodeRunID, deadJob.ID, []sdk.SpawnInfo{info}); err != nil {
+					log.Error(ctx, "manageDeadJob> Cannot add spawn info on node run job %d : %v", deadJob.ID, err)
+					_ = tx.Rollback()
+					continue
+				}
+			}
+			log.Info(ctx, "manageDeadJob> job %d lost its worker %s (%s), requeued: %t", deadJob.ID, deadJob.WorkerName, class, requeued)
+
+			if err := annotateJobLoss(tx, deadJob, class, requeued); err != nil {
+				log.Error(ctx, "manageDeadJob> Cannot annotate run of node run job %d : %v", deadJob.ID, err)
+				_ = tx.Rollback()
+				continue
+			}
+
+			if !requeued {
 				if _, err := UpdateNodeJobRunStatus(ctx, tx, store, sdk.Project{}, &deadJob, sdk.StatusStopped); err != nil {
 					log.Error(ctx, "manageDeadJob> Cannot update node run job %d : %v", deadJob.ID, err)
 					_ = tx.Rollback()
@@ -40,12 +70,6 @@ func manageDeadJob(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.S
 					_ = tx.Rollback()
 					continue
 				}
-			} else {
-				if err := RestartWorkflowNodeJob(ctx, tx, deadJob); err != nil {
-					log.Warning(ctx, "manageDeadJob> Cannot restart node job run %d: %v", deadJob.ID, err)
-					_ = tx.Rollback()
-					continue
-				}
 			}
 		} else if sdk.StatusIsTerminated(deadJob.Status) {
 			if err := DeleteNodeJobRun(tx, deadJob.ID); err != nil {
@@ -62,3 +86,46 @@ func manageDeadJob(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.S
 
 	return nil
 }
+
+// classifyJobLoss returns how given building job lost its worker.
+func classifyJobLoss(ctx context.Context, db gorp.SqlExecutor, job sdk.WorkflowNodeJobRun) string {
+	if len(job.Job.StepStatus) == 0 {
+		return sdk.JobLossBeforeStart
+	}
+	if job.HatcheryName == "" {
+		return sdk.JobLossWorker
+	}
+	// Dead services are deleted, so a missing hatchery stopped heartbeating too
+	if _, err := services.LoadByNameAndType(ctx, db, job.HatcheryName, services.TypeHatchery); err != nil {
+		if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			log.Warning(ctx, "classifyJobLoss> Cannot load hatchery %s: %v", job.HatcheryName, err)
+			return sdk.JobLossWorker
+		}
+		return sdk.JobLossHatchery
+	}
+	return sdk.JobLossWorker
+}
+
+// annotateJobLoss records the loss of the worker of given job as an annotation of its workflow run.
+func annotateJobLoss(db gorp.SqlExecutor, job sdk.WorkflowNodeJobRun, class string, requeued bool) error {
+	nodeRun, err := LoadNodeRunByID(db, job.WorkflowNodeRunID, LoadRunOptions{DisableDetailledNodeRun: true})
+	if err != nil {
+		return err
+	}
+
+	outcome := "stopped, the job is not declared idempotent"
+	if requeued {
+		outcome = fmt.Sprintf("requeued, attempt %d/%d", job.Retry+1, sdk.JobInfraRetryMax+1)
+	} else if job.JobLossRequeueable(class) {
+		outcome = fmt.Sprintf("stopped after %d attempts", job.Retry+1)
+	}
+	a := sdk.WorkflowRunAnnotation{
+		WorkflowRunID:     nodeRun.WorkflowRunID,
+		WorkflowNodeRunID: nodeRun.ID,
+		Type:              sdk.WorkflowRunAnnotationTypeValue,
+		Key:               sdk.JobLossAnnotationKey(job.ID),
+		Value:             fmt.Sprintf("Job %s lost worker %s (%s): %s", job.Job.Action.Name, job.WorkerName, class, outcome),
+		Author:            "cds",
+	}
+	return UpsertRunAnnotation(db, &a)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/fsamin/go-dump"
 	"github.com/go-gorp/gorp"
@@ -121,6 +122,7 @@ func hookRegistration(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 			previousHook, has := oldHooksByRef[h.Ref()]
 			if has {
 				h.UUID = previousHook.UUID
+				keepRepositoryWebHookSecret(h, previousHook)
 				// If previous hook is the same, we do nothing
 				if h.Equals(previousHook) {
 					continue
@@ -129,6 +131,9 @@ func hookRegistration(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 		} else if oldHooks != nil {
 			// search previous hook configuration by uuid
 			previousHook, has := oldHooks[h.UUID]
+			if has {
+				keepRepositoryWebHookSecret(h, *previousHook)
+			}
 			// If previous hook is the same, we do nothing
 			if has && h.Equals(*previousHook) {
 				continue
@@ -153,6 +158,12 @@ func hookRegistration(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 			}
 		}
 
+		if h.HookModelName == sdk.RepositoryWebHookModelName {
+			if err := setRepositoryWebHookSecret(ctx, db, store, proj, h, false); err != nil {
+				return err
+			}
+		}
+
 		if err := updateSchedulerPayload(ctx, db, store, proj, wf, h); err != nil {
 			return err
 		}
@@ -302,6 +313,7 @@ func createVCSConfiguration(ctx context.Context, db gorp.SqlExecutor, store cach
 		Method:   "POST",
 		URL:      h.Config["webHookURL"].Value,
 		Workflow: true,
+		Secret:   h.Config[sdk.HookConfigWebHookSecret].Value,
 	}
 
 	// Set given event filters if exists, else default values will be set by CreateHook func.
@@ -353,6 +365,7 @@ func updateVCSConfiguration(ctx context.Context, db gorp.SqlExecutor, store cach
 		Method:   "POST",
 		URL:      h.Config["webHookURL"].Value,
 		Workflow: true,
+		Secret:   h.Config[sdk.HookConfigWebHookSecret].Value,
 	}
 
 	// Set given event filters if exists, else default values will be set by CreateHook func.
@@ -376,6 +389,90 @@ func updateVCSConfiguration(ctx context.Context, db gorp.SqlExecutor, store cach
 	return nil
 }
 
+// keepRepositoryWebHookSecret sets the secrets of the previous version of a repository webhook if the hook has none,
+// the secrets are not exported with the workflow.
+func keepRepositoryWebHookSecret(h *sdk.NodeHook, previousHook sdk.NodeHook) {
+	if h.HookModelName != sdk.RepositoryWebHookModelName {
+		return
+	}
+	if _, has := h.Config[sdk.HookConfigWebHookSecret]; has {
+		return
+	}
+	for _, k := range []string{sdk.HookConfigWebHookSecret, sdk.HookConfigWebHookPreviousSecret, sdk.HookConfigWebHookPreviousSecretExpire} {
+		if v, has := previousHook.Config[k]; has {
+			h.Config[k] = v
+		}
+	}
+}
+
+// setRepositoryWebHookSecret generates the secret used by the repository manager to sign the payloads sent to a
+// repository webhook, if the repository manager supports it. An existing secret is replaced only if rotate is true.
+func setRepositoryWebHookSecret(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, h *sdk.NodeHook, rotate bool) error {
+	if _, has := h.Config[sdk.HookConfigWebHookSecret]; has && !rotate {
+		return nil
+	}
+
+	projectVCSServer := repositoriesmanager.GetProjectVCSServer(proj, h.Config[sdk.HookConfigVCSServer].Value)
+	if projectVCSServer == nil {
+		return nil
+	}
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, projectVCSServer)
+	if err != nil {
+		return sdk.WrapError(err, "cannot get vcs client")
+	}
+	webHookInfo, err := repositoriesmanager.GetWebhooksInfos(ctx, client)
+	if err != nil {
+		return sdk.WrapError(err, "cannot get vcs web hook info")
+	}
+	if !webHookInfo.WebhooksSecretSupported {
+		return nil
+	}
+
+	secret, err := sdk.GenerateHash()
+	if err != nil {
+		return err
+	}
+	h.Config[sdk.HookConfigWebHookSecret] = sdk.WorkflowNodeHookConfigValue{
+		Value:        secret,
+		Configurable: false,
+		Type:         sdk.HookConfigTypeString,
+	}
+	return nil
+}
+
+// RotateRepositoryWebHookSecret generates a new secret for a repository webhook of given workflow, then updates the
+// workflow to set the secret on the repository manager and on the hooks µservice. The previous secret is still
+// accepted by the hooks µservice during given overlap, to not miss the payloads sent while the repository manager
+// is updated.
+func RotateRepositoryWebHookSecret(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wf *sdk.Workflow, uuid string, overlap time.Duration) error {
+	h, has := wf.WorkflowData.GetHooks()[uuid]
+	if !has {
+		return sdk.WrapError(sdk.ErrNotFound, "cannot find hook %s", uuid)
+	}
+	if h.HookModelName != sdk.RepositoryWebHookModelName {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "only the secret of a repository webhook can be rotated")
+	}
+	previous, hasPrevious := h.Config[sdk.HookConfigWebHookSecret]
+	if err := setRepositoryWebHookSecret(ctx, db, store, proj, h, true); err != nil {
+		return err
+	}
+	if _, has := h.Config[sdk.HookConfigWebHookSecret]; !has {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "repository manager %s does not support webhook secrets", h.Config[sdk.HookConfigVCSServer].Value)
+	}
+
+	delete(h.Config, sdk.HookConfigWebHookPreviousSecret)
+	delete(h.Config, sdk.HookConfigWebHookPreviousSecretExpire)
+	if hasPrevious && overlap > 0 {
+		h.Config[sdk.HookConfigWebHookPreviousSecret] = previous
+		h.Config[sdk.HookConfigWebHookPreviousSecretExpire] = sdk.WorkflowNodeHookConfigValue{
+			Value:        time.Now().Add(overlap).Format(time.RFC3339),
+			Configurable: false,
+			Type:         sdk.HookConfigTypeString,
+		}
+	}
+	return Update(ctx, db, store, proj, wf, UpdateOptions{})
+}
+
 // DefaultPayload returns the default payload for the workflow root
 func DefaultPayload(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wf *sdk.Workflow) (interface{}, error) {
 	if wf.WorkflowData.Node.Context == nil || wf.WorkflowData.Node.Context.ApplicationID == 0 {
@@ -0,0 +1,66 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	tagGitHashBefore = "git.hash.before"
+	tagGitHashDest   = "git.hash.dest"
+)
+
+// CheckHookPathFilters returns true if the event of a repository webhook changed at least one file matching the path
+// filters of the hook, or if the hook has no path filters. Tags are not filtered, and if the changed files can't be
+// fetched from the repository manager the event is not filtered.
+func CheckHookPathFilters(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, h sdk.NodeHook, payload map[string]string) (bool, error) {
+	if h.HookModelName != sdk.RepositoryWebHookModelName {
+		return true, nil
+	}
+	filters, err := sdk.ParseHookPathFilters(h.Config)
+	if err != nil {
+		return false, err
+	}
+	if len(filters) == 0 || payload[tagGitHash] == "" || payload[tagGitTag] != "" {
+		return true, nil
+	}
+
+	base := payload[tagGitHashDest]
+	if base == "" {
+		base = payload[tagGitHashBefore]
+	}
+	// The previous hash of a new branch is 0000000000000000000000000000000000000000
+	if strings.Trim(base, "0") == "" {
+		base = ""
+	}
+
+	files, err := HookChangedFiles(ctx, db, store, proj, h, base, payload[tagGitHash])
+	if err != nil {
+		log.Warning(ctx, "CheckHookPathFilters> unable to get files changed by commit %s, path filters of hook %s are ignored: %v", payload[tagGitHash], h.UUID, err)
+		return true, nil
+	}
+	matched := sdk.MatchHookPathFilters(filters, files)
+	log.Debug("CheckHookPathFilters> hook %s: %d/%d changed files match the path filters", h.UUID, len(matched), len(files))
+	return len(matched) > 0, nil
+}
+
+// HookChangedFiles returns the files changed between base and head in the repository of a repository webhook, or by
+// head if base is empty.
+func HookChangedFiles(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, h sdk.NodeHook, base, head string) ([]string, error) {
+	vcsServer := repositoriesmanager.GetProjectVCSServer(proj, h.Config[sdk.HookConfigVCSServer].Value)
+	if vcsServer == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrNoReposManager, "cannot find repository manager %s for hook %s", h.Config[sdk.HookConfigVCSServer].Value, h.UUID)
+	}
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, vcsServer)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get vcs client")
+	}
+	return client.ChangedFiles(ctx, h.Config[sdk.HookConfigRepoFullName].Value, base, head)
+}
@@ -0,0 +1,138 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// LoadNodeMutexes returns the state of the mutex of each node of given workflow with a mutex.
+func LoadNodeMutexes(db gorp.SqlExecutor, w sdk.Workflow) ([]sdk.WorkflowNodeMutex, error) {
+	query := `select workflow_node_run.workflow_run_id, workflow_run.num, workflow_node_run.sub_num, workflow_node_run.id, workflow_node_run.status, workflow_node_run.start
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	where workflow_run.workflow_id = $1
+	and workflow_node_run.workflow_node_name = $2
+	and workflow_node_run.status = ANY(string_to_array($3, ','))
+	order by workflow_node_run.start asc, workflow_node_run.id asc`
+
+	now := time.Now()
+	mutexes := []sdk.WorkflowNodeMutex{}
+	for _, n := range w.WorkflowData.Array() {
+		if n.Context == nil || !n.Context.Mutex {
+			continue
+		}
+
+		runs, err := loadNodeMutexRuns(db, query, w.ID, n.Name)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to load node runs for mutex of node %s", n.Name)
+		}
+		mutexes = append(mutexes, sdk.NewWorkflowNodeMutex(n.Name, runs, now))
+	}
+	return mutexes, nil
+}
+
+func loadNodeMutexRuns(db gorp.SqlExecutor, query string, workflowID int64, nodeName string) ([]sdk.WorkflowNodeMutexRun, error) {
+	rows, err := db.Query(query, workflowID, nodeName, sdk.StatusWaiting+","+sdk.StatusBuilding)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	defer rows.Close() // nolint
+
+	var runs []sdk.WorkflowNodeMutexRun
+	for rows.Next() {
+		var r sdk.WorkflowNodeMutexRun
+		if err := rows.Scan(&r.WorkflowRunID, &r.Number, &r.SubNumber, &r.WorkflowNodeRunID, &r.Status, &r.Since); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		runs = append(runs, r)
+	}
+	return runs, sdk.WithStack(rows.Err())
+}
+
+// ReleaseNodeMutex starts the next node run waiting for the mutex of given node, even if the mutex is still held.
+func ReleaseNodeMutex(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, w sdk.Workflow, nodeName string) (*ProcessorReport, error) {
+	n := w.WorkflowData.NodeByName(nodeName)
+	if n == nil {
+		return nil, sdk.WithStack(sdk.ErrWorkflowNodeNotFound)
+	}
+	if n.Context == nil || !n.Context.Mutex {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "node %s has no mutex", nodeName)
+	}
+	return releaseMutex(ctx, db, store, proj, w.ID, nodeName)
+}
+
+// releaseMutex tries to find one node run of the same node from the same workflow at status Waiting and executes it.
+func releaseMutex(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, workflowID int64, nodeName string) (*ProcessorReport, error) {
+	report := new(ProcessorReport)
+
+	mutexQuery := `select workflow_node_run.id
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	join workflow on workflow.id = workflow_run.workflow_id
+	where workflow.id = $1
+	and workflow_node_run.workflow_node_name = $2
+	and workflow_node_run.status = $3
+	order by workflow_node_run.start asc
+	limit 1`
+	waitingRunID, errID := db.SelectInt(mutexQuery, workflowID, nodeName, string(sdk.StatusWaiting))
+	if errID != nil && errID != sql.ErrNoRows {
+		log.Error(ctx, "workflow.execute> Unable to load mutex-locked workflow node run ID: %v", errID)
+		return report, nil
+	}
+	//If not more run is found, stop the loop
+	if waitingRunID == 0 {
+		return report, nil
+	}
+	waitingRun, errRun := LoadNodeRunByID(db, waitingRunID, LoadRunOptions{})
+	if errRun != nil && sdk.Cause(errRun) != sql.ErrNoRows {
+		log.Error(ctx, "workflow.execute> Unable to load mutex-locked workflow rnode un: %v", errRun)
+		return report, nil
+	}
+	//If not more run is found, stop the loop
+	if waitingRun == nil {
+		return report, nil
+	}
+
+	//Here we are loading another workflow run
+	workflowRun, errWRun := LoadRunByID(db, waitingRun.WorkflowRunID, LoadRunOptions{})
+	if errWRun != nil {
+		log.Error(ctx, "workflow.execute> Unable to load mutex-locked workflow rnode un: %v", errWRun)
+		return report, nil
+	}
+	AddWorkflowRunInfo(workflowRun, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeMutexRelease.ID,
+		Args: []interface{}{waitingRun.WorkflowNodeName},
+		Type: sdk.MsgWorkflowNodeMutexRelease.Type,
+	})
+
+	if err := UpdateWorkflowRun(ctx, db, workflowRun); err != nil {
+		return nil, sdk.WrapError(err, "unable to update workflow run %d after mutex release", workflowRun.ID)
+	}
+
+	log.Debug("workflow.execute> process the node run %d because mutex has been released", waitingRun.ID)
+	r, err := executeNodeRun(ctx, db, store, proj, waitingRun)
+	report.Merge(ctx, r)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to reprocess workflow")
+	}
+	report.Add(ctx, newMutexEvent(*waitingRun))
+	return report, nil
+}
+
+func newMutexEvent(nr sdk.WorkflowNodeRun) sdk.EventRunWorkflowNodeMutex {
+	return sdk.EventRunWorkflowNodeMutex{
+		NodeName:          nr.WorkflowNodeName,
+		WorkflowRunID:     nr.WorkflowRunID,
+		Number:            nr.Number,
+		SubNumber:         nr.SubNumber,
+		WorkflowNodeRunID: nr.ID,
+		WaitingDuration:   int64(time.Since(nr.Start).Seconds()),
+	}
+}
@@ -3,8 +3,11 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/go-gorp/gorp"
+
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 	"github.com/ovh/cds/sdk/luascript"
@@ -40,6 +43,10 @@ func setValuesGitInBuildParameters(run *sdk.WorkflowNodeRun, vcsInfos vcsInfos)
 	sdk.ParameterAddOrSetValue(&run.BuildParameters, tagGitURL, sdk.StringParameter, vcsInfos.URL)
 	sdk.ParameterAddOrSetValue(&run.BuildParameters, tagGitHTTPURL, sdk.StringParameter, vcsInfos.HTTPUrl)
 	sdk.ParameterAddOrSetValue(&run.BuildParameters, tagGitServer, sdk.StringParameter, vcsInfos.Server)
+
+	if vcsInfos.Tag == "" && vcsInfos.BranchProtected != nil {
+		sdk.ParameterAddOrSetValue(&run.BuildParameters, tagGitBranchProtected, sdk.StringParameter, strconv.FormatBool(*vcsInfos.BranchProtected))
+	}
 }
 
 func checkCondition(ctx context.Context, wr *sdk.WorkflowRun, conditions sdk.WorkflowNodeConditions, params []sdk.Parameter) bool {
@@ -73,6 +80,19 @@ func checkCondition(ctx context.Context, wr *sdk.WorkflowRun, conditions sdk.Wor
 	return conditionsOK
 }
 
+// previousRunsConditionParameters returns the condition variables resolved from the previous runs of given node.
+func previousRunsConditionParameters(db gorp.SqlExecutor, wr *sdk.WorkflowRun, nodeName string) ([]sdk.Parameter, error) {
+	last, err := LoadLastNodeRunByName(db, wr.WorkflowID, nodeName, wr.Number, "")
+	if err != nil {
+		return nil, err
+	}
+	lastSuccess, err := LoadLastNodeRunByName(db, wr.WorkflowID, nodeName, wr.Number, sdk.StatusSuccess)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.WorkflowConditionsPreviousParameters(last, lastSuccess), nil
+}
+
 // AddWorkflowRunInfo add WorkflowRunInfo on a WorkflowRun
 func AddWorkflowRunInfo(run *sdk.WorkflowRun, infos ...sdk.SpawnMsg) {
 	for _, i := range infos {
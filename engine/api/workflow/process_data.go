@@ -18,6 +18,7 @@ type nodeRunContext struct {
 	Pipeline           sdk.Pipeline
 	Environment        sdk.Environment
 	ProjectIntegration sdk.ProjectIntegration
+	DeploymentPlan     bool
 	NodeGroups         []sdk.GroupPermission
 }
 
@@ -10,8 +10,11 @@ import (
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/api/permission"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/user"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -150,6 +153,7 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 	}
 	if n.Context.ProjectIntegrationID != 0 {
 		runContext.ProjectIntegration = wr.Workflow.ProjectIntegrations[n.Context.ProjectIntegrationID]
+		runContext.DeploymentPlan = n.Context.DeploymentPlan
 	}
 
 	// NODE CONTEXT BUILD PARAMETER
@@ -195,7 +199,7 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 	if isRoot || currentRepo == "" || (parentRepo != nil && parentRepo.Value == currentRepo) {
 		for _, param := range nr.BuildParameters {
 			switch param.Name {
-			case tagGitHash, tagGitBranch, tagGitTag, tagGitAuthor, tagGitMessage, tagGitRepository, tagGitURL, tagGitHTTPURL, tagGitServer:
+			case tagGitHash, tagGitBranch, tagGitBranchProtected, tagGitTag, tagGitAuthor, tagGitMessage, tagGitRepository, tagGitURL, tagGitHTTPURL, tagGitServer:
 				currentJobGitValues[param.Name] = param.Value
 			}
 		}
@@ -215,7 +219,7 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 				// copy git info from ancestors
 				for _, param := range parent[0].BuildParameters {
 					switch param.Name {
-					case tagGitHash, tagGitBranch, tagGitTag, tagGitAuthor, tagGitMessage, tagGitRepository, tagGitURL, tagGitHTTPURL, tagGitServer:
+					case tagGitHash, tagGitBranch, tagGitBranchProtected, tagGitTag, tagGitAuthor, tagGitMessage, tagGitRepository, tagGitURL, tagGitHTTPURL, tagGitServer:
 						currentJobGitValues[param.Name] = param.Value
 					}
 				}
@@ -256,11 +260,52 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 	}
 
 	// CONDITION
-	if !checkCondition(ctx, wr, n.Context.Conditions, nr.BuildParameters) {
+	// Data from the previous runs of the node are only given to the conditions, not to the run
+	conditionParams := nr.BuildParameters
+	if sdk.WorkflowConditionsUsePrevious(n.Context.Conditions) {
+		previousParams, err := previousRunsConditionParameters(db, wr, n.Name)
+		if err != nil {
+			return nil, false, sdk.WrapError(err, "unable to load previous runs of node %s", n.Name)
+		}
+		conditionParams = append(append([]sdk.Parameter{}, nr.BuildParameters...), previousParams...)
+	}
+	if !checkCondition(ctx, wr, n.Context.Conditions, conditionParams) {
 		log.Debug("Condition failed on processNode %d/%d %+v", wr.ID, n.ID, nr.BuildParameters)
 		return nil, false, nil
 	}
 
+	// A node bound to a deployment integration is only run for users with the deploy permission
+	if manual != nil && wr.Workflow.ExecutePermission(n) > sdk.PermissionReadExecute {
+		canDeploy, err := checkNodeDeployPermission(ctx, db, wr, n, manual.Username)
+		if err != nil {
+			return nil, false, err
+		}
+		if !canDeploy {
+			AddWorkflowRunInfo(wr, sdk.SpawnMsg{
+				ID:   sdk.MsgWorkflowNodeDeployForbidden.ID,
+				Args: []interface{}{n.Name, manual.Username},
+				Type: sdk.MsgWorkflowNodeDeployForbidden.Type,
+			})
+			return nil, false, nil
+		}
+	}
+
+	// A node that applies a deployment plan is never triggered automatically, it should be approved by a manual run
+	if manual == nil {
+		parentNodeIDs := make([]int64, 0, len(parents))
+		for _, parent := range parents {
+			parentNodeIDs = append(parentNodeIDs, parent.WorkflowNodeID)
+		}
+		if planNode := wr.Workflow.DeploymentPlanParent(n, parentNodeIDs); planNode != nil {
+			AddWorkflowRunInfo(wr, sdk.SpawnMsg{
+				ID:   sdk.MsgWorkflowNodeDeploymentApproval.ID,
+				Args: []interface{}{n.Name, planNode.Name},
+				Type: sdk.MsgWorkflowNodeDeploymentApproval.Type,
+			})
+			return nil, false, nil
+		}
+	}
+
 	// Resync vcsInfos if we dont call func getVCSInfos
 	if !needVCSInfo {
 		vcsInf = &vcsInfos{}
@@ -273,6 +318,7 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		vcsInf.URL = currentJobGitValues[tagGitURL]
 		vcsInf.HTTPUrl = currentJobGitValues[tagGitHTTPURL]
 		vcsInf.Server = currentJobGitValues[tagGitServer]
+		vcsInf.BranchProtected = parseBranchProtected(currentJobGitValues[tagGitBranchProtected])
 	}
 
 	// Update datas if repo change
@@ -280,6 +326,16 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		setValuesGitInBuildParameters(nr, *vcsInf)
 	}
 
+	// A deployment from an unprotected branch is only reported, run conditions on git.branch.protected should be used
+	// to prevent it
+	if runContext.ProjectIntegration.Model.Deployment && sdk.ParameterValue(nr.BuildParameters, tagGitBranchProtected) == "false" {
+		AddWorkflowRunInfo(wr, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeDeployUnprotectedBranch.ID,
+			Args: []interface{}{n.Name, sdk.ParameterValue(nr.BuildParameters, tagGitBranch)},
+			Type: sdk.MsgWorkflowNodeDeployUnprotectedBranch.Type,
+		})
+	}
+
 	// ADD TAG
 	// Tag VCS infos : add in tag only if it does not exist
 	if !wr.TagExists(tagGitRepository) {
@@ -389,6 +445,9 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		return nil, false, sdk.WrapError(err, "unable to execute workflow run")
 	}
 	report.Merge(ctx, r1)
+	if n.Context.Mutex {
+		report.Add(ctx, newMutexEvent(*nr))
+	}
 	return report, true, nil
 }
 
@@ -559,3 +618,25 @@ func computeBuildParameters(wr *sdk.WorkflowRun, run *sdk.WorkflowNodeRun, paren
 
 	return params, nil
 }
+
+// checkNodeDeployPermission returns true if the user that manually triggered a run can run given node bound to a
+// deployment integration.
+func checkNodeDeployPermission(ctx context.Context, db gorp.SqlExecutor, wr *sdk.WorkflowRun, n *sdk.Node, username string) (bool, error) {
+	if username == "" {
+		return false, nil
+	}
+	u, err := user.LoadByUsername(ctx, db, username)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrUserNotFound) {
+			return false, nil
+		}
+		return false, sdk.WrapError(err, "unable to load user %s", username)
+	}
+	groups, err := group.LoadAllByUserID(ctx, db, u.ID)
+	if err != nil {
+		return false, err
+	}
+	u.Groups = groups
+
+	return permission.AccessToWorkflowNode(ctx, db, &wr.Workflow, n, &sdk.AuthConsumer{AuthentifiedUser: u}, sdk.PermissionReadExecuteDeploy), nil
+}
@@ -65,10 +65,24 @@ func getBuildParameterFromNodeContext(proj sdk.Project, w *sdk.Workflow, runCont
 	// COMPUTE  INTEGRATION VARIABLE
 	if runContext.ProjectIntegration.ID != 0 {
 		vars["cds.integration"] = runContext.ProjectIntegration.Name
-		tmp := sdk.ParametersFromIntegration(runContext.ProjectIntegration.Config)
+		// Resolve project variables referenced by the integration config, secrets are resolved only in job secrets
+		projVars := make([]sdk.Variable, 0, len(proj.Variables))
+		for _, v := range proj.Variables {
+			if !sdk.NeedPlaceholder(v.Type) {
+				projVars = append(projVars, v)
+			}
+		}
+		config, err := runContext.ProjectIntegration.Config.Interpolate(projVars)
+		if err != nil {
+			return nil, err
+		}
+		tmp := sdk.ParametersFromIntegration(config)
 		for k, v := range tmp {
 			vars[k] = v
 		}
+		if runContext.DeploymentPlan {
+			vars[sdk.IntegrationDeploymentPlanParameter] = "true"
+		}
 
 		// COMPUTE DEPLOYMENT STRATEGIES VARIABLE
 		if runContext.Application.ID != 0 {
@@ -181,6 +195,13 @@ func getParentParameters(w *sdk.WorkflowRun, nodeRuns []*sdk.WorkflowNodeRun) ([
 				parentParams = append(parentParams, param)
 				continue
 			}
+			// Step outputs are inherited as is, and prefixed with the parent node name
+			if strings.HasPrefix(param.Name, sdk.StepOutputPrefix) {
+				parentParams = append(parentParams, param)
+				param.Name = prefix + param.Name
+				parentParams = append(parentParams, param)
+				continue
+			}
 			if strings.HasPrefix(param.Name, "gerrit.") {
 				parentParams = append(parentParams, param)
 				continue
@@ -224,6 +245,11 @@ func getNodeRunBuildParameters(ctx context.Context, proj sdk.Project, wr *sdk.Wo
 		tmp["cds.template.version"] = fmt.Sprintf("%d", wr.Workflow.TemplateInstance.WorkflowTemplateVersion)
 	}
 
+	// The worker uploads the artifacts of the node run to the storage pinned by the workflow
+	if storage := wr.Workflow.ArtifactStorageForNode(run.WorkflowNodeName); storage != "" {
+		tmp[sdk.WorkflowArtifactStorageParameter] = storage
+	}
+
 	_, next := observability.Span(ctx, "workflow.interpolate")
 	params = make([]sdk.Parameter, 0, len(tmp))
 	for k, v := range tmp {
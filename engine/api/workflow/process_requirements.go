@@ -20,7 +20,7 @@ func processNodeJobRunRequirements(ctx context.Context, db gorp.SqlExecutor, j s
 	var requirements sdk.RequirementList
 	var errm sdk.MultiError
 	var containsService bool
-	var model string
+	var model, osArch string
 	var tmp = sdk.ParametersToMap(run.BuildParameters)
 
 	pluginsRequirements := []sdk.Requirement{}
@@ -58,6 +58,19 @@ func processNodeJobRunRequirements(ctx context.Context, db gorp.SqlExecutor, j s
 			model = value
 		}
 
+		if v.Type == sdk.OSArchRequirement {
+			// It is forbidden to have more than one os-architecture requirement.
+			if osArch != "" {
+				errm.Append(sdk.ErrInvalidJobRequirementDuplicateOSArch)
+				break
+			}
+			if !sdk.IsValidOSArch(value) {
+				errm.Append(sdk.ErrInvalidJobRequirementOSArch)
+				break
+			}
+			osArch = value
+		}
+
 		if v.Type == sdk.NetworkAccessRequirement {
 			if !strings.Contains(value, ":") {
 				errm.Append(sdk.ErrInvalidJobRequirementNetworkAccess)
@@ -73,6 +86,9 @@ func processNodeJobRunRequirements(ctx context.Context, db gorp.SqlExecutor, j s
 		log.Error(ctx, "getNodeJobRunRequirements> error while getting worker model %s: %v", model, err)
 		errm.Append(err)
 	}
+	if wm != nil && osArch != "" && !wm.IsCompatibleOSArch(osArch) {
+		errm.Append(sdk.ErrInvalidJobRequirementWorkerModelOSArch)
+	}
 	if wm != nil {
 		// Check that the worker model has the binaries capabilitites
 		// only if the worker model doesn't need registration
@@ -118,6 +134,16 @@ func prepareRequirementsToNodeJobRunParameters(reqs sdk.RequirementList) []sdk.P
 	return params
 }
 
+// LoadNodeJobRunModel returns the worker model required by given job, or nil if the job has no model requirement.
+func LoadNodeJobRunModel(ctx context.Context, db gorp.SqlExecutor, job sdk.WorkflowNodeJobRun) (*sdk.Model, error) {
+	for _, r := range job.Job.Action.Requirements {
+		if r.Type == sdk.ModelRequirement {
+			return processNodeJobRunRequirementsGetModel(ctx, db, r.Value, job.ExecGroups.ToIDs())
+		}
+	}
+	return nil, nil
+}
+
 func processNodeJobRunRequirementsGetModel(ctx context.Context, db gorp.SqlExecutor, model string, execsGroupIDs []int64) (*sdk.Model, error) {
 	if model == "" {
 		return nil, nil
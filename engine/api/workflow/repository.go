@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/fsamin/go-dump"
@@ -15,6 +16,7 @@ import (
 	"github.com/ovh/cds/engine/api/keys"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/operation"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/api/workflowtemplate"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/exportentities"
@@ -35,6 +37,7 @@ type PushOption struct {
 	HookUUID           string
 	Force              bool
 	OldWorkflow        sdk.Workflow
+	Message            string
 }
 
 // CreateFromRepository a workflow from a repository.
@@ -76,8 +79,12 @@ func extractWorkflow(ctx context.Context, db *gorp.DbMap, store cache.Store, p *
 	ctx, end := observability.Span(ctx, "workflow.extractWorkflow")
 	defer end()
 	var allMsgs []sdk.Message
-	// Read files
-	tr, err := ReadCDSFiles(ope.LoadFiles.Results)
+	// Resolve includes then read files
+	files, err := ResolveCDSFiles(ctx, db, store, *p, ope)
+	if err != nil {
+		return allMsgs, sdk.WrapError(err, "unable to resolve cds files")
+	}
+	tr, err := ReadCDSFiles(files)
 	if err != nil {
 		allMsgs = append(allMsgs, sdk.NewMessage(sdk.MsgWorkflowErrorBadCdsDir))
 		return allMsgs, sdk.WrapError(err, "unable to read cds files")
@@ -129,6 +136,73 @@ func extractWorkflow(ctx context.Context, db *gorp.DbMap, store cache.Store, p *
 	return allMsgs, nil
 }
 
+// ResolveCDSFiles resolves include and extends directives of the CDS files loaded by given operation.
+// Fragments from other repositories are loaded from the same VCS server than the operation.
+func ResolveCDSFiles(ctx context.Context, db gorp.SqlExecutor, store cache.Store, p sdk.Project, ope sdk.Operation) (map[string][]byte, error) {
+	return exportentities.ResolveIncludes(ope.LoadFiles.Results, func(repository string) (map[string][]byte, error) {
+		return loadFragmentRepository(ctx, db, store, p, ope, repository)
+	})
+}
+
+// loadFragmentRepository loads CDS files from given repository, the repository can be suffixed
+// by a branch name (ie. my-org/my-repo@my-branch), the default branch is used otherwise.
+func loadFragmentRepository(ctx context.Context, db gorp.SqlExecutor, store cache.Store, p sdk.Project, ope sdk.Operation, repository string) (map[string][]byte, error) {
+	ctx, end := observability.Span(ctx, "workflow.loadFragmentRepository")
+	defer end()
+
+	repoFullName, branch := repository, ""
+	if i := strings.LastIndex(repository, "@"); i > 0 {
+		repoFullName, branch = repository[:i], repository[i+1:]
+	}
+
+	vcsServer := repositoriesmanager.GetProjectVCSServer(p, ope.VCSServer)
+	if vcsServer == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrNoReposManager, "cannot find vcs server %s on project %s", ope.VCSServer, p.Key)
+	}
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, p.Key, vcsServer)
+	if err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNoReposManagerClientAuth, "cannot get client for %s %s", p.Key, ope.VCSServer))
+	}
+	repo, err := client.RepoByFullname(ctx, repoFullName)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get repo %s", repoFullName)
+	}
+
+	fragmentOpe := sdk.Operation{
+		VCSServer:          ope.VCSServer,
+		RepoFullName:       repoFullName,
+		URL:                repo.HTTPCloneURL,
+		RepositoryStrategy: ope.RepositoryStrategy,
+		LoadFiles: sdk.OperationLoadFiles{
+			Pattern: WorkflowAsCodePattern,
+		},
+	}
+	if ope.RepositoryStrategy.ConnectionType == "ssh" {
+		fragmentOpe.URL = repo.SSHCloneURL
+	}
+	if branch == "" {
+		branches, err := client.Branches(ctx, repoFullName)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot list branches for %s/%s", ope.VCSServer, repoFullName)
+		}
+		for _, b := range branches {
+			if b.Default {
+				branch = b.DisplayID
+				break
+			}
+		}
+	}
+	fragmentOpe.Setup.Checkout.Branch = branch
+
+	if err := operation.PostRepositoryOperation(ctx, db, p, &fragmentOpe, nil); err != nil {
+		return nil, sdk.WrapError(err, "unable to post repository operation")
+	}
+	if err := pollRepositoryOperation(ctx, db, store, &fragmentOpe); err != nil {
+		return nil, sdk.WrapError(err, "cannot analyse repository %s", repository)
+	}
+	return fragmentOpe.LoadFiles.Results, nil
+}
+
 // ReadCDSFiles reads CDS files
 func ReadCDSFiles(files map[string][]byte) (*tar.Reader, error) {
 	// Create a buffer to write our archive to.
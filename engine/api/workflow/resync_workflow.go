@@ -40,6 +40,7 @@ func Resync(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sd
 	wr.Workflow.Environments = wf.Environments
 	wr.Workflow.ProjectIntegrations = wf.ProjectIntegrations
 	wr.Workflow.EventIntegrations = wf.EventIntegrations
+	wr.Workflow.ArtifactStorages = wf.ArtifactStorages
 	wr.Workflow.HookModels = wf.HookModels
 	wr.Workflow.OutGoingHookModels = wf.OutGoingHookModels
 
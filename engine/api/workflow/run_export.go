@@ -0,0 +1,257 @@
+package workflow
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// runExportWriter writes the files of a workflow run export in a tar archive and lists them in the manifest.
+type runExportWriter struct {
+	tw       *tar.Writer
+	manifest sdk.WorkflowRunExportManifest
+}
+
+func (e *runExportWriter) add(name string, content []byte) error {
+	if err := e.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: e.manifest.Exported,
+	}); err != nil {
+		return sdk.WrapError(err, "unable to write header for %s", name)
+	}
+	if _, err := e.tw.Write(content); err != nil {
+		return sdk.WrapError(err, "unable to write content of %s", name)
+	}
+	if name != sdk.WorkflowRunExportManifestFile && name != sdk.WorkflowRunExportSignatureFile {
+		e.manifest.Add(name, content)
+	}
+	return nil
+}
+
+func (e *runExportWriter) addJSON(name string, v interface{}) error {
+	btes, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	return e.add(name, btes)
+}
+
+func (e *runExportWriter) addCSV(name string, records [][]string) error {
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	if err := w.WriteAll(records); err != nil {
+		return sdk.WrapError(err, "unable to write %s", name)
+	}
+	return e.add(name, buf.Bytes())
+}
+
+// ExportRun writes a gzipped tar archive of given workflow run for compliance records: the run with its node
+// statuses and parameters as JSON and CSV, the step logs, the test reports, the manifest of artifacts and the
+// manual runs of nodes. The archive contains a manifest of its files signed with given func. Parameters of type
+// password are masked in given run.
+func ExportRun(ctx context.Context, db gorp.SqlExecutor, wr *sdk.WorkflowRun, exportedBy string, sign func(sdk.WorkflowRunExportManifest) (string, error), w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	e := &runExportWriter{
+		tw: tar.NewWriter(gw),
+		manifest: sdk.WorkflowRunExportManifest{
+			ProjectKey:   wr.Workflow.ProjectKey,
+			WorkflowName: wr.Workflow.Name,
+			Number:       wr.Number,
+			Status:       wr.Status,
+			Exported:     time.Now(),
+			ExportedBy:   exportedBy,
+		},
+	}
+
+	// Parameters of type password are never exported, job parameters are the build parameters of the node run
+	for id := range wr.WorkflowNodeRuns {
+		for i := range wr.WorkflowNodeRuns[id] {
+			nr := &wr.WorkflowNodeRuns[id][i]
+			nr.BuildParameters = runExportParameters(nr.BuildParameters)
+			nr.PipelineParameters = runExportParameters(nr.PipelineParameters)
+			for j := range nr.Stages {
+				for k := range nr.Stages[j].RunJobs {
+					nr.Stages[j].RunJobs[k].Parameters = nil
+				}
+			}
+		}
+	}
+	nodeRuns := runExportNodeRuns(wr)
+
+	if err := e.addJSON("run.json", wr); err != nil {
+		return err
+	}
+	if err := e.addCSV("nodes.csv", runExportNodesCSV(nodeRuns)); err != nil {
+		return err
+	}
+	if err := e.addCSV("jobs.csv", runExportJobsCSV(nodeRuns)); err != nil {
+		return err
+	}
+	if err := e.addCSV("parameters.csv", runExportParametersCSV(nodeRuns)); err != nil {
+		return err
+	}
+	if err := e.addCSV("artifacts.csv", runExportArtifactsCSV(nodeRuns)); err != nil {
+		return err
+	}
+	if err := e.addJSON("approvals.json", runExportApprovals(nodeRuns)); err != nil {
+		return err
+	}
+
+	for _, nr := range nodeRuns {
+		dir := fmt.Sprintf("%s.%d", nr.WorkflowNodeName, nr.SubNumber)
+		if nr.Tests != nil {
+			if err := e.addJSON(dir+"/tests.json", nr.Tests); err != nil {
+				return err
+			}
+		}
+		for _, s := range nr.Stages {
+			for _, rj := range s.RunJobs {
+				for _, step := range rj.Job.StepStatus {
+					logs, err := LoadStepLogs(db, rj.ID, int64(step.StepOrder))
+					if err != nil {
+						return sdk.WrapError(err, "unable to load logs for job %d step %d", rj.ID, step.StepOrder)
+					}
+					if logs == nil {
+						continue
+					}
+					name := fmt.Sprintf("%s/logs/%s.%d/step.%d.log", dir, rj.Job.Action.Name, rj.ID, step.StepOrder)
+					if err := e.add(name, []byte(logs.Val)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	if err := e.addJSON(sdk.WorkflowRunExportManifestFile, e.manifest); err != nil {
+		return err
+	}
+	signature, err := sign(e.manifest)
+	if err != nil {
+		return sdk.WrapError(err, "unable to sign manifest")
+	}
+	if err := e.add(sdk.WorkflowRunExportSignatureFile, []byte(signature)); err != nil {
+		return err
+	}
+
+	if err := e.tw.Close(); err != nil {
+		return sdk.WrapError(err, "unable to close tar writer")
+	}
+	return sdk.WrapError(gw.Close(), "unable to close gzip writer")
+}
+
+// runExportNodeRuns returns all the node runs of given workflow run, ordered by id.
+func runExportNodeRuns(wr *sdk.WorkflowRun) []sdk.WorkflowNodeRun {
+	var nodeRuns []sdk.WorkflowNodeRun
+	for _, nrs := range wr.WorkflowNodeRuns {
+		nodeRuns = append(nodeRuns, nrs...)
+	}
+	sort.Slice(nodeRuns, func(i, j int) bool { return nodeRuns[i].ID < nodeRuns[j].ID })
+	return nodeRuns
+}
+
+func runExportParameters(params []sdk.Parameter) []sdk.Parameter {
+	res := make([]sdk.Parameter, 0, len(params))
+	for _, p := range params {
+		if sdk.NeedPlaceholder(p.Type) {
+			p.Value = sdk.PasswordPlaceholder
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+func runExportDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func runExportNodesCSV(nodeRuns []sdk.WorkflowNodeRun) [][]string {
+	records := [][]string{{"node_run_id", "node", "subnumber", "status", "start", "done", "manual_by", "vcs_repository", "vcs_branch", "vcs_hash"}}
+	for _, nr := range nodeRuns {
+		var manualBy string
+		if nr.Manual != nil {
+			manualBy = nr.Manual.Username
+		}
+		records = append(records, []string{
+			strconv.FormatInt(nr.ID, 10), nr.WorkflowNodeName, strconv.FormatInt(nr.SubNumber, 10), nr.Status,
+			runExportDate(nr.Start), runExportDate(nr.Done), manualBy, nr.VCSRepository, nr.VCSBranch, nr.VCSHash,
+		})
+	}
+	return records
+}
+
+func runExportJobsCSV(nodeRuns []sdk.WorkflowNodeRun) [][]string {
+	records := [][]string{{"node_run_id", "node", "subnumber", "stage", "job_id", "job", "status", "start", "done", "model", "worker"}}
+	for _, nr := range nodeRuns {
+		for _, s := range nr.Stages {
+			for _, rj := range s.RunJobs {
+				records = append(records, []string{
+					strconv.FormatInt(nr.ID, 10), nr.WorkflowNodeName, strconv.FormatInt(nr.SubNumber, 10), s.Name,
+					strconv.FormatInt(rj.ID, 10), rj.Job.Action.Name, rj.Status, runExportDate(rj.Start), runExportDate(rj.Done),
+					rj.Model, rj.WorkerName,
+				})
+			}
+		}
+	}
+	return records
+}
+
+func runExportParametersCSV(nodeRuns []sdk.WorkflowNodeRun) [][]string {
+	records := [][]string{{"node_run_id", "node", "subnumber", "name", "type", "value"}}
+	for _, nr := range nodeRuns {
+		for _, p := range runExportParameters(nr.BuildParameters) {
+			records = append(records, []string{
+				strconv.FormatInt(nr.ID, 10), nr.WorkflowNodeName, strconv.FormatInt(nr.SubNumber, 10), p.Name, p.Type, p.Value,
+			})
+		}
+	}
+	return records
+}
+
+func runExportArtifactsCSV(nodeRuns []sdk.WorkflowNodeRun) [][]string {
+	records := [][]string{{"node_run_id", "node", "subnumber", "name", "tag", "size", "md5sum", "sha512sum", "created"}}
+	for _, nr := range nodeRuns {
+		for _, a := range nr.Artifacts {
+			records = append(records, []string{
+				strconv.FormatInt(nr.ID, 10), nr.WorkflowNodeName, strconv.FormatInt(nr.SubNumber, 10), a.Name, a.Tag,
+				strconv.FormatInt(a.Size, 10), a.MD5sum, a.SHA512sum, runExportDate(a.Created),
+			})
+		}
+	}
+	return records
+}
+
+func runExportApprovals(nodeRuns []sdk.WorkflowNodeRun) []sdk.WorkflowRunExportApproval {
+	approvals := []sdk.WorkflowRunExportApproval{}
+	for _, nr := range nodeRuns {
+		if nr.Manual == nil {
+			continue
+		}
+		approvals = append(approvals, sdk.WorkflowRunExportApproval{
+			NodeName:  nr.WorkflowNodeName,
+			SubNumber: nr.SubNumber,
+			Username:  nr.Manual.Username,
+			Fullname:  nr.Manual.Fullname,
+			Email:     nr.Manual.Email,
+			Date:      nr.Start,
+		})
+	}
+	return approvals
+}
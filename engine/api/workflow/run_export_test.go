@@ -0,0 +1,92 @@
+package workflow
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestExportRun(t *testing.T) {
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	wr := &sdk.WorkflowRun{
+		Number: 12,
+		Status: sdk.StatusSuccess,
+		Workflow: sdk.Workflow{
+			ProjectKey: "PROJ",
+			Name:       "wf",
+		},
+		WorkflowNodeRuns: map[int64][]sdk.WorkflowNodeRun{
+			1: {{
+				ID:               2,
+				WorkflowNodeName: "deploy",
+				Status:           sdk.StatusSuccess,
+				Start:            start,
+				Manual:           &sdk.WorkflowNodeRunManual{Username: "john"},
+				BuildParameters: []sdk.Parameter{
+					{Name: "cds.version", Type: sdk.StringParameter, Value: "12"},
+					{Name: "token", Type: sdk.SecretVariable, Value: "my-secret"},
+				},
+				Artifacts: []sdk.WorkflowNodeRunArtifact{{Name: "app.tar.gz", Size: 42, SHA512sum: "abc"}},
+			}},
+			0: {{ID: 1, WorkflowNodeName: "build", Status: sdk.StatusSuccess}},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, ExportRun(context.TODO(), nil, wr, "admin", func(m sdk.WorkflowRunExportManifest) (string, error) {
+		assert.Equal(t, "PROJ", m.ProjectKey)
+		assert.Equal(t, "admin", m.ExportedBy)
+		return "signature", nil
+	}, buf))
+
+	gr, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	files := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		files[hdr.Name], err = ioutil.ReadAll(tr)
+		require.NoError(t, err)
+	}
+
+	var manifest sdk.WorkflowRunExportManifest
+	require.NoError(t, json.Unmarshal(files[sdk.WorkflowRunExportManifestFile], &manifest))
+	assert.Equal(t, "signature", string(files[sdk.WorkflowRunExportSignatureFile]))
+	delete(files, sdk.WorkflowRunExportManifestFile)
+	delete(files, sdk.WorkflowRunExportSignatureFile)
+	require.NoError(t, manifest.Check(files))
+
+	assert.Equal(t, "node_run_id,node,subnumber,status,start,done,manual_by,vcs_repository,vcs_branch,vcs_hash\n"+
+		"1,build,0,Success,,,,,,\n"+
+		"2,deploy,0,Success,2020-01-02T03:04:05Z,,john,,,\n", string(files["nodes.csv"]))
+	assert.Equal(t, "node_run_id,node,subnumber,name,type,value\n"+
+		"2,deploy,0,cds.version,string,12\n"+
+		"2,deploy,0,token,password,"+sdk.PasswordPlaceholder+"\n", string(files["parameters.csv"]))
+	assert.Contains(t, string(files["artifacts.csv"]), "2,deploy,0,app.tar.gz,,42,,abc,\n")
+	assert.NotContains(t, string(files["run.json"]), "my-secret")
+
+	var approvals []sdk.WorkflowRunExportApproval
+	require.NoError(t, json.Unmarshal(files["approvals.json"], &approvals))
+	require.Len(t, approvals, 1)
+	assert.Equal(t, "deploy", approvals[0].NodeName)
+	assert.Equal(t, "john", approvals[0].Username)
+
+	// A modified file doesn't match the manifest
+	files["nodes.csv"] = []byte("node_run_id\n")
+	assert.Error(t, manifest.Check(files))
+}
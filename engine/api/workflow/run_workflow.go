@@ -27,6 +27,9 @@ const (
 	tagGitServer     = "git.server"
 )
 
+// tagGitBranchProtected is only set if the repositories manager gives the protection status of the branch.
+const tagGitBranchProtected = "git.branch.protected"
+
 //RunFromHook is the entry point to trigger a workflow from a hook
 func runFromHook(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wr *sdk.WorkflowRun, e *sdk.WorkflowNodeRunHookEvent, asCodeMsg []sdk.Message) (*ProcessorReport, error) {
 	var end func()
@@ -140,7 +143,7 @@ func StartWorkflowRun(ctx context.Context, db *gorp.DbMap, store cache.Store, pr
 			}
 
 			// check permission fo workflow node on handler layer
-			if !permission.AccessToWorkflowNode(ctx, db, &wr.Workflow, fromNode, u, sdk.PermissionReadExecute) {
+			if !permission.AccessToWorkflowNode(ctx, db, &wr.Workflow, fromNode, u, wr.Workflow.ExecutePermission(fromNode)) {
 				return nil, sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on root node %d", wr.Workflow.WorkflowData.Node.ID)
 			}
 
@@ -153,7 +156,7 @@ func StartWorkflowRun(ctx context.Context, db *gorp.DbMap, store cache.Store, pr
 		} else {
 			// heck permission fo workflow node on handler layer
 			// MANUAL RUN FROM ROOT NODE
-			if !permission.AccessToWorkflowNode(ctx, db, &wr.Workflow, &wr.Workflow.WorkflowData.Node, u, sdk.PermissionReadExecute) {
+			if !permission.AccessToWorkflowNode(ctx, db, &wr.Workflow, &wr.Workflow.WorkflowData.Node, u, wr.Workflow.ExecutePermission(&wr.Workflow.WorkflowData.Node)) {
 				return nil, sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %d", wr.Workflow.WorkflowData.Node.ID)
 			}
 			// Start new workflow
@@ -70,6 +70,11 @@ func Import(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sd
 	}
 	w.ID = oldW.ID
 
+	// The imported workflow gives all its artifact storages, the existing ones are removed if it has none
+	if w.ArtifactStorages == nil {
+		w.ArtifactStorages = []sdk.WorkflowArtifactStorage{}
+	}
+
 	// HookRegistration after workflow.Update.  It needs hooks to be created on DB
 	// Hook registration must only be done on default branch in case of workflow as-code
 	// The derivation branch is set in workflow parser it is not coming from the default branch
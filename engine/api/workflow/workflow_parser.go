@@ -7,6 +7,7 @@ import (
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/integration"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/exportentities"
@@ -24,6 +25,8 @@ type ImportOptions struct {
 	RepositoryName     string
 	RepositoryStrategy sdk.RepositoryStrategy
 	HookUUID           string
+	// Message is saved with the version of the workflow created by the import
+	Message string
 }
 
 // Parse parse an exportentities.workflow and return the parsed workflow
@@ -50,6 +53,37 @@ func Parse(ctx context.Context, proj sdk.Project, ew exportentities.Workflow) (*
 	return w, nil
 }
 
+// CheckIntegrations checks that the project integrations referenced by given parsed workflow exist with a model
+// compatible with their usage, and that the consumer is allowed to use them. An integration inherited from a group
+// integration can only be used by the members of the group or by an admin.
+func CheckIntegrations(db gorp.SqlExecutor, proj sdk.Project, w sdk.Workflow, consumer *sdk.AuthConsumer) (sdk.WorkflowImportIntegrationReport, error) {
+	models, err := integration.LoadModels(db)
+	if err != nil {
+		return sdk.WorkflowImportIntegrationReport{}, err
+	}
+
+	var allowed func(sdk.ProjectIntegration) bool
+	if consumer != nil && !consumer.Admin() {
+		gis, err := integration.LoadGroupIntegrationsByGroupIDsWithClearPassword(db, consumer.GetGroupIDs())
+		if err != nil {
+			return sdk.WorkflowImportIntegrationReport{}, err
+		}
+		groupIntegrationIDs := make(map[int64]struct{}, len(gis))
+		for _, gi := range gis {
+			groupIntegrationIDs[gi.ID] = struct{}{}
+		}
+		allowed = func(pi sdk.ProjectIntegration) bool {
+			if pi.GroupIntegrationID == nil {
+				return true
+			}
+			_, ok := groupIntegrationIDs[*pi.GroupIntegrationID]
+			return ok
+		}
+	}
+
+	return sdk.CheckWorkflowIntegrations(w, proj.Integrations, models, allowed), nil
+}
+
 // ParseAndImport parse an exportentities.workflow and insert or update the workflow in database
 func ParseAndImport(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, oldW *sdk.Workflow, ew exportentities.Workflow, u sdk.Identifiable, opts ImportOptions) (*sdk.Workflow, []sdk.Message, error) {
 	ctx, end := observability.Span(ctx, "workflow.ParseAndImport")
@@ -187,6 +221,12 @@ func ParseAndImport(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 	close(msgChan)
 	done.Wait()
 
+	if globalError == nil {
+		if _, err := InsertVersion(ctx, db, store, proj, w.ID, u, opts.Message); err != nil {
+			return nil, msgList, err
+		}
+	}
+
 	if ew.GetVersion() == exportentities.WorkflowVersion1 {
 		msgList = append(msgList, sdk.NewMessage(sdk.MsgWorkflowDeprecatedVersion, proj.Key, ew.GetName()))
 	}
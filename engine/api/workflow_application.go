@@ -36,7 +36,7 @@ func (api *API) releaseApplicationWorkflowHandler() service.Handler {
 			return errU
 		}
 
-		proj, errprod := project.Load(api.mustDB(),  key)
+		proj, errprod := project.Load(api.mustDB(), key)
 		if errprod != nil {
 			return sdk.WrapError(errprod, "releaseApplicationWorkflowHandler")
 		}
@@ -87,6 +87,12 @@ func (api *API) releaseApplicationWorkflowHandler() service.Handler {
 			return sdk.WithStack(errRelease)
 		}
 
+		// Tag the run as released, its artifacts will be kept with the release retention of the project
+		workflowRun.Tag(sdk.WorkflowRunTagRelease, req.TagName)
+		if err := workflow.UpdateWorkflowRunTags(api.mustDB(), workflowRun); err != nil {
+			return err
+		}
+
 		// Get artifacts to upload
 		var artifactToUpload []sdk.WorkflowNodeRunArtifact
 		for _, a := range workflowArtifacts {
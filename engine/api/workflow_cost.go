@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/cost"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getProjectCostHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(api.mustDBRead(), key)
+		if err != nil {
+			return err
+		}
+
+		return api.writeCostReport(ctx, w, r, cost.LoadFilter{ProjectID: p.ID}, sdk.WorkflowCostGroupByWorkflow, key)
+	}
+}
+
+func (api *API) getWorkflowCostHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		p, err := project.Load(api.mustDBRead(), key)
+		if err != nil {
+			return err
+		}
+		wf, err := workflow.Load(ctx, api.mustDBRead(), api.Cache, *p, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", name)
+		}
+
+		return api.writeCostReport(ctx, w, r, cost.LoadFilter{ProjectID: p.ID, WorkflowID: wf.ID}, sdk.WorkflowCostGroupByRun,
+			fmt.Sprintf("%s-%s", key, name))
+	}
+}
+
+func (api *API) getWorkflowRunCostHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDBRead(), key)
+		if err != nil {
+			return err
+		}
+		wf, err := workflow.Load(ctx, api.mustDBRead(), api.Cache, *p, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", name)
+		}
+
+		return api.writeCostReport(ctx, w, r, cost.LoadFilter{ProjectID: p.ID, WorkflowID: wf.ID, RunNumber: number},
+			sdk.WorkflowCostGroupByJob, fmt.Sprintf("%s-%s-%d", key, name, number))
+	}
+}
+
+// writeCostReport writes the cost report of the job runs that match given filter, as JSON or as CSV if asked. Lines
+// are grouped by given key unless another one is asked.
+func (api *API) writeCostReport(ctx context.Context, w http.ResponseWriter, r *http.Request, filter cost.LoadFilter, groupBy, filename string) error {
+	var err error
+	if filter.From, err = formTimeRFC3339(r, "from"); err != nil {
+		return err
+	}
+	if filter.To, err = formTimeRFC3339(r, "to"); err != nil {
+		return err
+	}
+	if g := FormString(r, "groupBy"); g != "" {
+		groupBy = g
+	}
+
+	usages, err := cost.LoadUsages(ctx, api.mustDBRead(), filter)
+	if err != nil {
+		return err
+	}
+	report, err := api.Config.Cost.Report(groupBy, usages)
+	if err != nil {
+		return err
+	}
+	report.From, report.To = filter.From, filter.To
+
+	switch FormString(r, "format") {
+	case "", "json":
+		return service.WriteJSON(w, report, http.StatusOK)
+	case "csv":
+		w.Header().Add("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s-cost.csv\"", filename))
+		w.WriteHeader(http.StatusOK)
+		return sdk.WrapError(csv.NewWriter(w).WriteAll(report.CSV()), "unable to write cost report")
+	default:
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid format %q, should be json or csv", FormString(r, "format"))
+	}
+}
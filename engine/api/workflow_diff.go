@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+	v2 "github.com/ovh/cds/sdk/exportentities/v2"
+)
+
+func (api *API) postWorkflowDiffHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		var req sdk.WorkflowDiffRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if req.Workflow == "" && req.AuditID == 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "a workflow file or an audit should be given")
+		}
+
+		proj, err := project.Load(api.mustDB(), key, project.LoadOptions.WithIntegrations)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load projet")
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *proj, name, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", name)
+		}
+
+		var opts []v2.ExportOptions
+		if wf.FromRepository != "" {
+			opts = append(opts, v2.WorkflowSkipIfOnlyOneRepoWebhook)
+		}
+		stored, err := v2.NewWorkflow(ctx, *wf, exportentities.WorkflowVersion2, opts...)
+		if err != nil {
+			return sdk.WrapError(err, "unable to export workflow")
+		}
+
+		from, to := stored, stored
+		if req.AuditID != 0 {
+			audit, err := workflow.LoadAudit(api.mustDB(), req.AuditID, wf.ID)
+			if err != nil {
+				return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound, "cannot find audit %d of workflow %s", req.AuditID, name))
+			}
+			if audit.DataType != "yaml" || audit.DataAfter == "" {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "audit %d does not contain a version of the workflow", req.AuditID)
+			}
+			from, err = workflowDiffParse([]byte(audit.DataAfter))
+			if err != nil {
+				return err
+			}
+		}
+		if req.Workflow != "" {
+			to, err = workflowDiffParse([]byte(req.Workflow))
+			if err != nil {
+				return err
+			}
+		}
+
+		diff, err := v2.Diff(from, to)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, diff, http.StatusOK)
+	}
+}
+
+// workflowDiffParse returns the workflow of given yaml or json file, only files of version v2.0 can be compared.
+func workflowDiffParse(content []byte) (v2.Workflow, error) {
+	wf, err := exportentities.UnmarshalWorkflow(content, exportentities.FormatYAML)
+	if err != nil {
+		return v2.Workflow{}, err
+	}
+	wfV2, ok := wf.(v2.Workflow)
+	if !ok {
+		return v2.Workflow{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "only workflows of version %s can be compared", exportentities.WorkflowVersion2)
+	}
+	return wfV2, nil
+}
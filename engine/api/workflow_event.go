@@ -10,6 +10,7 @@ import (
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowwebhook"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -21,6 +22,9 @@ func WorkflowSendEvent(ctx context.Context, db gorp.SqlExecutor, store cache.Sto
 	}
 	for _, wr := range report.Workflows() {
 		event.PublishWorkflowRun(ctx, wr, proj.Key)
+		if err := workflowwebhook.EnqueueRun(ctx, db, wr); err != nil {
+			log.Warning(ctx, "WorkflowSendEvent> Cannot enqueue workflow webhooks: %v", err)
+		}
 	}
 	for _, wnr := range report.Nodes() {
 		wr, errWR := workflow.LoadRunByID(db, wnr.WorkflowRunID, workflow.LoadRunOptions{
@@ -57,6 +61,9 @@ func WorkflowSendEvent(ctx context.Context, db gorp.SqlExecutor, store cache.Sto
 		}
 		eventsNotif := notification.GetUserWorkflowEvents(ctx, db, store, wr.Workflow.ProjectID, wr.Workflow.ProjectKey, workDB.Name, wr.Workflow.Notifications, &previousNodeRun, *nr)
 		event.PublishWorkflowNodeRun(ctx, *nr, wr.Workflow, eventsNotif)
+		if err := workflowwebhook.EnqueueNodeRun(ctx, db, *wr, *nr); err != nil {
+			log.Warning(ctx, "WorkflowSendEvent> Cannot enqueue workflow webhooks: %v", err)
+		}
 		e := &workflow.VCSEventMessenger{}
 		if err := e.SendVCSEvent(ctx, db, store, proj, *wr, wnr); err != nil {
 			log.Warning(ctx, "WorkflowSendEvent> Cannot send vcs notification")
@@ -78,4 +85,15 @@ func WorkflowSendEvent(ctx context.Context, db gorp.SqlExecutor, store cache.Sto
 		}
 		event.PublishWorkflowNodeJobRun(ctx, db, proj.Key, *wr, jobrun)
 	}
+
+	for _, m := range report.Mutexes() {
+		wr, errWR := workflow.LoadRunByID(db, m.WorkflowRunID, workflow.LoadRunOptions{
+			DisableDetailledNodeRun: true,
+		})
+		if errWR != nil {
+			log.Warning(ctx, "workflowSendEvent> Cannot load workflow run %d: %s", m.WorkflowRunID, errWR)
+			continue
+		}
+		event.PublishWorkflowNodeMutex(ctx, m, *wr, proj.Key)
+	}
 }
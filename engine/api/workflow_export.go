@@ -76,7 +76,12 @@ func (api *API) getWorkflowPullHandler() service.Handler {
 			return sdk.WrapError(err, "unable to load projet")
 		}
 
-		pull, err := workflow.Pull(ctx, api.mustDB(), api.Cache, *proj, name, project.EncryptWithBuiltinKey, opts...)
+		encryptFunc, err := api.exportEncryptFunc(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		pull, err := workflow.Pull(ctx, api.mustDB(), api.Cache, *proj, name, encryptFunc, opts...)
 		if err != nil {
 			return err
 		}
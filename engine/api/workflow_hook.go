@@ -7,6 +7,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
@@ -296,6 +297,132 @@ func (api *API) postWorkflowJobHookCallbackHandler() service.Handler {
 	}
 }
 
+func (api *API) postWorkflowHookSecretHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		uuid := vars["uuid"]
+
+		overlap, err := requestWebHookSecretOverlap(r)
+		if err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), key,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+			project.LoadOptions.WithPipelines,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithIntegrations,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		oldW, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, name, workflow.LoadOptions{WithIntegrations: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", name)
+		}
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, name, workflow.LoadOptions{WithIntegrations: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", name)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		if err := workflow.RotateRepositoryWebHookSecret(ctx, tx, api.Cache, *p, wf, uuid, overlap); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		wf1, err := workflow.LoadByID(ctx, api.mustDB(), api.Cache, *p, wf.ID, workflow.LoadOptions{WithIntegrations: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow")
+		}
+		event.PublishWorkflowUpdate(ctx, p.Key, *wf1, *oldW, getAPIConsumer(ctx))
+
+		h, has := wf1.WorkflowData.GetHooks()[uuid]
+		if !has {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+		delete(h.Config, sdk.HookConfigWebHookSecret)
+		delete(h.Config, sdk.HookConfigWebHookPreviousSecret)
+		return service.WriteJSON(w, h, http.StatusOK)
+	}
+}
+
+// postWorkflowHookPathFiltersCheckHandler returns the files changed by a commit in the repository of a repository
+// webhook, and the ones that match the path filters of the hook or the path filters given in the request.
+func (api *API) postWorkflowHookPathFiltersCheckHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		uuid := vars["uuid"]
+
+		var req sdk.HookPathFiltersCheckRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if req.Hash == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing commit hash")
+		}
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, name, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", name)
+		}
+
+		h, has := wf.WorkflowData.GetHooks()[uuid]
+		if !has {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+		if h.HookModelName != sdk.RepositoryWebHookModelName {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "only the path filters of a repository webhook can be checked")
+		}
+
+		var filters []string
+		if req.PathFilters != "" {
+			filters, err = sdk.ParseHookPathFiltersValue(req.PathFilters)
+		} else {
+			filters, err = sdk.ParseHookPathFilters(h.Config)
+		}
+		if err != nil {
+			return err
+		}
+
+		files, err := workflow.HookChangedFiles(ctx, api.mustDB(), api.Cache, *p, *h, req.Base, req.Hash)
+		if err != nil {
+			return err
+		}
+
+		res := sdk.HookPathFiltersCheck{
+			PathFilters:  filters,
+			ChangedFiles: files,
+			MatchedFiles: files,
+			Match:        true,
+		}
+		if len(filters) > 0 {
+			res.MatchedFiles = sdk.MatchHookPathFilters(filters, files)
+			res.Match = len(res.MatchedFiles) > 0
+		}
+
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
 func (api *API) getWorkflowJobHookDetailsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -75,6 +75,64 @@ func (api *API) postWorkflowPreviewHandler() service.Handler {
 	}
 }
 
+// checkWorkflowImportIntegrations returns an error if the workflow to import references project integrations that are
+// missing or that the consumer is not allowed to use. Parsing errors are returned later by the import.
+func (api *API) checkWorkflowImportIntegrations(ctx context.Context, proj sdk.Project, ew exportentities.Workflow) error {
+	wf, err := workflow.Parse(ctx, proj, ew)
+	if err != nil {
+		return nil
+	}
+	report, err := workflow.CheckIntegrations(api.mustDB(), proj, *wf, getAPIConsumer(ctx))
+	if err != nil {
+		return err
+	}
+	return report.IsValid()
+}
+
+func (api *API) postWorkflowPreviewIntegrationsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return sdk.NewError(sdk.ErrWrongRequest, err)
+		}
+		defer r.Body.Close()
+
+		contentType := r.Header.Get("Content-Type")
+		if contentType == "" {
+			contentType = http.DetectContentType(body)
+		}
+		format, err := exportentities.GetFormatFromContentType(contentType)
+		if err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), key, project.LoadOptions.WithIntegrations)
+		if err != nil {
+			return sdk.WrapError(err, "unable load project")
+		}
+
+		ew, err := exportentities.UnmarshalWorkflow(body, format)
+		if err != nil {
+			return sdk.NewError(sdk.ErrWrongRequest, err)
+		}
+
+		wf, err := workflow.Parse(ctx, *proj, ew)
+		if err != nil {
+			return sdk.WrapError(err, "unable to parse workflow %s", ew.GetName())
+		}
+
+		report, err := workflow.CheckIntegrations(api.mustDB(), *proj, *wf, getAPIConsumer(ctx))
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, report, http.StatusOK)
+	}
+}
+
 func (api *API) postWorkflowImportHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -113,6 +171,10 @@ func (api *API) postWorkflowImportHandler() service.Handler {
 			return sdk.WrapError(err, "unable load project")
 		}
 
+		if err := api.checkWorkflowImportIntegrations(ctx, *proj, ew); err != nil {
+			return err
+		}
+
 		tx, err := api.mustDB().Begin()
 		if err != nil {
 			return sdk.WrapError(err, "unable to start transaction")
@@ -134,7 +196,7 @@ func (api *API) postWorkflowImportHandler() service.Handler {
 			}
 		}
 
-		wrkflw, msgList, globalError := workflow.ParseAndImport(ctx, tx, api.Cache, *proj, wf, ew, getAPIConsumer(ctx), workflow.ImportOptions{Force: force})
+		wrkflw, msgList, globalError := workflow.ParseAndImport(ctx, tx, api.Cache, *proj, wf, ew, getAPIConsumer(ctx), workflow.ImportOptions{Force: force, Message: r.FormValue("message")})
 		msgListString := translate(r, msgList)
 		if globalError != nil {
 			if len(msgListString) != 0 {
@@ -220,13 +282,17 @@ func (api *API) putWorkflowImportHandler() service.Handler {
 			return sdk.NewErrorFrom(sdk.ErrForbidden, "can't edit a workflow that is ascode")
 		}
 
+		if err := api.checkWorkflowImportIntegrations(ctx, *proj, ew); err != nil {
+			return err
+		}
+
 		tx, err := api.mustDB().Begin()
 		if err != nil {
 			return sdk.WrapError(err, "unable to start transaction")
 		}
 		defer tx.Rollback() //nolint
 
-		wrkflw, msgList, globalError := workflow.ParseAndImport(ctx, tx, api.Cache, *proj, wf, ew, u, workflow.ImportOptions{Force: true, WorkflowName: wfName})
+		wrkflw, msgList, globalError := workflow.ParseAndImport(ctx, tx, api.Cache, *proj, wf, ew, u, workflow.ImportOptions{Force: true, WorkflowName: wfName, Message: r.FormValue("message")})
 		msgListString := translate(r, msgList)
 		if globalError != nil {
 			if len(msgListString) != 0 {
@@ -285,6 +351,12 @@ func (api *API) postWorkflowPushHandler() service.Handler {
 				Force:           FormBool(r, "force"),
 			}
 		}
+		if message := r.FormValue("message"); message != "" {
+			if pushOptions == nil {
+				pushOptions = &workflow.PushOption{IsDefaultBranch: true}
+			}
+			pushOptions.Message = message
+		}
 
 		u := getAPIConsumer(ctx)
 
@@ -319,6 +391,9 @@ func (api *API) postWorkflowPushHandler() service.Handler {
 		if err != nil {
 			return err
 		}
+		if err := api.checkWorkflowImportIntegrations(ctx, *proj, data.Workflow); err != nil {
+			return err
+		}
 		allMsg, wrkflw, oldWrkflw, err := workflow.Push(ctx, db, api.Cache, proj, data, pushOptions, u, project.DecryptWithBuiltinKey)
 		if err != nil {
 			return err
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/jobmetrics"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// postWorkflowJobMetricsHandler forwards metrics pushed by a job to the metrics integration of its project with the
+// labels of the run, it is called by the worker.
+func (api *API) postWorkflowJobMetricsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		var m sdk.JobMetrics
+		if err := service.UnmarshalBody(r, &m); err != nil {
+			return err
+		}
+		if err := m.IsValid(); err != nil {
+			return err
+		}
+
+		jobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load job %d", id)
+		}
+
+		pp, err := loadProjectMetricsIntegration(api.mustDB(), jobRun.ProjectID, m.Integration)
+		if err != nil {
+			return err
+		}
+
+		labels := map[string]string{
+			sdk.JobMetricLabelProject:  sdk.ParameterValue(jobRun.Parameters, "cds.project"),
+			sdk.JobMetricLabelWorkflow: sdk.ParameterValue(jobRun.Parameters, "cds.workflow"),
+			sdk.JobMetricLabelRun:      sdk.ParameterValue(jobRun.Parameters, "cds.run.number"),
+			sdk.JobMetricLabelNode:     sdk.ParameterValue(jobRun.Parameters, "cds.node"),
+			sdk.JobMetricLabelJob:      jobRun.Job.Action.Name,
+		}
+		return jobmetrics.Push(ctx, pp.Config, labels, m.Metrics)
+	}
+}
+
+// loadProjectMetricsIntegration returns the metrics integration of a project with clear passwords. If no name is
+// given the project should have only one metrics integration.
+func loadProjectMetricsIntegration(db gorp.SqlExecutor, projectID int64, name string) (sdk.ProjectIntegration, error) {
+	pps, err := integration.LoadIntegrationsByProjectIDWithClearPassword(db, projectID)
+	if err != nil {
+		return sdk.ProjectIntegration{}, err
+	}
+
+	var found []sdk.ProjectIntegration
+	for _, pp := range pps {
+		if pp.Model.Name != sdk.MetricsIntegrationModel || (name != "" && pp.Name != name) {
+			continue
+		}
+		found = append(found, pp)
+	}
+	switch {
+	case len(found) == 0 && name != "":
+		return sdk.ProjectIntegration{}, sdk.NewErrorFrom(sdk.ErrNotFound, "cannot find metrics integration %s", name)
+	case len(found) == 0:
+		return sdk.ProjectIntegration{}, sdk.NewErrorFrom(sdk.ErrNotFound, "project has no %s integration", sdk.MetricsIntegrationModel)
+	case len(found) > 1:
+		return sdk.ProjectIntegration{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "project has many %s integrations, the name of the integration should be given", sdk.MetricsIntegrationModel)
+	}
+
+	pp := found[0]
+	if err := integration.InterpolateConfig(db, &pp); err != nil {
+		return sdk.ProjectIntegration{}, err
+	}
+	return pp, nil
+}
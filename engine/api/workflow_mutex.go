@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowMutexesHandler returns, for each node of a workflow with a mutex, the node run that holds the mutex
+// and the node runs waiting for it.
+func (api *API) getWorkflowMutexesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		proj, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *proj, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s/%s", key, name)
+		}
+
+		mutexes, err := workflow.LoadNodeMutexes(api.mustDB(), *wf)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, mutexes, http.StatusOK)
+	}
+}
+
+// postWorkflowMutexReleaseHandler starts the next node run waiting for the mutex of a node, even if the mutex
+// is still held by another node run.
+func (api *API) postWorkflowMutexReleaseHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		nodeName := vars["nodeName"]
+
+		proj, err := project.Load(api.mustDB(), key, project.LoadOptions.WithVariables)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *proj, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s/%s", key, name)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		report, err := workflow.ReleaseNodeMutex(ctx, tx, api.Cache, *proj, *wf, nodeName)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		go WorkflowSendEvent(context.Background(), api.mustDB(), api.Cache, *proj, report)
+
+		mutexes, err := workflow.LoadNodeMutexes(api.mustDB(), *wf)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, mutexes, http.StatusOK)
+	}
+}
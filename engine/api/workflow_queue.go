@@ -13,12 +13,14 @@ import (
 
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/cost"
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/api/metrics"
 	"github.com/ovh/cds/engine/api/notification"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/queue"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/api/worker"
@@ -26,6 +28,7 @@ import (
 	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -66,13 +69,14 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 		}
 
 		// Load worker model
-		var workerModelName string
+		var workerModelName, workerModelFlavor string
 		if wk.ModelID != nil {
 			wm, err := workermodel.LoadByID(api.mustDB(), *wk.ModelID)
 			if err != nil {
 				return sdk.WithStack(sdk.ErrNoWorkerModel)
 			}
 			workerModelName = wm.Name
+			workerModelFlavor = wm.GetFlavor()
 		}
 
 		// Load job run
@@ -80,6 +84,9 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "cannot load job nodeJobRunID: %d", id)
 		}
+		if err := api.checkJobMaintenanceWindow(ctx, *pbj); err != nil {
+			return err
+		}
 
 		observability.Current(ctx,
 			observability.Tag(observability.TagWorkflowNodeJobRun, id),
@@ -92,8 +99,20 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrForbidden, "worker %s (%s) is not authorized to take this job:%d execGroups:%+v", wk.Name, workerModelName, id, pbj.ExecGroups)
 		}
 
+		// Checks that the worker binary supports the features used by the job, instead of failing later on an unknown handler
+		if err := sdk.CheckWorkerVersion(wk.Version, pbj.WorkerFeatures); err != nil {
+			infos := []sdk.SpawnInfo{{
+				RemoteTime: getRemoteTime(ctx),
+				Message:    sdk.SpawnMsg{ID: sdk.MsgSpawnInfoWorkerVersionOutdated.ID, Args: []interface{}{wk.Name, sdk.ExtractHTTPError(err, "").From}},
+			}}
+			if errI := workflow.AddSpawnInfosNodeJobRun(api.mustDB(), pbj.WorkflowNodeRunID, id, infos); errI != nil {
+				log.Warning(ctx, "postTakeWorkflowJobHandler> cannot add spawn infos on job %d: %v", id, errI)
+			}
+			return err
+		}
+
 		pbji := &sdk.WorkflowNodeJobRunData{}
-		report, err := takeJob(ctx, api.mustDB, api.Cache, p, id, workerModelName, pbji, wk, hatcheryName)
+		report, err := takeJob(ctx, api.mustDB, api.Cache, p, id, workerModelName, workerModelFlavor, pbji, wk, hatcheryName)
 		if err != nil {
 			return sdk.WrapError(err, "cannot takeJob nodeJobRunID:%d", id)
 		}
@@ -105,7 +124,7 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 	}
 }
 
-func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, id int64, workerModel string, wnjri *sdk.WorkflowNodeJobRunData, wk *sdk.Worker, hatcheryName string) (*workflow.ProcessorReport, error) {
+func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, id int64, workerModel, workerModelFlavor string, wnjri *sdk.WorkflowNodeJobRunData, wk *sdk.Worker, hatcheryName string) (*workflow.ProcessorReport, error) {
 	// Start a tx
 	tx, errBegin := dbFunc().Begin()
 	if errBegin != nil {
@@ -156,6 +175,24 @@ func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store,
 		return nil, sdk.WrapError(err, "Unable to load workflow run")
 	}
 
+	// Start the resource usage of the job, used to compute the cost of the run
+	if err := cost.InsertUsage(tx, sdk.WorkflowNodeJobRunUsage{
+		ProjectID:            p.ID,
+		WorkflowID:           workflowRun.WorkflowID,
+		WorkflowName:         workflowRun.Workflow.Name,
+		WorkflowRunID:        workflowRun.ID,
+		RunNumber:            workflowRun.Number,
+		WorkflowNodeRunID:    noderun.ID,
+		WorkflowNodeJobRunID: job.ID,
+		NodeName:             noderun.WorkflowNodeName,
+		JobName:              job.Job.Action.Name,
+		Model:                workerModel,
+		Flavor:               workerModelFlavor,
+		Start:                job.Start,
+	}); err != nil {
+		return nil, err
+	}
+
 	// Load the secrets
 	pv, err := project.LoadAllVariablesWithDecrytion(tx, p.ID)
 	if err != nil {
@@ -180,6 +217,13 @@ func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store,
 	wnjri.Secrets = append(wnjri.Secrets, secretsKeys...)
 	wnjri.NodeJobRun.Parameters = append(wnjri.NodeJobRun.Parameters, params...)
 
+	params, secretsRegistries, err := workflow.LoadNodeJobRunDockerRegistries(ctx, tx, p, pv, job)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load docker registries")
+	}
+	wnjri.Secrets = append(wnjri.Secrets, secretsRegistries...)
+	wnjri.NodeJobRun.Parameters = append(wnjri.NodeJobRun.Parameters, params...)
+
 	if err := tx.Commit(); err != nil {
 		return nil, sdk.WithStack(err)
 	}
@@ -203,6 +247,25 @@ func (api *API) postBookWorkflowJobHandler() service.Handler {
 			return err
 		}
 
+		p, err := project.LoadProjectByNodeJobRunID(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project by nodeJobRunID: %d", id)
+		}
+		if err := api.checkProjectQuota(ctx, *p, sdk.ProjectQuotaConcurrentJobs, 1); err != nil {
+			return err
+		}
+
+		job, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load job nodeJobRunID: %d", id)
+		}
+		if err := api.checkJobMaintenanceWindow(ctx, *job); err != nil {
+			return err
+		}
+		if job.WaitingRetry(time.Now()) {
+			return sdk.NewErrorFrom(sdk.ErrJobWaitingRetry, "job %d can't be booked before %s", id, job.RetryAfter.Format(time.RFC3339))
+		}
+
 		if _, err := workflow.BookNodeJobRun(ctx, api.Cache, id, s); err != nil {
 			return sdk.WrapError(err, "job already booked")
 		}
@@ -335,6 +398,67 @@ func (api *API) postSpawnInfosWorkflowJobHandler() service.Handler {
 	}
 }
 
+// postWorkflowJobInfraErrorHandler is called by a hatchery that failed to start a worker for a job because of the
+// infrastructure. The job is requeued with a delay, or failed if it reached its maximum number of attempts.
+func (api *API) postWorkflowJobInfraErrorHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		if ok := isHatchery(ctx); !ok {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		var infraErr sdk.JobInfraError
+		if err := service.UnmarshalBody(r, &infraErr); err != nil {
+			return err
+		}
+		if err := infraErr.IsValid(); err != nil {
+			return err
+		}
+
+		proj, err := project.LoadProjectByNodeJobRunID(ctx, api.mustDB(), api.Cache, id, project.LoadOptions.WithVariables)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project by nodeJobRunID: %d", id)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		job, err := workflow.LoadAndLockNodeJobRunSkipLocked(ctx, tx, api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node run job %d", id)
+		}
+		if job.Status != sdk.StatusWaiting {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "job %d is %s", id, job.Status)
+		}
+
+		requeued, err := workflow.RequeueNodeJobRunOnInfraError(ctx, tx, api.Cache, job, infraErr)
+		if err != nil {
+			return err
+		}
+		if requeued {
+			return sdk.WithStack(tx.Commit())
+		}
+
+		report, err := workflow.UpdateNodeJobRunStatus(ctx, tx, api.Cache, *proj, job, sdk.StatusFail)
+		if err != nil {
+			return sdk.WrapError(err, "cannot update NodeJobRun %d status", job.ID)
+		}
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		go WorkflowSendEvent(context.Background(), api.mustDB(), api.Cache, *proj, report)
+		return nil
+	}
+}
+
 func (api *API) postWorkflowJobResultHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		id, err := requestVarInt(r, "permJobID")
@@ -486,11 +610,70 @@ func postJobResult(ctx context.Context, dbFunc func(context.Context) *gorp.DbMap
 	}
 	// ^ build variables are now updated on job run and on node
 
+	// Record the secrets read by the job
+	for _, u := range res.SecretUsages {
+		if err := u.IsValid(); err != nil {
+			log.Warning(ctx, "postJobResult> invalid secret usage on job %d: %v", job.ID, err)
+			continue
+		}
+		usage := sdk.WorkflowRunSecretUsage{
+			WorkflowRunID:        node.WorkflowRunID,
+			WorkflowNodeRunID:    node.ID,
+			WorkflowNodeJobRunID: job.ID,
+			SubNumber:            node.SubNumber,
+			NodeName:             node.WorkflowNodeName,
+			JobName:              job.Job.Action.Name,
+			StepOrder:            u.StepOrder,
+			Name:                 u.Name,
+			Type:                 u.Type,
+			Source:               u.Source,
+		}
+		if err := workflow.InsertRunSecretUsage(tx, &usage); err != nil {
+			return nil, err
+		}
+	}
+
+	// Record the environment of the job
+	if res.Fingerprint != nil {
+		fingerprint := sdk.WorkflowRunJobFingerprint{
+			WorkflowRunID:        node.WorkflowRunID,
+			WorkflowNodeRunID:    node.ID,
+			WorkflowNodeJobRunID: job.ID,
+			Number:               node.Number,
+			SubNumber:            node.SubNumber,
+			NodeName:             node.WorkflowNodeName,
+			JobName:              job.Job.Action.Name,
+			Status:               res.Status,
+			Fingerprint:          *res.Fingerprint,
+		}
+		if err := workflow.InsertRunJobFingerprint(tx, &fingerprint); err != nil {
+			return nil, err
+		}
+	}
+
 	//Update worker status
 	if err := worker.SetStatus(tx, wr.ID, sdk.StatusWaiting); err != nil {
 		return nil, sdk.WrapError(err, "cannot update worker %s status", wr.ID)
 	}
 
+	// A job that failed because of the infrastructure is requeued until it reaches its maximum number of attempts
+	if res.Status == sdk.StatusFail && res.InfraError != "" {
+		infraErr := sdk.JobInfraError{Reason: res.InfraError, Message: wr.Name}
+		if err := infraErr.IsValid(); err != nil {
+			return nil, err
+		}
+		requeued, err := workflow.RequeueNodeJobRunOnInfraError(ctx, tx, nil, job, infraErr)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot requeue NodeJobRun %d", job.ID)
+		}
+		if requeued {
+			if err := tx.Commit(); err != nil {
+				return nil, sdk.WrapError(err, "cannot commit tx")
+			}
+			return new(workflow.ProcessorReport), nil
+		}
+	}
+
 	// Update action status
 	log.Debug("postJobResult> Updating %d to %s in queue", job.ID, res.Status)
 	newDBFunc := func() *gorp.DbMap {
@@ -772,19 +955,132 @@ func (api *API) getWorkflowJobQueueHandler() service.Handler {
 			filter.ModelType = []string{modelType}
 		}
 
-		var jobs []sdk.WorkflowNodeJobRun
-		// If the consumer is a worker, a hatchery or a non maintainer user, filter the job by its groups
-		if isW || isS || !isMaintainer(ctx) {
-			jobs, err = workflow.LoadNodeJobRunQueueByGroupIDs(ctx, api.mustDB(), api.Cache, filter, getAPIConsumer(ctx).GetGroupIDs())
+		jobs, err := api.loadScheduledWorkflowJobQueue(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if api.Config.Queue.Scheduler.Enabled {
+			w.Header().Set(cdsclient.ResponseQueueScheduledHeader, "true")
+		}
+
+		// Estimates are only given to users, hatcheries and workers don't need them
+		if !isW && !isS {
+			if err := api.setWorkflowJobQueueEstimates(ctx, jobs); err != nil {
+				return err
+			}
+		}
+
+		return service.WriteJSON(w, jobs, http.StatusOK)
+	}
+}
+
+// loadScheduledWorkflowJobQueue loads the queue and, if the fair scheduler is enabled, sorts it with the
+// scheduler. The limit of the filter is applied after scheduling. Jobs held by a maintenance window are hidden
+// to workers and hatcheries.
+func (api *API) loadScheduledWorkflowJobQueue(ctx context.Context, filter workflow.QueueFilter) ([]sdk.WorkflowNodeJobRun, error) {
+	if !api.Config.Queue.Scheduler.Enabled {
+		jobs, err := api.loadWorkflowJobQueue(ctx, filter)
+		if err != nil {
+			return nil, sdk.WrapError(err, "Unable to load queue")
+		}
+		if isWorker(ctx) || isService(ctx) {
+			jobs, _, err = api.filterMaintenanceWindowJobs(ctx, jobs)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return jobs, nil
+	}
+
+	limit := filter.Limit
+	filter.Limit = nil
+	jobs, err := api.loadWorkflowJobQueue(ctx, filter)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Unable to load queue")
+	}
+	if isWorker(ctx) || isService(ctx) {
+		jobs, _, err = api.filterMaintenanceWindowJobs(ctx, jobs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	jobs, err = queue.ScheduleJobs(ctx, api.mustDB(), api.Config.Queue.Scheduler, jobs)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to schedule queue")
+	}
+	if limit != nil && *limit > 0 && len(jobs) > *limit {
+		jobs = jobs[:*limit]
+	}
+	return jobs, nil
+}
+
+// setWorkflowJobQueueEstimates sets the position in the scheduling order and the estimated start and end of given
+// waiting jobs. Positions are computed on the whole queue, not only on the jobs visible by the consumer.
+func (api *API) setWorkflowJobQueueEstimates(ctx context.Context, jobs []sdk.WorkflowNodeJobRun) error {
+	var hasWaiting bool
+	for i := range jobs {
+		if jobs[i].Status == sdk.StatusWaiting {
+			hasWaiting = true
+			break
+		}
+	}
+	if !hasWaiting {
+		return nil
+	}
+
+	filter := workflow.NewQueueFilter()
+	filter.Statuses = []string{sdk.StatusWaiting, sdk.StatusBuilding}
+	all, err := workflow.LoadNodeJobRunQueue(ctx, api.mustDB(), api.Cache, filter)
+	if err != nil {
+		return sdk.WrapError(err, "unable to load queue")
+	}
+
+	var waiting, building []sdk.WorkflowNodeJobRun
+	nodeRunIDs := make([]int64, 0, len(all))
+	for _, j := range all {
+		nodeRunIDs = append(nodeRunIDs, j.WorkflowNodeRunID)
+		if j.Status == sdk.StatusBuilding {
+			building = append(building, j)
 		} else {
-			jobs, err = workflow.LoadNodeJobRunQueue(ctx, api.mustDB(), api.Cache, filter)
+			waiting = append(waiting, j)
 		}
+	}
+	if api.Config.Queue.Scheduler.Enabled {
+		waiting, err = queue.ScheduleJobs(ctx, api.mustDB(), api.Config.Queue.Scheduler, waiting)
 		if err != nil {
-			return sdk.WrapError(err, "Unable to load queue")
+			return sdk.WrapError(err, "unable to schedule queue")
 		}
+	}
 
-		return service.WriteJSON(w, jobs, http.StatusOK)
+	durations, err := queue.LoadJobDurations(ctx, api.mustDB(), nodeRunIDs)
+	if err != nil {
+		return err
+	}
+
+	estimates := make(map[int64]sdk.WorkflowNodeJobRun, len(waiting))
+	for _, j := range queue.Estimate(waiting, building, durations, time.Now()) {
+		estimates[j.ID] = j
+	}
+	for i := range jobs {
+		e, has := estimates[jobs[i].ID]
+		if !has {
+			continue
+		}
+		jobs[i].QueuePosition = e.QueuePosition
+		jobs[i].EstimatedDuration = e.EstimatedDuration
+		jobs[i].EstimatedStart = e.EstimatedStart
+		jobs[i].EstimatedDone = e.EstimatedDone
+	}
+	return nil
+}
+
+// loadWorkflowJobQueue loads the queue, if the consumer is a worker, a hatchery
+// or a non maintainer user, the jobs are filtered by its groups.
+func (api *API) loadWorkflowJobQueue(ctx context.Context, filter workflow.QueueFilter) ([]sdk.WorkflowNodeJobRun, error) {
+	if isWorker(ctx) || isService(ctx) || !isMaintainer(ctx) {
+		return workflow.LoadNodeJobRunQueueByGroupIDs(ctx, api.mustDB(), api.Cache, filter, getAPIConsumer(ctx).GetGroupIDs())
 	}
+	return workflow.LoadNodeJobRunQueue(ctx, api.mustDB(), api.Cache, filter)
 }
 
 func getModelTypeRatioService(ctx context.Context, r *http.Request) (string, *int, error) {
@@ -3,6 +3,8 @@ package api
 import (
 	"context"
 	"encoding/base64"
+	"io"
+	"io/ioutil"
 	"mime"
 	"net/http"
 	"strconv"
@@ -11,7 +13,9 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/cost"
 	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
@@ -196,6 +200,14 @@ func (api *API) postWorkflowJobArtifactHandler() service.Handler {
 			perm, _ = strconv.ParseUint(permStr, 10, 32)
 		}
 
+		p, err := project.Load(api.mustDB(), vars[permProjectKey])
+		if err != nil {
+			return err
+		}
+		if err := api.checkProjectQuota(ctx, *p, sdk.ProjectQuotaArtifactStorage, size); err != nil {
+			return err
+		}
+
 		tag, err := base64.RawURLEncoding.DecodeString(ref)
 		if err != nil {
 			return sdk.WrapError(err, "cannot decode ref")
@@ -232,6 +244,17 @@ func (api *API) postWorkflowJobArtifactHandler() service.Handler {
 				return sdk.WrapError(err, "cannot open file")
 			}
 
+			if err := api.scanArtifact(ctx, p.ID, nodeJobRunID, &art, func() (io.ReadCloser, error) {
+				return ioutil.NopCloser(file), nil
+			}); err != nil {
+				file.Close()
+				return err
+			}
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				file.Close()
+				return sdk.WrapError(err, "cannot rewind file")
+			}
+
 			objectPath, err := storageDriver.Store(&art, file)
 			if err != nil {
 				file.Close()
@@ -247,6 +270,10 @@ func (api *API) postWorkflowJobArtifactHandler() service.Handler {
 			_ = storageDriver.Delete(ctx, &art)
 			return sdk.WrapError(err, "Cannot update workflow node run")
 		}
+
+		if err := cost.AddUsageBytes(api.mustDB(), nodeJobRunID, art.Size, 0); err != nil {
+			log.Error(ctx, "postWorkflowJobArtifactHandler> %v", err)
+		}
 		return nil
 	}
 }
@@ -297,12 +324,32 @@ func (api *API) postWorkflowJobArtifactWithTempURLCallbackHandler() service.Hand
 			art.ProjectIntegrationID = &id
 		}
 
+		p, err := project.Load(api.mustDB(), vars[permProjectKey])
+		if err != nil {
+			return err
+		}
+		var nodeJobRunID int64
+		if wk := getAPIConsumer(ctx).Worker; wk.JobRunID != nil {
+			nodeJobRunID = *wk.JobRunID
+		}
+		if err := api.scanArtifact(ctx, p.ID, nodeJobRunID, &art, func() (io.ReadCloser, error) {
+			return storageDriver.Fetch(ctx, &art)
+		}); err != nil {
+			return err
+		}
+
 		nodeRun.Artifacts = append(nodeRun.Artifacts, art)
 		if err := workflow.InsertArtifact(api.mustDB(), &art); err != nil {
 			_ = storageDriver.Delete(ctx, &art)
 			return sdk.WrapError(err, "cannot update workflow node run")
 		}
 
+		if wk := getAPIConsumer(ctx).Worker; wk.JobRunID != nil {
+			if err := cost.AddUsageBytes(api.mustDB(), *wk.JobRunID, art.Size, 0); err != nil {
+				log.Error(ctx, "postWorkflowJobArtifactWithTempURLCallbackHandler> %v", err)
+			}
+		}
+
 		return nil
 	}
 }
@@ -357,6 +404,14 @@ func (api *API) postWorkflowJobArtifacWithTempURLHandler() service.Handler {
 			return sdk.WrapError(err, "cannot decode ref")
 		}
 
+		p, err := project.Load(api.mustDB(), vars[permProjectKey])
+		if err != nil {
+			return err
+		}
+		if err := api.checkProjectQuota(ctx, *p, sdk.ProjectQuotaArtifactStorage, art.Size); err != nil {
+			return err
+		}
+
 		art.WorkflowID = nodeRun.WorkflowRunID
 		art.WorkflowNodeRunID = nodeRun.ID
 		art.DownloadHash = hash
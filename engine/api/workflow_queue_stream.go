@@ -0,0 +1,210 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ovh/cds/engine/api/queue"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	queueStreamHeartbeatDelay = 10 * time.Second
+	queueStreamReloadDelay    = time.Second
+)
+
+// getWorkflowJobQueueStreamHandler streams waiting jobs as server-sent events. Waiting jobs are sent on connection
+// then new jobs are pushed when they are queued, instead of polling the queue. The cursor of the last received
+// event can be given with the Last-Event-ID header to resume the stream. A heartbeat is sent periodically.
+func (api *API) getWorkflowJobQueueStreamHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		// Make sure that the writer supports flushing.
+		f, ok := w.(http.Flusher)
+		if !ok {
+			return sdk.WithStack(fmt.Errorf("streaming unsupported"))
+		}
+
+		modelType, ratioService, err := getModelTypeRatioService(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		var cursor int64
+		if c := r.Header.Get("Last-Event-ID"); c != "" {
+			cursor, err = strconv.ParseInt(c, 10, 64)
+			if err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid cursor %s", c)
+			}
+		}
+
+		filter := func() workflow.QueueFilter {
+			filter := workflow.NewQueueFilter()
+			filter.RatioService = ratioService
+			filter.Rights = sdk.PermissionReadExecute
+			if modelType != "" {
+				filter.ModelType = []string{modelType}
+			}
+			return filter
+		}
+
+		// Subscribe to job run events before loading the queue to not miss a job
+		events := make(chan sdk.Event, 100)
+		subscriber := eventsBrokerQueueSubscribe{UUID: sdk.UUID(), events: events}
+		api.eventsBroker.chanAddQueueClient <- subscriber
+		defer func() { api.eventsBroker.chanRemoveQueueClient <- subscriber.UUID }()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+
+		send := func(e sdk.WorkflowQueueStreamEvent) error {
+			btes, err := json.Marshal(e)
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\ndata: %s\n\n", e.Cursor, btes); err != nil {
+				return sdk.WrapError(err, "unable to write to client")
+			}
+			f.Flush()
+			return nil
+		}
+
+		streamCursor := newQueueStreamCursor(cursor)
+		sendQueue := func() error {
+			all, err := api.loadWorkflowJobQueue(ctx, filter())
+			if err != nil {
+				return sdk.WrapError(err, "unable to load queue")
+			}
+			jobs, _, err := api.filterMaintenanceWindowJobs(ctx, all)
+			if err != nil {
+				return err
+			}
+			if api.Config.Queue.Scheduler.Enabled {
+				jobs, err = queue.ScheduleJobs(ctx, api.mustDB(), api.Config.Queue.Scheduler, jobs)
+				if err != nil {
+					return sdk.WrapError(err, "unable to schedule queue")
+				}
+			}
+			cursor := streamCursor.last
+			toSend := streamCursor.next(all, jobs)
+			for i := range toSend {
+				if toSend[i].ID > cursor {
+					cursor = toSend[i].ID
+				}
+				if err := send(sdk.WorkflowQueueStreamEvent{
+					Type:   sdk.WorkflowQueueStreamEventJob,
+					Cursor: cursor,
+					Job:    &toSend[i],
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if err := sendQueue(); err != nil {
+			return err
+		}
+
+		tickHeartbeat := time.NewTicker(queueStreamHeartbeatDelay)
+		defer tickHeartbeat.Stop()
+		tickReload := time.NewTicker(queueStreamReloadDelay)
+		defer tickReload.Stop()
+
+		var reload bool
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-r.Context().Done():
+				log.Debug("queue.stream: client disconnected")
+				return nil
+			case e := <-events:
+				var runJob sdk.EventRunWorkflowJob
+				if err := json.Unmarshal(e.Payload, &runJob); err != nil || runJob.Status != sdk.StatusWaiting {
+					continue
+				}
+				streamCursor.requeue(runJob.ID)
+				reload = true
+			case <-tickReload.C:
+				// Reload the queue at most once per tick for all received events
+				if !reload {
+					continue
+				}
+				reload = false
+				if err := sendQueue(); err != nil {
+					return err
+				}
+			case <-tickHeartbeat.C:
+				// Jobs not sent are checked again periodically as maintenance windows end and building jobs of a
+				// project over the scheduler limit are done without event
+				if streamCursor.hasPending() {
+					reload = true
+				}
+				if err := send(sdk.WorkflowQueueStreamEvent{
+					Type:   sdk.WorkflowQueueStreamEventHeartbeat,
+					Cursor: streamCursor.last,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// queueStreamCursor keeps track of the jobs sent on a queue stream. The cursor is the highest id of the sent jobs,
+// jobs under the cursor are only sent again if they were requeued or if they were not visible when the cursor passed
+// them (held by a maintenance window or over the scheduler limit of their project).
+type queueStreamCursor struct {
+	last    int64
+	pending map[int64]struct{}
+}
+
+func newQueueStreamCursor(last int64) *queueStreamCursor {
+	return &queueStreamCursor{last: last, pending: make(map[int64]struct{})}
+}
+
+// requeue marks a job that is waiting again to be sent again.
+func (c *queueStreamCursor) requeue(id int64) {
+	if id <= c.last {
+		c.pending[id] = struct{}{}
+	}
+}
+
+func (c *queueStreamCursor) hasPending() bool {
+	return len(c.pending) > 0
+}
+
+// next returns, in the given visible order, the visible jobs of the queue that should be sent then moves the cursor.
+// Jobs of the queue that are not visible are kept to be sent once visible.
+func (c *queueStreamCursor) next(all, visible []sdk.WorkflowNodeJobRun) []sdk.WorkflowNodeJobRun {
+	start := c.last
+	visibleIDs := make(map[int64]struct{}, len(visible))
+	var res []sdk.WorkflowNodeJobRun
+	for i := range visible {
+		visibleIDs[visible[i].ID] = struct{}{}
+		if _, ok := c.pending[visible[i].ID]; !ok && visible[i].ID <= start {
+			continue
+		}
+		res = append(res, visible[i])
+		if visible[i].ID > c.last {
+			c.last = visible[i].ID
+		}
+	}
+
+	c.pending = make(map[int64]struct{})
+	for i := range all {
+		if _, ok := visibleIDs[all[i].ID]; !ok {
+			c.pending[all[i].ID] = struct{}{}
+		}
+	}
+	return res
+}
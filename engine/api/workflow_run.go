@@ -2,11 +2,13 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -291,6 +293,21 @@ func (api *API) getWorkflowRunHandler() service.Handler {
 			return sdk.WrapError(err, "Unable to load workflow %s run number %d", name, number)
 		}
 
+		run.Annotations, err = workflow.LoadRunAnnotations(api.mustDB(), run.ID)
+		if err != nil {
+			return err
+		}
+
+		run.Results, err = workflow.LoadRunResults(api.mustDB(), run.ID)
+		if err != nil {
+			return err
+		}
+
+		run.Maintenance, err = api.loadWorkflowRunMaintenanceWindow(*run)
+		if err != nil {
+			return err
+		}
+
 		// Remove unused data
 		for i := range run.WorkflowNodeRuns {
 			for j := range run.WorkflowNodeRuns[i] {
@@ -771,6 +788,12 @@ func (api *API) getWorkflowNodeRunHandler() service.Handler {
 			return sdk.WrapError(err, "Unable to load last workflow run")
 		}
 
+		for i := range run.Stages {
+			if err := api.setWorkflowJobQueueEstimates(ctx, run.Stages[i].RunJobs); err != nil {
+				return err
+			}
+		}
+
 		run.Translate(r.Header.Get("Accept-Language"))
 		return service.WriteJSON(w, run, http.StatusOK)
 	}
@@ -855,6 +878,14 @@ func (api *API) postWorkflowRunHandler() service.Handler {
 			if !conditionsOK {
 				return sdk.WithStack(sdk.ErrConditionsNotOk)
 			}
+
+			pathFiltersOK, err := workflow.CheckHookPathFilters(ctx, api.mustDB(), api.Cache, *p, hook, opts.Hook.Payload)
+			if err != nil {
+				return err
+			}
+			if !pathFiltersOK {
+				return sdk.NewErrorFrom(sdk.ErrConditionsNotOk, "no changed file matches the path filters of the hook")
+			}
 		}
 
 		var wf *sdk.Workflow
@@ -879,11 +910,18 @@ func (api *API) postWorkflowRunHandler() service.Handler {
 					return sdk.WrapError(sdk.ErrWorkflowNodeNotFound, "unable to find node %d", id)
 				}
 
-				if !permission.AccessToWorkflowNode(ctx, api.mustDB(), &lastRun.Workflow, fromNode, getAPIConsumer(ctx), sdk.PermissionReadExecute) {
+				if !permission.AccessToWorkflowNode(ctx, api.mustDB(), &lastRun.Workflow, fromNode, getAPIConsumer(ctx), lastRun.Workflow.ExecutePermission(fromNode)) {
 					return sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %s", fromNode.Name)
 				}
 			}
 
+			if err := api.checkWorkflowRunPriority(ctx, name, lastRun.Priority, opts.Priority, vars); err != nil {
+				return err
+			}
+			if opts.Priority != "" {
+				lastRun.Priority = opts.Priority
+			}
+
 			lastRun.Status = sdk.StatusWaiting
 		} else {
 			var errWf error
@@ -900,10 +938,32 @@ func (api *API) postWorkflowRunHandler() service.Handler {
 			}
 
 			// Check node permission
-			if isService := isService(ctx); !isService && !permission.AccessToWorkflowNode(ctx, api.mustDB(), wf, &wf.WorkflowData.Node, getAPIConsumer(ctx), sdk.PermissionReadExecute) {
+			if isService := isService(ctx); !isService && !permission.AccessToWorkflowNode(ctx, api.mustDB(), wf, &wf.WorkflowData.Node, getAPIConsumer(ctx), wf.ExecutePermission(&wf.WorkflowData.Node)) {
 				return sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %s", wf.WorkflowData.Node.Name)
 			}
 
+			if err := api.checkProjectQuota(ctx, *p, sdk.ProjectQuotaRunsPerDay, 1); err != nil {
+				return err
+			}
+
+			// Check the payload of a manual run with the run form of the workflow
+			if opts.Manual != nil && wf.RunForm != nil && len(wf.RunForm.Parameters) > 0 {
+				payload, err := manualRunPayload(opts.Manual.Payload)
+				if err != nil {
+					return err
+				}
+				if err := wf.RunForm.Check(payload); err != nil {
+					return err
+				}
+				if len(payload) > 0 {
+					opts.Manual.Payload = payload
+				}
+			}
+
+			if err := api.checkWorkflowRunPriority(ctx, name, wf.Priority, opts.Priority, vars); err != nil {
+				return err
+			}
+
 			// CREATE WORKFLOW RUN
 			var errCreateRun error
 			lastRun, errCreateRun = workflow.CreateRun(api.mustDB(), wf, opts, c)
@@ -921,6 +981,24 @@ func (api *API) postWorkflowRunHandler() service.Handler {
 	}
 }
 
+// checkWorkflowRunPriority checks that the requested priority of a run is valid. Raising the priority of a run above
+// the one of its workflow requires the write permission on the workflow.
+func (api *API) checkWorkflowRunPriority(ctx context.Context, workflowName, current, requested string, vars map[string]string) error {
+	if requested == "" {
+		return nil
+	}
+	if !sdk.IsValidWorkflowPriority(requested) {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid priority %q, it should be one of %s", requested, strings.Join(sdk.WorkflowPriorities, ", "))
+	}
+	if sdk.WorkflowPriorityRank(requested) >= sdk.WorkflowPriorityRank(current) {
+		return nil
+	}
+	if err := api.checkWorkflowPermissions(ctx, workflowName, sdk.PermissionReadWriteExecute, vars); err != nil {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "you need the write permission on workflow %s to run it with priority %s", workflowName, requested)
+	}
+	return nil
+}
+
 func (api *API) initWorkflowRun(ctx context.Context, projKey string, wf *sdk.Workflow, wfRun *sdk.WorkflowRun, opts *sdk.WorkflowRunPostHandlerOption, u *sdk.AuthConsumer) {
 	var asCodeInfosMsg []sdk.Message
 	report := new(workflow.ProcessorReport)
@@ -1033,6 +1111,25 @@ func (api *API) initWorkflowRun(ctx context.Context, projKey string, wf *sdk.Wor
 	}, api.PanicDump())
 }
 
+// manualRunPayload returns the payload of a manual run as a map, the payload should be a JSON object.
+func manualRunPayload(payload interface{}) (map[string]interface{}, error) {
+	res := make(map[string]interface{})
+	if payload == nil {
+		return res, nil
+	}
+	if m, ok := payload.(map[string]interface{}); ok {
+		return m, nil
+	}
+	btes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if err := json.Unmarshal(btes, &res); err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "payload of the manual run should be a JSON object")
+	}
+	return res, nil
+}
+
 func failInitWorkflowRun(ctx context.Context, db *gorp.DbMap, wfRun *sdk.WorkflowRun, err error) *workflow.ProcessorReport {
 	report := new(workflow.ProcessorReport)
 
@@ -1078,6 +1175,9 @@ func (api *API) downloadworkflowArtifactDirectHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "Could not load artifact with hash %s", hash)
 		}
+		if art.IsQuarantined() {
+			return sdk.NewErrorFrom(sdk.ErrArtifactQuarantined, "artifact %s is infected: %s", art.Name, art.ScanReport)
+		}
 
 		w.Header().Add("Content-Type", "application/octet-stream")
 		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", art.Name))
@@ -1125,6 +1225,9 @@ func (api *API) getDownloadArtifactHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "cannot load artifacts")
 		}
+		if art.IsQuarantined() {
+			return sdk.NewErrorFrom(sdk.ErrArtifactQuarantined, "artifact %s is infected: %s", art.Name, art.ScanReport)
+		}
 
 		w.Header().Add("Content-Type", "application/octet-stream")
 		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", art.Name))
@@ -1197,6 +1300,11 @@ func (api *API) getWorkflowRunArtifactsHandler() service.Handler {
 				go func(art *sdk.WorkflowNodeRunArtifact) {
 					defer wg.Done()
 
+					// No temporary URL is given to download a quarantined artifact
+					if art.IsQuarantined() {
+						return
+					}
+
 					var integrationName string
 					if art.ProjectIntegrationID != nil && *art.ProjectIntegrationID > 0 {
 						projectIntegration, err := integration.LoadProjectIntegrationByID(api.mustDB(), *art.ProjectIntegrationID)
@@ -1360,6 +1468,99 @@ func (api *API) getWorkflowRunTagsHandler() service.Handler {
 	}
 }
 
+func (api *API) getWorkflowRunFormHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		proj, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project")
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *proj, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+
+		form := sdk.WorkflowRunForm{Parameters: []sdk.WorkflowRunFormParameter{}}
+		if wf.RunForm != nil && len(wf.RunForm.Parameters) > 0 {
+			form = *wf.RunForm
+		}
+
+		return service.WriteJSON(w, form, http.StatusOK)
+	}
+}
+
+// postWorkflowRunExplainHandler explains which nodes of a workflow would run for a hook event or a manual run request,
+// with the evaluation of their conditions. Nothing is executed.
+func (api *API) postWorkflowRunExplainHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		var opts sdk.WorkflowRunPostHandlerOption
+		if err := service.UnmarshalBody(r, &opts); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), key,
+			project.LoadOptions.WithVariables,
+			project.LoadOptions.WithFeatures(api.Cache),
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithApplicationVariables,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithPipelines,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project")
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, name, workflow.LoadOptions{
+			DeepPipeline:     true,
+			WithIntegrations: true,
+			WithTemplate:     true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+
+		// A manual run is explained for the current user
+		if opts.Hook == nil {
+			if opts.Manual == nil {
+				opts.Manual = &sdk.WorkflowNodeRunManual{}
+			}
+			c := getAPIConsumer(ctx)
+			opts.Manual.Username = c.GetUsername()
+			opts.Manual.Email = c.GetEmail()
+			opts.Manual.Fullname = c.GetFullname()
+
+			if wf.RunForm != nil && len(wf.RunForm.Parameters) > 0 {
+				payload, err := manualRunPayload(opts.Manual.Payload)
+				if err != nil {
+					return err
+				}
+				if err := wf.RunForm.Check(payload); err != nil {
+					return err
+				}
+				if len(payload) > 0 {
+					opts.Manual.Payload = payload
+				}
+			}
+		}
+
+		explain, err := workflow.ExplainRun(ctx, api.mustDB(), api.Cache, *p, wf, opts)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, explain, http.StatusOK)
+	}
+}
+
 func (api *API) postResyncVCSWorkflowRunHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		db := api.mustDB()
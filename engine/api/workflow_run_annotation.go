@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getWorkflowRunAnnotationsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		annotations, err := workflow.LoadRunAnnotations(api.mustDB(), wr.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, annotations, http.StatusOK)
+	}
+}
+
+// postWorkflowRunAnnotationHandler adds an annotation on a workflow run, an existing annotation with the same key
+// is replaced.
+func (api *API) postWorkflowRunAnnotationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		var a sdk.WorkflowRunAnnotation
+		if err := service.UnmarshalBody(r, &a); err != nil {
+			return err
+		}
+		if err := a.IsValid(); err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		if a.WorkflowNodeRunID != 0 {
+			if _, err := workflow.LoadNodeRun(api.mustDB(), key, name, number, a.WorkflowNodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true}); err != nil {
+				return err
+			}
+		}
+		a.WorkflowRunID = wr.ID
+		a.Author = getAPIConsumer(ctx).GetUsername()
+		if err := workflow.UpsertRunAnnotation(api.mustDB(), &a); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, a, http.StatusOK)
+	}
+}
+
+func (api *API) deleteWorkflowRunAnnotationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		if err := workflow.DeleteRunAnnotation(api.mustDB(), wr.ID, vars["annotationKey"]); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// postWorkflowJobAnnotationHandler adds an annotation on the workflow run of a job, it is called by the worker.
+func (api *API) postWorkflowJobAnnotationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		var a sdk.WorkflowRunAnnotation
+		if err := service.UnmarshalBody(r, &a); err != nil {
+			return err
+		}
+		if err := a.IsValid(); err != nil {
+			return err
+		}
+
+		nodeRun, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load node run of job %d", id)
+		}
+
+		a.WorkflowRunID = nodeRun.WorkflowRunID
+		a.WorkflowNodeRunID = nodeRun.ID
+		a.Author = getAPIConsumer(ctx).GetUsername()
+		if err := workflow.UpsertRunAnnotation(api.mustDB(), &a); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// badgeCacheMaxAge is the duration in seconds that a badge can be cached by clients.
+const badgeCacheMaxAge = 60
+
+var badgeColors = map[string]string{
+	"brightgreen": "#4c1",
+	"red":         "#e05d44",
+	"blue":        "#007ec6",
+	"orange":      "#fe7d37",
+	"lightgrey":   "#9f9f9f",
+}
+
+var badgeTemplate = template.Must(template.New("badge").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Message}}">
+<title>{{.Label}}: {{.Message}}</title>
+<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="{{.LabelWidth}}" height="20" fill="#555"/><rect x="{{.LabelWidth}}" width="{{.MessageWidth}}" height="20" fill="{{.Color}}"/><rect width="{{.Width}}" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="{{.LabelX}}" y="15" fill="#010101" fill-opacity=".3">{{.Label}}</text><text x="{{.LabelX}}" y="14">{{.Label}}</text>
+<text x="{{.MessageX}}" y="15" fill="#010101" fill-opacity=".3">{{.Message}}</text><text x="{{.MessageX}}" y="14">{{.Message}}</text>
+</g>
+</svg>
+`))
+
+// renderBadge returns the svg image for given badge, text width is approximated from the number of characters.
+func renderBadge(b sdk.WorkflowRunBadge) ([]byte, error) {
+	labelWidth := 10 + 7*len(b.Label)
+	messageWidth := 10 + 7*len(b.Message)
+	color, ok := badgeColors[b.Color]
+	if !ok {
+		color = badgeColors["lightgrey"]
+	}
+	var buf bytes.Buffer
+	if err := badgeTemplate.Execute(&buf, map[string]interface{}{
+		"Label":        b.Label,
+		"Message":      b.Message,
+		"Color":        color,
+		"Width":        labelWidth + messageWidth,
+		"LabelWidth":   labelWidth,
+		"MessageWidth": messageWidth,
+		"LabelX":       labelWidth / 2,
+		"MessageX":     labelWidth + messageWidth/2,
+	}); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// loadWorkflowRunBadge returns the badge for the latest run of a workflow, filtered on the branch given in query
+// string if any. It also sets caching headers and returns true if the client already has the badge.
+func (api *API) loadWorkflowRunBadge(w http.ResponseWriter, r *http.Request) (sdk.WorkflowRunBadge, bool, error) {
+	vars := mux.Vars(r)
+	key := vars["key"]
+	name := vars["workflowName"]
+
+	var tagFilter map[string]string
+	if branch := QueryString(r, "branch"); branch != "" {
+		tagFilter = map[string]string{"git.branch": branch}
+	}
+
+	runs, _, _, _, err := workflow.LoadRuns(api.mustDB(), key, name, 0, 1, tagFilter)
+	if err != nil {
+		return sdk.WorkflowRunBadge{}, false, sdk.WrapError(err, "unable to load last run of workflow %s/%s", key, name)
+	}
+
+	label := QueryString(r, "label")
+	if label == "" {
+		label = name
+	}
+
+	var runID int64
+	var status string
+	if len(runs) > 0 {
+		runID, status = runs[0].ID, runs[0].Status
+	}
+	etag := fmt.Sprintf(`"%d-%s-%s"`, runID, status, label)
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d, must-revalidate", badgeCacheMaxAge))
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return sdk.WorkflowRunBadge{}, true, nil
+	}
+
+	return sdk.NewWorkflowRunBadge(label, status), false, nil
+}
+
+// getWorkflowRunBadgeHandler returns a svg badge with the status of the latest run of a workflow.
+func (api *API) getWorkflowRunBadgeHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		badge, notModified, err := api.loadWorkflowRunBadge(w, r)
+		if err != nil || notModified {
+			return err
+		}
+
+		btes, err := renderBadge(badge)
+		if err != nil {
+			return err
+		}
+		return service.Write(w, btes, http.StatusOK, "image/svg+xml")
+	}
+}
+
+// getWorkflowRunShieldHandler returns the status of the latest run of a workflow in the shields.io endpoint format.
+func (api *API) getWorkflowRunShieldHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		badge, notModified, err := api.loadWorkflowRunBadge(w, r)
+		if err != nil || notModified {
+			return err
+		}
+		return service.WriteJSON(w, badge, http.StatusOK)
+	}
+}
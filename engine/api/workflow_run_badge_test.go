@@ -0,0 +1,19 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_renderBadge(t *testing.T) {
+	btes, err := renderBadge(sdk.NewWorkflowRunBadge("my<workflow>", sdk.StatusSuccess))
+	require.NoError(t, err)
+	svg := string(btes)
+	assert.Contains(t, svg, `fill="#4c1"`)
+	assert.Contains(t, svg, "my&lt;workflow&gt;: success")
+	assert.NotContains(t, svg, "<workflow>")
+}
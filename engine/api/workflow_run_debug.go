@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/net/websocket"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func workflowDebugSessionKey(jobID int64) string {
+	return cache.Key("workflow", "debug", "session", strconv.FormatInt(jobID, 10))
+}
+
+func workflowDebugChannel(jobID int64, messageType string) string {
+	return cache.Key("workflow", "debug", strconv.FormatInt(jobID, 10), messageType)
+}
+
+// getWorkflowJobDebugHandler opens the tunnel between the worker of a failed job held for debugging and the API. The
+// hold query param is the number of seconds the job is held. Inputs of the users are sent to the worker, and outputs
+// of the shell are sent to the users through the cache, so users can be connected to any API instance.
+func (api *API) getWorkflowJobDebugHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		jobID, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+		if !isWorker(ctx) {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+		seconds, err := FormInt(r, "hold")
+		if err != nil {
+			return err
+		}
+		hold := time.Duration(seconds) * time.Second
+		if hold <= 0 || hold > sdk.WorkflowNodeJobRunDebugMaxHold {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid hold duration, it should be between 1 second and %s", sdk.WorkflowNodeJobRunDebugMaxHold)
+		}
+
+		session := sdk.WorkflowNodeJobRunDebugSession{
+			WorkflowNodeJobRunID: jobID,
+			WorkerName:           getAPIConsumer(ctx).Worker.Name,
+			Expires:              time.Now().Add(hold),
+		}
+		if err := api.Cache.SetWithDuration(workflowDebugSessionKey(jobID), session, hold); err != nil {
+			return err
+		}
+		defer api.Cache.Delete(workflowDebugSessionKey(jobID)) // nolint
+
+		log.Info(ctx, "getWorkflowJobDebugHandler> job %d held for debugging on worker %s until %s", jobID, session.WorkerName, session.Expires)
+		serveWorkflowDebugTunnel(ctx, w, r, api.Cache, workflowDebugChannel(jobID, sdk.WorkflowNodeJobRunDebugMessageInput),
+			workflowDebugChannel(jobID, sdk.WorkflowNodeJobRunDebugMessageOutput), hold,
+			sdk.WorkflowNodeJobRunDebugMessageOutput, sdk.WorkflowNodeJobRunDebugMessageExit)
+		return nil
+	}
+}
+
+// getWorkflowNodeRunJobDebugHandler attaches the user to the shell of a failed job held for debugging.
+func (api *API) getWorkflowNodeRunJobDebugHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		nodeRunID, err := requestVarInt(r, "nodeRunID")
+		if err != nil {
+			return err
+		}
+		runJobID, err := requestVarInt(r, "runJobId")
+		if err != nil {
+			return err
+		}
+
+		// Check that the job belongs to the workflow
+		if _, err := workflow.LoadNodeRun(api.mustDB(), key, name, number, nodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true}); err != nil {
+			return err
+		}
+		job, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, runJobID)
+		if err != nil {
+			return err
+		}
+		if job.WorkflowNodeRunID != nodeRunID {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		var session sdk.WorkflowNodeJobRunDebugSession
+		found, err := api.Cache.Get(workflowDebugSessionKey(runJobID), &session)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "job %d is not held for debugging", runJobID)
+		}
+
+		log.Info(ctx, "getWorkflowNodeRunJobDebugHandler> user %s attached to job %d held on worker %s", getAPIConsumer(ctx).GetUsername(), runJobID, session.WorkerName)
+		serveWorkflowDebugTunnel(ctx, w, r, api.Cache, workflowDebugChannel(runJobID, sdk.WorkflowNodeJobRunDebugMessageOutput),
+			workflowDebugChannel(runJobID, sdk.WorkflowNodeJobRunDebugMessageInput), time.Until(session.Expires),
+			sdk.WorkflowNodeJobRunDebugMessageInput)
+		return nil
+	}
+}
+
+// serveWorkflowDebugTunnel upgrades the request to a websocket. Messages published on the subscribed channel are
+// sent on the websocket, and messages of given types received on the websocket are published on the other channel.
+func serveWorkflowDebugTunnel(ctx context.Context, w http.ResponseWriter, r *http.Request, store cache.Store, subscribe, publish string, timeout time.Duration, types ...string) {
+	websocket.Server{
+		// Clients are authenticated with their session token, the origin of the request is not checked
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			defer ws.Close() // nolint
+			// Remove the deadlines set by the http server on the hijacked connection
+			ws.SetDeadline(time.Time{}) // nolint
+
+			pubSub, err := store.Subscribe(subscribe)
+			if err != nil {
+				log.Error(ctx, "serveWorkflowDebugTunnel> cannot subscribe to %s: %v", subscribe, err)
+				return
+			}
+			defer pubSub.Unsubscribe(subscribe) // nolint
+
+			go func() {
+				defer cancel()
+				for ctx.Err() == nil {
+					msg, err := store.GetMessageFromSubscription(ctx, pubSub)
+					if err != nil {
+						log.Warning(ctx, "serveWorkflowDebugTunnel> cannot get message from %s: %v", subscribe, err)
+						continue
+					}
+					if msg == "" {
+						continue
+					}
+					if err := websocket.Message.Send(ws, msg); err != nil {
+						return
+					}
+				}
+			}()
+			// Close the websocket to stop reading it when the tunnel is closed from the other side or expires
+			go func() {
+				<-ctx.Done()
+				ws.Close() // nolint
+			}()
+
+			for {
+				var msg sdk.WorkflowNodeJobRunDebugMessage
+				if err := websocket.JSON.Receive(ws, &msg); err != nil {
+					return
+				}
+				if !sdk.IsInArray(msg.Type, types) {
+					continue
+				}
+				buf, err := json.Marshal(msg)
+				if err != nil {
+					return
+				}
+				if err := store.Publish(ctx, publish, string(buf)); err != nil {
+					log.Error(ctx, "serveWorkflowDebugTunnel> cannot publish to %s: %v", publish, err)
+					return
+				}
+				if msg.Type == sdk.WorkflowNodeJobRunDebugMessageExit {
+					return
+				}
+			}
+		},
+	}.ServeHTTP(w, r)
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// getWorkflowRunDurationComparisonHandler compares the durations of the nodes, jobs and steps of a workflow run with
+// the median durations of the last successful runs on the default branch, to spot pipeline slowdowns.
+func (api *API) getWorkflowRunDurationComparisonHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		size, err := FormInt(r, "baseline")
+		if err != nil {
+			return err
+		}
+		if size <= 0 {
+			size = sdk.WorkflowRunCompareDefaultBaselineSize
+		}
+		if size > sdk.WorkflowRunCompareMaxBaselineSize {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid baseline size %d, maximum is %d", size, sdk.WorkflowRunCompareMaxBaselineSize)
+		}
+
+		threshold := sdk.WorkflowRunCompareDefaultThreshold
+		if t := FormString(r, "threshold"); t != "" {
+			threshold, err = strconv.ParseFloat(t, 64)
+			if err != nil || threshold < 1 {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid threshold %s, should be a ratio greater than 1", t)
+			}
+		}
+
+		run, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s run number %d", name, number)
+		}
+
+		branch := FormString(r, "branch")
+		if branch == "" {
+			branch = api.workflowRunDefaultBranch(ctx, key, *run)
+		}
+
+		ids, err := workflow.LoadLastSuccessfulRunIDs(api.mustDB(), key, name, number, branch, size)
+		if err != nil {
+			return err
+		}
+		baseline := make([]sdk.WorkflowRun, 0, len(ids))
+		for _, id := range ids {
+			b, err := workflow.LoadRunByID(api.mustDB(), id, workflow.LoadRunOptions{})
+			if err != nil {
+				return err
+			}
+			baseline = append(baseline, *b)
+		}
+
+		c := sdk.CompareWorkflowRunDurations(*run, baseline, threshold, sdk.WorkflowRunCompareMinDelta)
+		c.Branch = branch
+		return service.WriteJSON(w, c, http.StatusOK)
+	}
+}
+
+// workflowRunDefaultBranch returns the default branch of the repository of the root node of given run, or an empty
+// string if it can't be found.
+func (api *API) workflowRunDefaultBranch(ctx context.Context, projectKey string, run sdk.WorkflowRun) string {
+	root := run.RootRun()
+	if root == nil || root.VCSServer == "" || root.VCSRepository == "" {
+		return ""
+	}
+
+	proj, err := project.Load(api.mustDB(), projectKey)
+	if err != nil {
+		log.Warning(ctx, "workflowRunDefaultBranch> cannot load project %s: %v", projectKey, err)
+		return ""
+	}
+	vcsServer := repositoriesmanager.GetProjectVCSServer(*proj, root.VCSServer)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, projectKey, vcsServer)
+	if err != nil {
+		log.Warning(ctx, "workflowRunDefaultBranch> cannot get vcs client %s for project %s: %v", root.VCSServer, projectKey, err)
+		return ""
+	}
+	branches, err := client.Branches(ctx, root.VCSRepository)
+	if err != nil {
+		log.Warning(ctx, "workflowRunDefaultBranch> cannot get branches of repository %s: %v", root.VCSRepository, err)
+		return ""
+	}
+	for _, b := range branches {
+		if b.Default {
+			return b.DisplayID
+		}
+	}
+	return ""
+}
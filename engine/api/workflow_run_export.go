@@ -0,0 +1,51 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowRunExportHandler returns a gzipped tar archive of a workflow run for compliance records. The archive
+// contains a manifest of its files signed with the API signing key.
+func (api *API) getWorkflowRunExportHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{
+			WithArtifacts: true,
+			WithTests:     true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s run number %d", name, number)
+		}
+
+		buf := new(bytes.Buffer)
+		if err := workflow.ExportRun(ctx, api.mustDB(), wr, getAPIConsumer(ctx).GetUsername(), func(m sdk.WorkflowRunExportManifest) (string, error) {
+			return authentication.SignJWS(m, 0) // 0 means no expiration time
+		}, buf); err != nil {
+			return err
+		}
+
+		w.Header().Add("Content-Type", "application/gzip")
+		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", wr.ExportName()))
+		w.WriteHeader(http.StatusOK)
+		_, err = io.Copy(w, buf)
+		return sdk.WrapError(err, "unable to copy content buffer in the response writer")
+	}
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowNodeRunJobLogsDownloadHandler streams the logs of all the steps of a job as plain text, or gzipped if
+// asked. Lines can be filtered on a regex and a minimal level, and steps on a time range.
+func (api *API) getWorkflowNodeRunJobLogsDownloadHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars["key"]
+		workflowName := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		nodeRunID, err := requestVarInt(r, "nodeRunID")
+		if err != nil {
+			return err
+		}
+		runJobID, err := requestVarInt(r, "runJobId")
+		if err != nil {
+			return err
+		}
+
+		filter := sdk.JobLogFilter{
+			Regex: FormString(r, "regex"),
+			Level: FormString(r, "level"),
+		}
+		if filter.From, err = formTimeRFC3339(r, "from"); err != nil {
+			return err
+		}
+		if filter.To, err = formTimeRFC3339(r, "to"); err != nil {
+			return err
+		}
+		if err := filter.Compile(); err != nil {
+			return err
+		}
+
+		// Check that the job is part of the node run
+		nodeRun, err := workflow.LoadNodeRun(api.mustDB(), projectKey, workflowName, number, nodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot find nodeRun %d/%d for workflow %s in project %s", nodeRunID, number, workflowName, projectKey)
+		}
+		var found bool
+		for _, s := range nodeRun.Stages {
+			for _, rj := range s.RunJobs {
+				if rj.ID == runJobID {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return sdk.WrapError(sdk.ErrNotFound, "cannot find job %d in nodeRun %d/%d for workflow %s in project %s", runJobID, nodeRunID, number, workflowName, projectKey)
+		}
+
+		steps, err := workflow.LoadLogsInfos(api.mustDB(), runJobID)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load logs for runJob %d", runJobID)
+		}
+
+		filename := fmt.Sprintf("%s-%d.%d-%s-job.%d.log", workflowName, number, nodeRun.SubNumber, nodeRun.WorkflowNodeName, runJobID)
+		var out io.Writer = w
+		if FormBool(r, "gzip") {
+			w.Header().Add("Content-Type", "application/gzip")
+			w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.gz\"", filename))
+			gz := gzip.NewWriter(w)
+			defer gz.Close() // nolint
+			out = gz
+		} else {
+			w.Header().Add("Content-Type", "text/plain; charset=utf-8")
+			w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+		}
+		w.WriteHeader(http.StatusOK)
+
+		// Steps are loaded one by one to not keep the logs of the whole job in memory
+		for _, s := range steps {
+			if !filter.MatchStep(s) {
+				continue
+			}
+			l, err := workflow.LoadStepLogs(api.mustDB(), runJobID, s.StepOrder)
+			if err != nil {
+				return sdk.WrapError(err, "cannot load log for runJob %d on step %d", runJobID, s.StepOrder)
+			}
+			if l == nil {
+				continue
+			}
+			if _, err := filter.WriteStep(out, *l); err != nil {
+				return sdk.WrapError(err, "unable to write logs of step %d in the response writer", s.StepOrder)
+			}
+		}
+		return nil
+	}
+}
+
+// formTimeRFC3339 returns the time given in a query parameter, nil if the parameter is empty.
+func formTimeRFC3339(r *http.Request, s string) (*time.Time, error) {
+	v := FormString(r, s)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid %s parameter %q, should be a RFC3339 date", s, v)
+	}
+	return &t, nil
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/plugin"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowRunReleaseTargetsHandler returns the release targets of a workflow run with their status, the node query
+// param filters the targets by pipeline node name.
+func (api *API) getWorkflowRunReleaseTargetsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		targets, err := workflow.LoadRunReleaseTargets(api.mustDB(), wr.ID)
+		if err != nil {
+			return err
+		}
+
+		if nodes := r.URL.Query()["node"]; len(nodes) > 0 {
+			filtered := make([]sdk.WorkflowRunReleaseTarget, 0, len(targets))
+			for _, t := range targets {
+				if sdk.IsInArray(t.WorkflowNodeName, nodes) {
+					filtered = append(filtered, t)
+				}
+			}
+			targets = filtered
+		}
+
+		return service.WriteJSON(w, targets, http.StatusOK)
+	}
+}
+
+// postWorkflowJobReleaseTargetsHandler starts the publication of the release targets of the node of a job, it is called by
+// the worker. It returns the targets to publish with the results and the artifacts of the workflow run.
+func (api *API) postWorkflowJobReleaseTargetsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		nodeRun, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load node run of job %d", id)
+		}
+		wr, err := workflow.LoadRunByID(api.mustDB(), nodeRun.WorkflowRunID, workflow.LoadRunOptions{WithArtifacts: true})
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		release := sdk.WorkflowNodeJobRunRelease{
+			Results:   []sdk.WorkflowRunResult{},
+			Artifacts: []sdk.WorkflowNodeRunArtifact{},
+		}
+		release.Targets, err = workflow.StartRunReleaseTargets(tx, *wr, *nodeRun)
+		if err != nil {
+			return err
+		}
+		if len(release.Targets) == 0 {
+			return service.WriteJSON(w, release, http.StatusOK)
+		}
+
+		integrations, err := integration.LoadIntegrationsByProjectID(tx, wr.ProjectID)
+		if err != nil {
+			return err
+		}
+		for i := range release.Targets {
+			t := &release.Targets[i]
+			var model *sdk.IntegrationModel
+			for _, pi := range integrations {
+				if pi.Name == t.ProjectIntegrationName {
+					model = &pi.Model
+					break
+				}
+			}
+			if model == nil {
+				return sdk.WithData(sdk.ErrIntegrationtNotFound, t.ProjectIntegrationName)
+			}
+			p, err := plugin.LoadByIntegrationModelIDAndType(tx, model.ID, sdk.GRPCPluginReleaseIntegration)
+			if err != nil {
+				return sdk.NewErrorFrom(sdk.ErrNotFound, "cannot find %s plugin for integration model %s: %v", sdk.GRPCPluginReleaseIntegration, model.Name, err)
+			}
+			t.PluginName = p.Name
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		release.Results, err = workflow.LoadRunResults(api.mustDB(), wr.ID)
+		if err != nil {
+			return err
+		}
+		// Only the artifacts of the last run of each pipeline are published
+		for _, nodeRuns := range wr.WorkflowNodeRuns {
+			if len(nodeRuns) > 0 {
+				release.Artifacts = append(release.Artifacts, nodeRuns[0].Artifacts...)
+			}
+		}
+
+		return service.WriteJSON(w, release, http.StatusOK)
+	}
+}
+
+// postWorkflowJobReleaseTargetStatusHandler sets the status of a release target published by the worker.
+func (api *API) postWorkflowJobReleaseTargetStatusHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+		integrationName := mux.Vars(r)["integrationName"]
+
+		var status sdk.WorkflowRunReleaseTargetStatus
+		if err := service.UnmarshalBody(r, &status); err != nil {
+			return err
+		}
+		if err := status.IsValid(); err != nil {
+			return err
+		}
+
+		nodeRun, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load node run of job %d", id)
+		}
+
+		targets, err := workflow.LoadRunReleaseTargets(api.mustDB(), nodeRun.WorkflowRunID, nodeRun.WorkflowNodeID)
+		if err != nil {
+			return err
+		}
+		var target *sdk.WorkflowRunReleaseTarget
+		for i := range targets {
+			if targets[i].ProjectIntegrationName == integrationName {
+				target = &targets[i]
+				break
+			}
+		}
+		if target == nil || target.WorkflowNodeRunID != nodeRun.ID {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "release target %s is not published by node run %d", integrationName, nodeRun.ID)
+		}
+
+		target.Status = status.Status
+		target.Details = status.Details
+		target.Attempts++
+		return workflow.UpdateRunReleaseTarget(api.mustDB(), target)
+	}
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowRunResultsHandler returns the results of a workflow run, the type query param filters the results.
+func (api *API) getWorkflowRunResultsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		results, err := workflow.LoadRunResults(api.mustDB(), wr.ID, r.URL.Query()["type"]...)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, results, http.StatusOK)
+	}
+}
+
+// postWorkflowJobRunResultHandler adds a result to the workflow run of a job, it is called by the worker.
+func (api *API) postWorkflowJobRunResultHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		var res sdk.WorkflowRunResult
+		if err := service.UnmarshalBody(r, &res); err != nil {
+			return err
+		}
+		if err := res.IsValid(); err != nil {
+			return err
+		}
+
+		nodeRun, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load node run of job %d", id)
+		}
+
+		res.ID = 0
+		res.WorkflowRunID = nodeRun.WorkflowRunID
+		res.WorkflowNodeRunID = nodeRun.ID
+		res.SubNumber = nodeRun.SubNumber
+		if err := workflow.InsertRunResult(api.mustDB(), &res); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// getWorkflowRunSecretUsagesHandler returns the secrets read by the jobs of a workflow run, the node query param
+// filters the secrets by pipeline node.
+func (api *API) getWorkflowRunSecretUsagesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		usages, err := workflow.LoadRunSecretUsages(api.mustDB(), wr.ID, r.URL.Query()["node"]...)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, usages, http.StatusOK)
+	}
+}
+
+// getWorkflowRunJobFingerprintsHandler returns the environment fingerprints of the jobs of a workflow run.
+func (api *API) getWorkflowRunJobFingerprintsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		fingerprints, err := workflow.LoadRunJobFingerprints(api.mustDB(), wr.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, fingerprints, http.StatusOK)
+	}
+}
+
+// getWorkflowRunJobFingerprintsCompareHandler compares the environment fingerprint of each job of a workflow run with
+// the one of the last successful run of the same job.
+func (api *API) getWorkflowRunJobFingerprintsCompareHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		comparisons, err := workflow.CompareRunJobFingerprints(api.mustDB(), *wr)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, comparisons, http.StatusOK)
+	}
+}
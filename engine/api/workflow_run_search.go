@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowRunsSearchHandler returns the runs of the project matching the filter given as query params. Set the
+// search query param with the id of a search saved by the user to use its filter.
+func (api *API) getWorkflowRunsSearchHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		if err := r.ParseForm(); err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot parse query params")
+		}
+
+		var filter sdk.WorkflowRunSearchFilter
+		if searchID := r.Form.Get("search"); searchID != "" {
+			id, err := strconv.ParseInt(searchID, 10, 64)
+			if err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given search id")
+			}
+			s, err := user.LoadWorkflowRunSearchByID(ctx, api.mustDB(), getAPIConsumer(ctx).AuthentifiedUser.ID, id)
+			if err != nil {
+				return err
+			}
+			if s.ProjectKey != key {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "search %s is not a search of project %s", s.Name, key)
+			}
+			filter = s.Filter
+		} else {
+			var err error
+			filter, err = sdk.WorkflowRunSearchFilterFromValues(r.Form)
+			if err != nil {
+				return err
+			}
+		}
+
+		offset, limit := 0, defaultLimit
+		var err error
+		if s := r.Form.Get("offset"); s != "" {
+			offset, err = strconv.Atoi(s)
+			if err != nil || offset < 0 {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given offset")
+			}
+		}
+		if s := r.Form.Get("limit"); s != "" {
+			limit, err = strconv.Atoi(s)
+			if err != nil || limit <= 0 {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given limit")
+			}
+		}
+		//Maximim range is set to 50
+		if limit > 50 {
+			limit = 50
+		}
+		w.Header().Add("Accept-Range", "run 50")
+
+		runs, offset, limit, count, err := workflow.SearchRuns(api.mustDB(), key, filter, offset, limit)
+		if err != nil {
+			return err
+		}
+		w.Header().Add("Content-Range", fmt.Sprintf("%d-%d/%d", offset, offset+len(runs), count))
+
+		code := http.StatusOK
+		if len(runs) < count {
+			code = http.StatusPartialContent
+		}
+
+		for i := range runs {
+			runs[i].Translate(r.Header.Get("Accept-Language"))
+		}
+
+		// Return empty array instead of nil
+		if runs == nil {
+			runs = []sdk.WorkflowRun{}
+		}
+		return service.WriteJSON(w, runs, code)
+	}
+}
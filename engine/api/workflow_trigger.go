@@ -90,6 +90,7 @@ func (api *API) getWorkflowTriggerConditionHandler() service.Handler {
 		for _, p := range params {
 			data.ConditionNames = append(data.ConditionNames, p.Name)
 		}
+		data.ConditionNames = append(data.ConditionNames, sdk.WorkflowConditionsPreviousVariableNames...)
 
 		sort.Strings(data.ConditionNames)
 		return service.WriteJSON(w, data, http.StatusOK)
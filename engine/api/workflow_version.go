@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func (api *API) getWorkflowVersionsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", name)
+		}
+
+		versions, err := workflow.LoadVersions(api.mustDB(), wf.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, versions, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowVersionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		version, err := strconv.ParseInt(vars["version"], 10, 64)
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given version")
+		}
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", name)
+		}
+
+		v, err := workflow.LoadVersion(api.mustDB(), wf.ID, version)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, v, http.StatusOK)
+	}
+}
+
+// postWorkflowVersionRollbackHandler imports the definition of a previous version of the workflow, it creates a new
+// version of the workflow.
+func (api *API) postWorkflowVersionRollbackHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		version, err := strconv.ParseInt(vars["version"], 10, 64)
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given version")
+		}
+
+		proj, err := project.Load(api.mustDB(), key,
+			project.LoadOptions.WithGroups,
+			project.LoadOptions.WithApplications,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithPipelines,
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *proj, name, workflow.LoadOptions{WithIcon: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s/%s", key, name)
+		}
+		if wf.FromRepository != "" {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot rollback a workflow as code, its history is in its repository")
+		}
+
+		v, err := workflow.LoadVersion(api.mustDB(), wf.ID, version)
+		if err != nil {
+			return err
+		}
+		ew, err := exportentities.UnmarshalWorkflow([]byte(v.Data), exportentities.FormatYAML)
+		if err != nil {
+			return sdk.WrapError(err, "cannot unmarshal version %d", version)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		message := fmt.Sprintf("Rollback to version %d", version)
+		if m := r.FormValue("message"); m != "" {
+			message = m
+		}
+		newWf, _, err := workflow.ParseAndImport(ctx, tx, api.Cache, *proj, wf, ew, getAPIConsumer(ctx), workflow.ImportOptions{Force: true, WorkflowName: name, Message: message})
+		if err != nil {
+			return sdk.WrapError(err, "cannot import version %d of workflow", version)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		newWf.Permissions.Readable = true
+		newWf.Permissions.Executable = true
+		newWf.Permissions.Deployable = true
+		newWf.Permissions.Writable = true
+
+		event.PublishWorkflowUpdate(ctx, key, *wf, *newWf, getAPIConsumer(ctx))
+
+		return service.WriteJSON(w, *newWf, http.StatusOK)
+	}
+}
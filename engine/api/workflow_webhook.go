@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowwebhook"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) loadWorkflowForWebhooks(ctx context.Context, r *http.Request) (*sdk.Workflow, error) {
+	vars := mux.Vars(r)
+	key := vars["key"]
+	name := vars["permWorkflowName"]
+
+	proj, err := project.Load(api.mustDB(), key)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load project %s", key)
+	}
+
+	wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *proj, name, workflow.LoadOptions{Minimal: true})
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load workflow %s", name)
+	}
+	return wf, nil
+}
+
+func (api *API) getWorkflowWebhooksHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForWebhooks(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		ws, err := workflowwebhook.LoadByWorkflowID(ctx, api.mustDB(), wf.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, ws, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowWebhookHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForWebhooks(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		var wh sdk.WorkflowWebhook
+		if err := service.UnmarshalBody(r, &wh); err != nil {
+			return err
+		}
+		if err := wh.IsValid(); err != nil {
+			return err
+		}
+		wh.ID = 0
+		wh.WorkflowID = wf.ID
+		if wh.SigningSecret == sdk.PasswordPlaceholder {
+			wh.SigningSecret = ""
+		}
+
+		if err := workflowwebhook.Insert(ctx, api.mustDB(), &wh); err != nil {
+			return err
+		}
+		if wh.SigningSecret != "" {
+			wh.SigningSecret = sdk.PasswordPlaceholder
+		}
+
+		return service.WriteJSON(w, wh, http.StatusCreated)
+	}
+}
+
+func (api *API) putWorkflowWebhookHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForWebhooks(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		id, err := requestVarInt(r, "webhookID")
+		if err != nil {
+			return err
+		}
+
+		var wh sdk.WorkflowWebhook
+		if err := service.UnmarshalBody(r, &wh); err != nil {
+			return err
+		}
+		if err := wh.IsValid(); err != nil {
+			return err
+		}
+		wh.ID = id
+		wh.WorkflowID = wf.ID
+
+		if err := workflowwebhook.Update(ctx, api.mustDB(), &wh); err != nil {
+			return err
+		}
+		if wh.SigningSecret != "" {
+			wh.SigningSecret = sdk.PasswordPlaceholder
+		}
+
+		return service.WriteJSON(w, wh, http.StatusOK)
+	}
+}
+
+func (api *API) deleteWorkflowWebhookHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForWebhooks(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		id, err := requestVarInt(r, "webhookID")
+		if err != nil {
+			return err
+		}
+
+		wh, err := workflowwebhook.LoadByIDWithClearSecret(ctx, api.mustDB(), wf.ID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := workflowwebhook.Delete(api.mustDB(), wh); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowWebhookDeliveriesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		return api.writeWorkflowWebhookDeliveries(ctx, w, r, FormString(r, "status"))
+	}
+}
+
+func (api *API) getWorkflowWebhookDeadLetterHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		return api.writeWorkflowWebhookDeliveries(ctx, w, r, sdk.WorkflowWebhookDeliveryStatusDead)
+	}
+}
+
+func (api *API) writeWorkflowWebhookDeliveries(ctx context.Context, w http.ResponseWriter, r *http.Request, status string) error {
+	wf, err := api.loadWorkflowForWebhooks(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	limit, err := FormInt(r, "limit")
+	if err != nil {
+		return err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	ds, err := workflowwebhook.LoadDeliveries(ctx, api.mustDB(), wf.ID, status, limit)
+	if err != nil {
+		return err
+	}
+
+	return service.WriteJSON(w, ds, http.StatusOK)
+}
+
+func (api *API) postWorkflowWebhookDeliveryReplayHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForWebhooks(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		id, err := requestVarInt(r, "deliveryID")
+		if err != nil {
+			return err
+		}
+
+		if err := workflowwebhook.ReplayDelivery(api.mustDB(), wf.ID, id); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowWebhookDeadLetterReplayHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForWebhooks(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		n, err := workflowwebhook.ReplayDeadDeliveries(api.mustDB(), wf.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, map[string]int64{"replayed": n}, http.StatusOK)
+	}
+}
@@ -63,6 +63,12 @@ func UpdateInstance(db gorp.SqlExecutor, wti *sdk.WorkflowTemplateInstance) erro
 	return sdk.WrapError(gorpmapping.Update(db, wti), "unable to update workflow template instance %d", wti.ID)
 }
 
+// UpdateInstanceDrift sets the drift of a workflow template instance in database, no audit is created.
+func UpdateInstanceDrift(db gorp.SqlExecutor, id int64, drift *sdk.WorkflowTemplateDrift) error {
+	_, err := db.Exec("UPDATE workflow_template_instance SET drift = $1 WHERE id = $2", drift, id)
+	return sdk.WrapError(err, "unable to update drift of workflow template instance %d", id)
+}
+
 // DeleteInstance for workflow template in database.
 func DeleteInstance(db gorp.SqlExecutor, wti *sdk.WorkflowTemplateInstance) error {
 	return sdk.WrapError(gorpmapping.Delete(db, wti), "unable to delete workflow template instance %d", wti.ID)
@@ -94,6 +100,17 @@ func LoadInstancesByTemplateIDAndProjectIDAndRequestWorkflowName(ctx context.Con
 	return getInstances(ctx, db, query, opts...)
 }
 
+// LoadInstancesWithWorkflow returns all workflow template instances linked to a workflow.
+func LoadInstancesWithWorkflow(ctx context.Context, db gorp.SqlExecutor, opts ...LoadInstanceOptionFunc) ([]sdk.WorkflowTemplateInstance, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM workflow_template_instance
+    WHERE workflow_id IS NOT NULL
+    ORDER BY id
+  `)
+	return getInstances(ctx, db, query, opts...)
+}
+
 // LoadInstancesByWorkflowIDs returns all workflow template instances by workflow ids.
 func LoadInstancesByWorkflowIDs(ctx context.Context, db gorp.SqlExecutor, workflowIDs []int64, opts ...LoadInstanceOptionFunc) ([]sdk.WorkflowTemplateInstance, error) {
 	query := gorpmapping.NewQuery(`
@@ -0,0 +1,95 @@
+package workflowtemplate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+	v2 "github.com/ovh/cds/sdk/exportentities/v2"
+)
+
+// ExecuteInstance executes the version of the template used by given instance.
+func ExecuteInstance(ctx context.Context, db gorp.SqlExecutor, wti sdk.WorkflowTemplateInstance) (exportentities.WorkflowComponents, error) {
+	wt, err := LoadByID(ctx, db, wti.WorkflowTemplateID, LoadOptions.Default)
+	if err != nil {
+		return exportentities.WorkflowComponents{}, err
+	}
+	if wt.Version != wti.WorkflowTemplateVersion {
+		wta, err := LoadAuditByTemplateIDAndVersion(ctx, db, wt.ID, wti.WorkflowTemplateVersion)
+		if err != nil {
+			return exportentities.WorkflowComponents{}, err
+		}
+		wt = &wta.DataAfter
+	}
+	return Execute(*wt, wti)
+}
+
+// Drift compares the workflow and pipelines generated by a template instance with the stored ones. Stored pipelines
+// are given by name, a generated pipeline that is not in the stored pipelines is drifted.
+func Drift(ctx context.Context, generated exportentities.WorkflowComponents, stored v2.Workflow, storedPipelines map[string]exportentities.PipelineV1) (sdk.WorkflowTemplateDrift, error) {
+	drift := sdk.WorkflowTemplateDrift{
+		Checked:   time.Now(),
+		Pipelines: []string{},
+	}
+
+	wf, err := GeneratedWorkflow(ctx, generated.Workflow)
+	if err != nil {
+		return drift, err
+	}
+	drift.Workflow, err = v2.Diff(wf, stored)
+	if err != nil {
+		return drift, err
+	}
+
+	for _, p := range generated.Pipelines {
+		pip, err := p.Pipeline()
+		if err != nil {
+			return drift, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot parse generated pipeline"))
+		}
+		s, ok := storedPipelines[pip.Name]
+		if !ok {
+			drift.Pipelines = append(drift.Pipelines, pip.Name)
+			continue
+		}
+		// Both pipelines are compared in their exported form to ignore the syntax of the generated file
+		a, err := json.Marshal(exportentities.NewPipelineV1(*pip))
+		if err != nil {
+			return drift, sdk.WithStack(err)
+		}
+		b, err := json.Marshal(s)
+		if err != nil {
+			return drift, sdk.WithStack(err)
+		}
+		if !bytes.Equal(a, b) {
+			drift.Pipelines = append(drift.Pipelines, pip.Name)
+		}
+	}
+	sort.Strings(drift.Pipelines)
+
+	return drift, nil
+}
+
+// GeneratedWorkflow returns the workflow generated by a template in the form of an exported workflow of version v2.0,
+// so it can be compared with a stored workflow.
+func GeneratedWorkflow(ctx context.Context, w exportentities.Workflow) (v2.Workflow, error) {
+	// ParseWorkflow changes the given workflow so a copy is parsed
+	btes, err := exportentities.Marshal(w, exportentities.FormatYAML)
+	if err != nil {
+		return v2.Workflow{}, err
+	}
+	cp, err := exportentities.UnmarshalWorkflow(btes, exportentities.FormatYAML)
+	if err != nil {
+		return v2.Workflow{}, err
+	}
+	wf, err := exportentities.ParseWorkflow(cp)
+	if err != nil {
+		return v2.Workflow{}, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot parse generated workflow"))
+	}
+	return v2.NewWorkflow(ctx, *wf, exportentities.WorkflowVersion2)
+}
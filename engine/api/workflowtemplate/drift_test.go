@@ -0,0 +1,89 @@
+package workflowtemplate_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func TestDrift(t *testing.T) {
+	tmpl := sdk.WorkflowTemplate{
+		ID: 42,
+		Workflow: base64.StdEncoding.EncodeToString([]byte(`
+name: [[.name]]
+version: v2.0
+workflow:
+  build:
+    pipeline: build
+  deploy:
+    depends_on:
+    - build
+    when:
+    - success
+    pipeline: deploy`)),
+		Pipelines: []sdk.PipelineTemplate{{
+			Value: base64.StdEncoding.EncodeToString([]byte(`
+version: v1.0
+name: build
+jobs:
+- job: Build
+  steps:
+  - script:
+    - make`)),
+		}, {
+			Value: base64.StdEncoding.EncodeToString([]byte(`
+version: v1.0
+name: deploy
+jobs:
+- job: Deploy
+  steps:
+  - script:
+    - make deploy`)),
+		}},
+	}
+	generated, err := workflowtemplate.Execute(tmpl, sdk.WorkflowTemplateInstance{
+		ID:      5,
+		Request: sdk.WorkflowTemplateRequest{WorkflowName: "my-workflow"},
+	})
+	require.NoError(t, err)
+
+	stored, err := workflowtemplate.GeneratedWorkflow(context.TODO(), generated.Workflow)
+	require.NoError(t, err)
+	again, err := workflowtemplate.GeneratedWorkflow(context.TODO(), generated.Workflow)
+	require.NoError(t, err)
+	assert.Equal(t, stored, again, "the generated workflow should not be changed")
+	storedPipelines := map[string]exportentities.PipelineV1{}
+	for _, p := range generated.Pipelines {
+		pip, err := p.Pipeline()
+		require.NoError(t, err)
+		storedPipelines[pip.Name] = exportentities.NewPipelineV1(*pip)
+	}
+
+	drift, err := workflowtemplate.Drift(context.TODO(), generated, stored, storedPipelines)
+	require.NoError(t, err)
+	assert.False(t, drift.IsDrifted())
+
+	// Change the workflow, a pipeline and remove the other one
+	deploy := stored.Workflow["deploy"]
+	deploy.When = []string{"manual"}
+	stored.Workflow["deploy"] = deploy
+	build := storedPipelines["build"]
+	build.Description = "Changed"
+	storedPipelines["build"] = build
+	delete(storedPipelines, "deploy")
+
+	drift, err = workflowtemplate.Drift(context.TODO(), generated, stored, storedPipelines)
+	require.NoError(t, err)
+	assert.True(t, drift.IsDrifted())
+	assert.Equal(t, []sdk.WorkflowDiffOperation{
+		{Op: sdk.WorkflowDiffOperationReplace, Path: "/workflow/deploy/when/0", Value: "manual"},
+	}, drift.Workflow.Patch)
+	assert.Equal(t, []string{"build", "deploy"}, drift.Pipelines)
+}
@@ -227,6 +227,8 @@ func CheckAndExecuteTemplate(ctx context.Context, db *gorp.DbMap, consumer sdk.A
 		old = &clone
 		wti.WorkflowTemplateVersion = wt.Version
 		wti.Request = req
+		// the drift of the regenerated workflow is unknown until it is computed again
+		wti.Drift = nil
 		if err := UpdateInstance(tx, wti); err != nil {
 			return nil, err
 		}
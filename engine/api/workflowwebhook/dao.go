@@ -0,0 +1,204 @@
+package workflowwebhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func getAll(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.WorkflowWebhook, error) {
+	var ws []dbWebhook
+	if err := gorpmapping.GetAll(ctx, db, query, &ws, gorpmapping.GetOptions.WithDecryption); err != nil {
+		return nil, sdk.WrapError(err, "cannot load workflow webhooks")
+	}
+
+	res := make([]sdk.WorkflowWebhook, 0, len(ws))
+	for i := range ws {
+		isValid, err := gorpmapping.CheckSignature(ws[i], ws[i].Signature)
+		if err != nil {
+			return nil, err
+		}
+		if !isValid {
+			log.Error(ctx, "workflowwebhook.getAll> workflow webhook %d data corrupted", ws[i].ID)
+			continue
+		}
+		res = append(res, ws[i].WorkflowWebhook)
+	}
+	return res, nil
+}
+
+// LoadByWorkflowID returns the webhooks of a workflow, signing secrets are blurred.
+func LoadByWorkflowID(ctx context.Context, db gorp.SqlExecutor, workflowID int64) ([]sdk.WorkflowWebhook, error) {
+	ws, err := LoadByWorkflowIDWithClearSecret(ctx, db, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ws {
+		if ws[i].SigningSecret != "" {
+			ws[i].SigningSecret = sdk.PasswordPlaceholder
+		}
+	}
+	return ws, nil
+}
+
+// LoadByWorkflowIDWithClearSecret returns the webhooks of a workflow.
+func LoadByWorkflowIDWithClearSecret(ctx context.Context, db gorp.SqlExecutor, workflowID int64) ([]sdk.WorkflowWebhook, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM workflow_webhook
+		WHERE workflow_id = $1
+		ORDER BY id`).Args(workflowID)
+	return getAll(ctx, db, query)
+}
+
+// LoadByIDWithClearSecret returns a webhook of a workflow.
+func LoadByIDWithClearSecret(ctx context.Context, db gorp.SqlExecutor, workflowID, id int64) (sdk.WorkflowWebhook, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM workflow_webhook
+		WHERE workflow_id = $1 AND id = $2`).Args(workflowID, id)
+	ws, err := getAll(ctx, db, query)
+	if err != nil {
+		return sdk.WorkflowWebhook{}, err
+	}
+	if len(ws) == 0 {
+		return sdk.WorkflowWebhook{}, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return ws[0], nil
+}
+
+// Insert a webhook for a workflow.
+func Insert(ctx context.Context, db gorp.SqlExecutor, w *sdk.WorkflowWebhook) error {
+	w.Created = time.Now()
+	secret := w.SigningSecret
+	dbw := dbWebhook{WorkflowWebhook: *w}
+	if err := gorpmapping.InsertAndSign(ctx, db, &dbw); err != nil {
+		return sdk.WrapError(err, "cannot insert workflow webhook")
+	}
+	*w = dbw.WorkflowWebhook
+	w.SigningSecret = secret
+	return nil
+}
+
+// Update a webhook of a workflow, a signing secret given with a placeholder is kept.
+func Update(ctx context.Context, db gorp.SqlExecutor, w *sdk.WorkflowWebhook) error {
+	old, err := LoadByIDWithClearSecret(ctx, db, w.WorkflowID, w.ID)
+	if err != nil {
+		return err
+	}
+	if w.SigningSecret == sdk.PasswordPlaceholder {
+		w.SigningSecret = old.SigningSecret
+	}
+	w.Created = old.Created
+
+	secret := w.SigningSecret
+	dbw := dbWebhook{WorkflowWebhook: *w}
+	if err := gorpmapping.UpdateAndSign(ctx, db, &dbw); err != nil {
+		return sdk.WrapError(err, "cannot update workflow webhook %d", w.ID)
+	}
+	*w = dbw.WorkflowWebhook
+	w.SigningSecret = secret
+	return nil
+}
+
+// Delete a webhook of a workflow and its deliveries.
+func Delete(db gorp.SqlExecutor, w sdk.WorkflowWebhook) error {
+	dbw := dbWebhook{WorkflowWebhook: w}
+	if _, err := db.Delete(&dbw); err != nil {
+		return sdk.WrapError(err, "cannot delete workflow webhook %d", w.ID)
+	}
+	return nil
+}
+
+// insertDelivery inserts a pending delivery, nothing is done if the same event was already sent by the webhook.
+func insertDelivery(db gorp.SqlExecutor, d sdk.WorkflowWebhookDelivery) error {
+	_, err := db.Exec(`
+		INSERT INTO workflow_webhook_delivery (workflow_webhook_id, workflow_id, workflow_run_id, workflow_node_run_id,
+			run_number, node_name, event, run_status, url, payload, status, created, next_attempt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+		ON CONFLICT DO NOTHING
+	`, d.WebhookID, d.WorkflowID, d.WorkflowRunID, d.WorkflowNodeRunID, d.RunNumber, d.NodeName, d.Event, d.RunStatus,
+		d.URL, d.Payload, sdk.WorkflowWebhookDeliveryStatusPending, time.Now())
+	return sdk.WrapError(err, "cannot insert delivery for workflow webhook %d", d.WebhookID)
+}
+
+// LoadDeliveries returns the last deliveries of the webhooks of a workflow, most recent first. Deliveries can be
+// filtered on their status.
+func LoadDeliveries(ctx context.Context, db gorp.SqlExecutor, workflowID int64, status string, limit int) ([]sdk.WorkflowWebhookDelivery, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM workflow_webhook_delivery
+		WHERE workflow_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created DESC, id DESC
+		LIMIT $3`).Args(workflowID, status, limit)
+	var ds []dbDelivery
+	if err := gorpmapping.GetAll(ctx, db, query, &ds); err != nil {
+		return nil, sdk.WrapError(err, "cannot load deliveries for workflow %d", workflowID)
+	}
+
+	res := make([]sdk.WorkflowWebhookDelivery, len(ds))
+	for i := range ds {
+		res[i] = sdk.WorkflowWebhookDelivery(ds[i])
+	}
+	return res, nil
+}
+
+// loadNextDeliveryForUpdate returns the next delivery to send and locks it.
+func loadNextDeliveryForUpdate(ctx context.Context, db gorp.SqlExecutor) (*sdk.WorkflowWebhookDelivery, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM workflow_webhook_delivery
+		WHERE status = ANY(string_to_array($1, ',')) AND next_attempt <= $2
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`).Args(sdk.WorkflowWebhookDeliveryStatusPending+","+sdk.WorkflowWebhookDeliveryStatusError, time.Now())
+	var d dbDelivery
+	found, err := gorpmapping.Get(ctx, db, query, &d)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load delivery")
+	}
+	if !found {
+		return nil, nil
+	}
+	res := sdk.WorkflowWebhookDelivery(d)
+	return &res, nil
+}
+
+func updateDelivery(db gorp.SqlExecutor, d *sdk.WorkflowWebhookDelivery) error {
+	dbd := dbDelivery(*d)
+	return sdk.WrapError(gorpmapping.Update(db, &dbd), "cannot update delivery %d", d.ID)
+}
+
+// ReplayDelivery resets a delivery of a workflow to be sent again.
+func ReplayDelivery(db gorp.SqlExecutor, workflowID, id int64) error {
+	res, err := db.Exec(`UPDATE workflow_webhook_delivery SET status = $3, attempts = 0, error = '', http_status = 0, next_attempt = $4
+		WHERE workflow_id = $1 AND id = $2`, workflowID, id, sdk.WorkflowWebhookDeliveryStatusPending, time.Now())
+	if err != nil {
+		return sdk.WrapError(err, "cannot replay delivery %d", id)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if n == 0 {
+		return sdk.WithStack(sdk.ErrNotFound)
+	}
+	return nil
+}
+
+// ReplayDeadDeliveries resets all the dead deliveries of a workflow to be sent again.
+func ReplayDeadDeliveries(db gorp.SqlExecutor, workflowID int64) (int64, error) {
+	res, err := db.Exec(`UPDATE workflow_webhook_delivery SET status = $2, attempts = 0, error = '', http_status = 0, next_attempt = $3
+		WHERE workflow_id = $1 AND status = $4`, workflowID, sdk.WorkflowWebhookDeliveryStatusPending, time.Now(), sdk.WorkflowWebhookDeliveryStatusDead)
+	if err != nil {
+		return 0, sdk.WrapError(err, "cannot replay dead deliveries of workflow %d", workflowID)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
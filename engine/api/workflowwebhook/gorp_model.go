@@ -0,0 +1,25 @@
+package workflowwebhook
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type dbWebhook struct {
+	gorpmapping.SignedEntity
+	sdk.WorkflowWebhook
+}
+
+func (e dbWebhook) Canonical() gorpmapping.CanonicalForms {
+	var _ = []interface{}{e.ID, e.WorkflowID, e.URL}
+	return gorpmapping.CanonicalForms{
+		"{{.ID}}{{.WorkflowID}}{{.URL}}",
+	}
+}
+
+type dbDelivery sdk.WorkflowWebhookDelivery
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbWebhook{}, "workflow_webhook", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbDelivery{}, "workflow_webhook_delivery", true, "id"))
+}
@@ -0,0 +1,224 @@
+package workflowwebhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/interpolate"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	deliveryInterval = 10 * time.Second
+	deliveryTimeout  = 30 * time.Second
+)
+
+var (
+	uiURL      string
+	httpClient = &http.Client{Timeout: deliveryTimeout, Transport: cdsclient.NewInstrumentedTransport(http.DefaultTransport)}
+)
+
+// Initialize sends the pending deliveries of workflow webhooks until given context is done. Deliveries are stored
+// in database so they can be sent by any API instance.
+func Initialize(ctx context.Context, dbFunc func() *gorp.DbMap, uiurl string) {
+	uiURL = uiurl
+	tick := time.NewTicker(deliveryInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "workflowwebhook.Initialize> exiting delivery: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			for ctx.Err() == nil {
+				found, err := deliverNext(ctx, dbFunc())
+				if err != nil {
+					log.Error(ctx, "workflowwebhook.Initialize> %v", err)
+					break
+				}
+				if !found {
+					break
+				}
+			}
+		}
+	}
+}
+
+// EnqueueRun creates the deliveries of the webhooks of a workflow for the status of given run.
+func EnqueueRun(ctx context.Context, db gorp.SqlExecutor, wr sdk.WorkflowRun) error {
+	params := runParams(wr)
+	params["cds.event"] = sdk.WorkflowWebhookEventRun
+	params["cds.node"] = ""
+	params["cds.status"] = wr.Status
+	return enqueue(ctx, db, wr, nil, sdk.WorkflowWebhookEventRun, wr.Status, params)
+}
+
+// EnqueueNodeRun creates the deliveries of the webhooks of a workflow for the status of given node run, build
+// parameters of the node run can be used in payload templates.
+func EnqueueNodeRun(ctx context.Context, db gorp.SqlExecutor, wr sdk.WorkflowRun, nr sdk.WorkflowNodeRun) error {
+	params := runParams(wr)
+	for _, p := range nr.BuildParameters {
+		params[p.Name] = p.Value
+	}
+	params["cds.event"] = sdk.WorkflowWebhookEventNode
+	params["cds.node"] = nr.WorkflowNodeName
+	params["cds.status"] = nr.Status
+	return enqueue(ctx, db, wr, &nr, sdk.WorkflowWebhookEventNode, nr.Status, params)
+}
+
+func runParams(wr sdk.WorkflowRun) map[string]string {
+	params := map[string]string{
+		"cds.project":    wr.Workflow.ProjectKey,
+		"cds.workflow":   wr.Workflow.Name,
+		"cds.run.number": strconv.FormatInt(wr.Number, 10),
+		"cds.buildURL":   fmt.Sprintf("%s/project/%s/workflow/%s/run/%d", uiURL, wr.Workflow.ProjectKey, wr.Workflow.Name, wr.Number),
+		"cds.branch":     "",
+	}
+	for _, t := range wr.Tags {
+		if t.Tag == "git.branch" {
+			params["cds.branch"] = t.Value
+		}
+	}
+	return params
+}
+
+func enqueue(ctx context.Context, db gorp.SqlExecutor, wr sdk.WorkflowRun, nr *sdk.WorkflowNodeRun, event, status string, params map[string]string) error {
+	ws, err := LoadByWorkflowIDWithClearSecret(ctx, db, wr.WorkflowID)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range ws {
+		if !w.Match(event, status) {
+			continue
+		}
+
+		vars := params
+		if strings.Contains(w.GetContentType(), "json") {
+			vars = escapeJSON(params)
+		}
+		payload, err := interpolate.Do(w.Payload(), vars)
+		if err != nil {
+			log.Warning(ctx, "workflowwebhook.enqueue> cannot interpolate payload of webhook %d: %v", w.ID, err)
+			continue
+		}
+
+		d := sdk.WorkflowWebhookDelivery{
+			WebhookID:     w.ID,
+			WorkflowID:    wr.WorkflowID,
+			WorkflowRunID: wr.ID,
+			RunNumber:     wr.Number,
+			Event:         event,
+			RunStatus:     status,
+			URL:           w.URL,
+			Payload:       payload,
+		}
+		if nr != nil {
+			d.WorkflowNodeRunID = nr.ID
+			d.NodeName = nr.WorkflowNodeName
+		}
+		if err := insertDelivery(db, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeJSON returns a copy of given params with values escaped to be used in a JSON string.
+func escapeJSON(params map[string]string) map[string]string {
+	res := make(map[string]string, len(params))
+	for k, v := range params {
+		b, _ := json.Marshal(v)
+		res[k] = string(b[1 : len(b)-1])
+	}
+	return res
+}
+
+// deliverNext sends the next pending delivery, it returns false if there is nothing to send.
+func deliverNext(ctx context.Context, db *gorp.DbMap) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	d, err := loadNextDeliveryForUpdate(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	if d == nil {
+		return false, nil
+	}
+
+	w, err := LoadByIDWithClearSecret(ctx, tx, d.WorkflowID, d.WebhookID)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	d.Attempts++
+	d.LastAttempt = &now
+	d.HTTPStatus, err = send(ctx, w, d.Payload)
+	if err != nil {
+		log.Warning(ctx, "workflowwebhook.deliverNext> cannot send delivery %d to %s: %v", d.ID, d.URL, err)
+		d.Error = err.Error()
+		if d.Attempts >= w.GetMaxAttempts() {
+			d.Status = sdk.WorkflowWebhookDeliveryStatusDead
+			d.NextAttempt = nil
+		} else {
+			d.Status = sdk.WorkflowWebhookDeliveryStatusError
+			next := now.Add(sdk.WorkflowWebhookRetryDelay(d.Attempts))
+			d.NextAttempt = &next
+		}
+	} else {
+		d.Status = sdk.WorkflowWebhookDeliveryStatusSuccess
+		d.Error = ""
+		d.NextAttempt = nil
+	}
+
+	if err := updateDelivery(tx, d); err != nil {
+		return false, err
+	}
+	return true, sdk.WithStack(tx.Commit())
+}
+
+// send posts the payload to the webhook url and returns the http status of the response. The request is signed if
+// the webhook has a signing secret.
+func send(ctx context.Context, w sdk.WorkflowWebhook, payload string) (int, error) {
+	body := []byte(payload)
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", w.GetContentType())
+	if w.SigningSecret != "" {
+		sdk.SignIntegrationRequest(req, w.SigningSecret, body, time.Now())
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close() // nolint
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with http status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
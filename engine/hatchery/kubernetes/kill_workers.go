@@ -39,6 +39,7 @@ func (h *HatcheryKubernetes) killAwolWorkers(ctx context.Context) error {
 				if err := hatchery.CheckWorkerModelRegister(h, modelPath); err != nil {
 					var spawnErr = sdk.SpawnErrorForm{
 						Error: err.Error(),
+						Class: hatchery.SpawnErrorClass(err),
 					}
 					tuple := strings.SplitN(modelPath, "/", 2)
 					if err := h.CDSClient().WorkerModelSpawnError(tuple[0], tuple[1], spawnErr); err != nil {
@@ -218,14 +218,11 @@ func (h *HatcheryKubernetes) WorkerModelsEnabled() ([]sdk.Model, error) {
 }
 
 // CanSpawn return wether or not hatchery can spawn model.
-// requirements are not supported
+// hostname requirements are not supported
 func (h *HatcheryKubernetes) CanSpawn(ctx context.Context, model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
-	// Service and Hostname requirement are not supported
+	// Hostname requirement are not supported
 	for _, r := range requirements {
-		if r.Type == sdk.ServiceRequirement {
-			log.Debug("CanSpawn> Job %d has a service requirement. Kubernetes can't spawn a worker for this job", jobID)
-			return false
-		} else if r.Type == sdk.HostnameRequirement {
+		if r.Type == sdk.HostnameRequirement {
 			log.Debug("CanSpawn> Job %d has a hostname requirement. Kubernetes can't spawn a worker for this job", jobID)
 			return false
 		}
@@ -273,6 +270,7 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 		GraylogPort:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Port,
 		GraylogExtraKey:   h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraKey,
 		GraylogExtraValue: h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		AutoUpdate:        h.Configuration().Provision.WorkerAutoUpdate,
 	}
 
 	udataParam.WorkflowJobID = spawnArgs.JobID
@@ -305,6 +303,8 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
 	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
 	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
+	envsWm["CDS_AUTO_UPDATE"] = fmt.Sprintf("%v", udataParam.AutoUpdate)
+	envsWm["CDS_MODEL_IMAGE"] = spawnArgs.Model.ModelDocker.Image
 
 	if spawnArgs.JobID > 0 {
 		envsWm["CDS_BOOKED_WORKFLOW_JOB_ID"] = fmt.Sprintf("%d", spawnArgs.JobID)
@@ -391,7 +391,7 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 			Image: img,
 		}
 
-		if sm, ok := envm["CDS_SERVICE_MEMORY"]; ok {
+		if sm, ok := envm[hatchery.ServiceOptionMemory]; ok {
 			mq, err := resource.ParseQuantity(sm)
 			if err != nil {
 				log.Warning(ctx, "hatchery> kubernetes> SpawnWorker> Unable to parse CDS_SERVICE_MEMORY value '%s': %s", sm, err)
@@ -402,12 +402,24 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 					apiv1.ResourceMemory: mq,
 				},
 			}
-			delete(envm, "CDS_SERVICE_MEMORY")
+			delete(envm, hatchery.ServiceOptionMemory)
 		}
 
-		if sa, ok := envm["CDS_SERVICE_ARGS"]; ok {
+		if sa, ok := envm[hatchery.ServiceOptionArgs]; ok {
 			servContainer.Args = hatchery.ParseArgs(sa)
-			delete(envm, "CDS_SERVICE_ARGS")
+			delete(envm, hatchery.ServiceOptionArgs)
+		}
+
+		if hc, timeout := hatchery.ParseServiceHealthcheck(envm); hc != "" {
+			servContainer.ReadinessProbe = &apiv1.Probe{
+				Handler: apiv1.Handler{
+					Exec: &apiv1.ExecAction{Command: []string{"sh", "-c", hc}},
+				},
+				PeriodSeconds:    2,
+				FailureThreshold: int32(timeout / (2 * time.Second)),
+			}
+			delete(envm, hatchery.ServiceOptionHealthcheck)
+			delete(envm, hatchery.ServiceOptionHealthcheckTimeout)
 		}
 
 		if len(envm) > 0 {
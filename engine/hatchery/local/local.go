@@ -166,7 +166,23 @@ func (h *HatcheryLocal) downloadWorker() error {
 		return sdk.WithStack(err)
 	}
 
-	return sdk.WithStack(fp.Close())
+	if err := fp.Close(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	// The api serves the worker binary of its own release
+	h.workerVersion = ""
+	if v, err := h.Client.Version(); err != nil {
+		log.Warning(ctx, "unable to get the version of the worker binary: %v", err)
+	} else {
+		h.workerVersion = v.Version
+	}
+	return nil
+}
+
+// WorkerVersion returns the version of the worker binary spawned by the hatchery.
+func (h *HatcheryLocal) WorkerVersion() string {
+	return h.workerVersion
 }
 
 func (h *HatcheryLocal) getWorkerBinaryName() string {
@@ -229,6 +245,11 @@ func (h *HatcheryLocal) CanSpawn(ctx context.Context, _ *sdk.Model, jobID int64,
 			log.Debug("CanSpawn> job %d cannot spawn on this OSArch.", jobID)
 			return false
 		}
+
+		if r.Type == sdk.IsolationRequirement && runtime.GOOS != "linux" {
+			log.Debug("CanSpawn> job %d with isolation requirement cannot spawn on %s.", jobID, runtime.GOOS)
+			return false
+		}
 	}
 	log.Debug("CanSpawn true for job %d", jobID)
 	return true
@@ -348,7 +369,7 @@ func (h *HatcheryLocal) checkRequirement(r sdk.Requirement) (bool, error) {
 			return false, nil
 		}
 		return true, nil
-	case sdk.PluginRequirement:
+	case sdk.PluginRequirement, sdk.IsolationRequirement:
 		return true, nil
 	case sdk.OSArchRequirement:
 		osarch := strings.Split(r.Value, "/")
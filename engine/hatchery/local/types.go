@@ -26,6 +26,8 @@ type HatcheryLocal struct {
 	// BasedirDedicated = basedir + hatchery.name
 	// this directory contains the worker donwloaded from api at startup
 	BasedirDedicated string
+	// workerVersion is the version of the worker binary downloaded from api, it is empty if unknown
+	workerVersion string
 }
 
 type workerCmd struct {
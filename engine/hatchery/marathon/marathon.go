@@ -228,6 +228,7 @@ func (h *HatcheryMarathon) SpawnWorker(ctx context.Context, spawnArgs hatchery.S
 		GraylogPort:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Port,
 		GraylogExtraKey:   h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraKey,
 		GraylogExtraValue: h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		AutoUpdate:        h.Configuration().Provision.WorkerAutoUpdate,
 	}
 
 	udataParam.WorkflowJobID = spawnArgs.JobID
@@ -278,6 +279,8 @@ func (h *HatcheryMarathon) SpawnWorker(ctx context.Context, spawnArgs hatchery.S
 	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
 	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
 	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
+	envsWm["CDS_AUTO_UPDATE"] = fmt.Sprintf("%v", udataParam.AutoUpdate)
+	envsWm["CDS_MODEL_IMAGE"] = spawnArgs.Model.ModelDocker.Image
 
 	if spawnArgs.JobID > 0 {
 		envsWm["CDS_BOOKED_WORKFLOW_JOB_ID"] = fmt.Sprintf("%d", spawnArgs.JobID)
@@ -561,6 +564,7 @@ func (h *HatcheryMarathon) killAwolWorkers() error {
 				if err := hatchery.CheckWorkerModelRegister(h, model); err != nil {
 					var spawnErr = sdk.SpawnErrorForm{
 						Error: err.Error(),
+						Class: hatchery.SpawnErrorClass(err),
 					}
 					tuple := strings.SplitN(model, "/", 2)
 					if err := h.CDSClient().WorkerModelSpawnError(tuple[0], tuple[1], spawnErr); err != nil {
@@ -420,6 +420,7 @@ func (h *HatcheryOpenstack) deleteServer(ctx context.Context, s servers.Server)
 		if err := hatchery.CheckWorkerModelRegister(h, modelPath); err != nil {
 			var spawnErr = sdk.SpawnErrorForm{
 				Error: err.Error(),
+				Class: hatchery.SpawnErrorClass(err),
 				Logs:  []byte(consoleLog),
 			}
 			tuple := strings.SplitN(modelPath, "/", 2)
@@ -17,6 +17,7 @@ import (
 	"github.com/ovh/cds/engine/service"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	docker "github.com/docker/docker/client"
 	"github.com/docker/go-connections/tlsconfig"
 	"github.com/gorilla/mux"
@@ -251,6 +252,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 
 	var network, networkAlias string
 	services := []string{}
+	healthchecks := map[string]time.Duration{}
 
 	if spawnArgs.JobID > 0 {
 		for _, r := range spawnArgs.Requirements {
@@ -277,7 +279,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 				img, envm := hatchery.ParseRequirementModel(r.Value)
 
 				serviceMemory := int64(1024)
-				if sm, ok := envm["CDS_SERVICE_MEMORY"]; ok {
+				if sm, ok := envm[hatchery.ServiceOptionMemory]; ok {
 					i, err := strconv.ParseUint(sm, 10, 32)
 					if err != nil {
 						log.Warning(ctx, "SpawnWorker> Unable to parse service option CDS_SERVICE_MEMORY=%s : %s", sm, err)
@@ -288,7 +290,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 				}
 
 				var cmdArgs []string
-				if sa, ok := envm["CDS_SERVICE_ARGS"]; ok {
+				if sa, ok := envm[hatchery.ServiceOptionArgs]; ok {
 					cmdArgs = hatchery.ParseArgs(sa)
 				}
 				if cmdArgs == nil {
@@ -328,6 +330,16 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 					entryPoint:   nil,
 				}
 
+				// If a health check is given, the worker will be started only when the service is healthy
+				if hc, timeout := hatchery.ParseServiceHealthcheck(envm); hc != "" {
+					args.healthcheck = &container.HealthConfig{
+						Test:     []string{"CMD-SHELL", hc},
+						Interval: serviceHealthcheckInterval,
+						Retries:  int(timeout / serviceHealthcheckInterval),
+					}
+					healthchecks[serviceName] = timeout
+				}
+
 				if err := h.createAndStartContainer(ctx, dockerClient, args, spawnArgs); err != nil {
 					log.Warning(ctx, "hatchery> swarm> SpawnWorker> Unable to start required container on %s: %s", dockerClient.name, err)
 					return err
@@ -337,6 +349,22 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 		}
 	}
 
+	if len(healthchecks) > 0 {
+		if err := h.waitServicesHealthy(ctx, dockerClient, healthchecks); err != nil {
+			log.Warning(ctx, "hatchery> swarm> SpawnWorker> Services for worker %s are not healthy on %s: %v", spawnArgs.WorkerName, dockerClient.name, err)
+			hatchery.SendSpawnInfo(ctx, h, spawnArgs.JobID, sdk.SpawnMsg{
+				ID:   sdk.MsgSpawnInfoHatcheryServiceUnhealthy.ID,
+				Args: []interface{}{h.Name(), err},
+			})
+			for _, serviceName := range services {
+				if errK := h.killAndRemove(ctx, dockerClient, serviceName); errK != nil {
+					log.Error(ctx, "hatchery> swarm> SpawnWorker> Unable to remove service %s on %s: %v", serviceName, dockerClient.name, errK)
+				}
+			}
+			return err
+		}
+	}
+
 	if spawnArgs.RegisterOnly {
 		spawnArgs.Model.ModelDocker.Cmd += " register"
 		memory = hatchery.MemoryRegisterContainer
@@ -368,6 +396,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 		GraylogPort:       h.Config.Provision.WorkerLogsOptions.Graylog.Port,
 		GraylogExtraKey:   h.Config.Provision.WorkerLogsOptions.Graylog.ExtraKey,
 		GraylogExtraValue: h.Config.Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		AutoUpdate:        h.Config.Provision.WorkerAutoUpdate,
 	}
 
 	udataParam.WorkflowJobID = spawnArgs.JobID
@@ -399,6 +428,8 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
 	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
 	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
+	envsWm["CDS_AUTO_UPDATE"] = fmt.Sprintf("%v", udataParam.AutoUpdate)
+	envsWm["CDS_MODEL_IMAGE"] = spawnArgs.Model.ModelDocker.Image
 
 	if spawnArgs.JobID > 0 {
 		envsWm["CDS_BOOKED_WORKFLOW_JOB_ID"] = fmt.Sprintf("%d", spawnArgs.JobID)
@@ -448,7 +479,8 @@ func (*HatcherySwarm) ModelType() string {
 }
 
 const (
-	timeoutPullImage = 10 * time.Minute
+	timeoutPullImage           = 10 * time.Minute
+	serviceHealthcheckInterval = 2 * time.Second
 )
 
 // CanSpawn checks if the model can be spawned by this hatchery
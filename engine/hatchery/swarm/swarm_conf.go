@@ -3,6 +3,7 @@ package swarm
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -113,6 +114,11 @@ func (h *HatcherySwarm) CheckConfiguration(cfg interface{}) error {
 	if hconfig.DefaultMemory <= 1 {
 		return fmt.Errorf("worker-memory must be > 1")
 	}
+	if hconfig.Images.CosignPublicKey != "" {
+		if _, err := os.Stat(hconfig.Images.CosignPublicKey); err != nil {
+			return fmt.Errorf("invalid cosign public key: %v", err)
+		}
+	}
 
 	return nil
 }
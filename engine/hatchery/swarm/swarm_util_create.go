@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	types "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -46,6 +47,7 @@ type containerArgs struct {
 	memory                             int64
 	dockerOpts                         dockerOpts
 	entryPoint                         strslice.StrSlice
+	healthcheck                        *container.HealthConfig
 }
 
 //shortcut to create+start(=run) a container
@@ -78,6 +80,10 @@ func (h *HatcherySwarm) createAndStartContainer(ctx context.Context, dockerClien
 		config.Entrypoint = cArgs.entryPoint
 	}
 
+	if cArgs.healthcheck != nil {
+		config.Healthcheck = cArgs.healthcheck
+	}
+
 	hostConfig := &container.HostConfig{
 		PortBindings: cArgs.dockerOpts.ports,
 		Privileged:   cArgs.dockerOpts.privileged,
@@ -99,6 +105,14 @@ func (h *HatcherySwarm) createAndStartContainer(ctx context.Context, dockerClien
 		}
 	}
 
+	if err := h.checkImage(ctx, cArgs.image, false); err != nil {
+		hatchery.SendSpawnInfo(ctx, h, spawnArgs.JobID, sdk.SpawnMsg{
+			ID:   sdk.MsgSpawnInfoHatcheryDockerPullBlocked.ID,
+			Args: []interface{}{h.Name(), cArgs.image, err.Error()},
+		})
+		return sdk.WithStack(err)
+	}
+
 	_, next := observability.Span(ctx, "swarm.dockerClient.ImageList")
 	// Check the images to know if we had to pull or not
 	images, errl := dockerClient.ImageList(ctx, types.ImageListOptions{All: true})
@@ -123,6 +137,14 @@ checkImage:
 	}
 
 	if !imageFound {
+		if err := h.checkImage(ctx, cArgs.image, true); err != nil {
+			hatchery.SendSpawnInfo(ctx, h, spawnArgs.JobID, sdk.SpawnMsg{
+				ID:   sdk.MsgSpawnInfoHatcheryDockerPullBlocked.ID,
+				Args: []interface{}{h.Name(), cArgs.image, err.Error()},
+			})
+			return sdk.WithStack(err)
+		}
+
 		hatchery.SendSpawnInfo(ctx, h, spawnArgs.JobID, sdk.SpawnMsg{
 			ID:   sdk.MsgSpawnInfoHatcheryStartDockerPull.ID,
 			Args: []interface{}{h.Name(), cArgs.image},
@@ -148,6 +170,16 @@ checkImage:
 		})
 	}
 
+	// The digest of the image is a part of the environment fingerprint of the jobs run by the worker
+	if cArgs.name == spawnArgs.WorkerName {
+		img, _, err := dockerClient.ImageInspectWithRaw(ctx, cArgs.image)
+		if err != nil {
+			log.Warning(ctx, "hatchery> swarm> createAndStartContainer> Unable to inspect image %s on %s: %v", cArgs.image, dockerClient.name, err)
+		} else if len(img.RepoDigests) > 0 {
+			config.Env = append(config.Env, "CDS_MODEL_IMAGE_DIGEST="+img.RepoDigests[0])
+		}
+	}
+
 	_, next = observability.Span(ctx, "swarm.dockerClient.ContainerCreate", observability.Tag(observability.TagWorker, cArgs.name), observability.Tag("network", fmt.Sprintf("%v", networkingConfig)))
 	c, err := dockerClient.ContainerCreate(ctx, config, hostConfig, networkingConfig, name)
 	if err != nil {
@@ -165,6 +197,40 @@ checkImage:
 	return nil
 }
 
+// waitServicesHealthy waits for given containers to be healthy, each one until its own timeout.
+func (h *HatcherySwarm) waitServicesHealthy(ctx context.Context, dockerClient *dockerClient, timeouts map[string]time.Duration) error {
+	ctx, end := observability.Span(ctx, "swarm.waitServicesHealthy")
+	defer end()
+
+	for name, timeout := range timeouts {
+		deadline := time.Now().Add(timeout)
+		for {
+			ctxInspect, cancel := context.WithTimeout(ctx, 10*time.Second)
+			c, err := dockerClient.ContainerInspect(ctxInspect, name)
+			cancel()
+			if err != nil {
+				return sdk.WrapError(err, "unable to inspect service %s on %s", name, dockerClient.name)
+			}
+			if c.State == nil || !c.State.Running {
+				return fmt.Errorf("service %s is not running", name)
+			}
+			if c.State.Health == nil || c.State.Health.Status == types.Healthy {
+				log.Debug("hatchery> swarm> waitServicesHealthy> service %s is healthy", name)
+				break
+			}
+			if c.State.Health.Status == types.Unhealthy || time.Now().After(deadline) {
+				return fmt.Errorf("service %s is not healthy after %s", name, timeout)
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(serviceHealthcheckInterval):
+			}
+		}
+	}
+	return nil
+}
+
 var regexPort = regexp.MustCompile("^--port=(.*):(.*)$")
 
 type dockerOpts struct {
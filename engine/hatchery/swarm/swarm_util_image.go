@@ -0,0 +1,75 @@
+package swarm
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	context "golang.org/x/net/context"
+)
+
+const dockerHubRegistry = "docker.io"
+
+// imageRegistry returns the registry of given image and the full reference of the image in this registry. Images
+// without registry are on Docker Hub, the library namespace is added to official images.
+func imageRegistry(img string) (string, string) {
+	registry, name := dockerHubRegistry, img
+	if i := strings.Index(img, "/"); i > 0 && (strings.ContainsAny(img[:i], ".:") || img[:i] == "localhost") {
+		registry, name = img[:i], img[i+1:]
+	}
+	if registry == "index.docker.io" || registry == "registry-1.docker.io" {
+		registry = dockerHubRegistry
+	}
+	if registry == dockerHubRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	return registry, registry + "/" + name
+}
+
+// isAllowed returns true if given image matches a pattern of the allowlist, or if the allowlist is empty.
+func (c ImagesConfiguration) isAllowed(img string) bool {
+	if len(c.Allowlist) == 0 {
+		return true
+	}
+	_, ref := imageRegistry(img)
+	for _, pattern := range c.Allowlist {
+		expr := "^" + strings.Replace(regexp.QuoteMeta(strings.TrimSpace(pattern)), `\*`, ".*", -1) + "$"
+		if regexp.MustCompile(expr).MatchString(ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// mirrorReference returns the reference of given image in the mirror of its registry if any.
+func (c ImagesConfiguration) mirrorReference(img string) (string, bool) {
+	registry, ref := imageRegistry(img)
+	mirror, ok := c.Mirrors[registry]
+	if !ok || mirror == "" {
+		return "", false
+	}
+	return strings.TrimSuffix(mirror, "/") + strings.TrimPrefix(ref, registry), true
+}
+
+// checkImage returns an error explaining how to fix the job if given image is not allowed by the policy of the
+// hatchery or if its signature can't be verified.
+func (h *HatcherySwarm) checkImage(ctx context.Context, img string, verifySignature bool) error {
+	_, ref := imageRegistry(img)
+	if !h.Config.Images.isAllowed(img) {
+		return fmt.Errorf("image %s is not allowed on this hatchery, use an image matching one of %s", ref, strings.Join(h.Config.Images.Allowlist, ", "))
+	}
+	if !verifySignature || h.Config.Images.CosignPublicKey == "" {
+		return nil
+	}
+
+	command := h.Config.Images.CosignCommand
+	if command == "" {
+		command = "cosign"
+	}
+	out, err := exec.CommandContext(ctx, command, "verify", "--key", h.Config.Images.CosignPublicKey, img).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("signature of image %s cannot be verified (%v: %s), sign the image with cosign or use a signed image", ref, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
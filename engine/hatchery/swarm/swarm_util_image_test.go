@@ -0,0 +1,73 @@
+package swarm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	context "golang.org/x/net/context"
+)
+
+func Test_imageRegistry(t *testing.T) {
+	tests := []struct {
+		image, registry, ref string
+	}{
+		{"golang:1.13", "docker.io", "docker.io/library/golang:1.13"},
+		{"ovhcom/cds-engine:latest", "docker.io", "docker.io/ovhcom/cds-engine:latest"},
+		{"index.docker.io/ovhcom/cds-engine", "docker.io", "docker.io/ovhcom/cds-engine"},
+		{"registry.mycompany.com/team/image:1", "registry.mycompany.com", "registry.mycompany.com/team/image:1"},
+		{"localhost:5000/image", "localhost:5000", "localhost:5000/image"},
+		{"localhost/image", "localhost", "localhost/image"},
+	}
+	for _, tt := range tests {
+		registry, ref := imageRegistry(tt.image)
+		assert.Equal(t, tt.registry, registry, tt.image)
+		assert.Equal(t, tt.ref, ref, tt.image)
+	}
+}
+
+func TestImagesConfiguration_isAllowed(t *testing.T) {
+	assert.True(t, ImagesConfiguration{}.isAllowed("golang:1.13"))
+
+	c := ImagesConfiguration{Allowlist: []string{"docker.io/library/*", "registry.mycompany.com/team/*"}}
+	assert.True(t, c.isAllowed("golang:1.13"))
+	assert.True(t, c.isAllowed("registry.mycompany.com/team/image:1"))
+	assert.False(t, c.isAllowed("ovhcom/cds-engine:latest"))
+	assert.False(t, c.isAllowed("registry.mycompany.com/other/image:1"))
+	assert.False(t, c.isAllowed("registry.mycompany.com.evil.com/team/image:1"))
+}
+
+func TestImagesConfiguration_mirrorReference(t *testing.T) {
+	c := ImagesConfiguration{Mirrors: map[string]string{"docker.io": "mirror.mycompany.com/"}}
+
+	ref, ok := c.mirrorReference("golang:1.13")
+	require.True(t, ok)
+	assert.Equal(t, "mirror.mycompany.com/library/golang:1.13", ref)
+
+	ref, ok = c.mirrorReference("ovhcom/cds-engine:latest")
+	require.True(t, ok)
+	assert.Equal(t, "mirror.mycompany.com/ovhcom/cds-engine:latest", ref)
+
+	_, ok = c.mirrorReference("registry.mycompany.com/team/image:1")
+	assert.False(t, ok)
+}
+
+func TestHatcherySwarm_checkImage(t *testing.T) {
+	h := &HatcherySwarm{Config: HatcheryConfiguration{Images: ImagesConfiguration{
+		Allowlist:       []string{"docker.io/library/*"},
+		CosignPublicKey: "cosign.pub",
+		CosignCommand:   "true",
+	}}}
+	require.NoError(t, h.checkImage(context.TODO(), "golang:1.13", true))
+
+	err := h.checkImage(context.TODO(), "ovhcom/cds-engine:latest", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use an image matching one of docker.io/library/*")
+
+	// The signature is verified only when the image is pulled
+	h.Config.Images.CosignCommand = "false"
+	require.NoError(t, h.checkImage(context.TODO(), "golang:1.13", false))
+	err = h.checkImage(context.TODO(), "golang:1.13", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signature of image docker.io/library/golang:1.13 cannot be verified")
+}
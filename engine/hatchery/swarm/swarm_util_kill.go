@@ -47,6 +47,7 @@ func (h *HatcherySwarm) killAndRemove(ctx context.Context, dockerClient *dockerC
 				}
 				var spawnErr = sdk.SpawnErrorForm{
 					Error: err.Error(),
+					Class: hatchery.SpawnErrorClass(err),
 				}
 
 				logsReader, errL := dockerClient.ContainerLogs(ctx, container.ID, logsOpts)
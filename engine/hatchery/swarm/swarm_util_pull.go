@@ -40,9 +40,17 @@ func (h *HatcherySwarm) pullImage(dockerClient *dockerClient, img string, timeou
 		auth := fmt.Sprintf(`{"username": "%s", "password": "%s", "serveraddress": "%s"}`, model.ModelDocker.Username, model.ModelDocker.Password, registry)
 		opts.RegistryAuth = base64.StdEncoding.EncodeToString([]byte(auth))
 	}
-	res, err := dockerClient.ImageCreate(ctx, img, opts)
+
+	// Images of private models are pulled from their registry with the credentials of the model
+	ref := img
+	if mirror, ok := h.Config.Images.mirrorReference(img); ok && !model.ModelDocker.Private {
+		log.Debug("hatchery> swarm> pullImage> pulling image %s through mirror %s", img, mirror)
+		ref = mirror
+	}
+
+	res, err := dockerClient.ImageCreate(ctx, ref, opts)
 	if err != nil {
-		log.Warning(ctx, "hatchery> swarm> pullImage> Unable to pull image %s on %s: %s", img, dockerClient.name, err)
+		log.Warning(ctx, "hatchery> swarm> pullImage> Unable to pull image %s on %s: %s", ref, dockerClient.name, err)
 		return sdk.WithStack(err)
 	}
 
@@ -61,6 +69,12 @@ func (h *HatcherySwarm) pullImage(dockerClient *dockerClient, img string, timeou
 	}
 
 	log.Debug(buff.String())
+
+	if ref != img {
+		if err := dockerClient.ImageTag(ctx, ref, img); err != nil {
+			return sdk.WrapError(err, "unable to tag image %s as %s", ref, img)
+		}
+	}
 	log.Info(ctx, "hatchery> swarm> pullImage> pulling image %s on %s - %.3f seconds elapsed", img, dockerClient.name, time.Since(t0).Seconds())
 
 	return nil
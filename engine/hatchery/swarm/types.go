@@ -30,6 +30,16 @@ type HatcheryConfiguration struct {
 	NetworkEnableIPv6 bool `mapstructure:"networkEnableIPv6" toml:"networkEnableIPv6" default:"false" commented:"false" comment:"if true: hatchery creates private network between services with ipv6 enabled" json:"networkEnableIPv6"`
 
 	DockerEngines map[string]DockerEngineConfiguration `mapstructure:"dockerEngines" toml:"dockerEngines" comment:"List of Docker Engines" json:"dockerEngines,omitempty"`
+
+	Images ImagesConfiguration `mapstructure:"images" toml:"images" comment:"Policy applied on images of workers and services" json:"images"`
+}
+
+// ImagesConfiguration is the policy applied by the hatchery on the images of workers and services
+type ImagesConfiguration struct {
+	Mirrors         map[string]string `mapstructure:"mirrors" toml:"mirrors" commented:"true" comment:"Pull-through caches used to pull images, by registry. Use docker.io for Docker Hub. Example: mirrors = { \"docker.io\" = \"mirror.mycompany.com\" }" json:"mirrors,omitempty"`
+	Allowlist       []string          `mapstructure:"allowlist" toml:"allowlist" commented:"true" comment:"If not empty, only images matching one of these patterns can be used, * matches any characters. Example: allowlist = [\"docker.io/library/*\", \"registry.mycompany.com/*\"]" json:"allowlist,omitempty"`
+	CosignPublicKey string            `mapstructure:"cosignPublicKey" toml:"cosignPublicKey" default:"" commented:"true" comment:"If set, pulled images should be signed with the private key of this cosign public key file" json:"cosignPublicKey,omitempty"`
+	CosignCommand   string            `mapstructure:"cosignCommand" toml:"cosignCommand" default:"cosign" commented:"true" comment:"Path of the cosign binary used to verify the signature of images" json:"cosignCommand,omitempty"`
 }
 
 // HatcherySwarm is a hatchery which can be connected to a remote to a docker remote api
@@ -119,7 +119,7 @@ func (h *HatcheryVSphere) getModels(ctx context.Context) []mo.VirtualMachine {
 	return models
 }
 
-// Get a model by name
+// Get the active template of a worker model by name
 func (h *HatcheryVSphere) getModelByName(ctx context.Context, name string) (mo.VirtualMachine, error) {
 	models := h.getModels(ctx)
 
@@ -127,14 +127,8 @@ func (h *HatcheryVSphere) getModelByName(ctx context.Context, name string) (mo.V
 		return mo.VirtualMachine{}, fmt.Errorf("no models list found")
 	}
 
-	for _, m := range models {
-		var annot annotation
-		if m.Config == nil || m.Config.Annotation == "" || m.Name != name {
-			continue
-		}
-		if err := json.Unmarshal([]byte(m.Config.Annotation), &annot); err == nil && annot.Model {
-			return m, nil
-		}
+	if m, ok := activeTemplate(models, name); ok {
+		return m, nil
 	}
 
 	return mo.VirtualMachine{}, fmt.Errorf("model not found")
@@ -158,6 +152,7 @@ func (h *HatcheryVSphere) deleteServer(s mo.VirtualMachine) error {
 				if err := hatchery.CheckWorkerModelRegister(h, annot.WorkerModelPath); err != nil {
 					var spawnErr = sdk.SpawnErrorForm{
 						Error: err.Error(),
+						Class: hatchery.SpawnErrorClass(err),
 					}
 					tuple := strings.SplitN(annot.WorkerModelPath, "/", 2)
 					if err := h.CDSClient().WorkerModelSpawnError(tuple[0], tuple[1], spawnErr); err != nil {
@@ -26,26 +26,40 @@ type annotation struct {
 	Model                   bool      `json:"model"`
 	ToDelete                bool      `json:"to_delete"`
 	Created                 time.Time `json:"created"`
+	// Template is the name of the template a worker was cloned from
+	Template string `json:"template,omitempty"`
+	// Retired is set on a template replaced by a new one, it is deleted when drained
+	Retired   bool      `json:"retired,omitempty"`
+	RetiredAt time.Time `json:"retired_at,omitempty"`
 }
 
 // SpawnWorker creates a new vm instance
-func (h *HatcheryVSphere) SpawnWorker(ctx context.Context, spawnArgs hatchery.SpawnArguments) error {
+func (h *HatcheryVSphere) SpawnWorker(ctx context.Context, spawnArgs hatchery.SpawnArguments) (err error) {
 	var vm *object.VirtualMachine
 	var errV error
-	_, errM := h.getModelByName(ctx, spawnArgs.Model.Name)
+	var templateName string
+	tmpl, errM := h.getModelByName(ctx, spawnArgs.Model.Name)
 
 	if errM != nil || spawnArgs.Model.NeedRegistration {
 		// Generate worker model vm
-		vm, errV = h.createVMModel(*spawnArgs.Model)
+		vm, templateName, errV = h.createVMModel(*spawnArgs.Model)
 	}
 
 	if vm == nil || errV != nil {
 		spawnArgs.Model.NeedRegistration = errV != nil // if we haven't registered
-		if vm, errV = h.finder.VirtualMachine(ctx, spawnArgs.Model.Name); errV != nil {
+		if errM == nil {
+			vm, templateName = object.NewVirtualMachine(h.vclient.Client, tmpl.Reference()), tmpl.Name
+		} else if vm, errV = h.finder.VirtualMachine(ctx, spawnArgs.Model.Name); errV != nil {
 			return sdk.WrapError(errV, "cannot find virtual machine with this model")
+		} else {
+			templateName = spawnArgs.Model.Name
 		}
 	}
 
+	defer func() {
+		h.recordSpawn(templateName, err == nil)
+	}()
+
 	annot := annotation{
 		HatcheryName:            h.Name(),
 		WorkerName:              spawnArgs.WorkerName,
@@ -53,6 +67,7 @@ func (h *HatcheryVSphere) SpawnWorker(ctx context.Context, spawnArgs hatchery.Sp
 		WorkerModelLastModified: fmt.Sprintf("%d", spawnArgs.Model.UserLastModified.Unix()),
 		WorkerModelName:         spawnArgs.ModelName(),
 		Created:                 time.Now(),
+		Template:                templateName,
 	}
 
 	cloneSpec, folder, errCfg := h.createVMConfig(vm, annot)
@@ -75,14 +90,15 @@ func (h *HatcheryVSphere) SpawnWorker(ctx context.Context, spawnArgs hatchery.Sp
 	return h.launchScriptWorker(spawnArgs.WorkerName, spawnArgs.JobID, spawnArgs.WorkerToken, *spawnArgs.Model, spawnArgs.RegisterOnly, info.Result.(types.ManagedObjectReference))
 }
 
-// createVMModel create a model for a specific worker model
-func (h *HatcheryVSphere) createVMModel(model sdk.Model) (*object.VirtualMachine, error) {
+// createVMModel registers a new template for a specific worker model, the previous templates of the model are retired.
+// It returns the template and its name.
+func (h *HatcheryVSphere) createVMModel(model sdk.Model) (*object.VirtualMachine, string, error) {
 	ctx := context.Background()
 	log.Info(ctx, "Create vm model %s", model.Name)
 
 	vm, errV := h.finder.VirtualMachine(ctx, model.ModelVirtualMachine.Image)
 	if errV != nil {
-		return vm, sdk.WrapError(errV, "createVMModel> Cannot find virtual machine")
+		return vm, "", sdk.WrapError(errV, "createVMModel> Cannot find virtual machine")
 	}
 
 	annot := annotation{
@@ -95,23 +111,23 @@ func (h *HatcheryVSphere) createVMModel(model sdk.Model) (*object.VirtualMachine
 
 	cloneSpec, folder, errCfg := h.createVMConfig(vm, annot)
 	if errCfg != nil {
-		return vm, sdk.WrapError(errCfg, "createVMModel> cannot create VM configuration")
+		return vm, "", sdk.WrapError(errCfg, "createVMModel> cannot create VM configuration")
 	}
 
 	task, errC := vm.Clone(ctx, folder, model.Name+"-tmp", *cloneSpec)
 	if errC != nil {
-		return vm, sdk.WrapError(errC, "createVMModel> cannot clone VM")
+		return vm, "", sdk.WrapError(errC, "createVMModel> cannot clone VM")
 	}
 
 	info, errWr := task.WaitForResult(ctx, nil)
 	if errWr != nil || info.State == types.TaskInfoStateError {
-		return vm, sdk.WrapError(errWr, "createVMModel> state in error")
+		return vm, "", sdk.WrapError(errWr, "createVMModel> state in error")
 	}
 
 	vm = object.NewVirtualMachine(h.vclient.Client, info.Result.(types.ManagedObjectReference))
 
 	if _, errW := vm.WaitForIP(ctx); errW != nil {
-		return vm, sdk.WrapError(errW, "createVMModel> cannot get an ip")
+		return vm, "", sdk.WrapError(errW, "createVMModel> cannot get an ip")
 	}
 
 	if _, errS := h.launchClientOp(vm, model.ModelVirtualMachine.PreCmd+"; \n"+model.ModelVirtualMachine.Cmd+"; \n"+model.ModelVirtualMachine.PostCmd, nil); errS != nil {
@@ -127,31 +143,28 @@ func (h *HatcheryVSphere) createVMModel(model sdk.Model) (*object.VirtualMachine
 	ctxTo, cancel := context.WithTimeout(ctx, 4*time.Minute)
 	defer cancel()
 	if err := vm.WaitForPowerState(ctxTo, types.VirtualMachinePowerStatePoweredOff); err != nil {
-		return nil, sdk.WrapError(err, "cannot wait for power state result")
+		return nil, "", sdk.WrapError(err, "cannot wait for power state result")
 	}
 	log.Info(ctx, "createVMModel> model %s is build", model.Name)
 
-	modelFound, errM := h.getModelByName(ctx, model.Name)
-	if errM == nil {
-		if errD := h.deleteServer(modelFound); errD != nil {
-			log.Warning(ctx, "createVMModel> Cannot delete previous model %s : %s", model.Name, errD)
-		}
-	}
-
+	templateName := newTemplateName(model.Name, annot.Created)
 	ctxTo, cancel = context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
-	task, errR := vm.Rename(ctxTo, model.Name)
+	task, errR := vm.Rename(ctxTo, templateName)
 	if errR != nil {
-		return vm, sdk.WrapError(errR, "createVMModel> Cannot rename model %s", model.Name)
+		return vm, "", sdk.WrapError(errR, "createVMModel> Cannot rename model %s", model.Name)
 	}
 
 	ctxTo, cancel = context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	if _, err := task.WaitForResult(ctxTo, nil); err != nil {
-		return vm, sdk.WrapError(err, "error on waiting result for vm renaming %s", model.Name)
+		return vm, "", sdk.WrapError(err, "error on waiting result for vm renaming %s", model.Name)
 	}
 
-	return vm, nil
+	// Previous templates are kept until the workers cloned from them are deleted
+	h.retireTemplates(ctx, model.Name, templateName)
+
+	return vm, templateName, nil
 }
 
 // launchScriptWorker launch a script on the worker
@@ -169,6 +182,8 @@ func (h *HatcheryVSphere) launchScriptWorker(name string, jobID int64, token str
 
 	env := []string{
 		"CDS_FROM_WORKER_IMAGE=true",
+		fmt.Sprintf("CDS_AUTO_UPDATE=%t", h.Configuration().Provision.WorkerAutoUpdate),
+		"CDS_MODEL_IMAGE=" + model.ModelVirtualMachine.Image,
 	}
 
 	env = append(env, h.getGraylogGrpcEnv(model)...)
@@ -196,6 +211,7 @@ func (h *HatcheryVSphere) launchScriptWorker(name string, jobID int64, token str
 		GraylogPort:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Port,
 		GraylogExtraKey:   h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraKey,
 		GraylogExtraValue: h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		AutoUpdate:        h.Configuration().Provision.WorkerAutoUpdate,
 	}
 
 	udataParam.WorkflowJobID = jobID
@@ -0,0 +1,187 @@
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// templateSpawnStats counts the workers spawned from a template.
+type templateSpawnStats struct {
+	Success int
+	Failure int
+}
+
+// newTemplateName returns the name of a new template for given worker model, templates of a model are suffixed by their
+// creation date so a new template can be registered while workers are still spawned from the previous one.
+func newTemplateName(modelName string, t time.Time) string {
+	return fmt.Sprintf("%s-%s", modelName, t.UTC().Format("20060102150405"))
+}
+
+// activeTemplate returns the last template created for given worker model that is not retired. Templates being built
+// are ignored.
+func activeTemplate(models []mo.VirtualMachine, modelName string) (mo.VirtualMachine, bool) {
+	var res mo.VirtualMachine
+	var resAnnot annotation
+	var found bool
+	for _, m := range models {
+		if m.Config == nil || m.Config.Annotation == "" || strings.HasSuffix(m.Name, "-tmp") {
+			continue
+		}
+		var annot annotation
+		if err := json.Unmarshal([]byte(m.Config.Annotation), &annot); err != nil || !annot.Model || annot.Retired {
+			continue
+		}
+		// Templates created before the templates lifecycle are named as the worker model
+		if annot.WorkerModelName != modelName && m.Name != modelName {
+			continue
+		}
+		if !found || annot.Created.After(resAnnot.Created) {
+			res, resAnnot, found = m, annot, true
+		}
+	}
+	return res, found
+}
+
+// drainedTemplates returns the retired templates that can be deleted: retired for more than given delay and without
+// workers cloned from them.
+func drainedTemplates(servers []mo.VirtualMachine, delay time.Duration, now time.Time) []mo.VirtualMachine {
+	used := make(map[string]struct{})
+	retired := make([]mo.VirtualMachine, 0)
+	for _, s := range servers {
+		if s.Config == nil || s.Config.Annotation == "" {
+			continue
+		}
+		var annot annotation
+		if err := json.Unmarshal([]byte(s.Config.Annotation), &annot); err != nil {
+			continue
+		}
+		if annot.Model {
+			if annot.Retired && now.Sub(annot.RetiredAt) >= delay {
+				retired = append(retired, s)
+			}
+			continue
+		}
+		if annot.Template != "" {
+			used[annot.Template] = struct{}{}
+		}
+	}
+
+	res := make([]mo.VirtualMachine, 0, len(retired))
+	for _, s := range retired {
+		if _, ok := used[s.Name]; !ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// retireTemplates marks all the active templates of given worker model as retired, except the given one. Retired
+// templates are not used to spawn new workers and are deleted when drained.
+func (h *HatcheryVSphere) retireTemplates(ctx context.Context, modelName, except string) {
+	for _, m := range h.getModels(ctx) {
+		if m.Config == nil || m.Config.Annotation == "" || m.Name == except || strings.HasSuffix(m.Name, "-tmp") {
+			continue
+		}
+		var annot annotation
+		if err := json.Unmarshal([]byte(m.Config.Annotation), &annot); err != nil || !annot.Model || annot.Retired {
+			continue
+		}
+		if annot.WorkerModelName != modelName && m.Name != modelName {
+			continue
+		}
+
+		annot.Retired = true
+		annot.RetiredAt = time.Now()
+		annotStr, err := json.Marshal(annot)
+		if err != nil {
+			log.Error(ctx, "retireTemplates> cannot marshal annotation of template %s: %v", m.Name, err)
+			continue
+		}
+		vm := object.NewVirtualMachine(h.vclient.Client, m.Reference())
+		ctxC, cancel := context.WithTimeout(ctx, reqTimeout)
+		task, err := vm.Reconfigure(ctxC, types.VirtualMachineConfigSpec{Annotation: string(annotStr)})
+		if err == nil {
+			err = task.Wait(ctxC)
+		}
+		cancel()
+		if err != nil {
+			log.Warning(ctx, "retireTemplates> cannot retire template %s: %v", m.Name, err)
+			continue
+		}
+		log.Info(ctx, "retireTemplates> template %s of model %s retired, it will be deleted when drained", m.Name, modelName)
+	}
+}
+
+// deleteDrainedTemplates deletes the retired templates that are not used by workers anymore.
+func (h *HatcheryVSphere) deleteDrainedTemplates() {
+	ctx := context.Background()
+	delay := time.Duration(h.Config.TemplateDrainingDelay) * time.Minute
+	for _, s := range drainedTemplates(h.getServers(), delay, time.Now()) {
+		log.Info(ctx, "deleteDrainedTemplates> deleting retired template %s", s.Name)
+		if err := h.deleteServer(s); err != nil {
+			log.Warning(ctx, "deleteDrainedTemplates> cannot delete template %s: %v", s.Name, err)
+		}
+		h.templateStatsMutex.Lock()
+		delete(h.templateStats, s.Name)
+		h.templateStatsMutex.Unlock()
+	}
+}
+
+// recordSpawn counts a worker spawned from given template.
+func (h *HatcheryVSphere) recordSpawn(template string, success bool) {
+	h.templateStatsMutex.Lock()
+	defer h.templateStatsMutex.Unlock()
+	if h.templateStats == nil {
+		h.templateStats = make(map[string]*templateSpawnStats)
+	}
+	stats, ok := h.templateStats[template]
+	if !ok {
+		stats = new(templateSpawnStats)
+		h.templateStats[template] = stats
+	}
+	if success {
+		stats.Success++
+	} else {
+		stats.Failure++
+	}
+}
+
+// templatesMonitoring returns the spawn success rate of each template, a template is in warning if most of the spawns
+// failed.
+func (h *HatcheryVSphere) templatesMonitoring() []sdk.MonitoringStatusLine {
+	h.templateStatsMutex.Lock()
+	defer h.templateStatsMutex.Unlock()
+
+	names := make([]string, 0, len(h.templateStats))
+	for name := range h.templateStats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]sdk.MonitoringStatusLine, 0, len(names))
+	for _, name := range names {
+		stats := h.templateStats[name]
+		total := stats.Success + stats.Failure
+		status := sdk.MonitoringStatusOK
+		if stats.Failure > stats.Success {
+			status = sdk.MonitoringStatusWarn
+		}
+		lines = append(lines, sdk.MonitoringStatusLine{
+			Component: "Template " + name,
+			Value:     fmt.Sprintf("%d/%d spawned (%d%%)", stats.Success, total, stats.Success*100/total),
+			Status:    status,
+		})
+	}
+	return lines
+}
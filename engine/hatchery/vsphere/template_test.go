@@ -0,0 +1,77 @@
+package vsphere
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func newTestVM(t *testing.T, name string, annot annotation) mo.VirtualMachine {
+	btes, err := json.Marshal(annot)
+	require.NoError(t, err)
+	vm := mo.VirtualMachine{Config: &types.VirtualMachineConfigInfo{Annotation: string(btes)}}
+	vm.Name = name
+	return vm
+}
+
+func TestActiveTemplate(t *testing.T) {
+	now := time.Now()
+	models := []mo.VirtualMachine{
+		newTestVM(t, "my-model", annotation{Model: true, WorkerModelName: "my-model", Created: now.Add(-3 * time.Hour), Retired: true}),
+		newTestVM(t, "my-model-1", annotation{Model: true, WorkerModelName: "my-model", Created: now.Add(-2 * time.Hour)}),
+		newTestVM(t, "my-model-2", annotation{Model: true, WorkerModelName: "my-model", Created: now.Add(-time.Hour)}),
+		newTestVM(t, "my-model-tmp", annotation{Model: true, WorkerModelName: "my-model", Created: now}),
+		newTestVM(t, "other-model", annotation{Model: true, WorkerModelName: "other-model", Created: now}),
+	}
+
+	m, found := activeTemplate(models, "my-model")
+	require.True(t, found)
+	require.Equal(t, "my-model-2", m.Name)
+
+	// A template created before the templates lifecycle is found by its name
+	models = []mo.VirtualMachine{newTestVM(t, "legacy-model", annotation{Model: true})}
+	m, found = activeTemplate(models, "legacy-model")
+	require.True(t, found)
+	require.Equal(t, "legacy-model", m.Name)
+
+	_, found = activeTemplate(models, "unknown-model")
+	require.False(t, found)
+}
+
+func TestDrainedTemplates(t *testing.T) {
+	now := time.Now()
+	servers := []mo.VirtualMachine{
+		newTestVM(t, "my-model-1", annotation{Model: true, WorkerModelName: "my-model", Retired: true, RetiredAt: now.Add(-time.Hour)}),
+		newTestVM(t, "my-model-2", annotation{Model: true, WorkerModelName: "my-model", Retired: true, RetiredAt: now.Add(-time.Hour)}),
+		newTestVM(t, "my-model-3", annotation{Model: true, WorkerModelName: "my-model", Retired: true, RetiredAt: now.Add(-time.Minute)}),
+		newTestVM(t, "my-model-4", annotation{Model: true, WorkerModelName: "my-model"}),
+		newTestVM(t, "worker-1", annotation{WorkerName: "worker-1", Template: "my-model-2"}),
+		newTestVM(t, "worker-2", annotation{WorkerName: "worker-2", Template: "my-model-4"}),
+	}
+
+	res := drainedTemplates(servers, 10*time.Minute, now)
+	require.Len(t, res, 1)
+	require.Equal(t, "my-model-1", res[0].Name)
+}
+
+func TestTemplatesMonitoring(t *testing.T) {
+	h := &HatcheryVSphere{}
+	h.recordSpawn("my-model-1", true)
+	h.recordSpawn("my-model-1", true)
+	h.recordSpawn("my-model-1", false)
+	h.recordSpawn("my-model-2", false)
+
+	lines := h.templatesMonitoring()
+	require.Len(t, lines, 2)
+	require.Equal(t, "Template my-model-1", lines[0].Component)
+	require.Equal(t, "2/3 spawned (66%)", lines[0].Value)
+	require.Equal(t, sdk.MonitoringStatusOK, lines[0].Status)
+	require.Equal(t, "Template my-model-2", lines[1].Component)
+	require.Equal(t, sdk.MonitoringStatusWarn, lines[1].Status)
+}
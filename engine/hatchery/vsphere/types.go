@@ -1,6 +1,8 @@
 package vsphere
 
 import (
+	"sync"
+
 	"github.com/ovh/cds/engine/service"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
@@ -42,6 +44,9 @@ type HatcheryConfiguration struct {
 
 	// CreateImageTimeout max wait for create a vsphere image (in seconds)
 	CreateImageTimeout int `mapstructure:"createImageTimeout" toml:"createImageTimeout" default:"180" commented:"false" comment:"max wait for create a vsphere image (in seconds)" json:"createImageTimeout"`
+
+	// TemplateDrainingDelay delay before deleting a retired template (in minutes)
+	TemplateDrainingDelay int `mapstructure:"templateDrainingDelay" toml:"templateDrainingDelay" default:"10" commented:"false" comment:"When a worker model is updated, its previous template is retired. A retired template is deleted after this delay (in minutes), when no worker cloned from it exists anymore" json:"templateDrainingDelay"`
 }
 
 // HatcheryVSphere spawns vm
@@ -54,6 +59,9 @@ type HatcheryVSphere struct {
 	network    object.NetworkReference
 	vclient    *govmomi.Client
 
+	templateStats      map[string]*templateSpawnStats
+	templateStatsMutex sync.Mutex
+
 	// User provided parameters
 	endpoint           string
 	user               string
@@ -73,6 +73,7 @@ func (h *HatcheryVSphere) ApplyConfiguration(cfg interface{}) error {
 func (h *HatcheryVSphere) Status(ctx context.Context) sdk.MonitoringStatus {
 	m := h.CommonMonitoring()
 	m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Workers", Value: fmt.Sprintf("%d/%d", len(h.WorkersStarted(ctx)), h.Config.Provision.MaxWorker), Status: sdk.MonitoringStatusOK})
+	m.Lines = append(m.Lines, h.templatesMonitoring()...)
 	return m
 }
 
@@ -183,6 +184,7 @@ func (h *HatcheryVSphere) main() {
 	serverListTick := time.NewTicker(10 * time.Second).C
 	killAwolServersTick := time.NewTicker(20 * time.Second).C
 	killDisabledWorkersTick := time.NewTicker(60 * time.Second).C
+	deleteDrainedTemplatesTick := time.NewTicker(60 * time.Second).C
 
 	for {
 		select {
@@ -196,6 +198,9 @@ func (h *HatcheryVSphere) main() {
 		case <-killDisabledWorkersTick:
 			h.killDisabledWorkers()
 
+		case <-deleteDrainedTemplatesTick:
+			h.deleteDrainedTemplates()
+
 		}
 	}
 }
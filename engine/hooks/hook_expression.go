@@ -0,0 +1,105 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// applyHookExpressions removes the events that don't match the filter expression of the hook, and adds the
+// parameters returned by its mapping expression to the payload of the other events.
+func applyHookExpressions(ctx context.Context, e *sdk.TaskExecution, hs []sdk.WorkflowNodeRunHookEvent) ([]sdk.WorkflowNodeRunHookEvent, error) {
+	filter, mapping, err := sdk.ParseHookExpressions(e.Config)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil && mapping == nil {
+		return hs, nil
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(e.WebHook.RequestBody, &body); err != nil {
+		body = nil
+	}
+	headers := make(map[string]interface{}, len(e.WebHook.RequestHeader))
+	for k, v := range e.WebHook.RequestHeader {
+		if len(v) > 0 {
+			headers[strings.ToLower(k)] = v[0]
+		}
+	}
+	vars := map[string]interface{}{
+		sdk.HookExpressionPayload: body,
+		sdk.HookExpressionHeaders: headers,
+		sdk.HookExpressionFiles:   changedFiles(body),
+	}
+
+	res := make([]sdk.WorkflowNodeRunHookEvent, 0, len(hs))
+	for _, h := range hs {
+		vars[sdk.HookExpressionParams] = h.Payload
+		if filter != nil {
+			match, err := filter.EvalBool(vars)
+			if err != nil {
+				return nil, sdk.NewErrorFrom(sdk.ErrInvalidHookConfiguration, "cannot evaluate filter expression: %v", err)
+			}
+			if !match {
+				log.Debug("Hooks> %s > event filtered by expression %s", e.UUID, filter)
+				continue
+			}
+		}
+		if mapping != nil {
+			params, err := mapping.EvalStringMap(vars)
+			if err != nil {
+				return nil, sdk.NewErrorFrom(sdk.ErrInvalidHookConfiguration, "cannot evaluate mapping expression: %v", err)
+			}
+			if h.Payload == nil {
+				h.Payload = make(map[string]string, len(params))
+			}
+			for k, v := range params {
+				h.Payload[k] = v
+			}
+		}
+		res = append(res, h)
+	}
+
+	if len(res) < len(hs) {
+		log.Info(ctx, "Hooks> %s > %d/%d events filtered", e.UUID, len(hs)-len(res), len(hs))
+	}
+	return res, nil
+}
+
+// changedFiles returns the files added, modified or removed by the commits of a push event. Only GitHub and GitLab
+// send the files in their push events.
+func changedFiles(body interface{}) []string {
+	m, ok := body.(map[string]interface{})
+	if !ok {
+		return []string{}
+	}
+	commits, _ := m["commits"].([]interface{})
+
+	files := []string{}
+	seen := map[string]struct{}{}
+	for _, c := range commits {
+		commit, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"added", "modified", "removed"} {
+			fs, _ := commit[key].([]interface{})
+			for _, f := range fs {
+				s, ok := f.(string)
+				if !ok {
+					continue
+				}
+				if _, ok := seen[s]; ok {
+					continue
+				}
+				seen[s] = struct{}{}
+				files = append(files, s)
+			}
+		}
+	}
+	return files
+}
@@ -0,0 +1,59 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_applyHookExpressions(t *testing.T) {
+	e := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeRepoManagerWebHook,
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigFilter:  {Value: `headers["x-github-event"] == "push" && files.exists(f, glob(f, "*.md"))`},
+			sdk.HookConfigMapping: {Value: `{"service": "docs", "short.branch": params["git.branch"].startsWith("feat/") ? "feature" : params["git.branch"], "pusher": payload.pusher.name}`},
+		},
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(githubPushEvent),
+			RequestHeader: map[string][]string{
+				GithubHeader: {"push"},
+			},
+		},
+	}
+	hs := []sdk.WorkflowNodeRunHookEvent{
+		{Payload: map[string]string{"git.branch": "my-branch"}},
+		{Payload: map[string]string{"git.branch": "feat/my-feature"}},
+	}
+
+	res, err := applyHookExpressions(context.TODO(), e, hs)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, map[string]string{"git.branch": "my-branch", "service": "docs", "short.branch": "my-branch", "pusher": "baxterthehacker"}, res[0].Payload)
+	assert.Equal(t, "feature", res[1].Payload["short.branch"])
+
+	// Only the files under services/api trigger the workflow
+	e.Config[sdk.HookConfigFilter] = sdk.WorkflowNodeHookConfigValue{Value: `files.exists(f, glob(f, "services/api/**"))`}
+	res, err = applyHookExpressions(context.TODO(), e, hs)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	e.Config[sdk.HookConfigFilter] = sdk.WorkflowNodeHookConfigValue{Value: `payload.unknown == "value"`}
+	_, err = applyHookExpressions(context.TODO(), e, hs)
+	assert.Error(t, err)
+}
+
+func Test_changedFiles(t *testing.T) {
+	body := map[string]interface{}{
+		"commits": []interface{}{
+			map[string]interface{}{"added": []interface{}{"a.go"}, "modified": []interface{}{"b.go"}},
+			map[string]interface{}{"modified": []interface{}{"a.go"}, "removed": []interface{}{"c.go"}},
+		},
+	}
+	assert.Equal(t, []string{"a.go", "b.go", "c.go"}, changedFiles(body))
+	assert.Equal(t, []string{}, changedFiles(nil))
+}
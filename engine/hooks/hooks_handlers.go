@@ -46,9 +46,32 @@ func (s *Service) webhookHandler() service.Handler {
 			return sdk.WrapError(err, "Unable to read request")
 		}
 
+		//Check the signature of the payload sent by the repository manager, the previous secret is accepted during the
+		//overlap of a rotation
+		now := time.Now()
+		if webHook.Type == TypeRepoManagerWebHook {
+			if err := verifyRepositoryWebHookSignature(webHook.Config[sdk.HookConfigWebHookSecret].Value, r.Header, req); err != nil {
+				previous := previousRepositoryWebHookSecret(webHook.Config, now)
+				if previous == "" || verifyRepositoryWebHookSignature(previous, r.Header, req) != nil {
+					return err
+				}
+			}
+		}
+
+		//Check the signature of the payload with the webhook secrets of the project
+		if webHook.Type == TypeWebHook {
+			secrets, err := s.projectWebHookSecrets(ctx, webHook.Config[sdk.HookConfigProject].Value, now)
+			if err != nil {
+				return err
+			}
+			if err := verifyWebHookSignature(secrets, r.Header, req); err != nil {
+				return err
+			}
+		}
+
 		//Prepare a web hook execution
 		exec := &sdk.TaskExecution{
-			Timestamp: time.Now().UnixNano(),
+			Timestamp: now.UnixNano(),
 			Type:      webHook.Type,
 			UUID:      webHook.UUID,
 			Config:    webHook.Config,
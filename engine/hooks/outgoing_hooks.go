@@ -22,6 +22,9 @@ import (
 	"github.com/ovh/cds/sdk/log"
 )
 
+// outgoingHookHTTPClient sends the requests of the outgoing webhooks.
+var outgoingHookHTTPClient = &http.Client{Timeout: 60 * time.Second, Transport: cdsclient.NewInstrumentedTransport(http.DefaultTransport)}
+
 func (s *Service) nodeRunToTask(nr sdk.WorkflowNodeRun) (sdk.Task, error) {
 	if nr.OutgoingHook == nil {
 		return sdk.Task{}, fmt.Errorf("Unsupported node type: %d", nr.WorkflowNodeID)
@@ -347,8 +350,7 @@ func (s *Service) doOutgoingWebHookExecution(ctx context.Context, t *sdk.TaskExe
 	dump, _ := httputil.DumpRequestOut(req, true)
 	logBuffer.Write(dump) // nolint
 
-	http.DefaultClient.Timeout = 60 * time.Second
-	res, err := http.DefaultClient.Do(req)
+	res, err := outgoingHookHTTPClient.Do(req.WithContext(ctx))
 	if err != nil {
 		return sdk.WrapError(handleError(ctx, err), "Unable to send request")
 	}
@@ -32,6 +32,7 @@ const (
 	GitlabHeader         = "X-Gitlab-Event"
 	BitbucketHeader      = "X-Event-Key"
 	BitbucketCloudHeader = "X-Event-Key_Cloud" // Fake header, do not use to fetch header, just to return custom header
+	SignatureHeader      = "X-Hub-Signature"
 
 	ConfigNumber    = "Number"
 	ConfigSubNumber = "SubNumber"
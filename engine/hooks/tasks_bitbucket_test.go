@@ -2,6 +2,10 @@ package hooks
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
 	"testing"
 
 	"github.com/ovh/cds/engine/api/test"
@@ -3077,3 +3081,18 @@ var bitbucketPrDeclined = `
         }
     }
 }`
+
+func Test_verifyRepositoryWebHookSignature(t *testing.T) {
+	body := []byte(bitbucketPushEvent)
+	// Signature computed for the secret "my-secret"
+	mac := hmac.New(sha256.New, []byte("my-secret"))
+	mac.Write(body) // nolint
+	header := http.Header{}
+	header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	assert.NoError(t, verifyRepositoryWebHookSignature("my-secret", header, body))
+	assert.NoError(t, verifyRepositoryWebHookSignature("", http.Header{}, body))
+	assert.True(t, sdk.ErrorIs(verifyRepositoryWebHookSignature("other-secret", header, body), sdk.ErrInvalidWebHookSignature))
+	assert.True(t, sdk.ErrorIs(verifyRepositoryWebHookSignature("my-secret", header, []byte("{}")), sdk.ErrInvalidWebHookSignature))
+	assert.True(t, sdk.ErrorIs(verifyRepositoryWebHookSignature("my-secret", http.Header{}, body), sdk.ErrInvalidWebHookSignature))
+}
@@ -2,16 +2,21 @@ package hooks
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"mime"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	dump "github.com/fsamin/go-dump"
 	"github.com/xanzy/go-gitlab"
 
+	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -19,14 +24,21 @@ import (
 func (s *Service) doWebHookExecution(ctx context.Context, e *sdk.TaskExecution) ([]sdk.WorkflowNodeRunHookEvent, error) {
 	log.Debug("Hooks> Processing webhook %s %s", e.UUID, e.Type)
 
+	var hs []sdk.WorkflowNodeRunHookEvent
 	if e.Type == TypeRepoManagerWebHook {
-		return s.executeRepositoryWebHook(ctx, e)
-	}
-	event, err := executeWebHook(e)
-	if err != nil {
-		return nil, err
+		var err error
+		hs, err = s.executeRepositoryWebHook(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		event, err := executeWebHook(e)
+		if err != nil {
+			return nil, err
+		}
+		hs = []sdk.WorkflowNodeRunHookEvent{*event}
 	}
-	return []sdk.WorkflowNodeRunHookEvent{*event}, nil
+	return applyHookExpressions(ctx, e, hs)
 }
 
 func getRepositoryHeader(whe *sdk.WebHookExecution, events []string) string {
@@ -43,6 +55,82 @@ func getRepositoryHeader(whe *sdk.WebHookExecution, events []string) string {
 	return ""
 }
 
+// projectWebHookSecretsCacheTTL is the delay in seconds during which the webhook secrets of a project are kept in cache.
+const projectWebHookSecretsCacheTTL = 60
+
+// webHookSignature returns the HMAC-SHA256 signature of a payload with given secret.
+func webHookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) // nolint
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyWebHookSignature checks that a payload is signed with one of given secrets. If there is no secret, the payload
+// is not checked.
+func verifyWebHookSignature(secrets []string, header http.Header, body []byte) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+	signature := []byte(header.Get(SignatureHeader))
+	for _, secret := range secrets {
+		if hmac.Equal(signature, []byte(webHookSignature(secret, body))) {
+			return nil
+		}
+	}
+	return sdk.WithStack(sdk.ErrInvalidWebHookSignature)
+}
+
+// verifyRepositoryWebHookSignature checks the HMAC-SHA256 signature of a payload sent to a repository webhook.
+// If no secret was set on the webhook, the payload is not signed by the repository manager.
+func verifyRepositoryWebHookSignature(secret string, header http.Header, body []byte) error {
+	if secret == "" {
+		return nil
+	}
+	return verifyWebHookSignature([]string{secret}, header, body)
+}
+
+// previousRepositoryWebHookSecret returns the secret of a repository webhook before its last rotation, if it is still
+// accepted at given time.
+func previousRepositoryWebHookSecret(config sdk.WorkflowNodeHookConfig, now time.Time) string {
+	secret := config[sdk.HookConfigWebHookPreviousSecret].Value
+	if secret == "" {
+		return ""
+	}
+	expire, err := time.Parse(time.RFC3339, config[sdk.HookConfigWebHookPreviousSecretExpire].Value)
+	if err != nil || !expire.After(now) {
+		return ""
+	}
+	return secret
+}
+
+// projectWebHookSecrets returns the webhook secrets of a project that are valid at given time. The secrets are
+// loaded from the API then kept in cache for a while.
+func (s *Service) projectWebHookSecrets(ctx context.Context, projectKey string, now time.Time) ([]string, error) {
+	k := cache.Key("hooks:project:webhook:secrets", projectKey)
+	var res []sdk.ProjectWebHookSecret
+	found, err := s.Cache.Get(k, &res)
+	if err != nil {
+		log.Error(ctx, "projectWebHookSecrets> cannot get from cache %s: %v", k, err)
+	}
+	if !found {
+		res, err = s.Client.ProjectWebHookSecretList(projectKey, true)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot get webhook secrets of project %s", projectKey)
+		}
+		if err := s.Cache.SetWithTTL(k, res, projectWebHookSecretsCacheTTL); err != nil {
+			log.Error(ctx, "projectWebHookSecrets> cannot set in cache %s: %v", k, err)
+		}
+	}
+
+	secrets := make([]string, 0, len(res))
+	for _, ps := range res {
+		if !ps.IsExpired(now) {
+			secrets = append(secrets, ps.Secret)
+		}
+	}
+	return secrets, nil
+}
+
 func (s *Service) executeRepositoryWebHook(ctx context.Context, t *sdk.TaskExecution) ([]sdk.WorkflowNodeRunHookEvent, error) {
 	// Prepare a struct to send to CDS API
 	payloads := []map[string]interface{}{}
@@ -192,7 +280,7 @@ func executeWebHook(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEvent, error)
 	//Prepare the payload
 	for k, v := range t.Config {
 		switch k {
-		case sdk.HookConfigProject, sdk.HookConfigWorkflow, sdk.WebHookModelConfigMethod:
+		case sdk.HookConfigProject, sdk.HookConfigWorkflow, sdk.WebHookModelConfigMethod, sdk.HookConfigFilter, sdk.HookConfigMapping:
 		default:
 			h.Payload[k] = v.Value
 		}
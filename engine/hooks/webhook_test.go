@@ -0,0 +1,36 @@
+package hooks
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_verifyWebHookSignature(t *testing.T) {
+	body := []byte(`{"foo":"bar"}`)
+	header := http.Header{}
+	header.Set(SignatureHeader, webHookSignature("previous-secret", body))
+
+	assert.NoError(t, verifyWebHookSignature(nil, http.Header{}, body))
+	assert.NoError(t, verifyWebHookSignature([]string{"new-secret", "previous-secret"}, header, body))
+	assert.True(t, sdk.ErrorIs(verifyWebHookSignature([]string{"new-secret"}, header, body), sdk.ErrInvalidWebHookSignature))
+	assert.True(t, sdk.ErrorIs(verifyWebHookSignature([]string{"new-secret", "previous-secret"}, http.Header{}, body), sdk.ErrInvalidWebHookSignature))
+}
+
+func Test_previousRepositoryWebHookSecret(t *testing.T) {
+	now := time.Now()
+	config := sdk.WorkflowNodeHookConfig{
+		sdk.HookConfigWebHookSecret:               {Value: "new-secret"},
+		sdk.HookConfigWebHookPreviousSecret:       {Value: "previous-secret"},
+		sdk.HookConfigWebHookPreviousSecretExpire: {Value: now.Add(time.Hour).Format(time.RFC3339)},
+	}
+	assert.Equal(t, "previous-secret", previousRepositoryWebHookSecret(config, now))
+	assert.Equal(t, "", previousRepositoryWebHookSecret(config, now.Add(2*time.Hour)))
+
+	config[sdk.HookConfigWebHookPreviousSecretExpire] = sdk.WorkflowNodeHookConfigValue{}
+	assert.Equal(t, "", previousRepositoryWebHookSecret(config, now))
+}
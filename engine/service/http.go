@@ -44,19 +44,27 @@ type RouterConfig struct {
 
 // HandlerConfig is the configuration for one handler
 type HandlerConfig struct {
-	Name             string
-	Method           string
-	Handler          Handler
-	IsDeprecated     bool
-	NeedAuth         bool
-	NeedAdmin        bool
-	MaintenanceAware bool
-	EnableTracing    bool
-	AllowProvider    bool
-	AllowedTokens    []string
-	AllowedScopes    []sdk.AuthConsumerScope
-	PermissionLevel  int
-	CleanURL         string
+	Name         string
+	Method       string
+	Handler      Handler
+	IsDeprecated bool
+	Sunset       *time.Time
+	Successor    string
+	// RequestBody and ResponseBody are values of the types of the bodies of the route, used to document it
+	RequestBody          interface{}
+	ResponseBody         interface{}
+	NeedAuth             bool
+	NeedAdmin            bool
+	MaintenanceAware     bool
+	EnableTracing        bool
+	AllowProvider        bool
+	IntegrationSignature bool
+	Idempotent           bool
+	AllowedTokens        []string
+	AllowedScopes        []sdk.AuthConsumerScope
+	ProjectVerb          sdk.AuthConsumerProjectVerb
+	PermissionLevel      int
+	CleanURL             string
 }
 
 // Accepted is a helper function used by asynchronous handlers
@@ -46,6 +46,9 @@ type HatcheryCommonConfiguration struct {
 		MaxConcurrentProvisioning int  `toml:"maxConcurrentProvisioning" default:"10" comment:"Maximum allowed simultaneous workers provisioning" json:"maxConcurrentProvisioning"`
 		MaxConcurrentRegistering  int  `toml:"maxConcurrentRegistering" default:"2" comment:"Maximum allowed simultaneous workers registering. -1 to disable registering on this hatchery" json:"maxConcurrentRegistering"`
 		RegisterFrequency         int  `toml:"registerFrequency" default:"60" comment:"Check if some worker model have to be registered each n Seconds" json:"registerFrequency"`
+		QueuePolling              bool `toml:"queuePolling" default:"false" commented:"true" comment:"Poll the queue instead of subscribing to the queue stream. Format:true or false" json:"queuePolling"`
+		DrainTimeout              int  `toml:"drainTimeout" default:"0" commented:"true" comment:"On stop, wait n seconds for the workers to finish their current job without spawning new ones. 0 to stop immediately" json:"drainTimeout"`
+		WorkerAutoUpdate          bool `toml:"workerAutoUpdate" default:"false" commented:"true" comment:"Allow the workers to download and execute the worker binary served by CDS API at startup, when their version differs from the API version. Format:true or false" json:"workerAutoUpdate"`
 		WorkerLogsOptions         struct {
 			Graylog struct {
 				Host       string `toml:"host" comment:"Example: thot.ovh.com" json:"host"`
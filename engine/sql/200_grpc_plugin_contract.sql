@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "grpc_plugin" ADD COLUMN IF NOT EXISTS contract JSONB;
+ALTER TABLE "workflow_node_run_job" ADD COLUMN IF NOT EXISTS integration_plugin_contract JSONB;
+
+-- +migrate Down
+ALTER TABLE "grpc_plugin" DROP COLUMN contract;
+ALTER TABLE "workflow_node_run_job" DROP COLUMN integration_plugin_contract;
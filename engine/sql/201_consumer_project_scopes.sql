@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "auth_consumer" ADD COLUMN project_scopes JSONB;
+
+-- +migrate Down
+ALTER TABLE "auth_consumer" DROP COLUMN project_scopes;
@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "audit_event" (
+    id BIGSERIAL PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    event_type VARCHAR(256) NOT NULL,
+    data JSONB
+);
+SELECT create_index('audit_event', 'IDX_AUDIT_EVENT_CREATED', 'created');
+
+CREATE TABLE IF NOT EXISTS "audit_export_cursor" (
+    target VARCHAR(256) PRIMARY KEY,
+    last_event_id BIGINT NOT NULL DEFAULT 0,
+    last_export TIMESTAMP WITH TIME ZONE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS "audit_export_cursor";
+DROP TABLE IF EXISTS "audit_event";
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "action_edge" ADD COLUMN IF NOT EXISTS "condition" TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE "action_edge" DROP COLUMN IF EXISTS "condition";
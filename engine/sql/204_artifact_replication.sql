@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "artifact_replication" (
+    id BIGSERIAL PRIMARY KEY,
+    object_path TEXT NOT NULL,
+    object_name TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    checksum TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    attempts INT NOT NULL DEFAULT 0,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_attempt TIMESTAMP WITH TIME ZONE
+);
+SELECT create_unique_index('artifact_replication', 'IDX_ARTIFACT_REPLICATION_OBJECT', 'object_path,object_name');
+SELECT create_index('artifact_replication', 'IDX_ARTIFACT_REPLICATION_STATUS', 'status');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "artifact_replication";
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "action_version" (
+    id BIGSERIAL PRIMARY KEY,
+    action_id BIGINT NOT NULL,
+    version VARCHAR(256) NOT NULL,
+    action JSONB,
+    yanked BOOLEAN NOT NULL DEFAULT false,
+    yanked_reason TEXT NOT NULL DEFAULT '',
+    author VARCHAR(256) NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_ACTION_VERSION_ACTION', 'action_version', 'action', 'action_id', 'id');
+SELECT create_unique_index('action_version', 'IDX_ACTION_VERSION_ACTION_ID_VERSION', 'action_id,version');
+
+ALTER TABLE "action_edge" ADD COLUMN IF NOT EXISTS "action_version" VARCHAR(256) NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE "action_edge" DROP COLUMN IF EXISTS "action_version";
+DROP TABLE IF EXISTS "action_version";
@@ -0,0 +1,35 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "environment_integration" (
+    id BIGSERIAL PRIMARY KEY,
+    environment_id BIGINT NOT NULL,
+    project_integration_id BIGINT NOT NULL
+);
+SELECT create_unique_index('environment_integration', 'IDX_ENVIRONMENT_INTEGRATION_ENVIRONMENT_ID', 'environment_id');
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_INTEGRATION_ENVIRONMENT', 'environment_integration', 'environment', 'environment_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_INTEGRATION_PROJECT_INTEGRATION', 'environment_integration', 'project_integration', 'project_integration_id', 'id');
+
+CREATE TABLE IF NOT EXISTS "environment_deployment" (
+    id BIGSERIAL PRIMARY KEY,
+    environment_id BIGINT NOT NULL,
+    application_id BIGINT NOT NULL,
+    project_integration_id BIGINT NOT NULL,
+    workflow_id BIGINT NOT NULL,
+    workflow_node_id BIGINT NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    num BIGINT NOT NULL,
+    sub_num BIGINT NOT NULL DEFAULT 0,
+    version VARCHAR(256) NOT NULL DEFAULT '',
+    vcs_hash VARCHAR(256) NOT NULL DEFAULT '',
+    deployed TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_index('environment_deployment', 'IDX_ENVIRONMENT_DEPLOYMENT_ENVIRONMENT_WORKFLOW', 'environment_id,workflow_id,deployed');
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_DEPLOYMENT_ENVIRONMENT', 'environment_deployment', 'environment', 'environment_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_DEPLOYMENT_APPLICATION', 'environment_deployment', 'application', 'application_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_DEPLOYMENT_PROJECT_INTEGRATION', 'environment_deployment', 'project_integration', 'project_integration_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_DEPLOYMENT_WORKFLOW', 'environment_deployment', 'workflow', 'workflow_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_DEPLOYMENT_WORKFLOW_RUN', 'environment_deployment', 'workflow_run', 'workflow_run_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "environment_deployment";
+DROP TABLE IF EXISTS "environment_integration";
@@ -0,0 +1,20 @@
+-- +migrate Up
+SELECT create_index('workflow_run', 'IDX_WORKFLOW_RUN_SEARCH_START', 'project_id,start');
+SELECT create_index('workflow_run', 'IDX_WORKFLOW_RUN_SEARCH_STATUS', 'project_id,status,start');
+
+CREATE TABLE IF NOT EXISTS "user_workflow_run_search" (
+    id BIGSERIAL PRIMARY KEY,
+    authentified_user_id VARCHAR(36) NOT NULL,
+    project_id BIGINT NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    filter JSONB,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_unique_index('user_workflow_run_search', 'IDX_USER_WORKFLOW_RUN_SEARCH_NAME', 'authentified_user_id,project_id,name');
+SELECT create_foreign_key_idx_cascade('FK_USER_WORKFLOW_RUN_SEARCH_AUTHENTIFIED_USER', 'user_workflow_run_search', 'authentified_user', 'authentified_user_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_USER_WORKFLOW_RUN_SEARCH_PROJECT', 'user_workflow_run_search', 'project', 'project_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "user_workflow_run_search";
+DROP INDEX IF EXISTS idx_workflow_run_search_start;
+DROP INDEX IF EXISTS idx_workflow_run_search_status;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "environment_deployment" ADD COLUMN IF NOT EXISTS status VARCHAR(50) NOT NULL DEFAULT 'Success';
+
+-- +migrate Down
+ALTER TABLE "environment_deployment" DROP COLUMN IF EXISTS status;
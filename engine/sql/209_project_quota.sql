@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "project_quota" (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    concurrent_jobs BIGINT NOT NULL DEFAULT 0,
+    artifact_storage BIGINT NOT NULL DEFAULT 0,
+    runs_per_day BIGINT NOT NULL DEFAULT 0,
+    warning_threshold BIGINT NOT NULL DEFAULT 0,
+    hard_stop BOOLEAN NOT NULL DEFAULT false
+);
+SELECT create_unique_index('project_quota', 'IDX_PROJECT_QUOTA_PROJECT_ID', 'project_id');
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_QUOTA_PROJECT', 'project_quota', 'project', 'project_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "project_quota";
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "group_integration" (
+    id BIGSERIAL PRIMARY KEY,
+    group_id BIGINT NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    integration_model_id BIGINT NOT NULL,
+    cipher_config BYTEA,
+    sig BYTEA,
+    signer TEXT
+);
+SELECT create_unique_index('group_integration', 'IDX_GROUP_INTEGRATION_GROUP_ID_NAME', 'group_id,name');
+SELECT create_foreign_key_idx_cascade('FK_GROUP_INTEGRATION_GROUP', 'group_integration', 'group', 'group_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_GROUP_INTEGRATION_INTEGRATION_MODEL', 'group_integration', 'integration_model', 'integration_model_id', 'id');
+
+ALTER TABLE "project_integration" ADD COLUMN IF NOT EXISTS group_integration_id BIGINT;
+ALTER TABLE "project_integration" ADD COLUMN IF NOT EXISTS overridden BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE "project_integration" ADD CONSTRAINT FK_PROJECT_INTEGRATION_GROUP_INTEGRATION FOREIGN KEY (group_integration_id) REFERENCES group_integration(id) ON DELETE SET NULL;
+
+-- +migrate Down
+ALTER TABLE "project_integration" DROP CONSTRAINT IF EXISTS FK_PROJECT_INTEGRATION_GROUP_INTEGRATION;
+ALTER TABLE "project_integration" DROP COLUMN IF EXISTS group_integration_id;
+ALTER TABLE "project_integration" DROP COLUMN IF EXISTS overridden;
+DROP TABLE IF EXISTS "group_integration";
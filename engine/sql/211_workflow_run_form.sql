@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "workflow" ADD COLUMN IF NOT EXISTS run_form JSONB;
+
+-- +migrate Down
+ALTER TABLE "workflow" DROP COLUMN IF EXISTS run_form;
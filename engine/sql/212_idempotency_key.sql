@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "idempotency_key" (
+    id BIGSERIAL PRIMARY KEY,
+    consumer_id VARCHAR(36) NOT NULL,
+    key VARCHAR(256) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    content_type VARCHAR(256) NOT NULL DEFAULT '',
+    response BYTEA,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_unique_index('idempotency_key', 'IDX_IDEMPOTENCY_KEY_CONSUMER_ID_KEY', 'consumer_id,key');
+SELECT create_index('idempotency_key', 'IDX_IDEMPOTENCY_KEY_CREATED', 'created');
+SELECT create_foreign_key_idx_cascade('FK_IDEMPOTENCY_KEY_AUTH_CONSUMER', 'idempotency_key', 'auth_consumer', 'consumer_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "idempotency_key";
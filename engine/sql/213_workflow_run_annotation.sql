@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_run_annotation" (
+    id BIGSERIAL PRIMARY KEY,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL DEFAULT 0,
+    type VARCHAR(32) NOT NULL,
+    key VARCHAR(256) NOT NULL,
+    value TEXT NOT NULL,
+    author VARCHAR(256) NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_unique_index('workflow_run_annotation', 'IDX_WORKFLOW_RUN_ANNOTATION_RUN_ID_KEY', 'workflow_run_id,key');
+SELECT create_index('workflow_run_annotation', 'IDX_WORKFLOW_RUN_ANNOTATION_KEY', 'key');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_RUN_ANNOTATION_WORKFLOW_RUN', 'workflow_run_annotation', 'workflow_run', 'workflow_run_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_run_annotation";
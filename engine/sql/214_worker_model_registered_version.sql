@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "worker_model" ADD COLUMN IF NOT EXISTS registered_version VARCHAR(256);
+
+-- +migrate Down
+ALTER TABLE "worker_model" DROP COLUMN IF EXISTS registered_version;
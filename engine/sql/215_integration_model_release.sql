@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "integration_model" ADD COLUMN IF NOT EXISTS release BOOLEAN NOT NULL DEFAULT false;
+
+-- +migrate Down
+ALTER TABLE "integration_model" DROP COLUMN IF EXISTS release;
@@ -0,0 +1,26 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "maintenance_window" (
+    id BIGSERIAL PRIMARY KEY,
+    project_key VARCHAR(256) NOT NULL DEFAULT '',
+    workflow_name VARCHAR(256) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL,
+    start_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    author VARCHAR(256) NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_index('maintenance_window', 'IDX_MAINTENANCE_WINDOW_END_DATE', 'end_date');
+
+CREATE TABLE IF NOT EXISTS "maintenance_window_override" (
+    workflow_run_id BIGINT PRIMARY KEY,
+    reason TEXT NOT NULL,
+    author VARCHAR(256) NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_foreign_key_idx_cascade('FK_MAINTENANCE_WINDOW_OVERRIDE_WORKFLOW_RUN', 'maintenance_window_override', 'workflow_run', 'workflow_run_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "maintenance_window_override";
+DROP TABLE IF EXISTS "maintenance_window";
@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "project_artifact_retention" (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    branch_days BIGINT NOT NULL DEFAULT 0,
+    tag_days BIGINT NOT NULL DEFAULT 0,
+    release_days BIGINT NOT NULL DEFAULT 0
+);
+SELECT create_unique_index('project_artifact_retention', 'IDX_PROJECT_ARTIFACT_RETENTION_PROJECT_ID', 'project_id');
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_ARTIFACT_RETENTION_PROJECT', 'project_artifact_retention', 'project', 'project_id', 'id');
+
+ALTER TABLE workflow_node_run_artifacts ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT false;
+
+-- +migrate Down
+DROP TABLE IF EXISTS "project_artifact_retention";
+ALTER TABLE workflow_node_run_artifacts DROP COLUMN pinned;
@@ -0,0 +1,35 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "scim_user" (
+    id BIGSERIAL PRIMARY KEY,
+    authentified_user_id VARCHAR(36) NOT NULL,
+    external_id VARCHAR(256) NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT true,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_unique_index('scim_user', 'IDX_SCIM_USER_AUTHENTIFIED_USER_ID', 'authentified_user_id');
+SELECT create_foreign_key_idx_cascade('FK_SCIM_USER_AUTHENTIFIED_USER', 'scim_user', 'authentified_user', 'authentified_user_id', 'id');
+
+CREATE TABLE IF NOT EXISTS "scim_group" (
+    id BIGSERIAL PRIMARY KEY,
+    group_id BIGINT NOT NULL,
+    external_id VARCHAR(256) NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_unique_index('scim_group', 'IDX_SCIM_GROUP_GROUP_ID', 'group_id');
+SELECT create_foreign_key_idx_cascade('FK_SCIM_GROUP_GROUP', 'scim_group', 'group', 'group_id', 'id');
+
+CREATE TABLE IF NOT EXISTS "scim_audit" (
+    id BIGSERIAL PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    operation VARCHAR(64) NOT NULL,
+    resource_type VARCHAR(64) NOT NULL,
+    resource_id VARCHAR(256) NOT NULL DEFAULT '',
+    resource_name VARCHAR(256) NOT NULL DEFAULT '',
+    detail TEXT NOT NULL DEFAULT ''
+);
+SELECT create_index('scim_audit', 'IDX_SCIM_AUDIT_CREATED', 'created');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "scim_user";
+DROP TABLE IF EXISTS "scim_group";
+DROP TABLE IF EXISTS "scim_audit";
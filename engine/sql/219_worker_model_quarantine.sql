@@ -0,0 +1,20 @@
+-- +migrate Up
+ALTER TABLE worker_model ADD COLUMN IF NOT EXISTS quarantined BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE worker_model ADD COLUMN IF NOT EXISTS quarantine_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE worker_model ADD COLUMN IF NOT EXISTS date_quarantined TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS "worker_model_spawn_error" (
+    id BIGSERIAL PRIMARY KEY,
+    worker_model_id BIGINT NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    class VARCHAR(64) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT ''
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKER_MODEL_SPAWN_ERROR_WORKER_MODEL', 'worker_model_spawn_error', 'worker_model', 'worker_model_id', 'id');
+SELECT create_index('worker_model_spawn_error', 'IDX_WORKER_MODEL_SPAWN_ERROR_CREATED', 'worker_model_id,created');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "worker_model_spawn_error";
+ALTER TABLE worker_model DROP COLUMN IF EXISTS quarantined;
+ALTER TABLE worker_model DROP COLUMN IF EXISTS quarantine_reason;
+ALTER TABLE worker_model DROP COLUMN IF EXISTS date_quarantined;
@@ -0,0 +1,47 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_webhook" (
+    id BIGSERIAL PRIMARY KEY,
+    workflow_id BIGINT NOT NULL,
+    url TEXT NOT NULL,
+    events JSONB,
+    statuses JSONB,
+    payload_template TEXT NOT NULL DEFAULT '',
+    content_type VARCHAR(256) NOT NULL DEFAULT '',
+    cipher_signing_secret BYTEA,
+    max_attempts INT NOT NULL DEFAULT 0,
+    disabled BOOLEAN NOT NULL DEFAULT false,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    sig BYTEA,
+    signer TEXT
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_WEBHOOK_WORKFLOW', 'workflow_webhook', 'workflow', 'workflow_id', 'id');
+
+CREATE TABLE IF NOT EXISTS "workflow_webhook_delivery" (
+    id BIGSERIAL PRIMARY KEY,
+    workflow_webhook_id BIGINT NOT NULL,
+    workflow_id BIGINT NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL DEFAULT 0,
+    run_number BIGINT NOT NULL DEFAULT 0,
+    node_name VARCHAR(256) NOT NULL DEFAULT '',
+    event VARCHAR(20) NOT NULL,
+    run_status VARCHAR(20) NOT NULL,
+    url TEXT NOT NULL,
+    payload TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    http_status INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_attempt TIMESTAMP WITH TIME ZONE,
+    next_attempt TIMESTAMP WITH TIME ZONE
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_WEBHOOK_DELIVERY_WEBHOOK', 'workflow_webhook_delivery', 'workflow_webhook', 'workflow_webhook_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_WEBHOOK_DELIVERY_WORKFLOW_RUN', 'workflow_webhook_delivery', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_unique_index('workflow_webhook_delivery', 'IDX_WORKFLOW_WEBHOOK_DELIVERY_EVENT', 'workflow_webhook_id,workflow_run_id,workflow_node_run_id,event,run_status');
+SELECT create_index('workflow_webhook_delivery', 'IDX_WORKFLOW_WEBHOOK_DELIVERY_STATUS', 'status,next_attempt');
+SELECT create_index('workflow_webhook_delivery', 'IDX_WORKFLOW_WEBHOOK_DELIVERY_WORKFLOW', 'workflow_id,created');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_webhook_delivery";
+DROP TABLE IF EXISTS "workflow_webhook";
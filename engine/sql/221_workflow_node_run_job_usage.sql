@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_node_run_job_usage" (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    workflow_id BIGINT NOT NULL,
+    workflow_name VARCHAR(256) NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    run_number BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    workflow_node_run_job_id BIGINT NOT NULL,
+    node_name VARCHAR(256) NOT NULL DEFAULT '',
+    job_name VARCHAR(256) NOT NULL DEFAULT '',
+    model VARCHAR(256) NOT NULL DEFAULT '',
+    flavor VARCHAR(256) NOT NULL DEFAULT '',
+    start TIMESTAMP WITH TIME ZONE NOT NULL,
+    done TIMESTAMP WITH TIME ZONE,
+    duration_seconds BIGINT NOT NULL DEFAULT 0,
+    artifact_bytes BIGINT NOT NULL DEFAULT 0,
+    cache_bytes BIGINT NOT NULL DEFAULT 0
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_JOB_USAGE_PROJECT', 'workflow_node_run_job_usage', 'project', 'project_id', 'id');
+SELECT create_unique_index('workflow_node_run_job_usage', 'IDX_WORKFLOW_NODE_RUN_JOB_USAGE_JOB', 'workflow_node_run_job_id');
+SELECT create_index('workflow_node_run_job_usage', 'IDX_WORKFLOW_NODE_RUN_JOB_USAGE_START', 'project_id,start');
+SELECT create_index('workflow_node_run_job_usage', 'IDX_WORKFLOW_NODE_RUN_JOB_USAGE_WORKFLOW', 'workflow_id,run_number');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_node_run_job_usage";
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE "worker" ADD COLUMN IF NOT EXISTS drain BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE "worker" ADD COLUMN IF NOT EXISTS shutdown_reason VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE "worker" ADD COLUMN IF NOT EXISTS shutdown_message TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE "worker" DROP COLUMN IF EXISTS drain;
+ALTER TABLE "worker" DROP COLUMN IF EXISTS shutdown_reason;
+ALTER TABLE "worker" DROP COLUMN IF EXISTS shutdown_message;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "workflow_node_run_job" ADD COLUMN IF NOT EXISTS retry_after TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "workflow_node_run_job" ADD COLUMN IF NOT EXISTS infra_error VARCHAR(64) NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE "workflow_node_run_job" DROP COLUMN IF EXISTS retry_after;
+ALTER TABLE "workflow_node_run_job" DROP COLUMN IF EXISTS infra_error;
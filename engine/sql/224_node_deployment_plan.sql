@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "w_node_context" ADD COLUMN IF NOT EXISTS deployment_plan BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE "w_node_context" DROP COLUMN IF EXISTS deployment_plan;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "workflow_node_run_artifacts" ADD COLUMN IF NOT EXISTS scan_status VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE "workflow_node_run_artifacts" ADD COLUMN IF NOT EXISTS scan_report TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE "workflow_node_run_artifacts" DROP COLUMN IF EXISTS scan_status;
+ALTER TABLE "workflow_node_run_artifacts" DROP COLUMN IF EXISTS scan_report;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE "workflow" ADD COLUMN IF NOT EXISTS priority VARCHAR(16) NOT NULL DEFAULT 'default';
+ALTER TABLE "workflow_run" ADD COLUMN IF NOT EXISTS priority VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE "workflow_node_run_job" ADD COLUMN IF NOT EXISTS priority INT NOT NULL DEFAULT 1;
+
+-- +migrate Down
+ALTER TABLE "workflow" DROP COLUMN IF EXISTS priority;
+ALTER TABLE "workflow_run" DROP COLUMN IF EXISTS priority;
+ALTER TABLE "workflow_node_run_job" DROP COLUMN IF EXISTS priority;
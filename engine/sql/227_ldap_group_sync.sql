@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "ldap_sync_group" (
+    id BIGSERIAL PRIMARY KEY,
+    group_id BIGINT NOT NULL,
+    ldap_group VARCHAR(512) NOT NULL DEFAULT '',
+    opt_out BOOLEAN NOT NULL DEFAULT false,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_unique_index('ldap_sync_group', 'IDX_LDAP_SYNC_GROUP_GROUP_ID', 'group_id');
+SELECT create_foreign_key_idx_cascade('FK_LDAP_SYNC_GROUP_GROUP', 'ldap_sync_group', 'group', 'group_id', 'id');
+
+CREATE TABLE IF NOT EXISTS "ldap_sync_member" (
+    id BIGSERIAL PRIMARY KEY,
+    group_id BIGINT NOT NULL,
+    authentified_user_id VARCHAR(36) NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_unique_index('ldap_sync_member', 'IDX_LDAP_SYNC_MEMBER_GROUP_ID_USER_ID', 'group_id,authentified_user_id');
+SELECT create_foreign_key_idx_cascade('FK_LDAP_SYNC_MEMBER_GROUP', 'ldap_sync_member', 'group', 'group_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_LDAP_SYNC_MEMBER_AUTHENTIFIED_USER', 'ldap_sync_member', 'authentified_user', 'authentified_user_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "ldap_sync_group";
+DROP TABLE IF EXISTS "ldap_sync_member";
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "user_dashboard" (
+    id BIGSERIAL PRIMARY KEY,
+    authentified_user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    widgets JSONB,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_unique_index('user_dashboard', 'IDX_USER_DASHBOARD_NAME', 'authentified_user_id,name');
+SELECT create_foreign_key_idx_cascade('FK_USER_DASHBOARD_AUTHENTIFIED_USER', 'user_dashboard', 'authentified_user', 'authentified_user_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "user_dashboard";
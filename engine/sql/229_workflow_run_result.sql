@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_run_result" (
+    id BIGSERIAL PRIMARY KEY,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    sub_num BIGINT NOT NULL DEFAULT 0,
+    type VARCHAR(32) NOT NULL,
+    data JSONB NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_index('workflow_run_result', 'IDX_WORKFLOW_RUN_RESULT_RUN_ID_TYPE', 'workflow_run_id,type');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_RUN_RESULT_WORKFLOW_RUN', 'workflow_run_result', 'workflow_run', 'workflow_run_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_run_result";
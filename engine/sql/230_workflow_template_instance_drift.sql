@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "workflow_template_instance" ADD COLUMN IF NOT EXISTS drift JSONB;
+
+-- +migrate Down
+ALTER TABLE "workflow_template_instance" DROP COLUMN IF EXISTS drift;
@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_run_secret_usage" (
+    id BIGSERIAL PRIMARY KEY,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    workflow_node_job_run_id BIGINT NOT NULL,
+    sub_num BIGINT NOT NULL DEFAULT 0,
+    node_name VARCHAR(256) NOT NULL DEFAULT '',
+    job_name VARCHAR(256) NOT NULL DEFAULT '',
+    step_order INT NOT NULL DEFAULT -1,
+    name VARCHAR(256) NOT NULL,
+    type VARCHAR(32) NOT NULL DEFAULT '',
+    source VARCHAR(32) NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_index('workflow_run_secret_usage', 'IDX_WORKFLOW_RUN_SECRET_USAGE_RUN_ID_NAME', 'workflow_run_id,name');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_RUN_SECRET_USAGE_WORKFLOW_RUN', 'workflow_run_secret_usage', 'workflow_run', 'workflow_run_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_run_secret_usage";
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_version" (
+    id BIGSERIAL PRIMARY KEY,
+    workflow_id BIGINT NOT NULL,
+    version BIGINT NOT NULL,
+    author VARCHAR(256) NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    data TEXT NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_unique_index('workflow_version', 'IDX_WORKFLOW_VERSION_WORKFLOW_ID_VERSION', 'workflow_id,version');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_VERSION_WORKFLOW', 'workflow_version', 'workflow', 'workflow_id', 'id');
+
+ALTER TABLE "workflow_run" ADD COLUMN IF NOT EXISTS workflow_version BIGINT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_version";
+ALTER TABLE "workflow_run" DROP COLUMN IF EXISTS workflow_version;
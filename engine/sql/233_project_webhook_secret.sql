@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "project_webhook_secret" (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    secret BYTEA,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    expire_at TIMESTAMP WITH TIME ZONE,
+    sig BYTEA,
+    signer TEXT
+);
+
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_WEBHOOK_SECRET_PROJECT', 'project_webhook_secret', 'project', 'project_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "project_webhook_secret";
@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_run_job_fingerprint" (
+    id BIGSERIAL PRIMARY KEY,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    workflow_node_job_run_id BIGINT NOT NULL,
+    num BIGINT NOT NULL DEFAULT 0,
+    sub_num BIGINT NOT NULL DEFAULT 0,
+    node_name VARCHAR(256) NOT NULL DEFAULT '',
+    job_name VARCHAR(256) NOT NULL DEFAULT '',
+    status VARCHAR(64) NOT NULL DEFAULT '',
+    fingerprint JSONB,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_index('workflow_run_job_fingerprint', 'IDX_WORKFLOW_RUN_JOB_FINGERPRINT_NODE_JOB', 'node_name,job_name,status');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_RUN_JOB_FINGERPRINT_WORKFLOW_RUN', 'workflow_run_job_fingerprint', 'workflow_run', 'workflow_run_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_run_job_fingerprint";
@@ -0,0 +1,22 @@
+-- +migrate Up
+ALTER TABLE "w_node_context" ADD COLUMN IF NOT EXISTS release_targets JSONB;
+
+CREATE TABLE IF NOT EXISTS "workflow_run_release_target" (
+    id BIGSERIAL PRIMARY KEY,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    workflow_node_name VARCHAR(256) NOT NULL DEFAULT '',
+    project_integration_name VARCHAR(256) NOT NULL,
+    status VARCHAR(64) NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
+    attempts BIGINT NOT NULL DEFAULT 0,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_unique_index('workflow_run_release_target', 'IDX_WORKFLOW_RUN_RELEASE_TARGET_NODE_INTEGRATION', 'workflow_run_id,workflow_node_id,project_integration_name');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_RUN_RELEASE_TARGET_WORKFLOW_RUN', 'workflow_run_release_target', 'workflow_run', 'workflow_run_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_run_release_target";
+ALTER TABLE "w_node_context" DROP COLUMN IF EXISTS release_targets;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "workflow_project_integration" ADD COLUMN IF NOT EXISTS artifact_storage BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE "workflow_project_integration" ADD COLUMN IF NOT EXISTS artifact_routing JSONB;
+
+-- +migrate Down
+ALTER TABLE "workflow_project_integration" DROP COLUMN IF EXISTS artifact_routing;
+ALTER TABLE "workflow_project_integration" DROP COLUMN IF EXISTS artifact_storage;
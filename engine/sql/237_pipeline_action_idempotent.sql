@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "pipeline_action" ADD COLUMN IF NOT EXISTS idempotent BOOLEAN NOT NULL DEFAULT false;
+
+-- +migrate Down
+ALTER TABLE "pipeline_action" DROP COLUMN IF EXISTS idempotent;
@@ -172,3 +172,45 @@ func (client *bitbucketcloudClient) CommitsBetweenRefs(ctx context.Context, repo
 
 	return commitsResult, nil
 }
+
+// ChangedFiles returns the files changed between base and head, or by head if base is empty.
+func (client *bitbucketcloudClient) ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error) {
+	// The spec of a diffstat is the source commit, compared to its first parent or to the destination commit
+	spec := head
+	if base != "" {
+		spec = head + ".." + base
+	}
+	params := url.Values{}
+	path := fmt.Sprintf("/repositories/%s/diffstat/%s", repo, spec)
+	nextPage := 1
+
+	var files []string
+	for {
+		if ctx.Err() != nil {
+			return nil, sdk.WithStack(ctx.Err())
+		}
+
+		if nextPage != 1 {
+			params.Set("page", fmt.Sprintf("%d", nextPage))
+		}
+
+		var response DiffStats
+		if err := client.do(ctx, "GET", "core", path, params, nil, &response); err != nil {
+			return nil, sdk.WrapError(err, "Unable to get diffstat")
+		}
+		for _, d := range response.Values {
+			if d.New != nil {
+				files = append(files, d.New.Path)
+			}
+			if d.Old != nil && (d.New == nil || d.Old.Path != d.New.Path) {
+				files = append(files, d.Old.Path)
+			}
+		}
+
+		if response.Next == "" {
+			break
+		}
+		nextPage++
+	}
+	return files, nil
+}
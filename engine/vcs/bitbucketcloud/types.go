@@ -482,3 +482,21 @@ type Tag struct {
 		Type    string    `json:"type"`
 	} `json:"target"`
 }
+
+type DiffStats struct {
+	Pagelen int        `json:"pagelen"`
+	Page    int        `json:"page"`
+	Size    int64      `json:"size"`
+	Values  []DiffStat `json:"values"`
+	Next    string     `json:"next"`
+}
+
+type DiffStat struct {
+	Status string        `json:"status"`
+	Old    *DiffStatFile `json:"old"`
+	New    *DiffStatFile `json:"new"`
+}
+
+type DiffStatFile struct {
+	Path string `json:"path"`
+}
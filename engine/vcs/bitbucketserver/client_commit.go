@@ -186,3 +186,40 @@ func (b *bitbucketClient) CommitsBetweenRefs(ctx context.Context, repo, base, he
 	}
 	return commits, nil
 }
+
+// ChangedFiles returns the files changed between base and head, or by head if base is empty.
+func (b *bitbucketClient) ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error) {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/changes", project, slug)
+	params := url.Values{}
+	params.Add("until", head)
+	if base != "" {
+		params.Add("since", base)
+	}
+
+	var files []string
+	response := ChangesResponse{}
+	for {
+		if response.NextPageStart != 0 {
+			params.Set("start", fmt.Sprintf("%d", response.NextPageStart))
+		}
+		response = ChangesResponse{}
+		if err := b.do(ctx, "GET", "core", path, params, nil, &response, nil); err != nil {
+			return nil, sdk.WrapError(err, "Unable to get changes %s", path)
+		}
+		for _, c := range response.Values {
+			files = append(files, c.Path.ToString)
+			if c.SrcPath != nil && c.SrcPath.ToString != "" {
+				files = append(files, c.SrcPath.ToString)
+			}
+		}
+		if response.IsLastPage || response.NextPageStart == 0 {
+			break
+		}
+	}
+	return files, nil
+}
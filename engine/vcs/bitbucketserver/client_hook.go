@@ -76,9 +76,11 @@ func (b *bitbucketClient) CreateHook(ctx context.Context, repo string, hook *sdk
 		return err
 	}
 
+	// If the webhook already exists, it is updated to set the events and the secret
 	for _, h := range hooks {
 		if h.URL == hook.URL {
-			return nil
+			hook.ID = fmt.Sprintf("%d", h.ID)
+			return b.UpdateHook(ctx, repo, hook)
 		}
 	}
 
@@ -94,6 +96,9 @@ func (b *bitbucketClient) CreateHook(ctx context.Context, repo string, hook *sdk
 		Name:          repo,
 		Configuration: make(map[string]string),
 	}
+	if hook.Secret != "" {
+		request.Configuration["secret"] = hook.Secret
+	}
 
 	values, err := json.Marshal(&request)
 	if err != nil {
@@ -123,6 +128,17 @@ func (b *bitbucketClient) UpdateHook(ctx context.Context, repo string, hook *sdk
 	}
 
 	bitbucketHook.Events = hook.Events
+	bitbucketHook.Active = true
+	if hook.URL != "" {
+		bitbucketHook.URL = hook.URL
+	}
+	// The secret is never returned by Bitbucket, it is set again on each update
+	if bitbucketHook.Configuration == nil {
+		bitbucketHook.Configuration = make(map[string]string)
+	}
+	if hook.Secret != "" {
+		bitbucketHook.Configuration["secret"] = hook.Secret
+	}
 
 	url := fmt.Sprintf("/projects/%s/repos/%s/webhooks/%d", project, slug, bitbucketHook.ID)
 
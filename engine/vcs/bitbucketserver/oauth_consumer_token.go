@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+
+	"github.com/ovh/cds/sdk/cdsclient"
 )
 
 // Token is an interface for RequestToken and AccessToken
@@ -176,7 +178,7 @@ func (c *bitbucketConsumer) RequestToken() (*RequestToken, error) {
 	}
 
 	// make the http request and get the response
-	resp, err := http.DefaultClient.Do(&req)
+	resp, err := cdsclient.DefaultHTTPClient.Do(&req)
 	if err != nil {
 		return nil, err
 	}
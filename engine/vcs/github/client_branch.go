@@ -79,12 +79,13 @@ func (g *githubClient) Branches(ctx context.Context, fullname string) ([]sdk.VCS
 	}
 
 	branchesResult := []sdk.VCSBranch{}
-	for _, b := range branches {
+	for i, b := range branches {
 		branch := sdk.VCSBranch{
 			DisplayID:    b.Name,
 			ID:           b.Name,
 			LatestCommit: b.Commit.Sha,
 			Default:      b.Name == repo.DefaultBranch,
+			Protected:    &branches[i].Protected,
 		}
 		for _, p := range b.Commit.Parents {
 			branch.Parents = append(branch.Parents, p.Sha)
@@ -155,6 +156,7 @@ func (g *githubClient) Branch(ctx context.Context, fullname, theBranch string) (
 		ID:           branch.Name,
 		LatestCommit: branch.Commit.Sha,
 		Default:      branch.Name == repo.DefaultBranch,
+		Protected:    &branch.Protected,
 	}
 
 	if branch.Commit.Sha != "" {
@@ -323,3 +323,34 @@ func (g *githubClient) CommitsBetweenRefs(ctx context.Context, repo, base, head
 
 	return commits, nil
 }
+
+// ChangedFiles returns the files changed between base and head, or by head if base is empty. The previous name of
+// a renamed file is also returned.
+// https://developer.github.com/v3/repos/commits/#compare-two-commits
+func (g *githubClient) ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error) {
+	url := "/repos/" + repo + "/commits/" + head
+	if base != "" {
+		url = fmt.Sprintf("/repos/%s/compare/%s...%s", repo, base, head)
+	}
+	status, body, _, err := g.get(ctx, url, withoutETag)
+	if err != nil {
+		log.Warning(ctx, "githubClient.ChangedFiles> Error %s", err)
+		return nil, err
+	}
+	if status >= 400 {
+		return nil, sdk.NewError(sdk.ErrRepoNotFound, errorAPI(body))
+	}
+
+	var c CommitFiles
+	if err := json.Unmarshal(body, &c); err != nil {
+		return nil, sdk.WrapError(err, "unable to parse github commit files")
+	}
+	files := make([]string, 0, len(c.Files))
+	for _, f := range c.Files {
+		files = append(files, f.Filename)
+		if f.PreviousFilename != "" {
+			files = append(files, f.PreviousFilename)
+		}
+	}
+	return files, nil
+}
@@ -234,6 +234,7 @@ type Plan struct {
 type Branch struct {
 	Name       string     `json:"name,omitempty"`
 	Commit     Commit     `json:"commit,omitempty"`
+	Protected  bool       `json:"protected"`
 	Protection Protection `json:"protection,omitempty"`
 }
 
@@ -704,3 +705,11 @@ type UserPermissionResponse struct {
 	Permission string      `json:"permission"`
 	User       GithubOwner `json:"user"`
 }
+
+// CommitFiles represents the files changed by a commit or between two commits
+type CommitFiles struct {
+	Files []struct {
+		Filename         string `json:"filename"`
+		PreviousFilename string `json:"previous_filename"`
+	} `json:"files"`
+}
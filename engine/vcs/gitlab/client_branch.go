@@ -26,6 +26,7 @@ func (c *gitlabClient) Branches(ctx context.Context, fullname string) ([]sdk.VCS
 			LatestCommit: b.Commit.ID,
 			Default:      b.Name == p.DefaultBranch,
 			Parents:      nil,
+			Protected:    &branches[i].Protected,
 		}
 	}
 
@@ -46,6 +47,7 @@ func (c *gitlabClient) Branch(ctx context.Context, fullname, branchName string)
 		LatestCommit: b.Commit.ID,
 		Default:      false,
 		Parents:      nil,
+		Protected:    &b.Protected,
 	}
 
 	return br, nil
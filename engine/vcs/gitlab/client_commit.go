@@ -105,3 +105,38 @@ func (c *gitlabClient) CommitsBetweenRefs(ctx context.Context, repo, base, head
 
 	return vcscommits, nil
 }
+
+// ChangedFiles returns the files changed between base and head, or by head if base is empty.
+func (c *gitlabClient) ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error) {
+	if base == "" {
+		gc, _, err := c.client.Commits.GetCommit(repo, head)
+		if err != nil {
+			return nil, err
+		}
+		if len(gc.ParentIDs) == 0 {
+			return nil, nil
+		}
+		base = gc.ParentIDs[0]
+	}
+
+	opt := &gitlab.CompareOptions{
+		From: &base,
+		To:   &head,
+	}
+	compare, _, err := c.client.Repositories.Compare(repo, opt)
+	if err != nil {
+		return nil, err
+	}
+	if compare == nil {
+		return nil, nil
+	}
+
+	files := make([]string, 0, len(compare.Diffs))
+	for _, d := range compare.Diffs {
+		files = append(files, d.NewPath)
+		if d.OldPath != "" && d.OldPath != d.NewPath {
+			files = append(files, d.OldPath)
+		}
+	}
+	return files, nil
+}
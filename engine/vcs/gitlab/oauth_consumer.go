@@ -13,6 +13,7 @@ import (
 	"github.com/xanzy/go-gitlab"
 
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -64,7 +65,7 @@ func (g *gitlabConsumer) postForm(path string, data url.Values, headers map[stri
 		}
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := cdsclient.DefaultHTTPClient.Do(req)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -84,16 +84,18 @@ func (s *Service) getVCSServersHooksHandler() service.Handler {
 			return sdk.WithStack(sdk.ErrNotFound)
 		}
 		res := struct {
-			WebhooksSupported  bool     `json:"webhooks_supported"`
-			WebhooksDisabled   bool     `json:"webhooks_disabled"`
-			WebhooksIcon       string   `json:"webhooks_icon"`
-			GerritHookDisabled bool     `json:"gerrithook_disabled"`
-			Events             []string `json:"events"`
+			WebhooksSupported       bool     `json:"webhooks_supported"`
+			WebhooksDisabled        bool     `json:"webhooks_disabled"`
+			WebhooksSecretSupported bool     `json:"webhooks_secret_supported"`
+			WebhooksIcon            string   `json:"webhooks_icon"`
+			GerritHookDisabled      bool     `json:"gerrithook_disabled"`
+			Events                  []string `json:"events"`
 		}{}
 
 		switch {
 		case cfg.Bitbucket != nil:
 			res.WebhooksSupported = true
+			res.WebhooksSecretSupported = true
 			res.WebhooksDisabled = cfg.Bitbucket.DisableWebHooks
 			res.WebhooksIcon = sdk.BitbucketIcon
 			// https://confluence.atlassian.com/bitbucketserver/event-payload-938025882.html
@@ -591,6 +593,41 @@ func (s *Service) getCommitHandler() service.Handler {
 	}
 }
 
+func (s *Service) getCommitChangedFilesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		commit := muxVar(r, "commit")
+		base := r.URL.Query().Get("base")
+
+		accessToken, accessTokenSecret, created, ok := getAccessTokens(ctx)
+		if !ok {
+			return sdk.WrapError(sdk.ErrUnauthorized, "VCS> getCommitChangedFilesHandler> Unable to get access token headers %s %s/%s", name, owner, repo)
+		}
+
+		consumer, err := s.getConsumer(name)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret, created)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+		// Check if access token has been refreshed
+		if accessToken != client.GetAccessToken(ctx) {
+			w.Header().Set(sdk.HeaderXAccessToken, client.GetAccessToken(ctx))
+		}
+
+		files, err := client.ChangedFiles(ctx, fmt.Sprintf("%s/%s", owner, repo), base, commit)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get files changed by commit %s on %s/%s", commit, owner, repo)
+		}
+		return service.WriteJSON(w, files, http.StatusOK)
+	}
+}
+
 func (s *Service) getCommitStatusHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		name := muxVar(r, "name")
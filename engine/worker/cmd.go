@@ -20,6 +20,7 @@ import (
 const (
 	envFlagPrefix           = "cds_"
 	flagFromGithub          = "from-github"
+	flagAutoUpdate          = "auto-update"
 	flagBaseDir             = "basedir"
 	flagBookedWorkflowJobID = "booked-workflow-job-id"
 	flagGraylogProtocol     = "graylog-protocol"
@@ -34,11 +35,14 @@ const (
 	flagName                = "name"
 	flagModel               = "model"
 	flagHatcheryName        = "hatchery-name"
+	flagToolsCatalog        = "tools-catalog"
+	flagLogSpoolMaxSize     = "log-spool-max-size"
 )
 
 func initFlagsRun(cmd *cobra.Command) {
 	flags := cmd.Flags()
 	flags.Bool(flagFromGithub, false, "Update binary from latest github release")
+	flags.Bool(flagAutoUpdate, false, "Update binary from CDS API at startup if its version differs from the API version")
 	flags.String(flagBaseDir, "", "This directory (default TMPDIR os environment var) will contains worker working directory and temporary files")
 	flags.Int64(flagBookedWorkflowJobID, 0, "Booked Workflow job id")
 	flags.String(flagGraylogProtocol, "", "Ex: --graylog-protocol=xxxx-yyyy")
@@ -53,6 +57,8 @@ func initFlagsRun(cmd *cobra.Command) {
 	flags.String(flagName, "", "Name of worker")
 	flags.String(flagModel, "", "Model of worker")
 	flags.String(flagHatcheryName, "", "Hatchery Name spawing worker")
+	flags.String(flagToolsCatalog, "", "Path or URL of the JSON catalog of tools that can be installed with worker tools install")
+	flags.Int64(flagLogSpoolMaxSize, 100, "Maximum size in MB of the job logs waiting to be sent to CDS API, new log lines are dropped when it is reached")
 }
 
 // FlagBool replaces viper.GetBool
@@ -170,4 +176,6 @@ func initFromFlags(cmd *cobra.Command, w *internal.CurrentWorker) {
 		log.Error(context.TODO(), "Cannot init worker: %v", err)
 		os.Exit(1)
 	}
+	w.SetToolsCatalog(FlagString(cmd, flagToolsCatalog))
+	w.SetLogSpoolMaxSize(FlagInt64(cmd, flagLogSpoolMaxSize) * 1024 * 1024)
 }
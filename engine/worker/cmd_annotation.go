@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/sdk"
+)
+
+var cmdAnnotationType string
+
+func cmdAnnotation() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "annotation",
+		Short: "worker annotation [--type link|value|markdown] <key>=<value>",
+		Long: `
+Inside a job, you can attach an annotation to the workflow run with the worker command:
+
+	worker annotation "deployed version=1.2.3"
+	worker annotation --type link ticket=https://jira.example.com/browse/CDS-1
+	worker annotation --type markdown "release notes=$(cat notes.md)"
+
+
+Annotations are displayed on the workflow run view, and runs can be searched by annotation. An annotation with the same key
+as an existing annotation of the run replaces it.
+
+	`,
+		Run: annotationCmd,
+	}
+	c.Flags().StringVar(&cmdAnnotationType, "type", sdk.WorkflowRunAnnotationTypeValue, "Type of the annotation: link, value or markdown")
+	return c
+}
+
+func annotationCmd(cmd *cobra.Command, args []string) {
+	portS := os.Getenv(internal.WorkerServerPort)
+	if portS == "" {
+		sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+	}
+
+	port, err := strconv.Atoi(portS)
+	if err != nil {
+		sdk.Exit("cannot parse '%s' as a port number", portS)
+	}
+
+	if len(args) != 1 {
+		sdk.Exit("Wrong usage: See '%s'\n", cmd.Short)
+	}
+	kv := strings.SplitN(args[0], "=", 2)
+	if len(kv) != 2 {
+		sdk.Exit("Wrong usage: See '%s'\n", cmd.Short)
+	}
+
+	a := sdk.WorkflowRunAnnotation{
+		Type:  cmdAnnotationType,
+		Key:   kv[0],
+		Value: kv[1],
+	}
+	if err := a.IsValid(); err != nil {
+		sdk.Exit("cannot add annotation: %v\n", err)
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		sdk.Exit("internal error (%s)\n", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/annotation", port), bytes.NewReader(data))
+	if err != nil {
+		sdk.Exit("cannot add annotation: %s\n", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		sdk.Exit("cannot add annotation: %s\n", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		if body, err := ioutil.ReadAll(resp.Body); err == nil {
+			if cdsError := sdk.DecodeError(body); cdsError != nil {
+				sdk.Exit("cannot add annotation: %v\n", cdsError)
+			}
+		}
+		sdk.Exit("cannot add annotation: HTTP %d\n", resp.StatusCode)
+	}
+}
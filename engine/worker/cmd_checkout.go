@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	cmdCheckoutRef          string
+	cmdCheckoutDepth        int
+	cmdCheckoutFilter       string
+	cmdCheckoutNoSubmodules bool
+)
+
+func cmdCheckout() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "checkout",
+		Short: "worker checkout [--ref=<ref>] [--depth=<depth>] [--filter=<filter>] [--no-submodules] [<directory>]",
+		Long: `
+Inside a step script you can clone the repository of the application at any ref, with the same vcs strategy
+and key as the action CheckoutApplication.
+
+The ref can be a branch, a tag or a commit hash. Prefix it with refs/heads/ or refs/tags/ to force a branch or a tag.
+Without ref, the branch, tag or commit of the workflow run is cloned.
+
+	worker checkout --ref=v1.0.0 ./release
+	worker checkout --ref=refs/heads/develop --depth=1 --filter=blob:none ./develop
+
+The clone is shallow with a depth of 50 by default, use --depth=0 to make a full clone.
+		`,
+		Run: checkoutCmd(),
+	}
+	c.Flags().StringVar(&cmdCheckoutRef, "ref", "", "Branch, tag or commit to checkout. Optional, default: the ref of the workflow run")
+	c.Flags().IntVar(&cmdCheckoutDepth, "depth", workerruntime.DefaultCheckoutDepth, "Depth of the clone, 0 for a full clone")
+	c.Flags().StringVar(&cmdCheckoutFilter, "filter", "", "Object filter of a partial clone, ie. blob:none. Optional")
+	c.Flags().BoolVar(&cmdCheckoutNoSubmodules, "no-submodules", false, "Do not clone the submodules")
+	return c
+}
+
+func checkoutCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(internal.WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		if len(args) > 1 {
+			sdk.Exit("worker checkout: invalid arguments. %s\n", cmd.Short)
+		}
+		if cmdCheckoutDepth < 0 {
+			sdk.Exit("depth parameter have to be positive")
+		}
+
+		wd, _ := os.Getwd()
+		directory := wd
+		if len(args) == 1 {
+			directory = args[0]
+			if !filepath.IsAbs(directory) {
+				directory = filepath.Join(wd, directory)
+			}
+		}
+
+		a := workerruntime.Checkout{
+			Ref:        cmdCheckoutRef,
+			Directory:  directory,
+			Depth:      cmdCheckoutDepth,
+			Filter:     cmdCheckoutFilter,
+			Submodules: !cmdCheckoutNoSubmodules,
+		}
+
+		data, errMarshal := json.Marshal(a)
+		if errMarshal != nil {
+			sdk.Exit("internal error (%s)\n", errMarshal)
+		}
+
+		req, errRequest := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/checkout", port), bytes.NewReader(data))
+		if errRequest != nil {
+			sdk.Exit("cannot post worker checkout (Request): %s\n", errRequest)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 30 * time.Minute
+
+		resp, errDo := client.Do(req)
+		if errDo != nil {
+			sdk.Exit("cannot post worker checkout (Do): %s\n", errDo)
+		}
+		defer resp.Body.Close() // nolint
+
+		if resp.StatusCode >= 300 {
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				sdk.Exit("cannot checkout HTTP %v\n", err)
+			}
+			cdsError := sdk.DecodeError(body)
+			sdk.Exit("checkout failed: %v\n", cdsError)
+		}
+	}
+}
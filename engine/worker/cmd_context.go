@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/sdk"
+)
+
+func cmdContext() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "context",
+		Short: "worker context",
+		Long: `
+Inside a job, you can get the context of the job as JSON instead of parsing environment variables:
+
+	worker context | jq -r '.node.workflow'
+	worker context | jq -r '.integration.config.namespace'
+
+The context contains the job, the workflow node run, the git information, all the parameters and variables, and the
+integration of the node with its config. The values of secrets are never given.
+
+	`,
+		Run: contextCmd,
+	}
+	return c
+}
+
+func contextCmd(cmd *cobra.Command, args []string) {
+	portS := os.Getenv(internal.WorkerServerPort)
+	if portS == "" {
+		sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+	}
+
+	port, err := strconv.Atoi(portS)
+	if err != nil {
+		sdk.Exit("cannot parse '%s' as a port number", portS)
+	}
+
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/context", port))
+	if err != nil {
+		sdk.Exit("cannot get job context: %s\n", err)
+	}
+	defer resp.Body.Close() // nolint
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		sdk.Exit("cannot read job context: %s\n", err)
+	}
+	if resp.StatusCode >= 300 {
+		if cdsError := sdk.DecodeError(body); cdsError != nil {
+			sdk.Exit("cannot get job context: %v\n", cdsError)
+		}
+		sdk.Exit("cannot get job context: HTTP %d\n", resp.StatusCode)
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		sdk.Exit("cannot read job context: %s\n", err)
+	}
+	fmt.Println(out.String())
+}
@@ -20,13 +20,15 @@ var (
 	cmdDownloadWorkflowName string
 	cmdDownloadNumber       string
 	cmdDownloadArtefactName string
+	cmdDownloadGlobs        []string
 	cmdDownloadTag          string
+	cmdDownloadParallel     int
 )
 
 func cmdDownload() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "download",
-		Short: "worker download [--workflow=<workflow-name>] [--number=<run-number>] [--tag=<tag>] [--pattern=<pattern>]",
+		Short: "worker download [--workflow=<workflow-name>] [--number=<run-number>] [--tag=<tag>] [--pattern=<pattern>] [--glob=<glob>] [--parallel=<n>]",
 		Long: `
 Inside a job, there are two ways to download an artifact:
 
@@ -41,6 +43,12 @@ Example:
 
 	worker download --pattern="files.*.yml"
 
+Glob patterns can also be used to select artifacts by name, an artifact is downloaded if it matches one of them:
+
+	worker download --glob="*.tar.gz" --glob="report-*.xml" --parallel=8
+
+Matching artifacts are downloaded in parallel, 4 at the same time by default.
+
 Theses two commands have the same result:
 
 	worker download
@@ -52,7 +60,9 @@ Theses two commands have the same result:
 	c.Flags().StringVar(&cmdDownloadWorkflowName, "workflow", "", "Workflow name to download from. Optional, default: current workflow")
 	c.Flags().StringVar(&cmdDownloadNumber, "number", "", "Workflow Number to download from. Optional, default: current workflow run")
 	c.Flags().StringVar(&cmdDownloadArtefactName, "pattern", "", "Pattern matching files to download. Optional, default: *")
+	c.Flags().StringSliceVar(&cmdDownloadGlobs, "glob", nil, "Glob pattern matching files to download, can be repeated. Optional")
 	c.Flags().StringVar(&cmdDownloadTag, "tag", "", "Tag matching files to download. Optional")
+	c.Flags().IntVar(&cmdDownloadParallel, "parallel", workerruntime.DefaultDownloadParallel, "Maximum number of files downloaded at the same time. Optional")
 	return c
 }
 
@@ -68,6 +78,10 @@ func downloadCmd() func(cmd *cobra.Command, args []string) {
 			sdk.Exit("cannot parse '%s' as a port number", portS)
 		}
 
+		if cmdDownloadParallel < 1 {
+			sdk.Exit("parallel parameter have to be greater than 0")
+		}
+
 		var number int64
 		if cmdDownloadNumber != "" {
 			var errN error
@@ -82,8 +96,10 @@ func downloadCmd() func(cmd *cobra.Command, args []string) {
 			Workflow:    cmdDownloadWorkflowName,
 			Number:      number,
 			Pattern:     cmdDownloadArtefactName,
+			Globs:       cmdDownloadGlobs,
 			Tag:         cmdDownloadTag,
 			Destination: wd,
+			Parallel:    cmdDownloadParallel,
 		}
 
 		data, errMarshal := json.Marshal(a)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"strconv"
@@ -14,10 +15,13 @@ import (
 	"github.com/ovh/cds/sdk"
 )
 
-var cmdExport = &cobra.Command{
-	Use:   "export",
-	Short: "worker export <varname> <value>",
-	Long: `
+var cmdExportOutput bool
+
+func cmdExport() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "export",
+		Short: "worker export [--output] <varname> <value>",
+		Long: `
 Inside a step script (https://ovh.github.io/cds/docs/actions/builtin-script/), you can create a build variable with the worker command:
 
 	worker export foo bar
@@ -36,8 +40,23 @@ You can use the build variable in :
 * the next stages in same pipeline ` + "`{{.cds.build.varname}}`" + `
 * the next pipelines ` + "`{{.workflow.pipelineName.build.varname}}`" + ` with ` + "`pipelineName`" + ` the name of the pipeline in your workflow
 
+## Step outputs
+
+With the ` + "`--output`" + ` flag, the variable is exported as an output of the current step, the step must have a name:
+
+	worker export --output version 1.2.3
+
+
+then, you can use the output:
+
+* in the next steps of the current job and in the next stages in same pipeline with ` + "`{{.outputs.stepname.varname}}`" + `
+* in the next pipelines with ` + "`{{.outputs.stepname.varname}}`" + ` or ` + "`{{.workflow.pipelineName.outputs.stepname.varname}}`" + `
+
 	`,
-	Run: exportCmd,
+		Run: exportCmd,
+	}
+	c.Flags().BoolVar(&cmdExportOutput, "output", false, "Export the variable as an output of the current step")
+	return c
 }
 
 func exportCmd(cmd *cobra.Command, args []string) {
@@ -66,7 +85,11 @@ func exportCmd(cmd *cobra.Command, args []string) {
 		sdk.Exit("internal error (%s)\n", err)
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/var", port), bytes.NewReader(data))
+	url := fmt.Sprintf("http://127.0.0.1:%d/var", port)
+	if cmdExportOutput {
+		url += "?output=true"
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
 	if err != nil {
 		sdk.Exit("cannot add variable: %s\n", err)
 	}
@@ -77,6 +100,11 @@ func exportCmd(cmd *cobra.Command, args []string) {
 	}
 
 	if resp.StatusCode >= 300 {
+		if body, err := ioutil.ReadAll(resp.Body); err == nil {
+			if cdsError := sdk.DecodeError(body); cdsError != nil {
+				sdk.Exit("cannot add variable: %v\n", cdsError)
+			}
+		}
 		sdk.Exit("cannot add variable: HTTP %d\n", resp.StatusCode)
 	}
 }
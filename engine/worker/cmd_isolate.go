@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func cmdIsolate() *cobra.Command {
+	var i workerruntime.Isolation
+	c := &cobra.Command{
+		Use:    workerruntime.IsolationCommand,
+		Long:   "worker isolate is a subcommand used by the worker to run a step of a job in isolation mode. This is not directly useful for end user",
+		Hidden: true, // user should not use this command directly
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				sdk.Exit("Wrong usage: worker %s [flags] -- <command> [args]\n", workerruntime.IsolationCommand)
+			}
+			if err := workerruntime.Isolate(i, args[0], args[1:]...); err != nil {
+				// The exit code of the step command is returned as is
+				if exitErr, ok := sdk.Cause(err).(*exec.ExitError); ok {
+					os.Exit(exitErr.ExitCode())
+				}
+				sdk.Exit("isolation failed: %v\n", err)
+			}
+		},
+	}
+	c.Flags().StringVar(&i.Root, "root", "", "Empty directory on which the root filesystem of the step is mounted")
+	c.Flags().StringVar(&i.Dir, "dir", "", "Working directory of the step")
+	c.Flags().StringArrayVar(&i.Writable, "rw", nil, "Path visible in read-write mode, can be repeated")
+	c.Flags().StringArrayVar(&i.ReadOnly, "ro", nil, "Path visible in read-only mode, can be repeated")
+	c.Flags().IntVar(&i.UID, "uid", 0, "User id of the step")
+	c.Flags().IntVar(&i.GID, "gid", 0, "Group id of the step")
+	return c
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/xml"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
@@ -20,6 +19,8 @@ func cmdJunitParser() *cobra.Command {
 		Long: `
 worker junit-parser command helps you to parse junit files and print a summary. 
 
+JUnit XML, TAP and go test -json reports are supported, the format is detected from the file content.
+
 It displays the number of tests, the number of passed tests, the number of failed tests and the number of skipped tests.
 
 Examples:
@@ -29,6 +30,8 @@ Examples:
 	10 10 0 0
 	$ worker junit-parser *.xml
 	20 20 0 0
+	$ go test -json ./... > report.json && worker junit-parser report.json
+	42 41 1 0
 `,
 		RunE: junitParserCmd(),
 	}
@@ -48,21 +51,15 @@ func junitParserCmd() func(cmd *cobra.Command, args []string) error {
 
 		var tests venom.Tests
 		for _, f := range filepaths {
-			var ftests venom.Tests
 			data, err := ioutil.ReadFile(f)
 			if err != nil {
 				return fmt.Errorf("junit parser: cannot read file %s (%s)", f, err)
 			}
-			var vf venom.Tests
-			if err := xml.Unmarshal(data, &vf); err != nil {
-				// Check if file contains testsuite only (and no testsuites)
-				if s, ok := action.ParseTestsuiteAlone(data); ok {
-					ftests.TestSuites = append(ftests.TestSuites, s)
-				}
-				tests.TestSuites = append(tests.TestSuites, ftests.TestSuites...)
-			} else {
-				tests.TestSuites = append(tests.TestSuites, vf.TestSuites...)
+			suites, err := action.ParseTestReport(filepath.Base(f), data)
+			if err != nil {
+				return fmt.Errorf("junit parser: cannot parse file %s (%s)", f, err)
 			}
+			tests.TestSuites = append(tests.TestSuites, suites...)
 		}
 
 		var res sdk.Result
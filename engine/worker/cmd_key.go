@@ -72,6 +72,14 @@ export PKEY="/tmp/5/0/2569/655/bd925028e70aea34/cds.key.proj-mykey.priv";
 
 So that, you can use custom git commands the previous installed SSH key.
 
+On Windows workers, the access to the installed SSH key is restricted to the worker user with ` + "`icacls`" + ` as expected by OpenSSH.
+The key can also be loaded in an agent by setting the ` + "`CDS_WORKER_KEY_AGENT`" + ` environment variable on the worker model:
+` + "`ssh-agent`" + ` to use the OpenSSH Authentication Agent service with ` + "`ssh-add`" + `, or ` + "`pageant`" + ` to convert the key with ` + "`puttygen`" + ` and load it in Pageant.
+
+On Linux workers, keys and secrets can be kept off the disk by setting the ` + "`CDS_WORKER_SECRETS_MOUNT`" + ` environment variable to ` + "`true`" + ` on the worker model:
+a tmpfs is mounted for each job, installed keys and a file for each password variable of the job are written in it, and it is wiped at the end of the job.
+Its path is given to steps in the ` + "`CDS_SECRETS_DIR`" + ` environment variable. If the worker is not allowed to mount a filesystem, a directory is created in ` + "`/dev/shm`" + `.
+
 `,
 		Example: "worker key install proj-test",
 		Run:     keyInstallCmd(),
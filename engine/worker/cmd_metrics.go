@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/sdk"
+)
+
+func cmdMetrics() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "metrics",
+		Short: "worker metrics push [--type gauge|counter] [--label <name>=<value>] <metric>=<value>...",
+		Long: `
+Inside a job, you can publish build metrics like test counts, binary sizes or coverage to the Metrics integration of the project:
+
+	worker metrics push binary_size_bytes=$(stat -c %s bin/app)
+	worker metrics push --type counter --label suite=unit tests_passed_total=142 tests_failed_total=3
+
+Metrics are forwarded by CDS to the Prometheus Pushgateway or InfluxDB of the integration, with the labels cds_project, cds_workflow,
+cds_run, cds_node and cds_job of the run.
+
+	`,
+	}
+	c.AddCommand(cmdMetricsPush())
+	return c
+}
+
+var (
+	cmdMetricsPushType        string
+	cmdMetricsPushDescription string
+	cmdMetricsPushLabels      []string
+	cmdMetricsPushIntegration string
+)
+
+func cmdMetricsPush() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "push",
+		Short:   "worker metrics push [--type gauge|counter] [--label <name>=<value>] <metric>=<value>...",
+		Example: "worker metrics push --label os=linux binary_size_bytes=1048576",
+		Run:     metricsPushCmd,
+	}
+	c.Flags().StringVar(&cmdMetricsPushType, "type", sdk.JobMetricTypeGauge, "Type of the metrics: gauge or counter")
+	c.Flags().StringVar(&cmdMetricsPushDescription, "description", "", "Description of the metrics")
+	c.Flags().StringArrayVar(&cmdMetricsPushLabels, "label", nil, "Label added to the metrics, with format <name>=<value>")
+	c.Flags().StringVar(&cmdMetricsPushIntegration, "integration", "", "Name of the Metrics integration, required if the project has many Metrics integrations")
+	return c
+}
+
+func metricsPushCmd(cmd *cobra.Command, args []string) {
+	portS := os.Getenv(internal.WorkerServerPort)
+	if portS == "" {
+		sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+	}
+
+	port, err := strconv.Atoi(portS)
+	if err != nil {
+		sdk.Exit("cannot parse '%s' as a port number", portS)
+	}
+
+	if len(args) == 0 {
+		sdk.Exit("Wrong usage: See '%s'\n", cmd.Short)
+	}
+
+	labels := make(map[string]string, len(cmdMetricsPushLabels))
+	for _, l := range cmdMetricsPushLabels {
+		kv := strings.SplitN(l, "=", 2)
+		if len(kv) != 2 {
+			sdk.Exit("invalid label %q, it should have format <name>=<value>\n", l)
+		}
+		labels[kv[0]] = kv[1]
+	}
+
+	m := sdk.JobMetrics{Integration: cmdMetricsPushIntegration}
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			sdk.Exit("Wrong usage: See '%s'\n", cmd.Short)
+		}
+		value, err := strconv.ParseFloat(kv[1], 64)
+		if err != nil {
+			sdk.Exit("invalid value %q for metric %s, it should be a number\n", kv[1], kv[0])
+		}
+		m.Metrics = append(m.Metrics, sdk.JobMetric{
+			Name:   kv[0],
+			Type:   cmdMetricsPushType,
+			Help:   cmdMetricsPushDescription,
+			Value:  value,
+			Labels: labels,
+		})
+	}
+	if err := m.IsValid(); err != nil {
+		sdk.Exit("cannot push metrics: %v\n", err)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		sdk.Exit("internal error (%s)\n", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/metrics/push", port), bytes.NewReader(data))
+	if err != nil {
+		sdk.Exit("cannot push metrics: %s\n", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		sdk.Exit("cannot push metrics: %s\n", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		if body, err := ioutil.ReadAll(resp.Body); err == nil {
+			if cdsError := sdk.DecodeError(body); cdsError != nil {
+				sdk.Exit("cannot push metrics: %v\n", cdsError)
+			}
+		}
+		sdk.Exit("cannot push metrics: HTTP %d\n", resp.StatusCode)
+	}
+}
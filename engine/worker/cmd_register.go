@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -25,11 +26,12 @@ func cmdRegisterRun() func(cmd *cobra.Command, args []string) {
 	return func(cmd *cobra.Command, args []string) {
 		var w = new(internal.CurrentWorker)
 		initFromFlags(cmd, w)
+		autoUpdateFromFlags(context.Background(), cmd, w)
 
 		if err := w.Register(context.Background()); err != nil {
 			log.Error(context.TODO(), "Unable to register worker %v", err)
 		}
-		if err := w.Unregister(context.Background()); err != nil {
+		if err := w.Unregister(context.Background(), sdk.WorkerShutdown{Reason: sdk.WorkerShutdownReasonJobDone}); err != nil {
 			log.Error(context.TODO(), "Unable to unregister worker %v", err)
 		}
 	}
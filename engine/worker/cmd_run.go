@@ -34,6 +34,9 @@ func runCmd() func(cmd *cobra.Command, args []string) {
 		// Setup workerfrom commandline flags or env variables
 		initFromFlags(cmd, w)
 
+		// Update the worker binary before registering, the updated binary is executed with the same arguments
+		autoUpdateFromFlags(ctx, cmd, w)
+
 		// Get the booked job ID
 		bookedWJobID := FlagInt64(cmd, flagBookedWorkflowJobID)
 
@@ -47,14 +50,20 @@ func runCmd() func(cmd *cobra.Command, args []string) {
 			cancel()
 		}()
 
+		// The first signal drains the worker: it finishes its current job before stopping. The second one stops it now.
 		go func() {
 			select {
 			case <-c:
-				cancel()
-				return
+				log.Info(ctx, "Draining worker, send the signal again to stop it now")
+				w.Drain()
 			case <-ctx.Done():
 				return
 			}
+			select {
+			case <-c:
+				cancel()
+			case <-ctx.Done():
+			}
 		}()
 		// Start the worker
 		if err := internal.StartWorker(ctx, w, bookedWJobID); err != nil {
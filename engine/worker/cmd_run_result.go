@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/sdk"
+)
+
+func cmdRunResult() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "run-result",
+		Short: "worker run-result docker-image|changelog",
+		Long: `
+Inside a job, you can register what the job produced as a result of the workflow run. Results are displayed on the
+workflow run view and can be listed with the API or cdsctl, without having to parse the logs of the job.
+
+	worker run-result docker-image registry.example.com/team/my-app:1.2.3 --digest sha256:2c26b4... --label git.hash={{.git.hash}}
+	worker run-result changelog CHANGELOG.md --version {{.cds.semver.next}}
+
+	`,
+	}
+	c.AddCommand(cmdRunResultDockerImage())
+	c.AddCommand(cmdRunResultChangelog())
+	return c
+}
+
+var (
+	cmdRunResultDockerImageDigest string
+	cmdRunResultDockerImageLabels []string
+)
+
+func cmdRunResultDockerImage() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "docker-image",
+		Short: "worker run-result docker-image <ref> [--digest sha256:<hash>] [--label <key>=<value>]...",
+		Long: `
+Register a docker image built or pushed by the job. The digest can be given with the --digest flag or in the
+reference of the image.
+
+	worker run-result docker-image registry.example.com/team/my-app:1.2.3 --digest sha256:2c26b4... --label git.hash={{.git.hash}}
+	worker run-result docker-image registry.example.com/team/my-app@sha256:2c26b4...
+
+	`,
+		Run: runResultDockerImageCmd,
+	}
+	c.Flags().StringVar(&cmdRunResultDockerImageDigest, "digest", "", "Digest of the image: sha256:<hash>")
+	c.Flags().StringArrayVar(&cmdRunResultDockerImageLabels, "label", nil, "Label of the image: <key>=<value>, can be repeated")
+	return c
+}
+
+func runResultDockerImageCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		sdk.Exit("Wrong usage: See '%s'\n", cmd.Short)
+	}
+
+	image := sdk.WorkflowRunResultDockerImage{
+		Ref:    args[0],
+		Digest: cmdRunResultDockerImageDigest,
+	}
+	for _, l := range cmdRunResultDockerImageLabels {
+		kv := strings.SplitN(l, "=", 2)
+		if len(kv) != 2 {
+			sdk.Exit("invalid label %q, it should be <key>=<value>\n", l)
+		}
+		if image.Labels == nil {
+			image.Labels = make(map[string]string, len(cmdRunResultDockerImageLabels))
+		}
+		image.Labels[kv[0]] = kv[1]
+	}
+
+	var res sdk.WorkflowRunResult
+	if err := res.SetDockerImage(image); err != nil {
+		sdk.Exit("internal error (%s)\n", err)
+	}
+	sendRunResult(res, "docker image")
+}
+
+var cmdRunResultChangelogVersion string
+
+func cmdRunResultChangelog() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "changelog",
+		Short: "worker run-result changelog <file> [--version <version>]",
+		Long: `
+Register the changelog of the version built by the job, in markdown. The changelog is published by the release
+pipelines of the workflow with the other results.
+
+	worker run-result changelog CHANGELOG.md --version {{.cds.semver.next}}
+
+	`,
+		Run: runResultChangelogCmd,
+	}
+	c.Flags().StringVar(&cmdRunResultChangelogVersion, "version", "", "Version described by the changelog")
+	return c
+}
+
+func runResultChangelogCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		sdk.Exit("Wrong usage: See '%s'\n", cmd.Short)
+	}
+
+	content, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		sdk.Exit("cannot read changelog %s: %v\n", args[0], err)
+	}
+
+	var res sdk.WorkflowRunResult
+	if err := res.SetChangelog(sdk.WorkflowRunResultChangelog{
+		Version: cmdRunResultChangelogVersion,
+		Content: string(content),
+	}); err != nil {
+		sdk.Exit("internal error (%s)\n", err)
+	}
+	sendRunResult(res, "changelog")
+}
+
+// sendRunResult checks given result and sends it to the worker that runs the job.
+func sendRunResult(res sdk.WorkflowRunResult, kind string) {
+	portS := os.Getenv(internal.WorkerServerPort)
+	if portS == "" {
+		sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+	}
+
+	port, err := strconv.Atoi(portS)
+	if err != nil {
+		sdk.Exit("cannot parse '%s' as a port number", portS)
+	}
+
+	if err := res.IsValid(); err != nil {
+		sdk.Exit("cannot add %s: %v\n", kind, err)
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		sdk.Exit("internal error (%s)\n", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/run-result", port), bytes.NewReader(data))
+	if err != nil {
+		sdk.Exit("cannot add %s: %s\n", kind, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		sdk.Exit("cannot add %s: %s\n", kind, err)
+	}
+
+	if resp.StatusCode >= 300 {
+		if body, err := ioutil.ReadAll(resp.Body); err == nil {
+			if cdsError := sdk.DecodeError(body); cdsError != nil {
+				sdk.Exit("cannot add %s: %v\n", kind, cdsError)
+			}
+		}
+		sdk.Exit("cannot add %s: HTTP %d\n", kind, resp.StatusCode)
+	}
+}
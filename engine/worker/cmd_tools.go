@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func cmdTools() *cobra.Command {
+	cmdToolsRoot := &cobra.Command{
+		Use:  "tools",
+		Long: "Inside a step script you can install a tool declared in the tools catalog of the worker",
+	}
+	cmdToolsRoot.AddCommand(cmdToolsInstall())
+
+	return cmdToolsRoot
+}
+
+func cmdToolsInstall() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "install",
+		Aliases: []string{"i", "add"},
+		Short:   "worker tools install <name> <version>",
+		Long: `
+Inside a step script you can install a tool declared in the tools catalog of the worker (see ` + "`--tools-catalog`" + ` flag of the worker).
+
+The tool archive is downloaded, its sha256 checksum is verified then it is extracted in the job workspace. The directory that contains the tool binaries is added at the beginning of the PATH of all the next steps of the job.
+
+` + "```" + `
+$ worker tools install helm 3.2.4
+` + "```" + `
+
+The command prints the directory that contains the tool binaries, so you can use the tool in the current step:
+
+` + "```" + `
+$ export PATH=$(worker tools install helm 3.2.4):$PATH
+$ helm version
+` + "```" + `
+`,
+		Example: "worker tools install helm 3.2.4",
+		Run:     toolsInstallCmd(),
+	}
+	return c
+}
+
+func toolsInstallCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(internal.WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("Error: worker tools install > %s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("Error: worker tools install > Cannot parse '%s' as a port number : %s\n", portS, errPort)
+		}
+
+		if len(args) != 2 {
+			sdk.Exit("Error: worker tools install > Wrong usage: Example : worker tools install helm 3.2.4\n")
+		}
+
+		buffer, _ := json.Marshal(workerruntime.ToolInstall{Name: args[0], Version: args[1]})
+		req, errRequest := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/tools/install", port), bytes.NewReader(buffer))
+		if errRequest != nil {
+			sdk.Exit("Error: worker tools install > cannot post worker tools install (Request): %s\n", errRequest)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 10 * time.Minute
+
+		resp, errDo := client.Do(req)
+		if errDo != nil {
+			sdk.Exit("Error: worker tools install > cannot post worker tools install (Do): %s\n", errDo)
+		}
+		defer resp.Body.Close() // nolint
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			sdk.Exit("Error: worker tools install > HTTP body read error %v\n", err)
+		}
+
+		if resp.StatusCode >= 300 {
+			cdsError := sdk.DecodeError(body)
+			if cdsError != nil {
+				sdk.Exit("Error: worker tools install > error: %v\n", cdsError)
+			} else {
+				sdk.Exit(string(body))
+			}
+		}
+
+		var toolResp workerruntime.ToolInstallResponse
+		if err := json.Unmarshal(body, &toolResp); err != nil {
+			sdk.Exit("Error: worker tools install > cannot unmarshall tool response: %s", string(body))
+		}
+
+		fmt.Println(toolResp.Path)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/inconshreveable/go-update"
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// autoUpdateFromFlags updates the worker binary before registering if the auto update is allowed by the hatchery. The
+// worker keeps its current version if the update fails.
+func autoUpdateFromFlags(ctx context.Context, cmd *cobra.Command, w *internal.CurrentWorker) {
+	if !FlagBool(cmd, flagAutoUpdate) {
+		return
+	}
+	if err := autoUpdate(ctx, w.Client(), FlagBool(cmd, flagInsecure)); err != nil {
+		log.Error(ctx, "Unable to update worker binary: %v", err)
+	}
+}
+
+// autoUpdate replaces the worker binary by the one served by the API if their versions differ, then executes the new
+// binary with the same arguments. It returns without error if the worker is up to date.
+func autoUpdate(ctx context.Context, client cdsclient.WorkerInterface, insecure bool) error {
+	apiVersion, err := client.Version()
+	if err != nil {
+		return sdk.WrapError(err, "cannot get API version")
+	}
+	if !sdk.IsWorkerUpdateNeeded(sdk.VERSION, apiVersion.Version) {
+		log.Debug("worker version %s is up to date with API version %s", sdk.VERSION, apiVersion.Version)
+		return nil
+	}
+
+	urlBinary := client.DownloadURLFromAPI("worker", sdk.GOOS, sdk.GOARCH, "")
+	log.Info(ctx, "Updating worker binary from version %s to %s with %s", sdk.VERSION, apiVersion.Version, urlBinary)
+
+	resp, err := cdsclient.NewHTTPClient(5*time.Minute, insecure).Get(urlBinary)
+	if err != nil {
+		return sdk.WrapError(err, "cannot download worker binary from %s", urlBinary)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot download worker binary from %s: http code %d", urlBinary, resp.StatusCode)
+	}
+	if err := sdk.CheckContentTypeBinary(resp); err != nil {
+		return err
+	}
+
+	if err := update.Apply(resp.Body, update.Options{}); err != nil {
+		return sdk.WrapError(err, "cannot replace worker binary")
+	}
+
+	path, err := os.Executable()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	// The new binary may serve another version than the API one, it must not try to update again
+	if err := os.Setenv("CDS_AUTO_UPDATE", "false"); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	log.Info(ctx, "Executing updated worker binary %s", path)
+	return execWorker(path, os.Args, os.Environ())
+}
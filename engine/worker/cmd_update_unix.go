@@ -0,0 +1,14 @@
+// +build !windows
+
+package main
+
+import (
+	"syscall"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// execWorker replaces the current process by the updated worker binary.
+func execWorker(path string, args, env []string) error {
+	return sdk.WithStack(syscall.Exec(path, args, env))
+}
@@ -0,0 +1,28 @@
+// +build windows
+
+package main
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// execWorker runs the updated worker binary then exits with its exit code, as the current process can't be replaced
+// on Windows.
+func execWorker(path string, args, env []string) error {
+	cmd := exec.Command(path, args[1:]...)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		return sdk.WithStack(err)
+	}
+	os.Exit(0)
+	return nil
+}
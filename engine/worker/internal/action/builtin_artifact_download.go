@@ -92,6 +92,14 @@ func RunArtifactDownload(ctx context.Context, wk workerruntime.Runtime, a sdk.Ac
 			continue
 		}
 
+		if a.IsQuarantined() {
+			res.Status = sdk.StatusFail
+			res.Reason = fmt.Sprintf("%s is infected and was quarantined: %s", a.Name, a.ScanReport)
+			wk.SendLog(ctx, workerruntime.LevelError, res.Reason)
+			wg.Done()
+			continue
+		}
+
 		go func(a *sdk.WorkflowNodeRunArtifact) {
 			defer wg.Done()
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -73,7 +74,12 @@ func RunArtifactUpload(ctx context.Context, wk workerruntime.Runtime, a sdk.Acti
 		}
 	}()
 
-	integrationName := sdk.DefaultIfEmptyStorage(strings.TrimSpace(sdk.ParameterValue(a.Parameters, "destination")))
+	// Without destination, the artifacts go to the storage pinned by the workflow for the node
+	integrationName := strings.TrimSpace(sdk.ParameterValue(a.Parameters, "destination"))
+	if integrationName == "" {
+		integrationName = sdk.ParameterValue(wk.Parameters(), sdk.WorkflowArtifactStorageParameter)
+	}
+	integrationName = sdk.DefaultIfEmptyStorage(integrationName)
 	projectKey := sdk.ParameterValue(wk.Parameters(), "cds.project")
 
 	wg.Add(len(filesPath))
@@ -106,6 +112,14 @@ func RunArtifactUpload(ctx context.Context, wk workerruntime.Runtime, a sdk.Acti
 
 	if !globalError.IsEmpty() {
 		log.Error(ctx, "Error while uploading artifact: %v", globalError.Error())
+		// If the storage failed to store all the files the job will be retried
+		res.InfraError = sdk.JobInfraErrorStorageUnavailable
+		for _, err := range *globalError {
+			if sdk.ExtractHTTPError(err, "").Status < http.StatusInternalServerError {
+				res.InfraError = ""
+				break
+			}
+		}
 		return res, fmt.Errorf("error: %v", globalError.Error())
 	}
 
@@ -147,3 +147,41 @@ func TestRunArtifactUpload_Relative(t *testing.T) {
 	require.NotNil(t, res)
 	assert.Equal(t, sdk.StatusSuccess, res.Status)
 }
+
+func TestRunArtifactUpload_WorkflowArtifactStorage(t *testing.T) {
+	wk, ctx := SetupTest(t)
+	wk.Params = []sdk.Parameter{
+		{Name: "cds.project", Value: "project"},
+		{Name: sdk.WorkflowArtifactStorageParameter, Value: "eu-bucket"},
+	}
+	fname := filepath.Join(wk.workingDirectory.Name(), "foo")
+	assert.NoError(t, afero.WriteFile(wk.workspace, fname, []byte("something"), os.ModePerm))
+
+	gock.New("http://lolcat.host").Get("/project/project/storage/eu-bucket").
+		Reply(200)
+
+	gock.New("http://lolcat.host").Post("/project/project/storage/eu-bucket/artifact/dGFn").
+		Reply(200)
+
+	gock.InterceptClient(wk.Client().(cdsclient.Raw).HTTPClient())
+	gock.InterceptClient(wk.Client().(cdsclient.Raw).HTTPSSEClient())
+
+	res, err := RunArtifactUpload(ctx, wk,
+		sdk.Action{
+			Parameters: []sdk.Parameter{
+				{
+					Name:  "path",
+					Value: "foo",
+				}, {
+					Name:  "tag",
+					Value: "tag",
+				},
+			},
+		},
+		[]sdk.Variable{})
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, sdk.StatusSuccess, res.Status)
+	assert.True(t, gock.IsDone())
+}
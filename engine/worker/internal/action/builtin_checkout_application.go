@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
 	"github.com/ovh/cds/sdk"
@@ -11,16 +12,22 @@ import (
 	"github.com/spf13/afero"
 )
 
+var commitHashRegexp = regexp.MustCompile("^[0-9a-f]{7,40}$")
+
 func RunCheckoutApplication(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
 	// Load action param
-	directory := sdk.ParameterFind(a.Parameters, "directory")
+	directory := sdk.ParameterValue(a.Parameters, "directory")
 
-	// Load build param
-	branch := sdk.ParameterFind(wk.Parameters(), "git.branch")
-	defaultBranch := sdk.ParameterValue(wk.Parameters(), "git.default_branch")
-	tag := sdk.ParameterValue(wk.Parameters(), "git.tag")
-	commit := sdk.ParameterFind(wk.Parameters(), "git.hash")
+	return Checkout(ctx, wk, secrets, workerruntime.Checkout{
+		Directory:  directory,
+		Depth:      workerruntime.DefaultCheckoutDepth,
+		Submodules: true,
+	})
+}
 
+// Checkout clones the repository of the job with the vcs strategy of the application. Without ref, the branch, tag or
+// commit of the workflow run is cloned.
+func Checkout(ctx context.Context, wk workerruntime.Runtime, secrets []sdk.Variable, c workerruntime.Checkout) (sdk.Result, error) {
 	gitURL, auth, err := vcsStrategy(ctx, wk, wk.Parameters(), secrets)
 	if err != nil {
 		return sdk.Result{}, err
@@ -28,12 +35,39 @@ func RunCheckoutApplication(ctx context.Context, wk workerruntime.Runtime, a sdk
 
 	//Prepare all options - clone options
 	var opts = &git.CloneOpts{
-		Recursive:               true,
+		Recursive:               c.Submodules,
 		NoStrictHostKeyChecking: true,
-		Depth:                   50,
-		Tag:                     tag,
+		Depth:                   c.Depth,
+		Filter:                  c.Filter,
 		ForceGetGitDescribe:     true,
 	}
+	if c.Ref != "" {
+		setCheckoutRef(opts, c.Ref)
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("checkout ref %s", c.Ref))
+	} else {
+		setCheckoutRunRef(ctx, wk, opts)
+	}
+
+	workdir, err := workerruntime.WorkingDirectory(ctx)
+	if err != nil {
+		return sdk.Result{}, fmt.Errorf("Unable to find current working directory: %v", err)
+	}
+	workdirPath := workdir.Name()
+	if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
+		workdirPath, _ = x.RealPath(workdirPath)
+	}
+	return gitClone(ctx, wk, wk.Parameters(), gitURL, workdirPath, c.Directory, auth, opts)
+}
+
+// setCheckoutRunRef sets the branch, tag or commit of the workflow run in the clone options.
+func setCheckoutRunRef(ctx context.Context, wk workerruntime.Runtime, opts *git.CloneOpts) {
+	// Load build param
+	branch := sdk.ParameterFind(wk.Parameters(), "git.branch")
+	defaultBranch := sdk.ParameterValue(wk.Parameters(), "git.default_branch")
+	tag := sdk.ParameterValue(wk.Parameters(), "git.tag")
+	commit := sdk.ParameterFind(wk.Parameters(), "git.hash")
+
+	opts.Tag = tag
 	if branch != nil {
 		opts.Branch = branch.Value
 	} else {
@@ -51,18 +85,19 @@ func RunCheckoutApplication(ctx context.Context, wk workerruntime.Runtime, a sdk
 	if commit != nil && commit.Value != "" && !r.MatchString(commit.Value) {
 		opts.CheckoutCommit = commit.Value
 	}
+}
 
-	var dir string
-	if directory != nil {
-		dir = directory.Value
-	}
-	workdir, err := workerruntime.WorkingDirectory(ctx)
-	if err != nil {
-		return sdk.Result{}, fmt.Errorf("Unable to find current working directory: %v", err)
-	}
-	workdirPath := workdir.Name()
-	if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
-		workdirPath, _ = x.RealPath(workdirPath)
+// setCheckoutRef sets given ref in the clone options. A ref prefixed by refs/tags/ or refs/heads/ is a tag or a
+// branch, a ref that looks like a commit hash is a commit, and any other ref is a branch or a tag.
+func setCheckoutRef(opts *git.CloneOpts, ref string) {
+	switch {
+	case strings.HasPrefix(ref, "refs/tags/"):
+		opts.Tag = strings.TrimPrefix(ref, "refs/tags/")
+	case strings.HasPrefix(ref, "refs/heads/"):
+		opts.Branch = strings.TrimPrefix(ref, "refs/heads/")
+	case commitHashRegexp.MatchString(ref):
+		opts.CheckoutCommit = ref
+	default:
+		opts.Branch = ref
 	}
-	return gitClone(ctx, wk, wk.Parameters(), gitURL, workdirPath, dir, auth, opts)
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs/git"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,3 +50,21 @@ func TestRunCheckoutApplication(t *testing.T) {
 	assert.NotEmpty(t, res.NewVariables)
 	t.Logf("new variables: %+v", res.NewVariables)
 }
+
+func TestSetCheckoutRef(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want git.CloneOpts
+	}{
+		{ref: "master", want: git.CloneOpts{Branch: "master"}},
+		{ref: "refs/heads/feat/checkout", want: git.CloneOpts{Branch: "feat/checkout"}},
+		{ref: "refs/tags/v1.0.0", want: git.CloneOpts{Tag: "v1.0.0"}},
+		{ref: "eb8b87a", want: git.CloneOpts{CheckoutCommit: "eb8b87a"}},
+		{ref: "f57e4c8405d5b6ffddc33755c105f73c64ed89da", want: git.CloneOpts{CheckoutCommit: "f57e4c8405d5b6ffddc33755c105f73c64ed89da"}},
+	}
+	for _, tt := range tests {
+		var opts git.CloneOpts
+		setCheckoutRef(&opts, tt.ref)
+		assert.Equal(t, tt.want, opts, tt.ref)
+	}
+}
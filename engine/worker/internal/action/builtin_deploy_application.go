@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
 	"github.com/ovh/cds/sdk"
@@ -36,6 +38,31 @@ func RunDeployApplication(ctx context.Context, wk workerruntime.Runtime, a sdk.A
 		return sdk.Result{}, err
 	}
 
+	// Check plugin inputs before starting it to fail fast on missing or invalid configuration
+	options := sdk.ParametersToMap(wk.Parameters())
+	if job.IntegrationPluginContract != nil {
+		if errs := job.IntegrationPluginContract.CheckInputs(options); !errs.IsEmpty() {
+			for _, e := range errs {
+				wk.SendLog(ctx, workerruntime.LevelError, e.Error())
+			}
+			return sdk.Result{
+				Status: sdk.StatusFail,
+				Reason: fmt.Sprintf("Invalid configuration for deployment integration %s: %s", pf.Model.Name, errs.Error()),
+			}, nil
+		}
+	}
+
+	// A release pipeline with release targets publishes the results of the workflow run to each target
+	if pf.Model.Release {
+		release, err := wk.Client().QueueJobReleaseTargets(ctx, jobID)
+		if err != nil {
+			return sdk.Result{}, fmt.Errorf("unable to retrieve release targets (%v)... Aborting", err)
+		}
+		if len(release.Targets) > 0 {
+			return runReleaseTargets(ctx, wk, jobID, job, *release, options)
+		}
+	}
+
 	//First check OS and Architecture
 	var currentOS = strings.ToLower(sdk.GOOS)
 	var currentARCH = strings.ToLower(sdk.GOARCH)
@@ -85,7 +112,13 @@ func RunDeployApplication(ctx context.Context, wk workerruntime.Runtime, a sdk.A
 	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Plugin %s v%s is ready", manifest.Name, manifest.Version))
 
 	query := integrationplugin.DeployQuery{
-		Options: sdk.ParametersToMap(wk.Parameters()),
+		Options: options,
+	}
+
+	if options[sdk.IntegrationDeploymentPlanParameter] == "true" {
+		res, err := runDeploymentPlan(ctx, wk, integrationPluginClient, &query, manifest)
+		integrationPluginClientStop(ctx, integrationPluginClient, done, stopLogs)
+		return res, err
 	}
 
 	res, err := integrationPluginClient.Deploy(ctx, &query)
@@ -99,6 +132,20 @@ func RunDeployApplication(ctx context.Context, wk workerruntime.Runtime, a sdk.A
 
 	if strings.ToUpper(res.Status) == strings.ToUpper(sdk.StatusSuccess) {
 		integrationPluginClientStop(ctx, integrationPluginClient, done, stopLogs)
+
+		// Check that the plugin returned the outputs declared in its contract
+		if job.IntegrationPluginContract != nil {
+			if _, errs := job.IntegrationPluginContract.CheckOutputs(res.Details); !errs.IsEmpty() {
+				for _, e := range errs {
+					wk.SendLog(ctx, workerruntime.LevelError, e.Error())
+				}
+				return sdk.Result{
+					Status: sdk.StatusFail,
+					Reason: fmt.Sprintf("Invalid outputs from plugin %s v%s: %s", manifest.Name, manifest.Version, errs.Error()),
+				}, nil
+			}
+		}
+
 		return sdk.Result{
 			Status: sdk.StatusSuccess,
 		}, nil
@@ -112,6 +159,36 @@ func RunDeployApplication(ctx context.Context, wk workerruntime.Runtime, a sdk.A
 	}, nil
 }
 
+// runDeploymentPlan asks the plugin for the changes it would apply, nothing is deployed. A plugin built before the
+// plan phase was added doesn't implement it, the job fails to not let the apply node run without a plan.
+func runDeploymentPlan(ctx context.Context, wk workerruntime.Runtime, c integrationplugin.IntegrationPluginClient, query *integrationplugin.DeployQuery, manifest *integrationplugin.IntegrationPluginManifest) (sdk.Result, error) {
+	wk.SendLog(ctx, workerruntime.LevelInfo, "# Running in deployment plan mode, nothing will be deployed")
+
+	res, err := c.Plan(ctx, query)
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return sdk.Result{
+				Status: sdk.StatusFail,
+				Reason: fmt.Sprintf("Plugin %s v%s doesn't support deployment plan mode", manifest.Name, manifest.Version),
+			}, nil
+		}
+		return sdk.Result{}, fmt.Errorf("Error planning deployment: %v", err)
+	}
+
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Plan:\n%s", res.Details))
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Status: %s", res.Status))
+
+	if strings.ToUpper(res.Status) != strings.ToUpper(sdk.StatusSuccess) {
+		return sdk.Result{
+			Status: sdk.StatusFail,
+			Reason: res.Details,
+		}, nil
+	}
+	return sdk.Result{
+		Status: sdk.StatusSuccess,
+	}, nil
+}
+
 func integrationPluginClientStop(ctx context.Context, integrationPluginClient integrationplugin.IntegrationPluginClient, done chan struct{}, stopLogs context.CancelFunc) {
 	if _, err := integrationPluginClient.Stop(ctx, new(empty.Empty)); err != nil {
 		// Transport is closing is a "normal" error, as we requested plugin to stop
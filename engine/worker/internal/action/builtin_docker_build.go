@@ -0,0 +1,197 @@
+package action
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// dockerBuildOptions are the options of a buildx build with a registry cache.
+type dockerBuildOptions struct {
+	builder    string
+	dockerfile string
+	context    string
+	images     []string
+	buildArgs  []string
+	push       bool
+	cacheRef   string
+}
+
+// args returns the arguments of the docker command, cache is imported from and exported to the cache ref. The max
+// mode exports the layers of all stages, not only the ones of the final image.
+func (o dockerBuildOptions) args() []string {
+	args := []string{"buildx", "build", "--builder", o.builder, "--file", o.dockerfile,
+		"--cache-from", "type=registry,ref=" + o.cacheRef,
+		"--cache-to", "type=registry,ref=" + o.cacheRef + ",mode=max",
+	}
+	for _, i := range o.images {
+		args = append(args, "--tag", i)
+	}
+	for _, a := range o.buildArgs {
+		args = append(args, "--build-arg", a)
+	}
+	if o.push {
+		args = append(args, "--push")
+	} else if len(o.images) > 0 {
+		args = append(args, "--load")
+	}
+	return append(args, o.context)
+}
+
+var dockerTagInvalidChars = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// dockerCacheTag returns a valid docker tag from given value.
+func dockerCacheTag(value string) string {
+	tag := strings.Trim(dockerTagInvalidChars.ReplaceAllString(strings.ToLower(value), "-"), "-.")
+	if len(tag) > 128 {
+		tag = tag[:128]
+	}
+	if tag == "" {
+		tag = "latest"
+	}
+	return tag
+}
+
+func RunDockerBuild(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	res := sdk.Result{Status: sdk.StatusFail}
+
+	integration := strings.TrimSpace(sdk.ParameterValue(a.Parameters, "integration"))
+	if integration == "" {
+		return res, fmt.Errorf("integration parameter is empty. aborting")
+	}
+	prefix := "cds.registry." + integration + "."
+	params := wk.Parameters()
+	url := sdk.ParameterValue(params, prefix+"url")
+	repository := sdk.ParameterValue(params, prefix+"cache_repository")
+	if repository == "" {
+		return res, fmt.Errorf("docker registry integration %s not found or without cache repository", integration)
+	}
+	username := sdk.ParameterValue(params, prefix+"username")
+	var password string
+	if v := sdk.VariableFind(secrets, prefix+"password"); v != nil {
+		password = v.Value
+	}
+
+	jobID, err := workerruntime.JobID(ctx)
+	if err != nil {
+		return res, err
+	}
+	stepOrder, err := workerruntime.StepOrder(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	workdir, err := workerruntime.WorkingDirectory(ctx)
+	if err != nil {
+		return res, err
+	}
+	tmpdir, err := workerruntime.TmpDirectory(ctx)
+	if err != nil {
+		return res, err
+	}
+	dir, tmp := workdir.Name(), tmpdir.Name()
+	if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
+		dir, _ = x.RealPath(dir)
+		tmp, _ = x.RealPath(tmp)
+	}
+
+	// Use a dedicated docker config to not share the registry credentials with other jobs
+	dockerConfig, err := ioutil.TempDir(tmp, "docker")
+	if err != nil {
+		return res, fmt.Errorf("unable to create docker config directory: %v", err)
+	}
+	defer os.RemoveAll(dockerConfig) // nolint
+	env := append(wk.Environ(), "DOCKER_CONFIG="+dockerConfig)
+
+	if username != "" {
+		if err := runDockerCommand(ctx, wk, dir, env, password, "login", url, "--username", username, "--password-stdin"); err != nil {
+			return res, err
+		}
+	}
+
+	opts := dockerBuildOptions{
+		builder:    fmt.Sprintf("cds-%d-%d", jobID, stepOrder),
+		dockerfile: sdk.ParameterValue(a.Parameters, "dockerfile"),
+		context:    sdk.ParameterValue(a.Parameters, "context"),
+		images:     strings.Fields(sdk.ParameterValue(a.Parameters, "image")),
+		push:       sdk.ParameterValue(a.Parameters, "push") == "true",
+		cacheRef:   repository + ":" + dockerCacheTag(sdk.ParameterValue(a.Parameters, "cacheTag")),
+	}
+	if opts.dockerfile == "" {
+		opts.dockerfile = "Dockerfile"
+	}
+	if opts.context == "" {
+		opts.context = "."
+	}
+	for _, l := range strings.Split(sdk.ParameterValue(a.Parameters, "buildArgs"), "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			opts.buildArgs = append(opts.buildArgs, l)
+		}
+	}
+
+	// The default docker driver can't export cache, a builder with the container driver is created for the step
+	if err := runDockerCommand(ctx, wk, dir, env, "", "buildx", "create", "--name", opts.builder, "--driver", "docker-container"); err != nil {
+		return res, err
+	}
+	defer func() {
+		if err := runDockerCommand(context.Background(), wk, dir, env, "", "buildx", "rm", opts.builder); err != nil {
+			log.Error(ctx, "unable to remove builder %s: %v", opts.builder, err)
+		}
+	}()
+
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Using build cache %s\n", opts.cacheRef))
+	if err := runDockerCommand(ctx, wk, dir, env, "", opts.args()...); err != nil {
+		return res, err
+	}
+
+	res.Status = sdk.StatusSuccess
+	return res, nil
+}
+
+// runDockerCommand runs a docker command in given directory and sends its output as step logs.
+func runDockerCommand(ctx context.Context, wk workerruntime.Runtime, dir string, env []string, stdin string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+
+	r, w := io.Pipe()
+	cmd.Stdout = w
+	cmd.Stderr = w
+	done := make(chan struct{})
+	go func() {
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadString('\n')
+			if line != "" {
+				wk.SendLog(ctx, workerruntime.LevelInfo, line)
+			}
+			if err != nil {
+				close(done)
+				return
+			}
+		}
+	}()
+
+	err := cmd.Run()
+	w.Close() // nolint
+	<-done
+	if err != nil {
+		return fmt.Errorf("docker %s failed: %v", args[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDockerBuildOptionsArgs(t *testing.T) {
+	opts := dockerBuildOptions{
+		builder:    "cds-1-0",
+		dockerfile: "Dockerfile",
+		context:    ".",
+		images:     []string{"registry.example.com/my/image:1.0"},
+		buildArgs:  []string{"VERSION=1.0"},
+		cacheRef:   "registry.example.com/cache:wf-pip",
+	}
+	assert.Equal(t, []string{"buildx", "build", "--builder", "cds-1-0", "--file", "Dockerfile",
+		"--cache-from", "type=registry,ref=registry.example.com/cache:wf-pip",
+		"--cache-to", "type=registry,ref=registry.example.com/cache:wf-pip,mode=max",
+		"--tag", "registry.example.com/my/image:1.0",
+		"--build-arg", "VERSION=1.0",
+		"--load", ".",
+	}, opts.args())
+
+	opts.push = true
+	args := opts.args()
+	assert.Equal(t, []string{"--push", "."}, args[len(args)-2:])
+}
+
+func TestDockerCacheTag(t *testing.T) {
+	assert.Equal(t, "my-workflow-build_image", dockerCacheTag("My Workflow-build_image"))
+	assert.Equal(t, "feat-branch", dockerCacheTag("/feat/branch."))
+	assert.Equal(t, "latest", dockerCacheTag(""))
+}
@@ -61,23 +61,16 @@ func RunParseJunitTestResultAction(ctx context.Context, wk workerruntime.Runtime
 	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("%d", len(files))+" file(s) to analyze")
 
 	for _, f := range files {
-		var ftests venom.Tests
-
 		data, errRead := afero.ReadFile(afero.NewOsFs(), f)
 		if errRead != nil {
 			return res, fmt.Errorf("UnitTest parser: cannot read file %s (%s)", f, errRead)
 		}
 
-		var vf venom.Tests
-		if err := xml.Unmarshal(data, &vf); err != nil {
-			// Check if file contains testsuite only (and no testsuites)
-			if s, ok := ParseTestsuiteAlone(data); ok {
-				ftests.TestSuites = append(ftests.TestSuites, s)
-			}
-			tests.TestSuites = append(tests.TestSuites, ftests.TestSuites...)
-		} else {
-			tests.TestSuites = append(tests.TestSuites, vf.TestSuites...)
+		suites, err := ParseTestReport(filepath.Base(f), data)
+		if err != nil {
+			return res, fmt.Errorf("UnitTest parser: cannot parse file %s (%s)", f, err)
 		}
+		tests.TestSuites = append(tests.TestSuites, suites...)
 	}
 
 	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("%d", len(tests.TestSuites))+" Total Testsuite(s)")
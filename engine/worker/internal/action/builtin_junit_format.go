@@ -0,0 +1,272 @@
+package action
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ovh/venom"
+)
+
+// Supported test report formats.
+const (
+	TestReportFormatJUnit  = "junit"
+	TestReportFormatTAP    = "tap"
+	TestReportFormatGoJSON = "gotest-json"
+)
+
+var (
+	tapVersionRegexp = regexp.MustCompile(`^TAP version \d+`)
+	tapPlanRegexp    = regexp.MustCompile(`^\d+\.\.\d+`)
+	tapTestRegexp    = regexp.MustCompile(`^(not ok|ok)\b\s*(\d+)?\s*(?:-\s*)?([^#]*)(?:#\s*(\S+)\s*(.*))?$`)
+)
+
+// DetectTestReportFormat returns the format of given test report content,
+// JUnit is returned if the content doesn't look like TAP or go test -json.
+func DetectTestReportFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] == '<' {
+		return TestReportFormatJUnit
+	}
+	if trimmed[0] == '{' {
+		return TestReportFormatGoJSON
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if tapVersionRegexp.MatchString(line) || tapPlanRegexp.MatchString(line) || tapTestRegexp.MatchString(line) {
+			return TestReportFormatTAP
+		}
+		break
+	}
+	return TestReportFormatJUnit
+}
+
+// ParseTestReport parses given JUnit, TAP or go test -json content to test suites.
+// Given name is used for test suites that don't provide one.
+func ParseTestReport(name string, data []byte) ([]venom.TestSuite, error) {
+	switch DetectTestReportFormat(data) {
+	case TestReportFormatTAP:
+		s, err := ParseTAP(name, data)
+		if err != nil {
+			return nil, err
+		}
+		return []venom.TestSuite{s}, nil
+	case TestReportFormatGoJSON:
+		return ParseGoTestJSON(data)
+	default:
+		var vf venom.Tests
+		if err := xml.Unmarshal(data, &vf); err != nil {
+			// Check if file contains testsuite only (and no testsuites)
+			if s, ok := ParseTestsuiteAlone(data); ok {
+				return []venom.TestSuite{s}, nil
+			}
+			return nil, nil
+		}
+		return vf.TestSuites, nil
+	}
+}
+
+// ParseTAP parses a Test Anything Protocol report to a test suite.
+func ParseTAP(name string, data []byte) (venom.TestSuite, error) {
+	s := venom.TestSuite{Name: name}
+
+	var current *venom.TestCase
+	var diagnostic []string
+	var inYAML bool
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if len(diagnostic) > 0 {
+			details := strings.Join(diagnostic, "\n")
+			if len(current.Failures) > 0 {
+				current.Failures[0].Value = details
+			} else {
+				current.Systemout.Value = details
+			}
+		}
+		s.TestCases = append(s.TestCases, *current)
+		current = nil
+		diagnostic = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+
+		if inYAML {
+			if line == "..." {
+				inYAML = false
+				continue
+			}
+			diagnostic = append(diagnostic, strings.TrimPrefix(raw, "  "))
+			continue
+		}
+
+		switch {
+		case line == "---" && current != nil:
+			inYAML = true
+		case strings.HasPrefix(line, "Bail out!"):
+			flush()
+			s.TestCases = append(s.TestCases, venom.TestCase{
+				Name:   "Bail out",
+				Errors: []venom.Failure{{Message: strings.TrimSpace(strings.TrimPrefix(line, "Bail out!"))}},
+			})
+		case strings.HasPrefix(line, "#"):
+			if current != nil {
+				diagnostic = append(diagnostic, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+			}
+		case tapTestRegexp.MatchString(line):
+			flush()
+			m := tapTestRegexp.FindStringSubmatch(line)
+			tc := venom.TestCase{
+				Classname: name,
+				Name:      strings.TrimSpace(m[3]),
+			}
+			if tc.Name == "" {
+				tc.Name = fmt.Sprintf("Test %s", m[2])
+			}
+			directive := strings.ToUpper(m[4])
+			switch {
+			case strings.HasPrefix(directive, "SKIP"):
+				tc.Skipped = []venom.Skipped{{Value: m[5]}}
+			case strings.HasPrefix(directive, "TODO"):
+				// TODO tests are not expected to succeed, so they are reported as skipped
+				tc.Skipped = []venom.Skipped{{Value: m[5]}}
+			case m[1] == "not ok":
+				tc.Failures = []venom.Failure{{Message: tc.Name}}
+			}
+			current = &tc
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return s, fmt.Errorf("cannot read TAP report: %v", err)
+	}
+
+	computeTestSuiteCounters(&s)
+	return s, nil
+}
+
+// goTestEvent is an event emitted by go test -json (see go doc test2json).
+type goTestEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test"`
+	Elapsed float64 `json:"Elapsed"`
+	Output  string  `json:"Output"`
+}
+
+// ParseGoTestJSON parses a go test -json report to test suites, one per package.
+func ParseGoTestJSON(data []byte) ([]venom.TestSuite, error) {
+	var packages []string
+	suites := map[string]*venom.TestSuite{}
+	cases := map[string]map[string]*venom.TestCase{}
+	order := map[string][]string{}
+	outputs := map[string]map[string]*strings.Builder{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+		var e goTestEvent
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("cannot read go test report: %v", err)
+		}
+
+		if _, ok := suites[e.Package]; !ok {
+			packages = append(packages, e.Package)
+			suites[e.Package] = &venom.TestSuite{Name: e.Package, Package: e.Package}
+			cases[e.Package] = map[string]*venom.TestCase{}
+			outputs[e.Package] = map[string]*strings.Builder{}
+		}
+		s := suites[e.Package]
+
+		if _, ok := outputs[e.Package][e.Test]; !ok {
+			outputs[e.Package][e.Test] = new(strings.Builder)
+		}
+		out := outputs[e.Package][e.Test]
+
+		if e.Test == "" {
+			switch e.Action {
+			case "output":
+				out.WriteString(e.Output)
+			case "pass", "fail", "skip":
+				s.Time = fmt.Sprintf("%.3f", e.Elapsed)
+				// A package that fails without any test means that it failed to build
+				if e.Action == "fail" && len(cases[e.Package]) == 0 {
+					order[e.Package] = append(order[e.Package], "")
+					cases[e.Package][""] = &venom.TestCase{
+						Classname: e.Package,
+						Name:      e.Package,
+						Errors:    []venom.Failure{{Message: "package failed", Value: out.String()}},
+					}
+				}
+			}
+			continue
+		}
+
+		tc, ok := cases[e.Package][e.Test]
+		if !ok {
+			tc = &venom.TestCase{Classname: e.Package, Name: e.Test}
+			cases[e.Package][e.Test] = tc
+			order[e.Package] = append(order[e.Package], e.Test)
+		}
+
+		switch e.Action {
+		case "output":
+			out.WriteString(e.Output)
+		case "pass":
+			tc.Time = fmt.Sprintf("%.3f", e.Elapsed)
+		case "fail":
+			tc.Time = fmt.Sprintf("%.3f", e.Elapsed)
+			tc.Failures = []venom.Failure{{Message: e.Test + " failed", Value: out.String()}}
+		case "skip":
+			tc.Time = fmt.Sprintf("%.3f", e.Elapsed)
+			tc.Skipped = []venom.Skipped{{Value: out.String()}}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read go test report: %v", err)
+	}
+
+	res := make([]venom.TestSuite, 0, len(packages))
+	for _, p := range packages {
+		s := suites[p]
+		for _, n := range order[p] {
+			tc := cases[p][n]
+			if len(tc.Failures) == 0 && len(tc.Errors) == 0 {
+				tc.Systemout.Value = outputs[p][n].String()
+			}
+			s.TestCases = append(s.TestCases, *tc)
+		}
+		computeTestSuiteCounters(s)
+		res = append(res, *s)
+	}
+	return res, nil
+}
+
+// computeTestSuiteCounters sets test suite counters from its test cases like a JUnit report does.
+func computeTestSuiteCounters(s *venom.TestSuite) {
+	s.Total = len(s.TestCases)
+	s.Failures, s.Errors, s.Skipped = 0, 0, 0
+	for _, tc := range s.TestCases {
+		s.Failures += len(tc.Failures)
+		s.Errors += len(tc.Errors)
+		s.Skipped += len(tc.Skipped)
+	}
+}
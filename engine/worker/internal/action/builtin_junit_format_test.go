@@ -0,0 +1,86 @@
+package action
+
+import (
+	"testing"
+
+	"github.com/ovh/venom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestParseTestReport_TAP(t *testing.T) {
+	report := `TAP version 13
+1..4
+ok 1 - should default path to an empty string
+not ok 2 - should default consolidate to true
+  ---
+  message: 'expected true'
+  severity: fail
+  ...
+ok 3 - should default useDotNotation to true # SKIP not implemented
+not ok 4 # TODO write this test
+`
+	assert.Equal(t, TestReportFormatTAP, DetectTestReportFormat([]byte(report)))
+
+	suites, err := ParseTestReport("results.tap", []byte(report))
+	require.NoError(t, err)
+	require.Len(t, suites, 1)
+	assert.Equal(t, "results.tap", suites[0].Name)
+	require.Len(t, suites[0].TestCases, 4)
+	assert.Equal(t, "should default consolidate to true", suites[0].TestCases[1].Name)
+	require.Len(t, suites[0].TestCases[1].Failures, 1)
+	assert.Contains(t, suites[0].TestCases[1].Failures[0].Value, "expected true")
+	assert.Len(t, suites[0].TestCases[2].Skipped, 1)
+	assert.Len(t, suites[0].TestCases[3].Skipped, 1)
+
+	tests := venom.Tests{TestSuites: suites}
+	var res sdk.Result
+	ComputeStats(&res, &tests)
+	assert.Equal(t, sdk.StatusFail, res.Status)
+	assert.Equal(t, 4, tests.Total)
+	assert.Equal(t, 3, tests.TotalOK)
+	assert.Equal(t, 1, tests.TotalKO)
+	assert.Equal(t, 2, tests.TotalSkipped)
+}
+
+func TestParseTestReport_GoTestJSON(t *testing.T) {
+	report := `{"Action":"run","Package":"github.com/ovh/cds/sdk","Test":"TestA"}
+{"Action":"output","Package":"github.com/ovh/cds/sdk","Test":"TestA","Output":"=== RUN   TestA\n"}
+{"Action":"pass","Package":"github.com/ovh/cds/sdk","Test":"TestA","Elapsed":0.01}
+{"Action":"run","Package":"github.com/ovh/cds/sdk","Test":"TestB"}
+{"Action":"output","Package":"github.com/ovh/cds/sdk","Test":"TestB","Output":"    b_test.go:12: expected 1, got 2\n"}
+{"Action":"fail","Package":"github.com/ovh/cds/sdk","Test":"TestB","Elapsed":0.02}
+{"Action":"run","Package":"github.com/ovh/cds/sdk","Test":"TestC"}
+{"Action":"skip","Package":"github.com/ovh/cds/sdk","Test":"TestC","Elapsed":0}
+{"Action":"fail","Package":"github.com/ovh/cds/sdk","Elapsed":0.05}
+{"Action":"output","Package":"github.com/ovh/cds/engine","Output":"# github.com/ovh/cds/engine\nbuild failed\n"}
+{"Action":"fail","Package":"github.com/ovh/cds/engine","Elapsed":0}
+`
+	assert.Equal(t, TestReportFormatGoJSON, DetectTestReportFormat([]byte(report)))
+
+	suites, err := ParseTestReport("report.json", []byte(report))
+	require.NoError(t, err)
+	require.Len(t, suites, 2)
+
+	assert.Equal(t, "github.com/ovh/cds/sdk", suites[0].Name)
+	require.Len(t, suites[0].TestCases, 3)
+	assert.Equal(t, "TestA", suites[0].TestCases[0].Name)
+	require.Len(t, suites[0].TestCases[1].Failures, 1)
+	assert.Contains(t, suites[0].TestCases[1].Failures[0].Value, "expected 1, got 2")
+	assert.Len(t, suites[0].TestCases[2].Skipped, 1)
+
+	require.Len(t, suites[1].TestCases, 1)
+	require.Len(t, suites[1].TestCases[0].Errors, 1)
+	assert.Contains(t, suites[1].TestCases[0].Errors[0].Value, "build failed")
+
+	tests := venom.Tests{TestSuites: suites}
+	var res sdk.Result
+	ComputeStats(&res, &tests)
+	assert.Equal(t, sdk.StatusFail, res.Status)
+	assert.Equal(t, 4, tests.Total)
+	assert.Equal(t, 2, tests.TotalOK)
+	assert.Equal(t, 2, tests.TotalKO)
+	assert.Equal(t, 1, tests.TotalSkipped)
+}
@@ -0,0 +1,147 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/grpcplugin/integrationplugin"
+)
+
+// runReleaseTargets publishes the results of the workflow run to each release target that was not published by a
+// previous run of the pipeline. A failed target doesn't stop the publication to the other ones, the step fails once
+// all the targets were processed.
+func runReleaseTargets(ctx context.Context, wk workerruntime.Runtime, jobID int64, job *sdk.WorkflowNodeJobRun, release sdk.WorkflowNodeJobRunRelease, options map[string]string) (sdk.Result, error) {
+	results, err := json.Marshal(release.Results)
+	if err != nil {
+		return sdk.Result{}, sdk.WithStack(err)
+	}
+	artifacts, err := json.Marshal(release.Artifacts)
+	if err != nil {
+		return sdk.Result{}, sdk.WithStack(err)
+	}
+
+	var failed []string
+	for _, t := range release.Targets {
+		if t.Status == sdk.StatusSuccess {
+			wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Release target %s was already published, skipping", t.ProjectIntegrationName))
+			continue
+		}
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Publishing to release target %s", t.ProjectIntegrationName))
+
+		status := sdk.WorkflowRunReleaseTargetStatus{Status: sdk.StatusFail}
+		targetOptions, err := releaseTargetOptions(wk, t, options)
+		if err == nil {
+			targetOptions[sdk.ReleaseOptionResults] = string(results)
+			targetOptions[sdk.ReleaseOptionArtifacts] = string(artifacts)
+			var res *integrationplugin.DeployResult
+			res, err = runReleasePlugin(ctx, wk, job, t.PluginName, targetOptions)
+			if err == nil {
+				status.Details = res.Details
+				if strings.EqualFold(res.Status, sdk.StatusSuccess) {
+					status.Status = sdk.StatusSuccess
+				}
+			}
+		}
+		if err != nil {
+			status.Details = err.Error()
+		}
+
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Details: %s", status.Details))
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Status: %s", status.Status))
+
+		if err := wk.Client().QueueJobReleaseTargetStatus(ctx, jobID, t.ProjectIntegrationName, status); err != nil {
+			return sdk.Result{}, fmt.Errorf("unable to send status of release target %s: %v", t.ProjectIntegrationName, err)
+		}
+		if status.Status != sdk.StatusSuccess {
+			failed = append(failed, t.ProjectIntegrationName)
+		}
+	}
+
+	if len(failed) > 0 {
+		return sdk.Result{
+			Status: sdk.StatusFail,
+			Reason: fmt.Sprintf("Unable to publish to release targets: %s", strings.Join(failed, ", ")),
+		}, nil
+	}
+	return sdk.Result{
+		Status: sdk.StatusSuccess,
+	}, nil
+}
+
+// releaseTargetOptions returns the options of the job where the cds.integration.* options are replaced by the
+// configuration of the given release target.
+func releaseTargetOptions(wk workerruntime.Runtime, t sdk.WorkflowRunReleaseTarget, options map[string]string) (map[string]string, error) {
+	pf, err := wk.Client().ProjectIntegrationGet(options["cds.project"], t.ProjectIntegrationName, true)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve release integration %s: %v", t.ProjectIntegrationName, err)
+	}
+
+	var projectVariables []sdk.Variable
+	targetOptions := make(map[string]string, len(options))
+	for k, v := range options {
+		if strings.HasPrefix(k, "cds.proj.") {
+			projectVariables = append(projectVariables, sdk.Variable{Name: strings.TrimPrefix(k, "cds.proj."), Value: v})
+		}
+		if k == "cds.integration" || strings.HasPrefix(k, "cds.integration.") {
+			continue
+		}
+		targetOptions[k] = v
+	}
+
+	config, err := pf.Config.Interpolate(projectVariables)
+	if err != nil {
+		return nil, err
+	}
+	targetOptions["cds.integration"] = pf.Name
+	for k, v := range config {
+		targetOptions["cds.integration."+k] = v.Value
+	}
+	return targetOptions, nil
+}
+
+// runReleasePlugin starts the given release plugin and calls it with given options. The binary of the plugin of the
+// node integration is given with the job, the other ones are downloaded.
+func runReleasePlugin(ctx context.Context, wk workerruntime.Runtime, job *sdk.WorkflowNodeJobRun, pluginName string, options map[string]string) (*integrationplugin.DeployResult, error) {
+	var binary *sdk.GRPCPluginBinary
+	for i := range job.IntegrationPluginBinaries {
+		b := job.IntegrationPluginBinaries[i]
+		if b.PluginName == pluginName && b.OS == strings.ToLower(sdk.GOOS) && b.Arch == strings.ToLower(sdk.GOARCH) {
+			binary = &b
+			break
+		}
+	}
+
+	pluginSocket, err := startGRPCPlugin(ctx, pluginName, wk, binary, startGRPCPluginOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to start GRPCPlugin %s: %v", pluginName, err)
+	}
+
+	c, err := integrationplugin.Client(context.Background(), pluginSocket.Socket)
+	if err != nil {
+		return nil, fmt.Errorf("unable to call GRPCPlugin %s: %v", pluginName, err)
+	}
+	pluginSocket.Client = c
+
+	logCtx, stopLogs := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go enablePluginLogger(logCtx, done, pluginSocket, wk)
+	defer integrationPluginClientStop(ctx, c, done, stopLogs)
+
+	manifest, err := c.Manifest(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve plugin manifest: %v", err)
+	}
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Plugin %s v%s is ready", manifest.Name, manifest.Version))
+
+	res, err := c.Deploy(ctx, &integrationplugin.DeployQuery{Options: options})
+	if err != nil {
+		return nil, fmt.Errorf("error publishing release: %v", err)
+	}
+	return res, nil
+}
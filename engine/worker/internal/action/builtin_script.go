@@ -199,6 +199,13 @@ func RunScriptAction(ctx context.Context, wk workerruntime.Runtime, a sdk.Action
 
 		log.Info(ctx, "runScriptAction> Running command %s %s in %s", script.shell, strings.Trim(fmt.Sprint(script.opts), "[]"), script.dir)
 		cmd := exec.CommandContext(ctx, script.shell, script.opts...)
+		if toolPaths, ok := workerruntime.IsolationPaths(ctx); ok {
+			cmd, err = isolatedScriptCommand(ctx, wk, script, toolPaths)
+			if err != nil {
+				chanErr <- fmt.Errorf("unable to run script in isolation mode: %v", err)
+				return
+			}
+		}
 		res.Status = sdk.StatusUnknown
 
 		cmd.Dir = script.dir
@@ -285,6 +292,60 @@ func RunScriptAction(ctx context.Context, wk workerruntime.Runtime, a sdk.Action
 	return res, globalErr
 }
 
+// isolatedScriptCommand returns the command that runs the script in its own namespaces. Only the system directories,
+// the workspace, the tool paths of the job, the keys and secrets of the job, the script and the worker binary are
+// visible from the step.
+func isolatedScriptCommand(ctx context.Context, wk workerruntime.Runtime, script *script, toolPaths []string) (*exec.Cmd, error) {
+	workerpath, err := osext.Executable()
+	if err != nil {
+		return nil, err
+	}
+	tmpdir, err := workerruntime.TmpDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tmpdirPath, err := realPath(wk.BaseDir(), tmpdir.Name())
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := filepath.Abs(script.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	readOnly := []string{workerpath, script.opts[len(script.opts)-1]}
+	if keysdir, err := workerruntime.KeysDirectory(ctx); err == nil {
+		keysdirPath, err := realPath(wk.BaseDir(), keysdir.Name())
+		if err != nil {
+			return nil, err
+		}
+		readOnly = append(readOnly, keysdirPath)
+	}
+	if secretsdir := wk.SecretsDirectory(); secretsdir != "" {
+		readOnly = append(readOnly, secretsdir)
+	}
+	readOnly = append(readOnly, toolPaths...)
+
+	return workerruntime.IsolatedCommand(ctx, workerpath, workerruntime.Isolation{
+		Root:     filepath.Join(tmpdirPath, "isolation"),
+		Dir:      workspace,
+		Writable: []string{workspace},
+		ReadOnly: readOnly,
+	}, script.shell, script.opts...)
+}
+
+// realPath returns the absolute path on the host of given file of the worker filesystem.
+func realPath(fs afero.Fs, name string) (string, error) {
+	if x, ok := fs.(*afero.BasePathFs); ok {
+		p, err := x.RealPath(name)
+		if err != nil {
+			return "", err
+		}
+		name = p
+	}
+	return filepath.Abs(name)
+}
+
 func isShell(in string) bool {
 	for _, v := range []string{"ksh", "bash", "sh", "zsh"} {
 		if strings.HasSuffix(in, v) {
@@ -0,0 +1,188 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+var semverPrereleaseRegexp = regexp.MustCompile(`^[0-9A-Za-z\-.]+$`)
+
+// semverRules returns the rules given by the parameters of the action, default rules are used for missing parameters.
+func semverRules(a sdk.Action) (sdk.SemverRules, error) {
+	rules := sdk.DefaultSemverRules()
+	for _, p := range []struct {
+		name  string
+		types *[]string
+	}{
+		{"majorTypes", &rules.MajorTypes},
+		{"minorTypes", &rules.MinorTypes},
+		{"patchTypes", &rules.PatchTypes},
+	} {
+		param := sdk.ParameterFind(a.Parameters, p.name)
+		if param == nil {
+			continue
+		}
+		*p.types = nil
+		for _, t := range strings.Split(param.Value, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				*p.types = append(*p.types, t)
+			}
+		}
+	}
+	if defaultBump := strings.TrimSpace(sdk.ParameterValue(a.Parameters, "defaultBump")); defaultBump != "" {
+		rules.DefaultBump = defaultBump
+	}
+	return rules, rules.IsValid()
+}
+
+func RunSemver(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	res := sdk.Result{Status: sdk.StatusFail}
+
+	rules, err := semverRules(a)
+	if err != nil {
+		return res, err
+	}
+	prefix := sdk.ParameterValue(a.Parameters, "prefix")
+	initialVersion := sdk.ParameterValue(a.Parameters, "initialVersion")
+	if initialVersion == "" {
+		initialVersion = "0.1.0"
+	}
+	prerelease := sdk.ParameterValue(a.Parameters, "prerelease")
+	if prerelease != "" && !semverPrereleaseRegexp.MatchString(prerelease) {
+		return res, fmt.Errorf("prerelease '%s' must comprise only ASCII alphanumerics and hyphen [0-9A-Za-z-.]", prerelease)
+	}
+
+	path := sdk.ParameterValue(a.Parameters, "path")
+	if path == "" {
+		workdir, err := workerruntime.WorkingDirectory(ctx)
+		if err != nil {
+			return res, err
+		}
+		path = workdir.Name()
+		if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
+			path, _ = x.RealPath(path)
+		}
+	}
+
+	if shallow, err := git.IsShallow(path); err != nil {
+		return res, fmt.Errorf("unable to read git repository %s: %v", path, err)
+	} else if shallow {
+		wk.SendLog(ctx, workerruntime.LevelWarn, "The repository is a shallow clone, version tags older than its history are ignored\n")
+	}
+
+	tags, err := git.TagsMerged(path)
+	if err != nil {
+		return res, fmt.Errorf("unable to list git tags: %v", err)
+	}
+	previousTag, previous := sdk.LatestSemverTag(tags, prefix)
+
+	messages, err := git.CommitMessages(path, previousTag)
+	if err != nil {
+		return res, fmt.Errorf("unable to list git commits: %v", err)
+	}
+
+	bump := rules.Bump(messages)
+	if previous == nil {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("No version tag found, using initial version %s\n", initialVersion))
+	} else {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Last version tag is %s, %d commits since this tag require a %s bump\n", previousTag, len(messages), bump))
+	}
+
+	next, err := sdk.NextSemver(previous, bump, initialVersion)
+	if err != nil {
+		return res, err
+	}
+	// The prerelease is only added to a new version
+	if prerelease != "" && (previous == nil || bump != sdk.SemverBumpNone) {
+		next.Pre = []semver.PRVersion{{VersionStr: prerelease}}
+	}
+	nextTag := prefix + next.String()
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("cds.semver.next: %s\n", next.String()))
+
+	res.NewVariables = []sdk.Variable{
+		{Name: "cds.semver.next", Type: sdk.StringVariable, Value: next.String()},
+		{Name: "cds.semver.next.tag", Type: sdk.StringVariable, Value: nextTag},
+		{Name: "cds.semver.previous", Type: sdk.StringVariable, Value: previousTag},
+		{Name: "cds.semver.bump", Type: sdk.StringVariable, Value: bump},
+	}
+
+	if sdk.ParameterValue(a.Parameters, "createTag") == "true" {
+		if previous != nil && bump == sdk.SemverBumpNone {
+			wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Version was not bumped, tag %s is not created\n", nextTag))
+		} else {
+			if err := semverCreateTag(ctx, wk, secrets, path, nextTag, sdk.ParameterValue(a.Parameters, "tagMessage")); err != nil {
+				return res, err
+			}
+			res.NewVariables = append(res.NewVariables, sdk.Variable{
+				Name:  "cds.release.version",
+				Type:  sdk.StringVariable,
+				Value: nextTag,
+			})
+		}
+	}
+
+	res.Status = sdk.StatusSuccess
+	return res, nil
+}
+
+// semverCreateTag creates and pushes given tag with the vcs config of the application, as the GitTag action does.
+func semverCreateTag(ctx context.Context, wk workerruntime.Runtime, secrets []sdk.Variable, path, name, message string) error {
+	gitURL, auth, err := vcsStrategy(ctx, wk, wk.Parameters(), secrets)
+	if err != nil {
+		return err
+	}
+
+	params := wk.Parameters()
+	username := sdk.ParameterValue(params, "cds.triggered_by.username")
+	if username == "" {
+		username = sdk.ParameterValue(params, "git.author")
+	}
+	if username == "" {
+		return fmt.Errorf("No user find to perform tag")
+	}
+
+	tagOpts := &git.TagOpts{
+		Message:  message,
+		Name:     name,
+		Path:     path,
+		Username: username,
+	}
+	if auth.SignKey.ID != "" {
+		tagOpts.SignKey = auth.SignKey.Private
+		tagOpts.SignID = auth.SignKey.ID
+		if err := ioutil.WriteFile("pgp.pub.key", []byte(auth.SignKey.Public), 0600); err != nil {
+			return fmt.Errorf("Cannot create pgp pub key file")
+		}
+		if err := ioutil.WriteFile("pgp.key", []byte(tagOpts.SignKey), 0600); err != nil {
+			return fmt.Errorf("Cannot create pgp key file")
+		}
+	}
+
+	stdErr := new(bytes.Buffer)
+	stdOut := new(bytes.Buffer)
+	git.LogFunc = log.InfoWithoutCtx
+	err = git.TagCreate(gitURL, auth, tagOpts, &git.OutputOpts{Stdout: stdOut, Stderr: stdErr})
+	if stdOut.Len() > 0 {
+		wk.SendLog(ctx, workerruntime.LevelInfo, stdOut.String())
+	}
+	if stdErr.Len() > 0 {
+		wk.SendLog(ctx, workerruntime.LevelWarn, stdErr.String())
+	}
+	if err != nil {
+		return fmt.Errorf("Unable to git tag: %v", err)
+	}
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Tag %s created\n", name))
+	return nil
+}
@@ -0,0 +1,32 @@
+package action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestSemverRules(t *testing.T) {
+	rules, err := semverRules(sdk.Action{})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.DefaultSemverRules(), rules)
+
+	rules, err = semverRules(sdk.Action{Parameters: []sdk.Parameter{
+		{Name: "majorTypes", Value: "release"},
+		{Name: "minorTypes", Value: "feat, refactor"},
+		{Name: "patchTypes", Value: ""},
+		{Name: "defaultBump", Value: "patch"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.SemverRules{
+		MajorTypes:  []string{"release"},
+		MinorTypes:  []string{"feat", "refactor"},
+		DefaultBump: sdk.SemverBumpPatch,
+	}, rules)
+
+	_, err = semverRules(sdk.Action{Parameters: []sdk.Parameter{{Name: "defaultBump", Value: "huge"}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,237 @@
+package action
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func workspaceDirectories(ctx context.Context, wk workerruntime.Runtime) (string, string, error) {
+	workdir, err := workerruntime.WorkingDirectory(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	tmpdir, err := workerruntime.TmpDirectory(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	var abs, tmp string
+	if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
+		abs, _ = x.RealPath(workdir.Name())
+		tmp, _ = x.RealPath(tmpdir.Name())
+	} else {
+		abs = workdir.Name()
+		tmp = tmpdir.Name()
+	}
+	return abs, tmp, nil
+}
+
+func workspaceSnapshotName(a sdk.Action) string {
+	name := strings.TrimSpace(sdk.ParameterValue(a.Parameters, "name"))
+	if name == "" {
+		return sdk.DefaultWorkspaceSnapshotName
+	}
+	return name
+}
+
+func RunWorkspaceSnapshot(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	res := sdk.Result{Status: sdk.StatusSuccess}
+
+	jobID, err := workerruntime.JobID(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	abs, tmp, err := workspaceDirectories(ctx, wk)
+	if err != nil {
+		return res, err
+	}
+
+	name := workspaceSnapshotName(a)
+	archivePath := filepath.Join(tmp, sdk.WorkspaceSnapshotArtifactName(name))
+
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Creating snapshot %s of workspace %s...", name, abs))
+	if err := writeWorkspaceSnapshot(abs, archivePath); err != nil {
+		return res, sdk.WrapError(err, "unable to create snapshot of workspace")
+	}
+	defer os.RemoveAll(archivePath) // nolint
+
+	integrationName := sdk.DefaultIfEmptyStorage(sdk.ParameterValue(wk.Parameters(), sdk.WorkflowArtifactStorageParameter))
+	projectKey := sdk.ParameterValue(wk.Parameters(), "cds.project")
+
+	throughTempURL, duration, err := wk.Client().QueueArtifactUpload(ctx, projectKey, integrationName, jobID, sdk.WorkspaceSnapshotArtifactTag, archivePath)
+	if err != nil {
+		return res, sdk.WrapError(err, "unable to upload snapshot of workspace")
+	}
+	if throughTempURL {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Snapshot %s uploaded in %.2fs to object store", name, duration.Seconds()))
+	} else {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Snapshot %s uploaded in %.2fs to CDS API", name, duration.Seconds()))
+	}
+
+	return res, nil
+}
+
+func RunWorkspaceRestore(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	res := sdk.Result{Status: sdk.StatusSuccess}
+
+	project := sdk.ParameterValue(wk.Parameters(), "cds.project")
+	workflow := sdk.ParameterValue(wk.Parameters(), "cds.workflow")
+	number := sdk.ParameterValue(wk.Parameters(), "cds.run.number")
+
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return res, fmt.Errorf("cds.run.number variable is not valid. aborting")
+	}
+
+	abs, tmp, err := workspaceDirectories(ctx, wk)
+	if err != nil {
+		return res, err
+	}
+
+	artifacts, err := wk.Client().WorkflowRunArtifacts(project, workflow, n)
+	if err != nil {
+		return res, err
+	}
+
+	// Several jobs can take a snapshot with the same name, the last uploaded one is restored
+	name := workspaceSnapshotName(a)
+	var snapshot *sdk.WorkflowNodeRunArtifact
+	for i := range artifacts {
+		if artifacts[i].Name != sdk.WorkspaceSnapshotArtifactName(name) || artifacts[i].Tag != sdk.WorkspaceSnapshotArtifactTag {
+			continue
+		}
+		if snapshot == nil || artifacts[i].ID > snapshot.ID {
+			snapshot = &artifacts[i]
+		}
+	}
+	if snapshot == nil {
+		res.Status = sdk.StatusFail
+		res.Reason = fmt.Sprintf("Snapshot %s not found in workflow %s/%s on run %d", name, project, workflow, n)
+		wk.SendLog(ctx, workerruntime.LevelError, res.Reason)
+		return res, fmt.Errorf("snapshot %s not found", name)
+	}
+
+	archivePath := filepath.Join(tmp, snapshot.Name)
+	f, err := os.OpenFile(archivePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0600))
+	if err != nil {
+		return res, sdk.WrapError(err, "unable to create file %s", archivePath)
+	}
+	defer os.RemoveAll(archivePath) // nolint
+
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Downloading snapshot %s from workflow %s/%s on run %d...", name, project, workflow, n))
+	if err := wk.Client().WorkflowNodeRunArtifactDownload(project, workflow, *snapshot, f); err != nil {
+		_ = f.Close()
+		return res, sdk.WrapError(err, "unable to download snapshot %s", name)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = f.Close()
+		return res, sdk.WithStack(err)
+	}
+
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Restoring snapshot %s in workspace %s...", name, abs))
+	err = readWorkspaceSnapshot(f, abs)
+	_ = f.Close()
+	if err != nil {
+		return res, sdk.WrapError(err, "unable to restore snapshot %s", name)
+	}
+
+	return res, nil
+}
+
+// writeWorkspaceSnapshot writes a gzipped tar archive of the content of given directory.
+func writeWorkspaceSnapshot(dir, archivePath string) error {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	paths := make([]string, len(infos))
+	for i := range infos {
+		paths[i] = infos[i].Name()
+	}
+
+	f, err := os.OpenFile(archivePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0600))
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer f.Close() // nolint
+
+	gw := gzip.NewWriter(f)
+	if err := sdk.CreateTarFromPaths(afero.NewOsFs(), dir, paths, gw, nil); err != nil {
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return sdk.WithStack(err)
+	}
+	return sdk.WithStack(f.Close())
+}
+
+// readWorkspaceSnapshot extracts a gzipped tar archive written by writeWorkspaceSnapshot in given directory. Existing
+// files are overwritten, entries outside of the directory are rejected.
+func readWorkspaceSnapshot(r io.Reader, dir string) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer gr.Close() // nolint
+
+	dir = filepath.Clean(dir)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+
+		target := filepath.Join(dir, header.Name)
+		if target != dir && !strings.HasPrefix(target, dir+string(filepath.Separator)) {
+			return fmt.Errorf("invalid path %s in snapshot", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, os.FileMode(header.Mode)|0700); err != nil {
+				return sdk.WithStack(err)
+			}
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return sdk.WithStack(err)
+			}
+			_ = os.Remove(target)
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return sdk.WithStack(err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return sdk.WithStack(err)
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				_ = f.Close()
+				return sdk.WithStack(err)
+			}
+			if err := f.Close(); err != nil {
+				return sdk.WithStack(err)
+			}
+		}
+	}
+}
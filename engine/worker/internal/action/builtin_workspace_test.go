@@ -0,0 +1,71 @@
+package action
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceSnapshot(t *testing.T) {
+	src, err := ioutil.TempDir("", "workspace-src")
+	require.NoError(t, err)
+	defer os.RemoveAll(src)
+	dst, err := ioutil.TempDir("", "workspace-dst")
+	require.NoError(t, err)
+	defer os.RemoveAll(dst)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "bin", "sub"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "main.go"), []byte("package main"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "bin", "sub", "app"), []byte("binary"), 0755))
+	require.NoError(t, os.Symlink(filepath.Join(src, "bin", "sub", "app"), filepath.Join(src, "app")))
+
+	// Existing files of the workspace are overwritten
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dst, "main.go"), []byte("package main // with a longer content"), 0644))
+
+	archivePath := filepath.Join(os.TempDir(), "workspace.test.tar.gz")
+	defer os.RemoveAll(archivePath)
+	require.NoError(t, writeWorkspaceSnapshot(src, archivePath))
+
+	f, err := os.Open(archivePath)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, readWorkspaceSnapshot(f, dst))
+
+	btes, err := ioutil.ReadFile(filepath.Join(dst, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main", string(btes))
+
+	fi, err := os.Stat(filepath.Join(dst, "bin", "sub", "app"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), fi.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(dst, "app"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("bin", "sub", "app"), link)
+}
+
+func TestWorkspaceSnapshotInvalidPath(t *testing.T) {
+	dst, err := ioutil.TempDir("", "workspace-dst")
+	require.NoError(t, err)
+	defer os.RemoveAll(dst)
+
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../evil", Mode: 0644, Size: 4, Typeflag: tar.TypeReg}))
+	_, err = tw.Write([]byte("evil"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	assert.Error(t, readWorkspaceSnapshot(buf, dst))
+	_, err = os.Stat(filepath.Join(filepath.Dir(dst), "evil"))
+	assert.True(t, os.IsNotExist(err))
+}
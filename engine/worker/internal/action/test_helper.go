@@ -78,7 +78,7 @@ func (w TestWorker) SendLog(ctx context.Context, level workerruntime.Level, form
 	w.t.Log("SendLog> [" + string(level) + "] " + format)
 
 }
-func (_ TestWorker) Unregister(ctx context.Context) error {
+func (_ TestWorker) Unregister(ctx context.Context, shutdown sdk.WorkerShutdown) error {
 	return nil
 }
 
@@ -118,6 +118,10 @@ func (w TestWorker) InstallKeyTo(key sdk.Variable, destinationPath string) (*wor
 	}, nil
 }
 
+func (_ TestWorker) SecretsDirectory() string {
+	return ""
+}
+
 var _ workerruntime.Runtime = new(TestWorker)
 
 func SetupTest(t *testing.T) (TestWorker, context.Context) {
@@ -25,6 +25,10 @@ func init() {
 	mapBuiltinActions[sdk.CoverageAction] = action.RunParseCoverageResultAction
 	mapBuiltinActions[sdk.ServeStaticFiles] = action.RunServeStaticFiles
 	mapBuiltinActions[sdk.InstallKeyAction] = action.RunInstallKey
+	mapBuiltinActions[sdk.DockerBuildAction] = action.RunDockerBuild
+	mapBuiltinActions[sdk.WorkspaceSnapshotAction] = action.RunWorkspaceSnapshot
+	mapBuiltinActions[sdk.WorkspaceRestoreAction] = action.RunWorkspaceRestore
+	mapBuiltinActions[sdk.SemverAction] = action.RunSemver
 }
 
 func (w *CurrentWorker) runBuiltin(ctx context.Context, a sdk.Action, secrets []sdk.Variable) sdk.Result {
@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// holdForDebug keeps a failed job on the worker if its debug mode is enabled, so users can attach a shell to inspect
+// the workspace through the API. It returns when the hold expires, when the shell exits or when the job is stopped.
+func (w *CurrentWorker) holdForDebug(ctx context.Context, jobInfo sdk.WorkflowNodeJobRunData, workdir string) {
+	hold, err := sdk.WorkflowNodeJobRunDebugHold(jobInfo.NodeJobRun.Parameters)
+	if err != nil {
+		log.Warning(ctx, "holdForDebug> %v", err)
+		return
+	}
+	if hold == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, hold)
+	defer cancel()
+	jobID := jobInfo.NodeJobRun.ID
+
+	shell, err := startDebugShell(ctx, workdir, w.Environ())
+	if err != nil {
+		log.Error(ctx, "holdForDebug> unable to start shell: %v", err)
+		return
+	}
+
+	params := jobInfo.NodeJobRun.Parameters
+	command := fmt.Sprintf("cdsctl workflow debug %s %s %s %d", sdk.ParameterValue(params, "cds.project"),
+		sdk.ParameterValue(params, "cds.workflow"), sdk.ParameterValue(params, "cds.run.number"), jobID)
+	infos := []sdk.SpawnInfo{{
+		RemoteTime: time.Now(),
+		Message:    sdk.SpawnMsg{ID: sdk.MsgSpawnInfoJobHeldForDebug.ID, Args: []interface{}{hold.String(), w.Name(), command}},
+	}}
+	if err := w.Client().QueueJobSendSpawnInfo(ctx, jobID, infos); err != nil {
+		log.Error(ctx, "holdForDebug> unable to send spawn info: %v", err)
+	}
+
+	deadline, _ := ctx.Deadline()
+	log.Info(ctx, "holdForDebug> job %d held for debugging until %s", jobID, deadline)
+	// The tunnel is opened again if the connection with the API is lost
+	for ctx.Err() == nil {
+		conn, err := w.Client().QueueJobDebug(ctx, jobID, time.Until(deadline))
+		if err != nil {
+			log.Warning(ctx, "holdForDebug> unable to open debug tunnel: %v", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(5 * time.Second):
+			}
+			continue
+		}
+		exited := shell.serve(ctx, conn)
+		conn.Close() // nolint
+		if exited {
+			break
+		}
+	}
+	log.Info(ctx, "holdForDebug> end of debug hold for job %d", jobID)
+}
+
+// debugShell is a shell started in the workspace of a held job.
+type debugShell struct {
+	stdin  io.WriteCloser
+	output chan []byte
+	done   chan struct{}
+}
+
+func startDebugShell(ctx context.Context, workdir string, env []string) (*debugShell, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-i")
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd")
+	}
+	cmd.Dir = workdir
+	cmd.Env = env
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	if err := cmd.Start(); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	s := &debugShell{
+		stdin:  stdin,
+		output: make(chan []byte, 100),
+		done:   make(chan struct{}),
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Debug("debugShell> shell exited: %v", err)
+		}
+		pw.Close() // nolint
+	}()
+	go func() {
+		defer close(s.done)
+		buf := make([]byte, 4096)
+		for {
+			n, err := pr.Read(buf)
+			if n > 0 {
+				data := make([]byte, n)
+				copy(data, buf[:n])
+				s.output <- data
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return s, nil
+}
+
+// serve sends the outputs of the shell on the connection and writes the inputs received on the connection to the
+// shell. It returns true if the shell exited.
+func (s *debugShell) serve(ctx context.Context, conn *websocket.Conn) bool {
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var msg sdk.WorkflowNodeJobRunDebugMessage
+			if err := websocket.JSON.Receive(conn, &msg); err != nil {
+				return
+			}
+			if msg.Type != sdk.WorkflowNodeJobRunDebugMessageInput {
+				continue
+			}
+			if _, err := s.stdin.Write(msg.Data); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case data := <-s.output:
+			if err := websocket.JSON.Send(conn, sdk.WorkflowNodeJobRunDebugMessage{Type: sdk.WorkflowNodeJobRunDebugMessageOutput, Data: data}); err != nil {
+				return false
+			}
+		case <-s.done:
+			// Send the remaining outputs then the end of the shell
+			for len(s.output) > 0 {
+				websocket.JSON.Send(conn, sdk.WorkflowNodeJobRunDebugMessage{Type: sdk.WorkflowNodeJobRunDebugMessageOutput, Data: <-s.output}) // nolint
+			}
+			websocket.JSON.Send(conn, sdk.WorkflowNodeJobRunDebugMessage{Type: sdk.WorkflowNodeJobRunDebugMessageExit}) // nolint
+			return true
+		case <-ctx.Done():
+			websocket.JSON.Send(conn, sdk.WorkflowNodeJobRunDebugMessage{Type: sdk.WorkflowNodeJobRunDebugMessageExit}) // nolint
+			return true
+		case <-closed:
+			return false
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestDebugShell(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dir := os.TempDir()
+	shell, err := startDebugShell(ctx, dir, []string{"MY_VAR=my-value"})
+	require.NoError(t, err)
+
+	exited := make(chan bool, 1)
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		exited <- shell.serve(ctx, conn)
+	}))
+	defer server.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http"), "", server.URL)
+	require.NoError(t, err)
+	defer conn.Close() // nolint
+
+	input := sdk.WorkflowNodeJobRunDebugMessage{Type: sdk.WorkflowNodeJobRunDebugMessageInput, Data: []byte("pwd; echo $MY_VAR; exit\n")}
+	require.NoError(t, websocket.JSON.Send(conn, input))
+
+	var output string
+	for {
+		var msg sdk.WorkflowNodeJobRunDebugMessage
+		require.NoError(t, websocket.JSON.Receive(conn, &msg))
+		if msg.Type == sdk.WorkflowNodeJobRunDebugMessageExit {
+			break
+		}
+		output += string(msg.Data)
+	}
+	assert.Contains(t, output, "my-value")
+	assert.Contains(t, output, strings.TrimSuffix(dir, "/"))
+	assert.True(t, <-exited)
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	// fingerprintToolTimeout is the maximum duration to get the version of a binary required by the job.
+	fingerprintToolTimeout = 5 * time.Second
+	// fingerprintToolVersionMaxLength is the maximum length of the version of a binary.
+	fingerprintToolVersionMaxLength = 256
+)
+
+// jobFingerprint collects the environment in which the job is run: system, worker, model and versions of the binaries
+// required by the job. The image and its digest are given by the hatchery.
+func (wk *CurrentWorker) jobFingerprint(ctx context.Context, requirements []sdk.Requirement) *sdk.JobFingerprint {
+	f := &sdk.JobFingerprint{
+		OS:            sdk.GOOS,
+		Arch:          sdk.GOARCH,
+		OSRelease:     osRelease(),
+		WorkerVersion: sdk.VERSION,
+		Model:         wk.register.model,
+		Image:         os.Getenv("CDS_MODEL_IMAGE"),
+		ImageDigest:   os.Getenv("CDS_MODEL_IMAGE_DIGEST"),
+		EnvVarNames:   envVarNames(os.Environ()),
+	}
+	for _, r := range requirements {
+		if r.Type != sdk.BinaryRequirement {
+			continue
+		}
+		if f.Tools == nil {
+			f.Tools = make(map[string]string)
+		}
+		f.Tools[r.Value] = toolVersion(ctx, r.Value)
+	}
+	return f
+}
+
+// osRelease returns the name of the distribution read in /etc/os-release, empty if the file doesn't exist.
+func osRelease() string {
+	btes, err := ioutil.ReadFile("/etc/os-release")
+	if err != nil {
+		return ""
+	}
+	return parseOSRelease(string(btes))
+}
+
+func parseOSRelease(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "PRETTY_NAME=") {
+			return strings.Trim(strings.TrimPrefix(line, "PRETTY_NAME="), `"'`)
+		}
+	}
+	return ""
+}
+
+// envVarNames returns the sorted names of given environment variables, without the technical variables of the worker.
+func envVarNames(environ []string) []string {
+	names := make([]string, 0, len(environ))
+	for _, e := range environ {
+		name := strings.SplitN(e, "=", 2)[0]
+		if name == "" || strings.HasPrefix(name, "CDS_") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// toolVersion returns the first line printed by the --version option of given binary.
+func toolVersion(ctx context.Context, binary string) string {
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return "not found"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fingerprintToolTimeout)
+	defer cancel()
+	// Some binaries print their version on stderr or exit with an error code, the output is kept anyway
+	out, _ := exec.CommandContext(ctx, path, "--version").CombinedOutput()
+	return firstLine(string(out), fingerprintToolVersionMaxLength)
+}
+
+func firstLine(s string, maxLength int) string {
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if len(line) > maxLength {
+			line = line[:maxLength]
+		}
+		return line
+	}
+	return "unknown"
+}
@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOSRelease(t *testing.T) {
+	content := `NAME="Debian GNU/Linux"
+VERSION_ID="10"
+PRETTY_NAME="Debian GNU/Linux 10 (buster)"
+ID=debian`
+	assert.Equal(t, "Debian GNU/Linux 10 (buster)", parseOSRelease(content))
+	assert.Equal(t, "", parseOSRelease("ID=alpine"))
+}
+
+func TestEnvVarNames(t *testing.T) {
+	names := envVarNames([]string{"PATH=/usr/bin", "CDS_TOKEN=secret", "HOME=/root", "EMPTY=", "=C:=C:\\"})
+	assert.Equal(t, []string{"EMPTY", "HOME", "PATH"}, names)
+}
+
+func TestToolVersion(t *testing.T) {
+	assert.Equal(t, "not found", toolVersion(context.TODO(), "this-binary-does-not-exist"))
+	assert.Equal(t, "unknown", firstLine("\n  \n", 10))
+	assert.Equal(t, "go version", firstLine("\ngo version go1.13\nother", 10))
+	assert.Equal(t, strings.Repeat("a", 10), firstLine(strings.Repeat("a", 20), 10))
+}
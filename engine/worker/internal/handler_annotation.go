@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func annotationHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+		defer r.Body.Close() // nolint
+
+		var a sdk.WorkflowRunAnnotation
+		if err := json.Unmarshal(data, &a); err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+		if err := a.IsValid(); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := wk.client.QueueJobAnnotation(ctx, wk.currentJob.wJob.ID, a); err != nil {
+			writeError(w, r, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/ovh/cds/engine/worker/internal/action"
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func checkoutHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+		defer r.Body.Close() // nolint
+
+		var req workerruntime.Checkout
+		if err := json.Unmarshal(data, &req); err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+		if req.Depth < 0 {
+			writeError(w, r, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid depth %d", req.Depth))
+			return
+		}
+
+		result, err := action.Checkout(wk.currentJob.context, wk, wk.currentJob.secrets, req)
+		if err != nil {
+			wk.SendLog(wk.currentJob.context, workerruntime.LevelError, fmt.Sprintf("Checkout failed: %v", err))
+			log.Error(ctx, "unable to checkout ref %s: %v", req.Ref, err)
+			writeError(w, r, err)
+			return
+		}
+		if result.Status != sdk.StatusSuccess {
+			writeError(w, r, sdk.NewErrorFrom(sdk.ErrUnknownError, "checkout failed: %s", result.Reason))
+			return
+		}
+	}
+}
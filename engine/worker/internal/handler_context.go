@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// contextHandler returns the interpolated context of the current job. Secrets are given with a placeholder value and
+// are masked in all the other values.
+func contextHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, r, sdk.ErrMethodNotAllowed)
+			return
+		}
+		if wk.currentJob.wJob == nil {
+			writeError(w, r, sdk.NewErrorFrom(sdk.ErrNotFound, "no job is running"))
+			return
+		}
+
+		c := sdk.NewWorkerJobContext(wk.currentJob.params, wk.currentJob.secrets)
+		c.Job.ID = wk.currentJob.wJob.ID
+		c.Job.NodeRunID = wk.currentJob.wJob.WorkflowNodeRunID
+		c.Job.Step = wk.currentJob.stepName
+		if c.Job.Name == "" {
+			c.Job.Name = wk.currentJob.wJob.Job.Action.Name
+		}
+		for _, v := range wk.currentJob.newVariables {
+			c.Variables[v.Name] = v.Value
+		}
+
+		for _, m := range []map[string]string{c.Parameters, c.Variables, c.Git} {
+			for k, v := range m {
+				m[k] = wk.maskSecrets(v)
+			}
+		}
+		if c.Integration != nil {
+			for k, v := range c.Integration.Config {
+				c.Integration.Config[k] = wk.maskSecrets(v)
+			}
+		}
+
+		writeJSON(w, c, http.StatusOK)
+	}
+}
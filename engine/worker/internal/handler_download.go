@@ -11,11 +11,11 @@ import (
 	"regexp"
 	"strconv"
 	"sync"
-	"time"
 
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/log"
 )
 
 func downloadHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
@@ -80,69 +80,102 @@ func downloadHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 			return
 		}
 
-		regexp, errp := regexp.Compile(reqArgs.Pattern)
-		if errp != nil {
-			newError := sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Invalid pattern %s : %s", reqArgs.Pattern, errp))
-			writeError(w, r, newError)
+		toDownload, err := filterArtifacts(artifacts, reqArgs)
+		if err != nil {
+			writeError(w, r, err)
 			return
 		}
-		wg := new(sync.WaitGroup)
-		wg.Add(len(artifacts))
 
-		//wk.SendLog(ctx,workerruntime.LevelInfo, "Downloading artifacts from into current directory")
-
-		var isInError bool
-		for i := range artifacts {
-			a := &artifacts[i]
+		parallel := reqArgs.Parallel
+		if parallel <= 0 {
+			parallel = workerruntime.DefaultDownloadParallel
+		}
 
-			if reqArgs.Pattern != "" && !regexp.MatchString(a.Name) {
-				//wk.SendLog(ctx,workerruntime.LevelError, fmt.Sprintf("%s does not match pattern %s - skipped", a.Name, reqArgs.Pattern))
-				wg.Done()
-				continue
-			}
+		// Download matching artifacts with at most 'parallel' downloads at the same time
+		sem := make(chan struct{}, parallel)
+		wg := new(sync.WaitGroup)
+		var mutex sync.Mutex
+		var errs sdk.MultiError
+		for i := range toDownload {
+			sem <- struct{}{}
 
-			if reqArgs.Tag != "" && a.Tag != reqArgs.Tag {
-				//wk.SendLog(ctx,workerruntime.LevelError, fmt.Sprintf("%s does not match tag %s - skipped", a.Name, reqArgs.Tag))
-				wg.Done()
-				continue
+			// there is one error, do not try to load all artifacts
+			mutex.Lock()
+			stop := !errs.IsEmpty()
+			mutex.Unlock()
+			if stop {
+				<-sem
+				break
 			}
 
+			wg.Add(1)
 			go func(a *sdk.WorkflowNodeRunArtifact) {
-				defer wg.Done()
-
-				path := path.Join(reqArgs.Destination, a.Name)
-				f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(a.Perm))
-				if err != nil {
-					//wk.SendLog(ctx,workerruntime.LevelError, fmt.Sprintf("Cannot download artifact (OpenFile) %s: %s", a.Name, err))
-					isInError = true
-					return
-				}
-				//wk.SendLog(ctx,workerruntime.LevelInfo, fmt.Sprintf("downloading artifact %s with tag %s from workflow %s/%s on run %d (%s)...", a.Name, a.Tag, projectKey, reqArgs.Workflow, reqArgs.Number, path))
-				if err := wk.client.WorkflowNodeRunArtifactDownload(projectKey, reqArgs.Workflow, *a, f); err != nil {
-					//wk.SendLog(ctx,workerruntime.LevelInfo, fmt.Sprintf("Cannot download artifact %s: %s", a.Name, err))
-					isInError = true
-					return
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				if err := downloadArtifact(wk, projectKey, reqArgs, *a); err != nil {
+					log.Error(ctx, "Cannot download artifact %s: %v", a.Name, err)
+					mutex.Lock()
+					errs.Append(fmt.Errorf("cannot download artifact %s: %v", a.Name, err))
+					mutex.Unlock()
 				}
-				if err := f.Close(); err != nil {
-					//wk.SendLog(ctx,workerruntime.LevelError, fmt.Sprintf("Cannot download artifact %s: %s", a.Name, err))
-					isInError = true
-					return
-				}
-			}(a)
-
-			// there is one error, do not try to load all artifacts
-			if isInError {
-				break
-			}
-			if len(artifacts) > 1 {
-				time.Sleep(3 * time.Second)
-			}
+			}(&toDownload[i])
 		}
 
 		wg.Wait()
-		if isInError {
-			newError := sdk.NewError(sdk.ErrUnknownError, fmt.Errorf("Error while downloading artefacts - see previous logs"))
+		if !errs.IsEmpty() {
+			newError := sdk.NewError(sdk.ErrUnknownError, fmt.Errorf("Error while downloading artefacts: %v", errs.Error()))
 			writeError(w, r, newError)
 		}
 	}
 }
+
+// filterArtifacts returns artifacts that match the regex pattern, one of the glob patterns and the tag of the request.
+func filterArtifacts(artifacts []sdk.WorkflowNodeRunArtifact, reqArgs workerruntime.DownloadArtifact) ([]sdk.WorkflowNodeRunArtifact, error) {
+	reg, err := regexp.Compile(reqArgs.Pattern)
+	if err != nil {
+		return nil, sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Invalid pattern %s : %s", reqArgs.Pattern, err))
+	}
+	for _, g := range reqArgs.Globs {
+		if _, err := path.Match(g, ""); err != nil {
+			return nil, sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Invalid glob pattern %s : %s", g, err))
+		}
+	}
+
+	var res []sdk.WorkflowNodeRunArtifact
+	for _, a := range artifacts {
+		if reqArgs.Pattern != "" && !reg.MatchString(a.Name) {
+			continue
+		}
+		if len(reqArgs.Globs) > 0 {
+			var match bool
+			for _, g := range reqArgs.Globs {
+				if ok, _ := path.Match(g, a.Name); ok {
+					match = true
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+		}
+		if reqArgs.Tag != "" && a.Tag != reqArgs.Tag {
+			continue
+		}
+		res = append(res, a)
+	}
+	return res, nil
+}
+
+func downloadArtifact(wk *CurrentWorker, projectKey string, reqArgs workerruntime.DownloadArtifact, a sdk.WorkflowNodeRunArtifact) error {
+	f, err := os.OpenFile(path.Join(reqArgs.Destination, a.Name), os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(a.Perm))
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := wk.client.WorkflowNodeRunArtifactDownload(projectKey, reqArgs.Workflow, a, f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return sdk.WithStack(f.Close())
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_filterArtifacts(t *testing.T) {
+	artifacts := []sdk.WorkflowNodeRunArtifact{
+		{Name: "app.tar.gz", Tag: "v1"},
+		{Name: "app.zip", Tag: "v1"},
+		{Name: "report-unit.xml", Tag: "v1"},
+		{Name: "report-unit.xml", Tag: "v2"},
+		{Name: "coverage.html", Tag: "v1"},
+	}
+
+	names := func(as []sdk.WorkflowNodeRunArtifact) []string {
+		res := make([]string, len(as))
+		for i := range as {
+			res[i] = as[i].Name + ":" + as[i].Tag
+		}
+		return res
+	}
+
+	res, err := filterArtifacts(artifacts, workerruntime.DownloadArtifact{})
+	require.NoError(t, err)
+	assert.Len(t, res, 5)
+
+	res, err = filterArtifacts(artifacts, workerruntime.DownloadArtifact{Globs: []string{"*.tar.gz", "report-*.xml"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app.tar.gz:v1", "report-unit.xml:v1", "report-unit.xml:v2"}, names(res))
+
+	res, err = filterArtifacts(artifacts, workerruntime.DownloadArtifact{Globs: []string{"report-*"}, Tag: "v2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"report-unit.xml:v2"}, names(res))
+
+	res, err = filterArtifacts(artifacts, workerruntime.DownloadArtifact{Pattern: "^app", Globs: []string{"*.zip"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app.zip:v1"}, names(res))
+
+	_, err = filterArtifacts(artifacts, workerruntime.DownloadArtifact{Globs: []string{"[app"}})
+	assert.Error(t, err)
+}
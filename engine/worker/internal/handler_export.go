@@ -10,6 +10,8 @@ import (
 	"github.com/ovh/cds/sdk/log"
 )
 
+// addBuildVarHandler adds a build variable to the current job. With the output query param, the variable is
+// exported as an output of the current step.
 func addBuildVarHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Get body
@@ -26,8 +28,26 @@ func addBuildVarHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
-		v.Name = "cds.build." + v.Name
 
+		if r.URL.Query().Get("output") == "true" {
+			name, err := sdk.StepOutputName(wk.currentJob.stepName, v.Name)
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			v.Name = name
+		} else {
+			v.Name = "cds.build." + v.Name
+		}
+
+		// An exported variable replaces the previous one with the same name
+		for i := range wk.currentJob.newVariables {
+			if wk.currentJob.newVariables[i].Name == v.Name {
+				wk.currentJob.newVariables[i].Value = v.Value
+				log.Debug("Variable %s updated in %+v", v.Name, wk.currentJob.newVariables)
+				return
+			}
+		}
 		wk.currentJob.newVariables = append(wk.currentJob.newVariables, v)
 		log.Debug("Variable %s added to %+v", v.Name, wk.currentJob.newVariables)
 	}
@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_addBuildVarHandler(t *testing.T) {
+	var w = new(CurrentWorker)
+	h := addBuildVarHandler(context.TODO(), w)
+
+	post := func(url string, v sdk.Variable) *httptest.ResponseRecorder {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, post("/var", sdk.Variable{Name: "foo", Value: "bar"}).Code)
+
+	// A step without name can't export outputs
+	assert.Equal(t, http.StatusBadRequest, post("/var?output=true", sdk.Variable{Name: "version", Value: "1.0.0"}).Code)
+
+	w.currentJob.stepName = "build"
+	assert.Equal(t, http.StatusOK, post("/var?output=true", sdk.Variable{Name: "version", Value: "1.0.0"}).Code)
+	assert.Equal(t, http.StatusOK, post("/var?output=true", sdk.Variable{Name: "version", Value: "1.0.1"}).Code)
+	assert.Equal(t, http.StatusBadRequest, post("/var?output=true", sdk.Variable{Name: "my.version", Value: "1.0.1"}).Code)
+
+	require.Len(t, w.currentJob.newVariables, 2)
+	assert.Equal(t, "cds.build.foo", w.currentJob.newVariables[0].Name)
+	assert.Equal(t, "outputs.build.version", w.currentJob.newVariables[1].Name)
+	assert.Equal(t, "1.0.1", w.currentJob.newVariables[1].Value)
+}
@@ -45,6 +45,10 @@ func tmplHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 			tmpvars[v.Name] = v.Value
 		}
 
+		for _, s := range referencedSecrets(string(btes), wk.currentJob.secrets) {
+			wk.recordSecretUsage(s, sdk.SecretUsageSourceInterpolation, wk.currentJob.stepOrder)
+		}
+
 		res, err := interpolate.Do(string(btes), tmpvars)
 		if err != nil {
 			log.Error(ctx, "Unable to interpolate: %v", err)
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func toolInstallHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+		defer r.Body.Close() // nolint
+
+		var req workerruntime.ToolInstall
+		if err := json.Unmarshal(data, &req); err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+		if req.Name == "" || req.Version == "" {
+			writeError(w, r, sdk.NewErrorFrom(sdk.ErrWrongRequest, "tool name and version are mandatory"))
+			return
+		}
+
+		catalog, err := wk.loadToolsCatalog(ctx)
+		if err != nil {
+			log.Error(ctx, "worker tools install > %v", err)
+			writeError(w, r, err)
+			return
+		}
+
+		tool, err := catalog.Find(req.Name, req.Version, sdk.GOOS, sdk.GOARCH)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		workingDirectory, err := workerruntime.WorkingDirectory(wk.currentJob.context)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		tmpDirectory, err := workerruntime.TmpDirectory(wk.currentJob.context)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		destination := path.Join(workingDirectory.Name(), ".cds", "tools", tool.Name, tool.Version)
+		binDir, err := installTool(ctx, wk.BaseDir(), tmpDirectory.Name(), destination, *tool, func(ctx context.Context, url string) (io.ReadCloser, error) {
+			resp, err := toolsHTTPGet(ctx, url)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Body, nil
+		})
+		if err != nil {
+			log.Error(ctx, "worker tools install > cannot install tool %s %s: %v", tool.Name, tool.Version, err)
+			writeError(w, r, err)
+			return
+		}
+
+		binAbs := binDir
+		if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
+			binAbs, err = x.RealPath(binDir)
+			if err != nil {
+				writeError(w, r, sdk.WithStack(err))
+				return
+			}
+		}
+		binAbs, err = filepath.Abs(binAbs)
+		if err != nil {
+			writeError(w, r, sdk.WithStack(err))
+			return
+		}
+
+		wk.currentJob.toolPaths = append(wk.currentJob.toolPaths, binAbs)
+
+		writeJSON(w, workerruntime.ToolInstallResponse{
+			Name:    tool.Name,
+			Version: tool.Version,
+			Path:    binAbs,
+		}, http.StatusOK)
+	}
+}
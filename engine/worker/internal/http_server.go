@@ -46,18 +46,25 @@ func (w *CurrentWorker) Serve(c context.Context) error {
 	log.Info(c, "Export variable HTTP server: %s", listener.Addr().String())
 	r := mux.NewRouter()
 
+	r.HandleFunc("/annotation", LogMiddleware(annotationHandler(c, w)))
 	r.HandleFunc("/artifacts", LogMiddleware(artifactsHandler(c, w)))
 	r.HandleFunc("/cache/{ref}/pull", LogMiddleware(cachePullHandler(c, w)))
 	r.HandleFunc("/cache/push", LogMiddleware(cachePushHandler(c, w)))
+	r.HandleFunc("/checkout", LogMiddleware(checkoutHandler(c, w)))
+	r.HandleFunc("/context", LogMiddleware(contextHandler(c, w)))
 	r.HandleFunc("/download", LogMiddleware(downloadHandler(c, w)))
 	r.HandleFunc("/exit", LogMiddleware(exitHandler(c, w)))
 	r.HandleFunc("/key/{key}/install", LogMiddleware(keyInstallHandler(c, w)))
+	r.HandleFunc("/metrics/push", LogMiddleware(metricsPushHandler(c, w)))
+	r.HandleFunc("/run-result", LogMiddleware(runResultHandler(c, w)))
 	r.HandleFunc("/tag", LogMiddleware(tagHandler(c, w)))
 	r.HandleFunc("/tmpl", LogMiddleware(tmplHandler(c, w)))
+	r.HandleFunc("/tools/install", LogMiddleware(toolInstallHandler(c, w)))
 	r.HandleFunc("/upload", LogMiddleware(uploadHandler(c, w)))
 	r.HandleFunc("/checksecret", LogMiddleware(checkSecretHandler(c, w)))
 	r.HandleFunc("/var", LogMiddleware(addBuildVarHandler(c, w)))
 	r.HandleFunc("/vulnerability", LogMiddleware(vulnerabilityHandler(c, w)))
+	r.NotFoundHandler = LogMiddleware(notFoundHandler())
 
 	srv := &http.Server{
 		Handler:      r,
@@ -83,6 +90,14 @@ func (w *CurrentWorker) Serve(c context.Context) error {
 	return nil
 }
 
+// notFoundHandler returns a clear error when a worker command calls a handler that does not exist in the running worker,
+// it happens when the command comes from a more recent worker binary than the one running the job.
+func notFoundHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, sdk.NewErrorFrom(sdk.ErrWorkerVersionOutdated, "%s %s is not supported by the running worker in version %s, please upgrade the worker binary", r.Method, r.URL.Path, sdk.VERSION))
+	}
+}
+
 func writeByteArray(w http.ResponseWriter, data []byte, status int) {
 	w.Header().Add("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -18,15 +18,28 @@ import (
 )
 
 func (wk *CurrentWorker) InstallKey(key sdk.Variable) (*workerruntime.KeyResponse, error) {
+	wk.recordSecretUsage(key, sdk.SecretUsageSourceKeyInstall, wk.currentJob.stepOrder)
 	switch key.Type {
 	case string(sdk.KeyTypeSSH):
-		keysDirectory, err := workerruntime.KeysDirectory(wk.currentJob.context)
-		if err != nil {
-			return nil, sdk.WithStack(err)
+		fs := wk.basedir
+		var keysDirectory string
+		if wk.currentJob.secretsDir != "" {
+			// Keys are installed in the secrets mount of the job to never be written on disk
+			fs = afero.NewOsFs()
+			keysDirectory = filepath.Join(wk.currentJob.secretsDir, "keys")
+			if err := fs.MkdirAll(keysDirectory, os.FileMode(0700)); err != nil {
+				return nil, sdk.WithStack(err)
+			}
+		} else {
+			kd, err := workerruntime.KeysDirectory(wk.currentJob.context)
+			if err != nil {
+				return nil, sdk.WithStack(err)
+			}
+			keysDirectory = kd.Name()
 		}
 
-		installedKeyPath := path.Join(keysDirectory.Name(), key.Name)
-		if err := vcs.CleanAllSSHKeys(wk.basedir, keysDirectory.Name()); err != nil {
+		installedKeyPath := path.Join(keysDirectory, key.Name)
+		if err := vcs.CleanAllSSHKeys(fs, keysDirectory); err != nil {
 			errClean := sdk.Error{
 				Message: fmt.Sprintf("Cannot clean ssh keys : %v", err),
 				Status:  http.StatusInternalServerError,
@@ -34,7 +47,7 @@ func (wk *CurrentWorker) InstallKey(key sdk.Variable) (*workerruntime.KeyRespons
 			return nil, sdk.WithStack(errClean)
 		}
 
-		if err := vcs.SetupSSHKey(wk.basedir, keysDirectory.Name(), key); err != nil {
+		if err := vcs.SetupSSHKey(fs, keysDirectory, key); err != nil {
 			errSetup := sdk.Error{
 				Message: fmt.Sprintf("Cannot setup ssh key %s : %v", key.Name, err),
 				Status:  http.StatusInternalServerError,
@@ -42,10 +55,18 @@ func (wk *CurrentWorker) InstallKey(key sdk.Variable) (*workerruntime.KeyRespons
 			return nil, sdk.WithStack(errSetup)
 		}
 
-		if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
+		if x, ok := fs.(*afero.BasePathFs); ok {
 			installedKeyPath, _ = x.RealPath(installedKeyPath)
 		}
 
+		if err := workerruntime.SetupKeyFile(installedKeyPath); err != nil {
+			errSetup := sdk.Error{
+				Message: fmt.Sprintf("Cannot setup ssh key %s : %v", key.Name, err),
+				Status:  http.StatusInternalServerError,
+			}
+			return nil, sdk.WithStack(errSetup)
+		}
+
 		return &workerruntime.KeyResponse{
 			PKey:    installedKeyPath,
 			Type:    sdk.KeyTypeSSH,
@@ -69,7 +90,8 @@ func (wk *CurrentWorker) InstallKey(key sdk.Variable) (*workerruntime.KeyRespons
 			}
 		}
 		content := []byte(key.Value)
-		tmpfile, errTmpFile := ioutil.TempFile("", key.Name)
+		// The key is written in the secrets mount of the job if any, else in the default temp directory
+		tmpfile, errTmpFile := ioutil.TempFile(wk.currentJob.secretsDir, key.Name)
 		if errTmpFile != nil {
 			errFile := sdk.Error{
 				Message: fmt.Sprintf("Cannot setup pgp key %s : %v", key.Name, errTmpFile),
@@ -127,6 +149,7 @@ func (wk *CurrentWorker) InstallKey(key sdk.Variable) (*workerruntime.KeyRespons
 }
 
 func (wk *CurrentWorker) InstallKeyTo(key sdk.Variable, destinationPath string) (*workerruntime.KeyResponse, error) {
+	wk.recordSecretUsage(key, sdk.SecretUsageSourceKeyInstall, wk.currentJob.stepOrder)
 	switch key.Type {
 	case string(sdk.KeyTypeSSH):
 		var absPath string
@@ -152,6 +175,14 @@ func (wk *CurrentWorker) InstallKeyTo(key sdk.Variable, destinationPath string)
 			return nil, sdk.WithStack(errSetup)
 		}
 
+		if err := workerruntime.SetupKeyFile(destinationPath); err != nil {
+			errSetup := sdk.Error{
+				Message: fmt.Sprintf("Cannot setup ssh key %s : %v", key.Name, err),
+				Status:  http.StatusInternalServerError,
+			}
+			return nil, sdk.WithStack(errSetup)
+		}
+
 		return &workerruntime.KeyResponse{
 			PKey:    destinationPath,
 			Type:    sdk.KeyTypeSSH,
@@ -175,7 +206,7 @@ func (wk *CurrentWorker) InstallKeyTo(key sdk.Variable, destinationPath string)
 			}
 		}
 		content := []byte(key.Value)
-		tmpfile, errTmpFile := ioutil.TempFile("", key.Name)
+		tmpfile, errTmpFile := ioutil.TempFile(wk.currentJob.secretsDir, key.Name)
 		if errTmpFile != nil {
 			errFile := sdk.Error{
 				Message: fmt.Sprintf("Cannot setup pgp key %s : %v", key.Name, errTmpFile),
@@ -93,6 +93,39 @@ func TestInstallKey_SSHKeyWithoutDestination(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestInstallKey_SSHKeyInSecretsDirectory(t *testing.T) {
+	var w = new(CurrentWorker)
+	fs := afero.NewOsFs()
+	basedir := "test-" + test.GetTestName(t) + "-" + sdk.RandomString(10) + "-" + fmt.Sprintf("%d", time.Now().Unix())
+	require.NoError(t, fs.MkdirAll(basedir, os.FileMode(0755)))
+
+	if err := w.Init("test-worker", "test-hatchery", "http://lolcat.host", "xxx-my-token", "", true, afero.NewBasePathFs(fs, basedir)); err != nil {
+		t.Fatalf("worker init failed: %v", err)
+	}
+
+	secretsDir, err := ioutil.TempDir("", "secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(secretsDir) // nolint
+	w.currentJob.secretsDir = secretsDir
+	w.currentJob.context = context.TODO()
+
+	priKey := generatePrivateKey(t, 2048)
+	priKeyPEM := encodePrivateKeyToPEM(priKey)
+	priKeyVar := sdk.Variable{
+		Name:  "my-ssh-key",
+		Type:  string(sdk.KeyTypeSSH),
+		Value: string(priKeyPEM),
+	}
+
+	resp, err := w.InstallKey(priKeyVar)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(secretsDir, "keys", "my-ssh-key"), resp.PKey)
+
+	content, err := ioutil.ReadFile(resp.PKey)
+	require.NoError(t, err)
+	assert.Equal(t, string(priKeyPEM), string(content))
+}
+
 func TestInstallKey_SSHKeyWithRelativeDestination(t *testing.T) {
 	var w = new(CurrentWorker)
 	fs := afero.NewOsFs()
@@ -0,0 +1,210 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	// DefaultLogSpoolMaxSize is the default maximum size in bytes of the log lines waiting to be sent
+	DefaultLogSpoolMaxSize int64 = 100 * 1024 * 1024
+	// logSpoolBatchSize is the maximum size of the log lines read from the spool at once
+	logSpoolBatchSize int64 = 1024 * 1024
+)
+
+// logSpool is a disk-backed queue of the log lines of a job. Lines are appended by the steps and removed by the sender
+// once they were sent, so a burst of lines or an unavailable API never blocks a step nor loses lines. When the lines
+// waiting to be sent reach the maximum size, new lines are dropped and a notice is added once there is space again.
+type logSpool struct {
+	mu      sync.Mutex
+	fs      afero.Fs
+	path    string
+	file    afero.File
+	maxSize int64
+	// start is the offset of the first line not sent, end is the offset of the end of the last line
+	start   int64
+	end     int64
+	dropped int64
+	closed  bool
+}
+
+type spooledLog struct {
+	sdk.Log
+	// end is the offset in the spool of the end of the line
+	end int64
+}
+
+func newLogSpool(fs afero.Fs, path string, maxSize int64) (*logSpool, error) {
+	f, err := fs.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, os.FileMode(0600))
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to create log spool %s", path)
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultLogSpoolMaxSize
+	}
+	return &logSpool{fs: fs, path: path, file: f, maxSize: maxSize}, nil
+}
+
+// Push appends a log line to the spool. The final line of a step is always kept even if the spool is full.
+func (s *logSpool) Push(l sdk.Log) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return sdk.WithStack(fmt.Errorf("log spool %s is closed", s.path))
+	}
+
+	data, err := json.Marshal(l)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	data = append(data, '\n')
+
+	if s.end-s.start+int64(len(data)) > s.maxSize && l.Done == nil {
+		s.dropped++
+		return nil
+	}
+
+	if s.dropped > 0 {
+		notice := l
+		notice.Val = fmt.Sprintf("[WARN] %d log lines were dropped because the log spool of the worker was full\n", s.dropped)
+		notice.Done = nil
+		noticeData, err := json.Marshal(notice)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		if err := s.write(append(noticeData, '\n')); err != nil {
+			return err
+		}
+		s.dropped = 0
+	}
+
+	return s.write(data)
+}
+
+func (s *logSpool) write(data []byte) error {
+	// A partial write is overwritten by the next line
+	if _, err := s.file.WriteAt(data, s.end); err != nil {
+		return sdk.WrapError(err, "unable to write in log spool %s", s.path)
+	}
+	s.end += int64(len(data))
+	return nil
+}
+
+// Peek returns the oldest lines of the spool up to given size, a line bigger than the size is returned alone. The
+// lines are kept in spool until they are committed.
+func (s *logSpool) Peek(maxSize int64) ([]spooledLog, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.end == s.start {
+		return nil, nil
+	}
+
+	size := s.end - s.start
+	if size > maxSize {
+		size = maxSize
+	}
+	buf, err := s.read(size)
+	if err != nil {
+		return nil, err
+	}
+	i := bytes.LastIndexByte(buf, '\n')
+	if i < 0 {
+		if buf, err = s.read(s.end - s.start); err != nil {
+			return nil, err
+		}
+		i = bytes.IndexByte(buf, '\n')
+	}
+	buf = buf[:i+1]
+
+	var logs []spooledLog
+	offset := s.start
+	for _, line := range bytes.SplitAfter(buf, []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		offset += int64(len(line))
+		var l sdk.Log
+		if err := json.Unmarshal(line, &l); err != nil {
+			return nil, sdk.WrapError(err, "invalid line in log spool %s", s.path)
+		}
+		logs = append(logs, spooledLog{Log: l, end: offset})
+	}
+	return logs, nil
+}
+
+func (s *logSpool) read(size int64) ([]byte, error) {
+	buf := make([]byte, size)
+	if _, err := s.file.ReadAt(buf, s.start); err != nil && err != io.EOF {
+		return nil, sdk.WrapError(err, "unable to read log spool %s", s.path)
+	}
+	return buf, nil
+}
+
+// Commit removes from the spool the lines that end before given offset. The file is truncated once all the lines
+// were sent to reclaim its space.
+func (s *logSpool) Commit(offset int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	if offset > s.start {
+		s.start = offset
+	}
+	if s.start == s.end && s.end > 0 {
+		if err := s.file.Truncate(0); err != nil {
+			return sdk.WrapError(err, "unable to truncate log spool %s", s.path)
+		}
+		s.start, s.end = 0, 0
+	}
+	return nil
+}
+
+// Len returns the size of the lines waiting to be sent.
+func (s *logSpool) Len() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.end - s.start
+}
+
+// Close removes the spool, the lines that were not sent are lost.
+func (s *logSpool) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if err := s.file.Close(); err != nil {
+		return sdk.WrapError(err, "unable to close log spool %s", s.path)
+	}
+	if err := s.fs.Remove(s.path); err != nil {
+		return sdk.WrapError(err, "unable to remove log spool %s", s.path)
+	}
+	return nil
+}
+
+// mergeSpooledLogs merges the consecutive lines of a step, so they are sent at once.
+func mergeSpooledLogs(lines []spooledLog) []spooledLog {
+	var merged []spooledLog
+	for _, l := range lines {
+		if n := len(merged); n > 0 && merged[n-1].StepOrder == l.StepOrder && merged[n-1].Done == nil {
+			last := &merged[n-1]
+			last.Val += l.Val
+			last.LastModified = l.LastModified
+			last.Done = l.Done
+			last.end = l.end
+			continue
+		}
+		merged = append(merged, l)
+	}
+	return merged
+}
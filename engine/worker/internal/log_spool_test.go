@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestLogSpool(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	s, err := newLogSpool(fs, "log-spool.jsonl", DefaultLogSpoolMaxSize)
+	require.NoError(t, err)
+
+	for _, v := range []string{"a\n", "b\n", "c\n"} {
+		require.NoError(t, s.Push(*sdk.NewLog(1, 1, v, 1)))
+	}
+	require.NoError(t, s.Push(*sdk.NewLog(1, 1, "d\n", 2)))
+
+	lines, err := s.Peek(logSpoolBatchSize)
+	require.NoError(t, err)
+	require.Len(t, lines, 4)
+
+	merged := mergeSpooledLogs(lines)
+	require.Len(t, merged, 2)
+	require.Equal(t, "a\nb\nc\n", merged[0].Val)
+	require.Equal(t, "d\n", merged[1].Val)
+
+	// Only the first merged line was sent, the other one is kept
+	require.NoError(t, s.Commit(merged[0].end))
+	lines, err = s.Peek(logSpoolBatchSize)
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	require.Equal(t, "d\n", lines[0].Val)
+
+	// The file is truncated once all the lines were sent
+	require.NoError(t, s.Commit(lines[0].end))
+	require.Equal(t, int64(0), s.Len())
+	fi, err := fs.Stat("log-spool.jsonl")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), fi.Size())
+
+	// A line bigger than the batch size is returned alone
+	require.NoError(t, s.Push(*sdk.NewLog(1, 1, strings.Repeat("x", 100)+"\n", 1)))
+	require.NoError(t, s.Push(*sdk.NewLog(1, 1, "y\n", 1)))
+	lines, err = s.Peek(10)
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	require.Equal(t, strings.Repeat("x", 100)+"\n", lines[0].Val)
+
+	require.NoError(t, s.Close())
+	_, err = fs.Stat("log-spool.jsonl")
+	require.Error(t, err)
+}
+
+func TestLogSpoolMaxSize(t *testing.T) {
+	s, err := newLogSpool(afero.NewMemMapFs(), "log-spool.jsonl", 1024)
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, s.Push(*sdk.NewLog(1, 1, "my log line\n", 1)))
+	}
+	require.True(t, s.Len() <= 1024)
+
+	// The final line of the step is kept even if the spool is full
+	done := time.Now()
+	final := sdk.NewLog(1, 1, "end\n", 1)
+	final.Done = &done
+	require.NoError(t, s.Push(*final))
+
+	// The lines that were dropped are notified before the final line
+	lines, err := s.Peek(logSpoolBatchSize)
+	require.NoError(t, err)
+	require.True(t, len(lines) < 100)
+	kept := len(lines) - 2
+	notice := lines[kept]
+	require.Equal(t, fmt.Sprintf("[WARN] %d log lines were dropped because the log spool of the worker was full\n", 100-kept), notice.Val)
+	last := lines[kept+1]
+	require.Equal(t, "end\n", last.Val)
+	require.NotNil(t, last.Done)
+
+	// Lines are accepted again once there is space
+	require.NoError(t, s.Commit(last.end))
+	require.NoError(t, s.Push(*sdk.NewLog(1, 1, "next\n", 2)))
+	lines, err = s.Peek(logSpoolBatchSize)
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	require.Equal(t, "next\n", lines[0].Val)
+}
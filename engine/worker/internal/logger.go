@@ -1,29 +1,30 @@
 package internal
 
 import (
-	"container/list"
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
 
+// logSpoolFlushTimeout is the maximum duration to wait for the spooled logs to be sent at the end of a job
+const logSpoolFlushTimeout = 5 * time.Minute
+
 func (wk *CurrentWorker) sendLog(buildID int64, value string, stepOrder int, final bool) error {
-	if wk.currentJob.wJob == nil {
+	if wk.currentJob.wJob == nil || wk.logger.spool == nil {
 		log.Error(wk.GetContext(), "unable to send log: %s", value)
 		return nil
 	}
-	if err := wk.Blur(&value); err != nil {
-		return err
-	}
+	value = wk.maskSecrets(value)
 	now := time.Now()
 	l := sdk.NewLog(buildID, wk.currentJob.wJob.WorkflowNodeRunID, value, stepOrder)
 	if final {
 		l.Done = &now
 	}
-	wk.logger.logChan <- *l
-	return nil
+	// Lines are written in the spool so the step is never blocked by the sending of the logs
+	return wk.logger.spool.Push(*l)
 }
 
 func (wk *CurrentWorker) logProcessor(ctx context.Context, jobID int64) error {
@@ -32,69 +33,72 @@ func (wk *CurrentWorker) logProcessor(ctx context.Context, jobID int64) error {
 		ticker.Stop()
 	}()
 
-	wk.logger.llist = list.New()
 	for {
 		select {
-		case l := <-wk.logger.logChan:
-			wk.logger.llist.PushBack(l)
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			wk.sendHTTPLog(ctx, jobID)
+			if err := wk.sendSpooledLogs(ctx, jobID); err != nil {
+				log.Error(ctx, "error: cannot send logs: %s", err)
+			}
 		}
 	}
 }
 
-func (wk *CurrentWorker) sendHTTPLog(ctx context.Context, jobID int64) {
-	var logs []*sdk.Log
-	var currentStepLog *sdk.Log
-	// While list is not empty
-	for wk.logger.llist.Len() > 0 {
-		// get older log line
-		e := wk.logger.llist.Front()
-		l := e.Value.(sdk.Log)
-		wk.logger.llist.Remove(e)
+// sendSpooledLogs sends the spooled lines, the consecutive lines of a step are merged. The lines are removed from the
+// spool once sent, it stops at the first error so the remaining lines are sent at next call.
+func (wk *CurrentWorker) sendSpooledLogs(ctx context.Context, jobID int64) error {
+	wk.logger.sendMu.Lock()
+	defer wk.logger.sendMu.Unlock()
 
-		// First log
-		if currentStepLog == nil {
-			currentStepLog = &l
-		} else if l.StepOrder == currentStepLog.StepOrder {
-			currentStepLog.Val += l.Val
-			currentStepLog.LastModified = l.LastModified
-			currentStepLog.Done = l.Done
-		} else {
-			// new Step
-			logs = append(logs, currentStepLog)
-			currentStepLog = &l
+	for {
+		lines, err := wk.logger.spool.Peek(logSpoolBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return nil
 		}
-	}
-
-	// insert last step
-	if currentStepLog != nil {
-		logs = append(logs, currentStepLog)
-	}
-
-	if len(logs) == 0 {
-		return
-	}
 
-	for _, l := range logs {
-		log.Debug("LOG: %v", l.Val)
-		// TODO: stop the worker a nice way,
-		// for the moment we are using context.Background and not the job context
-		if err := wk.Client().QueueSendLogs(context.Background(), jobID, *l); err != nil {
-			log.Error(ctx, "error: cannot send logs: %s", err)
-			continue
+		for _, l := range mergeSpooledLogs(lines) {
+			log.Debug("LOG: %v", l.Val)
+			// TODO: stop the worker a nice way,
+			// for the moment we are using context.Background and not the job context
+			if err := wk.Client().QueueSendLogs(context.Background(), jobID, l.Log); err != nil {
+				return err
+			}
+			if err := wk.logger.spool.Commit(l.end); err != nil {
+				return err
+			}
 		}
 	}
 }
 
-func (wk *CurrentWorker) drainLogsAndCloseLogger(c context.Context) error {
-	var i int
-	for (len(wk.logger.logChan) > 0 || (wk.logger.llist != nil && wk.logger.llist.Len() > 0)) && i < 60 {
+// drainLogsAndCloseLogger sends all the spooled logs of the job before removing the spool. It gives up after a timeout
+// if the logs can't be sent.
+func (wk *CurrentWorker) drainLogsAndCloseLogger(c context.Context, jobID int64) error {
+	defer func() {
+		if err := wk.logger.spool.Close(); err != nil {
+			log.Error(c, "unable to close log spool: %v", err)
+		}
+	}()
+
+	timeout := time.After(logSpoolFlushTimeout)
+	for {
+		err := wk.sendSpooledLogs(c, jobID)
+		if err == nil && wk.logger.spool.Len() == 0 {
+			return c.Err()
+		}
+		if err != nil {
+			log.Error(c, "error: cannot send logs: %s", err)
+		}
 		log.Debug("Draining logs...")
-		i++
-		time.Sleep(1 * time.Second)
+		select {
+		case <-timeout:
+			return sdk.WithStack(fmt.Errorf("unable to send all logs before timeout, %d bytes were lost", wk.logger.spool.Len()))
+		case <-c.Done():
+			return c.Err()
+		case <-time.After(1 * time.Second):
+		}
 	}
-	return c.Err()
 }
@@ -61,10 +61,11 @@ func (w *CurrentWorker) Register(ctx context.Context) error {
 	return nil
 }
 
-func (w *CurrentWorker) Unregister(ctx context.Context) error {
-	log.Info(ctx, "Unregistering worker")
+// Unregister disables the worker on the API with the reason of its shutdown.
+func (w *CurrentWorker) Unregister(ctx context.Context, shutdown sdk.WorkerShutdown) error {
+	log.Info(ctx, "Unregistering worker (reason: %s)", shutdown.Reason)
 	w.id = ""
-	if err := w.Client().WorkerUnregister(context.TODO()); err != nil {
+	if err := w.Client().WorkerUnregister(context.TODO(), shutdown); err != nil {
 		return err
 	}
 	return nil
@@ -12,6 +12,7 @@ import (
 
 	"github.com/shirou/gopsutil/mem"
 
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -26,6 +27,7 @@ var requirementCheckFuncs = map[string]func(w *CurrentWorker, r sdk.Requirement)
 	sdk.MemoryRequirement:        checkMemoryRequirement,
 	sdk.VolumeRequirement:        checkVolumeRequirement,
 	sdk.OSArchRequirement:        checkOSArchRequirement,
+	sdk.IsolationRequirement:     checkIsolationRequirement,
 }
 
 func checkRequirements(ctx context.Context, w *CurrentWorker, a *sdk.Action) (bool, []sdk.Requirement) {
@@ -209,6 +211,19 @@ func checkOSArchRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
 	return osarch[0] == strings.ToLower(sdk.GOOS) && osarch[1] == strings.ToLower(sdk.GOARCH), nil
 }
 
+// checkIsolationRequirement returns true if the worker can run the steps in their own namespaces.
+func checkIsolationRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
+	if err := workerruntime.CheckIsolation(); err != nil {
+		return false, err
+	}
+	for _, p := range sdk.IsolationPaths(r.Value) {
+		if _, err := os.Stat(p); err != nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // checkPluginDeployment returns true if current job:
 //  - is not linked to a deployment integration
 //  - is linked to a deployement integration, plugin well downloaded (in this func) and
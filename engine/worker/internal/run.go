@@ -17,6 +17,7 @@ import (
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/interpolate"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/luascript"
 )
 
 func processVariablesAndParameters(action *sdk.Action, jobParameters []sdk.Parameter, jobSecrets []sdk.Variable) error {
@@ -119,8 +120,11 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 	}
 
 	var nDisabled, nCriticalFailed int
+	var previousStepStatus string
 	for jobStepIndex, step := range a.Actions {
 		ctx = workerruntime.SetStepOrder(ctx, jobStepIndex)
+		w.currentJob.stepName = step.StepName
+		w.currentJob.stepOrder = jobStepIndex
 		if err := w.updateStepStatus(ctx, jobID, jobStepIndex, sdk.StatusBuilding); err != nil {
 			jobResult.Status = sdk.StatusFail
 			jobResult.Reason = fmt.Sprintf("Cannot update step (%d) status (%s): %v", jobStepIndex, sdk.StatusBuilding, err)
@@ -130,7 +134,20 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 			Status:  sdk.StatusNeverBuilt,
 			BuildID: jobID,
 		}
-		if nCriticalFailed == 0 || step.AlwaysExecuted {
+		var jobStatus = sdk.StatusSuccess
+		if nCriticalFailed > 0 {
+			jobStatus = sdk.StatusFail
+		}
+		run, err := w.checkStepCondition(ctx, step, step.Name, nCriticalFailed == 0 || step.AlwaysExecuted, jobStatus, previousStepStatus)
+		if err != nil {
+			stepResult.Status = sdk.StatusFail
+			stepResult.Reason = err.Error()
+			if !step.Optional {
+				nCriticalFailed++
+			}
+		} else if !run && step.Condition != "" {
+			stepResult.Status = sdk.StatusSkipped
+		} else if run {
 			stepResult = w.runAction(ctx, step, jobID, secrets, step.Name)
 
 			// Check if all newVariables are in currentJob.params
@@ -157,9 +174,14 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 			case sdk.StatusFail:
 				if !step.Optional {
 					nCriticalFailed++
+					// Let the API requeue the job if the step failed because of the infrastructure
+					if jobResult.InfraError == "" {
+						jobResult.InfraError = stepResult.InfraError
+					}
 				}
 			}
 		}
+		previousStepStatus = stepResult.Status
 		if err := w.updateStepStatus(ctx, jobID, jobStepIndex, stepResult.Status); err != nil {
 			jobResult.Status = sdk.StatusFail
 			jobResult.Reason = fmt.Sprintf("Cannot update step (%d) status (%s): %v", jobStepIndex, sdk.StatusBuilding, err)
@@ -177,6 +199,8 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 	}
 	if nCriticalFailed > 0 {
 		jobResult.Status = sdk.StatusFail
+	} else {
+		jobResult.InfraError = ""
 	}
 	return jobResult
 }
@@ -255,6 +279,7 @@ func (w *CurrentWorker) runSteps(ctx context.Context, steps []sdk.Action, a sdk.
 	}()
 	var criticalStepFailed bool
 	var nbDisabledChildren int
+	var previousStepStatus string
 
 	r := sdk.Result{
 		Status:  sdk.StatusFail,
@@ -271,14 +296,27 @@ func (w *CurrentWorker) runSteps(ctx context.Context, steps []sdk.Action, a sdk.
 			continue
 		}
 
-		if !criticalStepFailed || child.AlwaysExecuted {
+		var status = sdk.StatusSuccess
+		if criticalStepFailed {
+			status = sdk.StatusFail
+		}
+		run, err := w.checkStepCondition(ctx, child, childName, !criticalStepFailed || child.AlwaysExecuted, status, previousStepStatus)
+		if err != nil {
+			r = sdk.Result{Status: sdk.StatusFail, BuildID: jobID, Reason: err.Error()}
+			if !child.Optional {
+				criticalStepFailed = true
+			}
+		} else if !run && child.Condition != "" {
+			r = sdk.Result{Status: sdk.StatusSkipped, BuildID: jobID}
+		} else if run {
 			r = w.runAction(ctx, child, jobID, secrets, childName)
 			if r.Status != sdk.StatusSuccess && !child.Optional {
 				criticalStepFailed = true
 			}
-		} else if criticalStepFailed && !child.AlwaysExecuted {
+		} else {
 			r.Status = sdk.StatusNeverBuilt
 		}
+		previousStepStatus = r.Status
 
 		// Check if all newVariables are in currentJob.params
 		// variable can be add in w.currentJob.newVariables by worker command export
@@ -306,6 +344,47 @@ func (w *CurrentWorker) runSteps(ctx context.Context, steps []sdk.Action, a sdk.
 	return r, nbDisabledChildren
 }
 
+// checkStepCondition returns true if the step should be executed. Without condition, the default behavior is used.
+// The condition is a lua expression evaluated with the job parameters, the current status of the job (cds.job.status)
+// and the status of the previous step (cds.step.previous.status). Dots and dashes in variable names are replaced by
+// underscores, ie. git_branch == "master".
+func (w *CurrentWorker) checkStepCondition(ctx context.Context, step sdk.Action, stepName string, defaultRun bool, jobStatus, previousStepStatus string) (bool, error) {
+	if step.Condition == "" {
+		return defaultRun, nil
+	}
+
+	ok, err := checkStepCondition(step.Condition, w.currentJob.params, jobStatus, previousStepStatus)
+	if err != nil {
+		w.SendLog(ctx, workerruntime.LevelError, fmt.Sprintf("Invalid condition for step \"%s\": %v", stepName, err))
+		return false, err
+	}
+	if !ok {
+		w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Step \"%s\" skipped, condition \"%s\" is false", stepName, step.Condition))
+	}
+	return ok, nil
+}
+
+func checkStepCondition(condition string, params []sdk.Parameter, jobStatus, previousStepStatus string) (bool, error) {
+	vars := sdk.ParametersToMap(params)
+	vars["cds.job.status"] = jobStatus
+	vars["cds.step.previous.status"] = previousStepStatus
+
+	script := strings.TrimSpace(condition)
+	if !strings.HasPrefix(script, "return ") {
+		script = "return " + script
+	}
+
+	check, err := luascript.NewCheck()
+	if err != nil {
+		return false, sdk.WrapError(err, "cannot init lua system")
+	}
+	check.SetVariables(vars)
+	if err := check.Perform(script); err != nil {
+		return false, fmt.Errorf("cannot evaluate condition \"%s\": %v", condition, err)
+	}
+	return check.Result, nil
+}
+
 func (w *CurrentWorker) updateStepStatus(ctx context.Context, buildID int64, stepOrder int, status string) error {
 	step := sdk.StepStatus{
 		StepOrder: stepOrder,
@@ -511,15 +590,22 @@ func (w *CurrentWorker) ProcessJob(jobInfo sdk.WorkflowNodeJobRunData) (res sdk.
 	ctx = workerruntime.SetJobID(ctx, jobInfo.NodeJobRun.ID)
 	ctx = workerruntime.SetStepOrder(ctx, 0)
 
-	// start logger routine with a large buffer
-	w.logger.logChan = make(chan sdk.Log, 100000)
+	// start logger routine, the log lines are spooled on disk until they are sent
+	spool, err := newLogSpool(w.BaseDir(), fmt.Sprintf("log-spool-%d.jsonl", jobInfo.NodeJobRun.ID), w.logger.spoolMaxSize)
+	if err != nil {
+		return sdk.Result{
+			Status: sdk.StatusFail,
+			Reason: fmt.Sprintf("Error: unable to setup log spool: %v", err),
+		}
+	}
+	w.logger.spool = spool
 	go func() {
 		if err := w.logProcessor(ctx, jobInfo.NodeJobRun.ID); err != nil {
 			log.Error(ctx, "processJob> Logs processor error: %v", err)
 		}
 	}()
 	defer func() {
-		if err := w.drainLogsAndCloseLogger(ctx); err != nil {
+		if err := w.drainLogsAndCloseLogger(ctx, jobInfo.NodeJobRun.ID); err != nil {
 			log.Error(ctx, "processJob> Drain logs error: %v", err)
 		}
 	}()
@@ -557,6 +643,26 @@ func (w *CurrentWorker) ProcessJob(jobInfo sdk.WorkflowNodeJobRunData) (res sdk.
 	ctx = workerruntime.SetTmpDirectory(ctx, tdFile)
 	log.Debug("processJob> Setup tmp directory - %s", tdFile.Name())
 
+	w.currentJob.secretsDir = ""
+	if workerruntime.SecretsMountEnabled() {
+		secretsDir, err := w.setupSecretsDirectory(ctx, jobInfo)
+		if err != nil {
+			return sdk.Result{
+				Status: sdk.StatusFail,
+				Reason: fmt.Sprintf("Error: unable to setup secrets mount: %v", err),
+			}
+		}
+		w.currentJob.secretsDir = secretsDir
+		// Secrets are wiped whatever the way the job ends
+		defer w.teardownSecretsDirectory(ctx)
+		log.Debug("processJob> Setup secrets mount - %s", secretsDir)
+	}
+
+	if paths, ok := jobInfo.NodeJobRun.Job.Action.Requirements.Isolation(); ok {
+		ctx = workerruntime.SetIsolationPaths(ctx, paths)
+		log.Debug("processJob> Isolation mode enabled with tool paths %v", paths)
+	}
+
 	w.currentJob.context = ctx
 
 	var jobParameters = jobInfo.NodeJobRun.Parameters
@@ -575,6 +681,21 @@ func (w *CurrentWorker) ProcessJob(jobInfo sdk.WorkflowNodeJobRunData) (res sdk.
 		Value: jobInfo.NodeJobRun.Job.WorkerName,
 	})
 
+	// add hostname of each service on parameters available
+	for _, r := range jobInfo.NodeJobRun.Job.Action.Requirements {
+		if r.Type != sdk.ServiceRequirement {
+			continue
+		}
+		jobParameters = append(jobParameters, sdk.Parameter{
+			Name:  "cds.service." + r.Name + ".host",
+			Type:  sdk.StringParameter,
+			Value: r.Name,
+		})
+	}
+
+	// Record the secrets read by the job before they are replaced
+	w.recordInterpolatedSecrets(jobInfo.NodeJobRun.Job.Action, jobParameters, jobInfo.Secrets)
+
 	// REPLACE ALL VARIABLE EVEN SECRETS HERE
 	if err := processVariablesAndParameters(&jobInfo.NodeJobRun.Job.Action, jobParameters, jobInfo.Secrets); err != nil {
 		return sdk.Result{
@@ -596,12 +717,23 @@ func (w *CurrentWorker) ProcessJob(jobInfo sdk.WorkflowNodeJobRunData) (res sdk.
 
 	w.currentJob.params = jobParameters
 
+	fingerprint := w.jobFingerprint(ctx, jobInfo.NodeJobRun.Job.Action.Requirements)
+
 	res = w.runJob(ctx, &jobInfo.NodeJobRun.Job.Action, jobInfo.NodeJobRun.ID, jobInfo.Secrets)
+	res.SecretUsages = w.secretUsages()
+	res.Fingerprint = fingerprint
+
+	// Keep the workspace of a failed job if its debug mode is enabled
+	if res.Status == sdk.StatusFail {
+		w.holdForDebug(ctx, jobInfo, wdAbs)
+	}
 
 	if len(res.NewVariables) > 0 {
 		log.Debug("processJob> new variables: %v", res.NewVariables)
 	}
 
+	// Delete secrets mount before the working directories that contain it
+	w.teardownSecretsDirectory(ctx)
 	// Delete working directory
 	if err := teardownDirectory(w.basedir, wdFile.Name()); err != nil {
 		log.Error(ctx, "Cannot remove build directory: %s", err)
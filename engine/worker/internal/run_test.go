@@ -31,3 +31,25 @@ func Test_processVariablesAndParameters(t *testing.T) {
 	assert.Equal(t, expectedJobParameters, string(actualJobParameters))
 
 }
+
+func Test_checkStepCondition(t *testing.T) {
+	params := []sdk.Parameter{
+		{Name: "git.branch", Type: sdk.StringParameter, Value: "master"},
+		{Name: "cds.app.my-var", Type: sdk.StringParameter, Value: "foo"},
+	}
+
+	ok, err := checkStepCondition(`git_branch == "master"`, params, sdk.StatusSuccess, sdk.StatusSuccess)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = checkStepCondition(`return cds_app_my_var == "bar"`, params, sdk.StatusSuccess, sdk.StatusSuccess)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = checkStepCondition(`cds_job_status == "Fail" and cds_step_previous_status == "Fail"`, params, sdk.StatusFail, sdk.StatusFail)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = checkStepCondition(`git_branch ==`, params, sdk.StatusSuccess, sdk.StatusSuccess)
+	assert.Error(t, err)
+}
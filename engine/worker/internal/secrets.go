@@ -0,0 +1,213 @@
+package internal
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+var (
+	interpolateExpressionRegex = regexp.MustCompile(`{{[^{}]*}}`)
+	interpolateVariableRegex   = regexp.MustCompile(`\.([a-zA-Z0-9_\-]+(?:\.[a-zA-Z0-9_\-]+)*)`)
+)
+
+// secretEncodings returns the given secret value and its common encodings that could be written by a step.
+// Each line of a multiline secret is also returned as logs are sent line by line.
+func secretEncodings(value string) []string {
+	values := []string{value}
+	if strings.Contains(value, "\n") {
+		for _, l := range strings.Split(value, "\n") {
+			values = append(values, strings.TrimSpace(l))
+		}
+	}
+
+	res := make([]string, 0, len(values)*6)
+	for _, v := range values {
+		if len(v) < sdk.SecretMinLength {
+			continue
+		}
+		res = append(res,
+			v,
+			base64.StdEncoding.EncodeToString([]byte(v)),
+			base64.RawStdEncoding.EncodeToString([]byte(v)),
+			base64.URLEncoding.EncodeToString([]byte(v)),
+			url.QueryEscape(v),
+			url.PathEscape(v),
+		)
+	}
+	return res
+}
+
+// newSecretsReplacer returns a replacer that masks the values of given secrets and their encodings,
+// or nil if there is nothing to mask.
+func newSecretsReplacer(secrets []sdk.Variable) *strings.Replacer {
+	set := make(map[string]struct{})
+	for _, s := range secrets {
+		for _, v := range secretEncodings(s.Value) {
+			set[v] = struct{}{}
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+
+	// Longest values first so that a secret containing another one is fully masked
+	values := make([]string, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) == len(values[j]) {
+			return values[i] < values[j]
+		}
+		return len(values[i]) > len(values[j])
+	})
+
+	oldnew := make([]string, 0, len(values)*2)
+	for _, v := range values {
+		oldnew = append(oldnew, v, sdk.PasswordPlaceholder)
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+// maskSecrets replaces in given value all the secrets of the current job.
+func (wk *CurrentWorker) maskSecrets(value string) string {
+	if wk.currentJob.secretsReplacer == nil {
+		return value
+	}
+	return wk.currentJob.secretsReplacer.Replace(value)
+}
+
+// setupSecretsDirectory creates the secrets mount of the job in its working directory, then writes in it a file for
+// each secret variable of the job.
+func (wk *CurrentWorker) setupSecretsDirectory(ctx context.Context, jobInfo sdk.WorkflowNodeJobRunData) (string, error) {
+	dir, err := workingDirectory(ctx, wk.basedir, jobInfo, "secrets")
+	if err != nil {
+		return "", err
+	}
+	if x, ok := wk.basedir.(*afero.BasePathFs); ok {
+		dir, err = x.RealPath(dir)
+		if err != nil {
+			return "", sdk.WithStack(err)
+		}
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+
+	dir, err = workerruntime.SetupSecretsDirectory(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeSecretFiles(dir, jobInfo.Secrets); err != nil {
+		_ = workerruntime.TeardownSecretsDirectory(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// writeSecretFiles writes the value of each secret variable in a file named like the variable in given directory.
+// Keys are not written, they are installed in the directory on demand.
+func writeSecretFiles(dir string, secrets []sdk.Variable) error {
+	for _, s := range secrets {
+		if s.Type != sdk.SecretVariable {
+			continue
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, filepath.Base(s.Name)), []byte(s.Value), os.FileMode(0400)); err != nil {
+			return sdk.WrapError(err, "cannot write secret %s", s.Name)
+		}
+	}
+	return nil
+}
+
+// teardownSecretsDirectory wipes and removes the secrets mount of the current job if any.
+func (wk *CurrentWorker) teardownSecretsDirectory(ctx context.Context) {
+	if wk.currentJob.secretsDir == "" {
+		return
+	}
+	if err := workerruntime.TeardownSecretsDirectory(wk.currentJob.secretsDir); err != nil {
+		log.Error(ctx, "Cannot remove secrets mount: %s", err)
+	}
+	wk.currentJob.secretsDir = ""
+}
+
+// referencedSecrets returns the secrets used by the interpolation expressions of given value.
+func referencedSecrets(value string, secrets []sdk.Variable) []sdk.Variable {
+	if !strings.Contains(value, "{{") {
+		return nil
+	}
+	var res []sdk.Variable
+	for _, expr := range interpolateExpressionRegex.FindAllString(value, -1) {
+		for _, m := range interpolateVariableRegex.FindAllStringSubmatch(expr, -1) {
+			for _, s := range secrets {
+				if s.Name == m[1] {
+					res = append(res, s)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// recordSecretUsage records that a secret of the current job was read, by a step or by the job parameters if the step
+// order is -1.
+func (wk *CurrentWorker) recordSecretUsage(secret sdk.Variable, source string, stepOrder int) {
+	wk.currentJob.secretUsagesMu.Lock()
+	defer wk.currentJob.secretUsagesMu.Unlock()
+	for _, u := range wk.currentJob.secretUsages {
+		if u.Name == secret.Name && u.Source == source && u.StepOrder == stepOrder {
+			return
+		}
+	}
+	wk.currentJob.secretUsages = append(wk.currentJob.secretUsages, sdk.WorkflowRunSecretUsage{
+		Name:      secret.Name,
+		Type:      secret.Type,
+		Source:    source,
+		StepOrder: stepOrder,
+	})
+}
+
+// recordInterpolatedSecrets records the secrets used by the job parameters and by the parameters of the steps.
+func (wk *CurrentWorker) recordInterpolatedSecrets(a sdk.Action, jobParameters []sdk.Parameter, secrets []sdk.Variable) {
+	for _, p := range jobParameters {
+		for _, s := range referencedSecrets(p.Value, secrets) {
+			wk.recordSecretUsage(s, sdk.SecretUsageSourceInterpolation, -1)
+		}
+	}
+	for i := range a.Actions {
+		wk.recordStepInterpolatedSecrets(a.Actions[i], i, secrets)
+	}
+}
+
+func (wk *CurrentWorker) recordStepInterpolatedSecrets(a sdk.Action, stepOrder int, secrets []sdk.Variable) {
+	for _, p := range a.Parameters {
+		for _, s := range referencedSecrets(p.Value, secrets) {
+			wk.recordSecretUsage(s, sdk.SecretUsageSourceInterpolation, stepOrder)
+		}
+	}
+	for i := range a.Actions {
+		wk.recordStepInterpolatedSecrets(a.Actions[i], stepOrder, secrets)
+	}
+}
+
+// secretUsages returns the secrets read by the current job.
+func (wk *CurrentWorker) secretUsages() []sdk.WorkflowRunSecretUsage {
+	wk.currentJob.secretUsagesMu.Lock()
+	defer wk.currentJob.secretUsagesMu.Unlock()
+	return append([]sdk.WorkflowRunSecretUsage(nil), wk.currentJob.secretUsages...)
+}
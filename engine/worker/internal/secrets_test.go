@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestMaskSecrets(t *testing.T) {
+	var w = new(CurrentWorker)
+	assert.Equal(t, "nothing to mask", w.maskSecrets("nothing to mask"))
+
+	secret := "my-s3cr3t&value"
+	w.currentJob.secretsReplacer = newSecretsReplacer([]sdk.Variable{
+		{Name: "cds.proj.password", Value: secret},
+		{Name: "cds.proj.short", Value: "abc"},
+		{Name: "cds.key.proj-key.priv", Value: "-----BEGIN KEY-----\nmyprivatekeyline\n-----END KEY-----"},
+	})
+
+	assert.Equal(t, "password: "+sdk.PasswordPlaceholder+"\n", w.maskSecrets("password: "+secret+"\n"))
+	assert.Equal(t, "base64: "+sdk.PasswordPlaceholder, w.maskSecrets("base64: "+base64.StdEncoding.EncodeToString([]byte(secret))))
+	assert.Equal(t, "url: ?p="+sdk.PasswordPlaceholder, w.maskSecrets("url: ?p="+url.QueryEscape(secret)))
+	assert.Equal(t, "key line: "+sdk.PasswordPlaceholder, w.maskSecrets("key line: myprivatekeyline"))
+	assert.Equal(t, "short value abc is not masked", w.maskSecrets("short value abc is not masked"))
+}
+
+func TestWriteSecretFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint
+
+	require.NoError(t, writeSecretFiles(dir, []sdk.Variable{
+		{Name: "cds.proj.password", Type: sdk.SecretVariable, Value: "my-s3cr3t"},
+		{Name: "cds.proj.login", Type: sdk.StringVariable, Value: "my-login"},
+		{Name: "cds.key.proj-key.priv", Type: sdk.KeyVariable, Value: "my-private-key"},
+	}))
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "cds.proj.password"))
+	require.NoError(t, err)
+	assert.Equal(t, "my-s3cr3t", string(content))
+
+	infos, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, os.FileMode(0400), infos[0].Mode().Perm())
+}
+
+func TestRecordInterpolatedSecrets(t *testing.T) {
+	secrets := []sdk.Variable{
+		{Name: "cds.proj.password", Type: sdk.SecretVariable, Value: "s3cr3t"},
+		{Name: "cds.app.token", Type: sdk.SecretVariable, Value: "t0k3n"},
+		{Name: "cds.key.proj-key.priv", Type: sdk.KeySSHParameter, Value: "-----BEGIN KEY-----"},
+	}
+
+	assert.Empty(t, referencedSecrets("no expression cds.proj.password", secrets))
+	assert.Len(t, referencedSecrets("{{.cds.proj.password}} {{.cds.app.token | upper}}", secrets), 2)
+	assert.Empty(t, referencedSecrets("{{.cds.proj.password.other}}", secrets))
+
+	var w = new(CurrentWorker)
+	w.recordInterpolatedSecrets(sdk.Action{
+		Actions: []sdk.Action{
+			{Parameters: []sdk.Parameter{{Name: "script", Value: "echo {{.cds.build.foo}}"}}},
+			{
+				Parameters: []sdk.Parameter{{Name: "script", Value: "login -p {{.cds.proj.password}}"}},
+				Actions: []sdk.Action{
+					{Parameters: []sdk.Parameter{{Name: "token", Value: "{{.cds.app.token}}"}}},
+				},
+			},
+		},
+	}, []sdk.Parameter{
+		{Name: "cds.build.foo", Value: "{{.cds.proj.password}}"},
+	}, secrets)
+	w.recordSecretUsage(secrets[2], sdk.SecretUsageSourceKeyInstall, 1)
+	w.recordSecretUsage(secrets[2], sdk.SecretUsageSourceKeyInstall, 1)
+
+	assert.Equal(t, []sdk.WorkflowRunSecretUsage{
+		{Name: "cds.proj.password", Type: sdk.SecretVariable, Source: sdk.SecretUsageSourceInterpolation, StepOrder: -1},
+		{Name: "cds.proj.password", Type: sdk.SecretVariable, Source: sdk.SecretUsageSourceInterpolation, StepOrder: 1},
+		{Name: "cds.app.token", Type: sdk.SecretVariable, Source: sdk.SecretUsageSourceInterpolation, StepOrder: 1},
+		{Name: "cds.key.proj-key.priv", Type: sdk.KeySSHParameter, Source: sdk.SecretUsageSourceKeyInstall, StepOrder: 1},
+	}, w.secretUsages())
+}
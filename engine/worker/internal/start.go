@@ -33,9 +33,9 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 	errsChan := make(chan error, 1)
 
 	//Definition of the function which must be called to stop the worker
-	var endFunc = func() {
+	var endFunc = func(shutdown sdk.WorkerShutdown) {
 		log.Info(ctx, "Stopping worker %s", w.Name())
-		if err := w.Unregister(ctx); err != nil {
+		if err := w.Unregister(ctx, shutdown); err != nil {
 			log.Error(ctx, "Unable to unregister: %v", err)
 			mainError = err
 		}
@@ -61,7 +61,7 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 			// this job can't be process (err != nil)
 			// so, call endFunc() now, this worker don't have to work
 			// on another job
-			endFunc()
+			endFunc(sdk.WorkerShutdown{Reason: sdk.WorkerShutdownReasonError, Message: err.Error()})
 			return sdk.WrapError(err, "unable to process booked job")
 		}
 	} else {
@@ -81,7 +81,7 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 		for err := range errsChan {
 			log.Error(ctx, "An error has occured: %v", err)
 			if strings.Contains(err.Error(), "not authenticated") {
-				endFunc()
+				endFunc(sdk.WorkerShutdown{Reason: sdk.WorkerShutdownReasonError, Message: err.Error()})
 				return
 			}
 		}
@@ -95,12 +95,16 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 			case <-ctx.Done():
 				return
 			case <-refreshTick.C:
-				if err := w.Client().WorkerRefresh(ctx); err != nil {
+				hb, err := w.Client().WorkerRefresh(ctx)
+				if err != nil {
 					log.Error(ctx, "Heartbeat failed: %v", err)
 					nbErrors++
 					if nbErrors == 5 {
 						errsChan <- err
 					}
+				} else if hb.Drain && !w.Draining() {
+					log.Info(ctx, "Drain asked by CDS, the worker will stop after its current job")
+					w.Drain()
 				}
 				nbErrors = 0
 			}
@@ -110,14 +114,24 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 	// main loop
 	for {
 		if ctx.Err() != nil {
-			endFunc()
+			endFunc(sdk.WorkerShutdown{Reason: sdk.WorkerShutdownReasonInterrupted, Message: ctx.Err().Error()})
 			return ctx.Err()
 		}
 
 		select {
 		case <-ctx.Done():
-			endFunc()
+			endFunc(sdk.WorkerShutdown{Reason: sdk.WorkerShutdownReasonInterrupted, Message: ctx.Err().Error()})
 			return ctx.Err()
+		case <-w.drainChan():
+			// The worker is waiting for a job, a booked job is given back to the queue for another worker
+			log.Info(ctx, "Worker is drained")
+			if bookedJobID != 0 {
+				if err := w.Client().QueueJobRelease(ctx, bookedJobID); err != nil {
+					log.Error(ctx, "runCmd> QueueJobRelease> Cannot release job %d: %v", bookedJobID, err)
+				}
+			}
+			endFunc(sdk.WorkerShutdown{Reason: sdk.WorkerShutdownReasonDrained})
+			return nil
 		case j := <-jobsChan:
 			if j.ID == 0 {
 				continue
@@ -140,14 +154,20 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 			}
 
 			//Take the job
+			shutdown := sdk.WorkerShutdown{Reason: sdk.WorkerShutdownReasonJobDone}
 			if requirementsOK && pluginsOK {
 				log.Debug("checkQueue> Try take the job %d%s", j.ID, t)
 				if err := w.Take(ctx, j); err != nil {
 					log.Info(ctx, "Unable to run this job  %d. Take info:%s: %v", j.ID, t, err)
 					bookedJobID = 0 // nolint
 					errsChan <- err
+					shutdown = sdk.WorkerShutdown{Reason: sdk.WorkerShutdownReasonError, Message: err.Error()}
 				}
 			}
+			// Logs and artifacts of the job were sent by Take, a drained worker can stop now
+			if shutdown.Reason == sdk.WorkerShutdownReasonJobDone && w.Draining() {
+				shutdown.Reason = sdk.WorkerShutdownReasonDrained
+			}
 
 			if err := w.Client().WorkerSetStatus(ctx, sdk.StatusWaiting); err != nil {
 				log.Error(ctx, "WorkerSetStatus> error on WorkerSetStatus(ctx, sdk.StatusWaiting): %s", err)
@@ -155,7 +175,7 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 
 			// Unregister from engine
 			log.Info(ctx, "Job is done. Unregistering...")
-			endFunc()
+			endFunc(shutdown)
 			return nil
 		}
 	}
@@ -208,7 +208,7 @@ func TestStartWorkerWithABookedJob(t *testing.T) {
 		Reply(200).
 		JSON(nil)
 
-	gock.New("http://lolcat.host").Post("/queue/workflows/42/log").Times(8).
+	gock.New("http://lolcat.host").Post("/queue/workflows/42/log").Persist().
 		HeaderPresent("Authorization").
 		Reply(200).
 		JSON(nil)
@@ -28,8 +28,11 @@ func (w *CurrentWorker) Take(ctx context.Context, job sdk.WorkflowNodeJobRun) er
 	// Set build variables
 	w.currentJob.wJob = &info.NodeJobRun
 	w.currentJob.secrets = info.Secrets
+	w.currentJob.secretsReplacer = newSecretsReplacer(info.Secrets)
 	// Reset build variables
 	w.currentJob.newVariables = nil
+	w.currentJob.secretUsages = nil
+	w.currentJob.toolPaths = nil
 
 	start := time.Now()
 
@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+// Formats of the archives that can be declared in the tools catalog.
+const (
+	ToolFormatTarGz  = "tar.gz"
+	ToolFormatZip    = "zip"
+	ToolFormatBinary = "binary"
+)
+
+// Tool is an entry of the tools catalog. OS and Arch can be empty if the tool is available for all platforms.
+type Tool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	OS      string `json:"os,omitempty"`
+	Arch    string `json:"arch,omitempty"`
+	URL     string `json:"url"`
+	SHA256  string `json:"sha256"`
+	Format  string `json:"format"`
+	BinPath string `json:"bin_path,omitempty"`
+}
+
+// ToolsCatalog is the list of tools that can be installed by the worker.
+type ToolsCatalog struct {
+	Tools []Tool `json:"tools"`
+}
+
+// Find returns the tool matching given name and version for the given platform.
+func (c ToolsCatalog) Find(name, version, goos, goarch string) (*Tool, error) {
+	for i := range c.Tools {
+		t := c.Tools[i]
+		if t.Name != name || t.Version != version {
+			continue
+		}
+		if (t.OS != "" && t.OS != goos) || (t.Arch != "" && t.Arch != goarch) {
+			continue
+		}
+		return &t, nil
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "tool %s %s not found in catalog for %s/%s", name, version, goos, goarch)
+}
+
+func (wk *CurrentWorker) loadToolsCatalog(ctx context.Context) (ToolsCatalog, error) {
+	var catalog ToolsCatalog
+	if wk.toolsCatalog == "" {
+		return catalog, sdk.NewErrorFrom(sdk.ErrNotFound, "no tools catalog configured on this worker")
+	}
+
+	var btes []byte
+	if strings.HasPrefix(wk.toolsCatalog, "http://") || strings.HasPrefix(wk.toolsCatalog, "https://") {
+		resp, err := toolsHTTPGet(ctx, wk.toolsCatalog)
+		if err != nil {
+			return catalog, err
+		}
+		defer resp.Body.Close() // nolint
+		btes, err = ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return catalog, sdk.WrapError(err, "cannot read tools catalog")
+		}
+	} else {
+		var err error
+		btes, err = ioutil.ReadFile(wk.toolsCatalog)
+		if err != nil {
+			return catalog, sdk.WrapError(err, "cannot read tools catalog")
+		}
+	}
+
+	if err := json.Unmarshal(btes, &catalog); err != nil {
+		return catalog, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid tools catalog: %v", err)
+	}
+	return catalog, nil
+}
+
+func toolsHTTPGet(ctx context.Context, url string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		cancel()
+		return nil, sdk.WithStack(err)
+	}
+	resp, err := cdsclient.DefaultHTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, sdk.WrapError(err, "cannot get %s", url)
+	}
+	if resp.StatusCode >= 300 {
+		resp.Body.Close() // nolint
+		cancel()
+		return nil, sdk.WithStack(fmt.Errorf("cannot get %s: http status %d", url, resp.StatusCode))
+	}
+	resp.Body = cancelReadCloser{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelReadCloser) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// installTool downloads given tool into the tmp directory, checks its sha256 checksum then extracts it
+// in the destination directory. It returns the directory that contains the tool binaries.
+func installTool(ctx context.Context, fs afero.Fs, tmpDir, destination string, t Tool, download func(ctx context.Context, url string) (io.ReadCloser, error)) (string, error) {
+	if t.SHA256 == "" {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing sha256 checksum for tool %s %s", t.Name, t.Version)
+	}
+
+	body, err := download(ctx, t.URL)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close() // nolint
+
+	archive, err := afero.TempFile(fs, tmpDir, "tool-")
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	defer fs.Remove(archive.Name()) // nolint
+	defer archive.Close()           // nolint
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(archive, hash), body)
+	if err != nil {
+		return "", sdk.WrapError(err, "cannot download tool %s %s", t.Name, t.Version)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(sum, t.SHA256) {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid checksum for tool %s %s: expected %s but got %s", t.Name, t.Version, t.SHA256, sum)
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return "", sdk.WithStack(err)
+	}
+
+	if err := fs.RemoveAll(destination); err != nil {
+		return "", sdk.WithStack(err)
+	}
+	if err := fs.MkdirAll(destination, os.FileMode(0755)); err != nil {
+		return "", sdk.WithStack(err)
+	}
+
+	switch t.Format {
+	case ToolFormatTarGz:
+		if err := sdk.UntarGz(fs, destination, archive); err != nil {
+			return "", sdk.WrapError(err, "cannot extract tool %s %s", t.Name, t.Version)
+		}
+	case ToolFormatZip:
+		if err := unzipTool(fs, destination, archive, size); err != nil {
+			return "", sdk.WrapError(err, "cannot extract tool %s %s", t.Name, t.Version)
+		}
+	case ToolFormatBinary, "":
+		f, err := fs.OpenFile(path.Join(destination, t.Name), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(0755))
+		if err != nil {
+			return "", sdk.WithStack(err)
+		}
+		if _, err := io.Copy(f, archive); err != nil {
+			f.Close() // nolint
+			return "", sdk.WithStack(err)
+		}
+		if err := f.Close(); err != nil {
+			return "", sdk.WithStack(err)
+		}
+	default:
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported format %q for tool %s %s", t.Format, t.Name, t.Version)
+	}
+
+	binDir := path.Join(destination, t.BinPath)
+	if !strings.HasPrefix(binDir, path.Clean(destination)) {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid bin path %q for tool %s %s", t.BinPath, t.Name, t.Version)
+	}
+	return binDir, nil
+}
+
+func unzipTool(fs afero.Fs, destination string, r io.ReaderAt, size int64) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	for _, f := range zr.File {
+		target := path.Join(destination, filepath.ToSlash(f.Name))
+		if !strings.HasPrefix(target, path.Clean(destination)+"/") {
+			return sdk.WithStack(fmt.Errorf("invalid file path %s in archive", f.Name))
+		}
+		if f.FileInfo().IsDir() {
+			if err := fs.MkdirAll(target, os.FileMode(0755)); err != nil {
+				return sdk.WithStack(err)
+			}
+			continue
+		}
+		if err := fs.MkdirAll(path.Dir(target), os.FileMode(0755)); err != nil {
+			return sdk.WithStack(err)
+		}
+		src, err := f.Open()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		dst, err := fs.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, f.Mode())
+		if err != nil {
+			src.Close() // nolint
+			return sdk.WithStack(err)
+		}
+		_, err = io.Copy(dst, src)
+		src.Close() // nolint
+		dst.Close() // nolint
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestToolsCatalogFind(t *testing.T) {
+	catalog := ToolsCatalog{Tools: []Tool{
+		{Name: "helm", Version: "3.2.4", OS: "linux", Arch: "amd64", URL: "http://lolcat.host/helm-linux"},
+		{Name: "helm", Version: "3.2.4", OS: "darwin", Arch: "amd64", URL: "http://lolcat.host/helm-darwin"},
+		{Name: "script", Version: "1.0.0", URL: "http://lolcat.host/script"},
+	}}
+
+	tool, err := catalog.Find("helm", "3.2.4", "darwin", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "http://lolcat.host/helm-darwin", tool.URL)
+
+	tool, err = catalog.Find("script", "1.0.0", "windows", "386")
+	require.NoError(t, err)
+	assert.Equal(t, "http://lolcat.host/script", tool.URL)
+
+	_, err = catalog.Find("helm", "3.2.4", "windows", "amd64")
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+
+	_, err = catalog.Find("helm", "3.2.5", "linux", "amd64")
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
+
+func TestInstallTool(t *testing.T) {
+	var archive bytes.Buffer
+	gw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "linux-amd64/", Typeflag: tar.TypeDir, Mode: 0755}))
+	content := []byte("#!/bin/sh\necho helm")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "linux-amd64/helm", Typeflag: tar.TypeReg, Mode: 0755, Size: int64(len(content))}))
+	_, err := tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	sum := sha256.Sum256(archive.Bytes())
+	download := func(ctx context.Context, url string) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(archive.Bytes())), nil
+	}
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("tmp", os.FileMode(0755)))
+
+	tool := Tool{
+		Name:    "helm",
+		Version: "3.2.4",
+		URL:     "http://lolcat.host/helm.tar.gz",
+		SHA256:  hex.EncodeToString(sum[:]),
+		Format:  ToolFormatTarGz,
+		BinPath: "linux-amd64",
+	}
+	binDir, err := installTool(context.TODO(), fs, "tmp", "run/.cds/tools/helm/3.2.4", tool, download)
+	require.NoError(t, err)
+	assert.Equal(t, "run/.cds/tools/helm/3.2.4/linux-amd64", binDir)
+
+	btes, err := afero.ReadFile(fs, "run/.cds/tools/helm/3.2.4/linux-amd64/helm")
+	require.NoError(t, err)
+	assert.Equal(t, content, btes)
+
+	// Temporary archive should have been removed
+	files, err := afero.ReadDir(fs, "tmp")
+	require.NoError(t, err)
+	assert.Len(t, files, 0)
+
+	// An invalid checksum should not install the tool
+	tool.SHA256 = "d6a3b7a8f9c1"
+	_, err = installTool(context.TODO(), fs, "tmp", "run/.cds/tools/helm/3.2.5", tool, download)
+	require.Error(t, err)
+	exists, err := afero.Exists(fs, "run/.cds/tools/helm/3.2.5")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
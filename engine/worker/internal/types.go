@@ -1,12 +1,12 @@
 package internal
 
 import (
-	"container/list"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
@@ -32,8 +32,9 @@ type CurrentWorker struct {
 	basedir    afero.Fs
 	manualExit bool
 	logger     struct {
-		logChan chan sdk.Log
-		llist   *list.List
+		spool        *logSpool
+		spoolMaxSize int64
+		sendMu       sync.Mutex
 	}
 	httpPort int32
 	register struct {
@@ -42,17 +43,30 @@ type CurrentWorker struct {
 		model       string
 	}
 	currentJob struct {
-		wJob         *sdk.WorkflowNodeJobRun
-		newVariables []sdk.Variable
-		params       []sdk.Parameter
-		secrets      []sdk.Variable
-		context      context.Context
+		wJob            *sdk.WorkflowNodeJobRun
+		newVariables    []sdk.Variable
+		stepName        string
+		stepOrder       int
+		params          []sdk.Parameter
+		secrets         []sdk.Variable
+		secretsReplacer *strings.Replacer
+		toolPaths       []string
+		secretsDir      string
+		secretUsages    []sdk.WorkflowRunSecretUsage
+		secretUsagesMu  sync.Mutex
+		context         context.Context
 	}
 	status struct {
 		Name   string `json:"name"`
 		Status string `json:"status"`
 	}
-	client cdsclient.WorkerInterface
+	client       cdsclient.WorkerInterface
+	toolsCatalog string
+	drain        struct {
+		init  sync.Once
+		close sync.Once
+		c     chan struct{}
+	}
 }
 
 // BuiltInAction defines builtin action signature
@@ -68,6 +82,37 @@ func (wk *CurrentWorker) Init(name, hatcheryName, apiEndpoint, token string, mod
 	return nil
 }
 
+// Drain asks the worker to stop after its current job, or now if it is waiting for a job.
+func (wk *CurrentWorker) Drain() {
+	c := wk.drainChan()
+	wk.drain.close.Do(func() { close(c) })
+}
+
+// Draining returns true if the worker was asked to stop after its current job.
+func (wk *CurrentWorker) Draining() bool {
+	select {
+	case <-wk.drainChan():
+		return true
+	default:
+		return false
+	}
+}
+
+func (wk *CurrentWorker) drainChan() chan struct{} {
+	wk.drain.init.Do(func() { wk.drain.c = make(chan struct{}) })
+	return wk.drain.c
+}
+
+// SetToolsCatalog sets the location (file path or http url) of the catalog used by the tool installer.
+func (wk *CurrentWorker) SetToolsCatalog(location string) {
+	wk.toolsCatalog = location
+}
+
+// SetLogSpoolMaxSize sets the maximum size in bytes of the log lines of a job waiting to be sent to the API.
+func (wk *CurrentWorker) SetLogSpoolMaxSize(size int64) {
+	wk.logger.spoolMaxSize = size
+}
+
 func (wk *CurrentWorker) GetContext() context.Context {
 	return wk.currentJob.context
 }
@@ -106,6 +151,11 @@ func (wk *CurrentWorker) BaseDir() afero.Fs {
 	return wk.basedir
 }
 
+// SecretsDirectory returns the path of the secrets mount of the current job, empty if it's not enabled.
+func (wk *CurrentWorker) SecretsDirectory() string {
+	return wk.currentJob.secretsDir
+}
+
 func (wk *CurrentWorker) Environ() []string {
 	env := os.Environ()
 	newEnv := []string{"CI=1"}
@@ -126,6 +176,11 @@ func (wk *CurrentWorker) Environ() []string {
 	// Api Endpoint in CDS_API_URL var
 	newEnv = append(newEnv, fmt.Sprintf("%s=%s", CDSApiUrl, wk.register.apiEndpoint))
 
+	// Secrets mount of the job in CDS_SECRETS_DIR var
+	if wk.currentJob.secretsDir != "" {
+		newEnv = append(newEnv, fmt.Sprintf("%s=%s", workerruntime.SecretsDirectoryEnv, wk.currentJob.secretsDir))
+	}
+
 	//set up environment variables from pipeline build job parameters
 	for _, p := range wk.currentJob.params {
 		// avoid put private key in environment var as it's a binary value
@@ -150,6 +205,26 @@ func (wk *CurrentWorker) Environ() []string {
 		envName = strings.ToUpper(envName)
 		newEnv = append(newEnv, fmt.Sprintf("%s=%s", envName, p.Value))
 	}
+
+	// prepend directories of tools installed during the job to the PATH, last installed first
+	if len(wk.currentJob.toolPaths) > 0 {
+		toolPaths := make([]string, len(wk.currentJob.toolPaths))
+		for i := range wk.currentJob.toolPaths {
+			toolPaths[len(toolPaths)-1-i] = wk.currentJob.toolPaths[i]
+		}
+		path := strings.Join(toolPaths, string(os.PathListSeparator))
+		var found bool
+		for i := range newEnv {
+			if strings.HasPrefix(newEnv[i], "PATH=") {
+				newEnv[i] = "PATH=" + path + string(os.PathListSeparator) + strings.TrimPrefix(newEnv[i], "PATH=")
+				found = true
+				break
+			}
+		}
+		if !found {
+			newEnv = append(newEnv, "PATH="+path)
+		}
+	}
 	return newEnv
 }
 
@@ -2,20 +2,27 @@ package main
 
 func main() {
 	cmd := cmdMain()
-	cmd.AddCommand(cmdExport)
+	cmd.AddCommand(cmdExport())
 	cmd.AddCommand(cmdUpload())
 	cmd.AddCommand(cmdArtifacts())
 	cmd.AddCommand(cmdDownload())
+	cmd.AddCommand(cmdCheckout())
 	cmd.AddCommand(cmdTmpl())
 	cmd.AddCommand(cmdCheckSecret())
 	cmd.AddCommand(cmdTag())
+	cmd.AddCommand(cmdAnnotation())
+	cmd.AddCommand(cmdRunResult())
+	cmd.AddCommand(cmdMetrics())
+	cmd.AddCommand(cmdContext())
 	cmd.AddCommand(cmdRun())
 	cmd.AddCommand(cmdExit())
 	cmd.AddCommand(cmdVersion)
 	cmd.AddCommand(cmdRegister())
 	cmd.AddCommand(cmdCache())
 	cmd.AddCommand(cmdKey())
+	cmd.AddCommand(cmdTools())
 	cmd.AddCommand(cmdJunitParser())
+	cmd.AddCommand(cmdIsolate())
 
 	// last command: doc, this command is hidden
 	cmd.AddCommand(cmdDoc(cmd))
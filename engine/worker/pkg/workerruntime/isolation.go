@@ -0,0 +1,53 @@
+package workerruntime
+
+import (
+	"context"
+	"strconv"
+)
+
+// IsolationCommand is the hidden worker command that runs the command of a step in isolation mode.
+const IsolationCommand = "isolate"
+
+// Isolation describes the filesystem visible from a step run in isolation mode. The system directories are always
+// visible in read-only mode.
+type Isolation struct {
+	// Root is the empty directory on which the root filesystem of the step is mounted.
+	Root string
+	// Dir is the working directory of the step.
+	Dir string
+	// Writable are the paths visible in read-write mode, like the workspace of the job.
+	Writable []string
+	// ReadOnly are the paths visible in read-only mode, like the tool paths declared by the job.
+	ReadOnly []string
+	// UID and GID are the ids of the worker user, the step runs with the same ids.
+	UID int
+	GID int
+}
+
+// Args returns the arguments of the isolation command for given command.
+func (i Isolation) Args(name string, args ...string) []string {
+	res := []string{IsolationCommand, "--root", i.Root, "--dir", i.Dir, "--uid", strconv.Itoa(i.UID), "--gid", strconv.Itoa(i.GID)}
+	for _, p := range i.Writable {
+		res = append(res, "--rw", p)
+	}
+	for _, p := range i.ReadOnly {
+		res = append(res, "--ro", p)
+	}
+	res = append(res, "--", name)
+	return append(res, args...)
+}
+
+// IsolationPaths returns the tool paths visible from the steps of the current job, and false if the job doesn't
+// run in isolation mode.
+func IsolationPaths(ctx context.Context) ([]string, bool) {
+	paths, ok := ctx.Value(isolationPaths).([]string)
+	return paths, ok
+}
+
+// SetIsolationPaths enables the isolation mode for the current job with given tool paths.
+func SetIsolationPaths(ctx context.Context, paths []string) context.Context {
+	if paths == nil {
+		paths = []string{}
+	}
+	return context.WithValue(ctx, isolationPaths, paths)
+}
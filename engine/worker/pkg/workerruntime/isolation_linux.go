@@ -0,0 +1,211 @@
+// +build linux
+
+package workerruntime
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// isolationSystemPaths are the directories of the host visible in read-only mode from an isolated step, they contain
+// the shells, binaries and libraries used by the scripts.
+var isolationSystemPaths = []string{"/bin", "/sbin", "/usr", "/lib", "/lib32", "/lib64", "/etc"}
+
+// lockedMountFlags are the flags of a mount that can't be removed from a user namespace.
+const lockedMountFlags = syscall.MS_NOSUID | syscall.MS_NODEV | syscall.MS_NOEXEC | syscall.MS_NOATIME |
+	syscall.MS_NODIRATIME | syscall.MS_RELATIME
+
+// CheckIsolation returns an error if the worker is not allowed to create user namespaces.
+func CheckIsolation() error {
+	if sysctlIsZero("/proc/sys/user/max_user_namespaces") {
+		return sdk.WithStack(fmt.Errorf("user namespaces are disabled on this host"))
+	}
+	if os.Geteuid() != 0 && sysctlIsZero("/proc/sys/kernel/unprivileged_userns_clone") {
+		return sdk.WithStack(fmt.Errorf("unprivileged user namespaces are disabled on this host"))
+	}
+	return nil
+}
+
+func sysctlIsZero(file string) bool {
+	btes, err := ioutil.ReadFile(file)
+	return err == nil && strings.TrimSpace(string(btes)) == "0"
+}
+
+// IsolatedCommand returns the command that runs given command through the worker isolation command in new mount,
+// user and pid namespaces. The isolation command runs as root of the new user namespace to set up the mounts.
+func IsolatedCommand(ctx context.Context, workerPath string, i Isolation, name string, args ...string) (*exec.Cmd, error) {
+	i.UID, i.GID = os.Getuid(), os.Getgid()
+	cmd := exec.CommandContext(ctx, workerPath, i.Args(name, args...)...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:  syscall.CLONE_NEWNS | syscall.CLONE_NEWUSER | syscall.CLONE_NEWPID,
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: i.UID, Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: i.GID, Size: 1}},
+	}
+	return cmd, nil
+}
+
+// Isolate mounts on the root directory of given isolation a new filesystem with the system directories and the
+// paths of the isolation, then runs given command in it with the ids of the worker user. It must be called from the
+// namespaces created by IsolatedCommand, the current process stays the init process of the pid namespace.
+func Isolate(i Isolation, name string, args ...string) error {
+	// Mounts of the step must not be propagated to the host
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return sdk.WrapError(err, "cannot make mounts private")
+	}
+
+	if err := os.MkdirAll(i.Root, os.FileMode(0700)); err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := syscall.Mount("tmpfs", i.Root, "tmpfs", 0, "mode=0755"); err != nil {
+		return sdk.WrapError(err, "cannot mount root filesystem on %s", i.Root)
+	}
+
+	// A workspace in /tmp stays visible as binds are mounted after the tmpfs
+	if err := os.MkdirAll(filepath.Join(i.Root, "proc"), os.FileMode(0555)); err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := syscall.Mount("proc", filepath.Join(i.Root, "proc"), "proc", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, ""); err != nil {
+		return sdk.WrapError(err, "cannot mount proc")
+	}
+	if err := os.MkdirAll(filepath.Join(i.Root, "tmp"), os.FileMode(0755)); err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := syscall.Mount("tmpfs", filepath.Join(i.Root, "tmp"), "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, "mode=1777"); err != nil {
+		return sdk.WrapError(err, "cannot mount tmp")
+	}
+
+	type bind struct {
+		path     string
+		readOnly bool
+	}
+	var binds []bind
+	for _, p := range isolationSystemPaths {
+		fi, err := os.Lstat(p)
+		if err != nil {
+			continue
+		}
+		// Symbolic links, like /bin on merged /usr systems, are copied
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if err := copySymlink(i.Root, p); err != nil {
+				return err
+			}
+			continue
+		}
+		binds = append(binds, bind{path: p, readOnly: true})
+	}
+	for _, p := range i.ReadOnly {
+		if _, err := os.Stat(p); err != nil {
+			return sdk.WrapError(err, "cannot find isolation path %s", p)
+		}
+		binds = append(binds, bind{path: p, readOnly: true})
+	}
+	for _, p := range i.Writable {
+		binds = append(binds, bind{path: p})
+	}
+	binds = append(binds, bind{path: "/dev"})
+	// Parent directories are mounted before their children
+	sort.SliceStable(binds, func(a, b int) bool {
+		return strings.Count(filepath.Clean(binds[a].path), "/") < strings.Count(filepath.Clean(binds[b].path), "/")
+	})
+
+	for _, b := range binds {
+		if err := bindMount(i.Root, b.path, b.readOnly); err != nil {
+			return err
+		}
+	}
+
+	// The host filesystem is not reachable once the old root is detached
+	oldRoot := filepath.Join(i.Root, ".oldroot")
+	if err := os.MkdirAll(oldRoot, os.FileMode(0700)); err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := syscall.PivotRoot(i.Root, oldRoot); err != nil {
+		return sdk.WrapError(err, "cannot pivot root to %s", i.Root)
+	}
+	if err := syscall.Chdir("/"); err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := syscall.Unmount("/.oldroot", syscall.MNT_DETACH); err != nil {
+		return sdk.WrapError(err, "cannot unmount old root")
+	}
+	if err := os.Remove("/.oldroot"); err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := syscall.Mount("tmpfs", "/", "tmpfs", syscall.MS_REMOUNT|syscall.MS_RDONLY, "mode=0755"); err != nil {
+		return sdk.WrapError(err, "cannot remount root filesystem read-only")
+	}
+
+	// The command runs in a nested user namespace in which the worker user is mapped, so it has no capability
+	cmd := exec.Command(name, args...)
+	cmd.Dir = i.Dir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:  syscall.CLONE_NEWUSER,
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: i.UID, HostID: 0, Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: i.GID, HostID: 0, Size: 1}},
+	}
+	return sdk.WithStack(cmd.Run())
+}
+
+// bindMount mounts given path of the host at the same path in the new root.
+func bindMount(root, path string, readOnly bool) error {
+	target := filepath.Join(root, path)
+	fi, err := os.Stat(path)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if fi.IsDir() {
+		if err := os.MkdirAll(target, os.FileMode(0755)); err != nil {
+			return sdk.WithStack(err)
+		}
+	} else {
+		if err := os.MkdirAll(filepath.Dir(target), os.FileMode(0755)); err != nil {
+			return sdk.WithStack(err)
+		}
+		f, err := os.OpenFile(target, os.O_CREATE, os.FileMode(0644))
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		f.Close() // nolint
+	}
+
+	if err := syscall.Mount(path, target, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return sdk.WrapError(err, "cannot bind %s", path)
+	}
+	if !readOnly {
+		return nil
+	}
+
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return sdk.WithStack(err)
+	}
+	flags := uintptr(syscall.MS_BIND|syscall.MS_REMOUNT|syscall.MS_RDONLY) | uintptr(st.Flags)&lockedMountFlags
+	if err := syscall.Mount("", target, "", flags, ""); err != nil {
+		return sdk.WrapError(err, "cannot remount %s read-only", path)
+	}
+	return nil
+}
+
+func copySymlink(root, path string) error {
+	link, err := os.Readlink(path)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	target := filepath.Join(root, path)
+	if err := os.MkdirAll(filepath.Dir(target), os.FileMode(0755)); err != nil {
+		return sdk.WithStack(err)
+	}
+	return sdk.WithStack(os.Symlink(link, target))
+}
@@ -0,0 +1,26 @@
+// +build !linux
+
+package workerruntime
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// CheckIsolation returns an error as the isolation mode is only supported on Linux workers.
+func CheckIsolation() error {
+	return sdk.WithStack(fmt.Errorf("isolation mode is only supported on linux workers"))
+}
+
+// IsolatedCommand returns an error as the isolation mode is only supported on Linux workers.
+func IsolatedCommand(ctx context.Context, workerPath string, i Isolation, name string, args ...string) (*exec.Cmd, error) {
+	return nil, CheckIsolation()
+}
+
+// Isolate returns an error as the isolation mode is only supported on Linux workers.
+func Isolate(i Isolation, name string, args ...string) error {
+	return CheckIsolation()
+}
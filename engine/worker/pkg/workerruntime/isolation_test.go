@@ -0,0 +1,38 @@
+package workerruntime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsolationArgs(t *testing.T) {
+	i := Isolation{
+		Root:     "/var/lib/cds/tmp/isolation",
+		Dir:      "/var/lib/cds/run",
+		Writable: []string{"/var/lib/cds/run"},
+		ReadOnly: []string{"/usr/bin/worker", "/opt/go"},
+		UID:      1000,
+		GID:      1001,
+	}
+	assert.Equal(t, []string{
+		"isolate", "--root", "/var/lib/cds/tmp/isolation", "--dir", "/var/lib/cds/run", "--uid", "1000", "--gid", "1001",
+		"--rw", "/var/lib/cds/run", "--ro", "/usr/bin/worker", "--ro", "/opt/go",
+		"--", "/bin/sh", "-e", "script.sh",
+	}, i.Args("/bin/sh", "-e", "script.sh"))
+}
+
+func TestIsolationPaths(t *testing.T) {
+	ctx := context.Background()
+	_, ok := IsolationPaths(ctx)
+	assert.False(t, ok)
+
+	paths, ok := IsolationPaths(SetIsolationPaths(ctx, nil))
+	assert.True(t, ok)
+	assert.Empty(t, paths)
+
+	paths, ok = IsolationPaths(SetIsolationPaths(ctx, []string{"/opt/go"}))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"/opt/go"}, paths)
+}
@@ -0,0 +1,51 @@
+package workerruntime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KeyAgentEnv is the environment variable used to load installed SSH keys in an agent on Windows workers.
+const KeyAgentEnv = "CDS_WORKER_KEY_AGENT"
+
+// Agents that can be set in CDS_WORKER_KEY_AGENT.
+const (
+	KeyAgentOpenSSH = "ssh-agent"
+	KeyAgentPageant = "pageant"
+)
+
+// KeyAgent returns the agent in which installed SSH keys should be loaded, empty if none.
+func KeyAgent() (string, error) {
+	agent := strings.ToLower(strings.TrimSpace(os.Getenv(KeyAgentEnv)))
+	switch agent {
+	case "", KeyAgentOpenSSH, KeyAgentPageant:
+		return agent, nil
+	}
+	return "", fmt.Errorf("invalid value %q for %s, should be %s or %s", agent, KeyAgentEnv, KeyAgentOpenSSH, KeyAgentPageant)
+}
+
+// keyACLCommands returns the icacls commands that restrict access to a key file to given user only. OpenSSH for
+// Windows refuses to use a private key readable by other users, and POSIX permissions are ignored on NTFS.
+func keyACLCommands(path, username string) [][]string {
+	return [][]string{
+		{"icacls", path, "/inheritance:r"},
+		{"icacls", path, "/grant:r", username + ":F"},
+	}
+}
+
+// keyAgentCommands returns the commands that load a key file in given agent. Pageant only reads PuTTY keys so the
+// key is converted with puttygen first, the converted key is restricted to given user like the original one.
+func keyAgentCommands(agent, path, username string) [][]string {
+	switch agent {
+	case KeyAgentOpenSSH:
+		return [][]string{{"ssh-add", path}}
+	case KeyAgentPageant:
+		ppk := strings.TrimSuffix(path, filepath.Ext(path)) + ".ppk"
+		cmds := [][]string{{"puttygen", path, "-O", "private", "-o", ppk}}
+		cmds = append(cmds, keyACLCommands(ppk, username)...)
+		return append(cmds, []string{"pageant", ppk})
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package workerruntime
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyAgent(t *testing.T) {
+	defer os.Unsetenv(KeyAgentEnv)
+
+	os.Setenv(KeyAgentEnv, " Pageant ")
+	agent, err := KeyAgent()
+	require.NoError(t, err)
+	assert.Equal(t, KeyAgentPageant, agent)
+
+	os.Setenv(KeyAgentEnv, "gpg-agent")
+	_, err = KeyAgent()
+	assert.Error(t, err)
+}
+
+func TestKeyAgentCommands(t *testing.T) {
+	assert.Nil(t, keyAgentCommands("", `C:\keys\cds.key.proj-key.priv`, `HOST\cds`))
+	assert.Equal(t, [][]string{{"ssh-add", `C:\keys\cds.key.proj-key.priv`}}, keyAgentCommands(KeyAgentOpenSSH, `C:\keys\cds.key.proj-key.priv`, `HOST\cds`))
+	assert.Equal(t, [][]string{
+		{"puttygen", `C:\keys\cds.key.proj-key.priv`, "-O", "private", "-o", `C:\keys\cds.key.proj-key.ppk`},
+		{"icacls", `C:\keys\cds.key.proj-key.ppk`, "/inheritance:r"},
+		{"icacls", `C:\keys\cds.key.proj-key.ppk`, "/grant:r", `HOST\cds:F`},
+		{"pageant", `C:\keys\cds.key.proj-key.ppk`},
+	}, keyAgentCommands(KeyAgentPageant, `C:\keys\cds.key.proj-key.priv`, `HOST\cds`))
+}
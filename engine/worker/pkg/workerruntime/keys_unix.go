@@ -0,0 +1,8 @@
+// +build !windows
+
+package workerruntime
+
+// SetupKeyFile does nothing as installed SSH key files are already only readable by their owner.
+func SetupKeyFile(path string) error {
+	return nil
+}
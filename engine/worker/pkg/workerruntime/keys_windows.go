@@ -0,0 +1,51 @@
+// +build windows
+
+package workerruntime
+
+import (
+	"fmt"
+	"os/exec"
+	"os/user"
+	"strings"
+)
+
+// SetupKeyFile restricts the access of an installed SSH key file to the current user with icacls, then loads it
+// in the agent given by CDS_WORKER_KEY_AGENT if any.
+func SetupKeyFile(path string) error {
+	agent, err := KeyAgent()
+	if err != nil {
+		return err
+	}
+	u, err := user.Current()
+	if err != nil {
+		return fmt.Errorf("unable to get current user: %v", err)
+	}
+
+	for _, args := range keyACLCommands(path, u.Username) {
+		if err := runKeyCommand(args); err != nil {
+			return err
+		}
+	}
+
+	for _, args := range keyAgentCommands(agent, path, u.Username) {
+		// If it is not already running, pageant starts and stays in background with the key loaded
+		if args[0] == "pageant" {
+			if err := exec.Command(args[0], args[1:]...).Start(); err != nil {
+				return fmt.Errorf("unable to start %s: %v", strings.Join(args, " "), err)
+			}
+			continue
+		}
+		if err := runKeyCommand(args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runKeyCommand(args []string) error {
+	out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("unable to run %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package workerruntime
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SecretsMountEnv is the environment variable used to write the secrets of jobs in a tmpfs mounted for each job
+// instead of the disk. Only supported on Linux workers.
+const SecretsMountEnv = "CDS_WORKER_SECRETS_MOUNT"
+
+// SecretsDirectoryEnv is the environment variable that gives to steps the path of the secrets mount of the job.
+const SecretsDirectoryEnv = "CDS_SECRETS_DIR"
+
+// SecretsMountEnabled returns true if secrets should be written in a tmpfs mounted for each job.
+func SecretsMountEnabled() bool {
+	enabled, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv(SecretsMountEnv)))
+	return enabled
+}
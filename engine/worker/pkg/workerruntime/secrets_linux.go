@@ -0,0 +1,90 @@
+// +build linux
+
+package workerruntime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// tmpfsMagic is the type of a tmpfs filesystem returned by statfs.
+const tmpfsMagic = 0x01021994
+
+// secretsMountSize is the maximum size of a secrets mount.
+const secretsMountSize = "16m"
+
+// sharedMemoryDirectory is the tmpfs used when the worker is not allowed to mount a filesystem.
+const sharedMemoryDirectory = "/dev/shm"
+
+// SetupSecretsDirectory mounts a tmpfs only accessible by the worker user on given directory. If the worker is not
+// allowed to mount a filesystem, a directory is created in /dev/shm if it is a tmpfs. Returns the path of the
+// directory in which secrets can be written.
+func SetupSecretsDirectory(dir string) (string, error) {
+	if err := os.MkdirAll(dir, os.FileMode(0700)); err != nil {
+		return "", sdk.WithStack(err)
+	}
+
+	flags := uintptr(syscall.MS_NOSUID | syscall.MS_NODEV | syscall.MS_NOEXEC)
+	err := syscall.Mount("tmpfs", dir, "tmpfs", flags, "mode=0700,size="+secretsMountSize)
+	if err == nil {
+		return dir, nil
+	}
+	if err != syscall.EPERM {
+		return "", sdk.WrapError(err, "cannot mount tmpfs on %s", dir)
+	}
+	_ = os.Remove(dir)
+
+	if !isTmpfs(sharedMemoryDirectory) {
+		return "", sdk.WithStack(fmt.Errorf("cannot mount tmpfs on %s: worker is not allowed to mount a filesystem and %s is not a tmpfs", dir, sharedMemoryDirectory))
+	}
+	shmDir, err := ioutil.TempDir(sharedMemoryDirectory, "cds-secrets-")
+	if err != nil {
+		return "", sdk.WrapError(err, "cannot create secrets directory in %s", sharedMemoryDirectory)
+	}
+	return shmDir, nil
+}
+
+// TeardownSecretsDirectory removes all the files of given secrets directory, then unmounts and removes it.
+func TeardownSecretsDirectory(dir string) error {
+	names, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	for _, n := range names {
+		if err := os.RemoveAll(filepath.Join(dir, n.Name())); err != nil {
+			return sdk.WithStack(err)
+		}
+	}
+	// A secrets directory created in /dev/shm is not a mount point
+	if isMountPoint(dir) {
+		if err := syscall.Unmount(dir, 0); err != nil {
+			return sdk.WrapError(err, "cannot unmount %s", dir)
+		}
+	}
+	return sdk.WithStack(os.Remove(dir))
+}
+
+func isTmpfs(dir string) bool {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return false
+	}
+	return st.Type == tmpfsMagic
+}
+
+// isMountPoint returns true if given directory is not on the same device than its parent.
+func isMountPoint(dir string) bool {
+	var st, parent syscall.Stat_t
+	if err := syscall.Stat(dir, &st); err != nil {
+		return false
+	}
+	if err := syscall.Stat(filepath.Dir(dir), &parent); err != nil {
+		return false
+	}
+	return st.Dev != parent.Dev
+}
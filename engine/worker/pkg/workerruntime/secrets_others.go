@@ -0,0 +1,19 @@
+// +build !linux
+
+package workerruntime
+
+import (
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// SetupSecretsDirectory returns an error as secrets mounts are only supported on Linux workers.
+func SetupSecretsDirectory(dir string) (string, error) {
+	return "", sdk.WithStack(fmt.Errorf("secrets mount is only supported on linux workers, unset %s", SecretsMountEnv))
+}
+
+// TeardownSecretsDirectory does nothing as secrets mounts are only supported on Linux workers.
+func TeardownSecretsDirectory(dir string) error {
+	return nil
+}
@@ -0,0 +1,37 @@
+package workerruntime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretsMountEnabled(t *testing.T) {
+	defer os.Unsetenv(SecretsMountEnv)
+
+	assert.False(t, SecretsMountEnabled())
+	os.Setenv(SecretsMountEnv, " true ")
+	assert.True(t, SecretsMountEnabled())
+	os.Setenv(SecretsMountEnv, "no")
+	assert.False(t, SecretsMountEnabled())
+}
+
+func TestSetupSecretsDirectory(t *testing.T) {
+	base, err := ioutil.TempDir("", "secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(base) // nolint
+
+	dir, err := SetupSecretsDirectory(filepath.Join(base, "secrets"))
+	if err != nil {
+		t.Skipf("secrets mount is not available: %v", err)
+	}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cds.proj.password"), []byte("my-s3cr3t"), os.FileMode(0400)))
+
+	require.NoError(t, TeardownSecretsDirectory(dir))
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -12,12 +12,20 @@ import (
 	"github.com/spf13/afero"
 )
 
+// DefaultDownloadParallel is the default number of artifacts downloaded at the same time.
+const DefaultDownloadParallel = 4
+
+// DefaultCheckoutDepth is the default depth of the clone of a checkout.
+const DefaultCheckoutDepth = 50
+
 type DownloadArtifact struct {
-	Workflow    string `json:"workflow"`
-	Number      int64  `json:"number"`
-	Pattern     string `json:"pattern" cli:"pattern"`
-	Tag         string `json:"tag" cli:"tag"`
-	Destination string `json:"destination"`
+	Workflow    string   `json:"workflow"`
+	Number      int64    `json:"number"`
+	Pattern     string   `json:"pattern" cli:"pattern"`
+	Globs       []string `json:"globs,omitempty" cli:"globs"`
+	Tag         string   `json:"tag" cli:"tag"`
+	Destination string   `json:"destination"`
+	Parallel    int      `json:"parallel,omitempty"`
 }
 
 type UploadArtifact struct {
@@ -36,6 +44,29 @@ type KeyResponse struct {
 	Content []byte      `json:"-"`
 }
 
+// ToolInstall is the body of a tool install request on the worker HTTP server.
+type ToolInstall struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ToolInstallResponse gives the directory added to the PATH of next steps for an installed tool.
+type ToolInstallResponse struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Path    string `json:"path"`
+}
+
+// Checkout is the body of a checkout request on the worker HTTP server, it clones the repository of the job at given
+// ref. An empty ref clones the branch, tag or commit of the workflow run. A depth of 0 makes a full clone.
+type Checkout struct {
+	Ref        string `json:"ref,omitempty"`
+	Directory  string `json:"directory"`
+	Depth      int    `json:"depth"`
+	Filter     string `json:"filter,omitempty"`
+	Submodules bool   `json:"submodules"`
+}
+
 type TmplPath struct {
 	Path        string `json:"path"`
 	Destination string `json:"destination"`
@@ -53,6 +84,7 @@ const (
 	workDir
 	keysDir
 	tmpDir
+	isolationPaths
 	LevelDebug Level = "DEBUG"
 	LevelInfo  Level = "INFO"
 	LevelWarn  Level = "WARN"
@@ -67,7 +99,10 @@ type Runtime interface {
 	SendLog(ctx context.Context, level Level, format string)
 	InstallKey(key sdk.Variable) (*KeyResponse, error)
 	InstallKeyTo(key sdk.Variable, destinationPath string) (*KeyResponse, error)
-	Unregister(ctx context.Context) error
+	// SecretsDirectory returns the path of the tmpfs in which secrets of the current job are written, empty if the
+	// secrets mount is not enabled.
+	SecretsDirectory() string
+	Unregister(ctx context.Context, shutdown sdk.WorkerShutdown) error
 	Client() cdsclient.WorkerInterface
 	BaseDir() afero.Fs
 	Environ() []string
@@ -25,10 +25,25 @@ const (
 	CheckoutApplicationAction = "CheckoutApplication"
 	DeployApplicationAction   = "DeployApplication"
 	InstallKeyAction          = "InstallKey"
+	DockerBuildAction         = "DockerBuild"
+	WorkspaceSnapshotAction   = "WorkspaceSnapshot"
+	WorkspaceRestoreAction    = "WorkspaceRestore"
+	SemverAction              = "Semver"
 
 	DefaultGitCloneParameterTagValue = "{{.git.tag}}"
+	// DefaultDockerBuildParameterCacheTagValue shares the build cache between the runs of a pipeline in a workflow
+	DefaultDockerBuildParameterCacheTagValue = "{{.cds.workflow}}-{{.cds.pipeline}}"
+	// DefaultWorkspaceSnapshotName is the name of a workspace snapshot if not given
+	DefaultWorkspaceSnapshotName = "default"
+	// WorkspaceSnapshotArtifactTag is the tag of the artifacts that contain a workspace snapshot
+	WorkspaceSnapshotArtifactTag = "workspace"
 )
 
+// WorkspaceSnapshotArtifactName returns the name of the artifact that contains the workspace snapshot with given name.
+func WorkspaceSnapshotArtifactName(name string) string {
+	return "workspace." + name + ".tar.gz"
+}
+
 // NewAction instantiate a new Action
 func NewAction(name string) *Action {
 	return &Action{
@@ -50,6 +65,8 @@ type Action struct {
 	StepName       string `json:"step_name,omitempty" yaml:"step_name,omitempty" db:"-"`
 	Optional       bool   `json:"optional" yaml:"-" db:"-"`
 	AlwaysExecuted bool   `json:"always_executed" yaml:"-" db:"-"`
+	Condition      string `json:"condition,omitempty" yaml:"-" db:"-"`
+	Version        string `json:"version,omitempty" yaml:"-" db:"-"`
 	// aggregates
 	Requirements RequirementList `json:"requirements" db:"-"`
 	Parameters   []Parameter     `json:"parameters" db:"-"`
@@ -17,13 +17,17 @@ var List = []Manifest{
 	CheckoutApplication,
 	Coverage,
 	DeployApplication,
+	DockerBuild,
 	GitClone,
 	GitTag,
 	InstallKey,
 	JUnit,
 	Release,
 	Script,
+	Semver,
 	ServeStaticFiles,
+	WorkspaceRestore,
+	WorkspaceSnapshot,
 }
 
 // Manifest for a action.
@@ -16,7 +16,8 @@ Checkout a repository into a new directory.
 
 This action use the configuration from application vcs strategy to git clone the repository.
 The clone will be done with a depth of 50 and with submodules.
-If you want to modify theses options, you have to use gitClone action.
+If you want to modify theses options, you have to use gitClone action,
+or the worker checkout command in a script to clone the repository at another ref.
 `,
 		Parameters: []sdk.Parameter{
 			{
@@ -0,0 +1,90 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// DockerBuild action definition.
+var DockerBuild = Manifest{
+	Action: sdk.Action{
+		Name: sdk.DockerBuildAction,
+		Description: `Build a docker image with buildkit.
+The build cache is imported from and exported to a registry given by a DockerRegistry project integration, so the
+layers built by a previous run are reused by the next ones.
+`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "integration",
+				Description: "Name of the DockerRegistry project integration used to store the build cache.",
+				Value:       "",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "context",
+				Description: "(optional) The build context, relative to the workspace.",
+				Value:       ".",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "dockerfile",
+				Description: "(optional) The path to the Dockerfile, relative to the workspace.",
+				Value:       "Dockerfile",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "image",
+				Description: "(optional) Names of the built image, separated by a space. Example: registry.example.com/my/image:{{.cds.version}}",
+				Value:       "",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "buildArgs",
+				Description: "(optional) Build arguments, one KEY=VALUE per line.",
+				Value:       "",
+				Type:        sdk.TextParameter,
+			},
+			{
+				Name:        "push",
+				Description: "(optional) Push the image to its registry instead of loading it in the local docker daemon.",
+				Value:       "false",
+				Type:        sdk.BooleanParameter,
+			},
+			{
+				Name:        "cacheTag",
+				Description: "(optional) Tag of the build cache in the cache repository, builds sharing the same tag share their cache.",
+				Value:       sdk.DefaultDockerBuildParameterCacheTagValue,
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+		},
+		Requirements: []sdk.Requirement{
+			{
+				Name:  "docker",
+				Type:  sdk.BinaryRequirement,
+				Value: "docker",
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Stage1"},
+		Jobs: []exportentities.Job{{
+			Name:  "Job1",
+			Stage: "Stage1",
+			Steps: []exportentities.Step{
+				{
+					Checkout: &checkoutExample,
+				},
+				{
+					DockerBuild: &exportentities.StepDockerBuild{
+						Integration: "my-registry",
+						Image:       "registry.example.com/my/image:{{.cds.version}}",
+						Push:        "true",
+					},
+				},
+			},
+		}},
+	},
+}
@@ -11,11 +11,11 @@ var exampleJUnit = exportentities.StepJUnitReport("{{.cds.workspace}}/report.xml
 var JUnit = Manifest{
 	Action: sdk.Action{
 		Name:        sdk.JUnitAction,
-		Description: "This action parses a given Junit formatted XML file to extract its test results.",
+		Description: "This action parses a given Junit formatted XML file to extract its test results. TAP and go test -json reports are also supported, the format is detected from the file content.",
 		Parameters: []sdk.Parameter{
 			{
 				Name:        "path",
-				Description: `Path to junit xml file.`,
+				Description: `Path to junit xml, TAP or go test -json file.`,
 				Type:        sdk.TextParameter,
 			},
 		},
@@ -0,0 +1,127 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// Semver action definition.
+var Semver = Manifest{
+	Action: sdk.Action{
+		Name: sdk.SemverAction,
+		Description: `Compute the next semantic version of the repository from its last version tag and the conventional commits
+(https://www.conventionalcommits.org) since this tag. A breaking change bumps the major version, the types of the
+commits that bump the minor and the patch versions can be configured.
+
+The repository must be cloned with its tags, use depth 'false' on the GitClone action to find tags older than the
+last 50 commits. The following variables are exported:
+* cds.semver.next: the next version, without prefix
+* cds.semver.next.tag: the tag of the next version, with prefix
+* cds.semver.previous: the tag of the last version, empty if there is none
+* cds.semver.bump: the bump of the version, major, minor, patch or none
+
+If createTag is true, the tag of the next version is created and pushed using the vcs config of your application,
+unless the version was not bumped. The variable cds.release.version is then exported as for the GitTag action.
+`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "path",
+				Description: "(optional) The path to your git directory.",
+				Value:       "{{.cds.workspace}}",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "prefix",
+				Description: "(optional) Prefix of the version tags. Example: v for tags like v1.0.0.",
+				Value:       "",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "initialVersion",
+				Description: "(optional) Version used if the repository has no version tag.",
+				Value:       "0.1.0",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "majorTypes",
+				Description: "(optional) Types of commits that bump the major version, separated by a comma. Breaking changes always bump the major version.",
+				Value:       "",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "minorTypes",
+				Description: "(optional) Types of commits that bump the minor version, separated by a comma.",
+				Value:       "feat",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "patchTypes",
+				Description: "(optional) Types of commits that bump the patch version, separated by a comma.",
+				Value:       "fix,perf",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "defaultBump",
+				Description: "(optional) Bump for the commits that don't match any type. Must be 'major', 'minor', 'patch' or 'none'.",
+				Value:       sdk.SemverBumpNone,
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "prerelease",
+				Description: "(optional) Prerelease version added to the next version. Example: rc.{{.cds.run.number}} on a version 1.0.0 will return 1.0.0-rc.42.",
+				Value:       "",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "createTag",
+				Description: "(optional) Create and push the tag of the next version.",
+				Value:       "false",
+				Type:        sdk.BooleanParameter,
+			},
+			{
+				Name:        "tagMessage",
+				Description: "(optional) Set a message for the tag.",
+				Value:       "",
+				Type:        sdk.StringParameter,
+			},
+		},
+		Requirements: []sdk.Requirement{
+			{
+				Name:  "git",
+				Type:  sdk.BinaryRequirement,
+				Value: "git",
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Stage1"},
+		Jobs: []exportentities.Job{{
+			Name:  "Job1",
+			Stage: "Stage1",
+			Steps: []exportentities.Step{
+				{
+					GitClone: &exportentities.StepGitClone{
+						Branch:     "{{.git.branch}}",
+						Commit:     "{{.git.hash}}",
+						Depth:      "false",
+						Directory:  "{{.cds.workspace}}",
+						URL:        "{{.git.url}}",
+						PrivateKey: "proj-ssh-key",
+					},
+				},
+				{
+					Semver: &exportentities.StepSemver{
+						Prefix:    "v",
+						CreateTag: "true",
+					},
+				},
+			},
+		}},
+	},
+}
@@ -0,0 +1,76 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// WorkspaceSnapshot action definition.
+var WorkspaceSnapshot = Manifest{
+	Action: sdk.Action{
+		Name: sdk.WorkspaceSnapshotAction,
+		Description: `Snapshot the workspace of the job.
+The workspace is archived and uploaded as an artifact of the workflow run, so a downstream job of the same run can
+restore it with the WorkspaceRestore action instead of cloning and building again. Add this action as the last step of
+the job.
+`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "name",
+				Description: "(optional) Name of the snapshot, given to the WorkspaceRestore action.",
+				Value:       sdk.DefaultWorkspaceSnapshotName,
+				Type:        sdk.StringParameter,
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Build", "Test"},
+		Jobs: []exportentities.Job{{
+			Name:  "Build",
+			Stage: "Build",
+			Steps: []exportentities.Step{
+				{
+					Checkout: &checkoutExample,
+				},
+				{
+					Script: []string{"make build"},
+				},
+				{
+					WorkspaceSnapshot: &exportentities.StepWorkspace{},
+				},
+			},
+		}, {
+			Name:  "Test",
+			Stage: "Test",
+			Steps: []exportentities.Step{
+				{
+					WorkspaceRestore: &exportentities.StepWorkspace{},
+				},
+				{
+					Script: []string{"make test"},
+				},
+			},
+		}},
+	},
+}
+
+// WorkspaceRestore action definition.
+var WorkspaceRestore = Manifest{
+	Action: sdk.Action{
+		Name: sdk.WorkspaceRestoreAction,
+		Description: `Restore in the workspace of the job a snapshot taken by the WorkspaceSnapshot action in an upstream
+job of the same workflow run. If several snapshots have the same name, the last one is restored.
+`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "name",
+				Description: "(optional) Name of the snapshot to restore.",
+				Value:       sdk.DefaultWorkspaceSnapshotName,
+				Type:        sdk.StringParameter,
+			},
+		},
+	},
+	Example: WorkspaceSnapshot.Example,
+}
@@ -0,0 +1,116 @@
+package sdk
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+)
+
+// ActionVersion is a published version of an action, it contains a snapshot of the action at publication time.
+type ActionVersion struct {
+	ID           int64     `json:"id" db:"id"`
+	ActionID     int64     `json:"action_id" db:"action_id"`
+	Version      string    `json:"version" db:"version"`
+	Action       Action    `json:"action" db:"action"`
+	Yanked       bool      `json:"yanked" db:"yanked"`
+	YankedReason string    `json:"yanked_reason,omitempty" db:"yanked_reason"`
+	Author       string    `json:"author" db:"author"`
+	Created      time.Time `json:"created" db:"created"`
+}
+
+// ActionVersionYank is the body used to yank an action version.
+type ActionVersionYank struct {
+	Reason string `json:"reason"`
+}
+
+// ParseActionReference splits given action reference with format group/name@version, group and version are optional.
+func ParseActionReference(ref string) (groupName, name, version string) {
+	name = ref
+	if i := strings.LastIndex(name, "@"); i >= 0 {
+		name, version = name[:i], name[i+1:]
+	}
+	if i := strings.Index(name, "/"); i >= 0 {
+		groupName, name = name[:i], name[i+1:]
+	}
+	return groupName, name, version
+}
+
+// IsValidActionVersion returns an error if given version is not a valid semantic version.
+func IsValidActionVersion(version string) error {
+	if _, err := semver.Parse(version); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid action version %s, should be a semantic version like 1.2.3", version)
+	}
+	return nil
+}
+
+// ResolveActionVersion returns the greatest version that is not yanked and that matches given constraint.
+// A constraint can be a major version (v1), a minor version (v1.2) or a full version (v1.2.3), the v prefix is optional.
+func ResolveActionVersion(versions []ActionVersion, constraint string) (*ActionVersion, error) {
+	constraint = strings.TrimPrefix(constraint, "v")
+	for i := range versions {
+		if versions[i].Version == constraint && !versions[i].Yanked {
+			return &versions[i], nil
+		}
+	}
+
+	parts := strings.Split(constraint, ".")
+	if len(parts) > 3 {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid action version constraint %s", constraint)
+	}
+	nums := make([]uint64, len(parts))
+	for i := range parts {
+		n, err := strconv.ParseUint(parts[i], 10, 64)
+		if err != nil {
+			return nil, NewErrorFrom(ErrWrongRequest, "invalid action version constraint %s", constraint)
+		}
+		nums[i] = n
+	}
+
+	type candidate struct {
+		version semver.Version
+		index   int
+	}
+	var candidates []candidate
+	for i := range versions {
+		v, err := semver.Parse(versions[i].Version)
+		if err != nil || versions[i].Yanked {
+			continue
+		}
+		// pre-release versions can only be used with their exact version
+		if len(v.Pre) > 0 {
+			continue
+		}
+		current := []uint64{v.Major, v.Minor, v.Patch}
+		match := true
+		for j := range nums {
+			if current[j] != nums[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			candidates = append(candidates, candidate{version: v, index: i})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, NewErrorFrom(ErrNoAction, "no published version matches %s", constraint)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].version.GT(candidates[j].version) })
+	return &versions[candidates[0].index], nil
+}
+
+// SortActionVersions sorts given versions from the greatest to the lowest.
+func SortActionVersions(versions []ActionVersion) {
+	sort.Slice(versions, func(i, j int) bool {
+		vi, erri := semver.Parse(versions[i].Version)
+		vj, errj := semver.Parse(versions[j].Version)
+		if erri != nil || errj != nil {
+			return versions[i].Version > versions[j].Version
+		}
+		return vi.GT(vj)
+	})
+}
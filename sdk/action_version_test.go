@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseActionReference(t *testing.T) {
+	tests := []struct {
+		ref, group, name, version string
+	}{
+		{ref: "myAction", name: "myAction"},
+		{ref: "myGroup/myAction", group: "myGroup", name: "myAction"},
+		{ref: "myGroup/myAction@v1", group: "myGroup", name: "myAction", version: "v1"},
+		{ref: "myAction@1.2.3", name: "myAction", version: "1.2.3"},
+	}
+	for _, tt := range tests {
+		g, n, v := ParseActionReference(tt.ref)
+		assert.Equal(t, tt.group, g, tt.ref)
+		assert.Equal(t, tt.name, n, tt.ref)
+		assert.Equal(t, tt.version, v, tt.ref)
+	}
+}
+
+func TestResolveActionVersion(t *testing.T) {
+	versions := []ActionVersion{
+		{Version: "1.0.0"},
+		{Version: "1.2.0"},
+		{Version: "1.10.1"},
+		{Version: "1.11.0", Yanked: true},
+		{Version: "2.0.0-rc1"},
+		{Version: "2.0.0"},
+		{Version: "2.1.0", Yanked: true},
+	}
+
+	tests := []struct {
+		constraint string
+		expected   string
+		err        bool
+	}{
+		{constraint: "v1", expected: "1.10.1"},
+		{constraint: "1", expected: "1.10.1"},
+		{constraint: "v1.2", expected: "1.2.0"},
+		{constraint: "v1.0.0", expected: "1.0.0"},
+		{constraint: "2", expected: "2.0.0"},
+		{constraint: "2.0.0-rc1", expected: "2.0.0-rc1"},
+		{constraint: "1.11.0", err: true},
+		{constraint: "2.1", err: true},
+		{constraint: "3", err: true},
+		{constraint: "latest", err: true},
+	}
+	for _, tt := range tests {
+		v, err := ResolveActionVersion(versions, tt.constraint)
+		if tt.err {
+			assert.Error(t, err, tt.constraint)
+			continue
+		}
+		require.NoError(t, err, tt.constraint)
+		assert.Equal(t, tt.expected, v.Version, tt.constraint)
+	}
+}
+
+func TestSortActionVersions(t *testing.T) {
+	versions := []ActionVersion{{Version: "1.2.0"}, {Version: "1.10.0"}, {Version: "0.9.1"}}
+	SortActionVersions(versions)
+	assert.Equal(t, "1.10.0", versions[0].Version)
+	assert.Equal(t, "1.2.0", versions[1].Version)
+	assert.Equal(t, "0.9.1", versions[2].Version)
+}
@@ -0,0 +1,7 @@
+package sdk
+
+// CacheKey is a key of the cache of the API with its remaining time to live in seconds, -1 if the key doesn't expire.
+type CacheKey struct {
+	Key string `json:"key" cli:"key,key"`
+	TTL int64  `json:"ttl" cli:"ttl"`
+}
@@ -0,0 +1,26 @@
+package sdk
+
+import (
+	"time"
+)
+
+// Versions of the API. Routes without version in their path are the routes of the API v1.
+const (
+	APIVersion1 = "v1"
+	APIVersion2 = "v2"
+)
+
+// APIVersions lists the versions of the API, from the oldest to the latest.
+var APIVersions = []string{APIVersion1, APIVersion2}
+
+// APIRoute describes a route of the API in the route catalog.
+type APIRoute struct {
+	Route      string              `json:"route" cli:"route,key"`
+	Method     string              `json:"method" cli:"method,key"`
+	Handler    string              `json:"handler" cli:"handler"`
+	Scopes     []AuthConsumerScope `json:"scopes,omitempty" cli:"-"`
+	Versions   []string            `json:"versions" cli:"versions"`
+	Deprecated bool                `json:"deprecated" cli:"deprecated"`
+	Sunset     *time.Time          `json:"sunset,omitempty" cli:"sunset"`
+	Successor  string              `json:"successor,omitempty" cli:"successor"`
+}
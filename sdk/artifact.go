@@ -1,5 +1,7 @@
 package sdk
 
+import "time"
+
 // Builtin artifact manipulation actions
 const (
 	ArtifactUpload   = "Artifact Upload"
@@ -12,3 +14,41 @@ type ArtifactsStore struct {
 	Name                  string `json:"name"`
 	TemporaryURLSupported bool   `json:"temporary_url_supported"`
 }
+
+// Replication statuses of an artifact stored in the shared storage.
+const (
+	ArtifactReplicationStatusPending = "Pending"
+	ArtifactReplicationStatusSynced  = "Synced"
+	ArtifactReplicationStatusError   = "Error"
+)
+
+// ArtifactReplication is the replication state of an object from the storage to the replica storage.
+type ArtifactReplication struct {
+	ID          int64      `json:"id" db:"id"`
+	Path        string     `json:"path" db:"object_path"`
+	Name        string     `json:"name" db:"object_name"`
+	Status      string     `json:"status" db:"status"`
+	Checksum    string     `json:"checksum,omitempty" db:"checksum"`
+	Error       string     `json:"error,omitempty" db:"error"`
+	Attempts    int        `json:"attempts" db:"attempts"`
+	Created     time.Time  `json:"created" db:"created"`
+	LastAttempt *time.Time `json:"last_attempt,omitempty" db:"last_attempt"`
+}
+
+// GetName returns the name of the replicated object.
+func (a ArtifactReplication) GetName() string {
+	return a.Name
+}
+
+// GetPath returns the path of the replicated object.
+func (a ArtifactReplication) GetPath() string {
+	return a.Path
+}
+
+// ArtifactReplicationStatus gives the number of objects by replication status and the last errors.
+type ArtifactReplicationStatus struct {
+	Pending int64                 `json:"pending"`
+	Synced  int64                 `json:"synced"`
+	Error   int64                 `json:"error"`
+	Errors  []ArtifactReplication `json:"errors,omitempty"`
+}
@@ -0,0 +1,73 @@
+package sdk
+
+// Retention classes of the artifacts of a workflow run, given by the tags of the run.
+const (
+	ArtifactRetentionClassBranch  = "branch"
+	ArtifactRetentionClassTag     = "tag"
+	ArtifactRetentionClassRelease = "release"
+)
+
+// ArtifactRetentionClasses lists all the retention classes of the artifacts.
+var ArtifactRetentionClasses = []string{ArtifactRetentionClassBranch, ArtifactRetentionClassTag, ArtifactRetentionClassRelease}
+
+// WorkflowRunTagRelease is the tag set on a workflow run when a release is created from it.
+const WorkflowRunTagRelease = "cds.release"
+
+// ArtifactRetentionClass returns the retention class of the artifacts of the run: release if a release was created
+// from the run, tag if the run was triggered on a git tag, branch otherwise.
+func (r *WorkflowRun) ArtifactRetentionClass() string {
+	switch {
+	case r.TagExists(WorkflowRunTagRelease):
+		return ArtifactRetentionClassRelease
+	case r.TagExists("git.tag"):
+		return ArtifactRetentionClassTag
+	}
+	return ArtifactRetentionClassBranch
+}
+
+// ProjectArtifactRetention is the retention policy of the artifacts of a project. For each retention class, the
+// artifacts older than the given number of days are deleted, 0 means that the artifacts are kept as long as their
+// workflow run. Pinned artifacts are never deleted.
+type ProjectArtifactRetention struct {
+	ID        int64 `json:"id" db:"id"`
+	ProjectID int64 `json:"project_id" db:"project_id"`
+	Branch    int64 `json:"branch" db:"branch_days"`
+	Tag       int64 `json:"tag" db:"tag_days"`
+	Release   int64 `json:"release" db:"release_days"`
+}
+
+// IsValid returns an error if the retention policy is not valid, artifacts of tagged runs can't be kept less than the
+// ones of branch builds, and artifacts of released runs can't be kept less than the ones of tagged runs.
+func (p ProjectArtifactRetention) IsValid() error {
+	if p.Branch < 0 || p.Tag < 0 || p.Release < 0 {
+		return NewErrorFrom(ErrWrongRequest, "artifact retention durations must be positive")
+	}
+	if retentionShorter(p.Tag, p.Branch) {
+		return NewErrorFrom(ErrWrongRequest, "artifacts of tagged runs can't be kept less than the ones of branch builds")
+	}
+	if retentionShorter(p.Release, p.Tag) {
+		return NewErrorFrom(ErrWrongRequest, "artifacts of released runs can't be kept less than the ones of tagged runs")
+	}
+	return nil
+}
+
+// Days returns the retention in days of given class, 0 if the artifacts are kept as long as their workflow run.
+func (p ProjectArtifactRetention) Days(class string) int64 {
+	switch class {
+	case ArtifactRetentionClassBranch:
+		return p.Branch
+	case ArtifactRetentionClassTag:
+		return p.Tag
+	case ArtifactRetentionClassRelease:
+		return p.Release
+	}
+	return 0
+}
+
+// retentionShorter returns true if retention a is shorter than b, 0 being unlimited.
+func retentionShorter(a, b int64) bool {
+	if a == 0 {
+		return false
+	}
+	return b == 0 || a < b
+}
@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectArtifactRetentionIsValid(t *testing.T) {
+	assert.NoError(t, ProjectArtifactRetention{}.IsValid())
+	assert.NoError(t, ProjectArtifactRetention{Branch: 7, Tag: 90}.IsValid())
+	assert.NoError(t, ProjectArtifactRetention{Branch: 7, Tag: 90, Release: 365}.IsValid())
+	assert.NoError(t, ProjectArtifactRetention{Branch: 7}.IsValid())
+	assert.Error(t, ProjectArtifactRetention{Branch: -1}.IsValid())
+	assert.Error(t, ProjectArtifactRetention{Branch: 30, Tag: 7}.IsValid())
+	assert.Error(t, ProjectArtifactRetention{Tag: 7}.IsValid())
+	assert.Error(t, ProjectArtifactRetention{Branch: 7, Tag: 90, Release: 30}.IsValid())
+}
+
+func TestWorkflowRunArtifactRetentionClass(t *testing.T) {
+	r := WorkflowRun{Tags: []WorkflowRunTag{{Tag: "git.branch", Value: "master"}}}
+	assert.Equal(t, ArtifactRetentionClassBranch, r.ArtifactRetentionClass())
+
+	r.Tag("git.tag", "v1.0.0")
+	assert.Equal(t, ArtifactRetentionClassTag, r.ArtifactRetentionClass())
+
+	r.Tag(WorkflowRunTagRelease, "v1.0.0")
+	assert.Equal(t, ArtifactRetentionClassRelease, r.ArtifactRetentionClass())
+}
@@ -0,0 +1,20 @@
+package sdk
+
+// Status of the scan of an artifact by the artifact scan integration of its project. The status is empty if the
+// project has no artifact scan integration.
+const (
+	ArtifactScanStatusClean    = "clean"
+	ArtifactScanStatusInfected = "infected"
+	ArtifactScanStatusError    = "error"
+)
+
+// Types of scanners of the artifact scan integration.
+const (
+	ArtifactScanIntegrationTypeClamAV = "clamav"
+	ArtifactScanIntegrationTypeICAP   = "icap"
+)
+
+// IsQuarantined returns true if the artifact is infected, it can't be downloaded.
+func (a WorkflowNodeRunArtifact) IsQuarantined() bool {
+	return a.ScanStatus == ArtifactScanStatusInfected
+}
@@ -23,8 +23,45 @@ type client struct {
 	name          string
 }
 
-// NewHTTPClient returns a new HTTP Client.
+// NewHTTPClient returns a new HTTP Client, instrumented to trace its requests and record their metrics.
 func NewHTTPClient(timeout time.Duration, insecureSkipVerifyTLS bool) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: NewInstrumentedTransport(newHTTPTransport(timeout, &tls.Config{InsecureSkipVerify: insecureSkipVerifyTLS})),
+	}
+}
+
+// HTTPClientOptions are the connectivity options of a HTTP Client created with NewHTTPClientWithOptions.
+type HTTPClientOptions struct {
+	InsecureSkipVerifyTLS bool
+	// ProxyURL is the address of a http, https or socks5 proxy, the proxy of the environment is used if empty
+	ProxyURL string
+	// CABundle contains PEM encoded certificates trusted in addition to the system ones
+	CABundle string
+}
+
+// NewHTTPClientWithOptions returns a new instrumented HTTP Client that uses given proxy and trusts given certificates.
+func NewHTTPClientWithOptions(timeout time.Duration, opts HTTPClientOptions) (*http.Client, error) {
+	proxyURL, err := sdk.ParseIntegrationProxyURL(opts.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := sdk.ParseIntegrationCABundle(opts.CABundle)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := newHTTPTransport(timeout, &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerifyTLS, RootCAs: pool})
+	if proxyURL != nil {
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: NewInstrumentedTransport(transport),
+	}, nil
+}
+
+func newHTTPTransport(timeout time.Duration, tlsConfig *tls.Config) *http.Transport {
 	transport := http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
@@ -37,7 +74,7 @@ func NewHTTPClient(timeout time.Duration, insecureSkipVerifyTLS bool) *http.Clie
 		TLSHandshakeTimeout:   10 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
 		ResponseHeaderTimeout: 30 * time.Second,
-		TLSClientConfig:       &tls.Config{InsecureSkipVerify: insecureSkipVerifyTLS},
+		TLSClientConfig:       tlsConfig,
 	}
 
 	if timeout == 0 {
@@ -45,10 +82,7 @@ func NewHTTPClient(timeout time.Duration, insecureSkipVerifyTLS bool) *http.Clie
 		transport.ResponseHeaderTimeout = 0
 	}
 
-	return &http.Client{
-		Timeout:   timeout,
-		Transport: &transport,
-	}
+	return &transport
 }
 
 // New returns a client from a config struct
@@ -46,6 +46,53 @@ func (c *client) AdminCDSMigrationList() ([]sdk.Migration, error) {
 	return migrations, nil
 }
 
+func (c *client) AdminSCIMAudit(limit int) ([]sdk.SCIMAudit, error) {
+	var audits []sdk.SCIMAudit
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/admin/scim/audit?limit=%d", limit), &audits); err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
+
+func adminCachePath(projectKey, workflowName string) string {
+	path := fmt.Sprintf("/admin/cache/project/%s", url.PathEscape(projectKey))
+	if workflowName != "" {
+		path += fmt.Sprintf("/workflow/%s", url.PathEscape(workflowName))
+	}
+	return path
+}
+
+func (c *client) AdminCacheKeys(projectKey, workflowName string) ([]sdk.CacheKey, error) {
+	var keys []sdk.CacheKey
+	if _, err := c.GetJSON(context.Background(), adminCachePath(projectKey, workflowName), &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (c *client) AdminCacheInvalidate(projectKey, workflowName string) error {
+	_, err := c.DeleteJSON(context.Background(), adminCachePath(projectKey, workflowName), nil)
+	return err
+}
+
+func (c *client) AdminQueueJobCancel(id int64) error {
+	_, _, _, err := c.Request(context.Background(), "POST", fmt.Sprintf("/admin/queue/job/%d/cancel", id), nil)
+	return err
+}
+
+func (c *client) AdminQueueJobRequeue(id int64) error {
+	_, _, _, err := c.Request(context.Background(), "POST", fmt.Sprintf("/admin/queue/job/%d/requeue", id), nil)
+	return err
+}
+
+func (c *client) AdminQueueJobDiagnostic(id int64) (*sdk.QueueJobDiagnostic, error) {
+	var diag sdk.QueueJobDiagnostic
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/admin/queue/job/%d/diagnostic", id), &diag); err != nil {
+		return nil, err
+	}
+	return &diag, nil
+}
+
 func (c *client) Services() ([]sdk.Service, error) {
 	srvs := []sdk.Service{}
 	if _, err := c.GetJSON(context.Background(), "/admin/services", &srvs); err != nil {
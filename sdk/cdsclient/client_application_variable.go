@@ -37,3 +37,11 @@ func (c *client) ApplicationVariableGet(projectKey string, appName string, varNa
 	}
 	return variable, nil
 }
+
+func (c *client) ApplicationVariablesBatch(projectKey, appName string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	var diff sdk.VariableBatchDiff
+	if _, err := c.PostJSON(context.Background(), "/project/"+projectKey+"/application/"+appName+"/variable", batch, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
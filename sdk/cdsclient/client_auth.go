@@ -76,6 +76,12 @@ func (c *client) AuthConsumerCreateForUser(username string, request sdk.AuthCons
 	return consumer, err
 }
 
+func (c *client) AuthBrowserTokenCreateForUser(username string, request sdk.AuthConsumerBrowserTokenRequest) (sdk.AuthConsumerBrowserTokenResponse, error) {
+	var res sdk.AuthConsumerBrowserTokenResponse
+	_, _, _, err := c.RequestJSON(context.Background(), "POST", "/user/"+username+"/auth/consumer/browser", request, &res)
+	return res, err
+}
+
 func (c *client) AuthSessionListByUser(username string) (sdk.AuthSessions, error) {
 	var sessions sdk.AuthSessions
 	if _, err := c.GetJSON(context.Background(), "/user/"+username+"/auth/session", &sessions); err != nil {
@@ -24,7 +24,7 @@ func (c *client) DownloadURLFromAPI(name, os, arch, variant string) string {
 }
 
 func (c *client) DownloadURLFromGithub(filename string) (string, error) {
-	var httpClient = &http.Client{Timeout: 10 * time.Second}
+	var httpClient = &http.Client{Timeout: 10 * time.Second, Transport: NewInstrumentedTransport(http.DefaultTransport)}
 
 	r, err := httpClient.Get("https://api.github.com/repos/ovh/cds/releases/latest")
 	if err != nil {
@@ -37,3 +37,11 @@ func (c *client) EnvironmentVariableGet(projectKey string, envName string, varNa
 	}
 	return variable, nil
 }
+
+func (c *client) EnvironmentVariablesBatch(projectKey, envName string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	var diff sdk.VariableBatchDiff
+	if _, err := c.PostJSON(context.Background(), "/project/"+projectKey+"/environment/"+url.QueryEscape(envName)+"/variable", batch, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
@@ -0,0 +1,29 @@
+package cdsclient
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) GroupIntegrationList(groupName string) ([]sdk.GroupIntegration, error) {
+	var result []sdk.GroupIntegration
+	_, err := c.GetJSON(context.Background(), "/group/"+url.QueryEscape(groupName)+"/integrations", &result)
+	return result, err
+}
+
+func (c *client) GroupIntegrationCreate(groupName string, gi *sdk.GroupIntegration) error {
+	_, err := c.PostJSON(context.Background(), "/group/"+url.QueryEscape(groupName)+"/integrations", gi, gi)
+	return err
+}
+
+func (c *client) GroupIntegrationUpdate(groupName string, gi *sdk.GroupIntegration) error {
+	_, err := c.PutJSON(context.Background(), "/group/"+url.QueryEscape(groupName)+"/integrations/"+url.QueryEscape(gi.Name), gi, gi)
+	return err
+}
+
+func (c *client) GroupIntegrationDelete(groupName, name string) error {
+	_, _, _, err := c.Request(context.Background(), "DELETE", "/group/"+url.QueryEscape(groupName)+"/integrations/"+url.QueryEscape(name), nil)
+	return err
+}
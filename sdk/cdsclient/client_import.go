@@ -83,6 +83,21 @@ func (c *client) WorkflowImport(projectKey string, content io.Reader, mods ...Re
 	return messages, err
 }
 
+func (c *client) WorkflowImportIntegrationsCheck(projectKey string, content io.Reader, mods ...RequestModifier) (*sdk.WorkflowImportIntegrationReport, error) {
+	url := fmt.Sprintf("/project/%s/preview/workflows/integrations", projectKey)
+
+	btes, _, _, err := c.Request(context.Background(), "POST", url, content, mods...)
+	if err != nil {
+		return nil, err
+	}
+
+	var report sdk.WorkflowImportIntegrationReport
+	if err := json.Unmarshal(btes, &report); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return &report, nil
+}
+
 func (c *client) WorkflowPush(projectKey string, tarContent io.Reader, mods ...RequestModifier) ([]string, *tar.Reader, error) {
 	url := fmt.Sprintf("/project/%s/push/workflows", projectKey)
 
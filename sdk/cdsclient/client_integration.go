@@ -15,6 +15,14 @@ func (c *client) IntegrationModelList() ([]sdk.IntegrationModel, error) {
 	return models, nil
 }
 
+func (c *client) IntegrationModelCatalog() ([]sdk.IntegrationModelCatalogEntry, error) {
+	catalog := []sdk.IntegrationModelCatalogEntry{}
+	if _, err := c.GetJSON(context.Background(), "/integration/models/catalog", &catalog); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
 func (c *client) IntegrationModelGet(name string) (sdk.IntegrationModel, error) {
 	var model sdk.IntegrationModel
 	if _, err := c.GetJSON(context.Background(), "/integration/models/"+url.QueryEscape(name), &model); err != nil {
@@ -43,3 +51,11 @@ func (c *client) IntegrationModelDelete(name string) error {
 	}
 	return nil
 }
+
+func (c *client) AdminProjectIntegrationApply(req sdk.ProjectIntegrationApplyRequest) ([]sdk.ProjectIntegrationApplyResult, error) {
+	var results []sdk.ProjectIntegrationApplyResult
+	if _, err := c.PostJSON(context.Background(), "/admin/integration/apply", req, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -3,9 +3,35 @@ package cdsclient
 import (
 	"context"
 	"fmt"
+
+	"github.com/ovh/cds/sdk"
 )
 
 func (c *client) Maintenance(enable bool, hooks bool) error {
 	_, err := c.PostJSON(context.Background(), fmt.Sprintf("/admin/maintenance?enable=%v&withHook=%v", enable, hooks), nil, nil)
 	return err
 }
+
+func (c *client) MaintenanceWindowList() ([]sdk.MaintenanceWindow, error) {
+	windows := []sdk.MaintenanceWindow{}
+	if _, err := c.GetJSON(context.Background(), "/admin/maintenance/window", &windows); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+func (c *client) MaintenanceWindowCreate(w *sdk.MaintenanceWindow) error {
+	_, err := c.PostJSON(context.Background(), "/admin/maintenance/window", w, w)
+	return err
+}
+
+func (c *client) MaintenanceWindowDelete(id int64) error {
+	_, err := c.DeleteJSON(context.Background(), fmt.Sprintf("/admin/maintenance/window/%d", id), nil)
+	return err
+}
+
+func (c *client) MaintenanceWindowOverride(projectKey string, workflowName string, number int64, reason string) error {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/maintenance/override", projectKey, workflowName, number)
+	_, err := c.PostJSON(context.Background(), url, sdk.MaintenanceWindowOverride{Reason: reason}, nil)
+	return err
+}
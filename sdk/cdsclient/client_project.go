@@ -15,6 +15,14 @@ func (c *client) ProjectCreate(p *sdk.Project) error {
 	return err
 }
 
+func (c *client) ProjectBootstrap(req sdk.ProjectBootstrap) (*sdk.ProjectBootstrapResult, error) {
+	var res sdk.ProjectBootstrapResult
+	if _, err := c.PostJSON(context.Background(), "/project/bootstrap", req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
 func (c *client) ProjectDelete(key string) error {
 	_, err := c.DeleteJSON(context.Background(), "/project/"+key, nil, nil)
 	return err
@@ -0,0 +1,25 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectArtifactRetentionGet(projectKey string) (sdk.ProjectArtifactRetention, error) {
+	path := fmt.Sprintf("/project/%s/artifact/retention", projectKey)
+	var p sdk.ProjectArtifactRetention
+	if _, err := c.GetJSON(context.Background(), path, &p); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func (c *client) ProjectArtifactRetentionUpdate(projectKey string, policy *sdk.ProjectArtifactRetention) error {
+	path := fmt.Sprintf("/project/%s/artifact/retention", projectKey)
+	if _, err := c.PutJSON(context.Background(), path, policy, policy); err != nil {
+		return err
+	}
+	return nil
+}
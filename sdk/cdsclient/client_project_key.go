@@ -24,3 +24,9 @@ func (c *client) ProjectKeysDelete(projectKey string, keyName string) error {
 	_, _, _, err := c.Request(context.Background(), "DELETE", "/project/"+projectKey+"/keys/"+url.QueryEscape(keyName), nil)
 	return err
 }
+
+func (c *client) ProjectBuiltinPublicKey(projectKey string) (sdk.Key, error) {
+	var k sdk.Key
+	_, err := c.GetJSON(context.Background(), "/project/"+projectKey+"/encrypt/key", &k)
+	return k, err
+}
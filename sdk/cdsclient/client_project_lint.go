@@ -0,0 +1,22 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectLint(projectKey string, files map[string][]byte) ([]sdk.LintDiagnostic, error) {
+	req := sdk.LintRequest{Files: make(map[string]string, len(files))}
+	for name, content := range files {
+		req.Files[name] = string(content)
+	}
+
+	path := fmt.Sprintf("/project/%s/lint", projectKey)
+	var diagnostics []sdk.LintDiagnostic
+	if _, err := c.PostJSON(context.Background(), path, req, &diagnostics); err != nil {
+		return nil, err
+	}
+	return diagnostics, nil
+}
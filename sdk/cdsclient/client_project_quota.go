@@ -0,0 +1,34 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectQuotaGet(projectKey string) (sdk.ProjectQuotaStatus, error) {
+	path := fmt.Sprintf("/project/%s/quota", projectKey)
+	var s sdk.ProjectQuotaStatus
+	if _, err := c.GetJSON(context.Background(), path, &s); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+func (c *client) ProjectQuotaUpdate(projectKey string, quota *sdk.ProjectQuota) error {
+	path := fmt.Sprintf("/project/%s/quota", projectKey)
+	if _, err := c.PutJSON(context.Background(), path, quota, quota); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *client) ProjectCostReport(projectKey string, mods ...RequestModifier) (sdk.WorkflowCostReport, error) {
+	path := fmt.Sprintf("/project/%s/cost", projectKey)
+	var r sdk.WorkflowCostReport
+	if _, err := c.GetJSON(context.Background(), path, &r, mods...); err != nil {
+		return r, err
+	}
+	return r, nil
+}
@@ -49,3 +49,11 @@ func (c *client) VariableEncrypt(projectKey string, varName string, content stri
 	}
 	return variable, nil
 }
+
+func (c *client) ProjectVariablesBatch(projectKey string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	var diff sdk.VariableBatchDiff
+	if _, err := c.PostJSON(context.Background(), "/project/"+projectKey+"/variable", batch, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
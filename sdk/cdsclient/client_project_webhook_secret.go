@@ -0,0 +1,33 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectWebHookSecretList(projectKey string, clearSecret bool) ([]sdk.ProjectWebHookSecret, error) {
+	path := fmt.Sprintf("/project/%s/webhook/secret?clearSecret=%v", projectKey, clearSecret)
+	var res []sdk.ProjectWebHookSecret
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) ProjectWebHookSecretRotate(projectKey string, overlap time.Duration) (sdk.ProjectWebHookSecret, error) {
+	path := fmt.Sprintf("/project/%s/webhook/secret/rotate?overlap=%s", projectKey, url.QueryEscape(overlap.String()))
+	var res sdk.ProjectWebHookSecret
+	if _, err := c.PostJSON(context.Background(), path, nil, &res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (c *client) ProjectWebHookSecretDelete(projectKey string) error {
+	_, err := c.DeleteJSON(context.Background(), fmt.Sprintf("/project/%s/webhook/secret", projectKey), nil)
+	return err
+}
@@ -21,11 +21,16 @@ import (
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/venom"
 	"github.com/sguiheux/go-coverage"
+	"golang.org/x/net/websocket"
 )
 
+// queueStreamHeartbeatDelay is the delay between two heartbeats sent by the API on the queue stream.
+const queueStreamHeartbeatDelay = 10 * time.Second
+
 // shrinkQueue is used to shrink the polled queue 200% of the channel capacity (l)
 // it returns as reference date the date of the last element in the shrinkked queue
-func shrinkQueue(queue *sdk.WorkflowQueue, nbJobsToKeep int) time.Time {
+// if the queue was already scheduled by the API, its order is kept
+func shrinkQueue(queue *sdk.WorkflowQueue, nbJobsToKeep int, scheduled bool) time.Time {
 	if len(*queue) == 0 {
 		return time.Time{}
 	}
@@ -38,7 +43,9 @@ func shrinkQueue(queue *sdk.WorkflowQueue, nbJobsToKeep int) time.Time {
 	// we keep 2x this number
 	nbJobsToKeep = nbJobsToKeep * 2
 
-	queue.Sort()
+	if !scheduled {
+		queue.Sort()
+	}
 
 	if len(*queue) > nbJobsToKeep {
 		newQueue := (*queue)[:nbJobsToKeep]
@@ -122,42 +129,138 @@ func (c *client) QueuePolling(ctx context.Context, jobs chan<- sdk.WorkflowNodeJ
 				continue
 			}
 
-			urlValues := url.Values{}
-			if ratioService != nil {
-				urlValues.Set("ratioService", strconv.Itoa(*ratioService))
-			}
+			c.queueLoadJobs(ctx, jobs, errs, modelType, ratioService)
+		}
 
-			if modelType != "" {
-				urlValues.Set("modelType", modelType)
-			}
+	}
+}
 
-			ctxt, cancel := context.WithTimeout(ctx, 10*time.Second)
-			queue := sdk.WorkflowQueue{}
-			var urlSuffix = urlValues.Encode()
-			if urlSuffix != "" {
-				urlSuffix = "?" + urlSuffix
-			}
-			if _, err := c.GetJSON(ctxt, "/queue/workflows"+urlSuffix, &queue, nil); err != nil && !sdk.ErrorIs(err, sdk.ErrUnauthorized) {
-				errs <- sdk.WrapError(err, "Unable to load jobs")
-				cancel()
-				continue
-			} else if sdk.ErrorIs(err, sdk.ErrUnauthorized) {
-				cancel()
-				continue
-			}
+// queueLoadJobs loads waiting jobs from the queue and pushes them in given channel.
+func (c *client) queueLoadJobs(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, modelType string, ratioService *int) {
+	urlValues := url.Values{}
+	if ratioService != nil {
+		urlValues.Set("ratioService", strconv.Itoa(*ratioService))
+	}
 
-			cancel()
+	if modelType != "" {
+		urlValues.Set("modelType", modelType)
+	}
 
-			if c.config.Verbose {
-				fmt.Println("Jobs Queue size: ", len(queue))
-			}
+	ctxt, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	queue := sdk.WorkflowQueue{}
+	var urlSuffix = urlValues.Encode()
+	if urlSuffix != "" {
+		urlSuffix = "?" + urlSuffix
+	}
+	_, header, _, err := c.RequestJSON(ctxt, http.MethodGet, "/queue/workflows"+urlSuffix, nil, &queue)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrUnauthorized) {
+		errs <- sdk.WrapError(err, "Unable to load jobs")
+		return
+	} else if sdk.ErrorIs(err, sdk.ErrUnauthorized) {
+		return
+	}
+
+	if c.config.Verbose {
+		fmt.Println("Jobs Queue size: ", len(queue))
+	}
+
+	shrinkQueue(&queue, cap(jobs), header.Get(ResponseQueueScheduledHeader) == "true")
+	for _, j := range queue {
+		jobs <- j
+	}
+}
 
-			shrinkQueue(&queue, cap(jobs))
-			for _, j := range queue {
-				jobs <- j
+// QueueStream subscribes to the queue stream and pushes received jobs in given channel. The stream
+// is resumed from the last received cursor when the connection is lost or when no heartbeat is received.
+// The whole queue is also loaded every resync delay to get back jobs that were not spawned.
+func (c *client) QueueStream(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, resync time.Duration, modelType string, ratioService *int) error {
+	resyncTicker := time.NewTicker(resync)
+	defer resyncTicker.Stop()
+
+	path := "/queue/workflows/stream"
+	urlValues := url.Values{}
+	if ratioService != nil {
+		urlValues.Set("ratioService", strconv.Itoa(*ratioService))
+	}
+	if modelType != "" {
+		urlValues.Set("modelType", modelType)
+	}
+	if urlSuffix := urlValues.Encode(); urlSuffix != "" {
+		path += "?" + urlSuffix
+	}
+
+	var cursor int64
+	for {
+		streamCtx, cancel := context.WithCancel(ctx)
+		chanSSEvt := make(chan SSEvent)
+		stopped := make(chan struct{})
+		sdk.GoRoutine(streamCtx, "QueueStream", func(ctx context.Context) {
+			defer close(stopped)
+			if err := c.RequestSSEGet(ctx, path, chanSSEvt, SetHeader("Last-Event-ID", strconv.FormatInt(cursor, 10))); err != nil && ctx.Err() == nil {
+				errs <- sdk.WrapError(err, "queue stream disconnected")
+			}
+		})
+
+		// Without heartbeat for some time, the stream is considered as lost
+		heartbeatTimeout := time.NewTimer(3 * queueStreamHeartbeatDelay)
+	stream:
+		for {
+			select {
+			case <-ctx.Done():
+				heartbeatTimeout.Stop()
+				cancel()
+				if jobs != nil {
+					close(jobs)
+				}
+				return ctx.Err()
+			case <-stopped:
+				break stream
+			case <-heartbeatTimeout.C:
+				break stream
+			case <-resyncTicker.C:
+				if jobs != nil {
+					c.queueLoadJobs(ctx, jobs, errs, modelType, ratioService)
+				}
+			case evt := <-chanSSEvt:
+				content, _ := ioutil.ReadAll(evt.Data)
+				var e sdk.WorkflowQueueStreamEvent
+				if err := json.Unmarshal(content, &e); err != nil {
+					continue
+				}
+				if !heartbeatTimeout.Stop() {
+					<-heartbeatTimeout.C
+				}
+				heartbeatTimeout.Reset(3 * queueStreamHeartbeatDelay)
+				if e.Cursor > cursor {
+					cursor = e.Cursor
+				}
+				if jobs != nil && e.Type == sdk.WorkflowQueueStreamEventJob && e.Job != nil {
+					if e.Job.Header == nil {
+						e.Job.Header = sdk.WorkflowRunHeaders{}
+					}
+					e.Job.Header["SSE"] = "true"
+					jobs <- *e.Job
+				}
 			}
 		}
 
+		heartbeatTimeout.Stop()
+		cancel()
+		// Drain events of the lost stream until its reader is stopped
+		go func(chanSSEvt <-chan SSEvent, stopped <-chan struct{}) {
+			for {
+				select {
+				case <-chanSSEvt:
+				case <-stopped:
+					return
+				}
+			}
+		}(chanSSEvt, stopped)
+		if c.config.Verbose {
+			fmt.Println("queue stream lost, reconnecting")
+		}
+		time.Sleep(time.Second)
 	}
 }
 
@@ -239,6 +342,13 @@ func (c *client) QueueJobBook(ctx context.Context, id int64) error {
 	return err
 }
 
+// QueueJobInfraError reports that a job can't run because of the infrastructure
+func (c *client) QueueJobInfraError(ctx context.Context, id int64, infraErr sdk.JobInfraError) error {
+	path := fmt.Sprintf("/queue/workflows/%d/infraerror", id)
+	_, err := c.PostJSON(ctx, path, infraErr, nil)
+	return err
+}
+
 // QueueJobRelease release a job for a worker
 func (c *client) QueueJobRelease(ctx context.Context, id int64) error {
 	path := fmt.Sprintf("/queue/workflows/%d/book", id)
@@ -327,7 +437,7 @@ func (c *client) queueIndirectArtifactTempURLPost(url string, content []byte) er
 		}
 
 		var resp *http.Response
-		resp, globalErr = http.DefaultClient.Do(req)
+		resp, globalErr = DefaultHTTPClient.Do(req)
 		if globalErr == nil {
 			defer resp.Body.Close()
 
@@ -510,6 +620,44 @@ func (c *client) QueueJobTag(ctx context.Context, jobID int64, tags []sdk.Workfl
 	return err
 }
 
+func (c *client) QueueJobAnnotation(ctx context.Context, jobID int64, a sdk.WorkflowRunAnnotation) error {
+	path := fmt.Sprintf("/queue/workflows/%d/annotation", jobID)
+	_, err := c.PostJSON(ctx, path, a, nil)
+	return err
+}
+
+func (c *client) QueueJobRunResult(ctx context.Context, jobID int64, res sdk.WorkflowRunResult) error {
+	path := fmt.Sprintf("/queue/workflows/%d/run-result", jobID)
+	_, err := c.PostJSON(ctx, path, res, nil)
+	return err
+}
+
+func (c *client) QueueJobReleaseTargets(ctx context.Context, jobID int64) (*sdk.WorkflowNodeJobRunRelease, error) {
+	path := fmt.Sprintf("/queue/workflows/%d/release/targets", jobID)
+	var release sdk.WorkflowNodeJobRunRelease
+	if _, err := c.PostJSON(ctx, path, nil, &release); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+func (c *client) QueueJobReleaseTargetStatus(ctx context.Context, jobID int64, integrationName string, status sdk.WorkflowRunReleaseTargetStatus) error {
+	path := fmt.Sprintf("/queue/workflows/%d/release/targets/%s", jobID, url.PathEscape(integrationName))
+	_, err := c.PostJSON(ctx, path, status, nil)
+	return err
+}
+
+func (c *client) QueueJobDebug(ctx context.Context, jobID int64, hold time.Duration) (*websocket.Conn, error) {
+	path := fmt.Sprintf("/queue/workflows/%d/debug?hold=%d", jobID, int64(hold.Seconds()))
+	return c.openWebsocket(ctx, path)
+}
+
+func (c *client) QueueJobMetrics(ctx context.Context, jobID int64, m sdk.JobMetrics) error {
+	path := fmt.Sprintf("/queue/workflows/%d/metrics", jobID)
+	_, err := c.PostJSON(ctx, path, m, nil)
+	return err
+}
+
 func (c *client) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	status, err := c.PostJSON(ctx, "/queue/workflows/log/service", logs, nil)
 	if status >= 400 {
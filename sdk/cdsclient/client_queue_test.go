@@ -1,10 +1,19 @@
 package cdsclient
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/ovh/cds/sdk"
 )
 
@@ -73,7 +82,7 @@ func Test_shrinkQueue(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := shrinkQueue(tt.args.queue, tt.args.l); !reflect.DeepEqual(got, tt.want) {
+			if got := shrinkQueue(tt.args.queue, tt.args.l, false); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("shrinkQueue() = %v, want %v", got, tt.want)
 			}
 			for _, q := range *tt.args.queue {
@@ -82,3 +91,65 @@ func Test_shrinkQueue(t *testing.T) {
 		})
 	}
 }
+
+func TestQueueStream(t *testing.T) {
+	var mutex sync.Mutex
+	var lastEventIDs []string
+	stop := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/queue/workflows/stream", r.URL.Path)
+		assert.Equal(t, "docker", r.URL.Query().Get("modelType"))
+		mutex.Lock()
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		nb := len(lastEventIDs)
+		mutex.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		write := func(e sdk.WorkflowQueueStreamEvent) {
+			btes, _ := json.Marshal(e)
+			fmt.Fprintf(w, "id: %d\ndata: %s\n\n", e.Cursor, btes)
+			w.(http.Flusher).Flush()
+		}
+		switch nb {
+		case 1:
+			// The first stream is lost after one job, the client should reconnect with the cursor
+			write(sdk.WorkflowQueueStreamEvent{Type: sdk.WorkflowQueueStreamEventHeartbeat})
+			write(sdk.WorkflowQueueStreamEvent{Type: sdk.WorkflowQueueStreamEventJob, Cursor: 3, Job: &sdk.WorkflowNodeJobRun{ID: 3}})
+		default:
+			write(sdk.WorkflowQueueStreamEvent{Type: sdk.WorkflowQueueStreamEventJob, Cursor: 5, Job: &sdk.WorkflowNodeJobRun{ID: 5}})
+			select {
+			case <-r.Context().Done():
+			case <-stop:
+			}
+		}
+	}))
+	defer server.Close()
+	defer close(stop)
+
+	c := New(Config{Host: server.URL})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	jobs := make(chan sdk.WorkflowNodeJobRun, 10)
+	errs := make(chan error, 10)
+	done := make(chan error)
+	go func() {
+		done <- c.QueueStream(ctx, jobs, errs, time.Hour, "docker", nil)
+	}()
+
+	var ids []int64
+	for j := range jobs {
+		assert.Equal(t, "true", j.Header["SSE"])
+		ids = append(ids, j.ID)
+		if len(ids) == 2 {
+			cancel()
+		}
+	}
+	assert.Equal(t, []int64{3, 5}, ids)
+	assert.Equal(t, context.Canceled, <-done)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Len(t, lastEventIDs, 2)
+	assert.Equal(t, []string{"0", "3"}, lastEventIDs)
+}
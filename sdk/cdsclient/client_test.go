@@ -0,0 +1,51 @@
+package cdsclient
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClientWithOptions(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	// The certificate of the server is not trusted by default
+	c, err := NewHTTPClientWithOptions(time.Second, HTTPClientOptions{})
+	require.NoError(t, err)
+	_, err = c.Get(server.URL)
+	assert.Error(t, err)
+
+	c, err = NewHTTPClientWithOptions(time.Second, HTTPClientOptions{CABundle: caBundle})
+	require.NoError(t, err)
+	resp, err := c.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close() // nolint
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = NewHTTPClientWithOptions(time.Second, HTTPClientOptions{CABundle: "not a certificate"})
+	assert.Error(t, err)
+	_, err = NewHTTPClientWithOptions(time.Second, HTTPClientOptions{ProxyURL: "ftp://proxy.example.com"})
+	assert.Error(t, err)
+}
+
+func TestNewHTTPClientWithOptionsProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	c, err := NewHTTPClientWithOptions(time.Second, HTTPClientOptions{ProxyURL: proxy.URL})
+	require.NoError(t, err)
+	resp, err := c.Get("http://integration.example.com/events")
+	require.NoError(t, err)
+	resp.Body.Close() // nolint
+	assert.Equal(t, "http://integration.example.com/events", proxied)
+}
@@ -2,6 +2,7 @@ package cdsclient
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 
 	"github.com/ovh/cds/sdk"
@@ -70,3 +71,34 @@ func (c *client) UserGetSchema() (sdk.SchemaResponse, error) {
 	}
 	return res, nil
 }
+
+func (c *client) UserDashboardList() ([]sdk.Dashboard, error) {
+	var res []sdk.Dashboard
+	if _, err := c.GetJSON(context.Background(), "/user/dashboard", &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) UserDashboardCreate(d *sdk.Dashboard) error {
+	_, err := c.PostJSON(context.Background(), "/user/dashboard", d, d)
+	return err
+}
+
+func (c *client) UserDashboardUpdate(d *sdk.Dashboard) error {
+	_, err := c.PutJSON(context.Background(), fmt.Sprintf("/user/dashboard/%d", d.ID), d, d)
+	return err
+}
+
+func (c *client) UserDashboardDelete(id int64) error {
+	_, _, _, err := c.Request(context.Background(), "DELETE", fmt.Sprintf("/user/dashboard/%d", id), nil)
+	return err
+}
+
+func (c *client) UserDashboardData(id int64) (sdk.DashboardData, error) {
+	var res sdk.DashboardData
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/user/dashboard/%d/data", id), &res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
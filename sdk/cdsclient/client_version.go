@@ -13,3 +13,11 @@ func (c *client) Version() (*sdk.Version, error) {
 	}
 	return v, nil
 }
+
+func (c *client) APIRoutes() ([]sdk.APIRoute, error) {
+	var routes []sdk.APIRoute
+	if _, err := c.GetJSON(context.Background(), "/route", &routes); err != nil {
+		return nil, err
+	}
+	return routes, nil
+}
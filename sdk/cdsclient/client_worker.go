@@ -2,6 +2,7 @@ package cdsclient
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -19,10 +20,10 @@ func (c *client) WorkerList(ctx context.Context) ([]sdk.Worker, error) {
 	return p, nil
 }
 
-func (c *client) WorkerUnregister(ctx context.Context) error {
+func (c *client) WorkerUnregister(ctx context.Context, shutdown sdk.WorkerShutdown) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
-	if _, err := c.PostJSON(ctx, "/auth/consumer/worker/signout", nil, nil); err != nil {
+	if _, err := c.PostJSON(ctx, "/auth/consumer/worker/signout", shutdown, nil); err != nil {
 		return err
 	}
 	return nil
@@ -38,16 +39,34 @@ func (c *client) WorkerDisable(ctx context.Context, id string) error {
 	return nil
 }
 
-func (c *client) WorkerRefresh(ctx context.Context) error {
+func (c *client) WorkerDrain(ctx context.Context, id string) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
-	url := fmt.Sprintf("/worker/refresh")
+	url := fmt.Sprintf("/worker/%s/drain", id)
 	if _, err := c.PostJSON(ctx, url, nil, nil); err != nil {
 		return err
 	}
 	return nil
 }
 
+func (c *client) WorkerRefresh(ctx context.Context) (sdk.WorkerHeartbeat, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	var hb sdk.WorkerHeartbeat
+	url := fmt.Sprintf("/worker/refresh")
+	res, _, _, err := c.RequestJSON(ctx, http.MethodPost, url, nil, nil)
+	if err != nil {
+		return hb, err
+	}
+	// API older than the drain protocol returns an empty body
+	if len(res) > 0 {
+		if err := json.Unmarshal(res, &hb); err != nil {
+			return hb, sdk.WithStack(err)
+		}
+	}
+	return hb, nil
+}
+
 func (c *client) WorkerRegister(ctx context.Context, authToken string, form sdk.WorkerRegistrationForm) (*sdk.Worker, bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -13,6 +13,7 @@ import (
 type WorkerModelFilter struct {
 	State  string
 	Binary string
+	OSArch string
 }
 
 // WorkerModelBook books a worker model for register, used by hatcheries.
@@ -49,6 +50,9 @@ func (c *client) WorkerModels(filter *WorkerModelFilter) ([]sdk.Model, error) {
 				if filter.Binary != "" {
 					q.Add("binary", url.QueryEscape(filter.Binary))
 				}
+				if filter.OSArch != "" {
+					q.Add("os-architecture", url.QueryEscape(filter.OSArch))
+				}
 				req.URL.RawQuery = q.Encode()
 			},
 		}
@@ -122,3 +126,17 @@ func (c *client) WorkerModelDelete(groupName, name string) error {
 	_, errDelete := c.DeleteJSON(context.Background(), uri, nil)
 	return errDelete
 }
+
+func (c *client) WorkerModelHealth(groupName, name string) (sdk.ModelHealth, error) {
+	uri := fmt.Sprintf("/worker/model/%s/%s/health", groupName, name)
+	var health sdk.ModelHealth
+	_, err := c.GetJSON(context.Background(), uri, &health)
+	return health, err
+}
+
+func (c *client) WorkerModelQuarantineRelease(groupName, name string) (sdk.Model, error) {
+	uri := fmt.Sprintf("/worker/model/%s/%s/quarantine", groupName, name)
+	var model sdk.Model
+	_, err := c.DeleteJSON(context.Background(), uri, &model)
+	return model, err
+}
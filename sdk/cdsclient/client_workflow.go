@@ -12,6 +12,8 @@ import (
 	"net/url"
 	"time"
 
+	"golang.org/x/net/websocket"
+
 	"github.com/ovh/cds/sdk"
 )
 
@@ -80,6 +82,76 @@ func (c *client) WorkflowGroupDelete(projectKey, name, groupName string) error {
 	return nil
 }
 
+func (c *client) WorkflowWebhookList(projectKey, name string) ([]sdk.WorkflowWebhook, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/webhooks", projectKey, name)
+	var ws []sdk.WorkflowWebhook
+	if _, err := c.GetJSON(context.Background(), url, &ws); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+func (c *client) WorkflowWebhookAdd(projectKey, name string, w *sdk.WorkflowWebhook) error {
+	url := fmt.Sprintf("/project/%s/workflows/%s/webhooks", projectKey, name)
+	if _, err := c.PostJSON(context.Background(), url, w, w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *client) WorkflowWebhookUpdate(projectKey, name string, w *sdk.WorkflowWebhook) error {
+	url := fmt.Sprintf("/project/%s/workflows/%s/webhooks/%d", projectKey, name, w.ID)
+	if _, err := c.PutJSON(context.Background(), url, w, w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *client) WorkflowWebhookDelete(projectKey, name string, id int64) error {
+	url := fmt.Sprintf("/project/%s/workflows/%s/webhooks/%d", projectKey, name, id)
+	if _, err := c.DeleteJSON(context.Background(), url, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *client) WorkflowWebhookDeliveries(projectKey, name, status string, limit int) ([]sdk.WorkflowWebhookDelivery, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/webhooks/deliveries?status=%s&limit=%d", projectKey, name, status, limit)
+	var ds []sdk.WorkflowWebhookDelivery
+	if _, err := c.GetJSON(context.Background(), url, &ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+func (c *client) WorkflowWebhookDeliveryReplay(projectKey, name string, id int64) error {
+	url := fmt.Sprintf("/project/%s/workflows/%s/webhooks/deliveries/%d/replay", projectKey, name, id)
+	if _, err := c.PostJSON(context.Background(), url, nil, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *client) WorkflowWebhookDeadLetter(projectKey, name string, limit int) ([]sdk.WorkflowWebhookDelivery, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/webhooks/deadletter?limit=%d", projectKey, name, limit)
+	var ds []sdk.WorkflowWebhookDelivery
+	if _, err := c.GetJSON(context.Background(), url, &ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+func (c *client) WorkflowWebhookDeadLetterReplay(projectKey, name string) (int64, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/webhooks/deadletter/replay", projectKey, name)
+	var res struct {
+		Replayed int64 `json:"replayed"`
+	}
+	if _, err := c.PostJSON(context.Background(), url, nil, &res); err != nil {
+		return 0, err
+	}
+	return res.Replayed, nil
+}
+
 func (c *client) WorkflowRunGet(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error) {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d", projectKey, workflowName, number)
 	run := sdk.WorkflowRun{}
@@ -146,6 +218,39 @@ func (c *client) WorkflowDelete(projectKey string, workflowName string) error {
 	return err
 }
 
+func (c *client) WorkflowVersionList(projectKey string, workflowName string) ([]sdk.WorkflowVersion, error) {
+	versions := []sdk.WorkflowVersion{}
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/project/%s/workflows/%s/versions", projectKey, workflowName), &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (c *client) WorkflowVersionGet(projectKey string, workflowName string, version int64) (*sdk.WorkflowVersion, error) {
+	var v sdk.WorkflowVersion
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/project/%s/workflows/%s/versions/%d", projectKey, workflowName, version), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (c *client) WorkflowVersionRollback(projectKey string, workflowName string, version int64) (*sdk.Workflow, error) {
+	var wf sdk.Workflow
+	if _, err := c.PostJSON(context.Background(), fmt.Sprintf("/project/%s/workflows/%s/versions/%d/rollback", projectKey, workflowName, version), nil, &wf); err != nil {
+		return nil, err
+	}
+	return &wf, nil
+}
+
+func (c *client) WorkflowDiff(projectKey string, workflowName string, req sdk.WorkflowDiffRequest) (*sdk.WorkflowDiff, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/diff", projectKey, workflowName)
+	var diff sdk.WorkflowDiff
+	if _, err := c.PostJSON(context.Background(), url, req, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
 func (c *client) WorkflowRunArtifacts(projectKey string, workflowName string, number int64) ([]sdk.WorkflowNodeRunArtifact, error) {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/artifacts", projectKey, workflowName, number)
 	arts := []sdk.WorkflowNodeRunArtifact{}
@@ -155,6 +260,119 @@ func (c *client) WorkflowRunArtifacts(projectKey string, workflowName string, nu
 	return arts, nil
 }
 
+func (c *client) WorkflowRunCompare(projectKey string, workflowName string, number int64, mods ...RequestModifier) (*sdk.WorkflowRunDurationComparison, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/compare", projectKey, workflowName, number)
+	var comparison sdk.WorkflowRunDurationComparison
+	if _, err := c.GetJSON(context.Background(), url, &comparison, mods...); err != nil {
+		return nil, err
+	}
+	return &comparison, nil
+}
+
+func (c *client) WorkflowArtifactPin(projectKey string, workflowName string, artifactID int64, pinned bool) (*sdk.WorkflowNodeRunArtifact, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/artifact/%d/pin", projectKey, workflowName, artifactID)
+	var art sdk.WorkflowNodeRunArtifact
+	if pinned {
+		if _, err := c.PostJSON(context.Background(), url, nil, &art); err != nil {
+			return nil, err
+		}
+		return &art, nil
+	}
+	if _, err := c.DeleteJSON(context.Background(), url, &art); err != nil {
+		return nil, err
+	}
+	return &art, nil
+}
+
+func (c *client) WorkflowRunAnnotationList(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunAnnotation, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/annotations", projectKey, workflowName, number)
+	annotations := []sdk.WorkflowRunAnnotation{}
+	if _, err := c.GetJSON(context.Background(), url, &annotations); err != nil {
+		return nil, err
+	}
+	return annotations, nil
+}
+
+func (c *client) WorkflowRunAnnotationAdd(projectKey string, workflowName string, number int64, a sdk.WorkflowRunAnnotation) error {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/annotations", projectKey, workflowName, number)
+	_, err := c.PostJSON(context.Background(), url, a, nil)
+	return err
+}
+
+func (c *client) WorkflowRunAnnotationDelete(projectKey string, workflowName string, number int64, key string) error {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/annotations/%s", projectKey, workflowName, number, url.PathEscape(key))
+	_, err := c.DeleteJSON(context.Background(), path, nil)
+	return err
+}
+
+func (c *client) WorkflowRunResultList(projectKey string, workflowName string, number int64, types ...string) ([]sdk.WorkflowRunResult, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/results", projectKey, workflowName, number)
+	if len(types) > 0 {
+		path += "?" + url.Values{"type": types}.Encode()
+	}
+	results := []sdk.WorkflowRunResult{}
+	if _, err := c.GetJSON(context.Background(), path, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (c *client) WorkflowRunSecretUsageList(projectKey string, workflowName string, number int64, nodeNames ...string) ([]sdk.WorkflowRunSecretUsage, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/secrets/usage", projectKey, workflowName, number)
+	if len(nodeNames) > 0 {
+		path += "?" + url.Values{"node": nodeNames}.Encode()
+	}
+	usages := []sdk.WorkflowRunSecretUsage{}
+	if _, err := c.GetJSON(context.Background(), path, &usages); err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
+
+func (c *client) WorkflowRunReleaseTargetList(projectKey string, workflowName string, number int64, nodeNames ...string) ([]sdk.WorkflowRunReleaseTarget, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/release/targets", projectKey, workflowName, number)
+	if len(nodeNames) > 0 {
+		path += "?" + url.Values{"node": nodeNames}.Encode()
+	}
+	targets := []sdk.WorkflowRunReleaseTarget{}
+	if _, err := c.GetJSON(context.Background(), path, &targets); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+func (c *client) WorkflowRunJobFingerprintList(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunJobFingerprint, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/fingerprints", projectKey, workflowName, number)
+	fingerprints := []sdk.WorkflowRunJobFingerprint{}
+	if _, err := c.GetJSON(context.Background(), path, &fingerprints); err != nil {
+		return nil, err
+	}
+	return fingerprints, nil
+}
+
+func (c *client) WorkflowRunJobFingerprintCompare(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunJobFingerprintComparison, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/fingerprints/compare", projectKey, workflowName, number)
+	comparisons := []sdk.WorkflowRunJobFingerprintComparison{}
+	if _, err := c.GetJSON(context.Background(), path, &comparisons); err != nil {
+		return nil, err
+	}
+	return comparisons, nil
+}
+
+func (c *client) WorkflowNodeRunJobDebug(projectKey string, workflowName string, number, nodeRunID, jobID int64) (*websocket.Conn, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/job/%d/debug", projectKey, workflowName, number, nodeRunID, jobID)
+	return c.openWebsocket(context.Background(), path)
+}
+
+func (c *client) WorkflowRunExport(projectKey string, workflowName string, number int64) ([]byte, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/export", projectKey, workflowName, number)
+	body, _, _, err := c.Request(context.Background(), "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
 func (c *client) WorkflowNodeRun(projectKey string, workflowName string, number int64, nodeRunID int64) (*sdk.WorkflowNodeRun, error) {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d", projectKey, workflowName, number, nodeRunID)
 	run := sdk.WorkflowNodeRun{}
@@ -173,6 +391,24 @@ func (c *client) WorkflowRunNumberGet(projectKey string, workflowName string) (*
 	return &runNumber, nil
 }
 
+func (c *client) WorkflowRunForm(projectKey string, workflowName string) (*sdk.WorkflowRunForm, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/form", projectKey, workflowName)
+	var form sdk.WorkflowRunForm
+	if _, err := c.GetJSON(context.Background(), url, &form); err != nil {
+		return nil, err
+	}
+	return &form, nil
+}
+
+func (c *client) WorkflowRunExplain(projectKey string, workflowName string, opts sdk.WorkflowRunPostHandlerOption) (*sdk.WorkflowRunExplain, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/explain", projectKey, workflowName)
+	var explain sdk.WorkflowRunExplain
+	if _, err := c.PostJSON(context.Background(), url, opts, &explain); err != nil {
+		return nil, err
+	}
+	return &explain, nil
+}
+
 func (c *client) WorkflowRunNumberSet(projectKey string, workflowName string, number int64) error {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/num", projectKey, workflowName)
 	runNumber := sdk.WorkflowRunNumber{Num: number}
@@ -214,6 +450,42 @@ func (c *client) WorkflowNodeRunArtifactDownload(projectKey string, workflowName
 	return err
 }
 
+// WorkflowCostReport returns the cost report of a workflow, or of one of its runs if number is given.
+func (c *client) WorkflowCostReport(projectKey string, workflowName string, number int64, mods ...RequestModifier) (sdk.WorkflowCostReport, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/cost", projectKey, workflowName)
+	if number > 0 {
+		path = fmt.Sprintf("/project/%s/workflows/%s/runs/%d/cost", projectKey, workflowName, number)
+	}
+	var r sdk.WorkflowCostReport
+	if _, err := c.GetJSON(context.Background(), path, &r, mods...); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func (c *client) WorkflowNodeRunJobLogsDownload(projectKey string, workflowName string, number int64, nodeRunID, job int64, filter sdk.JobLogFilter, gzip bool, w io.Writer) error {
+	params := filter.QueryValues()
+	if gzip {
+		params.Set("gzip", "true")
+	}
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/job/%d/log/download?%s", projectKey, workflowName, number, nodeRunID, job, params.Encode())
+	reader, _, code, err := c.Stream(context.Background(), "GET", url, nil, true)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if code >= 400 {
+		body, _ := ioutil.ReadAll(reader)
+		if err := sdk.DecodeError(body); err != nil {
+			return err
+		}
+		return fmt.Errorf("HTTP Code %d", code)
+	}
+
+	_, err = io.Copy(w, reader)
+	return err
+}
+
 func (c *client) WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/release", projectKey, workflowName, runNumber, nodeRunID)
 	btes, _ := json.Marshal(release)
@@ -272,7 +544,8 @@ func (c *client) WorkflowRunFromManual(projectKey string, workflowName string, m
 		content.FromNodeIDs = []int64{fromNodeID}
 	}
 	run := &sdk.WorkflowRun{}
-	code, err := c.PostJSON(context.Background(), url, &content, run)
+	// The same key is sent on each retry of the request so that the workflow is run only once
+	code, err := c.PostJSON(context.Background(), url, &content, run, SetHeader(sdk.IdempotencyKeyHeader, sdk.UUID()))
 	if err != nil {
 		return nil, err
 	}
@@ -372,7 +645,7 @@ func (c *client) workflowCachePushIndirectUploadPost(url string, tarContent io.R
 		req.ContentLength = int64(size)
 
 		var resp *http.Response
-		resp, globalErr = http.DefaultClient.Do(req)
+		resp, globalErr = DefaultHTTPClient.Do(req)
 
 		if globalErr == nil {
 			defer resp.Body.Close()
@@ -450,3 +723,25 @@ func (c *client) WorkflowTemplateInstanceGet(projectKey, workflowName string) (*
 
 	return &i, nil
 }
+
+func (c *client) WorkflowTemplateInstanceDrift(projectKey, workflowName string) (*sdk.WorkflowTemplateDrift, error) {
+	url := fmt.Sprintf("/project/%s/workflow/%s/templateInstance/drift", projectKey, workflowName)
+
+	var d sdk.WorkflowTemplateDrift
+	if _, err := c.GetJSON(context.Background(), url, &d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+func (c *client) WorkflowTemplateInstanceReapply(projectKey, workflowName string, keepChanges bool) ([]string, error) {
+	url := fmt.Sprintf("/project/%s/workflow/%s/templateInstance/reapply?keepChanges=%t", projectKey, workflowName, keepChanges)
+
+	var msgs []string
+	if _, err := c.PostJSON(context.Background(), url, nil, &msgs); err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
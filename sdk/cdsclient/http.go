@@ -34,6 +34,14 @@ const (
 	ResponseEtagHeader = "Etag"
 	// ResponseProcessTimeHeader is used as HTTP header
 	ResponseProcessTimeHeader = "X-Api-Process-Time"
+	// ResponseQueueScheduledHeader is set by the API when the returned queue is already in scheduling order
+	ResponseQueueScheduledHeader = "X-Api-Queue-Scheduled"
+	// ResponseDeprecationHeader is set by the API when the requested route is deprecated
+	ResponseDeprecationHeader = "Deprecation"
+	// ResponseSunsetHeader is set by the API to the date at which the requested deprecated route will be removed
+	ResponseSunsetHeader = "Sunset"
+	// ResponseLinkHeader is set by the API to the route that should be used instead of the requested deprecated route
+	ResponseLinkHeader = "Link"
 )
 
 // RequestModifier is used to modify behavior of Request and Steam functions
@@ -60,6 +68,11 @@ func WithQueryParameter(key, value string) RequestModifier {
 	}
 }
 
+// WithFields selects the fields returned by the API in the JSON response, ex: WithFields("num", "status", "nodes.*.status")
+func WithFields(fields ...string) RequestModifier {
+	return WithQueryParameter("fields", strings.Join(fields, ","))
+}
+
 // PostJSON post the *in* struct as json. If set, it unmarshalls the response to *out*
 func (c *client) PostJSON(ctx context.Context, path string, in interface{}, out interface{}, mods ...RequestModifier) (int, error) {
 	_, _, code, err := c.RequestJSON(ctx, http.MethodPost, path, in, out, mods...)
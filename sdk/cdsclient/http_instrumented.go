@@ -0,0 +1,70 @@
+package cdsclient
+
+import (
+	"net/http"
+
+	"go.opencensus.io/plugin/ochttp"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/ovh/cds/sdk/tracingutils"
+)
+
+// DefaultHTTPClient is a shared instrumented HTTP client without timeout, to use instead of http.DefaultClient. The
+// timeout of a request should be set with its context.
+var DefaultHTTPClient = &http.Client{Transport: NewInstrumentedTransport(http.DefaultTransport)}
+
+// HTTPClientViews are the views of the metrics recorded by the instrumented HTTP clients: count, latency and sizes of
+// the requests by destination host, method and status. They should be registered by the services that export metrics.
+var HTTPClientViews = []*view.View{
+	{
+		Name:        "cds/http/client/completed_count",
+		Description: "Count of outbound HTTP requests by destination",
+		Measure:     ochttp.ClientRoundtripLatency,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{ochttp.KeyClientHost, ochttp.KeyClientMethod, ochttp.KeyClientStatus},
+	},
+	{
+		Name:        "cds/http/client/roundtrip_latency",
+		Description: "Latency of outbound HTTP requests by destination in milliseconds",
+		Measure:     ochttp.ClientRoundtripLatency,
+		Aggregation: ochttp.DefaultLatencyDistribution,
+		TagKeys:     []tag.Key{ochttp.KeyClientHost, ochttp.KeyClientMethod, ochttp.KeyClientStatus},
+	},
+	{
+		Name:        "cds/http/client/sent_bytes",
+		Description: "Size of the bodies of outbound HTTP requests by destination",
+		Measure:     ochttp.ClientSentBytes,
+		Aggregation: ochttp.DefaultSizeDistribution,
+		TagKeys:     []tag.Key{ochttp.KeyClientHost, ochttp.KeyClientMethod},
+	},
+	{
+		Name:        "cds/http/client/received_bytes",
+		Description: "Size of the bodies of outbound HTTP responses by destination",
+		Measure:     ochttp.ClientReceivedBytes,
+		Aggregation: ochttp.DefaultSizeDistribution,
+		TagKeys:     []tag.Key{ochttp.KeyClientHost, ochttp.KeyClientMethod},
+	},
+}
+
+// NewInstrumentedTransport wraps given transport to start a client span for each request, propagate the trace to the
+// destination with B3 headers and record the metrics of the request.
+func NewInstrumentedTransport(base http.RoundTripper) http.RoundTripper {
+	return &ochttp.Transport{
+		Base:        base,
+		Propagation: tracingutils.DefaultFormat,
+		FormatSpanName: func(req *http.Request) string {
+			return req.Method + " " + req.URL.Host
+		},
+	}
+}
+
+// baseTransport returns the *http.Transport used by given round tripper, nil if it's not an instrumented or an http
+// transport.
+func baseTransport(rt http.RoundTripper) *http.Transport {
+	if t, ok := rt.(*ochttp.Transport); ok {
+		rt = t.Base
+	}
+	t, _ := rt.(*http.Transport)
+	return t
+}
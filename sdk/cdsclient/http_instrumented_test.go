@@ -0,0 +1,46 @@
+package cdsclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+
+	"github.com/ovh/cds/sdk/tracingutils"
+)
+
+func TestNewHTTPClientPropagatesTrace(t *testing.T) {
+	var traceID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceID = r.Header.Get(tracingutils.TraceIDHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	ctx, span := trace.StartSpan(context.Background(), "test", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := NewHTTPClient(time.Second, false).Do(req.WithContext(ctx))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, span.SpanContext().TraceID.String(), traceID)
+}
+
+func TestBaseTransport(t *testing.T) {
+	c := NewHTTPClient(time.Second, true)
+	tr := baseTransport(c.Transport)
+	require.NotNil(t, tr)
+	assert.True(t, tr.TLSClientConfig.InsecureSkipVerify)
+
+	assert.Equal(t, http.DefaultTransport, baseTransport(http.DefaultTransport))
+	assert.Nil(t, baseTransport(nil))
+}
@@ -3,12 +3,14 @@ package cdsclient
 import (
 	"archive/tar"
 	"context"
+	"encoding/base64"
 	"io"
 	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/sguiheux/go-coverage"
+	"golang.org/x/net/websocket"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/venom"
@@ -46,6 +48,12 @@ type Admin interface {
 	AdminCDSMigrationList() ([]sdk.Migration, error)
 	AdminCDSMigrationCancel(id int64) error
 	AdminCDSMigrationReset(id int64) error
+	AdminSCIMAudit(limit int) ([]sdk.SCIMAudit, error)
+	AdminCacheKeys(projectKey, workflowName string) ([]sdk.CacheKey, error)
+	AdminCacheInvalidate(projectKey, workflowName string) error
+	AdminQueueJobCancel(id int64) error
+	AdminQueueJobRequeue(id int64) error
+	AdminQueueJobDiagnostic(id int64) (*sdk.QueueJobDiagnostic, error)
 	Services() ([]sdk.Service, error)
 	ServicesByName(name string) (*sdk.Service, error)
 	ServiceDelete(name string) error
@@ -66,6 +74,7 @@ type ExportImportInterface interface {
 	WorkflowExport(projectKey, name string, mods ...RequestModifier) ([]byte, error)
 	WorkflowPull(projectKey, name string, mods ...RequestModifier) (*tar.Reader, error)
 	WorkflowImport(projectKey string, content io.Reader, mods ...RequestModifier) ([]string, error)
+	WorkflowImportIntegrationsCheck(projectKey string, content io.Reader, mods ...RequestModifier) (*sdk.WorkflowImportIntegrationReport, error)
 	WorkerModelExport(groupName, name string, mods ...RequestModifier) ([]byte, error)
 	WorkerModelImport(content io.Reader, mods ...RequestModifier) (*sdk.Model, error)
 	WorkflowPush(projectKey string, tarContent io.Reader, mods ...RequestModifier) ([]string, *tar.Reader, error)
@@ -110,6 +119,7 @@ type ApplicationVariableClient interface {
 	ApplicationVariableDelete(projectKey string, appName string, varName string) error
 	ApplicationVariableGet(projectKey string, appName string, varName string) (*sdk.Variable, error)
 	ApplicationVariableUpdate(projectKey string, appName string, variable *sdk.Variable) error
+	ApplicationVariablesBatch(projectKey, appName string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error)
 }
 
 // EnvironmentClient exposes environment related functions
@@ -138,6 +148,7 @@ type EnvironmentVariableClient interface {
 	EnvironmentVariableDelete(projectKey string, envName string, varName string) error
 	EnvironmentVariableGet(projectKey string, envName string, varName string) (*sdk.Variable, error)
 	EnvironmentVariableUpdate(projectKey string, envName string, variable *sdk.Variable) error
+	EnvironmentVariablesBatch(projectKey, envName string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error)
 }
 
 // EventsClient listen SSE Events from CDS API
@@ -175,6 +186,10 @@ type GroupClient interface {
 	GroupMemberAdd(groupName string, member *sdk.GroupMember) (sdk.Group, error)
 	GroupMemberEdit(groupName string, member *sdk.GroupMember) (sdk.Group, error)
 	GroupMemberRemove(groupName, username string) error
+	GroupIntegrationList(groupName string) ([]sdk.GroupIntegration, error)
+	GroupIntegrationCreate(groupName string, gi *sdk.GroupIntegration) error
+	GroupIntegrationUpdate(groupName string, gi *sdk.GroupIntegration) error
+	GroupIntegrationDelete(groupName, name string) error
 }
 
 // BroadcastClient expose all function for CDS Broadcasts
@@ -196,11 +211,16 @@ type PipelineClient interface {
 // MaintenanceClient manage maintenance mode on CDS
 type MaintenanceClient interface {
 	Maintenance(enable bool, hooks bool) error
+	MaintenanceWindowList() ([]sdk.MaintenanceWindow, error)
+	MaintenanceWindowCreate(w *sdk.MaintenanceWindow) error
+	MaintenanceWindowDelete(id int64) error
+	MaintenanceWindowOverride(projectKey string, workflowName string, number int64, reason string) error
 }
 
 // ProjectClient exposes project related functions
 type ProjectClient interface {
 	ProjectCreate(proj *sdk.Project) error
+	ProjectBootstrap(req sdk.ProjectBootstrap) (*sdk.ProjectBootstrapResult, error)
 	ProjectDelete(projectKey string) error
 	ProjectGroupAdd(projectKey, groupName string, permission int, projectOnly bool) error
 	ProjectGroupDelete(projectKey, groupName string) error
@@ -216,6 +236,15 @@ type ProjectClient interface {
 	ProjectIntegrationDelete(projectKey string, integrationName string) error
 	ProjectRepositoryManagerList(projectKey string) ([]sdk.ProjectVCSServer, error)
 	ProjectRepositoryManagerDelete(projectKey string, repoManagerName string, force bool) error
+	ProjectQuotaGet(projectKey string) (sdk.ProjectQuotaStatus, error)
+	ProjectQuotaUpdate(projectKey string, quota *sdk.ProjectQuota) error
+	ProjectCostReport(projectKey string, mods ...RequestModifier) (sdk.WorkflowCostReport, error)
+	ProjectArtifactRetentionGet(projectKey string) (sdk.ProjectArtifactRetention, error)
+	ProjectArtifactRetentionUpdate(projectKey string, policy *sdk.ProjectArtifactRetention) error
+	ProjectLint(projectKey string, files map[string][]byte) ([]sdk.LintDiagnostic, error)
+	ProjectWebHookSecretList(projectKey string, clearSecret bool) ([]sdk.ProjectWebHookSecret, error)
+	ProjectWebHookSecretRotate(projectKey string, overlap time.Duration) (sdk.ProjectWebHookSecret, error)
+	ProjectWebHookSecretDelete(projectKey string) error
 }
 
 // ProjectKeysClient exposes project keys related functions
@@ -223,6 +252,7 @@ type ProjectKeysClient interface {
 	ProjectKeysList(projectKey string) ([]sdk.ProjectKey, error)
 	ProjectKeyCreate(projectKey string, key *sdk.ProjectKey) error
 	ProjectKeysDelete(projectKey string, keyProjectName string) error
+	ProjectBuiltinPublicKey(projectKey string) (sdk.Key, error)
 }
 
 // ProjectVariablesClient exposes project variables related functions
@@ -232,6 +262,7 @@ type ProjectVariablesClient interface {
 	ProjectVariableDelete(projectKey string, varName string) error
 	ProjectVariableGet(projectKey string, varName string) (*sdk.Variable, error)
 	ProjectVariableUpdate(projectKey string, variable *sdk.Variable) error
+	ProjectVariablesBatch(projectKey string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error)
 	VariableEncrypt(projectKey string, varName string, content string) (*sdk.Variable, error)
 }
 
@@ -240,9 +271,11 @@ type QueueClient interface {
 	QueueWorkflowNodeJobRun(status ...string) ([]sdk.WorkflowNodeJobRun, error)
 	QueueCountWorkflowNodeJobRun(since *time.Time, until *time.Time, modelType string, ratioService *int) (sdk.WorkflowNodeJobRunCount, error)
 	QueuePolling(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, delay time.Duration, modelType string, ratioService *int) error
+	QueueStream(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, resync time.Duration, modelType string, ratioService *int) error
 	QueueTakeJob(ctx context.Context, job sdk.WorkflowNodeJobRun) (*sdk.WorkflowNodeJobRunData, error)
 	QueueJobBook(ctx context.Context, id int64) error
 	QueueJobRelease(ctx context.Context, id int64) error
+	QueueJobInfraError(ctx context.Context, id int64, infraErr sdk.JobInfraError) error
 	QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error)
 	QueueJobSendSpawnInfo(ctx context.Context, id int64, in []sdk.SpawnInfo) error
 	QueueSendCoverage(ctx context.Context, id int64, report coverage.Report) error
@@ -254,6 +287,12 @@ type QueueClient interface {
 	QueueArtifactUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, time.Duration, error)
 	QueueStaticFilesUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, name, entrypoint, staticKey string, tarContent io.Reader) (string, bool, time.Duration, error)
 	QueueJobTag(ctx context.Context, jobID int64, tags []sdk.WorkflowRunTag) error
+	QueueJobAnnotation(ctx context.Context, jobID int64, a sdk.WorkflowRunAnnotation) error
+	QueueJobRunResult(ctx context.Context, jobID int64, res sdk.WorkflowRunResult) error
+	QueueJobReleaseTargets(ctx context.Context, jobID int64) (*sdk.WorkflowNodeJobRunRelease, error)
+	QueueJobReleaseTargetStatus(ctx context.Context, jobID int64, integrationName string, status sdk.WorkflowRunReleaseTargetStatus) error
+	QueueJobDebug(ctx context.Context, jobID int64, hold time.Duration) (*websocket.Conn, error)
+	QueueJobMetrics(ctx context.Context, jobID int64, metrics sdk.JobMetrics) error
 	QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error
 }
 
@@ -265,18 +304,26 @@ type UserClient interface {
 	UserGetGroups(username string) (map[string][]sdk.Group, error)
 	UpdateFavorite(params sdk.FavoriteParams) (interface{}, error)
 	UserGetSchema() (sdk.SchemaResponse, error)
+	UserDashboardList() ([]sdk.Dashboard, error)
+	UserDashboardCreate(d *sdk.Dashboard) error
+	UserDashboardUpdate(d *sdk.Dashboard) error
+	UserDashboardDelete(id int64) error
+	UserDashboardData(id int64) (sdk.DashboardData, error)
 }
 
 // WorkerClient exposes workers functions
 type WorkerClient interface {
 	WorkerModelBook(groupName, name string) error
 	WorkerList(ctx context.Context) ([]sdk.Worker, error)
-	WorkerRefresh(ctx context.Context) error
-	WorkerUnregister(ctx context.Context) error
+	WorkerRefresh(ctx context.Context) (sdk.WorkerHeartbeat, error)
+	WorkerUnregister(ctx context.Context, shutdown sdk.WorkerShutdown) error
 	WorkerDisable(ctx context.Context, id string) error
+	WorkerDrain(ctx context.Context, id string) error
 	WorkerModelAdd(name, modelType, patternName string, dockerModel *sdk.ModelDocker, vmModel *sdk.ModelVirtualMachine, groupID int64) (sdk.Model, error)
 	WorkerModel(groupName, name string) (sdk.Model, error)
 	WorkerModelDelete(groupName, name string) error
+	WorkerModelHealth(groupName, name string) (sdk.ModelHealth, error)
+	WorkerModelQuarantineRelease(groupName, name string) (sdk.Model, error)
 	WorkerModelSpawnError(groupName, name string, info sdk.SpawnErrorForm) error
 	WorkerModels(*WorkerModelFilter) ([]sdk.Model, error)
 	WorkerModelsEnabled() ([]sdk.Model, error)
@@ -296,18 +343,45 @@ type WorkflowClient interface {
 	WorkflowGet(projectKey, name string, opts ...RequestModifier) (*sdk.Workflow, error)
 	WorkflowUpdate(projectKey, name string, wf *sdk.Workflow) error
 	WorkflowDelete(projectKey string, workflowName string) error
+	WorkflowDiff(projectKey string, workflowName string, req sdk.WorkflowDiffRequest) (*sdk.WorkflowDiff, error)
+	WorkflowVersionList(projectKey string, workflowName string) ([]sdk.WorkflowVersion, error)
+	WorkflowVersionGet(projectKey string, workflowName string, version int64) (*sdk.WorkflowVersion, error)
+	WorkflowVersionRollback(projectKey string, workflowName string, version int64) (*sdk.Workflow, error)
 	WorkflowLabelAdd(projectKey, name, labelName string) error
 	WorkflowLabelDelete(projectKey, name string, labelID int64) error
 	WorkflowGroupAdd(projectKey, name, groupName string, permission int) error
 	WorkflowGroupDelete(projectKey, name, groupName string) error
+	WorkflowWebhookList(projectKey, name string) ([]sdk.WorkflowWebhook, error)
+	WorkflowWebhookAdd(projectKey, name string, w *sdk.WorkflowWebhook) error
+	WorkflowWebhookUpdate(projectKey, name string, w *sdk.WorkflowWebhook) error
+	WorkflowWebhookDelete(projectKey, name string, id int64) error
+	WorkflowWebhookDeliveries(projectKey, name, status string, limit int) ([]sdk.WorkflowWebhookDelivery, error)
+	WorkflowWebhookDeliveryReplay(projectKey, name string, id int64) error
+	WorkflowWebhookDeadLetter(projectKey, name string, limit int) ([]sdk.WorkflowWebhookDelivery, error)
+	WorkflowWebhookDeadLetterReplay(projectKey, name string) (int64, error)
 	WorkflowRunGet(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
 	WorkflowRunsDeleteByBranch(projectKey string, workflowName string, branch string) error
 	WorkflowRunResync(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
 	WorkflowRunSearch(projectKey string, offset, limit int64, filter ...Filter) ([]sdk.WorkflowRun, error)
 	WorkflowRunList(projectKey string, workflowName string, offset, limit int64) ([]sdk.WorkflowRun, error)
 	WorkflowRunArtifacts(projectKey string, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error)
+	WorkflowRunCompare(projectKey string, workflowName string, number int64, mods ...RequestModifier) (*sdk.WorkflowRunDurationComparison, error)
+	WorkflowArtifactPin(projectKey string, workflowName string, artifactID int64, pinned bool) (*sdk.WorkflowNodeRunArtifact, error)
+	WorkflowRunAnnotationList(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunAnnotation, error)
+	WorkflowRunAnnotationAdd(projectKey string, workflowName string, number int64, a sdk.WorkflowRunAnnotation) error
+	WorkflowRunAnnotationDelete(projectKey string, workflowName string, number int64, key string) error
+	WorkflowRunResultList(projectKey string, workflowName string, number int64, types ...string) ([]sdk.WorkflowRunResult, error)
+	WorkflowRunSecretUsageList(projectKey string, workflowName string, number int64, nodeNames ...string) ([]sdk.WorkflowRunSecretUsage, error)
+	WorkflowRunReleaseTargetList(projectKey string, workflowName string, number int64, nodeNames ...string) ([]sdk.WorkflowRunReleaseTarget, error)
+	WorkflowRunJobFingerprintList(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunJobFingerprint, error)
+	WorkflowRunJobFingerprintCompare(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunJobFingerprintComparison, error)
+	WorkflowNodeRunJobDebug(projectKey string, workflowName string, number, nodeRunID, jobID int64) (*websocket.Conn, error)
+	WorkflowRunExport(projectKey string, workflowName string, number int64) ([]byte, error)
+	WorkflowCostReport(projectKey string, workflowName string, number int64, mods ...RequestModifier) (sdk.WorkflowCostReport, error)
 	WorkflowRunFromHook(projectKey string, workflowName string, hook sdk.WorkflowNodeRunHookEvent) (*sdk.WorkflowRun, error)
 	WorkflowRunFromManual(projectKey string, workflowName string, manual sdk.WorkflowNodeRunManual, number, fromNodeID int64) (*sdk.WorkflowRun, error)
+	WorkflowRunForm(projectKey string, workflowName string) (*sdk.WorkflowRunForm, error)
+	WorkflowRunExplain(projectKey string, workflowName string, opts sdk.WorkflowRunPostHandlerOption) (*sdk.WorkflowRunExplain, error)
 	WorkflowRunNumberGet(projectKey string, workflowName string) (*sdk.WorkflowRunNumber, error)
 	WorkflowRunNumberSet(projectKey string, workflowName string, number int64) error
 	WorkflowStop(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
@@ -315,11 +389,14 @@ type WorkflowClient interface {
 	WorkflowNodeRun(projectKey string, name string, number int64, nodeRunID int64) (*sdk.WorkflowNodeRun, error)
 	WorkflowNodeRunArtifactDownload(projectKey string, name string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error
 	WorkflowNodeRunJobStep(projectKey string, workflowName string, number int64, nodeRunID, job int64, step int) (*sdk.BuildState, error)
+	WorkflowNodeRunJobLogsDownload(projectKey string, workflowName string, number int64, nodeRunID, job int64, filter sdk.JobLogFilter, gzip bool, w io.Writer) error
 	WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error
 	WorkflowAllHooksList() ([]sdk.NodeHook, error)
 	WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error
 	WorkflowCachePull(projectKey, integrationName, ref string) (io.Reader, error)
 	WorkflowTemplateInstanceGet(projectKey, workflowName string) (*sdk.WorkflowTemplateInstance, error)
+	WorkflowTemplateInstanceDrift(projectKey, workflowName string) (*sdk.WorkflowTemplateDrift, error)
+	WorkflowTemplateInstanceReapply(projectKey, workflowName string, keepChanges bool) ([]string, error)
 	WorkflowTransformAsCode(projectKey, workflowName string) (*sdk.Operation, error)
 	WorkflowTransformAsCodeFollow(projectKey, workflowName string, ope *sdk.Operation) error
 }
@@ -339,6 +416,8 @@ type IntegrationClient interface {
 	IntegrationModelAdd(m *sdk.IntegrationModel) error
 	IntegrationModelUpdate(m *sdk.IntegrationModel) error
 	IntegrationModelDelete(name string) error
+	IntegrationModelCatalog() ([]sdk.IntegrationModelCatalogEntry, error)
+	AdminProjectIntegrationApply(req sdk.ProjectIntegrationApplyRequest) ([]sdk.ProjectIntegrationApplyResult, error)
 }
 
 // Interface is the main interface for cdsclient package
@@ -374,14 +453,17 @@ type Interface interface {
 	MonitoringClient
 	HookClient
 	Version() (*sdk.Version, error)
+	APIRoutes() ([]sdk.APIRoute, error)
 	TemplateClient
 }
 
 type WorkerInterface interface {
+	DownloadURLFromAPI(name, os, arch, variant string) string
 	GRPCPluginsClient
 	ProjectIntegrationGet(projectKey string, integrationName string, clearPassword bool) (sdk.ProjectIntegration, error)
 	QueueClient
 	Requirements() ([]sdk.Requirement, error)
+	Version() (*sdk.Version, error)
 	WorkerClient
 	WorkflowRunArtifacts(projectKey string, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error)
 	WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error
@@ -416,18 +498,19 @@ type GRPCPluginsClient interface {
 	PluginGetBinaryInfos(name, os, arch string) (*sdk.GRPCPluginBinary, error)
 }
 
-/* ProviderClient exposes allowed methods for providers
- Usage:
+/*
+	 ProviderClient exposes allowed methods for providers
+	 Usage:
 
- 	cfg := ProviderConfig{
-		Host: "https://my-cds-api:8081",
-		Name: "my-provider-name",
-		Token: "my-very-long-secret-token",
-	}
-	client := NewProviderClient(cfg)
-	//Get the writable projects of a user
-	projects, err := client.ProjectsList(FilterByUser("a-username"), FilterByWritablePermission())
-	...
+	 	cfg := ProviderConfig{
+			Host: "https://my-cds-api:8081",
+			Name: "my-provider-name",
+			Token: "my-very-long-secret-token",
+		}
+		client := NewProviderClient(cfg)
+		//Get the writable projects of a user
+		projects, err := client.ProjectsList(FilterByUser("a-username"), FilterByWritablePermission())
+		...
 */
 type ProviderClient interface {
 	ApplicationsList(projectKey string, opts ...RequestModifier) ([]sdk.Application, error)
@@ -507,6 +590,23 @@ func Format(format string) RequestModifier {
 	}
 }
 
+// SealTo allow a provider to export secrets sealed for another project of the same CDS instance.
+func SealTo(projectKey string) RequestModifier {
+	return func(r *http.Request) {
+		q := r.URL.Query()
+		q.Set("sealTo", projectKey)
+		r.URL.RawQuery = q.Encode()
+	}
+}
+
+// SealWithPublicKey allow a provider to export secrets sealed with the builtin public key of a project, for example
+// from another CDS instance.
+func SealWithPublicKey(publicKey string) RequestModifier {
+	return func(r *http.Request) {
+		r.Header.Set(sdk.ExportSealPublicKeyHeader, base64.StdEncoding.EncodeToString([]byte(publicKey)))
+	}
+}
+
 func Force() RequestModifier {
 	return func(r *http.Request) {
 		q := r.URL.Query()
@@ -534,6 +634,7 @@ type AuthClient interface {
 	AuthConsumerDelete(username, id string) error
 	AuthConsumerRegen(username, id string) (sdk.AuthConsumerCreateResponse, error)
 	AuthConsumerCreateForUser(username string, request sdk.AuthConsumer) (sdk.AuthConsumerCreateResponse, error)
+	AuthBrowserTokenCreateForUser(username string, request sdk.AuthConsumerBrowserTokenRequest) (sdk.AuthConsumerBrowserTokenResponse, error)
 	AuthSessionListByUser(username string) (sdk.AuthSessions, error)
 	AuthSessionDelete(username, id string) error
 	AuthMe() (sdk.AuthCurrentConsumerResponse, error)
@@ -12,6 +12,7 @@ import (
 	cdsclient "github.com/ovh/cds/sdk/cdsclient"
 	venom "github.com/ovh/venom"
 	go_coverage "github.com/sguiheux/go-coverage"
+	websocket "golang.org/x/net/websocket"
 	io "io"
 	http "net/http"
 	reflect "reflect"
@@ -385,6 +386,93 @@ func (mr *MockAdminMockRecorder) AdminCDSMigrationReset(id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCDSMigrationReset", reflect.TypeOf((*MockAdmin)(nil).AdminCDSMigrationReset), id)
 }
 
+// AdminSCIMAudit mocks base method
+func (m *MockAdmin) AdminSCIMAudit(limit int) ([]sdk.SCIMAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminSCIMAudit", limit)
+	ret0, _ := ret[0].([]sdk.SCIMAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminSCIMAudit indicates an expected call of AdminSCIMAudit
+func (mr *MockAdminMockRecorder) AdminSCIMAudit(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminSCIMAudit", reflect.TypeOf((*MockAdmin)(nil).AdminSCIMAudit), limit)
+}
+
+// AdminCacheKeys mocks base method
+func (m *MockAdmin) AdminCacheKeys(projectKey, workflowName string) ([]sdk.CacheKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminCacheKeys", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.CacheKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminCacheKeys indicates an expected call of AdminCacheKeys
+func (mr *MockAdminMockRecorder) AdminCacheKeys(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCacheKeys", reflect.TypeOf((*MockAdmin)(nil).AdminCacheKeys), projectKey, workflowName)
+}
+
+// AdminCacheInvalidate mocks base method
+func (m *MockAdmin) AdminCacheInvalidate(projectKey, workflowName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminCacheInvalidate", projectKey, workflowName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminCacheInvalidate indicates an expected call of AdminCacheInvalidate
+func (mr *MockAdminMockRecorder) AdminCacheInvalidate(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCacheInvalidate", reflect.TypeOf((*MockAdmin)(nil).AdminCacheInvalidate), projectKey, workflowName)
+}
+
+// AdminQueueJobCancel mocks base method
+func (m *MockAdmin) AdminQueueJobCancel(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueJobCancel", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQueueJobCancel indicates an expected call of AdminQueueJobCancel
+func (mr *MockAdminMockRecorder) AdminQueueJobCancel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueJobCancel", reflect.TypeOf((*MockAdmin)(nil).AdminQueueJobCancel), id)
+}
+
+// AdminQueueJobRequeue mocks base method
+func (m *MockAdmin) AdminQueueJobRequeue(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueJobRequeue", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQueueJobRequeue indicates an expected call of AdminQueueJobRequeue
+func (mr *MockAdminMockRecorder) AdminQueueJobRequeue(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueJobRequeue", reflect.TypeOf((*MockAdmin)(nil).AdminQueueJobRequeue), id)
+}
+
+// AdminQueueJobDiagnostic mocks base method
+func (m *MockAdmin) AdminQueueJobDiagnostic(id int64) (*sdk.QueueJobDiagnostic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueJobDiagnostic", id)
+	ret0, _ := ret[0].(*sdk.QueueJobDiagnostic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminQueueJobDiagnostic indicates an expected call of AdminQueueJobDiagnostic
+func (mr *MockAdminMockRecorder) AdminQueueJobDiagnostic(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueJobDiagnostic", reflect.TypeOf((*MockAdmin)(nil).AdminQueueJobDiagnostic), id)
+}
+
 // Services mocks base method
 func (m *MockAdmin) Services() ([]sdk.Service, error) {
 	m.ctrl.T.Helper()
@@ -681,6 +769,26 @@ func (mr *MockExportImportInterfaceMockRecorder) WorkflowImport(projectKey, cont
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowImport", reflect.TypeOf((*MockExportImportInterface)(nil).WorkflowImport), varargs...)
 }
 
+// WorkflowImportIntegrationsCheck mocks base method
+func (m *MockExportImportInterface) WorkflowImportIntegrationsCheck(projectKey string, content io.Reader, mods ...cdsclient.RequestModifier) (*sdk.WorkflowImportIntegrationReport, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, content}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowImportIntegrationsCheck", varargs...)
+	ret0, _ := ret[0].(*sdk.WorkflowImportIntegrationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowImportIntegrationsCheck indicates an expected call of WorkflowImportIntegrationsCheck
+func (mr *MockExportImportInterfaceMockRecorder) WorkflowImportIntegrationsCheck(projectKey, content interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, content}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowImportIntegrationsCheck", reflect.TypeOf((*MockExportImportInterface)(nil).WorkflowImportIntegrationsCheck), varargs...)
+}
+
 // WorkerModelExport mocks base method
 func (m *MockExportImportInterface) WorkerModelExport(groupName, name string, mods ...cdsclient.RequestModifier) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -1079,6 +1187,21 @@ func (mr *MockApplicationClientMockRecorder) ApplicationVariableUpdate(projectKe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariableUpdate", reflect.TypeOf((*MockApplicationClient)(nil).ApplicationVariableUpdate), projectKey, appName, variable)
 }
 
+// ApplicationVariablesBatch mocks base method
+func (m *MockApplicationClient) ApplicationVariablesBatch(projectKey, appName string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationVariablesBatch", projectKey, appName, batch)
+	ret0, _ := ret[0].(*sdk.VariableBatchDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationVariablesBatch indicates an expected call of ApplicationVariablesBatch
+func (mr *MockApplicationClientMockRecorder) ApplicationVariablesBatch(projectKey, appName, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariablesBatch", reflect.TypeOf((*MockApplicationClient)(nil).ApplicationVariablesBatch), projectKey, appName, batch)
+}
+
 // ApplicationKeysList mocks base method
 func (m *MockApplicationClient) ApplicationKeysList(projectKey, appName string) ([]sdk.ApplicationKey, error) {
 	m.ctrl.T.Helper()
@@ -1283,6 +1406,21 @@ func (mr *MockApplicationVariableClientMockRecorder) ApplicationVariableUpdate(p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariableUpdate", reflect.TypeOf((*MockApplicationVariableClient)(nil).ApplicationVariableUpdate), projectKey, appName, variable)
 }
 
+// ApplicationVariablesBatch mocks base method
+func (m *MockApplicationVariableClient) ApplicationVariablesBatch(projectKey, appName string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationVariablesBatch", projectKey, appName, batch)
+	ret0, _ := ret[0].(*sdk.VariableBatchDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationVariablesBatch indicates an expected call of ApplicationVariablesBatch
+func (mr *MockApplicationVariableClientMockRecorder) ApplicationVariablesBatch(projectKey, appName, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariablesBatch", reflect.TypeOf((*MockApplicationVariableClient)(nil).ApplicationVariablesBatch), projectKey, appName, batch)
+}
+
 // MockEnvironmentClient is a mock of EnvironmentClient interface
 type MockEnvironmentClient struct {
 	ctrl     *gomock.Controller
@@ -1481,6 +1619,21 @@ func (mr *MockEnvironmentClientMockRecorder) EnvironmentVariableUpdate(projectKe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariableUpdate", reflect.TypeOf((*MockEnvironmentClient)(nil).EnvironmentVariableUpdate), projectKey, envName, variable)
 }
 
+// EnvironmentVariablesBatch mocks base method
+func (m *MockEnvironmentClient) EnvironmentVariablesBatch(projectKey, envName string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentVariablesBatch", projectKey, envName, batch)
+	ret0, _ := ret[0].(*sdk.VariableBatchDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnvironmentVariablesBatch indicates an expected call of EnvironmentVariablesBatch
+func (mr *MockEnvironmentClientMockRecorder) EnvironmentVariablesBatch(projectKey, envName, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariablesBatch", reflect.TypeOf((*MockEnvironmentClient)(nil).EnvironmentVariablesBatch), projectKey, envName, batch)
+}
+
 // EnvironmentKeysList mocks base method
 func (m *MockEnvironmentClient) EnvironmentKeysList(projectKey, envName string) ([]sdk.EnvironmentKey, error) {
 	m.ctrl.T.Helper()
@@ -1685,6 +1838,21 @@ func (mr *MockEnvironmentVariableClientMockRecorder) EnvironmentVariableUpdate(p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariableUpdate", reflect.TypeOf((*MockEnvironmentVariableClient)(nil).EnvironmentVariableUpdate), projectKey, envName, variable)
 }
 
+// EnvironmentVariablesBatch mocks base method
+func (m *MockEnvironmentVariableClient) EnvironmentVariablesBatch(projectKey, envName string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentVariablesBatch", projectKey, envName, batch)
+	ret0, _ := ret[0].(*sdk.VariableBatchDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnvironmentVariablesBatch indicates an expected call of EnvironmentVariablesBatch
+func (mr *MockEnvironmentVariableClientMockRecorder) EnvironmentVariablesBatch(projectKey, envName, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariablesBatch", reflect.TypeOf((*MockEnvironmentVariableClient)(nil).EnvironmentVariablesBatch), projectKey, envName, batch)
+}
+
 // MockEventsClient is a mock of EventsClient interface
 type MockEventsClient struct {
 	ctrl     *gomock.Controller
@@ -2097,6 +2265,63 @@ func (mr *MockGroupClientMockRecorder) GroupMemberRemove(groupName, username int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberRemove", reflect.TypeOf((*MockGroupClient)(nil).GroupMemberRemove), groupName, username)
 }
 
+// GroupIntegrationList mocks base method
+func (m *MockGroupClient) GroupIntegrationList(groupName string) ([]sdk.GroupIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupIntegrationList", groupName)
+	ret0, _ := ret[0].([]sdk.GroupIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupIntegrationList indicates an expected call of GroupIntegrationList
+func (mr *MockGroupClientMockRecorder) GroupIntegrationList(groupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupIntegrationList", reflect.TypeOf((*MockGroupClient)(nil).GroupIntegrationList), groupName)
+}
+
+// GroupIntegrationCreate mocks base method
+func (m *MockGroupClient) GroupIntegrationCreate(groupName string, gi *sdk.GroupIntegration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupIntegrationCreate", groupName, gi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GroupIntegrationCreate indicates an expected call of GroupIntegrationCreate
+func (mr *MockGroupClientMockRecorder) GroupIntegrationCreate(groupName, gi interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupIntegrationCreate", reflect.TypeOf((*MockGroupClient)(nil).GroupIntegrationCreate), groupName, gi)
+}
+
+// GroupIntegrationUpdate mocks base method
+func (m *MockGroupClient) GroupIntegrationUpdate(groupName string, gi *sdk.GroupIntegration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupIntegrationUpdate", groupName, gi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GroupIntegrationUpdate indicates an expected call of GroupIntegrationUpdate
+func (mr *MockGroupClientMockRecorder) GroupIntegrationUpdate(groupName, gi interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupIntegrationUpdate", reflect.TypeOf((*MockGroupClient)(nil).GroupIntegrationUpdate), groupName, gi)
+}
+
+// GroupIntegrationDelete mocks base method
+func (m *MockGroupClient) GroupIntegrationDelete(groupName, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupIntegrationDelete", groupName, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GroupIntegrationDelete indicates an expected call of GroupIntegrationDelete
+func (mr *MockGroupClientMockRecorder) GroupIntegrationDelete(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupIntegrationDelete", reflect.TypeOf((*MockGroupClient)(nil).GroupIntegrationDelete), groupName, name)
+}
+
 // MockBroadcastClient is a mock of BroadcastClient interface
 type MockBroadcastClient struct {
 	ctrl     *gomock.Controller
@@ -2301,6 +2526,63 @@ func (mr *MockMaintenanceClientMockRecorder) Maintenance(enable, hooks interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Maintenance", reflect.TypeOf((*MockMaintenanceClient)(nil).Maintenance), enable, hooks)
 }
 
+// MaintenanceWindowList mocks base method
+func (m *MockMaintenanceClient) MaintenanceWindowList() ([]sdk.MaintenanceWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceWindowList")
+	ret0, _ := ret[0].([]sdk.MaintenanceWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MaintenanceWindowList indicates an expected call of MaintenanceWindowList
+func (mr *MockMaintenanceClientMockRecorder) MaintenanceWindowList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceWindowList", reflect.TypeOf((*MockMaintenanceClient)(nil).MaintenanceWindowList))
+}
+
+// MaintenanceWindowCreate mocks base method
+func (m *MockMaintenanceClient) MaintenanceWindowCreate(w *sdk.MaintenanceWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceWindowCreate", w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaintenanceWindowCreate indicates an expected call of MaintenanceWindowCreate
+func (mr *MockMaintenanceClientMockRecorder) MaintenanceWindowCreate(w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceWindowCreate", reflect.TypeOf((*MockMaintenanceClient)(nil).MaintenanceWindowCreate), w)
+}
+
+// MaintenanceWindowDelete mocks base method
+func (m *MockMaintenanceClient) MaintenanceWindowDelete(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceWindowDelete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaintenanceWindowDelete indicates an expected call of MaintenanceWindowDelete
+func (mr *MockMaintenanceClientMockRecorder) MaintenanceWindowDelete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceWindowDelete", reflect.TypeOf((*MockMaintenanceClient)(nil).MaintenanceWindowDelete), id)
+}
+
+// MaintenanceWindowOverride mocks base method
+func (m *MockMaintenanceClient) MaintenanceWindowOverride(projectKey, workflowName string, number int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceWindowOverride", projectKey, workflowName, number, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaintenanceWindowOverride indicates an expected call of MaintenanceWindowOverride
+func (mr *MockMaintenanceClientMockRecorder) MaintenanceWindowOverride(projectKey, workflowName, number, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceWindowOverride", reflect.TypeOf((*MockMaintenanceClient)(nil).MaintenanceWindowOverride), projectKey, workflowName, number, reason)
+}
+
 // MockProjectClient is a mock of ProjectClient interface
 type MockProjectClient struct {
 	ctrl     *gomock.Controller
@@ -2338,6 +2620,21 @@ func (mr *MockProjectClientMockRecorder) ProjectCreate(proj interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCreate", reflect.TypeOf((*MockProjectClient)(nil).ProjectCreate), proj)
 }
 
+// ProjectBootstrap mocks base method
+func (m *MockProjectClient) ProjectBootstrap(req sdk.ProjectBootstrap) (*sdk.ProjectBootstrapResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectBootstrap", req)
+	ret0, _ := ret[0].(*sdk.ProjectBootstrapResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectBootstrap indicates an expected call of ProjectBootstrap
+func (mr *MockProjectClientMockRecorder) ProjectBootstrap(req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectBootstrap", reflect.TypeOf((*MockProjectClient)(nil).ProjectBootstrap), req)
+}
+
 // ProjectDelete mocks base method
 func (m *MockProjectClient) ProjectDelete(projectKey string) error {
 	m.ctrl.T.Helper()
@@ -2477,6 +2774,21 @@ func (mr *MockProjectClientMockRecorder) ProjectKeysDelete(projectKey, keyProjec
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectKeysDelete), projectKey, keyProjectName)
 }
 
+// ProjectBuiltinPublicKey mocks base method
+func (m *MockProjectClient) ProjectBuiltinPublicKey(projectKey string) (sdk.Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectBuiltinPublicKey", projectKey)
+	ret0, _ := ret[0].(sdk.Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectBuiltinPublicKey indicates an expected call of ProjectBuiltinPublicKey
+func (mr *MockProjectClientMockRecorder) ProjectBuiltinPublicKey(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectBuiltinPublicKey", reflect.TypeOf((*MockProjectClient)(nil).ProjectBuiltinPublicKey), projectKey)
+}
+
 // ProjectVariablesList mocks base method
 func (m *MockProjectClient) ProjectVariablesList(key string) ([]sdk.Variable, error) {
 	m.ctrl.T.Helper()
@@ -2549,6 +2861,21 @@ func (mr *MockProjectClientMockRecorder) ProjectVariableUpdate(projectKey, varia
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableUpdate", reflect.TypeOf((*MockProjectClient)(nil).ProjectVariableUpdate), projectKey, variable)
 }
 
+// ProjectVariablesBatch mocks base method
+func (m *MockProjectClient) ProjectVariablesBatch(projectKey string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectVariablesBatch", projectKey, batch)
+	ret0, _ := ret[0].(*sdk.VariableBatchDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectVariablesBatch indicates an expected call of ProjectVariablesBatch
+func (mr *MockProjectClientMockRecorder) ProjectVariablesBatch(projectKey, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariablesBatch", reflect.TypeOf((*MockProjectClient)(nil).ProjectVariablesBatch), projectKey, batch)
+}
+
 // VariableEncrypt mocks base method
 func (m *MockProjectClient) VariableEncrypt(projectKey, varName, content string) (*sdk.Variable, error) {
 	m.ctrl.T.Helper()
@@ -2677,97 +3004,249 @@ func (mr *MockProjectClientMockRecorder) ProjectRepositoryManagerDelete(projectK
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectRepositoryManagerDelete), projectKey, repoManagerName, force)
 }
 
-// MockProjectKeysClient is a mock of ProjectKeysClient interface
-type MockProjectKeysClient struct {
-	ctrl     *gomock.Controller
-	recorder *MockProjectKeysClientMockRecorder
+// ProjectLint mocks base method
+func (m *MockProjectClient) ProjectLint(projectKey string, files map[string][]byte) ([]sdk.LintDiagnostic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectLint", projectKey, files)
+	ret0, _ := ret[0].([]sdk.LintDiagnostic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockProjectKeysClientMockRecorder is the mock recorder for MockProjectKeysClient
-type MockProjectKeysClientMockRecorder struct {
-	mock *MockProjectKeysClient
+// ProjectLint indicates an expected call of ProjectLint
+func (mr *MockProjectClientMockRecorder) ProjectLint(projectKey, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectLint", reflect.TypeOf((*MockProjectClient)(nil).ProjectLint), projectKey, files)
 }
 
-// NewMockProjectKeysClient creates a new mock instance
-func NewMockProjectKeysClient(ctrl *gomock.Controller) *MockProjectKeysClient {
-	mock := &MockProjectKeysClient{ctrl: ctrl}
-	mock.recorder = &MockProjectKeysClientMockRecorder{mock}
-	return mock
+// ProjectWebHookSecretList mocks base method
+func (m *MockProjectClient) ProjectWebHookSecretList(projectKey string, clearSecret bool) ([]sdk.ProjectWebHookSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectWebHookSecretList", projectKey, clearSecret)
+	ret0, _ := ret[0].([]sdk.ProjectWebHookSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockProjectKeysClient) EXPECT() *MockProjectKeysClientMockRecorder {
-	return m.recorder
+// ProjectWebHookSecretList indicates an expected call of ProjectWebHookSecretList
+func (mr *MockProjectClientMockRecorder) ProjectWebHookSecretList(projectKey, clearSecret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectWebHookSecretList", reflect.TypeOf((*MockProjectClient)(nil).ProjectWebHookSecretList), projectKey, clearSecret)
 }
 
-// ProjectKeysList mocks base method
-func (m *MockProjectKeysClient) ProjectKeysList(projectKey string) ([]sdk.ProjectKey, error) {
+// ProjectWebHookSecretRotate mocks base method
+func (m *MockProjectClient) ProjectWebHookSecretRotate(projectKey string, overlap time.Duration) (sdk.ProjectWebHookSecret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeysList", projectKey)
-	ret0, _ := ret[0].([]sdk.ProjectKey)
+	ret := m.ctrl.Call(m, "ProjectWebHookSecretRotate", projectKey, overlap)
+	ret0, _ := ret[0].(sdk.ProjectWebHookSecret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectKeysList indicates an expected call of ProjectKeysList
-func (mr *MockProjectKeysClientMockRecorder) ProjectKeysList(projectKey interface{}) *gomock.Call {
+// ProjectWebHookSecretRotate indicates an expected call of ProjectWebHookSecretRotate
+func (mr *MockProjectClientMockRecorder) ProjectWebHookSecretRotate(projectKey, overlap interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysList", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysList), projectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectWebHookSecretRotate", reflect.TypeOf((*MockProjectClient)(nil).ProjectWebHookSecretRotate), projectKey, overlap)
 }
 
-// ProjectKeyCreate mocks base method
-func (m *MockProjectKeysClient) ProjectKeyCreate(projectKey string, key *sdk.ProjectKey) error {
+// ProjectWebHookSecretDelete mocks base method
+func (m *MockProjectClient) ProjectWebHookSecretDelete(projectKey string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeyCreate", projectKey, key)
+	ret := m.ctrl.Call(m, "ProjectWebHookSecretDelete", projectKey)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProjectKeyCreate indicates an expected call of ProjectKeyCreate
-func (mr *MockProjectKeysClientMockRecorder) ProjectKeyCreate(projectKey, key interface{}) *gomock.Call {
+// ProjectWebHookSecretDelete indicates an expected call of ProjectWebHookSecretDelete
+func (mr *MockProjectClientMockRecorder) ProjectWebHookSecretDelete(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyCreate", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeyCreate), projectKey, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectWebHookSecretDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectWebHookSecretDelete), projectKey)
 }
 
-// ProjectKeysDelete mocks base method
-func (m *MockProjectKeysClient) ProjectKeysDelete(projectKey, keyProjectName string) error {
+// ProjectQuotaGet mocks base method
+func (m *MockProjectClient) ProjectQuotaGet(projectKey string) (sdk.ProjectQuotaStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeysDelete", projectKey, keyProjectName)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ProjectQuotaGet", projectKey)
+	ret0, _ := ret[0].(sdk.ProjectQuotaStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ProjectKeysDelete indicates an expected call of ProjectKeysDelete
-func (mr *MockProjectKeysClientMockRecorder) ProjectKeysDelete(projectKey, keyProjectName interface{}) *gomock.Call {
+// ProjectQuotaGet indicates an expected call of ProjectQuotaGet
+func (mr *MockProjectClientMockRecorder) ProjectQuotaGet(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysDelete", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysDelete), projectKey, keyProjectName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectQuotaGet", reflect.TypeOf((*MockProjectClient)(nil).ProjectQuotaGet), projectKey)
 }
 
-// MockProjectVariablesClient is a mock of ProjectVariablesClient interface
-type MockProjectVariablesClient struct {
-	ctrl     *gomock.Controller
-	recorder *MockProjectVariablesClientMockRecorder
+// ProjectQuotaUpdate mocks base method
+func (m *MockProjectClient) ProjectQuotaUpdate(projectKey string, quota *sdk.ProjectQuota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectQuotaUpdate", projectKey, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockProjectVariablesClientMockRecorder is the mock recorder for MockProjectVariablesClient
-type MockProjectVariablesClientMockRecorder struct {
-	mock *MockProjectVariablesClient
+// ProjectQuotaUpdate indicates an expected call of ProjectQuotaUpdate
+func (mr *MockProjectClientMockRecorder) ProjectQuotaUpdate(projectKey, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectQuotaUpdate", reflect.TypeOf((*MockProjectClient)(nil).ProjectQuotaUpdate), projectKey, quota)
 }
 
-// NewMockProjectVariablesClient creates a new mock instance
-func NewMockProjectVariablesClient(ctrl *gomock.Controller) *MockProjectVariablesClient {
-	mock := &MockProjectVariablesClient{ctrl: ctrl}
-	mock.recorder = &MockProjectVariablesClientMockRecorder{mock}
-	return mock
+// ProjectCostReport mocks base method
+func (m *MockProjectClient) ProjectCostReport(projectKey string, mods ...cdsclient.RequestModifier) (sdk.WorkflowCostReport, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectCostReport", varargs...)
+	ret0, _ := ret[0].(sdk.WorkflowCostReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockProjectVariablesClient) EXPECT() *MockProjectVariablesClientMockRecorder {
-	return m.recorder
+// ProjectCostReport indicates an expected call of ProjectCostReport
+func (mr *MockProjectClientMockRecorder) ProjectCostReport(projectKey interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCostReport", reflect.TypeOf((*MockProjectClient)(nil).ProjectCostReport), varargs...)
 }
 
-// ProjectVariablesList mocks base method
-func (m *MockProjectVariablesClient) ProjectVariablesList(key string) ([]sdk.Variable, error) {
+// ProjectArtifactRetentionGet mocks base method
+func (m *MockProjectClient) ProjectArtifactRetentionGet(projectKey string) (sdk.ProjectArtifactRetention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectArtifactRetentionGet", projectKey)
+	ret0, _ := ret[0].(sdk.ProjectArtifactRetention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectArtifactRetentionGet indicates an expected call of ProjectArtifactRetentionGet
+func (mr *MockProjectClientMockRecorder) ProjectArtifactRetentionGet(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectArtifactRetentionGet", reflect.TypeOf((*MockProjectClient)(nil).ProjectArtifactRetentionGet), projectKey)
+}
+
+// ProjectArtifactRetentionUpdate mocks base method
+func (m *MockProjectClient) ProjectArtifactRetentionUpdate(projectKey string, policy *sdk.ProjectArtifactRetention) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectArtifactRetentionUpdate", projectKey, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectArtifactRetentionUpdate indicates an expected call of ProjectArtifactRetentionUpdate
+func (mr *MockProjectClientMockRecorder) ProjectArtifactRetentionUpdate(projectKey, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectArtifactRetentionUpdate", reflect.TypeOf((*MockProjectClient)(nil).ProjectArtifactRetentionUpdate), projectKey, policy)
+}
+
+// MockProjectKeysClient is a mock of ProjectKeysClient interface
+type MockProjectKeysClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectKeysClientMockRecorder
+}
+
+// MockProjectKeysClientMockRecorder is the mock recorder for MockProjectKeysClient
+type MockProjectKeysClientMockRecorder struct {
+	mock *MockProjectKeysClient
+}
+
+// NewMockProjectKeysClient creates a new mock instance
+func NewMockProjectKeysClient(ctrl *gomock.Controller) *MockProjectKeysClient {
+	mock := &MockProjectKeysClient{ctrl: ctrl}
+	mock.recorder = &MockProjectKeysClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProjectKeysClient) EXPECT() *MockProjectKeysClientMockRecorder {
+	return m.recorder
+}
+
+// ProjectKeysList mocks base method
+func (m *MockProjectKeysClient) ProjectKeysList(projectKey string) ([]sdk.ProjectKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeysList", projectKey)
+	ret0, _ := ret[0].([]sdk.ProjectKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeysList indicates an expected call of ProjectKeysList
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeysList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysList", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysList), projectKey)
+}
+
+// ProjectKeyCreate mocks base method
+func (m *MockProjectKeysClient) ProjectKeyCreate(projectKey string, key *sdk.ProjectKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyCreate", projectKey, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectKeyCreate indicates an expected call of ProjectKeyCreate
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeyCreate(projectKey, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyCreate", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeyCreate), projectKey, key)
+}
+
+// ProjectKeysDelete mocks base method
+func (m *MockProjectKeysClient) ProjectKeysDelete(projectKey, keyProjectName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeysDelete", projectKey, keyProjectName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectKeysDelete indicates an expected call of ProjectKeysDelete
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeysDelete(projectKey, keyProjectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysDelete", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysDelete), projectKey, keyProjectName)
+}
+
+// ProjectBuiltinPublicKey mocks base method
+func (m *MockProjectKeysClient) ProjectBuiltinPublicKey(projectKey string) (sdk.Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectBuiltinPublicKey", projectKey)
+	ret0, _ := ret[0].(sdk.Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectBuiltinPublicKey indicates an expected call of ProjectBuiltinPublicKey
+func (mr *MockProjectKeysClientMockRecorder) ProjectBuiltinPublicKey(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectBuiltinPublicKey", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectBuiltinPublicKey), projectKey)
+}
+
+// MockProjectVariablesClient is a mock of ProjectVariablesClient interface
+type MockProjectVariablesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectVariablesClientMockRecorder
+}
+
+// MockProjectVariablesClientMockRecorder is the mock recorder for MockProjectVariablesClient
+type MockProjectVariablesClientMockRecorder struct {
+	mock *MockProjectVariablesClient
+}
+
+// NewMockProjectVariablesClient creates a new mock instance
+func NewMockProjectVariablesClient(ctrl *gomock.Controller) *MockProjectVariablesClient {
+	mock := &MockProjectVariablesClient{ctrl: ctrl}
+	mock.recorder = &MockProjectVariablesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProjectVariablesClient) EXPECT() *MockProjectVariablesClientMockRecorder {
+	return m.recorder
+}
+
+// ProjectVariablesList mocks base method
+func (m *MockProjectVariablesClient) ProjectVariablesList(key string) ([]sdk.Variable, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ProjectVariablesList", key)
 	ret0, _ := ret[0].([]sdk.Variable)
@@ -2838,6 +3317,21 @@ func (mr *MockProjectVariablesClientMockRecorder) ProjectVariableUpdate(projectK
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableUpdate", reflect.TypeOf((*MockProjectVariablesClient)(nil).ProjectVariableUpdate), projectKey, variable)
 }
 
+// ProjectVariablesBatch mocks base method
+func (m *MockProjectVariablesClient) ProjectVariablesBatch(projectKey string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectVariablesBatch", projectKey, batch)
+	ret0, _ := ret[0].(*sdk.VariableBatchDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectVariablesBatch indicates an expected call of ProjectVariablesBatch
+func (mr *MockProjectVariablesClientMockRecorder) ProjectVariablesBatch(projectKey, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariablesBatch", reflect.TypeOf((*MockProjectVariablesClient)(nil).ProjectVariablesBatch), projectKey, batch)
+}
+
 // VariableEncrypt mocks base method
 func (m *MockProjectVariablesClient) VariableEncrypt(projectKey, varName, content string) (*sdk.Variable, error) {
 	m.ctrl.T.Helper()
@@ -2924,6 +3418,20 @@ func (mr *MockQueueClientMockRecorder) QueuePolling(ctx, jobs, errs, delay, mode
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueuePolling", reflect.TypeOf((*MockQueueClient)(nil).QueuePolling), ctx, jobs, errs, delay, modelType, ratioService)
 }
 
+// QueueStream mocks base method
+func (m *MockQueueClient) QueueStream(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, resync time.Duration, modelType string, ratioService *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueStream", ctx, jobs, errs, resync, modelType, ratioService)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueStream indicates an expected call of QueueStream
+func (mr *MockQueueClientMockRecorder) QueueStream(ctx, jobs, errs, resync, modelType, ratioService interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueStream", reflect.TypeOf((*MockQueueClient)(nil).QueueStream), ctx, jobs, errs, resync, modelType, ratioService)
+}
+
 // QueueTakeJob mocks base method
 func (m *MockQueueClient) QueueTakeJob(ctx context.Context, job sdk.WorkflowNodeJobRun) (*sdk.WorkflowNodeJobRunData, error) {
 	m.ctrl.T.Helper()
@@ -2967,6 +3475,20 @@ func (mr *MockQueueClientMockRecorder) QueueJobRelease(ctx, id interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobRelease", reflect.TypeOf((*MockQueueClient)(nil).QueueJobRelease), ctx, id)
 }
 
+// QueueJobInfraError mocks base method
+func (m *MockQueueClient) QueueJobInfraError(ctx context.Context, id int64, infraErr sdk.JobInfraError) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobInfraError", ctx, id, infraErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobInfraError indicates an expected call of QueueJobInfraError
+func (mr *MockQueueClientMockRecorder) QueueJobInfraError(ctx, id, infraErr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobInfraError", reflect.TypeOf((*MockQueueClient)(nil).QueueJobInfraError), ctx, id, infraErr)
+}
+
 // QueueJobInfo mocks base method
 func (m *MockQueueClient) QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	m.ctrl.T.Helper()
@@ -3127,6 +3649,92 @@ func (mr *MockQueueClientMockRecorder) QueueJobTag(ctx, jobID, tags interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobTag", reflect.TypeOf((*MockQueueClient)(nil).QueueJobTag), ctx, jobID, tags)
 }
 
+// QueueJobAnnotation mocks base method
+func (m *MockQueueClient) QueueJobAnnotation(ctx context.Context, jobID int64, a sdk.WorkflowRunAnnotation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobAnnotation", ctx, jobID, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobAnnotation indicates an expected call of QueueJobAnnotation
+func (mr *MockQueueClientMockRecorder) QueueJobAnnotation(ctx, jobID, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobAnnotation", reflect.TypeOf((*MockQueueClient)(nil).QueueJobAnnotation), ctx, jobID, a)
+}
+
+// QueueJobRunResult mocks base method
+func (m *MockQueueClient) QueueJobRunResult(ctx context.Context, jobID int64, res sdk.WorkflowRunResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobRunResult", ctx, jobID, res)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobRunResult indicates an expected call of QueueJobRunResult
+func (mr *MockQueueClientMockRecorder) QueueJobRunResult(ctx, jobID, res interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobRunResult", reflect.TypeOf((*MockQueueClient)(nil).QueueJobRunResult), ctx, jobID, res)
+}
+
+// QueueJobReleaseTargets mocks base method
+func (m *MockQueueClient) QueueJobReleaseTargets(ctx context.Context, jobID int64) (*sdk.WorkflowNodeJobRunRelease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobReleaseTargets", ctx, jobID)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeJobRunRelease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobReleaseTargets indicates an expected call of QueueJobReleaseTargets
+func (mr *MockQueueClientMockRecorder) QueueJobReleaseTargets(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobReleaseTargets", reflect.TypeOf((*MockQueueClient)(nil).QueueJobReleaseTargets), ctx, jobID)
+}
+
+// QueueJobReleaseTargetStatus mocks base method
+func (m *MockQueueClient) QueueJobReleaseTargetStatus(ctx context.Context, jobID int64, integrationName string, status sdk.WorkflowRunReleaseTargetStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobReleaseTargetStatus", ctx, jobID, integrationName, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobReleaseTargetStatus indicates an expected call of QueueJobReleaseTargetStatus
+func (mr *MockQueueClientMockRecorder) QueueJobReleaseTargetStatus(ctx, jobID, integrationName, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobReleaseTargetStatus", reflect.TypeOf((*MockQueueClient)(nil).QueueJobReleaseTargetStatus), ctx, jobID, integrationName, status)
+}
+
+// QueueJobDebug mocks base method
+func (m *MockQueueClient) QueueJobDebug(ctx context.Context, jobID int64, hold time.Duration) (*websocket.Conn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobDebug", ctx, jobID, hold)
+	ret0, _ := ret[0].(*websocket.Conn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobDebug indicates an expected call of QueueJobDebug
+func (mr *MockQueueClientMockRecorder) QueueJobDebug(ctx, jobID, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobDebug", reflect.TypeOf((*MockQueueClient)(nil).QueueJobDebug), ctx, jobID, hold)
+}
+
+// QueueJobMetrics mocks base method
+func (m *MockQueueClient) QueueJobMetrics(ctx context.Context, jobID int64, metrics sdk.JobMetrics) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobMetrics", ctx, jobID, metrics)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobMetrics indicates an expected call of QueueJobMetrics
+func (mr *MockQueueClientMockRecorder) QueueJobMetrics(ctx, jobID, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobMetrics", reflect.TypeOf((*MockQueueClient)(nil).QueueJobMetrics), ctx, jobID, metrics)
+}
+
 // QueueServiceLogs mocks base method
 func (m *MockQueueClient) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	m.ctrl.T.Helper()
@@ -3254,6 +3862,78 @@ func (mr *MockUserClientMockRecorder) UserGetSchema() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetSchema", reflect.TypeOf((*MockUserClient)(nil).UserGetSchema))
 }
 
+// UserDashboardList mocks base method
+func (m *MockUserClient) UserDashboardList() ([]sdk.Dashboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDashboardList")
+	ret0, _ := ret[0].([]sdk.Dashboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserDashboardList indicates an expected call of UserDashboardList
+func (mr *MockUserClientMockRecorder) UserDashboardList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDashboardList", reflect.TypeOf((*MockUserClient)(nil).UserDashboardList))
+}
+
+// UserDashboardCreate mocks base method
+func (m *MockUserClient) UserDashboardCreate(d *sdk.Dashboard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDashboardCreate", d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserDashboardCreate indicates an expected call of UserDashboardCreate
+func (mr *MockUserClientMockRecorder) UserDashboardCreate(d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDashboardCreate", reflect.TypeOf((*MockUserClient)(nil).UserDashboardCreate), d)
+}
+
+// UserDashboardUpdate mocks base method
+func (m *MockUserClient) UserDashboardUpdate(d *sdk.Dashboard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDashboardUpdate", d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserDashboardUpdate indicates an expected call of UserDashboardUpdate
+func (mr *MockUserClientMockRecorder) UserDashboardUpdate(d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDashboardUpdate", reflect.TypeOf((*MockUserClient)(nil).UserDashboardUpdate), d)
+}
+
+// UserDashboardDelete mocks base method
+func (m *MockUserClient) UserDashboardDelete(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDashboardDelete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserDashboardDelete indicates an expected call of UserDashboardDelete
+func (mr *MockUserClientMockRecorder) UserDashboardDelete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDashboardDelete", reflect.TypeOf((*MockUserClient)(nil).UserDashboardDelete), id)
+}
+
+// UserDashboardData mocks base method
+func (m *MockUserClient) UserDashboardData(id int64) (sdk.DashboardData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDashboardData", id)
+	ret0, _ := ret[0].(sdk.DashboardData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserDashboardData indicates an expected call of UserDashboardData
+func (mr *MockUserClientMockRecorder) UserDashboardData(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDashboardData", reflect.TypeOf((*MockUserClient)(nil).UserDashboardData), id)
+}
+
 // MockWorkerClient is a mock of WorkerClient interface
 type MockWorkerClient struct {
 	ctrl     *gomock.Controller
@@ -3307,11 +3987,12 @@ func (mr *MockWorkerClientMockRecorder) WorkerList(ctx interface{}) *gomock.Call
 }
 
 // WorkerRefresh mocks base method
-func (m *MockWorkerClient) WorkerRefresh(ctx context.Context) error {
+func (m *MockWorkerClient) WorkerRefresh(ctx context.Context) (sdk.WorkerHeartbeat, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "WorkerRefresh", ctx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(sdk.WorkerHeartbeat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // WorkerRefresh indicates an expected call of WorkerRefresh
@@ -3321,17 +4002,17 @@ func (mr *MockWorkerClientMockRecorder) WorkerRefresh(ctx interface{}) *gomock.C
 }
 
 // WorkerUnregister mocks base method
-func (m *MockWorkerClient) WorkerUnregister(ctx context.Context) error {
+func (m *MockWorkerClient) WorkerUnregister(ctx context.Context, shutdown sdk.WorkerShutdown) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerUnregister", ctx)
+	ret := m.ctrl.Call(m, "WorkerUnregister", ctx, shutdown)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // WorkerUnregister indicates an expected call of WorkerUnregister
-func (mr *MockWorkerClientMockRecorder) WorkerUnregister(ctx interface{}) *gomock.Call {
+func (mr *MockWorkerClientMockRecorder) WorkerUnregister(ctx, shutdown interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerUnregister", reflect.TypeOf((*MockWorkerClient)(nil).WorkerUnregister), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerUnregister", reflect.TypeOf((*MockWorkerClient)(nil).WorkerUnregister), ctx, shutdown)
 }
 
 // WorkerDisable mocks base method
@@ -3348,6 +4029,20 @@ func (mr *MockWorkerClientMockRecorder) WorkerDisable(ctx, id interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerDisable", reflect.TypeOf((*MockWorkerClient)(nil).WorkerDisable), ctx, id)
 }
 
+// WorkerDrain mocks base method
+func (m *MockWorkerClient) WorkerDrain(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerDrain", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkerDrain indicates an expected call of WorkerDrain
+func (mr *MockWorkerClientMockRecorder) WorkerDrain(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerDrain", reflect.TypeOf((*MockWorkerClient)(nil).WorkerDrain), ctx, id)
+}
+
 // WorkerModelAdd mocks base method
 func (m *MockWorkerClient) WorkerModelAdd(name, modelType, patternName string, dockerModel *sdk.ModelDocker, vmModel *sdk.ModelVirtualMachine, groupID int64) (sdk.Model, error) {
 	m.ctrl.T.Helper()
@@ -3392,6 +4087,36 @@ func (mr *MockWorkerClientMockRecorder) WorkerModelDelete(groupName, name interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelDelete", reflect.TypeOf((*MockWorkerClient)(nil).WorkerModelDelete), groupName, name)
 }
 
+// WorkerModelHealth mocks base method
+func (m *MockWorkerClient) WorkerModelHealth(groupName, name string) (sdk.ModelHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerModelHealth", groupName, name)
+	ret0, _ := ret[0].(sdk.ModelHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerModelHealth indicates an expected call of WorkerModelHealth
+func (mr *MockWorkerClientMockRecorder) WorkerModelHealth(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelHealth", reflect.TypeOf((*MockWorkerClient)(nil).WorkerModelHealth), groupName, name)
+}
+
+// WorkerModelQuarantineRelease mocks base method
+func (m *MockWorkerClient) WorkerModelQuarantineRelease(groupName, name string) (sdk.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerModelQuarantineRelease", groupName, name)
+	ret0, _ := ret[0].(sdk.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerModelQuarantineRelease indicates an expected call of WorkerModelQuarantineRelease
+func (mr *MockWorkerClientMockRecorder) WorkerModelQuarantineRelease(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelQuarantineRelease", reflect.TypeOf((*MockWorkerClient)(nil).WorkerModelQuarantineRelease), groupName, name)
+}
+
 // WorkerModelSpawnError mocks base method
 func (m *MockWorkerClient) WorkerModelSpawnError(groupName, name string, info sdk.SpawnErrorForm) error {
 	m.ctrl.T.Helper()
@@ -3611,6 +4336,66 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowDelete(projectKey, workflowNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowDelete", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowDelete), projectKey, workflowName)
 }
 
+// WorkflowDiff mocks base method
+func (m *MockWorkflowClient) WorkflowDiff(projectKey, workflowName string, req sdk.WorkflowDiffRequest) (*sdk.WorkflowDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowDiff", projectKey, workflowName, req)
+	ret0, _ := ret[0].(*sdk.WorkflowDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowDiff indicates an expected call of WorkflowDiff
+func (mr *MockWorkflowClientMockRecorder) WorkflowDiff(projectKey, workflowName, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowDiff", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowDiff), projectKey, workflowName, req)
+}
+
+// WorkflowVersionList mocks base method
+func (m *MockWorkflowClient) WorkflowVersionList(projectKey, workflowName string) ([]sdk.WorkflowVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowVersionList", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.WorkflowVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowVersionList indicates an expected call of WorkflowVersionList
+func (mr *MockWorkflowClientMockRecorder) WorkflowVersionList(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowVersionList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowVersionList), projectKey, workflowName)
+}
+
+// WorkflowVersionGet mocks base method
+func (m *MockWorkflowClient) WorkflowVersionGet(projectKey, workflowName string, version int64) (*sdk.WorkflowVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowVersionGet", projectKey, workflowName, version)
+	ret0, _ := ret[0].(*sdk.WorkflowVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowVersionGet indicates an expected call of WorkflowVersionGet
+func (mr *MockWorkflowClientMockRecorder) WorkflowVersionGet(projectKey, workflowName, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowVersionGet", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowVersionGet), projectKey, workflowName, version)
+}
+
+// WorkflowVersionRollback mocks base method
+func (m *MockWorkflowClient) WorkflowVersionRollback(projectKey, workflowName string, version int64) (*sdk.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowVersionRollback", projectKey, workflowName, version)
+	ret0, _ := ret[0].(*sdk.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowVersionRollback indicates an expected call of WorkflowVersionRollback
+func (mr *MockWorkflowClientMockRecorder) WorkflowVersionRollback(projectKey, workflowName, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowVersionRollback", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowVersionRollback), projectKey, workflowName, version)
+}
+
 // WorkflowLabelAdd mocks base method
 func (m *MockWorkflowClient) WorkflowLabelAdd(projectKey, name, labelName string) error {
 	m.ctrl.T.Helper()
@@ -3667,6 +4452,122 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowGroupDelete(projectKey, name,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGroupDelete", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowGroupDelete), projectKey, name, groupName)
 }
 
+// WorkflowWebhookList mocks base method
+func (m *MockWorkflowClient) WorkflowWebhookList(projectKey, name string) ([]sdk.WorkflowWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookList", projectKey, name)
+	ret0, _ := ret[0].([]sdk.WorkflowWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowWebhookList indicates an expected call of WorkflowWebhookList
+func (mr *MockWorkflowClientMockRecorder) WorkflowWebhookList(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowWebhookList), projectKey, name)
+}
+
+// WorkflowWebhookAdd mocks base method
+func (m *MockWorkflowClient) WorkflowWebhookAdd(projectKey, name string, w *sdk.WorkflowWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookAdd", projectKey, name, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowWebhookAdd indicates an expected call of WorkflowWebhookAdd
+func (mr *MockWorkflowClientMockRecorder) WorkflowWebhookAdd(projectKey, name, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookAdd", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowWebhookAdd), projectKey, name, w)
+}
+
+// WorkflowWebhookUpdate mocks base method
+func (m *MockWorkflowClient) WorkflowWebhookUpdate(projectKey, name string, w *sdk.WorkflowWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookUpdate", projectKey, name, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowWebhookUpdate indicates an expected call of WorkflowWebhookUpdate
+func (mr *MockWorkflowClientMockRecorder) WorkflowWebhookUpdate(projectKey, name, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookUpdate", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowWebhookUpdate), projectKey, name, w)
+}
+
+// WorkflowWebhookDelete mocks base method
+func (m *MockWorkflowClient) WorkflowWebhookDelete(projectKey, name string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookDelete", projectKey, name, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowWebhookDelete indicates an expected call of WorkflowWebhookDelete
+func (mr *MockWorkflowClientMockRecorder) WorkflowWebhookDelete(projectKey, name, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookDelete", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowWebhookDelete), projectKey, name, id)
+}
+
+// WorkflowWebhookDeliveries mocks base method
+func (m *MockWorkflowClient) WorkflowWebhookDeliveries(projectKey, name, status string, limit int) ([]sdk.WorkflowWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookDeliveries", projectKey, name, status, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowWebhookDeliveries indicates an expected call of WorkflowWebhookDeliveries
+func (mr *MockWorkflowClientMockRecorder) WorkflowWebhookDeliveries(projectKey, name, status, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookDeliveries", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowWebhookDeliveries), projectKey, name, status, limit)
+}
+
+// WorkflowWebhookDeliveryReplay mocks base method
+func (m *MockWorkflowClient) WorkflowWebhookDeliveryReplay(projectKey, name string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookDeliveryReplay", projectKey, name, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowWebhookDeliveryReplay indicates an expected call of WorkflowWebhookDeliveryReplay
+func (mr *MockWorkflowClientMockRecorder) WorkflowWebhookDeliveryReplay(projectKey, name, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookDeliveryReplay", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowWebhookDeliveryReplay), projectKey, name, id)
+}
+
+// WorkflowWebhookDeadLetter mocks base method
+func (m *MockWorkflowClient) WorkflowWebhookDeadLetter(projectKey, name string, limit int) ([]sdk.WorkflowWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookDeadLetter", projectKey, name, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowWebhookDeadLetter indicates an expected call of WorkflowWebhookDeadLetter
+func (mr *MockWorkflowClientMockRecorder) WorkflowWebhookDeadLetter(projectKey, name, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookDeadLetter", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowWebhookDeadLetter), projectKey, name, limit)
+}
+
+// WorkflowWebhookDeadLetterReplay mocks base method
+func (m *MockWorkflowClient) WorkflowWebhookDeadLetterReplay(projectKey, name string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookDeadLetterReplay", projectKey, name)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowWebhookDeadLetterReplay indicates an expected call of WorkflowWebhookDeadLetterReplay
+func (mr *MockWorkflowClientMockRecorder) WorkflowWebhookDeadLetterReplay(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookDeadLetterReplay", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowWebhookDeadLetterReplay), projectKey, name)
+}
+
 // WorkflowRunGet mocks base method
 func (m *MockWorkflowClient) WorkflowRunGet(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -3746,6 +4647,41 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowRunList(projectKey, workflowNa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunList), projectKey, workflowName, offset, limit)
 }
 
+// WorkflowRunCompare mocks base method
+func (m *MockWorkflowClient) WorkflowRunCompare(projectKey, workflowName string, number int64, mods ...cdsclient.RequestModifier) (*sdk.WorkflowRunDurationComparison, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, workflowName, number}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowRunCompare", varargs...)
+	ret0, _ := ret[0].(*sdk.WorkflowRunDurationComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunCompare indicates an expected call of WorkflowRunCompare
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunCompare(projectKey, workflowName, number interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, workflowName, number}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunCompare", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunCompare), varargs...)
+}
+
+// WorkflowArtifactPin mocks base method
+func (m *MockWorkflowClient) WorkflowArtifactPin(projectKey, workflowName string, artifactID int64, pinned bool) (*sdk.WorkflowNodeRunArtifact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowArtifactPin", projectKey, workflowName, artifactID, pinned)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunArtifact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowArtifactPin indicates an expected call of WorkflowArtifactPin
+func (mr *MockWorkflowClientMockRecorder) WorkflowArtifactPin(projectKey, workflowName, artifactID, pinned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactPin", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowArtifactPin), projectKey, workflowName, artifactID, pinned)
+}
+
 // WorkflowRunArtifacts mocks base method
 func (m *MockWorkflowClient) WorkflowRunArtifacts(projectKey, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error) {
 	m.ctrl.T.Helper()
@@ -3761,6 +4697,189 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowRunArtifacts(projectKey, name,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifacts", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunArtifacts), projectKey, name, number)
 }
 
+// WorkflowRunAnnotationList mocks base method
+func (m *MockWorkflowClient) WorkflowRunAnnotationList(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunAnnotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunAnnotationList", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunAnnotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunAnnotationList indicates an expected call of WorkflowRunAnnotationList
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunAnnotationList(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunAnnotationList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunAnnotationList), projectKey, workflowName, number)
+}
+
+// WorkflowRunAnnotationAdd mocks base method
+func (m *MockWorkflowClient) WorkflowRunAnnotationAdd(projectKey string, workflowName string, number int64, a sdk.WorkflowRunAnnotation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunAnnotationAdd", projectKey, workflowName, number, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowRunAnnotationAdd indicates an expected call of WorkflowRunAnnotationAdd
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunAnnotationAdd(projectKey, workflowName, number, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunAnnotationAdd", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunAnnotationAdd), projectKey, workflowName, number, a)
+}
+
+// WorkflowRunAnnotationDelete mocks base method
+func (m *MockWorkflowClient) WorkflowRunAnnotationDelete(projectKey string, workflowName string, number int64, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunAnnotationDelete", projectKey, workflowName, number, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowRunAnnotationDelete indicates an expected call of WorkflowRunAnnotationDelete
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunAnnotationDelete(projectKey, workflowName, number, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunAnnotationDelete", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunAnnotationDelete), projectKey, workflowName, number, key)
+}
+
+// WorkflowRunResultList mocks base method
+func (m *MockWorkflowClient) WorkflowRunResultList(projectKey, workflowName string, number int64, types ...string) ([]sdk.WorkflowRunResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, workflowName, number}
+	for _, a := range types {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowRunResultList", varargs...)
+	ret0, _ := ret[0].([]sdk.WorkflowRunResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunResultList indicates an expected call of WorkflowRunResultList
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunResultList(projectKey, workflowName, number interface{}, types ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, workflowName, number}, types...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunResultList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunResultList), varargs...)
+}
+
+// WorkflowRunSecretUsageList mocks base method
+func (m *MockWorkflowClient) WorkflowRunSecretUsageList(projectKey, workflowName string, number int64, nodeNames ...string) ([]sdk.WorkflowRunSecretUsage, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, workflowName, number}
+	for _, a := range nodeNames {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowRunSecretUsageList", varargs...)
+	ret0, _ := ret[0].([]sdk.WorkflowRunSecretUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunSecretUsageList indicates an expected call of WorkflowRunSecretUsageList
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunSecretUsageList(projectKey, workflowName, number interface{}, nodeNames ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, workflowName, number}, nodeNames...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSecretUsageList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunSecretUsageList), varargs...)
+}
+
+// WorkflowRunReleaseTargetList mocks base method
+func (m *MockWorkflowClient) WorkflowRunReleaseTargetList(projectKey, workflowName string, number int64, nodeNames ...string) ([]sdk.WorkflowRunReleaseTarget, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, workflowName, number}
+	for _, a := range nodeNames {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowRunReleaseTargetList", varargs...)
+	ret0, _ := ret[0].([]sdk.WorkflowRunReleaseTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunReleaseTargetList indicates an expected call of WorkflowRunReleaseTargetList
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunReleaseTargetList(projectKey, workflowName, number interface{}, nodeNames ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, workflowName, number}, nodeNames...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunReleaseTargetList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunReleaseTargetList), varargs...)
+}
+
+// WorkflowRunJobFingerprintList mocks base method
+func (m *MockWorkflowClient) WorkflowRunJobFingerprintList(projectKey, workflowName string, number int64) ([]sdk.WorkflowRunJobFingerprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunJobFingerprintList", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunJobFingerprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunJobFingerprintList indicates an expected call of WorkflowRunJobFingerprintList
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunJobFingerprintList(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunJobFingerprintList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunJobFingerprintList), projectKey, workflowName, number)
+}
+
+// WorkflowRunJobFingerprintCompare mocks base method
+func (m *MockWorkflowClient) WorkflowRunJobFingerprintCompare(projectKey, workflowName string, number int64) ([]sdk.WorkflowRunJobFingerprintComparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunJobFingerprintCompare", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunJobFingerprintComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunJobFingerprintCompare indicates an expected call of WorkflowRunJobFingerprintCompare
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunJobFingerprintCompare(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunJobFingerprintCompare", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunJobFingerprintCompare), projectKey, workflowName, number)
+}
+
+// WorkflowNodeRunJobDebug mocks base method
+func (m *MockWorkflowClient) WorkflowNodeRunJobDebug(projectKey, workflowName string, number, nodeRunID, jobID int64) (*websocket.Conn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowNodeRunJobDebug", projectKey, workflowName, number, nodeRunID, jobID)
+	ret0, _ := ret[0].(*websocket.Conn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowNodeRunJobDebug indicates an expected call of WorkflowNodeRunJobDebug
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunJobDebug(projectKey, workflowName, number, nodeRunID, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunJobDebug", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunJobDebug), projectKey, workflowName, number, nodeRunID, jobID)
+}
+
+// WorkflowRunExport mocks base method
+func (m *MockWorkflowClient) WorkflowRunExport(projectKey, workflowName string, number int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunExport", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunExport indicates an expected call of WorkflowRunExport
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunExport(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunExport", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunExport), projectKey, workflowName, number)
+}
+
+// WorkflowCostReport mocks base method
+func (m *MockWorkflowClient) WorkflowCostReport(projectKey, workflowName string, number int64, mods ...cdsclient.RequestModifier) (sdk.WorkflowCostReport, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, workflowName, number}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowCostReport", varargs...)
+	ret0, _ := ret[0].(sdk.WorkflowCostReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCostReport indicates an expected call of WorkflowCostReport
+func (mr *MockWorkflowClientMockRecorder) WorkflowCostReport(projectKey, workflowName, number interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, workflowName, number}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCostReport", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCostReport), varargs...)
+}
+
 // WorkflowRunFromHook mocks base method
 func (m *MockWorkflowClient) WorkflowRunFromHook(projectKey, workflowName string, hook sdk.WorkflowNodeRunHookEvent) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -3791,6 +4910,36 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowRunFromManual(projectKey, work
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromManual", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunFromManual), projectKey, workflowName, manual, number, fromNodeID)
 }
 
+// WorkflowRunForm mocks base method
+func (m *MockWorkflowClient) WorkflowRunForm(projectKey, workflowName string) (*sdk.WorkflowRunForm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunForm", projectKey, workflowName)
+	ret0, _ := ret[0].(*sdk.WorkflowRunForm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunForm indicates an expected call of WorkflowRunForm
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunForm(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunForm", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunForm), projectKey, workflowName)
+}
+
+// WorkflowRunExplain mocks base method
+func (m *MockWorkflowClient) WorkflowRunExplain(projectKey, workflowName string, opts sdk.WorkflowRunPostHandlerOption) (*sdk.WorkflowRunExplain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunExplain", projectKey, workflowName, opts)
+	ret0, _ := ret[0].(*sdk.WorkflowRunExplain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunExplain indicates an expected call of WorkflowRunExplain
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunExplain(projectKey, workflowName, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunExplain", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunExplain), projectKey, workflowName, opts)
+}
+
 // WorkflowRunNumberGet mocks base method
 func (m *MockWorkflowClient) WorkflowRunNumberGet(projectKey, workflowName string) (*sdk.WorkflowRunNumber, error) {
 	m.ctrl.T.Helper()
@@ -3894,6 +5043,20 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunJobStep(projectKey, wor
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunJobStep", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunJobStep), projectKey, workflowName, number, nodeRunID, job, step)
 }
 
+// WorkflowNodeRunJobLogsDownload mocks base method
+func (m *MockWorkflowClient) WorkflowNodeRunJobLogsDownload(projectKey, workflowName string, number int64, nodeRunID, job int64, filter sdk.JobLogFilter, gzip bool, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowNodeRunJobLogsDownload", projectKey, workflowName, number, nodeRunID, job, filter, gzip, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowNodeRunJobLogsDownload indicates an expected call of WorkflowNodeRunJobLogsDownload
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunJobLogsDownload(projectKey, workflowName, number, nodeRunID, job, filter, gzip, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunJobLogsDownload", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunJobLogsDownload), projectKey, workflowName, number, nodeRunID, job, filter, gzip, w)
+}
+
 // WorkflowNodeRunRelease mocks base method
 func (m *MockWorkflowClient) WorkflowNodeRunRelease(projectKey, workflowName string, runNumber, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error {
 	m.ctrl.T.Helper()
@@ -3967,6 +5130,36 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowTemplateInstanceGet(projectKey
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTemplateInstanceGet", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTemplateInstanceGet), projectKey, workflowName)
 }
 
+// WorkflowTemplateInstanceDrift mocks base method
+func (m *MockWorkflowClient) WorkflowTemplateInstanceDrift(projectKey, workflowName string) (*sdk.WorkflowTemplateDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowTemplateInstanceDrift", projectKey, workflowName)
+	ret0, _ := ret[0].(*sdk.WorkflowTemplateDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowTemplateInstanceDrift indicates an expected call of WorkflowTemplateInstanceDrift
+func (mr *MockWorkflowClientMockRecorder) WorkflowTemplateInstanceDrift(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTemplateInstanceDrift", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTemplateInstanceDrift), projectKey, workflowName)
+}
+
+// WorkflowTemplateInstanceReapply mocks base method
+func (m *MockWorkflowClient) WorkflowTemplateInstanceReapply(projectKey, workflowName string, keepChanges bool) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowTemplateInstanceReapply", projectKey, workflowName, keepChanges)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowTemplateInstanceReapply indicates an expected call of WorkflowTemplateInstanceReapply
+func (mr *MockWorkflowClientMockRecorder) WorkflowTemplateInstanceReapply(projectKey, workflowName, keepChanges interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTemplateInstanceReapply", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTemplateInstanceReapply), projectKey, workflowName, keepChanges)
+}
+
 // WorkflowTransformAsCode mocks base method
 func (m *MockWorkflowClient) WorkflowTransformAsCode(projectKey, workflowName string) (*sdk.Operation, error) {
 	m.ctrl.T.Helper()
@@ -4174,7 +5367,37 @@ func (mr *MockIntegrationClientMockRecorder) IntegrationModelDelete(name interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelDelete", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelDelete), name)
 }
 
-// MockInterface is a mock of Interface interface
+// IntegrationModelCatalog mocks base method
+func (m *MockIntegrationClient) IntegrationModelCatalog() ([]sdk.IntegrationModelCatalogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntegrationModelCatalog")
+	ret0, _ := ret[0].([]sdk.IntegrationModelCatalogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntegrationModelCatalog indicates an expected call of IntegrationModelCatalog
+func (mr *MockIntegrationClientMockRecorder) IntegrationModelCatalog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelCatalog", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelCatalog))
+}
+
+// AdminProjectIntegrationApply mocks base method
+func (m *MockIntegrationClient) AdminProjectIntegrationApply(req sdk.ProjectIntegrationApplyRequest) ([]sdk.ProjectIntegrationApplyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminProjectIntegrationApply", req)
+	ret0, _ := ret[0].([]sdk.ProjectIntegrationApplyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminProjectIntegrationApply indicates an expected call of AdminProjectIntegrationApply
+func (mr *MockIntegrationClientMockRecorder) AdminProjectIntegrationApply(req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminProjectIntegrationApply", reflect.TypeOf((*MockIntegrationClient)(nil).AdminProjectIntegrationApply), req)
+}
+
+// MockInterface is a mock of Interface interface
 type MockInterface struct {
 	ctrl     *gomock.Controller
 	recorder *MockInterfaceMockRecorder
@@ -4510,6 +5733,21 @@ func (mr *MockInterfaceMockRecorder) AuthConsumerCreateForUser(username, request
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerCreateForUser", reflect.TypeOf((*MockInterface)(nil).AuthConsumerCreateForUser), username, request)
 }
 
+// AuthBrowserTokenCreateForUser mocks base method
+func (m *MockInterface) AuthBrowserTokenCreateForUser(username string, request sdk.AuthConsumerBrowserTokenRequest) (sdk.AuthConsumerBrowserTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthBrowserTokenCreateForUser", username, request)
+	ret0, _ := ret[0].(sdk.AuthConsumerBrowserTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthBrowserTokenCreateForUser indicates an expected call of AuthBrowserTokenCreateForUser
+func (mr *MockInterfaceMockRecorder) AuthBrowserTokenCreateForUser(username, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthBrowserTokenCreateForUser", reflect.TypeOf((*MockInterface)(nil).AuthBrowserTokenCreateForUser), username, request)
+}
+
 // AuthSessionListByUser mocks base method
 func (m *MockInterface) AuthSessionListByUser(username string) (sdk.AuthSessions, error) {
 	m.ctrl.T.Helper()
@@ -4869,6 +6107,93 @@ func (mr *MockInterfaceMockRecorder) AdminCDSMigrationReset(id interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCDSMigrationReset", reflect.TypeOf((*MockInterface)(nil).AdminCDSMigrationReset), id)
 }
 
+// AdminSCIMAudit mocks base method
+func (m *MockInterface) AdminSCIMAudit(limit int) ([]sdk.SCIMAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminSCIMAudit", limit)
+	ret0, _ := ret[0].([]sdk.SCIMAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminSCIMAudit indicates an expected call of AdminSCIMAudit
+func (mr *MockInterfaceMockRecorder) AdminSCIMAudit(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminSCIMAudit", reflect.TypeOf((*MockInterface)(nil).AdminSCIMAudit), limit)
+}
+
+// AdminCacheKeys mocks base method
+func (m *MockInterface) AdminCacheKeys(projectKey, workflowName string) ([]sdk.CacheKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminCacheKeys", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.CacheKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminCacheKeys indicates an expected call of AdminCacheKeys
+func (mr *MockInterfaceMockRecorder) AdminCacheKeys(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCacheKeys", reflect.TypeOf((*MockInterface)(nil).AdminCacheKeys), projectKey, workflowName)
+}
+
+// AdminCacheInvalidate mocks base method
+func (m *MockInterface) AdminCacheInvalidate(projectKey, workflowName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminCacheInvalidate", projectKey, workflowName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminCacheInvalidate indicates an expected call of AdminCacheInvalidate
+func (mr *MockInterfaceMockRecorder) AdminCacheInvalidate(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCacheInvalidate", reflect.TypeOf((*MockInterface)(nil).AdminCacheInvalidate), projectKey, workflowName)
+}
+
+// AdminQueueJobCancel mocks base method
+func (m *MockInterface) AdminQueueJobCancel(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueJobCancel", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQueueJobCancel indicates an expected call of AdminQueueJobCancel
+func (mr *MockInterfaceMockRecorder) AdminQueueJobCancel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueJobCancel", reflect.TypeOf((*MockInterface)(nil).AdminQueueJobCancel), id)
+}
+
+// AdminQueueJobRequeue mocks base method
+func (m *MockInterface) AdminQueueJobRequeue(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueJobRequeue", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQueueJobRequeue indicates an expected call of AdminQueueJobRequeue
+func (mr *MockInterfaceMockRecorder) AdminQueueJobRequeue(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueJobRequeue", reflect.TypeOf((*MockInterface)(nil).AdminQueueJobRequeue), id)
+}
+
+// AdminQueueJobDiagnostic mocks base method
+func (m *MockInterface) AdminQueueJobDiagnostic(id int64) (*sdk.QueueJobDiagnostic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueJobDiagnostic", id)
+	ret0, _ := ret[0].(*sdk.QueueJobDiagnostic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminQueueJobDiagnostic indicates an expected call of AdminQueueJobDiagnostic
+func (mr *MockInterfaceMockRecorder) AdminQueueJobDiagnostic(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueJobDiagnostic", reflect.TypeOf((*MockInterface)(nil).AdminQueueJobDiagnostic), id)
+}
+
 // Services mocks base method
 func (m *MockInterface) Services() ([]sdk.Service, error) {
 	m.ctrl.T.Helper()
@@ -5179,6 +6504,21 @@ func (mr *MockInterfaceMockRecorder) ApplicationVariableUpdate(projectKey, appNa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariableUpdate", reflect.TypeOf((*MockInterface)(nil).ApplicationVariableUpdate), projectKey, appName, variable)
 }
 
+// ApplicationVariablesBatch mocks base method
+func (m *MockInterface) ApplicationVariablesBatch(projectKey, appName string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationVariablesBatch", projectKey, appName, batch)
+	ret0, _ := ret[0].(*sdk.VariableBatchDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationVariablesBatch indicates an expected call of ApplicationVariablesBatch
+func (mr *MockInterfaceMockRecorder) ApplicationVariablesBatch(projectKey, appName, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariablesBatch", reflect.TypeOf((*MockInterface)(nil).ApplicationVariablesBatch), projectKey, appName, batch)
+}
+
 // ApplicationKeysList mocks base method
 func (m *MockInterface) ApplicationKeysList(projectKey, appName string) ([]sdk.ApplicationKey, error) {
 	m.ctrl.T.Helper()
@@ -5456,6 +6796,21 @@ func (mr *MockInterfaceMockRecorder) EnvironmentVariableUpdate(projectKey, envNa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariableUpdate", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariableUpdate), projectKey, envName, variable)
 }
 
+// EnvironmentVariablesBatch mocks base method
+func (m *MockInterface) EnvironmentVariablesBatch(projectKey, envName string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentVariablesBatch", projectKey, envName, batch)
+	ret0, _ := ret[0].(*sdk.VariableBatchDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnvironmentVariablesBatch indicates an expected call of EnvironmentVariablesBatch
+func (mr *MockInterfaceMockRecorder) EnvironmentVariablesBatch(projectKey, envName, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariablesBatch", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariablesBatch), projectKey, envName, batch)
+}
+
 // EnvironmentKeysList mocks base method
 func (m *MockInterface) EnvironmentKeysList(projectKey, envName string) ([]sdk.EnvironmentKey, error) {
 	m.ctrl.T.Helper()
@@ -5651,6 +7006,26 @@ func (mr *MockInterfaceMockRecorder) WorkflowImport(projectKey, content interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowImport", reflect.TypeOf((*MockInterface)(nil).WorkflowImport), varargs...)
 }
 
+// WorkflowImportIntegrationsCheck mocks base method
+func (m *MockInterface) WorkflowImportIntegrationsCheck(projectKey string, content io.Reader, mods ...cdsclient.RequestModifier) (*sdk.WorkflowImportIntegrationReport, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, content}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowImportIntegrationsCheck", varargs...)
+	ret0, _ := ret[0].(*sdk.WorkflowImportIntegrationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowImportIntegrationsCheck indicates an expected call of WorkflowImportIntegrationsCheck
+func (mr *MockInterfaceMockRecorder) WorkflowImportIntegrationsCheck(projectKey, content interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, content}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowImportIntegrationsCheck", reflect.TypeOf((*MockInterface)(nil).WorkflowImportIntegrationsCheck), varargs...)
+}
+
 // WorkerModelExport mocks base method
 func (m *MockInterface) WorkerModelExport(groupName, name string, mods ...cdsclient.RequestModifier) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -5878,6 +7253,63 @@ func (mr *MockInterfaceMockRecorder) GroupMemberRemove(groupName, username inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberRemove", reflect.TypeOf((*MockInterface)(nil).GroupMemberRemove), groupName, username)
 }
 
+// GroupIntegrationList mocks base method
+func (m *MockInterface) GroupIntegrationList(groupName string) ([]sdk.GroupIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupIntegrationList", groupName)
+	ret0, _ := ret[0].([]sdk.GroupIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupIntegrationList indicates an expected call of GroupIntegrationList
+func (mr *MockInterfaceMockRecorder) GroupIntegrationList(groupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupIntegrationList", reflect.TypeOf((*MockInterface)(nil).GroupIntegrationList), groupName)
+}
+
+// GroupIntegrationCreate mocks base method
+func (m *MockInterface) GroupIntegrationCreate(groupName string, gi *sdk.GroupIntegration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupIntegrationCreate", groupName, gi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GroupIntegrationCreate indicates an expected call of GroupIntegrationCreate
+func (mr *MockInterfaceMockRecorder) GroupIntegrationCreate(groupName, gi interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupIntegrationCreate", reflect.TypeOf((*MockInterface)(nil).GroupIntegrationCreate), groupName, gi)
+}
+
+// GroupIntegrationUpdate mocks base method
+func (m *MockInterface) GroupIntegrationUpdate(groupName string, gi *sdk.GroupIntegration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupIntegrationUpdate", groupName, gi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GroupIntegrationUpdate indicates an expected call of GroupIntegrationUpdate
+func (mr *MockInterfaceMockRecorder) GroupIntegrationUpdate(groupName, gi interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupIntegrationUpdate", reflect.TypeOf((*MockInterface)(nil).GroupIntegrationUpdate), groupName, gi)
+}
+
+// GroupIntegrationDelete mocks base method
+func (m *MockInterface) GroupIntegrationDelete(groupName, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupIntegrationDelete", groupName, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GroupIntegrationDelete indicates an expected call of GroupIntegrationDelete
+func (mr *MockInterfaceMockRecorder) GroupIntegrationDelete(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupIntegrationDelete", reflect.TypeOf((*MockInterface)(nil).GroupIntegrationDelete), groupName, name)
+}
+
 // PluginsList mocks base method
 func (m *MockInterface) PluginsList() ([]sdk.GRPCPlugin, error) {
 	m.ctrl.T.Helper()
@@ -6079,6 +7511,63 @@ func (mr *MockInterfaceMockRecorder) Maintenance(enable, hooks interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Maintenance", reflect.TypeOf((*MockInterface)(nil).Maintenance), enable, hooks)
 }
 
+// MaintenanceWindowList mocks base method
+func (m *MockInterface) MaintenanceWindowList() ([]sdk.MaintenanceWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceWindowList")
+	ret0, _ := ret[0].([]sdk.MaintenanceWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MaintenanceWindowList indicates an expected call of MaintenanceWindowList
+func (mr *MockInterfaceMockRecorder) MaintenanceWindowList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceWindowList", reflect.TypeOf((*MockInterface)(nil).MaintenanceWindowList))
+}
+
+// MaintenanceWindowCreate mocks base method
+func (m *MockInterface) MaintenanceWindowCreate(w *sdk.MaintenanceWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceWindowCreate", w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaintenanceWindowCreate indicates an expected call of MaintenanceWindowCreate
+func (mr *MockInterfaceMockRecorder) MaintenanceWindowCreate(w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceWindowCreate", reflect.TypeOf((*MockInterface)(nil).MaintenanceWindowCreate), w)
+}
+
+// MaintenanceWindowDelete mocks base method
+func (m *MockInterface) MaintenanceWindowDelete(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceWindowDelete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaintenanceWindowDelete indicates an expected call of MaintenanceWindowDelete
+func (mr *MockInterfaceMockRecorder) MaintenanceWindowDelete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceWindowDelete", reflect.TypeOf((*MockInterface)(nil).MaintenanceWindowDelete), id)
+}
+
+// MaintenanceWindowOverride mocks base method
+func (m *MockInterface) MaintenanceWindowOverride(projectKey, workflowName string, number int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceWindowOverride", projectKey, workflowName, number, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaintenanceWindowOverride indicates an expected call of MaintenanceWindowOverride
+func (mr *MockInterfaceMockRecorder) MaintenanceWindowOverride(projectKey, workflowName, number, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceWindowOverride", reflect.TypeOf((*MockInterface)(nil).MaintenanceWindowOverride), projectKey, workflowName, number, reason)
+}
+
 // PipelineGet mocks base method
 func (m *MockInterface) PipelineGet(projectKey, name string, mods ...cdsclient.RequestModifier) (*sdk.Pipeline, error) {
 	m.ctrl.T.Helper()
@@ -6214,6 +7703,36 @@ func (mr *MockInterfaceMockRecorder) IntegrationModelDelete(name interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelDelete", reflect.TypeOf((*MockInterface)(nil).IntegrationModelDelete), name)
 }
 
+// IntegrationModelCatalog mocks base method
+func (m *MockInterface) IntegrationModelCatalog() ([]sdk.IntegrationModelCatalogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntegrationModelCatalog")
+	ret0, _ := ret[0].([]sdk.IntegrationModelCatalogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntegrationModelCatalog indicates an expected call of IntegrationModelCatalog
+func (mr *MockInterfaceMockRecorder) IntegrationModelCatalog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelCatalog", reflect.TypeOf((*MockInterface)(nil).IntegrationModelCatalog))
+}
+
+// AdminProjectIntegrationApply mocks base method
+func (m *MockInterface) AdminProjectIntegrationApply(req sdk.ProjectIntegrationApplyRequest) ([]sdk.ProjectIntegrationApplyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminProjectIntegrationApply", req)
+	ret0, _ := ret[0].([]sdk.ProjectIntegrationApplyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminProjectIntegrationApply indicates an expected call of AdminProjectIntegrationApply
+func (mr *MockInterfaceMockRecorder) AdminProjectIntegrationApply(req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminProjectIntegrationApply", reflect.TypeOf((*MockInterface)(nil).AdminProjectIntegrationApply), req)
+}
+
 // ProjectCreate mocks base method
 func (m *MockInterface) ProjectCreate(proj *sdk.Project) error {
 	m.ctrl.T.Helper()
@@ -6228,6 +7747,21 @@ func (mr *MockInterfaceMockRecorder) ProjectCreate(proj interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCreate", reflect.TypeOf((*MockInterface)(nil).ProjectCreate), proj)
 }
 
+// ProjectBootstrap mocks base method
+func (m *MockInterface) ProjectBootstrap(req sdk.ProjectBootstrap) (*sdk.ProjectBootstrapResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectBootstrap", req)
+	ret0, _ := ret[0].(*sdk.ProjectBootstrapResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectBootstrap indicates an expected call of ProjectBootstrap
+func (mr *MockInterfaceMockRecorder) ProjectBootstrap(req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectBootstrap", reflect.TypeOf((*MockInterface)(nil).ProjectBootstrap), req)
+}
+
 // ProjectDelete mocks base method
 func (m *MockInterface) ProjectDelete(projectKey string) error {
 	m.ctrl.T.Helper()
@@ -6367,6 +7901,21 @@ func (mr *MockInterfaceMockRecorder) ProjectKeysDelete(projectKey, keyProjectNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysDelete", reflect.TypeOf((*MockInterface)(nil).ProjectKeysDelete), projectKey, keyProjectName)
 }
 
+// ProjectBuiltinPublicKey mocks base method
+func (m *MockInterface) ProjectBuiltinPublicKey(projectKey string) (sdk.Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectBuiltinPublicKey", projectKey)
+	ret0, _ := ret[0].(sdk.Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectBuiltinPublicKey indicates an expected call of ProjectBuiltinPublicKey
+func (mr *MockInterfaceMockRecorder) ProjectBuiltinPublicKey(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectBuiltinPublicKey", reflect.TypeOf((*MockInterface)(nil).ProjectBuiltinPublicKey), projectKey)
+}
+
 // ProjectVariablesList mocks base method
 func (m *MockInterface) ProjectVariablesList(key string) ([]sdk.Variable, error) {
 	m.ctrl.T.Helper()
@@ -6439,6 +7988,21 @@ func (mr *MockInterfaceMockRecorder) ProjectVariableUpdate(projectKey, variable
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectVariableUpdate), projectKey, variable)
 }
 
+// ProjectVariablesBatch mocks base method
+func (m *MockInterface) ProjectVariablesBatch(projectKey string, batch sdk.VariableBatch) (*sdk.VariableBatchDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectVariablesBatch", projectKey, batch)
+	ret0, _ := ret[0].(*sdk.VariableBatchDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectVariablesBatch indicates an expected call of ProjectVariablesBatch
+func (mr *MockInterfaceMockRecorder) ProjectVariablesBatch(projectKey, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariablesBatch", reflect.TypeOf((*MockInterface)(nil).ProjectVariablesBatch), projectKey, batch)
+}
+
 // VariableEncrypt mocks base method
 func (m *MockInterface) VariableEncrypt(projectKey, varName, content string) (*sdk.Variable, error) {
 	m.ctrl.T.Helper()
@@ -6567,89 +8131,240 @@ func (mr *MockInterfaceMockRecorder) ProjectRepositoryManagerDelete(projectKey,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerDelete", reflect.TypeOf((*MockInterface)(nil).ProjectRepositoryManagerDelete), projectKey, repoManagerName, force)
 }
 
-// QueueWorkflowNodeJobRun mocks base method
-func (m *MockInterface) QueueWorkflowNodeJobRun(status ...string) ([]sdk.WorkflowNodeJobRun, error) {
+// ProjectLint mocks base method
+func (m *MockInterface) ProjectLint(projectKey string, files map[string][]byte) ([]sdk.LintDiagnostic, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
-	for _, a := range status {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "QueueWorkflowNodeJobRun", varargs...)
-	ret0, _ := ret[0].([]sdk.WorkflowNodeJobRun)
+	ret := m.ctrl.Call(m, "ProjectLint", projectKey, files)
+	ret0, _ := ret[0].([]sdk.LintDiagnostic)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// QueueWorkflowNodeJobRun indicates an expected call of QueueWorkflowNodeJobRun
-func (mr *MockInterfaceMockRecorder) QueueWorkflowNodeJobRun(status ...interface{}) *gomock.Call {
+// ProjectLint indicates an expected call of ProjectLint
+func (mr *MockInterfaceMockRecorder) ProjectLint(projectKey, files interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueWorkflowNodeJobRun", reflect.TypeOf((*MockInterface)(nil).QueueWorkflowNodeJobRun), status...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectLint", reflect.TypeOf((*MockInterface)(nil).ProjectLint), projectKey, files)
 }
 
-// QueueCountWorkflowNodeJobRun mocks base method
-func (m *MockInterface) QueueCountWorkflowNodeJobRun(since, until *time.Time, modelType string, ratioService *int) (sdk.WorkflowNodeJobRunCount, error) {
+// ProjectWebHookSecretList mocks base method
+func (m *MockInterface) ProjectWebHookSecretList(projectKey string, clearSecret bool) ([]sdk.ProjectWebHookSecret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueCountWorkflowNodeJobRun", since, until, modelType, ratioService)
-	ret0, _ := ret[0].(sdk.WorkflowNodeJobRunCount)
+	ret := m.ctrl.Call(m, "ProjectWebHookSecretList", projectKey, clearSecret)
+	ret0, _ := ret[0].([]sdk.ProjectWebHookSecret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// QueueCountWorkflowNodeJobRun indicates an expected call of QueueCountWorkflowNodeJobRun
-func (mr *MockInterfaceMockRecorder) QueueCountWorkflowNodeJobRun(since, until, modelType, ratioService interface{}) *gomock.Call {
+// ProjectWebHookSecretList indicates an expected call of ProjectWebHookSecretList
+func (mr *MockInterfaceMockRecorder) ProjectWebHookSecretList(projectKey, clearSecret interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueCountWorkflowNodeJobRun", reflect.TypeOf((*MockInterface)(nil).QueueCountWorkflowNodeJobRun), since, until, modelType, ratioService)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectWebHookSecretList", reflect.TypeOf((*MockInterface)(nil).ProjectWebHookSecretList), projectKey, clearSecret)
 }
 
-// QueuePolling mocks base method
-func (m *MockInterface) QueuePolling(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, delay time.Duration, modelType string, ratioService *int) error {
+// ProjectWebHookSecretRotate mocks base method
+func (m *MockInterface) ProjectWebHookSecretRotate(projectKey string, overlap time.Duration) (sdk.ProjectWebHookSecret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueuePolling", ctx, jobs, errs, delay, modelType, ratioService)
+	ret := m.ctrl.Call(m, "ProjectWebHookSecretRotate", projectKey, overlap)
+	ret0, _ := ret[0].(sdk.ProjectWebHookSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectWebHookSecretRotate indicates an expected call of ProjectWebHookSecretRotate
+func (mr *MockInterfaceMockRecorder) ProjectWebHookSecretRotate(projectKey, overlap interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectWebHookSecretRotate", reflect.TypeOf((*MockInterface)(nil).ProjectWebHookSecretRotate), projectKey, overlap)
+}
+
+// ProjectWebHookSecretDelete mocks base method
+func (m *MockInterface) ProjectWebHookSecretDelete(projectKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectWebHookSecretDelete", projectKey)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// QueuePolling indicates an expected call of QueuePolling
-func (mr *MockInterfaceMockRecorder) QueuePolling(ctx, jobs, errs, delay, modelType, ratioService interface{}) *gomock.Call {
+// ProjectWebHookSecretDelete indicates an expected call of ProjectWebHookSecretDelete
+func (mr *MockInterfaceMockRecorder) ProjectWebHookSecretDelete(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueuePolling", reflect.TypeOf((*MockInterface)(nil).QueuePolling), ctx, jobs, errs, delay, modelType, ratioService)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectWebHookSecretDelete", reflect.TypeOf((*MockInterface)(nil).ProjectWebHookSecretDelete), projectKey)
 }
 
-// QueueTakeJob mocks base method
-func (m *MockInterface) QueueTakeJob(ctx context.Context, job sdk.WorkflowNodeJobRun) (*sdk.WorkflowNodeJobRunData, error) {
+// ProjectQuotaGet mocks base method
+func (m *MockInterface) ProjectQuotaGet(projectKey string) (sdk.ProjectQuotaStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueTakeJob", ctx, job)
-	ret0, _ := ret[0].(*sdk.WorkflowNodeJobRunData)
+	ret := m.ctrl.Call(m, "ProjectQuotaGet", projectKey)
+	ret0, _ := ret[0].(sdk.ProjectQuotaStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// QueueTakeJob indicates an expected call of QueueTakeJob
-func (mr *MockInterfaceMockRecorder) QueueTakeJob(ctx, job interface{}) *gomock.Call {
+// ProjectQuotaGet indicates an expected call of ProjectQuotaGet
+func (mr *MockInterfaceMockRecorder) ProjectQuotaGet(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueTakeJob", reflect.TypeOf((*MockInterface)(nil).QueueTakeJob), ctx, job)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectQuotaGet", reflect.TypeOf((*MockInterface)(nil).ProjectQuotaGet), projectKey)
 }
 
-// QueueJobBook mocks base method
-func (m *MockInterface) QueueJobBook(ctx context.Context, id int64) error {
+// ProjectQuotaUpdate mocks base method
+func (m *MockInterface) ProjectQuotaUpdate(projectKey string, quota *sdk.ProjectQuota) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueJobBook", ctx, id)
+	ret := m.ctrl.Call(m, "ProjectQuotaUpdate", projectKey, quota)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// QueueJobBook indicates an expected call of QueueJobBook
-func (mr *MockInterfaceMockRecorder) QueueJobBook(ctx, id interface{}) *gomock.Call {
+// ProjectQuotaUpdate indicates an expected call of ProjectQuotaUpdate
+func (mr *MockInterfaceMockRecorder) ProjectQuotaUpdate(projectKey, quota interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobBook", reflect.TypeOf((*MockInterface)(nil).QueueJobBook), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectQuotaUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectQuotaUpdate), projectKey, quota)
 }
 
-// QueueJobRelease mocks base method
-func (m *MockInterface) QueueJobRelease(ctx context.Context, id int64) error {
+// ProjectCostReport mocks base method
+func (m *MockInterface) ProjectCostReport(projectKey string, mods ...cdsclient.RequestModifier) (sdk.WorkflowCostReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueJobRelease", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	varargs := []interface{}{projectKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectCostReport", varargs...)
+	ret0, _ := ret[0].(sdk.WorkflowCostReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCostReport indicates an expected call of ProjectCostReport
+func (mr *MockInterfaceMockRecorder) ProjectCostReport(projectKey interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCostReport", reflect.TypeOf((*MockInterface)(nil).ProjectCostReport), varargs...)
+}
+
+// ProjectArtifactRetentionGet mocks base method
+func (m *MockInterface) ProjectArtifactRetentionGet(projectKey string) (sdk.ProjectArtifactRetention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectArtifactRetentionGet", projectKey)
+	ret0, _ := ret[0].(sdk.ProjectArtifactRetention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectArtifactRetentionGet indicates an expected call of ProjectArtifactRetentionGet
+func (mr *MockInterfaceMockRecorder) ProjectArtifactRetentionGet(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectArtifactRetentionGet", reflect.TypeOf((*MockInterface)(nil).ProjectArtifactRetentionGet), projectKey)
+}
+
+// ProjectArtifactRetentionUpdate mocks base method
+func (m *MockInterface) ProjectArtifactRetentionUpdate(projectKey string, policy *sdk.ProjectArtifactRetention) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectArtifactRetentionUpdate", projectKey, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectArtifactRetentionUpdate indicates an expected call of ProjectArtifactRetentionUpdate
+func (mr *MockInterfaceMockRecorder) ProjectArtifactRetentionUpdate(projectKey, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectArtifactRetentionUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectArtifactRetentionUpdate), projectKey, policy)
+}
+
+// QueueWorkflowNodeJobRun mocks base method
+func (m *MockInterface) QueueWorkflowNodeJobRun(status ...string) ([]sdk.WorkflowNodeJobRun, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range status {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueueWorkflowNodeJobRun", varargs...)
+	ret0, _ := ret[0].([]sdk.WorkflowNodeJobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueWorkflowNodeJobRun indicates an expected call of QueueWorkflowNodeJobRun
+func (mr *MockInterfaceMockRecorder) QueueWorkflowNodeJobRun(status ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueWorkflowNodeJobRun", reflect.TypeOf((*MockInterface)(nil).QueueWorkflowNodeJobRun), status...)
+}
+
+// QueueCountWorkflowNodeJobRun mocks base method
+func (m *MockInterface) QueueCountWorkflowNodeJobRun(since, until *time.Time, modelType string, ratioService *int) (sdk.WorkflowNodeJobRunCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueCountWorkflowNodeJobRun", since, until, modelType, ratioService)
+	ret0, _ := ret[0].(sdk.WorkflowNodeJobRunCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueCountWorkflowNodeJobRun indicates an expected call of QueueCountWorkflowNodeJobRun
+func (mr *MockInterfaceMockRecorder) QueueCountWorkflowNodeJobRun(since, until, modelType, ratioService interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueCountWorkflowNodeJobRun", reflect.TypeOf((*MockInterface)(nil).QueueCountWorkflowNodeJobRun), since, until, modelType, ratioService)
+}
+
+// QueuePolling mocks base method
+func (m *MockInterface) QueuePolling(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, delay time.Duration, modelType string, ratioService *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueuePolling", ctx, jobs, errs, delay, modelType, ratioService)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueuePolling indicates an expected call of QueuePolling
+func (mr *MockInterfaceMockRecorder) QueuePolling(ctx, jobs, errs, delay, modelType, ratioService interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueuePolling", reflect.TypeOf((*MockInterface)(nil).QueuePolling), ctx, jobs, errs, delay, modelType, ratioService)
+}
+
+// QueueStream mocks base method
+func (m *MockInterface) QueueStream(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, resync time.Duration, modelType string, ratioService *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueStream", ctx, jobs, errs, resync, modelType, ratioService)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueStream indicates an expected call of QueueStream
+func (mr *MockInterfaceMockRecorder) QueueStream(ctx, jobs, errs, resync, modelType, ratioService interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueStream", reflect.TypeOf((*MockInterface)(nil).QueueStream), ctx, jobs, errs, resync, modelType, ratioService)
+}
+
+// QueueTakeJob mocks base method
+func (m *MockInterface) QueueTakeJob(ctx context.Context, job sdk.WorkflowNodeJobRun) (*sdk.WorkflowNodeJobRunData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueTakeJob", ctx, job)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeJobRunData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueTakeJob indicates an expected call of QueueTakeJob
+func (mr *MockInterfaceMockRecorder) QueueTakeJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueTakeJob", reflect.TypeOf((*MockInterface)(nil).QueueTakeJob), ctx, job)
+}
+
+// QueueJobBook mocks base method
+func (m *MockInterface) QueueJobBook(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobBook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobBook indicates an expected call of QueueJobBook
+func (mr *MockInterfaceMockRecorder) QueueJobBook(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobBook", reflect.TypeOf((*MockInterface)(nil).QueueJobBook), ctx, id)
+}
+
+// QueueJobRelease mocks base method
+func (m *MockInterface) QueueJobRelease(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobRelease", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // QueueJobRelease indicates an expected call of QueueJobRelease
@@ -6658,6 +8373,20 @@ func (mr *MockInterfaceMockRecorder) QueueJobRelease(ctx, id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobRelease", reflect.TypeOf((*MockInterface)(nil).QueueJobRelease), ctx, id)
 }
 
+// QueueJobInfraError mocks base method
+func (m *MockInterface) QueueJobInfraError(ctx context.Context, id int64, infraErr sdk.JobInfraError) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobInfraError", ctx, id, infraErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobInfraError indicates an expected call of QueueJobInfraError
+func (mr *MockInterfaceMockRecorder) QueueJobInfraError(ctx, id, infraErr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobInfraError", reflect.TypeOf((*MockInterface)(nil).QueueJobInfraError), ctx, id, infraErr)
+}
+
 // QueueJobInfo mocks base method
 func (m *MockInterface) QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	m.ctrl.T.Helper()
@@ -6818,6 +8547,92 @@ func (mr *MockInterfaceMockRecorder) QueueJobTag(ctx, jobID, tags interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobTag", reflect.TypeOf((*MockInterface)(nil).QueueJobTag), ctx, jobID, tags)
 }
 
+// QueueJobAnnotation mocks base method
+func (m *MockInterface) QueueJobAnnotation(ctx context.Context, jobID int64, a sdk.WorkflowRunAnnotation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobAnnotation", ctx, jobID, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobAnnotation indicates an expected call of QueueJobAnnotation
+func (mr *MockInterfaceMockRecorder) QueueJobAnnotation(ctx, jobID, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobAnnotation", reflect.TypeOf((*MockInterface)(nil).QueueJobAnnotation), ctx, jobID, a)
+}
+
+// QueueJobRunResult mocks base method
+func (m *MockInterface) QueueJobRunResult(ctx context.Context, jobID int64, res sdk.WorkflowRunResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobRunResult", ctx, jobID, res)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobRunResult indicates an expected call of QueueJobRunResult
+func (mr *MockInterfaceMockRecorder) QueueJobRunResult(ctx, jobID, res interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobRunResult", reflect.TypeOf((*MockInterface)(nil).QueueJobRunResult), ctx, jobID, res)
+}
+
+// QueueJobReleaseTargets mocks base method
+func (m *MockInterface) QueueJobReleaseTargets(ctx context.Context, jobID int64) (*sdk.WorkflowNodeJobRunRelease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobReleaseTargets", ctx, jobID)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeJobRunRelease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobReleaseTargets indicates an expected call of QueueJobReleaseTargets
+func (mr *MockInterfaceMockRecorder) QueueJobReleaseTargets(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobReleaseTargets", reflect.TypeOf((*MockInterface)(nil).QueueJobReleaseTargets), ctx, jobID)
+}
+
+// QueueJobReleaseTargetStatus mocks base method
+func (m *MockInterface) QueueJobReleaseTargetStatus(ctx context.Context, jobID int64, integrationName string, status sdk.WorkflowRunReleaseTargetStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobReleaseTargetStatus", ctx, jobID, integrationName, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobReleaseTargetStatus indicates an expected call of QueueJobReleaseTargetStatus
+func (mr *MockInterfaceMockRecorder) QueueJobReleaseTargetStatus(ctx, jobID, integrationName, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobReleaseTargetStatus", reflect.TypeOf((*MockInterface)(nil).QueueJobReleaseTargetStatus), ctx, jobID, integrationName, status)
+}
+
+// QueueJobDebug mocks base method
+func (m *MockInterface) QueueJobDebug(ctx context.Context, jobID int64, hold time.Duration) (*websocket.Conn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobDebug", ctx, jobID, hold)
+	ret0, _ := ret[0].(*websocket.Conn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobDebug indicates an expected call of QueueJobDebug
+func (mr *MockInterfaceMockRecorder) QueueJobDebug(ctx, jobID, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobDebug", reflect.TypeOf((*MockInterface)(nil).QueueJobDebug), ctx, jobID, hold)
+}
+
+// QueueJobMetrics mocks base method
+func (m *MockInterface) QueueJobMetrics(ctx context.Context, jobID int64, metrics sdk.JobMetrics) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobMetrics", ctx, jobID, metrics)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobMetrics indicates an expected call of QueueJobMetrics
+func (mr *MockInterfaceMockRecorder) QueueJobMetrics(ctx, jobID, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobMetrics", reflect.TypeOf((*MockInterface)(nil).QueueJobMetrics), ctx, jobID, metrics)
+}
+
 // QueueServiceLogs mocks base method
 func (m *MockInterface) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	m.ctrl.T.Helper()
@@ -6996,6 +8811,78 @@ func (mr *MockInterfaceMockRecorder) UserGetSchema() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetSchema", reflect.TypeOf((*MockInterface)(nil).UserGetSchema))
 }
 
+// UserDashboardList mocks base method
+func (m *MockInterface) UserDashboardList() ([]sdk.Dashboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDashboardList")
+	ret0, _ := ret[0].([]sdk.Dashboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserDashboardList indicates an expected call of UserDashboardList
+func (mr *MockInterfaceMockRecorder) UserDashboardList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDashboardList", reflect.TypeOf((*MockInterface)(nil).UserDashboardList))
+}
+
+// UserDashboardCreate mocks base method
+func (m *MockInterface) UserDashboardCreate(d *sdk.Dashboard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDashboardCreate", d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserDashboardCreate indicates an expected call of UserDashboardCreate
+func (mr *MockInterfaceMockRecorder) UserDashboardCreate(d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDashboardCreate", reflect.TypeOf((*MockInterface)(nil).UserDashboardCreate), d)
+}
+
+// UserDashboardUpdate mocks base method
+func (m *MockInterface) UserDashboardUpdate(d *sdk.Dashboard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDashboardUpdate", d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserDashboardUpdate indicates an expected call of UserDashboardUpdate
+func (mr *MockInterfaceMockRecorder) UserDashboardUpdate(d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDashboardUpdate", reflect.TypeOf((*MockInterface)(nil).UserDashboardUpdate), d)
+}
+
+// UserDashboardDelete mocks base method
+func (m *MockInterface) UserDashboardDelete(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDashboardDelete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserDashboardDelete indicates an expected call of UserDashboardDelete
+func (mr *MockInterfaceMockRecorder) UserDashboardDelete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDashboardDelete", reflect.TypeOf((*MockInterface)(nil).UserDashboardDelete), id)
+}
+
+// UserDashboardData mocks base method
+func (m *MockInterface) UserDashboardData(id int64) (sdk.DashboardData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDashboardData", id)
+	ret0, _ := ret[0].(sdk.DashboardData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserDashboardData indicates an expected call of UserDashboardData
+func (mr *MockInterfaceMockRecorder) UserDashboardData(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDashboardData", reflect.TypeOf((*MockInterface)(nil).UserDashboardData), id)
+}
+
 // WorkerModelBook mocks base method
 func (m *MockInterface) WorkerModelBook(groupName, name string) error {
 	m.ctrl.T.Helper()
@@ -7026,11 +8913,12 @@ func (mr *MockInterfaceMockRecorder) WorkerList(ctx interface{}) *gomock.Call {
 }
 
 // WorkerRefresh mocks base method
-func (m *MockInterface) WorkerRefresh(ctx context.Context) error {
+func (m *MockInterface) WorkerRefresh(ctx context.Context) (sdk.WorkerHeartbeat, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "WorkerRefresh", ctx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(sdk.WorkerHeartbeat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // WorkerRefresh indicates an expected call of WorkerRefresh
@@ -7040,17 +8928,17 @@ func (mr *MockInterfaceMockRecorder) WorkerRefresh(ctx interface{}) *gomock.Call
 }
 
 // WorkerUnregister mocks base method
-func (m *MockInterface) WorkerUnregister(ctx context.Context) error {
+func (m *MockInterface) WorkerUnregister(ctx context.Context, shutdown sdk.WorkerShutdown) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerUnregister", ctx)
+	ret := m.ctrl.Call(m, "WorkerUnregister", ctx, shutdown)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // WorkerUnregister indicates an expected call of WorkerUnregister
-func (mr *MockInterfaceMockRecorder) WorkerUnregister(ctx interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) WorkerUnregister(ctx, shutdown interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerUnregister", reflect.TypeOf((*MockInterface)(nil).WorkerUnregister), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerUnregister", reflect.TypeOf((*MockInterface)(nil).WorkerUnregister), ctx, shutdown)
 }
 
 // WorkerDisable mocks base method
@@ -7067,6 +8955,20 @@ func (mr *MockInterfaceMockRecorder) WorkerDisable(ctx, id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerDisable", reflect.TypeOf((*MockInterface)(nil).WorkerDisable), ctx, id)
 }
 
+// WorkerDrain mocks base method
+func (m *MockInterface) WorkerDrain(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerDrain", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkerDrain indicates an expected call of WorkerDrain
+func (mr *MockInterfaceMockRecorder) WorkerDrain(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerDrain", reflect.TypeOf((*MockInterface)(nil).WorkerDrain), ctx, id)
+}
+
 // WorkerModelAdd mocks base method
 func (m *MockInterface) WorkerModelAdd(name, modelType, patternName string, dockerModel *sdk.ModelDocker, vmModel *sdk.ModelVirtualMachine, groupID int64) (sdk.Model, error) {
 	m.ctrl.T.Helper()
@@ -7111,6 +9013,36 @@ func (mr *MockInterfaceMockRecorder) WorkerModelDelete(groupName, name interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelDelete", reflect.TypeOf((*MockInterface)(nil).WorkerModelDelete), groupName, name)
 }
 
+// WorkerModelHealth mocks base method
+func (m *MockInterface) WorkerModelHealth(groupName, name string) (sdk.ModelHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerModelHealth", groupName, name)
+	ret0, _ := ret[0].(sdk.ModelHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerModelHealth indicates an expected call of WorkerModelHealth
+func (mr *MockInterfaceMockRecorder) WorkerModelHealth(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelHealth", reflect.TypeOf((*MockInterface)(nil).WorkerModelHealth), groupName, name)
+}
+
+// WorkerModelQuarantineRelease mocks base method
+func (m *MockInterface) WorkerModelQuarantineRelease(groupName, name string) (sdk.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerModelQuarantineRelease", groupName, name)
+	ret0, _ := ret[0].(sdk.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerModelQuarantineRelease indicates an expected call of WorkerModelQuarantineRelease
+func (mr *MockInterfaceMockRecorder) WorkerModelQuarantineRelease(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelQuarantineRelease", reflect.TypeOf((*MockInterface)(nil).WorkerModelQuarantineRelease), groupName, name)
+}
+
 // WorkerModelSpawnError mocks base method
 func (m *MockInterface) WorkerModelSpawnError(groupName, name string, info sdk.SpawnErrorForm) error {
 	m.ctrl.T.Helper()
@@ -7242,165 +9174,559 @@ func (mr *MockInterfaceMockRecorder) WorkflowUpdate(projectKey, name, wf interfa
 // WorkflowDelete mocks base method
 func (m *MockInterface) WorkflowDelete(projectKey, workflowName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowDelete", projectKey, workflowName)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowDelete", projectKey, workflowName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowDelete indicates an expected call of WorkflowDelete
+func (mr *MockInterfaceMockRecorder) WorkflowDelete(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowDelete), projectKey, workflowName)
+}
+
+// WorkflowDiff mocks base method
+func (m *MockInterface) WorkflowDiff(projectKey, workflowName string, req sdk.WorkflowDiffRequest) (*sdk.WorkflowDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowDiff", projectKey, workflowName, req)
+	ret0, _ := ret[0].(*sdk.WorkflowDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowDiff indicates an expected call of WorkflowDiff
+func (mr *MockInterfaceMockRecorder) WorkflowDiff(projectKey, workflowName, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowDiff", reflect.TypeOf((*MockInterface)(nil).WorkflowDiff), projectKey, workflowName, req)
+}
+
+// WorkflowVersionList mocks base method
+func (m *MockInterface) WorkflowVersionList(projectKey, workflowName string) ([]sdk.WorkflowVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowVersionList", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.WorkflowVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowVersionList indicates an expected call of WorkflowVersionList
+func (mr *MockInterfaceMockRecorder) WorkflowVersionList(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowVersionList", reflect.TypeOf((*MockInterface)(nil).WorkflowVersionList), projectKey, workflowName)
+}
+
+// WorkflowVersionGet mocks base method
+func (m *MockInterface) WorkflowVersionGet(projectKey, workflowName string, version int64) (*sdk.WorkflowVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowVersionGet", projectKey, workflowName, version)
+	ret0, _ := ret[0].(*sdk.WorkflowVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowVersionGet indicates an expected call of WorkflowVersionGet
+func (mr *MockInterfaceMockRecorder) WorkflowVersionGet(projectKey, workflowName, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowVersionGet", reflect.TypeOf((*MockInterface)(nil).WorkflowVersionGet), projectKey, workflowName, version)
+}
+
+// WorkflowVersionRollback mocks base method
+func (m *MockInterface) WorkflowVersionRollback(projectKey, workflowName string, version int64) (*sdk.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowVersionRollback", projectKey, workflowName, version)
+	ret0, _ := ret[0].(*sdk.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowVersionRollback indicates an expected call of WorkflowVersionRollback
+func (mr *MockInterfaceMockRecorder) WorkflowVersionRollback(projectKey, workflowName, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowVersionRollback", reflect.TypeOf((*MockInterface)(nil).WorkflowVersionRollback), projectKey, workflowName, version)
+}
+
+// WorkflowLabelAdd mocks base method
+func (m *MockInterface) WorkflowLabelAdd(projectKey, name, labelName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowLabelAdd", projectKey, name, labelName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowLabelAdd indicates an expected call of WorkflowLabelAdd
+func (mr *MockInterfaceMockRecorder) WorkflowLabelAdd(projectKey, name, labelName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLabelAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowLabelAdd), projectKey, name, labelName)
+}
+
+// WorkflowLabelDelete mocks base method
+func (m *MockInterface) WorkflowLabelDelete(projectKey, name string, labelID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowLabelDelete", projectKey, name, labelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowLabelDelete indicates an expected call of WorkflowLabelDelete
+func (mr *MockInterfaceMockRecorder) WorkflowLabelDelete(projectKey, name, labelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLabelDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowLabelDelete), projectKey, name, labelID)
+}
+
+// WorkflowGroupAdd mocks base method
+func (m *MockInterface) WorkflowGroupAdd(projectKey, name, groupName string, permission int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowGroupAdd", projectKey, name, groupName, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowGroupAdd indicates an expected call of WorkflowGroupAdd
+func (mr *MockInterfaceMockRecorder) WorkflowGroupAdd(projectKey, name, groupName, permission interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGroupAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowGroupAdd), projectKey, name, groupName, permission)
+}
+
+// WorkflowGroupDelete mocks base method
+func (m *MockInterface) WorkflowGroupDelete(projectKey, name, groupName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowGroupDelete", projectKey, name, groupName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowGroupDelete indicates an expected call of WorkflowGroupDelete
+func (mr *MockInterfaceMockRecorder) WorkflowGroupDelete(projectKey, name, groupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGroupDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowGroupDelete), projectKey, name, groupName)
+}
+
+// WorkflowWebhookList mocks base method
+func (m *MockInterface) WorkflowWebhookList(projectKey, name string) ([]sdk.WorkflowWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookList", projectKey, name)
+	ret0, _ := ret[0].([]sdk.WorkflowWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowWebhookList indicates an expected call of WorkflowWebhookList
+func (mr *MockInterfaceMockRecorder) WorkflowWebhookList(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookList", reflect.TypeOf((*MockInterface)(nil).WorkflowWebhookList), projectKey, name)
+}
+
+// WorkflowWebhookAdd mocks base method
+func (m *MockInterface) WorkflowWebhookAdd(projectKey, name string, w *sdk.WorkflowWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookAdd", projectKey, name, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowWebhookAdd indicates an expected call of WorkflowWebhookAdd
+func (mr *MockInterfaceMockRecorder) WorkflowWebhookAdd(projectKey, name, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowWebhookAdd), projectKey, name, w)
+}
+
+// WorkflowWebhookUpdate mocks base method
+func (m *MockInterface) WorkflowWebhookUpdate(projectKey, name string, w *sdk.WorkflowWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookUpdate", projectKey, name, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowWebhookUpdate indicates an expected call of WorkflowWebhookUpdate
+func (mr *MockInterfaceMockRecorder) WorkflowWebhookUpdate(projectKey, name, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookUpdate", reflect.TypeOf((*MockInterface)(nil).WorkflowWebhookUpdate), projectKey, name, w)
+}
+
+// WorkflowWebhookDelete mocks base method
+func (m *MockInterface) WorkflowWebhookDelete(projectKey, name string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookDelete", projectKey, name, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowWebhookDelete indicates an expected call of WorkflowWebhookDelete
+func (mr *MockInterfaceMockRecorder) WorkflowWebhookDelete(projectKey, name, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowWebhookDelete), projectKey, name, id)
+}
+
+// WorkflowWebhookDeliveries mocks base method
+func (m *MockInterface) WorkflowWebhookDeliveries(projectKey, name, status string, limit int) ([]sdk.WorkflowWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookDeliveries", projectKey, name, status, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowWebhookDeliveries indicates an expected call of WorkflowWebhookDeliveries
+func (mr *MockInterfaceMockRecorder) WorkflowWebhookDeliveries(projectKey, name, status, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookDeliveries", reflect.TypeOf((*MockInterface)(nil).WorkflowWebhookDeliveries), projectKey, name, status, limit)
+}
+
+// WorkflowWebhookDeliveryReplay mocks base method
+func (m *MockInterface) WorkflowWebhookDeliveryReplay(projectKey, name string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookDeliveryReplay", projectKey, name, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowWebhookDeliveryReplay indicates an expected call of WorkflowWebhookDeliveryReplay
+func (mr *MockInterfaceMockRecorder) WorkflowWebhookDeliveryReplay(projectKey, name, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookDeliveryReplay", reflect.TypeOf((*MockInterface)(nil).WorkflowWebhookDeliveryReplay), projectKey, name, id)
+}
+
+// WorkflowWebhookDeadLetter mocks base method
+func (m *MockInterface) WorkflowWebhookDeadLetter(projectKey, name string, limit int) ([]sdk.WorkflowWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookDeadLetter", projectKey, name, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowWebhookDeadLetter indicates an expected call of WorkflowWebhookDeadLetter
+func (mr *MockInterfaceMockRecorder) WorkflowWebhookDeadLetter(projectKey, name, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookDeadLetter", reflect.TypeOf((*MockInterface)(nil).WorkflowWebhookDeadLetter), projectKey, name, limit)
+}
+
+// WorkflowWebhookDeadLetterReplay mocks base method
+func (m *MockInterface) WorkflowWebhookDeadLetterReplay(projectKey, name string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowWebhookDeadLetterReplay", projectKey, name)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowWebhookDeadLetterReplay indicates an expected call of WorkflowWebhookDeadLetterReplay
+func (mr *MockInterfaceMockRecorder) WorkflowWebhookDeadLetterReplay(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowWebhookDeadLetterReplay", reflect.TypeOf((*MockInterface)(nil).WorkflowWebhookDeadLetterReplay), projectKey, name)
+}
+
+// WorkflowRunGet mocks base method
+func (m *MockInterface) WorkflowRunGet(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunGet", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunGet indicates an expected call of WorkflowRunGet
+func (mr *MockInterfaceMockRecorder) WorkflowRunGet(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunGet", reflect.TypeOf((*MockInterface)(nil).WorkflowRunGet), projectKey, workflowName, number)
+}
+
+// WorkflowRunsDeleteByBranch mocks base method
+func (m *MockInterface) WorkflowRunsDeleteByBranch(projectKey, workflowName, branch string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunsDeleteByBranch", projectKey, workflowName, branch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowRunsDeleteByBranch indicates an expected call of WorkflowRunsDeleteByBranch
+func (mr *MockInterfaceMockRecorder) WorkflowRunsDeleteByBranch(projectKey, workflowName, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunsDeleteByBranch", reflect.TypeOf((*MockInterface)(nil).WorkflowRunsDeleteByBranch), projectKey, workflowName, branch)
+}
+
+// WorkflowRunResync mocks base method
+func (m *MockInterface) WorkflowRunResync(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunResync", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunResync indicates an expected call of WorkflowRunResync
+func (mr *MockInterfaceMockRecorder) WorkflowRunResync(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunResync", reflect.TypeOf((*MockInterface)(nil).WorkflowRunResync), projectKey, workflowName, number)
+}
+
+// WorkflowRunSearch mocks base method
+func (m *MockInterface) WorkflowRunSearch(projectKey string, offset, limit int64, filter ...cdsclient.Filter) ([]sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, offset, limit}
+	for _, a := range filter {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowRunSearch", varargs...)
+	ret0, _ := ret[0].([]sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunSearch indicates an expected call of WorkflowRunSearch
+func (mr *MockInterfaceMockRecorder) WorkflowRunSearch(projectKey, offset, limit interface{}, filter ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, offset, limit}, filter...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSearch", reflect.TypeOf((*MockInterface)(nil).WorkflowRunSearch), varargs...)
+}
+
+// WorkflowRunList mocks base method
+func (m *MockInterface) WorkflowRunList(projectKey, workflowName string, offset, limit int64) ([]sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunList", projectKey, workflowName, offset, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunList indicates an expected call of WorkflowRunList
+func (mr *MockInterfaceMockRecorder) WorkflowRunList(projectKey, workflowName, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunList", reflect.TypeOf((*MockInterface)(nil).WorkflowRunList), projectKey, workflowName, offset, limit)
+}
+
+// WorkflowRunCompare mocks base method
+func (m *MockInterface) WorkflowRunCompare(projectKey, workflowName string, number int64, mods ...cdsclient.RequestModifier) (*sdk.WorkflowRunDurationComparison, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, workflowName, number}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowRunCompare", varargs...)
+	ret0, _ := ret[0].(*sdk.WorkflowRunDurationComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunCompare indicates an expected call of WorkflowRunCompare
+func (mr *MockInterfaceMockRecorder) WorkflowRunCompare(projectKey, workflowName, number interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, workflowName, number}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunCompare", reflect.TypeOf((*MockInterface)(nil).WorkflowRunCompare), varargs...)
+}
+
+// WorkflowArtifactPin mocks base method
+func (m *MockInterface) WorkflowArtifactPin(projectKey, workflowName string, artifactID int64, pinned bool) (*sdk.WorkflowNodeRunArtifact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowArtifactPin", projectKey, workflowName, artifactID, pinned)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunArtifact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowArtifactPin indicates an expected call of WorkflowArtifactPin
+func (mr *MockInterfaceMockRecorder) WorkflowArtifactPin(projectKey, workflowName, artifactID, pinned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactPin", reflect.TypeOf((*MockInterface)(nil).WorkflowArtifactPin), projectKey, workflowName, artifactID, pinned)
+}
+
+// WorkflowRunArtifacts mocks base method
+func (m *MockInterface) WorkflowRunArtifacts(projectKey, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunArtifacts", projectKey, name, number)
+	ret0, _ := ret[0].([]sdk.WorkflowNodeRunArtifact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunArtifacts indicates an expected call of WorkflowRunArtifacts
+func (mr *MockInterfaceMockRecorder) WorkflowRunArtifacts(projectKey, name, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifacts", reflect.TypeOf((*MockInterface)(nil).WorkflowRunArtifacts), projectKey, name, number)
+}
+
+// WorkflowRunAnnotationList mocks base method
+func (m *MockInterface) WorkflowRunAnnotationList(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunAnnotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunAnnotationList", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunAnnotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowDelete indicates an expected call of WorkflowDelete
-func (mr *MockInterfaceMockRecorder) WorkflowDelete(projectKey, workflowName interface{}) *gomock.Call {
+// WorkflowRunAnnotationList indicates an expected call of WorkflowRunAnnotationList
+func (mr *MockInterfaceMockRecorder) WorkflowRunAnnotationList(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowDelete), projectKey, workflowName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunAnnotationList", reflect.TypeOf((*MockInterface)(nil).WorkflowRunAnnotationList), projectKey, workflowName, number)
 }
 
-// WorkflowLabelAdd mocks base method
-func (m *MockInterface) WorkflowLabelAdd(projectKey, name, labelName string) error {
+// WorkflowRunAnnotationAdd mocks base method
+func (m *MockInterface) WorkflowRunAnnotationAdd(projectKey string, workflowName string, number int64, a sdk.WorkflowRunAnnotation) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowLabelAdd", projectKey, name, labelName)
+	ret := m.ctrl.Call(m, "WorkflowRunAnnotationAdd", projectKey, workflowName, number, a)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WorkflowLabelAdd indicates an expected call of WorkflowLabelAdd
-func (mr *MockInterfaceMockRecorder) WorkflowLabelAdd(projectKey, name, labelName interface{}) *gomock.Call {
+// WorkflowRunAnnotationAdd indicates an expected call of WorkflowRunAnnotationAdd
+func (mr *MockInterfaceMockRecorder) WorkflowRunAnnotationAdd(projectKey, workflowName, number, a interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLabelAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowLabelAdd), projectKey, name, labelName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunAnnotationAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowRunAnnotationAdd), projectKey, workflowName, number, a)
 }
 
-// WorkflowLabelDelete mocks base method
-func (m *MockInterface) WorkflowLabelDelete(projectKey, name string, labelID int64) error {
+// WorkflowRunAnnotationDelete mocks base method
+func (m *MockInterface) WorkflowRunAnnotationDelete(projectKey string, workflowName string, number int64, key string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowLabelDelete", projectKey, name, labelID)
+	ret := m.ctrl.Call(m, "WorkflowRunAnnotationDelete", projectKey, workflowName, number, key)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WorkflowLabelDelete indicates an expected call of WorkflowLabelDelete
-func (mr *MockInterfaceMockRecorder) WorkflowLabelDelete(projectKey, name, labelID interface{}) *gomock.Call {
+// WorkflowRunAnnotationDelete indicates an expected call of WorkflowRunAnnotationDelete
+func (mr *MockInterfaceMockRecorder) WorkflowRunAnnotationDelete(projectKey, workflowName, number, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLabelDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowLabelDelete), projectKey, name, labelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunAnnotationDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowRunAnnotationDelete), projectKey, workflowName, number, key)
 }
 
-// WorkflowGroupAdd mocks base method
-func (m *MockInterface) WorkflowGroupAdd(projectKey, name, groupName string, permission int) error {
+// WorkflowRunResultList mocks base method
+func (m *MockInterface) WorkflowRunResultList(projectKey, workflowName string, number int64, types ...string) ([]sdk.WorkflowRunResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowGroupAdd", projectKey, name, groupName, permission)
-	ret0, _ := ret[0].(error)
-	return ret0
+	varargs := []interface{}{projectKey, workflowName, number}
+	for _, a := range types {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowRunResultList", varargs...)
+	ret0, _ := ret[0].([]sdk.WorkflowRunResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowGroupAdd indicates an expected call of WorkflowGroupAdd
-func (mr *MockInterfaceMockRecorder) WorkflowGroupAdd(projectKey, name, groupName, permission interface{}) *gomock.Call {
+// WorkflowRunResultList indicates an expected call of WorkflowRunResultList
+func (mr *MockInterfaceMockRecorder) WorkflowRunResultList(projectKey, workflowName, number interface{}, types ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGroupAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowGroupAdd), projectKey, name, groupName, permission)
+	varargs := append([]interface{}{projectKey, workflowName, number}, types...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunResultList", reflect.TypeOf((*MockInterface)(nil).WorkflowRunResultList), varargs...)
 }
 
-// WorkflowGroupDelete mocks base method
-func (m *MockInterface) WorkflowGroupDelete(projectKey, name, groupName string) error {
+// WorkflowRunSecretUsageList mocks base method
+func (m *MockInterface) WorkflowRunSecretUsageList(projectKey, workflowName string, number int64, nodeNames ...string) ([]sdk.WorkflowRunSecretUsage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowGroupDelete", projectKey, name, groupName)
-	ret0, _ := ret[0].(error)
-	return ret0
+	varargs := []interface{}{projectKey, workflowName, number}
+	for _, a := range nodeNames {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowRunSecretUsageList", varargs...)
+	ret0, _ := ret[0].([]sdk.WorkflowRunSecretUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowGroupDelete indicates an expected call of WorkflowGroupDelete
-func (mr *MockInterfaceMockRecorder) WorkflowGroupDelete(projectKey, name, groupName interface{}) *gomock.Call {
+// WorkflowRunSecretUsageList indicates an expected call of WorkflowRunSecretUsageList
+func (mr *MockInterfaceMockRecorder) WorkflowRunSecretUsageList(projectKey, workflowName, number interface{}, nodeNames ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGroupDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowGroupDelete), projectKey, name, groupName)
+	varargs := append([]interface{}{projectKey, workflowName, number}, nodeNames...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSecretUsageList", reflect.TypeOf((*MockInterface)(nil).WorkflowRunSecretUsageList), varargs...)
 }
 
-// WorkflowRunGet mocks base method
-func (m *MockInterface) WorkflowRunGet(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+// WorkflowRunReleaseTargetList mocks base method
+func (m *MockInterface) WorkflowRunReleaseTargetList(projectKey, workflowName string, number int64, nodeNames ...string) ([]sdk.WorkflowRunReleaseTarget, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunGet", projectKey, workflowName, number)
-	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	varargs := []interface{}{projectKey, workflowName, number}
+	for _, a := range nodeNames {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowRunReleaseTargetList", varargs...)
+	ret0, _ := ret[0].([]sdk.WorkflowRunReleaseTarget)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunGet indicates an expected call of WorkflowRunGet
-func (mr *MockInterfaceMockRecorder) WorkflowRunGet(projectKey, workflowName, number interface{}) *gomock.Call {
+// WorkflowRunReleaseTargetList indicates an expected call of WorkflowRunReleaseTargetList
+func (mr *MockInterfaceMockRecorder) WorkflowRunReleaseTargetList(projectKey, workflowName, number interface{}, nodeNames ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunGet", reflect.TypeOf((*MockInterface)(nil).WorkflowRunGet), projectKey, workflowName, number)
+	varargs := append([]interface{}{projectKey, workflowName, number}, nodeNames...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunReleaseTargetList", reflect.TypeOf((*MockInterface)(nil).WorkflowRunReleaseTargetList), varargs...)
 }
 
-// WorkflowRunsDeleteByBranch mocks base method
-func (m *MockInterface) WorkflowRunsDeleteByBranch(projectKey, workflowName, branch string) error {
+// WorkflowRunJobFingerprintList mocks base method
+func (m *MockInterface) WorkflowRunJobFingerprintList(projectKey, workflowName string, number int64) ([]sdk.WorkflowRunJobFingerprint, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunsDeleteByBranch", projectKey, workflowName, branch)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowRunJobFingerprintList", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunJobFingerprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowRunsDeleteByBranch indicates an expected call of WorkflowRunsDeleteByBranch
-func (mr *MockInterfaceMockRecorder) WorkflowRunsDeleteByBranch(projectKey, workflowName, branch interface{}) *gomock.Call {
+// WorkflowRunJobFingerprintList indicates an expected call of WorkflowRunJobFingerprintList
+func (mr *MockInterfaceMockRecorder) WorkflowRunJobFingerprintList(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunsDeleteByBranch", reflect.TypeOf((*MockInterface)(nil).WorkflowRunsDeleteByBranch), projectKey, workflowName, branch)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunJobFingerprintList", reflect.TypeOf((*MockInterface)(nil).WorkflowRunJobFingerprintList), projectKey, workflowName, number)
 }
 
-// WorkflowRunResync mocks base method
-func (m *MockInterface) WorkflowRunResync(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+// WorkflowRunJobFingerprintCompare mocks base method
+func (m *MockInterface) WorkflowRunJobFingerprintCompare(projectKey, workflowName string, number int64) ([]sdk.WorkflowRunJobFingerprintComparison, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunResync", projectKey, workflowName, number)
-	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret := m.ctrl.Call(m, "WorkflowRunJobFingerprintCompare", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunJobFingerprintComparison)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunResync indicates an expected call of WorkflowRunResync
-func (mr *MockInterfaceMockRecorder) WorkflowRunResync(projectKey, workflowName, number interface{}) *gomock.Call {
+// WorkflowRunJobFingerprintCompare indicates an expected call of WorkflowRunJobFingerprintCompare
+func (mr *MockInterfaceMockRecorder) WorkflowRunJobFingerprintCompare(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunResync", reflect.TypeOf((*MockInterface)(nil).WorkflowRunResync), projectKey, workflowName, number)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunJobFingerprintCompare", reflect.TypeOf((*MockInterface)(nil).WorkflowRunJobFingerprintCompare), projectKey, workflowName, number)
 }
 
-// WorkflowRunSearch mocks base method
-func (m *MockInterface) WorkflowRunSearch(projectKey string, offset, limit int64, filter ...cdsclient.Filter) ([]sdk.WorkflowRun, error) {
+// WorkflowNodeRunJobDebug mocks base method
+func (m *MockInterface) WorkflowNodeRunJobDebug(projectKey, workflowName string, number, nodeRunID, jobID int64) (*websocket.Conn, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{projectKey, offset, limit}
-	for _, a := range filter {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "WorkflowRunSearch", varargs...)
-	ret0, _ := ret[0].([]sdk.WorkflowRun)
+	ret := m.ctrl.Call(m, "WorkflowNodeRunJobDebug", projectKey, workflowName, number, nodeRunID, jobID)
+	ret0, _ := ret[0].(*websocket.Conn)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunSearch indicates an expected call of WorkflowRunSearch
-func (mr *MockInterfaceMockRecorder) WorkflowRunSearch(projectKey, offset, limit interface{}, filter ...interface{}) *gomock.Call {
+// WorkflowNodeRunJobDebug indicates an expected call of WorkflowNodeRunJobDebug
+func (mr *MockInterfaceMockRecorder) WorkflowNodeRunJobDebug(projectKey, workflowName, number, nodeRunID, jobID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{projectKey, offset, limit}, filter...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSearch", reflect.TypeOf((*MockInterface)(nil).WorkflowRunSearch), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunJobDebug", reflect.TypeOf((*MockInterface)(nil).WorkflowNodeRunJobDebug), projectKey, workflowName, number, nodeRunID, jobID)
 }
 
-// WorkflowRunList mocks base method
-func (m *MockInterface) WorkflowRunList(projectKey, workflowName string, offset, limit int64) ([]sdk.WorkflowRun, error) {
+// WorkflowRunExport mocks base method
+func (m *MockInterface) WorkflowRunExport(projectKey, workflowName string, number int64) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunList", projectKey, workflowName, offset, limit)
-	ret0, _ := ret[0].([]sdk.WorkflowRun)
+	ret := m.ctrl.Call(m, "WorkflowRunExport", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunList indicates an expected call of WorkflowRunList
-func (mr *MockInterfaceMockRecorder) WorkflowRunList(projectKey, workflowName, offset, limit interface{}) *gomock.Call {
+// WorkflowRunExport indicates an expected call of WorkflowRunExport
+func (mr *MockInterfaceMockRecorder) WorkflowRunExport(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunList", reflect.TypeOf((*MockInterface)(nil).WorkflowRunList), projectKey, workflowName, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunExport", reflect.TypeOf((*MockInterface)(nil).WorkflowRunExport), projectKey, workflowName, number)
 }
 
-// WorkflowRunArtifacts mocks base method
-func (m *MockInterface) WorkflowRunArtifacts(projectKey, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error) {
+// WorkflowCostReport mocks base method
+func (m *MockInterface) WorkflowCostReport(projectKey, workflowName string, number int64, mods ...cdsclient.RequestModifier) (sdk.WorkflowCostReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunArtifacts", projectKey, name, number)
-	ret0, _ := ret[0].([]sdk.WorkflowNodeRunArtifact)
+	varargs := []interface{}{projectKey, workflowName, number}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowCostReport", varargs...)
+	ret0, _ := ret[0].(sdk.WorkflowCostReport)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunArtifacts indicates an expected call of WorkflowRunArtifacts
-func (mr *MockInterfaceMockRecorder) WorkflowRunArtifacts(projectKey, name, number interface{}) *gomock.Call {
+// WorkflowCostReport indicates an expected call of WorkflowCostReport
+func (mr *MockInterfaceMockRecorder) WorkflowCostReport(projectKey, workflowName, number interface{}, mods ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifacts", reflect.TypeOf((*MockInterface)(nil).WorkflowRunArtifacts), projectKey, name, number)
+	varargs := append([]interface{}{projectKey, workflowName, number}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCostReport", reflect.TypeOf((*MockInterface)(nil).WorkflowCostReport), varargs...)
 }
 
 // WorkflowRunFromHook mocks base method
@@ -7433,6 +9759,36 @@ func (mr *MockInterfaceMockRecorder) WorkflowRunFromManual(projectKey, workflowN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromManual", reflect.TypeOf((*MockInterface)(nil).WorkflowRunFromManual), projectKey, workflowName, manual, number, fromNodeID)
 }
 
+// WorkflowRunForm mocks base method
+func (m *MockInterface) WorkflowRunForm(projectKey, workflowName string) (*sdk.WorkflowRunForm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunForm", projectKey, workflowName)
+	ret0, _ := ret[0].(*sdk.WorkflowRunForm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunForm indicates an expected call of WorkflowRunForm
+func (mr *MockInterfaceMockRecorder) WorkflowRunForm(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunForm", reflect.TypeOf((*MockInterface)(nil).WorkflowRunForm), projectKey, workflowName)
+}
+
+// WorkflowRunExplain mocks base method
+func (m *MockInterface) WorkflowRunExplain(projectKey, workflowName string, opts sdk.WorkflowRunPostHandlerOption) (*sdk.WorkflowRunExplain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunExplain", projectKey, workflowName, opts)
+	ret0, _ := ret[0].(*sdk.WorkflowRunExplain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunExplain indicates an expected call of WorkflowRunExplain
+func (mr *MockInterfaceMockRecorder) WorkflowRunExplain(projectKey, workflowName, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunExplain", reflect.TypeOf((*MockInterface)(nil).WorkflowRunExplain), projectKey, workflowName, opts)
+}
+
 // WorkflowRunNumberGet mocks base method
 func (m *MockInterface) WorkflowRunNumberGet(projectKey, workflowName string) (*sdk.WorkflowRunNumber, error) {
 	m.ctrl.T.Helper()
@@ -7536,6 +9892,20 @@ func (mr *MockInterfaceMockRecorder) WorkflowNodeRunJobStep(projectKey, workflow
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunJobStep", reflect.TypeOf((*MockInterface)(nil).WorkflowNodeRunJobStep), projectKey, workflowName, number, nodeRunID, job, step)
 }
 
+// WorkflowNodeRunJobLogsDownload mocks base method
+func (m *MockInterface) WorkflowNodeRunJobLogsDownload(projectKey, workflowName string, number int64, nodeRunID, job int64, filter sdk.JobLogFilter, gzip bool, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowNodeRunJobLogsDownload", projectKey, workflowName, number, nodeRunID, job, filter, gzip, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowNodeRunJobLogsDownload indicates an expected call of WorkflowNodeRunJobLogsDownload
+func (mr *MockInterfaceMockRecorder) WorkflowNodeRunJobLogsDownload(projectKey, workflowName, number, nodeRunID, job, filter, gzip, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunJobLogsDownload", reflect.TypeOf((*MockInterface)(nil).WorkflowNodeRunJobLogsDownload), projectKey, workflowName, number, nodeRunID, job, filter, gzip, w)
+}
+
 // WorkflowNodeRunRelease mocks base method
 func (m *MockInterface) WorkflowNodeRunRelease(projectKey, workflowName string, runNumber, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error {
 	m.ctrl.T.Helper()
@@ -7609,6 +9979,36 @@ func (mr *MockInterfaceMockRecorder) WorkflowTemplateInstanceGet(projectKey, wor
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTemplateInstanceGet", reflect.TypeOf((*MockInterface)(nil).WorkflowTemplateInstanceGet), projectKey, workflowName)
 }
 
+// WorkflowTemplateInstanceDrift mocks base method
+func (m *MockInterface) WorkflowTemplateInstanceDrift(projectKey, workflowName string) (*sdk.WorkflowTemplateDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowTemplateInstanceDrift", projectKey, workflowName)
+	ret0, _ := ret[0].(*sdk.WorkflowTemplateDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowTemplateInstanceDrift indicates an expected call of WorkflowTemplateInstanceDrift
+func (mr *MockInterfaceMockRecorder) WorkflowTemplateInstanceDrift(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTemplateInstanceDrift", reflect.TypeOf((*MockInterface)(nil).WorkflowTemplateInstanceDrift), projectKey, workflowName)
+}
+
+// WorkflowTemplateInstanceReapply mocks base method
+func (m *MockInterface) WorkflowTemplateInstanceReapply(projectKey, workflowName string, keepChanges bool) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowTemplateInstanceReapply", projectKey, workflowName, keepChanges)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowTemplateInstanceReapply indicates an expected call of WorkflowTemplateInstanceReapply
+func (mr *MockInterfaceMockRecorder) WorkflowTemplateInstanceReapply(projectKey, workflowName, keepChanges interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTemplateInstanceReapply", reflect.TypeOf((*MockInterface)(nil).WorkflowTemplateInstanceReapply), projectKey, workflowName, keepChanges)
+}
+
 // WorkflowTransformAsCode mocks base method
 func (m *MockInterface) WorkflowTransformAsCode(projectKey, workflowName string) (*sdk.Operation, error) {
 	m.ctrl.T.Helper()
@@ -7744,6 +10144,21 @@ func (mr *MockInterfaceMockRecorder) Version() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockInterface)(nil).Version))
 }
 
+// APIRoutes mocks base method
+func (m *MockInterface) APIRoutes() ([]sdk.APIRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIRoutes")
+	ret0, _ := ret[0].([]sdk.APIRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APIRoutes indicates an expected call of APIRoutes
+func (mr *MockInterfaceMockRecorder) APIRoutes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIRoutes", reflect.TypeOf((*MockInterface)(nil).APIRoutes))
+}
+
 // TemplateGet mocks base method
 func (m *MockInterface) TemplateGet(groupName, templateSlug string) (*sdk.WorkflowTemplate, error) {
 	m.ctrl.T.Helper()
@@ -8108,6 +10523,20 @@ func (mr *MockWorkerInterfaceMockRecorder) QueuePolling(ctx, jobs, errs, delay,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueuePolling", reflect.TypeOf((*MockWorkerInterface)(nil).QueuePolling), ctx, jobs, errs, delay, modelType, ratioService)
 }
 
+// QueueStream mocks base method
+func (m *MockWorkerInterface) QueueStream(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, resync time.Duration, modelType string, ratioService *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueStream", ctx, jobs, errs, resync, modelType, ratioService)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueStream indicates an expected call of QueueStream
+func (mr *MockWorkerInterfaceMockRecorder) QueueStream(ctx, jobs, errs, resync, modelType, ratioService interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueStream", reflect.TypeOf((*MockWorkerInterface)(nil).QueueStream), ctx, jobs, errs, resync, modelType, ratioService)
+}
+
 // QueueTakeJob mocks base method
 func (m *MockWorkerInterface) QueueTakeJob(ctx context.Context, job sdk.WorkflowNodeJobRun) (*sdk.WorkflowNodeJobRunData, error) {
 	m.ctrl.T.Helper()
@@ -8151,6 +10580,20 @@ func (mr *MockWorkerInterfaceMockRecorder) QueueJobRelease(ctx, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobRelease", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobRelease), ctx, id)
 }
 
+// QueueJobInfraError mocks base method
+func (m *MockWorkerInterface) QueueJobInfraError(ctx context.Context, id int64, infraErr sdk.JobInfraError) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobInfraError", ctx, id, infraErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobInfraError indicates an expected call of QueueJobInfraError
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobInfraError(ctx, id, infraErr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobInfraError", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobInfraError), ctx, id, infraErr)
+}
+
 // QueueJobInfo mocks base method
 func (m *MockWorkerInterface) QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	m.ctrl.T.Helper()
@@ -8311,6 +10754,92 @@ func (mr *MockWorkerInterfaceMockRecorder) QueueJobTag(ctx, jobID, tags interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobTag", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobTag), ctx, jobID, tags)
 }
 
+// QueueJobAnnotation mocks base method
+func (m *MockWorkerInterface) QueueJobAnnotation(ctx context.Context, jobID int64, a sdk.WorkflowRunAnnotation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobAnnotation", ctx, jobID, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobAnnotation indicates an expected call of QueueJobAnnotation
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobAnnotation(ctx, jobID, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobAnnotation", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobAnnotation), ctx, jobID, a)
+}
+
+// QueueJobRunResult mocks base method
+func (m *MockWorkerInterface) QueueJobRunResult(ctx context.Context, jobID int64, res sdk.WorkflowRunResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobRunResult", ctx, jobID, res)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobRunResult indicates an expected call of QueueJobRunResult
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobRunResult(ctx, jobID, res interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobRunResult", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobRunResult), ctx, jobID, res)
+}
+
+// QueueJobReleaseTargets mocks base method
+func (m *MockWorkerInterface) QueueJobReleaseTargets(ctx context.Context, jobID int64) (*sdk.WorkflowNodeJobRunRelease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobReleaseTargets", ctx, jobID)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeJobRunRelease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobReleaseTargets indicates an expected call of QueueJobReleaseTargets
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobReleaseTargets(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobReleaseTargets", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobReleaseTargets), ctx, jobID)
+}
+
+// QueueJobReleaseTargetStatus mocks base method
+func (m *MockWorkerInterface) QueueJobReleaseTargetStatus(ctx context.Context, jobID int64, integrationName string, status sdk.WorkflowRunReleaseTargetStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobReleaseTargetStatus", ctx, jobID, integrationName, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobReleaseTargetStatus indicates an expected call of QueueJobReleaseTargetStatus
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobReleaseTargetStatus(ctx, jobID, integrationName, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobReleaseTargetStatus", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobReleaseTargetStatus), ctx, jobID, integrationName, status)
+}
+
+// QueueJobDebug mocks base method
+func (m *MockWorkerInterface) QueueJobDebug(ctx context.Context, jobID int64, hold time.Duration) (*websocket.Conn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobDebug", ctx, jobID, hold)
+	ret0, _ := ret[0].(*websocket.Conn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobDebug indicates an expected call of QueueJobDebug
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobDebug(ctx, jobID, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobDebug", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobDebug), ctx, jobID, hold)
+}
+
+// QueueJobMetrics mocks base method
+func (m *MockWorkerInterface) QueueJobMetrics(ctx context.Context, jobID int64, metrics sdk.JobMetrics) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobMetrics", ctx, jobID, metrics)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobMetrics indicates an expected call of QueueJobMetrics
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobMetrics(ctx, jobID, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobMetrics", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobMetrics), ctx, jobID, metrics)
+}
+
 // QueueServiceLogs mocks base method
 func (m *MockWorkerInterface) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	m.ctrl.T.Helper()
@@ -8340,6 +10869,35 @@ func (mr *MockWorkerInterfaceMockRecorder) Requirements() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requirements", reflect.TypeOf((*MockWorkerInterface)(nil).Requirements))
 }
 
+// DownloadURLFromAPI mocks base method
+func (m *MockWorkerInterface) DownloadURLFromAPI(name, os, arch, variant string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadURLFromAPI", name, os, arch, variant)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// DownloadURLFromAPI indicates an expected call of DownloadURLFromAPI
+func (mr *MockWorkerInterfaceMockRecorder) DownloadURLFromAPI(name, os, arch, variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadURLFromAPI", reflect.TypeOf((*MockWorkerInterface)(nil).DownloadURLFromAPI), name, os, arch, variant)
+}
+
+// Version mocks base method
+func (m *MockWorkerInterface) Version() (*sdk.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version")
+	ret0, _ := ret[0].(*sdk.Version)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version
+func (mr *MockWorkerInterfaceMockRecorder) Version() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockWorkerInterface)(nil).Version))
+}
+
 // WorkerModelBook mocks base method
 func (m *MockWorkerInterface) WorkerModelBook(groupName, name string) error {
 	m.ctrl.T.Helper()
@@ -8370,11 +10928,12 @@ func (mr *MockWorkerInterfaceMockRecorder) WorkerList(ctx interface{}) *gomock.C
 }
 
 // WorkerRefresh mocks base method
-func (m *MockWorkerInterface) WorkerRefresh(ctx context.Context) error {
+func (m *MockWorkerInterface) WorkerRefresh(ctx context.Context) (sdk.WorkerHeartbeat, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "WorkerRefresh", ctx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(sdk.WorkerHeartbeat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // WorkerRefresh indicates an expected call of WorkerRefresh
@@ -8384,17 +10943,17 @@ func (mr *MockWorkerInterfaceMockRecorder) WorkerRefresh(ctx interface{}) *gomoc
 }
 
 // WorkerUnregister mocks base method
-func (m *MockWorkerInterface) WorkerUnregister(ctx context.Context) error {
+func (m *MockWorkerInterface) WorkerUnregister(ctx context.Context, shutdown sdk.WorkerShutdown) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerUnregister", ctx)
+	ret := m.ctrl.Call(m, "WorkerUnregister", ctx, shutdown)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // WorkerUnregister indicates an expected call of WorkerUnregister
-func (mr *MockWorkerInterfaceMockRecorder) WorkerUnregister(ctx interface{}) *gomock.Call {
+func (mr *MockWorkerInterfaceMockRecorder) WorkerUnregister(ctx, shutdown interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerUnregister", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerUnregister), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerUnregister", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerUnregister), ctx, shutdown)
 }
 
 // WorkerDisable mocks base method
@@ -8411,6 +10970,20 @@ func (mr *MockWorkerInterfaceMockRecorder) WorkerDisable(ctx, id interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerDisable", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerDisable), ctx, id)
 }
 
+// WorkerDrain mocks base method
+func (m *MockWorkerInterface) WorkerDrain(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerDrain", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkerDrain indicates an expected call of WorkerDrain
+func (mr *MockWorkerInterfaceMockRecorder) WorkerDrain(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerDrain", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerDrain), ctx, id)
+}
+
 // WorkerModelAdd mocks base method
 func (m *MockWorkerInterface) WorkerModelAdd(name, modelType, patternName string, dockerModel *sdk.ModelDocker, vmModel *sdk.ModelVirtualMachine, groupID int64) (sdk.Model, error) {
 	m.ctrl.T.Helper()
@@ -9252,6 +11825,21 @@ func (mr *MockAuthClientMockRecorder) AuthConsumerCreateForUser(username, reques
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerCreateForUser", reflect.TypeOf((*MockAuthClient)(nil).AuthConsumerCreateForUser), username, request)
 }
 
+// AuthBrowserTokenCreateForUser mocks base method
+func (m *MockAuthClient) AuthBrowserTokenCreateForUser(username string, request sdk.AuthConsumerBrowserTokenRequest) (sdk.AuthConsumerBrowserTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthBrowserTokenCreateForUser", username, request)
+	ret0, _ := ret[0].(sdk.AuthConsumerBrowserTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthBrowserTokenCreateForUser indicates an expected call of AuthBrowserTokenCreateForUser
+func (mr *MockAuthClientMockRecorder) AuthBrowserTokenCreateForUser(username, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthBrowserTokenCreateForUser", reflect.TypeOf((*MockAuthClient)(nil).AuthBrowserTokenCreateForUser), username, request)
+}
+
 // AuthSessionListByUser mocks base method
 func (m *MockAuthClient) AuthSessionListByUser(username string) (sdk.AuthSessions, error) {
 	m.ctrl.T.Helper()
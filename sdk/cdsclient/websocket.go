@@ -0,0 +1,67 @@
+package cdsclient
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// openWebsocket opens a websocket on given path of the API, authenticated with the session token of the client.
+func (c *client) openWebsocket(ctx context.Context, path string) (*websocket.Conn, error) {
+	if !c.config.HasValidSessionToken() && c.config.BuitinConsumerAuthenticationToken != "" {
+		resp, err := c.AuthConsumerSignin(sdk.ConsumerBuiltin, sdk.AuthConsumerSigninRequest{"token": c.config.BuitinConsumerAuthenticationToken})
+		if err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		c.config.SessionToken = resp.Token
+	}
+
+	url := c.config.Host + path
+	switch {
+	case strings.HasPrefix(url, "https://"):
+		url = "wss://" + strings.TrimPrefix(url, "https://")
+	case strings.HasPrefix(url, "http://"):
+		url = "ws://" + strings.TrimPrefix(url, "http://")
+	}
+
+	config, err := websocket.NewConfig(url, c.config.Host)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	// Use the same TLS config than the http client
+	if t := baseTransport(c.httpClient.Transport); t != nil && t.TLSClientConfig != nil {
+		config.TlsConfig = t.TLSClientConfig.Clone()
+	} else {
+		config.TlsConfig = &tls.Config{InsecureSkipVerify: c.config.InsecureSkipVerifyTLS}
+	}
+	config.Header.Set("Authorization", "Bearer "+c.config.SessionToken)
+
+	type result struct {
+		conn *websocket.Conn
+		err  error
+	}
+	res := make(chan result, 1)
+	go func() {
+		conn, err := websocket.DialConfig(config)
+		res <- result{conn, err}
+	}()
+	select {
+	case r := <-res:
+		if r.err != nil {
+			return nil, sdk.WithStack(fmt.Errorf("cannot open websocket on %s: %v", path, r.err))
+		}
+		return r.conn, nil
+	case <-ctx.Done():
+		go func() {
+			if r := <-res; r.conn != nil {
+				r.conn.Close() // nolint
+			}
+		}()
+		return nil, sdk.WithStack(ctx.Err())
+	}
+}
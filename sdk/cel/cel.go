@@ -0,0 +1,320 @@
+// Package cel implements the subset of the Common Expression Language (https://github.com/google/cel-spec) used to
+// filter and transform hook payloads.
+//
+// It supports literals (int, double, string, bool, null, lists and maps), field selection and indexing, the
+// arithmetic, logical, relational, in and ternary operators, the has, all, exists, exists_one, filter and map macros,
+// and the size, string, int, double, startsWith, endsWith, contains, matches, lowerAscii and upperAscii functions.
+// The glob function is an extension that matches a path against a pattern where * matches any characters except
+// the separator and ** matches any characters.
+package cel
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Program is a parsed expression that can be evaluated several times.
+type Program struct {
+	source string
+	root   node
+}
+
+// Parse returns the program of given expression or an error if the expression is not valid.
+func Parse(source string) (*Program, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	p := parser{tokens: tokens}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", t, t.pos)
+	}
+	return &Program{source: source, root: root}, nil
+}
+
+// String returns the source of the program.
+func (p *Program) String() string {
+	return p.source
+}
+
+// Eval returns the value of the program with given variables.
+func (p *Program) Eval(vars map[string]interface{}) (interface{}, error) {
+	if vars == nil {
+		vars = map[string]interface{}{}
+	}
+	return p.root.eval(&activation{vars: vars})
+}
+
+// EvalBool returns the value of a program that should return a bool.
+func (p *Program) EvalBool(vars map[string]interface{}) (bool, error) {
+	v, err := p.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression should return a bool, not %s", typeName(v))
+	}
+	return b, nil
+}
+
+// EvalStringMap returns the value of a program that should return a map, values are converted to strings.
+func (p *Program) EvalStringMap(vars map[string]interface{}) (map[string]string, error) {
+	v, err := p.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expression should return a map, not %s", typeName(v))
+	}
+	res := make(map[string]string, len(m))
+	for k := range m {
+		s, err := toString(normalize(m[k]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for key %s: %v", k, err)
+		}
+		res[k] = s
+	}
+	return res, nil
+}
+
+type macro struct {
+	method bool
+	args   int
+}
+
+var macros = map[string]macro{
+	"has":        {args: 1},
+	"all":        {method: true, args: 2},
+	"exists":     {method: true, args: 2},
+	"exists_one": {method: true, args: 2},
+	"filter":     {method: true, args: 2},
+	"map":        {method: true, args: 2},
+}
+
+type function struct {
+	args int
+	fn   func(args []interface{}) (interface{}, error)
+}
+
+// functions can be called as global functions or as methods, the target of a method is the first argument.
+var functions = map[string]function{
+	"size": {args: 1, fn: func(args []interface{}) (interface{}, error) {
+		switch v := args[0].(type) {
+		case string:
+			return int64(len([]rune(v))), nil
+		case []interface{}:
+			return int64(len(v)), nil
+		case map[string]interface{}:
+			return int64(len(v)), nil
+		}
+		return nil, fmt.Errorf("unsupported type %s", typeName(args[0]))
+	}},
+	"string": {args: 1, fn: func(args []interface{}) (interface{}, error) {
+		return toString(args[0])
+	}},
+	"int": {args: 1, fn: func(args []interface{}) (interface{}, error) {
+		switch v := args[0].(type) {
+		case int64:
+			return v, nil
+		case float64:
+			return int64(v), nil
+		case string:
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert '%s' to int", v)
+			}
+			return i, nil
+		}
+		return nil, fmt.Errorf("unsupported type %s", typeName(args[0]))
+	}},
+	"double": {args: 1, fn: func(args []interface{}) (interface{}, error) {
+		switch v := args[0].(type) {
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert '%s' to double", v)
+			}
+			return f, nil
+		}
+		return nil, fmt.Errorf("unsupported type %s", typeName(args[0]))
+	}},
+	"startsWith": stringFunction(func(s, arg string) (interface{}, error) { return strings.HasPrefix(s, arg), nil }),
+	"endsWith":   stringFunction(func(s, arg string) (interface{}, error) { return strings.HasSuffix(s, arg), nil }),
+	"contains":   stringFunction(func(s, arg string) (interface{}, error) { return strings.Contains(s, arg), nil }),
+	"matches": stringFunction(func(s, arg string) (interface{}, error) {
+		r, err := regexp.Compile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %v", err)
+		}
+		return r.MatchString(s), nil
+	}),
+	"glob": stringFunction(func(s, arg string) (interface{}, error) {
+		return MatchGlob(arg, s)
+	}),
+	"lowerAscii": {args: 1, fn: func(args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %s", typeName(args[0]))
+		}
+		return strings.ToLower(s), nil
+	}},
+	"upperAscii": {args: 1, fn: func(args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %s", typeName(args[0]))
+		}
+		return strings.ToUpper(s), nil
+	}},
+}
+
+func stringFunction(fn func(s, arg string) (interface{}, error)) function {
+	return function{args: 2, fn: func(args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %s", typeName(args[0]))
+		}
+		arg, ok := args[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("unsupported argument type %s", typeName(args[1]))
+		}
+		return fn(s, arg)
+	}}
+}
+
+func toString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []interface{}:
+		ss := make([]string, len(v))
+		for i := range v {
+			s, err := toString(normalize(v[i]))
+			if err != nil {
+				return "", err
+			}
+			ss[i] = s
+		}
+		return strings.Join(ss, ","), nil
+	}
+	return "", fmt.Errorf("cannot convert %s to string", typeName(v))
+}
+
+// MatchGlob returns true if given path matches the glob pattern, see globToRegexp for the syntax of the pattern.
+func MatchGlob(pattern, path string) (bool, error) {
+	r, err := globToRegexp(pattern)
+	if err != nil {
+		return false, err
+	}
+	return r.MatchString(path), nil
+}
+
+// globToRegexp converts a glob pattern to a regular expression: ** matches any characters, * any characters except
+// / and ? a single character except /.
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	r, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob pattern: %v", err)
+	}
+	return r, nil
+}
+
+// Variables returns the sorted names of the variables used by the program, except the ones declared by macros.
+func (p *Program) Variables() []string {
+	names := map[string]struct{}{}
+	collectVariables(p.root, map[string]struct{}{}, names)
+	res := make([]string, 0, len(names))
+	for n := range names {
+		res = append(res, n)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func collectVariables(n node, declared, names map[string]struct{}) {
+	switch n := n.(type) {
+	case identNode:
+		if _, ok := declared[n.name]; !ok {
+			names[n.name] = struct{}{}
+		}
+	case selectNode:
+		collectVariables(n.operand, declared, names)
+	case hasNode:
+		collectVariables(n.sel, declared, names)
+	case indexNode:
+		collectVariables(n.operand, declared, names)
+		collectVariables(n.index, declared, names)
+	case unaryNode:
+		collectVariables(n.operand, declared, names)
+	case logicalNode:
+		collectVariables(n.left, declared, names)
+		collectVariables(n.right, declared, names)
+	case binaryNode:
+		collectVariables(n.left, declared, names)
+		collectVariables(n.right, declared, names)
+	case conditionalNode:
+		collectVariables(n.cond, declared, names)
+		collectVariables(n.t, declared, names)
+		collectVariables(n.f, declared, names)
+	case listNode:
+		for _, e := range n.elems {
+			collectVariables(e, declared, names)
+		}
+	case mapNode:
+		for i := range n.keys {
+			collectVariables(n.keys[i], declared, names)
+			collectVariables(n.values[i], declared, names)
+		}
+	case callNode:
+		for _, e := range n.args {
+			collectVariables(e, declared, names)
+		}
+	case comprehensionNode:
+		collectVariables(n.target, declared, names)
+		inner := make(map[string]struct{}, len(declared)+1)
+		for k := range declared {
+			inner[k] = struct{}{}
+		}
+		inner[n.variable] = struct{}{}
+		collectVariables(n.expr, inner, names)
+	}
+}
@@ -0,0 +1,107 @@
+package cel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]interface{}{
+		"payload": map[string]interface{}{
+			"ref":     "refs/heads/master",
+			"size":    float64(3),
+			"commits": []interface{}{map[string]interface{}{"modified": []interface{}{"services/api/main.go", "README.md"}}},
+		},
+		"params": map[string]string{"git.branch": "master"},
+		"files":  []string{"services/api/main.go", "README.md"},
+	}
+
+	tests := []struct {
+		expr string
+		res  interface{}
+	}{
+		{expr: `1 + 2 * 3`, res: int64(7)},
+		{expr: `(1 + 2) * 3 == 9`, res: true},
+		{expr: `-2.5 + 1`, res: -1.5},
+		{expr: `'a' + "b"`, res: "ab"},
+		{expr: `payload.ref == "refs/heads/master" && payload.size > 2`, res: true},
+		{expr: `payload.ref.startsWith("refs/tags/") || params["git.branch"] in ["master", "main"]`, res: true},
+		{expr: `has(payload.ref) && !has(payload.tag)`, res: true},
+		{expr: `files.exists(f, glob(f, "services/api/**"))`, res: true},
+		{expr: `files.all(f, f.glob("services/*/*.go"))`, res: false},
+		{expr: `files.filter(f, f.endsWith(".md"))`, res: []interface{}{"README.md"}},
+		{expr: `payload.commits.map(c, size(c.modified))`, res: []interface{}{int64(2)}},
+		{expr: `files.exists_one(f, f.matches("^services/"))`, res: true},
+		{expr: `size(files) == 2 ? "two" : "other"`, res: "two"},
+		{expr: `{"service": "api", "branch": params["git.branch"]}`, res: map[string]interface{}{"service": "api", "branch": "master"}},
+		{expr: `"ref" in payload`, res: true},
+		{expr: `int("42") + 1`, res: int64(43)},
+		{expr: `string(payload.size)`, res: "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			p, err := Parse(tt.expr)
+			require.NoError(t, err)
+			res, err := p.Eval(vars)
+			require.NoError(t, err)
+			assert.Equal(t, tt.res, res)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		``,
+		`payload.`,
+		`1 +`,
+		`"unterminated`,
+		`unknown(1)`,
+		`size(1, 2)`,
+		`files.exists("f", true)`,
+		`has(payload)`,
+		`(1 + 2`,
+		`1 2`,
+		`a # b`,
+	} {
+		_, err := Parse(expr)
+		assert.Error(t, err, "expression %q should be invalid", expr)
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	vars := map[string]interface{}{"payload": map[string]interface{}{"ref": "master"}}
+	for _, expr := range []string{
+		`unknown == 1`,
+		`payload.missing == "a"`,
+		`payload.ref + 1`,
+		`1 / 0`,
+		`payload.ref && true`,
+		`payload.ref.matches("[")`,
+	} {
+		p, err := Parse(expr)
+		require.NoError(t, err)
+		_, err = p.Eval(vars)
+		assert.Error(t, err, "expression %q should fail", expr)
+	}
+}
+
+func TestEvalStringMap(t *testing.T) {
+	p, err := Parse(`{"count": 2, "enabled": true, "files": ["a", "b"], "empty": null}`)
+	require.NoError(t, err)
+	res, err := p.EvalStringMap(nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"count": "2", "enabled": "true", "files": "a,b", "empty": ""}, res)
+
+	p, err = Parse(`[1]`)
+	require.NoError(t, err)
+	_, err = p.EvalStringMap(nil)
+	assert.Error(t, err)
+}
+
+func TestVariables(t *testing.T) {
+	p, err := Parse(`files.exists(f, glob(f, prefix + "**")) && payload.ref == params["git.branch"]`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"files", "params", "payload", "prefix"}, p.Variables())
+}
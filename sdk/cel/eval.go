@@ -0,0 +1,527 @@
+package cel
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// activation resolves the variables of an expression, variables declared by macros are resolved before the parent.
+type activation struct {
+	name   string
+	value  interface{}
+	vars   map[string]interface{}
+	parent *activation
+}
+
+func (a *activation) resolve(name string) (interface{}, bool) {
+	for ; a != nil; a = a.parent {
+		if a.vars == nil && a.name == name {
+			return a.value, true
+		}
+		if v, ok := a.vars[name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+type node interface {
+	eval(a *activation) (interface{}, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n literalNode) eval(*activation) (interface{}, error) {
+	return n.value, nil
+}
+
+type identNode struct {
+	name string
+}
+
+func (n identNode) eval(a *activation) (interface{}, error) {
+	v, ok := a.resolve(n.name)
+	if !ok {
+		return nil, fmt.Errorf("undeclared reference to '%s'", n.name)
+	}
+	return normalize(v), nil
+}
+
+type selectNode struct {
+	operand node
+	field   string
+}
+
+func (n selectNode) eval(a *activation) (interface{}, error) {
+	v, err := n.operand.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot select field '%s' on %s", n.field, typeName(v))
+	}
+	f, ok := m[n.field]
+	if !ok {
+		return nil, fmt.Errorf("no such key: %s", n.field)
+	}
+	return normalize(f), nil
+}
+
+type hasNode struct {
+	sel selectNode
+}
+
+func (n hasNode) eval(a *activation) (interface{}, error) {
+	v, err := n.sel.operand.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	_, ok = m[n.sel.field]
+	return ok, nil
+}
+
+type indexNode struct {
+	operand node
+	index   node
+}
+
+func (n indexNode) eval(a *activation) (interface{}, error) {
+	v, err := n.operand.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	i, err := n.index.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case []interface{}:
+		idx, ok := i.(int64)
+		if !ok {
+			return nil, fmt.Errorf("list index should be an int, not %s", typeName(i))
+		}
+		if idx < 0 || idx >= int64(len(v)) {
+			return nil, fmt.Errorf("index out of range: %d", idx)
+		}
+		return normalize(v[idx]), nil
+	case map[string]interface{}:
+		key, ok := i.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key should be a string, not %s", typeName(i))
+		}
+		f, ok := v[key]
+		if !ok {
+			return nil, fmt.Errorf("no such key: %s", key)
+		}
+		return normalize(f), nil
+	}
+	return nil, fmt.Errorf("cannot index %s", typeName(v))
+}
+
+type unaryNode struct {
+	op      string
+	operand node
+}
+
+func (n unaryNode) eval(a *activation) (interface{}, error) {
+	v, err := n.operand.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "!":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator ! expects a bool, not %s", typeName(v))
+		}
+		return !b, nil
+	default:
+		switch v := v.(type) {
+		case int64:
+			return -v, nil
+		case float64:
+			return -v, nil
+		}
+		return nil, fmt.Errorf("operator - expects a number, not %s", typeName(v))
+	}
+}
+
+type logicalNode struct {
+	or          bool
+	left, right node
+}
+
+func (n logicalNode) eval(a *activation) (interface{}, error) {
+	for _, operand := range []node{n.left, n.right} {
+		v, err := operand.eval(a)
+		if err != nil {
+			return nil, err
+		}
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("logical operators expect bools, not %s", typeName(v))
+		}
+		// Short circuit
+		if b == n.or {
+			return b, nil
+		}
+	}
+	return !n.or, nil
+}
+
+type conditionalNode struct {
+	cond, t, f node
+}
+
+func (n conditionalNode) eval(a *activation) (interface{}, error) {
+	v, err := n.cond.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("condition should be a bool, not %s", typeName(v))
+	}
+	if b {
+		return n.t.eval(a)
+	}
+	return n.f.eval(a)
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n binaryNode) eval(a *activation) (interface{}, error) {
+	l, err := n.left.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.eval(a)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(l, r), nil
+	case "!=":
+		return !equal(l, r), nil
+	case "in":
+		switch r := r.(type) {
+		case []interface{}:
+			for i := range r {
+				if equal(l, normalize(r[i])) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case map[string]interface{}:
+			key, ok := l.(string)
+			if !ok {
+				return false, nil
+			}
+			_, ok = r[key]
+			return ok, nil
+		}
+		return nil, fmt.Errorf("operator in expects a list or a map, not %s", typeName(r))
+	case "<", "<=", ">", ">=":
+		c, err := compare(l, r)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	}
+	return arithmetic(n.op, l, r)
+}
+
+type listNode struct {
+	elems []node
+}
+
+func (n listNode) eval(a *activation) (interface{}, error) {
+	res := make([]interface{}, len(n.elems))
+	for i := range n.elems {
+		v, err := n.elems[i].eval(a)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = v
+	}
+	return res, nil
+}
+
+type mapNode struct {
+	keys, values []node
+}
+
+func (n mapNode) eval(a *activation) (interface{}, error) {
+	res := make(map[string]interface{}, len(n.keys))
+	for i := range n.keys {
+		k, err := n.keys[i].eval(a)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key should be a string, not %s", typeName(k))
+		}
+		if _, ok := res[key]; ok {
+			return nil, fmt.Errorf("duplicate map key: %s", key)
+		}
+		v, err := n.values[i].eval(a)
+		if err != nil {
+			return nil, err
+		}
+		res[key] = v
+	}
+	return res, nil
+}
+
+type callNode struct {
+	name string
+	fn   func(args []interface{}) (interface{}, error)
+	args []node
+}
+
+func (n callNode) eval(a *activation) (interface{}, error) {
+	args := make([]interface{}, len(n.args))
+	for i := range n.args {
+		v, err := n.args[i].eval(a)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := n.fn(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", n.name, err)
+	}
+	return v, nil
+}
+
+type comprehensionNode struct {
+	macro    string
+	target   node
+	variable string
+	expr     node
+}
+
+func (n comprehensionNode) eval(a *activation) (interface{}, error) {
+	t, err := n.target.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	var items []interface{}
+	switch t := t.(type) {
+	case []interface{}:
+		items = t
+	case map[string]interface{}:
+		// Comprehensions on maps iterate over the keys
+		for k := range t {
+			items = append(items, k)
+		}
+	default:
+		return nil, fmt.Errorf("%s expects a list or a map, not %s", n.macro, typeName(t))
+	}
+
+	var res []interface{}
+	var count int
+	for _, item := range items {
+		v, err := n.expr.eval(&activation{name: n.variable, value: normalize(item), parent: a})
+		if err != nil {
+			return nil, err
+		}
+		if n.macro == "map" {
+			res = append(res, v)
+			continue
+		}
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s expects a predicate returning a bool, not %s", n.macro, typeName(v))
+		}
+		switch {
+		case n.macro == "exists" && b:
+			return true, nil
+		case n.macro == "all" && !b:
+			return false, nil
+		case n.macro == "filter" && b:
+			res = append(res, item)
+		}
+		if b {
+			count++
+		}
+	}
+
+	switch n.macro {
+	case "exists":
+		return false, nil
+	case "all":
+		return true, nil
+	case "exists_one":
+		return count == 1, nil
+	}
+	if res == nil {
+		res = []interface{}{}
+	}
+	return res, nil
+}
+
+// normalize converts the values given by the caller to the types handled by the evaluator: int64, float64, string,
+// bool, nil, []interface{} and map[string]interface{}.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, bool, int64, float64, string, []interface{}, map[string]interface{}:
+		return v
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	case []string:
+		res := make([]interface{}, len(v))
+		for i := range v {
+			res[i] = v[i]
+		}
+		return res
+	case map[string]string:
+		res := make(map[string]interface{}, len(v))
+		for k := range v {
+			res[k] = v[k]
+		}
+		return res
+	}
+	return v
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case int64:
+		return "int"
+	case float64:
+		return "double"
+	case string:
+		return "string"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func equal(l, r interface{}) bool {
+	if lf, ok := toFloat(l); ok {
+		rf, ok := toFloat(r)
+		return ok && lf == rf
+	}
+	return reflect.DeepEqual(l, r)
+}
+
+func compare(l, r interface{}) (int, error) {
+	if lf, ok := toFloat(l); ok {
+		if rf, ok := toFloat(r); ok {
+			switch {
+			case lf < rf:
+				return -1, nil
+			case lf > rf:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	if ls, ok := l.(string); ok {
+		if rs, ok := r.(string); ok {
+			switch {
+			case ls < rs:
+				return -1, nil
+			case ls > rs:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %s and %s", typeName(l), typeName(r))
+}
+
+func arithmetic(op string, l, r interface{}) (interface{}, error) {
+	switch l := l.(type) {
+	case string:
+		if r, ok := r.(string); ok && op == "+" {
+			return l + r, nil
+		}
+	case []interface{}:
+		if r, ok := r.([]interface{}); ok && op == "+" {
+			return append(append([]interface{}{}, l...), r...), nil
+		}
+	case int64:
+		if r, ok := r.(int64); ok {
+			switch op {
+			case "+":
+				return l + r, nil
+			case "-":
+				return l - r, nil
+			case "*":
+				return l * r, nil
+			case "/", "%":
+				if r == 0 {
+					return nil, fmt.Errorf("division by zero")
+				}
+				if op == "/" {
+					return l / r, nil
+				}
+				return l % r, nil
+			}
+		}
+	}
+	lf, lok := toFloat(l)
+	rf, rok := toFloat(r)
+	if lok && rok {
+		switch op {
+		case "+":
+			return lf + rf, nil
+		case "-":
+			return lf - rf, nil
+		case "*":
+			return lf * rf, nil
+		case "/":
+			return lf / rf, nil
+		case "%":
+			return math.Mod(lf, rf), nil
+		}
+	}
+	return nil, fmt.Errorf("operator %s doesn't support %s and %s", op, typeName(l), typeName(r))
+}
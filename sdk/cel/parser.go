@@ -0,0 +1,365 @@
+package cel
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isPunct(values ...string) bool {
+	t := p.peek()
+	if t.kind != tokenPunct {
+		return false
+	}
+	for _, v := range values {
+		if t.value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(value string) error {
+	t := p.next()
+	if t.kind != tokenPunct || t.value != value {
+		return fmt.Errorf("expected '%s' but found %s at position %d", value, t, t.pos)
+	}
+	return nil
+}
+
+// expr = or ["?" expr ":" expr]
+func (p *parser) parseExpr() (node, error) {
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isPunct("?") {
+		return cond, nil
+	}
+	p.next()
+	t, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	f, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return conditionalNode{cond: cond, t: t, f: f}, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{or: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseRelation()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("&&") {
+		p.next()
+		right, err := p.parseRelation()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseRelation() (node, error) {
+	left, err := p.parseAddition()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op string
+		switch t := p.peek(); {
+		case p.isPunct("==", "!=", "<", "<=", ">", ">="):
+			op = t.value
+		case t.kind == tokenIdent && t.value == "in":
+			op = t.value
+		default:
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseAddition()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseAddition() (node, error) {
+	left, err := p.parseMultiplication()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("+", "-") {
+		op := p.next().value
+		right, err := p.parseMultiplication()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplication() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("*", "/", "%") {
+		op := p.next().value
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isPunct("!", "-") {
+		op := p.next().value
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{op: op, operand: operand}, nil
+	}
+	return p.parseMember()
+}
+
+func (p *parser) parseMember() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.isPunct("."):
+			p.next()
+			t := p.next()
+			if t.kind != tokenIdent {
+				return nil, fmt.Errorf("expected a field name but found %s at position %d", t, t.pos)
+			}
+			if !p.isPunct("(") {
+				n = selectNode{operand: n, field: t.value}
+				continue
+			}
+			args, err := p.parseArgs(")")
+			if err != nil {
+				return nil, err
+			}
+			n, err = newCallNode(t, n, args)
+			if err != nil {
+				return nil, err
+			}
+		case p.isPunct("["):
+			p.next()
+			index, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			n = indexNode{operand: n, index: index}
+		default:
+			return n, nil
+		}
+	}
+}
+
+// parseArgs reads a list of expressions separated by commas, the opening token should be the next token.
+func (p *parser) parseArgs(closing string) ([]node, error) {
+	p.next()
+	var args []node
+	if p.isPunct(closing) {
+		p.next()
+		return args, nil
+	}
+	for {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.isPunct(",") {
+			p.next()
+			continue
+		}
+		if err := p.expect(closing); err != nil {
+			return nil, err
+		}
+		return args, nil
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenInt:
+		p.next()
+		i, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %s at position %d", t.value, t.pos)
+		}
+		return literalNode{value: i}, nil
+	case tokenFloat:
+		p.next()
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s at position %d", t.value, t.pos)
+		}
+		return literalNode{value: f}, nil
+	case tokenString:
+		p.next()
+		return literalNode{value: t.value}, nil
+	case tokenIdent:
+		p.next()
+		switch t.value {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "null":
+			return literalNode{value: nil}, nil
+		}
+		if !p.isPunct("(") {
+			return identNode{name: t.value}, nil
+		}
+		args, err := p.parseArgs(")")
+		if err != nil {
+			return nil, err
+		}
+		return newCallNode(t, nil, args)
+	case tokenPunct:
+		switch t.value {
+		case "(":
+			p.next()
+			n, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "[":
+			elems, err := p.parseArgs("]")
+			if err != nil {
+				return nil, err
+			}
+			return listNode{elems: elems}, nil
+		case "{":
+			return p.parseMap()
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s at position %d", t, t.pos)
+}
+
+func (p *parser) parseMap() (node, error) {
+	p.next()
+	var m mapNode
+	if p.isPunct("}") {
+		p.next()
+		return m, nil
+	}
+	for {
+		key, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		m.keys = append(m.keys, key)
+		m.values = append(m.values, value)
+		if p.isPunct(",") {
+			p.next()
+			if p.isPunct("}") {
+				p.next()
+				return m, nil
+			}
+			continue
+		}
+		if err := p.expect("}"); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+}
+
+// newCallNode checks the name and the arity of a function or a macro call.
+func newCallNode(t token, target node, args []node) (node, error) {
+	if m, ok := macros[t.value]; ok && (target != nil) == m.method {
+		if len(args) != m.args {
+			return nil, fmt.Errorf("%s expects %d arguments at position %d", t.value, m.args, t.pos)
+		}
+		if t.value == "has" {
+			if _, ok := args[0].(selectNode); !ok {
+				return nil, fmt.Errorf("has expects a field selection at position %d", t.pos)
+			}
+			return hasNode{sel: args[0].(selectNode)}, nil
+		}
+		v, ok := args[0].(identNode)
+		if !ok {
+			return nil, fmt.Errorf("%s expects a variable name as first argument at position %d", t.value, t.pos)
+		}
+		return comprehensionNode{macro: t.value, target: target, variable: v.name, expr: args[1]}, nil
+	}
+
+	f, ok := functions[t.value]
+	if !ok {
+		return nil, fmt.Errorf("undeclared function %s at position %d", t.value, t.pos)
+	}
+	if target != nil {
+		args = append([]node{target}, args...)
+	}
+	if len(args) != f.args {
+		return nil, fmt.Errorf("%s expects %d arguments at position %d", t.value, f.args, t.pos)
+	}
+	return callNode{name: t.value, fn: f.fn, args: args}, nil
+}
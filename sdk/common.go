@@ -39,6 +39,14 @@ var (
 // EncryptFunc is a common type
 type EncryptFunc func(gorp.SqlExecutor, int64, string, string) (string, error)
 
+// SealedSecretPrefix prefixes secrets exported in a sealed form, they contain the secret encrypted with the builtin
+// key of the project where they can be imported instead of a reference to the encrypted data.
+const SealedSecretPrefix = "sealed:"
+
+// ExportSealPublicKeyHeader is the header used to give the base64 encoded builtin public key of the project for
+// which secrets should be sealed during an export.
+const ExportSealPublicKeyHeader = "X-Cds-Seal-Public-Key"
+
 // IDName is generally used when you want to get basic informations from db
 type IDName struct {
 	ID          int64   `json:"id" db:"id"`
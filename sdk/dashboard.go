@@ -0,0 +1,177 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Dashboard widget types.
+const (
+	DashboardWidgetLatestRuns       = "latest_runs"
+	DashboardWidgetQueueDepth       = "queue_depth"
+	DashboardWidgetFailingWorkflows = "failing_workflows"
+)
+
+// DashboardWidgetTypes list.
+var DashboardWidgetTypes = []string{
+	DashboardWidgetLatestRuns,
+	DashboardWidgetQueueDepth,
+	DashboardWidgetFailingWorkflows,
+}
+
+// Dashboard limits.
+const (
+	DashboardMaxWidgets         = 20
+	DashboardWidgetDefaultLimit = 5
+	DashboardWidgetMaxLimit     = 50
+	DashboardWidgetMaxWorkflows = 20
+	DashboardWidgetMaxProjects  = 50
+)
+
+// DashboardWorkflow identifies a workflow displayed by a dashboard widget.
+type DashboardWorkflow struct {
+	ProjectKey   string `json:"project_key" yaml:"project_key"`
+	WorkflowName string `json:"workflow_name" yaml:"workflow_name"`
+}
+
+// DashboardWidget is a block of a dashboard. Workflows are used by latest_runs widgets, projects by queue_depth and
+// failing_workflows widgets; without projects, all the projects of the user are used.
+type DashboardWidget struct {
+	Type      string              `json:"type" yaml:"type"`
+	Title     string              `json:"title,omitempty" yaml:"title,omitempty"`
+	Workflows []DashboardWorkflow `json:"workflows,omitempty" yaml:"workflows,omitempty"`
+	Projects  []string            `json:"projects,omitempty" yaml:"projects,omitempty"`
+	Limit     int                 `json:"limit,omitempty" yaml:"limit,omitempty"`
+}
+
+// IsValid returns an error if the widget is not valid.
+func (w DashboardWidget) IsValid() error {
+	if w.Limit < 0 || w.Limit > DashboardWidgetMaxLimit {
+		return NewErrorFrom(ErrWrongRequest, "invalid given limit for widget, it should be between 0 and %d", DashboardWidgetMaxLimit)
+	}
+	switch w.Type {
+	case DashboardWidgetLatestRuns:
+		if len(w.Workflows) == 0 {
+			return NewErrorFrom(ErrWrongRequest, "a %s widget should contain at least one workflow", w.Type)
+		}
+		if len(w.Workflows) > DashboardWidgetMaxWorkflows {
+			return NewErrorFrom(ErrWrongRequest, "a %s widget can't contain more than %d workflows", w.Type, DashboardWidgetMaxWorkflows)
+		}
+		for _, wf := range w.Workflows {
+			if wf.ProjectKey == "" || wf.WorkflowName == "" {
+				return NewErrorFrom(ErrWrongRequest, "invalid given workflow for widget, project key and workflow name are mandatory")
+			}
+		}
+		if len(w.Projects) > 0 {
+			return NewErrorFrom(ErrWrongRequest, "a %s widget can't contain projects", w.Type)
+		}
+	case DashboardWidgetQueueDepth, DashboardWidgetFailingWorkflows:
+		if len(w.Workflows) > 0 {
+			return NewErrorFrom(ErrWrongRequest, "a %s widget can't contain workflows", w.Type)
+		}
+		if len(w.Projects) > DashboardWidgetMaxProjects {
+			return NewErrorFrom(ErrWrongRequest, "a %s widget can't contain more than %d projects", w.Type, DashboardWidgetMaxProjects)
+		}
+		for _, key := range w.Projects {
+			if key == "" {
+				return NewErrorFrom(ErrWrongRequest, "invalid given project key for widget")
+			}
+		}
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid given widget type %s, it should be one of %s", w.Type, strings.Join(DashboardWidgetTypes, ", "))
+	}
+	return nil
+}
+
+// GetLimit returns the max number of items displayed by the widget.
+func (w DashboardWidget) GetLimit() int {
+	if w.Limit == 0 {
+		return DashboardWidgetDefaultLimit
+	}
+	return w.Limit
+}
+
+// DashboardWidgets is a slice of widgets stored as json.
+type DashboardWidgets []DashboardWidget
+
+// Value returns driver.Value from dashboard widgets.
+func (ws DashboardWidgets) Value() (driver.Value, error) {
+	j, err := json.Marshal(ws)
+	return j, WrapError(err, "cannot marshal DashboardWidgets")
+}
+
+// Scan dashboard widgets.
+func (ws *DashboardWidgets) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, ws), "cannot unmarshal DashboardWidgets")
+}
+
+// Dashboard is a set of widgets composed by a user.
+type Dashboard struct {
+	ID                 int64            `json:"id" db:"id" cli:"id,key" yaml:"-"`
+	AuthentifiedUserID string           `json:"-" db:"authentified_user_id" yaml:"-"`
+	Name               string           `json:"name" db:"name" cli:"name" yaml:"name"`
+	Widgets            DashboardWidgets `json:"widgets" db:"widgets" yaml:"widgets"`
+	Created            time.Time        `json:"created" db:"created" cli:"created" yaml:"-"`
+	LastModified       time.Time        `json:"last_modified" db:"last_modified" yaml:"-"`
+}
+
+// IsValid returns an error if the dashboard is not valid.
+func (d Dashboard) IsValid() error {
+	if d.Name == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid given name for dashboard")
+	}
+	if len(d.Widgets) > DashboardMaxWidgets {
+		return NewErrorFrom(ErrWrongRequest, "a dashboard can't contain more than %d widgets", DashboardMaxWidgets)
+	}
+	for i := range d.Widgets {
+		if err := d.Widgets[i].IsValid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DashboardData is a dashboard with the data of its widgets, in the same order.
+type DashboardData struct {
+	Dashboard Dashboard             `json:"dashboard"`
+	Widgets   []DashboardWidgetData `json:"widgets"`
+}
+
+// DashboardWidgetData is the data computed for a widget. If the widget can't be computed, for example because
+// the user lost the permission on a project, Error is set.
+type DashboardWidgetData struct {
+	Widget           DashboardWidget       `json:"widget"`
+	Error            string                `json:"error,omitempty"`
+	Runs             []DashboardRun        `json:"runs,omitempty"`
+	QueueDepth       []DashboardQueueDepth `json:"queue_depth,omitempty"`
+	FailingWorkflows []DashboardRun        `json:"failing_workflows,omitempty"`
+}
+
+// DashboardRun is a summary of a workflow run displayed by a widget.
+type DashboardRun struct {
+	ProjectKey   string    `json:"project_key" db:"project_key" cli:"project"`
+	WorkflowName string    `json:"workflow_name" db:"workflow_name" cli:"workflow"`
+	Number       int64     `json:"num" db:"num" cli:"num"`
+	Status       string    `json:"status" db:"status" cli:"status"`
+	Branch       string    `json:"branch,omitempty" db:"branch" cli:"branch"`
+	Start        time.Time `json:"start" db:"start" cli:"start"`
+	LastModified time.Time `json:"last_modified" db:"last_modified"`
+}
+
+// DashboardQueueDepth is the number of jobs waiting or building for a project.
+type DashboardQueueDepth struct {
+	ProjectKey string `json:"project_key" cli:"project"`
+	Waiting    int64  `json:"waiting" cli:"waiting"`
+	Building   int64  `json:"building" cli:"building"`
+}
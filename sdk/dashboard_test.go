@@ -0,0 +1,46 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboardIsValid(t *testing.T) {
+	d := Dashboard{
+		Name: "my-dashboard",
+		Widgets: DashboardWidgets{
+			{Type: DashboardWidgetLatestRuns, Workflows: []DashboardWorkflow{{ProjectKey: "PROJ", WorkflowName: "wf"}}},
+			{Type: DashboardWidgetQueueDepth},
+			{Type: DashboardWidgetFailingWorkflows, Projects: []string{"PROJ"}, Limit: 10},
+		},
+	}
+	assert.NoError(t, d.IsValid())
+	assert.Equal(t, DashboardWidgetDefaultLimit, d.Widgets[0].GetLimit())
+	assert.Equal(t, 10, d.Widgets[2].GetLimit())
+
+	invalids := []DashboardWidget{
+		{Type: "unknown"},
+		{Type: DashboardWidgetLatestRuns},
+		{Type: DashboardWidgetLatestRuns, Workflows: []DashboardWorkflow{{ProjectKey: "PROJ"}}},
+		{Type: DashboardWidgetLatestRuns, Workflows: []DashboardWorkflow{{ProjectKey: "PROJ", WorkflowName: "wf"}}, Projects: []string{"PROJ"}},
+		{Type: DashboardWidgetQueueDepth, Workflows: []DashboardWorkflow{{ProjectKey: "PROJ", WorkflowName: "wf"}}},
+		{Type: DashboardWidgetQueueDepth, Projects: []string{""}},
+		{Type: DashboardWidgetFailingWorkflows, Limit: DashboardWidgetMaxLimit + 1},
+	}
+	for _, w := range invalids {
+		assert.Error(t, w.IsValid(), "widget %+v should be invalid", w)
+	}
+
+	assert.Error(t, Dashboard{Widgets: d.Widgets}.IsValid())
+}
+
+func TestDashboardWidgetsScan(t *testing.T) {
+	ws := DashboardWidgets{{Type: DashboardWidgetQueueDepth, Projects: []string{"PROJ"}}}
+	v, err := ws.Value()
+	assert.NoError(t, err)
+
+	var res DashboardWidgets
+	assert.NoError(t, res.Scan(v))
+	assert.Equal(t, ws, res)
+}
@@ -0,0 +1,67 @@
+package sdk
+
+import (
+	"time"
+)
+
+// EnvironmentIntegration binds an environment to the deployment integration used to deploy on it.
+type EnvironmentIntegration struct {
+	ID                     int64  `json:"id" db:"id"`
+	EnvironmentID          int64  `json:"environment_id" db:"environment_id"`
+	ProjectIntegrationID   int64  `json:"project_integration_id" db:"project_integration_id"`
+	ProjectIntegrationName string `json:"project_integration_name" db:"-"`
+}
+
+// EnvironmentDeployment records a version of an application deployed on an environment by a workflow run.
+type EnvironmentDeployment struct {
+	ID                     int64     `json:"id" db:"id"`
+	EnvironmentID          int64     `json:"environment_id" db:"environment_id"`
+	ApplicationID          int64     `json:"application_id" db:"application_id"`
+	ProjectIntegrationID   int64     `json:"project_integration_id" db:"project_integration_id"`
+	WorkflowID             int64     `json:"workflow_id" db:"workflow_id"`
+	WorkflowNodeID         int64     `json:"workflow_node_id" db:"workflow_node_id"`
+	WorkflowRunID          int64     `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowNodeRunID      int64     `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	Number                 int64     `json:"num" db:"num"`
+	SubNumber              int64     `json:"subnumber" db:"sub_num"`
+	Version                string    `json:"version" db:"version"`
+	VCSHash                string    `json:"vcs_hash" db:"vcs_hash"`
+	Deployed               time.Time `json:"deployed" db:"deployed"`
+	Status                 string    `json:"status" db:"status"`
+	EnvironmentName        string    `json:"environment_name" db:"-"`
+	ApplicationName        string    `json:"application_name" db:"-"`
+	ProjectIntegrationName string    `json:"project_integration_name" db:"-"`
+	WorkflowName           string    `json:"workflow_name" db:"-"`
+}
+
+// EnvironmentPromotion is the body of a request to promote the version deployed on an environment to another one.
+type EnvironmentPromotion struct {
+	From            string `json:"from"`
+	To              string `json:"to"`
+	ApplicationName string `json:"application_name,omitempty"`
+}
+
+// IsValid returns an error if the promotion is not valid.
+func (p EnvironmentPromotion) IsValid() error {
+	if p.From == "" || p.To == "" {
+		return NewErrorFrom(ErrWrongRequest, "source and target environments are mandatory")
+	}
+	if p.From == p.To {
+		return NewErrorFrom(ErrWrongRequest, "source and target environments should be different")
+	}
+	return nil
+}
+
+// EnvironmentDeploymentStatus is the body of a deployment status callback sent by a deployment integration.
+type EnvironmentDeploymentStatus struct {
+	Status string `json:"status"`
+}
+
+// IsValid returns an error if the deployment status is not valid.
+func (s EnvironmentDeploymentStatus) IsValid() error {
+	switch s.Status {
+	case StatusBuilding, StatusSuccess, StatusFail:
+		return nil
+	}
+	return NewErrorFrom(ErrWrongRequest, "invalid deployment status %q, should be %s, %s or %s", s.Status, StatusBuilding, StatusSuccess, StatusFail)
+}
@@ -200,6 +200,18 @@ var (
 	ErrWorkflowAsCodeResync                          = Error{ID: 186, Status: http.StatusForbidden}
 	ErrWorkflowNodeNameDuplicate                     = Error{ID: 187, Status: http.StatusBadRequest}
 	ErrUnsupportedMediaType                          = Error{ID: 188, Status: http.StatusUnsupportedMediaType}
+	ErrInvalidJobRequirementDuplicateOSArch          = Error{ID: 189, Status: http.StatusBadRequest}
+	ErrInvalidJobRequirementOSArch                   = Error{ID: 190, Status: http.StatusBadRequest}
+	ErrInvalidJobRequirementWorkerModelOSArch        = Error{ID: 191, Status: http.StatusBadRequest}
+	ErrInvalidIntegrationSignature                   = Error{ID: 192, Status: http.StatusUnauthorized}
+	ErrInvalidWebHookSignature                       = Error{ID: 193, Status: http.StatusUnauthorized}
+	ErrProjectQuotaExceeded                          = Error{ID: 194, Status: http.StatusForbidden}
+	ErrIdempotencyKeyMismatch                        = Error{ID: 195, Status: http.StatusUnprocessableEntity}
+	ErrIdempotencyKeyInProgress                      = Error{ID: 196, Status: http.StatusConflict}
+	ErrWorkerVersionOutdated                         = Error{ID: 197, Status: http.StatusBadRequest}
+	ErrJobHeldByMaintenanceWindow                    = Error{ID: 198, Status: http.StatusConflict}
+	ErrJobWaitingRetry                               = Error{ID: 199, Status: http.StatusConflict}
+	ErrArtifactQuarantined                           = Error{ID: 200, Status: http.StatusForbidden}
 )
 
 var errorsAmericanEnglish = map[int]string{
@@ -382,6 +394,18 @@ var errorsAmericanEnglish = map[int]string{
 	ErrWorkflowAsCodeResync.ID:                          "You cannot resynchronize an as-code workflow",
 	ErrWorkflowNodeNameDuplicate.ID:                     "You cannot have same name for different pipelines in your workflow",
 	ErrUnsupportedMediaType.ID:                          "Request format invalid",
+	ErrInvalidJobRequirementDuplicateOSArch.ID:          "Invalid job requirements: you can't select multiple os-architecture",
+	ErrInvalidJobRequirementOSArch.ID:                   "Invalid job requirement: unsupported os-architecture value",
+	ErrInvalidJobRequirementWorkerModelOSArch.ID:        "Invalid job requirements: os-architecture doesn't match the one of the worker model",
+	ErrInvalidIntegrationSignature.ID:                   "Invalid integration signature",
+	ErrInvalidWebHookSignature.ID:                       "Invalid webhook signature",
+	ErrProjectQuotaExceeded.ID:                          "Project quota exceeded",
+	ErrIdempotencyKeyMismatch.ID:                        "Idempotency key already used for another request",
+	ErrIdempotencyKeyInProgress.ID:                      "A request with the same idempotency key is in progress",
+	ErrWorkerVersionOutdated.ID:                         "The worker binary is outdated and does not support this job",
+	ErrJobHeldByMaintenanceWindow.ID:                    "The job is held by a maintenance window",
+	ErrJobWaitingRetry.ID:                               "The job is waiting before a new attempt after an infrastructure error",
+	ErrArtifactQuarantined.ID:                           "The artifact is infected and was quarantined",
 }
 
 var errorsFrench = map[int]string{
@@ -564,6 +588,18 @@ var errorsFrench = map[int]string{
 	ErrWorkflowAsCodeResync.ID:                          "Impossible de resynchroniser un workflow en mode as-code",
 	ErrWorkflowNodeNameDuplicate.ID:                     "Vous ne pouvez pas avoir plusieurs fois le même nom de pipeline dans votre workflow",
 	ErrUnsupportedMediaType.ID:                          "Le format de la requête est invalide",
+	ErrInvalidJobRequirementDuplicateOSArch.ID:          "Pré-requis de job invalides: vous ne pouvez pas séléctionnez plusieurs os-architecture",
+	ErrInvalidJobRequirementOSArch.ID:                   "Pré-requis de job invalide: valeur d'os-architecture non supportée",
+	ErrInvalidJobRequirementWorkerModelOSArch.ID:        "Pré-requis de job invalides: l'os-architecture ne correspond pas à celle du modèle de worker",
+	ErrInvalidIntegrationSignature.ID:                   "Signature d'intégration invalide",
+	ErrInvalidWebHookSignature.ID:                       "Signature du webhook invalide",
+	ErrProjectQuotaExceeded.ID:                          "Quota du projet dépassé",
+	ErrIdempotencyKeyMismatch.ID:                        "Clé d'idempotence déjà utilisée pour une autre requête",
+	ErrIdempotencyKeyInProgress.ID:                      "Une requête avec la même clé d'idempotence est en cours",
+	ErrWorkerVersionOutdated.ID:                         "Le binaire du worker est obsolète et ne supporte pas ce job",
+	ErrJobHeldByMaintenanceWindow.ID:                    "Le job est retenu par une fenêtre de maintenance",
+	ErrJobWaitingRetry.ID:                               "Le job attend avant une nouvelle tentative suite à une erreur d'infrastructure",
+	ErrArtifactQuarantined.ID:                           "L'artefact est infecté et a été mis en quarantaine",
 }
 
 var errorsLanguages = []map[int]string{
@@ -30,6 +30,18 @@ type Event struct {
 	EventIntegrationsID []int64          `json:"event_integrations_id"`
 }
 
+// IsAudit returns true if the event describes a change made on a CDS entity (project, integration, permission, consumer...).
+// Workflow run, job and engine events are not audit events.
+func (e Event) IsAudit() bool {
+	switch e.EventType {
+	case "sdk.EventRunWorkflow", "sdk.EventRunWorkflowNode", "sdk.EventRunWorkflowJob", "sdk.EventRunWorkflowOutgoingHook",
+		"sdk.EventJob", "sdk.EventEngine", "sdk.EventNotif", "sdk.EventAsCodeEvent",
+		"sdk.EventWarningAdd", "sdk.EventWarningUpdate", "sdk.EventWarningDelete":
+		return false
+	}
+	return true
+}
+
 // EventFilter represents filters when getting events
 type EventFilter struct {
 	CurrentItem int            `json:"current_item"`
@@ -78,6 +90,19 @@ type EventRunWorkflowNode struct {
 	NodeType              string                    `json:"node_type,omitempty"`
 	GerritChange          *GerritChangeEvent        `json:"gerrit_change,omitempty"`
 	EventIntegrations     []int64                   `json:"event_integrations_id,omitempty"`
+	IntegrationName       string                    `json:"integration_name,omitempty"`
+	IntegrationType       string                    `json:"integration_type,omitempty"`
+	Commits               []VCSCommit               `json:"commits,omitempty"`
+}
+
+// EventRunWorkflowNodeMutex contains event data when a workflow node run acquires the mutex of its node
+type EventRunWorkflowNodeMutex struct {
+	NodeName          string `json:"node_name"`
+	WorkflowRunID     int64  `json:"workflow_run_id"`
+	Number            int64  `json:"num"`
+	SubNumber         int64  `json:"subnum"`
+	WorkflowNodeRunID int64  `json:"workflow_node_run_id"`
+	WaitingDuration   int64  `json:"waiting_duration"`
 }
 
 // GerritChangeEvent Gerrit information that are needed on event
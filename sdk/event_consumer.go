@@ -0,0 +1,24 @@
+package sdk
+
+// EventConsumerAdd represents the event when adding a builtin consumer
+type EventConsumerAdd struct {
+	ConsumerID    string                    `json:"consumer_id"`
+	Name          string                    `json:"name"`
+	ParentID      string                    `json:"parent_id,omitempty"`
+	GroupIDs      []int64                   `json:"group_ids,omitempty"`
+	ScopeDetails  AuthConsumerScopeDetails  `json:"scope_details,omitempty"`
+	ProjectScopes AuthConsumerProjectScopes `json:"project_scopes,omitempty"`
+}
+
+// EventConsumerRegen represents the event when regenerating the signin token of a builtin consumer
+type EventConsumerRegen struct {
+	ConsumerID     string `json:"consumer_id"`
+	Name           string `json:"name"`
+	RevokeSessions bool   `json:"revoke_sessions"`
+}
+
+// EventConsumerDelete represents the event when deleting a builtin consumer
+type EventConsumerDelete struct {
+	ConsumerID string `json:"consumer_id"`
+	Name       string `json:"name"`
+}
@@ -87,3 +87,19 @@ type EventProjectIntegrationUpdate struct {
 type EventProjectIntegrationDelete struct {
 	Integration ProjectIntegration `json:"integration"`
 }
+
+// EventProjectQuotaUpdate represents the event when updating the quotas of a project
+type EventProjectQuotaUpdate struct {
+	OldQuota ProjectQuota `json:"old_quota"`
+	NewQuota ProjectQuota `json:"new_quota"`
+}
+
+// EventProjectQuota represents the event when the usage of a project quota reaches its warning threshold or exceeds
+// its limit
+type EventProjectQuota struct {
+	Quota    string `json:"quota"`
+	Level    string `json:"level"`
+	Usage    int64  `json:"usage"`
+	Limit    int64  `json:"limit"`
+	HardStop bool   `json:"hard_stop"`
+}
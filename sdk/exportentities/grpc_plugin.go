@@ -10,6 +10,7 @@ type GRPCPlugin struct {
 	Author      string                    `json:"author" yaml:"author" cli:"author"`
 	Description string                    `json:"description" yaml:"description" cli:"description"`
 	Parameters  map[string]ParameterValue `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Contract    *sdk.GRPCPluginContract   `json:"contract,omitempty" yaml:"contract,omitempty"`
 }
 
 // NewGRPCPlugin returns a ready to export action
@@ -19,6 +20,7 @@ func NewGRPCPlugin(p sdk.GRPCPlugin) (plg GRPCPlugin) {
 	plg.Integration = p.Integration
 	plg.Author = p.Author
 	plg.Description = p.Description
+	plg.Contract = p.Contract
 	plg.Parameters = make(map[string]ParameterValue, len(p.Parameters))
 	for k, v := range p.Parameters {
 		param := ParameterValue{
@@ -43,6 +45,7 @@ func (plg *GRPCPlugin) GRPCPlugin() *sdk.GRPCPlugin {
 	p.Integration = plg.Integration
 	p.Author = plg.Author
 	p.Description = plg.Description
+	p.Contract = plg.Contract
 
 	//Compute parameters
 	p.Parameters = make([]sdk.Parameter, len(plg.Parameters))
@@ -0,0 +1,318 @@
+package exportentities
+
+import (
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Directives that can be used in cds files to reuse fragments from other files.
+const (
+	// IncludeKey can be set in any mapping to merge the content of one or many fragments in it,
+	// or as the only key of a list item to insert the fragment items in the list.
+	IncludeKey = "include"
+	// ExtendsKey can be set at the root of a file to use one or many files as base for it.
+	ExtendsKey = "extends"
+
+	// FragmentSuffix is the suffix of files that can only be included or extended.
+	FragmentSuffix = ".fragment"
+
+	maxIncludeDepth = 10
+)
+
+// FragmentLoader returns all the cds files of given repository, it is used to resolve
+// references to a central template repository (ie. "my-org/cds-templates:go/build.fragment.yml").
+type FragmentLoader func(repository string) (map[string][]byte, error)
+
+// IsFragment returns true if given file is a fragment that should not be imported as is.
+func IsFragment(filename string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(filename, path.Ext(filename)), FragmentSuffix)
+}
+
+// ResolveIncludes resolves include and extends directives for all given yaml files and returns
+// the flattened files. Fragments files are removed from the result and files without directives
+// are kept untouched. Override rules are the following: mappings are merged recursively, the value
+// from the including file is kept for scalars and lists, a key with a null value is removed.
+func ResolveIncludes(files map[string][]byte, loader FragmentLoader) (map[string][]byte, error) {
+	r := includeResolver{
+		files:  files,
+		loader: loader,
+		remote: make(map[string]map[string][]byte),
+	}
+
+	res := make(map[string][]byte, len(files))
+	for name, content := range files {
+		if IsFragment(name) {
+			continue
+		}
+		if format, err := GetFormatFromPath(name); err != nil || format != FormatYAML {
+			res[name] = content
+			continue
+		}
+
+		ref := fragmentReference{path: name}
+		r.resolved = false
+		doc, err := r.resolveDocument(content, ref, nil)
+		if err != nil {
+			return nil, err
+		}
+		if !r.resolved {
+			res[name] = content
+			continue
+		}
+
+		btes, err := yaml.Marshal(doc)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to marshal resolved file %s", name)
+		}
+		res[name] = btes
+	}
+
+	return res, nil
+}
+
+type fragmentReference struct {
+	repository string
+	path       string
+}
+
+func (f fragmentReference) String() string {
+	if f.repository == "" {
+		return f.path
+	}
+	return f.repository + ":" + f.path
+}
+
+// parseFragmentReference returns the reference for given value, relative to the current file.
+// A reference could be a path relative to the current file, a path from the root of the current
+// repository when starting with "/", or a path in another repository like "my-org/my-repo:path".
+func parseFragmentReference(current fragmentReference, value string) (fragmentReference, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fragmentReference{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid empty reference in %s", current)
+	}
+
+	if i := strings.Index(value, ":"); i >= 0 {
+		repo, p := value[:i], value[i+1:]
+		if repo == "" || p == "" {
+			return fragmentReference{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid reference %s in %s", value, current)
+		}
+		return fragmentReference{repository: repo, path: path.Clean(strings.TrimPrefix(p, "/"))}, nil
+	}
+
+	if strings.HasPrefix(value, "/") {
+		return fragmentReference{repository: current.repository, path: path.Clean(strings.TrimPrefix(value, "/"))}, nil
+	}
+	return fragmentReference{repository: current.repository, path: path.Join(path.Dir(current.path), value)}, nil
+}
+
+type includeResolver struct {
+	files    map[string][]byte
+	loader   FragmentLoader
+	remote   map[string]map[string][]byte
+	resolved bool
+}
+
+func (r *includeResolver) content(ref fragmentReference) ([]byte, error) {
+	files := r.files
+	if ref.repository != "" {
+		var ok bool
+		files, ok = r.remote[ref.repository]
+		if !ok {
+			if r.loader == nil {
+				return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot load %s: references to other repositories are not supported here", ref)
+			}
+			var err error
+			files, err = r.loader(ref.repository)
+			if err != nil {
+				return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot load repository %s", ref.repository))
+			}
+			r.remote[ref.repository] = files
+		}
+	}
+
+	// Paths from the root of the .cds directory are also accepted
+	for _, p := range []string{ref.path, path.Join(".cds", ref.path)} {
+		if btes, ok := files[p]; ok {
+			return btes, nil
+		}
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot find file %s", ref)
+}
+
+func (r *includeResolver) resolveDocument(content []byte, ref fragmentReference, stack []string) (interface{}, error) {
+	for _, s := range stack {
+		if s == ref.String() {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "include cycle detected: %s -> %s", strings.Join(stack, " -> "), ref)
+		}
+	}
+	if len(stack) > maxIncludeDepth {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "too many nested includes in %s", stack[0])
+	}
+	stack = append(stack, ref.String())
+
+	var doc interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot unmarshal %s as yaml", ref))
+	}
+
+	m, ok := doc.(map[interface{}]interface{})
+	if !ok {
+		return r.resolveNode(doc, ref, stack)
+	}
+
+	extends, ok := m[ExtendsKey]
+	if !ok {
+		return r.resolveNode(doc, ref, stack)
+	}
+	r.resolved = true
+	delete(m, ExtendsKey)
+
+	base, err := r.loadFragments(extends, ref, stack)
+	if err != nil {
+		return nil, err
+	}
+	doc, err = r.resolveNode(m, ref, stack)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range base {
+		if _, ok := b.(map[interface{}]interface{}); !ok {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "file extended by %s should be a mapping", ref)
+		}
+	}
+	return mergeFragments(append(base, doc)...), nil
+}
+
+// loadFragments returns resolved fragments for given include or extends value.
+func (r *includeResolver) loadFragments(value interface{}, ref fragmentReference, stack []string) ([]interface{}, error) {
+	var values []string
+	switch v := value.(type) {
+	case string:
+		values = []string{v}
+	case []interface{}:
+		for _, i := range v {
+			s, ok := i.(string)
+			if !ok {
+				return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid reference %v in %s", i, ref)
+			}
+			values = append(values, s)
+		}
+	default:
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid reference %v in %s", value, ref)
+	}
+
+	res := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		fragmentRef, err := parseFragmentReference(ref, v)
+		if err != nil {
+			return nil, err
+		}
+		content, err := r.content(fragmentRef)
+		if err != nil {
+			return nil, err
+		}
+		fragment, err := r.resolveDocument(content, fragmentRef, stack)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, fragment)
+	}
+	return res, nil
+}
+
+func (r *includeResolver) resolveNode(node interface{}, ref fragmentReference, stack []string) (interface{}, error) {
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		var base []interface{}
+		if include, ok := n[IncludeKey]; ok {
+			r.resolved = true
+			delete(n, IncludeKey)
+			var err error
+			base, err = r.loadFragments(include, ref, stack)
+			if err != nil {
+				return nil, err
+			}
+			for _, b := range base {
+				if _, ok := b.(map[interface{}]interface{}); !ok {
+					return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "fragment included in a mapping of %s should be a mapping", ref)
+				}
+			}
+		}
+		for k, v := range n {
+			resolved, err := r.resolveNode(v, ref, stack)
+			if err != nil {
+				return nil, err
+			}
+			n[k] = resolved
+		}
+		if len(base) == 0 {
+			return n, nil
+		}
+		return mergeFragments(append(base, n)...), nil
+	case []interface{}:
+		res := make([]interface{}, 0, len(n))
+		for _, item := range n {
+			if m, ok := item.(map[interface{}]interface{}); ok && len(m) == 1 {
+				if include, ok := m[IncludeKey]; ok {
+					r.resolved = true
+					fragments, err := r.loadFragments(include, ref, stack)
+					if err != nil {
+						return nil, err
+					}
+					for _, f := range fragments {
+						if items, ok := f.([]interface{}); ok {
+							res = append(res, items...)
+						} else {
+							res = append(res, f)
+						}
+					}
+					continue
+				}
+			}
+			resolved, err := r.resolveNode(item, ref, stack)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, resolved)
+		}
+		return res, nil
+	}
+	return node, nil
+}
+
+// mergeFragments merges given nodes, the last one overrides the previous ones.
+func mergeFragments(nodes ...interface{}) interface{} {
+	var res interface{}
+	for _, n := range nodes {
+		res = mergeFragment(res, n)
+	}
+	return res
+}
+
+func mergeFragment(base, override interface{}) interface{} {
+	baseMap, ok := base.(map[interface{}]interface{})
+	if !ok {
+		return override
+	}
+	overrideMap, ok := override.(map[interface{}]interface{})
+	if !ok {
+		return override
+	}
+
+	res := make(map[interface{}]interface{}, len(baseMap)+len(overrideMap))
+	for k, v := range baseMap {
+		res[k] = v
+	}
+	for k, v := range overrideMap {
+		if v == nil {
+			delete(res, k)
+			continue
+		}
+		res[k] = mergeFragment(res[k], v)
+	}
+	return res
+}
@@ -0,0 +1,111 @@
+package exportentities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func TestResolveIncludes(t *testing.T) {
+	files := map[string][]byte{
+		".cds/build.pip.yml": []byte(`version: v1.0
+name: build
+extends: fragments/base.fragment.yml
+parameters:
+  unused:
+jobs:
+- job: build
+  include: fragments/job.fragment.yml
+  steps:
+  - include: /.cds/fragments/steps.fragment.yml
+  - script: make build
+`),
+		".cds/fragments/base.fragment.yml": []byte(`version: v1.0
+name: base
+parameters:
+  unused:
+    type: string
+  version:
+    type: string
+`),
+		".cds/fragments/job.fragment.yml": []byte(`requirements:
+- binary: make
+steps:
+- script: echo overridden
+`),
+		".cds/fragments/steps.fragment.yml": []byte(`- checkout: '{{.cds.workspace}}'
+- include: templates/ci:go.fragment.yml
+`),
+		".cds/my-app.app.yml": []byte("version: v1.0\nname: my-app\n"),
+	}
+
+	var loaded []string
+	res, err := exportentities.ResolveIncludes(files, func(repository string) (map[string][]byte, error) {
+		loaded = append(loaded, repository)
+		return map[string][]byte{
+			".cds/go.fragment.yml": []byte("- script: go version\n"),
+		}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"templates/ci"}, loaded)
+
+	require.Len(t, res, 2)
+	assert.Equal(t, files[".cds/my-app.app.yml"], res[".cds/my-app.app.yml"])
+
+	var pip exportentities.PipelineV1
+	require.NoError(t, exportentities.Unmarshal(res[".cds/build.pip.yml"], exportentities.FormatYAML, &pip))
+	assert.Equal(t, "build", pip.Name)
+	require.Len(t, pip.Parameters, 1)
+	assert.Equal(t, "string", pip.Parameters["version"].Type)
+	require.Len(t, pip.Jobs, 1)
+	require.Len(t, pip.Jobs[0].Requirements, 1)
+	assert.Equal(t, "make", pip.Jobs[0].Requirements[0].Binary)
+	require.Len(t, pip.Jobs[0].Steps, 3)
+	assert.NotNil(t, pip.Jobs[0].Steps[0].Checkout)
+	require.NotNil(t, pip.Jobs[0].Steps[1].Script)
+	require.NotNil(t, pip.Jobs[0].Steps[2].Script)
+}
+
+func TestResolveIncludesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string][]byte
+	}{
+		{
+			name: "cycle",
+			files: map[string][]byte{
+				"a.yml":          []byte("extends: b.fragment.yml\n"),
+				"b.fragment.yml": []byte("extends: a.yml\n"),
+			},
+		},
+		{
+			name: "missing file",
+			files: map[string][]byte{
+				"a.yml": []byte("include: unknown.fragment.yml\n"),
+			},
+		},
+		{
+			name: "remote without loader",
+			files: map[string][]byte{
+				"a.yml": []byte("include: my-org/my-repo:b.fragment.yml\n"),
+			},
+		},
+		{
+			name: "extends a list",
+			files: map[string][]byte{
+				"a.yml":          []byte("extends: b.fragment.yml\n"),
+				"b.fragment.yml": []byte("- script: echo\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := exportentities.ResolveIncludes(tt.files, nil)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package exportentities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// IntegrationModel is the as code format of an integration model, plugins are only exported as references to the
+// plugins and binaries that should exist on the CDS instance where the model is imported.
+type IntegrationModel struct {
+	Name                    string                   `json:"name" yaml:"name"`
+	Author                  string                   `json:"author,omitempty" yaml:"author,omitempty"`
+	Identifier              string                   `json:"identifier,omitempty" yaml:"identifier,omitempty"`
+	Icon                    string                   `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Hook                    bool                     `json:"hook,omitempty" yaml:"hook,omitempty"`
+	Storage                 bool                     `json:"storage,omitempty" yaml:"storage,omitempty"`
+	Deployment              bool                     `json:"deployment,omitempty" yaml:"deployment,omitempty"`
+	Compute                 bool                     `json:"compute,omitempty" yaml:"compute,omitempty"`
+	Event                   bool                     `json:"event,omitempty" yaml:"event,omitempty"`
+	Release                 bool                     `json:"release,omitempty" yaml:"release,omitempty"`
+	Disabled                bool                     `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	Public                  bool                     `json:"public,omitempty" yaml:"public,omitempty"`
+	DefaultConfig           sdk.IntegrationConfig    `json:"default_config,omitempty" yaml:"default_config,omitempty"`
+	DeploymentDefaultConfig sdk.IntegrationConfig    `json:"deployment_default_config,omitempty" yaml:"deployment_default_config,omitempty"`
+	PublicConfigurations    sdk.IntegrationConfigMap `json:"public_configurations,omitempty" yaml:"public_configurations,omitempty"`
+	Plugins                 []IntegrationModelPlugin `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+}
+
+// IntegrationModelPlugin is a reference to a plugin of an integration model.
+type IntegrationModelPlugin struct {
+	Name     string                         `json:"name" yaml:"name"`
+	Type     string                         `json:"type" yaml:"type"`
+	Binaries []IntegrationModelPluginBinary `json:"binaries,omitempty" yaml:"binaries,omitempty"`
+}
+
+// IntegrationModelPluginBinary is a reference to a binary of a plugin.
+type IntegrationModelPluginBinary struct {
+	OS        string `json:"os" yaml:"os"`
+	Arch      string `json:"arch" yaml:"arch"`
+	SHA512sum string `json:"sha512sum,omitempty" yaml:"sha512sum,omitempty"`
+}
+
+// NewIntegrationModel creates an exportentities IntegrationModel from a sdk.IntegrationModel and the plugins of
+// the model.
+func NewIntegrationModel(m sdk.IntegrationModel, plugins []sdk.GRPCPlugin) IntegrationModel {
+	model := IntegrationModel{
+		Name:                    m.Name,
+		Author:                  m.Author,
+		Identifier:              m.Identifier,
+		Icon:                    m.Icon,
+		Hook:                    m.Hook,
+		Storage:                 m.Storage,
+		Deployment:              m.Deployment,
+		Compute:                 m.Compute,
+		Event:                   m.Event,
+		Release:                 m.Release,
+		Disabled:                m.Disabled,
+		Public:                  m.Public,
+		DefaultConfig:           m.DefaultConfig,
+		DeploymentDefaultConfig: m.DeploymentDefaultConfig,
+		PublicConfigurations:    m.PublicConfigurations.Clone(),
+	}
+
+	// passwords are never exported
+	for _, cfg := range model.PublicConfigurations {
+		for k, v := range cfg {
+			if v.Type == sdk.IntegrationConfigTypePassword {
+				v.Value = ""
+				cfg[k] = v
+			}
+		}
+	}
+
+	for _, p := range plugins {
+		if p.Integration != m.Name {
+			continue
+		}
+		plg := IntegrationModelPlugin{Name: p.Name, Type: p.Type}
+		for _, b := range p.Binaries {
+			plg.Binaries = append(plg.Binaries, IntegrationModelPluginBinary{
+				OS:        b.OS,
+				Arch:      b.Arch,
+				SHA512sum: b.SHA512sum,
+			})
+		}
+		sort.Slice(plg.Binaries, func(i, j int) bool {
+			return plg.Binaries[i].OS+"/"+plg.Binaries[i].Arch < plg.Binaries[j].OS+"/"+plg.Binaries[j].Arch
+		})
+		model.Plugins = append(model.Plugins, plg)
+	}
+	sort.Slice(model.Plugins, func(i, j int) bool { return model.Plugins[i].Name < model.Plugins[j].Name })
+
+	return model
+}
+
+// GetIntegrationModel converts an exportentities IntegrationModel to a sdk.IntegrationModel.
+func (m IntegrationModel) GetIntegrationModel() sdk.IntegrationModel {
+	return sdk.IntegrationModel{
+		Name:                    m.Name,
+		Author:                  m.Author,
+		Identifier:              m.Identifier,
+		Icon:                    m.Icon,
+		Hook:                    m.Hook,
+		Storage:                 m.Storage,
+		Deployment:              m.Deployment,
+		Compute:                 m.Compute,
+		Event:                   m.Event,
+		Release:                 m.Release,
+		Disabled:                m.Disabled,
+		Public:                  m.Public,
+		DefaultConfig:           m.DefaultConfig,
+		DeploymentDefaultConfig: m.DeploymentDefaultConfig,
+		PublicConfigurations:    m.PublicConfigurations,
+	}
+}
+
+// CheckPlugins returns an error if a plugin or a plugin binary referenced by the model is missing in given plugins,
+// or if its checksum is different.
+func (m IntegrationModel) CheckPlugins(plugins []sdk.GRPCPlugin) error {
+	var errs []string
+	for _, ref := range m.Plugins {
+		var plugin *sdk.GRPCPlugin
+		for i := range plugins {
+			if plugins[i].Name == ref.Name {
+				plugin = &plugins[i]
+				break
+			}
+		}
+		if plugin == nil {
+			errs = append(errs, fmt.Sprintf("plugin %s not found", ref.Name))
+			continue
+		}
+		if plugin.Type != ref.Type {
+			errs = append(errs, fmt.Sprintf("plugin %s has type %s instead of %s", ref.Name, plugin.Type, ref.Type))
+		}
+		if plugin.Integration != m.Name {
+			errs = append(errs, fmt.Sprintf("plugin %s is not linked to integration model %s", ref.Name, m.Name))
+		}
+		for _, b := range ref.Binaries {
+			bin := plugin.GetBinary(b.OS, b.Arch)
+			switch {
+			case bin == nil:
+				errs = append(errs, fmt.Sprintf("binary %s/%s of plugin %s not found", b.OS, b.Arch, ref.Name))
+			case b.SHA512sum != "" && bin.SHA512sum != b.SHA512sum:
+				errs = append(errs, fmt.Sprintf("binary %s/%s of plugin %s has a different checksum", b.OS, b.Arch, ref.Name))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid plugins for integration model %s: %s", m.Name, strings.Join(errs, ", "))
+	}
+	return nil
+}
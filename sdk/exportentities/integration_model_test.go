@@ -0,0 +1,78 @@
+package exportentities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func TestNewIntegrationModelAndGetIntegrationModel(t *testing.T) {
+	m := sdk.IntegrationModel{
+		ID:         1,
+		Name:       "my-deployment",
+		Author:     "me",
+		Deployment: true,
+		DeploymentDefaultConfig: sdk.IntegrationConfig{
+			"version": {Type: sdk.IntegrationConfigTypeString, Value: "{{.cds.version}}"},
+		},
+		PublicConfigurations: sdk.IntegrationConfigMap{
+			"prod": {
+				"host":  {Type: sdk.IntegrationConfigTypeString, Value: "https://prod.example.com"},
+				"token": {Type: sdk.IntegrationConfigTypePassword, Value: sdk.PasswordPlaceholder},
+			},
+		},
+		Public: true,
+	}
+	plugins := []sdk.GRPCPlugin{
+		{
+			Name:        "my-deployment-plugin",
+			Type:        sdk.GRPCPluginDeploymentIntegration,
+			Integration: "my-deployment",
+			Binaries: []sdk.GRPCPluginBinary{
+				{OS: "linux", Arch: "amd64", SHA512sum: "sum-linux"},
+				{OS: "darwin", Arch: "amd64", SHA512sum: "sum-darwin"},
+			},
+		},
+		{Name: "another-plugin", Integration: "another-model"},
+	}
+
+	e := exportentities.NewIntegrationModel(m, plugins)
+	require.Len(t, e.Plugins, 1)
+	assert.Equal(t, "my-deployment-plugin", e.Plugins[0].Name)
+	assert.Equal(t, []exportentities.IntegrationModelPluginBinary{
+		{OS: "darwin", Arch: "amd64", SHA512sum: "sum-darwin"},
+		{OS: "linux", Arch: "amd64", SHA512sum: "sum-linux"},
+	}, e.Plugins[0].Binaries)
+	assert.Equal(t, "", e.PublicConfigurations["prod"]["token"].Value)
+	assert.Equal(t, sdk.PasswordPlaceholder, m.PublicConfigurations["prod"]["token"].Value, "given model should not be modified")
+
+	b, err := yaml.Marshal(e)
+	require.NoError(t, err)
+	var imported exportentities.IntegrationModel
+	require.NoError(t, yaml.Unmarshal(b, &imported))
+	assert.Equal(t, e, imported)
+
+	res := imported.GetIntegrationModel()
+	assert.Equal(t, int64(0), res.ID)
+	assert.Equal(t, m.Name, res.Name)
+	assert.True(t, res.Deployment)
+	assert.True(t, res.Public)
+	assert.Equal(t, m.DeploymentDefaultConfig, res.DeploymentDefaultConfig)
+	assert.Equal(t, "https://prod.example.com", res.PublicConfigurations["prod"]["host"].Value)
+
+	assert.NoError(t, imported.CheckPlugins(plugins))
+
+	plugins[0].Binaries = plugins[0].Binaries[:1]
+	plugins[0].Binaries[0].SHA512sum = "other-sum"
+	err = imported.CheckPlugins(plugins)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "binary darwin/amd64 of plugin my-deployment-plugin not found")
+	assert.Contains(t, err.Error(), "binary linux/amd64 of plugin my-deployment-plugin has a different checksum")
+
+	assert.Error(t, imported.CheckPlugins(nil))
+}
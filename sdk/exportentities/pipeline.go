@@ -3,6 +3,7 @@ package exportentities
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/ovh/cds/sdk"
 )
@@ -48,18 +49,25 @@ type Job struct {
 	Requirements   []Requirement `json:"requirements,omitempty" yaml:"requirements,omitempty" jsonschema_description:"The list of requirements for the jobs."`
 	Optional       *bool         `json:"optional,omitempty" yaml:"optional,omitempty" jsonschema_description:"Set this option to ignore job's errors."`
 	AlwaysExecuted *bool         `json:"always_executed,omitempty" yaml:"always_executed,omitempty" jsonschema_description:"Set this option to execute the job even if a previous step failed."`
+	Idempotent     *bool         `json:"idempotent,omitempty" yaml:"idempotent,omitempty" jsonschema_description:"Set this option if the job can safely be run again, it will be requeued if its worker is lost."`
 }
 
 // Requirement represents an exported sdk.Requirement
 type Requirement struct {
-	Binary            string             `json:"binary,omitempty" yaml:"binary,omitempty"`
-	Network           string             `json:"network,omitempty" yaml:"network,omitempty"`
-	Model             string             `json:"model,omitempty" yaml:"model,omitempty"`
-	Hostname          string             `json:"hostname,omitempty" yaml:"hostname,omitempty"`
-	Plugin            string             `json:"plugin,omitempty" yaml:"plugin,omitempty"`
-	Service           ServiceRequirement `json:"service,omitempty" yaml:"service,omitempty"`
-	Memory            string             `json:"memory,omitempty" yaml:"memory,omitempty"`
-	OSArchRequirement string             `json:"os-architecture,omitempty" yaml:"os-architecture,omitempty"`
+	Binary            string                `json:"binary,omitempty" yaml:"binary,omitempty"`
+	Network           string                `json:"network,omitempty" yaml:"network,omitempty"`
+	Model             string                `json:"model,omitempty" yaml:"model,omitempty"`
+	Hostname          string                `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	Plugin            string                `json:"plugin,omitempty" yaml:"plugin,omitempty"`
+	Service           ServiceRequirement    `json:"service,omitempty" yaml:"service,omitempty"`
+	Memory            string                `json:"memory,omitempty" yaml:"memory,omitempty"`
+	OSArchRequirement string                `json:"os-architecture,omitempty" yaml:"os-architecture,omitempty"`
+	Isolation         *IsolationRequirement `json:"isolation,omitempty" yaml:"isolation,omitempty"`
+}
+
+// IsolationRequirement represents an exported sdk.Requirement of type IsolationRequirement
+type IsolationRequirement struct {
+	Paths []string `json:"paths,omitempty" yaml:"paths,omitempty"`
 }
 
 // ServiceRequirement represents an exported sdk.Requirement of type ServiceRequirement
@@ -153,6 +161,8 @@ func newRequirements(req []sdk.Requirement) []Requirement {
 			res = append(res, Requirement{OSArchRequirement: r.Value})
 		case sdk.MemoryRequirement:
 			res = append(res, Requirement{Memory: r.Value})
+		case sdk.IsolationRequirement:
+			res = append(res, Requirement{Isolation: &IsolationRequirement{Paths: sdk.IsolationPaths(r.Value)}})
 		}
 	}
 	return res
@@ -163,6 +173,9 @@ func newJob(j sdk.Job) Job {
 	if !j.Enabled {
 		jo.Enabled = &j.Enabled
 	}
+	if j.Idempotent {
+		jo.Idempotent = &j.Idempotent
+	}
 	jo.Steps = newSteps(j.Action)
 	jo.Description = j.Action.Description
 	jo.Requirements = newRequirements(j.Action.Requirements)
@@ -230,6 +243,10 @@ func computeJobRequirements(req []Requirement) []sdk.Requirement {
 			name = r.Service.Name
 			val = r.Service.Value
 			tpe = sdk.ServiceRequirement
+		} else if r.Isolation != nil {
+			name = "isolation"
+			val = strings.Join(r.Isolation.Paths, ",")
+			tpe = sdk.IsolationRequirement
 		}
 		res[i] = sdk.Requirement{
 			Name:  name,
@@ -254,6 +271,9 @@ func computeJob(name string, j Job) (*sdk.Job, error) {
 		job.Enabled = true
 	}
 	job.Action.Enabled = job.Enabled
+	if j.Idempotent != nil {
+		job.Idempotent = *j.Idempotent
+	}
 	job.Action.Requirements = computeJobRequirements(j.Requirements)
 
 	//Compute steps for the jobs
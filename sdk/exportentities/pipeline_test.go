@@ -7,6 +7,7 @@ import (
 	"github.com/ovh/cds/sdk/exportentities"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 
 	"github.com/ovh/cds/engine/api/test"
@@ -535,6 +536,72 @@ jobs:
 	assert.Len(t, p.Stages[0].Jobs[0].Action.Requirements, 2)
 }
 
+func Test_ImportPipelineWithIsolationRequirement(t *testing.T) {
+	in := `name: build-pr
+jobs:
+- job: build
+  requirements:
+  - isolation:
+      paths:
+      - /usr/local/go
+      - /opt/node
+  steps:
+  - script: make
+`
+
+	payload := &exportentities.PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+
+	require.Len(t, p.Stages[0].Jobs[0].Action.Requirements, 1)
+	assert.Equal(t, sdk.Requirement{Name: "isolation", Type: sdk.IsolationRequirement, Value: "/usr/local/go,/opt/node"}, p.Stages[0].Jobs[0].Action.Requirements[0])
+
+	exported := exportentities.NewPipelineV1(*p)
+	require.Len(t, exported.Jobs[0].Requirements, 1)
+	require.NotNil(t, exported.Jobs[0].Requirements[0].Isolation)
+	assert.Equal(t, []string{"/usr/local/go", "/opt/node"}, exported.Jobs[0].Requirements[0].Isolation.Paths)
+}
+
+func Test_ImportPipelineWithIdempotentJob(t *testing.T) {
+	in := `name: deploy
+jobs:
+- job: deploy
+  idempotent: true
+  steps:
+  - script: make deploy
+- job: notify
+  steps:
+  - script: make notify
+`
+
+	payload := &exportentities.PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+
+	require.Len(t, p.Stages[0].Jobs, 2)
+	jobs := map[string]sdk.Job{}
+	for _, j := range p.Stages[0].Jobs {
+		jobs[j.Action.Name] = j
+	}
+	assert.True(t, jobs["deploy"].Idempotent)
+	assert.False(t, jobs["notify"].Idempotent)
+
+	exported := exportentities.NewPipelineV1(*p)
+	require.Len(t, exported.Jobs, 2)
+	for _, j := range exported.Jobs {
+		if j.Name == "deploy" {
+			require.NotNil(t, j.Idempotent)
+			assert.True(t, *j.Idempotent)
+		} else {
+			assert.Nil(t, j.Idempotent)
+		}
+	}
+}
+
 func Test_ImportPipelineWithGitClone(t *testing.T) {
 	in := `name: build-all-images
 jobs:
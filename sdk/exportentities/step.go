@@ -26,6 +26,7 @@ func newStep(act sdk.Action) Step {
 	if act.AlwaysExecuted {
 		s.AlwaysExecuted = &sdk.True
 	}
+	s.If = act.Condition
 
 	switch act.Type {
 	case sdk.BuiltinAction:
@@ -217,6 +218,90 @@ func newStep(act sdk.Action) Step {
 		case sdk.DeployApplicationAction:
 			step := StepDeploy("{{.cds.application}}")
 			s.Deploy = &step
+		case sdk.DockerBuildAction:
+			s.DockerBuild = &StepDockerBuild{}
+			integration := sdk.ParameterFind(act.Parameters, "integration")
+			if integration != nil {
+				s.DockerBuild.Integration = integration.Value
+			}
+			context := sdk.ParameterFind(act.Parameters, "context")
+			if context != nil && context.Value != "." {
+				s.DockerBuild.Context = context.Value
+			}
+			dockerfile := sdk.ParameterFind(act.Parameters, "dockerfile")
+			if dockerfile != nil && dockerfile.Value != "Dockerfile" {
+				s.DockerBuild.Dockerfile = dockerfile.Value
+			}
+			image := sdk.ParameterFind(act.Parameters, "image")
+			if image != nil {
+				s.DockerBuild.Image = image.Value
+			}
+			buildArgs := sdk.ParameterFind(act.Parameters, "buildArgs")
+			if buildArgs != nil {
+				s.DockerBuild.BuildArgs = buildArgs.Value
+			}
+			push := sdk.ParameterFind(act.Parameters, "push")
+			if push != nil && push.Value != "false" {
+				s.DockerBuild.Push = push.Value
+			}
+			cacheTag := sdk.ParameterFind(act.Parameters, "cacheTag")
+			if cacheTag != nil && cacheTag.Value != sdk.DefaultDockerBuildParameterCacheTagValue {
+				s.DockerBuild.CacheTag = cacheTag.Value
+			}
+		case sdk.SemverAction:
+			s.Semver = &StepSemver{}
+			path := sdk.ParameterFind(act.Parameters, "path")
+			if path != nil && path.Value != "{{.cds.workspace}}" {
+				s.Semver.Path = path.Value
+			}
+			prefix := sdk.ParameterFind(act.Parameters, "prefix")
+			if prefix != nil {
+				s.Semver.Prefix = prefix.Value
+			}
+			initialVersion := sdk.ParameterFind(act.Parameters, "initialVersion")
+			if initialVersion != nil && initialVersion.Value != "0.1.0" {
+				s.Semver.InitialVersion = initialVersion.Value
+			}
+			majorTypes := sdk.ParameterFind(act.Parameters, "majorTypes")
+			if majorTypes != nil {
+				s.Semver.MajorTypes = majorTypes.Value
+			}
+			minorTypes := sdk.ParameterFind(act.Parameters, "minorTypes")
+			if minorTypes != nil && minorTypes.Value != "feat" {
+				s.Semver.MinorTypes = minorTypes.Value
+			}
+			patchTypes := sdk.ParameterFind(act.Parameters, "patchTypes")
+			if patchTypes != nil && patchTypes.Value != "fix,perf" {
+				s.Semver.PatchTypes = patchTypes.Value
+			}
+			defaultBump := sdk.ParameterFind(act.Parameters, "defaultBump")
+			if defaultBump != nil && defaultBump.Value != sdk.SemverBumpNone {
+				s.Semver.DefaultBump = defaultBump.Value
+			}
+			prerelease := sdk.ParameterFind(act.Parameters, "prerelease")
+			if prerelease != nil {
+				s.Semver.Prerelease = prerelease.Value
+			}
+			createTag := sdk.ParameterFind(act.Parameters, "createTag")
+			if createTag != nil && createTag.Value != "false" {
+				s.Semver.CreateTag = createTag.Value
+			}
+			tagMessage := sdk.ParameterFind(act.Parameters, "tagMessage")
+			if tagMessage != nil {
+				s.Semver.TagMessage = tagMessage.Value
+			}
+		case sdk.WorkspaceSnapshotAction:
+			s.WorkspaceSnapshot = &StepWorkspace{}
+			name := sdk.ParameterFind(act.Parameters, "name")
+			if name != nil && name.Value != sdk.DefaultWorkspaceSnapshotName {
+				s.WorkspaceSnapshot.Name = name.Value
+			}
+		case sdk.WorkspaceRestoreAction:
+			s.WorkspaceRestore = &StepWorkspace{}
+			name := sdk.ParameterFind(act.Parameters, "name")
+			if name != nil && name.Value != sdk.DefaultWorkspaceSnapshotName {
+				s.WorkspaceRestore.Name = name.Value
+			}
 		}
 	default:
 		args := make(StepParameters)
@@ -231,6 +316,9 @@ func newStep(act sdk.Action) Step {
 		if act.Group != nil && act.Group.Name != sdk.SharedInfraGroupName {
 			name = fmt.Sprintf("%s/%s", act.Group.Name, act.Name)
 		}
+		if act.Version != "" {
+			name = fmt.Sprintf("%s@%s", name, act.Version)
+		}
 
 		s.StepCustom = StepCustom{
 			name: args,
@@ -307,6 +395,36 @@ type StepGitTag struct {
 	TagPrerelease string `json:"tagPrerelease,omitempty" yaml:"tagPrerelease,omitempty"`
 }
 
+// StepDockerBuild represents exported docker build step.
+type StepDockerBuild struct {
+	BuildArgs   string `json:"buildArgs,omitempty" yaml:"buildArgs,omitempty"`
+	CacheTag    string `json:"cacheTag,omitempty" yaml:"cacheTag,omitempty"`
+	Context     string `json:"context,omitempty" yaml:"context,omitempty"`
+	Dockerfile  string `json:"dockerfile,omitempty" yaml:"dockerfile,omitempty"`
+	Image       string `json:"image,omitempty" yaml:"image,omitempty"`
+	Integration string `json:"integration,omitempty" yaml:"integration,omitempty" jsonschema:"required"`
+	Push        string `json:"push,omitempty" yaml:"push,omitempty"`
+}
+
+// StepSemver represents exported semver step.
+type StepSemver struct {
+	CreateTag      string `json:"createTag,omitempty" yaml:"createTag,omitempty"`
+	DefaultBump    string `json:"defaultBump,omitempty" yaml:"defaultBump,omitempty"`
+	InitialVersion string `json:"initialVersion,omitempty" yaml:"initialVersion,omitempty"`
+	MajorTypes     string `json:"majorTypes,omitempty" yaml:"majorTypes,omitempty"`
+	MinorTypes     string `json:"minorTypes,omitempty" yaml:"minorTypes,omitempty"`
+	PatchTypes     string `json:"patchTypes,omitempty" yaml:"patchTypes,omitempty"`
+	Path           string `json:"path,omitempty" yaml:"path,omitempty"`
+	Prefix         string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Prerelease     string `json:"prerelease,omitempty" yaml:"prerelease,omitempty"`
+	TagMessage     string `json:"tagMessage,omitempty" yaml:"tagMessage,omitempty"`
+}
+
+// StepWorkspace represents exported workspace snapshot and restore steps.
+type StepWorkspace struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
 // StepJUnitReport represents exported junit report step.
 type StepJUnitReport string
 
@@ -326,20 +444,25 @@ type Step struct {
 	Enabled        *bool  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
 	Optional       *bool  `json:"optional,omitempty" yaml:"optional,omitempty"`
 	AlwaysExecuted *bool  `json:"always_executed,omitempty" yaml:"always_executed,omitempty"`
+	If             string `json:"if,omitempty" yaml:"if,omitempty" jsonschema_description:"Lua expression evaluated by the worker before the step, the step is skipped if it is false.\nhttps://ovh.github.io/cds/docs/concepts/job/"`
 	// step specific data, only one option should be set
-	StepCustom       `json:"-" yaml:",inline"`
-	Script           interface{}           `json:"script,omitempty" yaml:"script,omitempty" jsonschema:"oneof_type=string;array,oneof_required=actionScript" jsonschema_description:"Script.\nhttps://ovh.github.io/cds/docs/actions/builtin-script"`
-	Coverage         *StepCoverage         `json:"coverage,omitempty" yaml:"coverage,omitempty" jsonschema:"oneof_required=actionCoverage" jsonschema_description:"Parse coverage report.\nhttps://ovh.github.io/cds/docs/actions/builtin-coverage"`
-	ArtifactDownload *StepArtifactDownload `json:"artifactDownload,omitempty" yaml:"artifactDownload,omitempty" jsonschema:"oneof_required=actionArtifactDownload" jsonschema_description:"Download artifacts in workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-artifact-download"`
-	ArtifactUpload   *StepArtifactUpload   `json:"artifactUpload,omitempty" yaml:"artifactUpload,omitempty" jsonschema:"oneof_required=actionArtifactUpload" jsonschema_description:"Upload artifacts from workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-artifact-upload"`
-	ServeStaticFiles *StepServeStaticFiles `json:"serveStaticFiles,omitempty" yaml:"serveStaticFiles,omitempty" jsonschema:"oneof_required=actionServeStaticFiles" jsonschema_description:"Serve static files.\nhttps://ovh.github.io/cds/docs/actions/builtin-serve-static-files"`
-	GitClone         *StepGitClone         `json:"gitClone,omitempty" yaml:"gitClone,omitempty" jsonschema:"oneof_required=actionGitClone" jsonschema_description:"Clone a git repository.\nhttps://ovh.github.io/cds/docs/actions/builtin-gitclone"`
-	GitTag           *StepGitTag           `json:"gitTag,omitempty" yaml:"gitTag,omitempty" jsonschema:"oneof_required=actionGitTag" jsonschema_description:"Create a git tag.\nhttps://ovh.github.io/cds/docs/actions/builtin-gittag"`
-	Release          *StepRelease          `json:"release,omitempty" yaml:"release,omitempty" jsonschema:"oneof_required=actionRelease" jsonschema_description:"Release an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-release"`
-	JUnitReport      *StepJUnitReport      `json:"jUnitReport,omitempty" yaml:"jUnitReport,omitempty" jsonschema:"oneof_required=actionJUNit" jsonschema_description:"Parse JUnit report.\nhttps://ovh.github.io/cds/docs/actions/builtin-junit"`
-	Checkout         *StepCheckout         `json:"checkout,omitempty" yaml:"checkout,omitempty" jsonschema:"oneof_required=actionCheckout" jsonschema_description:"Checkout repository for an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-checkoutapplication"`
-	InstallKey       *StepInstallKey       `json:"installKey,omitempty" yaml:"installKey,omitempty" jsonschema:"oneof_required=actionInstallKey" jsonschema_description:"Install a key (GPG, SSH) in your current workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-installkey"`
-	Deploy           *StepDeploy           `json:"deploy,omitempty" yaml:"deploy,omitempty" jsonschema:"oneof_required=actionDeploy" jsonschema_description:"Deploy an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-deployapplication"`
+	StepCustom        `json:"-" yaml:",inline"`
+	Script            interface{}           `json:"script,omitempty" yaml:"script,omitempty" jsonschema:"oneof_type=string;array,oneof_required=actionScript" jsonschema_description:"Script.\nhttps://ovh.github.io/cds/docs/actions/builtin-script"`
+	Coverage          *StepCoverage         `json:"coverage,omitempty" yaml:"coverage,omitempty" jsonschema:"oneof_required=actionCoverage" jsonschema_description:"Parse coverage report.\nhttps://ovh.github.io/cds/docs/actions/builtin-coverage"`
+	ArtifactDownload  *StepArtifactDownload `json:"artifactDownload,omitempty" yaml:"artifactDownload,omitempty" jsonschema:"oneof_required=actionArtifactDownload" jsonschema_description:"Download artifacts in workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-artifact-download"`
+	ArtifactUpload    *StepArtifactUpload   `json:"artifactUpload,omitempty" yaml:"artifactUpload,omitempty" jsonschema:"oneof_required=actionArtifactUpload" jsonschema_description:"Upload artifacts from workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-artifact-upload"`
+	ServeStaticFiles  *StepServeStaticFiles `json:"serveStaticFiles,omitempty" yaml:"serveStaticFiles,omitempty" jsonschema:"oneof_required=actionServeStaticFiles" jsonschema_description:"Serve static files.\nhttps://ovh.github.io/cds/docs/actions/builtin-serve-static-files"`
+	GitClone          *StepGitClone         `json:"gitClone,omitempty" yaml:"gitClone,omitempty" jsonschema:"oneof_required=actionGitClone" jsonschema_description:"Clone a git repository.\nhttps://ovh.github.io/cds/docs/actions/builtin-gitclone"`
+	GitTag            *StepGitTag           `json:"gitTag,omitempty" yaml:"gitTag,omitempty" jsonschema:"oneof_required=actionGitTag" jsonschema_description:"Create a git tag.\nhttps://ovh.github.io/cds/docs/actions/builtin-gittag"`
+	Release           *StepRelease          `json:"release,omitempty" yaml:"release,omitempty" jsonschema:"oneof_required=actionRelease" jsonschema_description:"Release an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-release"`
+	JUnitReport       *StepJUnitReport      `json:"jUnitReport,omitempty" yaml:"jUnitReport,omitempty" jsonschema:"oneof_required=actionJUNit" jsonschema_description:"Parse JUnit report.\nhttps://ovh.github.io/cds/docs/actions/builtin-junit"`
+	Checkout          *StepCheckout         `json:"checkout,omitempty" yaml:"checkout,omitempty" jsonschema:"oneof_required=actionCheckout" jsonschema_description:"Checkout repository for an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-checkoutapplication"`
+	InstallKey        *StepInstallKey       `json:"installKey,omitempty" yaml:"installKey,omitempty" jsonschema:"oneof_required=actionInstallKey" jsonschema_description:"Install a key (GPG, SSH) in your current workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-installkey"`
+	Deploy            *StepDeploy           `json:"deploy,omitempty" yaml:"deploy,omitempty" jsonschema:"oneof_required=actionDeploy" jsonschema_description:"Deploy an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-deployapplication"`
+	DockerBuild       *StepDockerBuild      `json:"dockerBuild,omitempty" yaml:"dockerBuild,omitempty" jsonschema:"oneof_required=actionDockerBuild" jsonschema_description:"Build a docker image with a build cache stored in a registry.\nhttps://ovh.github.io/cds/docs/actions/builtin-dockerbuild"`
+	Semver            *StepSemver           `json:"semver,omitempty" yaml:"semver,omitempty" jsonschema:"oneof_required=actionSemver" jsonschema_description:"Compute the next semantic version from git tags and conventional commits.\nhttps://ovh.github.io/cds/docs/actions/builtin-semver"`
+	WorkspaceSnapshot *StepWorkspace        `json:"workspaceSnapshot,omitempty" yaml:"workspaceSnapshot,omitempty" jsonschema:"oneof_required=actionWorkspaceSnapshot" jsonschema_description:"Snapshot the workspace to restore it in a downstream job.\nhttps://ovh.github.io/cds/docs/actions/builtin-workspacesnapshot"`
+	WorkspaceRestore  *StepWorkspace        `json:"workspaceRestore,omitempty" yaml:"workspaceRestore,omitempty" jsonschema:"oneof_required=actionWorkspaceRestore" jsonschema_description:"Restore a workspace snapshot taken by an upstream job.\nhttps://ovh.github.io/cds/docs/actions/builtin-workspacerestore"`
 }
 
 // MarshalJSON custom marshal json impl to inline custom step.
@@ -453,6 +576,18 @@ func (s Step) IsValid() bool {
 	if s.isDeploy() {
 		count++
 	}
+	if s.isDockerBuild() {
+		count++
+	}
+	if s.isSemver() {
+		count++
+	}
+	if s.isWorkspaceSnapshot() {
+		count++
+	}
+	if s.isWorkspaceRestore() {
+		count++
+	}
 	if s.isCoverage() {
 		count++
 	}
@@ -491,6 +626,14 @@ func (s Step) toAction() (*sdk.Action, error) {
 		a = s.asInstallKey()
 	} else if s.isDeploy() {
 		a = s.asDeployApplication()
+	} else if s.isDockerBuild() {
+		a, err = s.asDockerBuild()
+	} else if s.isSemver() {
+		a, err = s.asSemver()
+	} else if s.isWorkspaceSnapshot() {
+		a, err = s.asWorkspace(sdk.WorkspaceSnapshotAction, s.WorkspaceSnapshot)
+	} else if s.isWorkspaceRestore() {
+		a, err = s.asWorkspace(sdk.WorkspaceRestoreAction, s.WorkspaceRestore)
 	} else if s.isCoverage() {
 		a, err = s.asCoverage()
 	} else if s.isScript() {
@@ -506,6 +649,7 @@ func (s Step) toAction() (*sdk.Action, error) {
 	a.Enabled = s.Enabled == nil || *s.Enabled == sdk.True // enabled is true by default
 	a.Optional = s.Optional != nil && *s.Optional == sdk.True
 	a.AlwaysExecuted = s.AlwaysExecuted != nil && *s.AlwaysExecuted == sdk.True
+	a.Condition = s.If
 
 	return &a, nil
 }
@@ -647,6 +791,56 @@ func (s Step) asDeployApplication() sdk.Action {
 	}
 }
 
+func (s Step) isDockerBuild() bool { return s.DockerBuild != nil }
+
+func (s Step) asDockerBuild() (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(s.DockerBuild)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       sdk.DockerBuildAction,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	return a, nil
+}
+
+func (s Step) isSemver() bool { return s.Semver != nil }
+
+func (s Step) asSemver() (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(s.Semver)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       sdk.SemverAction,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	return a, nil
+}
+
+func (s Step) isWorkspaceSnapshot() bool { return s.WorkspaceSnapshot != nil }
+
+func (s Step) isWorkspaceRestore() bool { return s.WorkspaceRestore != nil }
+
+func (s Step) asWorkspace(name string, step *StepWorkspace) (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(step)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       name,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	return a, nil
+}
+
 func (s Step) isServeStaticFiles() bool { return s.ServeStaticFiles != nil }
 
 func (s Step) asServeStaticFiles() (sdk.Action, error) {
@@ -750,15 +944,14 @@ func (s Step) asAction() sdk.Action {
 		break
 	}
 
+	groupName, actionName, version := sdk.ParseActionReference(name)
 	a := sdk.Action{
-		Name:       name,
+		Name:       actionName,
+		Version:    version,
 		Parameters: []sdk.Parameter{},
 	}
-
-	splitted := strings.Split(name, "/")
-	if len(splitted) == 2 {
-		a.Name = splitted[1]
-		a.Group = &sdk.Group{Name: splitted[0]}
+	if groupName != "" {
+		a.Group = &sdk.Group{Name: groupName}
 	}
 
 	a.Parameters = sdk.ParametersFromMap(s.StepCustom[name])
@@ -57,6 +57,38 @@ var tests = []struct {
 		Json: `{"installKey":{"file":"myfile","name":"proj-mykey"}}`,
 		Yaml: "installKey:\n  file: myfile\n  name: proj-mykey\n",
 	},
+	{
+		Name: "Step with typed action docker build",
+		Step: exportentities.Step{
+			DockerBuild: &exportentities.StepDockerBuild{
+				Integration: "my-registry",
+				Push:        "true",
+			},
+		},
+		Json: `{"dockerBuild":{"integration":"my-registry","push":"true"}}`,
+		Yaml: "dockerBuild:\n  integration: my-registry\n  push: \"true\"\n",
+	},
+	{
+		Name: "Step with typed action semver",
+		Step: exportentities.Step{
+			Semver: &exportentities.StepSemver{
+				Prefix:    "v",
+				CreateTag: "true",
+			},
+		},
+		Json: `{"semver":{"createTag":"true","prefix":"v"}}`,
+		Yaml: "semver:\n  createTag: \"true\"\n  prefix: v\n",
+	},
+	{
+		Name: "Step with typed action workspace snapshot",
+		Step: exportentities.Step{
+			WorkspaceSnapshot: &exportentities.StepWorkspace{
+				Name: "build",
+			},
+		},
+		Json: `{"workspaceSnapshot":{"name":"build"}}`,
+		Yaml: "workspaceSnapshot:\n  name: build\n",
+	},
 	{
 		Name: "Step with not typed action",
 		Step: exportentities.Step{
@@ -68,6 +100,17 @@ var tests = []struct {
 		Json: `{"script":["line1","line2"]}`,
 		Yaml: "script:\n- line1\n- line2\n",
 	},
+	{
+		Name: "Step with condition",
+		Step: exportentities.Step{
+			If: `git_branch == "master"`,
+			Script: []interface{}{
+				"make publish",
+			},
+		},
+		Json: `{"if":"git_branch == \"master\"","script":["make publish"]}`,
+		Yaml: "if: git_branch == \"master\"\nscript:\n- make publish\n",
+	},
 }
 
 func TestMarshal(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/fsamin/go-dump"
@@ -22,11 +23,21 @@ type Workflow struct {
 	Hooks    map[string][]HookEntry `json:"hooks,omitempty" yaml:"hooks,omitempty" jsonschema_description:"Workflow hooks list."`
 
 	// extra workflow data
-	Permissions   map[string]int      `json:"permissions,omitempty" yaml:"permissions,omitempty" jsonschema_description:"The permissions for the workflow (ex: myGroup: 7).\nhttps://ovh.github.io/cds/docs/concepts/permissions"`
-	Metadata      map[string]string   `json:"metadata,omitempty" yaml:"metadata,omitempty"`
-	PurgeTags     []string            `json:"purge_tags,omitempty" yaml:"purge_tags,omitempty"`
-	Notifications []NotificationEntry `json:"notifications,omitempty" yaml:"notifications,omitempty"` // This is used when the workflow have only one pipeline
-	HistoryLength *int64              `json:"history_length,omitempty" yaml:"history_length,omitempty"`
+	Permissions   map[string]int       `json:"permissions,omitempty" yaml:"permissions,omitempty" jsonschema_description:"The permissions for the workflow (ex: myGroup: 7).\nhttps://ovh.github.io/cds/docs/concepts/permissions"`
+	Metadata      map[string]string    `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	PurgeTags     []string             `json:"purge_tags,omitempty" yaml:"purge_tags,omitempty"`
+	Notifications []NotificationEntry  `json:"notifications,omitempty" yaml:"notifications,omitempty"` // This is used when the workflow have only one pipeline
+	HistoryLength *int64               `json:"history_length,omitempty" yaml:"history_length,omitempty"`
+	RunForm       *sdk.WorkflowRunForm `json:"run_form,omitempty" yaml:"run_form,omitempty" jsonschema_description:"The typed parameters that can be given to a manual run of the workflow."`
+	Priority      string               `json:"priority,omitempty" yaml:"priority,omitempty" jsonschema_description:"The priority class of the workflow jobs in the queue: critical, default or batch."`
+	// ArtifactStorages pins the storage integrations that receive the artifacts of the workflow
+	ArtifactStorages []ArtifactStorageEntry `json:"artifact_storages,omitempty" yaml:"artifact_storages,omitempty" jsonschema_description:"The storage integrations of the project that receive the artifacts of the workflow.\nhttps://ovh.github.io/cds/docs/concepts/workflow/artifact-storages"`
+}
+
+// ArtifactStorageEntry represents a storage integration pinned on the workflow as code
+type ArtifactStorageEntry struct {
+	Integration string   `json:"integration" yaml:"integration" jsonschema_description:"The name of a storage integration of the project."`
+	Nodes       []string `json:"nodes,omitempty" yaml:"nodes,omitempty" jsonschema_description:"Names of the nodes whose artifacts are sent to the integration, all the other nodes if empty."`
 }
 
 // NodeEntry represents a node as code
@@ -40,11 +51,14 @@ type NodeEntry struct {
 	EnvironmentName        string                 `json:"environment,omitempty" yaml:"environment,omitempty" jsonschema_description:"The environment to use in the context of the node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/pipeline-context"`
 	ProjectIntegrationName string                 `json:"integration,omitempty" yaml:"integration,omitempty" jsonschema_description:"The integration to use in the context of the node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/pipeline-context"`
 	OneAtATime             *bool                  `json:"one_at_a_time,omitempty" yaml:"one_at_a_time,omitempty" jsonschema_description:"Set to true if you want to limit the execution of this node to one at a time."`
+	DeploymentPlan         bool                   `json:"deployment_plan,omitempty" yaml:"deployment_plan,omitempty" jsonschema_description:"Set to true to run the deployment integration of the node in plan only mode, the child node using the same integration should then be approved by a manual run."`
+	ReleaseTargets         []string               `json:"release_targets,omitempty" yaml:"release_targets,omitempty" jsonschema_description:"Names of the release integrations to which the results of the run are published, in addition to the integration of the node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/release-targets"`
 	Payload                map[string]interface{} `json:"payload,omitempty" yaml:"payload,omitempty"`
 	Parameters             map[string]string      `json:"parameters,omitempty" yaml:"parameters,omitempty" jsonschema_description:"List of parameters for the workflow."`
 	OutgoingHookModelName  string                 `json:"trigger,omitempty" yaml:"trigger,omitempty"`
 	OutgoingHookConfig     map[string]string      `json:"config,omitempty" yaml:"config,omitempty"`
 	Permissions            map[string]int         `json:"permissions,omitempty" yaml:"permissions,omitempty" jsonschema_description:"The permissions for the node (ex: myGroup: 7).\nhttps://ovh.github.io/cds/docs/concepts/permissions"`
+	Display                *sdk.NodeDisplay       `json:"display,omitempty" yaml:"display,omitempty" jsonschema_description:"Label, color and group used to draw the node in the workflow graph.\nhttps://ovh.github.io/cds/docs/concepts/workflow/node-display"`
 }
 
 type ConditionEntry struct {
@@ -92,6 +106,21 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, version string, opts ...Ex
 
 	exportedWorkflow.PurgeTags = w.PurgeTags
 
+	if w.RunForm != nil && len(w.RunForm.Parameters) > 0 {
+		exportedWorkflow.RunForm = w.RunForm
+	}
+
+	if w.Priority != sdk.WorkflowPriorityDefault {
+		exportedWorkflow.Priority = w.Priority
+	}
+
+	for _, s := range w.ArtifactStorages {
+		exportedWorkflow.ArtifactStorages = append(exportedWorkflow.ArtifactStorages, ArtifactStorageEntry{
+			Integration: s.ProjectIntegrationName,
+			Nodes:       s.Nodes,
+		})
+	}
+
 	nodes := w.WorkflowData.Array()
 
 	for _, n := range nodes {
@@ -143,6 +172,9 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, version string, opts ...Ex
 }
 
 func joinAsNode(n *sdk.Node) bool {
+	if n.Display != nil && !n.Display.IsEmpty() {
+		return true
+	}
 	return n.Context != nil && (n.Context.Conditions.LuaScript != "" || len(n.Context.Conditions.PlainConditions) > 0)
 }
 
@@ -229,6 +261,8 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 		if n.Context.Mutex {
 			entry.OneAtATime = &n.Context.Mutex
 		}
+		entry.DeploymentPlan = n.Context.DeploymentPlan
+		entry.ReleaseTargets = n.Context.ReleaseTargets
 
 		if n.Context.HasDefaultPayload() {
 			enc := dump.NewDefaultEncoder()
@@ -256,6 +290,11 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 		}
 	}
 
+	if n.Display != nil && !n.Display.IsEmpty() {
+		display := *n.Display
+		entry.Display = &display
+	}
+
 	if n.OutGoingHookContext != nil {
 		entry.OutgoingHookModelName = n.OutGoingHookContext.HookModelName
 
@@ -347,6 +386,16 @@ func (w Workflow) GetWorkflow() (*sdk.Workflow, error) {
 		return nil, sdk.WrapError(err, "Unable to check dependencies")
 	}
 	wf.PurgeTags = w.PurgeTags
+	wf.RunForm = w.RunForm
+	if w.Priority != sdk.WorkflowPriorityDefault {
+		wf.Priority = w.Priority
+	}
+	for _, s := range w.ArtifactStorages {
+		wf.ArtifactStorages = append(wf.ArtifactStorages, sdk.WorkflowArtifactStorage{
+			ProjectIntegrationName: s.Integration,
+			Nodes:                  s.Nodes,
+		})
+	}
 	if len(w.Metadata) > 0 {
 		wf.Metadata = make(map[string]string, len(w.Metadata))
 		for k, v := range w.Metadata {
@@ -410,6 +459,18 @@ func (w Workflow) CheckValidity() error {
 		mError.Append(fmt.Errorf("workflow name %s do not respect pattern %s", w.Name, sdk.NamePattern))
 	}
 
+	for name, e := range w.Workflow {
+		if e.Display != nil {
+			mError.Append(e.Display.IsValid(name))
+		}
+	}
+
+	for _, s := range w.ArtifactStorages {
+		if s.Integration == "" {
+			mError.Append(fmt.Errorf("error: wrong usage: artifact storage without integration"))
+		}
+	}
+
 	for name := range w.Hooks {
 		if _, ok := w.Workflow[name]; !ok {
 			mError.Append(fmt.Errorf("error: wrong usage: invalid hook on %s", name))
@@ -419,6 +480,14 @@ func (w Workflow) CheckValidity() error {
 	//Checks map notifications validity
 	mError.Append(CheckWorkflowNotificationsValidity(w))
 
+	if w.RunForm != nil {
+		mError.Append(w.RunForm.IsValid())
+	}
+
+	if !sdk.IsValidWorkflowPriority(w.Priority) {
+		mError.Append(fmt.Errorf("error: wrong usage: invalid priority %q, it should be one of %s", w.Priority, strings.Join(sdk.WorkflowPriorities, ", ")))
+	}
+
 	if mError.IsEmpty() {
 		return nil
 	}
@@ -591,6 +660,8 @@ func (e *NodeEntry) getNode(name string) (*sdk.Node, error) {
 			EnvironmentName:        e.EnvironmentName,
 			ProjectIntegrationName: e.ProjectIntegrationName,
 			Mutex:                  mutex,
+			DeploymentPlan:         e.DeploymentPlan,
+			ReleaseTargets:         e.ReleaseTargets,
 		},
 	}
 
@@ -606,6 +677,11 @@ func (e *NodeEntry) getNode(name string) (*sdk.Node, error) {
 		}
 	}
 
+	if e.Display != nil && !e.Display.IsEmpty() {
+		display := *e.Display
+		node.Display = &display
+	}
+
 	if len(e.Permissions) > 0 {
 		//Compute permissions
 		node.Groups = make([]sdk.GroupPermission, 0, len(e.Permissions))
@@ -0,0 +1,273 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Diff returns the changes to apply on a workflow to get another one. Nodes are compared by name.
+func Diff(from, to Workflow) (sdk.WorkflowDiff, error) {
+	a, err := toJSONValue(from)
+	if err != nil {
+		return sdk.WorkflowDiff{}, err
+	}
+	b, err := toJSONValue(to)
+	if err != nil {
+		return sdk.WorkflowDiff{}, err
+	}
+
+	d := sdk.WorkflowDiff{
+		Patch:   diffValues("", a, b, nil),
+		Summary: []string{},
+	}
+	if d.Patch == nil {
+		d.Patch = []sdk.WorkflowDiffOperation{}
+	}
+	for _, op := range d.Patch {
+		line := summarize(op, a)
+		var found bool
+		for i := range d.Summary {
+			if d.Summary[i] == line {
+				found = true
+				break
+			}
+		}
+		if !found {
+			d.Summary = append(d.Summary, line)
+		}
+	}
+	return d, nil
+}
+
+// Patch applies the operations of a diff on a workflow. Values added or replaced in objects are set even if the key
+// is missing, values removed from objects are ignored if the key is missing, it fails if the parent of a value is
+// missing.
+func Patch(w Workflow, patch []sdk.WorkflowDiffOperation) (Workflow, error) {
+	v, err := toJSONValue(w)
+	if err != nil {
+		return w, err
+	}
+	for _, op := range patch {
+		v, err = patchValue(v, op)
+		if err != nil {
+			return w, err
+		}
+	}
+
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return w, sdk.WithStack(err)
+	}
+	var res Workflow
+	if err := json.Unmarshal(buf, &res); err != nil {
+		return w, sdk.WithStack(err)
+	}
+	return res, nil
+}
+
+func patchValue(v interface{}, op sdk.WorkflowDiffOperation) (interface{}, error) {
+	if op.Path == "" {
+		if op.Op == sdk.WorkflowDiffOperationRemove {
+			return nil, nil
+		}
+		return op.Value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(op.Path, "/"), "/")
+	for i := range parts {
+		parts[i] = unescapePointer(parts[i])
+	}
+	parent := v
+	for _, p := range parts[:len(parts)-1] {
+		switch vp := parent.(type) {
+		case map[string]interface{}:
+			parent = vp[p]
+		case []interface{}:
+			i, err := strconv.Atoi(p)
+			if err != nil || i < 0 || i >= len(vp) {
+				parent = nil
+				break
+			}
+			parent = vp[i]
+		default:
+			parent = nil
+		}
+		if parent == nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrConflict, "cannot %s %s, its parent does not exist", op.Op, op.Path)
+		}
+	}
+
+	last := parts[len(parts)-1]
+	switch vp := parent.(type) {
+	case map[string]interface{}:
+		if op.Op == sdk.WorkflowDiffOperationRemove {
+			delete(vp, last)
+		} else {
+			vp[last] = op.Value
+		}
+		return v, nil
+	case []interface{}:
+		i, err := strconv.Atoi(last)
+		if err == nil && i >= 0 && i < len(vp) && op.Op == sdk.WorkflowDiffOperationReplace {
+			vp[i] = op.Value
+			return v, nil
+		}
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrConflict, "cannot %s %s", op.Op, op.Path)
+}
+
+func toJSONValue(w Workflow) (interface{}, error) {
+	buf, err := json.Marshal(w)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	var v interface{}
+	if err := json.Unmarshal(buf, &v); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return v, nil
+}
+
+// diffValues compares values decoded from JSON. Objects are compared key by key, arrays of the same length item by
+// item, other arrays and values are replaced.
+func diffValues(path string, a, b interface{}, ops []sdk.WorkflowDiffOperation) []sdk.WorkflowDiffOperation {
+	if reflect.DeepEqual(a, b) {
+		return ops
+	}
+
+	switch va := a.(type) {
+	case map[string]interface{}:
+		vb, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(va)+len(vb))
+		for k := range va {
+			keys = append(keys, k)
+		}
+		for k := range vb {
+			if _, ok := va[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := path + "/" + escapePointer(k)
+			x, inA := va[k]
+			y, inB := vb[k]
+			switch {
+			case !inB:
+				ops = append(ops, sdk.WorkflowDiffOperation{Op: sdk.WorkflowDiffOperationRemove, Path: p})
+			case !inA:
+				ops = append(ops, sdk.WorkflowDiffOperation{Op: sdk.WorkflowDiffOperationAdd, Path: p, Value: y})
+			default:
+				ops = diffValues(p, x, y, ops)
+			}
+		}
+		return ops
+	case []interface{}:
+		vb, ok := b.([]interface{})
+		if !ok || len(va) != len(vb) {
+			break
+		}
+		for i := range va {
+			ops = diffValues(fmt.Sprintf("%s/%d", path, i), va[i], vb[i], ops)
+		}
+		return ops
+	}
+
+	return append(ops, sdk.WorkflowDiffOperation{Op: sdk.WorkflowDiffOperationReplace, Path: path, Value: b})
+}
+
+func escapePointer(s string) string {
+	return strings.Replace(strings.Replace(s, "~", "~0", -1), "/", "~1", -1)
+}
+
+func unescapePointer(s string) string {
+	return strings.Replace(strings.Replace(s, "~1", "/", -1), "~0", "~", -1)
+}
+
+// nodeFieldLabels gives the labels used in the summary for some fields of a node.
+var nodeFieldLabels = map[string]string{
+	"depends_on":    "parents",
+	"conditions":    "conditions",
+	"when":          "conditions",
+	"integration":   "integration",
+	"one_at_a_time": "one at a time option",
+	"trigger":       "outgoing hook",
+	"config":        "outgoing hook configuration",
+}
+
+// summarize returns a human readable description of given operation applied on given workflow.
+func summarize(op sdk.WorkflowDiffOperation, from interface{}) string {
+	parts := strings.Split(strings.TrimPrefix(op.Path, "/"), "/")
+	for i := range parts {
+		parts[i] = unescapePointer(parts[i])
+	}
+
+	switch parts[0] {
+	case "workflow":
+		if len(parts) == 1 {
+			return "nodes changed"
+		}
+		node := parts[1]
+		if len(parts) == 2 {
+			switch op.Op {
+			case sdk.WorkflowDiffOperationAdd:
+				return fmt.Sprintf("node %s added", node)
+			case sdk.WorkflowDiffOperationRemove:
+				return fmt.Sprintf("node %s removed", node)
+			}
+			return fmt.Sprintf("node %s changed", node)
+		}
+		field := parts[2]
+		label, ok := nodeFieldLabels[field]
+		if !ok {
+			label = field
+		}
+		if len(parts) == 3 {
+			oldValue := lookup(from, parts[:3])
+			_, oldIsString := oldValue.(string)
+			_, newIsString := op.Value.(string)
+			switch {
+			case op.Op == sdk.WorkflowDiffOperationReplace && oldIsString && newIsString:
+				return fmt.Sprintf("%s of node %s changed from %s to %s", label, node, oldValue, op.Value)
+			case op.Op == sdk.WorkflowDiffOperationAdd && newIsString:
+				return fmt.Sprintf("%s of node %s set to %s", label, node, op.Value)
+			case op.Op == sdk.WorkflowDiffOperationRemove && oldIsString:
+				return fmt.Sprintf("%s %s of node %s removed", label, oldValue, node)
+			}
+		}
+		return fmt.Sprintf("%s of node %s changed", label, node)
+	case "hooks":
+		if len(parts) > 1 {
+			return fmt.Sprintf("hooks of node %s changed", parts[1])
+		}
+		return "hooks changed"
+	case "run_form":
+		return "run form changed"
+	case "purge_tags":
+		return "purge tags changed"
+	case "history_length":
+		return "history length changed"
+	}
+	return fmt.Sprintf("%s changed", parts[0])
+}
+
+// lookup returns the value at given path in a value decoded from JSON.
+func lookup(v interface{}, path []string) interface{} {
+	for _, p := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[p]
+	}
+	return v
+}
@@ -0,0 +1,117 @@
+package v2_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	v2 "github.com/ovh/cds/sdk/exportentities/v2"
+)
+
+func TestDiff(t *testing.T) {
+	from := v2.Workflow{
+		Name:    "my-workflow",
+		Version: "v2.0",
+		Workflow: map[string]v2.NodeEntry{
+			"build": {PipelineName: "build", ApplicationName: "my-app"},
+			"deploy": {
+				PipelineName:           "deploy",
+				DependsOn:              []string{"build"},
+				ProjectIntegrationName: "prod-k8s",
+				Conditions: &v2.ConditionEntry{PlainConditions: []v2.PlainConditionEntry{
+					{Variable: "git.branch", Operator: "eq", Value: "master"},
+				}},
+			},
+			"it/tests": {PipelineName: "tests", DependsOn: []string{"build"}},
+		},
+	}
+
+	d, err := v2.Diff(from, from)
+	require.NoError(t, err)
+	assert.Empty(t, d.Patch)
+	assert.Empty(t, d.Summary)
+
+	to := v2.Workflow{
+		Name:    "my-workflow",
+		Version: "v2.0",
+		Workflow: map[string]v2.NodeEntry{
+			"build": {PipelineName: "build", ApplicationName: "my-app"},
+			"deploy": {
+				PipelineName:           "deploy",
+				DependsOn:              []string{"build"},
+				ProjectIntegrationName: "staging-k8s",
+				Conditions: &v2.ConditionEntry{PlainConditions: []v2.PlainConditionEntry{
+					{Variable: "git.branch", Operator: "eq", Value: "main"},
+				}},
+			},
+			"lint": {PipelineName: "lint", DependsOn: []string{"build"}},
+		},
+	}
+
+	d, err = v2.Diff(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.WorkflowDiffOperation{
+		{Op: sdk.WorkflowDiffOperationReplace, Path: "/workflow/deploy/conditions/plain/0/value", Value: "main"},
+		{Op: sdk.WorkflowDiffOperationReplace, Path: "/workflow/deploy/integration", Value: "staging-k8s"},
+		{Op: sdk.WorkflowDiffOperationRemove, Path: "/workflow/it~1tests"},
+		{Op: sdk.WorkflowDiffOperationAdd, Path: "/workflow/lint", Value: map[string]interface{}{
+			"pipeline":   "lint",
+			"depends_on": []interface{}{"build"},
+		}},
+	}, d.Patch)
+	assert.Equal(t, []string{
+		"conditions of node deploy changed",
+		"integration of node deploy changed from prod-k8s to staging-k8s",
+		"node it/tests removed",
+		"node lint added",
+	}, d.Summary)
+}
+
+func TestPatch(t *testing.T) {
+	from := v2.Workflow{
+		Name:    "my-workflow",
+		Version: "v2.0",
+		Workflow: map[string]v2.NodeEntry{
+			"build":  {PipelineName: "build", ApplicationName: "my-app"},
+			"deploy": {PipelineName: "deploy", DependsOn: []string{"build"}, ProjectIntegrationName: "prod-k8s"},
+		},
+	}
+	to := v2.Workflow{
+		Name:    "my-workflow",
+		Version: "v2.0",
+		Workflow: map[string]v2.NodeEntry{
+			"build":  {PipelineName: "build", ApplicationName: "my-app"},
+			"deploy": {PipelineName: "deploy", DependsOn: []string{"build"}, ProjectIntegrationName: "staging-k8s"},
+			"lint":   {PipelineName: "lint", DependsOn: []string{"build"}},
+		},
+	}
+	d, err := v2.Diff(from, to)
+	require.NoError(t, err)
+
+	res, err := v2.Patch(from, d.Patch)
+	require.NoError(t, err)
+	assert.Equal(t, to, res)
+
+	// The changes are applied on another version of the workflow
+	other := v2.Workflow{
+		Name:    "my-workflow",
+		Version: "v2.0",
+		Workflow: map[string]v2.NodeEntry{
+			"build":  {PipelineName: "build", ApplicationName: "my-app"},
+			"deploy": {PipelineName: "deploy-v2", DependsOn: []string{"build"}, ProjectIntegrationName: "prod-k8s"},
+		},
+	}
+	res, err = v2.Patch(other, d.Patch)
+	require.NoError(t, err)
+	assert.Equal(t, "deploy-v2", res.Workflow["deploy"].PipelineName)
+	assert.Equal(t, "staging-k8s", res.Workflow["deploy"].ProjectIntegrationName)
+	assert.Contains(t, res.Workflow, "lint")
+
+	// The changes can't be applied if the node was removed
+	delete(other.Workflow, "deploy")
+	_, err = v2.Patch(other, d.Patch)
+	require.Error(t, err)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrConflict))
+}
@@ -97,6 +97,7 @@ func TestWorkflow_checkValidity(t *testing.T) {
 		Workflow    map[string]v2.NodeEntry
 		Hooks       map[string][]v2.HookEntry
 		Permissions map[string]int
+		RunForm     *sdk.WorkflowRunForm
 	}
 	tests := []struct {
 		name    string
@@ -120,6 +121,36 @@ func TestWorkflow_checkValidity(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Node with an invalid display color should raise an error",
+			fields: fields{
+				Name:    "myWorkflow",
+				Version: exportentities.WorkflowVersion2,
+				Workflow: map[string]v2.NodeEntry{
+					"root": {
+						PipelineName: "pipeline",
+						Display:      &sdk.NodeDisplay{Label: "Root", Color: "green"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Run form with a choice parameter without choices should raise an error",
+			fields: fields{
+				Name:    "myWorkflow",
+				Version: exportentities.WorkflowVersion2,
+				Workflow: map[string]v2.NodeEntry{
+					"root": {
+						PipelineName: "pipeline",
+					},
+				},
+				RunForm: &sdk.WorkflowRunForm{
+					Parameters: []sdk.WorkflowRunFormParameter{{Name: "env", Type: sdk.WorkflowRunFormParameterChoice}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -129,6 +160,7 @@ func TestWorkflow_checkValidity(t *testing.T) {
 				Workflow:    tt.fields.Workflow,
 				Hooks:       tt.fields.Hooks,
 				Permissions: tt.fields.Permissions,
+				RunForm:     tt.fields.RunForm,
 			}
 			if err := w.CheckValidity(); (err != nil) != tt.wantErr {
 				t.Errorf("Workflow.checkValidity() error = %v, wantErr %v", err, tt.wantErr)
@@ -956,6 +988,69 @@ workflow:
     - aa_2
     when:
     - manual
+`,
+		},
+		{
+			name: "Nodes and join with display",
+			yaml: `name: display
+version: v2.0
+workflow:
+  build:
+    pipeline: build
+    display:
+      label: Build
+      color: '#21ba45'
+  deploy:
+    depends_on:
+    - tests-done
+    when:
+    - success
+    pipeline: deploy
+    display:
+      color: '#db2828'
+      group: delivery
+  test-a:
+    depends_on:
+    - build
+    when:
+    - success
+    pipeline: test
+    display:
+      group: tests
+  test-b:
+    depends_on:
+    - build
+    when:
+    - success
+    pipeline: test
+    display:
+      group: tests
+  tests-done:
+    depends_on:
+    - test-a
+    - test-b
+    display:
+      label: All tests passed
+`,
+		},
+		{
+			name: "Workflow with artifact storages",
+			yaml: `name: storages
+version: v2.0
+workflow:
+  build:
+    pipeline: build
+  deploy-eu:
+    depends_on:
+    - build
+    when:
+    - success
+    pipeline: deploy
+artifact_storages:
+- integration: eu-bucket
+  nodes:
+  - deploy-eu
+- integration: default-bucket
 `,
 		},
 		{
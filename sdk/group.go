@@ -8,6 +8,9 @@ const (
 	PermissionRead = 4
 	// PermissionReadExecute  read & execute permission on the resource
 	PermissionReadExecute = 5
+	// PermissionReadExecuteDeploy read & execute permission on the resource, including the nodes of workflows bound to
+	// a deployment integration
+	PermissionReadExecuteDeploy = 6
 	// PermissionReadWriteExecute read/execute/write permission on the resource
 	PermissionReadWriteExecute = 7
 )
@@ -15,7 +18,7 @@ const (
 // IsValidPermissionValue checks that given permission int value match an exiting level.
 func IsValidPermissionValue(v int) bool {
 	switch v {
-	case PermissionRead, PermissionReadExecute, PermissionReadWriteExecute:
+	case PermissionRead, PermissionReadExecute, PermissionReadExecuteDeploy, PermissionReadWriteExecute:
 		return true
 	}
 	return false
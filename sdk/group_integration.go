@@ -0,0 +1,50 @@
+package sdk
+
+// GroupIntegration is a default integration of a group, it is copied on projects created with the group.
+// Only artifact storage and event integrations can be set as default.
+type GroupIntegration struct {
+	ID                 int64             `json:"id" db:"id" yaml:"-"`
+	GroupID            int64             `json:"group_id" db:"group_id" yaml:"-"`
+	Name               string            `json:"name" db:"name" cli:"name,key" yaml:"name"`
+	IntegrationModelID int64             `json:"integration_model_id" db:"integration_model_id" yaml:"-"`
+	Model              IntegrationModel  `json:"model" db:"-" yaml:"model"`
+	Config             IntegrationConfig `json:"config" db:"cipher_config" yaml:"config" gorpmapping:"encrypted,GroupID,IntegrationModelID"`
+}
+
+// IsValid returns an error if given group integration is not valid.
+func (g GroupIntegration) IsValid() error {
+	if !NamePatternRegex.MatchString(g.Name) {
+		return NewErrorFrom(ErrInvalidName, "invalid integration name, should match %s", NamePattern)
+	}
+	if g.Model.ID == 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid integration model")
+	}
+	if !g.Model.Storage && !g.Model.Event {
+		return NewErrorFrom(ErrWrongRequest, "only storage and event integrations can be set as default for a group")
+	}
+	if g.Model.Public {
+		return NewErrorFrom(ErrWrongRequest, "public integration %s is already available on all projects", g.Model.Name)
+	}
+	return g.Config.CheckConnectivity()
+}
+
+// Blur replaces password with a placeholder
+func (g *GroupIntegration) Blur() {
+	g.Config.Blur()
+	g.Model.Blur()
+	g.Model.DefaultConfig.Blur()
+	g.Model.DeploymentDefaultConfig.Blur()
+}
+
+// ProjectIntegration returns a project integration inherited from the group integration.
+func (g GroupIntegration) ProjectIntegration(projectID int64) ProjectIntegration {
+	id := g.ID
+	return ProjectIntegration{
+		ProjectID:          projectID,
+		Name:               g.Name,
+		IntegrationModelID: g.IntegrationModelID,
+		Model:              g.Model,
+		Config:             g.Config.Clone(),
+		GroupIntegrationID: &id,
+	}
+}
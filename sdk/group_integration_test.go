@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupIntegrationIsValid(t *testing.T) {
+	gi := GroupIntegration{Name: "my-kafka", Model: IntegrationModel{ID: 1, Name: KafkaIntegrationModel, Event: true}}
+	assert.NoError(t, gi.IsValid())
+
+	gi.Name = "my kafka"
+	assert.Error(t, gi.IsValid())
+
+	// Only storage and event integrations can be inherited
+	gi = GroupIntegration{Name: "my-k8s", Model: IntegrationModel{ID: 2, Name: "Kubernetes", Deployment: true}}
+	assert.Error(t, gi.IsValid())
+}
+
+func TestGroupIntegrationProjectIntegration(t *testing.T) {
+	gi := GroupIntegration{
+		ID:                 3,
+		Name:               "my-kafka",
+		IntegrationModelID: 1,
+		Config:             IntegrationConfig{"password": {Type: IntegrationConfigTypePassword, Value: "secret"}},
+	}
+
+	pi := gi.ProjectIntegration(42)
+	assert.Equal(t, int64(42), pi.ProjectID)
+	assert.Equal(t, "my-kafka", pi.Name)
+	assert.Equal(t, int64(1), pi.IntegrationModelID)
+	require.NotNil(t, pi.GroupIntegrationID)
+	assert.Equal(t, int64(3), *pi.GroupIntegrationID)
+	assert.False(t, pi.Overridden)
+
+	// The config is copied
+	pi.Config["password"] = IntegrationConfigValue{Type: IntegrationConfigTypePassword, Value: "other"}
+	assert.Equal(t, "secret", gi.Config["password"].Value)
+}
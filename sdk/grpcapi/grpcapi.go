@@ -0,0 +1,90 @@
+package grpcapi
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Token are gRPC credentials that send a session JWT as bearer token with each call.
+type Token struct {
+	JWT string
+	// Insecure allows to send the token on a connection without transport security.
+	Insecure bool
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (t Token) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.JWT}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+func (t Token) RequireTransportSecurity() bool {
+	return !t.Insecure
+}
+
+// Time returns given time as a protobuf timestamp, nil if the time is zero.
+func Time(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
+
+// NewJob returns the queue job for given workflow node job run.
+func NewJob(j sdk.WorkflowNodeJobRun) *Job {
+	res := &Job{
+		Id:                j.ID,
+		ProjectId:         j.ProjectID,
+		WorkflowNodeRunId: j.WorkflowNodeRunID,
+		Name:              j.Job.Action.Name,
+		Status:            j.Status,
+		Queued:            Time(j.Queued),
+		Model:             j.Model,
+		ModelType:         j.ModelType,
+		BookedBy:          j.BookedBy.Name,
+		ContainsService:   j.ContainsService,
+	}
+	for _, r := range j.Job.Action.Requirements {
+		res.Requirements = append(res.Requirements, &Requirement{
+			Name:  r.Name,
+			Type:  r.Type,
+			Value: r.Value,
+		})
+	}
+	return res
+}
+
+// NewRunStatus returns the status of given workflow run and of all its node runs, sorted by id.
+func NewRunStatus(wr sdk.WorkflowRun) *RunStatus {
+	res := &RunStatus{
+		Id:           wr.ID,
+		Number:       wr.Number,
+		Status:       wr.Status,
+		LastModified: Time(wr.LastModified),
+	}
+	for _, nrs := range wr.WorkflowNodeRuns {
+		for _, nr := range nrs {
+			res.Nodes = append(res.Nodes, &NodeRunStatus{
+				Id:             nr.ID,
+				WorkflowNodeId: nr.WorkflowNodeID,
+				Name:           nr.WorkflowNodeName,
+				SubNumber:      nr.SubNumber,
+				Status:         nr.Status,
+				Start:          Time(nr.Start),
+				Done:           Time(nr.Done),
+			})
+		}
+	}
+	sort.Slice(res.Nodes, func(i, j int) bool { return res.Nodes[i].Id < res.Nodes[j].Id })
+	return res
+}
@@ -0,0 +1,816 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: grpcapi.proto
+
+package grpcapi
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type QueueFilter struct {
+	Status               []string             `protobuf:"bytes,1,rep,name=status,proto3" json:"status,omitempty"`
+	ModelType            string               `protobuf:"bytes,2,opt,name=model_type,json=modelType,proto3" json:"model_type,omitempty"`
+	Since                *timestamp.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	Limit                int32                `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *QueueFilter) Reset()         { *m = QueueFilter{} }
+func (m *QueueFilter) String() string { return proto.CompactTextString(m) }
+func (*QueueFilter) ProtoMessage()    {}
+func (*QueueFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7b78476b7b33751, []int{0}
+}
+
+func (m *QueueFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueueFilter.Unmarshal(m, b)
+}
+func (m *QueueFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueueFilter.Marshal(b, m, deterministic)
+}
+func (m *QueueFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueueFilter.Merge(m, src)
+}
+func (m *QueueFilter) XXX_Size() int {
+	return xxx_messageInfo_QueueFilter.Size(m)
+}
+func (m *QueueFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueueFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueueFilter proto.InternalMessageInfo
+
+func (m *QueueFilter) GetStatus() []string {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *QueueFilter) GetModelType() string {
+	if m != nil {
+		return m.ModelType
+	}
+	return ""
+}
+
+func (m *QueueFilter) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *QueueFilter) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type Requirement struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Value                string   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Requirement) Reset()         { *m = Requirement{} }
+func (m *Requirement) String() string { return proto.CompactTextString(m) }
+func (*Requirement) ProtoMessage()    {}
+func (*Requirement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7b78476b7b33751, []int{1}
+}
+
+func (m *Requirement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Requirement.Unmarshal(m, b)
+}
+func (m *Requirement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Requirement.Marshal(b, m, deterministic)
+}
+func (m *Requirement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Requirement.Merge(m, src)
+}
+func (m *Requirement) XXX_Size() int {
+	return xxx_messageInfo_Requirement.Size(m)
+}
+func (m *Requirement) XXX_DiscardUnknown() {
+	xxx_messageInfo_Requirement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Requirement proto.InternalMessageInfo
+
+func (m *Requirement) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Requirement) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Requirement) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type Job struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId            int64                `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	WorkflowNodeRunId    int64                `protobuf:"varint,3,opt,name=workflow_node_run_id,json=workflowNodeRunId,proto3" json:"workflow_node_run_id,omitempty"`
+	Name                 string               `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Status               string               `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Queued               *timestamp.Timestamp `protobuf:"bytes,6,opt,name=queued,proto3" json:"queued,omitempty"`
+	Model                string               `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`
+	ModelType            string               `protobuf:"bytes,8,opt,name=model_type,json=modelType,proto3" json:"model_type,omitempty"`
+	Requirements         []*Requirement       `protobuf:"bytes,9,rep,name=requirements,proto3" json:"requirements,omitempty"`
+	BookedBy             string               `protobuf:"bytes,10,opt,name=booked_by,json=bookedBy,proto3" json:"booked_by,omitempty"`
+	ContainsService      bool                 `protobuf:"varint,11,opt,name=contains_service,json=containsService,proto3" json:"contains_service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Job) Reset()         { *m = Job{} }
+func (m *Job) String() string { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()    {}
+func (*Job) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7b78476b7b33751, []int{2}
+}
+
+func (m *Job) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Job.Unmarshal(m, b)
+}
+func (m *Job) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Job.Marshal(b, m, deterministic)
+}
+func (m *Job) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Job.Merge(m, src)
+}
+func (m *Job) XXX_Size() int {
+	return xxx_messageInfo_Job.Size(m)
+}
+func (m *Job) XXX_DiscardUnknown() {
+	xxx_messageInfo_Job.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Job proto.InternalMessageInfo
+
+func (m *Job) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Job) GetProjectId() int64 {
+	if m != nil {
+		return m.ProjectId
+	}
+	return 0
+}
+
+func (m *Job) GetWorkflowNodeRunId() int64 {
+	if m != nil {
+		return m.WorkflowNodeRunId
+	}
+	return 0
+}
+
+func (m *Job) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Job) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Job) GetQueued() *timestamp.Timestamp {
+	if m != nil {
+		return m.Queued
+	}
+	return nil
+}
+
+func (m *Job) GetModel() string {
+	if m != nil {
+		return m.Model
+	}
+	return ""
+}
+
+func (m *Job) GetModelType() string {
+	if m != nil {
+		return m.ModelType
+	}
+	return ""
+}
+
+func (m *Job) GetRequirements() []*Requirement {
+	if m != nil {
+		return m.Requirements
+	}
+	return nil
+}
+
+func (m *Job) GetBookedBy() string {
+	if m != nil {
+		return m.BookedBy
+	}
+	return ""
+}
+
+func (m *Job) GetContainsService() bool {
+	if m != nil {
+		return m.ContainsService
+	}
+	return false
+}
+
+type JobList struct {
+	Jobs                 []*Job   `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobList) Reset()         { *m = JobList{} }
+func (m *JobList) String() string { return proto.CompactTextString(m) }
+func (*JobList) ProtoMessage()    {}
+func (*JobList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7b78476b7b33751, []int{3}
+}
+
+func (m *JobList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobList.Unmarshal(m, b)
+}
+func (m *JobList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobList.Marshal(b, m, deterministic)
+}
+func (m *JobList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobList.Merge(m, src)
+}
+func (m *JobList) XXX_Size() int {
+	return xxx_messageInfo_JobList.Size(m)
+}
+func (m *JobList) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobList proto.InternalMessageInfo
+
+func (m *JobList) GetJobs() []*Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+type JobID struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobID) Reset()         { *m = JobID{} }
+func (m *JobID) String() string { return proto.CompactTextString(m) }
+func (*JobID) ProtoMessage()    {}
+func (*JobID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7b78476b7b33751, []int{4}
+}
+
+func (m *JobID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobID.Unmarshal(m, b)
+}
+func (m *JobID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobID.Marshal(b, m, deterministic)
+}
+func (m *JobID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobID.Merge(m, src)
+}
+func (m *JobID) XXX_Size() int {
+	return xxx_messageInfo_JobID.Size(m)
+}
+func (m *JobID) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobID proto.InternalMessageInfo
+
+func (m *JobID) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type RunQuery struct {
+	ProjectKey           string   `protobuf:"bytes,1,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	WorkflowName         string   `protobuf:"bytes,2,opt,name=workflow_name,json=workflowName,proto3" json:"workflow_name,omitempty"`
+	Number               int64    `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunQuery) Reset()         { *m = RunQuery{} }
+func (m *RunQuery) String() string { return proto.CompactTextString(m) }
+func (*RunQuery) ProtoMessage()    {}
+func (*RunQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7b78476b7b33751, []int{5}
+}
+
+func (m *RunQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunQuery.Unmarshal(m, b)
+}
+func (m *RunQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunQuery.Marshal(b, m, deterministic)
+}
+func (m *RunQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunQuery.Merge(m, src)
+}
+func (m *RunQuery) XXX_Size() int {
+	return xxx_messageInfo_RunQuery.Size(m)
+}
+func (m *RunQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunQuery proto.InternalMessageInfo
+
+func (m *RunQuery) GetProjectKey() string {
+	if m != nil {
+		return m.ProjectKey
+	}
+	return ""
+}
+
+func (m *RunQuery) GetWorkflowName() string {
+	if m != nil {
+		return m.WorkflowName
+	}
+	return ""
+}
+
+func (m *RunQuery) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+type NodeRunStatus struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WorkflowNodeId       int64                `protobuf:"varint,2,opt,name=workflow_node_id,json=workflowNodeId,proto3" json:"workflow_node_id,omitempty"`
+	Name                 string               `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	SubNumber            int64                `protobuf:"varint,4,opt,name=sub_number,json=subNumber,proto3" json:"sub_number,omitempty"`
+	Status               string               `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Start                *timestamp.Timestamp `protobuf:"bytes,6,opt,name=start,proto3" json:"start,omitempty"`
+	Done                 *timestamp.Timestamp `protobuf:"bytes,7,opt,name=done,proto3" json:"done,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *NodeRunStatus) Reset()         { *m = NodeRunStatus{} }
+func (m *NodeRunStatus) String() string { return proto.CompactTextString(m) }
+func (*NodeRunStatus) ProtoMessage()    {}
+func (*NodeRunStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7b78476b7b33751, []int{6}
+}
+
+func (m *NodeRunStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeRunStatus.Unmarshal(m, b)
+}
+func (m *NodeRunStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeRunStatus.Marshal(b, m, deterministic)
+}
+func (m *NodeRunStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeRunStatus.Merge(m, src)
+}
+func (m *NodeRunStatus) XXX_Size() int {
+	return xxx_messageInfo_NodeRunStatus.Size(m)
+}
+func (m *NodeRunStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeRunStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeRunStatus proto.InternalMessageInfo
+
+func (m *NodeRunStatus) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *NodeRunStatus) GetWorkflowNodeId() int64 {
+	if m != nil {
+		return m.WorkflowNodeId
+	}
+	return 0
+}
+
+func (m *NodeRunStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NodeRunStatus) GetSubNumber() int64 {
+	if m != nil {
+		return m.SubNumber
+	}
+	return 0
+}
+
+func (m *NodeRunStatus) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *NodeRunStatus) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *NodeRunStatus) GetDone() *timestamp.Timestamp {
+	if m != nil {
+		return m.Done
+	}
+	return nil
+}
+
+type RunStatus struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number               int64                `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Status               string               `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	LastModified         *timestamp.Timestamp `protobuf:"bytes,4,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	Nodes                []*NodeRunStatus     `protobuf:"bytes,5,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RunStatus) Reset()         { *m = RunStatus{} }
+func (m *RunStatus) String() string { return proto.CompactTextString(m) }
+func (*RunStatus) ProtoMessage()    {}
+func (*RunStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7b78476b7b33751, []int{7}
+}
+
+func (m *RunStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunStatus.Unmarshal(m, b)
+}
+func (m *RunStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunStatus.Marshal(b, m, deterministic)
+}
+func (m *RunStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunStatus.Merge(m, src)
+}
+func (m *RunStatus) XXX_Size() int {
+	return xxx_messageInfo_RunStatus.Size(m)
+}
+func (m *RunStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunStatus proto.InternalMessageInfo
+
+func (m *RunStatus) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *RunStatus) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *RunStatus) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *RunStatus) GetLastModified() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastModified
+	}
+	return nil
+}
+
+func (m *RunStatus) GetNodes() []*NodeRunStatus {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*QueueFilter)(nil), "grpcapi.QueueFilter")
+	proto.RegisterType((*Requirement)(nil), "grpcapi.Requirement")
+	proto.RegisterType((*Job)(nil), "grpcapi.Job")
+	proto.RegisterType((*JobList)(nil), "grpcapi.JobList")
+	proto.RegisterType((*JobID)(nil), "grpcapi.JobID")
+	proto.RegisterType((*RunQuery)(nil), "grpcapi.RunQuery")
+	proto.RegisterType((*NodeRunStatus)(nil), "grpcapi.NodeRunStatus")
+	proto.RegisterType((*RunStatus)(nil), "grpcapi.RunStatus")
+}
+
+func init() { proto.RegisterFile("grpcapi.proto", fileDescriptor_a7b78476b7b33751) }
+
+var fileDescriptor_a7b78476b7b33751 = []byte{
+	// 740 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xcd, 0x4e, 0xdb, 0x4a,
+	0x14, 0xc6, 0xb1, 0xf3, 0xe3, 0x93, 0x84, 0x0b, 0x23, 0x04, 0x56, 0x10, 0x22, 0xf2, 0xdd, 0xe4,
+	0xea, 0x5e, 0x19, 0x94, 0xab, 0x4a, 0xdd, 0x55, 0x4d, 0x69, 0xab, 0x84, 0x16, 0x85, 0x81, 0x0a,
+	0xa9, 0x1b, 0xcb, 0x3f, 0x03, 0x98, 0xc4, 0x1e, 0x63, 0x8f, 0x41, 0x7e, 0x87, 0x3e, 0x51, 0x1f,
+	0xa6, 0x8f, 0xd1, 0x55, 0x17, 0xd5, 0xcc, 0xd8, 0x89, 0x93, 0x0a, 0xd1, 0xee, 0x7c, 0xce, 0x7c,
+	0x33, 0xe7, 0x9c, 0xef, 0xfb, 0x66, 0x0c, 0xdd, 0x9b, 0x24, 0xf6, 0x9c, 0x38, 0xb0, 0xe2, 0x84,
+	0x32, 0x8a, 0x9a, 0x45, 0xd8, 0xdb, 0xbf, 0xa1, 0xf4, 0x66, 0x4e, 0x8e, 0x44, 0xda, 0xcd, 0xae,
+	0x8f, 0x48, 0x18, 0xb3, 0x5c, 0xa2, 0x7a, 0x87, 0xeb, 0x8b, 0x2c, 0x08, 0x49, 0xca, 0x9c, 0x30,
+	0x96, 0x00, 0xf3, 0x8b, 0x02, 0xed, 0xf3, 0x8c, 0x64, 0xe4, 0x5d, 0x30, 0x67, 0x24, 0x41, 0xbb,
+	0xd0, 0x48, 0x99, 0xc3, 0xb2, 0xd4, 0x50, 0xfa, 0xea, 0x40, 0xc7, 0x45, 0x84, 0x0e, 0x00, 0x42,
+	0xea, 0x93, 0xb9, 0xcd, 0xf2, 0x98, 0x18, 0xb5, 0xbe, 0x32, 0xd0, 0xb1, 0x2e, 0x32, 0x97, 0x79,
+	0x4c, 0xd0, 0x31, 0xd4, 0xd3, 0x20, 0xf2, 0x88, 0xa1, 0xf6, 0x95, 0x41, 0x7b, 0xd8, 0xb3, 0x64,
+	0x5d, 0xab, 0xac, 0x6b, 0x5d, 0x96, 0x75, 0xb1, 0x04, 0xa2, 0x1d, 0xa8, 0xcf, 0x83, 0x30, 0x60,
+	0x86, 0xd6, 0x57, 0x06, 0x75, 0x2c, 0x03, 0xf3, 0x14, 0xda, 0x98, 0xdc, 0x67, 0x41, 0x42, 0x42,
+	0x12, 0x31, 0x84, 0x40, 0x8b, 0x9c, 0x90, 0x18, 0x8a, 0xa8, 0x27, 0xbe, 0x79, 0xae, 0xd2, 0x83,
+	0xf8, 0xe6, 0x87, 0x3d, 0x38, 0xf3, 0x4c, 0x96, 0xd7, 0xb1, 0x0c, 0xcc, 0x1f, 0x35, 0x50, 0x27,
+	0xd4, 0x45, 0x9b, 0x50, 0x0b, 0x7c, 0x71, 0x86, 0x8a, 0x6b, 0x81, 0xcf, 0x67, 0x89, 0x13, 0x7a,
+	0x47, 0x3c, 0x66, 0x07, 0xbe, 0x38, 0x47, 0xc5, 0x7a, 0x91, 0x19, 0xfb, 0xe8, 0x08, 0x76, 0x1e,
+	0x69, 0x32, 0xbb, 0x9e, 0xd3, 0x47, 0x3b, 0xa2, 0x3e, 0xb1, 0x93, 0x2c, 0xe2, 0x40, 0x55, 0x00,
+	0xb7, 0xcb, 0xb5, 0x33, 0xea, 0x13, 0x9c, 0x45, 0x63, 0x7f, 0xd1, 0xa5, 0x56, 0xe9, 0x72, 0xc9,
+	0x63, 0x5d, 0x64, 0x8b, 0x08, 0x0d, 0xa1, 0x71, 0xcf, 0xe9, 0xf6, 0x8d, 0xc6, 0xb3, 0x4c, 0x15,
+	0x48, 0x3e, 0x9d, 0x60, 0xda, 0x68, 0xca, 0xe9, 0x44, 0xb0, 0xa6, 0x48, 0x6b, 0x5d, 0x91, 0x97,
+	0xd0, 0x49, 0x96, 0x4c, 0xa6, 0x86, 0xde, 0x57, 0x07, 0xed, 0xe1, 0x8e, 0x55, 0xba, 0xa8, 0x42,
+	0x33, 0x5e, 0x41, 0xa2, 0x7d, 0xd0, 0x5d, 0x4a, 0x67, 0xc4, 0xb7, 0xdd, 0xdc, 0x00, 0x71, 0x6e,
+	0x4b, 0x26, 0x46, 0x39, 0xfa, 0x07, 0xb6, 0x3c, 0x1a, 0x31, 0x27, 0x88, 0x52, 0x3b, 0x25, 0xc9,
+	0x43, 0xe0, 0x11, 0xa3, 0xdd, 0x57, 0x06, 0x2d, 0xfc, 0x57, 0x99, 0xbf, 0x90, 0x69, 0xf3, 0x5f,
+	0x68, 0x4e, 0xa8, 0xfb, 0x21, 0x48, 0x19, 0xea, 0x83, 0x76, 0x47, 0x5d, 0xe9, 0xa9, 0xf6, 0xb0,
+	0xb3, 0x68, 0x62, 0x42, 0x5d, 0x2c, 0x56, 0xcc, 0x3d, 0xa8, 0x4f, 0xa8, 0x3b, 0x3e, 0x59, 0x17,
+	0xcb, 0xbc, 0x85, 0x16, 0xce, 0xa2, 0xf3, 0x8c, 0x24, 0x39, 0x3a, 0x84, 0x76, 0x29, 0xdc, 0x8c,
+	0xe4, 0x85, 0x2b, 0x4a, 0x2d, 0x4f, 0x49, 0x8e, 0xfe, 0x86, 0xee, 0x52, 0x3a, 0x2e, 0x89, 0x34,
+	0x49, 0x67, 0xa1, 0x59, 0x21, 0x4d, 0x94, 0x85, 0x2e, 0x49, 0x0a, 0x45, 0x8b, 0xc8, 0xfc, 0xae,
+	0x40, 0xb7, 0x10, 0xf5, 0x42, 0x8a, 0xb5, 0x6e, 0x9c, 0x01, 0x6c, 0xad, 0x3a, 0x63, 0x61, 0x9f,
+	0xcd, 0xaa, 0x2b, 0x2a, 0x96, 0x50, 0x2b, 0x96, 0x38, 0x00, 0x48, 0x33, 0xd7, 0x2e, 0x6a, 0x6b,
+	0xd2, 0x76, 0x69, 0xe6, 0x9e, 0x89, 0xc4, 0x93, 0x8e, 0xe1, 0x57, 0x8b, 0x39, 0x09, 0xfb, 0x0d,
+	0xc3, 0x48, 0x20, 0xb2, 0x40, 0xf3, 0x69, 0x44, 0x8c, 0xe6, 0xb3, 0x1b, 0x04, 0xce, 0xfc, 0xaa,
+	0x80, 0xfe, 0xf4, 0xd0, 0x4b, 0xba, 0x6a, 0x55, 0xba, 0x2a, 0xfd, 0xaa, 0x2b, 0xfd, 0xbe, 0x82,
+	0xee, 0xdc, 0x49, 0x99, 0x1d, 0x52, 0x3f, 0xb8, 0x0e, 0x88, 0x6f, 0x68, 0xcf, 0xb6, 0xd1, 0xe1,
+	0x1b, 0x3e, 0x16, 0x78, 0xf4, 0x1f, 0xd4, 0x39, 0xb9, 0x9c, 0x07, 0xee, 0x96, 0xdd, 0x85, 0x5b,
+	0x56, 0xc4, 0xc1, 0x12, 0x34, 0xfc, 0xa6, 0x40, 0xf7, 0xaa, 0x20, 0x5f, 0x3c, 0x64, 0xe8, 0x18,
+	0xb4, 0x09, 0x75, 0x53, 0xb4, 0xf4, 0x7a, 0xe5, 0x81, 0xeb, 0x6d, 0x55, 0xcd, 0xc7, 0xcd, 0x69,
+	0x6e, 0xf0, 0x1d, 0x23, 0x4a, 0x67, 0x68, 0xb3, 0xba, 0x36, 0x3e, 0xe9, 0xed, 0xfe, 0xd2, 0xf3,
+	0x5b, 0xfe, 0xb6, 0x9a, 0x1b, 0xfc, 0x1a, 0x7f, 0x8a, 0xdc, 0x3f, 0xdb, 0xf3, 0x02, 0x5a, 0x57,
+	0x0e, 0xf3, 0x6e, 0x71, 0x16, 0xa1, 0xed, 0xe5, 0x3d, 0x2c, 0xcc, 0xdd, 0x43, 0xd5, 0x94, 0x9c,
+	0xd1, 0xdc, 0x38, 0x56, 0x46, 0x43, 0xd8, 0xf3, 0x68, 0x68, 0xd1, 0x87, 0x5b, 0xcb, 0xf3, 0x53,
+	0x2b, 0xf5, 0x67, 0x25, 0x70, 0xd4, 0x79, 0x8f, 0xa7, 0x6f, 0x5e, 0x4f, 0xc7, 0x53, 0x5e, 0x6a,
+	0xaa, 0x7c, 0x2e, 0xff, 0x09, 0x6e, 0x43, 0x14, 0xff, 0xff, 0xe7, 0x00, 0xb5, 0x11, 0x0e, 0x94,
+	0x34, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// WorkflowQueueClient is the client API for WorkflowQueue service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WorkflowQueueClient interface {
+	Jobs(ctx context.Context, in *QueueFilter, opts ...grpc.CallOption) (*JobList, error)
+	Book(ctx context.Context, in *JobID, opts ...grpc.CallOption) (*empty.Empty, error)
+	Unbook(ctx context.Context, in *JobID, opts ...grpc.CallOption) (*empty.Empty, error)
+	WatchRun(ctx context.Context, in *RunQuery, opts ...grpc.CallOption) (WorkflowQueue_WatchRunClient, error)
+}
+
+type workflowQueueClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWorkflowQueueClient(cc *grpc.ClientConn) WorkflowQueueClient {
+	return &workflowQueueClient{cc}
+}
+
+func (c *workflowQueueClient) Jobs(ctx context.Context, in *QueueFilter, opts ...grpc.CallOption) (*JobList, error) {
+	out := new(JobList)
+	err := c.cc.Invoke(ctx, "/grpcapi.WorkflowQueue/Jobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowQueueClient) Book(ctx context.Context, in *JobID, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/grpcapi.WorkflowQueue/Book", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowQueueClient) Unbook(ctx context.Context, in *JobID, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/grpcapi.WorkflowQueue/Unbook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowQueueClient) WatchRun(ctx context.Context, in *RunQuery, opts ...grpc.CallOption) (WorkflowQueue_WatchRunClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WorkflowQueue_serviceDesc.Streams[0], "/grpcapi.WorkflowQueue/WatchRun", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workflowQueueWatchRunClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WorkflowQueue_WatchRunClient interface {
+	Recv() (*RunStatus, error)
+	grpc.ClientStream
+}
+
+type workflowQueueWatchRunClient struct {
+	grpc.ClientStream
+}
+
+func (x *workflowQueueWatchRunClient) Recv() (*RunStatus, error) {
+	m := new(RunStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WorkflowQueueServer is the server API for WorkflowQueue service.
+type WorkflowQueueServer interface {
+	Jobs(context.Context, *QueueFilter) (*JobList, error)
+	Book(context.Context, *JobID) (*empty.Empty, error)
+	Unbook(context.Context, *JobID) (*empty.Empty, error)
+	WatchRun(*RunQuery, WorkflowQueue_WatchRunServer) error
+}
+
+func RegisterWorkflowQueueServer(s *grpc.Server, srv WorkflowQueueServer) {
+	s.RegisterService(&_WorkflowQueue_serviceDesc, srv)
+}
+
+func _WorkflowQueue_Jobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowQueueServer).Jobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcapi.WorkflowQueue/Jobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowQueueServer).Jobs(ctx, req.(*QueueFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowQueue_Book_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowQueueServer).Book(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcapi.WorkflowQueue/Book",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowQueueServer).Book(ctx, req.(*JobID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowQueue_Unbook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowQueueServer).Unbook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcapi.WorkflowQueue/Unbook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowQueueServer).Unbook(ctx, req.(*JobID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowQueue_WatchRun_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkflowQueueServer).WatchRun(m, &workflowQueueWatchRunServer{stream})
+}
+
+type WorkflowQueue_WatchRunServer interface {
+	Send(*RunStatus) error
+	grpc.ServerStream
+}
+
+type workflowQueueWatchRunServer struct {
+	grpc.ServerStream
+}
+
+func (x *workflowQueueWatchRunServer) Send(m *RunStatus) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _WorkflowQueue_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "grpcapi.WorkflowQueue",
+	HandlerType: (*WorkflowQueueServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Jobs",
+			Handler:    _WorkflowQueue_Jobs_Handler,
+		},
+		{
+			MethodName: "Book",
+			Handler:    _WorkflowQueue_Book_Handler,
+		},
+		{
+			MethodName: "Unbook",
+			Handler:    _WorkflowQueue_Unbook_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRun",
+			Handler:       _WorkflowQueue_WatchRun_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "grpcapi.proto",
+}
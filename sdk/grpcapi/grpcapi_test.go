@@ -0,0 +1,54 @@
+package grpcapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestNewJob(t *testing.T) {
+	queued := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var j sdk.WorkflowNodeJobRun
+	j.ID = 1
+	j.ProjectID = 2
+	j.Status = sdk.StatusWaiting
+	j.Queued = queued
+	j.Job.Action.Name = "build"
+	j.Job.Action.Requirements = []sdk.Requirement{{Name: "go", Type: sdk.BinaryRequirement, Value: "go"}}
+
+	res := NewJob(j)
+	assert.Equal(t, int64(1), res.Id)
+	assert.Equal(t, int64(2), res.ProjectId)
+	assert.Equal(t, "build", res.Name)
+	assert.Equal(t, sdk.StatusWaiting, res.Status)
+	require.Len(t, res.Requirements, 1)
+	assert.Equal(t, "go", res.Requirements[0].Value)
+
+	ts, err := ptypes.Timestamp(res.Queued)
+	require.NoError(t, err)
+	assert.Equal(t, queued, ts)
+}
+
+func TestNewRunStatus(t *testing.T) {
+	res := NewRunStatus(sdk.WorkflowRun{
+		ID:     1,
+		Number: 10,
+		Status: sdk.StatusBuilding,
+		WorkflowNodeRuns: map[int64][]sdk.WorkflowNodeRun{
+			2: {{ID: 4, WorkflowNodeID: 2, WorkflowNodeName: "deploy", Status: sdk.StatusBuilding}},
+			1: {{ID: 3, WorkflowNodeID: 1, WorkflowNodeName: "build", Status: sdk.StatusSuccess, Start: time.Now()}},
+		},
+	})
+	assert.Equal(t, int64(10), res.Number)
+	assert.Nil(t, res.LastModified)
+	require.Len(t, res.Nodes, 2)
+	assert.Equal(t, "build", res.Nodes[0].Name)
+	assert.NotNil(t, res.Nodes[0].Start)
+	assert.Nil(t, res.Nodes[0].Done)
+	assert.Equal(t, "deploy", res.Nodes[1].Name)
+}
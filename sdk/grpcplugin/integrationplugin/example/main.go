@@ -41,6 +41,13 @@ func (e *ExamplePlugin) DeployStatus(ctx context.Context, q *integrationplugin.D
 	}, nil
 }
 
+func (e *ExamplePlugin) Plan(ctx context.Context, q *integrationplugin.DeployQuery) (*integrationplugin.DeployResult, error) {
+	return &integrationplugin.DeployResult{
+		Details: "nothing to change",
+		Status:  "success",
+	}, nil
+}
+
 func main() {
 	if os.Args[1:][0] == "serve" {
 		e := ExamplePlugin{}
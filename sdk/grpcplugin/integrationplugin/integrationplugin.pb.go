@@ -223,33 +223,34 @@ func init() {
 func init() { proto.RegisterFile("integrationplugin.proto", fileDescriptor_ad20155c873eed76) }
 
 var fileDescriptor_ad20155c873eed76 = []byte{
-	// 415 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x4f, 0x8b, 0xda, 0x40,
-	0x1c, 0x35, 0xd1, 0x6a, 0xfb, 0x53, 0x4a, 0x1d, 0x8a, 0x4d, 0x53, 0x68, 0x25, 0xed, 0x41, 0xa8,
-	0x8c, 0x60, 0x2f, 0xe2, 0xa9, 0x88, 0x1e, 0xa4, 0x94, 0xda, 0x78, 0x28, 0xb4, 0xa7, 0x98, 0x8c,
-	0x31, 0x18, 0x33, 0x61, 0x66, 0x22, 0xe4, 0xdc, 0x2f, 0xb0, 0xdf, 0x6a, 0xbf, 0xd6, 0x92, 0x99,
-	0xc9, 0x6e, 0x20, 0x9b, 0x65, 0x6f, 0xf9, 0xfd, 0x79, 0xef, 0x37, 0xef, 0x3d, 0x02, 0xef, 0xa2,
-	0x44, 0x90, 0x90, 0x79, 0x22, 0xa2, 0x49, 0x1a, 0x67, 0x61, 0x94, 0xe0, 0x94, 0x51, 0x41, 0xd1,
-	0xb0, 0x36, 0xb0, 0x3f, 0x84, 0x94, 0x86, 0x31, 0x99, 0xc9, 0x85, 0x43, 0x76, 0x9c, 0x91, 0x4b,
-	0x2a, 0x72, 0xb5, 0xef, 0xfc, 0x37, 0xe0, 0xfd, 0xf6, 0x01, 0xb2, 0x93, 0x90, 0x9f, 0x5e, 0x12,
-	0x1d, 0x09, 0x17, 0x08, 0x41, 0x27, 0xf1, 0x2e, 0xc4, 0x32, 0xc6, 0xc6, 0xe4, 0x95, 0x2b, 0xbf,
-	0x91, 0x05, 0xbd, 0x2b, 0x61, 0x3c, 0xa2, 0x89, 0x65, 0xca, 0x76, 0x59, 0xa2, 0x31, 0xf4, 0x03,
-	0xc2, 0x7d, 0x16, 0xa5, 0x05, 0x95, 0xd5, 0x96, 0xd3, 0x6a, 0x0b, 0x8d, 0xa0, 0xeb, 0x65, 0xe2,
-	0x44, 0x99, 0xd5, 0x91, 0x43, 0x5d, 0x39, 0x37, 0x06, 0xf4, 0xd7, 0x24, 0x8d, 0x69, 0xfe, 0x3b,
-	0x23, 0x2c, 0x47, 0x1b, 0xe8, 0x51, 0x89, 0xe0, 0x96, 0x31, 0x6e, 0x4f, 0xfa, 0xf3, 0xaf, 0xb8,
-	0x2e, 0xb8, 0x02, 0xc0, 0xbf, 0xd4, 0xf6, 0x26, 0x11, 0x2c, 0x77, 0x4b, 0xac, 0xbd, 0x84, 0x41,
-	0x75, 0x80, 0xde, 0x40, 0xfb, 0x4c, 0x72, 0xad, 0xa6, 0xf8, 0x44, 0x6f, 0xe1, 0xc5, 0xd5, 0x8b,
-	0x33, 0xa2, 0xa5, 0xa8, 0x62, 0x69, 0x2e, 0x0c, 0xe7, 0x3b, 0x0c, 0xd4, 0x01, 0x97, 0xf0, 0x2c,
-	0x16, 0xc5, 0xd3, 0xb9, 0xf0, 0x44, 0xc6, 0x35, 0x5c, 0x57, 0x85, 0x1d, 0x01, 0x11, 0x5e, 0x14,
-	0xf3, 0xd2, 0x0e, 0x5d, 0x3a, 0x9f, 0x61, 0xa8, 0x18, 0xf6, 0x72, 0x53, 0x29, 0x7b, 0x0d, 0xe6,
-	0x76, 0xad, 0x29, 0xcc, 0xed, 0x7a, 0x7e, 0x6b, 0xc2, 0xb0, 0xe6, 0x3f, 0x72, 0xe1, 0xe5, 0x7d,
-	0x06, 0x23, 0xac, 0xf2, 0xc3, 0x65, 0x7e, 0x78, 0x53, 0xe4, 0x67, 0x4f, 0x1f, 0xb1, 0xa4, 0x31,
-	0x49, 0xa7, 0x85, 0x7e, 0x40, 0x57, 0x3d, 0x07, 0x7d, 0x7c, 0xda, 0x4c, 0xfb, 0x53, 0xe3, 0x5c,
-	0x79, 0xe1, 0xb4, 0xd0, 0x9f, 0xd2, 0x1d, 0xa5, 0x0d, 0x7d, 0x69, 0x84, 0x54, 0xc4, 0x3f, 0x87,
-	0x78, 0x01, 0x9d, 0xbd, 0xa0, 0x69, 0xa3, 0xea, 0x86, 0xbe, 0xd3, 0x5a, 0xfd, 0x83, 0xa9, 0x4f,
-	0x2f, 0x98, 0x5e, 0x4f, 0xd8, 0x0f, 0x38, 0xe6, 0xc1, 0x19, 0x87, 0x2c, 0xf5, 0xf5, 0x99, 0xda,
-	0xe1, 0xd5, 0xa8, 0x66, 0xd6, 0xae, 0xa0, 0xdc, 0x19, 0x7f, 0xeb, 0xff, 0xd0, 0xa1, 0x2b, 0xcf,
-	0x7d, 0xbb, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x04, 0xbd, 0x83, 0x33, 0x78, 0x03, 0x00, 0x00,
+	// 421 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcf, 0x6b, 0xd4, 0x40,
+	0x14, 0xde, 0xfc, 0x70, 0xab, 0x6f, 0x17, 0x71, 0x07, 0x59, 0x63, 0x04, 0x5d, 0xa2, 0x87, 0x82,
+	0x65, 0x0a, 0xf5, 0x52, 0x7a, 0x92, 0xb2, 0x7b, 0x08, 0x22, 0xc6, 0xf4, 0x20, 0xe8, 0x69, 0x9a,
+	0x4c, 0xd3, 0xd0, 0xec, 0x4c, 0x98, 0x99, 0x2c, 0xe4, 0xec, 0x3f, 0x20, 0xfe, 0xc5, 0x92, 0x99,
+	0x89, 0x06, 0x62, 0xc4, 0x43, 0x6f, 0xf3, 0x7e, 0x7c, 0xef, 0xbd, 0xef, 0xfb, 0x18, 0x78, 0x56,
+	0x32, 0x45, 0x0b, 0x41, 0x54, 0xc9, 0x59, 0x5d, 0x35, 0x45, 0xc9, 0x70, 0x2d, 0xb8, 0xe2, 0x68,
+	0x35, 0x2a, 0x84, 0x2f, 0x0a, 0xce, 0x8b, 0x8a, 0x9e, 0xea, 0x86, 0xeb, 0xe6, 0xe6, 0x94, 0xee,
+	0x6b, 0xd5, 0x9a, 0xfe, 0xe8, 0xbb, 0x03, 0xcf, 0xe3, 0x3f, 0x90, 0x44, 0x43, 0x3e, 0x12, 0x56,
+	0xde, 0x50, 0xa9, 0x10, 0x02, 0x9f, 0x91, 0x3d, 0x0d, 0x9c, 0x8d, 0x73, 0xfc, 0x28, 0xd5, 0x6f,
+	0x14, 0xc0, 0xd1, 0x81, 0x0a, 0x59, 0x72, 0x16, 0xb8, 0x3a, 0xdd, 0x87, 0x68, 0x03, 0x8b, 0x9c,
+	0xca, 0x4c, 0x94, 0x75, 0x37, 0x2a, 0xf0, 0x74, 0x75, 0x98, 0x42, 0x6b, 0x98, 0x93, 0x46, 0xdd,
+	0x72, 0x11, 0xf8, 0xba, 0x68, 0xa3, 0xe8, 0x87, 0x03, 0x8b, 0x2d, 0xad, 0x2b, 0xde, 0x7e, 0x6e,
+	0xa8, 0x68, 0xd1, 0x0e, 0x8e, 0xb8, 0x46, 0xc8, 0xc0, 0xd9, 0x78, 0xc7, 0x8b, 0xb3, 0xb7, 0x78,
+	0x4c, 0x78, 0x00, 0xc0, 0x9f, 0x4c, 0xf7, 0x8e, 0x29, 0xd1, 0xa6, 0x3d, 0x36, 0xbc, 0x80, 0xe5,
+	0xb0, 0x80, 0x9e, 0x80, 0x77, 0x47, 0x5b, 0xcb, 0xa6, 0x7b, 0xa2, 0xa7, 0xf0, 0xe0, 0x40, 0xaa,
+	0x86, 0x5a, 0x2a, 0x26, 0xb8, 0x70, 0xcf, 0x9d, 0xe8, 0x3d, 0x2c, 0xcd, 0x82, 0x94, 0xca, 0xa6,
+	0x52, 0xdd, 0xe9, 0x52, 0x11, 0xd5, 0x48, 0x0b, 0xb7, 0x51, 0x27, 0x47, 0x4e, 0x15, 0x29, 0x2b,
+	0xd9, 0xcb, 0x61, 0xc3, 0xe8, 0x35, 0xac, 0xcc, 0x84, 0x2b, 0xdd, 0x69, 0x98, 0x3d, 0x06, 0x37,
+	0xde, 0xda, 0x11, 0x6e, 0xbc, 0x3d, 0xfb, 0xe9, 0xc1, 0x6a, 0xa4, 0x3f, 0x4a, 0xe1, 0xe1, 0x6f,
+	0x0f, 0xd6, 0xd8, 0xf8, 0x87, 0x7b, 0xff, 0xf0, 0xae, 0xf3, 0x2f, 0x3c, 0xf9, 0x8b, 0x24, 0x93,
+	0x4e, 0x46, 0x33, 0xf4, 0x01, 0xe6, 0xe6, 0x1c, 0xf4, 0xf2, 0xdf, 0x62, 0x86, 0xaf, 0x26, 0xeb,
+	0x46, 0x8b, 0x68, 0x86, 0xbe, 0xc0, 0x72, 0xc8, 0x0d, 0xbd, 0x99, 0x84, 0x0c, 0xc8, 0xff, 0xcf,
+	0xe0, 0x18, 0xfc, 0xa4, 0x22, 0xec, 0x3e, 0x6e, 0x3c, 0x07, 0xff, 0x4a, 0xf1, 0x7a, 0x52, 0xc0,
+	0x89, 0x7c, 0x34, 0xbb, 0xfc, 0x06, 0x27, 0x19, 0xdf, 0x63, 0x7e, 0xb8, 0xc5, 0x59, 0x2e, 0xb1,
+	0xcc, 0xef, 0x70, 0x21, 0xea, 0xcc, 0xae, 0x19, 0x2d, 0xbe, 0x5c, 0x8f, 0x74, 0x4f, 0xba, 0x91,
+	0x89, 0xf3, 0x75, 0xfc, 0x1d, 0xaf, 0xe7, 0x7a, 0xdd, 0xbb, 0x5f, 0x03, 0x00, 0xa6, 0x56, 0x04,
+	0xb9, 0xc3, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -267,6 +268,7 @@ type IntegrationPluginClient interface {
 	Manifest(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*IntegrationPluginManifest, error)
 	Deploy(ctx context.Context, in *DeployQuery, opts ...grpc.CallOption) (*DeployResult, error)
 	DeployStatus(ctx context.Context, in *DeployStatusQuery, opts ...grpc.CallOption) (*DeployResult, error)
+	Plan(ctx context.Context, in *DeployQuery, opts ...grpc.CallOption) (*DeployResult, error)
 	Stop(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
@@ -305,6 +307,15 @@ func (c *integrationPluginClient) DeployStatus(ctx context.Context, in *DeploySt
 	return out, nil
 }
 
+func (c *integrationPluginClient) Plan(ctx context.Context, in *DeployQuery, opts ...grpc.CallOption) (*DeployResult, error) {
+	out := new(DeployResult)
+	err := c.cc.Invoke(ctx, "/integrationplugin.IntegrationPlugin/Plan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *integrationPluginClient) Stop(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/integrationplugin.IntegrationPlugin/Stop", in, out, opts...)
@@ -319,6 +330,7 @@ type IntegrationPluginServer interface {
 	Manifest(context.Context, *empty.Empty) (*IntegrationPluginManifest, error)
 	Deploy(context.Context, *DeployQuery) (*DeployResult, error)
 	DeployStatus(context.Context, *DeployStatusQuery) (*DeployResult, error)
+	Plan(context.Context, *DeployQuery) (*DeployResult, error)
 	Stop(context.Context, *empty.Empty) (*empty.Empty, error)
 }
 
@@ -380,6 +392,24 @@ func _IntegrationPlugin_DeployStatus_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IntegrationPlugin_Plan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntegrationPluginServer).Plan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/integrationplugin.IntegrationPlugin/Plan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntegrationPluginServer).Plan(ctx, req.(*DeployQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _IntegrationPlugin_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -414,6 +444,10 @@ var _IntegrationPlugin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeployStatus",
 			Handler:    _IntegrationPlugin_DeployStatus_Handler,
 		},
+		{
+			MethodName: "Plan",
+			Handler:    _IntegrationPlugin_Plan_Handler,
+		},
 		{
 			MethodName: "Stop",
 			Handler:    _IntegrationPlugin_Stop_Handler,
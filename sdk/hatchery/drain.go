@@ -0,0 +1,60 @@
+package hatchery
+
+import (
+	"context"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// drainWorkers asks all the registered workers of the hatchery to stop after their current job, then waits for them
+// until they are all disabled or given timeout is reached.
+func drainWorkers(ctx context.Context, h Interface, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	drained := make(map[string]struct{})
+	tick := time.NewTicker(5 * time.Second)
+	defer tick.Stop()
+	for {
+		workers, err := h.CDSClient().WorkerList(ctx)
+		if err != nil {
+			log.Error(ctx, "hatchery> drainWorkers> unable to get registered workers: %v", err)
+		}
+
+		var running int
+		for _, w := range workers {
+			if w.Status == sdk.StatusDisabled {
+				if _, has := drained[w.ID]; has {
+					log.Info(ctx, "hatchery> drainWorkers> worker %s stopped: %s %s", w.Name, w.ShutdownReason, w.ShutdownMessage)
+					delete(drained, w.ID)
+				}
+				continue
+			}
+			running++
+			if _, has := drained[w.ID]; has {
+				continue
+			}
+			if !w.Drain {
+				if err := h.CDSClient().WorkerDrain(ctx, w.ID); err != nil {
+					log.Error(ctx, "hatchery> drainWorkers> unable to drain worker %s: %v", w.Name, err)
+					continue
+				}
+			}
+			drained[w.ID] = struct{}{}
+		}
+		if err == nil && running == 0 {
+			log.Info(ctx, "hatchery> drainWorkers> all workers are stopped")
+			return
+		}
+
+		log.Info(ctx, "hatchery> drainWorkers> waiting for %d workers", running)
+		select {
+		case <-ctx.Done():
+			log.Warning(ctx, "hatchery> drainWorkers> %d workers are still running: %v", running, ctx.Err())
+			return
+		case <-tick.C:
+		}
+	}
+}
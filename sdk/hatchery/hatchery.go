@@ -50,14 +50,31 @@ func Create(ctx context.Context, h Interface) error {
 		cancel()
 	}()
 
+	// With a drain timeout, the first signal stops spawning workers and waits for the running ones to finish their
+	// job. The second one stops the hatchery now.
+	draining := make(chan struct{})
 	go func() {
 		select {
 		case <-c:
-			defer cancel()
-			return
 		case <-ctx.Done():
 			return
 		}
+		drainTimeout := h.Configuration().Provision.DrainTimeout
+		if drainTimeout <= 0 {
+			cancel()
+			return
+		}
+		log.Info(ctx, "Create> draining hatchery for %d seconds, send the signal again to stop it now", drainTimeout)
+		close(draining)
+		go func() {
+			select {
+			case <-c:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		drainWorkers(ctx, h, time.Duration(drainTimeout)*time.Second)
+		cancel()
 	}()
 
 	// Init call hatchery.Register()
@@ -92,15 +109,28 @@ func Create(ctx context.Context, h Interface) error {
 	// purges expired items every minute
 	spawnIDs := cache.New(10*time.Second, 60*time.Second)
 
-	sdk.GoRoutine(ctx, "queuePolling",
-		func(ctx context.Context) {
-			if err := h.CDSClient().QueuePolling(ctx, wjobs, errs, 20*time.Second, modelType, h.Configuration().Provision.RatioService); err != nil {
-				log.Error(ctx, "Queues polling stopped: %v", err)
-				cancel()
-			}
-		},
-		PanicDump(h),
-	)
+	if h.Configuration().Provision.QueuePolling {
+		sdk.GoRoutine(ctx, "queuePolling",
+			func(ctx context.Context) {
+				if err := h.CDSClient().QueuePolling(ctx, wjobs, errs, 20*time.Second, modelType, h.Configuration().Provision.RatioService); err != nil {
+					log.Error(ctx, "Queues polling stopped: %v", err)
+					cancel()
+				}
+			},
+			PanicDump(h),
+		)
+	} else {
+		// New jobs are pushed by the API, the whole queue is only loaded to get back jobs that were not spawned
+		sdk.GoRoutine(ctx, "queueStream",
+			func(ctx context.Context) {
+				if err := h.CDSClient().QueueStream(ctx, wjobs, errs, 2*time.Minute, modelType, h.Configuration().Provision.RatioService); err != nil {
+					log.Error(ctx, "Queue stream stopped: %v", err)
+					cancel()
+				}
+			},
+			PanicDump(h),
+		)
+	}
 
 	// run the starters pool
 	workersStartChan := startWorkerStarters(ctx, h)
@@ -131,7 +161,7 @@ func Create(ctx context.Context, h Interface) error {
 			}
 		case j := <-wjobs:
 			t0 := time.Now()
-			if j.ID == 0 {
+			if j.ID == 0 || isDraining(draining) {
 				continue
 			}
 
@@ -189,6 +219,13 @@ func Create(ctx context.Context, h Interface) error {
 				continue
 			}
 
+			//Check if the job was requeued after an infrastructure error and should not start yet
+			if j.WaitingRetry(time.Now()) {
+				log.Debug("hatchery> job %d is waiting for its next attempt", j.ID)
+				endTrace("waiting retry")
+				continue
+			}
+
 			//Before doing anything, push in cache
 			spawnIDs.SetDefault(strconv.FormatInt(j.ID, 10), j.ID)
 
@@ -215,6 +252,7 @@ func Create(ctx context.Context, h Interface) error {
 				hostname:          hostname,
 				timestamp:         time.Now().Unix(),
 				workflowNodeRunID: j.WorkflowNodeRunID,
+				workerMinVersion:  j.WorkerMinVersion,
 			}
 
 			// Check at least one worker model can match
@@ -258,6 +296,9 @@ func Create(ctx context.Context, h Interface) error {
 			workersStartChan <- workerRequest
 
 		case <-chanRegister:
+			if isDraining(draining) {
+				continue
+			}
 			if err := workerRegister(ctx, hWithModels, workersStartChan); err != nil {
 				log.Warning(ctx, "Error on workerRegister: %s", err)
 			}
@@ -265,7 +306,21 @@ func Create(ctx context.Context, h Interface) error {
 	}
 }
 
+func isDraining(draining <-chan struct{}) bool {
+	select {
+	case <-draining:
+		return true
+	default:
+		return false
+	}
+}
+
 func canRunJob(ctx context.Context, h Interface, j workerStarterRequest) bool {
+	if hv, ok := h.(InterfaceWithWorkerVersion); ok && !sdk.IsWorkerVersionCompatible(hv.WorkerVersion(), j.workerMinVersion) {
+		log.Info(ctx, "canRunJob> %d - job %d - worker binary version %s is outdated, this job requires version %s", j.timestamp, j.id, hv.WorkerVersion(), j.workerMinVersion)
+		return false
+	}
+
 	for _, r := range j.requirements {
 		// If requirement is an hostname requirement, it's for a specific worker
 		if r.Type == sdk.HostnameRequirement && r.Value != j.hostname {
@@ -303,8 +358,13 @@ func canRunJobWithModel(ctx context.Context, h InterfaceWithModels, j workerStar
 		return false
 	}
 
-	if model.NbSpawnErr > 5 {
-		log.Warning(ctx, "canRunJob> Too many errors on spawn with model %s, please check this worker model", model.Name)
+	if !model.IsCompatibleWorkerVersion(j.workerMinVersion) {
+		log.Info(ctx, "canRunJob> %d - job %d - model %s has an outdated worker binary in version %s, this job requires version %s", j.timestamp, j.id, model.Name, model.RegisteredVersion, j.workerMinVersion)
+		return false
+	}
+
+	if model.Quarantined {
+		log.Warning(ctx, "canRunJob> Worker model %s is quarantined after too many errors on spawn, please check this worker model", model.Name)
 		return false
 	}
 
@@ -370,11 +430,17 @@ func canRunJobWithModel(ctx context.Context, h InterfaceWithModels, j workerStar
 			continue
 		}
 
-		if r.Type == sdk.OSArchRequirement && model.RegisteredOS != "" && model.RegisteredArch != "" && r.Value != (model.RegisteredOS+"/"+model.RegisteredArch) {
+		if r.Type == sdk.OSArchRequirement && !model.IsCompatibleOSArch(r.Value) {
 			log.Debug("canRunJob> %d - job %d - job with OSArch requirement: cannot spawn on this OSArch. current model: %s/%s", j.timestamp, j.id, model.RegisteredOS, model.RegisteredArch)
 			return false
 		}
 
+		// isolation relies on Linux namespaces
+		if r.Type == sdk.IsolationRequirement && model.RegisteredOS != "" && model.RegisteredOS != "linux" {
+			log.Debug("canRunJob> %d - job %d - job with isolation requirement: only for linux workers. current model: %s/%s", j.timestamp, j.id, model.RegisteredOS, model.RegisteredArch)
+			return false
+		}
+
 		if !containsModelRequirement && !containsHostnameRequirement {
 			if r.Type == sdk.BinaryRequirement {
 				found := false
@@ -63,8 +63,8 @@ loopModels:
 		}
 
 		// if current hatchery is in same group than worker model -> do not avoid spawn, even if worker model is in error
-		if models[k].NbSpawnErr > 5 {
-			log.Warning(ctx, "hatchery> workerRegister> Too many errors on spawn with model %s, please check this worker model", models[k].Name)
+		if models[k].Quarantined {
+			log.Warning(ctx, "hatchery> workerRegister> Worker model %s is quarantined after too many errors on spawn, please check this worker model", models[k].Name)
 			continue
 		}
 
@@ -3,10 +3,23 @@ package hatchery
 import (
 	"bytes"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
+// Options that can be given in a service requirement value.
+const (
+	ServiceOptionMemory             = "CDS_SERVICE_MEMORY"
+	ServiceOptionArgs               = "CDS_SERVICE_ARGS"
+	ServiceOptionHealthcheck        = "CDS_SERVICE_HEALTHCHECK"
+	ServiceOptionHealthcheckTimeout = "CDS_SERVICE_HEALTHCHECK_TIMEOUT"
+
+	// DefaultServiceHealthcheckTimeout is the maximum duration to wait for a service to be healthy.
+	DefaultServiceHealthcheckTimeout = 2 * time.Minute
+)
+
 var (
 	// reSplitParams accepts:
 	// 	 TEST
@@ -64,6 +77,20 @@ func ParseRequirementModel(rm string) (string, map[string]string) {
 	return img, env
 }
 
+// ParseServiceHealthcheck returns the command used to check that a service is healthy and the
+// maximum duration to wait for it, from the environment of a service requirement.
+// An empty command is returned if no health check was set on the service.
+//
+// Example of input:
+//   "postgres:latest CDS_SERVICE_HEALTHCHECK='pg_isready -U postgres' CDS_SERVICE_HEALTHCHECK_TIMEOUT=60"
+func ParseServiceHealthcheck(env map[string]string) (string, time.Duration) {
+	timeout := DefaultServiceHealthcheckTimeout
+	if t, err := strconv.Atoi(env[ServiceOptionHealthcheckTimeout]); err == nil && t > 0 {
+		timeout = time.Duration(t) * time.Second
+	}
+	return strings.TrimSpace(env[ServiceOptionHealthcheck]), timeout
+}
+
 // ParseArgs splits str on spaces into a slice of strings taking into
 // account any quoting (using '' or "") even inside args, and any
 // backslash-escaping even without quotes:
@@ -2,6 +2,7 @@ package hatchery_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -129,3 +130,15 @@ func TestParseArgs(t *testing.T) {
 		assert.Equal(t, test.expectedArgs, args, "ParseArgs("+test.in+")")
 	}
 }
+
+func TestParseServiceHealthcheck(t *testing.T) {
+	_, env := hatchery.ParseRequirementModel(`postgres:latest CDS_SERVICE_HEALTHCHECK='pg_isready -U postgres' CDS_SERVICE_HEALTHCHECK_TIMEOUT=30`)
+	cmd, timeout := hatchery.ParseServiceHealthcheck(env)
+	assert.Equal(t, "pg_isready -U postgres", cmd)
+	assert.Equal(t, 30*time.Second, timeout)
+
+	_, env = hatchery.ParseRequirementModel(`redis:latest`)
+	cmd, timeout = hatchery.ParseServiceHealthcheck(env)
+	assert.Equal(t, "", cmd)
+	assert.Equal(t, hatchery.DefaultServiceHealthcheckTimeout, timeout)
+}
@@ -0,0 +1,16 @@
+package hatchery
+
+import (
+	"github.com/ovh/cds/sdk"
+)
+
+// SpawnErrorClass returns the class of an error that occurred while spawning or checking a worker.
+func SpawnErrorClass(err error) string {
+	if err == nil {
+		return sdk.SpawnErrorClassUnknown
+	}
+	if sdk.ErrorIs(err, sdk.ErrWorkerModelDeploymentFailed) {
+		return sdk.SpawnErrorClassBootTimeout
+	}
+	return sdk.ClassifySpawnError(err.Error())
+}
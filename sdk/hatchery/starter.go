@@ -26,6 +26,7 @@ type workerStarterRequest struct {
 	hostname            string
 	timestamp           int64
 	workflowNodeRunID   int64
+	workerMinVersion    string
 	registerWorkerModel *sdk.Model
 }
 
@@ -84,6 +85,7 @@ func workerStarter(ctx context.Context, h Interface, workerNum string, jobs <-ch
 			if err != nil {
 				var spawnError = sdk.SpawnErrorForm{
 					Error: fmt.Sprintf("cannot spawn worker for register: %v", err),
+					Class: sdk.SpawnErrorClassInternal,
 				}
 				if err := h.CDSClient().WorkerModelSpawnError(m.Group.Name, m.Name, spawnError); err != nil {
 					log.Error(ctx, "workerStarter> error on call client.WorkerModelSpawnError on worker model %s for register: %s", m.Name, err)
@@ -96,6 +98,7 @@ func workerStarter(ctx context.Context, h Interface, workerNum string, jobs <-ch
 				log.Warning(ctx, "workerRegister> cannot spawn worker for register:%s err:%v", m.Name, err)
 				var spawnError = sdk.SpawnErrorForm{
 					Error: fmt.Sprintf("cannot spawn worker for register: %v", err),
+					Class: SpawnErrorClass(err),
 				}
 				if err := h.CDSClient().WorkerModelSpawnError(m.Group.Name, m.Name, spawnError); err != nil {
 					log.Error(ctx, "workerRegister> error on call client.WorkerModelSpawnError on worker model %s for register: %s", m.Name, err)
@@ -183,6 +186,7 @@ func spawnWorkerForJob(ctx context.Context, h Interface, j workerStarterRequest)
 	if err != nil {
 		var spawnError = sdk.SpawnErrorForm{
 			Error: fmt.Sprintf("cannot spawn worker for register: %v", err),
+			Class: sdk.SpawnErrorClassInternal,
 		}
 		if err := h.CDSClient().WorkerModelSpawnError(j.model.Group.Name, j.model.Name, spawnError); err != nil {
 			log.Error(ctx, "hatchery> spawnWorkerForJob> error on call client.WorkerModelSpawnError on worker model %s for register: %s", j.model.Name, err)
@@ -202,6 +206,23 @@ func spawnWorkerForJob(ctx context.Context, h Interface, j workerStarterRequest)
 		})
 		log.Error(ctx, "hatchery %s cannot spawn worker %s for job %d: %v", h.Service().Name, modelName, j.id, errSpawn)
 		next()
+		class := SpawnErrorClass(errSpawn)
+		if j.model != nil {
+			var spawnError = sdk.SpawnErrorForm{
+				Error: fmt.Sprintf("cannot spawn worker for job %d: %v", j.id, errSpawn),
+				Class: class,
+			}
+			if err := h.CDSClient().WorkerModelSpawnError(j.model.Group.Name, j.model.Name, spawnError); err != nil {
+				log.Error(ctx, "hatchery> spawnWorkerForJob> error on call client.WorkerModelSpawnError on worker model %s: %s", j.model.Name, err)
+			}
+		}
+		// The worker did not boot in time, the job is requeued by the API with a delay
+		if class == sdk.SpawnErrorClassBootTimeout {
+			infraErr := sdk.JobInfraError{Reason: sdk.JobInfraErrorSpawnTimeout, Message: errSpawn.Error()}
+			if err := h.CDSClient().QueueJobInfraError(ctxJob, j.id, infraErr); err != nil {
+				log.Error(ctx, "hatchery> spawnWorkerForJob> cannot report infrastructure error for job %d: %v", j.id, err)
+			}
+		}
 		return false
 	}
 
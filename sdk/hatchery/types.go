@@ -111,6 +111,12 @@ type InterfaceWithModels interface {
 	WorkerModelsEnabled() ([]sdk.Model, error)
 }
 
+// InterfaceWithWorkerVersion is implemented by hatcheries that know the version of the worker binary they spawn.
+type InterfaceWithWorkerVersion interface {
+	Interface
+	WorkerVersion() string
+}
+
 type Metrics struct {
 	Jobs               *stats.Int64Measure
 	JobsSSE            *stats.Int64Measure
@@ -2,40 +2,46 @@ package sdk
 
 // These are constants about hooks
 const (
-	WebHookModelName              = "WebHook"
-	RepositoryWebHookModelName    = "RepositoryWebHook"
-	GerritHookModelName           = "GerritHook"
-	SchedulerModelName            = "Scheduler"
-	GitPollerModelName            = "Git Repository Poller"
-	KafkaHookModelName            = "Kafka hook"
-	RabbitMQHookModelName         = "RabbitMQ hook"
-	WorkflowModelName             = "Workflow"
-	HookConfigProject             = "project"
-	HookConfigWorkflow            = "workflow"
-	HookConfigTargetProject       = "target_project"
-	HookConfigTargetWorkflow      = "target_workflow"
-	HookConfigTargetHook          = "target_hook"
-	HookConfigWorkflowID          = "workflow_id"
-	HookConfigWebHookID           = "webHookID"
-	HookConfigVCSServer           = "vcsServer"
-	HookConfigEventFilter         = "eventFilter"
-	HookConfigRepoFullName        = "repoFullName"
-	HookConfigModelType           = "model_type"
-	HookConfigModelName           = "model_name"
-	HookConfigIcon                = "hookIcon"
-	WebHookModelConfigMethod      = "method"
-	RepositoryWebHookModelMethod  = "method"
-	SchedulerModelCron            = "cron"
-	SchedulerModelTimezone        = "timezone"
-	Payload                       = "payload"
-	HookModelIntegration          = "integration"
-	KafkaHookModelConsumerGroup   = "consumer group"
-	KafkaHookModelTopic           = "topic"
-	RabbitMQHookModelQueue        = "queue"
-	RabbitMQHookModelBindingKey   = "binding_key"
-	RabbitMQHookModelExchangeType = "exchange_type"
-	RabbitMQHookModelExchangeName = "exchange_name"
-	RabbitMQHookModelConsumerTag  = "consumer_tag"
+	WebHookModelName                      = "WebHook"
+	RepositoryWebHookModelName            = "RepositoryWebHook"
+	GerritHookModelName                   = "GerritHook"
+	SchedulerModelName                    = "Scheduler"
+	GitPollerModelName                    = "Git Repository Poller"
+	KafkaHookModelName                    = "Kafka hook"
+	RabbitMQHookModelName                 = "RabbitMQ hook"
+	WorkflowModelName                     = "Workflow"
+	HookConfigProject                     = "project"
+	HookConfigWorkflow                    = "workflow"
+	HookConfigTargetProject               = "target_project"
+	HookConfigTargetWorkflow              = "target_workflow"
+	HookConfigTargetHook                  = "target_hook"
+	HookConfigWorkflowID                  = "workflow_id"
+	HookConfigWebHookID                   = "webHookID"
+	HookConfigWebHookSecret               = "webHookSecret"
+	HookConfigWebHookPreviousSecret       = "webHookPreviousSecret"
+	HookConfigWebHookPreviousSecretExpire = "webHookPreviousSecretExpire"
+	HookConfigVCSServer                   = "vcsServer"
+	HookConfigEventFilter                 = "eventFilter"
+	HookConfigFilter                      = "filter"
+	HookConfigMapping                     = "mapping"
+	HookConfigPathFilters                 = "path_filters"
+	HookConfigRepoFullName                = "repoFullName"
+	HookConfigModelType                   = "model_type"
+	HookConfigModelName                   = "model_name"
+	HookConfigIcon                        = "hookIcon"
+	WebHookModelConfigMethod              = "method"
+	RepositoryWebHookModelMethod          = "method"
+	SchedulerModelCron                    = "cron"
+	SchedulerModelTimezone                = "timezone"
+	Payload                               = "payload"
+	HookModelIntegration                  = "integration"
+	KafkaHookModelConsumerGroup           = "consumer group"
+	KafkaHookModelTopic                   = "topic"
+	RabbitMQHookModelQueue                = "queue"
+	RabbitMQHookModelBindingKey           = "binding_key"
+	RabbitMQHookModelExchangeType         = "exchange_type"
+	RabbitMQHookModelExchangeName         = "exchange_name"
+	RabbitMQHookModelConsumerTag          = "consumer_tag"
 )
 
 // Here are the default hooks
@@ -128,6 +134,16 @@ var (
 				Configurable: true,
 				Type:         HookConfigTypeString,
 			},
+			HookConfigFilter: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigMapping: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 		},
 	}
 
@@ -143,6 +159,21 @@ var (
 				Configurable: false,
 				Type:         HookConfigTypeString,
 			},
+			HookConfigFilter: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigMapping: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigPathFilters: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 		},
 	}
 
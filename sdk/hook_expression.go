@@ -0,0 +1,67 @@
+package sdk
+
+import (
+	"strings"
+
+	"github.com/ovh/cds/sdk/cel"
+)
+
+// Variables available in the filter and mapping expressions of a webhook.
+const (
+	HookExpressionPayload = "payload"
+	HookExpressionParams  = "params"
+	HookExpressionHeaders = "headers"
+	HookExpressionFiles   = "files"
+)
+
+// HookExpressionVariables list.
+var HookExpressionVariables = []string{
+	HookExpressionPayload,
+	HookExpressionParams,
+	HookExpressionHeaders,
+	HookExpressionFiles,
+}
+
+// hookConfigOptionals are the hook config keys that are ignored when they are empty, so hooks saved before the key
+// was added to the model keep the same ref.
+var hookConfigOptionals = map[string]struct{}{
+	HookConfigFilter:      {},
+	HookConfigMapping:     {},
+	HookConfigPathFilters: {},
+}
+
+func isEmptyOptionalHookConfig(key string, v WorkflowNodeHookConfigValue) bool {
+	_, ok := hookConfigOptionals[key]
+	return ok && v.Value == ""
+}
+
+// ParseHookExpressions returns the filter and the mapping expressions of a hook config, or nil if they are not set.
+func ParseHookExpressions(cfg WorkflowNodeHookConfig) (filter *cel.Program, mapping *cel.Program, err error) {
+	filter, err = parseHookExpression(cfg, HookConfigFilter)
+	if err != nil {
+		return nil, nil, err
+	}
+	mapping, err = parseHookExpression(cfg, HookConfigMapping)
+	if err != nil {
+		return nil, nil, err
+	}
+	return filter, mapping, nil
+}
+
+func parseHookExpression(cfg WorkflowNodeHookConfig, key string) (*cel.Program, error) {
+	source := strings.TrimSpace(cfg[key].Value)
+	if source == "" {
+		return nil, nil
+	}
+	p, err := cel.Parse(source)
+	if err != nil {
+		return nil, NewErrorFrom(ErrInvalidHookConfiguration, "invalid %s expression: %v", key, err)
+	}
+	for _, v := range p.Variables() {
+		if !IsInArray(v, HookExpressionVariables) {
+			return nil, NewErrorFrom(ErrInvalidHookConfiguration, "invalid %s expression: unknown variable %s, it should be one of %s",
+				key, v, strings.Join(HookExpressionVariables, ", "))
+		}
+	}
+	return p, nil
+}
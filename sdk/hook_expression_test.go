@@ -0,0 +1,45 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHookExpressions(t *testing.T) {
+	filter, mapping, err := ParseHookExpressions(WorkflowNodeHookConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, filter)
+	assert.Nil(t, mapping)
+
+	filter, mapping, err = ParseHookExpressions(WorkflowNodeHookConfig{
+		HookConfigFilter:  {Value: `files.exists(f, glob(f, "services/api/**"))`},
+		HookConfigMapping: {Value: `{"branch": params["git.branch"]}`},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, filter)
+	assert.NotNil(t, mapping)
+
+	_, _, err = ParseHookExpressions(WorkflowNodeHookConfig{HookConfigFilter: {Value: `payload.ref ==`}})
+	assert.True(t, ErrorIs(err, ErrInvalidHookConfiguration))
+
+	_, _, err = ParseHookExpressions(WorkflowNodeHookConfig{HookConfigMapping: {Value: `{"branch": branch}`}})
+	assert.True(t, ErrorIs(err, ErrInvalidHookConfiguration))
+}
+
+func TestNodeHookRefIgnoresEmptyExpressions(t *testing.T) {
+	h := NodeHook{
+		HookModelName: WebHookModelName,
+		Config: WorkflowNodeHookConfig{
+			WebHookModelConfigMethod: {Value: "POST", Configurable: true},
+		},
+	}
+	ref := h.Ref()
+
+	h.Config[HookConfigFilter] = WorkflowNodeHookConfigValue{Configurable: true}
+	assert.Equal(t, ref, h.Ref())
+
+	h.Config[HookConfigFilter] = WorkflowNodeHookConfigValue{Value: `headers["x-event"] == "push"`, Configurable: true}
+	assert.NotEqual(t, ref, h.Ref())
+}
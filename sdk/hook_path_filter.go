@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"strings"
+
+	"github.com/ovh/cds/sdk/cel"
+)
+
+// HookPathFiltersCheckRequest is the commit to check against the path filters of a repository webhook. If path
+// filters are given they are checked instead of the ones of the hook.
+type HookPathFiltersCheckRequest struct {
+	Hash        string `json:"hash"`
+	Base        string `json:"base,omitempty"`
+	PathFilters string `json:"path_filters,omitempty"`
+}
+
+// HookPathFiltersCheck is the result of the check of a commit against path filters.
+type HookPathFiltersCheck struct {
+	PathFilters  []string `json:"path_filters"`
+	ChangedFiles []string `json:"changed_files"`
+	MatchedFiles []string `json:"matched_files"`
+	Match        bool     `json:"match"`
+}
+
+// ParseHookPathFilters returns the path filters of a hook config. Filters are glob patterns separated by new lines or
+// commas, a pattern starting with ! excludes the files matched by the previous patterns.
+func ParseHookPathFilters(cfg WorkflowNodeHookConfig) ([]string, error) {
+	return ParseHookPathFiltersValue(cfg[HookConfigPathFilters].Value)
+}
+
+// ParseHookPathFiltersValue returns the path filters of given config value.
+func ParseHookPathFiltersValue(value string) ([]string, error) {
+	filters := []string{}
+	for _, f := range strings.FieldsFunc(value, func(r rune) bool { return r == '\n' || r == ',' }) {
+		f = strings.TrimSpace(f)
+		if f == "" || f == "!" {
+			continue
+		}
+		if _, err := cel.MatchGlob(strings.TrimPrefix(f, "!"), ""); err != nil {
+			return nil, NewErrorFrom(ErrInvalidHookConfiguration, "invalid path filter %s: %v", f, err)
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// MatchHookPathFilters returns the files that match given path filters. The last pattern that matches a file decides
+// if it is included or excluded, and if all the patterns are exclusions the other files are included.
+func MatchHookPathFilters(filters []string, files []string) []string {
+	onlyExclusions := true
+	for _, f := range filters {
+		if !strings.HasPrefix(f, "!") {
+			onlyExclusions = false
+			break
+		}
+	}
+
+	matched := []string{}
+	for _, file := range files {
+		file = strings.TrimPrefix(file, "/")
+		included := onlyExclusions
+		for _, f := range filters {
+			exclude := strings.HasPrefix(f, "!")
+			if ok, _ := cel.MatchGlob(strings.TrimPrefix(f, "!"), file); ok {
+				included = !exclude
+			}
+		}
+		if included {
+			matched = append(matched, file)
+		}
+	}
+	return matched
+}
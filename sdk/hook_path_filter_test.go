@@ -0,0 +1,39 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHookPathFilters(t *testing.T) {
+	filters, err := ParseHookPathFilters(WorkflowNodeHookConfig{
+		HookConfigPathFilters: {Value: "services/api/**, libs/**\n\n  !**/*.md  \n"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"services/api/**", "libs/**", "!**/*.md"}, filters)
+
+	filters, err = ParseHookPathFilters(WorkflowNodeHookConfig{})
+	require.NoError(t, err)
+	assert.Empty(t, filters)
+}
+
+func TestMatchHookPathFilters(t *testing.T) {
+	files := []string{"services/api/main.go", "services/api/README.md", "services/ui/index.ts", "libs/log/log.go", "README.md"}
+
+	tests := []struct {
+		filters []string
+		matched []string
+	}{
+		{filters: []string{"services/api/**"}, matched: []string{"services/api/main.go", "services/api/README.md"}},
+		{filters: []string{"services/api/**", "libs/**", "!**/*.md"}, matched: []string{"services/api/main.go", "libs/log/log.go"}},
+		{filters: []string{"!**/*.md"}, matched: []string{"services/api/main.go", "services/ui/index.ts", "libs/log/log.go"}},
+		{filters: []string{"*.md"}, matched: []string{"README.md"}},
+		{filters: []string{"services/*/index.ts"}, matched: []string{"services/ui/index.ts"}},
+		{filters: []string{"docs/**"}, matched: []string{}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.matched, MatchHookPathFilters(tt.filters, files), "filters %v", tt.filters)
+	}
+}
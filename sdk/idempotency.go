@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"time"
+)
+
+// Headers used by idempotent routes of the API.
+const (
+	// IdempotencyKeyHeader is set by a client to identify a request, retries of the request should use the same key.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotencyReplayedHeader is set on a response that was returned again for a retried request.
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+)
+
+// IdempotencyKeyMaxLength is the maximum length of the value of the Idempotency-Key header.
+const IdempotencyKeyMaxLength = 256
+
+// IdempotencyKey is the response of a request sent to an idempotent route of the API with an idempotency key. The
+// status code is zero while the request is processed.
+type IdempotencyKey struct {
+	ID          int64     `json:"id" db:"id"`
+	ConsumerID  string    `json:"consumer_id" db:"consumer_id"`
+	Key         string    `json:"key" db:"key"`
+	RequestHash string    `json:"request_hash" db:"request_hash"`
+	StatusCode  int       `json:"status_code" db:"status_code"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Response    []byte    `json:"response" db:"response"`
+	Created     time.Time `json:"created" db:"created"`
+}
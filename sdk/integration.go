@@ -4,15 +4,24 @@ import (
 	"database/sql/driver"
 	json "encoding/json"
 	"fmt"
+
+	"github.com/ovh/cds/sdk/interpolate"
 )
 
 // This is the buitin integration model
 const (
-	KafkaIntegrationModel         = "Kafka"
-	RabbitMQIntegrationModel      = "RabbitMQ"
-	OpenstackIntegrationModel     = "Openstack"
-	AWSIntegrationModel           = "AWS"
-	DefaultStorageIntegrationName = "shared.infra"
+	KafkaIntegrationModel          = "Kafka"
+	RabbitMQIntegrationModel       = "RabbitMQ"
+	OpenstackIntegrationModel      = "Openstack"
+	AWSIntegrationModel            = "AWS"
+	WebhookIntegrationModel        = "Webhook"
+	DockerRegistryIntegrationModel = "DockerRegistry"
+	SlackIntegrationModel          = "Slack"
+	MicrosoftTeamsIntegrationModel = "MicrosoftTeams"
+	MetricsIntegrationModel        = "Metrics"
+	ArtifactScanIntegrationModel   = "ArtifactScan"
+	JiraIntegrationModel           = "Jira"
+	DefaultStorageIntegrationName  = "shared.infra"
 )
 
 // Here are the default plateform models
@@ -22,6 +31,13 @@ var (
 		&RabbitMQIntegration,
 		&OpenstackIntegration,
 		&AWSIntegration,
+		&WebhookIntegration,
+		&DockerRegistryIntegration,
+		&SlackIntegration,
+		&MicrosoftTeamsIntegration,
+		&MetricsIntegration,
+		&ArtifactScanIntegration,
+		&JiraIntegration,
 	}
 	// KafkaIntegration represents a kafka integration
 	KafkaIntegration = IntegrationModel{
@@ -99,6 +115,8 @@ var (
 			"storage_temporary_url_supported": IntegrationConfigValue{
 				Type: IntegrationConfigTypeString,
 			},
+			IntegrationConfigProxyURL: integrationProxyURLConfigValue,
+			IntegrationConfigCABundle: integrationCABundleConfigValue,
 		},
 		Storage:  true,
 		Disabled: false,
@@ -135,13 +153,280 @@ var (
 			"force_path_style": IntegrationConfigValue{
 				Type: IntegrationConfigTypeBoolean,
 			},
+			IntegrationConfigProxyURL: integrationProxyURLConfigValue,
+			IntegrationConfigCABundle: integrationCABundleConfigValue,
 		},
 		Storage:  true,
 		Disabled: false,
 		Hook:     false,
 	}
+	// WebhookIntegration represents a webhook integration, events are sent as JSON with a HTTP POST request
+	WebhookIntegration = IntegrationModel{
+		Name:       WebhookIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/webhook",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "URL that receives events, ex: https://splunk.example.com:8088/services/collector/raw",
+			},
+			"auth header": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Name of the HTTP header used to authenticate, default is Authorization",
+			},
+			"auth token": IntegrationConfigValue{
+				Type:        IntegrationConfigTypePassword,
+				Description: "Value of the authentication header, ex: Splunk <token>",
+			},
+			IntegrationConfigSigningSecret: IntegrationConfigValue{
+				Type:        IntegrationConfigTypePassword,
+				Description: "Secret used to sign events with HMAC-SHA256 in the X-Cds-Signature header, and to verify the callbacks of the webhook",
+			},
+			IntegrationConfigProxyURL: integrationProxyURLConfigValue,
+			IntegrationConfigCABundle: integrationCABundleConfigValue,
+		},
+		Disabled: false,
+		Hook:     false,
+		Event:    true,
+	}
+	// DockerRegistryIntegration represents a docker registry integration, used by the DockerBuild action to store
+	// the buildkit cache of images
+	DockerRegistryIntegration = IntegrationModel{
+		Name:       DockerRegistryIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/docker-registry",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Address of the registry, ex: registry.example.com",
+			},
+			"username": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"password": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+			"cache_repository": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Repository that stores the build cache, ex: registry.example.com/cds/cache",
+			},
+		},
+		Disabled: false,
+		Hook:     false,
+	}
+	// SlackIntegration represents a slack integration, workflow run events are sent as Block Kit messages to an
+	// incoming webhook
+	SlackIntegration = IntegrationModel{
+		Name:          SlackIntegrationModel,
+		Author:        "CDS",
+		Identifier:    "github.com/ovh/cds/integration/builtin/slack",
+		Icon:          "",
+		DefaultConfig: chatIntegrationDefaultConfig("https://hooks.slack.com/services/xxx/yyy/zzz"),
+		Disabled:      false,
+		Hook:          false,
+		Event:         true,
+	}
+	// MicrosoftTeamsIntegration represents a microsoft teams integration, workflow run events are sent as Adaptive
+	// Cards to an incoming webhook
+	MicrosoftTeamsIntegration = IntegrationModel{
+		Name:          MicrosoftTeamsIntegrationModel,
+		Author:        "CDS",
+		Identifier:    "github.com/ovh/cds/integration/builtin/microsoft-teams",
+		Icon:          "",
+		DefaultConfig: chatIntegrationDefaultConfig("https://example.webhook.office.com/webhookb2/xxx"),
+		Disabled:      false,
+		Hook:          false,
+		Event:         true,
+	}
+	// MetricsIntegration represents a Prometheus Pushgateway or InfluxDB integration, metrics pushed by jobs with
+	// the worker are forwarded to it
+	MetricsIntegration = IntegrationModel{
+		Name:       MetricsIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/metrics",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"type": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       MetricsIntegrationTypePushgateway,
+				Description: "Backend that receives metrics: " + MetricsIntegrationTypePushgateway + " or " + MetricsIntegrationTypeInfluxDB,
+			},
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Address of the backend, ex: https://pushgateway.example.com or https://influxdb.example.com:8086",
+			},
+			"username": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"password": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+			"job": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       "cds",
+				Description: "Value of the job label of metrics pushed to a Pushgateway",
+			},
+			"database": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Database that stores metrics pushed to InfluxDB",
+			},
+			IntegrationConfigProxyURL: integrationProxyURLConfigValue,
+			IntegrationConfigCABundle: integrationCABundleConfigValue,
+		},
+		Disabled: false,
+		Hook:     false,
+	}
+	// ArtifactScanIntegration represents a ClamAV or ICAP antivirus integration, artifacts uploaded by jobs are
+	// scanned and infected artifacts are quarantined
+	ArtifactScanIntegration = IntegrationModel{
+		Name:       ArtifactScanIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/artifact-scan",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"type": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       ArtifactScanIntegrationTypeClamAV,
+				Description: "Protocol of the scanner: " + ArtifactScanIntegrationTypeClamAV + " (clamd) or " + ArtifactScanIntegrationTypeICAP,
+			},
+			"address": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Address of the scanner, ex: clamd.example.com:3310 or icap.example.com:1344",
+			},
+			"service": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       "avscan",
+				Description: "Name of the ICAP service that scans responses",
+			},
+			"timeout": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       "60",
+				Description: "Timeout of the scan of an artifact in seconds",
+			},
+		},
+		Disabled: false,
+		Hook:     false,
+	}
+	// JiraIntegration represents a JIRA integration, the runs of the deployment and release pipelines create or
+	// update the JIRA issues referenced by the commit messages
+	JiraIntegration = IntegrationModel{
+		Name:       JiraIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/jira",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Address of the JIRA server, ex: https://example.atlassian.net",
+			},
+			"username": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "User of the API, the email of the account for JIRA Cloud. Leave empty to use a personal access token",
+			},
+			"token": IntegrationConfigValue{
+				Type:        IntegrationConfigTypePassword,
+				Description: "API token of the user, or personal access token",
+			},
+			"project": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Key of the JIRA project of the created issues, only the issues of this project are updated if set, ex: OPS",
+			},
+			"statuses": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       JiraIntegrationDefaultStatuses,
+				Description: "Comma separated list of the statuses of the pipeline runs that update JIRA",
+			},
+			"transition": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Name of the transition applied to the referenced issues, ex: Deployed. No transition is applied if empty",
+			},
+			"comment template": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeText,
+				Value:       JiraIntegrationDefaultCommentTemplate,
+				Description: "Comment added to the referenced issues, no comment is added if empty",
+			},
+			"create issue": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeBoolean,
+				Value:       "false",
+				Description: "Create an issue in the JIRA project for each pipeline run",
+			},
+			"issue type": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       "Task",
+				Description: "Type of the created issues",
+			},
+			"summary template": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       JiraIntegrationDefaultSummaryTemplate,
+				Description: "Summary of the created issues",
+			},
+			"description template": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeText,
+				Value:       JiraIntegrationDefaultDescriptionTemplate,
+				Description: "Description of the created issues",
+			},
+			"fields template": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeText,
+				Description: "JSON object of additional fields of the created issues, ex: {\"labels\": [\"{{.cds.workflow}}\"], \"customfield_10010\": \"{{.cds.integration}}\"}",
+			},
+			IntegrationConfigProxyURL: integrationProxyURLConfigValue,
+			IntegrationConfigCABundle: integrationCABundleConfigValue,
+		},
+		Disabled: false,
+		Hook:     false,
+		Event:    true,
+	}
+)
+
+// Default values of the config of JIRA integrations.
+const (
+	JiraIntegrationDefaultStatuses            = StatusSuccess
+	JiraIntegrationDefaultCommentTemplate     = "Pipeline {{.cds.node}} of workflow {{.cds.project}}/{{.cds.workflow}} #{{.cds.run.number}} on {{.cds.branch}} with {{.cds.integration}}: {{.cds.status}}\n{{.cds.buildURL}}"
+	JiraIntegrationDefaultSummaryTemplate     = "{{.cds.workflow}} #{{.cds.run.number}}: {{.cds.node}} with {{.cds.integration}}"
+	JiraIntegrationDefaultDescriptionTemplate = "Pipeline {{.cds.node}} of workflow {{.cds.project}}/{{.cds.workflow}} #{{.cds.run.number}} on {{.cds.branch}}: {{.cds.status}}\n{{.cds.buildURL}}\n\nIssues: {{.cds.issues}}"
 )
 
+// Default values of the config of chat integrations.
+const (
+	ChatIntegrationDefaultEvents           = "workflow"
+	ChatIntegrationDefaultStatuses         = StatusSuccess + "," + StatusFail + "," + StatusStopped
+	ChatIntegrationDefaultWorkflowTemplate = "Workflow *{{.cds.project}}/{{.cds.workflow}}* #{{.cds.run.number}} on {{.cds.branch}}: *{{.cds.status}}*"
+	ChatIntegrationDefaultNodeTemplate     = "Pipeline *{{.cds.node}}* of workflow *{{.cds.project}}/{{.cds.workflow}}* #{{.cds.run.number}} on {{.cds.branch}}: *{{.cds.status}}*"
+)
+
+func chatIntegrationDefaultConfig(url string) IntegrationConfig {
+	return IntegrationConfig{
+		"url": IntegrationConfigValue{
+			Type:        IntegrationConfigTypePassword,
+			Description: "Incoming webhook URL of the channel, ex: " + url,
+		},
+		"events": IntegrationConfigValue{
+			Type:        IntegrationConfigTypeString,
+			Value:       ChatIntegrationDefaultEvents,
+			Description: "Comma separated list of notified events: workflow for workflow runs, node for pipeline runs",
+		},
+		"statuses": IntegrationConfigValue{
+			Type:        IntegrationConfigTypeString,
+			Value:       ChatIntegrationDefaultStatuses,
+			Description: "Comma separated list of notified statuses, all statuses are notified if empty",
+		},
+		"workflow template": IntegrationConfigValue{
+			Type:        IntegrationConfigTypeText,
+			Value:       ChatIntegrationDefaultWorkflowTemplate,
+			Description: "Message sent for a workflow run",
+		},
+		"node template": IntegrationConfigValue{
+			Type:        IntegrationConfigTypeText,
+			Value:       ChatIntegrationDefaultNodeTemplate,
+			Description: "Message sent for a pipeline run",
+		},
+		IntegrationConfigProxyURL: integrationProxyURLConfigValue,
+		IntegrationConfigCABundle: integrationCABundleConfigValue,
+	}
+}
+
 // IntegrationType represents all different type of integrations
 type IntegrationType string
 
@@ -151,8 +436,17 @@ const (
 	IntegrationTypeHook       = IntegrationType("hook")
 	IntegrationTypeStorage    = IntegrationType("storage")
 	IntegrationTypeDeployment = IntegrationType("deployment")
+	IntegrationTypeRelease    = IntegrationType("release")
 )
 
+// IntegrationStorageDriverConfigKey is the config key used by a storage integration model that is not builtin to give the
+// protocol of its object storage, s3 or swift.
+const IntegrationStorageDriverConfigKey = "storage driver"
+
+// IntegrationDeploymentPlanParameter is the build parameter set on the jobs of a node in deployment plan mode, the
+// deployment plugin is then asked for the changes it would apply instead of deploying.
+const IntegrationDeploymentPlanParameter = "cds.integration.plan"
+
 // DefaultIfEmptyStorage return sdk.DefaultStorageIntegrationName if integrationName is empty
 func DefaultIfEmptyStorage(integrationName string) string {
 	if integrationName == "" {
@@ -185,6 +479,25 @@ func (config IntegrationConfig) Clone() IntegrationConfig {
 	return new
 }
 
+// Interpolate returns a copy of the config where project variables referenced with the {{.cds.proj.*}}
+// syntax are resolved with given project variables. Other expressions are kept unchanged.
+func (config IntegrationConfig) Interpolate(projectVariables []Variable) (IntegrationConfig, error) {
+	vars := make(map[string]string, len(projectVariables))
+	for _, v := range projectVariables {
+		vars["cds.proj."+v.Name] = v.Value
+	}
+	new := config.Clone()
+	for k, v := range new {
+		value, err := interpolate.Do(v.Value, vars)
+		if err != nil {
+			return nil, NewErrorFrom(ErrWrongRequest, "cannot interpolate integration config %s: %v", k, err)
+		}
+		v.Value = value
+		new[k] = v
+	}
+	return new, nil
+}
+
 // Value returns driver.Value from IntegrationConfig.
 func (config IntegrationConfig) Value() (driver.Value, error) {
 	j, err := json.Marshal(config)
@@ -301,6 +614,7 @@ type IntegrationModel struct {
 	Deployment              bool                 `json:"deployment" db:"deployment" yaml:"deployment" cli:"deployment_supported"`
 	Compute                 bool                 `json:"compute" db:"compute" yaml:"compute" cli:"compute_supported"`
 	Event                   bool                 `json:"event" db:"event" yaml:"event" cli:"event_supported"`
+	Release                 bool                 `json:"release" db:"release" yaml:"release" cli:"release_supported"`
 	Public                  bool                 `json:"public,omitempty" db:"public" yaml:"public,omitempty"`
 }
 
@@ -308,6 +622,38 @@ func (p *IntegrationModel) Blur() {
 	p.PublicConfigurations.Blur()
 }
 
+// Supports returns true if the model has the capability of given type of integration: storage for the artifacts,
+// event, deployment, release, hook or compute.
+func (p IntegrationModel) Supports(t IntegrationType) bool {
+	switch t {
+	case IntegrationTypeStorage:
+		return p.Storage
+	case IntegrationTypeEvent:
+		return p.Event
+	case IntegrationTypeDeployment:
+		return p.Deployment
+	case IntegrationTypeRelease:
+		return p.Release
+	case IntegrationTypeHook:
+		return p.Hook
+	case IntegrationTypeCompute:
+		return p.Compute
+	}
+	return false
+}
+
+// PluginType returns the type of the integration plugin run on a workflow node that uses the model, or an empty
+// string if the model can't be used on a workflow node.
+func (p IntegrationModel) PluginType() string {
+	switch {
+	case p.Deployment:
+		return GRPCPluginDeploymentIntegration
+	case p.Release:
+		return GRPCPluginReleaseIntegration
+	}
+	return ""
+}
+
 //IsBuiltin checks is the model is builtin or not
 func (p IntegrationModel) IsBuiltin() bool {
 	for _, m := range BuiltinIntegrationModels {
@@ -326,6 +672,10 @@ type ProjectIntegration struct {
 	IntegrationModelID int64             `json:"integration_model_id" db:"integration_model_id" yaml:"-"`
 	Model              IntegrationModel  `json:"model" db:"-" yaml:"model"`
 	Config             IntegrationConfig `json:"config" db:"cipher_config" yaml:"config" gorpmapping:"encrypted,ProjectID,IntegrationModelID"`
+	// GroupIntegrationID is set if the integration was inherited from a default integration of a group, its config
+	// follows the one of the group integration until Overridden is set
+	GroupIntegrationID *int64 `json:"group_integration_id,omitempty" db:"group_integration_id" yaml:"-"`
+	Overridden         bool   `json:"overridden" db:"overridden" yaml:"-"`
 	// GRPCPlugin field is used to get all plugins associatied to an integration
 	// when we GET /project/{permProjectKey}/integrations/{integrationName}
 	GRPCPlugins []GRPCPlugin `json:"integration_plugins,omitempty" db:"-" yaml:"-"`
@@ -362,3 +712,43 @@ func (config *IntegrationConfig) HideSecrets() {
 		}
 	}
 }
+
+// Status of the result of a project integration apply.
+const (
+	ProjectIntegrationApplyCreated = "created"
+	ProjectIntegrationApplyUpdated = "updated"
+	ProjectIntegrationApplySkipped = "skipped"
+	ProjectIntegrationApplyError   = "error"
+)
+
+// ProjectIntegrationApplyRequest is an integration to create on several projects. The {{.cds.project}} expression in
+// config values is replaced by the key of each project, other expressions are kept unchanged. Existing integrations
+// with the same name are updated only if asked.
+type ProjectIntegrationApplyRequest struct {
+	Name        string            `json:"name" yaml:"name"`
+	Model       string            `json:"model" yaml:"model"`
+	Config      IntegrationConfig `json:"config" yaml:"config"`
+	ProjectKeys []string          `json:"project_keys" yaml:"project_keys"`
+	Update      bool              `json:"update,omitempty" yaml:"update,omitempty"`
+}
+
+// IsValid returns an error if the request is not valid.
+func (r ProjectIntegrationApplyRequest) IsValid() error {
+	if r.Name == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid integration name")
+	}
+	if r.Model == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid integration model")
+	}
+	if len(r.ProjectKeys) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "no project given")
+	}
+	return nil
+}
+
+// ProjectIntegrationApplyResult is the result of a project integration apply for a project.
+type ProjectIntegrationApplyResult struct {
+	ProjectKey string `json:"project_key" cli:"project,key"`
+	Status     string `json:"status" cli:"status"`
+	Message    string `json:"message,omitempty" cli:"message"`
+}
@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// IntegrationTypes lists all the types of integration in the order used to display the capabilities of a model.
+var IntegrationTypes = []IntegrationType{
+	IntegrationTypeStorage,
+	IntegrationTypeEvent,
+	IntegrationTypeDeployment,
+	IntegrationTypeRelease,
+	IntegrationTypeHook,
+	IntegrationTypeCompute,
+}
+
+// IntegrationModelCatalogEntry describes how to use an integration model. It is generated from the model definition
+// so that the UI, cdsctl and the documentation stay in sync with the code.
+type IntegrationModelCatalogEntry struct {
+	Name             string                      `json:"name" cli:"name,key"`
+	Author           string                      `json:"author" cli:"author"`
+	Identifier       string                      `json:"identifier,omitempty" cli:"identifier"`
+	Icon             string                      `json:"icon,omitempty" cli:"-"`
+	Builtin          bool                        `json:"builtin" cli:"builtin"`
+	Public           bool                        `json:"public" cli:"public"`
+	Capabilities     []IntegrationType           `json:"capabilities" cli:"-"`
+	Config           []IntegrationConfigSchema   `json:"config" cli:"-"`
+	DeploymentConfig []IntegrationConfigSchema   `json:"deployment_config,omitempty" cli:"-"`
+	Permissions      IntegrationModelPermissions `json:"permissions" cli:"-"`
+	Example          string                      `json:"example,omitempty" cli:"-"`
+}
+
+// IntegrationConfigSchema describes a key of the config of an integration model.
+type IntegrationConfigSchema struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+	Secret      bool   `json:"secret"`
+}
+
+// IntegrationModelPermissions gives the permissions required to use an integration model.
+type IntegrationModelPermissions struct {
+	// ProjectVerb is required on a project to add an integration of the model, it is empty if the integration can't
+	// be added by the users of the project.
+	ProjectVerb AuthConsumerProjectVerb `json:"project_verb,omitempty"`
+	// GroupAdmin is true if an administrator of a group can set an integration of the model as default for its group.
+	GroupAdmin bool `json:"group_admin"`
+	// Admin is true if the integrations of the model are configured only by CDS administrators, it is the case of
+	// public models that are available on all projects.
+	Admin bool `json:"admin"`
+}
+
+// integrationCatalogExample is the YAML file imported on a project with cdsctl to add an integration of a model.
+type integrationCatalogExample struct {
+	Name  string `yaml:"name"`
+	Model struct {
+		Name string `yaml:"name"`
+	} `yaml:"model"`
+	Config IntegrationConfig `yaml:"config,omitempty"`
+}
+
+// CatalogEntry returns the catalog entry of the model, its secrets are never given.
+func (p IntegrationModel) CatalogEntry() (IntegrationModelCatalogEntry, error) {
+	e := IntegrationModelCatalogEntry{
+		Name:             p.Name,
+		Author:           p.Author,
+		Identifier:       p.Identifier,
+		Icon:             p.Icon,
+		Builtin:          p.IsBuiltin(),
+		Public:           p.Public,
+		Capabilities:     []IntegrationType{},
+		Config:           p.DefaultConfig.Schema(),
+		DeploymentConfig: p.DeploymentDefaultConfig.Schema(),
+		Permissions: IntegrationModelPermissions{
+			GroupAdmin: !p.Public && (p.Storage || p.Event),
+			Admin:      p.Public,
+		},
+	}
+	for _, t := range IntegrationTypes {
+		if p.Supports(t) {
+			e.Capabilities = append(e.Capabilities, t)
+		}
+	}
+	if p.Public {
+		return e, nil
+	}
+
+	e.Permissions.ProjectVerb = AuthConsumerProjectVerbManageIntegration
+
+	var example integrationCatalogExample
+	example.Name = "my-" + strings.ToLower(strings.Replace(p.Name, " ", "-", -1))
+	example.Model.Name = p.Name
+	example.Config = make(IntegrationConfig, len(p.DefaultConfig))
+	for k, v := range p.DefaultConfig {
+		if v.Type == IntegrationConfigTypePassword {
+			v.Value = ""
+		}
+		example.Config[k] = IntegrationConfigValue{Type: v.Type, Value: v.Value}
+	}
+	btes, err := yaml.Marshal(example)
+	if err != nil {
+		return e, WrapError(err, "cannot marshal example of integration model %s", p.Name)
+	}
+	e.Example = string(btes)
+
+	return e, nil
+}
+
+// Schema returns the keys of the config sorted by name, the values of secrets are never given.
+func (config IntegrationConfig) Schema() []IntegrationConfigSchema {
+	res := make([]IntegrationConfigSchema, 0, len(config))
+	for k, v := range config {
+		s := IntegrationConfigSchema{
+			Key:         k,
+			Type:        v.Type,
+			Description: v.Description,
+			Secret:      v.Type == IntegrationConfigTypePassword,
+		}
+		if !s.Secret {
+			s.Default = v.Value
+		}
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Key < res[j].Key })
+	return res
+}
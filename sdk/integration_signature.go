@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers of a request signed with the signing secret of an integration.
+const (
+	IntegrationSignatureHeader          = "X-Cds-Signature"
+	IntegrationSignatureTimestampHeader = "X-Cds-Timestamp"
+)
+
+// IntegrationConfigSigningSecret is the key of the integration config that contains the secret used to sign
+// the requests sent to an integration and to verify the callbacks it sends to CDS.
+const IntegrationConfigSigningSecret = "signing secret"
+
+// IntegrationSignatureTolerance is the max difference between the timestamp of a signed request and the time
+// it is verified, older requests are rejected to prevent replays.
+const IntegrationSignatureTolerance = 5 * time.Minute
+
+const integrationSignaturePrefix = "sha256="
+
+// SigningSecret returns the signing secret of the integration config, empty if not set.
+func (config IntegrationConfig) SigningSecret() string {
+	return config[IntegrationConfigSigningSecret].Value
+}
+
+// SignIntegrationPayload returns the HMAC-SHA256 signature of given body at given timestamp. The timestamp is
+// part of the signed content so a captured signature can't be reused with another timestamp.
+func SignIntegrationPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + ".")) // nolint
+	mac.Write(body)                                           // nolint
+	return integrationSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignIntegrationRequest sets the signature headers of a request with given body.
+func SignIntegrationRequest(req *http.Request, secret string, body []byte, now time.Time) {
+	ts := now.Unix()
+	req.Header.Set(IntegrationSignatureTimestampHeader, strconv.FormatInt(ts, 10))
+	req.Header.Set(IntegrationSignatureHeader, SignIntegrationPayload(secret, ts, body))
+}
+
+// VerifyIntegrationSignature checks the signature headers of a request with given body. An error is returned if
+// the signature is missing, invalid or too old.
+func VerifyIntegrationSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	if secret == "" {
+		return NewErrorFrom(ErrInvalidIntegrationSignature, "no signing secret configured on integration")
+	}
+	signature := header.Get(IntegrationSignatureHeader)
+	if !strings.HasPrefix(signature, integrationSignaturePrefix) {
+		return NewErrorFrom(ErrInvalidIntegrationSignature, "missing or unsupported signature")
+	}
+	ts, err := strconv.ParseInt(header.Get(IntegrationSignatureTimestampHeader), 10, 64)
+	if err != nil {
+		return NewErrorFrom(ErrInvalidIntegrationSignature, "missing or invalid signature timestamp")
+	}
+	if d := now.Sub(time.Unix(ts, 0)); d > IntegrationSignatureTolerance || d < -IntegrationSignatureTolerance {
+		return NewErrorFrom(ErrInvalidIntegrationSignature, "signature timestamp is out of tolerance")
+	}
+	if !hmac.Equal([]byte(signature), []byte(SignIntegrationPayload(secret, ts, body))) {
+		return WithStack(ErrInvalidIntegrationSignature)
+	}
+	return nil
+}
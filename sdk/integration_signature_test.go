@@ -0,0 +1,33 @@
+package sdk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyIntegrationSignature(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	body := []byte(`{"status":"Success"}`)
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost", nil)
+	require.NoError(t, err)
+	SignIntegrationRequest(req, "my-secret", body, now)
+
+	require.NoError(t, VerifyIntegrationSignature("my-secret", req.Header, body, now.Add(time.Minute)))
+
+	// Wrong secret, modified body, replayed request or no secret configured
+	assert.True(t, ErrorIs(VerifyIntegrationSignature("other-secret", req.Header, body, now), ErrInvalidIntegrationSignature))
+	assert.True(t, ErrorIs(VerifyIntegrationSignature("my-secret", req.Header, []byte(`{"status":"Fail"}`), now), ErrInvalidIntegrationSignature))
+	assert.True(t, ErrorIs(VerifyIntegrationSignature("my-secret", req.Header, body, now.Add(10*time.Minute)), ErrInvalidIntegrationSignature))
+	assert.True(t, ErrorIs(VerifyIntegrationSignature("", req.Header, body, now), ErrInvalidIntegrationSignature))
+
+	// The timestamp is part of the signed content
+	req.Header.Set(IntegrationSignatureTimestampHeader, "1600000001")
+	assert.True(t, ErrorIs(VerifyIntegrationSignature("my-secret", req.Header, body, now), ErrInvalidIntegrationSignature))
+
+	assert.True(t, ErrorIs(VerifyIntegrationSignature("my-secret", http.Header{}, body, now), ErrInvalidIntegrationSignature))
+}
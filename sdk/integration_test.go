@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationConfigInterpolate(t *testing.T) {
+	config := IntegrationConfig{
+		"url": IntegrationConfigValue{
+			Type:  IntegrationConfigTypeString,
+			Value: "https://{{.cds.proj.host}}/api",
+		},
+		"password": IntegrationConfigValue{
+			Type:  IntegrationConfigTypePassword,
+			Value: "{{.cds.proj.password}}",
+		},
+		"topic": IntegrationConfigValue{
+			Type:  IntegrationConfigTypeString,
+			Value: "{{.cds.proj.unknown}}-{{.cds.app.foo}}",
+		},
+		"user": IntegrationConfigValue{
+			Type:  IntegrationConfigTypeString,
+			Value: "admin",
+		},
+	}
+
+	res, err := config.Interpolate([]Variable{
+		{Name: "host", Type: StringVariable, Value: "my-host.local"},
+		{Name: "password", Type: SecretVariable, Value: "my-secret"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://my-host.local/api", res["url"].Value)
+	assert.Equal(t, "my-secret", res["password"].Value)
+	assert.Equal(t, IntegrationConfigTypePassword, res["password"].Type)
+	assert.Equal(t, "{{.cds.proj.unknown}}-{{.cds.app.foo}}", res["topic"].Value)
+	assert.Equal(t, "admin", res["user"].Value)
+
+	// Given config should not be updated
+	assert.Equal(t, "https://{{.cds.proj.host}}/api", config["url"].Value)
+}
+
+func TestIntegrationModelCapabilities(t *testing.T) {
+	m := IntegrationModel{Name: "my-release", Release: true, Event: true}
+	assert.True(t, m.Supports(IntegrationTypeRelease))
+	assert.True(t, m.Supports(IntegrationTypeEvent))
+	assert.False(t, m.Supports(IntegrationTypeStorage))
+	assert.Equal(t, GRPCPluginReleaseIntegration, m.PluginType())
+
+	m.Deployment = true
+	assert.Equal(t, GRPCPluginDeploymentIntegration, m.PluginType())
+	assert.Equal(t, "", IntegrationModel{Storage: true}.PluginType())
+
+	p := GRPCPlugin{Type: GRPCPluginDeploymentIntegration}
+	assert.NoError(t, p.IsCompatibleWith(m))
+	assert.Error(t, p.IsCompatibleWith(IntegrationModel{Name: "my-events", Event: true}))
+	p.Type = GRPCPluginReleaseIntegration
+	assert.NoError(t, p.IsCompatibleWith(m))
+}
+
+func TestIntegrationModelCatalogEntry(t *testing.T) {
+	e, err := RabbitMQIntegration.CatalogEntry()
+	require.NoError(t, err)
+	assert.True(t, e.Builtin)
+	assert.Equal(t, []IntegrationType{IntegrationTypeHook}, e.Capabilities)
+	assert.Equal(t, AuthConsumerProjectVerbManageIntegration, e.Permissions.ProjectVerb)
+	assert.False(t, e.Permissions.GroupAdmin)
+	require.Len(t, e.Config, 3)
+	assert.Equal(t, IntegrationConfigSchema{Key: "password", Type: IntegrationConfigTypePassword, Secret: true}, e.Config[0])
+	assert.Equal(t, "uri", e.Config[1].Key)
+	assert.Equal(t, `name: my-rabbitmq
+model:
+  name: RabbitMQ
+config:
+  password:
+    value: ""
+    type: password
+  uri:
+    value: ""
+    type: string
+  username:
+    value: ""
+    type: string
+`, e.Example)
+
+	m := OpenstackIntegration
+	m.Name = "my-openstack"
+	m.Public = true
+	m.DefaultConfig = IntegrationConfig{"password": IntegrationConfigValue{Type: IntegrationConfigTypePassword, Value: "secret"}}
+	e, err = m.CatalogEntry()
+	require.NoError(t, err)
+	assert.False(t, e.Builtin)
+	assert.True(t, e.Permissions.Admin)
+	assert.Empty(t, e.Permissions.ProjectVerb)
+	assert.Empty(t, e.Example)
+	assert.Equal(t, []IntegrationType{IntegrationTypeStorage}, e.Capabilities)
+	assert.Empty(t, e.Config[0].Default)
+}
@@ -5,6 +5,7 @@ type Job struct {
 	PipelineActionID int64                  `json:"pipeline_action_id"`
 	PipelineStageID  int64                  `json:"pipeline_stage_id"`
 	Enabled          bool                   `json:"enabled"`
+	Idempotent       bool                   `json:"idempotent,omitempty"`
 	LastModified     int64                  `json:"last_modified"`
 	Action           Action                 `json:"action"`
 	Warnings         []PipelineBuildWarning `json:"warnings"`
@@ -0,0 +1,52 @@
+package sdk
+
+import "time"
+
+// Reasons of the infrastructure failures of jobs. A job that fails because of the infrastructure is requeued instead
+// of failing the run.
+const (
+	JobInfraErrorWorkerLost         = "worker_lost"
+	JobInfraErrorSpawnTimeout       = "spawn_timeout"
+	JobInfraErrorStorageUnavailable = "storage_unavailable"
+)
+
+// Backoff between the attempts of a job that failed because of the infrastructure.
+const (
+	JobInfraRetryMax       = 3
+	JobInfraRetryBaseDelay = 30 * time.Second
+	JobInfraRetryMaxDelay  = 10 * time.Minute
+)
+
+// JobInfraError is sent by a hatchery or a worker when a job can't run because of the infrastructure.
+type JobInfraError struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+// IsValid returns an error if the reason is unknown.
+func (e JobInfraError) IsValid() error {
+	switch e.Reason {
+	case JobInfraErrorWorkerLost, JobInfraErrorSpawnTimeout, JobInfraErrorStorageUnavailable:
+		return nil
+	}
+	return NewErrorFrom(ErrWrongRequest, "invalid infrastructure error reason %q", e.Reason)
+}
+
+// JobInfraRetryDelay returns the delay before the next attempt of a job already retried given times. It doubles at
+// each attempt and is capped to JobInfraRetryMaxDelay.
+func JobInfraRetryDelay(retry int) time.Duration {
+	d := JobInfraRetryBaseDelay
+	for i := 0; i < retry; i++ {
+		d *= 2
+		if d >= JobInfraRetryMaxDelay {
+			return JobInfraRetryMaxDelay
+		}
+	}
+	return d
+}
+
+// WaitingRetry returns true if the job was requeued after an infrastructure error and its next attempt should not
+// start yet.
+func (j WorkflowNodeJobRun) WaitingRetry(now time.Time) bool {
+	return j.RetryAfter != nil && j.RetryAfter.After(now)
+}
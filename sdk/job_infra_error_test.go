@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobInfraRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, JobInfraRetryDelay(0))
+	assert.Equal(t, time.Minute, JobInfraRetryDelay(1))
+	assert.Equal(t, 2*time.Minute, JobInfraRetryDelay(2))
+	assert.Equal(t, 8*time.Minute, JobInfraRetryDelay(4))
+	assert.Equal(t, JobInfraRetryMaxDelay, JobInfraRetryDelay(5))
+	assert.Equal(t, JobInfraRetryMaxDelay, JobInfraRetryDelay(100))
+}
+
+func TestWorkflowNodeJobRunWaitingRetry(t *testing.T) {
+	now := time.Now()
+	assert.False(t, WorkflowNodeJobRun{}.WaitingRetry(now))
+
+	next := now.Add(time.Minute)
+	assert.True(t, WorkflowNodeJobRun{RetryAfter: &next}.WaitingRetry(now))
+	assert.False(t, WorkflowNodeJobRun{RetryAfter: &next}.WaitingRetry(next.Add(time.Second)))
+
+	assert.NoError(t, JobInfraError{Reason: JobInfraErrorSpawnTimeout}.IsValid())
+	assert.Error(t, JobInfraError{Reason: "user_error"}.IsValid())
+}
@@ -0,0 +1,24 @@
+package sdk
+
+import "fmt"
+
+// Classes of the loss of a building job whose worker stopped heartbeating.
+const (
+	// JobLossBeforeStart means that the worker was lost before running any step of the job.
+	JobLossBeforeStart = "lost_before_start"
+	// JobLossWorker means that the worker was lost while running the job, its hatchery is still alive.
+	JobLossWorker = "worker_lost"
+	// JobLossHatchery means that the hatchery that spawned the worker was also lost.
+	JobLossHatchery = "hatchery_lost"
+)
+
+// JobLossAnnotationKey returns the key of the run annotation describing the loss of given job.
+func JobLossAnnotationKey(jobID int64) string {
+	return fmt.Sprintf("incident.job.%d", jobID)
+}
+
+// JobLossRequeueable returns true if a job lost with given class can be run again. A job that didn't run any step
+// can always be requeued, else it should be declared idempotent by the pipeline author.
+func (j WorkflowNodeJobRun) JobLossRequeueable(class string) bool {
+	return class == JobLossBeforeStart || j.Job.Idempotent
+}
@@ -0,0 +1,18 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowNodeJobRunJobLossRequeueable(t *testing.T) {
+	job := WorkflowNodeJobRun{}
+	assert.True(t, job.JobLossRequeueable(JobLossBeforeStart))
+	assert.False(t, job.JobLossRequeueable(JobLossWorker))
+	assert.False(t, job.JobLossRequeueable(JobLossHatchery))
+
+	job.Job.Idempotent = true
+	assert.True(t, job.JobLossRequeueable(JobLossWorker))
+	assert.True(t, job.JobLossRequeueable(JobLossHatchery))
+}
@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Types of metrics pushed by jobs.
+const (
+	JobMetricTypeGauge   = "gauge"
+	JobMetricTypeCounter = "counter"
+)
+
+// Labels set by CDS on metrics pushed by jobs, they can't be set by the job.
+const (
+	JobMetricLabelPrefix   = "cds_"
+	JobMetricLabelProject  = "cds_project"
+	JobMetricLabelWorkflow = "cds_workflow"
+	JobMetricLabelRun      = "cds_run"
+	JobMetricLabelNode     = "cds_node"
+	JobMetricLabelJob      = "cds_job"
+)
+
+// Types of backends of the metrics integration.
+const (
+	MetricsIntegrationTypePushgateway = "pushgateway"
+	MetricsIntegrationTypeInfluxDB    = "influxdb"
+)
+
+var (
+	jobMetricNamePattern  = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	jobMetricLabelPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// JobMetric is a value published by a job, like a test count, a binary size or a coverage.
+type JobMetric struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type,omitempty"`
+	Help   string            `json:"help,omitempty"`
+	Value  float64           `json:"value"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// IsValid returns an error if the metric is not valid, an empty type is a gauge. Names follow Prometheus conventions.
+func (m *JobMetric) IsValid() error {
+	if m.Type == "" {
+		m.Type = JobMetricTypeGauge
+	}
+	if !jobMetricNamePattern.MatchString(m.Name) {
+		return NewErrorFrom(ErrWrongRequest, "invalid metric name %q, it should match %s", m.Name, jobMetricNamePattern)
+	}
+	if m.Type != JobMetricTypeGauge && m.Type != JobMetricTypeCounter {
+		return NewErrorFrom(ErrWrongRequest, "invalid type %q for metric %s, should be %s or %s", m.Type, m.Name, JobMetricTypeGauge, JobMetricTypeCounter)
+	}
+	for k := range m.Labels {
+		if !jobMetricLabelPattern.MatchString(k) || strings.HasPrefix(k, "__") {
+			return NewErrorFrom(ErrWrongRequest, "invalid label name %q for metric %s", k, m.Name)
+		}
+		if strings.HasPrefix(k, JobMetricLabelPrefix) {
+			return NewErrorFrom(ErrWrongRequest, "label name %q of metric %s is reserved, labels prefixed by %s are set by CDS", k, m.Name, JobMetricLabelPrefix)
+		}
+	}
+	return nil
+}
+
+// JobMetrics are the metrics pushed by a job to a metrics integration of its project. If no integration is given, the
+// project should have only one metrics integration.
+type JobMetrics struct {
+	Integration string      `json:"integration,omitempty"`
+	Metrics     []JobMetric `json:"metrics"`
+}
+
+// IsValid returns an error if one of the metrics is not valid.
+func (m *JobMetrics) IsValid() error {
+	if len(m.Metrics) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "no metric to push")
+	}
+	for i := range m.Metrics {
+		if err := m.Metrics[i].IsValid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobMetricsIsValid(t *testing.T) {
+	m := JobMetrics{Metrics: []JobMetric{{Name: "binary_size_bytes", Value: 1024, Labels: map[string]string{"os": "linux"}}}}
+	assert.NoError(t, m.IsValid())
+	assert.Equal(t, JobMetricTypeGauge, m.Metrics[0].Type)
+
+	assert.NoError(t, (&JobMetric{Name: "tests_total", Type: JobMetricTypeCounter, Value: 42}).IsValid())
+
+	assert.Error(t, (&JobMetrics{}).IsValid())
+	assert.Error(t, (&JobMetric{Name: "binary size"}).IsValid())
+	assert.Error(t, (&JobMetric{Name: "coverage", Type: "histogram"}).IsValid())
+	assert.Error(t, (&JobMetric{Name: "coverage", Labels: map[string]string{"package-name": "sdk"}}).IsValid())
+	assert.Error(t, (&JobMetric{Name: "coverage", Labels: map[string]string{JobMetricLabelProject: "OTHER"}}).IsValid())
+}
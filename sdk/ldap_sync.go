@@ -0,0 +1,63 @@
+package sdk
+
+import "time"
+
+// LDAPGroup is a group read from the LDAP directory with the uid of its members.
+type LDAPGroup struct {
+	DN      string   `json:"dn"`
+	Name    string   `json:"name"`
+	Members []string `json:"members,omitempty"`
+}
+
+// LDAP group sync actions.
+const (
+	LDAPSyncActionGroupCreate  = "group_create"
+	LDAPSyncActionGroupAdopt   = "group_adopt"
+	LDAPSyncActionMemberAdd    = "member_add"
+	LDAPSyncActionMemberRemove = "member_remove"
+)
+
+// LDAP group sync conflicts.
+const (
+	LDAPSyncConflictInvalidGroup = "invalid_group"
+	LDAPSyncConflictLocalGroup   = "local_group"
+	LDAPSyncConflictManualMember = "manual_member"
+	LDAPSyncConflictAdminMember  = "admin_member"
+	LDAPSyncConflictUnknownUser  = "unknown_user"
+)
+
+// LDAPSyncReport is the result of a synchronization of the LDAP groups with the CDS groups. With DryRun, the actions
+// were computed but not applied.
+type LDAPSyncReport struct {
+	Start     time.Time          `json:"start"`
+	End       time.Time          `json:"end"`
+	DryRun    bool               `json:"dry_run"`
+	Status    string             `json:"status"`
+	Error     string             `json:"error,omitempty"`
+	Actions   []LDAPSyncAction   `json:"actions,omitempty"`
+	Conflicts []LDAPSyncConflict `json:"conflicts,omitempty"`
+	OptedOut  []string           `json:"opted_out,omitempty"`
+}
+
+// LDAPSyncAction is a change made on a CDS group to match its LDAP group.
+type LDAPSyncAction struct {
+	Type      string `json:"type"`
+	Group     string `json:"group"`
+	LDAPGroup string `json:"ldap_group,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+}
+
+// LDAPSyncConflict is a difference between a LDAP group and a CDS group that the sync can't resolve, because the CDS
+// group or membership is managed manually.
+type LDAPSyncConflict struct {
+	Type     string `json:"type"`
+	Group    string `json:"group"`
+	Username string `json:"username,omitempty"`
+	Detail   string `json:"detail"`
+}
+
+// LDAPSyncGroupOptOut is the body to opt a group out of the LDAP group sync.
+type LDAPSyncGroupOptOut struct {
+	OptOut bool `json:"opt_out"`
+}
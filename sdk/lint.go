@@ -0,0 +1,23 @@
+package sdk
+
+// Severities of a lint diagnostic.
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+	LintSeverityInfo    = "info"
+)
+
+// LintRequest contains the files to lint, indexed by file name. The type of a file is given by its name like for a
+// workflow push (ex: my-pipeline.pip.yml, my-application.app.yml).
+type LintRequest struct {
+	Files map[string]string `json:"files"`
+}
+
+// LintDiagnostic is an issue found in a linted file.
+type LintDiagnostic struct {
+	File     string `json:"file" cli:"file"`
+	Severity string `json:"severity" cli:"severity"`
+	Path     string `json:"path,omitempty" cli:"path"`
+	Message  string `json:"message" cli:"message"`
+	Fix      string `json:"fix,omitempty" cli:"fix"`
+}
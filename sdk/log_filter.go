@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Levels of the lines of a job log, as prefixed by the worker, from the lowest to the highest.
+var jobLogLevels = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+// JobLogFilter filters the lines of the logs of a job on a regex and a minimal level, and its steps on a time range.
+// Lines without level are given the level of the previous line.
+type JobLogFilter struct {
+	Regex string     `json:"regex,omitempty"`
+	Level string     `json:"level,omitempty"`
+	From  *time.Time `json:"from,omitempty"`
+	To    *time.Time `json:"to,omitempty"`
+
+	regexp   *regexp.Regexp
+	minLevel int
+}
+
+// Compile checks the filter and prepares it to be used.
+func (f *JobLogFilter) Compile() error {
+	if f.Regex != "" {
+		r, err := regexp.Compile(f.Regex)
+		if err != nil {
+			return NewErrorFrom(ErrWrongRequest, "invalid regex %q: %v", f.Regex, err)
+		}
+		f.regexp = r
+	}
+	f.minLevel = 0
+	if f.Level != "" {
+		f.minLevel = jobLogLevelIndex(strings.ToUpper(f.Level))
+		if f.minLevel < 0 {
+			return NewErrorFrom(ErrWrongRequest, "invalid level %q, should be one of %s", f.Level, strings.Join(jobLogLevels, ", "))
+		}
+	}
+	if f.From != nil && f.To != nil && f.To.Before(*f.From) {
+		return NewErrorFrom(ErrWrongRequest, "invalid time range, end is before start")
+	}
+	return nil
+}
+
+// QueryValues returns the filter as query parameters.
+func (f JobLogFilter) QueryValues() url.Values {
+	v := url.Values{}
+	if f.Regex != "" {
+		v.Set("regex", f.Regex)
+	}
+	if f.Level != "" {
+		v.Set("level", f.Level)
+	}
+	if f.From != nil {
+		v.Set("from", f.From.Format(time.RFC3339))
+	}
+	if f.To != nil {
+		v.Set("to", f.To.Format(time.RFC3339))
+	}
+	return v
+}
+
+// MatchStep returns true if the logs of given step were written during the time range of the filter.
+func (f JobLogFilter) MatchStep(l Log) bool {
+	end := l.Done
+	if end == nil {
+		end = l.LastModified
+	}
+	if f.From != nil && end != nil && end.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && l.Start != nil && l.Start.After(*f.To) {
+		return false
+	}
+	return true
+}
+
+// WriteStep writes the lines of given step logs that match the filter, it returns the number of written lines.
+func (f JobLogFilter) WriteStep(w io.Writer, l Log) (int, error) {
+	var n int
+	level := 1 // lines written before any level are considered as INFO
+	scanner := bufio.NewScanner(strings.NewReader(l.Val))
+	scanner.Buffer(make([]byte, 64*1024), len(l.Val)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if lvl := jobLogLineLevel(line); lvl >= 0 {
+			level = lvl
+		}
+		if level < f.minLevel {
+			continue
+		}
+		if f.regexp != nil && !f.regexp.MatchString(line) {
+			continue
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, WithStack(scanner.Err())
+}
+
+func jobLogLevelIndex(level string) int {
+	for i := range jobLogLevels {
+		if jobLogLevels[i] == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// jobLogLineLevel returns the index of the level prefix of a log line like "[WARN] message", -1 if there is none.
+func jobLogLineLevel(line string) int {
+	if !strings.HasPrefix(line, "[") {
+		return -1
+	}
+	i := strings.Index(line, "] ")
+	if i < 0 {
+		return -1
+	}
+	return jobLogLevelIndex(line[1:i])
+}
@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobLogFilterWriteStep(t *testing.T) {
+	l := Log{Val: "starting\n[INFO] fetching deps\n[WARN] deprecated flag\nstack line\n[ERROR] connection refused\n[DEBUG] retrying\n[INFO] done\n"}
+
+	var buf bytes.Buffer
+	f := JobLogFilter{}
+	require.NoError(t, f.Compile())
+	n, err := f.WriteStep(&buf, l)
+	require.NoError(t, err)
+	assert.Equal(t, 7, n)
+	assert.Equal(t, l.Val, buf.String())
+
+	buf.Reset()
+	f = JobLogFilter{Level: "warn"}
+	require.NoError(t, f.Compile())
+	n, err = f.WriteStep(&buf, l)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "[WARN] deprecated flag\nstack line\n[ERROR] connection refused\n", buf.String())
+
+	buf.Reset()
+	f = JobLogFilter{Regex: "refused|done", Level: "INFO"}
+	require.NoError(t, f.Compile())
+	n, err = f.WriteStep(&buf, l)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "[ERROR] connection refused\n[INFO] done\n", buf.String())
+
+	assert.Error(t, (&JobLogFilter{Regex: "("}).Compile())
+	assert.Error(t, (&JobLogFilter{Level: "FATAL"}).Compile())
+}
+
+func TestJobLogFilterMatchStep(t *testing.T) {
+	t0 := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(10 * time.Minute)
+	l := Log{Start: &t0, Done: &t1}
+
+	assert.True(t, JobLogFilter{}.MatchStep(l))
+	from, to := t0.Add(5*time.Minute), t0.Add(time.Hour)
+	assert.True(t, JobLogFilter{From: &from, To: &to}.MatchStep(l))
+	from = t1.Add(time.Minute)
+	assert.False(t, JobLogFilter{From: &from}.MatchStep(l))
+	to = t0.Add(-time.Minute)
+	assert.False(t, JobLogFilter{To: &to}.MatchStep(l))
+
+	from, to = t1, t0
+	assert.Error(t, (&JobLogFilter{From: &from, To: &to}).Compile())
+
+	f := JobLogFilter{Regex: "a|b", Level: "WARN", From: &t0}
+	assert.Equal(t, "from=2020-04-01T10%3A00%3A00Z&level=WARN&regex=a%7Cb", f.QueryValues().Encode())
+}
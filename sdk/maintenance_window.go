@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"time"
+)
+
+// MaintenanceWindow is a time window during which the jobs of the selected projects and workflows stay in queue, like
+// around a production freeze. New runs are created but their jobs are not executed before the end of the window.
+// An empty project key selects all the projects, an empty workflow name selects all the workflows of the project.
+type MaintenanceWindow struct {
+	ID           int64     `json:"id" db:"id" cli:"id,key"`
+	ProjectKey   string    `json:"project_key,omitempty" db:"project_key" cli:"project_key"`
+	WorkflowName string    `json:"workflow_name,omitempty" db:"workflow_name" cli:"workflow_name"`
+	Reason       string    `json:"reason" db:"reason" cli:"reason"`
+	Start        time.Time `json:"start" db:"start_date" cli:"start"`
+	End          time.Time `json:"end" db:"end_date" cli:"end"`
+	Author       string    `json:"author" db:"author" cli:"author"`
+	Created      time.Time `json:"created" db:"created" cli:"-"`
+}
+
+// IsValid returns an error if the maintenance window is not valid.
+func (w MaintenanceWindow) IsValid() error {
+	if w.Reason == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid empty reason for maintenance window")
+	}
+	if w.Start.IsZero() || w.End.IsZero() || !w.End.After(w.Start) {
+		return NewErrorFrom(ErrWrongRequest, "invalid maintenance window, its end should be after its start")
+	}
+	if w.WorkflowName != "" && w.ProjectKey == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid maintenance window, a project key is required to select the workflow %s", w.WorkflowName)
+	}
+	return nil
+}
+
+// IsActive returns true if given time is in the maintenance window.
+func (w MaintenanceWindow) IsActive(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// Selects returns true if the maintenance window applies to given workflow.
+func (w MaintenanceWindow) Selects(projectKey, workflowName string) bool {
+	if w.ProjectKey != "" && w.ProjectKey != projectKey {
+		return false
+	}
+	return w.WorkflowName == "" || w.WorkflowName == workflowName
+}
+
+// MaintenanceWindows is a list of maintenance windows.
+type MaintenanceWindows []MaintenanceWindow
+
+// Active returns the active maintenance window that ends last for given workflow, or nil if the workflow is not in
+// maintenance at given time.
+func (l MaintenanceWindows) Active(t time.Time, projectKey, workflowName string) *MaintenanceWindow {
+	var res *MaintenanceWindow
+	for i := range l {
+		if !l[i].IsActive(t) || !l[i].Selects(projectKey, workflowName) {
+			continue
+		}
+		if res == nil || l[i].End.After(res.End) {
+			res = &l[i]
+		}
+	}
+	return res
+}
+
+// MaintenanceWindowOverride allows the jobs of a workflow run to be executed during the maintenance windows, it is
+// used for emergencies like a hotfix during a production freeze.
+type MaintenanceWindowOverride struct {
+	WorkflowRunID int64     `json:"workflow_run_id" db:"workflow_run_id"`
+	Reason        string    `json:"reason" db:"reason"`
+	Author        string    `json:"author" db:"author"`
+	Created       time.Time `json:"created" db:"created"`
+}
@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindowIsValid(t *testing.T) {
+	now := time.Now()
+	assert.NoError(t, MaintenanceWindow{Reason: "Production freeze", Start: now, End: now.Add(time.Hour)}.IsValid())
+	assert.Error(t, MaintenanceWindow{Start: now, End: now.Add(time.Hour)}.IsValid())
+	assert.Error(t, MaintenanceWindow{Reason: "Production freeze", Start: now, End: now}.IsValid())
+	assert.Error(t, MaintenanceWindow{Reason: "Production freeze", WorkflowName: "deploy", Start: now, End: now.Add(time.Hour)}.IsValid())
+}
+
+func TestMaintenanceWindowsActive(t *testing.T) {
+	now := time.Now()
+	windows := MaintenanceWindows{
+		{ID: 1, ProjectKey: "PROJ", Reason: "Production freeze", Start: now.Add(-time.Hour), End: now.Add(time.Hour)},
+		{ID: 2, ProjectKey: "PROJ", WorkflowName: "deploy", Reason: "Database migration", Start: now.Add(-time.Hour), End: now.Add(2 * time.Hour)},
+		{ID: 3, Reason: "Datacenter move", Start: now.Add(time.Hour), End: now.Add(3 * time.Hour)},
+	}
+
+	assert.Nil(t, windows.Active(now, "OTHER", "deploy"))
+
+	w := windows.Active(now, "PROJ", "build")
+	require.NotNil(t, w)
+	assert.Equal(t, int64(1), w.ID)
+
+	w = windows.Active(now, "PROJ", "deploy")
+	require.NotNil(t, w)
+	assert.Equal(t, int64(2), w.ID)
+
+	w = windows.Active(now.Add(150*time.Minute), "OTHER", "deploy")
+	require.NotNil(t, w)
+	assert.Equal(t, int64(3), w.ID)
+	assert.Nil(t, windows.Active(now.Add(3*time.Hour), "OTHER", "deploy"))
+}
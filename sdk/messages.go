@@ -60,6 +60,8 @@ var (
 	MsgSpawnInfoHatcheryStartDockerPull    = &Message{"MsgSpawnInfoHatcheryStartDockerPull", trad{FR: "La Hatchery %s a démarré le docker pull de l'image %s...", EN: "Hatchery %s starts docker pull %s..."}, nil, RunInfoTypInfo}
 	MsgSpawnInfoHatcheryEndDockerPull      = &Message{"MsgSpawnInfoHatcheryEndDockerPull", trad{FR: "La Hatchery %s a terminé le docker pull de l'image %s", EN: "Hatchery %s docker pull %s done"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoHatcheryEndDockerPullErr   = &Message{"MsgSpawnInfoHatcheryEndDockerPullErr", trad{FR: "⚠ La Hatchery %s a terminé le docker pull de l'image %s en erreur: %s", EN: "⚠ Hatchery %s - docker pull %s done with error: %v"}, nil, RunInfoTypeError}
+	MsgSpawnInfoHatcheryDockerPullBlocked  = &Message{"MsgSpawnInfoHatcheryDockerPullBlocked", trad{FR: "⚠ La Hatchery %s a bloqué l'image %s: %s", EN: "⚠ Hatchery %s - image %s blocked: %s"}, nil, RunInfoTypeError}
+	MsgSpawnInfoHatcheryServiceUnhealthy   = &Message{"MsgSpawnInfoHatcheryServiceUnhealthy", trad{FR: "⚠ La Hatchery %s n'a pas pu démarrer les services du job: %v", EN: "⚠ Hatchery %s - unable to start job services: %v"}, nil, RunInfoTypeError}
 	MsgSpawnInfoDeprecatedModel            = &Message{"MsgSpawnInfoDeprecatedModel", trad{FR: "⚠ Attention vous utilisez un worker model (%s) déprécié", EN: "⚠ Pay attention you are using a deprecated worker model (%s)"}, nil, RunInfoTypeWarning}
 	MsgSpawnInfoWorkerEnd                  = &Message{"MsgSpawnInfoWorkerEnd", trad{FR: "✓ Le worker %s a terminé et a passé %s à travailler sur les étapes", EN: "✓ Worker %s finished working on this job and took %s to work on the steps"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoJobInQueue                 = &Message{"MsgSpawnInfoJobInQueue", trad{FR: "✓ Le job a été mis en file d'attente", EN: "✓ Job has been queued"}, nil, RunInfoTypInfo}
@@ -68,9 +70,19 @@ var (
 	MsgSpawnInfoWorkerForJob               = &Message{"MsgSpawnInfoWorkerForJob", trad{FR: "Ce worker %s a été créé pour lancer ce job", EN: "This worker %s was created to take this action"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoWorkerForJobError          = &Message{"MsgSpawnInfoWorkerForJobError", trad{FR: "⚠ Ce worker %s a été créé pour lancer ce job, mais ne possède pas tous les pré-requis. Vérifiez que les prérequis suivants:%s", EN: "⚠ This worker %s was created to take this action, but does not have all prerequisites. Please verify the following prerequisites:%s"}, nil, RunInfoTypeError}
 	MsgSpawnInfoJobError                   = &Message{"MsgSpawnInfoJobError", trad{FR: "⚠ Impossible de lancer ce job : %s", EN: "⚠ Unable to run this job: %s"}, nil, RunInfoTypInfo}
+	MsgSpawnInfoWorkerVersionOutdated      = &Message{"MsgSpawnInfoWorkerVersionOutdated", trad{FR: "⚠ Le worker %s ne peut pas lancer ce job : %s", EN: "⚠ Worker %s cannot run this job: %s"}, nil, RunInfoTypeError}
+	MsgSpawnInfoJobInfraRetry              = &Message{"MsgSpawnInfoJobInfraRetry", trad{FR: "⚠ Erreur d'infrastructure (%s) : le job est remis en file, tentative %d/%d dans %s", EN: "⚠ Infrastructure error (%s): job requeued, attempt %d/%d in %s"}, nil, RunInfoTypeWarning}
+	MsgSpawnInfoJobInfraFail               = &Message{"MsgSpawnInfoJobInfraFail", trad{FR: "⚠ Erreur d'infrastructure (%s) : le job a échoué après %d tentatives", EN: "⚠ Infrastructure error (%s): job failed after %d attempts"}, nil, RunInfoTypeError}
+	MsgSpawnInfoArtifactInfected           = &Message{"MsgSpawnInfoArtifactInfected", trad{FR: "⚠ L'artefact %s est infecté, il a été mis en quarantaine : %s", EN: "⚠ Artifact %s is infected, it was quarantined: %s"}, nil, RunInfoTypeError}
+	MsgSpawnInfoJobHeldForDebug            = &Message{"MsgSpawnInfoJobHeldForDebug", trad{FR: "Le job a échoué, il est retenu %s sur le worker %s pour être débogué avec: %s", EN: "Job failed, it is held %s on worker %s for debugging with: %s"}, nil, RunInfoTypeWarning}
+	MsgSpawnInfoJobRequeued                = &Message{"MsgSpawnInfoJobRequeued", trad{FR: "Le job a été remis en file par %s", EN: "Job requeued by %s"}, nil, RunInfoTypeWarning}
+	MsgSpawnInfoJobLost                    = &Message{"MsgSpawnInfoJobLost", trad{FR: "⚠ Le worker %s a été perdu (%s) : le job n'est pas déclaré idempotent, il a été arrêté", EN: "⚠ Worker %s was lost (%s): job is not declared idempotent, it was stopped"}, nil, RunInfoTypeError}
 	MsgWorkflowStarting                    = &Message{"MsgWorkflowStarting", trad{FR: "Le workflow %s#%s a été démarré", EN: "Workflow %s#%s has been started"}, nil, RunInfoTypInfo}
 	MsgWorkflowError                       = &Message{"MsgWorkflowError", trad{FR: "⚠ Une erreur est survenue: %v", EN: "⚠ An error has occurred: %v"}, nil, RunInfoTypeError}
 	MsgWorkflowConditionError              = &Message{"MsgWorkflowConditionError", trad{FR: "Les conditions de lancement ne sont pas respectées.", EN: "Run conditions aren't ok."}, nil, RunInfoTypInfo}
+	MsgWorkflowNodeDeployForbidden         = &Message{"MsgWorkflowNodeDeployForbidden", trad{FR: "Le pipeline %s utilise une intégration de déploiement, il n'a pas été lancé car %s n'a pas la permission de déployer.", EN: "Pipeline %s uses a deployment integration, it was not run because %s doesn't have the deploy permission."}, nil, RunInfoTypeWarning}
+	MsgWorkflowNodeDeployUnprotectedBranch = &Message{"MsgWorkflowNodeDeployUnprotectedBranch", trad{FR: "⚠ Le pipeline %s utilise une intégration de déploiement, il est lancé pour la branche non protégée %s.", EN: "⚠ Pipeline %s uses a deployment integration, it is run for the unprotected branch %s."}, nil, RunInfoTypeWarning}
+	MsgWorkflowNodeDeploymentApproval      = &Message{"MsgWorkflowNodeDeploymentApproval", trad{FR: "Le pipeline %s déploie les changements prévus par %s, il doit être lancé manuellement pour approuver le déploiement.", EN: "Pipeline %s deploys the changes planned by %s, it should be run manually to approve the deployment."}, nil, RunInfoTypInfo}
 	MsgWorkflowNodeStop                    = &Message{"MsgWorkflowNodeStop", trad{FR: "Le pipeline a été arrété par %s", EN: "The pipeline has been stopped by %s"}, nil, RunInfoTypInfo}
 	MsgWorkflowNodeMutex                   = &Message{"MsgWorkflowNodeMutex", trad{FR: "Le pipeline %s est mis en attente tant qu'il est en cours sur un autre run", EN: "The pipeline %s is waiting while it's running on another run"}, nil, RunInfoTypInfo}
 	MsgWorkflowNodeMutexRelease            = &Message{"MsgWorkflowNodeMutexRelease", trad{FR: "Lancement du pipeline %s", EN: "Triggering pipeline %s"}, nil, RunInfoTypInfo}
@@ -132,6 +144,8 @@ var Messages = map[string]*Message{
 	MsgSpawnInfoHatcheryStartDockerPull.ID:    MsgSpawnInfoHatcheryStartDockerPull,
 	MsgSpawnInfoHatcheryEndDockerPull.ID:      MsgSpawnInfoHatcheryEndDockerPull,
 	MsgSpawnInfoHatcheryEndDockerPullErr.ID:   MsgSpawnInfoHatcheryEndDockerPullErr,
+	MsgSpawnInfoHatcheryDockerPullBlocked.ID:  MsgSpawnInfoHatcheryDockerPullBlocked,
+	MsgSpawnInfoHatcheryServiceUnhealthy.ID:   MsgSpawnInfoHatcheryServiceUnhealthy,
 	MsgSpawnInfoDeprecatedModel.ID:            MsgSpawnInfoDeprecatedModel,
 	MsgSpawnInfoWorkerEnd.ID:                  MsgSpawnInfoWorkerEnd,
 	MsgSpawnInfoJobInQueue.ID:                 MsgSpawnInfoJobInQueue,
@@ -140,9 +154,19 @@ var Messages = map[string]*Message{
 	MsgSpawnInfoWorkerForJob.ID:               MsgSpawnInfoWorkerForJob,
 	MsgSpawnInfoWorkerForJobError.ID:          MsgSpawnInfoWorkerForJobError,
 	MsgSpawnInfoJobError.ID:                   MsgSpawnInfoJobError,
+	MsgSpawnInfoWorkerVersionOutdated.ID:      MsgSpawnInfoWorkerVersionOutdated,
+	MsgSpawnInfoJobInfraRetry.ID:              MsgSpawnInfoJobInfraRetry,
+	MsgSpawnInfoJobInfraFail.ID:               MsgSpawnInfoJobInfraFail,
+	MsgSpawnInfoArtifactInfected.ID:           MsgSpawnInfoArtifactInfected,
+	MsgSpawnInfoJobHeldForDebug.ID:            MsgSpawnInfoJobHeldForDebug,
+	MsgSpawnInfoJobRequeued.ID:                MsgSpawnInfoJobRequeued,
+	MsgSpawnInfoJobLost.ID:                    MsgSpawnInfoJobLost,
 	MsgWorkflowStarting.ID:                    MsgWorkflowStarting,
 	MsgWorkflowError.ID:                       MsgWorkflowError,
 	MsgWorkflowConditionError.ID:              MsgWorkflowConditionError,
+	MsgWorkflowNodeDeployForbidden.ID:         MsgWorkflowNodeDeployForbidden,
+	MsgWorkflowNodeDeployUnprotectedBranch.ID: MsgWorkflowNodeDeployUnprotectedBranch,
+	MsgWorkflowNodeDeploymentApproval.ID:      MsgWorkflowNodeDeploymentApproval,
 	MsgWorkflowNodeStop.ID:                    MsgWorkflowNodeStop,
 	MsgWorkflowNodeMutex.ID:                   MsgWorkflowNodeMutex,
 	MsgWorkflowNodeMutexRelease.ID:            MsgWorkflowNodeMutexRelease,
@@ -3,20 +3,50 @@ package sdk
 // These are type of plugins
 const (
 	GRPCPluginDeploymentIntegration = "integration-deploy_application"
+	GRPCPluginReleaseIntegration    = "integration-release"
 	GRPCPluginAction                = "action"
 )
 
 // GRPCPlugin is the type representing a plugin over GRPC
 type GRPCPlugin struct {
-	ID                 int64              `json:"id" yaml:"id" cli:"id" db:"id"`
-	Name               string             `json:"name" yaml:"name" cli:"name,key" db:"name"`
-	Type               string             `json:"type" yaml:"type" cli:"type" db:"type"`
-	Author             string             `json:"author" yaml:"author" cli:"author" db:"author"`
-	Description        string             `json:"description" yaml:"description" cli:"description" db:"description"`
-	Parameters         []Parameter        `json:"parameters,omitempty" yaml:"parameters,omitempty" cli:"parameters" db:"-"`
-	Binaries           []GRPCPluginBinary `json:"binaries" yaml:"binaries" cli:"-" db:"-"`
-	IntegrationModelID *int64             `json:"-" db:"integration_model_id" yaml:"-" cli:"-"`
-	Integration        string             `json:"integration" db:"-" yaml:"integration" cli:"integration"`
+	ID                 int64               `json:"id" yaml:"id" cli:"id" db:"id"`
+	Name               string              `json:"name" yaml:"name" cli:"name,key" db:"name"`
+	Type               string              `json:"type" yaml:"type" cli:"type" db:"type"`
+	Author             string              `json:"author" yaml:"author" cli:"author" db:"author"`
+	Description        string              `json:"description" yaml:"description" cli:"description" db:"description"`
+	Parameters         []Parameter         `json:"parameters,omitempty" yaml:"parameters,omitempty" cli:"parameters" db:"-"`
+	Binaries           []GRPCPluginBinary  `json:"binaries" yaml:"binaries" cli:"-" db:"-"`
+	IntegrationModelID *int64              `json:"-" db:"integration_model_id" yaml:"-" cli:"-"`
+	Integration        string              `json:"integration" db:"-" yaml:"integration" cli:"integration"`
+	Contract           *GRPCPluginContract `json:"contract,omitempty" db:"-" yaml:"contract,omitempty" cli:"-"`
+}
+
+// IsValid returns an error if the plugin is invalid.
+func (p GRPCPlugin) IsValid() error {
+	if p.Contract != nil {
+		if p.Type != GRPCPluginDeploymentIntegration && p.Type != GRPCPluginReleaseIntegration {
+			return NewErrorFrom(ErrWrongRequest, "a contract can only be set on %s and %s plugins", GRPCPluginDeploymentIntegration, GRPCPluginReleaseIntegration)
+		}
+		if err := p.Contract.IsValid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsCompatibleWith returns an error if the plugin can't be attached to given integration model.
+func (p GRPCPlugin) IsCompatibleWith(m IntegrationModel) error {
+	switch p.Type {
+	case GRPCPluginDeploymentIntegration:
+		if !m.Supports(IntegrationTypeDeployment) {
+			return NewErrorFrom(ErrWrongRequest, "integration model %s does not support deployment", m.Name)
+		}
+	case GRPCPluginReleaseIntegration:
+		if !m.Supports(IntegrationTypeRelease) {
+			return NewErrorFrom(ErrWrongRequest, "integration model %s does not support release", m.Name)
+		}
+	}
+	return nil
 }
 
 // GetBinary returns the binary for a specific os and arch
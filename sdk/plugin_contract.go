@@ -0,0 +1,161 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// These are the types of values that can be declared in a plugin contract
+const (
+	GRPCPluginContractTypeString  = "string"
+	GRPCPluginContractTypeNumber  = "number"
+	GRPCPluginContractTypeBoolean = "boolean"
+	GRPCPluginContractTypeURL     = "url"
+)
+
+// GRPCPluginContract describes the inputs expected by an integration plugin and
+// the outputs it returns in the details of its result, as a JSON object.
+type GRPCPluginContract struct {
+	Inputs  []GRPCPluginContractField `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	Outputs []GRPCPluginContractField `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// GRPCPluginContractField describes a value of a plugin contract.
+type GRPCPluginContractField struct {
+	Name        string `json:"name" yaml:"name"`
+	Type        string `json:"type,omitempty" yaml:"type,omitempty"`
+	Required    bool   `json:"required,omitempty" yaml:"required,omitempty"`
+	Pattern     string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// IsValid returns an error if the contract is invalid.
+func (c GRPCPluginContract) IsValid() error {
+	for _, fields := range [][]GRPCPluginContractField{c.Inputs, c.Outputs} {
+		names := make(map[string]struct{}, len(fields))
+		for _, f := range fields {
+			if f.Name == "" {
+				return NewErrorFrom(ErrWrongRequest, "invalid plugin contract: a field name is missing")
+			}
+			if _, ok := names[f.Name]; ok {
+				return NewErrorFrom(ErrWrongRequest, "invalid plugin contract: field %s is declared twice", f.Name)
+			}
+			names[f.Name] = struct{}{}
+			switch f.Type {
+			case "", GRPCPluginContractTypeString, GRPCPluginContractTypeNumber, GRPCPluginContractTypeBoolean, GRPCPluginContractTypeURL:
+			default:
+				return NewErrorFrom(ErrWrongRequest, "invalid plugin contract: unknown type %s for field %s", f.Type, f.Name)
+			}
+			if f.Pattern != "" {
+				if _, err := regexp.Compile(f.Pattern); err != nil {
+					return NewErrorFrom(ErrWrongRequest, "invalid plugin contract: invalid pattern for field %s: %v", f.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// CheckInputs checks given values against the inputs of the contract and returns
+// an error for each input that is missing or invalid.
+func (c GRPCPluginContract) CheckInputs(values map[string]string) MultiError {
+	var errs MultiError
+	for _, f := range c.Inputs {
+		v, ok := values[f.Name]
+		if !ok || v == "" {
+			if f.Required {
+				errs.Append(fmt.Errorf("plugin input %s is required but is not set%s", f.Name, f.hint()))
+			}
+			continue
+		}
+		if err := f.check(v); err != nil {
+			errs.Append(fmt.Errorf("plugin input %s: %v%s", f.Name, err, f.hint()))
+		}
+	}
+	return errs
+}
+
+// CheckOutputs parses given plugin result details as a JSON object and checks its
+// values against the outputs of the contract. Returns outputs values as strings.
+func (c GRPCPluginContract) CheckOutputs(details string) (map[string]string, MultiError) {
+	var errs MultiError
+	if len(c.Outputs) == 0 {
+		return nil, errs
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(details), &raw); err != nil {
+		errs.Append(fmt.Errorf("plugin outputs should be a JSON object: %v", err))
+		return nil, errs
+	}
+
+	values := make(map[string]string, len(c.Outputs))
+	for _, f := range c.Outputs {
+		v, ok := raw[f.Name]
+		if !ok || v == nil {
+			if f.Required {
+				errs.Append(fmt.Errorf("plugin output %s is required but was not returned", f.Name))
+			}
+			continue
+		}
+		var s string
+		switch x := v.(type) {
+		case string:
+			s = x
+		case float64:
+			s = strconv.FormatFloat(x, 'f', -1, 64)
+		case bool:
+			s = strconv.FormatBool(x)
+		default:
+			errs.Append(fmt.Errorf("plugin output %s should be a scalar value", f.Name))
+			continue
+		}
+		if err := f.check(s); err != nil {
+			errs.Append(fmt.Errorf("plugin output %s: %v", f.Name, err))
+			continue
+		}
+		values[f.Name] = s
+	}
+	return values, errs
+}
+
+// check returns an error if given value doesn't match the field, the value is not
+// added to the error as it could be a secret.
+func (f GRPCPluginContractField) check(v string) error {
+	switch f.Type {
+	case GRPCPluginContractTypeNumber:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return fmt.Errorf("value is not a number")
+		}
+	case GRPCPluginContractTypeBoolean:
+		if _, err := strconv.ParseBool(v); err != nil {
+			return fmt.Errorf("value is not a boolean")
+		}
+	case GRPCPluginContractTypeURL:
+		u, err := url.Parse(v)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("value is not a valid url")
+		}
+	}
+	if f.Pattern != "" {
+		reg, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %s: %v", f.Pattern, err)
+		}
+		if !reg.MatchString(v) {
+			return fmt.Errorf("value does not match pattern %s", f.Pattern)
+		}
+	}
+	return nil
+}
+
+func (f GRPCPluginContractField) hint() string {
+	if f.Description == "" {
+		return ""
+	}
+	return " (" + strings.TrimSuffix(f.Description, ".") + ")"
+}
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGRPCPluginContract(t *testing.T) {
+	c := GRPCPluginContract{
+		Inputs: []GRPCPluginContractField{
+			{Name: "cds.integration.deployment.host", Type: GRPCPluginContractTypeURL, Required: true},
+			{Name: "cds.integration.deployment.token", Required: true, Description: "API token of the deployment service."},
+			{Name: "cds.integration.deployment.timeout", Type: GRPCPluginContractTypeNumber},
+			{Name: "cds.integration.deployment.env", Pattern: "^(prod|dev)$"},
+		},
+		Outputs: []GRPCPluginContractField{
+			{Name: "deployment_id", Required: true},
+			{Name: "replicas", Type: GRPCPluginContractTypeNumber},
+		},
+	}
+	require.NoError(t, c.IsValid())
+
+	errs := c.CheckInputs(map[string]string{
+		"cds.integration.deployment.host":    "https://deploy.example.com",
+		"cds.integration.deployment.token":   "my-token",
+		"cds.integration.deployment.timeout": "30",
+		"cds.integration.deployment.env":     "prod",
+	})
+	assert.True(t, errs.IsEmpty())
+
+	errs = c.CheckInputs(map[string]string{
+		"cds.integration.deployment.host":    "deploy.example.com",
+		"cds.integration.deployment.timeout": "thirty",
+		"cds.integration.deployment.env":     "staging",
+	})
+	require.Len(t, errs, 4)
+	assert.Equal(t, "plugin input cds.integration.deployment.host: value is not a valid url", errs[0].Error())
+	assert.Equal(t, "plugin input cds.integration.deployment.token is required but is not set (API token of the deployment service)", errs[1].Error())
+	assert.Equal(t, "plugin input cds.integration.deployment.timeout: value is not a number", errs[2].Error())
+	assert.Equal(t, "plugin input cds.integration.deployment.env: value does not match pattern ^(prod|dev)$", errs[3].Error())
+
+	outputs, errs := c.CheckOutputs(`{"deployment_id": "abc", "replicas": 3}`)
+	assert.True(t, errs.IsEmpty())
+	assert.Equal(t, map[string]string{"deployment_id": "abc", "replicas": "3"}, outputs)
+
+	_, errs = c.CheckOutputs(`{"replicas": "three"}`)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "plugin output deployment_id is required but was not returned", errs[0].Error())
+	assert.Equal(t, "plugin output replicas: value is not a number", errs[1].Error())
+
+	_, errs = c.CheckOutputs(`Deployment done`)
+	require.Len(t, errs, 1)
+
+	invalid := GRPCPluginContract{Inputs: []GRPCPluginContractField{{Name: "a", Type: "date"}}}
+	assert.Error(t, invalid.IsValid())
+}
@@ -55,12 +55,16 @@ type Permissions struct {
 	Readable   bool `json:"readable"`
 	Writable   bool `json:"writable"`
 	Executable bool `json:"executable"`
+	Deployable bool `json:"deployable"`
 }
 
 func (p Permissions) Level() int {
 	var i = 7
 	if !p.Writable {
-		i = 5
+		i = 6
+		if !p.Deployable {
+			i = 5
+		}
 	}
 	if !p.Executable {
 		i = 4
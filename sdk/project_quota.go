@@ -0,0 +1,102 @@
+package sdk
+
+// Quotas of a project.
+const (
+	ProjectQuotaConcurrentJobs  = "concurrent_jobs"
+	ProjectQuotaArtifactStorage = "artifact_storage"
+	ProjectQuotaRunsPerDay      = "runs_per_day"
+)
+
+// ProjectQuotas lists all the quotas of a project.
+var ProjectQuotas = []string{ProjectQuotaConcurrentJobs, ProjectQuotaArtifactStorage, ProjectQuotaRunsPerDay}
+
+// Levels of the usage of a project quota.
+const (
+	ProjectQuotaLevelWarning  = "warning"
+	ProjectQuotaLevelExceeded = "exceeded"
+)
+
+// DefaultProjectQuotaWarningThreshold is the percentage of a quota above which a warning event is sent.
+const DefaultProjectQuotaWarningThreshold = 80
+
+// ProjectQuota is the configuration of the quotas of a project, set by CDS administrators. A limit of 0 means no limit.
+// When HardStop is true, the jobs, runs and artifacts that would exceed a limit are refused.
+type ProjectQuota struct {
+	ID               int64 `json:"id" db:"id"`
+	ProjectID        int64 `json:"project_id" db:"project_id"`
+	ConcurrentJobs   int64 `json:"concurrent_jobs" db:"concurrent_jobs"`
+	ArtifactStorage  int64 `json:"artifact_storage" db:"artifact_storage"`
+	RunsPerDay       int64 `json:"runs_per_day" db:"runs_per_day"`
+	WarningThreshold int64 `json:"warning_threshold" db:"warning_threshold"`
+	HardStop         bool  `json:"hard_stop" db:"hard_stop"`
+}
+
+// IsValid returns an error if the quota configuration is not valid.
+func (q ProjectQuota) IsValid() error {
+	if q.ConcurrentJobs < 0 || q.ArtifactStorage < 0 || q.RunsPerDay < 0 {
+		return NewErrorFrom(ErrWrongRequest, "quota limits must be positive")
+	}
+	if q.WarningThreshold < 0 || q.WarningThreshold > 100 {
+		return NewErrorFrom(ErrWrongRequest, "warning threshold must be a percentage")
+	}
+	return nil
+}
+
+// Limit returns the limit of given quota, 0 if there is no limit.
+func (q ProjectQuota) Limit(name string) int64 {
+	switch name {
+	case ProjectQuotaConcurrentJobs:
+		return q.ConcurrentJobs
+	case ProjectQuotaArtifactStorage:
+		return q.ArtifactStorage
+	case ProjectQuotaRunsPerDay:
+		return q.RunsPerDay
+	}
+	return 0
+}
+
+// Level returns the level of given usage of a quota: exceeded above its limit, warning above its warning threshold,
+// empty otherwise.
+func (q ProjectQuota) Level(name string, usage int64) string {
+	limit := q.Limit(name)
+	if limit <= 0 {
+		return ""
+	}
+	if usage > limit {
+		return ProjectQuotaLevelExceeded
+	}
+	threshold := q.WarningThreshold
+	if threshold == 0 {
+		threshold = DefaultProjectQuotaWarningThreshold
+	}
+	if usage*100 >= limit*threshold {
+		return ProjectQuotaLevelWarning
+	}
+	return ""
+}
+
+// ProjectQuotaUsage is the current usage of the quotas of a project.
+type ProjectQuotaUsage struct {
+	ConcurrentJobs  int64 `json:"concurrent_jobs"`
+	ArtifactStorage int64 `json:"artifact_storage"`
+	RunsPerDay      int64 `json:"runs_per_day"`
+}
+
+// Value returns the usage of given quota.
+func (u ProjectQuotaUsage) Value(name string) int64 {
+	switch name {
+	case ProjectQuotaConcurrentJobs:
+		return u.ConcurrentJobs
+	case ProjectQuotaArtifactStorage:
+		return u.ArtifactStorage
+	case ProjectQuotaRunsPerDay:
+		return u.RunsPerDay
+	}
+	return 0
+}
+
+// ProjectQuotaStatus is the configuration of the quotas of a project with their current usage.
+type ProjectQuotaStatus struct {
+	Quota ProjectQuota      `json:"quota"`
+	Usage ProjectQuotaUsage `json:"usage"`
+}
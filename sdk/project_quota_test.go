@@ -0,0 +1,24 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectQuotaLevel(t *testing.T) {
+	q := ProjectQuota{ConcurrentJobs: 10, RunsPerDay: 100, WarningThreshold: 50}
+
+	assert.Equal(t, "", q.Level(ProjectQuotaConcurrentJobs, 4))
+	assert.Equal(t, ProjectQuotaLevelWarning, q.Level(ProjectQuotaConcurrentJobs, 5))
+	assert.Equal(t, ProjectQuotaLevelWarning, q.Level(ProjectQuotaConcurrentJobs, 10))
+	assert.Equal(t, ProjectQuotaLevelExceeded, q.Level(ProjectQuotaConcurrentJobs, 11))
+
+	// A quota without limit is never reached
+	assert.Equal(t, "", q.Level(ProjectQuotaArtifactStorage, 1<<40))
+
+	// The default warning threshold is used if not set
+	q.WarningThreshold = 0
+	assert.Equal(t, "", q.Level(ProjectQuotaRunsPerDay, 79))
+	assert.Equal(t, ProjectQuotaLevelWarning, q.Level(ProjectQuotaRunsPerDay, 80))
+}
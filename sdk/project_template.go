@@ -0,0 +1,118 @@
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ProjectBootstrap is the request to create a project from a project template.
+type ProjectBootstrap struct {
+	Key         string          `json:"key"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Template    ProjectTemplate `json:"template"`
+}
+
+// ProjectBootstrapResult is returned when a project was created from a project template. Placeholders contains the
+// names of the password variables of the project that should be set to complete the integrations.
+type ProjectBootstrapResult struct {
+	Project      Project  `json:"project"`
+	Workflow     string   `json:"workflow,omitempty"`
+	Placeholders []string `json:"placeholders,omitempty"`
+	Messages     []string `json:"messages,omitempty"`
+}
+
+// ProjectTemplate describes the resources created with a new project: the groups with their permissions, the default
+// integrations and a starter workflow generated from a workflow template.
+type ProjectTemplate struct {
+	Groups       []ProjectTemplateGroup       `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Integrations []ProjectTemplateIntegration `json:"integrations,omitempty" yaml:"integrations,omitempty"`
+	Workflow     *ProjectTemplateWorkflow     `json:"workflow,omitempty" yaml:"workflow,omitempty"`
+}
+
+// ProjectTemplateGroup is a group added on the project with given permission.
+type ProjectTemplateGroup struct {
+	Name       string `json:"name" yaml:"name"`
+	Permission int    `json:"permission" yaml:"permission"`
+}
+
+// ProjectTemplateIntegration is an integration created on the project. Password values of the integration should
+// not be given, they are replaced by placeholders that reference project password variables.
+type ProjectTemplateIntegration struct {
+	Name   string            `json:"name" yaml:"name"`
+	Model  string            `json:"model" yaml:"model"`
+	Config map[string]string `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// ProjectTemplateWorkflow is the starter workflow of the project, generated from the workflow template at given path
+// (i.e. group/slug). If RepositoryHook is set, a repository webhook is added on the root node of the workflow.
+type ProjectTemplateWorkflow struct {
+	Template       string            `json:"template" yaml:"template"`
+	Name           string            `json:"name" yaml:"name"`
+	Parameters     map[string]string `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RepositoryHook bool              `json:"repository_hook,omitempty" yaml:"repository_hook,omitempty"`
+}
+
+var templateWorkflowPathRegex = regexp.MustCompile("^[a-zA-Z0-9._-]+/[a-zA-Z0-9._-]+(@[0-9]+)?$")
+
+// IsValid returns an error if the project template is not valid.
+func (t ProjectTemplate) IsValid() error {
+	var writable bool
+	groups := make(map[string]struct{}, len(t.Groups))
+	for _, g := range t.Groups {
+		if g.Name == "" {
+			return NewErrorFrom(ErrWrongRequest, "invalid project template: missing group name")
+		}
+		if _, ok := groups[g.Name]; ok {
+			return NewErrorFrom(ErrWrongRequest, "invalid project template: duplicated group %s", g.Name)
+		}
+		groups[g.Name] = struct{}{}
+		if !IsValidPermissionValue(g.Permission) {
+			return NewErrorFrom(ErrWrongRequest, "invalid project template: invalid permission %d for group %s", g.Permission, g.Name)
+		}
+		if g.Permission == PermissionReadWriteExecute {
+			writable = true
+		}
+	}
+	if len(t.Groups) > 0 && !writable {
+		return NewErrorFrom(ErrWrongRequest, "invalid project template: at least one group should have the permission %d", PermissionReadWriteExecute)
+	}
+
+	integrations := make(map[string]struct{}, len(t.Integrations))
+	for _, i := range t.Integrations {
+		if !NamePatternRegex.MatchString(i.Name) {
+			return NewErrorFrom(ErrWrongRequest, "invalid project template: invalid integration name %q", i.Name)
+		}
+		if _, ok := integrations[i.Name]; ok {
+			return NewErrorFrom(ErrWrongRequest, "invalid project template: duplicated integration %s", i.Name)
+		}
+		integrations[i.Name] = struct{}{}
+		if i.Model == "" {
+			return NewErrorFrom(ErrWrongRequest, "invalid project template: missing model for integration %s", i.Name)
+		}
+	}
+
+	if t.Workflow != nil {
+		if !templateWorkflowPathRegex.MatchString(t.Workflow.Template) {
+			return NewErrorFrom(ErrWrongRequest, "invalid project template: invalid workflow template path %q, it should be group/slug", t.Workflow.Template)
+		}
+		if !NamePatternRegex.MatchString(t.Workflow.Name) {
+			return NewErrorFrom(ErrWrongRequest, "invalid project template: invalid workflow name %q", t.Workflow.Name)
+		}
+	}
+	return nil
+}
+
+// ProjectTemplateSecretVariable returns the name of the project password variable used as placeholder for given
+// integration config key.
+func ProjectTemplateSecretVariable(integrationName, key string) string {
+	r := strings.NewReplacer("-", "_", ".", "_", " ", "_")
+	return fmt.Sprintf("integration_%s_%s", r.Replace(integrationName), r.Replace(key))
+}
+
+// ProjectTemplateSecretPlaceholder returns the value of an integration config key that references given project
+// password variable, it is resolved when the integration is used.
+func ProjectTemplateSecretPlaceholder(variableName string) string {
+	return fmt.Sprintf("{{.cds.proj.%s}}", variableName)
+}
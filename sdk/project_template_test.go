@@ -0,0 +1,40 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectTemplateIsValid(t *testing.T) {
+	tmpl := ProjectTemplate{
+		Groups: []ProjectTemplateGroup{
+			{Name: "my-team", Permission: PermissionReadWriteExecute},
+			{Name: "my-team-ops", Permission: PermissionReadExecuteDeploy},
+		},
+		Integrations: []ProjectTemplateIntegration{{Name: "my-metrics", Model: MetricsIntegrationModel}},
+		Workflow:     &ProjectTemplateWorkflow{Template: "shared.infra/go-service@2", Name: "my-service"},
+	}
+	require.NoError(t, tmpl.IsValid())
+
+	tmpl.Groups[0].Permission = PermissionReadExecute
+	assert.Error(t, tmpl.IsValid(), "a group should have RWX permission")
+	tmpl.Groups[0].Permission = 3
+	assert.Error(t, tmpl.IsValid())
+	tmpl.Groups[0].Permission = PermissionReadWriteExecute
+
+	tmpl.Integrations = append(tmpl.Integrations, ProjectTemplateIntegration{Name: "my-metrics", Model: MetricsIntegrationModel})
+	assert.Error(t, tmpl.IsValid(), "integration names should be unique")
+	tmpl.Integrations = tmpl.Integrations[:1]
+
+	tmpl.Workflow.Template = "go-service"
+	assert.Error(t, tmpl.IsValid())
+}
+
+func TestProjectTemplateSecretPlaceholder(t *testing.T) {
+	name := ProjectTemplateSecretVariable("my-webhook", "auth token")
+	assert.Equal(t, "integration_my_webhook_auth_token", name)
+	assert.True(t, NamePatternRegex.MatchString(name))
+	assert.Equal(t, "{{.cds.proj.integration_my_webhook_auth_token}}", ProjectTemplateSecretPlaceholder(name))
+}
@@ -0,0 +1,23 @@
+package sdk
+
+import "time"
+
+// DefaultWebHookSecretOverlap is the default delay during which the previous secrets of a webhook are still accepted
+// after a rotation, to let the time to update the senders of the payloads.
+const DefaultWebHookSecretOverlap = 24 * time.Hour
+
+// ProjectWebHookSecret is a secret used to sign the payloads sent to the incoming webhooks of a project. When a project
+// has secrets, the hooks µservice rejects the payloads that are not signed with one of them. After a rotation the
+// previous secrets are valid until they expire.
+type ProjectWebHookSecret struct {
+	ID        int64      `json:"id" db:"id"`
+	ProjectID int64      `json:"project_id" db:"project_id"`
+	Secret    string     `json:"secret,omitempty" db:"secret" gorpmapping:"encrypted,ID,ProjectID"`
+	Created   time.Time  `json:"created" db:"created"`
+	ExpireAt  *time.Time `json:"expire_at,omitempty" db:"expire_at"`
+}
+
+// IsExpired returns true if the secret expired at given time.
+func (s ProjectWebHookSecret) IsExpired(now time.Time) bool {
+	return s.ExpireAt != nil && !s.ExpireAt.After(now)
+}
@@ -0,0 +1,32 @@
+package sdk
+
+import "fmt"
+
+// QueueJobDiagnostic explains why a job of the queue is not taken by a hatchery, it contains the result of each check
+// done on the job.
+type QueueJobDiagnostic struct {
+	JobID  int64           `json:"job_id"`
+	Checks []QueueJobCheck `json:"checks"`
+}
+
+// QueueJobCheck is the result of a check done on a job of the queue.
+type QueueJobCheck struct {
+	Name   string `json:"name" cli:"check"`
+	OK     bool   `json:"ok" cli:"ok"`
+	Detail string `json:"detail" cli:"detail"`
+}
+
+// Add appends a check result to the diagnostic.
+func (d *QueueJobDiagnostic) Add(name string, ok bool, format string, args ...interface{}) {
+	d.Checks = append(d.Checks, QueueJobCheck{Name: name, OK: ok, Detail: fmt.Sprintf(format, args...)})
+}
+
+// IsBlocked returns true if one of the checks failed.
+func (d QueueJobDiagnostic) IsBlocked() bool {
+	for _, c := range d.Checks {
+		if !c.OK {
+			return true
+		}
+	}
+	return false
+}
@@ -81,6 +81,8 @@ type VCSBranch struct {
 	LatestCommit string   `json:"latest_commit"`
 	Default      bool     `json:"default"`
 	Parents      []string `json:"parents"`
+	// Protected is nil if the repositories manager doesn't give the protection status of the branch
+	Protected *bool `json:"protected,omitempty"`
 }
 
 //VCSPullRequest represents a pull request
@@ -141,6 +143,7 @@ type VCSHook struct {
 	Disable     bool     `json:"disable"`
 	InsecureSSL bool     `json:"insecure_ssl"`
 	Workflow    bool     `json:"workflow"`
+	Secret      string   `json:"secret,omitempty"`
 }
 
 // VCSCommitStatus represents a status on a VCS repository
@@ -3,6 +3,8 @@ package sdk
 import (
 	"context"
 	"net"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -25,6 +27,9 @@ const (
 	VolumeRequirement = "volume"
 	// OSArchRequirement checks the 'dist' of a worker eg {GOOS}/{GOARCH}
 	OSArchRequirement = "os-architecture"
+	// IsolationRequirement runs each script step of the job in its own mount and user namespaces, the value is the
+	// comma separated list of tool paths visible from the steps in addition to the workspace. Only supported on Linux.
+	IsolationRequirement = "isolation"
 )
 
 // RequirementList is a list of requirement
@@ -65,14 +70,23 @@ func (l RequirementList) IsValid() error {
 		}
 	}
 
-	// check that only one model requirement and hostname exists
-	nbModel, nbHostname := 0, 0
+	// check that only one model requirement, hostname and os-architecture exists
+	nbModel, nbHostname, nbOSArch, nbIsolation := 0, 0, 0, 0
 	for i := range l {
 		switch l[i].Type {
 		case ModelRequirement:
 			nbModel++
 		case HostnameRequirement:
 			nbHostname++
+		case OSArchRequirement:
+			nbOSArch++
+		case IsolationRequirement:
+			nbIsolation++
+			for _, p := range IsolationPaths(l[i].Value) {
+				if !filepath.IsAbs(p) {
+					return NewErrorFrom(ErrInvalidJobRequirement, "invalid isolation path %s, it should be absolute", p)
+				}
+			}
 		}
 	}
 	if nbModel > 1 {
@@ -81,6 +95,12 @@ func (l RequirementList) IsValid() error {
 	if nbHostname > 1 {
 		return WithStack(ErrInvalidJobRequirementDuplicateHostname)
 	}
+	if nbOSArch > 1 {
+		return WithStack(ErrInvalidJobRequirementDuplicateOSArch)
+	}
+	if nbIsolation > 1 {
+		return NewErrorFrom(ErrInvalidJobRequirement, "only one isolation requirement is allowed")
+	}
 
 	return nil
 }
@@ -97,6 +117,7 @@ var (
 		MemoryRequirement,
 		VolumeRequirement,
 		OSArchRequirement,
+		IsolationRequirement,
 	}
 
 	// OSArchRequirementValues comes from go tool dist list
@@ -110,7 +131,7 @@ var (
 		//{"darwin/386", OSArchRequirement, "darwin/386"},
 		{Name: "darwin/amd64", Type: OSArchRequirement, Value: "darwin/amd64"},
 		//{"darwin/arm", OSArchRequirement, "darwin/arm"},
-		//{"darwin/arm64", OSArchRequirement, "darwin/arm64"},
+		{Name: "darwin/arm64", Type: OSArchRequirement, Value: "darwin/arm64"},
 		//{"dragonfly/amd64", OSArchRequirement, "dragonfly/amd64"},
 		{Name: "freebsd/386", Type: OSArchRequirement, Value: "freebsd/386"},
 		{Name: "freebsd/amd64", Type: OSArchRequirement, Value: "freebsd/amd64"},
@@ -142,6 +163,38 @@ var (
 	}
 )
 
+// IsValidOSArch returns true if given value is a supported os-architecture requirement value.
+func IsValidOSArch(value string) bool {
+	for i := range OSArchRequirementValues {
+		if OSArchRequirementValues[i].Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// IsolationPaths returns the tool paths of an isolation requirement value.
+func IsolationPaths(value string) []string {
+	var paths []string
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, filepath.Clean(p))
+		}
+	}
+	return paths
+}
+
+// Isolation returns the tool paths of the isolation requirement of the list, and false if there is no isolation
+// requirement.
+func (l RequirementList) Isolation() ([]string, bool) {
+	for i := range l {
+		if l[i].Type == IsolationRequirement {
+			return IsolationPaths(l[i].Value), true
+		}
+	}
+	return nil, false
+}
+
 // Requirement can be :
 // - a binary "which /usr/bin/docker"
 // - a network access "telnet google.com 443"
@@ -2,6 +2,8 @@ package sdk
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRequirementListDeduplicate(t *testing.T) {
@@ -100,3 +102,41 @@ func TestRequirementListDeduplicate(t *testing.T) {
 		})
 	}
 }
+
+func TestRequirementListIsValidOSArch(t *testing.T) {
+	l := RequirementList{{Name: "linux/arm64", Type: OSArchRequirement, Value: "linux/arm64"}}
+	if err := l.IsValid(); err != nil {
+		t.Errorf("RequirementList.IsValid() error = %v", err)
+	}
+
+	l = append(l, Requirement{Name: "darwin/arm64", Type: OSArchRequirement, Value: "darwin/arm64"})
+	if err := l.IsValid(); !ErrorIs(err, ErrInvalidJobRequirementDuplicateOSArch) {
+		t.Errorf("RequirementList.IsValid() error = %v, want %v", err, ErrInvalidJobRequirementDuplicateOSArch)
+	}
+
+	if !IsValidOSArch("windows/amd64") || IsValidOSArch("linux/unknown") {
+		t.Errorf("IsValidOSArch() returns wrong values")
+	}
+}
+
+func TestRequirementListIsolation(t *testing.T) {
+	paths, ok := RequirementList{{Name: "bash", Type: BinaryRequirement, Value: "bash"}}.Isolation()
+	assert.False(t, ok)
+	assert.Nil(t, paths)
+
+	l := RequirementList{
+		{Name: "bash", Type: BinaryRequirement, Value: "bash"},
+		{Name: "isolation", Type: IsolationRequirement, Value: " /opt/go/, /usr/local/node ,"},
+	}
+	paths, ok = l.Isolation()
+	assert.True(t, ok)
+	assert.Equal(t, []string{"/opt/go", "/usr/local/node"}, paths)
+	assert.NoError(t, l.IsValid())
+
+	paths, ok = RequirementList{{Name: "isolation", Type: IsolationRequirement}}.Isolation()
+	assert.True(t, ok)
+	assert.Nil(t, paths)
+
+	l = RequirementList{{Name: "isolation", Type: IsolationRequirement, Value: "/opt/go,tools"}}
+	assert.Error(t, l.IsValid())
+}
@@ -11,4 +11,10 @@ type Result struct {
 	RemoteTime   time.Time  `json:"remoteTime,omitempty"`
 	Duration     string     `json:"duration,omitempty"`
 	NewVariables []Variable `json:"new_variables,omitempty"`
+	// InfraError is set if the job failed because of the infrastructure, the job is then requeued
+	InfraError string `json:"infra_error,omitempty"`
+	// SecretUsages are the secrets and keys read by the job
+	SecretUsages []WorkflowRunSecretUsage `json:"secret_usages,omitempty"`
+	// Fingerprint is the environment in which the job was run
+	Fingerprint *JobFingerprint `json:"fingerprint,omitempty"`
 }
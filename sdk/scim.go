@@ -0,0 +1,386 @@
+package sdk
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SCIM 2.0 schemas used by the provisioning endpoints.
+const (
+	SCIMSchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMSchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMSchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMSchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+)
+
+// SCIM resource types.
+const (
+	SCIMResourceTypeUser  = "User"
+	SCIMResourceTypeGroup = "Group"
+)
+
+// SCIMMaxCount is the maximum number of resources returned in a page of a SCIM list.
+const SCIMMaxCount = 100
+
+// Operations stored in the audit trail of the SCIM provisioning.
+const (
+	SCIMAuditOperationCreate       = "create"
+	SCIMAuditOperationAdopt        = "adopt"
+	SCIMAuditOperationConflict     = "conflict"
+	SCIMAuditOperationUpdate       = "update"
+	SCIMAuditOperationDeactivate   = "deactivate"
+	SCIMAuditOperationReactivate   = "reactivate"
+	SCIMAuditOperationDelete       = "delete"
+	SCIMAuditOperationMemberAdd    = "member_add"
+	SCIMAuditOperationMemberRemove = "member_remove"
+)
+
+// SCIMMeta contains the metadata of a SCIM resource.
+type SCIMMeta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// SCIMName is the name of a SCIM user.
+type SCIMName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// SCIMEmail is an email of a SCIM user.
+type SCIMEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// SCIMMember is a member of a SCIM group, or a group of a SCIM user.
+type SCIMMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// SCIMUser is a CDS user provisioned by an identity provider. Its id is the id of the CDS user.
+type SCIMUser struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	UserName    string       `json:"userName"`
+	Name        SCIMName     `json:"name"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Emails      []SCIMEmail  `json:"emails,omitempty"`
+	Active      *bool        `json:"active,omitempty"`
+	Groups      []SCIMMember `json:"groups,omitempty"`
+	Meta        SCIMMeta     `json:"meta"`
+}
+
+// IsActive returns true if the user is active, users are active by default.
+func (u SCIMUser) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// Fullname returns the full name of the user from its display name or its name.
+func (u SCIMUser) Fullname() string {
+	switch {
+	case u.DisplayName != "":
+		return u.DisplayName
+	case u.Name.Formatted != "":
+		return u.Name.Formatted
+	case u.Name.GivenName != "" || u.Name.FamilyName != "":
+		return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+	}
+	return u.UserName
+}
+
+// PrimaryEmail returns the primary email of the user, or its first email if none is primary.
+func (u SCIMUser) PrimaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// IsValid returns an error if the user can't be provisioned in CDS.
+func (u SCIMUser) IsValid() error {
+	if u.UserName == "" || u.UserName == "me" {
+		return NewErrorFrom(ErrWrongRequest, "invalid given userName")
+	}
+	if !IsValidEmail(u.PrimaryEmail()) {
+		return NewErrorFrom(ErrWrongRequest, "invalid given email for user %s", u.UserName)
+	}
+	return nil
+}
+
+// ApplyPatch applies the operations of a SCIM patch request on the user. Attributes that are not stored by CDS are
+// ignored.
+func (u *SCIMUser) ApplyPatch(ops []SCIMPatchOperation) error {
+	for _, op := range ops {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+		case "remove":
+			if strings.ToLower(op.Path) == "externalid" {
+				u.ExternalID = ""
+			}
+			continue
+		default:
+			return NewErrorFrom(ErrWrongRequest, "invalid patch operation %s", op.Op)
+		}
+
+		if op.Path != "" {
+			if err := u.setAttribute(op.Path, op.Value); err != nil {
+				return err
+			}
+			continue
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return NewErrorFrom(ErrWrongRequest, "invalid patch value: %v", err)
+		}
+		for k, v := range values {
+			if err := u.setAttribute(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (u *SCIMUser) setAttribute(path string, value json.RawMessage) error {
+	var err error
+	switch strings.ToLower(path) {
+	case "active":
+		var active bool
+		active, err = scimBool(value)
+		u.Active = &active
+	case "username":
+		err = json.Unmarshal(value, &u.UserName)
+	case "externalid":
+		err = json.Unmarshal(value, &u.ExternalID)
+	case "displayname":
+		err = json.Unmarshal(value, &u.DisplayName)
+	case "name":
+		err = json.Unmarshal(value, &u.Name)
+	case "name.formatted":
+		err = json.Unmarshal(value, &u.Name.Formatted)
+	case "name.givenname":
+		err = json.Unmarshal(value, &u.Name.GivenName)
+	case "name.familyname":
+		err = json.Unmarshal(value, &u.Name.FamilyName)
+	case "emails":
+		err = json.Unmarshal(value, &u.Emails)
+	case `emails[type eq "work"].value`, "emails[primary eq true].value":
+		var email string
+		if err = json.Unmarshal(value, &email); err == nil {
+			u.Emails = []SCIMEmail{{Value: email, Type: "work", Primary: true}}
+		}
+	}
+	if err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid value for attribute %s: %v", path, err)
+	}
+	return nil
+}
+
+// scimBool parses a SCIM boolean, some identity providers send booleans as strings.
+func scimBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(s)
+}
+
+// SCIMGroup is a CDS group provisioned by an identity provider. Its id is the id of the CDS group.
+type SCIMGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []SCIMMember `json:"members,omitempty"`
+	Meta        SCIMMeta     `json:"meta"`
+}
+
+// GroupName returns the name of the CDS group for the display name of the SCIM group, the characters that are not
+// allowed in a group name are replaced by dashes.
+func (g SCIMGroup) GroupName() string {
+	return scimInvalidNameChars.ReplaceAllString(strings.TrimSpace(g.DisplayName), "-")
+}
+
+var scimInvalidNameChars = regexp.MustCompile("[^a-zA-Z0-9._-]")
+
+var scimMemberPathRegexp = regexp.MustCompile(`(?i)^members\[value eq "([^"]+)"\]$`)
+
+// ApplyPatch applies the operations of a SCIM patch request on the group.
+func (g *SCIMGroup) ApplyPatch(ops []SCIMPatchOperation) error {
+	for _, op := range ops {
+		path := strings.ToLower(op.Path)
+		if m := scimMemberPathRegexp.FindStringSubmatch(op.Path); m != nil {
+			if strings.ToLower(op.Op) != "remove" {
+				return NewErrorFrom(ErrWrongRequest, "invalid patch operation %s for path %s", op.Op, op.Path)
+			}
+			g.removeMembers([]SCIMMember{{Value: m[1]}})
+			continue
+		}
+
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+			if path == "" {
+				var values map[string]json.RawMessage
+				if err := json.Unmarshal(op.Value, &values); err != nil {
+					return NewErrorFrom(ErrWrongRequest, "invalid patch value: %v", err)
+				}
+				for k, v := range values {
+					if err := g.setAttribute(strings.ToLower(op.Op), strings.ToLower(k), v); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err := g.setAttribute(strings.ToLower(op.Op), path, op.Value); err != nil {
+				return err
+			}
+		case "remove":
+			switch path {
+			case "members":
+				if len(op.Value) == 0 {
+					g.Members = nil
+					continue
+				}
+				var members []SCIMMember
+				if err := json.Unmarshal(op.Value, &members); err != nil {
+					return NewErrorFrom(ErrWrongRequest, "invalid members: %v", err)
+				}
+				g.removeMembers(members)
+			case "externalid":
+				g.ExternalID = ""
+			}
+		default:
+			return NewErrorFrom(ErrWrongRequest, "invalid patch operation %s", op.Op)
+		}
+	}
+	return nil
+}
+
+func (g *SCIMGroup) setAttribute(op, path string, value json.RawMessage) error {
+	var err error
+	switch path {
+	case "displayname":
+		err = json.Unmarshal(value, &g.DisplayName)
+	case "externalid":
+		err = json.Unmarshal(value, &g.ExternalID)
+	case "members":
+		var members []SCIMMember
+		if err = json.Unmarshal(value, &members); err == nil {
+			if op == "replace" {
+				g.Members = nil
+			}
+			g.addMembers(members)
+		}
+	}
+	if err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid value for attribute %s: %v", path, err)
+	}
+	return nil
+}
+
+func (g *SCIMGroup) addMembers(members []SCIMMember) {
+	for _, m := range members {
+		if !g.HasMember(m.Value) {
+			g.Members = append(g.Members, m)
+		}
+	}
+}
+
+func (g *SCIMGroup) removeMembers(members []SCIMMember) {
+	filtered := g.Members[:0]
+	for _, existing := range g.Members {
+		var removed bool
+		for _, m := range members {
+			if m.Value == existing.Value {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			filtered = append(filtered, existing)
+		}
+	}
+	g.Members = filtered
+}
+
+// HasMember returns true if the user for given id is a member of the group.
+func (g SCIMGroup) HasMember(userID string) bool {
+	for _, m := range g.Members {
+		if m.Value == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// SCIMListResponse is a page of SCIM resources.
+type SCIMListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// SCIMPatchOp is the body of a SCIM patch request.
+type SCIMPatchOp struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMPatchOperation is an operation of a SCIM patch request.
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// SCIMFilter is a SCIM equality filter on an attribute, the only kind of filter sent by identity providers to
+// look for existing resources.
+type SCIMFilter struct {
+	Attribute string
+	Value     string
+}
+
+var scimFilterRegexp = regexp.MustCompile(`(?i)^\s*([a-z.]+)\s+eq\s+"([^"]*)"\s*$`)
+
+// ParseSCIMFilter parses a filter like userName eq "john.doe", it returns nil for an empty filter.
+func ParseSCIMFilter(s string) (*SCIMFilter, error) {
+	if s == "" {
+		return nil, nil
+	}
+	m := scimFilterRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "unsupported filter %s, only equality filters are supported", s)
+	}
+	return &SCIMFilter{Attribute: strings.ToLower(m[1]), Value: m[2]}, nil
+}
+
+// SCIMAudit is an entry of the audit trail of the SCIM provisioning.
+type SCIMAudit struct {
+	ID           int64     `json:"id" db:"id" cli:"id"`
+	Created      time.Time `json:"created" db:"created" cli:"created"`
+	Operation    string    `json:"operation" db:"operation" cli:"operation"`
+	ResourceType string    `json:"resource_type" db:"resource_type" cli:"resource_type"`
+	ResourceID   string    `json:"resource_id" db:"resource_id" cli:"resource_id"`
+	ResourceName string    `json:"resource_name" db:"resource_name" cli:"resource_name"`
+	Detail       string    `json:"detail" db:"detail" cli:"detail"`
+}
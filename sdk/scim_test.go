@@ -0,0 +1,72 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSCIMFilter(t *testing.T) {
+	f, err := ParseSCIMFilter("")
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	f, err = ParseSCIMFilter(`userName eq "john.doe@example.com"`)
+	require.NoError(t, err)
+	assert.Equal(t, &SCIMFilter{Attribute: "username", Value: "john.doe@example.com"}, f)
+
+	f, err = ParseSCIMFilter(`displayName EQ "Engineering Team"`)
+	require.NoError(t, err)
+	assert.Equal(t, &SCIMFilter{Attribute: "displayname", Value: "Engineering Team"}, f)
+
+	_, err = ParseSCIMFilter(`userName sw "john"`)
+	assert.Error(t, err)
+}
+
+func TestSCIMUserApplyPatch(t *testing.T) {
+	u := SCIMUser{
+		UserName: "john.doe",
+		Name:     SCIMName{GivenName: "John", FamilyName: "Doe"},
+		Emails:   []SCIMEmail{{Value: "john.doe@example.com", Primary: true}},
+	}
+	assert.True(t, u.IsActive())
+	assert.Equal(t, "John Doe", u.Fullname())
+	assert.NoError(t, u.IsValid())
+
+	var ops []SCIMPatchOperation
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"op": "Replace", "path": "active", "value": "False"},
+		{"op": "replace", "value": {"displayName": "Johnny Doe", "title": "Engineer"}},
+		{"op": "add", "path": "emails[type eq \"work\"].value", "value": "johnny@example.com"}
+	]`), &ops))
+	require.NoError(t, u.ApplyPatch(ops))
+
+	assert.False(t, u.IsActive())
+	assert.Equal(t, "Johnny Doe", u.Fullname())
+	assert.Equal(t, "johnny@example.com", u.PrimaryEmail())
+
+	assert.Error(t, u.ApplyPatch([]SCIMPatchOperation{{Op: "move", Path: "active"}}))
+}
+
+func TestSCIMGroupApplyPatch(t *testing.T) {
+	g := SCIMGroup{DisplayName: "Engineering Team", Members: []SCIMMember{{Value: "u1"}, {Value: "u2"}}}
+	assert.Equal(t, "Engineering-Team", g.GroupName())
+
+	var ops []SCIMPatchOperation
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"op": "add", "path": "members", "value": [{"value": "u2"}, {"value": "u3"}]},
+		{"op": "remove", "path": "members[value eq \"u1\"]"},
+		{"op": "replace", "value": {"displayName": "Engineering"}}
+	]`), &ops))
+	require.NoError(t, g.ApplyPatch(ops))
+	assert.Equal(t, []SCIMMember{{Value: "u2"}, {Value: "u3"}}, g.Members)
+	assert.Equal(t, "Engineering", g.DisplayName)
+
+	require.NoError(t, g.ApplyPatch([]SCIMPatchOperation{{Op: "remove", Path: "members", Value: json.RawMessage(`[{"value": "u3"}]`)}}))
+	assert.Equal(t, []SCIMMember{{Value: "u2"}}, g.Members)
+
+	require.NoError(t, g.ApplyPatch([]SCIMPatchOperation{{Op: "replace", Path: "members", Value: json.RawMessage(`[{"value": "u4"}]`)}}))
+	assert.Equal(t, []SCIMMember{{Value: "u4"}}, g.Members)
+}
@@ -0,0 +1,147 @@
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// Levels of the bump of a semantic version.
+const (
+	SemverBumpMajor = "major"
+	SemverBumpMinor = "minor"
+	SemverBumpPatch = "patch"
+	SemverBumpNone  = "none"
+)
+
+var (
+	semverBumpOrder = map[string]int{
+		SemverBumpNone:  0,
+		SemverBumpPatch: 1,
+		SemverBumpMinor: 2,
+		SemverBumpMajor: 3,
+	}
+	// Header of a conventional commit: type(scope)!: description
+	conventionalCommitHeader = regexp.MustCompile(`^([a-zA-Z]+)(\([^)]*\))?(!)?: \S`)
+	// Footer of a conventional commit that describes a breaking change
+	conventionalCommitBreakingChange = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+)
+
+// SemverRules gives the bump of the version for the types of conventional commits (https://www.conventionalcommits.org).
+// A breaking change always bumps the major version. The default bump is used for the commits that don't match any
+// type.
+type SemverRules struct {
+	MajorTypes  []string `json:"major_types,omitempty"`
+	MinorTypes  []string `json:"minor_types,omitempty"`
+	PatchTypes  []string `json:"patch_types,omitempty"`
+	DefaultBump string   `json:"default_bump,omitempty"`
+}
+
+// DefaultSemverRules returns the rules of the conventional commits specification: feat bumps the minor version, fix
+// and perf bump the patch version, other commits don't bump the version.
+func DefaultSemverRules() SemverRules {
+	return SemverRules{
+		MinorTypes:  []string{"feat"},
+		PatchTypes:  []string{"fix", "perf"},
+		DefaultBump: SemverBumpNone,
+	}
+}
+
+// IsValid returns an error if the default bump is not a valid level.
+func (r SemverRules) IsValid() error {
+	if _, ok := semverBumpOrder[r.DefaultBump]; !ok {
+		return NewErrorFrom(ErrWrongRequest, "invalid default bump %q, it must be one of: major, minor, patch, none", r.DefaultBump)
+	}
+	return nil
+}
+
+// CommitBump returns the bump of the version required by given commit message.
+func (r SemverRules) CommitBump(message string) string {
+	header := strings.SplitN(strings.TrimSpace(message), "\n", 2)[0]
+	m := conventionalCommitHeader.FindStringSubmatch(header)
+	if m == nil {
+		return r.DefaultBump
+	}
+	if m[3] == "!" || conventionalCommitBreakingChange.MatchString(message) {
+		return SemverBumpMajor
+	}
+	commitType := strings.ToLower(m[1])
+	for _, rule := range []struct {
+		types []string
+		bump  string
+	}{
+		{r.MajorTypes, SemverBumpMajor},
+		{r.MinorTypes, SemverBumpMinor},
+		{r.PatchTypes, SemverBumpPatch},
+	} {
+		for _, t := range rule.types {
+			if strings.ToLower(t) == commitType {
+				return rule.bump
+			}
+		}
+	}
+	return r.DefaultBump
+}
+
+// Bump returns the highest bump required by given commit messages.
+func (r SemverRules) Bump(messages []string) string {
+	bump := SemverBumpNone
+	for _, m := range messages {
+		if b := r.CommitBump(m); semverBumpOrder[b] > semverBumpOrder[bump] {
+			bump = b
+		}
+	}
+	return bump
+}
+
+// LatestSemverTag returns the highest released version in given tags that starts with the prefix. Tags that are not
+// semver compatible and prereleases are ignored. The tag is empty if no version was found.
+func LatestSemverTag(tags []string, prefix string) (string, *semver.Version) {
+	var latestTag string
+	var latest *semver.Version
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t == "" || !strings.HasPrefix(t, prefix) {
+			continue
+		}
+		v, err := semver.Parse(strings.TrimPrefix(t, prefix))
+		if err != nil || len(v.Pre) > 0 {
+			continue
+		}
+		if latest == nil || v.GT(*latest) {
+			latestTag, latest = t, &v
+		}
+	}
+	return latestTag, latest
+}
+
+// NextSemver returns the version following the previous one for given bump. If there is no previous version, the
+// initial version is returned whatever the bump.
+func NextSemver(previous *semver.Version, bump string, initial string) (semver.Version, error) {
+	if previous == nil {
+		v, err := semver.Parse(initial)
+		if err != nil {
+			return v, NewErrorFrom(ErrWrongRequest, "initial version %q is not semver compatible", initial)
+		}
+		return v, nil
+	}
+
+	next := semver.Version{Major: previous.Major, Minor: previous.Minor, Patch: previous.Patch}
+	switch bump {
+	case SemverBumpMajor:
+		next.Major++
+		next.Minor = 0
+		next.Patch = 0
+	case SemverBumpMinor:
+		next.Minor++
+		next.Patch = 0
+	case SemverBumpPatch:
+		next.Patch++
+	case SemverBumpNone:
+	default:
+		return next, WithStack(fmt.Errorf("invalid bump %q", bump))
+	}
+	return next, nil
+}
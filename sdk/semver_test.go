@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemverRulesCommitBump(t *testing.T) {
+	rules := DefaultSemverRules()
+	assert.Equal(t, SemverBumpMinor, rules.CommitBump("feat: add semver action"))
+	assert.Equal(t, SemverBumpMinor, rules.CommitBump("Feat(worker): add semver action\n\nsome details"))
+	assert.Equal(t, SemverBumpPatch, rules.CommitBump("fix(api): check nil pointer"))
+	assert.Equal(t, SemverBumpMajor, rules.CommitBump("refactor!: remove old routes"))
+	assert.Equal(t, SemverBumpMajor, rules.CommitBump("fix: rename parameter\n\nBREAKING CHANGE: the parameter foo is now bar"))
+	assert.Equal(t, SemverBumpNone, rules.CommitBump("chore: update dependencies"))
+	assert.Equal(t, SemverBumpNone, rules.CommitBump("Merge branch 'master'"))
+	// BREAKING CHANGE is only a footer of a conventional commit
+	assert.Equal(t, SemverBumpNone, rules.CommitBump("update doc\n\nBREAKING CHANGE: nothing"))
+
+	rules.MajorTypes = []string{"release"}
+	rules.DefaultBump = SemverBumpPatch
+	assert.Equal(t, SemverBumpMajor, rules.CommitBump("release: v2"))
+	assert.Equal(t, SemverBumpPatch, rules.CommitBump("Merge branch 'master'"))
+
+	assert.Equal(t, SemverBumpMinor, DefaultSemverRules().Bump([]string{"fix: a", "feat: b", "chore: c"}))
+	assert.Equal(t, SemverBumpNone, DefaultSemverRules().Bump(nil))
+
+	assert.NoError(t, rules.IsValid())
+	rules.DefaultBump = "huge"
+	assert.Error(t, rules.IsValid())
+}
+
+func TestLatestSemverTag(t *testing.T) {
+	tags := []string{"v1.2.0", "v1.10.0", "v2.0.0-rc.1", "1.11.0", "vfoo", "v1.9.3"}
+	tag, v := LatestSemverTag(tags, "v")
+	assert.Equal(t, "v1.10.0", tag)
+	require.NotNil(t, v)
+	assert.Equal(t, "1.10.0", v.String())
+
+	tag, v = LatestSemverTag(tags, "")
+	assert.Equal(t, "1.11.0", tag)
+	require.NotNil(t, v)
+
+	tag, v = LatestSemverTag(tags, "release-")
+	assert.Equal(t, "", tag)
+	assert.Nil(t, v)
+}
+
+func TestNextSemver(t *testing.T) {
+	previous := semver.MustParse("1.2.3")
+	for bump, expected := range map[string]string{
+		SemverBumpMajor: "2.0.0",
+		SemverBumpMinor: "1.3.0",
+		SemverBumpPatch: "1.2.4",
+		SemverBumpNone:  "1.2.3",
+	} {
+		v, err := NextSemver(&previous, bump, "0.1.0")
+		require.NoError(t, err)
+		assert.Equal(t, expected, v.String(), bump)
+	}
+
+	v, err := NextSemver(nil, SemverBumpMajor, "0.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "0.1.0", v.String())
+
+	_, err = NextSemver(nil, SemverBumpMajor, "first")
+	assert.Error(t, err)
+	_, err = NextSemver(&previous, "huge", "0.1.0")
+	assert.Error(t, err)
+}
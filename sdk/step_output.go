@@ -0,0 +1,25 @@
+package sdk
+
+import (
+	"regexp"
+)
+
+// StepOutputPrefix is the prefix of the variables exported as outputs by a step, an output is available in the
+// following steps and jobs of the node run and in the downstream node runs as {{.outputs.stepname.varname}}.
+const StepOutputPrefix = "outputs."
+
+var stepOutputNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// StepOutputName returns the name of the variable of an output exported by given step.
+func StepOutputName(stepName, name string) (string, error) {
+	if stepName == "" {
+		return "", NewErrorFrom(ErrWrongRequest, "the step should have a name to export outputs")
+	}
+	if !stepOutputNamePattern.MatchString(stepName) {
+		return "", NewErrorFrom(ErrWrongRequest, "invalid step name %q to export outputs, it should match %s", stepName, stepOutputNamePattern)
+	}
+	if !stepOutputNamePattern.MatchString(name) {
+		return "", NewErrorFrom(ErrWrongRequest, "invalid output name %q, it should match %s", name, stepOutputNamePattern)
+	}
+	return StepOutputPrefix + stepName + "." + name, nil
+}
@@ -0,0 +1,21 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepOutputName(t *testing.T) {
+	name, err := StepOutputName("build", "version")
+	require.NoError(t, err)
+	assert.Equal(t, "outputs.build.version", name)
+
+	_, err = StepOutputName("", "version")
+	assert.True(t, ErrorIs(err, ErrWrongRequest))
+	_, err = StepOutputName("my.step", "version")
+	assert.True(t, ErrorIs(err, ErrWrongRequest))
+	_, err = StepOutputName("build", "my version")
+	assert.True(t, ErrorIs(err, ErrWrongRequest))
+}
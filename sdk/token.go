@@ -209,6 +209,104 @@ func (s AuthConsumerScopeDetails) Value() (driver.Value, error) {
 	return j, WrapError(err, "cannot marshal AuthConsumerScopeDetails")
 }
 
+// AuthConsumerProjectVerb alias type for string.
+type AuthConsumerProjectVerb string
+
+// Available auth consumer project verbs.
+const (
+	AuthConsumerProjectVerbRunWorkflow       AuthConsumerProjectVerb = "RunWorkflow"
+	AuthConsumerProjectVerbReadRun           AuthConsumerProjectVerb = "ReadRun"
+	AuthConsumerProjectVerbManageIntegration AuthConsumerProjectVerb = "ManageIntegration"
+)
+
+// AuthConsumerProjectVerbs list.
+var AuthConsumerProjectVerbs = []AuthConsumerProjectVerb{
+	AuthConsumerProjectVerbRunWorkflow,
+	AuthConsumerProjectVerbReadRun,
+	AuthConsumerProjectVerbManageIntegration,
+}
+
+// IsValid returns validity for project verb.
+func (v AuthConsumerProjectVerb) IsValid() bool {
+	for i := range AuthConsumerProjectVerbs {
+		if AuthConsumerProjectVerbs[i] == v {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthConsumerProjectScope restricts a consumer to given verbs on a project.
+type AuthConsumerProjectScope struct {
+	ProjectKey string                    `json:"project_key"`
+	Verbs      []AuthConsumerProjectVerb `json:"verbs"`
+}
+
+// AuthConsumerProjectScopes type used for database json storage.
+type AuthConsumerProjectScopes []AuthConsumerProjectScope
+
+// IsValid returns an error if current project scopes are invalids.
+func (s AuthConsumerProjectScopes) IsValid() error {
+	mProject := map[string]struct{}{}
+	for _, ps := range s {
+		if ps.ProjectKey == "" {
+			return NewErrorFrom(ErrWrongRequest, "missing project key in given project scopes")
+		}
+		if _, ok := mProject[ps.ProjectKey]; ok {
+			return NewErrorFrom(ErrWrongRequest, "duplicated project %s in given project scopes", ps.ProjectKey)
+		}
+		mProject[ps.ProjectKey] = struct{}{}
+
+		if len(ps.Verbs) == 0 {
+			return NewErrorFrom(ErrWrongRequest, "at least one verb should be given for project %s", ps.ProjectKey)
+		}
+		mVerb := map[AuthConsumerProjectVerb]struct{}{}
+		for _, v := range ps.Verbs {
+			if !v.IsValid() {
+				return NewErrorFrom(ErrWrongRequest, "invalid verb %s for project %s in given project scopes", v, ps.ProjectKey)
+			}
+			if _, ok := mVerb[v]; ok {
+				return NewErrorFrom(ErrWrongRequest, "duplicated verb %s for project %s in given project scopes", v, ps.ProjectKey)
+			}
+			mVerb[v] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// IsAllowed returns true if given verb is allowed on given project.
+func (s AuthConsumerProjectScopes) IsAllowed(projectKey string, verb AuthConsumerProjectVerb) bool {
+	for i := range s {
+		if s[i].ProjectKey != projectKey {
+			continue
+		}
+		for j := range s[i].Verbs {
+			if s[i].Verbs[j] == verb {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Scan project scope slice.
+func (s *AuthConsumerProjectScopes) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, s), "cannot unmarshal AuthConsumerProjectScopes")
+}
+
+// Value returns driver.Value from project scope slice.
+func (s AuthConsumerProjectScopes) Value() (driver.Value, error) {
+	j, err := json.Marshal(s)
+	return j, WrapError(err, "cannot marshal AuthConsumerProjectScopes")
+}
+
 // AuthConsumerScopeSlice type used for database json storage.
 type AuthConsumerScopeSlice []AuthConsumerScope
 
@@ -377,20 +475,21 @@ type AuthConsumers []AuthConsumer
 
 // AuthConsumer issues session linked to an authentified user.
 type AuthConsumer struct {
-	ID                 string                   `json:"id" cli:"id,key" db:"id"`
-	Name               string                   `json:"name" cli:"name" db:"name"`
-	Description        string                   `json:"description" cli:"description" db:"description"`
-	ParentID           *string                  `json:"parent_id,omitempty" db:"parent_id"`
-	AuthentifiedUserID string                   `json:"user_id,omitempty" db:"user_id"`
-	Type               AuthConsumerType         `json:"type" cli:"type" db:"type"`
-	Data               AuthConsumerData         `json:"-" db:"data"` // NEVER returns auth consumer data in json, TODO this fields should be visible only in auth package
-	Created            time.Time                `json:"created" cli:"created" db:"created"`
-	GroupIDs           Int64Slice               `json:"group_ids,omitempty" cli:"group_ids" db:"group_ids"`
-	InvalidGroupIDs    Int64Slice               `json:"invalid_group_ids,omitempty" db:"invalid_group_ids"`
-	ScopeDetails       AuthConsumerScopeDetails `json:"scope_details,omitempty" cli:"scope_details" db:"scope_details"`
-	IssuedAt           time.Time                `json:"issued_at" cli:"issued_at" db:"issued_at"`
-	Disabled           bool                     `json:"disabled" cli:"disabled" db:"disabled"`
-	Warnings           AuthConsumerWarnings     `json:"warnings,omitempty" db:"warnings"`
+	ID                 string                    `json:"id" cli:"id,key" db:"id"`
+	Name               string                    `json:"name" cli:"name" db:"name"`
+	Description        string                    `json:"description" cli:"description" db:"description"`
+	ParentID           *string                   `json:"parent_id,omitempty" db:"parent_id"`
+	AuthentifiedUserID string                    `json:"user_id,omitempty" db:"user_id"`
+	Type               AuthConsumerType          `json:"type" cli:"type" db:"type"`
+	Data               AuthConsumerData          `json:"-" db:"data"` // NEVER returns auth consumer data in json, TODO this fields should be visible only in auth package
+	Created            time.Time                 `json:"created" cli:"created" db:"created"`
+	GroupIDs           Int64Slice                `json:"group_ids,omitempty" cli:"group_ids" db:"group_ids"`
+	InvalidGroupIDs    Int64Slice                `json:"invalid_group_ids,omitempty" db:"invalid_group_ids"`
+	ScopeDetails       AuthConsumerScopeDetails  `json:"scope_details,omitempty" cli:"scope_details" db:"scope_details"`
+	ProjectScopes      AuthConsumerProjectScopes `json:"project_scopes,omitempty" cli:"project_scopes" db:"project_scopes"`
+	IssuedAt           time.Time                 `json:"issued_at" cli:"issued_at" db:"issued_at"`
+	Disabled           bool                      `json:"disabled" cli:"disabled" db:"disabled"`
+	Warnings           AuthConsumerWarnings      `json:"warnings,omitempty" db:"warnings"`
 	// aggregates
 	AuthentifiedUser *AuthentifiedUser `json:"user,omitempty" db:"-"`
 	Groups           Groups            `json:"groups,omitempty" db:"-"`
@@ -407,6 +506,9 @@ func (c AuthConsumer) IsValid(scopeDetails AuthConsumerScopeDetails) error {
 	if err := c.ScopeDetails.IsValid(); err != nil {
 		return err
 	}
+	if err := c.ProjectScopes.IsValid(); err != nil {
+		return err
+	}
 
 	mEndpoints := scopeDetails.ToEndpointsMap()
 
@@ -0,0 +1,139 @@
+package sdk
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// A browser token is a short-lived personal access token usable from a browser context, like a browser extension.
+// It is a read only builtin consumer that can only be used from given origins.
+const (
+	AuthConsumerBrowserTokenDefaultDuration = time.Hour
+	AuthConsumerBrowserTokenMaxDuration     = 24 * time.Hour
+	authConsumerDataBrowserOrigins          = "browser_origins"
+)
+
+// AuthConsumerBrowserTokenScopes are the scopes that can be given to a browser token.
+var AuthConsumerBrowserTokenScopes = []AuthConsumerScope{
+	AuthConsumerScopeUser,
+	AuthConsumerScopeProject,
+	AuthConsumerScopeRun,
+}
+
+// AuthConsumerBrowserTokenRequest is the request to create a browser token, the duration is given in seconds.
+type AuthConsumerBrowserTokenRequest struct {
+	Name          string                    `json:"name"`
+	Description   string                    `json:"description"`
+	Origins       []string                  `json:"origins"`
+	Scopes        []AuthConsumerScope       `json:"scopes"`
+	ProjectScopes AuthConsumerProjectScopes `json:"project_scopes,omitempty"`
+	Duration      int64                     `json:"duration,omitempty"`
+}
+
+// IsValid returns an error if the request is not valid.
+func (r AuthConsumerBrowserTokenRequest) IsValid() error {
+	if r.Name == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid given name")
+	}
+	if len(r.Origins) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "at least one origin should be given for a browser token")
+	}
+	for _, o := range r.Origins {
+		if err := IsValidOriginPattern(o); err != nil {
+			return err
+		}
+	}
+	if len(r.Scopes) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "at least one scope should be given for a browser token")
+	}
+	for _, s := range r.Scopes {
+		if !IsInAuthConsumerScopes(s, AuthConsumerBrowserTokenScopes) {
+			return NewErrorFrom(ErrWrongRequest, "scope %s can't be given to a browser token", s)
+		}
+	}
+	if r.Duration < 0 || time.Duration(r.Duration)*time.Second > AuthConsumerBrowserTokenMaxDuration {
+		return NewErrorFrom(ErrWrongRequest, "invalid duration, a browser token is valid at most %s", AuthConsumerBrowserTokenMaxDuration)
+	}
+	return r.ProjectScopes.IsValid()
+}
+
+// GetDuration returns the validity duration of the token.
+func (r AuthConsumerBrowserTokenRequest) GetDuration() time.Duration {
+	if r.Duration == 0 {
+		return AuthConsumerBrowserTokenDefaultDuration
+	}
+	return time.Duration(r.Duration) * time.Second
+}
+
+// AuthConsumerBrowserTokenResponse is the response for a browser token creation, the token is a session JWT to give
+// in the Authorization header.
+type AuthConsumerBrowserTokenResponse struct {
+	Token    string        `json:"token"`
+	ExpireAt time.Time     `json:"expire_at"`
+	Consumer *AuthConsumer `json:"consumer"`
+}
+
+// IsInAuthConsumerScopes returns true if given scope is in the list.
+func IsInAuthConsumerScopes(s AuthConsumerScope, scopes []AuthConsumerScope) bool {
+	for i := range scopes {
+		if scopes[i] == s {
+			return true
+		}
+	}
+	return false
+}
+
+// SetBrowserTokenOrigins marks the consumer as a browser token usable from given origins.
+func (c *AuthConsumer) SetBrowserTokenOrigins(origins []string) {
+	if c.Data == nil {
+		c.Data = AuthConsumerData{}
+	}
+	c.Data[authConsumerDataBrowserOrigins] = strings.Join(origins, ",")
+}
+
+// IsBrowserToken returns true if the consumer is a browser token.
+func (c AuthConsumer) IsBrowserToken() bool {
+	_, ok := c.Data[authConsumerDataBrowserOrigins]
+	return ok
+}
+
+// BrowserTokenAllowsOrigin returns true if the browser token can be used from given origin.
+func (c AuthConsumer) BrowserTokenAllowsOrigin(origin string) bool {
+	for _, pattern := range strings.Split(c.Data[authConsumerDataBrowserOrigins], ",") {
+		if MatchOrigin(pattern, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidOriginPattern returns an error if given origin pattern is not a scheme and a host, the host can start with a
+// "*." wildcard to match all its sub domains.
+func IsValidOriginPattern(pattern string) error {
+	u, err := url.Parse(pattern)
+	if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") || strings.Contains(pattern, ",") {
+		return NewErrorFrom(ErrWrongRequest, "invalid origin %q, it should be like https://example.com or https://*.example.com", pattern)
+	}
+	if strings.Contains(strings.TrimPrefix(u.Host, "*."), "*") {
+		return NewErrorFrom(ErrWrongRequest, "invalid origin %q, only a leading wildcard is allowed in the host", pattern)
+	}
+	return nil
+}
+
+// MatchOrigin returns true if given origin matches given pattern, see IsValidOriginPattern.
+func MatchOrigin(pattern, origin string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+	if pattern == "" || origin == "" {
+		return false
+	}
+	if pattern == "*" || pattern == origin {
+		return true
+	}
+	i := strings.Index(pattern, "://*.")
+	if i < 0 {
+		return false
+	}
+	scheme, domain := pattern[:i+3], pattern[i+4:]
+	return strings.HasPrefix(origin, scheme) && strings.HasSuffix(origin, domain) && len(origin) > len(scheme)+len(domain)
+}
@@ -0,0 +1,60 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchOrigin(t *testing.T) {
+	assert.True(t, MatchOrigin("https://github.com", "https://github.com"))
+	assert.True(t, MatchOrigin("https://github.com/", "https://github.com"))
+	assert.True(t, MatchOrigin("*", "https://github.com"))
+	assert.True(t, MatchOrigin("https://*.example.com", "https://ui.example.com"))
+	assert.False(t, MatchOrigin("https://*.example.com", "https://example.com"))
+	assert.False(t, MatchOrigin("https://*.example.com", "http://ui.example.com"))
+	assert.False(t, MatchOrigin("https://*.example.com", "https://ui.example.com.evil.com"))
+	assert.False(t, MatchOrigin("https://github.com", "https://gist.github.com"))
+	assert.False(t, MatchOrigin("https://github.com", ""))
+}
+
+func TestAuthConsumerBrowserTokenRequestIsValid(t *testing.T) {
+	req := AuthConsumerBrowserTokenRequest{
+		Name:    "extension",
+		Origins: []string{"https://github.com", "https://*.example.com"},
+		Scopes:  []AuthConsumerScope{AuthConsumerScopeRun},
+	}
+	assert.NoError(t, req.IsValid())
+	assert.Equal(t, AuthConsumerBrowserTokenDefaultDuration, req.GetDuration())
+
+	req.Duration = int64((2 * time.Hour).Seconds())
+	assert.NoError(t, req.IsValid())
+	assert.Equal(t, 2*time.Hour, req.GetDuration())
+
+	req.Duration = int64((48 * time.Hour).Seconds())
+	assert.Error(t, req.IsValid())
+	req.Duration = 0
+
+	req.Scopes = []AuthConsumerScope{AuthConsumerScopeAdmin}
+	assert.Error(t, req.IsValid())
+	req.Scopes = []AuthConsumerScope{AuthConsumerScopeRun}
+
+	req.Origins = []string{"github.com"}
+	assert.Error(t, req.IsValid())
+	req.Origins = []string{"https://git*.com"}
+	assert.Error(t, req.IsValid())
+	req.Origins = nil
+	assert.Error(t, req.IsValid())
+}
+
+func TestAuthConsumerBrowserTokenOrigins(t *testing.T) {
+	var c AuthConsumer
+	assert.False(t, c.IsBrowserToken())
+
+	c.SetBrowserTokenOrigins([]string{"https://github.com", "https://*.example.com"})
+	assert.True(t, c.IsBrowserToken())
+	assert.True(t, c.BrowserTokenAllowsOrigin("https://github.com"))
+	assert.True(t, c.BrowserTokenAllowsOrigin("https://ui.example.com"))
+	assert.False(t, c.BrowserTokenAllowsOrigin("https://evil.com"))
+}
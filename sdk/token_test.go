@@ -109,3 +109,67 @@ func TestAuthConsumerScopeDetailsIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthConsumerProjectScopesIsValid(t *testing.T) {
+	cases := []struct {
+		Name          string
+		ProjectScopes sdk.AuthConsumerProjectScopes
+		Error         bool
+	}{
+		{
+			Name: "Unique projects with unique verbs should be valid",
+			ProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbReadRun, sdk.AuthConsumerProjectVerbRunWorkflow}},
+				{ProjectKey: "PROJ2", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbManageIntegration}},
+			},
+			Error: false,
+		},
+		{
+			Name: "Missing project key should generate an error",
+			ProjectScopes: sdk.AuthConsumerProjectScopes{
+				{Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbReadRun}},
+			},
+			Error: true,
+		},
+		{
+			Name: "Duplicate projects should generate an error",
+			ProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbReadRun}},
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbRunWorkflow}},
+			},
+			Error: true,
+		},
+		{
+			Name: "Missing verbs should generate an error",
+			ProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1"},
+			},
+			Error: true,
+		},
+		{
+			Name: "Invalid verb should generate an error",
+			ProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{"DeleteEverything"}},
+			},
+			Error: true,
+		},
+		{
+			Name: "Duplicate verbs should generate an error",
+			ProjectScopes: sdk.AuthConsumerProjectScopes{
+				{ProjectKey: "PROJ1", Verbs: []sdk.AuthConsumerProjectVerb{sdk.AuthConsumerProjectVerbReadRun, sdk.AuthConsumerProjectVerbReadRun}},
+			},
+			Error: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := c.ProjectScopes.IsValid()
+			if c.Error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
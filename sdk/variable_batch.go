@@ -0,0 +1,76 @@
+package sdk
+
+// VariableBatch is a set of changes applied at once on the variables of a project, an application or an environment.
+// All the changes are applied or none of them.
+type VariableBatch struct {
+	Create []Variable `json:"create,omitempty"`
+	Update []Variable `json:"update,omitempty"`
+	Delete []string   `json:"delete,omitempty"`
+}
+
+// IsValid checks that the batch has changes, that the variables have a name and a valid type and that a variable is
+// changed only once.
+func (b VariableBatch) IsValid() error {
+	if len(b.Create) == 0 && len(b.Update) == 0 && len(b.Delete) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "variable batch is empty")
+	}
+	names := make(map[string]struct{}, len(b.Create)+len(b.Update)+len(b.Delete))
+	checkName := func(name string) error {
+		if name == "" {
+			return NewErrorFrom(ErrWrongRequest, "variable name is mandatory")
+		}
+		if _, ok := names[name]; ok {
+			return NewErrorFrom(ErrWrongRequest, "variable %s is changed twice in the batch", name)
+		}
+		names[name] = struct{}{}
+		return nil
+	}
+	for _, vs := range [][]Variable{b.Create, b.Update} {
+		for _, v := range vs {
+			if err := checkName(v.Name); err != nil {
+				return err
+			}
+			if !IsInArray(v.Type, AvailableVariableType) {
+				return NewErrorFrom(ErrWrongRequest, "invalid variable type %s for variable %s", v.Type, v.Name)
+			}
+		}
+	}
+	for _, name := range b.Delete {
+		if err := checkName(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VariableBatchDiff is the result of a variable batch. An updated variable that was not changed is not in the diff.
+type VariableBatchDiff struct {
+	Created []Variable       `json:"created"`
+	Updated []VariableChange `json:"updated"`
+	Deleted []Variable       `json:"deleted"`
+}
+
+// VariableChange is a variable before and after its update.
+type VariableChange struct {
+	Before Variable `json:"before"`
+	After  Variable `json:"after"`
+}
+
+// HidePasswords replaces the value of the secret variables of the diff by a placeholder.
+func (d *VariableBatchDiff) HidePasswords() {
+	hide := func(v *Variable) {
+		if NeedPlaceholder(v.Type) {
+			v.Value = PasswordPlaceholder
+		}
+	}
+	for i := range d.Created {
+		hide(&d.Created[i])
+	}
+	for i := range d.Updated {
+		hide(&d.Updated[i].Before)
+		hide(&d.Updated[i].After)
+	}
+	for i := range d.Deleted {
+		hide(&d.Deleted[i])
+	}
+}
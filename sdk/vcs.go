@@ -57,6 +57,7 @@ type VCSAuthorizedClient interface {
 	Commits(ctx context.Context, repo, branch, since, until string) ([]VCSCommit, error)
 	Commit(ctx context.Context, repo, hash string) (VCSCommit, error)
 	CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]VCSCommit, error)
+	ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error)
 
 	// PullRequests
 	PullRequest(context.Context, string, int) (VCSPullRequest, error)
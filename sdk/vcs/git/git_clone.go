@@ -21,6 +21,8 @@ type CloneOpts struct {
 	CheckoutCommit          string
 	NoStrictHostKeyChecking bool
 	ForceGetGitDescribe     bool
+	// Filter makes a partial clone with given object filter, ie. blob:none to fetch the blobs on demand.
+	Filter string
 }
 
 // Clone make a git clone
@@ -73,6 +75,10 @@ func prepareGitCloneCommands(repo, workdirPath, path string, opts *CloneOpts) (s
 			gitcmd.args = append(gitcmd.args, "--depth", fmt.Sprintf("%d", opts.Depth))
 		}
 
+		if opts.Filter != "" {
+			gitcmd.args = append(gitcmd.args, "--filter="+opts.Filter)
+		}
+
 		if opts.Branch != "" || (opts.Tag != "" && opts.Tag != sdk.DefaultGitCloneParameterTagValue) {
 			if opts.Tag != "" && opts.Tag != sdk.DefaultGitCloneParameterTagValue {
 				gitcmd.args = append(gitcmd.args, "--branch", opts.Tag)
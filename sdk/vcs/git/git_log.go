@@ -0,0 +1,69 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// TagsMerged returns the tags reachable from HEAD in given git directory.
+func TagsMerged(dir string) ([]string, error) {
+	out, err := gitRawCommandOutput(dir, "tag", "--merged", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, t := range strings.Split(out, "\n") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags, nil
+}
+
+// CommitMessages returns the messages of the commits reachable from HEAD but not from given revision in given git
+// directory. All the commits reachable from HEAD are returned if the revision is empty.
+func CommitMessages(dir, from string) ([]string, error) {
+	rev := "HEAD"
+	if from != "" {
+		rev = from + "..HEAD"
+	}
+	// Messages are separated by a NUL character because they can contain new lines
+	out, err := gitRawCommandOutput(dir, "log", "--format=%B%x00", rev)
+	if err != nil {
+		return nil, err
+	}
+	var messages []string
+	for _, m := range strings.Split(out, "\x00") {
+		if m = strings.TrimSpace(m); m != "" {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+// IsShallow returns true if given git directory is a shallow clone.
+func IsShallow(dir string) (bool, error) {
+	out, err := gitRawCommandOutput(dir, "rev-parse", "--is-shallow-repository")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) == "true", nil
+}
+
+func gitRawCommandOutput(dir string, args ...string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	stdErr := new(bytes.Buffer)
+	stdOut := new(bytes.Buffer)
+	c := cmd{workdir: dir, cmd: "git", args: args}
+	if err := runGitCommandRaw(cmds{c}, &OutputOpts{Stdout: stdOut, Stderr: stdErr}); err != nil {
+		return "", fmt.Errorf("error while running %s: %v %s", c, err, strings.TrimSpace(stdErr.String()))
+	}
+	return stdOut.String(), nil
+}
@@ -227,6 +227,21 @@ func Test_gitCommand(t *testing.T) {
 				"git reset --hard eb8b87a",
 			},
 		},
+		{
+			name: "Partial clone of public repo over http",
+			args: args{
+				repo: "https://github.com/ovh/cds.git",
+				path: "tmp/Test_gitCommand-4",
+				opts: &CloneOpts{
+					Depth:  1,
+					Filter: "blob:none",
+					Tag:    "0.41.0",
+				},
+			},
+			want: []string{
+				"git clone --depth 1 --filter=blob:none --branch 0.41.0 https://github.com/ovh/cds.git tmp/Test_gitCommand-4",
+			},
+		},
 	}
 	for _, tt := range tests {
 		os.RemoveAll(test.GetTestName(t))
@@ -20,6 +20,29 @@ type Worker struct {
 	Version    string    `json:"version" cli:"version"  db:"version"`
 	OS         string    `json:"os" cli:"os"  db:"os"`
 	Arch       string    `json:"arch" cli:"arch"  db:"arch"`
+	// Drain is set to ask the worker to finish its current job and to stop without taking a new one
+	Drain           bool   `json:"drain" cli:"drain" db:"drain"`
+	ShutdownReason  string `json:"shutdown_reason,omitempty" cli:"shutdown_reason" db:"shutdown_reason"`
+	ShutdownMessage string `json:"shutdown_message,omitempty" cli:"-" db:"shutdown_message"`
+}
+
+// Reasons given by a worker when it stops.
+const (
+	WorkerShutdownReasonJobDone     = "job_done"
+	WorkerShutdownReasonDrained     = "drained"
+	WorkerShutdownReasonInterrupted = "interrupted"
+	WorkerShutdownReasonError       = "error"
+)
+
+// WorkerShutdown is sent by a worker when it unregisters, to let the API and its hatchery know why it stopped.
+type WorkerShutdown struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+// WorkerHeartbeat is returned to a worker when it refreshes its last beat.
+type WorkerHeartbeat struct {
+	Drain bool `json:"drain"`
 }
 
 // WorkerRegistrationForm represents the arguments needed to register a worker
@@ -34,6 +57,7 @@ type WorkerRegistrationForm struct {
 type SpawnErrorForm struct {
 	Error string
 	Logs  []byte
+	Class string
 }
 
 // WorkerArgs is all the args needed to run a worker
@@ -48,6 +72,7 @@ type WorkerArgs struct {
 	WorkflowJobID   int64  `json:"workflow_job_id"`
 	TTL             int    `json:"ttl"`
 	FromWorkerImage bool   `json:"from_worker_image"`
+	AutoUpdate      bool   `json:"auto_update"`
 	//Graylog params
 	GraylogHost       string `json:"graylog_host"`
 	GraylogPort       int    `json:"graylog_port"`
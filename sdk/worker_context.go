@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"strconv"
+	"strings"
+)
+
+// WorkerJobContext is the interpolated context of the job run by a worker, it is returned by the /context handler of
+// the worker so plugins and scripts don't have to parse environment variables. The values of secrets are never given.
+type WorkerJobContext struct {
+	Job         WorkerJobContextJob          `json:"job"`
+	Node        WorkerJobContextNode         `json:"node"`
+	Git         map[string]string            `json:"git,omitempty"`
+	Parameters  map[string]string            `json:"parameters"`
+	Variables   map[string]string            `json:"variables"`
+	Integration *WorkerJobContextIntegration `json:"integration,omitempty"`
+}
+
+// WorkerJobContextJob describes the job run by the worker.
+type WorkerJobContextJob struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Stage     string `json:"stage,omitempty"`
+	Step      string `json:"step,omitempty"`
+	NodeRunID int64  `json:"node_run_id"`
+}
+
+// WorkerJobContextNode describes the workflow node run of the job.
+type WorkerJobContextNode struct {
+	Project     string `json:"project"`
+	Workflow    string `json:"workflow"`
+	RunNumber   int64  `json:"run_number"`
+	SubNumber   int64  `json:"sub_number"`
+	Version     string `json:"version,omitempty"`
+	Name        string `json:"name"`
+	Pipeline    string `json:"pipeline,omitempty"`
+	Application string `json:"application,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+// WorkerJobContextIntegration is the integration of the node with its config, without passwords.
+type WorkerJobContextIntegration struct {
+	Name   string            `json:"name"`
+	Config map[string]string `json:"config"`
+}
+
+// NewWorkerJobContext returns the context of a job from its parameters and secrets. Secrets are given in variables
+// with a placeholder value, parameters that have the name of a secret are removed.
+func NewWorkerJobContext(params []Parameter, secrets []Variable) WorkerJobContext {
+	c := WorkerJobContext{
+		Parameters: make(map[string]string, len(params)),
+		Variables:  make(map[string]string, len(secrets)),
+	}
+
+	isSecret := make(map[string]struct{}, len(secrets))
+	for _, s := range secrets {
+		isSecret[s.Name] = struct{}{}
+		c.Variables[s.Name] = PasswordPlaceholder
+	}
+
+	for _, p := range params {
+		if _, ok := isSecret[p.Name]; ok {
+			continue
+		}
+		c.Parameters[p.Name] = p.Value
+
+		switch {
+		case strings.HasPrefix(p.Name, "git."):
+			if c.Git == nil {
+				c.Git = make(map[string]string)
+			}
+			c.Git[strings.TrimPrefix(p.Name, "git.")] = p.Value
+		case p.Name == "cds.integration":
+			if c.Integration == nil {
+				c.Integration = &WorkerJobContextIntegration{Config: make(map[string]string)}
+			}
+			c.Integration.Name = p.Value
+		case strings.HasPrefix(p.Name, "cds.integration."):
+			if c.Integration == nil {
+				c.Integration = &WorkerJobContextIntegration{Config: make(map[string]string)}
+			}
+			c.Integration.Config[strings.TrimPrefix(p.Name, "cds.integration.")] = p.Value
+		}
+	}
+
+	c.Job.Name = c.Parameters["cds.job"]
+	c.Job.Stage = c.Parameters["cds.stage"]
+	c.Node.Project = c.Parameters["cds.project"]
+	c.Node.Workflow = c.Parameters["cds.workflow"]
+	c.Node.RunNumber, _ = strconv.ParseInt(c.Parameters["cds.run.number"], 10, 64)
+	c.Node.SubNumber, _ = strconv.ParseInt(c.Parameters["cds.run.subnumber"], 10, 64)
+	c.Node.Version = c.Parameters["cds.version"]
+	c.Node.Name = c.Parameters["cds.node"]
+	c.Node.Pipeline = c.Parameters["cds.pipeline"]
+	c.Node.Application = c.Parameters["cds.application"]
+	c.Node.Environment = c.Parameters["cds.environment"]
+
+	return c
+}
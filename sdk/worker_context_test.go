@@ -0,0 +1,45 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWorkerJobContext(t *testing.T) {
+	params := []Parameter{
+		{Name: "cds.project", Value: "PROJ"},
+		{Name: "cds.workflow", Value: "my-workflow"},
+		{Name: "cds.run.number", Value: "12"},
+		{Name: "cds.run.subnumber", Value: "1"},
+		{Name: "cds.node", Value: "deploy-prod"},
+		{Name: "cds.pipeline", Value: "deploy"},
+		{Name: "cds.job", Value: "Deploy"},
+		{Name: "cds.application", Value: "my-app"},
+		{Name: "git.branch", Value: "master"},
+		{Name: "cds.integration", Value: "my-k8s"},
+		{Name: "cds.integration.namespace", Value: "prod"},
+		{Name: "cds.integration.token", Value: "my-secret-token"},
+	}
+	secrets := []Variable{
+		{Name: "cds.integration.token", Value: "my-secret-token", Type: SecretVariable},
+		{Name: "cds.proj.password", Value: "my-password", Type: SecretVariable},
+	}
+
+	c := NewWorkerJobContext(params, secrets)
+	assert.Equal(t, "PROJ", c.Node.Project)
+	assert.Equal(t, int64(12), c.Node.RunNumber)
+	assert.Equal(t, int64(1), c.Node.SubNumber)
+	assert.Equal(t, "deploy-prod", c.Node.Name)
+	assert.Equal(t, "Deploy", c.Job.Name)
+	assert.Equal(t, map[string]string{"branch": "master"}, c.Git)
+	if assert.NotNil(t, c.Integration) {
+		assert.Equal(t, "my-k8s", c.Integration.Name)
+		assert.Equal(t, map[string]string{"namespace": "prod"}, c.Integration.Config)
+	}
+	assert.NotContains(t, c.Parameters, "cds.integration.token")
+	assert.Equal(t, PasswordPlaceholder, c.Variables["cds.integration.token"])
+	assert.Equal(t, PasswordPlaceholder, c.Variables["cds.proj.password"])
+
+	assert.Nil(t, NewWorkerJobContext(params[:4], nil).Integration)
+}
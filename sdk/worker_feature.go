@@ -0,0 +1,145 @@
+package sdk
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// Features of the worker binary that can be used by the steps of a job.
+const (
+	WorkerFeatureAnnotation        = "annotation"
+	WorkerFeatureDockerBuild       = "docker-build"
+	WorkerFeatureIsolation         = "isolation"
+	WorkerFeatureSemver            = "semver"
+	WorkerFeatureStepOutput        = "step-output"
+	WorkerFeatureToolsInstall      = "tools-install"
+	WorkerFeatureWorkspaceSnapshot = "workspace-snapshot"
+)
+
+// WorkerFeatureMinVersions gives for each feature the minimum version of the worker binary that supports it.
+var WorkerFeatureMinVersions = map[string]string{
+	WorkerFeatureAnnotation:        "0.45.0",
+	WorkerFeatureDockerBuild:       "0.45.0",
+	WorkerFeatureIsolation:         "0.45.0",
+	WorkerFeatureSemver:            "0.45.0",
+	WorkerFeatureStepOutput:        "0.45.0",
+	WorkerFeatureToolsInstall:      "0.45.0",
+	WorkerFeatureWorkspaceSnapshot: "0.45.0",
+}
+
+// Features used by builtin actions and by worker commands called from a script.
+var (
+	workerFeatureBuiltinActions = map[string]string{
+		DockerBuildAction:       WorkerFeatureDockerBuild,
+		SemverAction:            WorkerFeatureSemver,
+		WorkspaceSnapshotAction: WorkerFeatureWorkspaceSnapshot,
+		WorkspaceRestoreAction:  WorkerFeatureWorkspaceSnapshot,
+	}
+	workerFeatureCommands = map[string]*regexp.Regexp{
+		WorkerFeatureAnnotation:   regexp.MustCompile(`(^|[\s;&|(])worker\s+annotation\b`),
+		WorkerFeatureStepOutput:   regexp.MustCompile(`(^|[\s;&|(])worker\s+export\s[^\n]*--output\b`),
+		WorkerFeatureToolsInstall: regexp.MustCompile(`(^|[\s;&|(])worker\s+tools\s+install\b`),
+	}
+)
+
+// WorkerFeatures returns the sorted list of worker features used by the action and its children. The scripts are
+// statically analyzed to find the worker commands they call.
+func (a Action) WorkerFeatures() []string {
+	features := make(map[string]struct{})
+	a.workerFeatures(features)
+
+	var res []string
+	for f := range features {
+		res = append(res, f)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (a Action) workerFeatures(features map[string]struct{}) {
+	if _, ok := a.Requirements.Isolation(); ok {
+		features[WorkerFeatureIsolation] = struct{}{}
+	}
+	if a.Type == BuiltinAction {
+		if f, ok := workerFeatureBuiltinActions[a.Name]; ok {
+			features[f] = struct{}{}
+		}
+		if a.Name == ScriptAction {
+			if script := ParameterFind(a.Parameters, "script"); script != nil {
+				for f, r := range workerFeatureCommands {
+					if r.MatchString(script.Value) {
+						features[f] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+	for i := range a.Actions {
+		a.Actions[i].workerFeatures(features)
+	}
+}
+
+// WorkerMinVersion returns the minimum version of the worker binary that supports all given features, or an empty
+// string if any version can be used.
+func WorkerMinVersion(features []string) string {
+	var min semver.Version
+	for _, f := range features {
+		v, err := semver.Parse(WorkerFeatureMinVersions[f])
+		if err == nil && v.GT(min) {
+			min = v
+		}
+	}
+	if min.EQ(semver.Version{}) {
+		return ""
+	}
+	return min.String()
+}
+
+// IsWorkerVersionCompatible returns true if the given worker version is greater than or equal to the minimum version.
+// Snapshot and other non semantic versions are development builds that are considered as compatible.
+func IsWorkerVersionCompatible(version, minVersion string) bool {
+	if minVersion == "" {
+		return true
+	}
+	v, err := semver.Parse(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return true
+	}
+	min, err := semver.Parse(minVersion)
+	if err != nil {
+		return true
+	}
+	return v.GTE(min)
+}
+
+// CheckWorkerVersion returns an error if the given worker version doesn't support one of the features.
+func CheckWorkerVersion(version string, features []string) error {
+	var unsupported []string
+	for _, f := range features {
+		if !IsWorkerVersionCompatible(version, WorkerFeatureMinVersions[f]) {
+			unsupported = append(unsupported, f)
+		}
+	}
+	if len(unsupported) > 0 {
+		return NewErrorFrom(ErrWorkerVersionOutdated, "worker version %s does not support %s, version %s or greater is required",
+			version, strings.Join(unsupported, ", "), WorkerMinVersion(unsupported))
+	}
+	return nil
+}
+
+// IsWorkerUpdateNeeded returns true if the worker binary should be replaced by the one served by the API, i.e. if
+// their versions differ. Snapshot and other non semantic versions are development builds that are never updated.
+func IsWorkerUpdateNeeded(version, apiVersion string) bool {
+	v, err := semver.Parse(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return false
+	}
+	apiV, err := semver.Parse(strings.TrimPrefix(apiVersion, "v"))
+	if err != nil {
+		return false
+	}
+	return !v.EQ(apiV)
+}
@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionWorkerFeatures(t *testing.T) {
+	job := Action{
+		Name: "build",
+		Actions: []Action{
+			{Type: BuiltinAction, Name: ScriptAction, Parameters: []Parameter{{Name: "script", Value: "make\nworker export foo bar"}}},
+			{Type: BuiltinAction, Name: GitCloneAction},
+		},
+	}
+	assert.Nil(t, job.WorkerFeatures())
+	assert.Equal(t, "", WorkerMinVersion(job.WorkerFeatures()))
+
+	job.Actions = append(job.Actions,
+		Action{Type: BuiltinAction, Name: ScriptAction, Parameters: []Parameter{{Name: "script", Value: "make && worker annotation version=$(cat VERSION)\nworker export --output version 1.2.3"}}},
+		Action{Type: DefaultAction, Name: "my-action", Actions: []Action{
+			{Type: BuiltinAction, Name: WorkspaceRestoreAction},
+		}},
+	)
+	features := job.WorkerFeatures()
+	assert.Equal(t, []string{WorkerFeatureAnnotation, WorkerFeatureStepOutput, WorkerFeatureWorkspaceSnapshot}, features)
+	assert.Equal(t, "0.45.0", WorkerMinVersion(features))
+}
+
+func TestCheckWorkerVersion(t *testing.T) {
+	features := []string{WorkerFeatureAnnotation, WorkerFeatureDockerBuild}
+	assert.NoError(t, CheckWorkerVersion("snapshot", features))
+	assert.NoError(t, CheckWorkerVersion("0.45.1", features))
+	assert.NoError(t, CheckWorkerVersion("0.44.0", nil))
+
+	err := CheckWorkerVersion("0.44.0", features)
+	require.Error(t, err)
+	assert.True(t, ErrorIs(err, ErrWorkerVersionOutdated))
+	assert.Contains(t, err.Error(), "worker version 0.44.0 does not support annotation, docker-build, version 0.45.0 or greater is required")
+}
+
+func TestIsWorkerUpdateNeeded(t *testing.T) {
+	assert.False(t, IsWorkerUpdateNeeded("0.45.0", "0.45.0"))
+	assert.False(t, IsWorkerUpdateNeeded("v0.45.0", "0.45.0"))
+	assert.True(t, IsWorkerUpdateNeeded("0.44.0", "0.45.0"))
+	assert.True(t, IsWorkerUpdateNeeded("0.46.0", "0.45.0"))
+	assert.False(t, IsWorkerUpdateNeeded("snapshot", "0.45.0"))
+	assert.False(t, IsWorkerUpdateNeeded("0.44.0", "snapshot"))
+}
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -48,6 +49,7 @@ type Model struct {
 	RegisteredCapabilities []Requirement       `json:"registered_capabilities"  db:"-" cli:"-"`
 	RegisteredOS           string              `json:"registered_os"  db:"-" cli:"-"`
 	RegisteredArch         string              `json:"registered_arch"  db:"-" cli:"-"`
+	RegisteredVersion      string              `json:"registered_version,omitempty"  db:"-" cli:"-"`
 	NeedRegistration       bool                `json:"need_registration"  db:"need_registration" cli:"-"`
 	LastRegistration       time.Time           `json:"last_registration"  db:"last_registration" cli:"-"`
 	CheckRegistration      bool                `json:"check_registration"  db:"check_registration" cli:"-"`
@@ -62,6 +64,9 @@ type Model struct {
 	LastSpawnErr     string     `json:"last_spawn_err" db:"-" cli:"-"`
 	LastSpawnErrLogs *string    `json:"last_spawn_err_log" db:"-" cli:"-"`
 	DateLastSpawnErr *time.Time `json:"date_last_spawn_err" db:"date_last_spawn_err" cli:"-"`
+	Quarantined      bool       `json:"quarantined" db:"quarantined" cli:"quarantined"`
+	QuarantineReason string     `json:"quarantine_reason,omitempty" db:"quarantine_reason" cli:"-"`
+	DateQuarantined  *time.Time `json:"date_quarantined,omitempty" db:"date_quarantined" cli:"-"`
 	IsDeprecated     bool       `json:"is_deprecated" db:"is_deprecated" cli:"deprecated"`
 	IsOfficial       bool       `json:"is_official" db:"-" cli:"official"`
 	PatternName      string     `json:"pattern_name,omitempty" db:"-" cli:"-"`
@@ -70,6 +75,25 @@ type Model struct {
 	Group    *Group `json:"group" db:"-" cli:"-"`
 }
 
+// IsCompatibleOSArch returns true if the model can run a job with given os-architecture requirement value.
+// A model that was never registered is considered as compatible because its architecture is unknown, except for
+// docker models that can only run linux workers.
+func (m Model) IsCompatibleOSArch(osArch string) bool {
+	if m.RegisteredOS == "" || m.RegisteredArch == "" {
+		return m.Type != Docker || strings.HasPrefix(osArch, "linux/")
+	}
+	return osArch == m.RegisteredOS+"/"+m.RegisteredArch
+}
+
+// IsCompatibleWorkerVersion returns true if the worker binary of the model supports given minimum version. A model
+// whose workers never registered is considered as compatible because its worker version is unknown.
+func (m Model) IsCompatibleWorkerVersion(minVersion string) bool {
+	if m.RegisteredVersion == "" {
+		return true
+	}
+	return IsWorkerVersionCompatible(m.RegisteredVersion, minVersion)
+}
+
 // Update workflow template field from new data.
 func (m *Model) Update(data Model) {
 	m.Name = data.Name
@@ -143,6 +167,14 @@ func (m Model) GetPath(groupName string) string {
 	return fmt.Sprintf("%s/%s", groupName, m.Name)
 }
 
+// GetFlavor returns the flavor of a virtual machine model, or the type of the model for other models.
+func (m Model) GetFlavor() string {
+	if m.ModelVirtualMachine.Flavor != "" {
+		return m.ModelVirtualMachine.Flavor
+	}
+	return m.Type
+}
+
 // ModelVirtualMachine for openstack or vsphere
 type ModelVirtualMachine struct {
 	Image   string `json:"image,omitempty"`
@@ -0,0 +1,101 @@
+package sdk
+
+import (
+	"strings"
+	"time"
+)
+
+// Classes of the errors that occur when a hatchery spawns a worker.
+const (
+	SpawnErrorClassImagePull   = "image_pull"
+	SpawnErrorClassQuota       = "quota"
+	SpawnErrorClassBootTimeout = "boot_timeout"
+	SpawnErrorClassInternal    = "internal"
+	SpawnErrorClassUnknown     = "unknown"
+)
+
+// SpawnErrorClasses lists all the classes of spawn errors.
+var SpawnErrorClasses = []string{
+	SpawnErrorClassImagePull,
+	SpawnErrorClassQuota,
+	SpawnErrorClassBootTimeout,
+	SpawnErrorClassInternal,
+	SpawnErrorClassUnknown,
+}
+
+// WorkerModelQuarantineThreshold is the number of consecutive spawn failures caused by a worker model after which
+// the model is quarantined.
+const WorkerModelQuarantineThreshold = 5
+
+var spawnErrorPatterns = []struct {
+	class    string
+	patterns []string
+}{
+	{SpawnErrorClassImagePull, []string{"errimagepull", "imagepullbackoff", "pull access denied", "manifest unknown", "no such image", "image not found", "failed to pull", "error pulling image", "repository does not exist", "invalid reference format"}},
+	{SpawnErrorClassQuota, []string{"quota", "limit exceeded", "insufficient", "no space left", "resources exhausted", "too many requests", "no valid host"}},
+	{SpawnErrorClassBootTimeout, []string{"timeout", "timed out", "deadline exceeded", "did not register"}},
+}
+
+// ClassifySpawnError returns the class of a spawn error from its message.
+func ClassifySpawnError(msg string) string {
+	msg = strings.ToLower(msg)
+	for _, p := range spawnErrorPatterns {
+		for _, pattern := range p.patterns {
+			if strings.Contains(msg, pattern) {
+				return p.class
+			}
+		}
+	}
+	return SpawnErrorClassUnknown
+}
+
+// SpawnErrorClassIsModelFailure returns true if the errors of given class are caused by the worker model itself, only
+// these errors lead to the quarantine of the model. A cloud quota or an error of the hatchery is not a failure of the
+// model.
+func SpawnErrorClassIsModelFailure(class string) bool {
+	switch class {
+	case SpawnErrorClassQuota, SpawnErrorClassInternal:
+		return false
+	}
+	return true
+}
+
+// ModelSpawnError is a spawn error of a worker model kept in its history.
+type ModelSpawnError struct {
+	ID            int64     `json:"id" db:"id" cli:"-"`
+	WorkerModelID int64     `json:"worker_model_id" db:"worker_model_id" cli:"-"`
+	Created       time.Time `json:"created" db:"created" cli:"created"`
+	Class         string    `json:"class" db:"class" cli:"class"`
+	Error         string    `json:"error" db:"error" cli:"error"`
+}
+
+// ModelHealth is the health of a worker model given by its last spawn errors.
+type ModelHealth struct {
+	Quarantined      bool              `json:"quarantined" cli:"quarantined"`
+	QuarantineReason string            `json:"quarantine_reason,omitempty" cli:"quarantine_reason"`
+	DateQuarantined  *time.Time        `json:"date_quarantined,omitempty" cli:"date_quarantined"`
+	NbSpawnErr       int64             `json:"nb_spawn_err" cli:"nb_spawn_err"`
+	LastRegistration time.Time         `json:"last_registration" cli:"last_registration"`
+	ErrorsByClass    map[string]int64  `json:"errors_by_class" cli:"-"`
+	LastErrors       []ModelSpawnError `json:"last_errors" cli:"-"`
+}
+
+// NewModelHealth returns the health of given worker model from its last spawn errors.
+func NewModelHealth(m Model, errs []ModelSpawnError) ModelHealth {
+	h := ModelHealth{
+		Quarantined:      m.Quarantined,
+		QuarantineReason: m.QuarantineReason,
+		DateQuarantined:  m.DateQuarantined,
+		NbSpawnErr:       m.NbSpawnErr,
+		LastRegistration: m.LastRegistration,
+		ErrorsByClass:    make(map[string]int64, len(SpawnErrorClasses)),
+		LastErrors:       errs,
+	}
+	for _, c := range SpawnErrorClasses {
+		h.ErrorsByClass[c] = 0
+	}
+	for _, e := range errs {
+		h.ErrorsByClass[e.Class]++
+	}
+	return h
+}
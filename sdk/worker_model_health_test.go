@@ -0,0 +1,36 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifySpawnError(t *testing.T) {
+	assert.Equal(t, SpawnErrorClassImagePull, ClassifySpawnError("Back-off pulling image: ErrImagePull"))
+	assert.Equal(t, SpawnErrorClassImagePull, ClassifySpawnError("Error response from daemon: pull access denied for foo/bar"))
+	assert.Equal(t, SpawnErrorClassQuota, ClassifySpawnError("Quota exceeded for instances: Requested 1, but already used 20 of 20 instances"))
+	assert.Equal(t, SpawnErrorClassQuota, ClassifySpawnError("No valid host was found. There are not enough hosts available."))
+	assert.Equal(t, SpawnErrorClassBootTimeout, ClassifySpawnError("context deadline exceeded"))
+	assert.Equal(t, SpawnErrorClassBootTimeout, ClassifySpawnError("worker did not register within 10m0s"))
+	assert.Equal(t, SpawnErrorClassUnknown, ClassifySpawnError("exit status 1"))
+
+	assert.True(t, SpawnErrorClassIsModelFailure(SpawnErrorClassImagePull))
+	assert.True(t, SpawnErrorClassIsModelFailure(SpawnErrorClassUnknown))
+	assert.False(t, SpawnErrorClassIsModelFailure(SpawnErrorClassQuota))
+	assert.False(t, SpawnErrorClassIsModelFailure(SpawnErrorClassInternal))
+}
+
+func TestNewModelHealth(t *testing.T) {
+	h := NewModelHealth(Model{Quarantined: true, NbSpawnErr: 5}, []ModelSpawnError{
+		{Class: SpawnErrorClassImagePull},
+		{Class: SpawnErrorClassImagePull},
+		{Class: SpawnErrorClassQuota},
+	})
+	assert.True(t, h.Quarantined)
+	assert.Equal(t, int64(5), h.NbSpawnErr)
+	assert.Equal(t, int64(2), h.ErrorsByClass[SpawnErrorClassImagePull])
+	assert.Equal(t, int64(1), h.ErrorsByClass[SpawnErrorClassQuota])
+	assert.Equal(t, int64(0), h.ErrorsByClass[SpawnErrorClassBootTimeout])
+	assert.Len(t, h.LastErrors, 3)
+}
@@ -0,0 +1,32 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelIsCompatibleOSArch(t *testing.T) {
+	m := Model{Type: Docker}
+	assert.True(t, m.IsCompatibleOSArch("linux/arm64"))
+	assert.False(t, m.IsCompatibleOSArch("windows/amd64"))
+
+	m = Model{Type: Openstack}
+	assert.True(t, m.IsCompatibleOSArch("windows/amd64"))
+
+	m.RegisteredOS, m.RegisteredArch = "linux", "amd64"
+	assert.True(t, m.IsCompatibleOSArch("linux/amd64"))
+	assert.False(t, m.IsCompatibleOSArch("linux/arm64"))
+}
+
+func TestModelIsCompatibleWorkerVersion(t *testing.T) {
+	m := Model{Type: Docker}
+	assert.True(t, m.IsCompatibleWorkerVersion("0.45.0"))
+
+	m.RegisteredVersion = "0.44.1"
+	assert.True(t, m.IsCompatibleWorkerVersion(""))
+	assert.False(t, m.IsCompatibleWorkerVersion("0.45.0"))
+
+	m.RegisteredVersion = "0.45.2"
+	assert.True(t, m.IsCompatibleWorkerVersion("0.45.0"))
+}
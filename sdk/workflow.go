@@ -51,7 +51,10 @@ type Workflow struct {
 	Favorite                bool                         `json:"favorite" db:"-" cli:"favorite"`
 	WorkflowData            WorkflowData                 `json:"workflow_data" db:"-" cli:"-"`
 	EventIntegrations       []ProjectIntegration         `json:"event_integrations,omitempty" db:"-" cli:"-"`
+	ArtifactStorages        []WorkflowArtifactStorage    `json:"artifact_storages,omitempty" db:"-" cli:"-"`
 	AsCodeEvent             []AsCodeEvent                `json:"as_code_events,omitempty" db:"-" cli:"-"`
+	RunForm                 *WorkflowRunForm             `json:"run_form,omitempty" db:"-" cli:"-"`
+	Priority                string                       `json:"priority,omitempty" db:"-" cli:"-"`
 	// aggregates
 	TemplateInstance *WorkflowTemplateInstance `json:"-" db:"-" cli:"-"`
 	FromTemplate     string                    `json:"from_template,omitempty" db:"-" cli:"-"`
@@ -84,6 +87,36 @@ func (w *Workflow) GetApplication(ID int64) Application {
 	return w.Applications[ID]
 }
 
+// ExecutePermission returns the permission level required to run given node, a node bound to a deployment
+// integration can only be run by groups with the deploy permission.
+func (w *Workflow) ExecutePermission(n *Node) int {
+	if n == nil || n.Context == nil || n.Context.ProjectIntegrationID == 0 {
+		return PermissionReadExecute
+	}
+	if pp, has := w.ProjectIntegrations[n.Context.ProjectIntegrationID]; has && pp.Model.Deployment {
+		return PermissionReadExecuteDeploy
+	}
+	return PermissionReadExecute
+}
+
+// DeploymentPlanParent returns the parent node that planned the deployment of given node, or nil. A node that applies
+// a deployment plan is bound to the same deployment integration as its parent and should be approved by a user.
+func (w *Workflow) DeploymentPlanParent(n *Node, parentNodeIDs []int64) *Node {
+	if n == nil || n.Context == nil || n.Context.ProjectIntegrationID == 0 || n.Context.DeploymentPlan {
+		return nil
+	}
+	for _, id := range parentNodeIDs {
+		parent := w.WorkflowData.NodeByID(id)
+		if parent == nil || parent.Context == nil {
+			continue
+		}
+		if parent.Context.DeploymentPlan && parent.Context.ProjectIntegrationID == n.Context.ProjectIntegrationID {
+			return parent
+		}
+	}
+	return nil
+}
+
 // WorkflowNotification represents notifications on a workflow
 type WorkflowNotification struct {
 	ID             int64                    `json:"id,omitempty" db:"id"`
@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// WorkflowArtifactStorageParameter is the build parameter that gives to the worker the storage integration that
+// receives the artifacts of a node run.
+const WorkflowArtifactStorageParameter = "cds.artifacts.storage"
+
+// WorkflowArtifactStorage pins a storage integration of the project that receives the artifacts of a workflow. When
+// the project has several storage integrations (e.g. regional buckets), the artifacts of given nodes can be routed to
+// a storage, a storage without nodes receives the artifacts of all the other nodes.
+type WorkflowArtifactStorage struct {
+	ProjectIntegrationID   int64    `json:"project_integration_id"`
+	ProjectIntegrationName string   `json:"project_integration_name"`
+	Nodes                  []string `json:"nodes,omitempty"`
+}
+
+// WorkflowArtifactStorageRouting is the routing metadata stored with the link between a workflow and a storage
+// integration.
+type WorkflowArtifactStorageRouting struct {
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// Value returns driver.Value from WorkflowArtifactStorageRouting.
+func (r WorkflowArtifactStorageRouting) Value() (driver.Value, error) {
+	j, err := json.Marshal(r)
+	return j, WrapError(err, "cannot marshal WorkflowArtifactStorageRouting")
+}
+
+// Scan workflow artifact storage routing.
+func (r *WorkflowArtifactStorageRouting) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(json.Unmarshal(source, r), "cannot unmarshal WorkflowArtifactStorageRouting")
+}
+
+// ArtifactStorageForNode returns the name of the storage integration that receives the artifacts of given node, the
+// storage routing the node is used first then the storage without nodes. It returns an empty string if the workflow
+// doesn't pin any storage for the node, the default storage is used.
+func (w *Workflow) ArtifactStorageForNode(nodeName string) string {
+	var defaultStorage string
+	for _, s := range w.ArtifactStorages {
+		if len(s.Nodes) == 0 {
+			defaultStorage = s.ProjectIntegrationName
+			continue
+		}
+		for _, n := range s.Nodes {
+			if n == nodeName {
+				return s.ProjectIntegrationName
+			}
+		}
+	}
+	return defaultStorage
+}
+
+// CheckArtifactStorages checks that the artifact storages of the workflow route each node at most once and that there
+// is at most one storage for all the other nodes.
+func (w *Workflow) CheckArtifactStorages() error {
+	nodes := make(map[string]struct{})
+	for _, n := range w.WorkflowData.Array() {
+		nodes[n.Name] = struct{}{}
+	}
+
+	storages := make(map[string]struct{}, len(w.ArtifactStorages))
+	routed := make(map[string]string)
+	var defaultStorage string
+	for _, s := range w.ArtifactStorages {
+		if _, ok := storages[s.ProjectIntegrationName]; ok {
+			return NewErrorFrom(ErrWorkflowInvalid, "artifact storage %s is used twice", s.ProjectIntegrationName)
+		}
+		storages[s.ProjectIntegrationName] = struct{}{}
+
+		if len(s.Nodes) == 0 {
+			if defaultStorage != "" {
+				return NewErrorFrom(ErrWorkflowInvalid, "artifact storages %s and %s can't both receive the artifacts of all the pipelines", defaultStorage, s.ProjectIntegrationName)
+			}
+			defaultStorage = s.ProjectIntegrationName
+			continue
+		}
+		for _, n := range s.Nodes {
+			if _, ok := nodes[n]; !ok {
+				return NewErrorFrom(ErrWorkflowInvalid, "artifact storage %s routes unknown pipeline %s", s.ProjectIntegrationName, n)
+			}
+			if other, ok := routed[n]; ok {
+				return NewErrorFrom(ErrWorkflowInvalid, "pipeline %s is routed to artifact storages %s and %s", n, other, s.ProjectIntegrationName)
+			}
+			routed[n] = s.ProjectIntegrationName
+		}
+	}
+	return nil
+}
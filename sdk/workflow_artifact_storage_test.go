@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowArtifactStorages(t *testing.T) {
+	w := Workflow{
+		WorkflowData: WorkflowData{
+			Node: Node{
+				Name: "build",
+				Type: NodeTypePipeline,
+				Triggers: []NodeTrigger{
+					{ChildNode: Node{Name: "deploy-eu", Type: NodeTypePipeline}},
+					{ChildNode: Node{Name: "deploy-us", Type: NodeTypePipeline}},
+				},
+			},
+		},
+	}
+	assert.Equal(t, "", w.ArtifactStorageForNode("build"))
+
+	w.ArtifactStorages = []WorkflowArtifactStorage{
+		{ProjectIntegrationName: "eu-bucket", Nodes: []string{"deploy-eu"}},
+		{ProjectIntegrationName: "default-bucket"},
+		{ProjectIntegrationName: "us-bucket", Nodes: []string{"deploy-us"}},
+	}
+	assert.NoError(t, w.CheckArtifactStorages())
+	assert.Equal(t, "default-bucket", w.ArtifactStorageForNode("build"))
+	assert.Equal(t, "eu-bucket", w.ArtifactStorageForNode("deploy-eu"))
+	assert.Equal(t, "us-bucket", w.ArtifactStorageForNode("deploy-us"))
+
+	w.ArtifactStorages = []WorkflowArtifactStorage{{ProjectIntegrationName: "eu-bucket"}, {ProjectIntegrationName: "us-bucket"}}
+	assert.Error(t, w.CheckArtifactStorages())
+
+	w.ArtifactStorages = []WorkflowArtifactStorage{{ProjectIntegrationName: "eu-bucket"}, {ProjectIntegrationName: "eu-bucket", Nodes: []string{"build"}}}
+	assert.Error(t, w.CheckArtifactStorages())
+
+	w.ArtifactStorages = []WorkflowArtifactStorage{{ProjectIntegrationName: "eu-bucket", Nodes: []string{"deploy-asia"}}}
+	assert.Error(t, w.CheckArtifactStorages())
+
+	w.ArtifactStorages = []WorkflowArtifactStorage{
+		{ProjectIntegrationName: "eu-bucket", Nodes: []string{"deploy-eu"}},
+		{ProjectIntegrationName: "us-bucket", Nodes: []string{"deploy-eu", "deploy-us"}},
+	}
+	assert.Error(t, w.CheckArtifactStorages())
+}
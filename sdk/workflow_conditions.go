@@ -3,6 +3,7 @@ package sdk
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/ovh/cds/sdk/interpolate"
@@ -34,53 +35,136 @@ var (
 
 //WorkflowCheckConditions checks conditions given a list of parameters
 func WorkflowCheckConditions(conditions []WorkflowNodeCondition, params []Parameter) (bool, error) {
+	_, conditionsOK, err := WorkflowExplainConditions(conditions, params)
+	return conditionsOK, err
+}
+
+// WorkflowConditionResult is the result of the evaluation of a condition, with the resolved values of its variable
+// and of its expected value.
+type WorkflowConditionResult struct {
+	Variable      string `json:"variable" cli:"variable"`
+	Operator      string `json:"operator" cli:"operator"`
+	Value         string `json:"value" cli:"value"`
+	ResolvedValue string `json:"resolved_value" cli:"resolved_value"`
+	VariableValue string `json:"variable_value" cli:"variable_value"`
+	OK            bool   `json:"ok" cli:"ok"`
+}
+
+// WorkflowExplainConditions checks conditions given a list of parameters and returns the result of each condition.
+func WorkflowExplainConditions(conditions []WorkflowNodeCondition, params []Parameter) ([]WorkflowConditionResult, bool, error) {
 	if len(conditions) == 0 {
-		return true, nil
+		return nil, true, nil
 	}
 	mapParams := ParametersToMap(params)
 	for k, v := range mapParams {
 		var err error
 		mapParams[k], err = interpolate.Do(v, mapParams)
 		if err != nil {
-			return false, fmt.Errorf("Unable to interpolate %s (%v)", v, err)
+			return nil, false, fmt.Errorf("Unable to interpolate %s (%v)", v, err)
 		}
 	}
 
 	var conditionsOK = true
+	results := make([]WorkflowConditionResult, 0, len(conditions))
 	for _, cond := range conditions {
+		res := WorkflowConditionResult{
+			Variable:      cond.Variable,
+			Operator:      cond.Operator,
+			Value:         cond.Value,
+			VariableValue: mapParams[cond.Variable],
+		}
+
 		var err error
 		cond.Value, err = interpolate.Do(cond.Value, mapParams)
 		if err != nil {
-			return false, fmt.Errorf("Unable to interpolate %s (%v)", cond.Value, err)
+			return nil, false, fmt.Errorf("Unable to interpolate %s (%v)", cond.Value, err)
 		}
+		res.ResolvedValue = cond.Value
 
 		switch cond.Operator {
 		case WorkflowConditionsOperatorEquals:
-			conditionsOK = conditionsOK && cond.Value == mapParams[cond.Variable]
+			res.OK = cond.Value == mapParams[cond.Variable]
 
 		case WorkflowConditionsOperatorNotEquals:
-			conditionsOK = conditionsOK && cond.Value != mapParams[cond.Variable]
+			res.OK = cond.Value != mapParams[cond.Variable]
 
 		case WorkflowConditionsOperatorLessThan:
-			conditionsOK = conditionsOK && strings.Compare(mapParams[cond.Variable], cond.Value) < 0
+			res.OK = strings.Compare(mapParams[cond.Variable], cond.Value) < 0
 
 		case WorkflowConditionsOperatorLessOrEqualThan:
-			conditionsOK = conditionsOK && strings.Compare(mapParams[cond.Variable], cond.Value) <= 0
+			res.OK = strings.Compare(mapParams[cond.Variable], cond.Value) <= 0
 
 		case WorkflowConditionsOperatorGreaterThan:
-			conditionsOK = conditionsOK && strings.Compare(mapParams[cond.Variable], cond.Value) > 0
+			res.OK = strings.Compare(mapParams[cond.Variable], cond.Value) > 0
 
 		case WorkflowConditionsOperatorGreaterOrEqualThan:
-			conditionsOK = conditionsOK && strings.Compare(mapParams[cond.Variable], cond.Value) >= 0
+			res.OK = strings.Compare(mapParams[cond.Variable], cond.Value) >= 0
 
 		case WorkflowConditionsOperatorRegex:
 			match, err := regexp.MatchString(cond.Value, mapParams[cond.Variable])
 			if err != nil {
-				return false, fmt.Errorf("Unable to match string with regex %s (%v)", cond.Value, err)
+				return nil, false, fmt.Errorf("Unable to match string with regex %s (%v)", cond.Value, err)
 			}
-			conditionsOK = conditionsOK && match
+			res.OK = match
+
+		default:
+			// An unknown operator doesn't change the result of the conditions
+			res.OK = true
 		}
+		conditionsOK = conditionsOK && res.OK
+		results = append(results, res)
 	}
 
-	return conditionsOK, nil
+	return results, conditionsOK, nil
+}
+
+// WorkflowConditionsPreviousPrefix is the prefix of the condition variables resolved from the previous runs of a node:
+// cds.previous.status and cds.previous.number come from the last run of the node, cds.previous.success.* from its last
+// successful run, with its build parameters and the digests of its artifacts.
+const WorkflowConditionsPreviousPrefix = "cds.previous."
+
+// WorkflowConditionsPreviousVariableNames lists the most useful variables resolved from the previous runs of a node.
+var WorkflowConditionsPreviousVariableNames = []string{
+	"cds.previous.status",
+	"cds.previous.number",
+	"cds.previous.success.number",
+	"cds.previous.success.cds.version",
+	"cds.previous.success.git.hash",
+	"cds.previous.success.git.tag",
+}
+
+// WorkflowConditionsUsePrevious returns true if the conditions reference data from the previous runs of the node, so
+// that these runs are only loaded when needed.
+func WorkflowConditionsUsePrevious(conditions WorkflowNodeConditions) bool {
+	if conditions.LuaScript != "" {
+		return strings.Contains(conditions.LuaScript, strings.Replace(WorkflowConditionsPreviousPrefix, ".", "_", -1))
+	}
+	for _, c := range conditions.PlainConditions {
+		if strings.HasPrefix(c.Variable, WorkflowConditionsPreviousPrefix) || strings.Contains(c.Value, WorkflowConditionsPreviousPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkflowConditionsPreviousParameters returns the condition variables for the last run and the last successful run
+// of a node, both runs can be nil if the node was never run.
+func WorkflowConditionsPreviousParameters(last, lastSuccess *WorkflowNodeRun) []Parameter {
+	params := []Parameter{}
+	if last != nil {
+		AddParameter(&params, WorkflowConditionsPreviousPrefix+"status", StringParameter, last.Status)
+		AddParameter(&params, WorkflowConditionsPreviousPrefix+"number", StringParameter, strconv.FormatInt(last.Number, 10))
+	}
+	if lastSuccess != nil {
+		prefix := WorkflowConditionsPreviousPrefix + "success."
+		AddParameter(&params, prefix+"number", StringParameter, strconv.FormatInt(lastSuccess.Number, 10))
+		for _, p := range lastSuccess.BuildParameters {
+			AddParameter(&params, prefix+p.Name, p.Type, p.Value)
+		}
+		for _, a := range lastSuccess.Artifacts {
+			AddParameter(&params, prefix+"artifact."+a.Name+".md5", StringParameter, a.MD5sum)
+			AddParameter(&params, prefix+"artifact."+a.Name+".sha512", StringParameter, a.SHA512sum)
+		}
+	}
+	return params
 }
@@ -0,0 +1,76 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowConditionsUsePrevious(t *testing.T) {
+	assert.False(t, WorkflowConditionsUsePrevious(WorkflowNodeConditions{
+		PlainConditions: []WorkflowNodeCondition{{Variable: "git.branch", Operator: WorkflowConditionsOperatorEquals, Value: "master"}},
+	}))
+	assert.True(t, WorkflowConditionsUsePrevious(WorkflowNodeConditions{
+		PlainConditions: []WorkflowNodeCondition{{Variable: "cds.previous.status", Operator: WorkflowConditionsOperatorEquals, Value: StatusSuccess}},
+	}))
+	assert.True(t, WorkflowConditionsUsePrevious(WorkflowNodeConditions{
+		PlainConditions: []WorkflowNodeCondition{{Variable: "git.hash", Operator: WorkflowConditionsOperatorNotEquals, Value: "{{.cds.previous.success.git.hash}}"}},
+	}))
+	assert.True(t, WorkflowConditionsUsePrevious(WorkflowNodeConditions{LuaScript: "return git_hash ~= cds_previous_success_git_hash"}))
+}
+
+func TestWorkflowCheckConditionsWithPreviousRuns(t *testing.T) {
+	conditions := []WorkflowNodeCondition{{Variable: "cds.build.version", Operator: WorkflowConditionsOperatorNotEquals, Value: "{{.cds.previous.success.cds.build.version}}"}}
+	params := []Parameter{{Name: "cds.build.version", Type: StringParameter, Value: "1.2.0"}}
+
+	// The node was never run, the previous version is empty
+	ok, err := WorkflowCheckConditions(conditions, append(params, WorkflowConditionsPreviousParameters(nil, nil)...))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	last := &WorkflowNodeRun{Number: 12, Status: StatusFail}
+	lastSuccess := &WorkflowNodeRun{
+		Number:          11,
+		Status:          StatusSuccess,
+		BuildParameters: []Parameter{{Name: "cds.build.version", Type: StringParameter, Value: "1.2.0"}},
+		Artifacts:       []WorkflowNodeRunArtifact{{Name: "app.tar.gz", MD5sum: "abc", SHA512sum: "def"}},
+	}
+	previous := WorkflowConditionsPreviousParameters(last, lastSuccess)
+	assert.Equal(t, "Fail", ParameterValue(previous, "cds.previous.status"))
+	assert.Equal(t, "12", ParameterValue(previous, "cds.previous.number"))
+	assert.Equal(t, "11", ParameterValue(previous, "cds.previous.success.number"))
+	assert.Equal(t, "def", ParameterValue(previous, "cds.previous.success.artifact.app.tar.gz.sha512"))
+
+	// The version did not change since the last successful run
+	ok, err = WorkflowCheckConditions(conditions, append(params, previous...))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestWorkflowExplainConditions(t *testing.T) {
+	conditions := []WorkflowNodeCondition{
+		{Variable: "git.branch", Operator: WorkflowConditionsOperatorEquals, Value: "{{.cds.env.branch}}"},
+		{Variable: "git.author", Operator: WorkflowConditionsOperatorRegex, Value: "^bot-.*"},
+	}
+	params := []Parameter{
+		{Name: "git.branch", Type: StringParameter, Value: "master"},
+		{Name: "git.author", Type: StringParameter, Value: "bob"},
+		{Name: "cds.env.branch", Type: StringParameter, Value: "master"},
+	}
+
+	res, ok, err := WorkflowExplainConditions(conditions, params)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.Len(t, res, 2)
+	assert.True(t, res[0].OK)
+	assert.Equal(t, "{{.cds.env.branch}}", res[0].Value)
+	assert.Equal(t, "master", res[0].ResolvedValue)
+	assert.False(t, res[1].OK)
+	assert.Equal(t, "bob", res[1].VariableValue)
+
+	res, ok, err = WorkflowExplainConditions(nil, params)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, res)
+}
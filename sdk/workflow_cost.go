@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"strconv"
+	"time"
+)
+
+// Keys used to group the lines of a workflow cost report.
+const (
+	WorkflowCostGroupByWorkflow = "workflow"
+	WorkflowCostGroupByRun      = "run"
+	WorkflowCostGroupByJob      = "job"
+)
+
+// WorkflowNodeJobRunUsage is the resource usage of a job run: the duration of the job on a worker model flavor and the
+// size of the artifacts and caches it uploaded. Usages are kept when workflow runs are purged for chargeback.
+type WorkflowNodeJobRunUsage struct {
+	ID                   int64      `json:"id" db:"id"`
+	ProjectID            int64      `json:"project_id" db:"project_id"`
+	WorkflowID           int64      `json:"workflow_id" db:"workflow_id"`
+	WorkflowName         string     `json:"workflow_name" db:"workflow_name"`
+	WorkflowRunID        int64      `json:"workflow_run_id" db:"workflow_run_id"`
+	RunNumber            int64      `json:"run_number" db:"run_number"`
+	WorkflowNodeRunID    int64      `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	WorkflowNodeJobRunID int64      `json:"workflow_node_run_job_id" db:"workflow_node_run_job_id"`
+	NodeName             string     `json:"node_name" db:"node_name"`
+	JobName              string     `json:"job_name" db:"job_name"`
+	Model                string     `json:"model,omitempty" db:"model"`
+	Flavor               string     `json:"flavor,omitempty" db:"flavor"`
+	Start                time.Time  `json:"start" db:"start"`
+	Done                 *time.Time `json:"done,omitempty" db:"done"`
+	DurationSeconds      int64      `json:"duration_seconds" db:"duration_seconds"`
+	ArtifactBytes        int64      `json:"artifact_bytes" db:"artifact_bytes"`
+	CacheBytes           int64      `json:"cache_bytes" db:"cache_bytes"`
+}
+
+// WorkflowCostReport is the cost of the job runs of a project, a workflow or a workflow run, grouped by workflow, run
+// or job.
+type WorkflowCostReport struct {
+	Currency string             `json:"currency"`
+	GroupBy  string             `json:"group_by"`
+	From     *time.Time         `json:"from,omitempty"`
+	To       *time.Time         `json:"to,omitempty"`
+	Lines    []WorkflowCostLine `json:"lines"`
+	Total    WorkflowCostLine   `json:"total"`
+}
+
+// WorkflowCostLine is the aggregated usage and cost of some job runs.
+type WorkflowCostLine struct {
+	WorkflowName    string  `json:"workflow_name,omitempty" cli:"workflow"`
+	RunNumber       int64   `json:"run_number,omitempty" cli:"run"`
+	NodeName        string  `json:"node_name,omitempty" cli:"node"`
+	JobName         string  `json:"job_name,omitempty" cli:"job"`
+	Flavor          string  `json:"flavor,omitempty" cli:"flavor"`
+	Jobs            int64   `json:"jobs" cli:"jobs"`
+	DurationSeconds int64   `json:"duration_seconds" cli:"duration_seconds"`
+	ArtifactBytes   int64   `json:"artifact_bytes" cli:"artifact_bytes"`
+	CacheBytes      int64   `json:"cache_bytes" cli:"cache_bytes"`
+	DurationCost    float64 `json:"duration_cost" cli:"-"`
+	ArtifactCost    float64 `json:"artifact_cost" cli:"-"`
+	CacheCost       float64 `json:"cache_cost" cli:"-"`
+	Cost            float64 `json:"cost" cli:"cost"`
+}
+
+// Add the usage and cost of another line to the line.
+func (l *WorkflowCostLine) Add(o WorkflowCostLine) {
+	l.Jobs += o.Jobs
+	l.DurationSeconds += o.DurationSeconds
+	l.ArtifactBytes += o.ArtifactBytes
+	l.CacheBytes += o.CacheBytes
+	l.DurationCost += o.DurationCost
+	l.ArtifactCost += o.ArtifactCost
+	l.CacheCost += o.CacheCost
+	l.Cost += o.Cost
+}
+
+// CSV returns the lines of the report and its total as CSV records, with a header.
+func (r WorkflowCostReport) CSV() [][]string {
+	records := [][]string{{"workflow", "run", "node", "job", "flavor", "jobs", "duration_seconds", "artifact_bytes",
+		"cache_bytes", "duration_cost", "artifact_cost", "cache_cost", "cost", "currency"}}
+	record := func(l WorkflowCostLine) []string {
+		var run string
+		if l.RunNumber > 0 {
+			run = strconv.FormatInt(l.RunNumber, 10)
+		}
+		return []string{l.WorkflowName, run, l.NodeName, l.JobName, l.Flavor,
+			strconv.FormatInt(l.Jobs, 10),
+			strconv.FormatInt(l.DurationSeconds, 10),
+			strconv.FormatInt(l.ArtifactBytes, 10),
+			strconv.FormatInt(l.CacheBytes, 10),
+			strconv.FormatFloat(l.DurationCost, 'f', 4, 64),
+			strconv.FormatFloat(l.ArtifactCost, 'f', 4, 64),
+			strconv.FormatFloat(l.CacheCost, 'f', 4, 64),
+			strconv.FormatFloat(l.Cost, 'f', 4, 64),
+			r.Currency,
+		}
+	}
+	for _, l := range r.Lines {
+		records = append(records, record(l))
+	}
+	total := r.Total
+	total.WorkflowName = "total"
+	return append(records, record(total))
+}
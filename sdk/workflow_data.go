@@ -114,3 +114,29 @@ func (w *WorkflowData) NodeByName(s string) *Node {
 	}
 	return nil
 }
+
+// NodeDeployingOn returns the node of the workflow that deploys given application on given environment with
+// given integration. If applicationID or integrationID equals to zero, it is ignored. An error is returned if
+// there is not exactly one node matching.
+func (w *WorkflowData) NodeDeployingOn(environmentID, applicationID, integrationID int64) (*Node, error) {
+	var res *Node
+	for _, n := range w.Array() {
+		if n.Context == nil || n.Context.EnvironmentID != environmentID || n.Context.ProjectIntegrationID == 0 {
+			continue
+		}
+		if applicationID != 0 && n.Context.ApplicationID != applicationID {
+			continue
+		}
+		if integrationID != 0 && n.Context.ProjectIntegrationID != integrationID {
+			continue
+		}
+		if res != nil {
+			return nil, NewErrorFrom(ErrWrongRequest, "more than one node deploys on environment, nodes %s and %s", res.Name, n.Name)
+		}
+		res = n
+	}
+	if res == nil {
+		return nil, NewErrorFrom(ErrWorkflowNodeNotFound, "no node deploys on environment")
+	}
+	return res, nil
+}
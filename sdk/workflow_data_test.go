@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowDataNodeDeployingOn(t *testing.T) {
+	w := WorkflowData{
+		Node: Node{
+			ID:      1,
+			Name:    "build",
+			Context: &NodeContext{ApplicationID: 10},
+			Triggers: []NodeTrigger{
+				{ChildNode: Node{ID: 2, Name: "deploy-staging", Context: &NodeContext{ApplicationID: 10, EnvironmentID: 100, ProjectIntegrationID: 1000}}},
+				{ChildNode: Node{ID: 3, Name: "deploy-prod-a", Context: &NodeContext{ApplicationID: 10, EnvironmentID: 200, ProjectIntegrationID: 1000}}},
+				{ChildNode: Node{ID: 4, Name: "deploy-prod-b", Context: &NodeContext{ApplicationID: 10, EnvironmentID: 200, ProjectIntegrationID: 2000}}},
+				{ChildNode: Node{ID: 5, Name: "test-prod", Context: &NodeContext{ApplicationID: 10, EnvironmentID: 200}}},
+			},
+		},
+	}
+
+	n, err := w.NodeDeployingOn(100, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "deploy-staging", n.Name)
+
+	_, err = w.NodeDeployingOn(200, 10, 0)
+	assert.True(t, ErrorIs(err, ErrWrongRequest), "two nodes deploy on environment without integration filter")
+
+	n, err = w.NodeDeployingOn(200, 10, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, "deploy-prod-b", n.Name)
+
+	_, err = w.NodeDeployingOn(100, 11, 0)
+	assert.True(t, ErrorIs(err, ErrWorkflowNodeNotFound))
+}
@@ -0,0 +1,30 @@
+package sdk
+
+// Operations of a workflow diff, see RFC 6902.
+const (
+	WorkflowDiffOperationAdd     = "add"
+	WorkflowDiffOperationRemove  = "remove"
+	WorkflowDiffOperationReplace = "replace"
+)
+
+// WorkflowDiffRequest gives the versions of a workflow to compare. The version of the workflow after the change given
+// by the audit is compared to the given workflow file. Without audit, the stored workflow is compared to the file,
+// without file, the version of the audit is compared to the stored workflow.
+type WorkflowDiffRequest struct {
+	Workflow string `json:"workflow,omitempty"`
+	AuditID  int64  `json:"audit_id,omitempty"`
+}
+
+// WorkflowDiff is a structural diff between two versions of a workflow, as a JSON patch on the as code representation
+// of the workflow and as a human readable summary.
+type WorkflowDiff struct {
+	Patch   []WorkflowDiffOperation `json:"patch"`
+	Summary []string                `json:"summary"`
+}
+
+// WorkflowDiffOperation is an operation of a JSON patch.
+type WorkflowDiffOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
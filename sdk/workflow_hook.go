@@ -37,7 +37,7 @@ func (h NodeHook) Ref() string {
 	})
 	for _, k := range mapKeys {
 		cfg := h.Config[k.String()]
-		if cfg.Configurable {
+		if cfg.Configurable && !isEmptyOptionalHookConfig(k.String(), cfg) {
 			s += k.String() + ":" + cfg.Value + ";"
 		}
 	}
@@ -130,7 +130,7 @@ func GetBuiltinOutgoingHookModelByName(name string) *WorkflowHookModel {
 func (cfg WorkflowNodeHookConfig) Values(model WorkflowNodeHookConfig) map[string]string {
 	r := make(map[string]string)
 	for k, v := range cfg {
-		if model[k].Configurable {
+		if model[k].Configurable && !isEmptyOptionalHookConfig(k, v) {
 			r[k] = v.Value
 		}
 	}
@@ -0,0 +1,164 @@
+package sdk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Statuses of a project integration referenced by a workflow to import.
+const (
+	WorkflowImportIntegrationStatusOK           = "ok"
+	WorkflowImportIntegrationStatusMissing      = "missing"
+	WorkflowImportIntegrationStatusInvalidModel = "invalid_model"
+	WorkflowImportIntegrationStatusForbidden    = "forbidden"
+)
+
+// WorkflowImportIntegrationCheck is the result of the check of a project integration referenced by a workflow to
+// import. Required models are the names of the models that can be used for all the usages of the integration.
+type WorkflowImportIntegrationCheck struct {
+	Name           string   `json:"name" cli:"name,key"`
+	Status         string   `json:"status" cli:"status"`
+	Model          string   `json:"model,omitempty" cli:"model"`
+	RequiredModels []string `json:"required_models" cli:"required_models"`
+	Usages         []string `json:"usages" cli:"usages"`
+}
+
+// WorkflowImportIntegrationReport lists the project integrations referenced by the nodes and the hooks of a workflow
+// to import.
+type WorkflowImportIntegrationReport struct {
+	Workflow     string                           `json:"workflow"`
+	Integrations []WorkflowImportIntegrationCheck `json:"integrations"`
+}
+
+// IsValid returns an error that lists the integrations preventing the import of the workflow.
+func (r WorkflowImportIntegrationReport) IsValid() error {
+	var missing, invalid, forbidden []string
+	for _, c := range r.Integrations {
+		switch c.Status {
+		case WorkflowImportIntegrationStatusMissing:
+			missing = append(missing, fmt.Sprintf("%s (required models: %s)", c.Name, strings.Join(c.RequiredModels, ", ")))
+		case WorkflowImportIntegrationStatusInvalidModel:
+			invalid = append(invalid, fmt.Sprintf("%s (model %s, required models: %s)", c.Name, c.Model, strings.Join(c.RequiredModels, ", ")))
+		case WorkflowImportIntegrationStatusForbidden:
+			forbidden = append(forbidden, c.Name)
+		}
+	}
+	if len(forbidden) > 0 {
+		return NewErrorFrom(ErrForbidden, "workflow %s uses integrations that you are not allowed to use: %s", r.Workflow, strings.Join(forbidden, ", "))
+	}
+	if len(missing) > 0 {
+		return NewErrorFrom(ErrIntegrationtNotFound, "workflow %s uses missing integrations: %s", r.Workflow, strings.Join(missing, "; "))
+	}
+	if len(invalid) > 0 {
+		return NewErrorFrom(ErrWorkflowInvalid, "workflow %s uses integrations with invalid models: %s", r.Workflow, strings.Join(invalid, "; "))
+	}
+	return nil
+}
+
+type workflowIntegrationUsage struct {
+	description string
+	supports    func(IntegrationModel) bool
+}
+
+// CheckWorkflowIntegrations checks the project integrations referenced by the nodes and the hooks of given workflow
+// against the integrations of the project and the available models. The allowed func returns false for the
+// integrations that the user can't use, it can be nil.
+func CheckWorkflowIntegrations(w Workflow, integrations []ProjectIntegration, models []IntegrationModel, allowed func(ProjectIntegration) bool) WorkflowImportIntegrationReport {
+	var names []string
+	usages := make(map[string][]workflowIntegrationUsage)
+	add := func(name, description string, supports func(IntegrationModel) bool) {
+		if name == "" {
+			return
+		}
+		if _, ok := usages[name]; !ok {
+			names = append(names, name)
+		}
+		usages[name] = append(usages[name], workflowIntegrationUsage{description: description, supports: supports})
+	}
+
+	w.VisitNode(func(n *Node, _ *Workflow) {
+		if n.Context != nil && n.Context.ProjectIntegrationName != "" {
+			if n.Context.DeploymentPlan {
+				add(n.Context.ProjectIntegrationName, fmt.Sprintf("node %s (deployment plan)", n.Name), func(m IntegrationModel) bool {
+					return m.Deployment
+				})
+			} else {
+				add(n.Context.ProjectIntegrationName, fmt.Sprintf("node %s", n.Name), func(m IntegrationModel) bool {
+					return m.PluginType() != ""
+				})
+			}
+		}
+		if n.Context != nil {
+			for _, t := range n.Context.ReleaseTargets {
+				add(t, fmt.Sprintf("release target of node %s", n.Name), func(m IntegrationModel) bool {
+					return m.Release
+				})
+			}
+		}
+		for _, h := range n.Hooks {
+			var modelName string
+			switch h.HookModelName {
+			case KafkaHookModelName:
+				modelName = KafkaIntegrationModel
+			case RabbitMQHookModelName:
+				modelName = RabbitMQIntegrationModel
+			default:
+				continue
+			}
+			add(h.Config[HookModelIntegration].Value, fmt.Sprintf("%s on node %s", h.HookModelName, n.Name), func(m IntegrationModel) bool {
+				return m.Name == modelName
+			})
+		}
+	})
+
+	report := WorkflowImportIntegrationReport{
+		Workflow:     w.Name,
+		Integrations: make([]WorkflowImportIntegrationCheck, 0, len(names)),
+	}
+	for _, name := range names {
+		supportsAll := func(m IntegrationModel) bool {
+			for _, u := range usages[name] {
+				if !u.supports(m) {
+					return false
+				}
+			}
+			return true
+		}
+
+		c := WorkflowImportIntegrationCheck{Name: name, RequiredModels: []string{}}
+		for _, u := range usages[name] {
+			c.Usages = append(c.Usages, u.description)
+		}
+		for _, m := range models {
+			if supportsAll(m) {
+				c.RequiredModels = append(c.RequiredModels, m.Name)
+			}
+		}
+		sort.Strings(c.RequiredModels)
+
+		var pi *ProjectIntegration
+		for i := range integrations {
+			if integrations[i].Name == name {
+				pi = &integrations[i]
+				break
+			}
+		}
+
+		switch {
+		case pi == nil:
+			c.Status = WorkflowImportIntegrationStatusMissing
+		case !supportsAll(pi.Model):
+			c.Status = WorkflowImportIntegrationStatusInvalidModel
+			c.Model = pi.Model.Name
+		case allowed != nil && !allowed(*pi):
+			c.Status = WorkflowImportIntegrationStatusForbidden
+			c.Model = pi.Model.Name
+		default:
+			c.Status = WorkflowImportIntegrationStatusOK
+			c.Model = pi.Model.Name
+		}
+		report.Integrations = append(report.Integrations, c)
+	}
+	return report
+}
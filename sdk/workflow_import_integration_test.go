@@ -0,0 +1,95 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWorkflowIntegrations(t *testing.T) {
+	k8s := IntegrationModel{Name: "Kubernetes", Deployment: true}
+	kafka := IntegrationModel{Name: KafkaIntegrationModel, Event: true, Hook: true}
+	rabbit := IntegrationModel{Name: RabbitMQIntegrationModel, Hook: true}
+	models := []IntegrationModel{k8s, kafka, rabbit, {Name: "Artifactory", Release: true}}
+
+	groupIntegrationID := int64(3)
+	integrations := []ProjectIntegration{
+		{Name: "my-k8s", Model: k8s},
+		{Name: "my-kafka", Model: kafka, GroupIntegrationID: &groupIntegrationID},
+		{Name: "my-rabbit", Model: rabbit},
+	}
+
+	w := Workflow{
+		Name: "my-workflow",
+		WorkflowData: WorkflowData{
+			Node: Node{
+				Name:    "build",
+				Context: &NodeContext{},
+				Hooks: []NodeHook{
+					{HookModelName: KafkaHookModelName, Config: WorkflowNodeHookConfig{HookModelIntegration: {Value: "my-kafka"}}},
+					{HookModelName: RabbitMQHookModelName, Config: WorkflowNodeHookConfig{HookModelIntegration: {Value: "my-k8s"}}},
+				},
+				Triggers: []NodeTrigger{
+					{ChildNode: Node{Name: "deploy", Context: &NodeContext{ProjectIntegrationName: "my-k8s"}}},
+					{ChildNode: Node{Name: "release", Context: &NodeContext{ProjectIntegrationName: "my-artifactory", DeploymentPlan: true}}},
+				},
+			},
+		},
+	}
+
+	report := CheckWorkflowIntegrations(w, integrations, models, func(pi ProjectIntegration) bool {
+		return pi.GroupIntegrationID == nil
+	})
+	assert.Equal(t, "my-workflow", report.Workflow)
+	require.Len(t, report.Integrations, 3)
+
+	assert.Equal(t, WorkflowImportIntegrationCheck{
+		Name:           "my-kafka",
+		Status:         WorkflowImportIntegrationStatusForbidden,
+		Model:          KafkaIntegrationModel,
+		RequiredModels: []string{KafkaIntegrationModel},
+		Usages:         []string{"Kafka hook on node build"},
+	}, report.Integrations[0])
+
+	// The integration is used by a RabbitMQ hook and by a deployment node, no model supports both
+	assert.Equal(t, WorkflowImportIntegrationCheck{
+		Name:           "my-k8s",
+		Status:         WorkflowImportIntegrationStatusInvalidModel,
+		Model:          "Kubernetes",
+		RequiredModels: []string{},
+		Usages:         []string{"RabbitMQ hook on node build", "node deploy"},
+	}, report.Integrations[1])
+
+	assert.Equal(t, WorkflowImportIntegrationCheck{
+		Name:           "my-artifactory",
+		Status:         WorkflowImportIntegrationStatusMissing,
+		RequiredModels: []string{"Kubernetes"},
+		Usages:         []string{"node release (deployment plan)"},
+	}, report.Integrations[2])
+
+	err := report.IsValid()
+	require.Error(t, err)
+	assert.True(t, ErrorIs(err, ErrForbidden))
+
+	report = CheckWorkflowIntegrations(w, integrations, models, nil)
+	err = report.IsValid()
+	require.Error(t, err)
+	assert.True(t, ErrorIs(err, ErrIntegrationtNotFound))
+	assert.Contains(t, err.Error(), "my-artifactory (required models: Kubernetes)")
+
+	report = CheckWorkflowIntegrations(Workflow{
+		Name: "release",
+		WorkflowData: WorkflowData{
+			Node: Node{Name: "publish", Context: &NodeContext{ReleaseTargets: []string{"my-k8s"}}},
+		},
+	}, integrations, models, nil)
+	require.Len(t, report.Integrations, 1)
+	assert.Equal(t, WorkflowImportIntegrationStatusInvalidModel, report.Integrations[0].Status)
+	assert.Equal(t, []string{"Artifactory"}, report.Integrations[0].RequiredModels)
+	assert.Equal(t, []string{"release target of node publish"}, report.Integrations[0].Usages)
+
+	report = CheckWorkflowIntegrations(Workflow{Name: "empty"}, integrations, models, nil)
+	assert.Empty(t, report.Integrations)
+	assert.NoError(t, report.IsValid())
+}
@@ -28,6 +28,7 @@ type Node struct {
 	JoinContext         []NodeJoin        `json:"parents" db:"-"`
 	Hooks               []NodeHook        `json:"hooks" db:"-"`
 	Groups              []GroupPermission `json:"groups,omitempty" db:"-"`
+	Display             *NodeDisplay      `json:"display,omitempty" db:"-"`
 }
 
 func (n Node) GetHook(UUID string) *NodeHook {
@@ -55,6 +56,10 @@ type NodeContext struct {
 	DefaultPipelineParameters []Parameter            `json:"default_pipeline_parameters" db:"-"`
 	Conditions                WorkflowNodeConditions `json:"conditions" db:"-"`
 	Mutex                     bool                   `json:"mutex" db:"mutex"`
+	DeploymentPlan            bool                   `json:"deployment_plan,omitempty" db:"deployment_plan"`
+	// ReleaseTargets are the names of the release integrations to which the node publishes the results of the run, in
+	// addition to the integration of the node
+	ReleaseTargets []string `json:"release_targets,omitempty" db:"-"`
 }
 
 // FilterHooksConfig filter all hooks configuration and remove somme configuration key
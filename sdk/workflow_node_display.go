@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// NodeDisplayMaxLength is the maximum length of the label and the group of a node display
+const NodeDisplayMaxLength = 64
+
+var nodeDisplayColorRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// NodeDisplay contains the settings used to draw a node in the workflow graph, it has no effect on the runs.
+type NodeDisplay struct {
+	Label string `json:"label,omitempty" yaml:"label,omitempty" jsonschema_description:"Text displayed instead of the name of the node."`
+	Color string `json:"color,omitempty" yaml:"color,omitempty" jsonschema_description:"Color of the node, as an hexadecimal code (ex: #21ba45)."`
+	Group string `json:"group,omitempty" yaml:"group,omitempty" jsonschema_description:"Name of the lane in which the node is drawn with the other nodes of the same group."`
+}
+
+// IsEmpty returns true if no display setting is set.
+func (d NodeDisplay) IsEmpty() bool {
+	return d.Label == "" && d.Color == "" && d.Group == ""
+}
+
+// IsValid checks the display settings of given node.
+func (d NodeDisplay) IsValid(nodeName string) error {
+	if err := checkNodeDisplayText(nodeName, "label", d.Label); err != nil {
+		return err
+	}
+	if err := checkNodeDisplayText(nodeName, "group", d.Group); err != nil {
+		return err
+	}
+	if d.Color != "" && !nodeDisplayColorRegex.MatchString(d.Color) {
+		return NewErrorFrom(ErrWorkflowInvalid, "invalid display color %q of node %s, should be an hexadecimal code like #21ba45", d.Color, nodeName)
+	}
+	return nil
+}
+
+func checkNodeDisplayText(nodeName, name, value string) error {
+	if utf8.RuneCountInString(value) > NodeDisplayMaxLength {
+		return NewErrorFrom(ErrWorkflowInvalid, "display %s of node %s should not exceed %d characters", name, nodeName, NodeDisplayMaxLength)
+	}
+	if strings.ContainsAny(value, "\r\n\t") || strings.TrimSpace(value) != value {
+		return NewErrorFrom(ErrWorkflowInvalid, "invalid display %s %q of node %s", name, value, nodeName)
+	}
+	return nil
+}
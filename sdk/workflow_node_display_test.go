@@ -0,0 +1,20 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeDisplayIsValid(t *testing.T) {
+	assert.NoError(t, NodeDisplay{}.IsValid("build"))
+	assert.NoError(t, NodeDisplay{Label: "Build & package", Color: "#21ba45", Group: "ci"}.IsValid("build"))
+	assert.NoError(t, NodeDisplay{Color: "#FFF"}.IsValid("build"))
+
+	assert.Error(t, NodeDisplay{Color: "green"}.IsValid("build"))
+	assert.Error(t, NodeDisplay{Color: "#21ba4"}.IsValid("build"))
+	assert.Error(t, NodeDisplay{Label: strings.Repeat("a", NodeDisplayMaxLength+1)}.IsValid("build"))
+	assert.Error(t, NodeDisplay{Label: "Build\npackage"}.IsValid("build"))
+	assert.Error(t, NodeDisplay{Group: " ci"}.IsValid("build"))
+}
@@ -0,0 +1,38 @@
+package sdk
+
+import "time"
+
+// WorkflowNodeMutex describes the mutex of a workflow node, with the node run that holds it and the node runs
+// waiting for it.
+type WorkflowNodeMutex struct {
+	NodeName string                 `json:"node_name"`
+	Holder   *WorkflowNodeMutexRun  `json:"holder,omitempty"`
+	Waiting  []WorkflowNodeMutexRun `json:"waiting"`
+}
+
+// WorkflowNodeMutexRun is a node run holding or waiting for a workflow node mutex.
+type WorkflowNodeMutexRun struct {
+	WorkflowRunID     int64     `json:"workflow_run_id"`
+	Number            int64     `json:"num"`
+	SubNumber         int64     `json:"subnum"`
+	WorkflowNodeRunID int64     `json:"workflow_node_run_id"`
+	Status            string    `json:"status"`
+	Since             time.Time `json:"since"`
+	Duration          int64     `json:"duration"`
+}
+
+// NewWorkflowNodeMutex returns the mutex state of a node from its waiting and building node runs,
+// the duration of each run is computed from given time.
+func NewWorkflowNodeMutex(nodeName string, runs []WorkflowNodeMutexRun, now time.Time) WorkflowNodeMutex {
+	m := WorkflowNodeMutex{NodeName: nodeName, Waiting: []WorkflowNodeMutexRun{}}
+	for i := range runs {
+		r := runs[i]
+		r.Duration = int64(now.Sub(r.Since).Seconds())
+		if r.Status == StatusBuilding && m.Holder == nil {
+			m.Holder = &r
+			continue
+		}
+		m.Waiting = append(m.Waiting, r)
+	}
+	return m
+}
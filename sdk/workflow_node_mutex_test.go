@@ -0,0 +1,31 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWorkflowNodeMutex(t *testing.T) {
+	now := time.Now()
+	m := NewWorkflowNodeMutex("deploy", []WorkflowNodeMutexRun{
+		{WorkflowNodeRunID: 1, Status: StatusWaiting, Since: now.Add(-3 * time.Minute)},
+		{WorkflowNodeRunID: 2, Status: StatusBuilding, Since: now.Add(-2 * time.Minute)},
+		{WorkflowNodeRunID: 3, Status: StatusWaiting, Since: now.Add(-time.Minute)},
+	}, now)
+
+	assert.Equal(t, "deploy", m.NodeName)
+	require.NotNil(t, m.Holder)
+	assert.Equal(t, int64(2), m.Holder.WorkflowNodeRunID)
+	assert.Equal(t, int64(120), m.Holder.Duration)
+	require.Len(t, m.Waiting, 2)
+	assert.Equal(t, int64(1), m.Waiting[0].WorkflowNodeRunID)
+	assert.Equal(t, int64(180), m.Waiting[0].Duration)
+	assert.Equal(t, int64(3), m.Waiting[1].WorkflowNodeRunID)
+
+	m = NewWorkflowNodeMutex("deploy", nil, now)
+	assert.Nil(t, m.Holder)
+	assert.Empty(t, m.Waiting)
+}
@@ -138,3 +138,40 @@ func TestAncestorsSimple(t *testing.T) {
 
 	}
 }
+
+func TestWorkflowExecutePermission(t *testing.T) {
+	w := Workflow{
+		ProjectIntegrations: map[int64]ProjectIntegration{
+			1: {ID: 1, Model: IntegrationModel{Name: "Kubernetes", Deployment: true}},
+			2: {ID: 2, Model: IntegrationModel{Name: KafkaIntegrationModel}},
+		},
+	}
+	assert.Equal(t, PermissionReadExecute, w.ExecutePermission(&Node{Name: "build"}))
+	assert.Equal(t, PermissionReadExecute, w.ExecutePermission(&Node{Name: "events", Context: &NodeContext{ProjectIntegrationID: 2}}))
+	assert.Equal(t, PermissionReadExecuteDeploy, w.ExecutePermission(&Node{Name: "deploy", Context: &NodeContext{ProjectIntegrationID: 1}}))
+
+	assert.Equal(t, PermissionReadExecute, Permissions{Readable: true, Executable: true}.Level())
+	assert.Equal(t, PermissionReadExecuteDeploy, Permissions{Readable: true, Executable: true, Deployable: true}.Level())
+	assert.Equal(t, PermissionReadWriteExecute, Permissions{Readable: true, Executable: true, Deployable: true, Writable: true}.Level())
+}
+
+func TestWorkflowDeploymentPlanParent(t *testing.T) {
+	w := Workflow{
+		WorkflowData: WorkflowData{
+			Node: Node{ID: 1, Name: "build", Triggers: []NodeTrigger{
+				{ChildNode: Node{ID: 2, Name: "plan-prod", Context: &NodeContext{ProjectIntegrationID: 10, DeploymentPlan: true}}},
+				{ChildNode: Node{ID: 3, Name: "plan-staging", Context: &NodeContext{ProjectIntegrationID: 20, DeploymentPlan: true}}},
+			}},
+		},
+	}
+	apply := &Node{ID: 4, Name: "deploy-prod", Context: &NodeContext{ProjectIntegrationID: 10}}
+
+	parent := w.DeploymentPlanParent(apply, []int64{2})
+	if assert.NotNil(t, parent) {
+		assert.Equal(t, "plan-prod", parent.Name)
+	}
+	assert.Nil(t, w.DeploymentPlanParent(apply, []int64{1}))
+	assert.Nil(t, w.DeploymentPlanParent(apply, []int64{3}))
+	assert.Nil(t, w.DeploymentPlanParent(&Node{ID: 5, Name: "plan-again", Context: &NodeContext{ProjectIntegrationID: 10, DeploymentPlan: true}}, []int64{2}))
+	assert.Nil(t, w.DeploymentPlanParent(&Node{ID: 6, Name: "notify"}, []int64{2}))
+}
@@ -0,0 +1,49 @@
+package sdk
+
+// Priority classes of workflows and workflow runs. Jobs of a critical run are scheduled and booked by hatcheries
+// before the default ones, jobs of a batch run are scheduled after them.
+const (
+	WorkflowPriorityCritical = "critical"
+	WorkflowPriorityDefault  = "default"
+	WorkflowPriorityBatch    = "batch"
+)
+
+// WorkflowPriorities contains all available priority classes.
+var WorkflowPriorities = []string{WorkflowPriorityCritical, WorkflowPriorityDefault, WorkflowPriorityBatch}
+
+// IsValidWorkflowPriority returns true if given priority class exists, an empty priority is the default one.
+func IsValidWorkflowPriority(priority string) bool {
+	if priority == "" {
+		return true
+	}
+	for _, p := range WorkflowPriorities {
+		if p == priority {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkflowPriorityRank returns the rank of given priority class, lower ranks are scheduled first.
+func WorkflowPriorityRank(priority string) int {
+	switch priority {
+	case WorkflowPriorityCritical:
+		return 0
+	case WorkflowPriorityBatch:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// WorkflowPriorityFromRank returns the priority class of given rank.
+func WorkflowPriorityFromRank(rank int) string {
+	switch rank {
+	case 0:
+		return WorkflowPriorityCritical
+	case 2:
+		return WorkflowPriorityBatch
+	default:
+		return WorkflowPriorityDefault
+	}
+}
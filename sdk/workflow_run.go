@@ -47,11 +47,16 @@ type WorkflowRun struct {
 	WorkflowNodeRuns map[int64][]WorkflowNodeRun      `json:"nodes,omitempty" db:"-"`
 	Infos            []WorkflowRunInfo                `json:"infos,omitempty" db:"-"`
 	Tags             []WorkflowRunTag                 `json:"tags,omitempty" db:"-" cli:"tags"`
+	Annotations      []WorkflowRunAnnotation          `json:"annotations,omitempty" db:"-" cli:"-"`
+	Results          []WorkflowRunResult              `json:"results,omitempty" db:"-" cli:"-"`
+	Maintenance      *MaintenanceWindow               `json:"maintenance,omitempty" db:"-" cli:"-"`
 	LastSubNumber    int64                            `json:"last_subnumber" db:"last_sub_num"`
 	LastExecution    time.Time                        `json:"last_execution" db:"last_execution" cli:"last_execution"`
 	ToDelete         bool                             `json:"to_delete" db:"to_delete" cli:"-"`
 	JoinTriggersRun  map[int64]WorkflowNodeTriggerRun `json:"join_triggers_run,omitempty" db:"-"`
 	Header           WorkflowRunHeaders               `json:"header,omitempty" db:"-"`
+	Priority         string                           `json:"priority,omitempty" db:"priority"`
+	WorkflowVersion  int64                            `json:"workflow_version,omitempty" db:"workflow_version"`
 }
 
 // WorkflowNodeRunRelease represents the request struct use by release builtin action for workflow
@@ -68,6 +73,7 @@ type WorkflowRunPostHandlerOption struct {
 	Manual      *WorkflowNodeRunManual    `json:"manual,omitempty"`
 	Number      *int64                    `json:"number,omitempty"`
 	FromNodeIDs []int64                   `json:"from_nodes,omitempty"`
+	Priority    string                    `json:"priority,omitempty"`
 }
 
 //WorkflowRunNumber contains a workflow run number
@@ -331,6 +337,9 @@ type WorkflowNodeRunArtifact struct {
 	TempURL              string    `json:"temp_url,omitempty" db:"-"`
 	TempURLSecretKey     string    `json:"-" db:"-"`
 	ProjectIntegrationID *int64    `json:"project_integration_id" db:"project_integration_id"`
+	Pinned               bool      `json:"pinned" db:"pinned" cli:"pinned"`
+	ScanStatus           string    `json:"scan_status,omitempty" db:"scan_status" cli:"scan_status"`
+	ScanReport           string    `json:"scan_report,omitempty" db:"scan_report"`
 }
 
 // Equal returns true if w WorkflowNodeRunArtifact equals c
@@ -353,27 +362,37 @@ func (w WorkflowNodeRunArtifact) Equal(c WorkflowNodeRunArtifact) bool {
 
 //WorkflowNodeJobRun represents an job to be run
 type WorkflowNodeJobRun struct {
-	ProjectID                 int64              `json:"project_id"`
-	ID                        int64              `json:"id"`
-	WorkflowNodeRunID         int64              `json:"workflow_node_run_id,omitempty"`
-	Job                       ExecutedJob        `json:"job"`
-	Parameters                []Parameter        `json:"parameters,omitempty"`
-	Status                    string             `json:"status"`
-	Retry                     int                `json:"retry"`
-	Queued                    time.Time          `json:"queued,omitempty" cli:"queued"`
-	QueuedSeconds             int64              `json:"queued_seconds,omitempty"`
-	Start                     time.Time          `json:"start,omitempty"`
-	Done                      time.Time          `json:"done,omitempty"`
-	Model                     string             `json:"model,omitempty"`
-	ModelType                 string             `json:"model_type,omitempty"`
-	BookedBy                  Service            `json:"bookedby,omitempty"`
-	SpawnInfos                []SpawnInfo        `json:"spawninfos"`
-	ExecGroups                Groups             `json:"exec_groups"`
-	IntegrationPluginBinaries []GRPCPluginBinary `json:"integration_plugin_binaries,omitempty"`
-	Header                    WorkflowRunHeaders `json:"header,omitempty"`
-	ContainsService           bool               `json:"contains_service,omitempty"`
-	HatcheryName              string             `json:"hatchery_name,omitempty"`
-	WorkerName                string             `json:"worker_name,omitempty"`
+	ProjectID                 int64               `json:"project_id"`
+	ID                        int64               `json:"id"`
+	WorkflowNodeRunID         int64               `json:"workflow_node_run_id,omitempty"`
+	Job                       ExecutedJob         `json:"job"`
+	Parameters                []Parameter         `json:"parameters,omitempty"`
+	Status                    string              `json:"status"`
+	Retry                     int                 `json:"retry"`
+	RetryAfter                *time.Time          `json:"retry_after,omitempty"`
+	InfraError                string              `json:"infra_error,omitempty"`
+	Queued                    time.Time           `json:"queued,omitempty" cli:"queued"`
+	QueuedSeconds             int64               `json:"queued_seconds,omitempty"`
+	Start                     time.Time           `json:"start,omitempty"`
+	Done                      time.Time           `json:"done,omitempty"`
+	Model                     string              `json:"model,omitempty"`
+	ModelType                 string              `json:"model_type,omitempty"`
+	BookedBy                  Service             `json:"bookedby,omitempty"`
+	SpawnInfos                []SpawnInfo         `json:"spawninfos"`
+	ExecGroups                Groups              `json:"exec_groups"`
+	IntegrationPluginBinaries []GRPCPluginBinary  `json:"integration_plugin_binaries,omitempty"`
+	IntegrationPluginContract *GRPCPluginContract `json:"integration_plugin_contract,omitempty"`
+	Header                    WorkflowRunHeaders  `json:"header,omitempty"`
+	ContainsService           bool                `json:"contains_service,omitempty"`
+	HatcheryName              string              `json:"hatchery_name,omitempty"`
+	WorkerName                string              `json:"worker_name,omitempty"`
+	WorkerFeatures            []string            `json:"worker_features,omitempty"`
+	WorkerMinVersion          string              `json:"worker_min_version,omitempty"`
+	Priority                  string              `json:"priority,omitempty"`
+	QueuePosition             int                 `json:"queue_position,omitempty"`
+	EstimatedDuration         int64               `json:"estimated_duration,omitempty"`
+	EstimatedStart            *time.Time          `json:"estimated_start,omitempty"`
+	EstimatedDone             *time.Time          `json:"estimated_done,omitempty"`
 }
 
 // WorkflowNodeJobRunSummary is a light representation of WorkflowNodeJobRun for CDS event
@@ -465,6 +484,54 @@ func (w *WorkflowNodeRunArtifact) GetPath() string {
 
 type WorkflowQueue []WorkflowNodeJobRun
 
+// Types of events sent on the workflow queue stream.
+const (
+	WorkflowQueueStreamEventJob       = "job"
+	WorkflowQueueStreamEventHeartbeat = "heartbeat"
+)
+
+// WorkflowQueueStreamEvent is sent to hatcheries subscribed to the workflow queue stream. The cursor
+// can be given back to resume the stream without receiving again jobs that were already sent.
+type WorkflowQueueStreamEvent struct {
+	Type   string              `json:"type"`
+	Cursor int64               `json:"cursor"`
+	Job    *WorkflowNodeJobRun `json:"job,omitempty"`
+}
+
+// WorkflowRunBadge describes the status of the latest run of a workflow, it's compatible with the shields.io
+// endpoint format.
+type WorkflowRunBadge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// NewWorkflowRunBadge returns a badge for given run status, an empty status means that there is no run.
+func NewWorkflowRunBadge(label, status string) WorkflowRunBadge {
+	b := WorkflowRunBadge{
+		SchemaVersion: 1,
+		Label:         label,
+		Message:       strings.ToLower(status),
+	}
+	switch status {
+	case StatusSuccess:
+		b.Color = "brightgreen"
+	case StatusFail:
+		b.Color = "red"
+	case StatusWaiting, StatusBuilding, StatusChecking, StatusPending:
+		b.Color = "blue"
+	case StatusStopped:
+		b.Color = "orange"
+	case "":
+		b.Message = "no run"
+		b.Color = "lightgrey"
+	default:
+		b.Color = "lightgrey"
+	}
+	return b
+}
+
 func (q WorkflowQueue) Sort() {
 	//Count the number of WorkflowNodeJobRun per project_id
 	n := make(map[int64]int, len(q))
@@ -475,6 +542,11 @@ func (q WorkflowQueue) Sort() {
 	}
 
 	sort.Slice(q, func(i, j int) bool {
+		r1 := WorkflowPriorityRank(q[i].Priority)
+		r2 := WorkflowPriorityRank(q[j].Priority)
+		if r1 != r2 {
+			return r1 < r2
+		}
 		p1 := n[q[i].ProjectID]
 		p2 := n[q[j].ProjectID]
 		return p1 < p2
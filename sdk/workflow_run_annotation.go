@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"net/url"
+	"time"
+)
+
+// Types of workflow run annotations.
+const (
+	WorkflowRunAnnotationTypeLink     = "link"
+	WorkflowRunAnnotationTypeValue    = "value"
+	WorkflowRunAnnotationTypeMarkdown = "markdown"
+)
+
+// WorkflowRunAnnotation is a typed information attached to a workflow run by a job or an external system, like a
+// deployed version or the URL of a ticket. The key of an annotation is unique for a run.
+type WorkflowRunAnnotation struct {
+	ID                int64     `json:"id" db:"id" cli:"-"`
+	WorkflowRunID     int64     `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	WorkflowNodeRunID int64     `json:"workflow_node_run_id,omitempty" db:"workflow_node_run_id" cli:"-"`
+	Type              string    `json:"type" db:"type" cli:"type"`
+	Key               string    `json:"key" db:"key" cli:"key,key"`
+	Value             string    `json:"value" db:"value" cli:"value"`
+	Author            string    `json:"author" db:"author" cli:"author"`
+	Created           time.Time `json:"created" db:"created" cli:"created"`
+}
+
+// IsValid returns an error if the annotation is not valid, an empty type is a value.
+func (a *WorkflowRunAnnotation) IsValid() error {
+	if a.Type == "" {
+		a.Type = WorkflowRunAnnotationTypeValue
+	}
+	if a.Key == "" || len(a.Key) > 256 {
+		return NewErrorFrom(ErrWrongRequest, "invalid annotation key, it should not be empty and have at most 256 characters")
+	}
+	if a.Value == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid empty value for annotation %s", a.Key)
+	}
+	switch a.Type {
+	case WorkflowRunAnnotationTypeValue:
+		if len(a.Value) > 1024 {
+			return NewErrorFrom(ErrWrongRequest, "value of annotation %s should have at most 1024 characters", a.Key)
+		}
+	case WorkflowRunAnnotationTypeLink:
+		u, err := url.Parse(a.Value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return NewErrorFrom(ErrWrongRequest, "value of annotation %s should be an http or https URL", a.Key)
+		}
+	case WorkflowRunAnnotationTypeMarkdown:
+		if len(a.Value) > 65536 {
+			return NewErrorFrom(ErrWrongRequest, "value of annotation %s should have at most 65536 characters", a.Key)
+		}
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid type %q for annotation %s, should be %s, %s or %s", a.Type, a.Key,
+			WorkflowRunAnnotationTypeLink, WorkflowRunAnnotationTypeValue, WorkflowRunAnnotationTypeMarkdown)
+	}
+	return nil
+}
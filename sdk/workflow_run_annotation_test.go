@@ -0,0 +1,21 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowRunAnnotationIsValid(t *testing.T) {
+	a := WorkflowRunAnnotation{Key: "deployed version", Value: "1.2.3"}
+	assert.NoError(t, a.IsValid())
+	assert.Equal(t, WorkflowRunAnnotationTypeValue, a.Type)
+
+	assert.NoError(t, (&WorkflowRunAnnotation{Type: WorkflowRunAnnotationTypeLink, Key: "ticket", Value: "https://jira.example.com/browse/CDS-1"}).IsValid())
+	assert.NoError(t, (&WorkflowRunAnnotation{Type: WorkflowRunAnnotationTypeMarkdown, Key: "notes", Value: "# Release\n* fix"}).IsValid())
+
+	assert.Error(t, (&WorkflowRunAnnotation{Value: "1.2.3"}).IsValid())
+	assert.Error(t, (&WorkflowRunAnnotation{Key: "version"}).IsValid())
+	assert.Error(t, (&WorkflowRunAnnotation{Type: WorkflowRunAnnotationTypeLink, Key: "ticket", Value: "javascript:alert(1)"}).IsValid())
+	assert.Error(t, (&WorkflowRunAnnotation{Type: "list", Key: "version", Value: "1.2.3"}).IsValid())
+}
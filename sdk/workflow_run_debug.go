@@ -0,0 +1,52 @@
+package sdk
+
+import (
+	"strconv"
+	"time"
+)
+
+// WorkflowNodeJobRunDebugHoldParameter is the parameter that enables the debug mode of a job, its value is the number
+// of minutes a failed job is held on its worker so a user can attach a shell to inspect the workspace.
+const WorkflowNodeJobRunDebugHoldParameter = "cds.debug.hold"
+
+// WorkflowNodeJobRunDebugMaxHold is the max duration a failed job can be held on its worker.
+const WorkflowNodeJobRunDebugMaxHold = 60 * time.Minute
+
+// Types of the messages exchanged with the debug shell of a held job.
+const (
+	WorkflowNodeJobRunDebugMessageInput  = "input"
+	WorkflowNodeJobRunDebugMessageOutput = "output"
+	WorkflowNodeJobRunDebugMessageExit   = "exit"
+)
+
+// WorkflowNodeJobRunDebugSession is a failed job held on its worker for debugging.
+type WorkflowNodeJobRunDebugSession struct {
+	WorkflowNodeJobRunID int64     `json:"workflow_node_job_run_id"`
+	WorkerName           string    `json:"worker_name"`
+	Expires              time.Time `json:"expires"`
+}
+
+// WorkflowNodeJobRunDebugMessage is a message exchanged with the debug shell of a held job. Inputs are sent by the
+// user to the shell, outputs are sent by the shell to the user, and exit is sent when the shell exits.
+type WorkflowNodeJobRunDebugMessage struct {
+	Type string `json:"type"`
+	Data []byte `json:"data,omitempty"`
+}
+
+// WorkflowNodeJobRunDebugHold returns how long a failed job should be held for debugging, given its parameters.
+// It returns 0 if the debug mode is not enabled.
+func WorkflowNodeJobRunDebugHold(params []Parameter) (time.Duration, error) {
+	v := ParameterValue(params, WorkflowNodeJobRunDebugHoldParameter)
+	if v == "" {
+		return 0, nil
+	}
+	minutes, err := strconv.Atoi(v)
+	if err != nil || minutes < 0 {
+		return 0, NewErrorFrom(ErrWrongRequest, "invalid value %q for parameter %s, it should be a number of minutes", v, WorkflowNodeJobRunDebugHoldParameter)
+	}
+	hold := time.Duration(minutes) * time.Minute
+	if hold > WorkflowNodeJobRunDebugMaxHold {
+		hold = WorkflowNodeJobRunDebugMaxHold
+	}
+	return hold, nil
+}
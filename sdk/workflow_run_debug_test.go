@@ -0,0 +1,26 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowNodeJobRunDebugHold(t *testing.T) {
+	hold, err := WorkflowNodeJobRunDebugHold(nil)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), hold)
+
+	hold, err = WorkflowNodeJobRunDebugHold([]Parameter{{Name: WorkflowNodeJobRunDebugHoldParameter, Value: "15"}})
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, hold)
+
+	hold, err = WorkflowNodeJobRunDebugHold([]Parameter{{Name: WorkflowNodeJobRunDebugHoldParameter, Value: "600"}})
+	require.NoError(t, err)
+	assert.Equal(t, WorkflowNodeJobRunDebugMaxHold, hold)
+
+	_, err = WorkflowNodeJobRunDebugHold([]Parameter{{Name: WorkflowNodeJobRunDebugHoldParameter, Value: "ten"}})
+	assert.Error(t, err)
+}
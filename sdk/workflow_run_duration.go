@@ -0,0 +1,160 @@
+package sdk
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Kinds of duration of a workflow run.
+const (
+	WorkflowRunDurationKindNode = "node"
+	WorkflowRunDurationKindJob  = "job"
+	WorkflowRunDurationKindStep = "step"
+)
+
+// Default values used to compare the durations of a workflow run with a baseline.
+const (
+	WorkflowRunCompareDefaultBaselineSize = 10
+	WorkflowRunCompareMaxBaselineSize     = 50
+	WorkflowRunCompareDefaultThreshold    = 1.5
+	WorkflowRunCompareMinDelta            = 10 * time.Second
+)
+
+// WorkflowRunDuration is the duration of a node, a job or a step of a workflow run. Its name is the path of the
+// element in the run, ie. "build/Compile/1 GitClone" for the first step of the job Compile of the node build.
+type WorkflowRunDuration struct {
+	Kind     string
+	Name     string
+	Duration time.Duration
+}
+
+// Durations returns the durations of the terminated nodes, jobs and steps of the run, nodes are ordered by start.
+func (r WorkflowRun) Durations() []WorkflowRunDuration {
+	nodeRuns := make([]WorkflowNodeRun, 0, len(r.WorkflowNodeRuns))
+	for _, nrs := range r.WorkflowNodeRuns {
+		if len(nrs) > 0 {
+			nodeRuns = append(nodeRuns, nrs[0])
+		}
+	}
+	sort.Slice(nodeRuns, func(i, j int) bool {
+		if nodeRuns[i].Start.Equal(nodeRuns[j].Start) {
+			return nodeRuns[i].WorkflowNodeName < nodeRuns[j].WorkflowNodeName
+		}
+		return nodeRuns[i].Start.Before(nodeRuns[j].Start)
+	})
+
+	var res []WorkflowRunDuration
+	for _, nr := range nodeRuns {
+		if !StatusIsTerminated(nr.Status) || !nr.Done.After(nr.Start) {
+			continue
+		}
+		res = append(res, WorkflowRunDuration{Kind: WorkflowRunDurationKindNode, Name: nr.WorkflowNodeName, Duration: nr.Done.Sub(nr.Start)})
+		for _, s := range nr.Stages {
+			for _, rj := range s.RunJobs {
+				if !StatusIsTerminated(rj.Status) || !rj.Done.After(rj.Start) {
+					continue
+				}
+				jobName := nr.WorkflowNodeName + "/" + rj.Job.Action.Name
+				res = append(res, WorkflowRunDuration{Kind: WorkflowRunDurationKindJob, Name: jobName, Duration: rj.Done.Sub(rj.Start)})
+				for _, ss := range rj.Job.StepStatus {
+					if !StatusIsTerminated(ss.Status) || !ss.Done.After(ss.Start) || ss.StepOrder >= len(rj.Job.Action.Actions) {
+						continue
+					}
+					step := rj.Job.Action.Actions[ss.StepOrder]
+					stepName := step.StepName
+					if stepName == "" {
+						stepName = step.Name
+					}
+					res = append(res, WorkflowRunDuration{
+						Kind:     WorkflowRunDurationKindStep,
+						Name:     fmt.Sprintf("%s/%d %s", jobName, ss.StepOrder+1, stepName),
+						Duration: ss.Done.Sub(ss.Start),
+					})
+				}
+			}
+		}
+	}
+	return res
+}
+
+// WorkflowRunDurationDelta compares a duration of a workflow run with the median duration of the same element
+// in the baseline runs. Durations are given in seconds.
+type WorkflowRunDurationDelta struct {
+	Kind     string  `json:"kind" cli:"kind"`
+	Name     string  `json:"name" cli:"name,key"`
+	Duration int64   `json:"duration" cli:"duration"`
+	Baseline int64   `json:"baseline" cli:"baseline"`
+	Delta    int64   `json:"delta" cli:"delta"`
+	Ratio    float64 `json:"ratio" cli:"ratio"`
+	Samples  int     `json:"samples" cli:"samples"`
+	Outlier  bool    `json:"outlier" cli:"outlier"`
+}
+
+// WorkflowRunDurationComparison is the comparison of the durations of a workflow run with a baseline, the median
+// of the last successful runs on the default branch.
+type WorkflowRunDurationComparison struct {
+	Number    int64                      `json:"num"`
+	Branch    string                     `json:"branch,omitempty"`
+	Baseline  []int64                    `json:"baseline"`
+	Threshold float64                    `json:"threshold"`
+	Deltas    []WorkflowRunDurationDelta `json:"deltas"`
+	Outliers  []WorkflowRunDurationDelta `json:"outliers"`
+}
+
+// CompareWorkflowRunDurations compares the durations of given run with the median durations of the baseline runs.
+// An element is an outlier if it is slower than its median by the threshold ratio and by at least minDelta,
+// outliers are sorted by decreasing delta.
+func CompareWorkflowRunDurations(run WorkflowRun, baseline []WorkflowRun, threshold float64, minDelta time.Duration) WorkflowRunDurationComparison {
+	res := WorkflowRunDurationComparison{
+		Number:    run.Number,
+		Baseline:  make([]int64, 0, len(baseline)),
+		Threshold: threshold,
+		Deltas:    []WorkflowRunDurationDelta{},
+		Outliers:  []WorkflowRunDurationDelta{},
+	}
+
+	samples := make(map[string][]time.Duration)
+	for _, b := range baseline {
+		res.Baseline = append(res.Baseline, b.Number)
+		for _, d := range b.Durations() {
+			samples[d.Name] = append(samples[d.Name], d.Duration)
+		}
+	}
+
+	for _, d := range run.Durations() {
+		delta := WorkflowRunDurationDelta{
+			Kind:     d.Kind,
+			Name:     d.Name,
+			Duration: int64(d.Duration.Seconds()),
+			Samples:  len(samples[d.Name]),
+		}
+		if delta.Samples > 0 {
+			median := medianDuration(samples[d.Name])
+			delta.Baseline = int64(median.Seconds())
+			delta.Delta = int64((d.Duration - median).Seconds())
+			if median > 0 {
+				delta.Ratio = float64(d.Duration) / float64(median)
+			}
+			delta.Outlier = d.Duration-median >= minDelta && (median == 0 || delta.Ratio >= threshold)
+		}
+		res.Deltas = append(res.Deltas, delta)
+		if delta.Outlier {
+			res.Outliers = append(res.Outliers, delta)
+		}
+	}
+	sort.SliceStable(res.Outliers, func(i, j int) bool { return res.Outliers[i].Delta > res.Outliers[j].Delta })
+
+	return res
+}
+
+func medianDuration(ds []time.Duration) time.Duration {
+	sorted := make([]time.Duration, len(ds))
+	copy(sorted, ds)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,90 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWorkflowRunWithDurations(number int64, build, step time.Duration) WorkflowRun {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return WorkflowRun{
+		Number: number,
+		WorkflowNodeRuns: map[int64][]WorkflowNodeRun{
+			1: {{
+				WorkflowNodeName: "build",
+				Status:           StatusSuccess,
+				Start:            start,
+				Done:             start.Add(build),
+				Stages: []Stage{{
+					RunJobs: []WorkflowNodeJobRun{{
+						Status: StatusSuccess,
+						Start:  start,
+						Done:   start.Add(build),
+						Job: ExecutedJob{
+							Job: Job{Action: Action{
+								Name:    "Compile",
+								Actions: []Action{{Name: "GitClone"}, {Name: "Script", StepName: "make"}},
+							}},
+							StepStatus: []StepStatus{
+								{StepOrder: 0, Status: StatusSuccess, Start: start, Done: start.Add(5 * time.Second)},
+								{StepOrder: 1, Status: StatusSuccess, Start: start.Add(5 * time.Second), Done: start.Add(5*time.Second + step)},
+							},
+						},
+					}},
+				}},
+			}},
+			2: {{
+				WorkflowNodeName: "deploy",
+				Status:           StatusBuilding,
+				Start:            start.Add(build),
+			}},
+		},
+	}
+}
+
+func TestWorkflowRunDurations(t *testing.T) {
+	ds := testWorkflowRunWithDurations(1, time.Minute, 50*time.Second).Durations()
+	assert.Equal(t, []WorkflowRunDuration{
+		{Kind: WorkflowRunDurationKindNode, Name: "build", Duration: time.Minute},
+		{Kind: WorkflowRunDurationKindJob, Name: "build/Compile", Duration: time.Minute},
+		{Kind: WorkflowRunDurationKindStep, Name: "build/Compile/1 GitClone", Duration: 5 * time.Second},
+		{Kind: WorkflowRunDurationKindStep, Name: "build/Compile/2 make", Duration: 50 * time.Second},
+	}, ds)
+}
+
+func TestCompareWorkflowRunDurations(t *testing.T) {
+	baseline := []WorkflowRun{
+		testWorkflowRunWithDurations(9, time.Minute, 50*time.Second),
+		testWorkflowRunWithDurations(8, 70*time.Second, 60*time.Second),
+		testWorkflowRunWithDurations(7, 10*time.Minute, 50*time.Second),
+	}
+	run := testWorkflowRunWithDurations(10, 3*time.Minute, 170*time.Second)
+
+	c := CompareWorkflowRunDurations(run, baseline, WorkflowRunCompareDefaultThreshold, WorkflowRunCompareMinDelta)
+	assert.Equal(t, int64(10), c.Number)
+	assert.Equal(t, []int64{9, 8, 7}, c.Baseline)
+	require.Len(t, c.Deltas, 4)
+
+	assert.Equal(t, WorkflowRunDurationDelta{
+		Kind:     WorkflowRunDurationKindNode,
+		Name:     "build",
+		Duration: 180,
+		Baseline: 70,
+		Delta:    110,
+		Ratio:    180.0 / 70.0,
+		Samples:  3,
+		Outlier:  true,
+	}, c.Deltas[0])
+	assert.False(t, c.Deltas[2].Outlier, "an unchanged step is not an outlier")
+
+	require.Len(t, c.Outliers, 3)
+	assert.Equal(t, "build/Compile/2 make", c.Outliers[0].Name)
+	assert.Equal(t, int64(120), c.Outliers[0].Delta)
+
+	c = CompareWorkflowRunDurations(run, nil, WorkflowRunCompareDefaultThreshold, WorkflowRunCompareMinDelta)
+	assert.Empty(t, c.Outliers)
+	assert.Equal(t, 0, c.Deltas[0].Samples)
+}
@@ -0,0 +1,29 @@
+package sdk
+
+// WorkflowRunExplain explains which nodes of a workflow would run for a hook event or a manual run request, and why.
+// The parents of a node are considered successful.
+type WorkflowRunExplain struct {
+	Hook  *WorkflowRunExplainHook  `json:"hook,omitempty"`
+	Nodes []WorkflowRunExplainNode `json:"nodes"`
+}
+
+// WorkflowRunExplainHook explains if a hook event would start a run.
+type WorkflowRunExplainHook struct {
+	UUID       string                    `json:"uuid"`
+	Model      string                    `json:"model"`
+	Run        bool                      `json:"run"`
+	Reason     string                    `json:"reason"`
+	Conditions []WorkflowConditionResult `json:"conditions,omitempty"`
+	LuaScript  string                    `json:"lua_script,omitempty"`
+}
+
+// WorkflowRunExplainNode explains if a node would run, with the evaluation of its conditions.
+type WorkflowRunExplainNode struct {
+	NodeID     int64                     `json:"node_id" cli:"-"`
+	Name       string                    `json:"name" cli:"node,key"`
+	Type       string                    `json:"type" cli:"type"`
+	Run        bool                      `json:"run" cli:"run"`
+	Reason     string                    `json:"reason" cli:"reason"`
+	Conditions []WorkflowConditionResult `json:"conditions,omitempty" cli:"-"`
+	LuaScript  string                    `json:"lua_script,omitempty" cli:"-"`
+}
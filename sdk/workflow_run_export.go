@@ -0,0 +1,77 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Files of a workflow run export archive that describe its content.
+const (
+	WorkflowRunExportManifestFile  = "manifest.json"
+	WorkflowRunExportSignatureFile = "manifest.jws"
+)
+
+// WorkflowRunExportManifest lists the files of a workflow run export archive with their checksum. The manifest is
+// signed by the API, the signature is given in the archive as a JWS that can be verified with the API public key.
+type WorkflowRunExportManifest struct {
+	ProjectKey   string                  `json:"project_key"`
+	WorkflowName string                  `json:"workflow_name"`
+	Number       int64                   `json:"num"`
+	Status       string                  `json:"status"`
+	Exported     time.Time               `json:"exported"`
+	ExportedBy   string                  `json:"exported_by"`
+	Files        []WorkflowRunExportFile `json:"files"`
+}
+
+// WorkflowRunExportFile is a file of a workflow run export archive.
+type WorkflowRunExportFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Add appends a file to the manifest.
+func (m *WorkflowRunExportManifest) Add(name string, content []byte) {
+	sum := sha256.Sum256(content)
+	m.Files = append(m.Files, WorkflowRunExportFile{
+		Name:   name,
+		Size:   int64(len(content)),
+		SHA256: hex.EncodeToString(sum[:]),
+	})
+}
+
+// Check returns an error if given files don't match the manifest, files should contain all the files of the archive
+// except the manifest and its signature.
+func (m WorkflowRunExportManifest) Check(files map[string][]byte) error {
+	if len(files) != len(m.Files) {
+		return NewErrorFrom(ErrWrongRequest, "archive contains %d files, manifest lists %d files", len(files), len(m.Files))
+	}
+	for _, f := range m.Files {
+		content, ok := files[f.Name]
+		if !ok {
+			return NewErrorFrom(ErrWrongRequest, "file %s not found in archive", f.Name)
+		}
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != f.SHA256 {
+			return NewErrorFrom(ErrWrongRequest, "invalid checksum for file %s", f.Name)
+		}
+	}
+	return nil
+}
+
+// WorkflowRunExportApproval is a manual run of a workflow node, recorded in a workflow run export.
+type WorkflowRunExportApproval struct {
+	NodeName  string    `json:"node_name"`
+	SubNumber int64     `json:"subnumber"`
+	Username  string    `json:"username"`
+	Fullname  string    `json:"fullname"`
+	Email     string    `json:"email"`
+	Date      time.Time `json:"date"`
+}
+
+// ExportName returns the name of the export archive of the workflow run.
+func (r WorkflowRun) ExportName() string {
+	return fmt.Sprintf("%s-%d.%d-export.tar.gz", r.Workflow.Name, r.Number, r.LastSubNumber)
+}
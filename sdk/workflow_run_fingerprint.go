@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// JobFingerprint describes the environment in which a job was run by a worker: system, worker, model and versions of
+// the binaries required by the job. Only the names of the environment variables are collected, never their values.
+type JobFingerprint struct {
+	OS            string            `json:"os"`
+	Arch          string            `json:"arch"`
+	OSRelease     string            `json:"os_release,omitempty"`
+	WorkerVersion string            `json:"worker_version"`
+	Model         string            `json:"model,omitempty"`
+	Image         string            `json:"image,omitempty"`
+	ImageDigest   string            `json:"image_digest,omitempty"`
+	Tools         map[string]string `json:"tools,omitempty"`
+	EnvVarNames   []string          `json:"env_var_names,omitempty"`
+}
+
+// Value returns driver.Value from job fingerprint.
+func (f JobFingerprint) Value() (driver.Value, error) {
+	j, err := json.Marshal(f)
+	return j, WrapError(err, "cannot marshal JobFingerprint")
+}
+
+// Scan job fingerprint.
+func (f *JobFingerprint) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, f), "cannot unmarshal JobFingerprint")
+}
+
+// JobFingerprintDifference is a field of a job fingerprint that differs from the reference fingerprint. Tools are
+// compared by their name, prefixed with tool., and environment variables by their name, prefixed with env.
+type JobFingerprintDifference struct {
+	Field     string `json:"field" cli:"field,key"`
+	Current   string `json:"current" cli:"current"`
+	Reference string `json:"reference" cli:"reference"`
+}
+
+// Diff returns the fields of the fingerprint that differ from the reference fingerprint, ordered by field.
+func (f JobFingerprint) Diff(ref JobFingerprint) []JobFingerprintDifference {
+	var diffs []JobFingerprintDifference
+	add := func(field, current, reference string) {
+		if current != reference {
+			diffs = append(diffs, JobFingerprintDifference{Field: field, Current: current, Reference: reference})
+		}
+	}
+
+	add("arch", f.Arch, ref.Arch)
+	add("image", f.Image, ref.Image)
+	add("image_digest", f.ImageDigest, ref.ImageDigest)
+	add("model", f.Model, ref.Model)
+	add("os", f.OS, ref.OS)
+	add("os_release", f.OSRelease, ref.OSRelease)
+	add("worker_version", f.WorkerVersion, ref.WorkerVersion)
+
+	tools := make(map[string]struct{}, len(f.Tools)+len(ref.Tools))
+	for name := range f.Tools {
+		tools[name] = struct{}{}
+	}
+	for name := range ref.Tools {
+		tools[name] = struct{}{}
+	}
+	for name := range tools {
+		add("tool."+name, f.Tools[name], ref.Tools[name])
+	}
+
+	const set = "set"
+	for _, name := range f.EnvVarNames {
+		if !IsInArray(name, ref.EnvVarNames) {
+			add("env."+name, set, "")
+		}
+	}
+	for _, name := range ref.EnvVarNames {
+		if !IsInArray(name, f.EnvVarNames) {
+			add("env."+name, "", set)
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
+
+// WorkflowRunJobFingerprint is the fingerprint of the environment of a job of a workflow run, with the status of the job.
+type WorkflowRunJobFingerprint struct {
+	ID                   int64          `json:"id" db:"id"`
+	WorkflowRunID        int64          `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowNodeRunID    int64          `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	WorkflowNodeJobRunID int64          `json:"workflow_node_job_run_id" db:"workflow_node_job_run_id"`
+	Number               int64          `json:"num" db:"num"`
+	SubNumber            int64          `json:"sub_num" db:"sub_num"`
+	NodeName             string         `json:"node_name" db:"node_name"`
+	JobName              string         `json:"job_name" db:"job_name"`
+	Status               string         `json:"status" db:"status"`
+	Fingerprint          JobFingerprint `json:"fingerprint" db:"fingerprint"`
+	Created              time.Time      `json:"created" db:"created"`
+}
+
+// WorkflowRunJobFingerprintComparison compares the fingerprint of a job of a workflow run with the fingerprint of the
+// last successful run of the same job. The reference number is 0 if the job never succeeded before.
+type WorkflowRunJobFingerprintComparison struct {
+	NodeName        string                     `json:"node_name"`
+	JobName         string                     `json:"job_name"`
+	SubNumber       int64                      `json:"sub_num"`
+	Status          string                     `json:"status"`
+	ReferenceNumber int64                      `json:"reference_num"`
+	Differences     []JobFingerprintDifference `json:"differences"`
+}
@@ -0,0 +1,37 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobFingerprintDiff(t *testing.T) {
+	ref := JobFingerprint{
+		OS:            "linux",
+		Arch:          "amd64",
+		OSRelease:     "Debian GNU/Linux 10 (buster)",
+		WorkerVersion: "0.45.0",
+		Model:         "shared.infra/debian",
+		Image:         "debian:10",
+		Tools:         map[string]string{"git": "git version 2.20.1", "make": "GNU Make 4.2.1"},
+		EnvVarNames:   []string{"HOME", "HTTP_PROXY", "PATH"},
+	}
+	assert.Empty(t, ref.Diff(ref))
+
+	f := ref
+	f.WorkerVersion = "0.46.0"
+	f.ImageDigest = "debian@sha256:abc"
+	f.Tools = map[string]string{"git": "git version 2.30.2", "npm": "6.14.4"}
+	f.EnvVarNames = []string{"GOPATH", "HOME", "PATH"}
+
+	assert.Equal(t, []JobFingerprintDifference{
+		{Field: "env.GOPATH", Current: "set", Reference: ""},
+		{Field: "env.HTTP_PROXY", Current: "", Reference: "set"},
+		{Field: "image_digest", Current: "debian@sha256:abc", Reference: ""},
+		{Field: "tool.git", Current: "git version 2.30.2", Reference: "git version 2.20.1"},
+		{Field: "tool.make", Current: "", Reference: "GNU Make 4.2.1"},
+		{Field: "tool.npm", Current: "6.14.4", Reference: ""},
+		{Field: "worker_version", Current: "0.46.0", Reference: "0.45.0"},
+	}, f.Diff(ref))
+}
@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Types of the parameters of a workflow run form.
+const (
+	WorkflowRunFormParameterString  = "string"
+	WorkflowRunFormParameterNumber  = "number"
+	WorkflowRunFormParameterBoolean = "boolean"
+	WorkflowRunFormParameterChoice  = "choice"
+)
+
+// WorkflowRunForm describes the parameters that can be given in the payload of a manual run of a workflow.
+type WorkflowRunForm struct {
+	Parameters []WorkflowRunFormParameter `json:"parameters" yaml:"parameters"`
+}
+
+// WorkflowRunFormParameter is a typed parameter of a workflow run form, its name is the key of the value in the
+// payload of the run.
+type WorkflowRunFormParameter struct {
+	Name        string   `json:"name" yaml:"name"`
+	Type        string   `json:"type,omitempty" yaml:"type,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Default     string   `json:"default,omitempty" yaml:"default,omitempty"`
+	Required    bool     `json:"required,omitempty" yaml:"required,omitempty"`
+	Choices     []string `json:"choices,omitempty" yaml:"choices,omitempty"`
+}
+
+// IsValid returns an error if the form contains invalid or duplicated parameters.
+func (f WorkflowRunForm) IsValid() error {
+	names := make(map[string]struct{}, len(f.Parameters))
+	for _, p := range f.Parameters {
+		if !NamePatternRegex.MatchString(p.Name) {
+			return NewErrorFrom(ErrWrongRequest, "invalid run form parameter name %q, should match %s", p.Name, NamePattern)
+		}
+		if _, ok := names[p.Name]; ok {
+			return NewErrorFrom(ErrWrongRequest, "run form parameter %s is declared more than once", p.Name)
+		}
+		names[p.Name] = struct{}{}
+
+		switch p.Type {
+		case "", WorkflowRunFormParameterString, WorkflowRunFormParameterNumber, WorkflowRunFormParameterBoolean:
+		case WorkflowRunFormParameterChoice:
+			if len(p.Choices) == 0 {
+				return NewErrorFrom(ErrWrongRequest, "run form parameter %s of type %s should have choices", p.Name, p.Type)
+			}
+		default:
+			return NewErrorFrom(ErrWrongRequest, "invalid type %q for run form parameter %s, should be %s", p.Type, p.Name,
+				strings.Join([]string{WorkflowRunFormParameterString, WorkflowRunFormParameterNumber, WorkflowRunFormParameterBoolean, WorkflowRunFormParameterChoice}, ", "))
+		}
+
+		if p.Default != "" {
+			if err := p.check(p.Default); err != nil {
+				return NewErrorFrom(ErrWrongRequest, "invalid default value for run form parameter %s: %v", p.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Check checks the values of given manual run payload against the form, and sets the default value of missing
+// parameters. Values of parameters that are not in the form are kept as is.
+func (f WorkflowRunForm) Check(payload map[string]interface{}) error {
+	var errs []string
+	for _, p := range f.Parameters {
+		v, ok := payload[p.Name]
+		value := fmt.Sprintf("%v", v)
+		if !ok || v == nil || value == "" {
+			if p.Default != "" {
+				payload[p.Name] = p.Default
+				continue
+			}
+			if p.Required {
+				errs = append(errs, fmt.Sprintf("parameter %s is required", p.Name))
+			}
+			continue
+		}
+		if err := p.check(value); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid value for parameter %s: %v", p.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid run parameters: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func (p WorkflowRunFormParameter) check(value string) error {
+	switch p.Type {
+	case WorkflowRunFormParameterNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+	case WorkflowRunFormParameterBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+	case WorkflowRunFormParameterChoice:
+		for _, c := range p.Choices {
+			if c == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of %s", value, strings.Join(p.Choices, ", "))
+	}
+	return nil
+}
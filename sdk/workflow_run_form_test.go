@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRunFormIsValid(t *testing.T) {
+	assert.NoError(t, WorkflowRunForm{Parameters: []WorkflowRunFormParameter{
+		{Name: "version", Required: true},
+		{Name: "replicas", Type: WorkflowRunFormParameterNumber, Default: "2"},
+		{Name: "env", Type: WorkflowRunFormParameterChoice, Choices: []string{"dev", "prod"}, Default: "dev"},
+	}}.IsValid())
+
+	assert.Error(t, WorkflowRunForm{Parameters: []WorkflowRunFormParameter{{Name: "my version"}}}.IsValid())
+	assert.Error(t, WorkflowRunForm{Parameters: []WorkflowRunFormParameter{{Name: "env"}, {Name: "env"}}}.IsValid())
+	assert.Error(t, WorkflowRunForm{Parameters: []WorkflowRunFormParameter{{Name: "env", Type: "list"}}}.IsValid())
+	assert.Error(t, WorkflowRunForm{Parameters: []WorkflowRunFormParameter{{Name: "env", Type: WorkflowRunFormParameterChoice}}}.IsValid())
+	assert.Error(t, WorkflowRunForm{Parameters: []WorkflowRunFormParameter{{Name: "dry-run", Type: WorkflowRunFormParameterBoolean, Default: "maybe"}}}.IsValid())
+}
+
+func TestWorkflowRunFormCheck(t *testing.T) {
+	form := WorkflowRunForm{Parameters: []WorkflowRunFormParameter{
+		{Name: "version", Required: true},
+		{Name: "replicas", Type: WorkflowRunFormParameterNumber, Default: "2"},
+		{Name: "env", Type: WorkflowRunFormParameterChoice, Choices: []string{"dev", "prod"}},
+		{Name: "dry-run", Type: WorkflowRunFormParameterBoolean},
+	}}
+
+	payload := map[string]interface{}{"version": "1.0.0", "env": "prod", "dry-run": true, "git.branch": "master"}
+	require.NoError(t, form.Check(payload))
+	assert.Equal(t, map[string]interface{}{"version": "1.0.0", "replicas": "2", "env": "prod", "dry-run": true, "git.branch": "master"}, payload)
+
+	err := form.Check(map[string]interface{}{"replicas": "two", "env": "staging"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parameter version is required")
+	assert.Contains(t, err.Error(), "invalid value for parameter replicas")
+	assert.Contains(t, err.Error(), "invalid value for parameter env")
+}
@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"time"
+)
+
+// Options given to the release plugins of the targets of a release pipeline.
+const (
+	// ReleaseOptionResults is the JSON array of the results of the workflow run
+	ReleaseOptionResults = "cds.release.results"
+	// ReleaseOptionArtifacts is the JSON array of the artifacts of the workflow run
+	ReleaseOptionArtifacts = "cds.release.artifacts"
+)
+
+// WorkflowRunReleaseTarget is the publication of the results of a workflow run to a release integration by a release
+// pipeline. A target that was successfully published is skipped when the pipeline is run again in the same workflow
+// run, so only the failed targets are retried.
+type WorkflowRunReleaseTarget struct {
+	ID                     int64     `json:"id" db:"id" cli:"-"`
+	WorkflowRunID          int64     `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	WorkflowNodeID         int64     `json:"workflow_node_id" db:"workflow_node_id" cli:"-"`
+	WorkflowNodeRunID      int64     `json:"workflow_node_run_id" db:"workflow_node_run_id" cli:"-"`
+	WorkflowNodeName       string    `json:"workflow_node_name" db:"workflow_node_name" cli:"pipeline"`
+	ProjectIntegrationName string    `json:"project_integration_name" db:"project_integration_name" cli:"integration,key"`
+	Status                 string    `json:"status" db:"status" cli:"status"`
+	Details                string    `json:"details,omitempty" db:"details" cli:"details"`
+	Attempts               int64     `json:"attempts" db:"attempts" cli:"attempts"`
+	LastModified           time.Time `json:"last_modified" db:"last_modified" cli:"last_modified"`
+	// PluginName is the name of the release plugin of the integration, it is only given to the worker
+	PluginName string `json:"plugin_name,omitempty" db:"-" cli:"-"`
+}
+
+// WorkflowNodeJobRunRelease is given to the worker that runs a release pipeline with release targets: the targets
+// and what is published to them. Targets is empty if the pipeline has no release targets.
+type WorkflowNodeJobRunRelease struct {
+	Targets   []WorkflowRunReleaseTarget `json:"targets"`
+	Results   []WorkflowRunResult        `json:"results"`
+	Artifacts []WorkflowNodeRunArtifact  `json:"artifacts"`
+}
+
+// WorkflowRunReleaseTargetStatus is sent by the worker once a release target was published.
+type WorkflowRunReleaseTargetStatus struct {
+	Status  string `json:"status"`
+	Details string `json:"details,omitempty"`
+}
+
+// IsValid returns an error if the status is not a final status.
+func (s WorkflowRunReleaseTargetStatus) IsValid() error {
+	if s.Status != StatusSuccess && s.Status != StatusFail {
+		return NewErrorFrom(ErrWrongRequest, "invalid release target status %q, should be %s or %s", s.Status, StatusSuccess, StatusFail)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package sdk
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Types of workflow run results.
+const (
+	WorkflowRunResultTypeDockerImage = "docker-image"
+	WorkflowRunResultTypeChangelog   = "changelog"
+)
+
+// WorkflowRunResultChangelogMaxSize is the maximum size of the content of a changelog result.
+const WorkflowRunResultChangelogMaxSize = 64 * 1024
+
+var (
+	dockerImageRefPattern    = regexp.MustCompile(`^(?:[a-zA-Z0-9.-]+(?::[0-9]+)?/)?[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*(?::[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?(?:@sha256:[a-f0-9]{64})?$`)
+	dockerImageDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// WorkflowRunResult is something produced by a job of a workflow run, like a docker image. The data of a result
+// depends on its type.
+type WorkflowRunResult struct {
+	ID                int64           `json:"id" db:"id"`
+	WorkflowRunID     int64           `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowNodeRunID int64           `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	SubNumber         int64           `json:"sub_num" db:"sub_num"`
+	Type              string          `json:"type" db:"type"`
+	DataRaw           json.RawMessage `json:"data" db:"data"`
+	Created           time.Time       `json:"created" db:"created"`
+}
+
+// IsValid returns an error if the type of the result is unknown or if its data is not valid for its type.
+func (r *WorkflowRunResult) IsValid() error {
+	switch r.Type {
+	case WorkflowRunResultTypeDockerImage:
+		image, err := r.GetDockerImage()
+		if err != nil {
+			return err
+		}
+		if err := image.IsValid(); err != nil {
+			return err
+		}
+		// Store the digest found in the reference
+		return r.SetDockerImage(image)
+	case WorkflowRunResultTypeChangelog:
+		changelog, err := r.GetChangelog()
+		if err != nil {
+			return err
+		}
+		return changelog.IsValid()
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid run result type %q, should be %s or %s", r.Type, WorkflowRunResultTypeDockerImage, WorkflowRunResultTypeChangelog)
+	}
+}
+
+// GetDockerImage returns the data of a docker image result.
+func (r WorkflowRunResult) GetDockerImage() (WorkflowRunResultDockerImage, error) {
+	var image WorkflowRunResultDockerImage
+	if r.Type != WorkflowRunResultTypeDockerImage {
+		return image, NewErrorFrom(ErrWrongRequest, "run result %d is not a docker image", r.ID)
+	}
+	if err := json.Unmarshal(r.DataRaw, &image); err != nil {
+		return image, NewErrorFrom(ErrWrongRequest, "invalid docker image run result: %v", err)
+	}
+	return image, nil
+}
+
+// SetDockerImage sets the type and the data of a docker image result.
+func (r *WorkflowRunResult) SetDockerImage(image WorkflowRunResultDockerImage) error {
+	data, err := json.Marshal(image)
+	if err != nil {
+		return WrapError(err, "cannot marshal docker image")
+	}
+	r.Type = WorkflowRunResultTypeDockerImage
+	r.DataRaw = data
+	return nil
+}
+
+// WorkflowRunResultDockerImage is a docker image built or pushed by a job.
+type WorkflowRunResultDockerImage struct {
+	Ref    string            `json:"ref"`
+	Digest string            `json:"digest,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// IsValid returns an error if the reference or the digest of the image are not valid. If the reference contains a
+// digest, the digest of the image is set to it.
+func (i *WorkflowRunResultDockerImage) IsValid() error {
+	if i.Ref == "" || len(i.Ref) > 512 || !dockerImageRefPattern.MatchString(i.Ref) {
+		return NewErrorFrom(ErrWrongRequest, "invalid docker image reference %q", i.Ref)
+	}
+	if idx := strings.LastIndex(i.Ref, "@"); idx >= 0 {
+		refDigest := i.Ref[idx+1:]
+		if i.Digest == "" {
+			i.Digest = refDigest
+		} else if i.Digest != refDigest {
+			return NewErrorFrom(ErrWrongRequest, "digest %s doesn't match the digest of docker image reference %s", i.Digest, i.Ref)
+		}
+	}
+	if i.Digest != "" && !dockerImageDigestPattern.MatchString(i.Digest) {
+		return NewErrorFrom(ErrWrongRequest, "invalid docker image digest %q, it should be sha256:<64 hexadecimal characters>", i.Digest)
+	}
+	for k, v := range i.Labels {
+		if k == "" || len(k) > 256 || len(v) > 1024 {
+			return NewErrorFrom(ErrWrongRequest, "invalid label %q for docker image %s", k, i.Ref)
+		}
+	}
+	return nil
+}
+
+// GetChangelog returns the data of a changelog result.
+func (r WorkflowRunResult) GetChangelog() (WorkflowRunResultChangelog, error) {
+	var changelog WorkflowRunResultChangelog
+	if r.Type != WorkflowRunResultTypeChangelog {
+		return changelog, NewErrorFrom(ErrWrongRequest, "run result %d is not a changelog", r.ID)
+	}
+	if err := json.Unmarshal(r.DataRaw, &changelog); err != nil {
+		return changelog, NewErrorFrom(ErrWrongRequest, "invalid changelog run result: %v", err)
+	}
+	return changelog, nil
+}
+
+// SetChangelog sets the type and the data of a changelog result.
+func (r *WorkflowRunResult) SetChangelog(changelog WorkflowRunResultChangelog) error {
+	data, err := json.Marshal(changelog)
+	if err != nil {
+		return WrapError(err, "cannot marshal changelog")
+	}
+	r.Type = WorkflowRunResultTypeChangelog
+	r.DataRaw = data
+	return nil
+}
+
+// WorkflowRunResultChangelog is the changelog of the version built by a job, in markdown.
+type WorkflowRunResultChangelog struct {
+	Version string `json:"version,omitempty"`
+	Content string `json:"content"`
+}
+
+// IsValid returns an error if the changelog is empty or too big.
+func (c WorkflowRunResultChangelog) IsValid() error {
+	if strings.TrimSpace(c.Content) == "" {
+		return NewErrorFrom(ErrWrongRequest, "changelog is empty")
+	}
+	if len(c.Content) > WorkflowRunResultChangelogMaxSize {
+		return NewErrorFrom(ErrWrongRequest, "changelog is too big, its maximum size is %d bytes", WorkflowRunResultChangelogMaxSize)
+	}
+	if len(c.Version) > 256 {
+		return NewErrorFrom(ErrWrongRequest, "invalid changelog version %q", c.Version)
+	}
+	return nil
+}
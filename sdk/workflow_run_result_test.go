@@ -0,0 +1,77 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRunResultDockerImageIsValid(t *testing.T) {
+	digest := "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+	for _, ref := range []string{
+		"alpine",
+		"alpine:3.12",
+		"library/alpine:latest",
+		"registry.example.com:5000/team/my-app:v1.2.3",
+		"registry.example.com/team/my_app@" + digest,
+	} {
+		i := WorkflowRunResultDockerImage{Ref: ref}
+		assert.NoError(t, i.IsValid(), "reference %s should be valid", ref)
+	}
+
+	for _, ref := range []string{
+		"",
+		"Alpine",
+		"alpine:",
+		"my app:latest",
+		"alpine@sha256:1234",
+	} {
+		i := WorkflowRunResultDockerImage{Ref: ref}
+		assert.Error(t, i.IsValid(), "reference %s should not be valid", ref)
+	}
+
+	i := WorkflowRunResultDockerImage{Ref: "my-app@" + digest}
+	require.NoError(t, i.IsValid())
+	assert.Equal(t, digest, i.Digest)
+
+	assert.Error(t, (&WorkflowRunResultDockerImage{Ref: "my-app", Digest: "1234"}).IsValid())
+	assert.Error(t, (&WorkflowRunResultDockerImage{Ref: "my-app@" + digest, Digest: "sha256:" + digest[10:] + "ab"}).IsValid())
+	assert.Error(t, (&WorkflowRunResultDockerImage{Ref: "my-app", Labels: map[string]string{"": "value"}}).IsValid())
+}
+
+func TestWorkflowRunResultIsValid(t *testing.T) {
+	var r WorkflowRunResult
+	require.NoError(t, r.SetDockerImage(WorkflowRunResultDockerImage{
+		Ref:    "my-app:v1@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Labels: map[string]string{"git.hash": "abcdef"},
+	}))
+	require.NoError(t, r.IsValid())
+
+	image, err := r.GetDockerImage()
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", image.Digest)
+	assert.Equal(t, "abcdef", image.Labels["git.hash"])
+
+	assert.Error(t, (&WorkflowRunResult{Type: "artifact", DataRaw: []byte(`{}`)}).IsValid())
+	assert.Error(t, (&WorkflowRunResult{Type: WorkflowRunResultTypeDockerImage, DataRaw: []byte(`"my-app"`)}).IsValid())
+}
+
+func TestWorkflowRunResultChangelogIsValid(t *testing.T) {
+	var r WorkflowRunResult
+	require.NoError(t, r.SetChangelog(WorkflowRunResultChangelog{Version: "1.2.0", Content: "## Features\n* add release targets"}))
+	require.NoError(t, r.IsValid())
+	assert.Equal(t, WorkflowRunResultTypeChangelog, r.Type)
+
+	changelog, err := r.GetChangelog()
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", changelog.Version)
+
+	_, err = r.GetDockerImage()
+	assert.Error(t, err)
+
+	assert.Error(t, WorkflowRunResultChangelog{Content: " \n"}.IsValid())
+	assert.Error(t, WorkflowRunResultChangelog{Content: strings.Repeat("a", WorkflowRunResultChangelogMaxSize+1)}.IsValid())
+}
@@ -0,0 +1,197 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WorkflowRunSearchFilter contains the filters used to search the runs of the workflows of a project. All the
+// given filters should match, durations are given in seconds.
+type WorkflowRunSearchFilter struct {
+	Workflows   []string          `json:"workflows,omitempty"`
+	Status      []string          `json:"status,omitempty"`
+	Branch      string            `json:"branch,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	TriggeredBy string            `json:"triggered_by,omitempty"`
+	StartAfter  *time.Time        `json:"start_after,omitempty"`
+	StartBefore *time.Time        `json:"start_before,omitempty"`
+	MinDuration int64             `json:"min_duration,omitempty"`
+	MaxDuration int64             `json:"max_duration,omitempty"`
+}
+
+// IsValid returns an error if the filter is not valid.
+func (f WorkflowRunSearchFilter) IsValid() error {
+	for _, s := range f.Status {
+		if !StatusValidate(s) {
+			return NewErrorFrom(ErrWrongRequest, "invalid given status %s", s)
+		}
+	}
+	if f.StartAfter != nil && f.StartBefore != nil && f.StartBefore.Before(*f.StartAfter) {
+		return NewErrorFrom(ErrWrongRequest, "start before date should be after start after date")
+	}
+	if f.MinDuration < 0 || f.MaxDuration < 0 {
+		return NewErrorFrom(ErrWrongRequest, "durations should be positive")
+	}
+	if f.MaxDuration > 0 && f.MaxDuration < f.MinDuration {
+		return NewErrorFrom(ErrWrongRequest, "max duration should be greater than min duration")
+	}
+	return nil
+}
+
+// AllTags returns the given tags with the branch and triggered by filters, that are stored as run tags.
+func (f WorkflowRunSearchFilter) AllTags() map[string]string {
+	tags := make(map[string]string, len(f.Tags)+2)
+	for k, v := range f.Tags {
+		tags[k] = v
+	}
+	if f.Branch != "" {
+		tags["git.branch"] = f.Branch
+	}
+	if f.TriggeredBy != "" {
+		tags["triggered_by"] = f.TriggeredBy
+	}
+	return tags
+}
+
+// Values returns the filter as query string values, tags are given as key:value.
+func (f WorkflowRunSearchFilter) Values() url.Values {
+	v := url.Values{}
+	for _, w := range f.Workflows {
+		v.Add("workflow", w)
+	}
+	for _, s := range f.Status {
+		v.Add("status", s)
+	}
+	if f.Branch != "" {
+		v.Set("branch", f.Branch)
+	}
+	keys := make([]string, 0, len(f.Tags))
+	for k := range f.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v.Add("tag", k+":"+f.Tags[k])
+	}
+	keys = make([]string, 0, len(f.Annotations))
+	for k := range f.Annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v.Add("annotation", k+":"+f.Annotations[k])
+	}
+	if f.TriggeredBy != "" {
+		v.Set("triggered_by", f.TriggeredBy)
+	}
+	if f.StartAfter != nil {
+		v.Set("start_after", f.StartAfter.Format(time.RFC3339))
+	}
+	if f.StartBefore != nil {
+		v.Set("start_before", f.StartBefore.Format(time.RFC3339))
+	}
+	if f.MinDuration > 0 {
+		v.Set("min_duration", strconv.FormatInt(f.MinDuration, 10))
+	}
+	if f.MaxDuration > 0 {
+		v.Set("max_duration", strconv.FormatInt(f.MaxDuration, 10))
+	}
+	return v
+}
+
+// WorkflowRunSearchFilterFromValues parses a filter from query string values.
+func WorkflowRunSearchFilterFromValues(v url.Values) (WorkflowRunSearchFilter, error) {
+	f := WorkflowRunSearchFilter{
+		Workflows:   v["workflow"],
+		Status:      v["status"],
+		Branch:      v.Get("branch"),
+		TriggeredBy: v.Get("triggered_by"),
+	}
+	for _, t := range v["tag"] {
+		kv := strings.SplitN(t, ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return f, NewErrorFrom(ErrWrongRequest, "invalid given tag %s, it should be key:value", t)
+		}
+		if f.Tags == nil {
+			f.Tags = make(map[string]string)
+		}
+		f.Tags[kv[0]] = kv[1]
+	}
+	for _, a := range v["annotation"] {
+		kv := strings.SplitN(a, ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return f, NewErrorFrom(ErrWrongRequest, "invalid given annotation %s, it should be key:value", a)
+		}
+		if f.Annotations == nil {
+			f.Annotations = make(map[string]string)
+		}
+		f.Annotations[kv[0]] = kv[1]
+	}
+	for key, date := range map[string]**time.Time{"start_after": &f.StartAfter, "start_before": &f.StartBefore} {
+		if s := v.Get(key); s != "" {
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				return f, NewErrorFrom(ErrWrongRequest, "invalid given date for %s, it should be RFC3339", key)
+			}
+			*date = &t
+		}
+	}
+	for key, duration := range map[string]*int64{"min_duration": &f.MinDuration, "max_duration": &f.MaxDuration} {
+		if s := v.Get(key); s != "" {
+			d, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return f, NewErrorFrom(ErrWrongRequest, "invalid given duration for %s, it should be a number of seconds", key)
+			}
+			*duration = d
+		}
+	}
+	return f, f.IsValid()
+}
+
+// Value returns driver.Value from workflow run search filter.
+func (f WorkflowRunSearchFilter) Value() (driver.Value, error) {
+	j, err := json.Marshal(f)
+	return j, WrapError(err, "cannot marshal WorkflowRunSearchFilter")
+}
+
+// Scan workflow run search filter.
+func (f *WorkflowRunSearchFilter) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, f), "cannot unmarshal WorkflowRunSearchFilter")
+}
+
+// WorkflowRunSearch is a workflow run search saved by a user for a project.
+type WorkflowRunSearch struct {
+	ID                 int64                   `json:"id" db:"id"`
+	AuthentifiedUserID string                  `json:"-" db:"authentified_user_id"`
+	ProjectID          int64                   `json:"-" db:"project_id"`
+	ProjectKey         string                  `json:"project_key" db:"-"`
+	Name               string                  `json:"name" db:"name"`
+	Filter             WorkflowRunSearchFilter `json:"filter" db:"filter"`
+	Created            time.Time               `json:"created" db:"created"`
+}
+
+// IsValid returns an error if the saved search is not valid.
+func (s WorkflowRunSearch) IsValid() error {
+	if s.Name == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid given name for search")
+	}
+	if s.ProjectKey == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid given project key for search")
+	}
+	return s.Filter.IsValid()
+}
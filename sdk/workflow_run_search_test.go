@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRunSearchFilterValues(t *testing.T) {
+	after := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := after.Add(24 * time.Hour)
+	f := WorkflowRunSearchFilter{
+		Workflows:   []string{"wf1", "wf2"},
+		Status:      []string{StatusSuccess, StatusFail},
+		Branch:      "master",
+		Tags:        map[string]string{"env": "prod", "version": "1.0:beta"},
+		Annotations: map[string]string{"deployed version": "1.0.1"},
+		TriggeredBy: "john",
+		StartAfter:  &after,
+		StartBefore: &before,
+		MinDuration: 60,
+		MaxDuration: 3600,
+	}
+
+	res, err := WorkflowRunSearchFilterFromValues(f.Values())
+	require.NoError(t, err)
+	assert.Equal(t, f, res)
+
+	assert.Equal(t, map[string]string{"env": "prod", "version": "1.0:beta", "git.branch": "master", "triggered_by": "john"}, res.AllTags())
+}
+
+func TestWorkflowRunSearchFilterFromInvalidValues(t *testing.T) {
+	for _, v := range []url.Values{
+		{"status": {"Unknown"}},
+		{"tag": {"novalue"}},
+		{"annotation": {":novalue"}},
+		{"start_after": {"yesterday"}},
+		{"start_after": {"2020-01-02T00:00:00Z"}, "start_before": {"2020-01-01T00:00:00Z"}},
+		{"min_duration": {"ten"}},
+		{"min_duration": {"60"}, "max_duration": {"30"}},
+	} {
+		_, err := WorkflowRunSearchFilterFromValues(v)
+		assert.True(t, ErrorIs(err, ErrWrongRequest), "%v should be invalid", v)
+	}
+}
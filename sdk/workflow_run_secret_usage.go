@@ -0,0 +1,45 @@
+package sdk
+
+import (
+	"time"
+)
+
+// Sources of a secret usage, the way a job read a secret.
+const (
+	SecretUsageSourceInterpolation = "interpolation"
+	SecretUsageSourceKeyInstall    = "key-install"
+)
+
+// WorkflowRunSecretUsage is a secret or a key read by a job of a workflow run. The step order is -1 if the secret was
+// read by the parameters of the job.
+type WorkflowRunSecretUsage struct {
+	ID                   int64     `json:"id" db:"id"`
+	WorkflowRunID        int64     `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowNodeRunID    int64     `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	WorkflowNodeJobRunID int64     `json:"workflow_node_job_run_id" db:"workflow_node_job_run_id"`
+	SubNumber            int64     `json:"sub_num" db:"sub_num"`
+	NodeName             string    `json:"node_name" db:"node_name"`
+	JobName              string    `json:"job_name" db:"job_name"`
+	StepOrder            int       `json:"step_order" db:"step_order"`
+	Name                 string    `json:"name" db:"name"`
+	Type                 string    `json:"type" db:"type"`
+	Source               string    `json:"source" db:"source"`
+	Created              time.Time `json:"created" db:"created"`
+}
+
+// IsValid returns an error if the secret usage sent by a worker is not valid.
+func (u WorkflowRunSecretUsage) IsValid() error {
+	if u.Name == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid secret usage: missing secret name")
+	}
+	switch u.Source {
+	case SecretUsageSourceInterpolation, SecretUsageSourceKeyInstall:
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid secret usage source %q, should be %s or %s", u.Source,
+			SecretUsageSourceInterpolation, SecretUsageSourceKeyInstall)
+	}
+	if u.StepOrder < -1 {
+		return NewErrorFrom(ErrWrongRequest, "invalid secret usage step order %d", u.StepOrder)
+	}
+	return nil
+}
@@ -238,3 +238,37 @@ func TestWorkflowQueue_Sort(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkflowQueue_SortPriority(t *testing.T) {
+	q := WorkflowQueue{
+		{ProjectID: 1, ID: 1, Priority: WorkflowPriorityBatch},
+		{ProjectID: 2, ID: 2},
+		{ProjectID: 2, ID: 3},
+		{ProjectID: 2, ID: 4, Priority: WorkflowPriorityCritical},
+	}
+	q.Sort()
+
+	ids := make([]int64, len(q))
+	for i := range q {
+		ids[i] = q[i].ID
+	}
+	assert.Equal(t, int64(4), ids[0])
+	assert.ElementsMatch(t, []int64{2, 3}, ids[1:3])
+	assert.Equal(t, int64(1), ids[3])
+}
+
+func TestNewWorkflowRunBadge(t *testing.T) {
+	b := NewWorkflowRunBadge("build", StatusSuccess)
+	assert.Equal(t, WorkflowRunBadge{SchemaVersion: 1, Label: "build", Message: "success", Color: "brightgreen"}, b)
+
+	b = NewWorkflowRunBadge("build", StatusFail)
+	assert.Equal(t, "fail", b.Message)
+	assert.Equal(t, "red", b.Color)
+
+	b = NewWorkflowRunBadge("build", StatusBuilding)
+	assert.Equal(t, "blue", b.Color)
+
+	b = NewWorkflowRunBadge("build", "")
+	assert.Equal(t, "no run", b.Message)
+	assert.Equal(t, "lightgrey", b.Color)
+}
@@ -389,6 +389,7 @@ type WorkflowTemplateInstance struct {
 	WorkflowTemplateVersion int64                   `json:"workflow_template_version" db:"workflow_template_version"`
 	Request                 WorkflowTemplateRequest `json:"request" db:"request"`
 	WorkflowName            string                  `json:"workflow_name" db:"workflow_name"`
+	Drift                   *WorkflowTemplateDrift  `json:"drift,omitempty" db:"drift"`
 	// aggregates
 	FirstAudit *AuditWorkflowTemplateInstance `json:"first_audit,omitempty" db:"-"`
 	LastAudit  *AuditWorkflowTemplateInstance `json:"last_audit,omitempty" db:"-"`
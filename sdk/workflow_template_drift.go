@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// WorkflowTemplateDrift is the difference between the workflow generated by a template instance and the stored
+// workflow, due to the changes made on the workflow or its pipelines after the template was applied.
+type WorkflowTemplateDrift struct {
+	Checked time.Time `json:"checked"`
+	// Workflow gives the changes to apply on the generated workflow to get the stored one
+	Workflow WorkflowDiff `json:"workflow"`
+	// Pipelines gives the names of the generated pipelines that were changed or removed
+	Pipelines []string `json:"pipelines"`
+}
+
+// IsDrifted returns true if the workflow or one of its pipelines was changed since the template was applied.
+func (d WorkflowTemplateDrift) IsDrifted() bool {
+	return len(d.Workflow.Patch) > 0 || len(d.Pipelines) > 0
+}
+
+// Value returns driver.Value from workflow template drift.
+func (d WorkflowTemplateDrift) Value() (driver.Value, error) {
+	j, err := json.Marshal(d)
+	return j, WrapError(err, "cannot marshal WorkflowTemplateDrift")
+}
+
+// Scan workflow template drift.
+func (d *WorkflowTemplateDrift) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(json.Unmarshal(source, d), "cannot unmarshal WorkflowTemplateDrift")
+}
@@ -0,0 +1,17 @@
+package sdk
+
+import (
+	"time"
+)
+
+// WorkflowVersion is an immutable version of the definition of a workflow, saved each time the definition changes.
+// Data contains the workflow exported in yaml.
+type WorkflowVersion struct {
+	ID         int64     `json:"id" db:"id" cli:"-"`
+	WorkflowID int64     `json:"workflow_id" db:"workflow_id" cli:"-"`
+	Version    int64     `json:"version" db:"version" cli:"version,key"`
+	Author     string    `json:"author" db:"author" cli:"author"`
+	Message    string    `json:"message" db:"message" cli:"message"`
+	Data       string    `json:"data,omitempty" db:"data" cli:"-"`
+	Created    time.Time `json:"created" db:"created" cli:"created"`
+}
@@ -0,0 +1,143 @@
+package sdk
+
+import (
+	"net/url"
+	"time"
+)
+
+// Events that can be sent by a workflow webhook.
+const (
+	WorkflowWebhookEventRun  = "run"
+	WorkflowWebhookEventNode = "node"
+)
+
+// Delivery statuses of a workflow webhook event, a delivery in error is retried until it is dead.
+const (
+	WorkflowWebhookDeliveryStatusPending = "Pending"
+	WorkflowWebhookDeliveryStatusSuccess = "Success"
+	WorkflowWebhookDeliveryStatusError   = "Error"
+	WorkflowWebhookDeliveryStatusDead    = "Dead"
+)
+
+// Default settings of a workflow webhook.
+const (
+	WorkflowWebhookDefaultMaxAttempts = 8
+	WorkflowWebhookDefaultContentType = "application/json"
+	WorkflowWebhookDefaultPayload     = `{"event": "{{.cds.event}}", "project": "{{.cds.project}}", "workflow": "{{.cds.workflow}}", "number": {{.cds.run.number}}, "node": "{{.cds.node}}", "status": "{{.cds.status}}", "branch": "{{.cds.branch}}", "url": "{{.cds.buildURL}}"}`
+
+	workflowWebhookRetryBaseDelay = 30 * time.Second
+	workflowWebhookRetryMaxDelay  = time.Hour
+)
+
+var workflowWebhookStatuses = StringSlice{StatusWaiting, StatusBuilding, StatusSuccess, StatusFail, StatusStopped, StatusSkipped, StatusDisabled}
+
+// WorkflowWebhook sends the status changes of the runs of a workflow, and of their nodes, to an url.
+type WorkflowWebhook struct {
+	ID              int64       `json:"id" db:"id" cli:"id,key"`
+	WorkflowID      int64       `json:"workflow_id" db:"workflow_id" cli:"-"`
+	URL             string      `json:"url" db:"url" cli:"url"`
+	Events          StringSlice `json:"events,omitempty" db:"events" cli:"events"`
+	Statuses        StringSlice `json:"statuses,omitempty" db:"statuses" cli:"statuses"`
+	PayloadTemplate string      `json:"payload_template,omitempty" db:"payload_template" cli:"-"`
+	ContentType     string      `json:"content_type,omitempty" db:"content_type" cli:"-"`
+	SigningSecret   string      `json:"signing_secret,omitempty" db:"cipher_signing_secret" cli:"-" gorpmapping:"encrypted,ID,WorkflowID"`
+	MaxAttempts     int         `json:"max_attempts,omitempty" db:"max_attempts" cli:"max_attempts"`
+	Disabled        bool        `json:"disabled" db:"disabled" cli:"disabled"`
+	Created         time.Time   `json:"created" db:"created" cli:"-"`
+}
+
+// IsValid returns an error if the webhook is invalid.
+func (w WorkflowWebhook) IsValid() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid webhook url %q", w.URL)
+	}
+	for _, e := range w.Events {
+		if e != WorkflowWebhookEventRun && e != WorkflowWebhookEventNode {
+			return NewErrorFrom(ErrWrongRequest, "invalid webhook event %q", e)
+		}
+	}
+	for _, s := range w.Statuses {
+		if !workflowWebhookStatuses.Contains(s) {
+			return NewErrorFrom(ErrWrongRequest, "invalid webhook status %q", s)
+		}
+	}
+	if w.MaxAttempts < 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid webhook max attempts %d", w.MaxAttempts)
+	}
+	return nil
+}
+
+// Match returns true if the webhook should be sent for given event and status, all events and statuses are sent by
+// default.
+func (w WorkflowWebhook) Match(event, status string) bool {
+	if w.Disabled {
+		return false
+	}
+	if len(w.Events) > 0 && !w.Events.Contains(event) {
+		return false
+	}
+	return len(w.Statuses) == 0 || w.Statuses.Contains(status)
+}
+
+// Payload returns the payload template of the webhook, or the default one.
+func (w WorkflowWebhook) Payload() string {
+	if w.PayloadTemplate == "" {
+		return WorkflowWebhookDefaultPayload
+	}
+	return w.PayloadTemplate
+}
+
+// GetContentType returns the content type of the webhook, or the default one.
+func (w WorkflowWebhook) GetContentType() string {
+	if w.ContentType == "" {
+		return WorkflowWebhookDefaultContentType
+	}
+	return w.ContentType
+}
+
+// GetMaxAttempts returns the number of attempts after which an event is dead, or the default one.
+func (w WorkflowWebhook) GetMaxAttempts() int {
+	if w.MaxAttempts == 0 {
+		return WorkflowWebhookDefaultMaxAttempts
+	}
+	return w.MaxAttempts
+}
+
+// WorkflowWebhookDelivery is an event of a workflow webhook and the state of its delivery.
+type WorkflowWebhookDelivery struct {
+	ID                int64      `json:"id" db:"id" cli:"id,key"`
+	WebhookID         int64      `json:"webhook_id" db:"workflow_webhook_id" cli:"webhook_id"`
+	WorkflowID        int64      `json:"workflow_id" db:"workflow_id" cli:"-"`
+	WorkflowRunID     int64      `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	WorkflowNodeRunID int64      `json:"workflow_node_run_id,omitempty" db:"workflow_node_run_id" cli:"-"`
+	RunNumber         int64      `json:"run_number" db:"run_number" cli:"run_number"`
+	NodeName          string     `json:"node_name,omitempty" db:"node_name" cli:"node_name"`
+	Event             string     `json:"event" db:"event" cli:"event"`
+	RunStatus         string     `json:"run_status" db:"run_status" cli:"run_status"`
+	URL               string     `json:"url" db:"url" cli:"url"`
+	Payload           string     `json:"payload" db:"payload" cli:"-"`
+	Status            string     `json:"status" db:"status" cli:"status"`
+	Attempts          int        `json:"attempts" db:"attempts" cli:"attempts"`
+	HTTPStatus        int        `json:"http_status,omitempty" db:"http_status" cli:"http_status"`
+	Error             string     `json:"error,omitempty" db:"error" cli:"error"`
+	Created           time.Time  `json:"created" db:"created" cli:"created"`
+	LastAttempt       *time.Time `json:"last_attempt,omitempty" db:"last_attempt" cli:"-"`
+	NextAttempt       *time.Time `json:"next_attempt,omitempty" db:"next_attempt" cli:"-"`
+}
+
+// WorkflowWebhookRetryDelay returns the delay before the next attempt of a delivery that failed given number of
+// times, the delay is doubled after each attempt.
+func WorkflowWebhookRetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	d := workflowWebhookRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= workflowWebhookRetryMaxDelay {
+			return workflowWebhookRetryMaxDelay
+		}
+	}
+	return d
+}
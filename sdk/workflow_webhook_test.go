@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowWebhookIsValid(t *testing.T) {
+	assert.NoError(t, WorkflowWebhook{URL: "https://example.com/hook"}.IsValid())
+	assert.NoError(t, WorkflowWebhook{URL: "http://example.com", Events: StringSlice{WorkflowWebhookEventRun}, Statuses: StringSlice{StatusFail}}.IsValid())
+	assert.Error(t, WorkflowWebhook{URL: "ftp://example.com"}.IsValid())
+	assert.Error(t, WorkflowWebhook{URL: "example.com/hook"}.IsValid())
+	assert.Error(t, WorkflowWebhook{URL: "https://example.com", Events: StringSlice{"job"}}.IsValid())
+	assert.Error(t, WorkflowWebhook{URL: "https://example.com", Statuses: StringSlice{"Unknown"}}.IsValid())
+	assert.Error(t, WorkflowWebhook{URL: "https://example.com", MaxAttempts: -1}.IsValid())
+}
+
+func TestWorkflowWebhookMatch(t *testing.T) {
+	w := WorkflowWebhook{}
+	assert.True(t, w.Match(WorkflowWebhookEventRun, StatusBuilding))
+	assert.True(t, w.Match(WorkflowWebhookEventNode, StatusSuccess))
+
+	w = WorkflowWebhook{Events: StringSlice{WorkflowWebhookEventRun}, Statuses: StringSlice{StatusSuccess, StatusFail}}
+	assert.True(t, w.Match(WorkflowWebhookEventRun, StatusFail))
+	assert.False(t, w.Match(WorkflowWebhookEventRun, StatusBuilding))
+	assert.False(t, w.Match(WorkflowWebhookEventNode, StatusFail))
+
+	w.Disabled = true
+	assert.False(t, w.Match(WorkflowWebhookEventRun, StatusFail))
+}
+
+func TestWorkflowWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Duration(0), WorkflowWebhookRetryDelay(0))
+	assert.Equal(t, 30*time.Second, WorkflowWebhookRetryDelay(1))
+	assert.Equal(t, time.Minute, WorkflowWebhookRetryDelay(2))
+	assert.Equal(t, 4*time.Minute, WorkflowWebhookRetryDelay(4))
+	assert.Equal(t, time.Hour, WorkflowWebhookRetryDelay(8))
+	assert.Equal(t, time.Hour, WorkflowWebhookRetryDelay(100))
+}
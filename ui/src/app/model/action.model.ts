@@ -14,6 +14,8 @@ export class Action {
     parameters: Array<Parameter>;
     actions: Array<Action>;
     optional: boolean;
+    condition: string;
+    version: string;
     always_executed: boolean;
     enabled: boolean;
     deprecated: boolean;
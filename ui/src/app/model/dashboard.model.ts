@@ -0,0 +1,53 @@
+export class DashboardWorkflow {
+    project_key: string;
+    workflow_name: string;
+}
+
+export class DashboardWidget {
+    static LatestRuns = 'latest_runs';
+    static QueueDepth = 'queue_depth';
+    static FailingWorkflows = 'failing_workflows';
+
+    type: string;
+    title: string;
+    workflows: Array<DashboardWorkflow>;
+    projects: Array<string>;
+    limit: number;
+}
+
+export class Dashboard {
+    id: number;
+    name: string;
+    widgets: Array<DashboardWidget>;
+    created: string;
+    last_modified: string;
+}
+
+export class DashboardRun {
+    project_key: string;
+    workflow_name: string;
+    num: number;
+    status: string;
+    branch: string;
+    start: string;
+    last_modified: string;
+}
+
+export class DashboardQueueDepth {
+    project_key: string;
+    waiting: number;
+    building: number;
+}
+
+export class DashboardWidgetData {
+    widget: DashboardWidget;
+    error: string;
+    runs: Array<DashboardRun>;
+    queue_depth: Array<DashboardQueueDepth>;
+    failing_workflows: Array<DashboardRun>;
+}
+
+export class DashboardData {
+    dashboard: Dashboard;
+    widgets: Array<DashboardWidgetData>;
+}
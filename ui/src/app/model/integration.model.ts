@@ -12,9 +12,38 @@ export class IntegrationModel {
     deployment: boolean;
     compute: boolean;
     event: boolean;
+    release: boolean;
     public: boolean;
 }
 
+export class IntegrationModelCatalogEntry {
+    name: string;
+    author: string;
+    identifier: string;
+    icon: string;
+    builtin: boolean;
+    public: boolean;
+    capabilities: Array<string>;
+    config: Array<IntegrationConfigSchema>;
+    deployment_config: Array<IntegrationConfigSchema>;
+    permissions: IntegrationModelPermissions;
+    example: string;
+}
+
+export class IntegrationConfigSchema {
+    key: string;
+    type: string;
+    default: string;
+    description: string;
+    secret: boolean;
+}
+
+export class IntegrationModelPermissions {
+    project_verb: string;
+    group_admin: boolean;
+    admin: boolean;
+}
+
 export class ProjectIntegration {
     id: number;
     name: string;
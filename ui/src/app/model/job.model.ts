@@ -5,6 +5,7 @@ export class Job {
     pipeline_action_id: number;
     action: Action;
     enabled: boolean;
+    idempotent: boolean;
     last_modified: string;
     step_status: Array<StepStatus>;
     warnings: Array<ActionWarning>;
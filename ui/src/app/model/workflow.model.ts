@@ -45,11 +45,14 @@ export class Workflow {
     environments: { [key: number]: Environment; };
     project_integrations: { [key: number]: ProjectIntegration; };
     event_integrations: ProjectIntegration[];
+    artifact_storages: Array<WorkflowArtifactStorage>;
     hook_models: { [key: number]: WorkflowHookModel; };
     outgoing_hook_models: { [key: number]: WorkflowHookModel; };
     labels: Label[];
     workflow_data: WorkflowData;
     as_code_events: Array<AsCodeEvents>;
+    run_form: WorkflowRunForm;
+    priority: string;
 
     preview: Workflow;
     asCode: string;
@@ -391,6 +394,20 @@ export class WorkflowData {
     joins: Array<WNode>;
 }
 
+// WorkflowRunForm describes the typed parameters of the payload of a manual run
+export class WorkflowRunForm {
+    parameters: Array<WorkflowRunFormParameter>;
+}
+
+export class WorkflowRunFormParameter {
+    name: string;
+    type: string;
+    description: string;
+    default: string;
+    required: boolean;
+    choices: Array<string>;
+}
+
 export class WNode {
     id: number;
     workflow_id: number;
@@ -403,6 +420,7 @@ export class WNode {
     parents: Array<WNodeJoin>;
     hooks: Array<WNodeHook>;
     groups: Array<GroupPermission>;
+    display: WNodeDisplay;
 
     constructor() {
         this.context = new WNodeContext();
@@ -634,6 +652,20 @@ export class WNodeContext {
     default_pipeline_parameters: Array<Parameter>;
     conditions: WorkflowNodeConditions;
     mutex: boolean;
+    deployment_plan: boolean;
+    release_targets: Array<string>;
+}
+
+export class WorkflowArtifactStorage {
+    project_integration_id: number;
+    project_integration_name: string;
+    nodes: Array<string>;
+}
+
+export class WNodeDisplay {
+    label: string;
+    color: string;
+    group: string;
 }
 
 export class WNodeOutgoingHook {
@@ -21,6 +21,7 @@ export class WorkflowRunRequest {
     manual: WorkflowNodeRunManual;
     number: number;
     from_nodes: Array<number>;
+    priority: string;
 }
 
 export class WorkflowRun {
@@ -38,8 +39,12 @@ export class WorkflowRun {
     last_execution: string;
     nodes: { [key: string]: Array<WorkflowNodeRun>; };
     tags: Array<WorkflowRunTags>;
+    annotations: Array<WorkflowRunAnnotation>;
+    results: Array<WorkflowRunResult>;
+    maintenance: MaintenanceWindow;
     commits: Array<Commit>;
     infos: Array<SpawnInfo>;
+    priority: string;
 
     // Useful for UI
     duration: string;
@@ -66,6 +71,46 @@ export class WorkflowRunTags {
     value: string;
 }
 
+export class MaintenanceWindow {
+    id: number;
+    project_key: string;
+    workflow_name: string;
+    reason: string;
+    start: string;
+    end: string;
+    author: string;
+    created: string;
+}
+
+export class WorkflowRunResult {
+    static readonly TypeDockerImage = 'docker-image';
+
+    id: number;
+    workflow_run_id: number;
+    workflow_node_run_id: number;
+    sub_num: number;
+    type: string;
+    data: WorkflowRunResultDockerImage;
+    created: string;
+}
+
+export class WorkflowRunResultDockerImage {
+    ref: string;
+    digest: string;
+    labels: { [key: string]: string; };
+}
+
+export class WorkflowRunAnnotation {
+    id: number;
+    workflow_run_id: number;
+    workflow_node_run_id: number;
+    type: string;
+    key: string;
+    value: string;
+    author: string;
+    created: string;
+}
+
 // WorkflowNodeRun is as execution instance of a node
 export class WorkflowNodeRun implements WithKey {
     workflow_run_id: number;
@@ -125,6 +170,9 @@ export class WorkflowNodeRunArtifact {
     sha512sum: string;
     object_path: string;
     created: string;
+    pinned: boolean;
+    scan_status: string;
+    scan_report: string;
 }
 
 // WorkflowNodeRunStaticFiles represent static files
@@ -151,6 +199,11 @@ export class WorkflowNodeJobRun {
     model: string;
     bookedby: Hatchery;
     spawninfos: Array<SpawnInfo>;
+    queue_position: number;
+    estimated_duration: number;
+    estimated_start: string;
+    estimated_done: string;
+    priority: string;
 
     // UI infos for queue
     duration: string;
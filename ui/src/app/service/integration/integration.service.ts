@@ -1,6 +1,6 @@
 import {HttpClient} from '@angular/common/http';
 import { Injectable } from '@angular/core';
-import {IntegrationModel} from 'app/model/integration.model';
+import {IntegrationModel, IntegrationModelCatalogEntry} from 'app/model/integration.model';
 import {Observable} from 'rxjs';
 
 @Injectable()
@@ -12,4 +12,8 @@ export class IntegrationService {
         return this._http.get<Array<IntegrationModel>>('/integration/models');
     }
 
+    getIntegrationModelsCatalog(): Observable<Array<IntegrationModelCatalogEntry>> {
+        return this._http.get<Array<IntegrationModelCatalogEntry>>('/integration/models/catalog');
+    }
+
 }
@@ -4,11 +4,13 @@ import {Injectable} from '@angular/core';
 export class PermissionService {
     private r = 4;
     private rx = 5;
+    private rxd = 6;
     private rwx = 7;
 
     private permissions = [
         { 'name': 'permission_read', 'value': this.r },
         { 'name': 'permission_read_execute', 'value': this.rx },
+        { 'name': 'permission_read_execute_deploy', 'value': this.rxd },
         { 'name': 'permission_read_write_execute', 'value': this.rwx }
     ];
 
@@ -112,6 +112,10 @@ export class RequirementsFormComponent implements OnInit {
                     case 'model':
                         helpMsg = this._translate.instant('requirement_help_model');
                         break;
+                    case 'isolation':
+                        placeHolderValue = '/usr/local/go,/opt/node';
+                        helpMsg = this._translate.instant('requirement_help_isolation');
+                        break;
                 }
                 this.placeholderTypeName[a] = placeHolderName;
                 this.placeholderTypeValue[a] = placeHolderValue;
@@ -139,7 +143,9 @@ export class RequirementsFormComponent implements OnInit {
         this.popupText = '';
         let goodModel = this.newRequirement.type !== 'model' || !this.config.disableModel;
         let goodHostname = this.newRequirement.type !== 'hostname' || !this.config.disableHostname;
-        this.isFormValid = (form.valid === true && this.newRequirement.name !== '' && this.newRequirement.value !== '')
+        // the value of an isolation requirement is an optional list of tool paths
+        let goodValue = this.newRequirement.value !== '' || this.newRequirement.type === 'isolation';
+        this.isFormValid = (form.valid === true && this.newRequirement.name !== '' && goodValue)
             && goodModel && goodHostname;
         if (!goodModel) {
             this.popupText = this._translate.instant('requirement_error_model');
@@ -152,7 +158,7 @@ export class RequirementsFormComponent implements OnInit {
     selectType(): void {
         this.newRequirement.value = '';
         this.newRequirement.opts = '';
-        this.newRequirement.name = '';
+        this.newRequirement.name = this.newRequirement.type === 'isolation' ? 'isolation' : '';
     }
 
     setName(form): void {
@@ -174,6 +180,9 @@ export class RequirementsFormComponent implements OnInit {
             case OSArchitecture:
                 this.newRequirement.name = OSArchitecture;
                 break;
+            case 'isolation':
+                this.newRequirement.name = 'isolation';
+                break;
             default:
                 // else, name is the value of the requirement
                 this.newRequirement.name = this.newRequirement.value;
@@ -24,7 +24,7 @@ export class ApplicationDeploymentComponent {
     set project(project: Project) {
         this._project = project;
         if (project.integrations) {
-            this.filteredIntegrations = project.integrations.filter(p => p.model.deployment);
+            this.filteredIntegrations = project.integrations.filter(p => p.model.deployment || p.model.release);
         }
     }
     get project(): Project {
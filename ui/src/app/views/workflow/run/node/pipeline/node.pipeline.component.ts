@@ -36,8 +36,8 @@ export class WorkflowRunNodePipelineComponent implements OnInit, OnDestroy {
     // Pipeline data
     stages: Array<Stage>;
     jobTime: Map<number, string>;
-    mapJobStatus: Map<number, { status: string, warnings: number, start: string, done: string }>
-        = new Map<number, { status: string, warnings: number, start: string, done: string }>();
+    mapJobStatus: Map<number, { status: string, warnings: number, start: string, done: string, queue_position: number, estimated_start: string }>
+        = new Map<number, { status: string, warnings: number, start: string, done: string, queue_position: number, estimated_start: string }>();
 
     queryParamsSub: Subscription;
     pipelineStatusEnum = PipelineStatus;
@@ -151,10 +151,13 @@ export class WorkflowRunNodePipelineComponent implements OnInit, OnDestroy {
 
                         // Update job status
                         let jobStatusItem = this.mapJobStatus.get(rj.job.pipeline_action_id);
-                        if (!jobStatusItem || jobStatusItem.status !== rj.status) {
+                        if (!jobStatusItem || jobStatusItem.status !== rj.status ||
+                            jobStatusItem.queue_position !== rj.queue_position) {
                             refresh = true;
-                            this.mapJobStatus.set(rj.job.pipeline_action_id,
-                                { status: rj.status, warnings, start: rj.start, done: rj.done });
+                            this.mapJobStatus.set(rj.job.pipeline_action_id, {
+                                status: rj.status, warnings, start: rj.start, done: rj.done,
+                                queue_position: rj.queue_position, estimated_start: rj.estimated_start
+                            });
                         }
 
                         if (!currentNodeJobRun && sIndex === 0 && rjIndex === 0) {
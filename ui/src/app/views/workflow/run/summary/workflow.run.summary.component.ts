@@ -94,6 +94,12 @@ export class WorkflowRunSummaryComponent implements OnInit {
                 if (this.workflowRun.infos && wr.infos && this.workflowRun.infos.length !== wr.infos.length) {
                     refreshView = true;
                 }
+                if ((this.workflowRun.annotations || []).length !== (wr.annotations || []).length) {
+                    refreshView = true;
+                }
+                if ((this.workflowRun.results || []).length !== (wr.results || []).length) {
+                    refreshView = true;
+                }
                 if (!refreshView) {
                     return;
                 }